@@ -178,6 +178,24 @@ func Run(opts RunOptions) {
 		logger.Fatal("error creating the kv client", zap.Error(err))
 	}
 
+	if cfg.Statsd != nil {
+		// Create the StatsD server options, which match the rules of the
+		// metrics using the kv client.
+		statsdInstrumentOpts := instrumentOpts.
+			SetMetricsScope(scope.
+				SubScope("statsd-server").
+				Tagged(map[string]string{"server": "statsd"}))
+		statsdServerOpts, err := cfg.Statsd.NewServerOptions(client,
+			clock.NewOptions(), statsdInstrumentOpts)
+		if err != nil {
+			logger.Fatal("could not create statsd server options", zap.Error(err))
+		}
+
+		serverOptions = serverOptions.
+			SetStatsdAddr(cfg.Statsd.ListenAddress).
+			SetStatsdServerOpts(statsdServerOpts)
+	}
+
 	// Create the runtime options manager.
 	runtimeCfg := cfg.RuntimeOptionsOrDefault()
 	runtimeOptsManager := runtimeCfg.NewRuntimeOptionsManager()
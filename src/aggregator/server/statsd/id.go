@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package statsd
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	coordmodel "github.com/m3db/m3/src/cmd/services/m3coordinator/model"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/serialize"
+)
+
+var (
+	rollupTagName  = []byte(coordmodel.RollupTagName)
+	rollupTagValue = []byte(coordmodel.RollupTagValue)
+
+	errEncodedTagsUnavailable = errors.New("unable to access encoded tags")
+)
+
+// NB: the IDs of the metrics are their serialized tags, with the name of the
+// metrics stored in the name tag, just like the IDs of the metrics written
+// to the aggregator by the coordinator so that both are matched by the same
+// rules and ingested by the coordinator the same way.
+
+// encodeID encodes the tag pairs as the serialized tags of an ID, sorting
+// them by name and keeping the first tag of the pairs with the same name.
+func encodeID(pool serialize.TagEncoderPool, pairs []id.TagPair) ([]byte, error) {
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return bytes.Compare(pairs[i].Name, pairs[j].Name) < 0
+	})
+
+	tags := make([]ident.Tag, 0, len(pairs))
+	for i, pair := range pairs {
+		if i > 0 && bytes.Equal(pair.Name, pairs[i-1].Name) {
+			continue
+		}
+		tags = append(tags, ident.Tag{
+			Name:  ident.BytesID(pair.Name),
+			Value: ident.BytesID(pair.Value),
+		})
+	}
+
+	encoder := pool.Get()
+	defer encoder.Finalize()
+
+	if err := encoder.Encode(ident.NewTagsIterator(ident.NewTags(tags...))); err != nil {
+		return nil, err
+	}
+	data, ok := encoder.Data()
+	if !ok {
+		return nil, errEncodedTagsUnavailable
+	}
+	return append([]byte(nil), data.Bytes()...), nil
+}
+
+// NewRollupIDFn returns a function creating the IDs of the rollup metrics of
+// rollup rules, in the format of the IDs of the metrics of the server.
+func NewRollupIDFn(opts Options) id.NewIDFn {
+	var (
+		nameTag = opts.NameTag()
+		pool    = opts.TagEncoderPool()
+	)
+	return func(newName []byte, tagPairs []id.TagPair) []byte {
+		pairs := make([]id.TagPair, 0, len(tagPairs)+2)
+		pairs = append(pairs,
+			id.TagPair{Name: nameTag, Value: newName},
+			id.TagPair{Name: rollupTagName, Value: rollupTagValue})
+		pairs = append(pairs, tagPairs...)
+		encoded, err := encodeID(pool, pairs)
+		if err != nil {
+			panic(err) // Encoding should never fail
+		}
+		return encoded
+	}
+}
+
+// IsRollupID returns whether the tags of an ID, as returned by the name and
+// tags function of the server, are the ones of a rollup metric.
+func IsRollupID(name []byte, tags []byte) bool {
+	value, ok, err := serialize.TagValueFromEncodedTagsFast(tags, rollupTagName)
+	return err == nil && ok && bytes.Equal(value, rollupTagValue)
+}
+
+func newNameAndTagsFn(nameTag []byte) id.NameAndTagsFn {
+	return func(id []byte) ([]byte, []byte, error) {
+		name, _, err := serialize.TagValueFromEncodedTagsFast(id, nameTag)
+		if err != nil {
+			return nil, nil, err
+		}
+		// The ID is always the encoded tags.
+		return name, id, nil
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package statsd
+
+import (
+	"errors"
+	"time"
+
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/pool"
+	"github.com/m3db/m3/src/x/serialize"
+	xserver "github.com/m3db/m3/src/x/server"
+)
+
+const (
+	// A default limit value of 0 means error log rate limiting is disabled.
+	defaultErrorLogLimitPerSecond = 0
+
+	// The default interval the unique values of sets are counted over.
+	defaultSetsFlushInterval = 10 * time.Second
+)
+
+var (
+	defaultNameTag = []byte("__name__")
+
+	errNoNameTag                = errors.New("no name tag set")
+	errNoTagEncoderPool         = errors.New("no tag encoder pool set")
+	errInvalidSetsFlushInterval = errors.New("sets flush interval must be positive")
+)
+
+// Options provide a set of server options.
+type Options interface {
+	// Validate validates the options.
+	Validate() error
+
+	// SetClockOptions sets the clock options.
+	SetClockOptions(value clock.Options) Options
+
+	// ClockOptions returns the clock options.
+	ClockOptions() clock.Options
+
+	// SetInstrumentOptions sets the instrument options.
+	SetInstrumentOptions(value instrument.Options) Options
+
+	// InstrumentOptions returns the instrument options.
+	InstrumentOptions() instrument.Options
+
+	// SetServerOptions sets the server options.
+	SetServerOptions(value xserver.Options) Options
+
+	// ServerOptions returns the server options.
+	ServerOptions() xserver.Options
+
+	// SetMatcher sets the matcher of the mapping and rollup rules applied to
+	// the metrics, if not set the metrics use the default staged metadatas.
+	SetMatcher(value rules.Matcher) Options
+
+	// Matcher returns the matcher of the mapping and rollup rules applied to
+	// the metrics.
+	Matcher() rules.Matcher
+
+	// SetNameTag sets the tag the metric names are stored in.
+	SetNameTag(value []byte) Options
+
+	// NameTag returns the tag the metric names are stored in.
+	NameTag() []byte
+
+	// SetTagEncoderPool sets the pool of the encoders of the metric IDs.
+	SetTagEncoderPool(value serialize.TagEncoderPool) Options
+
+	// TagEncoderPool returns the pool of the encoders of the metric IDs.
+	TagEncoderPool() serialize.TagEncoderPool
+
+	// SetSetsFlushInterval sets the interval the unique values of sets are
+	// counted over and added as gauges.
+	SetSetsFlushInterval(value time.Duration) Options
+
+	// SetsFlushInterval returns the interval the unique values of sets are
+	// counted over and added as gauges.
+	SetsFlushInterval() time.Duration
+
+	// SetErrorLogLimitPerSecond sets the error log limit per second.
+	SetErrorLogLimitPerSecond(value int64) Options
+
+	// ErrorLogLimitPerSecond returns the error log limit per second.
+	ErrorLogLimitPerSecond() int64
+}
+
+type options struct {
+	clockOpts            clock.Options
+	instrumentOpts       instrument.Options
+	serverOpts           xserver.Options
+	matcher              rules.Matcher
+	nameTag              []byte
+	tagEncoderPool       serialize.TagEncoderPool
+	setsFlushInterval    time.Duration
+	errLogLimitPerSecond int64
+}
+
+// NewOptions creates a new set of server options.
+func NewOptions() Options {
+	tagEncoderPool := serialize.NewTagEncoderPool(serialize.NewTagEncoderOptions(),
+		pool.NewObjectPoolOptions())
+	tagEncoderPool.Init()
+	return &options{
+		clockOpts:            clock.NewOptions(),
+		instrumentOpts:       instrument.NewOptions(),
+		serverOpts:           xserver.NewOptions(),
+		nameTag:              defaultNameTag,
+		tagEncoderPool:       tagEncoderPool,
+		setsFlushInterval:    defaultSetsFlushInterval,
+		errLogLimitPerSecond: defaultErrorLogLimitPerSecond,
+	}
+}
+
+func (o *options) Validate() error {
+	if len(o.nameTag) == 0 {
+		return errNoNameTag
+	}
+	if o.tagEncoderPool == nil {
+		return errNoTagEncoderPool
+	}
+	if o.setsFlushInterval <= 0 {
+		return errInvalidSetsFlushInterval
+	}
+	return nil
+}
+
+func (o *options) SetClockOptions(value clock.Options) Options {
+	opts := *o
+	opts.clockOpts = value
+	return &opts
+}
+
+func (o *options) ClockOptions() clock.Options {
+	return o.clockOpts
+}
+
+func (o *options) SetInstrumentOptions(value instrument.Options) Options {
+	opts := *o
+	opts.instrumentOpts = value
+	return &opts
+}
+
+func (o *options) InstrumentOptions() instrument.Options {
+	return o.instrumentOpts
+}
+
+func (o *options) SetServerOptions(value xserver.Options) Options {
+	opts := *o
+	opts.serverOpts = value
+	return &opts
+}
+
+func (o *options) ServerOptions() xserver.Options {
+	return o.serverOpts
+}
+
+func (o *options) SetMatcher(value rules.Matcher) Options {
+	opts := *o
+	opts.matcher = value
+	return &opts
+}
+
+func (o *options) Matcher() rules.Matcher {
+	return o.matcher
+}
+
+func (o *options) SetNameTag(value []byte) Options {
+	opts := *o
+	opts.nameTag = value
+	return &opts
+}
+
+func (o *options) NameTag() []byte {
+	return o.nameTag
+}
+
+func (o *options) SetTagEncoderPool(value serialize.TagEncoderPool) Options {
+	opts := *o
+	opts.tagEncoderPool = value
+	return &opts
+}
+
+func (o *options) TagEncoderPool() serialize.TagEncoderPool {
+	return o.tagEncoderPool
+}
+
+func (o *options) SetSetsFlushInterval(value time.Duration) Options {
+	opts := *o
+	opts.setsFlushInterval = value
+	return &opts
+}
+
+func (o *options) SetsFlushInterval() time.Duration {
+	return o.setsFlushInterval
+}
+
+func (o *options) SetErrorLogLimitPerSecond(value int64) Options {
+	opts := *o
+	opts.errLogLimitPerSecond = value
+	return &opts
+}
+
+func (o *options) ErrorLogLimitPerSecond() int64 {
+	return o.errLogLimitPerSecond
+}
@@ -0,0 +1,195 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package statsd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// metricType is the type of a statsd metric.
+type metricType int
+
+const (
+	counterType metricType = iota
+	gaugeType
+	timerType
+	setType
+)
+
+var (
+	eventPrefix        = []byte("_e{")
+	serviceCheckPrefix = []byte("_sc|")
+
+	errUnsupportedLine = errors.New("events and service checks are not supported")
+	errRelativeGauge   = errors.New("relative gauges are not supported")
+	errNoName          = errors.New("metric has no name")
+	errNoType          = errors.New("metric has no type")
+	errNoValue         = errors.New("metric has no value")
+)
+
+type tag struct {
+	name  []byte
+	value []byte
+}
+
+// parsedMetric is a statsd metric parsed from a line, the byte slices of which
+// refer to the line.
+type parsedMetric struct {
+	name       []byte
+	metricType metricType
+	values     []float64
+	setValues  [][]byte
+	sampleRate float64
+	tags       []tag
+}
+
+func (m *parsedMetric) reset() {
+	m.name = nil
+	m.metricType = counterType
+	m.values = m.values[:0]
+	m.setValues = m.setValues[:0]
+	m.sampleRate = 1
+	m.tags = m.tags[:0]
+}
+
+// parseLine parses a line in the statsd format extended by DogStatsD:
+// <name>:<value>[:<value>...]|<type>[|@<sample rate>][|#<tag>:<value>,...].
+// Tags without a value are skipped, as are unknown fields such as container
+// IDs and timestamps.
+func parseLine(line []byte, m *parsedMetric) error {
+	m.reset()
+	if bytes.HasPrefix(line, eventPrefix) || bytes.HasPrefix(line, serviceCheckPrefix) {
+		return errUnsupportedLine
+	}
+
+	idx := bytes.IndexByte(line, ':')
+	if idx == 0 {
+		return errNoName
+	}
+	if idx < 0 {
+		return errNoValue
+	}
+	m.name = line[:idx]
+	line = line[idx+1:]
+
+	idx = bytes.IndexByte(line, '|')
+	if idx < 0 {
+		return errNoType
+	}
+	values := line[:idx]
+	line = line[idx+1:]
+
+	var typ []byte
+	typ, line = nextField(line)
+	switch string(typ) {
+	case "c":
+		m.metricType = counterType
+	case "g":
+		m.metricType = gaugeType
+	case "ms", "h", "d":
+		m.metricType = timerType
+	case "s":
+		m.metricType = setType
+	default:
+		return fmt.Errorf("unknown metric type: %s", typ)
+	}
+
+	for len(line) > 0 {
+		var field []byte
+		field, line = nextField(line)
+		if len(field) == 0 {
+			continue
+		}
+		switch field[0] {
+		case '@':
+			rate, err := strconv.ParseFloat(string(field[1:]), 64)
+			if err != nil {
+				return fmt.Errorf("invalid sample rate: %s", field[1:])
+			}
+			if rate <= 0 || rate > 1 {
+				return fmt.Errorf("sample rate out of range: %s", field[1:])
+			}
+			m.sampleRate = rate
+		case '#':
+			m.parseTags(field[1:])
+		}
+	}
+
+	// NB: DogStatsD allows several values of a metric separated by colons.
+	for len(values) > 0 {
+		var value []byte
+		idx := bytes.IndexByte(values, ':')
+		if idx < 0 {
+			value, values = values, nil
+		} else {
+			value, values = values[:idx], values[idx+1:]
+		}
+		if len(value) == 0 {
+			continue
+		}
+		if m.metricType == setType {
+			m.setValues = append(m.setValues, value)
+			continue
+		}
+		// NB: a signed gauge value is a delta to the current value of the gauge
+		// in StatsD, which is not kept since gauges are set to their last value.
+		if m.metricType == gaugeType && (value[0] == '+' || value[0] == '-') {
+			return errRelativeGauge
+		}
+		v, err := strconv.ParseFloat(string(value), 64)
+		if err != nil {
+			return fmt.Errorf("invalid value: %s", value)
+		}
+		m.values = append(m.values, v)
+	}
+	if len(m.values) == 0 && len(m.setValues) == 0 {
+		return errNoValue
+	}
+	return nil
+}
+
+func (m *parsedMetric) parseTags(tags []byte) {
+	for len(tags) > 0 {
+		var t []byte
+		idx := bytes.IndexByte(tags, ',')
+		if idx < 0 {
+			t, tags = tags, nil
+		} else {
+			t, tags = tags[:idx], tags[idx+1:]
+		}
+		idx = bytes.IndexByte(t, ':')
+		if idx <= 0 || idx == len(t)-1 {
+			continue
+		}
+		m.tags = append(m.tags, tag{name: t[:idx], value: t[idx+1:]})
+	}
+}
+
+func nextField(line []byte) ([]byte, []byte) {
+	idx := bytes.IndexByte(line, '|')
+	if idx < 0 {
+		return line, nil
+	}
+	return line[:idx], line[idx+1:]
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package statsd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected parsedMetric
+	}{
+		{
+			line: "requests:1|c",
+			expected: parsedMetric{
+				name:       []byte("requests"),
+				metricType: counterType,
+				values:     []float64{1},
+				sampleRate: 1,
+			},
+		},
+		{
+			line: "requests:2|c|@0.5|#env:prod,host:a,production",
+			expected: parsedMetric{
+				name:       []byte("requests"),
+				metricType: counterType,
+				values:     []float64{2},
+				sampleRate: 0.5,
+				tags: []tag{
+					{name: []byte("env"), value: []byte("prod")},
+					{name: []byte("host"), value: []byte("a")},
+				},
+			},
+		},
+		{
+			line: "temperature:3.5|g|#room:kitchen",
+			expected: parsedMetric{
+				name:       []byte("temperature"),
+				metricType: gaugeType,
+				values:     []float64{3.5},
+				sampleRate: 1,
+				tags: []tag{
+					{name: []byte("room"), value: []byte("kitchen")},
+				},
+			},
+		},
+		{
+			line: "latency:10:20:30|ms|c:container|T1656581400",
+			expected: parsedMetric{
+				name:       []byte("latency"),
+				metricType: timerType,
+				values:     []float64{10, 20, 30},
+				sampleRate: 1,
+			},
+		},
+		{
+			line: "size:42|h",
+			expected: parsedMetric{
+				name:       []byte("size"),
+				metricType: timerType,
+				values:     []float64{42},
+				sampleRate: 1,
+			},
+		},
+		{
+			line: "size:42|d",
+			expected: parsedMetric{
+				name:       []byte("size"),
+				metricType: timerType,
+				values:     []float64{42},
+				sampleRate: 1,
+			},
+		},
+		{
+			line: "users:alice|s",
+			expected: parsedMetric{
+				name:       []byte("users"),
+				metricType: setType,
+				setValues:  [][]byte{[]byte("alice")},
+				sampleRate: 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			var m parsedMetric
+			require.NoError(t, parseLine([]byte(test.line), &m))
+			assert.Equal(t, string(test.expected.name), string(m.name))
+			assert.Equal(t, test.expected.metricType, m.metricType)
+			assert.Equal(t, test.expected.sampleRate, m.sampleRate)
+			assert.Equal(t, len(test.expected.values), len(m.values))
+			for i := range test.expected.values {
+				assert.Equal(t, test.expected.values[i], m.values[i])
+			}
+			assert.Equal(t, test.expected.setValues, m.setValues)
+			assert.Equal(t, test.expected.tags, m.tags)
+		})
+	}
+}
+
+func TestParseLineErrors(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{line: "_e{5,4}:title|text", expected: errUnsupportedLine.Error()},
+		{line: "_sc|check|0", expected: errUnsupportedLine.Error()},
+		{line: "temperature:+5|g", expected: errRelativeGauge.Error()},
+		{line: "temperature:1:-3|g", expected: errRelativeGauge.Error()},
+		{line: ":1|c", expected: errNoName.Error()},
+		{line: "requests|c", expected: errNoValue.Error()},
+		{line: "requests:1", expected: errNoType.Error()},
+		{line: "requests:|c", expected: errNoValue.Error()},
+		{line: "requests:1|x", expected: "unknown metric type: x"},
+		{line: "requests:a|c", expected: "invalid value: a"},
+		{line: "requests:1|c|@2", expected: "sample rate out of range: 2"},
+		{line: "requests:1|c|@a", expected: "invalid sample rate: a"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.line, func(t *testing.T) {
+			var m parsedMetric
+			require.EqualError(t, parseLine([]byte(test.line), &m), test.expected)
+		})
+	}
+}
@@ -0,0 +1,349 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package statsd implements a UDP server ingesting StatsD and DogStatsD
+// metrics into the aggregator.
+package statsd
+
+import (
+	"bytes"
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/aggregator/aggregator"
+	"github.com/m3db/m3/src/aggregator/rate"
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/metric"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/metric/unaggregated"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/serialize"
+	xserver "github.com/m3db/m3/src/x/server"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// NewServer creates a new StatsD UDP server adding the metrics it receives
+// to the aggregator. Counters, gauges, timers, histograms and distributions
+// are added as they are received, while the unique values of sets are
+// counted and added as gauges every sets flush interval.
+func NewServer(
+	address string,
+	aggregator aggregator.Aggregator,
+	opts Options,
+) (xserver.PacketServer, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	iOpts := opts.InstrumentOptions()
+	handlerScope := iOpts.MetricsScope().Tagged(map[string]string{"handler": "statsd"})
+	handler := newHandler(aggregator, opts.SetInstrumentOptions(iOpts.SetMetricsScope(handlerScope)))
+	return &server{
+		PacketServer:      xserver.NewPacketServer(address, handler, opts.ServerOptions()),
+		handler:           handler,
+		setsFlushInterval: opts.SetsFlushInterval(),
+		doneCh:            make(chan struct{}),
+	}, nil
+}
+
+type server struct {
+	xserver.PacketServer
+
+	handler           *handler
+	setsFlushInterval time.Duration
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	doneCh    chan struct{}
+	wg        sync.WaitGroup
+}
+
+func (s *server) ListenAndServe() error {
+	if err := s.PacketServer.ListenAndServe(); err != nil {
+		return err
+	}
+	s.startFlushingSets()
+	return nil
+}
+
+func (s *server) Serve(conn net.PacketConn) error {
+	if err := s.PacketServer.Serve(conn); err != nil {
+		return err
+	}
+	s.startFlushingSets()
+	return nil
+}
+
+func (s *server) Close() {
+	s.PacketServer.Close()
+	s.closeOnce.Do(func() {
+		close(s.doneCh)
+	})
+	s.wg.Wait()
+}
+
+func (s *server) startFlushingSets() {
+	s.startOnce.Do(func() {
+		s.wg.Add(1)
+		go s.flushSetsEvery(s.setsFlushInterval)
+	})
+}
+
+func (s *server) flushSetsEvery(interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.handler.flushSets()
+		case <-s.doneCh:
+			s.handler.flushSets()
+			return
+		}
+	}
+}
+
+type handlerMetrics struct {
+	metrics           tally.Counter
+	unsupportedLines  tally.Counter
+	parseErrors       tally.Counter
+	encodeErrors      tally.Counter
+	addUntimedErrors  tally.Counter
+	errLogRateLimited tally.Counter
+}
+
+func newHandlerMetrics(scope tally.Scope) handlerMetrics {
+	return handlerMetrics{
+		metrics:           scope.Counter("metrics"),
+		unsupportedLines:  scope.Counter("unsupported-lines"),
+		parseErrors:       scope.Counter("parse-errors"),
+		encodeErrors:      scope.Counter("encode-errors"),
+		addUntimedErrors:  scope.Counter("add-untimed-errors"),
+		errLogRateLimited: scope.Counter("error-log-rate-limited"),
+	}
+}
+
+type handler struct {
+	sync.Mutex
+
+	aggregator     aggregator.Aggregator
+	matcher        rules.Matcher
+	matchOpts      rules.MatchOptions
+	nameTag        []byte
+	tagEncoderPool serialize.TagEncoderPool
+	nowFn          clock.NowFn
+	log            *zap.Logger
+
+	errLogRateLimiter *rate.Limiter
+	metrics           handlerMetrics
+
+	// NB: the following are reused across packets and guarded by the lock.
+	metric parsedMetric
+	pairs  []id.TagPair
+	idIter serialize.MetricTagsIterator
+	sets   map[string]map[string]struct{}
+}
+
+func newHandler(aggregator aggregator.Aggregator, opts Options) *handler {
+	iOpts := opts.InstrumentOptions()
+	var limiter *rate.Limiter
+	if rateLimit := opts.ErrorLogLimitPerSecond(); rateLimit != 0 {
+		limiter = rate.NewLimiter(rateLimit)
+	}
+
+	tagLimits := serialize.NewTagSerializationLimits()
+	tagIter := serialize.NewUncheckedMetricTagsIterator(tagLimits)
+	return &handler{
+		aggregator: aggregator,
+		matcher:    opts.Matcher(),
+		matchOpts: rules.MatchOptions{
+			NameAndTagsFn: newNameAndTagsFn(opts.NameTag()),
+			SortedTagIteratorFn: func(tagPairs []byte) id.SortedTagIterator {
+				tagIter.Reset(tagPairs)
+				return tagIter
+			},
+		},
+		nameTag:           opts.NameTag(),
+		tagEncoderPool:    opts.TagEncoderPool(),
+		nowFn:             opts.ClockOptions().NowFn(),
+		log:               iOpts.Logger(),
+		errLogRateLimiter: limiter,
+		metrics:           newHandlerMetrics(iOpts.MetricsScope()),
+		idIter:            serialize.NewUncheckedMetricTagsIterator(tagLimits),
+		sets:              make(map[string]map[string]struct{}),
+	}
+}
+
+func (h *handler) HandlePacket(packet []byte) {
+	h.Lock()
+	defer h.Unlock()
+
+	for len(packet) > 0 {
+		var line []byte
+		if idx := bytes.IndexByte(packet, '\n'); idx < 0 {
+			line, packet = packet, nil
+		} else {
+			line, packet = packet[:idx], packet[idx+1:]
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		if err := parseLine(line, &h.metric); err != nil {
+			if err == errUnsupportedLine || err == errRelativeGauge {
+				h.metrics.unsupportedLines.Inc(1)
+				continue
+			}
+			h.metrics.parseErrors.Inc(1)
+			h.logError("unable to parse statsd line",
+				zap.ByteString("line", line),
+				zap.Error(err))
+			continue
+		}
+
+		h.metrics.metrics.Inc(1)
+		h.add(&h.metric)
+	}
+}
+
+func (h *handler) add(m *parsedMetric) {
+	pairs := append(h.pairs[:0], id.TagPair{Name: h.nameTag, Value: m.name})
+	for _, t := range m.tags {
+		if bytes.Equal(t.name, h.nameTag) {
+			continue
+		}
+		pairs = append(pairs, id.TagPair{Name: t.name, Value: t.value})
+	}
+	h.pairs = pairs
+
+	encodedID, err := encodeID(h.tagEncoderPool, pairs)
+	if err != nil {
+		h.metrics.encodeErrors.Inc(1)
+		h.logError("unable to encode statsd metric ID",
+			zap.ByteString("name", m.name),
+			zap.Error(err))
+		return
+	}
+
+	union := unaggregated.MetricUnion{ID: encodedID}
+	switch m.metricType {
+	case counterType:
+		var sum float64
+		for _, v := range m.values {
+			sum += v
+		}
+		// Scale sampled counters up to the count they were sampled from.
+		union.Type = metric.CounterType
+		union.CounterVal = int64(math.Round(sum / m.sampleRate))
+	case gaugeType:
+		// NB: the last value of a gauge is its current value.
+		union.Type = metric.GaugeType
+		union.GaugeVal = m.values[len(m.values)-1]
+	case timerType:
+		// NB: the aggregator has no notion of the weight of the values of
+		// timers, so sample rates of timers are ignored.
+		union.Type = metric.TimerType
+		union.BatchTimerVal = append([]float64(nil), m.values...)
+	case setType:
+		values, ok := h.sets[string(encodedID)]
+		if !ok {
+			values = make(map[string]struct{})
+			h.sets[string(encodedID)] = values
+		}
+		for _, v := range m.setValues {
+			values[string(v)] = struct{}{}
+		}
+		return
+	}
+
+	h.addUntimed(union)
+}
+
+// flushSets adds the number of unique values of each set received since
+// the last flush as a gauge.
+func (h *handler) flushSets() {
+	h.Lock()
+	defer h.Unlock()
+
+	for encodedID, values := range h.sets {
+		h.addUntimed(unaggregated.MetricUnion{
+			Type:     metric.GaugeType,
+			ID:       []byte(encodedID),
+			GaugeVal: float64(len(values)),
+		})
+		delete(h.sets, encodedID)
+	}
+}
+
+func (h *handler) addUntimed(union unaggregated.MetricUnion) {
+	if err := h.addUntimedWithRules(union); err != nil {
+		h.metrics.addUntimedErrors.Inc(1)
+		h.logError("error adding untimed metric",
+			zap.Stringer("type", union.Type),
+			zap.Stringer("id", union.ID),
+			zap.Error(err))
+	}
+}
+
+func (h *handler) addUntimedWithRules(union unaggregated.MetricUnion) error {
+	if h.matcher == nil {
+		return h.aggregator.AddUntimed(union, metadata.DefaultStagedMetadatas)
+	}
+
+	nowNanos := h.nowFn().UnixNano()
+	h.idIter.Reset(union.ID)
+	result, err := h.matcher.ForwardMatch(h.idIter, nowNanos, nowNanos+1, h.matchOpts)
+	if err != nil {
+		return err
+	}
+
+	var multiErr xerrors.MultiError
+	if metadatas := result.ForExistingIDAt(nowNanos); !metadatas.IsDropPolicyApplied() {
+		multiErr = multiErr.Add(h.aggregator.AddUntimed(union, metadatas))
+	}
+	for i := 0; i < result.NumNewRollupIDs(); i++ {
+		rollup := result.ForNewRollupIDsAt(i, nowNanos)
+		rollupUnion := union
+		rollupUnion.ID = rollup.ID
+		multiErr = multiErr.Add(h.aggregator.AddUntimed(rollupUnion, rollup.Metadatas))
+	}
+	return multiErr.FinalError()
+}
+
+func (h *handler) logError(msg string, fields ...zap.Field) {
+	// We rate limit the error log here because the error rate may scale with
+	// the metrics incoming rate and consume lots of cpu cycles.
+	if h.errLogRateLimiter != nil && !h.errLogRateLimiter.IsAllowed(1, xtime.ToUnixNano(h.nowFn())) {
+		h.metrics.errLogRateLimited.Inc(1)
+		return
+	}
+	h.log.Error(msg, fields...)
+}
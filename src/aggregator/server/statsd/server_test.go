@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package statsd
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
+
+	"github.com/m3db/m3/src/aggregator/aggregator/capture"
+	"github.com/m3db/m3/src/metrics/aggregation"
+	"github.com/m3db/m3/src/metrics/matcher"
+	"github.com/m3db/m3/src/metrics/metadata"
+	"github.com/m3db/m3/src/metrics/metric/id"
+	"github.com/m3db/m3/src/metrics/metric/unaggregated"
+	"github.com/m3db/m3/src/metrics/policy"
+	"github.com/m3db/m3/src/metrics/rules"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var testMetadatas = metadata.StagedMetadatas{
+	{
+		Metadata: metadata.Metadata{
+			Pipelines: []metadata.PipelineMetadata{
+				{
+					AggregationID: aggregation.MustCompressTypes(aggregation.Sum),
+					StoragePolicies: []policy.StoragePolicy{
+						policy.NewStoragePolicy(10*time.Second, xtime.Second, 48*time.Hour),
+					},
+				},
+			},
+		},
+	},
+}
+
+func testID(t *testing.T, nameAndTags ...string) []byte {
+	pairs := []id.TagPair{{Name: defaultNameTag, Value: []byte(nameAndTags[0])}}
+	for i := 1; i < len(nameAndTags); i += 2 {
+		pairs = append(pairs, id.TagPair{
+			Name:  []byte(nameAndTags[i]),
+			Value: []byte(nameAndTags[i+1]),
+		})
+	}
+	encoded, err := encodeID(NewOptions().TagEncoderPool(), pairs)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestHandlePacket(t *testing.T) {
+	agg := capture.NewAggregator()
+	h := newHandler(agg, NewOptions())
+
+	h.HandlePacket([]byte("requests:2|c|@0.5|#host:a,env:prod\n" +
+		"temperature:1:3|g\n" +
+		"latency:10:20|ms|#host:a\n" +
+		"\n" +
+		"_e{5,4}:title|text\n" +
+		"invalid\n"))
+
+	res := agg.Snapshot()
+	require.Equal(t, []unaggregated.CounterWithMetadatas{
+		{
+			Counter: unaggregated.Counter{
+				ID:    testID(t, "requests", "env", "prod", "host", "a"),
+				Value: 4,
+			},
+			StagedMetadatas: metadata.DefaultStagedMetadatas,
+		},
+	}, res.CountersWithMetadatas)
+	require.Equal(t, []unaggregated.GaugeWithMetadatas{
+		{
+			Gauge: unaggregated.Gauge{
+				ID:    testID(t, "temperature"),
+				Value: 3,
+			},
+			StagedMetadatas: metadata.DefaultStagedMetadatas,
+		},
+	}, res.GaugesWithMetadatas)
+	require.Equal(t, []unaggregated.BatchTimerWithMetadatas{
+		{
+			BatchTimer: unaggregated.BatchTimer{
+				ID:     testID(t, "latency", "host", "a"),
+				Values: []float64{10, 20},
+			},
+			StagedMetadatas: metadata.DefaultStagedMetadatas,
+		},
+	}, res.BatchTimersWithMetadatas)
+}
+
+func TestHandlePacketRelativeGauges(t *testing.T) {
+	scope := tally.NewTestScope("", nil)
+	agg := capture.NewAggregator()
+	h := newHandler(agg, NewOptions().SetInstrumentOptions(
+		instrument.NewOptions().SetMetricsScope(scope)))
+
+	h.HandlePacket([]byte("temperature:3|g\ntemperature:+5|g\ntemperature:-2|g"))
+
+	require.Equal(t, []unaggregated.GaugeWithMetadatas{
+		{
+			Gauge: unaggregated.Gauge{
+				ID:    testID(t, "temperature"),
+				Value: 3,
+			},
+			StagedMetadatas: metadata.DefaultStagedMetadatas,
+		},
+	}, agg.Snapshot().GaugesWithMetadatas)
+	snapshot := scope.Snapshot().Counters()
+	require.Equal(t, int64(1), snapshot["metrics+"].Value())
+	require.Equal(t, int64(2), snapshot["unsupported-lines+"].Value())
+	require.Equal(t, int64(0), snapshot["parse-errors+"].Value())
+}
+
+func TestHandlePacketSets(t *testing.T) {
+	agg := capture.NewAggregator()
+	h := newHandler(agg, NewOptions())
+
+	h.HandlePacket([]byte("users:alice|s\nusers:bob|s\nusers:alice|s"))
+	require.Equal(t, 0, agg.NumMetricsAdded())
+
+	h.flushSets()
+	require.Equal(t, []unaggregated.GaugeWithMetadatas{
+		{
+			Gauge: unaggregated.Gauge{
+				ID:    testID(t, "users"),
+				Value: 2,
+			},
+			StagedMetadatas: metadata.DefaultStagedMetadatas,
+		},
+	}, agg.Snapshot().GaugesWithMetadatas)
+
+	// Sets are reset once flushed.
+	h.flushSets()
+	require.Empty(t, agg.Snapshot().GaugesWithMetadatas)
+}
+
+func TestHandlePacketMatchRules(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		metricID = testID(t, "requests", "host", "a")
+		rollupID = testID(t, "requests_by_host", string(rollupTagName), string(rollupTagValue))
+	)
+	m := matcher.NewMockMatcher(ctrl)
+	m.EXPECT().
+		ForwardMatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			matchID id.ID,
+			_, _ int64,
+			opts rules.MatchOptions,
+		) (rules.MatchResult, error) {
+			require.Equal(t, metricID, matchID.Bytes())
+			name, tags, err := opts.NameAndTagsFn(matchID.Bytes())
+			require.NoError(t, err)
+			require.Equal(t, "requests", string(name))
+			require.Equal(t, metricID, tags)
+			return rules.NewMatchResult(0, 0, testMetadatas, []rules.IDWithMetadatas{
+				{ID: rollupID, Metadatas: testMetadatas},
+			}, true), nil
+		})
+
+	agg := capture.NewAggregator()
+	h := newHandler(agg, NewOptions().SetMatcher(m))
+	h.HandlePacket([]byte("requests:1|c|#host:a"))
+
+	counters := agg.Snapshot().CountersWithMetadatas
+	require.Equal(t, 2, len(counters))
+	require.Equal(t, unaggregated.Counter{ID: metricID, Value: 1}, counters[0].Counter)
+	require.True(t, testMetadatas.Equal(counters[0].StagedMetadatas))
+	require.Equal(t, unaggregated.Counter{ID: rollupID, Value: 1}, counters[1].Counter)
+	require.True(t, testMetadatas.Equal(counters[1].StagedMetadatas))
+}
+
+func TestNewRollupIDFn(t *testing.T) {
+	newRollupID := NewRollupIDFn(NewOptions())
+	rollupID := newRollupID([]byte("requests_by_host"), []id.TagPair{
+		{Name: []byte("host"), Value: []byte("a")},
+	})
+	require.Equal(t, testID(t, "requests_by_host", "host", "a",
+		string(rollupTagName), string(rollupTagValue)), rollupID)
+	require.True(t, IsRollupID(nil, rollupID))
+	require.False(t, IsRollupID(nil, testID(t, "requests", "host", "a")))
+}
+
+func TestServer(t *testing.T) {
+	agg := capture.NewAggregator()
+	opts := NewOptions().SetSetsFlushInterval(time.Hour)
+	s, err := NewServer("127.0.0.1:0", agg, opts)
+	require.NoError(t, err)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	require.NoError(t, s.Serve(conn))
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	_, err = client.Write([]byte("requests:1|c\nusers:alice|s"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return agg.NumMetricsAdded() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Sets are flushed when the server is closed.
+	s.Close()
+	require.Equal(t, 2, agg.NumMetricsAdded())
+}
+
+func TestNewServerInvalidOptions(t *testing.T) {
+	_, err := NewServer("127.0.0.1:0", capture.NewAggregator(),
+		NewOptions().SetSetsFlushInterval(0))
+	require.Equal(t, errInvalidSetsFlushInterval, err)
+}
@@ -44,6 +44,10 @@ type Configuration struct {
 	// Optional.
 	RawTCP *RawTCPServerConfiguration `yaml:"rawtcp"`
 
+	// StatsD server configuration.
+	// Optional.
+	Statsd *StatsdServerConfiguration `yaml:"statsd"`
+
 	// HTTP server configuration.
 	// Optional.
 	HTTP *HTTPServerConfiguration `yaml:"http"`
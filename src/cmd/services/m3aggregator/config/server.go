@@ -26,8 +26,13 @@ import (
 	"github.com/m3db/m3/src/aggregator/server/http"
 	"github.com/m3db/m3/src/aggregator/server/m3msg"
 	"github.com/m3db/m3/src/aggregator/server/rawtcp"
+	"github.com/m3db/m3/src/aggregator/server/statsd"
+	"github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/metrics/encoding/protobuf"
+	"github.com/m3db/m3/src/metrics/filters"
+	"github.com/m3db/m3/src/metrics/matcher"
 	"github.com/m3db/m3/src/msg/consumer"
+	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/pool"
 	"github.com/m3db/m3/src/x/retry"
@@ -118,6 +123,71 @@ func (c *RawTCPServerConfiguration) NewServerOptions(
 	return opts
 }
 
+// StatsdServerConfiguration contains StatsD UDP server configuration.
+type StatsdServerConfiguration struct {
+	// StatsD server listening address.
+	ListenAddress string `yaml:"listenAddress" validate:"nonzero"`
+
+	// Error log limit per second.
+	ErrorLogLimitPerSecond *int64 `yaml:"errorLogLimitPerSecond"`
+
+	// Interval the unique values of sets are counted over.
+	SetsFlushInterval *time.Duration `yaml:"setsFlushInterval"`
+
+	// Matcher of the mapping and rollup rules applied to the metrics, the
+	// metrics use the default storage policies of the aggregator if not set.
+	Matcher *matcher.Configuration `yaml:"matcher"`
+}
+
+// NewServerOptions create a new set of StatsD server options.
+func (c *StatsdServerConfiguration) NewServerOptions(
+	kvClient client.Client,
+	clockOpts clock.Options,
+	instrumentOpts instrument.Options,
+) (statsd.Options, error) {
+	opts := statsd.NewOptions().
+		SetClockOptions(clockOpts).
+		SetInstrumentOptions(instrumentOpts).
+		SetServerOptions(xserver.NewOptions().SetInstrumentOptions(instrumentOpts))
+	if c.ErrorLogLimitPerSecond != nil {
+		opts = opts.SetErrorLogLimitPerSecond(*c.ErrorLogLimitPerSecond)
+	}
+	if c.SetsFlushInterval != nil {
+		opts = opts.SetSetsFlushInterval(*c.SetsFlushInterval)
+	}
+
+	if c.Matcher != nil {
+		scope := instrumentOpts.MetricsScope()
+		iOpts := instrumentOpts.SetMetricsScope(scope.SubScope("matcher"))
+		matcherOpts, err := c.Matcher.NewOptions(kvClient, clockOpts, iOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		// NB: the IDs of the metrics are encoded tags like the IDs of the
+		// metrics written by the coordinator rather than M3 IDs, so the rules
+		// are matched the way the coordinator does.
+		opts = opts.SetNameTag([]byte(c.Matcher.NameTagKey))
+		ruleSetOpts := matcherOpts.RuleSetOptions().
+			SetTagsFilterOptions(filters.TagsFilterOptions{
+				NameTagKey: opts.NameTag(),
+			}).
+			SetNewRollupIDFn(statsd.NewRollupIDFn(opts)).
+			SetIsRollupIDFn(statsd.IsRollupID)
+
+		m, err := matcher.NewMatcher(nil, matcherOpts.SetRuleSetOptions(ruleSetOpts))
+		if err != nil {
+			return nil, err
+		}
+		opts = opts.SetMatcher(m)
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
 // protobufUnaggregatedIteratorConfiguration contains configuration for protobuf unaggregated iterator.
 type protobufUnaggregatedIteratorConfiguration struct {
 	// Initial buffer size.
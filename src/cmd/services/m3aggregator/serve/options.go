@@ -24,6 +24,7 @@ import (
 	httpserver "github.com/m3db/m3/src/aggregator/server/http"
 	m3msgserver "github.com/m3db/m3/src/aggregator/server/m3msg"
 	rawtcpserver "github.com/m3db/m3/src/aggregator/server/rawtcp"
+	statsdserver "github.com/m3db/m3/src/aggregator/server/statsd"
 	"github.com/m3db/m3/src/x/instrument"
 	xio "github.com/m3db/m3/src/x/io"
 )
@@ -54,6 +55,18 @@ type Options interface {
 	// RawTCPServerOpts returns the RawTCPServerOpts.
 	RawTCPServerOpts() rawtcpserver.Options
 
+	// SetStatsdAddr sets the StatsD address.
+	SetStatsdAddr(value string) Options
+
+	// StatsdAddr returns the StatsD address.
+	StatsdAddr() string
+
+	// SetStatsdServerOpts sets the StatsdServerOpts.
+	SetStatsdServerOpts(value statsdserver.Options) Options
+
+	// StatsdServerOpts returns the StatsdServerOpts.
+	StatsdServerOpts() statsdserver.Options
+
 	// SetHTTPAddr sets the HTTP address.
 	SetHTTPAddr(value string) Options
 
@@ -84,6 +97,8 @@ type options struct {
 	m3msgServerOpts  m3msgserver.Options
 	rawTCPAddr       string
 	rawTCPServerOpts rawtcpserver.Options
+	statsdAddr       string
+	statsdServerOpts statsdserver.Options
 	httpAddr         string
 	httpServerOpts   httpserver.Options
 	iOpts            instrument.Options
@@ -138,6 +153,26 @@ func (o *options) RawTCPServerOpts() rawtcpserver.Options {
 	return o.rawTCPServerOpts
 }
 
+func (o *options) SetStatsdAddr(value string) Options {
+	opts := *o
+	opts.statsdAddr = value
+	return &opts
+}
+
+func (o *options) StatsdAddr() string {
+	return o.statsdAddr
+}
+
+func (o *options) SetStatsdServerOpts(value statsdserver.Options) Options {
+	opts := *o
+	opts.statsdServerOpts = value
+	return &opts
+}
+
+func (o *options) StatsdServerOpts() statsdserver.Options {
+	return o.statsdServerOpts
+}
+
 func (o *options) SetHTTPAddr(value string) Options {
 	opts := *o
 	opts.httpAddr = value
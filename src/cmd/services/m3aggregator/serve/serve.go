@@ -30,6 +30,7 @@ import (
 	httpserver "github.com/m3db/m3/src/aggregator/server/http"
 	m3msgserver "github.com/m3db/m3/src/aggregator/server/m3msg"
 	rawtcpserver "github.com/m3db/m3/src/aggregator/server/rawtcp"
+	statsdserver "github.com/m3db/m3/src/aggregator/server/statsd"
 	xdebug "github.com/m3db/m3/src/x/debug"
 )
 
@@ -94,6 +95,26 @@ func Serve(
 		log.Info("raw TCP server listening", zap.String("addr", rawTCPAddr))
 	}
 
+	if statsdAddr := opts.StatsdAddr(); statsdAddr != "" {
+		serverOpts := opts.StatsdServerOpts()
+		statsdServer, err := statsdserver.NewServer(statsdAddr, aggregator, serverOpts)
+		if err != nil {
+			return fmt.Errorf("could not create statsd server: addr=%s, err=%v", statsdAddr, err)
+		}
+		if err := statsdServer.ListenAndServe(); err != nil {
+			return fmt.Errorf("could not start statsd server at: addr=%s, err=%v", statsdAddr, err)
+		}
+
+		defer func() {
+			start := time.Now()
+			closeLogger.Info("closing statsd server")
+			statsdServer.Close()
+			closeLogger.Info("closed statsd server", zap.String("took", time.Since(start).String()))
+		}()
+
+		log.Info("statsd server listening", zap.String("addr", statsdAddr))
+	}
+
 	if httpAddr := opts.HTTPAddr(); httpAddr != "" {
 		serverOpts := opts.HTTPServerOpts()
 		xdebug.RegisterPProfHandlers(serverOpts.Mux())
@@ -58,6 +58,10 @@ const (
 	maxResourcePoolNameSize = 1024
 	maxPooledTagsSize       = 16
 	defaultResourcePoolSize = 4096
+
+	plaintextProtocol = "plaintext"
+	pickleProtocol    = "pickle"
+	udpProtocol       = "udp"
 )
 
 var (
@@ -65,6 +69,12 @@ var (
 	carbonSeparatorByte  = byte('.')
 	carbonSeparatorBytes = []byte{carbonSeparatorByte}
 
+	// Used for parsing graphite 1.1 tagged names, i.e. "foo.bar;dc=east".
+	carbonTagSeparatorByte  = byte(graphite.TaggedSeparator)
+	carbonTagSeparatorBytes = []byte{carbonTagSeparatorByte}
+	carbonTagValueByte      = byte('=')
+	carbonTaggedNameTag     = []byte(graphite.TaggedNameTag)
+
 	errCannotGenerateTagsFromEmptyName = errors.New("cannot generate tags from empty name")
 	errIOptsMustBeSet                  = errors.New("carbon ingester options: instrument options must be st")
 	errWorkerPoolMustBeSet             = errors.New("carbon ingester options: worker pool must be set")
@@ -77,6 +87,20 @@ type Options struct {
 	IngesterConfig    config.CarbonIngesterConfiguration
 }
 
+// Ingester is a carbon ingester, it handles plaintext connections and can
+// also handle pickle connections and plaintext UDP packets.
+type Ingester interface {
+	m3xserver.Handler
+
+	// PickleHandler returns a handler for connections using the pickle protocol.
+	PickleHandler() m3xserver.Handler
+
+	// HandlePacket handles a packet of newline delimited plaintext metrics,
+	// the packet can be reused once the function returns. It must not be
+	// called concurrently.
+	HandlePacket(packet []byte)
+}
+
 // CarbonIngesterRules contains the carbon ingestion rules.
 type CarbonIngesterRules struct {
 	Rules []config.CarbonIngesterRuleConfiguration
@@ -98,7 +122,7 @@ func NewIngester(
 	downsamplerAndWriter ingest.DownsamplerAndWriter,
 	clusterNamespacesWatcher m3.ClusterNamespacesWatcher,
 	opts Options,
-) (m3xserver.Handler, error) {
+) (Ingester, error) {
 	err := opts.Validate()
 	if err != nil {
 		return nil, err
@@ -155,6 +179,9 @@ type ingester struct {
 
 	lineResourcesPool pool.ObjectPool
 
+	// Only used by HandlePacket, which is called by a single reader.
+	packetMetrics []carbon.Metric
+
 	sync.RWMutex
 	rules []ruleAndMatcher
 }
@@ -271,66 +298,123 @@ func (i *ingester) regenerateIngestionRulesWithLock(clusterNamespaces m3.Cluster
 	return rules
 }
 
+// scanner is the common interface of the plaintext and pickle scanners.
+type scanner interface {
+	Scan() bool
+	Metric() ([]byte, time.Time, float64)
+	Err() error
+}
+
 func (i *ingester) Handle(conn net.Conn) {
+	s := carbon.NewScanner(conn, i.opts.InstrumentOptions)
+	i.handleScanner(s, &s.MalformedCount, i.metrics.plaintext)
+}
+
+func (i *ingester) PickleHandler() m3xserver.Handler {
+	return &pickleHandler{ingester: i}
+}
+
+func (i *ingester) handleScanner(
+	s scanner,
+	malformedCount *int,
+	protocolMetrics carbonProtocolMetrics,
+) {
 	var (
-		// Interfaces require a context be passed, but M3DB client already has timeouts
-		// built in and allocating a new context each time is expensive so we just pass
-		// the same context always and rely on M3DB client timeouts.
-		ctx     = context.Background()
-		wg      = sync.WaitGroup{}
-		s       = carbon.NewScanner(conn, i.opts.InstrumentOptions)
-		logger  = i.opts.InstrumentOptions.Logger()
-		rewrite = &i.opts.IngesterConfig.Rewrite
+		wg             sync.WaitGroup
+		logger         = i.opts.InstrumentOptions.Logger()
+		flushMalformed = func() {
+			i.metrics.malformed.Inc(int64(*malformedCount))
+			protocolMetrics.malformed.Inc(int64(*malformedCount))
+			*malformedCount = 0
+		}
 	)
 
-	logger.Debug("handling new carbon ingestion connection")
+	logger.Debug("handling new carbon ingestion connection",
+		zap.String("protocol", protocolMetrics.protocol))
 	for s.Scan() {
-		received := time.Now()
 		name, timestamp, value := s.Metric()
+		i.dispatch(&wg, name, timestamp, value, protocolMetrics)
+		flushMalformed()
+	}
 
-		resources := i.getLineResources()
+	// Account for malformed metrics found after the last valid metric.
+	flushMalformed()
 
-		// Copy name since scanner bytes are recycled.
-		resources.name = copyAndRewrite(resources.name, name, rewrite)
+	if err := s.Err(); err != nil {
+		logger.Error("encountered error during carbon ingestion when scanning connection",
+			zap.String("protocol", protocolMetrics.protocol), zap.Error(err))
+	}
 
-		wg.Add(1)
-		i.opts.WorkerPool.Go(func() {
-			ok := i.write(ctx, resources, xtime.ToUnixNano(timestamp), value)
-			if ok {
-				i.metrics.success.Inc(1)
-			}
+	logger.Debug("waiting for outstanding carbon ingestion writes to complete")
+	wg.Wait()
+	logger.Debug("all outstanding writes completed, shutting down carbon ingestion handler")
 
-			now := time.Now()
+	// Don't close the connection, that is the server's responsibility.
+}
 
-			// Always record age regardless of success/failure since
-			// sometimes errors can be due to how old the metrics are
-			// and not recording age would obscure this visibility from
-			// the metrics of how fresh/old the incoming metrics are.
-			age := now.Sub(timestamp)
-			i.metrics.ingestLatency.RecordDuration(age)
+func (i *ingester) HandlePacket(packet []byte) {
+	var malformed int
+	i.packetMetrics, malformed = carbon.ParseAndAppendPacket(i.packetMetrics[:0], packet)
+	for _, m := range i.packetMetrics {
+		// No need to wait for the writes since the names are copied before
+		// dispatching and the packet can be reused as soon as we return.
+		i.dispatch(nil, m.Name, m.Time, m.Val, i.metrics.udp)
+	}
 
-			// Also record write latency (not relative to metric timestamp).
-			i.metrics.writeLatency.RecordDuration(now.Sub(received))
+	i.metrics.malformed.Inc(int64(malformed))
+	i.metrics.udp.malformed.Inc(int64(malformed))
+}
 
-			// The contract is that after the DownsamplerAndWriter returns, any resources
-			// that it needed to hold onto have already been copied.
-			i.putLineResources(resources)
-			wg.Done()
-		})
+// dispatch copies the name of the metric and writes it using the worker pool,
+// the wait group is optional.
+func (i *ingester) dispatch(
+	wg *sync.WaitGroup,
+	name []byte,
+	timestamp time.Time,
+	value float64,
+	protocolMetrics carbonProtocolMetrics,
+) {
+	var (
+		// Interfaces require a context be passed, but M3DB client already has timeouts
+		// built in and allocating a new context each time is expensive so we just pass
+		// the same context always and rely on M3DB client timeouts.
+		ctx       = context.Background()
+		received  = time.Now()
+		resources = i.getLineResources()
+	)
 
-		i.metrics.malformed.Inc(int64(s.MalformedCount))
-		s.MalformedCount = 0
-	}
+	// Copy name since scanner bytes are recycled.
+	resources.name = copyAndRewrite(resources.name, name, &i.opts.IngesterConfig.Rewrite)
+	protocolMetrics.received.Inc(1)
 
-	if err := s.Err(); err != nil {
-		logger.Error("encountered error during carbon ingestion when scanning connection", zap.Error(err))
+	if wg != nil {
+		wg.Add(1)
 	}
+	i.opts.WorkerPool.Go(func() {
+		ok := i.write(ctx, resources, xtime.ToUnixNano(timestamp), value)
+		if ok {
+			i.metrics.success.Inc(1)
+		}
 
-	logger.Debug("waiting for outstanding carbon ingestion writes to complete")
-	wg.Wait()
-	logger.Debug("all outstanding writes completed, shutting down carbon ingestion handler")
+		now := time.Now()
 
-	// Don't close the connection, that is the server's responsibility.
+		// Always record age regardless of success/failure since
+		// sometimes errors can be due to how old the metrics are
+		// and not recording age would obscure this visibility from
+		// the metrics of how fresh/old the incoming metrics are.
+		age := now.Sub(timestamp)
+		i.metrics.ingestLatency.RecordDuration(age)
+
+		// Also record write latency (not relative to metric timestamp).
+		i.metrics.writeLatency.RecordDuration(now.Sub(received))
+
+		// The contract is that after the DownsamplerAndWriter returns, any resources
+		// that it needed to hold onto have already been copied.
+		i.putLineResources(resources)
+		if wg != nil {
+			wg.Done()
+		}
+	})
 }
 
 func (i *ingester) write(
@@ -446,12 +530,43 @@ func (i *ingester) Close() {
 	// We don't maintain any state in-between connections so there is nothing to do here.
 }
 
+type pickleHandler struct {
+	ingester *ingester
+}
+
+func (h *pickleHandler) Handle(conn net.Conn) {
+	s := carbon.NewPickleScanner(conn, h.ingester.opts.InstrumentOptions)
+	h.ingester.handleScanner(s, &s.MalformedCount, h.ingester.metrics.pickle)
+}
+
+func (h *pickleHandler) Close() {
+	// The ingester does not maintain any state in-between connections.
+}
+
 type carbonIngesterMetrics struct {
 	success       tally.Counter
 	err           tally.Counter
 	malformed     tally.Counter
 	ingestLatency tally.Histogram
 	writeLatency  tally.Histogram
+	plaintext     carbonProtocolMetrics
+	pickle        carbonProtocolMetrics
+	udp           carbonProtocolMetrics
+}
+
+type carbonProtocolMetrics struct {
+	protocol  string
+	received  tally.Counter
+	malformed tally.Counter
+}
+
+func newCarbonProtocolMetrics(scope tally.Scope, protocol string) carbonProtocolMetrics {
+	scope = scope.Tagged(map[string]string{"protocol": protocol})
+	return carbonProtocolMetrics{
+		protocol:  protocol,
+		received:  scope.Counter("protocol-received"),
+		malformed: scope.Counter("protocol-malformed"),
+	}
 }
 
 func newCarbonIngesterMetrics(scope tally.Scope) (carbonIngesterMetrics, error) {
@@ -465,6 +580,9 @@ func newCarbonIngesterMetrics(scope tally.Scope) (carbonIngesterMetrics, error)
 		malformed:     scope.Counter("malformed"),
 		writeLatency:  scope.SubScope("write").Histogram("latency", buckets.WriteLatencyBuckets),
 		ingestLatency: scope.SubScope("ingest").Histogram("latency", buckets.IngestLatencyBuckets),
+		plaintext:     newCarbonProtocolMetrics(scope, plaintextProtocol),
+		pickle:        newCarbonProtocolMetrics(scope, pickleProtocol),
+		udp:           newCarbonProtocolMetrics(scope, udpProtocol),
 	}, nil
 }
 
@@ -478,6 +596,16 @@ func newCarbonIngesterMetrics(scope tally.Scope) (carbonIngesterMetrics, error)
 //	__g0__:foo
 //	__g1__:bar
 //	__g2__:baz
+//
+// Graphite tagged names keep their tags and their metric path is stored under
+// the "name" tag, such that an input like:
+//
+//	foo.bar;dc=east
+//
+// becomes
+//
+//	dc:east
+//	name:foo.bar
 func GenerateTagsFromName(
 	name []byte,
 	opts models.TagOptions,
@@ -504,6 +632,10 @@ func generateTagsFromName(
 		return models.EmptyTags(), errCannotGenerateTagsFromEmptyName
 	}
 
+	if idx := bytes.IndexByte(name, carbonTagSeparatorByte); idx >= 0 {
+		return generateTagsFromTaggedName(name, idx, opts, tags)
+	}
+
 	numTags := bytes.Count(name, carbonSeparatorBytes) + 1
 
 	if cap(tags) >= numTags {
@@ -549,6 +681,67 @@ func generateTagsFromName(
 	return models.Tags{Opts: opts, Tags: tags}, nil
 }
 
+func generateTagsFromTaggedName(
+	name []byte,
+	pathEnd int,
+	opts models.TagOptions,
+	tags []models.Tag,
+) (models.Tags, error) {
+	if pathEnd == 0 {
+		return models.EmptyTags(),
+			fmt.Errorf("carbon metric: %s has tags but no name", string(name))
+	}
+
+	numTags := bytes.Count(name, carbonTagSeparatorBytes) + 1
+	if cap(tags) >= numTags {
+		tags = tags[:0]
+	} else {
+		tags = make([]models.Tag, 0, numTags)
+	}
+
+	tags = append(tags, models.Tag{
+		Name:  carbonTaggedNameTag,
+		Value: name[:pathEnd],
+	})
+
+	remaining := name[pathEnd+1:]
+	for len(remaining) > 0 {
+		tag := remaining
+		if idx := bytes.IndexByte(remaining, carbonTagSeparatorByte); idx >= 0 {
+			tag = remaining[:idx]
+			remaining = remaining[idx+1:]
+		} else {
+			remaining = nil
+		}
+
+		idx := bytes.IndexByte(tag, carbonTagValueByte)
+		if idx <= 0 || idx == len(tag)-1 {
+			return models.EmptyTags(),
+				fmt.Errorf("carbon metric: %s has invalid tag: %s", string(name), string(tag))
+		}
+		if bytes.Equal(tag[:idx], carbonTaggedNameTag) {
+			return models.EmptyTags(),
+				fmt.Errorf("carbon metric: %s has reserved tag: %s", string(name), string(tag))
+		}
+
+		tags = append(tags, models.Tag{
+			Name:  tag[:idx],
+			Value: tag[idx+1:],
+		})
+	}
+
+	result := models.Tags{Opts: opts, Tags: tags}.Normalize()
+	for i := 1; i < len(result.Tags); i++ {
+		if bytes.Equal(result.Tags[i-1].Name, result.Tags[i].Name) {
+			return models.EmptyTags(),
+				fmt.Errorf("carbon metric: %s has duplicate tag: %s",
+					string(name), string(result.Tags[i].Name))
+		}
+	}
+
+	return result, nil
+}
+
 // Compile all the carbon ingestion rules into matcher so that we can
 // perform matching. Also, generate all the mapping rules and storage
 // policies that we will need to pass to the DownsamplerAndWriter upfront
@@ -23,9 +23,11 @@ package ingestcarbon
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/rand"
 	"net"
 	"reflect"
@@ -37,6 +39,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber-go/tally"
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
@@ -261,6 +264,140 @@ func TestIngesterHandleConn(t *testing.T) {
 	assertTestMetricsAreEqual(t, testMetrics, found)
 }
 
+func TestIngesterHandlePickleConn(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	ingester, scope, found := newTestProtocolIngester(t, ctrl)
+
+	var (
+		buf    bytes.Buffer
+		header [4]byte
+	)
+	for _, message := range [][]byte{
+		testPickleMessage(testMetrics[:len(testMetrics)/2]),
+		[]byte("\x80\x02K\x01."), // Not a list.
+		testPickleMessage(testMetrics[len(testMetrics)/2:]),
+	} {
+		binary.BigEndian.PutUint32(header[:], uint32(len(message)))
+		buf.Write(header[:])
+		buf.Write(message)
+	}
+
+	ingester.PickleHandler().Handle(&byteConn{b: &buf})
+
+	assertTestMetricsAreEqual(t, testMetrics, found())
+	assertProtocolCounters(t, scope, pickleProtocol, len(testMetrics), 1)
+}
+
+func TestIngesterHandlePacket(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	ingester, scope, found := newTestProtocolIngester(t, ctrl)
+
+	packet := []byte("foo.bar 1 1\ngarbage\nfoo.baz;dc=east 2 2\n")
+	ingester.HandlePacket(packet)
+	// The packet can be reused as soon as it has been handled.
+	copy(packet, "xxxxxxxxxxxxxxxxxxxxxxxxx")
+
+	require.Eventually(t, func() bool {
+		return len(found()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	assertTestMetricsAreEqual(t, []testMetric{
+		{tags: mustGenerateTagsFromName(t, []byte("foo.bar")), timestamp: 1, value: 1},
+		{tags: mustGenerateTagsFromName(t, []byte("foo.baz;dc=east")), timestamp: 2, value: 2},
+	}, found())
+	assertProtocolCounters(t, scope, udpProtocol, 2, 1)
+}
+
+func newTestProtocolIngester(
+	t *testing.T,
+	ctrl *gomock.Controller,
+) (Ingester, tally.TestScope, func() []testMetric) {
+	var (
+		lock  sync.Mutex
+		found []testMetric
+	)
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.EXPECT().
+		Write(gomock.Any(), gomock.Any(), gomock.Any(), xtime.Second, gomock.Any(), gomock.Any(), graphiteSource).
+		DoAndReturn(func(
+			_ context.Context,
+			tags models.Tags,
+			dp ts.Datapoints,
+			_ xtime.Unit,
+			_ []byte,
+			_ ingest.WriteOptions,
+			_ ts.SourceType,
+		) interface{} {
+			lock.Lock()
+			// Clone tags because they (and their underlying bytes) are pooled.
+			found = append(found, testMetric{
+				tags:      tags.Clone(),
+				timestamp: int(dp[0].Timestamp.Seconds()),
+				value:     dp[0].Value,
+			})
+			lock.Unlock()
+			return nil
+		}).AnyTimes()
+
+	session := client.NewMockSession(ctrl)
+	watcher := newTestWatcher(t, session, m3.AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("10s:48h"),
+		Resolution:  10 * time.Second,
+		Retention:   48 * time.Hour,
+		Session:     session,
+	})
+
+	scope := tally.NewTestScope("", nil)
+	opts := newTestOpts(testRulesMatchAll)
+	opts.InstrumentOptions = opts.InstrumentOptions.SetMetricsScope(scope)
+	ingester, err := NewIngester(mockDownsamplerAndWriter, watcher, opts)
+	require.NoError(t, err)
+
+	return ingester, scope, func() []testMetric {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]testMetric(nil), found...)
+	}
+}
+
+// testPickleMessage pickles the metrics with the protocol 2 the same way
+// python's pickle.dumps does for a list of (path, (timestamp, value)) tuples.
+func testPickleMessage(metrics []testMetric) []byte {
+	message := []byte{0x80, 0x02, ']', '('}
+	for _, m := range metrics {
+		message = append(message, 'X')
+		message = binary.LittleEndian.AppendUint32(message, uint32(len(m.metric)))
+		message = append(message, m.metric...)
+		message = append(message, 'J')
+		message = binary.LittleEndian.AppendUint32(message, uint32(m.timestamp))
+		message = append(message, 'G')
+		message = binary.BigEndian.AppendUint64(message, math.Float64bits(m.value))
+		message = append(message, 0x86, 0x86)
+	}
+	return append(message, 'e', '.')
+}
+
+func assertProtocolCounters(
+	t *testing.T,
+	scope tally.TestScope,
+	protocol string,
+	received int,
+	malformed int,
+) {
+	counters := scope.Snapshot().Counters()
+	for name, expected := range map[string]int{
+		"protocol-received":  received,
+		"protocol-malformed": malformed,
+	} {
+		counter, ok := counters[name+"+protocol="+protocol]
+		require.True(t, ok, name)
+		require.Equal(t, int64(expected), counter.Value(), name)
+	}
+}
+
 func TestIngesterHonorsMatchers(t *testing.T) {
 	tests := []struct {
 		name                 string
@@ -611,6 +748,35 @@ func TestGenerateTagsFromName(t *testing.T) {
 			expectedErr:  fmt.Errorf("carbon metric: foo.bar.baz.. has duplicate separator"),
 			expectedTags: []models.Tag{},
 		},
+		{
+			name: "disk.used;host=a;dc=east",
+			id:   "disk.used;dc=east;host=a",
+			expectedTags: []models.Tag{
+				{Name: []byte("dc"), Value: []byte("east")},
+				{Name: []byte("host"), Value: []byte("a")},
+				{Name: []byte("name"), Value: []byte("disk.used")},
+			},
+		},
+		{
+			name:         ";dc=east",
+			expectedErr:  fmt.Errorf("carbon metric: ;dc=east has tags but no name"),
+			expectedTags: []models.Tag{},
+		},
+		{
+			name:         "disk.used;dc=",
+			expectedErr:  fmt.Errorf("carbon metric: disk.used;dc= has invalid tag: dc="),
+			expectedTags: []models.Tag{},
+		},
+		{
+			name:         "disk.used;name=foo",
+			expectedErr:  fmt.Errorf("carbon metric: disk.used;name=foo has reserved tag: name=foo"),
+			expectedTags: []models.Tag{},
+		},
+		{
+			name:         "disk.used;dc=east;dc=west",
+			expectedErr:  fmt.Errorf("carbon metric: disk.used;dc=east;dc=west has duplicate tag: dc"),
+			expectedTags: []models.Tag{},
+		},
 	}
 
 	opts := models.NewTagOptions().SetIDSchemeType(models.TypeGraphite)
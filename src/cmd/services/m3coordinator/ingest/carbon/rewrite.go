@@ -21,6 +21,8 @@
 package ingestcarbon
 
 import (
+	"bytes"
+
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 )
 
@@ -34,6 +36,12 @@ func copyAndRewrite(
 		return append(dst[:0], src...)
 	}
 
+	// Graphite tags of tagged names are kept as is, only the path is rewritten.
+	var tags []byte
+	if idx := bytes.IndexByte(src, carbonTagSeparatorByte); idx >= 0 {
+		src, tags = src[:idx], src[idx:]
+	}
+
 	// Copy into dst as we rewrite.
 	dst = dst[:0]
 	leadingDots := true
@@ -84,5 +92,5 @@ func copyAndRewrite(
 		// Remove trailing dot.
 		dst = dst[:i]
 	}
-	return dst
+	return append(dst, tags...)
 }
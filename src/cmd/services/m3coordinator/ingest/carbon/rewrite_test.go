@@ -65,6 +65,14 @@ func TestCopyAndRewrite(t *testing.T) {
 				Cleanup: true,
 			},
 		},
+		{
+			name:     "tagged with rewrite cleanup",
+			input:    "foo$$.bar.;dc=east;host=a.b",
+			expected: "foo_.bar;dc=east;host=a.b",
+			cfg: &config.CarbonIngesterRewriteConfiguration{
+				Cleanup: true,
+			},
+		},
 		{
 			name:     "collapse two dots with rewrite cleanup",
 			input:    "foo..bar.baz",
@@ -0,0 +1,216 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scrape
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	defaultScrapeInterval        = time.Minute
+	defaultScrapeTimeout         = 10 * time.Second
+	defaultMetricsPath           = "/metrics"
+	defaultScheme                = "http"
+	defaultFileSDRefreshInterval = 5 * time.Minute
+	defaultBodySizeLimit         = 100 << 20
+)
+
+// Configuration is the configuration of the scrape manager, which scrapes
+// Prometheus targets and writes the samples as if they were remote written.
+type Configuration struct {
+	// ScrapeInterval is the default interval jobs scrape their targets at.
+	ScrapeInterval time.Duration `yaml:"scrapeInterval"`
+	// ScrapeTimeout is the default timeout of the scrapes of jobs.
+	ScrapeTimeout time.Duration `yaml:"scrapeTimeout"`
+	// Jobs are the scrape jobs.
+	Jobs []JobConfiguration `yaml:"jobs"`
+}
+
+// JobConfiguration is the configuration of a scrape job.
+type JobConfiguration struct {
+	// JobName is the name of the job, set as the job label of the samples.
+	JobName string `yaml:"jobName" validate:"nonzero"`
+	// ScrapeInterval is the interval the targets are scraped at.
+	ScrapeInterval time.Duration `yaml:"scrapeInterval"`
+	// ScrapeTimeout is the timeout of a scrape, which must not be greater
+	// than the scrape interval.
+	ScrapeTimeout time.Duration `yaml:"scrapeTimeout"`
+	// MetricsPath is the HTTP path metrics are scraped from.
+	MetricsPath string `yaml:"metricsPath"`
+	// Scheme is the scheme metrics are scraped with, http or https.
+	Scheme string `yaml:"scheme"`
+	// Params are the URL parameters of the scrape requests.
+	Params map[string][]string `yaml:"params"`
+	// HonorLabels keeps the labels of scraped samples conflicting with the
+	// target labels rather than renaming them with an exported_ prefix.
+	HonorLabels bool `yaml:"honorLabels"`
+	// HonorTimestamps uses the timestamps of scraped samples if present
+	// rather than the time of the scrape, defaults to true.
+	HonorTimestamps *bool `yaml:"honorTimestamps"`
+	// BodySizeLimit is the maximum uncompressed size in bytes of the body of
+	// a scrape, scrapes of larger bodies fail, defaults to 100MiB.
+	BodySizeLimit int64 `yaml:"bodySizeLimit"`
+	// SampleLimit is the maximum number of samples of a scrape, scrapes of
+	// more samples fail, no limit if zero.
+	SampleLimit int `yaml:"sampleLimit"`
+	// StaticConfigs are the statically configured targets.
+	StaticConfigs []StaticConfiguration `yaml:"staticConfigs"`
+	// FileSDConfigs are the file based service discovery configurations.
+	FileSDConfigs []FileSDConfiguration `yaml:"fileSDConfigs"`
+}
+
+// StaticConfiguration is a group of statically configured targets.
+type StaticConfiguration struct {
+	// Targets are the host:port addresses of the targets.
+	Targets []string `yaml:"targets"`
+	// Labels are set on the samples of all the targets of the group.
+	Labels map[string]string `yaml:"labels"`
+}
+
+// FileSDConfiguration is the configuration of a file based service
+// discovery, reading target groups in the Prometheus file_sd format.
+type FileSDConfiguration struct {
+	// Files are the patterns of the JSON or YAML files to read target
+	// groups from, the last path element may contain a glob.
+	Files []string `yaml:"files"`
+	// RefreshInterval is the interval the files are read again at.
+	RefreshInterval time.Duration `yaml:"refreshInterval"`
+}
+
+// MetricsPathOrDefault returns the metrics path or the default.
+func (c JobConfiguration) MetricsPathOrDefault() string {
+	if c.MetricsPath == "" {
+		return defaultMetricsPath
+	}
+	return c.MetricsPath
+}
+
+// SchemeOrDefault returns the scheme or the default.
+func (c JobConfiguration) SchemeOrDefault() string {
+	if c.Scheme == "" {
+		return defaultScheme
+	}
+	return c.Scheme
+}
+
+// HonorTimestampsOrDefault returns whether to honor timestamps or the
+// default.
+func (c JobConfiguration) HonorTimestampsOrDefault() bool {
+	if c.HonorTimestamps == nil {
+		return true
+	}
+	return *c.HonorTimestamps
+}
+
+// BodySizeLimitOrDefault returns the body size limit or the default.
+func (c JobConfiguration) BodySizeLimitOrDefault() int64 {
+	if c.BodySizeLimit == 0 {
+		return defaultBodySizeLimit
+	}
+	return c.BodySizeLimit
+}
+
+// RefreshIntervalOrDefault returns the refresh interval or the default.
+func (c FileSDConfiguration) RefreshIntervalOrDefault() time.Duration {
+	if c.RefreshInterval <= 0 {
+		return defaultFileSDRefreshInterval
+	}
+	return c.RefreshInterval
+}
+
+// Validate validates the job configuration.
+func (c JobConfiguration) Validate() error {
+	if c.JobName == "" {
+		return errors.New("scrape job has no name")
+	}
+	if c.ScrapeInterval <= 0 {
+		return fmt.Errorf("scrape job %s: scrape interval must be positive", c.JobName)
+	}
+	if c.ScrapeTimeout <= 0 || c.ScrapeTimeout > c.ScrapeInterval {
+		return fmt.Errorf("scrape job %s: scrape timeout must be positive "+
+			"and not greater than the scrape interval", c.JobName)
+	}
+	if scheme := c.SchemeOrDefault(); scheme != "http" && scheme != "https" {
+		return fmt.Errorf("scrape job %s: invalid scheme %s", c.JobName, scheme)
+	}
+	if c.BodySizeLimit < 0 {
+		return fmt.Errorf("scrape job %s: body size limit must not be negative", c.JobName)
+	}
+	if c.SampleLimit < 0 {
+		return fmt.Errorf("scrape job %s: sample limit must not be negative", c.JobName)
+	}
+	for _, sd := range c.FileSDConfigs {
+		for _, pattern := range sd.Files {
+			if _, err := filepath.Match(pattern, ""); err != nil {
+				return fmt.Errorf("scrape job %s: invalid file pattern %s: %w",
+					c.JobName, pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// NewManager returns a new scrape manager writing the scraped samples with
+// the given writer.
+func (c Configuration) NewManager(
+	writer ingest.DownsamplerAndWriter,
+	tagOptions models.TagOptions,
+	storeMetricsType bool,
+	iOpts instrument.Options,
+) (Manager, error) {
+	interval := c.ScrapeInterval
+	if interval <= 0 {
+		interval = defaultScrapeInterval
+	}
+	timeout := c.ScrapeTimeout
+	if timeout <= 0 {
+		timeout = defaultScrapeTimeout
+	}
+
+	jobs := make([]JobConfiguration, 0, len(c.Jobs))
+	for _, job := range c.Jobs {
+		if job.ScrapeInterval <= 0 {
+			job.ScrapeInterval = interval
+		}
+		if job.ScrapeTimeout <= 0 {
+			job.ScrapeTimeout = timeout
+			if timeout > job.ScrapeInterval {
+				job.ScrapeTimeout = job.ScrapeInterval
+			}
+		}
+		jobs = append(jobs, job)
+	}
+
+	return NewManager(Options{
+		Jobs:              jobs,
+		Writer:            writer,
+		TagOptions:        tagOptions,
+		StoreMetricsType:  storeMetricsType,
+		InstrumentOptions: iOpts,
+	})
+}
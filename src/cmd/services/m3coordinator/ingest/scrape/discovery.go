@@ -0,0 +1,160 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scrape
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v2"
+)
+
+// targetGroup is a group of targets sharing the same labels.
+type targetGroup struct {
+	// source identifies the group for debugging.
+	source  string
+	targets []string
+	labels  map[string]string
+}
+
+// fileTargetGroup is a target group of a service discovery file, in the
+// Prometheus file_sd format.
+type fileTargetGroup struct {
+	Targets []string          `yaml:"targets"`
+	Labels  map[string]string `yaml:"labels"`
+}
+
+func staticTargetGroups(jobName string, configs []StaticConfiguration) []targetGroup {
+	groups := make([]targetGroup, 0, len(configs))
+	for i, cfg := range configs {
+		groups = append(groups, targetGroup{
+			source:  fmt.Sprintf("%s/static/%d", jobName, i),
+			targets: cfg.Targets,
+			labels:  cfg.Labels,
+		})
+	}
+	return groups
+}
+
+// fileDiscoverer reads the target groups of the files matching the patterns
+// of a file based service discovery configuration.
+type fileDiscoverer struct {
+	sync.RWMutex
+
+	patterns []string
+	logger   *zap.Logger
+	// groups are the target groups by file, the groups of a file that can
+	// not be read are kept until the file is removed.
+	groups map[string][]targetGroup
+}
+
+func newFileDiscoverer(patterns []string, logger *zap.Logger) *fileDiscoverer {
+	return &fileDiscoverer{
+		patterns: patterns,
+		logger:   logger,
+		groups:   make(map[string][]targetGroup),
+	}
+}
+
+// refresh reads the target groups of the files again.
+func (d *fileDiscoverer) refresh() {
+	files := make(map[string]struct{})
+	for _, pattern := range d.patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			d.logger.Error("invalid service discovery file pattern",
+				zap.String("pattern", pattern), zap.Error(err))
+			continue
+		}
+		for _, match := range matches {
+			files[match] = struct{}{}
+		}
+	}
+
+	groups := make(map[string][]targetGroup, len(files))
+	for file := range files {
+		fileGroups, err := readTargetGroupsFile(file)
+		if err != nil {
+			d.logger.Error("could not read service discovery file",
+				zap.String("file", file), zap.Error(err))
+			d.RLock()
+			fileGroups = d.groups[file]
+			d.RUnlock()
+		}
+		groups[file] = fileGroups
+	}
+
+	d.Lock()
+	d.groups = groups
+	d.Unlock()
+}
+
+// targetGroups returns the target groups of all the files.
+func (d *fileDiscoverer) targetGroups() []targetGroup {
+	d.RLock()
+	defer d.RUnlock()
+
+	files := make([]string, 0, len(d.groups))
+	for file := range d.groups {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	var groups []targetGroup
+	for _, file := range files {
+		groups = append(groups, d.groups[file]...)
+	}
+	return groups
+}
+
+func readTargetGroupsFile(file string) ([]targetGroup, error) {
+	switch ext := strings.ToLower(filepath.Ext(file)); ext {
+	case ".json", ".yml", ".yaml":
+	default:
+		return nil, fmt.Errorf("unsupported file extension: %s", ext)
+	}
+
+	data, err := ioutil.ReadFile(file) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+
+	// NB: JSON files are also valid YAML.
+	var fileGroups []fileTargetGroup
+	if err := yaml.Unmarshal(data, &fileGroups); err != nil {
+		return nil, err
+	}
+
+	groups := make([]targetGroup, 0, len(fileGroups))
+	for i, group := range fileGroups {
+		groups = append(groups, targetGroup{
+			source:  fmt.Sprintf("%s:%d", file, i),
+			targets: group.Targets,
+			labels:  group.Labels,
+		})
+	}
+	return groups, nil
+}
@@ -0,0 +1,443 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package scrape scrapes Prometheus targets and writes the scraped samples
+// through the same downsampler and storage write path as remote writes.
+package scrape
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	jobLabel      = "job"
+	instanceLabel = "instance"
+
+	addressLabel     = "__address__"
+	schemeLabel      = "__scheme__"
+	metricsPathLabel = "__metrics_path__"
+)
+
+var (
+	errNoWriter          = errors.New("scrape manager has no writer")
+	errNoTagOptions      = errors.New("scrape manager has no tag options")
+	errNoInstrumentOpts  = errors.New("scrape manager has no instrument options")
+	errManagerNotStarted = errors.New("scrape manager was not started")
+)
+
+// TargetHealth is the health of a scrape target.
+type TargetHealth string
+
+const (
+	// HealthUnknown is the health of targets not scraped yet.
+	HealthUnknown TargetHealth = "unknown"
+	// HealthGood is the health of targets whose last scrape succeeded.
+	HealthGood TargetHealth = "up"
+	// HealthBad is the health of targets whose last scrape failed.
+	HealthBad TargetHealth = "down"
+)
+
+// TargetStatus is the status of a scrape target.
+type TargetStatus struct {
+	// DiscoveredLabels are the labels of the target before the target
+	// labels were resolved.
+	DiscoveredLabels labels.Labels
+	// Labels are the labels set on the samples of the target.
+	Labels labels.Labels
+	// ScrapePool is the name of the job of the target.
+	ScrapePool string
+	// ScrapeURL is the URL the target is scraped from.
+	ScrapeURL string
+	// LastError is the error of the last scrape, empty if it succeeded.
+	LastError string
+	// LastScrape is the time of the last scrape.
+	LastScrape time.Time
+	// LastScrapeDuration is the duration of the last scrape.
+	LastScrapeDuration time.Duration
+	// Health is the health of the target.
+	Health TargetHealth
+	// ScrapeInterval is the interval the target is scraped at.
+	ScrapeInterval time.Duration
+	// ScrapeTimeout is the timeout of the scrapes of the target.
+	ScrapeTimeout time.Duration
+}
+
+// Manager scrapes the targets of the scrape jobs.
+type Manager interface {
+	// Start starts discovering and scraping the targets.
+	Start() error
+
+	// Targets returns the status of the scraped targets.
+	Targets() []TargetStatus
+
+	// Close stops scraping the targets.
+	Close() error
+}
+
+// Options are the scrape manager options.
+type Options struct {
+	// Jobs are the scrape jobs, with their intervals and timeouts set.
+	Jobs []JobConfiguration
+	// Writer writes the scraped samples.
+	Writer ingest.DownsamplerAndWriter
+	// TagOptions are the options of the tags of the written series.
+	TagOptions models.TagOptions
+	// StoreMetricsType stores the metric type of the samples.
+	StoreMetricsType bool
+	// HTTPClient is the client targets are scraped with, a new client is
+	// used if not set.
+	HTTPClient *http.Client
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+// Validate validates the scrape manager options.
+func (o Options) Validate() error {
+	if o.Writer == nil {
+		return errNoWriter
+	}
+	if o.TagOptions == nil {
+		return errNoTagOptions
+	}
+	if o.InstrumentOptions == nil {
+		return errNoInstrumentOpts
+	}
+
+	names := make(map[string]struct{}, len(o.Jobs))
+	for _, job := range o.Jobs {
+		if err := job.Validate(); err != nil {
+			return err
+		}
+		if _, ok := names[job.JobName]; ok {
+			return fmt.Errorf("duplicate scrape job name: %s", job.JobName)
+		}
+		names[job.JobName] = struct{}{}
+	}
+	return nil
+}
+
+type manager struct {
+	sync.Mutex
+
+	pools   []*scrapePool
+	logger  *zap.Logger
+	started bool
+	closed  bool
+	closeCh chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewManager returns a new scrape manager.
+func NewManager(opts Options) (Manager, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	client := opts.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	var (
+		scope   = opts.InstrumentOptions.MetricsScope().SubScope("scrape")
+		logger  = opts.InstrumentOptions.Logger()
+		metrics = newScrapeMetrics(scope)
+		pools   = make([]*scrapePool, 0, len(opts.Jobs))
+	)
+	for _, job := range opts.Jobs {
+		pools = append(pools, newScrapePool(job, client, opts, metrics, logger))
+	}
+
+	return &manager{
+		pools:   pools,
+		logger:  logger,
+		closeCh: make(chan struct{}),
+	}, nil
+}
+
+func (m *manager) Start() error {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.started {
+		return errors.New("scrape manager already started")
+	}
+	m.started = true
+
+	for _, pool := range m.pools {
+		for _, discoverer := range pool.discoverers {
+			discoverer.refresh()
+		}
+		pool.sync()
+
+		for i, discoverer := range pool.discoverers {
+			interval := pool.job.FileSDConfigs[i].RefreshIntervalOrDefault()
+			m.wg.Add(1)
+			go m.refreshEvery(pool, discoverer, interval)
+		}
+	}
+
+	m.logger.Info("started scrape manager", zap.Int("jobs", len(m.pools)))
+	return nil
+}
+
+func (m *manager) refreshEvery(
+	pool *scrapePool,
+	discoverer *fileDiscoverer,
+	interval time.Duration,
+) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.closeCh:
+			return
+		case <-ticker.C:
+		}
+
+		discoverer.refresh()
+		pool.sync()
+	}
+}
+
+func (m *manager) Targets() []TargetStatus {
+	var targets []TargetStatus
+	for _, pool := range m.pools {
+		targets = append(targets, pool.targets()...)
+	}
+	return targets
+}
+
+func (m *manager) Close() error {
+	m.Lock()
+	defer m.Unlock()
+
+	if !m.started {
+		return errManagerNotStarted
+	}
+	if m.closed {
+		return nil
+	}
+	m.closed = true
+
+	close(m.closeCh)
+	m.wg.Wait()
+	for _, pool := range m.pools {
+		pool.stop()
+	}
+	return nil
+}
+
+type scrapeMetrics struct {
+	success          tally.Counter
+	err              tally.Counter
+	samples          tally.Counter
+	writeErr         tally.Counter
+	bodySizeLimitErr tally.Counter
+	sampleLimitErr   tally.Counter
+	scrapeLatency    tally.Timer
+}
+
+func newScrapeMetrics(scope tally.Scope) scrapeMetrics {
+	return scrapeMetrics{
+		success:          scope.Counter("success"),
+		err:              scope.Counter("error"),
+		samples:          scope.Counter("samples"),
+		writeErr:         scope.Counter("write-error"),
+		bodySizeLimitErr: scope.Counter("body-size-limit-exceeded"),
+		sampleLimitErr:   scope.Counter("sample-limit-exceeded"),
+		scrapeLatency:    scope.Timer("latency"),
+	}
+}
+
+// target is a scrape target.
+type target struct {
+	discoveredLabels labels.Labels
+	labels           labels.Labels
+	url              string
+	hash             uint64
+}
+
+// scrapePool scrapes the targets of a job.
+type scrapePool struct {
+	sync.Mutex
+
+	job         JobConfiguration
+	static      []targetGroup
+	discoverers []*fileDiscoverer
+	client      *http.Client
+	opts        Options
+	metrics     scrapeMetrics
+	logger      *zap.Logger
+	loops       map[uint64]*scrapeLoop
+}
+
+func newScrapePool(
+	job JobConfiguration,
+	client *http.Client,
+	opts Options,
+	metrics scrapeMetrics,
+	logger *zap.Logger,
+) *scrapePool {
+	discoverers := make([]*fileDiscoverer, 0, len(job.FileSDConfigs))
+	for _, sd := range job.FileSDConfigs {
+		discoverers = append(discoverers, newFileDiscoverer(sd.Files, logger))
+	}
+
+	return &scrapePool{
+		job:         job,
+		static:      staticTargetGroups(job.JobName, job.StaticConfigs),
+		discoverers: discoverers,
+		client:      client,
+		opts:        opts,
+		metrics:     metrics,
+		logger:      logger.With(zap.String("job", job.JobName)),
+		loops:       make(map[uint64]*scrapeLoop),
+	}
+}
+
+// sync starts scraping the targets discovered since the last sync and
+// stops scraping the targets not discovered anymore.
+func (p *scrapePool) sync() {
+	groups := append([]targetGroup(nil), p.static...)
+	for _, discoverer := range p.discoverers {
+		groups = append(groups, discoverer.targetGroups()...)
+	}
+
+	targets := make(map[uint64]*target)
+	for _, group := range groups {
+		for _, address := range group.targets {
+			t, err := p.newTarget(address, group.labels)
+			if err != nil {
+				p.logger.Error("invalid scrape target",
+					zap.String("source", group.source),
+					zap.String("target", address),
+					zap.Error(err))
+				continue
+			}
+			targets[t.hash] = t
+		}
+	}
+
+	p.Lock()
+	defer p.Unlock()
+
+	for hash, loop := range p.loops {
+		if _, ok := targets[hash]; !ok {
+			loop.stop()
+			delete(p.loops, hash)
+		}
+	}
+	for hash, t := range targets {
+		if _, ok := p.loops[hash]; ok {
+			continue
+		}
+		loop := newScrapeLoop(p, t)
+		p.loops[hash] = loop
+		go loop.run()
+	}
+}
+
+func (p *scrapePool) newTarget(address string, groupLabels map[string]string) (*target, error) {
+	if address == "" || strings.Contains(address, "/") {
+		return nil, fmt.Errorf("invalid target address: %q", address)
+	}
+
+	scrapeURL := url.URL{
+		Scheme:   p.job.SchemeOrDefault(),
+		Host:     address,
+		Path:     p.job.MetricsPathOrDefault(),
+		RawQuery: url.Values(p.job.Params).Encode(),
+	}
+
+	discovered := labels.NewBuilder(nil).
+		Set(addressLabel, address).
+		Set(schemeLabel, scrapeURL.Scheme).
+		Set(metricsPathLabel, scrapeURL.Path).
+		Set(jobLabel, p.job.JobName)
+	lb := labels.NewBuilder(nil).
+		Set(instanceLabel, address)
+	for name, value := range groupLabels {
+		discovered.Set(name, value)
+		if strings.HasPrefix(name, "__") {
+			continue
+		}
+		lb.Set(name, value)
+	}
+	lb.Set(jobLabel, p.job.JobName)
+
+	t := &target{
+		discoveredLabels: discovered.Labels(),
+		labels:           lb.Labels(),
+		url:              scrapeURL.String(),
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(t.url))
+	_, _ = h.Write([]byte(t.labels.String()))
+	t.hash = h.Sum64()
+	return t, nil
+}
+
+func (p *scrapePool) targets() []TargetStatus {
+	p.Lock()
+	statuses := make([]TargetStatus, 0, len(p.loops))
+	for _, loop := range p.loops {
+		statuses = append(statuses, loop.status())
+	}
+	p.Unlock()
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].ScrapeURL != statuses[j].ScrapeURL {
+			return statuses[i].ScrapeURL < statuses[j].ScrapeURL
+		}
+		return labels.Compare(statuses[i].Labels, statuses[j].Labels) < 0
+	})
+	return statuses
+}
+
+func (p *scrapePool) stop() {
+	p.Lock()
+	defer p.Unlock()
+
+	for hash, loop := range p.loops {
+		loop.stop()
+		delete(p.loops, hash)
+	}
+}
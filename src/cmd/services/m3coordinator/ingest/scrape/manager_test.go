@@ -0,0 +1,187 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scrape
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+func TestNewManagerValidation(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	writer := ingest.NewMockDownsamplerAndWriter(ctrl)
+	newManager := func(jobs ...JobConfiguration) error {
+		_, err := NewManager(Options{
+			Jobs:              jobs,
+			Writer:            writer,
+			TagOptions:        models.NewTagOptions(),
+			InstrumentOptions: instrument.NewOptions(),
+		})
+		return err
+	}
+
+	valid := JobConfiguration{
+		JobName:        "test",
+		ScrapeInterval: time.Minute,
+		ScrapeTimeout:  10 * time.Second,
+	}
+	require.NoError(t, newManager(valid))
+	require.Error(t, newManager(valid, valid))
+
+	invalid := valid
+	invalid.ScrapeTimeout = 2 * time.Minute
+	require.Error(t, newManager(invalid))
+
+	invalid = valid
+	invalid.Scheme = "ftp"
+	require.Error(t, newManager(invalid))
+
+	invalid = valid
+	invalid.JobName = ""
+	require.Error(t, newManager(invalid))
+
+	invalid = valid
+	invalid.BodySizeLimit = -1
+	require.Error(t, newManager(invalid))
+
+	invalid = valid
+	invalid.SampleLimit = -1
+	require.Error(t, newManager(invalid))
+
+	_, err := NewManager(Options{Jobs: []JobConfiguration{valid}})
+	require.Error(t, err)
+}
+
+func TestConfigurationNewManagerDefaults(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := Configuration{
+		ScrapeTimeout: 30 * time.Second,
+		Jobs: []JobConfiguration{
+			{JobName: "default"},
+			{JobName: "fast", ScrapeInterval: 15 * time.Second},
+		},
+	}
+	m, err := cfg.NewManager(ingest.NewMockDownsamplerAndWriter(ctrl),
+		models.NewTagOptions(), false, instrument.NewOptions())
+	require.NoError(t, err)
+
+	pools := m.(*manager).pools
+	require.Equal(t, 2, len(pools))
+	assert.Equal(t, time.Minute, pools[0].job.ScrapeInterval)
+	assert.Equal(t, 30*time.Second, pools[0].job.ScrapeTimeout)
+	assert.Equal(t, 15*time.Second, pools[1].job.ScrapeInterval)
+	assert.Equal(t, 15*time.Second, pools[1].job.ScrapeTimeout)
+}
+
+func TestManagerTargets(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "scrape")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	file := filepath.Join(dir, "targets.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`[
+		{"targets": ["host1:9100", "host2:9100"], "labels": {"env": "prod", "__meta": "x"}}
+	]`), 0600))
+
+	writer := ingest.NewMockDownsamplerAndWriter(ctrl)
+	writer.EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).
+		AnyTimes()
+
+	m, err := NewManager(Options{
+		Jobs: []JobConfiguration{
+			{
+				JobName:        "node",
+				ScrapeInterval: time.Hour,
+				ScrapeTimeout:  time.Second,
+				StaticConfigs: []StaticConfiguration{
+					{Targets: []string{"static:9100"}},
+				},
+				FileSDConfigs: []FileSDConfiguration{
+					{Files: []string{filepath.Join(dir, "*.json")}},
+				},
+			},
+		},
+		Writer:            writer,
+		TagOptions:        models.NewTagOptions(),
+		InstrumentOptions: instrument.NewOptions(),
+	})
+	require.NoError(t, err)
+	require.Error(t, m.Close())
+	require.NoError(t, m.Start())
+	defer func() { require.NoError(t, m.Close()) }()
+
+	targets := m.Targets()
+	require.Equal(t, 3, len(targets))
+	assert.Equal(t, "http://host1:9100/metrics", targets[0].ScrapeURL)
+	assert.Equal(t, "http://host2:9100/metrics", targets[1].ScrapeURL)
+	assert.Equal(t, "http://static:9100/metrics", targets[2].ScrapeURL)
+	assert.Equal(t, "node", targets[0].ScrapePool)
+	assert.Equal(t, HealthUnknown, targets[0].Health)
+	assert.Equal(t, time.Hour, targets[0].ScrapeInterval)
+	assert.Equal(t, `{env="prod", instance="host1:9100", job="node"}`,
+		targets[0].Labels.String())
+	assert.Equal(t, "x", targets[0].DiscoveredLabels.Get("__meta"))
+	assert.Equal(t, "host1:9100", targets[0].DiscoveredLabels.Get("__address__"))
+
+	// Removed targets stop being scraped and invalid files keep their
+	// previously discovered targets.
+	require.NoError(t, ioutil.WriteFile(file, []byte(`[{"targets": ["host1:9100"]}]`), 0600))
+	pool := m.(*manager).pools[0]
+	pool.discoverers[0].refresh()
+	pool.sync()
+
+	targets = m.Targets()
+	require.Equal(t, 2, len(targets))
+	assert.Equal(t, `{instance="host1:9100", job="node"}`, targets[0].Labels.String())
+
+	require.NoError(t, ioutil.WriteFile(file, []byte(`not yaml: [`), 0600))
+	pool.discoverers[0].refresh()
+	pool.sync()
+	assert.Equal(t, 2, len(m.Targets()))
+
+	require.NoError(t, os.Remove(file))
+	pool.discoverers[0].refresh()
+	pool.sync()
+	targets = m.Targets()
+	require.Equal(t, 1, len(targets))
+	assert.Equal(t, "http://static:9100/metrics", targets[0].ScrapeURL)
+}
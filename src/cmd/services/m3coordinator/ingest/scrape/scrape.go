@@ -0,0 +1,526 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scrape
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/textparse"
+	"github.com/prometheus/prometheus/model/value"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// NB: the accept header Prometheus scrapes with.
+	acceptHeader = "application/openmetrics-text;version=1.0.0," +
+		"application/openmetrics-text;version=0.0.1;q=0.75," +
+		"text/plain;version=0.0.4;q=0.5,*/*;q=0.1"
+	userAgent = "m3coordinator"
+
+	openMetricsMediaType = "application/openmetrics-text"
+	exportedLabelPrefix  = "exported_"
+
+	upMetricName             = "up"
+	scrapeDurationMetricName = "scrape_duration_seconds"
+	scrapeSamplesMetricName  = "scrape_samples_scraped"
+)
+
+var (
+	errBodySizeLimit = errors.New("body size limit exceeded")
+	errSampleLimit   = errors.New("sample limit exceeded")
+
+	metricTypes = map[textparse.MetricType]prompb.MetricType{
+		textparse.MetricTypeCounter:        prompb.MetricType_COUNTER,
+		textparse.MetricTypeGauge:          prompb.MetricType_GAUGE,
+		textparse.MetricTypeHistogram:      prompb.MetricType_HISTOGRAM,
+		textparse.MetricTypeGaugeHistogram: prompb.MetricType_GAUGE_HISTOGRAM,
+		textparse.MetricTypeSummary:        prompb.MetricType_SUMMARY,
+		textparse.MetricTypeInfo:           prompb.MetricType_INFO,
+		textparse.MetricTypeStateset:       prompb.MetricType_STATESET,
+		textparse.MetricTypeUnknown:        prompb.MetricType_UNKNOWN,
+	}
+
+	// familySuffixes are the suffixes of the samples of a metric family,
+	// which is named without them in the type metadata.
+	familySuffixes = []string{
+		"_total", "_created", "_bucket", "_sum", "_count", "_gcount", "_gsum", "_info",
+	}
+)
+
+// scrapeLoop scrapes a target at the interval of its job.
+type scrapeLoop struct {
+	pool   *scrapePool
+	target *target
+	nowFn  func() time.Time
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	doneCh chan struct{}
+
+	sync.RWMutex
+	lastError          error
+	lastScrape         time.Time
+	lastScrapeDuration time.Duration
+	health             TargetHealth
+}
+
+func newScrapeLoop(pool *scrapePool, t *target) *scrapeLoop {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &scrapeLoop{
+		pool:   pool,
+		target: t,
+		nowFn:  time.Now,
+		ctx:    ctx,
+		cancel: cancel,
+		doneCh: make(chan struct{}),
+		health: HealthUnknown,
+	}
+}
+
+func (l *scrapeLoop) run() {
+	defer close(l.doneCh)
+
+	// NB: spread the scrapes of the targets over the interval.
+	interval := l.pool.job.ScrapeInterval
+	offset := time.Duration(l.target.hash % uint64(interval))
+	select {
+	case <-l.ctx.Done():
+		return
+	case <-time.After(offset):
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		l.scrapeAndWrite()
+
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *scrapeLoop) stop() {
+	l.cancel()
+	<-l.doneCh
+}
+
+func (l *scrapeLoop) status() TargetStatus {
+	l.RLock()
+	defer l.RUnlock()
+
+	var lastError string
+	if l.lastError != nil {
+		lastError = l.lastError.Error()
+	}
+	return TargetStatus{
+		DiscoveredLabels:   l.target.discoveredLabels,
+		Labels:             l.target.labels,
+		ScrapePool:         l.pool.job.JobName,
+		ScrapeURL:          l.target.url,
+		LastError:          lastError,
+		LastScrape:         l.lastScrape,
+		LastScrapeDuration: l.lastScrapeDuration,
+		Health:             l.health,
+		ScrapeInterval:     l.pool.job.ScrapeInterval,
+		ScrapeTimeout:      l.pool.job.ScrapeTimeout,
+	}
+}
+
+// scrapeAndWrite scrapes the target and writes the scraped samples along
+// with the up, scrape_duration_seconds and scrape_samples_scraped series
+// reporting the scrape, all the samples being dropped if the scrape fails,
+// including when it exceeds the body size or the sample limit of the job.
+func (l *scrapeLoop) scrapeAndWrite() {
+	var (
+		start  = l.nowFn()
+		series []prompb.TimeSeries
+	)
+	body, contentType, err := l.scrape()
+	if err == nil {
+		series, err = l.parse(body, contentType, start)
+	}
+	duration := l.nowFn().Sub(start)
+	if err != nil {
+		series = nil
+	}
+
+	samples := len(series)
+	series = append(series, l.reportSeries(start, duration, samples, err == nil)...)
+	if writeErr := l.write(series); writeErr != nil {
+		l.pool.metrics.writeErr.Inc(1)
+		l.pool.logger.Error("could not write scraped samples",
+			zap.String("target", l.target.url), zap.Error(writeErr))
+		if err == nil {
+			err = writeErr
+		}
+	}
+
+	l.pool.metrics.scrapeLatency.Record(duration)
+	if err != nil {
+		l.pool.metrics.err.Inc(1)
+	} else {
+		l.pool.metrics.success.Inc(1)
+		l.pool.metrics.samples.Inc(int64(samples))
+	}
+
+	l.Lock()
+	l.lastError = err
+	l.lastScrape = start
+	l.lastScrapeDuration = duration
+	l.health = HealthGood
+	if err != nil {
+		l.health = HealthBad
+	}
+	l.Unlock()
+}
+
+func (l *scrapeLoop) scrape() ([]byte, string, error) {
+	timeout := l.pool.job.ScrapeTimeout
+	ctx, cancel := context.WithTimeout(l.ctx, timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.target.url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("Accept", acceptHeader)
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("X-Prometheus-Scrape-Timeout-Seconds",
+		strconv.FormatFloat(timeout.Seconds(), 'f', -1, 64))
+
+	resp, err := l.pool.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("server returned HTTP status %s", resp.Status)
+	}
+
+	// NB: read one byte more than the limit to tell a body of the size of the
+	// limit from a larger one.
+	limit := l.pool.job.BodySizeLimitOrDefault()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(body)) > limit {
+		l.pool.metrics.bodySizeLimitErr.Inc(1)
+		return nil, "", fmt.Errorf("%w: %d bytes", errBodySizeLimit, limit)
+	}
+	return body, resp.Header.Get("Content-Type"), nil
+}
+
+// parse parses the scraped samples in the Prometheus text or OpenMetrics
+// format, timestamping them with the time of the scrape unless the samples
+// have timestamps and the job honors them.
+func (l *scrapeLoop) parse(
+	body []byte,
+	contentType string,
+	scrapeTime time.Time,
+) ([]prompb.TimeSeries, error) {
+	source := prompb.Source_PROMETHEUS
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil &&
+		mediaType == openMetricsMediaType {
+		source = prompb.Source_OPEN_METRICS
+	}
+
+	var (
+		parser          = textparse.New(body, contentType)
+		honorTimestamps = l.pool.job.HonorTimestampsOrDefault()
+		defaultTime     = storage.TimeToPromTimestamp(xtime.ToUnixNano(scrapeTime))
+		types           = make(map[string]prompb.MetricType)
+		series          []prompb.TimeSeries
+	)
+	for {
+		entry, err := parser.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch entry {
+		case textparse.EntryType:
+			name, typ := parser.Type()
+			types[string(name)] = metricTypes[typ]
+
+		case textparse.EntrySeries:
+			_, timestamp, v := parser.Series()
+			if value.IsStaleNaN(v) {
+				continue
+			}
+
+			t := defaultTime
+			if timestamp != nil && honorTimestamps {
+				t = *timestamp
+			}
+
+			var lset labels.Labels
+			parser.Metric(&lset)
+			lset = l.sampleLabels(lset)
+
+			if limit := l.pool.job.SampleLimit; limit > 0 && len(series) >= limit {
+				l.pool.metrics.sampleLimitErr.Inc(1)
+				return nil, fmt.Errorf("%w: %d samples", errSampleLimit, limit)
+			}
+			series = append(series, prompb.TimeSeries{
+				Labels:  labelsToPromLabels(lset),
+				Samples: []prompb.Sample{{Value: v, Timestamp: t}},
+				Type:    familyType(types, lset.Get(labels.MetricName)),
+				Source:  source,
+			})
+		}
+	}
+
+	return series, nil
+}
+
+// sampleLabels sets the target labels on the labels of a scraped sample,
+// renaming the conflicting scraped labels with an exported_ prefix unless
+// the job honors the scraped labels.
+func (l *scrapeLoop) sampleLabels(lset labels.Labels) labels.Labels {
+	lb := labels.NewBuilder(lset)
+	for _, targetLabel := range l.target.labels {
+		existing := lset.Get(targetLabel.Name)
+		if l.pool.job.HonorLabels {
+			if existing == "" {
+				lb.Set(targetLabel.Name, targetLabel.Value)
+			}
+			continue
+		}
+
+		if existing != "" {
+			lb.Set(exportedLabelPrefix+targetLabel.Name, existing)
+		}
+		lb.Set(targetLabel.Name, targetLabel.Value)
+	}
+	return lb.Labels()
+}
+
+func (l *scrapeLoop) reportSeries(
+	scrapeTime time.Time,
+	duration time.Duration,
+	samples int,
+	up bool,
+) []prompb.TimeSeries {
+	upValue := 0.0
+	if up {
+		upValue = 1
+	}
+
+	t := storage.TimeToPromTimestamp(xtime.ToUnixNano(scrapeTime))
+	report := func(name string, v float64) prompb.TimeSeries {
+		lset := labels.NewBuilder(l.target.labels).
+			Set(labels.MetricName, name).
+			Labels()
+		return prompb.TimeSeries{
+			Labels:  labelsToPromLabels(lset),
+			Samples: []prompb.Sample{{Value: v, Timestamp: t}},
+			Type:    prompb.MetricType_GAUGE,
+			Source:  prompb.Source_PROMETHEUS,
+		}
+	}
+
+	return []prompb.TimeSeries{
+		report(upMetricName, upValue),
+		report(scrapeDurationMetricName, duration.Seconds()),
+		report(scrapeSamplesMetricName, float64(samples)),
+	}
+}
+
+func (l *scrapeLoop) write(series []prompb.TimeSeries) error {
+	iter, err := newSeriesIter(series, l.pool.opts.TagOptions,
+		l.pool.opts.StoreMetricsType)
+	if err != nil {
+		return err
+	}
+
+	batchErr := l.pool.opts.Writer.WriteBatch(l.ctx, iter, ingest.WriteOptions{})
+	if batchErr != nil {
+		return batchErr
+	}
+	return nil
+}
+
+// familyType returns the type of the metric family of the sample name.
+func familyType(types map[string]prompb.MetricType, name string) prompb.MetricType {
+	if typ, ok := types[name]; ok {
+		return typ
+	}
+	for _, suffix := range familySuffixes {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		if typ, ok := types[strings.TrimSuffix(name, suffix)]; ok {
+			return typ
+		}
+	}
+	return prompb.MetricType_UNKNOWN
+}
+
+func labelsToPromLabels(lset labels.Labels) []prompb.Label {
+	result := make([]prompb.Label, 0, len(lset))
+	for _, l := range lset {
+		result = append(result, prompb.Label{
+			Name:  []byte(l.Name),
+			Value: []byte(l.Value),
+		})
+	}
+	return result
+}
+
+// seriesIter iterates over scraped series to write them in a batch.
+type seriesIter struct {
+	idx        int
+	err        error
+	attributes []ts.SeriesAttributes
+	tags       []models.Tags
+	datapoints []ts.Datapoints
+	metadatas  []ts.Metadata
+	annotation []byte
+
+	storeMetricsType bool
+}
+
+var _ ingest.DownsampleAndWriteIter = (*seriesIter)(nil)
+
+func newSeriesIter(
+	series []prompb.TimeSeries,
+	tagOpts models.TagOptions,
+	storeMetricsType bool,
+) (*seriesIter, error) {
+	iter := &seriesIter{
+		idx:              -1,
+		attributes:       make([]ts.SeriesAttributes, 0, len(series)),
+		tags:             make([]models.Tags, 0, len(series)),
+		datapoints:       make([]ts.Datapoints, 0, len(series)),
+		storeMetricsType: storeMetricsType,
+	}
+	for _, s := range series {
+		attributes, err := storage.PromTimeSeriesToSeriesAttributes(s)
+		if err != nil {
+			return nil, err
+		}
+
+		iter.attributes = append(iter.attributes, attributes)
+		iter.tags = append(iter.tags, storage.PromLabelsToM3Tags(s.Labels, tagOpts))
+		iter.datapoints = append(iter.datapoints, storage.PromSamplesToM3Datapoints(s.Samples))
+	}
+	return iter, nil
+}
+
+func (i *seriesIter) Next() bool {
+	if i.err != nil {
+		return false
+	}
+
+	i.idx++
+	if i.idx >= len(i.tags) {
+		return false
+	}
+
+	if !i.storeMetricsType {
+		return true
+	}
+
+	payload, err := storage.SeriesAttributesToAnnotationPayload(i.attributes[i.idx])
+	if err != nil {
+		i.err = err
+		return false
+	}
+
+	i.annotation, err = payload.Marshal()
+	if err != nil {
+		i.err = err
+		return false
+	}
+	if len(i.annotation) == 0 {
+		i.annotation = nil
+	}
+	return true
+}
+
+func (i *seriesIter) Current() ingest.IterValue {
+	if i.idx < 0 || i.idx >= len(i.tags) {
+		return ingest.IterValue{}
+	}
+
+	value := ingest.IterValue{
+		Tags:       i.tags[i.idx],
+		Datapoints: i.datapoints[i.idx],
+		Attributes: i.attributes[i.idx],
+		Unit:       xtime.Millisecond,
+		Annotation: i.annotation,
+	}
+	if i.idx < len(i.metadatas) {
+		value.Metadata = i.metadatas[i.idx]
+	}
+	return value
+}
+
+func (i *seriesIter) Reset() error {
+	i.idx = -1
+	i.err = nil
+	i.annotation = nil
+	return nil
+}
+
+func (i *seriesIter) Error() error {
+	return i.err
+}
+
+func (i *seriesIter) SetCurrentMetadata(metadata ts.Metadata) {
+	if len(i.metadatas) == 0 {
+		i.metadatas = make([]ts.Metadata, len(i.tags))
+	}
+	if i.idx < 0 || i.idx >= len(i.metadatas) {
+		return
+	}
+	i.metadatas[i.idx] = metadata
+}
@@ -0,0 +1,356 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scrape
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/x/instrument"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+const testMetrics = `# HELP http_requests_total The number of requests.
+# TYPE http_requests_total counter
+http_requests_total{code="200",job="app"} 10
+http_requests_total{code="500"} 2 1600000000000
+# TYPE temperature gauge
+temperature 21.5
+`
+
+type testSample struct {
+	tags       map[string]string
+	value      float64
+	timestamp  time.Time
+	attributes ts.SeriesAttributes
+}
+
+func expectWrites(
+	writer *ingest.MockDownsamplerAndWriter,
+	written *[][]testSample,
+) *gomock.Call {
+	return writer.EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), ingest.WriteOptions{}).
+		DoAndReturn(func(
+			_ context.Context,
+			iter ingest.DownsampleAndWriteIter,
+			_ ingest.WriteOptions,
+		) ingest.BatchError {
+			var samples []testSample
+			for iter.Next() {
+				value := iter.Current()
+				tags := make(map[string]string, value.Tags.Len())
+				for _, tag := range value.Tags.Tags {
+					tags[string(tag.Name)] = string(tag.Value)
+				}
+				for _, dp := range value.Datapoints {
+					samples = append(samples, testSample{
+						tags:       tags,
+						value:      dp.Value,
+						timestamp:  dp.Timestamp.ToTime(),
+						attributes: value.Attributes,
+					})
+				}
+			}
+			*written = append(*written, samples)
+			return nil
+		})
+}
+
+func newTestScrapePool(
+	t *testing.T,
+	writer ingest.DownsamplerAndWriter,
+	job JobConfiguration,
+) *scrapePool {
+	if job.ScrapeInterval == 0 {
+		job.ScrapeInterval = time.Minute
+	}
+	if job.ScrapeTimeout == 0 {
+		job.ScrapeTimeout = 10 * time.Second
+	}
+
+	m, err := NewManager(Options{
+		Jobs:              []JobConfiguration{job},
+		Writer:            writer,
+		TagOptions:        models.NewTagOptions(),
+		InstrumentOptions: instrument.NewOptions(),
+	})
+	require.NoError(t, err)
+	return m.(*manager).pools[0]
+}
+
+func newTestScrapeLoop(
+	t *testing.T,
+	pool *scrapePool,
+	address string,
+	groupLabels map[string]string,
+	now time.Time,
+) *scrapeLoop {
+	target, err := pool.newTarget(address, groupLabels)
+	require.NoError(t, err)
+
+	loop := newScrapeLoop(pool, target)
+	loop.nowFn = func() time.Time { return now }
+	return loop
+}
+
+func TestScrapeLoopScrapeAndWrite(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/metrics", r.URL.Path)
+		assert.Equal(t, "bar", r.URL.Query().Get("foo"))
+		assert.Equal(t, "10", r.Header.Get("X-Prometheus-Scrape-Timeout-Seconds"))
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = w.Write([]byte(testMetrics))
+	}))
+	defer server.Close()
+
+	var (
+		written [][]testSample
+		writer  = ingest.NewMockDownsamplerAndWriter(ctrl)
+		now     = time.Unix(1600000100, 0)
+		address = server.Listener.Addr().String()
+		pool    = newTestScrapePool(t, writer, JobConfiguration{
+			JobName: "test",
+			Params:  url.Values{"foo": []string{"bar"}},
+		})
+		loop = newTestScrapeLoop(t, pool, address, map[string]string{"env": "prod"}, now)
+	)
+	expectWrites(writer, &written)
+
+	loop.scrapeAndWrite()
+	require.Equal(t, 1, len(written))
+
+	targetTags := func(tags map[string]string) map[string]string {
+		tags["job"] = "test"
+		tags["instance"] = address
+		tags["env"] = "prod"
+		return tags
+	}
+	expected := []testSample{
+		{
+			tags: targetTags(map[string]string{
+				"__name__":     "http_requests_total",
+				"code":         "200",
+				"exported_job": "app",
+			}),
+			value:     10,
+			timestamp: now,
+			attributes: ts.SeriesAttributes{
+				PromType:          ts.PromMetricTypeCounter,
+				HandleValueResets: true,
+				Source:            ts.SourceTypePrometheus,
+			},
+		},
+		{
+			tags: targetTags(map[string]string{
+				"__name__": "http_requests_total",
+				"code":     "500",
+			}),
+			value:     2,
+			timestamp: time.Unix(1600000000, 0),
+			attributes: ts.SeriesAttributes{
+				PromType:          ts.PromMetricTypeCounter,
+				HandleValueResets: true,
+				Source:            ts.SourceTypePrometheus,
+			},
+		},
+		{
+			tags:      targetTags(map[string]string{"__name__": "temperature"}),
+			value:     21.5,
+			timestamp: now,
+			attributes: ts.SeriesAttributes{
+				PromType: ts.PromMetricTypeGauge,
+				Source:   ts.SourceTypePrometheus,
+			},
+		},
+	}
+	require.Equal(t, len(expected)+3, len(written[0]))
+	for i, sample := range expected {
+		actual := written[0][i]
+		assert.Equal(t, sample.tags, actual.tags)
+		assert.Equal(t, sample.value, actual.value)
+		assert.True(t, sample.timestamp.Equal(actual.timestamp))
+		assert.Equal(t, sample.attributes, actual.attributes)
+	}
+
+	report := written[0][len(expected):]
+	assert.Equal(t, targetTags(map[string]string{"__name__": "up"}), report[0].tags)
+	assert.Equal(t, 1.0, report[0].value)
+	assert.Equal(t, "scrape_duration_seconds", report[1].tags["__name__"])
+	assert.Equal(t, "scrape_samples_scraped", report[2].tags["__name__"])
+	assert.Equal(t, 3.0, report[2].value)
+
+	status := loop.status()
+	assert.Equal(t, HealthGood, status.Health)
+	assert.Equal(t, "", status.LastError)
+	assert.True(t, now.Equal(status.LastScrape))
+	assert.Equal(t, "test", status.ScrapePool)
+	assert.Equal(t, "http://"+address+"/metrics?foo=bar", status.ScrapeURL)
+}
+
+func TestScrapeLoopHonorLabels(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`foo{job="app"} 1` + "\n"))
+	}))
+	defer server.Close()
+
+	var (
+		written [][]testSample
+		writer  = ingest.NewMockDownsamplerAndWriter(ctrl)
+		pool    = newTestScrapePool(t, writer, JobConfiguration{
+			JobName:     "test",
+			HonorLabels: true,
+		})
+		loop = newTestScrapeLoop(t, pool, server.Listener.Addr().String(), nil, time.Now())
+	)
+	expectWrites(writer, &written)
+
+	loop.scrapeAndWrite()
+	require.Equal(t, 1, len(written))
+	assert.Equal(t, "app", written[0][0].tags["job"])
+	assert.Equal(t, "foo", written[0][0].tags["__name__"])
+	assert.Equal(t, "", written[0][0].tags["exported_job"])
+}
+
+func TestScrapeLoopFailure(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	var (
+		written [][]testSample
+		writer  = ingest.NewMockDownsamplerAndWriter(ctrl)
+		pool    = newTestScrapePool(t, writer, JobConfiguration{JobName: "test"})
+		loop    = newTestScrapeLoop(t, pool, server.Listener.Addr().String(), nil, time.Now())
+	)
+	expectWrites(writer, &written)
+
+	loop.scrapeAndWrite()
+	require.Equal(t, 1, len(written))
+	require.Equal(t, 3, len(written[0]))
+	assert.Equal(t, "up", written[0][0].tags["__name__"])
+	assert.Equal(t, 0.0, written[0][0].value)
+	assert.Equal(t, 0.0, written[0][2].value)
+
+	status := loop.status()
+	assert.Equal(t, HealthBad, status.Health)
+	assert.Contains(t, status.LastError, "500")
+}
+
+func TestScrapeLoopLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testMetrics))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		job      JobConfiguration
+		expected string
+	}{
+		{
+			name:     "body size limit",
+			job:      JobConfiguration{JobName: "test", BodySizeLimit: int64(len(testMetrics) - 1)},
+			expected: errBodySizeLimit.Error(),
+		},
+		{
+			name:     "sample limit",
+			job:      JobConfiguration{JobName: "test", SampleLimit: 2},
+			expected: errSampleLimit.Error(),
+		},
+		{
+			name: "within limits",
+			job: JobConfiguration{
+				JobName:       "test",
+				BodySizeLimit: int64(len(testMetrics)),
+				SampleLimit:   3,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctrl := xtest.NewController(t)
+			defer ctrl.Finish()
+
+			var (
+				written [][]testSample
+				writer  = ingest.NewMockDownsamplerAndWriter(ctrl)
+				pool    = newTestScrapePool(t, writer, test.job)
+				loop    = newTestScrapeLoop(t, pool, server.Listener.Addr().String(), nil, time.Now())
+			)
+			expectWrites(writer, &written)
+
+			loop.scrapeAndWrite()
+			require.Equal(t, 1, len(written))
+
+			status := loop.status()
+			if test.expected == "" {
+				require.Equal(t, 6, len(written[0]))
+				assert.Equal(t, HealthGood, status.Health)
+				return
+			}
+
+			// NB: only the series reporting the failed scrape are written.
+			require.Equal(t, 3, len(written[0]))
+			assert.Equal(t, "up", written[0][0].tags["__name__"])
+			assert.Equal(t, 0.0, written[0][0].value)
+			assert.Equal(t, HealthBad, status.Health)
+			assert.Contains(t, status.LastError, test.expected)
+		})
+	}
+}
+
+func TestFamilyType(t *testing.T) {
+	types := map[string]prompb.MetricType{
+		"requests":       prompb.MetricType_COUNTER,
+		"latency":        prompb.MetricType_HISTOGRAM,
+		"latency_bucket": prompb.MetricType_GAUGE,
+	}
+
+	assert.Equal(t, prompb.MetricType_COUNTER, familyType(types, "requests"))
+	assert.Equal(t, prompb.MetricType_COUNTER, familyType(types, "requests_total"))
+	assert.Equal(t, prompb.MetricType_HISTOGRAM, familyType(types, "latency_sum"))
+	assert.Equal(t, prompb.MetricType_GAUGE, familyType(types, "latency_bucket"))
+	assert.Equal(t, prompb.MetricType_UNKNOWN, familyType(types, "other"))
+}
@@ -455,6 +455,9 @@ func TestConfiguration(t *testing.T) {
     force_index_summaries_mmap_memory: true
     force_bloom_filter_mmap_memory: true
     bloomFilterFalsePositivePercent: null
+    encryption: null
+    tieredStorage: null
+    backup: null
   commitlog:
     flushMaxBytes: 524288
     flushEvery: 1s
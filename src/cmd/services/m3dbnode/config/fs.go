@@ -23,6 +23,12 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/encryption"
+	"github.com/m3db/m3/src/x/objstore"
 )
 
 const (
@@ -41,6 +47,8 @@ const (
 	defaultForceIndexSummariesMmapMemory   = false
 	defaultForceBloomFilterMmapMemory      = false
 	defaultBloomFilterFalsePositivePercent = 0.02
+	defaultTieredStorageCacheDirectory     = "cache"
+	defaultTieredStorageCacheMaxBytes      = 16 << 30 // 16GiB
 )
 
 // DefaultMmapConfiguration is the default mmap configuration.
@@ -98,6 +106,78 @@ type FilesystemConfiguration struct {
 	// BloomFilterFalsePositivePercent controls the target false positive percentage
 	// for the bloom filters for the fileset files.
 	BloomFilterFalsePositivePercent *float64 `yaml:"bloomFilterFalsePositivePercent"`
+
+	// Encryption enables encryption at rest of the fileset and commit log
+	// files, files are written in plaintext if not set.
+	Encryption *encryption.Configuration `yaml:"encryption"`
+
+	// TieredStorage enables offloading the filesets of sealed blocks to an
+	// object store, filesets are kept on local disk if not set.
+	TieredStorage *TieredStorageConfiguration `yaml:"tieredStorage"`
+
+	// Backup enables backups of namespaces to an object store, namespaces
+	// cannot be backed up or restored if not set.
+	Backup *BackupConfiguration `yaml:"backup"`
+}
+
+// TieredStorageConfiguration is the configuration for offloading the
+// filesets of sealed blocks to an object store, offloaded filesets are
+// fetched back into a local cache when queried.
+type TieredStorageConfiguration struct {
+	// Store is the object store filesets are offloaded to.
+	Store objstore.Configuration `yaml:"store"`
+
+	// OffloadAfter is how long after the end of a block its filesets are
+	// offloaded.
+	OffloadAfter time.Duration `yaml:"offloadAfter" validate:"nonzero"`
+
+	// Namespaces restricts offloading to the given namespaces, filesets of
+	// all namespaces are offloaded if not set.
+	Namespaces []string `yaml:"namespaces"`
+
+	// CacheDirectory is the directory offloaded filesets are fetched into,
+	// defaults to a directory under the file path prefix. It must not
+	// contain the file path prefix or be within its data, index, snapshots,
+	// commitlogs or tombstones directories.
+	CacheDirectory *string `yaml:"cacheDirectory"`
+
+	// CacheMaxBytes is the size the cache of fetched filesets is kept under.
+	CacheMaxBytes *int64 `yaml:"cacheMaxBytes"`
+
+	// OffloadConcurrency is how many filesets are offloaded at once,
+	// defaults to 4 if not set.
+	OffloadConcurrency int `yaml:"offloadConcurrency" validate:"min=0"`
+}
+
+// CacheDirectoryOrDefault returns the configured cache directory if
+// configured, or a directory under the file path prefix otherwise.
+func (c TieredStorageConfiguration) CacheDirectoryOrDefault(filePathPrefix string) string {
+	if c.CacheDirectory != nil {
+		return *c.CacheDirectory
+	}
+
+	return path.Join(filePathPrefix, defaultTieredStorageCacheDirectory)
+}
+
+// CacheMaxBytesOrDefault returns the configured cache size if configured, or
+// a default value otherwise.
+func (c TieredStorageConfiguration) CacheMaxBytesOrDefault() int64 {
+	if c.CacheMaxBytes != nil {
+		return *c.CacheMaxBytes
+	}
+
+	return defaultTieredStorageCacheMaxBytes
+}
+
+// BackupConfiguration is the configuration for backing up namespaces to an
+// object store and restoring them from it.
+type BackupConfiguration struct {
+	// Store is the object store backups are written to.
+	Store objstore.Configuration `yaml:"store"`
+
+	// SnapshotTimeout is how long a backup waits for the snapshot that
+	// captures the commit log at its point in time, defaults to ten minutes.
+	SnapshotTimeout time.Duration `yaml:"snapshotTimeout"`
 }
 
 // Validate validates the Filesystem configuration. We use this method to validate
@@ -145,6 +225,28 @@ func (f FilesystemConfiguration) Validate() error {
 			*f.BloomFilterFalsePositivePercent)
 	}
 
+	if f.TieredStorage != nil && f.TieredStorage.CacheMaxBytes != nil &&
+		*f.TieredStorage.CacheMaxBytes < 0 {
+		return fmt.Errorf(
+			"fs tieredStorage cacheMaxBytes is set to: %d, but must be at least 0",
+			*f.TieredStorage.CacheMaxBytes)
+	}
+
+	if f.TieredStorage != nil {
+		filePathPrefix := f.FilePathPrefixOrDefault()
+		err := fs.ValidateTieredStorageCacheDirectory(filePathPrefix,
+			f.TieredStorage.CacheDirectoryOrDefault(filePathPrefix))
+		if err != nil {
+			return fmt.Errorf("fs tieredStorage cacheDirectory is invalid: %w", err)
+		}
+	}
+
+	if f.Backup != nil && f.Backup.SnapshotTimeout < 0 {
+		return fmt.Errorf(
+			"fs backup snapshotTimeout is set to: %v, but must be at least 0",
+			f.Backup.SnapshotTimeout)
+	}
+
 	return nil
 }
 
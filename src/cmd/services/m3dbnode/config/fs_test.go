@@ -47,3 +47,26 @@ func TestFilesystemConfigurationParseNewDirectoryMode(t *testing.T) {
 
 	assert.Equal(t, os.FileMode(0775)|os.ModeDir, v)
 }
+
+func TestFilesystemConfigurationValidateTieredStorageCacheDirectory(t *testing.T) {
+	var (
+		prefix = "/var/lib/m3db"
+		cfg    = FilesystemConfiguration{
+			FilePathPrefix: &prefix,
+			TieredStorage:  &TieredStorageConfiguration{},
+		}
+	)
+	require.NoError(t, cfg.Validate())
+
+	for _, cacheDir := range []string{"/mnt/cache", "/var/lib/m3db/cache"} {
+		dir := cacheDir
+		cfg.TieredStorage.CacheDirectory = &dir
+		require.NoError(t, cfg.Validate(), cacheDir)
+	}
+
+	for _, cacheDir := range []string{"/var/lib/m3db", "/var/lib", "/var/lib/m3db/data"} {
+		dir := cacheDir
+		cfg.TieredStorage.CacheDirectory = &dir
+		require.Error(t, cfg.Validate(), cacheDir)
+	}
+}
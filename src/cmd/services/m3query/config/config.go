@@ -29,12 +29,16 @@ import (
 	"github.com/m3db/m3/src/cluster/placement"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/downsample"
 	ingestm3msg "github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/m3msg"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/scrape"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/server/m3msg"
 	"github.com/m3db/m3/src/metrics/aggregation"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
+	"github.com/m3db/m3/src/query/executor"
 	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/exemplar"
 	"github.com/m3db/m3/src/query/storage/m3"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
@@ -67,6 +71,8 @@ const (
 
 	defaultCarbonIngesterListenAddress = "0.0.0.0:7204"
 
+	defaultCarbonEventsLimit = 10000
+
 	defaultQueryTimeout = 30 * time.Second
 
 	defaultPrometheusMaxSamplesPerQuery = 100000000
@@ -179,6 +185,10 @@ type Configuration struct {
 	// Carbon is the carbon configuration.
 	Carbon *CarbonConfiguration `yaml:"carbon"`
 
+	// Scrape if set scrapes Prometheus targets and writes the samples as
+	// if they were remote written.
+	Scrape *scrape.Configuration `yaml:"scrape"`
+
 	// Middleware is middleware-specific configuration.
 	Middleware MiddlewareConfiguration `yaml:"middleware"`
 
@@ -201,6 +211,19 @@ type Configuration struct {
 	// StoreMetricsType controls if metrics type is stored or not.
 	StoreMetricsType *bool `yaml:"storeMetricsType"`
 
+	// IngestCreatedTimestamps controls if the created timestamps of counters,
+	// histograms and summaries received with Prometheus remote write 2.0
+	// requests are ingested as zero samples.
+	IngestCreatedTimestamps bool `yaml:"ingestCreatedTimestamps"`
+
+	// Exemplars is the configuration for storing the exemplars received with
+	// Prometheus remote writes.
+	Exemplars exemplar.Configuration `yaml:"exemplars"`
+
+	// ResultsCache is the configuration for caching the results of range
+	// queries.
+	ResultsCache resultscache.Configuration `yaml:"resultsCache"`
+
 	// MultiProcess is the multi-process configuration.
 	MultiProcess MultiProcessConfiguration `yaml:"multiProcess"`
 
@@ -296,6 +319,9 @@ type QueryConfiguration struct {
 	// RequireSeriesEndpointStartEndTime requires requests to /series endpoint
 	// to specify a start and end time to prevent unbounded queries.
 	RequireSeriesEndpointStartEndTime bool `yaml:"requireSeriesEndpointStartEndTime"`
+	// Split is configuration for splitting long range queries into
+	// sub-queries that are executed concurrently.
+	Split QuerySplitConfiguration `yaml:"split"`
 }
 
 // TimeoutOrDefault returns the configured timeout or default value.
@@ -306,6 +332,39 @@ func (c QueryConfiguration) TimeoutOrDefault() time.Duration {
 	return defaultQueryTimeout
 }
 
+// QuerySplitConfiguration is configuration for splitting long range queries
+// executed by the M3 query engine.
+type QuerySplitConfiguration struct {
+	// Enabled enables query splitting.
+	Enabled bool `yaml:"enabled"`
+	// Interval is the interval range queries are split by.
+	Interval *time.Duration `yaml:"interval"`
+	// SeriesShards is the number of series shards that associative
+	// aggregations such as sum, count, min and max are split into,
+	// values below two disable splitting by series.
+	SeriesShards int `yaml:"seriesShards"`
+	// MaxConcurrency is the maximum number of sub-queries executed
+	// concurrently for a single query.
+	MaxConcurrency int `yaml:"maxConcurrency"`
+}
+
+// SplitOptions returns the engine split options, or false if query
+// splitting is disabled.
+func (c QuerySplitConfiguration) SplitOptions() (executor.SplitOptions, bool) {
+	if !c.Enabled {
+		return executor.SplitOptions{}, false
+	}
+
+	opts := executor.SplitOptions{
+		SeriesShards:   c.SeriesShards,
+		MaxConcurrency: c.MaxConcurrency,
+	}
+	if c.Interval != nil {
+		opts.Interval = *c.Interval
+	}
+	return opts, true
+}
+
 // RestrictTagsAsStorageRestrictByTag returns restrict tags as
 // storage options to restrict all queries by default.
 func (c QueryConfiguration) RestrictTagsAsStorageRestrictByTag() (*storage.RestrictByTag, bool, error) {
@@ -507,6 +566,8 @@ type CarbonConfiguration struct {
 	LimitsFind *LimitsConfiguration `yaml:"limitsFind"`
 	// LimitsRender sets the limits configuration for render queries.
 	LimitsRender *LimitsConfiguration `yaml:"limitsRender"`
+	// Events if set enables the graphite events API and events() function.
+	Events *CarbonEventsConfiguration `yaml:"events"`
 	// AggregateNamespacesAllData configures whether all aggregate
 	// namespaces contain entire copies of the data set.
 	// This affects whether queries can be optimized or not, if false
@@ -624,12 +685,38 @@ type PrometheusMiddlewareConfiguration struct {
 	ResolutionMultiplier int `yaml:"resolutionMultiplier"`
 }
 
+// CarbonEventsConfiguration is the configuration for graphite events.
+type CarbonEventsConfiguration struct {
+	// Namespace is the dedicated dbnode namespace events are stored in, it
+	// must be a namespace of the unaggregated cluster and should use the proto
+	// encoding with the eventspb.Event schema.
+	Namespace string `yaml:"namespace" validate:"nonzero"`
+	// Limit is the max number of events returned by a query, defaults to
+	// 10000 if zero.
+	Limit int `yaml:"limit" validate:"min=0"`
+}
+
+// LimitOrDefault returns the max number of events returned by a query.
+func (c CarbonEventsConfiguration) LimitOrDefault() int {
+	if c.Limit > 0 {
+		return c.Limit
+	}
+
+	return defaultCarbonEventsLimit
+}
+
 // CarbonIngesterConfiguration is the configuration struct for carbon ingestion.
 type CarbonIngesterConfiguration struct {
-	ListenAddress  string                             `yaml:"listenAddress"`
-	MaxConcurrency int                                `yaml:"maxConcurrency"`
-	Rewrite        CarbonIngesterRewriteConfiguration `yaml:"rewrite"`
-	Rules          []CarbonIngesterRuleConfiguration  `yaml:"rules"`
+	ListenAddress string `yaml:"listenAddress"`
+	// PickleListenAddress is the TCP address to listen on for the pickle
+	// protocol, the pickle listener is disabled if not set.
+	PickleListenAddress string `yaml:"pickleListenAddress"`
+	// UDPListenAddress is the address to listen on for plaintext metrics
+	// sent over UDP, the UDP listener is disabled if not set.
+	UDPListenAddress string                             `yaml:"udpListenAddress"`
+	MaxConcurrency   int                                `yaml:"maxConcurrency"`
+	Rewrite          CarbonIngesterRewriteConfiguration `yaml:"rewrite"`
+	Rules            []CarbonIngesterRuleConfiguration  `yaml:"rules"`
 }
 
 // CarbonIngesterRewriteConfiguration is the configuration for rewriting
@@ -221,3 +221,14 @@ func TestKeepNaNsDefault(t *testing.T) {
 	r = ResultOptions{}
 	assert.Equal(t, false, r.KeepNaNs)
 }
+
+func TestCarbonEventsConfiguration(t *testing.T) {
+	var cfg CarbonEventsConfiguration
+	require.NoError(t, yaml.Unmarshal([]byte("limit: 0"), &cfg))
+	require.Error(t, validator.Validate(cfg))
+	assert.Equal(t, defaultCarbonEventsLimit, cfg.LimitOrDefault())
+
+	require.NoError(t, yaml.Unmarshal([]byte("namespace: events\nlimit: 10"), &cfg))
+	require.NoError(t, validator.Validate(cfg))
+	assert.Equal(t, 10, cfg.LimitOrDefault())
+}
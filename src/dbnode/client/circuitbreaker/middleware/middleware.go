@@ -147,6 +147,10 @@ func (c *client) AggregateTiles(
 	return c.next.AggregateTiles(ctx, req)
 }
 
+func (c *client) Backup(ctx thrift.Context, req *rpc.BackupRequest) (*rpc.BackupResult_, error) {
+	return c.next.Backup(ctx, req)
+}
+
 func (c *client) Bootstrapped(ctx thrift.Context) (*rpc.NodeBootstrappedResult_, error) {
 	return c.next.Bootstrapped(ctx)
 }
@@ -178,6 +182,13 @@ func (c *client) DebugProfileStop(
 	return c.next.DebugProfileStop(ctx, req)
 }
 
+func (c *client) DeleteSeries(
+	ctx thrift.Context,
+	req *rpc.DeleteSeriesRequest,
+) (*rpc.DeleteSeriesResult_, error) {
+	return c.next.DeleteSeries(ctx, req)
+}
+
 func (c *client) Fetch(ctx thrift.Context, req *rpc.FetchRequest) (*rpc.FetchResult_, error) {
 	return c.next.Fetch(ctx, req)
 }
@@ -243,6 +254,10 @@ func (c *client) Repair(ctx thrift.Context) error {
 	return c.next.Repair(ctx)
 }
 
+func (c *client) Restore(ctx thrift.Context, req *rpc.RestoreRequest) (*rpc.RestoreResult_, error) {
+	return c.next.Restore(ctx, req)
+}
+
 func (c *client) SetPersistRateLimit(
 	ctx thrift.Context,
 	req *rpc.NodeSetPersistRateLimitRequest,
@@ -815,6 +815,21 @@ func (mr *MockAdminSessionMockRecorder) Aggregate(ctx, namespace, q, opts interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockAdminSession)(nil).Aggregate), ctx, namespace, q, opts)
 }
 
+// Backup mocks base method.
+func (m *MockAdminSession) Backup(namespace ident.ID, backupID string, hosts []string) ([]BackupHostResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", namespace, backupID, hosts)
+	ret0, _ := ret[0].([]BackupHostResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockAdminSessionMockRecorder) Backup(namespace, backupID, hosts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockAdminSession)(nil).Backup), namespace, backupID, hosts)
+}
+
 // BorrowConnections mocks base method.
 func (m *MockAdminSession) BorrowConnections(shardID uint32, fn WithBorrowConnectionFn, opts BorrowConnectionOptions) (BorrowConnectionsResult, error) {
 	m.ctrl.T.Helper()
@@ -860,6 +875,21 @@ func (mr *MockAdminSessionMockRecorder) DedicatedConnection(shardID, opts interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedicatedConnection", reflect.TypeOf((*MockAdminSession)(nil).DedicatedConnection), shardID, opts)
 }
 
+// DeleteSeries mocks base method.
+func (m *MockAdminSession) DeleteSeries(namespace ident.ID, q index.Query, start, end time.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockAdminSessionMockRecorder) DeleteSeries(namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockAdminSession)(nil).DeleteSeries), namespace, q, start, end)
+}
+
 // Fetch mocks base method.
 func (m *MockAdminSession) Fetch(namespace, id ident.ID, startInclusive, endExclusive time.UnixNano) (encoding.SeriesIterator, error) {
 	m.ctrl.T.Helper()
@@ -1040,6 +1070,21 @@ func (mr *MockAdminSessionMockRecorder) Replicas() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replicas", reflect.TypeOf((*MockAdminSession)(nil).Replicas))
 }
 
+// Restore mocks base method.
+func (m *MockAdminSession) Restore(namespace ident.ID, backupID string, pointInTime time.UnixNano, hosts []string) ([]BackupHostResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", namespace, backupID, pointInTime, hosts)
+	ret0, _ := ret[0].([]BackupHostResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockAdminSessionMockRecorder) Restore(namespace, backupID, pointInTime, hosts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAdminSession)(nil).Restore), namespace, backupID, pointInTime, hosts)
+}
+
 // ShardID mocks base method.
 func (m *MockAdminSession) ShardID(id ident.ID) (uint32, error) {
 	m.ctrl.T.Helper()
@@ -1277,6 +1322,20 @@ func (mr *MockOptionsMockRecorder) BackgroundHealthCheckStutter() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackgroundHealthCheckStutter", reflect.TypeOf((*MockOptions)(nil).BackgroundHealthCheckStutter))
 }
 
+// BackupRequestTimeout mocks base method.
+func (m *MockOptions) BackupRequestTimeout() time0.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupRequestTimeout")
+	ret0, _ := ret[0].(time0.Duration)
+	return ret0
+}
+
+// BackupRequestTimeout indicates an expected call of BackupRequestTimeout.
+func (mr *MockOptionsMockRecorder) BackupRequestTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupRequestTimeout", reflect.TypeOf((*MockOptions)(nil).BackupRequestTimeout))
+}
+
 // ChannelOptions mocks base method.
 func (m *MockOptions) ChannelOptions() *tchannel_go.ChannelOptions {
 	m.ctrl.T.Helper()
@@ -1893,6 +1952,20 @@ func (mr *MockOptionsMockRecorder) SetBackgroundHealthCheckStutter(value interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBackgroundHealthCheckStutter", reflect.TypeOf((*MockOptions)(nil).SetBackgroundHealthCheckStutter), value)
 }
 
+// SetBackupRequestTimeout mocks base method.
+func (m *MockOptions) SetBackupRequestTimeout(value time0.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBackupRequestTimeout", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetBackupRequestTimeout indicates an expected call of SetBackupRequestTimeout.
+func (mr *MockOptionsMockRecorder) SetBackupRequestTimeout(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBackupRequestTimeout", reflect.TypeOf((*MockOptions)(nil).SetBackupRequestTimeout), value)
+}
+
 // SetChannelOptions mocks base method.
 func (m *MockOptions) SetChannelOptions(value *tchannel_go.ChannelOptions) Options {
 	m.ctrl.T.Helper()
@@ -3064,6 +3137,20 @@ func (mr *MockAdminOptionsMockRecorder) BackgroundHealthCheckStutter() *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackgroundHealthCheckStutter", reflect.TypeOf((*MockAdminOptions)(nil).BackgroundHealthCheckStutter))
 }
 
+// BackupRequestTimeout mocks base method.
+func (m *MockAdminOptions) BackupRequestTimeout() time0.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupRequestTimeout")
+	ret0, _ := ret[0].(time0.Duration)
+	return ret0
+}
+
+// BackupRequestTimeout indicates an expected call of BackupRequestTimeout.
+func (mr *MockAdminOptionsMockRecorder) BackupRequestTimeout() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupRequestTimeout", reflect.TypeOf((*MockAdminOptions)(nil).BackupRequestTimeout))
+}
+
 // BootstrapConsistencyLevel mocks base method.
 func (m *MockAdminOptions) BootstrapConsistencyLevel() topology.ReadConsistencyLevel {
 	m.ctrl.T.Helper()
@@ -3778,6 +3865,20 @@ func (mr *MockAdminOptionsMockRecorder) SetBackgroundHealthCheckStutter(value in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBackgroundHealthCheckStutter", reflect.TypeOf((*MockAdminOptions)(nil).SetBackgroundHealthCheckStutter), value)
 }
 
+// SetBackupRequestTimeout mocks base method.
+func (m *MockAdminOptions) SetBackupRequestTimeout(value time0.Duration) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBackupRequestTimeout", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetBackupRequestTimeout indicates an expected call of SetBackupRequestTimeout.
+func (mr *MockAdminOptionsMockRecorder) SetBackupRequestTimeout(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBackupRequestTimeout", reflect.TypeOf((*MockAdminOptions)(nil).SetBackupRequestTimeout), value)
+}
+
 // SetBootstrapConsistencyLevel mocks base method.
 func (m *MockAdminOptions) SetBootstrapConsistencyLevel(value topology.ReadConsistencyLevel) AdminOptions {
 	m.ctrl.T.Helper()
@@ -4965,6 +5066,21 @@ func (mr *MockclientSessionMockRecorder) Aggregate(ctx, namespace, q, opts inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aggregate", reflect.TypeOf((*MockclientSession)(nil).Aggregate), ctx, namespace, q, opts)
 }
 
+// Backup mocks base method.
+func (m *MockclientSession) Backup(namespace ident.ID, backupID string, hosts []string) ([]BackupHostResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", namespace, backupID, hosts)
+	ret0, _ := ret[0].([]BackupHostResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockclientSessionMockRecorder) Backup(namespace, backupID, hosts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockclientSession)(nil).Backup), namespace, backupID, hosts)
+}
+
 // BorrowConnections mocks base method.
 func (m *MockclientSession) BorrowConnections(shardID uint32, fn WithBorrowConnectionFn, opts BorrowConnectionOptions) (BorrowConnectionsResult, error) {
 	m.ctrl.T.Helper()
@@ -5010,6 +5126,21 @@ func (mr *MockclientSessionMockRecorder) DedicatedConnection(shardID, opts inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DedicatedConnection", reflect.TypeOf((*MockclientSession)(nil).DedicatedConnection), shardID, opts)
 }
 
+// DeleteSeries mocks base method.
+func (m *MockclientSession) DeleteSeries(namespace ident.ID, q index.Query, start, end time.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", namespace, q, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockclientSessionMockRecorder) DeleteSeries(namespace, q, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockclientSession)(nil).DeleteSeries), namespace, q, start, end)
+}
+
 // Fetch mocks base method.
 func (m *MockclientSession) Fetch(namespace, id ident.ID, startInclusive, endExclusive time.UnixNano) (encoding.SeriesIterator, error) {
 	m.ctrl.T.Helper()
@@ -5204,6 +5335,21 @@ func (mr *MockclientSessionMockRecorder) Replicas() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replicas", reflect.TypeOf((*MockclientSession)(nil).Replicas))
 }
 
+// Restore mocks base method.
+func (m *MockclientSession) Restore(namespace ident.ID, backupID string, pointInTime time.UnixNano, hosts []string) ([]BackupHostResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", namespace, backupID, pointInTime, hosts)
+	ret0, _ := ret[0].([]BackupHostResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockclientSessionMockRecorder) Restore(namespace, backupID, pointInTime, hosts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockclientSession)(nil).Restore), namespace, backupID, pointInTime, hosts)
+}
+
 // ShardID mocks base method.
 func (m *MockclientSession) ShardID(id ident.ID) (uint32, error) {
 	m.ctrl.T.Helper()
@@ -316,6 +316,12 @@ func (q *queue) drain() {
 				}
 			case *truncateOp:
 				q.asyncTruncate(v)
+			case *deleteSeriesOp:
+				q.asyncDeleteSeries(v)
+			case *backupOp:
+				q.asyncBackup(v)
+			case *restoreOp:
+				q.asyncRestore(v)
 			default:
 				completionFn := ops[i].CompletionFn()
 				completionFn(nil, errQueueUnknownOperation(q.host.ID()))
@@ -1020,6 +1026,83 @@ func (q *queue) asyncTruncate(op *truncateOp) {
 	})
 }
 
+func (q *queue) asyncDeleteSeries(op *deleteSeriesOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, _, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		// NB: Deleting series is an administrative operation similar to
+		// truncating a namespace so the truncate request timeout is used.
+		ctx, _ := thrift.NewContext(q.opts.TruncateRequestTimeout())
+		if res, err := client.DeleteSeries(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
+func (q *queue) asyncBackup(op *backupOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, _, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.BackupRequestTimeout())
+		if res, err := client.Backup(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
+func (q *queue) asyncRestore(op *restoreOp) {
+	q.Add(1)
+
+	q.workerPool.Go(func() {
+		cleanup := q.Done
+
+		client, _, err := q.connPool.NextClient()
+		if err != nil {
+			// No client available
+			op.completionFn(nil, err)
+			cleanup()
+			return
+		}
+
+		ctx, _ := thrift.NewContext(q.opts.BackupRequestTimeout())
+		if res, err := client.Restore(ctx, &op.request); err != nil {
+			op.completionFn(nil, err)
+		} else {
+			op.completionFn(res, nil)
+		}
+
+		cleanup()
+	})
+}
+
 func (q *queue) mustWrapAndCheckContext(
 	callingContext context.Context,
 	method string,
@@ -89,6 +89,9 @@ const (
 	// defaultTruncateRequestTimeout is the default truncate request timeout
 	defaultTruncateRequestTimeout = 60 * time.Second
 
+	// defaultBackupRequestTimeout is the default backup and restore request timeout
+	defaultBackupRequestTimeout = time.Hour
+
 	// defaultWriteShardsInitializing is the default write to shards intializing value
 	defaultWriteShardsInitializing = true
 
@@ -253,6 +256,7 @@ type options struct {
 	writeRequestTimeout                                 time.Duration
 	fetchRequestTimeout                                 time.Duration
 	truncateRequestTimeout                              time.Duration
+	backupRequestTimeout                                time.Duration
 	backgroundConnectInterval                           time.Duration
 	backgroundConnectStutter                            time.Duration
 	backgroundHealthCheckInterval                       time.Duration
@@ -412,6 +416,7 @@ func newOptions() *options {
 		writeRequestTimeout:                                 defaultWriteRequestTimeout,
 		fetchRequestTimeout:                                 defaultFetchRequestTimeout,
 		truncateRequestTimeout:                              defaultTruncateRequestTimeout,
+		backupRequestTimeout:                                defaultBackupRequestTimeout,
 		backgroundConnectInterval:                           defaultBackgroundConnectInterval,
 		backgroundConnectStutter:                            defaultBackgroundConnectStutter,
 		backgroundHealthCheckInterval:                       defaultBackgroundHealthCheckInterval,
@@ -712,6 +717,16 @@ func (o *options) TruncateRequestTimeout() time.Duration {
 	return o.truncateRequestTimeout
 }
 
+func (o *options) SetBackupRequestTimeout(value time.Duration) Options {
+	opts := *o
+	opts.backupRequestTimeout = value
+	return &opts
+}
+
+func (o *options) BackupRequestTimeout() time.Duration {
+	return o.backupRequestTimeout
+}
+
 func (o *options) SetBackgroundConnectInterval(value time.Duration) Options {
 	opts := *o
 	opts.backgroundConnectInterval = value
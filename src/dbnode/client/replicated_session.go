@@ -348,6 +348,35 @@ func (s replicatedSession) Truncate(namespace ident.ID) (int64, error) {
 	return s.session.Truncate(namespace)
 }
 
+// DeleteSeries deletes the datapoints within the given time range of the
+// series matching the query from all replicas.
+func (s replicatedSession) DeleteSeries(
+	namespace ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	return s.session.DeleteSeries(namespace, q, start, end)
+}
+
+// Backup backs up the namespace on the given hosts of the primary cluster.
+func (s replicatedSession) Backup(
+	namespace ident.ID,
+	backupID string,
+	hosts []string,
+) ([]BackupHostResult, error) {
+	return s.session.Backup(namespace, backupID, hosts)
+}
+
+// Restore restores the namespace on the given hosts of the primary cluster.
+func (s replicatedSession) Restore(
+	namespace ident.ID,
+	backupID string,
+	pointInTime xtime.UnixNano,
+	hosts []string,
+) ([]BackupHostResult, error) {
+	return s.session.Restore(namespace, backupID, pointInTime, hosts)
+}
+
 // FetchBootstrapBlocksFromPeers will fetch the most fulfilled block
 // for each series using the runtime configurable bootstrap level consistency.
 func (s replicatedSession) FetchBootstrapBlocksFromPeers(
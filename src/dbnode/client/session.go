@@ -2288,6 +2288,185 @@ func (s *session) Truncate(namespace ident.ID) (int64, error) {
 	return truncated, resultErr.FinalError()
 }
 
+func (s *session) DeleteSeries(
+	namespace ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	var (
+		wg            sync.WaitGroup
+		enqueueErr    xerrors.MultiError
+		resultErrLock sync.Mutex
+		resultErr     xerrors.MultiError
+		deleted       int64
+	)
+
+	req, err := convert.ToRPCDeleteSeriesRequest(namespace, q, start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	op := &deleteSeriesOp{request: req}
+	op.completionFn = func(result interface{}, err error) {
+		if err != nil {
+			resultErrLock.Lock()
+			resultErr = resultErr.Add(err)
+			resultErrLock.Unlock()
+		} else {
+			res := result.(*rpc.DeleteSeriesResult_)
+			atomic.AddInt64(&deleted, res.NumSeries)
+		}
+		wg.Done()
+	}
+
+	s.state.RLock()
+	for idx := range s.state.queues {
+		wg.Add(1)
+		if err := s.state.queues[idx].Enqueue(op); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Error("failed to enqueue request", zap.Error(err))
+		return 0, err
+	}
+
+	// Wait for series to be deleted on all replicas
+	wg.Wait()
+
+	return deleted, resultErr.FinalError()
+}
+
+func (s *session) Backup(
+	namespace ident.ID,
+	backupID string,
+	hosts []string,
+) ([]BackupHostResult, error) {
+	req := rpc.BackupRequest{
+		NameSpace: namespace.Bytes(),
+		BackupID:  backupID,
+	}
+	return s.fanoutBackupOp(hosts, func(host string, done func(BackupHostResult, error)) op {
+		return &backupOp{
+			request: req,
+			completionFn: func(result interface{}, err error) {
+				if err != nil {
+					done(BackupHostResult{}, err)
+					return
+				}
+				res := result.(*rpc.BackupResult_)
+				done(BackupHostResult{
+					HostID:   host,
+					BackupID: backupID,
+					NumFiles: res.NumFiles,
+					NumBytes: res.NumBytes,
+				}, nil)
+			},
+		}
+	})
+}
+
+func (s *session) Restore(
+	namespace ident.ID,
+	backupID string,
+	pointInTime xtime.UnixNano,
+	hosts []string,
+) ([]BackupHostResult, error) {
+	req := rpc.RestoreRequest{
+		NameSpace:       namespace.Bytes(),
+		BackupID:        backupID,
+		PointInTime:     int64(pointInTime),
+		PointInTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+	}
+	return s.fanoutBackupOp(hosts, func(host string, done func(BackupHostResult, error)) op {
+		return &restoreOp{
+			request: req,
+			completionFn: func(result interface{}, err error) {
+				if err != nil {
+					done(BackupHostResult{}, err)
+					return
+				}
+				res := result.(*rpc.RestoreResult_)
+				done(BackupHostResult{
+					HostID:   host,
+					BackupID: res.BackupID,
+					NumFiles: res.NumFiles,
+					NumBytes: res.NumBytes,
+				}, nil)
+			},
+		}
+	})
+}
+
+// fanoutBackupOp enqueues a backup or restore op to the queues of the given
+// hosts, or all hosts if none are specified, and waits for their results.
+func (s *session) fanoutBackupOp(
+	hosts []string,
+	newOp func(host string, done func(BackupHostResult, error)) op,
+) ([]BackupHostResult, error) {
+	var (
+		wg         sync.WaitGroup
+		enqueueErr xerrors.MultiError
+		resultLock sync.Mutex
+		resultErr  xerrors.MultiError
+		results    []BackupHostResult
+	)
+
+	s.state.RLock()
+	queues := s.state.queues
+	if len(hosts) > 0 {
+		queues = make([]hostQueue, 0, len(hosts))
+		for _, host := range hosts {
+			queue, ok := s.state.queuesByHostID[host]
+			if !ok {
+				enqueueErr = enqueueErr.Add(fmt.Errorf("host not in topology: %s", host))
+				continue
+			}
+			queues = append(queues, queue)
+		}
+	}
+	if err := enqueueErr.FinalError(); err != nil {
+		s.state.RUnlock()
+		return nil, err
+	}
+
+	for _, queue := range queues {
+		host := queue.Host().ID()
+		wg.Add(1)
+		op := newOp(host, func(result BackupHostResult, err error) {
+			resultLock.Lock()
+			if err != nil {
+				resultErr = resultErr.Add(fmt.Errorf("host %s: %w", host, err))
+			} else {
+				results = append(results, result)
+			}
+			resultLock.Unlock()
+			wg.Done()
+		})
+		if err := queue.Enqueue(op); err != nil {
+			wg.Done()
+			enqueueErr = enqueueErr.Add(err)
+		}
+	}
+	s.state.RUnlock()
+
+	if err := enqueueErr.FinalError(); err != nil {
+		s.log.Error("failed to enqueue request", zap.Error(err))
+		return nil, err
+	}
+
+	// Wait for the op to complete on all hosts
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].HostID < results[j].HostID
+	})
+	return results, resultErr.FinalError()
+}
+
 // NB(r): Excluding maligned struct check here as we can
 // live with a few extra bytes since this struct is only
 // ever passed by stack, its much more readable not optimized
@@ -0,0 +1,126 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestBackup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			backup, ok := op.(*backupOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), backup.request.NameSpace)
+			assert.Equal(t, "backup0", backup.request.BackupID)
+
+			result := &rpc.BackupResult_{NumFiles: int64(idx + 1), NumBytes: 10}
+			backup.completionFn(result, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	results, err := s.Backup(ident.StringID("metrics"), "backup0", nil)
+	require.NoError(t, err)
+	require.Equal(t, sessionTestReplicas, len(results))
+	for i, result := range results {
+		assert.Equal(t, "backup0", result.BackupID)
+		assert.Equal(t, int64(10), result.NumBytes)
+		if i > 0 {
+			assert.True(t, results[i-1].HostID < result.HostID)
+		}
+	}
+
+	assert.NoError(t, session.Close())
+}
+
+func TestRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	pointInTime := xtime.Now()
+	mockHostQueues(ctrl, session, sessionTestReplicas, []testEnqueueFn{
+		func(idx int, op op) {
+			restore, ok := op.(*restoreOp)
+			assert.True(t, ok)
+			assert.Equal(t, []byte("metrics"), restore.request.NameSpace)
+			assert.Equal(t, "", restore.request.BackupID)
+			assert.Equal(t, int64(pointInTime), restore.request.PointInTime)
+			assert.Equal(t, rpc.TimeType_UNIX_NANOSECONDS, restore.request.PointInTimeType)
+
+			result := &rpc.RestoreResult_{BackupID: "backup0", NumFiles: 2, NumBytes: 10}
+			restore.completionFn(result, nil)
+		},
+	})
+
+	assert.NoError(t, session.Open())
+
+	results, err := s.Restore(ident.StringID("metrics"), "", pointInTime, nil)
+	require.NoError(t, err)
+	require.Equal(t, sessionTestReplicas, len(results))
+	for _, result := range results {
+		assert.Equal(t, "backup0", result.BackupID)
+		assert.Equal(t, int64(2), result.NumFiles)
+	}
+
+	assert.NoError(t, session.Close())
+}
+
+func TestBackupUnknownHost(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	opts := newSessionTestOptions()
+	s, err := newSession(opts)
+	assert.NoError(t, err)
+	session := s.(*session)
+
+	mockHostQueues(ctrl, session, sessionTestReplicas, nil)
+
+	assert.NoError(t, session.Open())
+
+	_, err = s.Backup(ident.StringID("metrics"), "backup0", []string{"unknown"})
+	require.Error(t, err)
+
+	assert.NoError(t, session.Close())
+}
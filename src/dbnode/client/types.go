@@ -259,6 +259,25 @@ type AdminSession interface {
 	// Truncate will truncate the namespace for a given shard.
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteSeries deletes the datapoints within the given time range of the
+	// series matching the query from all replicas, returning the number of
+	// series deleted summed across replicas.
+	DeleteSeries(namespace ident.ID, q index.Query, start, end xtime.UnixNano) (int64, error)
+
+	// Backup backs up the namespace on the given hosts, or all hosts if none
+	// are specified, returning the result of each host.
+	Backup(namespace ident.ID, backupID string, hosts []string) ([]BackupHostResult, error)
+
+	// Restore restores the namespace from the backup with the given ID or,
+	// if empty, the latest backup taken at or before the point in time on
+	// the given hosts, or all hosts if none are specified.
+	Restore(
+		namespace ident.ID,
+		backupID string,
+		pointInTime xtime.UnixNano,
+		hosts []string,
+	) ([]BackupHostResult, error)
+
 	// FetchBootstrapBlocksFromPeers will fetch the most fulfilled block
 	// for each series using the runtime configurable bootstrap level consistency.
 	FetchBootstrapBlocksFromPeers(
@@ -313,6 +332,14 @@ type AdminSession interface {
 	) (rpc.TChanNode, Channel, error)
 }
 
+// BackupHostResult is the result of a backup or restore on a host.
+type BackupHostResult struct {
+	HostID   string
+	BackupID string
+	NumFiles int64
+	NumBytes int64
+}
+
 // BorrowConnectionOptions are options to use when borrowing a connection
 type BorrowConnectionOptions struct {
 	// ContinueOnBorrowError allows skipping hosts that cannot borrow
@@ -470,6 +497,12 @@ type Options interface {
 	// TruncateRequestTimeout returns the truncateRequestTimeout.
 	TruncateRequestTimeout() time.Duration
 
+	// SetBackupRequestTimeout sets the backupRequestTimeout.
+	SetBackupRequestTimeout(value time.Duration) Options
+
+	// BackupRequestTimeout returns the backupRequestTimeout.
+	BackupRequestTimeout() time.Duration
+
 	// SetBackgroundConnectInterval sets the backgroundConnectInterval.
 	SetBackgroundConnectInterval(value time.Duration) Options
 
@@ -148,6 +148,10 @@ type Payload struct {
 	OpenMetricsHandleValueResets bool                  `protobuf:"varint,2,opt,name=open_metrics_handle_value_resets,json=openMetricsHandleValueResets,proto3" json:"open_metrics_handle_value_resets,omitempty"`
 	// Used when source_format == GRAPHITE
 	GraphiteType GraphiteType `protobuf:"varint,4,opt,name=graphite_type,json=graphiteType,proto3,enum=annotation.GraphiteType" json:"graphite_type,omitempty"`
+	// Used when open_metrics_family_type == HISTOGRAM or GAUGE_HISTOGRAM and the
+	// datapoint is a native (sparse) histogram. Holds the full histogram of the
+	// datapoint as encoded by the query storage histogram package.
+	NativeHistogram []byte `protobuf:"bytes,5,opt,name=native_histogram,json=nativeHistogram,proto3" json:"native_histogram,omitempty"`
 }
 
 func (m *Payload) Reset()                    { *m = Payload{} }
@@ -183,6 +187,13 @@ func (m *Payload) GetGraphiteType() GraphiteType {
 	return GraphiteType_GRAPHITE_UNKNOWN
 }
 
+func (m *Payload) GetNativeHistogram() []byte {
+	if m != nil {
+		return m.NativeHistogram
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Payload)(nil), "annotation.Payload")
 	proto.RegisterEnum("annotation.SourceFormat", SourceFormat_name, SourceFormat_value)
@@ -229,6 +240,12 @@ func (m *Payload) MarshalTo(dAtA []byte) (int, error) {
 		i++
 		i = encodeVarintAnnotation(dAtA, i, uint64(m.GraphiteType))
 	}
+	if len(m.NativeHistogram) > 0 {
+		dAtA[i] = 0x2a
+		i++
+		i = encodeVarintAnnotation(dAtA, i, uint64(len(m.NativeHistogram)))
+		i += copy(dAtA[i:], m.NativeHistogram)
+	}
 	return i, nil
 }
 
@@ -256,6 +273,10 @@ func (m *Payload) Size() (n int) {
 	if m.GraphiteType != 0 {
 		n += 1 + sovAnnotation(uint64(m.GraphiteType))
 	}
+	l = len(m.NativeHistogram)
+	if l > 0 {
+		n += 1 + l + sovAnnotation(uint64(l))
+	}
 	return n
 }
 
@@ -378,6 +399,37 @@ func (m *Payload) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NativeHistogram", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowAnnotation
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthAnnotation
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NativeHistogram = append(m.NativeHistogram[:0], dAtA[iNdEx:postIndex]...)
+			if m.NativeHistogram == nil {
+				m.NativeHistogram = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipAnnotation(dAtA[iNdEx:])
@@ -509,33 +561,34 @@ func init() {
 }
 
 var fileDescriptorAnnotation = []byte{
-	// 441 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0x86, 0xe3, 0x24, 0x6d, 0xd2, 0xc1, 0x2d, 0xab, 0x85, 0x4a, 0x3e, 0xa0, 0x28, 0x70, 0xaa,
-	0x72, 0x88, 0x25, 0x72, 0xe6, 0x60, 0x2a, 0xc7, 0xb1, 0x90, 0xed, 0x68, 0xed, 0x80, 0xe0, 0xb2,
-	0x5a, 0xc7, 0xdb, 0xc4, 0x52, 0xec, 0xb5, 0xec, 0x0d, 0x52, 0x1e, 0x80, 0x3b, 0xef, 0xc1, 0x8b,
-	0x70, 0xe4, 0x11, 0x50, 0x78, 0x11, 0xe4, 0x2d, 0x25, 0x8b, 0xe8, 0xcd, 0xf3, 0xcf, 0x37, 0xa3,
-	0x6f, 0xac, 0x05, 0x7f, 0x93, 0xcb, 0xed, 0x3e, 0x9d, 0xae, 0x45, 0x61, 0x17, 0xb3, 0x2c, 0xb5,
-	0x8b, 0x99, 0xdd, 0xd4, 0x6b, 0x3b, 0x4b, 0x4b, 0x91, 0x71, 0x7b, 0xc3, 0x4b, 0x5e, 0x33, 0xc9,
-	0x33, 0xbb, 0xaa, 0x85, 0x14, 0x36, 0x2b, 0x4b, 0x21, 0x99, 0xcc, 0x45, 0xa9, 0x7d, 0x4e, 0x55,
-	0x0f, 0xc3, 0x29, 0x79, 0xf5, 0xad, 0x0b, 0x83, 0x25, 0x3b, 0xec, 0x04, 0xcb, 0xf0, 0x27, 0xb0,
-	0x44, 0xc5, 0x4b, 0x5a, 0x70, 0x59, 0xe7, 0xeb, 0x86, 0xde, 0xb1, 0x22, 0xdf, 0x1d, 0xa8, 0x3c,
-	0x54, 0xdc, 0x32, 0xc6, 0xc6, 0xcd, 0xd5, 0xeb, 0x97, 0x53, 0x6d, 0x59, 0x54, 0xf1, 0x32, 0xb8,
-	0x47, 0xe7, 0x8a, 0x4c, 0x0e, 0x15, 0x27, 0xd7, 0xe2, 0xb1, 0x18, 0xcf, 0x61, 0xfc, 0xcf, 0xee,
-	0x2d, 0x2b, 0xb3, 0x1d, 0xa7, 0x9f, 0xd9, 0x6e, 0xcf, 0x69, 0xcd, 0x1b, 0x2e, 0x1b, 0xab, 0x3b,
-	0x36, 0x6e, 0x86, 0xe4, 0x85, 0xb6, 0x60, 0xa1, 0xa8, 0xf7, 0x2d, 0x44, 0x14, 0x83, 0xdf, 0xc0,
-	0x65, 0x23, 0xf6, 0xf5, 0x9a, 0xd3, 0x3b, 0x51, 0x17, 0x4c, 0x5a, 0x3d, 0x25, 0x66, 0xe9, 0x62,
-	0xb1, 0x02, 0xe6, 0xaa, 0x4f, 0xcc, 0x46, 0xab, 0xda, 0xf1, 0x4d, 0xcd, 0xaa, 0x6d, 0x2e, 0xf9,
-	0xfd, 0x5d, 0xfd, 0xff, 0xc7, 0xbd, 0x3f, 0x80, 0x3a, 0xc7, 0xdc, 0x68, 0xd5, 0x64, 0x0a, 0xa6,
-	0xbe, 0x1c, 0x23, 0x30, 0xa3, 0xa5, 0x1b, 0xd2, 0xc0, 0x4d, 0x88, 0x7f, 0x1b, 0xa3, 0x0e, 0x36,
-	0x61, 0xe8, 0x11, 0x67, 0xb9, 0xf0, 0x13, 0x17, 0x19, 0x93, 0x2f, 0x06, 0x5c, 0x3f, 0xfa, 0x9b,
-	0xf0, 0x13, 0x18, 0xac, 0xc2, 0x77, 0x61, 0xf4, 0x21, 0x44, 0x9d, 0xb6, 0xb8, 0x8d, 0x56, 0x61,
-	0xe2, 0x12, 0x64, 0xe0, 0x0b, 0x38, 0xf3, 0x9c, 0x95, 0xe7, 0xa2, 0x2e, 0xbe, 0x84, 0x8b, 0x85,
-	0x1f, 0x27, 0x91, 0x47, 0x9c, 0x00, 0xf5, 0xf0, 0x33, 0x78, 0xaa, 0x3a, 0xf4, 0x14, 0xf6, 0xdb,
-	0xd9, 0x78, 0x15, 0x04, 0x0e, 0xf9, 0x88, 0xce, 0xf0, 0x10, 0xfa, 0x7e, 0x38, 0x8f, 0xd0, 0x79,
-	0xeb, 0x11, 0x27, 0x4e, 0xe2, 0xc6, 0x6e, 0x82, 0x06, 0x93, 0x14, 0x4c, 0xfd, 0x2a, 0xfc, 0x1c,
-	0xd0, 0x83, 0x25, 0x3d, 0x69, 0xe8, 0xe9, 0xc9, 0x07, 0xc3, 0xd5, 0xdf, 0xf4, 0x41, 0x4c, 0xcf,
-	0x12, 0x3f, 0x70, 0x09, 0xea, 0xbd, 0x45, 0xdf, 0x8f, 0x23, 0xe3, 0xc7, 0x71, 0x64, 0xfc, 0x3c,
-	0x8e, 0x8c, 0xaf, 0xbf, 0x46, 0x9d, 0xf4, 0x5c, 0x3d, 0xb7, 0xd9, 0xef, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x36, 0xb4, 0x60, 0xa4, 0xbb, 0x02, 0x00, 0x00,
+	// 462 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xcf, 0x8e, 0xd3, 0x30,
+	0x10, 0x87, 0xeb, 0xfe, 0xd9, 0x76, 0x4d, 0x76, 0xd7, 0x32, 0xac, 0x94, 0x03, 0xaa, 0x0a, 0xa7,
+	0xd2, 0x43, 0x23, 0xd1, 0x33, 0x87, 0xb2, 0x4a, 0xdb, 0x08, 0x25, 0xa9, 0x9c, 0x14, 0x04, 0x17,
+	0xcb, 0x69, 0xbc, 0x69, 0xa4, 0x26, 0x8e, 0x12, 0x77, 0xa5, 0x3e, 0x00, 0x77, 0x1e, 0x8b, 0x23,
+	0x27, 0xce, 0xa8, 0xbc, 0x08, 0x8a, 0x4b, 0xa9, 0xd1, 0xee, 0x2d, 0xf3, 0xcd, 0x6f, 0x46, 0x9f,
+	0x47, 0x81, 0x4e, 0x92, 0xca, 0xcd, 0x2e, 0x1a, 0xaf, 0x45, 0x66, 0x65, 0x93, 0x38, 0xb2, 0xb2,
+	0x89, 0x55, 0x95, 0x6b, 0x2b, 0x8e, 0x72, 0x11, 0x73, 0x2b, 0xe1, 0x39, 0x2f, 0x99, 0xe4, 0xb1,
+	0x55, 0x94, 0x42, 0x0a, 0x8b, 0xe5, 0xb9, 0x90, 0x4c, 0xa6, 0x22, 0xd7, 0x3e, 0xc7, 0xaa, 0x87,
+	0xe1, 0x99, 0xbc, 0xfe, 0xd9, 0x84, 0xdd, 0x25, 0xdb, 0x6f, 0x05, 0x8b, 0xf1, 0x17, 0x68, 0x8a,
+	0x82, 0xe7, 0x34, 0xe3, 0xb2, 0x4c, 0xd7, 0x15, 0xbd, 0x67, 0x59, 0xba, 0xdd, 0x53, 0xb9, 0x2f,
+	0xb8, 0x09, 0x06, 0x60, 0x78, 0xfd, 0xf6, 0xd5, 0x58, 0x5b, 0xe6, 0x17, 0x3c, 0x77, 0x8f, 0xd1,
+	0x99, 0x4a, 0x86, 0xfb, 0x82, 0x93, 0x5b, 0xf1, 0x14, 0xc6, 0x33, 0x38, 0xf8, 0x6f, 0xf7, 0x86,
+	0xe5, 0xf1, 0x96, 0xd3, 0x07, 0xb6, 0xdd, 0x71, 0x5a, 0xf2, 0x8a, 0xcb, 0xca, 0x6c, 0x0e, 0xc0,
+	0xb0, 0x47, 0x5e, 0x6a, 0x0b, 0x16, 0x2a, 0xf5, 0xb1, 0x0e, 0x11, 0x95, 0xc1, 0xef, 0xe0, 0x55,
+	0x25, 0x76, 0xe5, 0x9a, 0xd3, 0x7b, 0x51, 0x66, 0x4c, 0x9a, 0x2d, 0x25, 0x66, 0xea, 0x62, 0x81,
+	0x0a, 0xcc, 0x54, 0x9f, 0x18, 0x95, 0x56, 0xd5, 0xe3, 0x49, 0xc9, 0x8a, 0x4d, 0x2a, 0xf9, 0xf1,
+	0x5d, 0xed, 0xc7, 0xe3, 0xf3, 0xbf, 0x01, 0xf5, 0x1c, 0x23, 0xd1, 0x2a, 0xfc, 0x06, 0xa2, 0x9c,
+	0xc9, 0xf4, 0x81, 0xd3, 0x4d, 0x5a, 0x49, 0x91, 0x94, 0x2c, 0x33, 0x3b, 0x03, 0x30, 0x34, 0xc8,
+	0xcd, 0x91, 0x2f, 0x4e, 0x78, 0x34, 0x86, 0x86, 0xee, 0x81, 0x11, 0x34, 0xfc, 0xa5, 0xed, 0x51,
+	0xd7, 0x0e, 0x89, 0x73, 0x17, 0xa0, 0x06, 0x36, 0x60, 0x6f, 0x4e, 0xa6, 0xcb, 0x85, 0x13, 0xda,
+	0x08, 0x8c, 0xbe, 0x02, 0x78, 0xfb, 0xe4, 0x45, 0xf1, 0x33, 0xd8, 0x5d, 0x79, 0x1f, 0x3c, 0xff,
+	0x93, 0x87, 0x1a, 0x75, 0x71, 0xe7, 0xaf, 0xbc, 0xd0, 0x26, 0x08, 0xe0, 0x4b, 0xd8, 0x99, 0x4f,
+	0x57, 0x73, 0x1b, 0x35, 0xf1, 0x15, 0xbc, 0x5c, 0x38, 0x41, 0xe8, 0xcf, 0xc9, 0xd4, 0x45, 0x2d,
+	0xfc, 0x1c, 0xde, 0xa8, 0x0e, 0x3d, 0xc3, 0x76, 0x3d, 0x1b, 0xac, 0x5c, 0x77, 0x4a, 0x3e, 0xa3,
+	0x0e, 0xee, 0xc1, 0xb6, 0xe3, 0xcd, 0x7c, 0x74, 0x51, 0x7b, 0x04, 0xe1, 0x34, 0xb4, 0x03, 0x3b,
+	0x44, 0xdd, 0x51, 0x04, 0x0d, 0xfd, 0x00, 0xf8, 0x05, 0x44, 0x27, 0x4b, 0x7a, 0xd6, 0xd0, 0xe9,
+	0xd9, 0x07, 0xc3, 0xeb, 0x7f, 0xf4, 0x24, 0xa6, 0xb3, 0xd0, 0x71, 0x6d, 0x82, 0x5a, 0xef, 0xd1,
+	0xf7, 0x43, 0x1f, 0xfc, 0x38, 0xf4, 0xc1, 0xaf, 0x43, 0x1f, 0x7c, 0xfb, 0xdd, 0x6f, 0x44, 0x17,
+	0xea, 0xcf, 0x9c, 0xfc, 0x19, 0x00, 0x96, 0x24, 0x82, 0x8d, 0xe6, 0x02, 0x00, 0x00,
 }
@@ -56,14 +56,16 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
 type IndexVolumeInfo struct {
-	MajorVersion    int64                        `protobuf:"varint,1,opt,name=majorVersion,proto3" json:"majorVersion,omitempty"`
-	BlockStart      int64                        `protobuf:"varint,2,opt,name=blockStart,proto3" json:"blockStart,omitempty"`
-	BlockSize       int64                        `protobuf:"varint,3,opt,name=blockSize,proto3" json:"blockSize,omitempty"`
-	FileType        int64                        `protobuf:"varint,4,opt,name=fileType,proto3" json:"fileType,omitempty"`
-	Shards          []uint32                     `protobuf:"varint,5,rep,packed,name=shards" json:"shards,omitempty"`
-	SnapshotTime    int64                        `protobuf:"varint,6,opt,name=snapshotTime,proto3" json:"snapshotTime,omitempty"`
-	Segments        []*SegmentInfo               `protobuf:"bytes,7,rep,name=segments" json:"segments,omitempty"`
-	IndexVolumeType *google_protobuf.StringValue `protobuf:"bytes,8,opt,name=indexVolumeType" json:"indexVolumeType,omitempty"`
+	MajorVersion     int64                        `protobuf:"varint,1,opt,name=majorVersion,proto3" json:"majorVersion,omitempty"`
+	BlockStart       int64                        `protobuf:"varint,2,opt,name=blockStart,proto3" json:"blockStart,omitempty"`
+	BlockSize        int64                        `protobuf:"varint,3,opt,name=blockSize,proto3" json:"blockSize,omitempty"`
+	FileType         int64                        `protobuf:"varint,4,opt,name=fileType,proto3" json:"fileType,omitempty"`
+	Shards           []uint32                     `protobuf:"varint,5,rep,packed,name=shards" json:"shards,omitempty"`
+	SnapshotTime     int64                        `protobuf:"varint,6,opt,name=snapshotTime,proto3" json:"snapshotTime,omitempty"`
+	Segments         []*SegmentInfo               `protobuf:"bytes,7,rep,name=segments" json:"segments,omitempty"`
+	IndexVolumeType  *google_protobuf.StringValue `protobuf:"bytes,8,opt,name=indexVolumeType" json:"indexVolumeType,omitempty"`
+	EncryptionKeyID  string                       `protobuf:"bytes,9,opt,name=encryptionKeyID,proto3" json:"encryptionKeyID,omitempty"`
+	EncryptedDataKey []byte                       `protobuf:"bytes,10,opt,name=encryptedDataKey,proto3" json:"encryptedDataKey,omitempty"`
 }
 
 func (m *IndexVolumeInfo) Reset()                    { *m = IndexVolumeInfo{} }
@@ -127,6 +129,20 @@ func (m *IndexVolumeInfo) GetIndexVolumeType() *google_protobuf.StringValue {
 	return nil
 }
 
+func (m *IndexVolumeInfo) GetEncryptionKeyID() string {
+	if m != nil {
+		return m.EncryptionKeyID
+	}
+	return ""
+}
+
+func (m *IndexVolumeInfo) GetEncryptedDataKey() []byte {
+	if m != nil {
+		return m.EncryptedDataKey
+	}
+	return nil
+}
+
 type SegmentInfo struct {
 	SegmentType  string             `protobuf:"bytes,1,opt,name=segmentType,proto3" json:"segmentType,omitempty"`
 	MajorVersion int64              `protobuf:"varint,2,opt,name=majorVersion,proto3" json:"majorVersion,omitempty"`
@@ -350,6 +366,18 @@ func (m *IndexVolumeInfo) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n3
 	}
+	if len(m.EncryptionKeyID) > 0 {
+		dAtA[i] = 0x4a
+		i++
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.EncryptionKeyID)))
+		i += copy(dAtA[i:], m.EncryptionKeyID)
+	}
+	if len(m.EncryptedDataKey) > 0 {
+		dAtA[i] = 0x52
+		i++
+		i = encodeVarintIndex(dAtA, i, uint64(len(m.EncryptedDataKey)))
+		i += copy(dAtA[i:], m.EncryptedDataKey)
+	}
 	return i, nil
 }
 
@@ -573,6 +601,14 @@ func (m *IndexVolumeInfo) Size() (n int) {
 		l = m.IndexVolumeType.Size()
 		n += 1 + l + sovIndex(uint64(l))
 	}
+	l = len(m.EncryptionKeyID)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
+	l = len(m.EncryptedDataKey)
+	if l > 0 {
+		n += 1 + l + sovIndex(uint64(l))
+	}
 	return n
 }
 
@@ -919,6 +955,66 @@ func (m *IndexVolumeInfo) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptionKeyID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= (uint64(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptionKeyID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EncryptedDataKey", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIndex
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIndex
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EncryptedDataKey = append(m.EncryptedDataKey[:0], dAtA[iNdEx:postIndex]...)
+			if m.EncryptedDataKey == nil {
+				m.EncryptedDataKey = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIndex(dAtA[iNdEx:])
@@ -1616,36 +1712,38 @@ func init() {
 }
 
 var fileDescriptorIndex = []byte{
-	// 484 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x92, 0xcf, 0x8a, 0xdb, 0x30,
-	0x10, 0xc6, 0x6b, 0xbb, 0x49, 0xb3, 0xe3, 0xa4, 0x69, 0x45, 0x59, 0xcc, 0xb2, 0x18, 0xe3, 0x93,
-	0x0f, 0xc5, 0x86, 0xe4, 0xd8, 0x42, 0xa1, 0x94, 0x85, 0xbd, 0x3a, 0xdb, 0xdc, 0xe5, 0x58, 0x71,
-	0xd4, 0xda, 0x92, 0x91, 0x14, 0xfa, 0xe7, 0x29, 0xfa, 0x4a, 0xa5, 0x97, 0x1e, 0xfb, 0x08, 0x25,
-	0x7d, 0x91, 0x22, 0xc9, 0x9b, 0x38, 0xc9, 0x1e, 0xf6, 0x12, 0xf8, 0xbe, 0xf9, 0xe4, 0x99, 0xf9,
-	0x65, 0xe0, 0x5d, 0x45, 0xd5, 0x66, 0x5b, 0xa4, 0x2b, 0xde, 0x64, 0xcd, 0xbc, 0x2c, 0xb2, 0x66,
-	0x9e, 0x49, 0xb1, 0xca, 0xca, 0x82, 0xf1, 0x92, 0x64, 0x15, 0x61, 0x44, 0x60, 0x45, 0xca, 0xac,
-	0x15, 0x5c, 0xf1, 0x8c, 0xb2, 0x92, 0x7c, 0xb5, 0xbf, 0xa9, 0x71, 0xd0, 0xc0, 0x88, 0xab, 0xb0,
-	0xe2, 0xbc, 0xaa, 0x89, 0x8d, 0x15, 0xdb, 0x75, 0xf6, 0x45, 0xe0, 0xb6, 0x25, 0x42, 0xda, 0x58,
-	0xfc, 0xcb, 0x85, 0xe9, 0xad, 0x4e, 0x2e, 0x79, 0xbd, 0x6d, 0xc8, 0x2d, 0x5b, 0x73, 0x14, 0xc3,
-	0xb8, 0xc1, 0x9f, 0xb8, 0x58, 0x12, 0x21, 0x29, 0x67, 0x81, 0x13, 0x39, 0x89, 0x97, 0x1f, 0x79,
-	0x28, 0x04, 0x28, 0x6a, 0xbe, 0xfa, 0xbc, 0x50, 0x58, 0xa8, 0xc0, 0x35, 0x89, 0x9e, 0x83, 0xae,
-	0xe1, 0xc2, 0x2a, 0xfa, 0x9d, 0x04, 0x9e, 0x29, 0x1f, 0x0c, 0x74, 0x05, 0xa3, 0x35, 0xad, 0xc9,
-	0xdd, 0xb7, 0x96, 0x04, 0x4f, 0x4d, 0x71, 0xaf, 0xd1, 0x25, 0x0c, 0xe5, 0x06, 0x8b, 0x52, 0x06,
-	0x83, 0xc8, 0x4b, 0x26, 0x79, 0xa7, 0xf4, 0x54, 0x92, 0xe1, 0x56, 0x6e, 0xb8, 0xba, 0xa3, 0x0d,
-	0x09, 0x86, 0x76, 0xaa, 0xbe, 0x87, 0x52, 0x18, 0x49, 0x52, 0x35, 0x84, 0x29, 0x19, 0x3c, 0x8b,
-	0xbc, 0xc4, 0x9f, 0xa1, 0xd4, 0x42, 0x59, 0x58, 0x5b, 0xef, 0x97, 0xef, 0x33, 0xe8, 0x06, 0xa6,
-	0xf4, 0xb0, 0xbc, 0x19, 0x67, 0x14, 0x39, 0x89, 0x3f, 0xbb, 0x4e, 0x2d, 0xb7, 0xf4, 0x9e, 0x5b,
-	0xba, 0x50, 0x82, 0xb2, 0x6a, 0x89, 0xeb, 0x2d, 0xc9, 0x4f, 0x1f, 0xc5, 0x3f, 0x1d, 0xf0, 0x7b,
-	0x1d, 0x50, 0x04, 0x7e, 0xd7, 0xc3, 0x7c, 0x53, 0x03, 0xbc, 0xc8, 0xfb, 0xd6, 0x19, 0x63, 0xf7,
-	0x01, 0xc6, 0x3a, 0x43, 0xd9, 0x21, 0xe3, 0x75, 0x99, 0x9e, 0xa7, 0x49, 0x36, 0x44, 0xe1, 0x12,
-	0x2b, 0x6c, 0x48, 0x8e, 0xf3, 0xbd, 0x46, 0xaf, 0x61, 0xa0, 0xa9, 0x5a, 0x90, 0xfe, 0xec, 0xf2,
-	0x18, 0xc5, 0x0d, 0xad, 0xcd, 0xdf, 0x9d, 0xdb, 0x50, 0xfc, 0x06, 0xa6, 0x27, 0x15, 0x94, 0xc0,
-	0x54, 0x1e, 0xac, 0xde, 0x2a, 0xa7, 0x76, 0x5c, 0xc3, 0xd8, 0x5c, 0xd1, 0x07, 0x5a, 0x11, 0xa9,
-	0xa4, 0x3e, 0x0f, 0xca, 0xd6, 0xdc, 0x4a, 0xf3, 0x68, 0x92, 0xf7, 0x1c, 0xf4, 0x16, 0x9e, 0x77,
-	0x9f, 0xe8, 0x5e, 0x04, 0xae, 0x99, 0xf1, 0xd5, 0xf1, 0x8c, 0xb6, 0x98, 0x9f, 0x64, 0x63, 0x0c,
-	0x93, 0xa3, 0xc0, 0x23, 0x78, 0xa7, 0xf7, 0x2c, 0x6c, 0x9f, 0xe0, 0x9c, 0x45, 0xd7, 0xab, 0xa3,
-	0xf1, 0x11, 0x5e, 0x9e, 0xd5, 0x1e, 0xcf, 0x43, 0x1f, 0x71, 0x69, 0x77, 0x77, 0xcd, 0xee, 0x9d,
-	0x7a, 0xff, 0xe2, 0xf7, 0x2e, 0x74, 0xfe, 0xec, 0x42, 0xe7, 0xef, 0x2e, 0x74, 0x7e, 0xfc, 0x0b,
-	0x9f, 0x14, 0x43, 0x73, 0x61, 0xf3, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff, 0x48, 0x32, 0xee, 0x47,
-	0xf1, 0x03, 0x00, 0x00,
+	// 523 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x93, 0xc1, 0x8a, 0xdb, 0x3c,
+	0x14, 0x85, 0x7f, 0xc7, 0xff, 0x4c, 0x13, 0x25, 0x69, 0xa6, 0xa2, 0x0c, 0x62, 0x18, 0x8c, 0xf1,
+	0xca, 0x94, 0x62, 0x43, 0xb2, 0x6c, 0xa1, 0x50, 0xc2, 0x40, 0x98, 0x9d, 0x33, 0xcd, 0x5e, 0x8e,
+	0x6f, 0x1c, 0xb5, 0xb6, 0x64, 0x24, 0x85, 0x36, 0x7d, 0x8a, 0x6e, 0xfb, 0x38, 0xdd, 0x75, 0xd9,
+	0x47, 0x28, 0xe9, 0x8b, 0x14, 0x4b, 0x9e, 0xc4, 0x49, 0xba, 0x98, 0x4d, 0xe0, 0x7e, 0xf7, 0x28,
+	0x3a, 0xf7, 0x5c, 0x19, 0xbd, 0xcb, 0x99, 0x5e, 0x6f, 0xd2, 0x68, 0x29, 0xca, 0xb8, 0x9c, 0x64,
+	0x69, 0x5c, 0x4e, 0x62, 0x25, 0x97, 0x71, 0x96, 0x72, 0x91, 0x41, 0x9c, 0x03, 0x07, 0x49, 0x35,
+	0x64, 0x71, 0x25, 0x85, 0x16, 0x31, 0xe3, 0x19, 0x7c, 0xb1, 0xbf, 0x91, 0x21, 0xf8, 0xc2, 0x14,
+	0x37, 0x5e, 0x2e, 0x44, 0x5e, 0x80, 0x95, 0xa5, 0x9b, 0x55, 0xfc, 0x59, 0xd2, 0xaa, 0x02, 0xa9,
+	0xac, 0x2c, 0xf8, 0xee, 0xa2, 0xd1, 0xac, 0x56, 0x2e, 0x44, 0xb1, 0x29, 0x61, 0xc6, 0x57, 0x02,
+	0x07, 0x68, 0x50, 0xd2, 0x8f, 0x42, 0x2e, 0x40, 0x2a, 0x26, 0x38, 0x71, 0x7c, 0x27, 0x74, 0x93,
+	0x23, 0x86, 0x3d, 0x84, 0xd2, 0x42, 0x2c, 0x3f, 0xcd, 0x35, 0x95, 0x9a, 0x74, 0x8c, 0xa2, 0x45,
+	0xf0, 0x2d, 0xea, 0xd9, 0x8a, 0x7d, 0x05, 0xe2, 0x9a, 0xf6, 0x01, 0xe0, 0x1b, 0xd4, 0x5d, 0xb1,
+	0x02, 0x1e, 0xb6, 0x15, 0x90, 0xff, 0x4d, 0x73, 0x5f, 0xe3, 0x6b, 0x74, 0xa9, 0xd6, 0x54, 0x66,
+	0x8a, 0x5c, 0xf8, 0x6e, 0x38, 0x4c, 0x9a, 0xaa, 0x76, 0xa5, 0x38, 0xad, 0xd4, 0x5a, 0xe8, 0x07,
+	0x56, 0x02, 0xb9, 0xb4, 0xae, 0xda, 0x0c, 0x47, 0xa8, 0xab, 0x20, 0x2f, 0x81, 0x6b, 0x45, 0x9e,
+	0xf9, 0x6e, 0xd8, 0x1f, 0xe3, 0xc8, 0x86, 0x32, 0xb7, 0xb8, 0x9e, 0x2f, 0xd9, 0x6b, 0xf0, 0x1d,
+	0x1a, 0xb1, 0xc3, 0xf0, 0xc6, 0x4e, 0xd7, 0x77, 0xc2, 0xfe, 0xf8, 0x36, 0xb2, 0xb9, 0x45, 0x8f,
+	0xb9, 0x45, 0x73, 0x2d, 0x19, 0xcf, 0x17, 0xb4, 0xd8, 0x40, 0x72, 0x7a, 0x08, 0x87, 0x68, 0x04,
+	0x7c, 0x29, 0xb7, 0x95, 0x66, 0x82, 0xdf, 0xc3, 0x76, 0x36, 0x25, 0x3d, 0xdf, 0x09, 0x7b, 0xc9,
+	0x29, 0xc6, 0xaf, 0xd0, 0x55, 0x83, 0x20, 0x9b, 0x52, 0x4d, 0xef, 0x61, 0x4b, 0x90, 0xef, 0x84,
+	0x83, 0xe4, 0x8c, 0x07, 0x3f, 0x1c, 0xd4, 0x6f, 0xf9, 0xc6, 0x3e, 0xea, 0x37, 0xce, 0x8d, 0x53,
+	0xc7, 0xdc, 0xd0, 0x46, 0x67, 0x9b, 0xeb, 0xfc, 0x63, 0x73, 0xb5, 0x86, 0xf1, 0x83, 0xc6, 0x6d,
+	0x34, 0x2d, 0x56, 0xef, 0xa7, 0x04, 0x4d, 0x33, 0xaa, 0xa9, 0xd9, 0xcf, 0x20, 0xd9, 0xd7, 0xf8,
+	0x35, 0xba, 0xa8, 0x77, 0x65, 0xd7, 0xd3, 0x1f, 0x5f, 0x1f, 0x07, 0x7c, 0xc7, 0x0a, 0xf3, 0x88,
+	0x12, 0x2b, 0x0a, 0xde, 0xa0, 0xd1, 0x49, 0xa7, 0x0e, 0x4b, 0x1d, 0x50, 0x6b, 0x94, 0x53, 0x1c,
+	0x14, 0x68, 0x60, 0xde, 0xe6, 0x94, 0xe5, 0xa0, 0xb4, 0xaa, 0x1f, 0x1d, 0xe3, 0x2b, 0x61, 0x4b,
+	0x73, 0x68, 0x98, 0xb4, 0x08, 0x7e, 0x8b, 0x9e, 0x37, 0x7f, 0xd1, 0x9c, 0x20, 0x1d, 0xe3, 0xf1,
+	0xe5, 0xb1, 0x47, 0xdb, 0x4c, 0x4e, 0xb4, 0x01, 0x45, 0xc3, 0x23, 0xc1, 0x13, 0xf2, 0x8e, 0x1e,
+	0xb3, 0xb0, 0xf7, 0x90, 0xf3, 0x2c, 0x9a, 0xbb, 0x9a, 0x34, 0x3e, 0xa0, 0x17, 0x67, 0xbd, 0xa7,
+	0xe7, 0x51, 0x7f, 0x1a, 0x99, 0x9d, 0xbd, 0x63, 0x66, 0x6f, 0xaa, 0xf7, 0x57, 0x3f, 0x77, 0x9e,
+	0xf3, 0x6b, 0xe7, 0x39, 0xbf, 0x77, 0x9e, 0xf3, 0xed, 0x8f, 0xf7, 0x5f, 0x7a, 0x69, 0xde, 0xed,
+	0xe4, 0xef, 0x00, 0x51, 0xb8, 0xa9, 0x00, 0x47, 0x04, 0x00, 0x00,
 }
@@ -65,6 +65,34 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion2 // please upgrade the proto package
 
+// CompressionType is the compression applied to the filesets of a namespace.
+type CompressionType int32
+
+const (
+	// Filesets are not compressed.
+	CompressionType_NO_COMPRESSION CompressionType = 0
+	// Filesets are compressed using Zstandard.
+	CompressionType_ZSTD_COMPRESSION CompressionType = 1
+	// Filesets are compressed using Snappy.
+	CompressionType_SNAPPY_COMPRESSION CompressionType = 2
+)
+
+var CompressionType_name = map[int32]string{
+	0: "NO_COMPRESSION",
+	1: "ZSTD_COMPRESSION",
+	2: "SNAPPY_COMPRESSION",
+}
+var CompressionType_value = map[string]int32{
+	"NO_COMPRESSION":     0,
+	"ZSTD_COMPRESSION":   1,
+	"SNAPPY_COMPRESSION": 2,
+}
+
+func (x CompressionType) String() string {
+	return proto.EnumName(CompressionType_name, int32(x))
+}
+func (CompressionType) EnumDescriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{0} }
+
 // StagingStatus represents the current status of the namespace.
 type StagingStatus int32
 
@@ -91,7 +119,7 @@ var StagingStatus_value = map[string]int32{
 func (x StagingStatus) String() string {
 	return proto.EnumName(StagingStatus_name, int32(x))
 }
-func (StagingStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{0} }
+func (StagingStatus) EnumDescriptor() ([]byte, []int) { return fileDescriptorNamespace, []int{1} }
 
 type RetentionOptions struct {
 	RetentionPeriodNanos                     int64 `protobuf:"varint,1,opt,name=retentionPeriodNanos,proto3" json:"retentionPeriodNanos,omitempty"`
@@ -196,6 +224,7 @@ type NamespaceOptions struct {
 	CacheBlocksOnRetrieve *google_protobuf1.BoolValue `protobuf:"bytes,12,opt,name=cacheBlocksOnRetrieve" json:"cacheBlocksOnRetrieve,omitempty"`
 	AggregationOptions    *AggregationOptions         `protobuf:"bytes,13,opt,name=aggregationOptions" json:"aggregationOptions,omitempty"`
 	StagingState          *StagingState               `protobuf:"bytes,14,opt,name=stagingState" json:"stagingState,omitempty"`
+	Compression           CompressionType             `protobuf:"varint,15,opt,name=compression,proto3,enum=namespace.CompressionType" json:"compression,omitempty"`
 	// Use larger field ID to ensure new fields are always added before extended options.
 	ExtendedOptions *ExtendedOptions `protobuf:"bytes,1000,opt,name=extendedOptions" json:"extendedOptions,omitempty"`
 }
@@ -303,6 +332,13 @@ func (m *NamespaceOptions) GetStagingState() *StagingState {
 	return nil
 }
 
+func (m *NamespaceOptions) GetCompression() CompressionType {
+	if m != nil {
+		return m.Compression
+	}
+	return CompressionType_NO_COMPRESSION
+}
+
 func (m *NamespaceOptions) GetExtendedOptions() *ExtendedOptions {
 	if m != nil {
 		return m.ExtendedOptions
@@ -499,6 +535,7 @@ func init() {
 	proto.RegisterType((*Registry)(nil), "namespace.Registry")
 	proto.RegisterType((*NamespaceRuntimeOptions)(nil), "namespace.NamespaceRuntimeOptions")
 	proto.RegisterType((*ExtendedOptions)(nil), "namespace.ExtendedOptions")
+	proto.RegisterEnum("namespace.CompressionType", CompressionType_name, CompressionType_value)
 	proto.RegisterEnum("namespace.StagingStatus", StagingStatus_name, StagingStatus_value)
 }
 func (m *RetentionOptions) Marshal() (dAtA []byte, err error) {
@@ -747,6 +784,11 @@ func (m *NamespaceOptions) MarshalTo(dAtA []byte) (int, error) {
 		}
 		i += n7
 	}
+	if m.Compression != 0 {
+		dAtA[i] = 0x78
+		i++
+		i = encodeVarintNamespace(dAtA, i, uint64(m.Compression))
+	}
 	if m.ExtendedOptions != nil {
 		dAtA[i] = 0xc2
 		i++
@@ -1132,6 +1174,9 @@ func (m *NamespaceOptions) Size() (n int) {
 		l = m.StagingState.Size()
 		n += 1 + l + sovNamespace(uint64(l))
 	}
+	if m.Compression != 0 {
+		n += 1 + sovNamespace(uint64(m.Compression))
+	}
 	if m.ExtendedOptions != nil {
 		l = m.ExtendedOptions.Size()
 		n += 2 + l + sovNamespace(uint64(l))
@@ -1928,6 +1973,25 @@ func (m *NamespaceOptions) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Compression", wireType)
+			}
+			m.Compression = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowNamespace
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Compression |= (CompressionType(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		case 1000:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedOptions", wireType)
@@ -2918,68 +2982,71 @@ func init() {
 }
 
 var fileDescriptorNamespace = []byte{
-	// 1001 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x96, 0xdd, 0x6e, 0x1b, 0x45,
-	0x14, 0x80, 0xbb, 0xf9, 0x73, 0x72, 0xec, 0x24, 0xce, 0xa8, 0x10, 0x2b, 0x2d, 0xa6, 0x5a, 0x7e,
-	0x14, 0x55, 0xc8, 0xa6, 0xc9, 0x0d, 0x14, 0xa9, 0xe0, 0x24, 0x26, 0x72, 0x29, 0x8e, 0x35, 0x69,
-	0x29, 0xe4, 0x6e, 0x76, 0xf7, 0x78, 0xb3, 0xea, 0x7a, 0x66, 0x35, 0x33, 0xdb, 0x24, 0x3c, 0x43,
-	0x2f, 0x78, 0x0f, 0x5e, 0x84, 0x4b, 0x1e, 0x01, 0x05, 0x21, 0x71, 0xc3, 0x3b, 0xa0, 0x9d, 0xf5,
-	0x3a, 0xfb, 0xe3, 0x96, 0x88, 0x9b, 0x68, 0x72, 0xce, 0x77, 0x7e, 0xe6, 0xfc, 0xcc, 0x1a, 0x8e,
-	0xfd, 0x40, 0x9f, 0xc7, 0x4e, 0xc7, 0x15, 0x93, 0xee, 0x64, 0xdf, 0x73, 0xba, 0x93, 0xfd, 0xae,
-	0x92, 0x6e, 0xd7, 0x73, 0xb8, 0xf0, 0xb0, 0xeb, 0x23, 0x47, 0xc9, 0x34, 0x7a, 0xdd, 0x48, 0x0a,
-	0x2d, 0xba, 0x9c, 0x4d, 0x50, 0x45, 0xcc, 0xc5, 0x9b, 0x53, 0xc7, 0x68, 0xc8, 0xda, 0x4c, 0xb0,
-	0x73, 0xdf, 0x17, 0xc2, 0x0f, 0x31, 0x35, 0x71, 0xe2, 0x71, 0x57, 0x69, 0x19, 0xbb, 0x3a, 0x05,
-	0x77, 0xda, 0x65, 0xed, 0x85, 0x64, 0x51, 0x84, 0x52, 0x4d, 0xf5, 0x47, 0xff, 0x37, 0x23, 0xe5,
-	0x9e, 0xe3, 0x84, 0xa5, 0x5e, 0xec, 0x37, 0x8b, 0xd0, 0xa4, 0xa8, 0x91, 0xeb, 0x40, 0xf0, 0x93,
-	0x28, 0xf9, 0xab, 0xc8, 0x1e, 0xdc, 0x95, 0x99, 0x6c, 0x84, 0x32, 0x10, 0xde, 0x90, 0x71, 0xa1,
-	0x5a, 0xd6, 0x03, 0x6b, 0x77, 0x91, 0xce, 0xd5, 0x91, 0x4f, 0x61, 0xc3, 0x09, 0x85, 0xfb, 0xea,
-	0x34, 0xf8, 0x19, 0x53, 0x7a, 0xc1, 0xd0, 0x25, 0x29, 0xf9, 0x0c, 0xb6, 0x9c, 0x78, 0x3c, 0x46,
-	0xf9, 0x6d, 0xac, 0x63, 0x39, 0x45, 0x17, 0x0d, 0x5a, 0x55, 0x90, 0x5d, 0xd8, 0x4c, 0x85, 0x23,
-	0xa6, 0x74, 0xca, 0x2e, 0x19, 0xb6, 0x2c, 0x36, 0x64, 0x12, 0xe9, 0x88, 0x69, 0xd6, 0xbf, 0x8c,
-	0x02, 0x79, 0xd5, 0x5a, 0x7e, 0x60, 0xed, 0xae, 0xd2, 0xb2, 0x98, 0x9c, 0xc1, 0x6e, 0x49, 0xd4,
-	0x1b, 0x6b, 0x94, 0x43, 0xa1, 0x7b, 0xae, 0x8b, 0x4a, 0xe5, 0x6f, 0xbc, 0x62, 0x82, 0xdd, 0x9a,
-	0x27, 0x4f, 0x60, 0x67, 0x6c, 0xd2, 0xa7, 0xf3, 0xea, 0x57, 0x33, 0xde, 0xde, 0x41, 0xd8, 0x23,
-	0x68, 0x0c, 0xb8, 0x87, 0x97, 0x59, 0x27, 0x5a, 0x50, 0x43, 0xce, 0x9c, 0x10, 0x3d, 0x53, 0xfc,
-	0x55, 0x9a, 0xfd, 0x7b, 0xdb, 0x7a, 0xdb, 0xff, 0xac, 0x40, 0x73, 0x98, 0xf5, 0x3e, 0x73, 0xfb,
-	0x10, 0x9a, 0x8e, 0x10, 0x5a, 0x69, 0xc9, 0xa2, 0x7e, 0xc1, 0x7f, 0x45, 0x4e, 0x6c, 0x68, 0x8c,
-	0xc3, 0x58, 0x9d, 0x67, 0xdc, 0x82, 0xe1, 0x0a, 0xb2, 0xa4, 0xa9, 0x17, 0x32, 0xd0, 0xa8, 0x9e,
-	0x8b, 0x43, 0x31, 0x99, 0x04, 0xfa, 0x99, 0xf0, 0x4d, 0x53, 0x57, 0x69, 0x55, 0x91, 0xa4, 0xee,
-	0x86, 0xc8, 0x78, 0x3c, 0x8b, 0xbd, 0x64, 0xd0, 0x92, 0x94, 0x7c, 0x0c, 0xeb, 0x12, 0x23, 0x16,
-	0xc8, 0x0c, 0x4b, 0x1b, 0x5a, 0x14, 0x92, 0x63, 0x68, 0xca, 0xd2, 0x00, 0x9b, 0xb6, 0xd5, 0xf7,
-	0xee, 0x75, 0x6e, 0x96, 0xaf, 0x3c, 0xe3, 0xb4, 0x62, 0x94, 0x4c, 0x90, 0xe2, 0x2c, 0x52, 0xe7,
-	0x42, 0x67, 0x01, 0x6b, 0xe9, 0x04, 0x95, 0xc4, 0xe4, 0x2b, 0x68, 0x04, 0xb9, 0x2e, 0xb5, 0x56,
-	0x4d, 0xb8, 0xed, 0x5c, 0xb8, 0x7c, 0x13, 0x69, 0x01, 0x26, 0x4f, 0x60, 0x3d, 0xdd, 0xc0, 0xcc,
-	0x7a, 0xcd, 0x58, 0xb7, 0x72, 0xd6, 0xa7, 0x79, 0x3d, 0x2d, 0xe2, 0x49, 0xad, 0x5d, 0x11, 0x7a,
-	0x2f, 0x4d, 0x59, 0xb3, 0x44, 0x21, 0xad, 0x75, 0x45, 0x41, 0x9e, 0xc2, 0x86, 0x8c, 0xb9, 0x0e,
-	0x26, 0x59, 0xef, 0x5b, 0x75, 0x13, 0xce, 0xce, 0x85, 0x9b, 0x8d, 0x07, 0x2d, 0x90, 0xb4, 0x64,
-	0x49, 0x46, 0xf0, 0x9e, 0xcb, 0xdc, 0x73, 0x3c, 0x48, 0x26, 0x4c, 0x9d, 0x70, 0x8a, 0x5a, 0x06,
-	0xf8, 0x1a, 0x5b, 0x0d, 0xe3, 0x72, 0xa7, 0x93, 0xbe, 0x58, 0x9d, 0xec, 0xc5, 0xea, 0x1c, 0x08,
-	0x11, 0xfe, 0xc0, 0xc2, 0x18, 0xe9, 0x7c, 0x43, 0xf2, 0x3d, 0x10, 0xe6, 0xfb, 0x12, 0x7d, 0x96,
-	0xef, 0xde, 0xba, 0x71, 0xf7, 0x41, 0x2e, 0xc3, 0x5e, 0x05, 0xa2, 0x73, 0x0c, 0x93, 0xbe, 0x28,
-	0xcd, 0xfc, 0x80, 0xfb, 0xa7, 0x9a, 0x69, 0x6c, 0x6d, 0x54, 0xfa, 0x72, 0x9a, 0x53, 0xd3, 0x02,
-	0x4c, 0xfa, 0xb0, 0x89, 0x97, 0x1a, 0xb9, 0x87, 0x5e, 0x96, 0xc8, 0xdf, 0xb5, 0xe9, 0xc5, 0x6e,
-	0x1c, 0xf4, 0x8b, 0x08, 0x2d, 0xdb, 0xd8, 0x23, 0x20, 0xd5, 0x6c, 0xc9, 0x63, 0x68, 0xe4, 0xf2,
-	0x4d, 0x5e, 0xd2, 0xc5, 0xdd, 0xfa, 0xde, 0xfb, 0xf3, 0xaf, 0x48, 0x0b, 0xac, 0xcd, 0xa1, 0x9e,
-	0x53, 0x92, 0x36, 0x40, 0xa6, 0x9e, 0x6d, 0x6d, 0x4e, 0x42, 0xbe, 0x06, 0x60, 0x5a, 0xcb, 0xc0,
-	0x89, 0x35, 0xa6, 0x8f, 0x42, 0x7d, 0xef, 0xc3, 0x39, 0x81, 0xd0, 0xeb, 0xcd, 0x30, 0x9a, 0x33,
-	0xb1, 0xdf, 0x58, 0x70, 0x77, 0x1e, 0x94, 0x2c, 0x88, 0x44, 0x25, 0xc2, 0x38, 0xc9, 0x23, 0xff,
-	0x45, 0x28, 0x8b, 0xc9, 0x53, 0xd8, 0xf2, 0xc4, 0x05, 0x57, 0x6c, 0x12, 0x85, 0xb3, 0xc1, 0x4b,
-	0x53, 0xb9, 0x9f, 0x4b, 0xe5, 0xa8, 0xcc, 0xd0, 0xaa, 0x99, 0xfd, 0x09, 0x6c, 0x55, 0x38, 0xd2,
-	0x84, 0x45, 0x16, 0x86, 0xd3, 0xdb, 0x27, 0x47, 0xfb, 0x1b, 0x68, 0xe4, 0x9b, 0x4b, 0x3e, 0x87,
-	0x15, 0xa5, 0x99, 0x8e, 0xd3, 0x1c, 0x37, 0x8a, 0xfb, 0x75, 0x03, 0xc6, 0x8a, 0x4e, 0x39, 0xfb,
-	0x57, 0x0b, 0x56, 0x29, 0xfa, 0x81, 0xd2, 0xf2, 0x8a, 0x1c, 0x02, 0xcc, 0xf8, 0xac, 0x5d, 0x1f,
-	0x15, 0xde, 0x93, 0x14, 0xbc, 0x59, 0x1e, 0xd5, 0xe7, 0x5a, 0x5e, 0xd1, 0x9c, 0xd9, 0xce, 0x19,
-	0x6c, 0x96, 0xd4, 0x49, 0xe2, 0xaf, 0xf0, 0xca, 0xe4, 0xb4, 0x46, 0x93, 0x23, 0x79, 0x04, 0xcb,
-	0xaf, 0x93, 0x1d, 0x99, 0xd6, 0xe7, 0xde, 0xbc, 0xc5, 0xcc, 0xca, 0x93, 0x92, 0x8f, 0x17, 0xbe,
-	0xb0, 0xec, 0xbf, 0x2c, 0xd8, 0x7e, 0xcb, 0xe2, 0x12, 0x0f, 0xda, 0xe6, 0xd5, 0x35, 0xaf, 0x50,
-	0xc0, 0xfd, 0x11, 0xca, 0xc3, 0xd1, 0x8b, 0x43, 0xc1, 0xdd, 0x58, 0x4a, 0xe4, 0x6e, 0x1a, 0x3f,
-	0xe9, 0x45, 0x79, 0x63, 0x8f, 0x44, 0xec, 0x84, 0x98, 0xee, 0xec, 0x7f, 0xf8, 0x48, 0xa2, 0x98,
-	0x8f, 0xc0, 0xdb, 0xa3, 0x2c, 0xdc, 0x26, 0xca, 0xbb, 0x7d, 0xd8, 0x3f, 0xc2, 0x66, 0x69, 0xe7,
-	0x08, 0x81, 0x25, 0x7d, 0x15, 0xe1, 0xb4, 0x88, 0xe6, 0x4c, 0x1e, 0x41, 0x4d, 0x14, 0xe6, 0x6c,
-	0xbb, 0x12, 0xf5, 0xd4, 0xfc, 0xba, 0xa2, 0x19, 0xf7, 0xf0, 0x4b, 0x58, 0x2f, 0x0c, 0x02, 0xa9,
-	0x43, 0xed, 0xc5, 0xf0, 0xbb, 0xe1, 0xc9, 0xcb, 0x61, 0xf3, 0x0e, 0x69, 0x42, 0x63, 0x30, 0x1c,
-	0x3c, 0x1f, 0xf4, 0x9e, 0x0d, 0xce, 0x06, 0xc3, 0xe3, 0xa6, 0x45, 0xd6, 0x60, 0x99, 0xf6, 0x7b,
-	0x47, 0x3f, 0x35, 0x17, 0x0e, 0x9a, 0xbf, 0x5d, 0xb7, 0xad, 0xdf, 0xaf, 0xdb, 0xd6, 0x1f, 0xd7,
-	0x6d, 0xeb, 0x97, 0x3f, 0xdb, 0x77, 0x9c, 0x15, 0x13, 0x66, 0xff, 0xdf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0x48, 0x8b, 0xb8, 0x2d, 0x28, 0x0a, 0x00, 0x00,
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x96, 0xdb, 0x6e, 0x1a, 0x47,
+	0x18, 0x80, 0xb3, 0xf8, 0x80, 0xfd, 0x83, 0x01, 0x8f, 0xdc, 0x18, 0xd1, 0x94, 0x5a, 0xdb, 0x83,
+	0x90, 0x55, 0x41, 0x63, 0xdf, 0xb4, 0x69, 0x95, 0x16, 0x03, 0xb5, 0x48, 0x13, 0x40, 0x83, 0xd3,
+	0x34, 0xbe, 0xa9, 0x86, 0xdd, 0x61, 0xbd, 0xca, 0xb2, 0xb3, 0x9a, 0x99, 0x8d, 0x4d, 0x9f, 0x21,
+	0x17, 0x7d, 0x8f, 0xbc, 0x48, 0x2f, 0xfb, 0x08, 0x95, 0xab, 0x4a, 0x7d, 0x8c, 0x6a, 0x67, 0x59,
+	0xd8, 0x03, 0x49, 0xad, 0xde, 0x44, 0x9b, 0xff, 0xff, 0xfe, 0xc3, 0xfc, 0x27, 0x0c, 0xe7, 0x96,
+	0x2d, 0xaf, 0xfc, 0x49, 0xd3, 0x60, 0xb3, 0xd6, 0xec, 0xd4, 0x9c, 0xb4, 0x66, 0xa7, 0x2d, 0xc1,
+	0x8d, 0x96, 0x39, 0x71, 0x99, 0x49, 0x5b, 0x16, 0x75, 0x29, 0x27, 0x92, 0x9a, 0x2d, 0x8f, 0x33,
+	0xc9, 0x5a, 0x2e, 0x99, 0x51, 0xe1, 0x11, 0x83, 0xae, 0xbe, 0x9a, 0x4a, 0x83, 0x76, 0x97, 0x82,
+	0xda, 0x03, 0x8b, 0x31, 0xcb, 0xa1, 0xa1, 0xc9, 0xc4, 0x9f, 0xb6, 0x84, 0xe4, 0xbe, 0x21, 0x43,
+	0xb0, 0x56, 0x4f, 0x6b, 0xaf, 0x39, 0xf1, 0x3c, 0xca, 0xc5, 0x42, 0xdf, 0xfd, 0xbf, 0x19, 0x09,
+	0xe3, 0x8a, 0xce, 0x48, 0xe8, 0x45, 0x7f, 0xb3, 0x01, 0x15, 0x4c, 0x25, 0x75, 0xa5, 0xcd, 0xdc,
+	0xa1, 0x17, 0xfc, 0x2b, 0xd0, 0x09, 0x1c, 0xf0, 0x48, 0x36, 0xa2, 0xdc, 0x66, 0xe6, 0x80, 0xb8,
+	0x4c, 0x54, 0xb5, 0x23, 0xad, 0xb1, 0x81, 0xd7, 0xea, 0xd0, 0xe7, 0x50, 0x9a, 0x38, 0xcc, 0x78,
+	0x35, 0xb6, 0x7f, 0xa5, 0x21, 0x9d, 0x53, 0x74, 0x4a, 0x8a, 0xbe, 0x80, 0xfd, 0x89, 0x3f, 0x9d,
+	0x52, 0xfe, 0x83, 0x2f, 0x7d, 0xbe, 0x40, 0x37, 0x14, 0x9a, 0x55, 0xa0, 0x06, 0x94, 0x43, 0xe1,
+	0x88, 0x08, 0x19, 0xb2, 0x9b, 0x8a, 0x4d, 0x8b, 0x15, 0x19, 0x44, 0xea, 0x12, 0x49, 0x7a, 0x37,
+	0x9e, 0xcd, 0xe7, 0xd5, 0xad, 0x23, 0xad, 0xb1, 0x83, 0xd3, 0x62, 0x74, 0x09, 0x8d, 0x94, 0xa8,
+	0x3d, 0x95, 0x94, 0x0f, 0x98, 0x6c, 0x1b, 0x06, 0x15, 0x22, 0xfe, 0xe2, 0x6d, 0x15, 0xec, 0xce,
+	0x3c, 0x7a, 0x0c, 0xb5, 0xa9, 0x4a, 0x1f, 0xaf, 0xab, 0x5f, 0x5e, 0x79, 0x7b, 0x0f, 0xa1, 0x8f,
+	0xa0, 0xd8, 0x77, 0x4d, 0x7a, 0x13, 0x75, 0xa2, 0x0a, 0x79, 0xea, 0x92, 0x89, 0x43, 0x4d, 0x55,
+	0xfc, 0x1d, 0x1c, 0xfd, 0xf7, 0xae, 0xf5, 0xd6, 0xdf, 0xe6, 0xa1, 0x32, 0x88, 0x7a, 0x1f, 0xb9,
+	0x3d, 0x86, 0xca, 0x84, 0x31, 0x29, 0x24, 0x27, 0x5e, 0x2f, 0xe1, 0x3f, 0x23, 0x47, 0x3a, 0x14,
+	0xa7, 0x8e, 0x2f, 0xae, 0x22, 0x2e, 0xa7, 0xb8, 0x84, 0x2c, 0x68, 0xea, 0x35, 0xb7, 0x25, 0x15,
+	0x17, 0xac, 0xc3, 0x66, 0x33, 0x5b, 0x3e, 0x65, 0x96, 0x6a, 0xea, 0x0e, 0xce, 0x2a, 0x82, 0xd4,
+	0x0d, 0x87, 0x12, 0xd7, 0x5f, 0xc6, 0xde, 0x54, 0x68, 0x4a, 0x8a, 0x3e, 0x85, 0x3d, 0x4e, 0x3d,
+	0x62, 0xf3, 0x08, 0x0b, 0x1b, 0x9a, 0x14, 0xa2, 0x73, 0xa8, 0xf0, 0xd4, 0x00, 0xab, 0xb6, 0x15,
+	0x4e, 0x3e, 0x6c, 0xae, 0x96, 0x2f, 0x3d, 0xe3, 0x38, 0x63, 0x14, 0x4c, 0x90, 0x70, 0x89, 0x27,
+	0xae, 0x98, 0x8c, 0x02, 0xe6, 0xc3, 0x09, 0x4a, 0x89, 0xd1, 0x37, 0x50, 0xb4, 0x63, 0x5d, 0xaa,
+	0xee, 0xa8, 0x70, 0x87, 0xb1, 0x70, 0xf1, 0x26, 0xe2, 0x04, 0x8c, 0x1e, 0xc3, 0x5e, 0xb8, 0x81,
+	0x91, 0xf5, 0xae, 0xb2, 0xae, 0xc6, 0xac, 0xc7, 0x71, 0x3d, 0x4e, 0xe2, 0x41, 0xad, 0x0d, 0xe6,
+	0x98, 0x2f, 0x54, 0x59, 0xa3, 0x44, 0x21, 0xac, 0x75, 0x46, 0x81, 0x9e, 0x40, 0x89, 0xfb, 0xae,
+	0xb4, 0x67, 0x51, 0xef, 0xab, 0x05, 0x15, 0x4e, 0x8f, 0x85, 0x5b, 0x8e, 0x07, 0x4e, 0x90, 0x38,
+	0x65, 0x89, 0x46, 0xf0, 0x81, 0x41, 0x8c, 0x2b, 0x7a, 0x16, 0x4c, 0x98, 0x18, 0xba, 0x98, 0x4a,
+	0x6e, 0xd3, 0xd7, 0xb4, 0x5a, 0x54, 0x2e, 0x6b, 0xcd, 0xf0, 0x62, 0x35, 0xa3, 0x8b, 0xd5, 0x3c,
+	0x63, 0xcc, 0xf9, 0x89, 0x38, 0x3e, 0xc5, 0xeb, 0x0d, 0xd1, 0x33, 0x40, 0xc4, 0xb2, 0x38, 0xb5,
+	0x48, 0xbc, 0x7b, 0x7b, 0xca, 0xdd, 0x47, 0xb1, 0x0c, 0xdb, 0x19, 0x08, 0xaf, 0x31, 0x0c, 0xfa,
+	0x22, 0x24, 0xb1, 0x6c, 0xd7, 0x1a, 0x4b, 0x22, 0x69, 0xb5, 0x94, 0xe9, 0xcb, 0x38, 0xa6, 0xc6,
+	0x09, 0x18, 0x7d, 0x0b, 0x05, 0x83, 0xcd, 0x3c, 0x4e, 0x85, 0xb0, 0x99, 0x5b, 0x2d, 0x1f, 0x69,
+	0x8d, 0xd2, 0x49, 0x2d, 0x66, 0xdb, 0x59, 0x69, 0x2f, 0xe6, 0x1e, 0xc5, 0x71, 0x1c, 0xf5, 0xa0,
+	0x4c, 0x6f, 0x24, 0x75, 0x4d, 0x6a, 0x46, 0xcf, 0xf8, 0x27, 0xbf, 0x28, 0xcb, 0xca, 0x45, 0x2f,
+	0x89, 0xe0, 0xb4, 0x8d, 0x3e, 0x02, 0x94, 0x7d, 0x2b, 0x7a, 0x04, 0xc5, 0xd8, 0x6b, 0x83, 0x3b,
+	0xbc, 0xd1, 0x28, 0x9c, 0xdc, 0x5f, 0x5f, 0x20, 0x9c, 0x60, 0x75, 0x17, 0x0a, 0x31, 0x25, 0xaa,
+	0x03, 0x44, 0xea, 0xe5, 0xce, 0xc7, 0x24, 0xe8, 0x3b, 0x00, 0x22, 0x25, 0xb7, 0x27, 0xbe, 0xa4,
+	0xe1, 0x49, 0x29, 0x9c, 0x7c, 0xbc, 0x26, 0x10, 0x35, 0xdb, 0x4b, 0x0c, 0xc7, 0x4c, 0xf4, 0x37,
+	0x1a, 0x1c, 0xac, 0x83, 0x82, 0xf5, 0xe2, 0x54, 0x30, 0xc7, 0x0f, 0xf2, 0x88, 0xff, 0x9e, 0xa4,
+	0xc5, 0xe8, 0x09, 0xec, 0x9b, 0xec, 0xda, 0x15, 0x64, 0xe6, 0x39, 0xcb, 0xb1, 0x0d, 0x53, 0x79,
+	0x10, 0x4b, 0xa5, 0x9b, 0x66, 0x70, 0xd6, 0x4c, 0xff, 0x0c, 0xf6, 0x33, 0x1c, 0xaa, 0xc0, 0x06,
+	0x71, 0x9c, 0xc5, 0xeb, 0x83, 0x4f, 0xfd, 0x7b, 0x28, 0xc6, 0x47, 0x03, 0x7d, 0x09, 0xdb, 0x42,
+	0x12, 0xe9, 0x87, 0x39, 0x96, 0x92, 0xdb, 0xb9, 0x02, 0x7d, 0x81, 0x17, 0x9c, 0xfe, 0x56, 0x83,
+	0x1d, 0x4c, 0x2d, 0x5b, 0x48, 0x3e, 0x47, 0x1d, 0x80, 0x25, 0x1f, 0xb5, 0xeb, 0x93, 0xc4, 0x35,
+	0x0a, 0xc1, 0xd5, 0xea, 0x89, 0x9e, 0x2b, 0xf9, 0x1c, 0xc7, 0xcc, 0x6a, 0x97, 0x50, 0x4e, 0xa9,
+	0x83, 0xc4, 0x5f, 0xd1, 0xb9, 0xca, 0x69, 0x17, 0x07, 0x9f, 0xe8, 0x21, 0x6c, 0xbd, 0x0e, 0x36,
+	0xac, 0x9a, 0xcb, 0x9c, 0xbc, 0xf4, 0xd5, 0xc7, 0x21, 0xf9, 0x28, 0xf7, 0x95, 0xa6, 0xff, 0xad,
+	0xc1, 0xe1, 0x3b, 0xd6, 0x1e, 0x99, 0x50, 0x57, 0x37, 0x5b, 0xdd, 0x30, 0xdb, 0xb5, 0x46, 0x94,
+	0x77, 0x46, 0xcf, 0x3b, 0xcc, 0x35, 0x7c, 0xce, 0xa9, 0x6b, 0x84, 0xf1, 0x83, 0x5e, 0xa4, 0xf7,
+	0xbd, 0xcb, 0xfc, 0x89, 0x43, 0xc3, 0x8d, 0xff, 0x0f, 0x1f, 0x41, 0x14, 0xf5, 0x13, 0xf2, 0xee,
+	0x28, 0xb9, 0xbb, 0x44, 0x79, 0xbf, 0x0f, 0xfd, 0x67, 0x28, 0xa7, 0x76, 0x0e, 0x21, 0xd8, 0x94,
+	0x73, 0x8f, 0x2e, 0x8a, 0xa8, 0xbe, 0xd1, 0x43, 0xc8, 0xb3, 0xc4, 0x9c, 0x1d, 0x66, 0xa2, 0x8e,
+	0xd5, 0xdf, 0x66, 0x38, 0xe2, 0x8e, 0xc7, 0x50, 0x4e, 0x1d, 0x04, 0x84, 0xa0, 0x34, 0x18, 0xfe,
+	0xd2, 0x19, 0x3e, 0x1b, 0xe1, 0xde, 0x78, 0xdc, 0x1f, 0x0e, 0x2a, 0xf7, 0xd0, 0x01, 0x54, 0x2e,
+	0xc7, 0x17, 0xdd, 0x84, 0x54, 0x43, 0xf7, 0x01, 0x8d, 0x07, 0xed, 0xd1, 0xe8, 0x65, 0x42, 0x9e,
+	0x3b, 0xfe, 0x1a, 0xf6, 0x12, 0xd3, 0x85, 0x0a, 0x90, 0x7f, 0x3e, 0xf8, 0x71, 0x30, 0x7c, 0x11,
+	0xf8, 0xaa, 0x40, 0xb1, 0x3f, 0xe8, 0x5f, 0xf4, 0xdb, 0x4f, 0xfb, 0x97, 0xfd, 0xc1, 0x79, 0x45,
+	0x43, 0xbb, 0xb0, 0x85, 0x7b, 0xed, 0xee, 0xcb, 0x4a, 0xee, 0xac, 0xf2, 0xfb, 0x6d, 0x5d, 0xfb,
+	0xe3, 0xb6, 0xae, 0xfd, 0x79, 0x5b, 0xd7, 0x7e, 0xfb, 0xab, 0x7e, 0x6f, 0xb2, 0xad, 0x72, 0x3f,
+	0xfd, 0x77, 0x00, 0x54, 0x87, 0xd6, 0x07, 0xbb, 0x0a, 0x00, 0x00,
 }
@@ -69,6 +69,9 @@ service Node {
 	void                           writeTaggedBatchRawV2(1: WriteTaggedBatchRawV2Request req) throws (1: WriteBatchRawErrors err)
 	void                           repair() throws (1: Error err)
 	TruncateResult                 truncate(1: TruncateRequest req) throws (1: Error err)
+	DeleteSeriesResult             deleteSeries(1: DeleteSeriesRequest req) throws (1: Error err)
+	BackupResult                   backup(1: BackupRequest req) throws (1: Error err)
+	RestoreResult                  restore(1: RestoreRequest req) throws (1: Error err)
 
 	AggregateTilesResult aggregateTiles(1: AggregateTilesRequest req) throws (1: Error err)
 
@@ -185,6 +188,8 @@ struct FetchTaggedRequest {
 	9: optional i64 docsLimit
 	10: optional binary source
 	11: optional bool requireNoWait = false
+	12: optional i32 seriesShardIndex
+	13: optional i32 seriesShardCount
 }
 
 struct FetchTaggedResult {
@@ -322,6 +327,41 @@ struct TruncateResult {
 	1: required i64 numSeries
 }
 
+struct DeleteSeriesRequest {
+	1: required binary nameSpace
+	2: required binary query
+	3: required i64 rangeStart
+	4: required i64 rangeEnd
+	5: optional TimeType rangeTimeType = TimeType.UNIX_SECONDS
+}
+
+struct DeleteSeriesResult {
+	1: required i64 numSeries
+}
+
+struct BackupRequest {
+	1: required binary nameSpace
+	2: required string backupID
+}
+
+struct BackupResult {
+	1: required i64 numFiles
+	2: required i64 numBytes
+}
+
+struct RestoreRequest {
+	1: required binary nameSpace
+	2: required string backupID
+	3: required i64 pointInTime
+	4: optional TimeType pointInTimeType = TimeType.UNIX_SECONDS
+}
+
+struct RestoreResult {
+	1: required string backupID
+	2: required i64 numFiles
+	3: required i64 numBytes
+}
+
 struct NodeHealthResult {
 	1: required bool ok
 	2: required string status
@@ -3429,6 +3429,8 @@ func (p *Segment) String() string {
 //  - DocsLimit
 //  - Source
 //  - RequireNoWait
+//  - SeriesShardIndex
+//  - SeriesShardCount
 type FetchTaggedRequest struct {
 	NameSpace         []byte   `thrift:"nameSpace,1,required" db:"nameSpace" json:"nameSpace"`
 	Query             []byte   `thrift:"query,2,required" db:"query" json:"query"`
@@ -3441,6 +3443,8 @@ type FetchTaggedRequest struct {
 	DocsLimit         *int64   `thrift:"docsLimit,9" db:"docsLimit" json:"docsLimit,omitempty"`
 	Source            []byte   `thrift:"source,10" db:"source" json:"source,omitempty"`
 	RequireNoWait     bool     `thrift:"requireNoWait,11" db:"requireNoWait" json:"requireNoWait,omitempty"`
+	SeriesShardIndex  *int32   `thrift:"seriesShardIndex,12" db:"seriesShardIndex" json:"seriesShardIndex,omitempty"`
+	SeriesShardCount  *int32   `thrift:"seriesShardCount,13" db:"seriesShardCount" json:"seriesShardCount,omitempty"`
 }
 
 func NewFetchTaggedRequest() *FetchTaggedRequest {
@@ -3512,6 +3516,24 @@ var FetchTaggedRequest_RequireNoWait_DEFAULT bool = false
 func (p *FetchTaggedRequest) GetRequireNoWait() bool {
 	return p.RequireNoWait
 }
+
+var FetchTaggedRequest_SeriesShardIndex_DEFAULT int32
+
+func (p *FetchTaggedRequest) GetSeriesShardIndex() int32 {
+	if !p.IsSetSeriesShardIndex() {
+		return FetchTaggedRequest_SeriesShardIndex_DEFAULT
+	}
+	return *p.SeriesShardIndex
+}
+
+var FetchTaggedRequest_SeriesShardCount_DEFAULT int32
+
+func (p *FetchTaggedRequest) GetSeriesShardCount() int32 {
+	if !p.IsSetSeriesShardCount() {
+		return FetchTaggedRequest_SeriesShardCount_DEFAULT
+	}
+	return *p.SeriesShardCount
+}
 func (p *FetchTaggedRequest) IsSetSeriesLimit() bool {
 	return p.SeriesLimit != nil
 }
@@ -3536,6 +3558,14 @@ func (p *FetchTaggedRequest) IsSetRequireNoWait() bool {
 	return p.RequireNoWait != FetchTaggedRequest_RequireNoWait_DEFAULT
 }
 
+func (p *FetchTaggedRequest) IsSetSeriesShardIndex() bool {
+	return p.SeriesShardIndex != nil
+}
+
+func (p *FetchTaggedRequest) IsSetSeriesShardCount() bool {
+	return p.SeriesShardCount != nil
+}
+
 func (p *FetchTaggedRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
@@ -3605,6 +3635,14 @@ func (p *FetchTaggedRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField11(iprot); err != nil {
 				return err
 			}
+		case 12:
+			if err := p.ReadField12(iprot); err != nil {
+				return err
+			}
+		case 13:
+			if err := p.ReadField13(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -3735,6 +3773,24 @@ func (p *FetchTaggedRequest) ReadField11(iprot thrift.TProtocol) error {
 	return nil
 }
 
+func (p *FetchTaggedRequest) ReadField12(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.SeriesShardIndex = &v
+	}
+	return nil
+}
+
+func (p *FetchTaggedRequest) ReadField13(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 13: ", err)
+	} else {
+		p.SeriesShardCount = &v
+	}
+	return nil
+}
+
 func (p *FetchTaggedRequest) Write(oprot thrift.TProtocol) error {
 	if err := oprot.WriteStructBegin("FetchTaggedRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
@@ -3773,6 +3829,12 @@ func (p *FetchTaggedRequest) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField11(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField12(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField13(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -3938,6 +4000,36 @@ func (p *FetchTaggedRequest) writeField11(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
+func (p *FetchTaggedRequest) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSeriesShardIndex() {
+		if err := oprot.WriteFieldBegin("seriesShardIndex", thrift.I32, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:seriesShardIndex: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.SeriesShardIndex)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.seriesShardIndex (12) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:seriesShardIndex: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *FetchTaggedRequest) writeField13(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSeriesShardCount() {
+		if err := oprot.WriteFieldBegin("seriesShardCount", thrift.I32, 13); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 13:seriesShardCount: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.SeriesShardCount)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.seriesShardCount (13) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 13:seriesShardCount: ", p), err)
+		}
+	}
+	return err
+}
+
 func (p *FetchTaggedRequest) String() string {
 	if p == nil {
 		return "<nil>"
@@ -8330,50 +8422,59 @@ func (p *TruncateResult_) String() string {
 }
 
 // Attributes:
-//  - Ok
-//  - Status
-//  - Bootstrapped
-//  - Metadata
-type NodeHealthResult_ struct {
-	Ok           bool              `thrift:"ok,1,required" db:"ok" json:"ok"`
-	Status       string            `thrift:"status,2,required" db:"status" json:"status"`
-	Bootstrapped bool              `thrift:"bootstrapped,3,required" db:"bootstrapped" json:"bootstrapped"`
-	Metadata     map[string]string `thrift:"metadata,4" db:"metadata" json:"metadata,omitempty"`
+//  - NameSpace
+//  - Query
+//  - RangeStart
+//  - RangeEnd
+//  - RangeTimeType
+type DeleteSeriesRequest struct {
+	NameSpace     []byte   `thrift:"nameSpace,1,required" db:"nameSpace" json:"nameSpace"`
+	Query         []byte   `thrift:"query,2,required" db:"query" json:"query"`
+	RangeStart    int64    `thrift:"rangeStart,3,required" db:"rangeStart" json:"rangeStart"`
+	RangeEnd      int64    `thrift:"rangeEnd,4,required" db:"rangeEnd" json:"rangeEnd"`
+	RangeTimeType TimeType `thrift:"rangeTimeType,5" db:"rangeTimeType" json:"rangeTimeType,omitempty"`
 }
 
-func NewNodeHealthResult_() *NodeHealthResult_ {
-	return &NodeHealthResult_{}
+func NewDeleteSeriesRequest() *DeleteSeriesRequest {
+	return &DeleteSeriesRequest{
+		RangeTimeType: 0,
+	}
 }
 
-func (p *NodeHealthResult_) GetOk() bool {
-	return p.Ok
+func (p *DeleteSeriesRequest) GetNameSpace() []byte {
+	return p.NameSpace
 }
 
-func (p *NodeHealthResult_) GetStatus() string {
-	return p.Status
+func (p *DeleteSeriesRequest) GetQuery() []byte {
+	return p.Query
 }
 
-func (p *NodeHealthResult_) GetBootstrapped() bool {
-	return p.Bootstrapped
+func (p *DeleteSeriesRequest) GetRangeStart() int64 {
+	return p.RangeStart
 }
 
-var NodeHealthResult__Metadata_DEFAULT map[string]string
+func (p *DeleteSeriesRequest) GetRangeEnd() int64 {
+	return p.RangeEnd
+}
 
-func (p *NodeHealthResult_) GetMetadata() map[string]string {
-	return p.Metadata
+var DeleteSeriesRequest_RangeTimeType_DEFAULT TimeType = 0
+
+func (p *DeleteSeriesRequest) GetRangeTimeType() TimeType {
+	return p.RangeTimeType
 }
-func (p *NodeHealthResult_) IsSetMetadata() bool {
-	return p.Metadata != nil
+func (p *DeleteSeriesRequest) IsSetRangeTimeType() bool {
+	return p.RangeTimeType != DeleteSeriesRequest_RangeTimeType_DEFAULT
 }
 
-func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
+func (p *DeleteSeriesRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetOk bool = false
-	var issetStatus bool = false
-	var issetBootstrapped bool = false
+	var issetNameSpace bool = false
+	var issetQuery bool = false
+	var issetRangeStart bool = false
+	var issetRangeEnd bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -8388,21 +8489,26 @@ func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetOk = true
+			issetNameSpace = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetStatus = true
+			issetQuery = true
 		case 3:
 			if err := p.ReadField3(iprot); err != nil {
 				return err
 			}
-			issetBootstrapped = true
+			issetRangeStart = true
 		case 4:
 			if err := p.ReadField4(iprot); err != nil {
 				return err
 			}
+			issetRangeEnd = true
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -8415,75 +8521,69 @@ func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetOk {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Ok is not set"))
+	if !issetNameSpace {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameSpace is not set"))
 	}
-	if !issetStatus {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	if !issetQuery {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
 	}
-	if !issetBootstrapped {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Bootstrapped is not set"))
+	if !issetRangeStart {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
+	}
+	if !issetRangeEnd {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeEnd is not set"))
 	}
 	return nil
 }
 
-func (p *NodeHealthResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *DeleteSeriesRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Ok = v
+		p.NameSpace = v
 	}
 	return nil
 }
 
-func (p *NodeHealthResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *DeleteSeriesRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.Status = v
+		p.Query = v
 	}
 	return nil
 }
 
-func (p *NodeHealthResult_) ReadField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *DeleteSeriesRequest) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 3: ", err)
 	} else {
-		p.Bootstrapped = v
+		p.RangeStart = v
 	}
 	return nil
 }
 
-func (p *NodeHealthResult_) ReadField4(iprot thrift.TProtocol) error {
-	_, _, size, err := iprot.ReadMapBegin()
-	if err != nil {
-		return thrift.PrependError("error reading map begin: ", err)
-	}
-	tMap := make(map[string]string, size)
-	p.Metadata = tMap
-	for i := 0; i < size; i++ {
-		var _key22 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_key22 = v
-		}
-		var _val23 string
-		if v, err := iprot.ReadString(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_val23 = v
-		}
-		p.Metadata[_key22] = _val23
+func (p *DeleteSeriesRequest) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.RangeEnd = v
 	}
-	if err := iprot.ReadMapEnd(); err != nil {
-		return thrift.PrependError("error reading map end: ", err)
+	return nil
+}
+
+func (p *DeleteSeriesRequest) ReadField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		temp := TimeType(v)
+		p.RangeTimeType = temp
 	}
 	return nil
 }
 
-func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeHealthResult"); err != nil {
+func (p *DeleteSeriesRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DeleteSeriesRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -8499,6 +8599,9 @@ func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField4(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField5(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -8509,90 +8612,100 @@ func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeHealthResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("ok", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ok: ", p), err)
+func (p *DeleteSeriesRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nameSpace: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.Ok)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.ok (1) field write error: ", p), err)
+	if err := oprot.WriteBinary(p.NameSpace); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ok: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nameSpace: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeHealthResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("status", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+func (p *DeleteSeriesRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:query: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.Status)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	if err := oprot.WriteBinary(p.Query); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.query (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:query: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeHealthResult_) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("bootstrapped", thrift.BOOL, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:bootstrapped: ", p), err)
+func (p *DeleteSeriesRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeStart: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.Bootstrapped)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.bootstrapped (3) field write error: ", p), err)
+	if err := oprot.WriteI64(int64(p.RangeStart)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeStart (3) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:bootstrapped: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:rangeStart: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeHealthResult_) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetMetadata() {
-		if err := oprot.WriteFieldBegin("metadata", thrift.MAP, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:metadata: ", p), err)
-		}
-		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Metadata)); err != nil {
-			return thrift.PrependError("error writing map begin: ", err)
-		}
-		for k, v := range p.Metadata {
-			if err := oprot.WriteString(string(k)); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
-			}
-			if err := oprot.WriteString(string(v)); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
-			}
+func (p *DeleteSeriesRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:rangeEnd: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.RangeEnd)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeEnd (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:rangeEnd: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteSeriesRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRangeTimeType() {
+		if err := oprot.WriteFieldBegin("rangeTimeType", thrift.I32, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:rangeTimeType: ", p), err)
 		}
-		if err := oprot.WriteMapEnd(); err != nil {
-			return thrift.PrependError("error writing map end: ", err)
+		if err := oprot.WriteI32(int32(p.RangeTimeType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.rangeTimeType (5) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:metadata: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:rangeTimeType: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *NodeHealthResult_) String() string {
+func (p *DeleteSeriesRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeHealthResult_(%+v)", *p)
+	return fmt.Sprintf("DeleteSeriesRequest(%+v)", *p)
 }
 
-type NodeBootstrappedResult_ struct {
+// Attributes:
+//  - NumSeries
+type DeleteSeriesResult_ struct {
+	NumSeries int64 `thrift:"numSeries,1,required" db:"numSeries" json:"numSeries"`
 }
 
-func NewNodeBootstrappedResult_() *NodeBootstrappedResult_ {
-	return &NodeBootstrappedResult_{}
+func NewDeleteSeriesResult_() *DeleteSeriesResult_ {
+	return &DeleteSeriesResult_{}
 }
 
-func (p *NodeBootstrappedResult_) Read(iprot thrift.TProtocol) error {
+func (p *DeleteSeriesResult_) GetNumSeries() int64 {
+	return p.NumSeries
+}
+func (p *DeleteSeriesResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetNumSeries bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -8601,8 +8714,16 @@ func (p *NodeBootstrappedResult_) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetNumSeries = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -8611,14 +8732,29 @@ func (p *NodeBootstrappedResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	if !issetNumSeries {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NumSeries is not set"))
+	}
 	return nil
 }
 
-func (p *NodeBootstrappedResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeBootstrappedResult"); err != nil {
+func (p *DeleteSeriesResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NumSeries = v
+	}
+	return nil
+}
+
+func (p *DeleteSeriesResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DeleteSeriesResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -8629,25 +8765,53 @@ func (p *NodeBootstrappedResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeBootstrappedResult_) String() string {
+func (p *DeleteSeriesResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("numSeries", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:numSeries: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.NumSeries)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.numSeries (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:numSeries: ", p), err)
+	}
+	return err
+}
+
+func (p *DeleteSeriesResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeBootstrappedResult_(%+v)", *p)
+	return fmt.Sprintf("DeleteSeriesResult_(%+v)", *p)
 }
 
-type NodeBootstrappedInPlacementOrNoPlacementResult_ struct {
+// Attributes:
+//  - NameSpace
+//  - BackupID
+type BackupRequest struct {
+	NameSpace []byte `thrift:"nameSpace,1,required" db:"nameSpace" json:"nameSpace"`
+	BackupID  string `thrift:"backupID,2,required" db:"backupID" json:"backupID"`
 }
 
-func NewNodeBootstrappedInPlacementOrNoPlacementResult_() *NodeBootstrappedInPlacementOrNoPlacementResult_ {
-	return &NodeBootstrappedInPlacementOrNoPlacementResult_{}
+func NewBackupRequest() *BackupRequest {
+	return &BackupRequest{}
 }
 
-func (p *NodeBootstrappedInPlacementOrNoPlacementResult_) Read(iprot thrift.TProtocol) error {
+func (p *BackupRequest) GetNameSpace() []byte {
+	return p.NameSpace
+}
+
+func (p *BackupRequest) GetBackupID() string {
+	return p.BackupID
+}
+func (p *BackupRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetNameSpace bool = false
+	var issetBackupID bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -8656,8 +8820,21 @@ func (p *NodeBootstrappedInPlacementOrNoPlacementResult_) Read(iprot thrift.TPro
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetNameSpace = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetBackupID = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -8666,14 +8843,44 @@ func (p *NodeBootstrappedInPlacementOrNoPlacementResult_) Read(iprot thrift.TPro
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	if !issetNameSpace {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameSpace is not set"))
+	}
+	if !issetBackupID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field BackupID is not set"))
+	}
 	return nil
 }
 
-func (p *NodeBootstrappedInPlacementOrNoPlacementResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeBootstrappedInPlacementOrNoPlacementResult"); err != nil {
+func (p *BackupRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.NameSpace = v
+	}
+	return nil
+}
+
+func (p *BackupRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.BackupID = v
+	}
+	return nil
+}
+
+func (p *BackupRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("BackupRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -8684,46 +8891,65 @@ func (p *NodeBootstrappedInPlacementOrNoPlacementResult_) Write(oprot thrift.TPr
 	return nil
 }
 
-func (p *NodeBootstrappedInPlacementOrNoPlacementResult_) String() string {
+func (p *BackupRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nameSpace: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.NameSpace); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nameSpace: ", p), err)
+	}
+	return err
+}
+
+func (p *BackupRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("backupID", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:backupID: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.BackupID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.backupID (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:backupID: ", p), err)
+	}
+	return err
+}
+
+func (p *BackupRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeBootstrappedInPlacementOrNoPlacementResult_(%+v)", *p)
+	return fmt.Sprintf("BackupRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - LimitEnabled
-//  - LimitMbps
-//  - LimitCheckEvery
-type NodePersistRateLimitResult_ struct {
-	LimitEnabled    bool    `thrift:"limitEnabled,1,required" db:"limitEnabled" json:"limitEnabled"`
-	LimitMbps       float64 `thrift:"limitMbps,2,required" db:"limitMbps" json:"limitMbps"`
-	LimitCheckEvery int64   `thrift:"limitCheckEvery,3,required" db:"limitCheckEvery" json:"limitCheckEvery"`
-}
-
-func NewNodePersistRateLimitResult_() *NodePersistRateLimitResult_ {
-	return &NodePersistRateLimitResult_{}
+//  - NumFiles
+//  - NumBytes
+type BackupResult_ struct {
+	NumFiles int64 `thrift:"numFiles,1,required" db:"numFiles" json:"numFiles"`
+	NumBytes int64 `thrift:"numBytes,2,required" db:"numBytes" json:"numBytes"`
 }
 
-func (p *NodePersistRateLimitResult_) GetLimitEnabled() bool {
-	return p.LimitEnabled
+func NewBackupResult_() *BackupResult_ {
+	return &BackupResult_{}
 }
 
-func (p *NodePersistRateLimitResult_) GetLimitMbps() float64 {
-	return p.LimitMbps
+func (p *BackupResult_) GetNumFiles() int64 {
+	return p.NumFiles
 }
 
-func (p *NodePersistRateLimitResult_) GetLimitCheckEvery() int64 {
-	return p.LimitCheckEvery
+func (p *BackupResult_) GetNumBytes() int64 {
+	return p.NumBytes
 }
-func (p *NodePersistRateLimitResult_) Read(iprot thrift.TProtocol) error {
+func (p *BackupResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetLimitEnabled bool = false
-	var issetLimitMbps bool = false
-	var issetLimitCheckEvery bool = false
+	var issetNumFiles bool = false
+	var issetNumBytes bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -8738,17 +8964,12 @@ func (p *NodePersistRateLimitResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetLimitEnabled = true
+			issetNumFiles = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetLimitMbps = true
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
-			issetLimitCheckEvery = true
+			issetNumBytes = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -8761,47 +8982,35 @@ func (p *NodePersistRateLimitResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetLimitEnabled {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitEnabled is not set"))
-	}
-	if !issetLimitMbps {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitMbps is not set"))
+	if !issetNumFiles {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NumFiles is not set"))
 	}
-	if !issetLimitCheckEvery {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitCheckEvery is not set"))
+	if !issetNumBytes {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NumBytes is not set"))
 	}
 	return nil
 }
 
-func (p *NodePersistRateLimitResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *BackupResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.LimitEnabled = v
-	}
-	return nil
-}
-
-func (p *NodePersistRateLimitResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadDouble(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.LimitMbps = v
+		p.NumFiles = v
 	}
 	return nil
 }
 
-func (p *NodePersistRateLimitResult_) ReadField3(iprot thrift.TProtocol) error {
+func (p *BackupResult_) ReadField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
+		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.LimitCheckEvery = v
+		p.NumBytes = v
 	}
 	return nil
 }
 
-func (p *NodePersistRateLimitResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodePersistRateLimitResult"); err != nil {
+func (p *BackupResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("BackupResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -8811,9 +9020,6 @@ func (p *NodePersistRateLimitResult_) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -8824,109 +9030,87 @@ func (p *NodePersistRateLimitResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodePersistRateLimitResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("limitEnabled", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:limitEnabled: ", p), err)
-	}
-	if err := oprot.WriteBool(bool(p.LimitEnabled)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.limitEnabled (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:limitEnabled: ", p), err)
-	}
-	return err
-}
-
-func (p *NodePersistRateLimitResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("limitMbps", thrift.DOUBLE, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:limitMbps: ", p), err)
+func (p *BackupResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("numFiles", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:numFiles: ", p), err)
 	}
-	if err := oprot.WriteDouble(float64(p.LimitMbps)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.limitMbps (2) field write error: ", p), err)
+	if err := oprot.WriteI64(int64(p.NumFiles)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.numFiles (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:limitMbps: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:numFiles: ", p), err)
 	}
 	return err
 }
 
-func (p *NodePersistRateLimitResult_) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("limitCheckEvery", thrift.I64, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:limitCheckEvery: ", p), err)
+func (p *BackupResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("numBytes", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:numBytes: ", p), err)
 	}
-	if err := oprot.WriteI64(int64(p.LimitCheckEvery)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.limitCheckEvery (3) field write error: ", p), err)
+	if err := oprot.WriteI64(int64(p.NumBytes)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.numBytes (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:limitCheckEvery: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:numBytes: ", p), err)
 	}
 	return err
 }
 
-func (p *NodePersistRateLimitResult_) String() string {
+func (p *BackupResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodePersistRateLimitResult_(%+v)", *p)
+	return fmt.Sprintf("BackupResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - LimitEnabled
-//  - LimitMbps
-//  - LimitCheckEvery
-type NodeSetPersistRateLimitRequest struct {
-	LimitEnabled    *bool    `thrift:"limitEnabled,1" db:"limitEnabled" json:"limitEnabled,omitempty"`
-	LimitMbps       *float64 `thrift:"limitMbps,2" db:"limitMbps" json:"limitMbps,omitempty"`
-	LimitCheckEvery *int64   `thrift:"limitCheckEvery,3" db:"limitCheckEvery" json:"limitCheckEvery,omitempty"`
-}
-
-func NewNodeSetPersistRateLimitRequest() *NodeSetPersistRateLimitRequest {
-	return &NodeSetPersistRateLimitRequest{}
+//  - NameSpace
+//  - BackupID
+//  - PointInTime
+//  - PointInTimeType
+type RestoreRequest struct {
+	NameSpace       []byte   `thrift:"nameSpace,1,required" db:"nameSpace" json:"nameSpace"`
+	BackupID        string   `thrift:"backupID,2,required" db:"backupID" json:"backupID"`
+	PointInTime     int64    `thrift:"pointInTime,3,required" db:"pointInTime" json:"pointInTime"`
+	PointInTimeType TimeType `thrift:"pointInTimeType,4" db:"pointInTimeType" json:"pointInTimeType,omitempty"`
 }
 
-var NodeSetPersistRateLimitRequest_LimitEnabled_DEFAULT bool
-
-func (p *NodeSetPersistRateLimitRequest) GetLimitEnabled() bool {
-	if !p.IsSetLimitEnabled() {
-		return NodeSetPersistRateLimitRequest_LimitEnabled_DEFAULT
+func NewRestoreRequest() *RestoreRequest {
+	return &RestoreRequest{
+		PointInTimeType: 0,
 	}
-	return *p.LimitEnabled
 }
 
-var NodeSetPersistRateLimitRequest_LimitMbps_DEFAULT float64
-
-func (p *NodeSetPersistRateLimitRequest) GetLimitMbps() float64 {
-	if !p.IsSetLimitMbps() {
-		return NodeSetPersistRateLimitRequest_LimitMbps_DEFAULT
-	}
-	return *p.LimitMbps
+func (p *RestoreRequest) GetNameSpace() []byte {
+	return p.NameSpace
 }
 
-var NodeSetPersistRateLimitRequest_LimitCheckEvery_DEFAULT int64
-
-func (p *NodeSetPersistRateLimitRequest) GetLimitCheckEvery() int64 {
-	if !p.IsSetLimitCheckEvery() {
-		return NodeSetPersistRateLimitRequest_LimitCheckEvery_DEFAULT
-	}
-	return *p.LimitCheckEvery
-}
-func (p *NodeSetPersistRateLimitRequest) IsSetLimitEnabled() bool {
-	return p.LimitEnabled != nil
+func (p *RestoreRequest) GetBackupID() string {
+	return p.BackupID
 }
 
-func (p *NodeSetPersistRateLimitRequest) IsSetLimitMbps() bool {
-	return p.LimitMbps != nil
+func (p *RestoreRequest) GetPointInTime() int64 {
+	return p.PointInTime
 }
 
-func (p *NodeSetPersistRateLimitRequest) IsSetLimitCheckEvery() bool {
-	return p.LimitCheckEvery != nil
+var RestoreRequest_PointInTimeType_DEFAULT TimeType = 0
+
+func (p *RestoreRequest) GetPointInTimeType() TimeType {
+	return p.PointInTimeType
+}
+func (p *RestoreRequest) IsSetPointInTimeType() bool {
+	return p.PointInTimeType != RestoreRequest_PointInTimeType_DEFAULT
 }
 
-func (p *NodeSetPersistRateLimitRequest) Read(iprot thrift.TProtocol) error {
+func (p *RestoreRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetNameSpace bool = false
+	var issetBackupID bool = false
+	var issetPointInTime bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -8940,17 +9124,24 @@ func (p *NodeSetPersistRateLimitRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
+			issetNameSpace = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
+			issetBackupID = true
 		case 3:
 			if err := p.ReadField3(iprot); err != nil {
 				return err
 			}
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
+			issetPointInTime = true
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
 			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
@@ -8960,38 +9151,57 @@ func (p *NodeSetPersistRateLimitRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	if !issetNameSpace {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameSpace is not set"))
+	}
+	if !issetBackupID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field BackupID is not set"))
+	}
+	if !issetPointInTime {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field PointInTime is not set"))
+	}
 	return nil
 }
 
-func (p *NodeSetPersistRateLimitRequest) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *RestoreRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.LimitEnabled = &v
+		p.NameSpace = v
 	}
 	return nil
 }
 
-func (p *NodeSetPersistRateLimitRequest) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadDouble(); err != nil {
+func (p *RestoreRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.LimitMbps = &v
+		p.BackupID = v
 	}
 	return nil
 }
 
-func (p *NodeSetPersistRateLimitRequest) ReadField3(iprot thrift.TProtocol) error {
+func (p *RestoreRequest) ReadField3(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 3: ", err)
 	} else {
-		p.LimitCheckEvery = &v
+		p.PointInTime = v
 	}
 	return nil
 }
 
-func (p *NodeSetPersistRateLimitRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeSetPersistRateLimitRequest"); err != nil {
+func (p *RestoreRequest) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		temp := TimeType(v)
+		p.PointInTimeType = temp
+	}
+	return nil
+}
+
+func (p *RestoreRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RestoreRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -9004,6 +9214,9 @@ func (p *NodeSetPersistRateLimitRequest) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField3(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -9014,77 +9227,100 @@ func (p *NodeSetPersistRateLimitRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeSetPersistRateLimitRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if p.IsSetLimitEnabled() {
-		if err := oprot.WriteFieldBegin("limitEnabled", thrift.BOOL, 1); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:limitEnabled: ", p), err)
-		}
-		if err := oprot.WriteBool(bool(*p.LimitEnabled)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.limitEnabled (1) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:limitEnabled: ", p), err)
-		}
+func (p *RestoreRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:nameSpace: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.NameSpace); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:nameSpace: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeSetPersistRateLimitRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetLimitMbps() {
-		if err := oprot.WriteFieldBegin("limitMbps", thrift.DOUBLE, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:limitMbps: ", p), err)
-		}
-		if err := oprot.WriteDouble(float64(*p.LimitMbps)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.limitMbps (2) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:limitMbps: ", p), err)
-		}
+func (p *RestoreRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("backupID", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:backupID: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.BackupID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.backupID (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:backupID: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeSetPersistRateLimitRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetLimitCheckEvery() {
-		if err := oprot.WriteFieldBegin("limitCheckEvery", thrift.I64, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:limitCheckEvery: ", p), err)
+func (p *RestoreRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("pointInTime", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:pointInTime: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.PointInTime)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.pointInTime (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:pointInTime: ", p), err)
+	}
+	return err
+}
+
+func (p *RestoreRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetPointInTimeType() {
+		if err := oprot.WriteFieldBegin("pointInTimeType", thrift.I32, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:pointInTimeType: ", p), err)
 		}
-		if err := oprot.WriteI64(int64(*p.LimitCheckEvery)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.limitCheckEvery (3) field write error: ", p), err)
+		if err := oprot.WriteI32(int32(p.PointInTimeType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.pointInTimeType (4) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:limitCheckEvery: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:pointInTimeType: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *NodeSetPersistRateLimitRequest) String() string {
+func (p *RestoreRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeSetPersistRateLimitRequest(%+v)", *p)
+	return fmt.Sprintf("RestoreRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - WriteNewSeriesAsync
-type NodeWriteNewSeriesAsyncResult_ struct {
-	WriteNewSeriesAsync bool `thrift:"writeNewSeriesAsync,1,required" db:"writeNewSeriesAsync" json:"writeNewSeriesAsync"`
+//  - BackupID
+//  - NumFiles
+//  - NumBytes
+type RestoreResult_ struct {
+	BackupID string `thrift:"backupID,1,required" db:"backupID" json:"backupID"`
+	NumFiles int64  `thrift:"numFiles,2,required" db:"numFiles" json:"numFiles"`
+	NumBytes int64  `thrift:"numBytes,3,required" db:"numBytes" json:"numBytes"`
 }
 
-func NewNodeWriteNewSeriesAsyncResult_() *NodeWriteNewSeriesAsyncResult_ {
-	return &NodeWriteNewSeriesAsyncResult_{}
+func NewRestoreResult_() *RestoreResult_ {
+	return &RestoreResult_{}
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) GetWriteNewSeriesAsync() bool {
-	return p.WriteNewSeriesAsync
+func (p *RestoreResult_) GetBackupID() string {
+	return p.BackupID
 }
-func (p *NodeWriteNewSeriesAsyncResult_) Read(iprot thrift.TProtocol) error {
+
+func (p *RestoreResult_) GetNumFiles() int64 {
+	return p.NumFiles
+}
+
+func (p *RestoreResult_) GetNumBytes() int64 {
+	return p.NumBytes
+}
+func (p *RestoreResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesAsync bool = false
+	var issetBackupID bool = false
+	var issetNumFiles bool = false
+	var issetNumBytes bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -9099,7 +9335,17 @@ func (p *NodeWriteNewSeriesAsyncResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetWriteNewSeriesAsync = true
+			issetBackupID = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetNumFiles = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetNumBytes = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9112,29 +9358,59 @@ func (p *NodeWriteNewSeriesAsyncResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesAsync {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesAsync is not set"))
+	if !issetBackupID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field BackupID is not set"))
+	}
+	if !issetNumFiles {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NumFiles is not set"))
+	}
+	if !issetNumBytes {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NumBytes is not set"))
 	}
 	return nil
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
+func (p *RestoreResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.WriteNewSeriesAsync = v
+		p.BackupID = v
 	}
 	return nil
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeWriteNewSeriesAsyncResult"); err != nil {
+func (p *RestoreResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.NumFiles = v
+	}
+	return nil
+}
+
+func (p *RestoreResult_) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.NumBytes = v
+	}
+	return nil
+}
+
+func (p *RestoreResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RestoreResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -9145,45 +9421,97 @@ func (p *NodeWriteNewSeriesAsyncResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesAsync", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesAsync: ", p), err)
+func (p *RestoreResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("backupID", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:backupID: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.WriteNewSeriesAsync)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesAsync (1) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.BackupID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.backupID (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesAsync: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:backupID: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeWriteNewSeriesAsyncResult_) String() string {
+func (p *RestoreResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("numFiles", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:numFiles: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.NumFiles)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.numFiles (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:numFiles: ", p), err)
+	}
+	return err
+}
+
+func (p *RestoreResult_) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("numBytes", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:numBytes: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.NumBytes)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.numBytes (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:numBytes: ", p), err)
+	}
+	return err
+}
+
+func (p *RestoreResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteNewSeriesAsyncResult_(%+v)", *p)
+	return fmt.Sprintf("RestoreResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - WriteNewSeriesAsync
-type NodeSetWriteNewSeriesAsyncRequest struct {
-	WriteNewSeriesAsync bool `thrift:"writeNewSeriesAsync,1,required" db:"writeNewSeriesAsync" json:"writeNewSeriesAsync"`
+//  - Ok
+//  - Status
+//  - Bootstrapped
+//  - Metadata
+type NodeHealthResult_ struct {
+	Ok           bool              `thrift:"ok,1,required" db:"ok" json:"ok"`
+	Status       string            `thrift:"status,2,required" db:"status" json:"status"`
+	Bootstrapped bool              `thrift:"bootstrapped,3,required" db:"bootstrapped" json:"bootstrapped"`
+	Metadata     map[string]string `thrift:"metadata,4" db:"metadata" json:"metadata,omitempty"`
 }
 
-func NewNodeSetWriteNewSeriesAsyncRequest() *NodeSetWriteNewSeriesAsyncRequest {
-	return &NodeSetWriteNewSeriesAsyncRequest{}
+func NewNodeHealthResult_() *NodeHealthResult_ {
+	return &NodeHealthResult_{}
 }
 
-func (p *NodeSetWriteNewSeriesAsyncRequest) GetWriteNewSeriesAsync() bool {
-	return p.WriteNewSeriesAsync
+func (p *NodeHealthResult_) GetOk() bool {
+	return p.Ok
 }
-func (p *NodeSetWriteNewSeriesAsyncRequest) Read(iprot thrift.TProtocol) error {
+
+func (p *NodeHealthResult_) GetStatus() string {
+	return p.Status
+}
+
+func (p *NodeHealthResult_) GetBootstrapped() bool {
+	return p.Bootstrapped
+}
+
+var NodeHealthResult__Metadata_DEFAULT map[string]string
+
+func (p *NodeHealthResult_) GetMetadata() map[string]string {
+	return p.Metadata
+}
+func (p *NodeHealthResult_) IsSetMetadata() bool {
+	return p.Metadata != nil
+}
+
+func (p *NodeHealthResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesAsync bool = false
+	var issetOk bool = false
+	var issetStatus bool = false
+	var issetBootstrapped bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -9198,7 +9526,21 @@ func (p *NodeSetWriteNewSeriesAsyncRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetWriteNewSeriesAsync = true
+			issetOk = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetStatus = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetBootstrapped = true
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9211,147 +9553,75 @@ func (p *NodeSetWriteNewSeriesAsyncRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesAsync {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesAsync is not set"))
+	if !issetOk {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Ok is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
+	}
+	if !issetBootstrapped {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Bootstrapped is not set"))
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesAsyncRequest) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeHealthResult_) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.WriteNewSeriesAsync = v
+		p.Ok = v
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesAsyncRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesAsyncRequest"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
-	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
+func (p *NodeHealthResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Status = v
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesAsyncRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesAsync", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesAsync: ", p), err)
-	}
-	if err := oprot.WriteBool(bool(p.WriteNewSeriesAsync)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesAsync (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesAsync: ", p), err)
-	}
-	return err
-}
-
-func (p *NodeSetWriteNewSeriesAsyncRequest) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *NodeHealthResult_) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Bootstrapped = v
 	}
-	return fmt.Sprintf("NodeSetWriteNewSeriesAsyncRequest(%+v)", *p)
-}
-
-// Attributes:
-//  - WriteNewSeriesBackoffDuration
-//  - DurationType
-type NodeWriteNewSeriesBackoffDurationResult_ struct {
-	WriteNewSeriesBackoffDuration int64    `thrift:"writeNewSeriesBackoffDuration,1,required" db:"writeNewSeriesBackoffDuration" json:"writeNewSeriesBackoffDuration"`
-	DurationType                  TimeType `thrift:"durationType,2,required" db:"durationType" json:"durationType"`
-}
-
-func NewNodeWriteNewSeriesBackoffDurationResult_() *NodeWriteNewSeriesBackoffDurationResult_ {
-	return &NodeWriteNewSeriesBackoffDurationResult_{}
-}
-
-func (p *NodeWriteNewSeriesBackoffDurationResult_) GetWriteNewSeriesBackoffDuration() int64 {
-	return p.WriteNewSeriesBackoffDuration
+	return nil
 }
 
-func (p *NodeWriteNewSeriesBackoffDurationResult_) GetDurationType() TimeType {
-	return p.DurationType
-}
-func (p *NodeWriteNewSeriesBackoffDurationResult_) Read(iprot thrift.TProtocol) error {
-	if _, err := iprot.ReadStructBegin(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+func (p *NodeHealthResult_) ReadField4(iprot thrift.TProtocol) error {
+	_, _, size, err := iprot.ReadMapBegin()
+	if err != nil {
+		return thrift.PrependError("error reading map begin: ", err)
 	}
-
-	var issetWriteNewSeriesBackoffDuration bool = false
-	var issetDurationType bool = false
-
-	for {
-		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
-		if err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
-		}
-		if fieldTypeId == thrift.STOP {
-			break
-		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-			issetWriteNewSeriesBackoffDuration = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-			issetDurationType = true
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
+	tMap := make(map[string]string, size)
+	p.Metadata = tMap
+	for i := 0; i < size; i++ {
+		var _key22 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_key22 = v
 		}
-		if err := iprot.ReadFieldEnd(); err != nil {
-			return err
+		var _val23 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_val23 = v
 		}
+		p.Metadata[_key22] = _val23
 	}
-	if err := iprot.ReadStructEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-	}
-	if !issetWriteNewSeriesBackoffDuration {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesBackoffDuration is not set"))
-	}
-	if !issetDurationType {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field DurationType is not set"))
-	}
-	return nil
-}
-
-func (p *NodeWriteNewSeriesBackoffDurationResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.WriteNewSeriesBackoffDuration = v
-	}
-	return nil
-}
-
-func (p *NodeWriteNewSeriesBackoffDurationResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		temp := TimeType(v)
-		p.DurationType = temp
+	if err := iprot.ReadMapEnd(); err != nil {
+		return thrift.PrependError("error reading map end: ", err)
 	}
 	return nil
 }
 
-func (p *NodeWriteNewSeriesBackoffDurationResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeWriteNewSeriesBackoffDurationResult"); err != nil {
+func (p *NodeHealthResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeHealthResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -9361,6 +9631,12 @@ func (p *NodeWriteNewSeriesBackoffDurationResult_) Write(oprot thrift.TProtocol)
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -9371,73 +9647,90 @@ func (p *NodeWriteNewSeriesBackoffDurationResult_) Write(oprot thrift.TProtocol)
 	return nil
 }
 
-func (p *NodeWriteNewSeriesBackoffDurationResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesBackoffDuration", thrift.I64, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesBackoffDuration: ", p), err)
+func (p *NodeHealthResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("ok", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ok: ", p), err)
 	}
-	if err := oprot.WriteI64(int64(p.WriteNewSeriesBackoffDuration)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesBackoffDuration (1) field write error: ", p), err)
+	if err := oprot.WriteBool(bool(p.Ok)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.ok (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesBackoffDuration: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ok: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeWriteNewSeriesBackoffDurationResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("durationType", thrift.I32, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:durationType: ", p), err)
+func (p *NodeHealthResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
 	}
-	if err := oprot.WriteI32(int32(p.DurationType)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.durationType (2) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:durationType: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeWriteNewSeriesBackoffDurationResult_) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *NodeHealthResult_) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("bootstrapped", thrift.BOOL, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:bootstrapped: ", p), err)
 	}
-	return fmt.Sprintf("NodeWriteNewSeriesBackoffDurationResult_(%+v)", *p)
+	if err := oprot.WriteBool(bool(p.Bootstrapped)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.bootstrapped (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:bootstrapped: ", p), err)
+	}
+	return err
 }
 
-// Attributes:
-//  - WriteNewSeriesBackoffDuration
-//  - DurationType
-type NodeSetWriteNewSeriesBackoffDurationRequest struct {
-	WriteNewSeriesBackoffDuration int64    `thrift:"writeNewSeriesBackoffDuration,1,required" db:"writeNewSeriesBackoffDuration" json:"writeNewSeriesBackoffDuration"`
-	DurationType                  TimeType `thrift:"durationType,2" db:"durationType" json:"durationType,omitempty"`
+func (p *NodeHealthResult_) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetMetadata() {
+		if err := oprot.WriteFieldBegin("metadata", thrift.MAP, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:metadata: ", p), err)
+		}
+		if err := oprot.WriteMapBegin(thrift.STRING, thrift.STRING, len(p.Metadata)); err != nil {
+			return thrift.PrependError("error writing map begin: ", err)
+		}
+		for k, v := range p.Metadata {
+			if err := oprot.WriteString(string(k)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteMapEnd(); err != nil {
+			return thrift.PrependError("error writing map end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:metadata: ", p), err)
+		}
+	}
+	return err
 }
 
-func NewNodeSetWriteNewSeriesBackoffDurationRequest() *NodeSetWriteNewSeriesBackoffDurationRequest {
-	return &NodeSetWriteNewSeriesBackoffDurationRequest{
-		DurationType: 2,
+func (p *NodeHealthResult_) String() string {
+	if p == nil {
+		return "<nil>"
 	}
+	return fmt.Sprintf("NodeHealthResult_(%+v)", *p)
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) GetWriteNewSeriesBackoffDuration() int64 {
-	return p.WriteNewSeriesBackoffDuration
+type NodeBootstrappedResult_ struct {
 }
 
-var NodeSetWriteNewSeriesBackoffDurationRequest_DurationType_DEFAULT TimeType = 2
-
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) GetDurationType() TimeType {
-	return p.DurationType
-}
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) IsSetDurationType() bool {
-	return p.DurationType != NodeSetWriteNewSeriesBackoffDurationRequest_DurationType_DEFAULT
+func NewNodeBootstrappedResult_() *NodeBootstrappedResult_ {
+	return &NodeBootstrappedResult_{}
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Read(iprot thrift.TProtocol) error {
+func (p *NodeBootstrappedResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesBackoffDuration bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -9446,20 +9739,8 @@ func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Read(iprot thrift.TProtoco
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-			issetWriteNewSeriesBackoffDuration = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -9468,107 +9749,43 @@ func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Read(iprot thrift.TProtoco
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesBackoffDuration {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesBackoffDuration is not set"))
-	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.WriteNewSeriesBackoffDuration = v
+func (p *NodeBootstrappedResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeBootstrappedResult"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		temp := TimeType(v)
-		p.DurationType = temp
-	}
-	return nil
-}
-
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesBackoffDurationRequest"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
-	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
-	}
-	return nil
-}
-
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesBackoffDuration", thrift.I64, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesBackoffDuration: ", p), err)
-	}
-	if err := oprot.WriteI64(int64(p.WriteNewSeriesBackoffDuration)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesBackoffDuration (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesBackoffDuration: ", p), err)
-	}
-	return err
-}
-
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetDurationType() {
-		if err := oprot.WriteFieldBegin("durationType", thrift.I32, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:durationType: ", p), err)
-		}
-		if err := oprot.WriteI32(int32(p.DurationType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.durationType (2) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:durationType: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeSetWriteNewSeriesBackoffDurationRequest) String() string {
+func (p *NodeBootstrappedResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeSetWriteNewSeriesBackoffDurationRequest(%+v)", *p)
+	return fmt.Sprintf("NodeBootstrappedResult_(%+v)", *p)
 }
 
-// Attributes:
-//  - WriteNewSeriesLimitPerShardPerSecond
-type NodeWriteNewSeriesLimitPerShardPerSecondResult_ struct {
-	WriteNewSeriesLimitPerShardPerSecond int64 `thrift:"writeNewSeriesLimitPerShardPerSecond,1,required" db:"writeNewSeriesLimitPerShardPerSecond" json:"writeNewSeriesLimitPerShardPerSecond"`
+type NodeBootstrappedInPlacementOrNoPlacementResult_ struct {
 }
 
-func NewNodeWriteNewSeriesLimitPerShardPerSecondResult_() *NodeWriteNewSeriesLimitPerShardPerSecondResult_ {
-	return &NodeWriteNewSeriesLimitPerShardPerSecondResult_{}
+func NewNodeBootstrappedInPlacementOrNoPlacementResult_() *NodeBootstrappedInPlacementOrNoPlacementResult_ {
+	return &NodeBootstrappedInPlacementOrNoPlacementResult_{}
 }
 
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) GetWriteNewSeriesLimitPerShardPerSecond() int64 {
-	return p.WriteNewSeriesLimitPerShardPerSecond
-}
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Read(iprot thrift.TProtocol) error {
+func (p *NodeBootstrappedInPlacementOrNoPlacementResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesLimitPerShardPerSecond bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -9577,16 +9794,8 @@ func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Read(iprot thrift.TPro
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-			issetWriteNewSeriesLimitPerShardPerSecond = true
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -9595,29 +9804,14 @@ func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Read(iprot thrift.TPro
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesLimitPerShardPerSecond {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesLimitPerShardPerSecond is not set"))
-	}
-	return nil
-}
-
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.WriteNewSeriesLimitPerShardPerSecond = v
-	}
 	return nil
 }
 
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeWriteNewSeriesLimitPerShardPerSecondResult"); err != nil {
+func (p *NodeBootstrappedInPlacementOrNoPlacementResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeBootstrappedInPlacementOrNoPlacementResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -9628,45 +9822,46 @@ func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Write(oprot thrift.TPr
 	return nil
 }
 
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesLimitPerShardPerSecond", thrift.I64, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
-	}
-	if err := oprot.WriteI64(int64(p.WriteNewSeriesLimitPerShardPerSecond)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesLimitPerShardPerSecond (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
-	}
-	return err
-}
-
-func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) String() string {
+func (p *NodeBootstrappedInPlacementOrNoPlacementResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteNewSeriesLimitPerShardPerSecondResult_(%+v)", *p)
+	return fmt.Sprintf("NodeBootstrappedInPlacementOrNoPlacementResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - WriteNewSeriesLimitPerShardPerSecond
-type NodeSetWriteNewSeriesLimitPerShardPerSecondRequest struct {
-	WriteNewSeriesLimitPerShardPerSecond int64 `thrift:"writeNewSeriesLimitPerShardPerSecond,1,required" db:"writeNewSeriesLimitPerShardPerSecond" json:"writeNewSeriesLimitPerShardPerSecond"`
+//  - LimitEnabled
+//  - LimitMbps
+//  - LimitCheckEvery
+type NodePersistRateLimitResult_ struct {
+	LimitEnabled    bool    `thrift:"limitEnabled,1,required" db:"limitEnabled" json:"limitEnabled"`
+	LimitMbps       float64 `thrift:"limitMbps,2,required" db:"limitMbps" json:"limitMbps"`
+	LimitCheckEvery int64   `thrift:"limitCheckEvery,3,required" db:"limitCheckEvery" json:"limitCheckEvery"`
 }
 
-func NewNodeSetWriteNewSeriesLimitPerShardPerSecondRequest() *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest {
-	return &NodeSetWriteNewSeriesLimitPerShardPerSecondRequest{}
+func NewNodePersistRateLimitResult_() *NodePersistRateLimitResult_ {
+	return &NodePersistRateLimitResult_{}
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) GetWriteNewSeriesLimitPerShardPerSecond() int64 {
-	return p.WriteNewSeriesLimitPerShardPerSecond
+func (p *NodePersistRateLimitResult_) GetLimitEnabled() bool {
+	return p.LimitEnabled
 }
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Read(iprot thrift.TProtocol) error {
+
+func (p *NodePersistRateLimitResult_) GetLimitMbps() float64 {
+	return p.LimitMbps
+}
+
+func (p *NodePersistRateLimitResult_) GetLimitCheckEvery() int64 {
+	return p.LimitCheckEvery
+}
+func (p *NodePersistRateLimitResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetWriteNewSeriesLimitPerShardPerSecond bool = false
+	var issetLimitEnabled bool = false
+	var issetLimitMbps bool = false
+	var issetLimitCheckEvery bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -9681,7 +9876,17 @@ func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Read(iprot thrift.T
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetWriteNewSeriesLimitPerShardPerSecond = true
+			issetLimitEnabled = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetLimitMbps = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetLimitCheckEvery = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9694,29 +9899,59 @@ func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Read(iprot thrift.T
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetWriteNewSeriesLimitPerShardPerSecond {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesLimitPerShardPerSecond is not set"))
+	if !issetLimitEnabled {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitEnabled is not set"))
+	}
+	if !issetLimitMbps {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitMbps is not set"))
+	}
+	if !issetLimitCheckEvery {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field LimitCheckEvery is not set"))
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
+func (p *NodePersistRateLimitResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.WriteNewSeriesLimitPerShardPerSecond = v
+		p.LimitEnabled = v
 	}
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesLimitPerShardPerSecondRequest"); err != nil {
+func (p *NodePersistRateLimitResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.LimitMbps = v
+	}
+	return nil
+}
+
+func (p *NodePersistRateLimitResult_) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.LimitCheckEvery = v
+	}
+	return nil
+}
+
+func (p *NodePersistRateLimitResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodePersistRateLimitResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -9727,53 +9962,109 @@ func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Write(oprot thrift.
 	return nil
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("writeNewSeriesLimitPerShardPerSecond", thrift.I64, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
+func (p *NodePersistRateLimitResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("limitEnabled", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:limitEnabled: ", p), err)
 	}
-	if err := oprot.WriteI64(int64(p.WriteNewSeriesLimitPerShardPerSecond)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesLimitPerShardPerSecond (1) field write error: ", p), err)
+	if err := oprot.WriteBool(bool(p.LimitEnabled)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.limitEnabled (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:limitEnabled: ", p), err)
 	}
 	return err
 }
 
-func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) String() string {
+func (p *NodePersistRateLimitResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("limitMbps", thrift.DOUBLE, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:limitMbps: ", p), err)
+	}
+	if err := oprot.WriteDouble(float64(p.LimitMbps)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.limitMbps (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:limitMbps: ", p), err)
+	}
+	return err
+}
+
+func (p *NodePersistRateLimitResult_) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("limitCheckEvery", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:limitCheckEvery: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.LimitCheckEvery)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.limitCheckEvery (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:limitCheckEvery: ", p), err)
+	}
+	return err
+}
+
+func (p *NodePersistRateLimitResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeSetWriteNewSeriesLimitPerShardPerSecondRequest(%+v)", *p)
+	return fmt.Sprintf("NodePersistRateLimitResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - Ok
-//  - Status
-type HealthResult_ struct {
-	Ok     bool   `thrift:"ok,1,required" db:"ok" json:"ok"`
-	Status string `thrift:"status,2,required" db:"status" json:"status"`
+//  - LimitEnabled
+//  - LimitMbps
+//  - LimitCheckEvery
+type NodeSetPersistRateLimitRequest struct {
+	LimitEnabled    *bool    `thrift:"limitEnabled,1" db:"limitEnabled" json:"limitEnabled,omitempty"`
+	LimitMbps       *float64 `thrift:"limitMbps,2" db:"limitMbps" json:"limitMbps,omitempty"`
+	LimitCheckEvery *int64   `thrift:"limitCheckEvery,3" db:"limitCheckEvery" json:"limitCheckEvery,omitempty"`
 }
 
-func NewHealthResult_() *HealthResult_ {
-	return &HealthResult_{}
+func NewNodeSetPersistRateLimitRequest() *NodeSetPersistRateLimitRequest {
+	return &NodeSetPersistRateLimitRequest{}
 }
 
-func (p *HealthResult_) GetOk() bool {
-	return p.Ok
+var NodeSetPersistRateLimitRequest_LimitEnabled_DEFAULT bool
+
+func (p *NodeSetPersistRateLimitRequest) GetLimitEnabled() bool {
+	if !p.IsSetLimitEnabled() {
+		return NodeSetPersistRateLimitRequest_LimitEnabled_DEFAULT
+	}
+	return *p.LimitEnabled
 }
 
-func (p *HealthResult_) GetStatus() string {
-	return p.Status
+var NodeSetPersistRateLimitRequest_LimitMbps_DEFAULT float64
+
+func (p *NodeSetPersistRateLimitRequest) GetLimitMbps() float64 {
+	if !p.IsSetLimitMbps() {
+		return NodeSetPersistRateLimitRequest_LimitMbps_DEFAULT
+	}
+	return *p.LimitMbps
 }
-func (p *HealthResult_) Read(iprot thrift.TProtocol) error {
+
+var NodeSetPersistRateLimitRequest_LimitCheckEvery_DEFAULT int64
+
+func (p *NodeSetPersistRateLimitRequest) GetLimitCheckEvery() int64 {
+	if !p.IsSetLimitCheckEvery() {
+		return NodeSetPersistRateLimitRequest_LimitCheckEvery_DEFAULT
+	}
+	return *p.LimitCheckEvery
+}
+func (p *NodeSetPersistRateLimitRequest) IsSetLimitEnabled() bool {
+	return p.LimitEnabled != nil
+}
+
+func (p *NodeSetPersistRateLimitRequest) IsSetLimitMbps() bool {
+	return p.LimitMbps != nil
+}
+
+func (p *NodeSetPersistRateLimitRequest) IsSetLimitCheckEvery() bool {
+	return p.LimitCheckEvery != nil
+}
+
+func (p *NodeSetPersistRateLimitRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetOk bool = false
-	var issetStatus bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -9787,12 +10078,14 @@ func (p *HealthResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetOk = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetStatus = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -9805,35 +10098,38 @@ func (p *HealthResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetOk {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Ok is not set"))
-	}
-	if !issetStatus {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
-	}
 	return nil
 }
 
-func (p *HealthResult_) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeSetPersistRateLimitRequest) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Ok = v
+		p.LimitEnabled = &v
 	}
 	return nil
 }
 
-func (p *HealthResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *NodeSetPersistRateLimitRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadDouble(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.Status = v
+		p.LimitMbps = &v
 	}
 	return nil
 }
 
-func (p *HealthResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("HealthResult"); err != nil {
+func (p *NodeSetPersistRateLimitRequest) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.LimitCheckEvery = &v
+	}
+	return nil
+}
+
+func (p *NodeSetPersistRateLimitRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeSetPersistRateLimitRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -9843,6 +10139,9 @@ func (p *HealthResult_) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -9853,191 +10152,282 @@ func (p *HealthResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *HealthResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("ok", thrift.BOOL, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ok: ", p), err)
-	}
-	if err := oprot.WriteBool(bool(p.Ok)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.ok (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ok: ", p), err)
+func (p *NodeSetPersistRateLimitRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimitEnabled() {
+		if err := oprot.WriteFieldBegin("limitEnabled", thrift.BOOL, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:limitEnabled: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.LimitEnabled)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limitEnabled (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:limitEnabled: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *HealthResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("status", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.Status)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+func (p *NodeSetPersistRateLimitRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimitMbps() {
+		if err := oprot.WriteFieldBegin("limitMbps", thrift.DOUBLE, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:limitMbps: ", p), err)
+		}
+		if err := oprot.WriteDouble(float64(*p.LimitMbps)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limitMbps (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:limitMbps: ", p), err)
+		}
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
+	return err
+}
+
+func (p *NodeSetPersistRateLimitRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimitCheckEvery() {
+		if err := oprot.WriteFieldBegin("limitCheckEvery", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:limitCheckEvery: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.LimitCheckEvery)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limitCheckEvery (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:limitCheckEvery: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *HealthResult_) String() string {
+func (p *NodeSetPersistRateLimitRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("HealthResult_(%+v)", *p)
+	return fmt.Sprintf("NodeSetPersistRateLimitRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - Query
-//  - RangeStart
-//  - RangeEnd
-//  - NameSpace
-//  - SeriesLimit
-//  - TagNameFilter
-//  - AggregateQueryType
-//  - RangeType
-//  - Source
-//  - DocsLimit
-//  - RequireExhaustive
-//  - RequireNoWait
-type AggregateQueryRawRequest struct {
-	Query              []byte             `thrift:"query,1,required" db:"query" json:"query"`
-	RangeStart         int64              `thrift:"rangeStart,2,required" db:"rangeStart" json:"rangeStart"`
-	RangeEnd           int64              `thrift:"rangeEnd,3,required" db:"rangeEnd" json:"rangeEnd"`
-	NameSpace          []byte             `thrift:"nameSpace,4,required" db:"nameSpace" json:"nameSpace"`
-	SeriesLimit        *int64             `thrift:"seriesLimit,5" db:"seriesLimit" json:"seriesLimit,omitempty"`
-	TagNameFilter      [][]byte           `thrift:"tagNameFilter,6" db:"tagNameFilter" json:"tagNameFilter,omitempty"`
-	AggregateQueryType AggregateQueryType `thrift:"aggregateQueryType,7" db:"aggregateQueryType" json:"aggregateQueryType,omitempty"`
-	RangeType          TimeType           `thrift:"rangeType,8" db:"rangeType" json:"rangeType,omitempty"`
-	Source             []byte             `thrift:"source,9" db:"source" json:"source,omitempty"`
-	DocsLimit          *int64             `thrift:"docsLimit,10" db:"docsLimit" json:"docsLimit,omitempty"`
-	RequireExhaustive  *bool              `thrift:"requireExhaustive,11" db:"requireExhaustive" json:"requireExhaustive,omitempty"`
-	RequireNoWait      *bool              `thrift:"requireNoWait,12" db:"requireNoWait" json:"requireNoWait,omitempty"`
-}
-
-func NewAggregateQueryRawRequest() *AggregateQueryRawRequest {
-	return &AggregateQueryRawRequest{
-		AggregateQueryType: 1,
-
-		RangeType: 0,
-	}
-}
-
-func (p *AggregateQueryRawRequest) GetQuery() []byte {
-	return p.Query
-}
-
-func (p *AggregateQueryRawRequest) GetRangeStart() int64 {
-	return p.RangeStart
+//  - WriteNewSeriesAsync
+type NodeWriteNewSeriesAsyncResult_ struct {
+	WriteNewSeriesAsync bool `thrift:"writeNewSeriesAsync,1,required" db:"writeNewSeriesAsync" json:"writeNewSeriesAsync"`
 }
 
-func (p *AggregateQueryRawRequest) GetRangeEnd() int64 {
-	return p.RangeEnd
+func NewNodeWriteNewSeriesAsyncResult_() *NodeWriteNewSeriesAsyncResult_ {
+	return &NodeWriteNewSeriesAsyncResult_{}
 }
 
-func (p *AggregateQueryRawRequest) GetNameSpace() []byte {
-	return p.NameSpace
+func (p *NodeWriteNewSeriesAsyncResult_) GetWriteNewSeriesAsync() bool {
+	return p.WriteNewSeriesAsync
 }
+func (p *NodeWriteNewSeriesAsyncResult_) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
 
-var AggregateQueryRawRequest_SeriesLimit_DEFAULT int64
+	var issetWriteNewSeriesAsync bool = false
 
-func (p *AggregateQueryRawRequest) GetSeriesLimit() int64 {
-	if !p.IsSetSeriesLimit() {
-		return AggregateQueryRawRequest_SeriesLimit_DEFAULT
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetWriteNewSeriesAsync = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
 	}
-	return *p.SeriesLimit
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetWriteNewSeriesAsync {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesAsync is not set"))
+	}
+	return nil
 }
 
-var AggregateQueryRawRequest_TagNameFilter_DEFAULT [][]byte
-
-func (p *AggregateQueryRawRequest) GetTagNameFilter() [][]byte {
-	return p.TagNameFilter
+func (p *NodeWriteNewSeriesAsyncResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.WriteNewSeriesAsync = v
+	}
+	return nil
 }
 
-var AggregateQueryRawRequest_AggregateQueryType_DEFAULT AggregateQueryType = 1
-
-func (p *AggregateQueryRawRequest) GetAggregateQueryType() AggregateQueryType {
-	return p.AggregateQueryType
+func (p *NodeWriteNewSeriesAsyncResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeWriteNewSeriesAsyncResult"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
 }
 
-var AggregateQueryRawRequest_RangeType_DEFAULT TimeType = 0
-
-func (p *AggregateQueryRawRequest) GetRangeType() TimeType {
-	return p.RangeType
+func (p *NodeWriteNewSeriesAsyncResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesAsync", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesAsync: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.WriteNewSeriesAsync)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesAsync (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesAsync: ", p), err)
+	}
+	return err
 }
 
-var AggregateQueryRawRequest_Source_DEFAULT []byte
+func (p *NodeWriteNewSeriesAsyncResult_) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeWriteNewSeriesAsyncResult_(%+v)", *p)
+}
 
-func (p *AggregateQueryRawRequest) GetSource() []byte {
-	return p.Source
+// Attributes:
+//  - WriteNewSeriesAsync
+type NodeSetWriteNewSeriesAsyncRequest struct {
+	WriteNewSeriesAsync bool `thrift:"writeNewSeriesAsync,1,required" db:"writeNewSeriesAsync" json:"writeNewSeriesAsync"`
 }
 
-var AggregateQueryRawRequest_DocsLimit_DEFAULT int64
+func NewNodeSetWriteNewSeriesAsyncRequest() *NodeSetWriteNewSeriesAsyncRequest {
+	return &NodeSetWriteNewSeriesAsyncRequest{}
+}
 
-func (p *AggregateQueryRawRequest) GetDocsLimit() int64 {
-	if !p.IsSetDocsLimit() {
-		return AggregateQueryRawRequest_DocsLimit_DEFAULT
-	}
-	return *p.DocsLimit
+func (p *NodeSetWriteNewSeriesAsyncRequest) GetWriteNewSeriesAsync() bool {
+	return p.WriteNewSeriesAsync
 }
+func (p *NodeSetWriteNewSeriesAsyncRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
 
-var AggregateQueryRawRequest_RequireExhaustive_DEFAULT bool
+	var issetWriteNewSeriesAsync bool = false
 
-func (p *AggregateQueryRawRequest) GetRequireExhaustive() bool {
-	if !p.IsSetRequireExhaustive() {
-		return AggregateQueryRawRequest_RequireExhaustive_DEFAULT
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetWriteNewSeriesAsync = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
 	}
-	return *p.RequireExhaustive
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetWriteNewSeriesAsync {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesAsync is not set"))
+	}
+	return nil
 }
 
-var AggregateQueryRawRequest_RequireNoWait_DEFAULT bool
-
-func (p *AggregateQueryRawRequest) GetRequireNoWait() bool {
-	if !p.IsSetRequireNoWait() {
-		return AggregateQueryRawRequest_RequireNoWait_DEFAULT
+func (p *NodeSetWriteNewSeriesAsyncRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.WriteNewSeriesAsync = v
 	}
-	return *p.RequireNoWait
-}
-func (p *AggregateQueryRawRequest) IsSetSeriesLimit() bool {
-	return p.SeriesLimit != nil
+	return nil
 }
 
-func (p *AggregateQueryRawRequest) IsSetTagNameFilter() bool {
-	return p.TagNameFilter != nil
+func (p *NodeSetWriteNewSeriesAsyncRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesAsyncRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
 }
 
-func (p *AggregateQueryRawRequest) IsSetAggregateQueryType() bool {
-	return p.AggregateQueryType != AggregateQueryRawRequest_AggregateQueryType_DEFAULT
+func (p *NodeSetWriteNewSeriesAsyncRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesAsync", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesAsync: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.WriteNewSeriesAsync)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesAsync (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesAsync: ", p), err)
+	}
+	return err
 }
 
-func (p *AggregateQueryRawRequest) IsSetRangeType() bool {
-	return p.RangeType != AggregateQueryRawRequest_RangeType_DEFAULT
+func (p *NodeSetWriteNewSeriesAsyncRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeSetWriteNewSeriesAsyncRequest(%+v)", *p)
 }
 
-func (p *AggregateQueryRawRequest) IsSetSource() bool {
-	return p.Source != nil
+// Attributes:
+//  - WriteNewSeriesBackoffDuration
+//  - DurationType
+type NodeWriteNewSeriesBackoffDurationResult_ struct {
+	WriteNewSeriesBackoffDuration int64    `thrift:"writeNewSeriesBackoffDuration,1,required" db:"writeNewSeriesBackoffDuration" json:"writeNewSeriesBackoffDuration"`
+	DurationType                  TimeType `thrift:"durationType,2,required" db:"durationType" json:"durationType"`
 }
 
-func (p *AggregateQueryRawRequest) IsSetDocsLimit() bool {
-	return p.DocsLimit != nil
+func NewNodeWriteNewSeriesBackoffDurationResult_() *NodeWriteNewSeriesBackoffDurationResult_ {
+	return &NodeWriteNewSeriesBackoffDurationResult_{}
 }
 
-func (p *AggregateQueryRawRequest) IsSetRequireExhaustive() bool {
-	return p.RequireExhaustive != nil
+func (p *NodeWriteNewSeriesBackoffDurationResult_) GetWriteNewSeriesBackoffDuration() int64 {
+	return p.WriteNewSeriesBackoffDuration
 }
 
-func (p *AggregateQueryRawRequest) IsSetRequireNoWait() bool {
-	return p.RequireNoWait != nil
+func (p *NodeWriteNewSeriesBackoffDurationResult_) GetDurationType() TimeType {
+	return p.DurationType
 }
-
-func (p *AggregateQueryRawRequest) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteNewSeriesBackoffDurationResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetQuery bool = false
-	var issetRangeStart bool = false
-	var issetRangeEnd bool = false
-	var issetNameSpace bool = false
+	var issetWriteNewSeriesBackoffDuration bool = false
+	var issetDurationType bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -10052,54 +10442,12 @@ func (p *AggregateQueryRawRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetQuery = true
+			issetWriteNewSeriesBackoffDuration = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetRangeStart = true
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
-			issetRangeEnd = true
-		case 4:
-			if err := p.ReadField4(iprot); err != nil {
-				return err
-			}
-			issetNameSpace = true
-		case 5:
-			if err := p.ReadField5(iprot); err != nil {
-				return err
-			}
-		case 6:
-			if err := p.ReadField6(iprot); err != nil {
-				return err
-			}
-		case 7:
-			if err := p.ReadField7(iprot); err != nil {
-				return err
-			}
-		case 8:
-			if err := p.ReadField8(iprot); err != nil {
-				return err
-			}
-		case 9:
-			if err := p.ReadField9(iprot); err != nil {
-				return err
-			}
-		case 10:
-			if err := p.ReadField10(iprot); err != nil {
-				return err
-			}
-		case 11:
-			if err := p.ReadField11(iprot); err != nil {
-				return err
-			}
-		case 12:
-			if err := p.ReadField12(iprot); err != nil {
-				return err
-			}
+			issetDurationType = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -10112,146 +10460,179 @@ func (p *AggregateQueryRawRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetQuery {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
-	}
-	if !issetRangeStart {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
-	}
-	if !issetRangeEnd {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeEnd is not set"))
+	if !issetWriteNewSeriesBackoffDuration {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesBackoffDuration is not set"))
 	}
-	if !issetNameSpace {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameSpace is not set"))
+	if !issetDurationType {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field DurationType is not set"))
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawRequest) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
+func (p *NodeWriteNewSeriesBackoffDurationResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Query = v
+		p.WriteNewSeriesBackoffDuration = v
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawRequest) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
+func (p *NodeWriteNewSeriesBackoffDurationResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.RangeStart = v
+		temp := TimeType(v)
+		p.DurationType = temp
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawRequest) ReadField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
-	} else {
-		p.RangeEnd = v
+func (p *NodeWriteNewSeriesBackoffDurationResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeWriteNewSeriesBackoffDurationResult"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	return nil
-}
-
-func (p *AggregateQueryRawRequest) ReadField4(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
-		return thrift.PrependError("error reading field 4: ", err)
-	} else {
-		p.NameSpace = v
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawRequest) ReadField5(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 5: ", err)
-	} else {
-		p.SeriesLimit = &v
+func (p *NodeWriteNewSeriesBackoffDurationResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesBackoffDuration", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesBackoffDuration: ", p), err)
 	}
-	return nil
+	if err := oprot.WriteI64(int64(p.WriteNewSeriesBackoffDuration)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesBackoffDuration (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesBackoffDuration: ", p), err)
+	}
+	return err
 }
 
-func (p *AggregateQueryRawRequest) ReadField6(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
+func (p *NodeWriteNewSeriesBackoffDurationResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("durationType", thrift.I32, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:durationType: ", p), err)
 	}
-	tSlice := make([][]byte, 0, size)
-	p.TagNameFilter = tSlice
-	for i := 0; i < size; i++ {
-		var _elem24 []byte
-		if v, err := iprot.ReadBinary(); err != nil {
-			return thrift.PrependError("error reading field 0: ", err)
-		} else {
-			_elem24 = v
-		}
-		p.TagNameFilter = append(p.TagNameFilter, _elem24)
+	if err := oprot.WriteI32(int32(p.DurationType)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.durationType (2) field write error: ", p), err)
 	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:durationType: ", p), err)
 	}
-	return nil
+	return err
 }
 
-func (p *AggregateQueryRawRequest) ReadField7(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 7: ", err)
-	} else {
-		temp := AggregateQueryType(v)
-		p.AggregateQueryType = temp
+func (p *NodeWriteNewSeriesBackoffDurationResult_) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return nil
+	return fmt.Sprintf("NodeWriteNewSeriesBackoffDurationResult_(%+v)", *p)
 }
 
-func (p *AggregateQueryRawRequest) ReadField8(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 8: ", err)
-	} else {
-		temp := TimeType(v)
-		p.RangeType = temp
-	}
-	return nil
+// Attributes:
+//  - WriteNewSeriesBackoffDuration
+//  - DurationType
+type NodeSetWriteNewSeriesBackoffDurationRequest struct {
+	WriteNewSeriesBackoffDuration int64    `thrift:"writeNewSeriesBackoffDuration,1,required" db:"writeNewSeriesBackoffDuration" json:"writeNewSeriesBackoffDuration"`
+	DurationType                  TimeType `thrift:"durationType,2" db:"durationType" json:"durationType,omitempty"`
 }
 
-func (p *AggregateQueryRawRequest) ReadField9(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
-		return thrift.PrependError("error reading field 9: ", err)
-	} else {
-		p.Source = v
+func NewNodeSetWriteNewSeriesBackoffDurationRequest() *NodeSetWriteNewSeriesBackoffDurationRequest {
+	return &NodeSetWriteNewSeriesBackoffDurationRequest{
+		DurationType: 2,
 	}
-	return nil
 }
 
-func (p *AggregateQueryRawRequest) ReadField10(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 10: ", err)
-	} else {
-		p.DocsLimit = &v
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) GetWriteNewSeriesBackoffDuration() int64 {
+	return p.WriteNewSeriesBackoffDuration
+}
+
+var NodeSetWriteNewSeriesBackoffDurationRequest_DurationType_DEFAULT TimeType = 2
+
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) GetDurationType() TimeType {
+	return p.DurationType
+}
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) IsSetDurationType() bool {
+	return p.DurationType != NodeSetWriteNewSeriesBackoffDurationRequest_DurationType_DEFAULT
+}
+
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetWriteNewSeriesBackoffDuration bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetWriteNewSeriesBackoffDuration = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetWriteNewSeriesBackoffDuration {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesBackoffDuration is not set"))
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawRequest) ReadField11(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
-		return thrift.PrependError("error reading field 11: ", err)
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.RequireExhaustive = &v
+		p.WriteNewSeriesBackoffDuration = v
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawRequest) ReadField12(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
-		return thrift.PrependError("error reading field 12: ", err)
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.RequireNoWait = &v
+		temp := TimeType(v)
+		p.DurationType = temp
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateQueryRawRequest"); err != nil {
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesBackoffDurationRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -10261,36 +10642,6 @@ func (p *AggregateQueryRawRequest) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField4(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField5(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField6(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField7(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField8(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField9(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField10(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField11(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField12(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -10301,234 +10652,159 @@ func (p *AggregateQueryRawRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRawRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("query", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesBackoffDuration", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesBackoffDuration: ", p), err)
 	}
-	if err := oprot.WriteBinary(p.Query); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.query (1) field write error: ", p), err)
+	if err := oprot.WriteI64(int64(p.WriteNewSeriesBackoffDuration)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesBackoffDuration (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesBackoffDuration: ", p), err)
 	}
 	return err
 }
 
-func (p *AggregateQueryRawRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:rangeStart: ", p), err)
-	}
-	if err := oprot.WriteI64(int64(p.RangeStart)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.rangeStart (2) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:rangeStart: ", p), err)
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDurationType() {
+		if err := oprot.WriteFieldBegin("durationType", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:durationType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(p.DurationType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.durationType (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:durationType: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *AggregateQueryRawRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeEnd: ", p), err)
-	}
-	if err := oprot.WriteI64(int64(p.RangeEnd)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.rangeEnd (3) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:rangeEnd: ", p), err)
+func (p *NodeSetWriteNewSeriesBackoffDurationRequest) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return err
+	return fmt.Sprintf("NodeSetWriteNewSeriesBackoffDurationRequest(%+v)", *p)
 }
 
-func (p *AggregateQueryRawRequest) writeField4(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 4); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:nameSpace: ", p), err)
-	}
-	if err := oprot.WriteBinary(p.NameSpace); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (4) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:nameSpace: ", p), err)
-	}
-	return err
+// Attributes:
+//  - WriteNewSeriesLimitPerShardPerSecond
+type NodeWriteNewSeriesLimitPerShardPerSecondResult_ struct {
+	WriteNewSeriesLimitPerShardPerSecond int64 `thrift:"writeNewSeriesLimitPerShardPerSecond,1,required" db:"writeNewSeriesLimitPerShardPerSecond" json:"writeNewSeriesLimitPerShardPerSecond"`
 }
 
-func (p *AggregateQueryRawRequest) writeField5(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSeriesLimit() {
-		if err := oprot.WriteFieldBegin("seriesLimit", thrift.I64, 5); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:seriesLimit: ", p), err)
-		}
-		if err := oprot.WriteI64(int64(*p.SeriesLimit)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.seriesLimit (5) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:seriesLimit: ", p), err)
-		}
-	}
-	return err
+func NewNodeWriteNewSeriesLimitPerShardPerSecondResult_() *NodeWriteNewSeriesLimitPerShardPerSecondResult_ {
+	return &NodeWriteNewSeriesLimitPerShardPerSecondResult_{}
 }
 
-func (p *AggregateQueryRawRequest) writeField6(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTagNameFilter() {
-		if err := oprot.WriteFieldBegin("tagNameFilter", thrift.LIST, 6); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:tagNameFilter: ", p), err)
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) GetWriteNewSeriesLimitPerShardPerSecond() int64 {
+	return p.WriteNewSeriesLimitPerShardPerSecond
+}
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetWriteNewSeriesLimitPerShardPerSecond bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
 		}
-		if err := oprot.WriteListBegin(thrift.STRING, len(p.TagNameFilter)); err != nil {
-			return thrift.PrependError("error writing list begin: ", err)
+		if fieldTypeId == thrift.STOP {
+			break
 		}
-		for _, v := range p.TagNameFilter {
-			if err := oprot.WriteBinary(v); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetWriteNewSeriesLimitPerShardPerSecond = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
 			}
 		}
-		if err := oprot.WriteListEnd(); err != nil {
-			return thrift.PrependError("error writing list end: ", err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:tagNameFilter: ", p), err)
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
 	}
-	return err
-}
-
-func (p *AggregateQueryRawRequest) writeField7(oprot thrift.TProtocol) (err error) {
-	if p.IsSetAggregateQueryType() {
-		if err := oprot.WriteFieldBegin("aggregateQueryType", thrift.I32, 7); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:aggregateQueryType: ", p), err)
-		}
-		if err := oprot.WriteI32(int32(p.AggregateQueryType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.aggregateQueryType (7) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:aggregateQueryType: ", p), err)
-		}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return err
-}
-
-func (p *AggregateQueryRawRequest) writeField8(oprot thrift.TProtocol) (err error) {
-	if p.IsSetRangeType() {
-		if err := oprot.WriteFieldBegin("rangeType", thrift.I32, 8); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:rangeType: ", p), err)
-		}
-		if err := oprot.WriteI32(int32(p.RangeType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.rangeType (8) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:rangeType: ", p), err)
-		}
+	if !issetWriteNewSeriesLimitPerShardPerSecond {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesLimitPerShardPerSecond is not set"))
 	}
-	return err
+	return nil
 }
 
-func (p *AggregateQueryRawRequest) writeField9(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSource() {
-		if err := oprot.WriteFieldBegin("source", thrift.STRING, 9); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:source: ", p), err)
-		}
-		if err := oprot.WriteBinary(p.Source); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.source (9) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:source: ", p), err)
-		}
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.WriteNewSeriesLimitPerShardPerSecond = v
 	}
-	return err
+	return nil
 }
 
-func (p *AggregateQueryRawRequest) writeField10(oprot thrift.TProtocol) (err error) {
-	if p.IsSetDocsLimit() {
-		if err := oprot.WriteFieldBegin("docsLimit", thrift.I64, 10); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:docsLimit: ", p), err)
-		}
-		if err := oprot.WriteI64(int64(*p.DocsLimit)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.docsLimit (10) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:docsLimit: ", p), err)
-		}
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeWriteNewSeriesLimitPerShardPerSecondResult"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	return err
-}
-
-func (p *AggregateQueryRawRequest) writeField11(oprot thrift.TProtocol) (err error) {
-	if p.IsSetRequireExhaustive() {
-		if err := oprot.WriteFieldBegin("requireExhaustive", thrift.BOOL, 11); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:requireExhaustive: ", p), err)
-		}
-		if err := oprot.WriteBool(bool(*p.RequireExhaustive)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.requireExhaustive (11) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 11:requireExhaustive: ", p), err)
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
 	}
-	return err
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
 }
 
-func (p *AggregateQueryRawRequest) writeField12(oprot thrift.TProtocol) (err error) {
-	if p.IsSetRequireNoWait() {
-		if err := oprot.WriteFieldBegin("requireNoWait", thrift.BOOL, 12); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:requireNoWait: ", p), err)
-		}
-		if err := oprot.WriteBool(bool(*p.RequireNoWait)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.requireNoWait (12) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:requireNoWait: ", p), err)
-		}
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesLimitPerShardPerSecond", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.WriteNewSeriesLimitPerShardPerSecond)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesLimitPerShardPerSecond (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
 	}
 	return err
 }
 
-func (p *AggregateQueryRawRequest) String() string {
+func (p *NodeWriteNewSeriesLimitPerShardPerSecondResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AggregateQueryRawRequest(%+v)", *p)
+	return fmt.Sprintf("NodeWriteNewSeriesLimitPerShardPerSecondResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - Results
-//  - Exhaustive
-//  - WaitedIndex
-type AggregateQueryRawResult_ struct {
-	Results     []*AggregateQueryRawResultTagNameElement `thrift:"results,1,required" db:"results" json:"results"`
-	Exhaustive  bool                                     `thrift:"exhaustive,2,required" db:"exhaustive" json:"exhaustive"`
-	WaitedIndex *int64                                   `thrift:"waitedIndex,3" db:"waitedIndex" json:"waitedIndex,omitempty"`
-}
-
-func NewAggregateQueryRawResult_() *AggregateQueryRawResult_ {
-	return &AggregateQueryRawResult_{}
-}
-
-func (p *AggregateQueryRawResult_) GetResults() []*AggregateQueryRawResultTagNameElement {
-	return p.Results
+//  - WriteNewSeriesLimitPerShardPerSecond
+type NodeSetWriteNewSeriesLimitPerShardPerSecondRequest struct {
+	WriteNewSeriesLimitPerShardPerSecond int64 `thrift:"writeNewSeriesLimitPerShardPerSecond,1,required" db:"writeNewSeriesLimitPerShardPerSecond" json:"writeNewSeriesLimitPerShardPerSecond"`
 }
 
-func (p *AggregateQueryRawResult_) GetExhaustive() bool {
-	return p.Exhaustive
+func NewNodeSetWriteNewSeriesLimitPerShardPerSecondRequest() *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest {
+	return &NodeSetWriteNewSeriesLimitPerShardPerSecondRequest{}
 }
 
-var AggregateQueryRawResult__WaitedIndex_DEFAULT int64
-
-func (p *AggregateQueryRawResult_) GetWaitedIndex() int64 {
-	if !p.IsSetWaitedIndex() {
-		return AggregateQueryRawResult__WaitedIndex_DEFAULT
-	}
-	return *p.WaitedIndex
-}
-func (p *AggregateQueryRawResult_) IsSetWaitedIndex() bool {
-	return p.WaitedIndex != nil
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) GetWriteNewSeriesLimitPerShardPerSecond() int64 {
+	return p.WriteNewSeriesLimitPerShardPerSecond
 }
-
-func (p *AggregateQueryRawResult_) Read(iprot thrift.TProtocol) error {
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetResults bool = false
-	var issetExhaustive bool = false
+	var issetWriteNewSeriesLimitPerShardPerSecond bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -10543,16 +10819,7 @@ func (p *AggregateQueryRawResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetResults = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-			issetExhaustive = true
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
+			issetWriteNewSeriesLimitPerShardPerSecond = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -10565,67 +10832,29 @@ func (p *AggregateQueryRawResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetResults {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Results is not set"))
+	if !issetWriteNewSeriesLimitPerShardPerSecond {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field WriteNewSeriesLimitPerShardPerSecond is not set"))
 	}
-	if !issetExhaustive {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Exhaustive is not set"))
+	return nil
+}
+
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.WriteNewSeriesLimitPerShardPerSecond = v
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawResult_) ReadField1(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
-	}
-	tSlice := make([]*AggregateQueryRawResultTagNameElement, 0, size)
-	p.Results = tSlice
-	for i := 0; i < size; i++ {
-		_elem25 := &AggregateQueryRawResultTagNameElement{}
-		if err := _elem25.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem25), err)
-		}
-		p.Results = append(p.Results, _elem25)
-	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
-	}
-	return nil
-}
-
-func (p *AggregateQueryRawResult_) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.Exhaustive = v
-	}
-	return nil
-}
-
-func (p *AggregateQueryRawResult_) ReadField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
-	} else {
-		p.WaitedIndex = &v
-	}
-	return nil
-}
-
-func (p *AggregateQueryRawResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateQueryRawResult"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NodeSetWriteNewSeriesLimitPerShardPerSecondRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -10636,93 +10865,52 @@ func (p *AggregateQueryRawResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRawResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("results", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:results: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Results)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Results {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-		}
-	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:results: ", p), err)
-	}
-	return err
-}
-
-func (p *AggregateQueryRawResult_) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("exhaustive", thrift.BOOL, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:exhaustive: ", p), err)
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("writeNewSeriesLimitPerShardPerSecond", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
 	}
-	if err := oprot.WriteBool(bool(p.Exhaustive)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.exhaustive (2) field write error: ", p), err)
+	if err := oprot.WriteI64(int64(p.WriteNewSeriesLimitPerShardPerSecond)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.writeNewSeriesLimitPerShardPerSecond (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:exhaustive: ", p), err)
-	}
-	return err
-}
-
-func (p *AggregateQueryRawResult_) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetWaitedIndex() {
-		if err := oprot.WriteFieldBegin("waitedIndex", thrift.I64, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:waitedIndex: ", p), err)
-		}
-		if err := oprot.WriteI64(int64(*p.WaitedIndex)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.waitedIndex (3) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:waitedIndex: ", p), err)
-		}
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:writeNewSeriesLimitPerShardPerSecond: ", p), err)
 	}
 	return err
 }
 
-func (p *AggregateQueryRawResult_) String() string {
+func (p *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AggregateQueryRawResult_(%+v)", *p)
+	return fmt.Sprintf("NodeSetWriteNewSeriesLimitPerShardPerSecondRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - TagName
-//  - TagValues
-type AggregateQueryRawResultTagNameElement struct {
-	TagName   []byte                                    `thrift:"tagName,1,required" db:"tagName" json:"tagName"`
-	TagValues []*AggregateQueryRawResultTagValueElement `thrift:"tagValues,2" db:"tagValues" json:"tagValues,omitempty"`
+//  - Ok
+//  - Status
+type HealthResult_ struct {
+	Ok     bool   `thrift:"ok,1,required" db:"ok" json:"ok"`
+	Status string `thrift:"status,2,required" db:"status" json:"status"`
 }
 
-func NewAggregateQueryRawResultTagNameElement() *AggregateQueryRawResultTagNameElement {
-	return &AggregateQueryRawResultTagNameElement{}
+func NewHealthResult_() *HealthResult_ {
+	return &HealthResult_{}
 }
 
-func (p *AggregateQueryRawResultTagNameElement) GetTagName() []byte {
-	return p.TagName
+func (p *HealthResult_) GetOk() bool {
+	return p.Ok
 }
 
-var AggregateQueryRawResultTagNameElement_TagValues_DEFAULT []*AggregateQueryRawResultTagValueElement
-
-func (p *AggregateQueryRawResultTagNameElement) GetTagValues() []*AggregateQueryRawResultTagValueElement {
-	return p.TagValues
-}
-func (p *AggregateQueryRawResultTagNameElement) IsSetTagValues() bool {
-	return p.TagValues != nil
+func (p *HealthResult_) GetStatus() string {
+	return p.Status
 }
-
-func (p *AggregateQueryRawResultTagNameElement) Read(iprot thrift.TProtocol) error {
+func (p *HealthResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetTagName bool = false
+	var issetOk bool = false
+	var issetStatus bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -10737,11 +10925,12 @@ func (p *AggregateQueryRawResultTagNameElement) Read(iprot thrift.TProtocol) err
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetTagName = true
+			issetOk = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
+			issetStatus = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -10754,43 +10943,35 @@ func (p *AggregateQueryRawResultTagNameElement) Read(iprot thrift.TProtocol) err
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetTagName {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TagName is not set"))
+	if !issetOk {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Ok is not set"))
+	}
+	if !issetStatus {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Status is not set"))
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawResultTagNameElement) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
+func (p *HealthResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.TagName = v
+		p.Ok = v
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawResultTagNameElement) ReadField2(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
-	}
-	tSlice := make([]*AggregateQueryRawResultTagValueElement, 0, size)
-	p.TagValues = tSlice
-	for i := 0; i < size; i++ {
-		_elem26 := &AggregateQueryRawResultTagValueElement{}
-		if err := _elem26.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem26), err)
-		}
-		p.TagValues = append(p.TagValues, _elem26)
-	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+func (p *HealthResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Status = v
 	}
 	return nil
 }
 
-func (p *AggregateQueryRawResultTagNameElement) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateQueryRawResultTagNameElement"); err != nil {
+func (p *HealthResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("HealthResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -10810,305 +10991,188 @@ func (p *AggregateQueryRawResultTagNameElement) Write(oprot thrift.TProtocol) er
 	return nil
 }
 
-func (p *AggregateQueryRawResultTagNameElement) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("tagName", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tagName: ", p), err)
+func (p *HealthResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("ok", thrift.BOOL, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:ok: ", p), err)
 	}
-	if err := oprot.WriteBinary(p.TagName); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.tagName (1) field write error: ", p), err)
+	if err := oprot.WriteBool(bool(p.Ok)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.ok (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tagName: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:ok: ", p), err)
 	}
 	return err
 }
 
-func (p *AggregateQueryRawResultTagNameElement) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTagValues() {
-		if err := oprot.WriteFieldBegin("tagValues", thrift.LIST, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:tagValues: ", p), err)
-		}
-		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.TagValues)); err != nil {
-			return thrift.PrependError("error writing list begin: ", err)
-		}
-		for _, v := range p.TagValues {
-			if err := v.Write(oprot); err != nil {
-				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
-			}
-		}
-		if err := oprot.WriteListEnd(); err != nil {
-			return thrift.PrependError("error writing list end: ", err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:tagValues: ", p), err)
-		}
+func (p *HealthResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("status", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:status: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Status)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.status (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:status: ", p), err)
 	}
 	return err
 }
 
-func (p *AggregateQueryRawResultTagNameElement) String() string {
+func (p *HealthResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AggregateQueryRawResultTagNameElement(%+v)", *p)
+	return fmt.Sprintf("HealthResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - TagValue
-type AggregateQueryRawResultTagValueElement struct {
-	TagValue []byte `thrift:"tagValue,1,required" db:"tagValue" json:"tagValue"`
+//  - Query
+//  - RangeStart
+//  - RangeEnd
+//  - NameSpace
+//  - SeriesLimit
+//  - TagNameFilter
+//  - AggregateQueryType
+//  - RangeType
+//  - Source
+//  - DocsLimit
+//  - RequireExhaustive
+//  - RequireNoWait
+type AggregateQueryRawRequest struct {
+	Query              []byte             `thrift:"query,1,required" db:"query" json:"query"`
+	RangeStart         int64              `thrift:"rangeStart,2,required" db:"rangeStart" json:"rangeStart"`
+	RangeEnd           int64              `thrift:"rangeEnd,3,required" db:"rangeEnd" json:"rangeEnd"`
+	NameSpace          []byte             `thrift:"nameSpace,4,required" db:"nameSpace" json:"nameSpace"`
+	SeriesLimit        *int64             `thrift:"seriesLimit,5" db:"seriesLimit" json:"seriesLimit,omitempty"`
+	TagNameFilter      [][]byte           `thrift:"tagNameFilter,6" db:"tagNameFilter" json:"tagNameFilter,omitempty"`
+	AggregateQueryType AggregateQueryType `thrift:"aggregateQueryType,7" db:"aggregateQueryType" json:"aggregateQueryType,omitempty"`
+	RangeType          TimeType           `thrift:"rangeType,8" db:"rangeType" json:"rangeType,omitempty"`
+	Source             []byte             `thrift:"source,9" db:"source" json:"source,omitempty"`
+	DocsLimit          *int64             `thrift:"docsLimit,10" db:"docsLimit" json:"docsLimit,omitempty"`
+	RequireExhaustive  *bool              `thrift:"requireExhaustive,11" db:"requireExhaustive" json:"requireExhaustive,omitempty"`
+	RequireNoWait      *bool              `thrift:"requireNoWait,12" db:"requireNoWait" json:"requireNoWait,omitempty"`
 }
 
-func NewAggregateQueryRawResultTagValueElement() *AggregateQueryRawResultTagValueElement {
-	return &AggregateQueryRawResultTagValueElement{}
+func NewAggregateQueryRawRequest() *AggregateQueryRawRequest {
+	return &AggregateQueryRawRequest{
+		AggregateQueryType: 1,
+
+		RangeType: 0,
+	}
 }
 
-func (p *AggregateQueryRawResultTagValueElement) GetTagValue() []byte {
-	return p.TagValue
+func (p *AggregateQueryRawRequest) GetQuery() []byte {
+	return p.Query
 }
-func (p *AggregateQueryRawResultTagValueElement) Read(iprot thrift.TProtocol) error {
-	if _, err := iprot.ReadStructBegin(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
-	}
 
-	var issetTagValue bool = false
-
-	for {
-		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
-		if err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
-		}
-		if fieldTypeId == thrift.STOP {
-			break
-		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-			issetTagValue = true
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
-		}
-		if err := iprot.ReadFieldEnd(); err != nil {
-			return err
-		}
-	}
-	if err := iprot.ReadStructEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-	}
-	if !issetTagValue {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TagValue is not set"))
-	}
-	return nil
-}
-
-func (p *AggregateQueryRawResultTagValueElement) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBinary(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.TagValue = v
-	}
-	return nil
-}
-
-func (p *AggregateQueryRawResultTagValueElement) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateQueryRawResultTagValueElement"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
-	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
-	}
-	return nil
-}
-
-func (p *AggregateQueryRawResultTagValueElement) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("tagValue", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tagValue: ", p), err)
-	}
-	if err := oprot.WriteBinary(p.TagValue); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.tagValue (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tagValue: ", p), err)
-	}
-	return err
-}
-
-func (p *AggregateQueryRawResultTagValueElement) String() string {
-	if p == nil {
-		return "<nil>"
-	}
-	return fmt.Sprintf("AggregateQueryRawResultTagValueElement(%+v)", *p)
-}
-
-// Attributes:
-//  - Query
-//  - RangeStart
-//  - RangeEnd
-//  - NameSpace
-//  - SeriesLimit
-//  - TagNameFilter
-//  - AggregateQueryType
-//  - RangeType
-//  - Source
-//  - DocsLimit
-//  - RequireExhaustive
-//  - RequireNoWait
-type AggregateQueryRequest struct {
-	Query              *Query             `thrift:"query,1" db:"query" json:"query,omitempty"`
-	RangeStart         int64              `thrift:"rangeStart,2,required" db:"rangeStart" json:"rangeStart"`
-	RangeEnd           int64              `thrift:"rangeEnd,3,required" db:"rangeEnd" json:"rangeEnd"`
-	NameSpace          string             `thrift:"nameSpace,4,required" db:"nameSpace" json:"nameSpace"`
-	SeriesLimit        *int64             `thrift:"seriesLimit,5" db:"seriesLimit" json:"seriesLimit,omitempty"`
-	TagNameFilter      []string           `thrift:"tagNameFilter,6" db:"tagNameFilter" json:"tagNameFilter,omitempty"`
-	AggregateQueryType AggregateQueryType `thrift:"aggregateQueryType,7" db:"aggregateQueryType" json:"aggregateQueryType,omitempty"`
-	RangeType          TimeType           `thrift:"rangeType,8" db:"rangeType" json:"rangeType,omitempty"`
-	Source             []byte             `thrift:"source,9" db:"source" json:"source,omitempty"`
-	DocsLimit          *int64             `thrift:"docsLimit,10" db:"docsLimit" json:"docsLimit,omitempty"`
-	RequireExhaustive  *bool              `thrift:"requireExhaustive,11" db:"requireExhaustive" json:"requireExhaustive,omitempty"`
-	RequireNoWait      *bool              `thrift:"requireNoWait,12" db:"requireNoWait" json:"requireNoWait,omitempty"`
-}
-
-func NewAggregateQueryRequest() *AggregateQueryRequest {
-	return &AggregateQueryRequest{
-		AggregateQueryType: 1,
-
-		RangeType: 0,
-	}
-}
-
-var AggregateQueryRequest_Query_DEFAULT *Query
-
-func (p *AggregateQueryRequest) GetQuery() *Query {
-	if !p.IsSetQuery() {
-		return AggregateQueryRequest_Query_DEFAULT
-	}
-	return p.Query
-}
-
-func (p *AggregateQueryRequest) GetRangeStart() int64 {
+func (p *AggregateQueryRawRequest) GetRangeStart() int64 {
 	return p.RangeStart
 }
 
-func (p *AggregateQueryRequest) GetRangeEnd() int64 {
+func (p *AggregateQueryRawRequest) GetRangeEnd() int64 {
 	return p.RangeEnd
 }
 
-func (p *AggregateQueryRequest) GetNameSpace() string {
+func (p *AggregateQueryRawRequest) GetNameSpace() []byte {
 	return p.NameSpace
 }
 
-var AggregateQueryRequest_SeriesLimit_DEFAULT int64
+var AggregateQueryRawRequest_SeriesLimit_DEFAULT int64
 
-func (p *AggregateQueryRequest) GetSeriesLimit() int64 {
+func (p *AggregateQueryRawRequest) GetSeriesLimit() int64 {
 	if !p.IsSetSeriesLimit() {
-		return AggregateQueryRequest_SeriesLimit_DEFAULT
+		return AggregateQueryRawRequest_SeriesLimit_DEFAULT
 	}
 	return *p.SeriesLimit
 }
 
-var AggregateQueryRequest_TagNameFilter_DEFAULT []string
+var AggregateQueryRawRequest_TagNameFilter_DEFAULT [][]byte
 
-func (p *AggregateQueryRequest) GetTagNameFilter() []string {
+func (p *AggregateQueryRawRequest) GetTagNameFilter() [][]byte {
 	return p.TagNameFilter
 }
 
-var AggregateQueryRequest_AggregateQueryType_DEFAULT AggregateQueryType = 1
+var AggregateQueryRawRequest_AggregateQueryType_DEFAULT AggregateQueryType = 1
 
-func (p *AggregateQueryRequest) GetAggregateQueryType() AggregateQueryType {
+func (p *AggregateQueryRawRequest) GetAggregateQueryType() AggregateQueryType {
 	return p.AggregateQueryType
 }
 
-var AggregateQueryRequest_RangeType_DEFAULT TimeType = 0
+var AggregateQueryRawRequest_RangeType_DEFAULT TimeType = 0
 
-func (p *AggregateQueryRequest) GetRangeType() TimeType {
+func (p *AggregateQueryRawRequest) GetRangeType() TimeType {
 	return p.RangeType
 }
 
-var AggregateQueryRequest_Source_DEFAULT []byte
+var AggregateQueryRawRequest_Source_DEFAULT []byte
 
-func (p *AggregateQueryRequest) GetSource() []byte {
+func (p *AggregateQueryRawRequest) GetSource() []byte {
 	return p.Source
 }
 
-var AggregateQueryRequest_DocsLimit_DEFAULT int64
+var AggregateQueryRawRequest_DocsLimit_DEFAULT int64
 
-func (p *AggregateQueryRequest) GetDocsLimit() int64 {
+func (p *AggregateQueryRawRequest) GetDocsLimit() int64 {
 	if !p.IsSetDocsLimit() {
-		return AggregateQueryRequest_DocsLimit_DEFAULT
+		return AggregateQueryRawRequest_DocsLimit_DEFAULT
 	}
 	return *p.DocsLimit
 }
 
-var AggregateQueryRequest_RequireExhaustive_DEFAULT bool
+var AggregateQueryRawRequest_RequireExhaustive_DEFAULT bool
 
-func (p *AggregateQueryRequest) GetRequireExhaustive() bool {
+func (p *AggregateQueryRawRequest) GetRequireExhaustive() bool {
 	if !p.IsSetRequireExhaustive() {
-		return AggregateQueryRequest_RequireExhaustive_DEFAULT
+		return AggregateQueryRawRequest_RequireExhaustive_DEFAULT
 	}
 	return *p.RequireExhaustive
 }
 
-var AggregateQueryRequest_RequireNoWait_DEFAULT bool
+var AggregateQueryRawRequest_RequireNoWait_DEFAULT bool
 
-func (p *AggregateQueryRequest) GetRequireNoWait() bool {
+func (p *AggregateQueryRawRequest) GetRequireNoWait() bool {
 	if !p.IsSetRequireNoWait() {
-		return AggregateQueryRequest_RequireNoWait_DEFAULT
+		return AggregateQueryRawRequest_RequireNoWait_DEFAULT
 	}
 	return *p.RequireNoWait
 }
-func (p *AggregateQueryRequest) IsSetQuery() bool {
-	return p.Query != nil
-}
-
-func (p *AggregateQueryRequest) IsSetSeriesLimit() bool {
+func (p *AggregateQueryRawRequest) IsSetSeriesLimit() bool {
 	return p.SeriesLimit != nil
 }
 
-func (p *AggregateQueryRequest) IsSetTagNameFilter() bool {
+func (p *AggregateQueryRawRequest) IsSetTagNameFilter() bool {
 	return p.TagNameFilter != nil
 }
 
-func (p *AggregateQueryRequest) IsSetAggregateQueryType() bool {
-	return p.AggregateQueryType != AggregateQueryRequest_AggregateQueryType_DEFAULT
+func (p *AggregateQueryRawRequest) IsSetAggregateQueryType() bool {
+	return p.AggregateQueryType != AggregateQueryRawRequest_AggregateQueryType_DEFAULT
 }
 
-func (p *AggregateQueryRequest) IsSetRangeType() bool {
-	return p.RangeType != AggregateQueryRequest_RangeType_DEFAULT
+func (p *AggregateQueryRawRequest) IsSetRangeType() bool {
+	return p.RangeType != AggregateQueryRawRequest_RangeType_DEFAULT
 }
 
-func (p *AggregateQueryRequest) IsSetSource() bool {
+func (p *AggregateQueryRawRequest) IsSetSource() bool {
 	return p.Source != nil
 }
 
-func (p *AggregateQueryRequest) IsSetDocsLimit() bool {
+func (p *AggregateQueryRawRequest) IsSetDocsLimit() bool {
 	return p.DocsLimit != nil
 }
 
-func (p *AggregateQueryRequest) IsSetRequireExhaustive() bool {
+func (p *AggregateQueryRawRequest) IsSetRequireExhaustive() bool {
 	return p.RequireExhaustive != nil
 }
 
-func (p *AggregateQueryRequest) IsSetRequireNoWait() bool {
+func (p *AggregateQueryRawRequest) IsSetRequireNoWait() bool {
 	return p.RequireNoWait != nil
 }
 
-func (p *AggregateQueryRequest) Read(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetQuery bool = false
 	var issetRangeStart bool = false
 	var issetRangeEnd bool = false
 	var issetNameSpace bool = false
@@ -11126,6 +11190,7 @@ func (p *AggregateQueryRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
+			issetQuery = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
@@ -11185,6 +11250,9 @@ func (p *AggregateQueryRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	if !issetQuery {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
+	}
 	if !issetRangeStart {
 		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
 	}
@@ -11197,15 +11265,16 @@ func (p *AggregateQueryRequest) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField1(iprot thrift.TProtocol) error {
-	p.Query = &Query{}
-	if err := p.Query.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
+func (p *AggregateQueryRawRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Query = v
 	}
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField2(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
@@ -11214,7 +11283,7 @@ func (p *AggregateQueryRequest) ReadField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField3(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField3(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 3: ", err)
 	} else {
@@ -11223,8 +11292,8 @@ func (p *AggregateQueryRequest) ReadField3(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField4(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *AggregateQueryRawRequest) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 4: ", err)
 	} else {
 		p.NameSpace = v
@@ -11232,7 +11301,7 @@ func (p *AggregateQueryRequest) ReadField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField5(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField5(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 5: ", err)
 	} else {
@@ -11241,21 +11310,21 @@ func (p *AggregateQueryRequest) ReadField5(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField6(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField6(iprot thrift.TProtocol) error {
 	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return thrift.PrependError("error reading list begin: ", err)
 	}
-	tSlice := make([]string, 0, size)
+	tSlice := make([][]byte, 0, size)
 	p.TagNameFilter = tSlice
 	for i := 0; i < size; i++ {
-		var _elem27 string
-		if v, err := iprot.ReadString(); err != nil {
+		var _elem24 []byte
+		if v, err := iprot.ReadBinary(); err != nil {
 			return thrift.PrependError("error reading field 0: ", err)
 		} else {
-			_elem27 = v
+			_elem24 = v
 		}
-		p.TagNameFilter = append(p.TagNameFilter, _elem27)
+		p.TagNameFilter = append(p.TagNameFilter, _elem24)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -11263,7 +11332,7 @@ func (p *AggregateQueryRequest) ReadField6(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField7(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField7(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 7: ", err)
 	} else {
@@ -11273,7 +11342,7 @@ func (p *AggregateQueryRequest) ReadField7(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField8(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField8(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 8: ", err)
 	} else {
@@ -11283,7 +11352,7 @@ func (p *AggregateQueryRequest) ReadField8(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField9(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField9(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 9: ", err)
 	} else {
@@ -11292,7 +11361,7 @@ func (p *AggregateQueryRequest) ReadField9(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField10(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField10(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 10: ", err)
 	} else {
@@ -11301,7 +11370,7 @@ func (p *AggregateQueryRequest) ReadField10(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField11(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField11(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 11: ", err)
 	} else {
@@ -11310,7 +11379,7 @@ func (p *AggregateQueryRequest) ReadField11(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) ReadField12(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawRequest) ReadField12(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 12: ", err)
 	} else {
@@ -11319,8 +11388,8 @@ func (p *AggregateQueryRequest) ReadField12(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateQueryRequest"); err != nil {
+func (p *AggregateQueryRawRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryRawRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -11370,22 +11439,20 @@ func (p *AggregateQueryRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if p.IsSetQuery() {
-		if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
-		}
-		if err := p.Query.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
-		}
+func (p *AggregateQueryRawRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
+	}
+	if err := oprot.WriteBinary(p.Query); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.query (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
 	}
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField2(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 2); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:rangeStart: ", p), err)
 	}
@@ -11398,7 +11465,7 @@ func (p *AggregateQueryRequest) writeField2(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField3(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 3); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeEnd: ", p), err)
 	}
@@ -11411,11 +11478,11 @@ func (p *AggregateQueryRequest) writeField3(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField4(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 4); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:nameSpace: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.NameSpace)); err != nil {
+	if err := oprot.WriteBinary(p.NameSpace); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (4) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
@@ -11424,7 +11491,7 @@ func (p *AggregateQueryRequest) writeField4(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField5(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField5(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSeriesLimit() {
 		if err := oprot.WriteFieldBegin("seriesLimit", thrift.I64, 5); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:seriesLimit: ", p), err)
@@ -11439,7 +11506,7 @@ func (p *AggregateQueryRequest) writeField5(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField6(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField6(oprot thrift.TProtocol) (err error) {
 	if p.IsSetTagNameFilter() {
 		if err := oprot.WriteFieldBegin("tagNameFilter", thrift.LIST, 6); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:tagNameFilter: ", p), err)
@@ -11448,7 +11515,7 @@ func (p *AggregateQueryRequest) writeField6(oprot thrift.TProtocol) (err error)
 			return thrift.PrependError("error writing list begin: ", err)
 		}
 		for _, v := range p.TagNameFilter {
-			if err := oprot.WriteString(string(v)); err != nil {
+			if err := oprot.WriteBinary(v); err != nil {
 				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
 			}
 		}
@@ -11462,7 +11529,7 @@ func (p *AggregateQueryRequest) writeField6(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField7(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField7(oprot thrift.TProtocol) (err error) {
 	if p.IsSetAggregateQueryType() {
 		if err := oprot.WriteFieldBegin("aggregateQueryType", thrift.I32, 7); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:aggregateQueryType: ", p), err)
@@ -11477,7 +11544,7 @@ func (p *AggregateQueryRequest) writeField7(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField8(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField8(oprot thrift.TProtocol) (err error) {
 	if p.IsSetRangeType() {
 		if err := oprot.WriteFieldBegin("rangeType", thrift.I32, 8); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:rangeType: ", p), err)
@@ -11492,7 +11559,7 @@ func (p *AggregateQueryRequest) writeField8(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField9(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField9(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSource() {
 		if err := oprot.WriteFieldBegin("source", thrift.STRING, 9); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:source: ", p), err)
@@ -11507,7 +11574,7 @@ func (p *AggregateQueryRequest) writeField9(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField10(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField10(oprot thrift.TProtocol) (err error) {
 	if p.IsSetDocsLimit() {
 		if err := oprot.WriteFieldBegin("docsLimit", thrift.I64, 10); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:docsLimit: ", p), err)
@@ -11522,7 +11589,7 @@ func (p *AggregateQueryRequest) writeField10(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField11(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField11(oprot thrift.TProtocol) (err error) {
 	if p.IsSetRequireExhaustive() {
 		if err := oprot.WriteFieldBegin("requireExhaustive", thrift.BOOL, 11); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:requireExhaustive: ", p), err)
@@ -11537,7 +11604,7 @@ func (p *AggregateQueryRequest) writeField11(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) writeField12(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawRequest) writeField12(oprot thrift.TProtocol) (err error) {
 	if p.IsSetRequireNoWait() {
 		if err := oprot.WriteFieldBegin("requireNoWait", thrift.BOOL, 12); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:requireNoWait: ", p), err)
@@ -11552,33 +11619,48 @@ func (p *AggregateQueryRequest) writeField12(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryRequest) String() string {
+func (p *AggregateQueryRawRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AggregateQueryRequest(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryRawRequest(%+v)", *p)
 }
 
 // Attributes:
 //  - Results
 //  - Exhaustive
-type AggregateQueryResult_ struct {
-	Results    []*AggregateQueryResultTagNameElement `thrift:"results,1,required" db:"results" json:"results"`
-	Exhaustive bool                                  `thrift:"exhaustive,2,required" db:"exhaustive" json:"exhaustive"`
+//  - WaitedIndex
+type AggregateQueryRawResult_ struct {
+	Results     []*AggregateQueryRawResultTagNameElement `thrift:"results,1,required" db:"results" json:"results"`
+	Exhaustive  bool                                     `thrift:"exhaustive,2,required" db:"exhaustive" json:"exhaustive"`
+	WaitedIndex *int64                                   `thrift:"waitedIndex,3" db:"waitedIndex" json:"waitedIndex,omitempty"`
 }
 
-func NewAggregateQueryResult_() *AggregateQueryResult_ {
-	return &AggregateQueryResult_{}
+func NewAggregateQueryRawResult_() *AggregateQueryRawResult_ {
+	return &AggregateQueryRawResult_{}
 }
 
-func (p *AggregateQueryResult_) GetResults() []*AggregateQueryResultTagNameElement {
+func (p *AggregateQueryRawResult_) GetResults() []*AggregateQueryRawResultTagNameElement {
 	return p.Results
 }
 
-func (p *AggregateQueryResult_) GetExhaustive() bool {
+func (p *AggregateQueryRawResult_) GetExhaustive() bool {
 	return p.Exhaustive
 }
-func (p *AggregateQueryResult_) Read(iprot thrift.TProtocol) error {
+
+var AggregateQueryRawResult__WaitedIndex_DEFAULT int64
+
+func (p *AggregateQueryRawResult_) GetWaitedIndex() int64 {
+	if !p.IsSetWaitedIndex() {
+		return AggregateQueryRawResult__WaitedIndex_DEFAULT
+	}
+	return *p.WaitedIndex
+}
+func (p *AggregateQueryRawResult_) IsSetWaitedIndex() bool {
+	return p.WaitedIndex != nil
+}
+
+func (p *AggregateQueryRawResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -11605,6 +11687,10 @@ func (p *AggregateQueryResult_) Read(iprot thrift.TProtocol) error {
 				return err
 			}
 			issetExhaustive = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -11626,19 +11712,19 @@ func (p *AggregateQueryResult_) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryResult_) ReadField1(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawResult_) ReadField1(iprot thrift.TProtocol) error {
 	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return thrift.PrependError("error reading list begin: ", err)
 	}
-	tSlice := make([]*AggregateQueryResultTagNameElement, 0, size)
+	tSlice := make([]*AggregateQueryRawResultTagNameElement, 0, size)
 	p.Results = tSlice
 	for i := 0; i < size; i++ {
-		_elem28 := &AggregateQueryResultTagNameElement{}
-		if err := _elem28.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem28), err)
+		_elem25 := &AggregateQueryRawResultTagNameElement{}
+		if err := _elem25.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem25), err)
 		}
-		p.Results = append(p.Results, _elem28)
+		p.Results = append(p.Results, _elem25)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -11646,7 +11732,7 @@ func (p *AggregateQueryResult_) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryResult_) ReadField2(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawResult_) ReadField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
@@ -11655,8 +11741,17 @@ func (p *AggregateQueryResult_) ReadField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateQueryResult"); err != nil {
+func (p *AggregateQueryRawResult_) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.WaitedIndex = &v
+	}
+	return nil
+}
+
+func (p *AggregateQueryRawResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryRawResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -11666,6 +11761,9 @@ func (p *AggregateQueryResult_) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -11676,7 +11774,7 @@ func (p *AggregateQueryResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateQueryResult_) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawResult_) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("results", thrift.LIST, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:results: ", p), err)
 	}
@@ -11697,7 +11795,7 @@ func (p *AggregateQueryResult_) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryResult_) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawResult_) writeField2(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("exhaustive", thrift.BOOL, 2); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:exhaustive: ", p), err)
 	}
@@ -11710,39 +11808,54 @@ func (p *AggregateQueryResult_) writeField2(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *AggregateQueryResult_) String() string {
+func (p *AggregateQueryRawResult_) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetWaitedIndex() {
+		if err := oprot.WriteFieldBegin("waitedIndex", thrift.I64, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:waitedIndex: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.WaitedIndex)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.waitedIndex (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:waitedIndex: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AggregateQueryRawResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AggregateQueryResult_(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryRawResult_(%+v)", *p)
 }
 
 // Attributes:
 //  - TagName
 //  - TagValues
-type AggregateQueryResultTagNameElement struct {
-	TagName   string                                 `thrift:"tagName,1,required" db:"tagName" json:"tagName"`
-	TagValues []*AggregateQueryResultTagValueElement `thrift:"tagValues,2" db:"tagValues" json:"tagValues,omitempty"`
+type AggregateQueryRawResultTagNameElement struct {
+	TagName   []byte                                    `thrift:"tagName,1,required" db:"tagName" json:"tagName"`
+	TagValues []*AggregateQueryRawResultTagValueElement `thrift:"tagValues,2" db:"tagValues" json:"tagValues,omitempty"`
 }
 
-func NewAggregateQueryResultTagNameElement() *AggregateQueryResultTagNameElement {
-	return &AggregateQueryResultTagNameElement{}
+func NewAggregateQueryRawResultTagNameElement() *AggregateQueryRawResultTagNameElement {
+	return &AggregateQueryRawResultTagNameElement{}
 }
 
-func (p *AggregateQueryResultTagNameElement) GetTagName() string {
+func (p *AggregateQueryRawResultTagNameElement) GetTagName() []byte {
 	return p.TagName
 }
 
-var AggregateQueryResultTagNameElement_TagValues_DEFAULT []*AggregateQueryResultTagValueElement
+var AggregateQueryRawResultTagNameElement_TagValues_DEFAULT []*AggregateQueryRawResultTagValueElement
 
-func (p *AggregateQueryResultTagNameElement) GetTagValues() []*AggregateQueryResultTagValueElement {
+func (p *AggregateQueryRawResultTagNameElement) GetTagValues() []*AggregateQueryRawResultTagValueElement {
 	return p.TagValues
 }
-func (p *AggregateQueryResultTagNameElement) IsSetTagValues() bool {
+func (p *AggregateQueryRawResultTagNameElement) IsSetTagValues() bool {
 	return p.TagValues != nil
 }
 
-func (p *AggregateQueryResultTagNameElement) Read(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawResultTagNameElement) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -11785,8 +11898,8 @@ func (p *AggregateQueryResultTagNameElement) Read(iprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *AggregateQueryResultTagNameElement) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *AggregateQueryRawResultTagNameElement) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
 		p.TagName = v
@@ -11794,19 +11907,19 @@ func (p *AggregateQueryResultTagNameElement) ReadField1(iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *AggregateQueryResultTagNameElement) ReadField2(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawResultTagNameElement) ReadField2(iprot thrift.TProtocol) error {
 	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return thrift.PrependError("error reading list begin: ", err)
 	}
-	tSlice := make([]*AggregateQueryResultTagValueElement, 0, size)
+	tSlice := make([]*AggregateQueryRawResultTagValueElement, 0, size)
 	p.TagValues = tSlice
 	for i := 0; i < size; i++ {
-		_elem29 := &AggregateQueryResultTagValueElement{}
-		if err := _elem29.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem29), err)
+		_elem26 := &AggregateQueryRawResultTagValueElement{}
+		if err := _elem26.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem26), err)
 		}
-		p.TagValues = append(p.TagValues, _elem29)
+		p.TagValues = append(p.TagValues, _elem26)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -11814,8 +11927,8 @@ func (p *AggregateQueryResultTagNameElement) ReadField2(iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *AggregateQueryResultTagNameElement) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateQueryResultTagNameElement"); err != nil {
+func (p *AggregateQueryRawResultTagNameElement) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryRawResultTagNameElement"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -11835,11 +11948,11 @@ func (p *AggregateQueryResultTagNameElement) Write(oprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *AggregateQueryResultTagNameElement) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawResultTagNameElement) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("tagName", thrift.STRING, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tagName: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.TagName)); err != nil {
+	if err := oprot.WriteBinary(p.TagName); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T.tagName (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
@@ -11848,7 +11961,7 @@ func (p *AggregateQueryResultTagNameElement) writeField1(oprot thrift.TProtocol)
 	return err
 }
 
-func (p *AggregateQueryResultTagNameElement) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawResultTagNameElement) writeField2(oprot thrift.TProtocol) (err error) {
 	if p.IsSetTagValues() {
 		if err := oprot.WriteFieldBegin("tagValues", thrift.LIST, 2); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:tagValues: ", p), err)
@@ -11871,27 +11984,27 @@ func (p *AggregateQueryResultTagNameElement) writeField2(oprot thrift.TProtocol)
 	return err
 }
 
-func (p *AggregateQueryResultTagNameElement) String() string {
+func (p *AggregateQueryRawResultTagNameElement) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AggregateQueryResultTagNameElement(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryRawResultTagNameElement(%+v)", *p)
 }
 
 // Attributes:
 //  - TagValue
-type AggregateQueryResultTagValueElement struct {
-	TagValue string `thrift:"tagValue,1,required" db:"tagValue" json:"tagValue"`
+type AggregateQueryRawResultTagValueElement struct {
+	TagValue []byte `thrift:"tagValue,1,required" db:"tagValue" json:"tagValue"`
 }
 
-func NewAggregateQueryResultTagValueElement() *AggregateQueryResultTagValueElement {
-	return &AggregateQueryResultTagValueElement{}
+func NewAggregateQueryRawResultTagValueElement() *AggregateQueryRawResultTagValueElement {
+	return &AggregateQueryRawResultTagValueElement{}
 }
 
-func (p *AggregateQueryResultTagValueElement) GetTagValue() string {
+func (p *AggregateQueryRawResultTagValueElement) GetTagValue() []byte {
 	return p.TagValue
 }
-func (p *AggregateQueryResultTagValueElement) Read(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRawResultTagValueElement) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -11930,8 +12043,8 @@ func (p *AggregateQueryResultTagValueElement) Read(iprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *AggregateQueryResultTagValueElement) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *AggregateQueryRawResultTagValueElement) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
 		p.TagValue = v
@@ -11939,8 +12052,8 @@ func (p *AggregateQueryResultTagValueElement) ReadField1(iprot thrift.TProtocol)
 	return nil
 }
 
-func (p *AggregateQueryResultTagValueElement) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateQueryResultTagValueElement"); err != nil {
+func (p *AggregateQueryRawResultTagValueElement) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryRawResultTagValueElement"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -11957,11 +12070,11 @@ func (p *AggregateQueryResultTagValueElement) Write(oprot thrift.TProtocol) erro
 	return nil
 }
 
-func (p *AggregateQueryResultTagValueElement) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRawResultTagValueElement) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("tagValue", thrift.STRING, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tagValue: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.TagValue)); err != nil {
+	if err := oprot.WriteBinary(p.TagValue); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T.tagValue (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
@@ -11970,11 +12083,11 @@ func (p *AggregateQueryResultTagValueElement) writeField1(oprot thrift.TProtocol
 	return err
 }
 
-func (p *AggregateQueryResultTagValueElement) String() string {
+func (p *AggregateQueryRawResultTagValueElement) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AggregateQueryResultTagValueElement(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryRawResultTagValueElement(%+v)", *p)
 }
 
 // Attributes:
@@ -11982,132 +12095,158 @@ func (p *AggregateQueryResultTagValueElement) String() string {
 //  - RangeStart
 //  - RangeEnd
 //  - NameSpace
-//  - Limit
-//  - NoData
+//  - SeriesLimit
+//  - TagNameFilter
+//  - AggregateQueryType
 //  - RangeType
-//  - ResultTimeType
 //  - Source
-//  - ClusterOptions
-type QueryRequest struct {
-	Query          *Query               `thrift:"query,1,required" db:"query" json:"query"`
-	RangeStart     int64                `thrift:"rangeStart,2,required" db:"rangeStart" json:"rangeStart"`
-	RangeEnd       int64                `thrift:"rangeEnd,3,required" db:"rangeEnd" json:"rangeEnd"`
-	NameSpace      string               `thrift:"nameSpace,4,required" db:"nameSpace" json:"nameSpace"`
-	Limit          *int64               `thrift:"limit,5" db:"limit" json:"limit,omitempty"`
-	NoData         *bool                `thrift:"noData,6" db:"noData" json:"noData,omitempty"`
-	RangeType      TimeType             `thrift:"rangeType,7" db:"rangeType" json:"rangeType,omitempty"`
-	ResultTimeType TimeType             `thrift:"resultTimeType,8" db:"resultTimeType" json:"resultTimeType,omitempty"`
-	Source         []byte               `thrift:"source,9" db:"source" json:"source,omitempty"`
-	ClusterOptions *ClusterQueryOptions `thrift:"clusterOptions,10" db:"clusterOptions" json:"clusterOptions,omitempty"`
+//  - DocsLimit
+//  - RequireExhaustive
+//  - RequireNoWait
+type AggregateQueryRequest struct {
+	Query              *Query             `thrift:"query,1" db:"query" json:"query,omitempty"`
+	RangeStart         int64              `thrift:"rangeStart,2,required" db:"rangeStart" json:"rangeStart"`
+	RangeEnd           int64              `thrift:"rangeEnd,3,required" db:"rangeEnd" json:"rangeEnd"`
+	NameSpace          string             `thrift:"nameSpace,4,required" db:"nameSpace" json:"nameSpace"`
+	SeriesLimit        *int64             `thrift:"seriesLimit,5" db:"seriesLimit" json:"seriesLimit,omitempty"`
+	TagNameFilter      []string           `thrift:"tagNameFilter,6" db:"tagNameFilter" json:"tagNameFilter,omitempty"`
+	AggregateQueryType AggregateQueryType `thrift:"aggregateQueryType,7" db:"aggregateQueryType" json:"aggregateQueryType,omitempty"`
+	RangeType          TimeType           `thrift:"rangeType,8" db:"rangeType" json:"rangeType,omitempty"`
+	Source             []byte             `thrift:"source,9" db:"source" json:"source,omitempty"`
+	DocsLimit          *int64             `thrift:"docsLimit,10" db:"docsLimit" json:"docsLimit,omitempty"`
+	RequireExhaustive  *bool              `thrift:"requireExhaustive,11" db:"requireExhaustive" json:"requireExhaustive,omitempty"`
+	RequireNoWait      *bool              `thrift:"requireNoWait,12" db:"requireNoWait" json:"requireNoWait,omitempty"`
 }
 
-func NewQueryRequest() *QueryRequest {
-	return &QueryRequest{
-		RangeType: 0,
+func NewAggregateQueryRequest() *AggregateQueryRequest {
+	return &AggregateQueryRequest{
+		AggregateQueryType: 1,
 
-		ResultTimeType: 0,
+		RangeType: 0,
 	}
 }
 
-var QueryRequest_Query_DEFAULT *Query
+var AggregateQueryRequest_Query_DEFAULT *Query
 
-func (p *QueryRequest) GetQuery() *Query {
+func (p *AggregateQueryRequest) GetQuery() *Query {
 	if !p.IsSetQuery() {
-		return QueryRequest_Query_DEFAULT
+		return AggregateQueryRequest_Query_DEFAULT
 	}
 	return p.Query
 }
 
-func (p *QueryRequest) GetRangeStart() int64 {
+func (p *AggregateQueryRequest) GetRangeStart() int64 {
 	return p.RangeStart
 }
 
-func (p *QueryRequest) GetRangeEnd() int64 {
+func (p *AggregateQueryRequest) GetRangeEnd() int64 {
 	return p.RangeEnd
 }
 
-func (p *QueryRequest) GetNameSpace() string {
+func (p *AggregateQueryRequest) GetNameSpace() string {
 	return p.NameSpace
 }
 
-var QueryRequest_Limit_DEFAULT int64
+var AggregateQueryRequest_SeriesLimit_DEFAULT int64
 
-func (p *QueryRequest) GetLimit() int64 {
-	if !p.IsSetLimit() {
-		return QueryRequest_Limit_DEFAULT
+func (p *AggregateQueryRequest) GetSeriesLimit() int64 {
+	if !p.IsSetSeriesLimit() {
+		return AggregateQueryRequest_SeriesLimit_DEFAULT
 	}
-	return *p.Limit
+	return *p.SeriesLimit
 }
 
-var QueryRequest_NoData_DEFAULT bool
+var AggregateQueryRequest_TagNameFilter_DEFAULT []string
 
-func (p *QueryRequest) GetNoData() bool {
-	if !p.IsSetNoData() {
-		return QueryRequest_NoData_DEFAULT
-	}
-	return *p.NoData
+func (p *AggregateQueryRequest) GetTagNameFilter() []string {
+	return p.TagNameFilter
 }
 
-var QueryRequest_RangeType_DEFAULT TimeType = 0
+var AggregateQueryRequest_AggregateQueryType_DEFAULT AggregateQueryType = 1
 
-func (p *QueryRequest) GetRangeType() TimeType {
-	return p.RangeType
+func (p *AggregateQueryRequest) GetAggregateQueryType() AggregateQueryType {
+	return p.AggregateQueryType
 }
 
-var QueryRequest_ResultTimeType_DEFAULT TimeType = 0
+var AggregateQueryRequest_RangeType_DEFAULT TimeType = 0
 
-func (p *QueryRequest) GetResultTimeType() TimeType {
-	return p.ResultTimeType
+func (p *AggregateQueryRequest) GetRangeType() TimeType {
+	return p.RangeType
 }
 
-var QueryRequest_Source_DEFAULT []byte
+var AggregateQueryRequest_Source_DEFAULT []byte
 
-func (p *QueryRequest) GetSource() []byte {
+func (p *AggregateQueryRequest) GetSource() []byte {
 	return p.Source
 }
 
-var QueryRequest_ClusterOptions_DEFAULT *ClusterQueryOptions
+var AggregateQueryRequest_DocsLimit_DEFAULT int64
 
-func (p *QueryRequest) GetClusterOptions() *ClusterQueryOptions {
-	if !p.IsSetClusterOptions() {
-		return QueryRequest_ClusterOptions_DEFAULT
+func (p *AggregateQueryRequest) GetDocsLimit() int64 {
+	if !p.IsSetDocsLimit() {
+		return AggregateQueryRequest_DocsLimit_DEFAULT
 	}
-	return p.ClusterOptions
+	return *p.DocsLimit
 }
-func (p *QueryRequest) IsSetQuery() bool {
+
+var AggregateQueryRequest_RequireExhaustive_DEFAULT bool
+
+func (p *AggregateQueryRequest) GetRequireExhaustive() bool {
+	if !p.IsSetRequireExhaustive() {
+		return AggregateQueryRequest_RequireExhaustive_DEFAULT
+	}
+	return *p.RequireExhaustive
+}
+
+var AggregateQueryRequest_RequireNoWait_DEFAULT bool
+
+func (p *AggregateQueryRequest) GetRequireNoWait() bool {
+	if !p.IsSetRequireNoWait() {
+		return AggregateQueryRequest_RequireNoWait_DEFAULT
+	}
+	return *p.RequireNoWait
+}
+func (p *AggregateQueryRequest) IsSetQuery() bool {
 	return p.Query != nil
 }
 
-func (p *QueryRequest) IsSetLimit() bool {
-	return p.Limit != nil
+func (p *AggregateQueryRequest) IsSetSeriesLimit() bool {
+	return p.SeriesLimit != nil
 }
 
-func (p *QueryRequest) IsSetNoData() bool {
-	return p.NoData != nil
+func (p *AggregateQueryRequest) IsSetTagNameFilter() bool {
+	return p.TagNameFilter != nil
 }
 
-func (p *QueryRequest) IsSetRangeType() bool {
-	return p.RangeType != QueryRequest_RangeType_DEFAULT
+func (p *AggregateQueryRequest) IsSetAggregateQueryType() bool {
+	return p.AggregateQueryType != AggregateQueryRequest_AggregateQueryType_DEFAULT
 }
 
-func (p *QueryRequest) IsSetResultTimeType() bool {
-	return p.ResultTimeType != QueryRequest_ResultTimeType_DEFAULT
+func (p *AggregateQueryRequest) IsSetRangeType() bool {
+	return p.RangeType != AggregateQueryRequest_RangeType_DEFAULT
 }
 
-func (p *QueryRequest) IsSetSource() bool {
+func (p *AggregateQueryRequest) IsSetSource() bool {
 	return p.Source != nil
 }
 
-func (p *QueryRequest) IsSetClusterOptions() bool {
-	return p.ClusterOptions != nil
+func (p *AggregateQueryRequest) IsSetDocsLimit() bool {
+	return p.DocsLimit != nil
 }
 
-func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) IsSetRequireExhaustive() bool {
+	return p.RequireExhaustive != nil
+}
+
+func (p *AggregateQueryRequest) IsSetRequireNoWait() bool {
+	return p.RequireNoWait != nil
+}
+
+func (p *AggregateQueryRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetQuery bool = false
 	var issetRangeStart bool = false
 	var issetRangeEnd bool = false
 	var issetNameSpace bool = false
@@ -12125,7 +12264,6 @@ func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetQuery = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
@@ -12165,6 +12303,14 @@ func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField10(iprot); err != nil {
 				return err
 			}
+		case 11:
+			if err := p.ReadField11(iprot); err != nil {
+				return err
+			}
+		case 12:
+			if err := p.ReadField12(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -12177,9 +12323,6 @@ func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetQuery {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
-	}
 	if !issetRangeStart {
 		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
 	}
@@ -12192,7 +12335,7 @@ func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryRequest) ReadField1(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) ReadField1(iprot thrift.TProtocol) error {
 	p.Query = &Query{}
 	if err := p.Query.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
@@ -12200,7 +12343,7 @@ func (p *QueryRequest) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryRequest) ReadField2(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) ReadField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
@@ -12209,7 +12352,7 @@ func (p *QueryRequest) ReadField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryRequest) ReadField3(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) ReadField3(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 3: ", err)
 	} else {
@@ -12218,7 +12361,7 @@ func (p *QueryRequest) ReadField3(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryRequest) ReadField4(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) ReadField4(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 4: ", err)
 	} else {
@@ -12227,45 +12370,58 @@ func (p *QueryRequest) ReadField4(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryRequest) ReadField5(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) ReadField5(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 5: ", err)
 	} else {
-		p.Limit = &v
+		p.SeriesLimit = &v
 	}
 	return nil
 }
 
-func (p *QueryRequest) ReadField6(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
-		return thrift.PrependError("error reading field 6: ", err)
-	} else {
-		p.NoData = &v
+func (p *AggregateQueryRequest) ReadField6(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]string, 0, size)
+	p.TagNameFilter = tSlice
+	for i := 0; i < size; i++ {
+		var _elem27 string
+		if v, err := iprot.ReadString(); err != nil {
+			return thrift.PrependError("error reading field 0: ", err)
+		} else {
+			_elem27 = v
+		}
+		p.TagNameFilter = append(p.TagNameFilter, _elem27)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *QueryRequest) ReadField7(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) ReadField7(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 7: ", err)
 	} else {
-		temp := TimeType(v)
-		p.RangeType = temp
+		temp := AggregateQueryType(v)
+		p.AggregateQueryType = temp
 	}
 	return nil
 }
 
-func (p *QueryRequest) ReadField8(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) ReadField8(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadI32(); err != nil {
 		return thrift.PrependError("error reading field 8: ", err)
 	} else {
 		temp := TimeType(v)
-		p.ResultTimeType = temp
+		p.RangeType = temp
 	}
 	return nil
 }
 
-func (p *QueryRequest) ReadField9(iprot thrift.TProtocol) error {
+func (p *AggregateQueryRequest) ReadField9(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBinary(); err != nil {
 		return thrift.PrependError("error reading field 9: ", err)
 	} else {
@@ -12274,16 +12430,35 @@ func (p *QueryRequest) ReadField9(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryRequest) ReadField10(iprot thrift.TProtocol) error {
-	p.ClusterOptions = &ClusterQueryOptions{}
-	if err := p.ClusterOptions.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ClusterOptions), err)
+func (p *AggregateQueryRequest) ReadField10(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 10: ", err)
+	} else {
+		p.DocsLimit = &v
 	}
 	return nil
 }
 
-func (p *QueryRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("QueryRequest"); err != nil {
+func (p *AggregateQueryRequest) ReadField11(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 11: ", err)
+	} else {
+		p.RequireExhaustive = &v
+	}
+	return nil
+}
+
+func (p *AggregateQueryRequest) ReadField12(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 12: ", err)
+	} else {
+		p.RequireNoWait = &v
+	}
+	return nil
+}
+
+func (p *AggregateQueryRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -12317,6 +12492,12 @@ func (p *QueryRequest) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField10(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField11(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField12(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -12327,20 +12508,22 @@ func (p *QueryRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
-	}
-	if err := p.Query.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
+func (p *AggregateQueryRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetQuery() {
+		if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
+		}
+		if err := p.Query.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *QueryRequest) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRequest) writeField2(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 2); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:rangeStart: ", p), err)
 	}
@@ -12353,7 +12536,7 @@ func (p *QueryRequest) writeField2(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *QueryRequest) writeField3(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRequest) writeField3(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 3); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeEnd: ", p), err)
 	}
@@ -12366,7 +12549,7 @@ func (p *QueryRequest) writeField3(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *QueryRequest) writeField4(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRequest) writeField4(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 4); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:nameSpace: ", p), err)
 	}
@@ -12379,67 +12562,75 @@ func (p *QueryRequest) writeField4(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *QueryRequest) writeField5(oprot thrift.TProtocol) (err error) {
-	if p.IsSetLimit() {
-		if err := oprot.WriteFieldBegin("limit", thrift.I64, 5); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:limit: ", p), err)
+func (p *AggregateQueryRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSeriesLimit() {
+		if err := oprot.WriteFieldBegin("seriesLimit", thrift.I64, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:seriesLimit: ", p), err)
 		}
-		if err := oprot.WriteI64(int64(*p.Limit)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.limit (5) field write error: ", p), err)
+		if err := oprot.WriteI64(int64(*p.SeriesLimit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.seriesLimit (5) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:limit: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:seriesLimit: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *QueryRequest) writeField6(oprot thrift.TProtocol) (err error) {
-	if p.IsSetNoData() {
-		if err := oprot.WriteFieldBegin("noData", thrift.BOOL, 6); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:noData: ", p), err)
+func (p *AggregateQueryRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTagNameFilter() {
+		if err := oprot.WriteFieldBegin("tagNameFilter", thrift.LIST, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:tagNameFilter: ", p), err)
 		}
-		if err := oprot.WriteBool(bool(*p.NoData)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.noData (6) field write error: ", p), err)
+		if err := oprot.WriteListBegin(thrift.STRING, len(p.TagNameFilter)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
 		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:noData: ", p), err)
+		for _, v := range p.TagNameFilter {
+			if err := oprot.WriteString(string(v)); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T. (0) field write error: ", p), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:tagNameFilter: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *QueryRequest) writeField7(oprot thrift.TProtocol) (err error) {
-	if p.IsSetRangeType() {
-		if err := oprot.WriteFieldBegin("rangeType", thrift.I32, 7); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:rangeType: ", p), err)
+func (p *AggregateQueryRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAggregateQueryType() {
+		if err := oprot.WriteFieldBegin("aggregateQueryType", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:aggregateQueryType: ", p), err)
 		}
-		if err := oprot.WriteI32(int32(p.RangeType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.rangeType (7) field write error: ", p), err)
+		if err := oprot.WriteI32(int32(p.AggregateQueryType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.aggregateQueryType (7) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:rangeType: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:aggregateQueryType: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *QueryRequest) writeField8(oprot thrift.TProtocol) (err error) {
-	if p.IsSetResultTimeType() {
-		if err := oprot.WriteFieldBegin("resultTimeType", thrift.I32, 8); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:resultTimeType: ", p), err)
+func (p *AggregateQueryRequest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRangeType() {
+		if err := oprot.WriteFieldBegin("rangeType", thrift.I32, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:rangeType: ", p), err)
 		}
-		if err := oprot.WriteI32(int32(p.ResultTimeType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.resultTimeType (8) field write error: ", p), err)
+		if err := oprot.WriteI32(int32(p.RangeType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.rangeType (8) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:resultTimeType: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:rangeType: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *QueryRequest) writeField9(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryRequest) writeField9(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSource() {
 		if err := oprot.WriteFieldBegin("source", thrift.STRING, 9); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:source: ", p), err)
@@ -12454,200 +12645,78 @@ func (p *QueryRequest) writeField9(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *QueryRequest) writeField10(oprot thrift.TProtocol) (err error) {
-	if p.IsSetClusterOptions() {
-		if err := oprot.WriteFieldBegin("clusterOptions", thrift.STRUCT, 10); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:clusterOptions: ", p), err)
+func (p *AggregateQueryRequest) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDocsLimit() {
+		if err := oprot.WriteFieldBegin("docsLimit", thrift.I64, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:docsLimit: ", p), err)
 		}
-		if err := p.ClusterOptions.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ClusterOptions), err)
+		if err := oprot.WriteI64(int64(*p.DocsLimit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.docsLimit (10) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:clusterOptions: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:docsLimit: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *QueryRequest) String() string {
-	if p == nil {
-		return "<nil>"
-	}
-	return fmt.Sprintf("QueryRequest(%+v)", *p)
-}
-
-// Attributes:
-//  - ReadConsistency
-//  - ConflictResolutionStrategy
-type ClusterQueryOptions struct {
-	ReadConsistency            *ReadConsistency        `thrift:"readConsistency,1" db:"readConsistency" json:"readConsistency,omitempty"`
-	ConflictResolutionStrategy *EqualTimestampStrategy `thrift:"conflictResolutionStrategy,2" db:"conflictResolutionStrategy" json:"conflictResolutionStrategy,omitempty"`
-}
-
-func NewClusterQueryOptions() *ClusterQueryOptions {
-	return &ClusterQueryOptions{}
-}
-
-var ClusterQueryOptions_ReadConsistency_DEFAULT ReadConsistency
-
-func (p *ClusterQueryOptions) GetReadConsistency() ReadConsistency {
-	if !p.IsSetReadConsistency() {
-		return ClusterQueryOptions_ReadConsistency_DEFAULT
-	}
-	return *p.ReadConsistency
-}
-
-var ClusterQueryOptions_ConflictResolutionStrategy_DEFAULT EqualTimestampStrategy
-
-func (p *ClusterQueryOptions) GetConflictResolutionStrategy() EqualTimestampStrategy {
-	if !p.IsSetConflictResolutionStrategy() {
-		return ClusterQueryOptions_ConflictResolutionStrategy_DEFAULT
-	}
-	return *p.ConflictResolutionStrategy
-}
-func (p *ClusterQueryOptions) IsSetReadConsistency() bool {
-	return p.ReadConsistency != nil
-}
-
-func (p *ClusterQueryOptions) IsSetConflictResolutionStrategy() bool {
-	return p.ConflictResolutionStrategy != nil
-}
-
-func (p *ClusterQueryOptions) Read(iprot thrift.TProtocol) error {
-	if _, err := iprot.ReadStructBegin(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
-	}
-
-	for {
-		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
-		if err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
-		}
-		if fieldTypeId == thrift.STOP {
-			break
-		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
-		}
-		if err := iprot.ReadFieldEnd(); err != nil {
-			return err
-		}
-	}
-	if err := iprot.ReadStructEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-	}
-	return nil
-}
-
-func (p *ClusterQueryOptions) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		temp := ReadConsistency(v)
-		p.ReadConsistency = &temp
-	}
-	return nil
-}
-
-func (p *ClusterQueryOptions) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		temp := EqualTimestampStrategy(v)
-		p.ConflictResolutionStrategy = &temp
-	}
-	return nil
-}
-
-func (p *ClusterQueryOptions) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("ClusterQueryOptions"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
-	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
-	}
-	return nil
-}
-
-func (p *ClusterQueryOptions) writeField1(oprot thrift.TProtocol) (err error) {
-	if p.IsSetReadConsistency() {
-		if err := oprot.WriteFieldBegin("readConsistency", thrift.I32, 1); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:readConsistency: ", p), err)
+func (p *AggregateQueryRequest) writeField11(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequireExhaustive() {
+		if err := oprot.WriteFieldBegin("requireExhaustive", thrift.BOOL, 11); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 11:requireExhaustive: ", p), err)
 		}
-		if err := oprot.WriteI32(int32(*p.ReadConsistency)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.readConsistency (1) field write error: ", p), err)
+		if err := oprot.WriteBool(bool(*p.RequireExhaustive)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requireExhaustive (11) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:readConsistency: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 11:requireExhaustive: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *ClusterQueryOptions) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetConflictResolutionStrategy() {
-		if err := oprot.WriteFieldBegin("conflictResolutionStrategy", thrift.I32, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:conflictResolutionStrategy: ", p), err)
+func (p *AggregateQueryRequest) writeField12(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRequireNoWait() {
+		if err := oprot.WriteFieldBegin("requireNoWait", thrift.BOOL, 12); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 12:requireNoWait: ", p), err)
 		}
-		if err := oprot.WriteI32(int32(*p.ConflictResolutionStrategy)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.conflictResolutionStrategy (2) field write error: ", p), err)
+		if err := oprot.WriteBool(bool(*p.RequireNoWait)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.requireNoWait (12) field write error: ", p), err)
 		}
 		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:conflictResolutionStrategy: ", p), err)
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 12:requireNoWait: ", p), err)
 		}
 	}
 	return err
 }
 
-func (p *ClusterQueryOptions) String() string {
+func (p *AggregateQueryRequest) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ClusterQueryOptions(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryRequest(%+v)", *p)
 }
 
 // Attributes:
 //  - Results
 //  - Exhaustive
-type QueryResult_ struct {
-	Results    []*QueryResultElement `thrift:"results,1,required" db:"results" json:"results"`
-	Exhaustive bool                  `thrift:"exhaustive,2,required" db:"exhaustive" json:"exhaustive"`
+type AggregateQueryResult_ struct {
+	Results    []*AggregateQueryResultTagNameElement `thrift:"results,1,required" db:"results" json:"results"`
+	Exhaustive bool                                  `thrift:"exhaustive,2,required" db:"exhaustive" json:"exhaustive"`
 }
 
-func NewQueryResult_() *QueryResult_ {
-	return &QueryResult_{}
+func NewAggregateQueryResult_() *AggregateQueryResult_ {
+	return &AggregateQueryResult_{}
 }
 
-func (p *QueryResult_) GetResults() []*QueryResultElement {
+func (p *AggregateQueryResult_) GetResults() []*AggregateQueryResultTagNameElement {
 	return p.Results
 }
 
-func (p *QueryResult_) GetExhaustive() bool {
+func (p *AggregateQueryResult_) GetExhaustive() bool {
 	return p.Exhaustive
 }
-func (p *QueryResult_) Read(iprot thrift.TProtocol) error {
+func (p *AggregateQueryResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -12695,19 +12764,19 @@ func (p *QueryResult_) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryResult_) ReadField1(iprot thrift.TProtocol) error {
+func (p *AggregateQueryResult_) ReadField1(iprot thrift.TProtocol) error {
 	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return thrift.PrependError("error reading list begin: ", err)
 	}
-	tSlice := make([]*QueryResultElement, 0, size)
+	tSlice := make([]*AggregateQueryResultTagNameElement, 0, size)
 	p.Results = tSlice
 	for i := 0; i < size; i++ {
-		_elem30 := &QueryResultElement{}
-		if err := _elem30.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem30), err)
+		_elem28 := &AggregateQueryResultTagNameElement{}
+		if err := _elem28.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem28), err)
 		}
-		p.Results = append(p.Results, _elem30)
+		p.Results = append(p.Results, _elem28)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -12715,7 +12784,7 @@ func (p *QueryResult_) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryResult_) ReadField2(iprot thrift.TProtocol) error {
+func (p *AggregateQueryResult_) ReadField2(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadBool(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
@@ -12724,8 +12793,8 @@ func (p *QueryResult_) ReadField2(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("QueryResult"); err != nil {
+func (p *AggregateQueryResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -12745,7 +12814,7 @@ func (p *QueryResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryResult_) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryResult_) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("results", thrift.LIST, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:results: ", p), err)
 	}
@@ -12766,7 +12835,7 @@ func (p *QueryResult_) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *QueryResult_) writeField2(oprot thrift.TProtocol) (err error) {
+func (p *AggregateQueryResult_) writeField2(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("exhaustive", thrift.BOOL, 2); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:exhaustive: ", p), err)
 	}
@@ -12779,46 +12848,44 @@ func (p *QueryResult_) writeField2(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *QueryResult_) String() string {
+func (p *AggregateQueryResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("QueryResult_(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - ID
-//  - Tags
-//  - Datapoints
-type QueryResultElement struct {
-	ID         string       `thrift:"id,1,required" db:"id" json:"id"`
-	Tags       []*Tag       `thrift:"tags,2,required" db:"tags" json:"tags"`
-	Datapoints []*Datapoint `thrift:"datapoints,3,required" db:"datapoints" json:"datapoints"`
+//  - TagName
+//  - TagValues
+type AggregateQueryResultTagNameElement struct {
+	TagName   string                                 `thrift:"tagName,1,required" db:"tagName" json:"tagName"`
+	TagValues []*AggregateQueryResultTagValueElement `thrift:"tagValues,2" db:"tagValues" json:"tagValues,omitempty"`
 }
 
-func NewQueryResultElement() *QueryResultElement {
-	return &QueryResultElement{}
+func NewAggregateQueryResultTagNameElement() *AggregateQueryResultTagNameElement {
+	return &AggregateQueryResultTagNameElement{}
 }
 
-func (p *QueryResultElement) GetID() string {
-	return p.ID
+func (p *AggregateQueryResultTagNameElement) GetTagName() string {
+	return p.TagName
 }
 
-func (p *QueryResultElement) GetTags() []*Tag {
-	return p.Tags
-}
+var AggregateQueryResultTagNameElement_TagValues_DEFAULT []*AggregateQueryResultTagValueElement
 
-func (p *QueryResultElement) GetDatapoints() []*Datapoint {
-	return p.Datapoints
+func (p *AggregateQueryResultTagNameElement) GetTagValues() []*AggregateQueryResultTagValueElement {
+	return p.TagValues
 }
-func (p *QueryResultElement) Read(iprot thrift.TProtocol) error {
+func (p *AggregateQueryResultTagNameElement) IsSetTagValues() bool {
+	return p.TagValues != nil
+}
+
+func (p *AggregateQueryResultTagNameElement) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetID bool = false
-	var issetTags bool = false
-	var issetDatapoints bool = false
+	var issetTagName bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -12833,17 +12900,11 @@ func (p *QueryResultElement) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetID = true
+			issetTagName = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetTags = true
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
-			issetDatapoints = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -12856,62 +12917,34 @@ func (p *QueryResultElement) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetID {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
-	}
-	if !issetTags {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tags is not set"))
-	}
-	if !issetDatapoints {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Datapoints is not set"))
+	if !issetTagName {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TagName is not set"))
 	}
 	return nil
 }
 
-func (p *QueryResultElement) ReadField1(iprot thrift.TProtocol) error {
+func (p *AggregateQueryResultTagNameElement) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.ID = v
-	}
-	return nil
-}
-
-func (p *QueryResultElement) ReadField2(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
-	}
-	tSlice := make([]*Tag, 0, size)
-	p.Tags = tSlice
-	for i := 0; i < size; i++ {
-		_elem31 := &Tag{}
-		if err := _elem31.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem31), err)
-		}
-		p.Tags = append(p.Tags, _elem31)
-	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+		p.TagName = v
 	}
 	return nil
 }
 
-func (p *QueryResultElement) ReadField3(iprot thrift.TProtocol) error {
+func (p *AggregateQueryResultTagNameElement) ReadField2(iprot thrift.TProtocol) error {
 	_, size, err := iprot.ReadListBegin()
 	if err != nil {
 		return thrift.PrependError("error reading list begin: ", err)
 	}
-	tSlice := make([]*Datapoint, 0, size)
-	p.Datapoints = tSlice
+	tSlice := make([]*AggregateQueryResultTagValueElement, 0, size)
+	p.TagValues = tSlice
 	for i := 0; i < size; i++ {
-		_elem32 := &Datapoint{
-			TimestampTimeType: 0,
-		}
-		if err := _elem32.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem32), err)
+		_elem29 := &AggregateQueryResultTagValueElement{}
+		if err := _elem29.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem29), err)
 		}
-		p.Datapoints = append(p.Datapoints, _elem32)
+		p.TagValues = append(p.TagValues, _elem29)
 	}
 	if err := iprot.ReadListEnd(); err != nil {
 		return thrift.PrependError("error reading list end: ", err)
@@ -12919,8 +12952,8 @@ func (p *QueryResultElement) ReadField3(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryResultElement) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("QueryResultElement"); err != nil {
+func (p *AggregateQueryResultTagNameElement) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryResultTagNameElement"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -12930,9 +12963,6 @@ func (p *QueryResultElement) Write(oprot thrift.TProtocol) error {
 		if err := p.writeField2(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -12943,94 +12973,68 @@ func (p *QueryResultElement) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *QueryResultElement) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
+func (p *AggregateQueryResultTagNameElement) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tagName", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tagName: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.ID)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.TagName)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.tagName (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tagName: ", p), err)
 	}
 	return err
 }
 
-func (p *QueryResultElement) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("tags", thrift.LIST, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:tags: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tags)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Tags {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+func (p *AggregateQueryResultTagNameElement) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTagValues() {
+		if err := oprot.WriteFieldBegin("tagValues", thrift.LIST, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:tagValues: ", p), err)
 		}
-	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:tags: ", p), err)
-	}
-	return err
-}
-
-func (p *QueryResultElement) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("datapoints", thrift.LIST, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:datapoints: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Datapoints)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Datapoints {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		if err := oprot.WriteListBegin(thrift.STRUCT, len(p.TagValues)); err != nil {
+			return thrift.PrependError("error writing list begin: ", err)
+		}
+		for _, v := range p.TagValues {
+			if err := v.Write(oprot); err != nil {
+				return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+			}
+		}
+		if err := oprot.WriteListEnd(); err != nil {
+			return thrift.PrependError("error writing list end: ", err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:tagValues: ", p), err)
 		}
-	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:datapoints: ", p), err)
 	}
 	return err
 }
 
-func (p *QueryResultElement) String() string {
+func (p *AggregateQueryResultTagNameElement) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("QueryResultElement(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryResultTagNameElement(%+v)", *p)
 }
 
 // Attributes:
-//  - Field
-//  - Term
-type TermQuery struct {
-	Field string `thrift:"field,1,required" db:"field" json:"field"`
-	Term  string `thrift:"term,2,required" db:"term" json:"term"`
-}
-
-func NewTermQuery() *TermQuery {
-	return &TermQuery{}
+//  - TagValue
+type AggregateQueryResultTagValueElement struct {
+	TagValue string `thrift:"tagValue,1,required" db:"tagValue" json:"tagValue"`
 }
 
-func (p *TermQuery) GetField() string {
-	return p.Field
+func NewAggregateQueryResultTagValueElement() *AggregateQueryResultTagValueElement {
+	return &AggregateQueryResultTagValueElement{}
 }
 
-func (p *TermQuery) GetTerm() string {
-	return p.Term
+func (p *AggregateQueryResultTagValueElement) GetTagValue() string {
+	return p.TagValue
 }
-func (p *TermQuery) Read(iprot thrift.TProtocol) error {
+func (p *AggregateQueryResultTagValueElement) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetField bool = false
-	var issetTerm bool = false
+	var issetTagValue bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -13045,12 +13049,7 @@ func (p *TermQuery) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetField = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-			issetTerm = true
+			issetTagValue = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -13063,44 +13062,29 @@ func (p *TermQuery) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetField {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
-	}
-	if !issetTerm {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	if !issetTagValue {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TagValue is not set"))
 	}
 	return nil
 }
 
-func (p *TermQuery) ReadField1(iprot thrift.TProtocol) error {
+func (p *AggregateQueryResultTagValueElement) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Field = v
-	}
-	return nil
-}
-
-func (p *TermQuery) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.Term = v
+		p.TagValue = v
 	}
 	return nil
 }
 
-func (p *TermQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("TermQuery"); err != nil {
+func (p *AggregateQueryResultTagValueElement) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateQueryResultTagValueElement"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -13111,65 +13095,160 @@ func (p *TermQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *TermQuery) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.Field)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
-	}
-	return err
-}
-
-func (p *TermQuery) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("term", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:term: ", p), err)
+func (p *AggregateQueryResultTagValueElement) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tagValue", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:tagValue: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.Term)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.term (2) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.TagValue)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.tagValue (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:term: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:tagValue: ", p), err)
 	}
 	return err
 }
 
-func (p *TermQuery) String() string {
+func (p *AggregateQueryResultTagValueElement) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("TermQuery(%+v)", *p)
+	return fmt.Sprintf("AggregateQueryResultTagValueElement(%+v)", *p)
 }
 
 // Attributes:
-//  - Field
-//  - Regexp
-type RegexpQuery struct {
-	Field  string `thrift:"field,1,required" db:"field" json:"field"`
-	Regexp string `thrift:"regexp,2,required" db:"regexp" json:"regexp"`
+//  - Query
+//  - RangeStart
+//  - RangeEnd
+//  - NameSpace
+//  - Limit
+//  - NoData
+//  - RangeType
+//  - ResultTimeType
+//  - Source
+//  - ClusterOptions
+type QueryRequest struct {
+	Query          *Query               `thrift:"query,1,required" db:"query" json:"query"`
+	RangeStart     int64                `thrift:"rangeStart,2,required" db:"rangeStart" json:"rangeStart"`
+	RangeEnd       int64                `thrift:"rangeEnd,3,required" db:"rangeEnd" json:"rangeEnd"`
+	NameSpace      string               `thrift:"nameSpace,4,required" db:"nameSpace" json:"nameSpace"`
+	Limit          *int64               `thrift:"limit,5" db:"limit" json:"limit,omitempty"`
+	NoData         *bool                `thrift:"noData,6" db:"noData" json:"noData,omitempty"`
+	RangeType      TimeType             `thrift:"rangeType,7" db:"rangeType" json:"rangeType,omitempty"`
+	ResultTimeType TimeType             `thrift:"resultTimeType,8" db:"resultTimeType" json:"resultTimeType,omitempty"`
+	Source         []byte               `thrift:"source,9" db:"source" json:"source,omitempty"`
+	ClusterOptions *ClusterQueryOptions `thrift:"clusterOptions,10" db:"clusterOptions" json:"clusterOptions,omitempty"`
 }
 
-func NewRegexpQuery() *RegexpQuery {
-	return &RegexpQuery{}
+func NewQueryRequest() *QueryRequest {
+	return &QueryRequest{
+		RangeType: 0,
+
+		ResultTimeType: 0,
+	}
 }
 
-func (p *RegexpQuery) GetField() string {
-	return p.Field
+var QueryRequest_Query_DEFAULT *Query
+
+func (p *QueryRequest) GetQuery() *Query {
+	if !p.IsSetQuery() {
+		return QueryRequest_Query_DEFAULT
+	}
+	return p.Query
 }
 
-func (p *RegexpQuery) GetRegexp() string {
-	return p.Regexp
+func (p *QueryRequest) GetRangeStart() int64 {
+	return p.RangeStart
 }
-func (p *RegexpQuery) Read(iprot thrift.TProtocol) error {
+
+func (p *QueryRequest) GetRangeEnd() int64 {
+	return p.RangeEnd
+}
+
+func (p *QueryRequest) GetNameSpace() string {
+	return p.NameSpace
+}
+
+var QueryRequest_Limit_DEFAULT int64
+
+func (p *QueryRequest) GetLimit() int64 {
+	if !p.IsSetLimit() {
+		return QueryRequest_Limit_DEFAULT
+	}
+	return *p.Limit
+}
+
+var QueryRequest_NoData_DEFAULT bool
+
+func (p *QueryRequest) GetNoData() bool {
+	if !p.IsSetNoData() {
+		return QueryRequest_NoData_DEFAULT
+	}
+	return *p.NoData
+}
+
+var QueryRequest_RangeType_DEFAULT TimeType = 0
+
+func (p *QueryRequest) GetRangeType() TimeType {
+	return p.RangeType
+}
+
+var QueryRequest_ResultTimeType_DEFAULT TimeType = 0
+
+func (p *QueryRequest) GetResultTimeType() TimeType {
+	return p.ResultTimeType
+}
+
+var QueryRequest_Source_DEFAULT []byte
+
+func (p *QueryRequest) GetSource() []byte {
+	return p.Source
+}
+
+var QueryRequest_ClusterOptions_DEFAULT *ClusterQueryOptions
+
+func (p *QueryRequest) GetClusterOptions() *ClusterQueryOptions {
+	if !p.IsSetClusterOptions() {
+		return QueryRequest_ClusterOptions_DEFAULT
+	}
+	return p.ClusterOptions
+}
+func (p *QueryRequest) IsSetQuery() bool {
+	return p.Query != nil
+}
+
+func (p *QueryRequest) IsSetLimit() bool {
+	return p.Limit != nil
+}
+
+func (p *QueryRequest) IsSetNoData() bool {
+	return p.NoData != nil
+}
+
+func (p *QueryRequest) IsSetRangeType() bool {
+	return p.RangeType != QueryRequest_RangeType_DEFAULT
+}
+
+func (p *QueryRequest) IsSetResultTimeType() bool {
+	return p.ResultTimeType != QueryRequest_ResultTimeType_DEFAULT
+}
+
+func (p *QueryRequest) IsSetSource() bool {
+	return p.Source != nil
+}
+
+func (p *QueryRequest) IsSetClusterOptions() bool {
+	return p.ClusterOptions != nil
+}
+
+func (p *QueryRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetField bool = false
-	var issetRegexp bool = false
+	var issetQuery bool = false
+	var issetRangeStart bool = false
+	var issetRangeEnd bool = false
+	var issetNameSpace bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -13184,12 +13263,46 @@ func (p *RegexpQuery) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetField = true
+			issetQuery = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-			issetRegexp = true
+			issetRangeStart = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetRangeEnd = true
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+			issetNameSpace = true
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.ReadField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.ReadField8(iprot); err != nil {
+				return err
+			}
+		case 9:
+			if err := p.ReadField9(iprot); err != nil {
+				return err
+			}
+		case 10:
+			if err := p.ReadField10(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -13202,164 +13315,146 @@ func (p *RegexpQuery) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetField {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
+	if !issetQuery {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
 	}
-	if !issetRegexp {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Regexp is not set"))
+	if !issetRangeStart {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
+	}
+	if !issetRangeEnd {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeEnd is not set"))
+	}
+	if !issetNameSpace {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field NameSpace is not set"))
 	}
 	return nil
 }
 
-func (p *RegexpQuery) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.Field = v
+func (p *QueryRequest) ReadField1(iprot thrift.TProtocol) error {
+	p.Query = &Query{}
+	if err := p.Query.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
 	}
 	return nil
 }
 
-func (p *RegexpQuery) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
+func (p *QueryRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
 		return thrift.PrependError("error reading field 2: ", err)
 	} else {
-		p.Regexp = v
+		p.RangeStart = v
 	}
 	return nil
 }
 
-func (p *RegexpQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("RegexpQuery"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
-	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
+func (p *QueryRequest) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.RangeEnd = v
 	}
 	return nil
 }
 
-func (p *RegexpQuery) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.Field)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
+func (p *QueryRequest) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.NameSpace = v
 	}
-	return err
+	return nil
 }
 
-func (p *RegexpQuery) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("regexp", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:regexp: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.Regexp)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.regexp (2) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:regexp: ", p), err)
+func (p *QueryRequest) ReadField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Limit = &v
 	}
-	return err
+	return nil
 }
 
-func (p *RegexpQuery) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *QueryRequest) ReadField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.NoData = &v
 	}
-	return fmt.Sprintf("RegexpQuery(%+v)", *p)
-}
-
-// Attributes:
-//  - Query
-type NegationQuery struct {
-	Query *Query `thrift:"query,1,required" db:"query" json:"query"`
-}
-
-func NewNegationQuery() *NegationQuery {
-	return &NegationQuery{}
+	return nil
 }
 
-var NegationQuery_Query_DEFAULT *Query
-
-func (p *NegationQuery) GetQuery() *Query {
-	if !p.IsSetQuery() {
-		return NegationQuery_Query_DEFAULT
+func (p *QueryRequest) ReadField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		temp := TimeType(v)
+		p.RangeType = temp
 	}
-	return p.Query
-}
-func (p *NegationQuery) IsSetQuery() bool {
-	return p.Query != nil
+	return nil
 }
 
-func (p *NegationQuery) Read(iprot thrift.TProtocol) error {
-	if _, err := iprot.ReadStructBegin(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+func (p *QueryRequest) ReadField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		temp := TimeType(v)
+		p.ResultTimeType = temp
 	}
+	return nil
+}
 
-	var issetQuery bool = false
-
-	for {
-		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
-		if err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
-		}
-		if fieldTypeId == thrift.STOP {
-			break
-		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-			issetQuery = true
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
-		}
-		if err := iprot.ReadFieldEnd(); err != nil {
-			return err
-		}
-	}
-	if err := iprot.ReadStructEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-	}
-	if !issetQuery {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
+func (p *QueryRequest) ReadField9(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBinary(); err != nil {
+		return thrift.PrependError("error reading field 9: ", err)
+	} else {
+		p.Source = v
 	}
 	return nil
 }
 
-func (p *NegationQuery) ReadField1(iprot thrift.TProtocol) error {
-	p.Query = &Query{}
-	if err := p.Query.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
+func (p *QueryRequest) ReadField10(iprot thrift.TProtocol) error {
+	p.ClusterOptions = &ClusterQueryOptions{}
+	if err := p.ClusterOptions.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.ClusterOptions), err)
 	}
 	return nil
 }
 
-func (p *NegationQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("NegationQuery"); err != nil {
+func (p *QueryRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField6(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField7(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField8(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField9(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField10(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -13370,7 +13465,7 @@ func (p *NegationQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NegationQuery) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *QueryRequest) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
 	}
@@ -13383,151 +13478,184 @@ func (p *NegationQuery) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NegationQuery) String() string {
-	if p == nil {
-		return "<nil>"
-	}
-	return fmt.Sprintf("NegationQuery(%+v)", *p)
-}
-
-// Attributes:
-//  - Queries
-type ConjunctionQuery struct {
-	Queries []*Query `thrift:"queries,1,required" db:"queries" json:"queries"`
-}
-
-func NewConjunctionQuery() *ConjunctionQuery {
-	return &ConjunctionQuery{}
-}
-
-func (p *ConjunctionQuery) GetQueries() []*Query {
-	return p.Queries
-}
-func (p *ConjunctionQuery) Read(iprot thrift.TProtocol) error {
-	if _, err := iprot.ReadStructBegin(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
-	}
-
-	var issetQueries bool = false
-
-	for {
-		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
-		if err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
-		}
-		if fieldTypeId == thrift.STOP {
-			break
-		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-			issetQueries = true
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
-		}
-		if err := iprot.ReadFieldEnd(); err != nil {
-			return err
-		}
+func (p *QueryRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:rangeStart: ", p), err)
 	}
-	if err := iprot.ReadStructEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	if err := oprot.WriteI64(int64(p.RangeStart)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeStart (2) field write error: ", p), err)
 	}
-	if !issetQueries {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Queries is not set"))
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:rangeStart: ", p), err)
 	}
-	return nil
+	return err
 }
 
-func (p *ConjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
+func (p *QueryRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeEnd: ", p), err)
 	}
-	tSlice := make([]*Query, 0, size)
-	p.Queries = tSlice
-	for i := 0; i < size; i++ {
-		_elem33 := &Query{}
-		if err := _elem33.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem33), err)
-		}
-		p.Queries = append(p.Queries, _elem33)
+	if err := oprot.WriteI64(int64(p.RangeEnd)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeEnd (3) field write error: ", p), err)
 	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:rangeEnd: ", p), err)
 	}
-	return nil
+	return err
 }
 
-func (p *ConjunctionQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("ConjunctionQuery"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
+func (p *QueryRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("nameSpace", thrift.STRING, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:nameSpace: ", p), err)
 	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
+	if err := oprot.WriteString(string(p.NameSpace)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.nameSpace (4) field write error: ", p), err)
 	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:nameSpace: ", p), err)
 	}
-	return nil
+	return err
 }
 
-func (p *ConjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("queries", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queries: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Queries)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Queries {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+func (p *QueryRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetLimit() {
+		if err := oprot.WriteFieldBegin("limit", thrift.I64, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:limit: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.Limit)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.limit (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:limit: ", p), err)
 		}
-	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queries: ", p), err)
 	}
 	return err
 }
 
-func (p *ConjunctionQuery) String() string {
-	if p == nil {
+func (p *QueryRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNoData() {
+		if err := oprot.WriteFieldBegin("noData", thrift.BOOL, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:noData: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.NoData)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.noData (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:noData: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *QueryRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRangeType() {
+		if err := oprot.WriteFieldBegin("rangeType", thrift.I32, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:rangeType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(p.RangeType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.rangeType (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:rangeType: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *QueryRequest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetResultTimeType() {
+		if err := oprot.WriteFieldBegin("resultTimeType", thrift.I32, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:resultTimeType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(p.ResultTimeType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.resultTimeType (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:resultTimeType: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *QueryRequest) writeField9(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSource() {
+		if err := oprot.WriteFieldBegin("source", thrift.STRING, 9); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 9:source: ", p), err)
+		}
+		if err := oprot.WriteBinary(p.Source); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.source (9) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 9:source: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *QueryRequest) writeField10(oprot thrift.TProtocol) (err error) {
+	if p.IsSetClusterOptions() {
+		if err := oprot.WriteFieldBegin("clusterOptions", thrift.STRUCT, 10); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 10:clusterOptions: ", p), err)
+		}
+		if err := p.ClusterOptions.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.ClusterOptions), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 10:clusterOptions: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *QueryRequest) String() string {
+	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("ConjunctionQuery(%+v)", *p)
+	return fmt.Sprintf("QueryRequest(%+v)", *p)
 }
 
 // Attributes:
-//  - Queries
-type DisjunctionQuery struct {
-	Queries []*Query `thrift:"queries,1,required" db:"queries" json:"queries"`
+//  - ReadConsistency
+//  - ConflictResolutionStrategy
+type ClusterQueryOptions struct {
+	ReadConsistency            *ReadConsistency        `thrift:"readConsistency,1" db:"readConsistency" json:"readConsistency,omitempty"`
+	ConflictResolutionStrategy *EqualTimestampStrategy `thrift:"conflictResolutionStrategy,2" db:"conflictResolutionStrategy" json:"conflictResolutionStrategy,omitempty"`
 }
 
-func NewDisjunctionQuery() *DisjunctionQuery {
-	return &DisjunctionQuery{}
+func NewClusterQueryOptions() *ClusterQueryOptions {
+	return &ClusterQueryOptions{}
 }
 
-func (p *DisjunctionQuery) GetQueries() []*Query {
-	return p.Queries
+var ClusterQueryOptions_ReadConsistency_DEFAULT ReadConsistency
+
+func (p *ClusterQueryOptions) GetReadConsistency() ReadConsistency {
+	if !p.IsSetReadConsistency() {
+		return ClusterQueryOptions_ReadConsistency_DEFAULT
+	}
+	return *p.ReadConsistency
 }
-func (p *DisjunctionQuery) Read(iprot thrift.TProtocol) error {
+
+var ClusterQueryOptions_ConflictResolutionStrategy_DEFAULT EqualTimestampStrategy
+
+func (p *ClusterQueryOptions) GetConflictResolutionStrategy() EqualTimestampStrategy {
+	if !p.IsSetConflictResolutionStrategy() {
+		return ClusterQueryOptions_ConflictResolutionStrategy_DEFAULT
+	}
+	return *p.ConflictResolutionStrategy
+}
+func (p *ClusterQueryOptions) IsSetReadConsistency() bool {
+	return p.ReadConsistency != nil
+}
+
+func (p *ClusterQueryOptions) IsSetConflictResolutionStrategy() bool {
+	return p.ConflictResolutionStrategy != nil
+}
+
+func (p *ClusterQueryOptions) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetQueries bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -13541,7 +13669,10 @@ func (p *DisjunctionQuery) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetQueries = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -13554,40 +13685,40 @@ func (p *DisjunctionQuery) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetQueries {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Queries is not set"))
-	}
 	return nil
 }
 
-func (p *DisjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
-	_, size, err := iprot.ReadListBegin()
-	if err != nil {
-		return thrift.PrependError("error reading list begin: ", err)
-	}
-	tSlice := make([]*Query, 0, size)
-	p.Queries = tSlice
-	for i := 0; i < size; i++ {
-		_elem34 := &Query{}
-		if err := _elem34.Read(iprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem34), err)
-		}
-		p.Queries = append(p.Queries, _elem34)
+func (p *ClusterQueryOptions) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		temp := ReadConsistency(v)
+		p.ReadConsistency = &temp
 	}
-	if err := iprot.ReadListEnd(); err != nil {
-		return thrift.PrependError("error reading list end: ", err)
+	return nil
+}
+
+func (p *ClusterQueryOptions) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		temp := EqualTimestampStrategy(v)
+		p.ConflictResolutionStrategy = &temp
 	}
 	return nil
 }
 
-func (p *DisjunctionQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("DisjunctionQuery"); err != nil {
+func (p *ClusterQueryOptions) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ClusterQueryOptions"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -13598,46 +13729,70 @@ func (p *DisjunctionQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *DisjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("queries", thrift.LIST, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queries: ", p), err)
-	}
-	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Queries)); err != nil {
-		return thrift.PrependError("error writing list begin: ", err)
-	}
-	for _, v := range p.Queries {
-		if err := v.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+func (p *ClusterQueryOptions) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetReadConsistency() {
+		if err := oprot.WriteFieldBegin("readConsistency", thrift.I32, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:readConsistency: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.ReadConsistency)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.readConsistency (1) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:readConsistency: ", p), err)
 		}
 	}
-	if err := oprot.WriteListEnd(); err != nil {
-		return thrift.PrependError("error writing list end: ", err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queries: ", p), err)
+	return err
+}
+
+func (p *ClusterQueryOptions) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetConflictResolutionStrategy() {
+		if err := oprot.WriteFieldBegin("conflictResolutionStrategy", thrift.I32, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:conflictResolutionStrategy: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(*p.ConflictResolutionStrategy)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.conflictResolutionStrategy (2) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:conflictResolutionStrategy: ", p), err)
+		}
 	}
 	return err
 }
 
-func (p *DisjunctionQuery) String() string {
+func (p *ClusterQueryOptions) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("DisjunctionQuery(%+v)", *p)
+	return fmt.Sprintf("ClusterQueryOptions(%+v)", *p)
 }
 
-type AllQuery struct {
+// Attributes:
+//  - Results
+//  - Exhaustive
+type QueryResult_ struct {
+	Results    []*QueryResultElement `thrift:"results,1,required" db:"results" json:"results"`
+	Exhaustive bool                  `thrift:"exhaustive,2,required" db:"exhaustive" json:"exhaustive"`
 }
 
-func NewAllQuery() *AllQuery {
-	return &AllQuery{}
+func NewQueryResult_() *QueryResult_ {
+	return &QueryResult_{}
 }
 
-func (p *AllQuery) Read(iprot thrift.TProtocol) error {
+func (p *QueryResult_) GetResults() []*QueryResultElement {
+	return p.Results
+}
+
+func (p *QueryResult_) GetExhaustive() bool {
+	return p.Exhaustive
+}
+func (p *QueryResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetResults bool = false
+	var issetExhaustive bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -13646,8 +13801,21 @@ func (p *AllQuery) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetResults = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetExhaustive = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -13656,14 +13824,55 @@ func (p *AllQuery) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	if !issetResults {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Results is not set"))
+	}
+	if !issetExhaustive {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Exhaustive is not set"))
+	}
 	return nil
 }
 
-func (p *AllQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AllQuery"); err != nil {
+func (p *QueryResult_) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*QueryResultElement, 0, size)
+	p.Results = tSlice
+	for i := 0; i < size; i++ {
+		_elem30 := &QueryResultElement{}
+		if err := _elem30.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem30), err)
+		}
+		p.Results = append(p.Results, _elem30)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *QueryResult_) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Exhaustive = v
+	}
+	return nil
+}
+
+func (p *QueryResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -13674,32 +13883,80 @@ func (p *AllQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AllQuery) String() string {
+func (p *QueryResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("results", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:results: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Results)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Results {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:results: ", p), err)
+	}
+	return err
+}
+
+func (p *QueryResult_) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("exhaustive", thrift.BOOL, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:exhaustive: ", p), err)
+	}
+	if err := oprot.WriteBool(bool(p.Exhaustive)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.exhaustive (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:exhaustive: ", p), err)
+	}
+	return err
+}
+
+func (p *QueryResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AllQuery(%+v)", *p)
+	return fmt.Sprintf("QueryResult_(%+v)", *p)
 }
 
 // Attributes:
-//  - Field
-type FieldQuery struct {
-	Field string `thrift:"field,1,required" db:"field" json:"field"`
+//  - ID
+//  - Tags
+//  - Datapoints
+type QueryResultElement struct {
+	ID         string       `thrift:"id,1,required" db:"id" json:"id"`
+	Tags       []*Tag       `thrift:"tags,2,required" db:"tags" json:"tags"`
+	Datapoints []*Datapoint `thrift:"datapoints,3,required" db:"datapoints" json:"datapoints"`
 }
 
-func NewFieldQuery() *FieldQuery {
-	return &FieldQuery{}
+func NewQueryResultElement() *QueryResultElement {
+	return &QueryResultElement{}
 }
 
-func (p *FieldQuery) GetField() string {
-	return p.Field
+func (p *QueryResultElement) GetID() string {
+	return p.ID
 }
-func (p *FieldQuery) Read(iprot thrift.TProtocol) error {
+
+func (p *QueryResultElement) GetTags() []*Tag {
+	return p.Tags
+}
+
+func (p *QueryResultElement) GetDatapoints() []*Datapoint {
+	return p.Datapoints
+}
+func (p *QueryResultElement) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetField bool = false
+	var issetID bool = false
+	var issetTags bool = false
+	var issetDatapoints bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -13714,7 +13971,17 @@ func (p *FieldQuery) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetField = true
+			issetID = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetTags = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetDatapoints = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -13727,29 +13994,83 @@ func (p *FieldQuery) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetField {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
+	if !issetID {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ID is not set"))
+	}
+	if !issetTags {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Tags is not set"))
+	}
+	if !issetDatapoints {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Datapoints is not set"))
 	}
 	return nil
 }
 
-func (p *FieldQuery) ReadField1(iprot thrift.TProtocol) error {
+func (p *QueryResultElement) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Field = v
+		p.ID = v
 	}
 	return nil
 }
 
-func (p *FieldQuery) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("FieldQuery"); err != nil {
+func (p *QueryResultElement) ReadField2(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*Tag, 0, size)
+	p.Tags = tSlice
+	for i := 0; i < size; i++ {
+		_elem31 := &Tag{}
+		if err := _elem31.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem31), err)
+		}
+		p.Tags = append(p.Tags, _elem31)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *QueryResultElement) ReadField3(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*Datapoint, 0, size)
+	p.Datapoints = tSlice
+	for i := 0; i < size; i++ {
+		_elem32 := &Datapoint{
+			TimestampTimeType: 0,
+		}
+		if err := _elem32.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem32), err)
+		}
+		p.Datapoints = append(p.Datapoints, _elem32)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
+	}
+	return nil
+}
+
+func (p *QueryResultElement) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("QueryResultElement"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -13760,143 +14081,234 @@ func (p *FieldQuery) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *FieldQuery) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
+func (p *QueryResultElement) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("id", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:id: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.Field)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.ID)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.id (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:id: ", p), err)
 	}
 	return err
 }
 
-func (p *FieldQuery) String() string {
+func (p *QueryResultElement) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("tags", thrift.LIST, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:tags: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Tags)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Tags {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:tags: ", p), err)
+	}
+	return err
+}
+
+func (p *QueryResultElement) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("datapoints", thrift.LIST, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:datapoints: ", p), err)
+	}
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Datapoints)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Datapoints {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:datapoints: ", p), err)
+	}
+	return err
+}
+
+func (p *QueryResultElement) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("FieldQuery(%+v)", *p)
+	return fmt.Sprintf("QueryResultElement(%+v)", *p)
 }
 
 // Attributes:
-//  - Term
-//  - Regexp
-//  - Negation
-//  - Conjunction
-//  - Disjunction
-//  - All
 //  - Field
-type Query struct {
-	Term        *TermQuery        `thrift:"term,1" db:"term" json:"term,omitempty"`
-	Regexp      *RegexpQuery      `thrift:"regexp,2" db:"regexp" json:"regexp,omitempty"`
-	Negation    *NegationQuery    `thrift:"negation,3" db:"negation" json:"negation,omitempty"`
-	Conjunction *ConjunctionQuery `thrift:"conjunction,4" db:"conjunction" json:"conjunction,omitempty"`
-	Disjunction *DisjunctionQuery `thrift:"disjunction,5" db:"disjunction" json:"disjunction,omitempty"`
-	All         *AllQuery         `thrift:"all,6" db:"all" json:"all,omitempty"`
-	Field       *FieldQuery       `thrift:"field,7" db:"field" json:"field,omitempty"`
+//  - Term
+type TermQuery struct {
+	Field string `thrift:"field,1,required" db:"field" json:"field"`
+	Term  string `thrift:"term,2,required" db:"term" json:"term"`
 }
 
-func NewQuery() *Query {
-	return &Query{}
+func NewTermQuery() *TermQuery {
+	return &TermQuery{}
 }
 
-var Query_Term_DEFAULT *TermQuery
+func (p *TermQuery) GetField() string {
+	return p.Field
+}
 
-func (p *Query) GetTerm() *TermQuery {
-	if !p.IsSetTerm() {
-		return Query_Term_DEFAULT
-	}
+func (p *TermQuery) GetTerm() string {
 	return p.Term
 }
-
-var Query_Regexp_DEFAULT *RegexpQuery
-
-func (p *Query) GetRegexp() *RegexpQuery {
-	if !p.IsSetRegexp() {
-		return Query_Regexp_DEFAULT
+func (p *TermQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
-	return p.Regexp
-}
 
-var Query_Negation_DEFAULT *NegationQuery
+	var issetField bool = false
+	var issetTerm bool = false
 
-func (p *Query) GetNegation() *NegationQuery {
-	if !p.IsSetNegation() {
-		return Query_Negation_DEFAULT
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetField = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetTerm = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
 	}
-	return p.Negation
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetField {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
+	}
+	if !issetTerm {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Term is not set"))
+	}
+	return nil
 }
 
-var Query_Conjunction_DEFAULT *ConjunctionQuery
-
-func (p *Query) GetConjunction() *ConjunctionQuery {
-	if !p.IsSetConjunction() {
-		return Query_Conjunction_DEFAULT
+func (p *TermQuery) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Field = v
 	}
-	return p.Conjunction
+	return nil
 }
 
-var Query_Disjunction_DEFAULT *DisjunctionQuery
-
-func (p *Query) GetDisjunction() *DisjunctionQuery {
-	if !p.IsSetDisjunction() {
-		return Query_Disjunction_DEFAULT
+func (p *TermQuery) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Term = v
 	}
-	return p.Disjunction
+	return nil
 }
 
-var Query_All_DEFAULT *AllQuery
-
-func (p *Query) GetAll() *AllQuery {
-	if !p.IsSetAll() {
-		return Query_All_DEFAULT
+func (p *TermQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("TermQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	return p.All
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
 }
 
-var Query_Field_DEFAULT *FieldQuery
-
-func (p *Query) GetField() *FieldQuery {
-	if !p.IsSetField() {
-		return Query_Field_DEFAULT
+func (p *TermQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
 	}
-	return p.Field
-}
-func (p *Query) IsSetTerm() bool {
-	return p.Term != nil
+	if err := oprot.WriteString(string(p.Field)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
+	}
+	return err
 }
 
-func (p *Query) IsSetRegexp() bool {
-	return p.Regexp != nil
+func (p *TermQuery) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("term", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:term: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Term)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.term (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:term: ", p), err)
+	}
+	return err
 }
 
-func (p *Query) IsSetNegation() bool {
-	return p.Negation != nil
+func (p *TermQuery) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("TermQuery(%+v)", *p)
 }
 
-func (p *Query) IsSetConjunction() bool {
-	return p.Conjunction != nil
+// Attributes:
+//  - Field
+//  - Regexp
+type RegexpQuery struct {
+	Field  string `thrift:"field,1,required" db:"field" json:"field"`
+	Regexp string `thrift:"regexp,2,required" db:"regexp" json:"regexp"`
 }
 
-func (p *Query) IsSetDisjunction() bool {
-	return p.Disjunction != nil
+func NewRegexpQuery() *RegexpQuery {
+	return &RegexpQuery{}
 }
 
-func (p *Query) IsSetAll() bool {
-	return p.All != nil
+func (p *RegexpQuery) GetField() string {
+	return p.Field
 }
 
-func (p *Query) IsSetField() bool {
-	return p.Field != nil
+func (p *RegexpQuery) GetRegexp() string {
+	return p.Regexp
 }
-
-func (p *Query) Read(iprot thrift.TProtocol) error {
+func (p *RegexpQuery) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetField bool = false
+	var issetRegexp bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -13910,30 +14322,12 @@ func (p *Query) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
+			issetField = true
 		case 2:
 			if err := p.ReadField2(iprot); err != nil {
 				return err
 			}
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
-		case 4:
-			if err := p.ReadField4(iprot); err != nil {
-				return err
-			}
-		case 5:
-			if err := p.ReadField5(iprot); err != nil {
-				return err
-			}
-		case 6:
-			if err := p.ReadField6(iprot); err != nil {
-				return err
-			}
-		case 7:
-			if err := p.ReadField7(iprot); err != nil {
-				return err
-			}
+			issetRegexp = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -13946,274 +14340,213 @@ func (p *Query) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	if !issetField {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
+	}
+	if !issetRegexp {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Regexp is not set"))
+	}
 	return nil
 }
 
-func (p *Query) ReadField1(iprot thrift.TProtocol) error {
-	p.Term = &TermQuery{}
-	if err := p.Term.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Term), err)
+func (p *RegexpQuery) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Field = v
 	}
 	return nil
 }
 
-func (p *Query) ReadField2(iprot thrift.TProtocol) error {
-	p.Regexp = &RegexpQuery{}
-	if err := p.Regexp.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Regexp), err)
+func (p *RegexpQuery) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.Regexp = v
 	}
 	return nil
 }
 
-func (p *Query) ReadField3(iprot thrift.TProtocol) error {
-	p.Negation = &NegationQuery{}
-	if err := p.Negation.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Negation), err)
+func (p *RegexpQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("RegexpQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
 	return nil
 }
 
-func (p *Query) ReadField4(iprot thrift.TProtocol) error {
-	p.Conjunction = &ConjunctionQuery{}
-	if err := p.Conjunction.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Conjunction), err)
+func (p *RegexpQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
 	}
-	return nil
+	if err := oprot.WriteString(string(p.Field)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
+	}
+	return err
 }
 
-func (p *Query) ReadField5(iprot thrift.TProtocol) error {
-	p.Disjunction = &DisjunctionQuery{}
-	if err := p.Disjunction.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Disjunction), err)
+func (p *RegexpQuery) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("regexp", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:regexp: ", p), err)
 	}
-	return nil
+	if err := oprot.WriteString(string(p.Regexp)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.regexp (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:regexp: ", p), err)
+	}
+	return err
 }
 
-func (p *Query) ReadField6(iprot thrift.TProtocol) error {
-	p.All = &AllQuery{}
-	if err := p.All.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.All), err)
+func (p *RegexpQuery) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return nil
+	return fmt.Sprintf("RegexpQuery(%+v)", *p)
 }
 
-func (p *Query) ReadField7(iprot thrift.TProtocol) error {
-	p.Field = &FieldQuery{}
-	if err := p.Field.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Field), err)
+// Attributes:
+//  - Query
+type NegationQuery struct {
+	Query *Query `thrift:"query,1,required" db:"query" json:"query"`
+}
+
+func NewNegationQuery() *NegationQuery {
+	return &NegationQuery{}
+}
+
+var NegationQuery_Query_DEFAULT *Query
+
+func (p *NegationQuery) GetQuery() *Query {
+	if !p.IsSetQuery() {
+		return NegationQuery_Query_DEFAULT
 	}
-	return nil
+	return p.Query
+}
+func (p *NegationQuery) IsSetQuery() bool {
+	return p.Query != nil
 }
 
-func (p *Query) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("Query"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+func (p *NegationQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField4(oprot); err != nil {
-			return err
+
+	var issetQuery bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
 		}
-		if err := p.writeField5(oprot); err != nil {
-			return err
+		if fieldTypeId == thrift.STOP {
+			break
 		}
-		if err := p.writeField6(oprot); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetQuery = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
-		if err := p.writeField7(oprot); err != nil {
+		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
 		}
 	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
+	if !issetQuery {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Query is not set"))
 	}
 	return nil
 }
 
-func (p *Query) writeField1(oprot thrift.TProtocol) (err error) {
-	if p.IsSetTerm() {
-		if err := oprot.WriteFieldBegin("term", thrift.STRUCT, 1); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
-		}
-		if err := p.Term.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Term), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
-		}
+func (p *NegationQuery) ReadField1(iprot thrift.TProtocol) error {
+	p.Query = &Query{}
+	if err := p.Query.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Query), err)
 	}
-	return err
+	return nil
 }
 
-func (p *Query) writeField2(oprot thrift.TProtocol) (err error) {
-	if p.IsSetRegexp() {
-		if err := oprot.WriteFieldBegin("regexp", thrift.STRUCT, 2); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:regexp: ", p), err)
-		}
-		if err := p.Regexp.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Regexp), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:regexp: ", p), err)
-		}
+func (p *NegationQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("NegationQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	return err
-}
-
-func (p *Query) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetNegation() {
-		if err := oprot.WriteFieldBegin("negation", thrift.STRUCT, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:negation: ", p), err)
-		}
-		if err := p.Negation.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Negation), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:negation: ", p), err)
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
 	}
-	return err
-}
-
-func (p *Query) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetConjunction() {
-		if err := oprot.WriteFieldBegin("conjunction", thrift.STRUCT, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:conjunction: ", p), err)
-		}
-		if err := p.Conjunction.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Conjunction), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:conjunction: ", p), err)
-		}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	return err
-}
-
-func (p *Query) writeField5(oprot thrift.TProtocol) (err error) {
-	if p.IsSetDisjunction() {
-		if err := oprot.WriteFieldBegin("disjunction", thrift.STRUCT, 5); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:disjunction: ", p), err)
-		}
-		if err := p.Disjunction.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Disjunction), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:disjunction: ", p), err)
-		}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	return err
+	return nil
 }
 
-func (p *Query) writeField6(oprot thrift.TProtocol) (err error) {
-	if p.IsSetAll() {
-		if err := oprot.WriteFieldBegin("all", thrift.STRUCT, 6); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:all: ", p), err)
-		}
-		if err := p.All.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.All), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:all: ", p), err)
-		}
+func (p *NegationQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("query", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:query: ", p), err)
 	}
-	return err
-}
-
-func (p *Query) writeField7(oprot thrift.TProtocol) (err error) {
-	if p.IsSetField() {
-		if err := oprot.WriteFieldBegin("field", thrift.STRUCT, 7); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:field: ", p), err)
-		}
-		if err := p.Field.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Field), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:field: ", p), err)
-		}
+	if err := p.Query.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Query), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:query: ", p), err)
 	}
 	return err
 }
 
-func (p *Query) String() string {
+func (p *NegationQuery) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("Query(%+v)", *p)
+	return fmt.Sprintf("NegationQuery(%+v)", *p)
 }
 
 // Attributes:
-//  - SourceNamespace
-//  - TargetNamespace
-//  - RangeStart
-//  - RangeEnd
-//  - Step
-//  - RangeType
-type AggregateTilesRequest struct {
-	SourceNamespace string   `thrift:"sourceNamespace,1,required" db:"sourceNamespace" json:"sourceNamespace"`
-	TargetNamespace string   `thrift:"targetNamespace,2,required" db:"targetNamespace" json:"targetNamespace"`
-	RangeStart      int64    `thrift:"rangeStart,3,required" db:"rangeStart" json:"rangeStart"`
-	RangeEnd        int64    `thrift:"rangeEnd,4,required" db:"rangeEnd" json:"rangeEnd"`
-	Step            string   `thrift:"step,5,required" db:"step" json:"step"`
-	RangeType       TimeType `thrift:"rangeType,6" db:"rangeType" json:"rangeType,omitempty"`
+//  - Queries
+type ConjunctionQuery struct {
+	Queries []*Query `thrift:"queries,1,required" db:"queries" json:"queries"`
 }
 
-func NewAggregateTilesRequest() *AggregateTilesRequest {
-	return &AggregateTilesRequest{
-		RangeType: 0,
-	}
+func NewConjunctionQuery() *ConjunctionQuery {
+	return &ConjunctionQuery{}
 }
 
-func (p *AggregateTilesRequest) GetSourceNamespace() string {
-	return p.SourceNamespace
+func (p *ConjunctionQuery) GetQueries() []*Query {
+	return p.Queries
 }
-
-func (p *AggregateTilesRequest) GetTargetNamespace() string {
-	return p.TargetNamespace
-}
-
-func (p *AggregateTilesRequest) GetRangeStart() int64 {
-	return p.RangeStart
-}
-
-func (p *AggregateTilesRequest) GetRangeEnd() int64 {
-	return p.RangeEnd
-}
-
-func (p *AggregateTilesRequest) GetStep() string {
-	return p.Step
-}
-
-var AggregateTilesRequest_RangeType_DEFAULT TimeType = 0
-
-func (p *AggregateTilesRequest) GetRangeType() TimeType {
-	return p.RangeType
-}
-func (p *AggregateTilesRequest) IsSetRangeType() bool {
-	return p.RangeType != AggregateTilesRequest_RangeType_DEFAULT
-}
-
-func (p *AggregateTilesRequest) Read(iprot thrift.TProtocol) error {
+func (p *ConjunctionQuery) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetSourceNamespace bool = false
-	var issetTargetNamespace bool = false
-	var issetRangeStart bool = false
-	var issetRangeEnd bool = false
-	var issetStep bool = false
+	var issetQueries bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -14228,31 +14561,7 @@ func (p *AggregateTilesRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetSourceNamespace = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-			issetTargetNamespace = true
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
-			issetRangeStart = true
-		case 4:
-			if err := p.ReadField4(iprot); err != nil {
-				return err
-			}
-			issetRangeEnd = true
-		case 5:
-			if err := p.ReadField5(iprot); err != nil {
-				return err
-			}
-			issetStep = true
-		case 6:
-			if err := p.ReadField6(iprot); err != nil {
-				return err
-			}
+			issetQueries = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -14265,102 +14574,40 @@ func (p *AggregateTilesRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetSourceNamespace {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field SourceNamespace is not set"))
-	}
-	if !issetTargetNamespace {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TargetNamespace is not set"))
-	}
-	if !issetRangeStart {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
-	}
-	if !issetRangeEnd {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeEnd is not set"))
-	}
-	if !issetStep {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Step is not set"))
-	}
-	return nil
-}
-
-func (p *AggregateTilesRequest) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.SourceNamespace = v
-	}
-	return nil
-}
-
-func (p *AggregateTilesRequest) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.TargetNamespace = v
-	}
-	return nil
-}
-
-func (p *AggregateTilesRequest) ReadField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
-	} else {
-		p.RangeStart = v
+	if !issetQueries {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Queries is not set"))
 	}
 	return nil
 }
 
-func (p *AggregateTilesRequest) ReadField4(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 4: ", err)
-	} else {
-		p.RangeEnd = v
+func (p *ConjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
 	}
-	return nil
-}
-
-func (p *AggregateTilesRequest) ReadField5(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 5: ", err)
-	} else {
-		p.Step = v
+	tSlice := make([]*Query, 0, size)
+	p.Queries = tSlice
+	for i := 0; i < size; i++ {
+		_elem33 := &Query{}
+		if err := _elem33.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem33), err)
+		}
+		p.Queries = append(p.Queries, _elem33)
 	}
-	return nil
-}
-
-func (p *AggregateTilesRequest) ReadField6(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI32(); err != nil {
-		return thrift.PrependError("error reading field 6: ", err)
-	} else {
-		temp := TimeType(v)
-		p.RangeType = temp
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *AggregateTilesRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateTilesRequest"); err != nil {
+func (p *ConjunctionQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("ConjunctionQuery"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField4(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField5(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField6(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -14371,112 +14618,53 @@ func (p *AggregateTilesRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateTilesRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("sourceNamespace", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:sourceNamespace: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.SourceNamespace)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.sourceNamespace (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:sourceNamespace: ", p), err)
-	}
-	return err
-}
-
-func (p *AggregateTilesRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("targetNamespace", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:targetNamespace: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.TargetNamespace)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.targetNamespace (2) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:targetNamespace: ", p), err)
-	}
-	return err
-}
-
-func (p *AggregateTilesRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 3); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeStart: ", p), err)
-	}
-	if err := oprot.WriteI64(int64(p.RangeStart)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.rangeStart (3) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:rangeStart: ", p), err)
-	}
-	return err
-}
-
-func (p *AggregateTilesRequest) writeField4(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 4); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:rangeEnd: ", p), err)
-	}
-	if err := oprot.WriteI64(int64(p.RangeEnd)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.rangeEnd (4) field write error: ", p), err)
+func (p *ConjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("queries", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queries: ", p), err)
 	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:rangeEnd: ", p), err)
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Queries)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
 	}
-	return err
-}
-
-func (p *AggregateTilesRequest) writeField5(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("step", thrift.STRING, 5); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:step: ", p), err)
+	for _, v := range p.Queries {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
 	}
-	if err := oprot.WriteString(string(p.Step)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.step (5) field write error: ", p), err)
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:step: ", p), err)
-	}
-	return err
-}
-
-func (p *AggregateTilesRequest) writeField6(oprot thrift.TProtocol) (err error) {
-	if p.IsSetRangeType() {
-		if err := oprot.WriteFieldBegin("rangeType", thrift.I32, 6); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:rangeType: ", p), err)
-		}
-		if err := oprot.WriteI32(int32(p.RangeType)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.rangeType (6) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:rangeType: ", p), err)
-		}
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queries: ", p), err)
 	}
 	return err
 }
 
-func (p *AggregateTilesRequest) String() string {
+func (p *ConjunctionQuery) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AggregateTilesRequest(%+v)", *p)
+	return fmt.Sprintf("ConjunctionQuery(%+v)", *p)
 }
 
 // Attributes:
-//  - ProcessedTileCount
-type AggregateTilesResult_ struct {
-	ProcessedTileCount int64 `thrift:"processedTileCount,1,required" db:"processedTileCount" json:"processedTileCount"`
+//  - Queries
+type DisjunctionQuery struct {
+	Queries []*Query `thrift:"queries,1,required" db:"queries" json:"queries"`
 }
 
-func NewAggregateTilesResult_() *AggregateTilesResult_ {
-	return &AggregateTilesResult_{}
+func NewDisjunctionQuery() *DisjunctionQuery {
+	return &DisjunctionQuery{}
 }
 
-func (p *AggregateTilesResult_) GetProcessedTileCount() int64 {
-	return p.ProcessedTileCount
+func (p *DisjunctionQuery) GetQueries() []*Query {
+	return p.Queries
 }
-func (p *AggregateTilesResult_) Read(iprot thrift.TProtocol) error {
+func (p *DisjunctionQuery) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetProcessedTileCount bool = false
+	var issetQueries bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -14491,7 +14679,7 @@ func (p *AggregateTilesResult_) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetProcessedTileCount = true
+			issetQueries = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -14504,23 +14692,34 @@ func (p *AggregateTilesResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetProcessedTileCount {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ProcessedTileCount is not set"))
+	if !issetQueries {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Queries is not set"))
 	}
 	return nil
 }
 
-func (p *AggregateTilesResult_) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.ProcessedTileCount = v
+func (p *DisjunctionQuery) ReadField1(iprot thrift.TProtocol) error {
+	_, size, err := iprot.ReadListBegin()
+	if err != nil {
+		return thrift.PrependError("error reading list begin: ", err)
+	}
+	tSlice := make([]*Query, 0, size)
+	p.Queries = tSlice
+	for i := 0; i < size; i++ {
+		_elem34 := &Query{}
+		if err := _elem34.Read(iprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", _elem34), err)
+		}
+		p.Queries = append(p.Queries, _elem34)
+	}
+	if err := iprot.ReadListEnd(); err != nil {
+		return thrift.PrependError("error reading list end: ", err)
 	}
 	return nil
 }
 
-func (p *AggregateTilesResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("AggregateTilesResult"); err != nil {
+func (p *DisjunctionQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DisjunctionQuery"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -14537,142 +14736,108 @@ func (p *AggregateTilesResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *AggregateTilesResult_) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("processedTileCount", thrift.I64, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:processedTileCount: ", p), err)
+func (p *DisjunctionQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("queries", thrift.LIST, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:queries: ", p), err)
 	}
-	if err := oprot.WriteI64(int64(p.ProcessedTileCount)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.processedTileCount (1) field write error: ", p), err)
+	if err := oprot.WriteListBegin(thrift.STRUCT, len(p.Queries)); err != nil {
+		return thrift.PrependError("error writing list begin: ", err)
+	}
+	for _, v := range p.Queries {
+		if err := v.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", v), err)
+		}
+	}
+	if err := oprot.WriteListEnd(); err != nil {
+		return thrift.PrependError("error writing list end: ", err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:processedTileCount: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:queries: ", p), err)
 	}
 	return err
 }
 
-func (p *AggregateTilesResult_) String() string {
+func (p *DisjunctionQuery) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("AggregateTilesResult_(%+v)", *p)
-}
-
-// Attributes:
-//  - Name
-//  - FilePathTemplate
-//  - Interval
-//  - Duration
-//  - Debug
-//  - ConditionalNumGoroutinesGreaterThan
-//  - ConditionalNumGoroutinesLessThan
-//  - ConditionalIsOverloaded
-type DebugProfileStartRequest struct {
-	Name                                string  `thrift:"name,1,required" db:"name" json:"name"`
-	FilePathTemplate                    string  `thrift:"filePathTemplate,2,required" db:"filePathTemplate" json:"filePathTemplate"`
-	Interval                            *string `thrift:"interval,3" db:"interval" json:"interval,omitempty"`
-	Duration                            *string `thrift:"duration,4" db:"duration" json:"duration,omitempty"`
-	Debug                               *int64  `thrift:"debug,5" db:"debug" json:"debug,omitempty"`
-	ConditionalNumGoroutinesGreaterThan *int64  `thrift:"conditionalNumGoroutinesGreaterThan,6" db:"conditionalNumGoroutinesGreaterThan" json:"conditionalNumGoroutinesGreaterThan,omitempty"`
-	ConditionalNumGoroutinesLessThan    *int64  `thrift:"conditionalNumGoroutinesLessThan,7" db:"conditionalNumGoroutinesLessThan" json:"conditionalNumGoroutinesLessThan,omitempty"`
-	ConditionalIsOverloaded             *bool   `thrift:"conditionalIsOverloaded,8" db:"conditionalIsOverloaded" json:"conditionalIsOverloaded,omitempty"`
-}
-
-func NewDebugProfileStartRequest() *DebugProfileStartRequest {
-	return &DebugProfileStartRequest{}
+	return fmt.Sprintf("DisjunctionQuery(%+v)", *p)
 }
 
-func (p *DebugProfileStartRequest) GetName() string {
-	return p.Name
+type AllQuery struct {
 }
 
-func (p *DebugProfileStartRequest) GetFilePathTemplate() string {
-	return p.FilePathTemplate
+func NewAllQuery() *AllQuery {
+	return &AllQuery{}
 }
 
-var DebugProfileStartRequest_Interval_DEFAULT string
-
-func (p *DebugProfileStartRequest) GetInterval() string {
-	if !p.IsSetInterval() {
-		return DebugProfileStartRequest_Interval_DEFAULT
+func (p *AllQuery) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
-	return *p.Interval
-}
-
-var DebugProfileStartRequest_Duration_DEFAULT string
 
-func (p *DebugProfileStartRequest) GetDuration() string {
-	if !p.IsSetDuration() {
-		return DebugProfileStartRequest_Duration_DEFAULT
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
 	}
-	return *p.Duration
-}
-
-var DebugProfileStartRequest_Debug_DEFAULT int64
-
-func (p *DebugProfileStartRequest) GetDebug() int64 {
-	if !p.IsSetDebug() {
-		return DebugProfileStartRequest_Debug_DEFAULT
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return *p.Debug
+	return nil
 }
 
-var DebugProfileStartRequest_ConditionalNumGoroutinesGreaterThan_DEFAULT int64
-
-func (p *DebugProfileStartRequest) GetConditionalNumGoroutinesGreaterThan() int64 {
-	if !p.IsSetConditionalNumGoroutinesGreaterThan() {
-		return DebugProfileStartRequest_ConditionalNumGoroutinesGreaterThan_DEFAULT
+func (p *AllQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AllQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	return *p.ConditionalNumGoroutinesGreaterThan
-}
-
-var DebugProfileStartRequest_ConditionalNumGoroutinesLessThan_DEFAULT int64
-
-func (p *DebugProfileStartRequest) GetConditionalNumGoroutinesLessThan() int64 {
-	if !p.IsSetConditionalNumGoroutinesLessThan() {
-		return DebugProfileStartRequest_ConditionalNumGoroutinesLessThan_DEFAULT
+	if p != nil {
 	}
-	return *p.ConditionalNumGoroutinesLessThan
-}
-
-var DebugProfileStartRequest_ConditionalIsOverloaded_DEFAULT bool
-
-func (p *DebugProfileStartRequest) GetConditionalIsOverloaded() bool {
-	if !p.IsSetConditionalIsOverloaded() {
-		return DebugProfileStartRequest_ConditionalIsOverloaded_DEFAULT
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	return *p.ConditionalIsOverloaded
-}
-func (p *DebugProfileStartRequest) IsSetInterval() bool {
-	return p.Interval != nil
-}
-
-func (p *DebugProfileStartRequest) IsSetDuration() bool {
-	return p.Duration != nil
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
 }
 
-func (p *DebugProfileStartRequest) IsSetDebug() bool {
-	return p.Debug != nil
+func (p *AllQuery) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AllQuery(%+v)", *p)
 }
 
-func (p *DebugProfileStartRequest) IsSetConditionalNumGoroutinesGreaterThan() bool {
-	return p.ConditionalNumGoroutinesGreaterThan != nil
+// Attributes:
+//  - Field
+type FieldQuery struct {
+	Field string `thrift:"field,1,required" db:"field" json:"field"`
 }
 
-func (p *DebugProfileStartRequest) IsSetConditionalNumGoroutinesLessThan() bool {
-	return p.ConditionalNumGoroutinesLessThan != nil
+func NewFieldQuery() *FieldQuery {
+	return &FieldQuery{}
 }
 
-func (p *DebugProfileStartRequest) IsSetConditionalIsOverloaded() bool {
-	return p.ConditionalIsOverloaded != nil
+func (p *FieldQuery) GetField() string {
+	return p.Field
 }
-
-func (p *DebugProfileStartRequest) Read(iprot thrift.TProtocol) error {
+func (p *FieldQuery) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetName bool = false
-	var issetFilePathTemplate bool = false
+	var issetField bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -14687,36 +14852,7 @@ func (p *DebugProfileStartRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetName = true
-		case 2:
-			if err := p.ReadField2(iprot); err != nil {
-				return err
-			}
-			issetFilePathTemplate = true
-		case 3:
-			if err := p.ReadField3(iprot); err != nil {
-				return err
-			}
-		case 4:
-			if err := p.ReadField4(iprot); err != nil {
-				return err
-			}
-		case 5:
-			if err := p.ReadField5(iprot); err != nil {
-				return err
-			}
-		case 6:
-			if err := p.ReadField6(iprot); err != nil {
-				return err
-			}
-		case 7:
-			if err := p.ReadField7(iprot); err != nil {
-				return err
-			}
-		case 8:
-			if err := p.ReadField8(iprot); err != nil {
-				return err
-			}
+			issetField = true
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -14729,116 +14865,29 @@ func (p *DebugProfileStartRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetName {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Name is not set"))
-	}
-	if !issetFilePathTemplate {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field FilePathTemplate is not set"))
+	if !issetField {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Field is not set"))
 	}
 	return nil
 }
 
-func (p *DebugProfileStartRequest) ReadField1(iprot thrift.TProtocol) error {
+func (p *FieldQuery) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Name = v
+		p.Field = v
 	}
 	return nil
 }
 
-func (p *DebugProfileStartRequest) ReadField2(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 2: ", err)
-	} else {
-		p.FilePathTemplate = v
-	}
-	return nil
-}
-
-func (p *DebugProfileStartRequest) ReadField3(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 3: ", err)
-	} else {
-		p.Interval = &v
-	}
-	return nil
-}
-
-func (p *DebugProfileStartRequest) ReadField4(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 4: ", err)
-	} else {
-		p.Duration = &v
-	}
-	return nil
-}
-
-func (p *DebugProfileStartRequest) ReadField5(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 5: ", err)
-	} else {
-		p.Debug = &v
-	}
-	return nil
-}
-
-func (p *DebugProfileStartRequest) ReadField6(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 6: ", err)
-	} else {
-		p.ConditionalNumGoroutinesGreaterThan = &v
-	}
-	return nil
-}
-
-func (p *DebugProfileStartRequest) ReadField7(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadI64(); err != nil {
-		return thrift.PrependError("error reading field 7: ", err)
-	} else {
-		p.ConditionalNumGoroutinesLessThan = &v
-	}
-	return nil
-}
-
-func (p *DebugProfileStartRequest) ReadField8(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadBool(); err != nil {
-		return thrift.PrependError("error reading field 8: ", err)
-	} else {
-		p.ConditionalIsOverloaded = &v
-	}
-	return nil
-}
-
-func (p *DebugProfileStartRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("DebugProfileStartRequest"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+func (p *FieldQuery) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("FieldQuery"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
-		if err := p.writeField2(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField3(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField4(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField5(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField6(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField7(oprot); err != nil {
-			return err
-		}
-		if err := p.writeField8(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -14849,204 +14898,143 @@ func (p *DebugProfileStartRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *DebugProfileStartRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err)
+func (p *FieldQuery) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("field", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:field: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.Name)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.Field)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.field (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:field: ", p), err)
 	}
 	return err
 }
 
-func (p *DebugProfileStartRequest) writeField2(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("filePathTemplate", thrift.STRING, 2); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:filePathTemplate: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.FilePathTemplate)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.filePathTemplate (2) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:filePathTemplate: ", p), err)
+func (p *FieldQuery) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return err
+	return fmt.Sprintf("FieldQuery(%+v)", *p)
 }
 
-func (p *DebugProfileStartRequest) writeField3(oprot thrift.TProtocol) (err error) {
-	if p.IsSetInterval() {
-		if err := oprot.WriteFieldBegin("interval", thrift.STRING, 3); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:interval: ", p), err)
-		}
-		if err := oprot.WriteString(string(*p.Interval)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.interval (3) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:interval: ", p), err)
-		}
+// Attributes:
+//  - Term
+//  - Regexp
+//  - Negation
+//  - Conjunction
+//  - Disjunction
+//  - All
+//  - Field
+type Query struct {
+	Term        *TermQuery        `thrift:"term,1" db:"term" json:"term,omitempty"`
+	Regexp      *RegexpQuery      `thrift:"regexp,2" db:"regexp" json:"regexp,omitempty"`
+	Negation    *NegationQuery    `thrift:"negation,3" db:"negation" json:"negation,omitempty"`
+	Conjunction *ConjunctionQuery `thrift:"conjunction,4" db:"conjunction" json:"conjunction,omitempty"`
+	Disjunction *DisjunctionQuery `thrift:"disjunction,5" db:"disjunction" json:"disjunction,omitempty"`
+	All         *AllQuery         `thrift:"all,6" db:"all" json:"all,omitempty"`
+	Field       *FieldQuery       `thrift:"field,7" db:"field" json:"field,omitempty"`
+}
+
+func NewQuery() *Query {
+	return &Query{}
+}
+
+var Query_Term_DEFAULT *TermQuery
+
+func (p *Query) GetTerm() *TermQuery {
+	if !p.IsSetTerm() {
+		return Query_Term_DEFAULT
 	}
-	return err
+	return p.Term
 }
 
-func (p *DebugProfileStartRequest) writeField4(oprot thrift.TProtocol) (err error) {
-	if p.IsSetDuration() {
-		if err := oprot.WriteFieldBegin("duration", thrift.STRING, 4); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:duration: ", p), err)
-		}
-		if err := oprot.WriteString(string(*p.Duration)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.duration (4) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:duration: ", p), err)
-		}
+var Query_Regexp_DEFAULT *RegexpQuery
+
+func (p *Query) GetRegexp() *RegexpQuery {
+	if !p.IsSetRegexp() {
+		return Query_Regexp_DEFAULT
 	}
-	return err
+	return p.Regexp
 }
 
-func (p *DebugProfileStartRequest) writeField5(oprot thrift.TProtocol) (err error) {
-	if p.IsSetDebug() {
-		if err := oprot.WriteFieldBegin("debug", thrift.I64, 5); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:debug: ", p), err)
-		}
-		if err := oprot.WriteI64(int64(*p.Debug)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.debug (5) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:debug: ", p), err)
-		}
+var Query_Negation_DEFAULT *NegationQuery
+
+func (p *Query) GetNegation() *NegationQuery {
+	if !p.IsSetNegation() {
+		return Query_Negation_DEFAULT
 	}
-	return err
+	return p.Negation
 }
 
-func (p *DebugProfileStartRequest) writeField6(oprot thrift.TProtocol) (err error) {
-	if p.IsSetConditionalNumGoroutinesGreaterThan() {
-		if err := oprot.WriteFieldBegin("conditionalNumGoroutinesGreaterThan", thrift.I64, 6); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:conditionalNumGoroutinesGreaterThan: ", p), err)
-		}
-		if err := oprot.WriteI64(int64(*p.ConditionalNumGoroutinesGreaterThan)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.conditionalNumGoroutinesGreaterThan (6) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:conditionalNumGoroutinesGreaterThan: ", p), err)
-		}
+var Query_Conjunction_DEFAULT *ConjunctionQuery
+
+func (p *Query) GetConjunction() *ConjunctionQuery {
+	if !p.IsSetConjunction() {
+		return Query_Conjunction_DEFAULT
 	}
-	return err
+	return p.Conjunction
 }
 
-func (p *DebugProfileStartRequest) writeField7(oprot thrift.TProtocol) (err error) {
-	if p.IsSetConditionalNumGoroutinesLessThan() {
-		if err := oprot.WriteFieldBegin("conditionalNumGoroutinesLessThan", thrift.I64, 7); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:conditionalNumGoroutinesLessThan: ", p), err)
-		}
-		if err := oprot.WriteI64(int64(*p.ConditionalNumGoroutinesLessThan)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.conditionalNumGoroutinesLessThan (7) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:conditionalNumGoroutinesLessThan: ", p), err)
-		}
+var Query_Disjunction_DEFAULT *DisjunctionQuery
+
+func (p *Query) GetDisjunction() *DisjunctionQuery {
+	if !p.IsSetDisjunction() {
+		return Query_Disjunction_DEFAULT
 	}
-	return err
+	return p.Disjunction
 }
 
-func (p *DebugProfileStartRequest) writeField8(oprot thrift.TProtocol) (err error) {
-	if p.IsSetConditionalIsOverloaded() {
-		if err := oprot.WriteFieldBegin("conditionalIsOverloaded", thrift.BOOL, 8); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:conditionalIsOverloaded: ", p), err)
-		}
-		if err := oprot.WriteBool(bool(*p.ConditionalIsOverloaded)); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T.conditionalIsOverloaded (8) field write error: ", p), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:conditionalIsOverloaded: ", p), err)
-		}
+var Query_All_DEFAULT *AllQuery
+
+func (p *Query) GetAll() *AllQuery {
+	if !p.IsSetAll() {
+		return Query_All_DEFAULT
 	}
-	return err
+	return p.All
 }
 
-func (p *DebugProfileStartRequest) String() string {
-	if p == nil {
-		return "<nil>"
+var Query_Field_DEFAULT *FieldQuery
+
+func (p *Query) GetField() *FieldQuery {
+	if !p.IsSetField() {
+		return Query_Field_DEFAULT
 	}
-	return fmt.Sprintf("DebugProfileStartRequest(%+v)", *p)
+	return p.Field
+}
+func (p *Query) IsSetTerm() bool {
+	return p.Term != nil
 }
 
-type DebugProfileStartResult_ struct {
+func (p *Query) IsSetRegexp() bool {
+	return p.Regexp != nil
 }
 
-func NewDebugProfileStartResult_() *DebugProfileStartResult_ {
-	return &DebugProfileStartResult_{}
+func (p *Query) IsSetNegation() bool {
+	return p.Negation != nil
 }
 
-func (p *DebugProfileStartResult_) Read(iprot thrift.TProtocol) error {
-	if _, err := iprot.ReadStructBegin(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
-	}
-
-	for {
-		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
-		if err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
-		}
-		if fieldTypeId == thrift.STOP {
-			break
-		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
-		}
-		if err := iprot.ReadFieldEnd(); err != nil {
-			return err
-		}
-	}
-	if err := iprot.ReadStructEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-	}
-	return nil
-}
-
-func (p *DebugProfileStartResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("DebugProfileStartResult"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
-	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
-	}
-	return nil
+func (p *Query) IsSetConjunction() bool {
+	return p.Conjunction != nil
 }
 
-func (p *DebugProfileStartResult_) String() string {
-	if p == nil {
-		return "<nil>"
-	}
-	return fmt.Sprintf("DebugProfileStartResult_(%+v)", *p)
+func (p *Query) IsSetDisjunction() bool {
+	return p.Disjunction != nil
 }
 
-// Attributes:
-//  - Name
-type DebugProfileStopRequest struct {
-	Name string `thrift:"name,1,required" db:"name" json:"name"`
+func (p *Query) IsSetAll() bool {
+	return p.All != nil
 }
 
-func NewDebugProfileStopRequest() *DebugProfileStopRequest {
-	return &DebugProfileStopRequest{}
+func (p *Query) IsSetField() bool {
+	return p.Field != nil
 }
 
-func (p *DebugProfileStopRequest) GetName() string {
-	return p.Name
-}
-func (p *DebugProfileStopRequest) Read(iprot thrift.TProtocol) error {
+func (p *Query) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetName bool = false
-
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -15060,7 +15048,30 @@ func (p *DebugProfileStopRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetName = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.ReadField7(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -15073,97 +15084,91 @@ func (p *DebugProfileStopRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetName {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Name is not set"))
-	}
 	return nil
 }
 
-func (p *DebugProfileStopRequest) ReadField1(iprot thrift.TProtocol) error {
-	if v, err := iprot.ReadString(); err != nil {
-		return thrift.PrependError("error reading field 1: ", err)
-	} else {
-		p.Name = v
+func (p *Query) ReadField1(iprot thrift.TProtocol) error {
+	p.Term = &TermQuery{}
+	if err := p.Term.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Term), err)
 	}
 	return nil
 }
 
-func (p *DebugProfileStopRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("DebugProfileStopRequest"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
-	}
-	if err := oprot.WriteFieldStop(); err != nil {
-		return thrift.PrependError("write field stop error: ", err)
-	}
-	if err := oprot.WriteStructEnd(); err != nil {
-		return thrift.PrependError("write struct stop error: ", err)
+func (p *Query) ReadField2(iprot thrift.TProtocol) error {
+	p.Regexp = &RegexpQuery{}
+	if err := p.Regexp.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Regexp), err)
 	}
 	return nil
 }
 
-func (p *DebugProfileStopRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err)
-	}
-	if err := oprot.WriteString(string(p.Name)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err)
+func (p *Query) ReadField3(iprot thrift.TProtocol) error {
+	p.Negation = &NegationQuery{}
+	if err := p.Negation.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Negation), err)
 	}
-	return err
+	return nil
 }
 
-func (p *DebugProfileStopRequest) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *Query) ReadField4(iprot thrift.TProtocol) error {
+	p.Conjunction = &ConjunctionQuery{}
+	if err := p.Conjunction.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Conjunction), err)
 	}
-	return fmt.Sprintf("DebugProfileStopRequest(%+v)", *p)
+	return nil
 }
 
-type DebugProfileStopResult_ struct {
+func (p *Query) ReadField5(iprot thrift.TProtocol) error {
+	p.Disjunction = &DisjunctionQuery{}
+	if err := p.Disjunction.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Disjunction), err)
+	}
+	return nil
 }
 
-func NewDebugProfileStopResult_() *DebugProfileStopResult_ {
-	return &DebugProfileStopResult_{}
+func (p *Query) ReadField6(iprot thrift.TProtocol) error {
+	p.All = &AllQuery{}
+	if err := p.All.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.All), err)
+	}
+	return nil
 }
 
-func (p *DebugProfileStopResult_) Read(iprot thrift.TProtocol) error {
-	if _, err := iprot.ReadStructBegin(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+func (p *Query) ReadField7(iprot thrift.TProtocol) error {
+	p.Field = &FieldQuery{}
+	if err := p.Field.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Field), err)
 	}
+	return nil
+}
 
-	for {
-		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
-		if err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+func (p *Query) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("Query"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
-		if fieldTypeId == thrift.STOP {
-			break
+		if err := p.writeField2(oprot); err != nil {
+			return err
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
+		if err := p.writeField3(oprot); err != nil {
 			return err
 		}
-		if err := iprot.ReadFieldEnd(); err != nil {
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField6(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField7(oprot); err != nil {
 			return err
 		}
-	}
-	if err := iprot.ReadStructEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
-	}
-	return nil
-}
-
-func (p *DebugProfileStopResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("DebugProfileStopResult"); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
-	}
-	if p != nil {
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -15174,32 +15179,179 @@ func (p *DebugProfileStopResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *DebugProfileStopResult_) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *Query) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetTerm() {
+		if err := oprot.WriteFieldBegin("term", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:term: ", p), err)
+		}
+		if err := p.Term.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Term), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:term: ", p), err)
+		}
 	}
-	return fmt.Sprintf("DebugProfileStopResult_(%+v)", *p)
+	return err
 }
 
-// Attributes:
-//  - Directory
-type DebugIndexMemorySegmentsRequest struct {
-	Directory string `thrift:"directory,1,required" db:"directory" json:"directory"`
+func (p *Query) writeField2(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRegexp() {
+		if err := oprot.WriteFieldBegin("regexp", thrift.STRUCT, 2); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:regexp: ", p), err)
+		}
+		if err := p.Regexp.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Regexp), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 2:regexp: ", p), err)
+		}
+	}
+	return err
 }
 
-func NewDebugIndexMemorySegmentsRequest() *DebugIndexMemorySegmentsRequest {
-	return &DebugIndexMemorySegmentsRequest{}
+func (p *Query) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetNegation() {
+		if err := oprot.WriteFieldBegin("negation", thrift.STRUCT, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:negation: ", p), err)
+		}
+		if err := p.Negation.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Negation), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:negation: ", p), err)
+		}
+	}
+	return err
 }
 
-func (p *DebugIndexMemorySegmentsRequest) GetDirectory() string {
-	return p.Directory
-}
-func (p *DebugIndexMemorySegmentsRequest) Read(iprot thrift.TProtocol) error {
+func (p *Query) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetConjunction() {
+		if err := oprot.WriteFieldBegin("conjunction", thrift.STRUCT, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:conjunction: ", p), err)
+		}
+		if err := p.Conjunction.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Conjunction), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:conjunction: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Query) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDisjunction() {
+		if err := oprot.WriteFieldBegin("disjunction", thrift.STRUCT, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:disjunction: ", p), err)
+		}
+		if err := p.Disjunction.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Disjunction), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:disjunction: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Query) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetAll() {
+		if err := oprot.WriteFieldBegin("all", thrift.STRUCT, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:all: ", p), err)
+		}
+		if err := p.All.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.All), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:all: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Query) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetField() {
+		if err := oprot.WriteFieldBegin("field", thrift.STRUCT, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:field: ", p), err)
+		}
+		if err := p.Field.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Field), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:field: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *Query) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("Query(%+v)", *p)
+}
+
+// Attributes:
+//  - SourceNamespace
+//  - TargetNamespace
+//  - RangeStart
+//  - RangeEnd
+//  - Step
+//  - RangeType
+type AggregateTilesRequest struct {
+	SourceNamespace string   `thrift:"sourceNamespace,1,required" db:"sourceNamespace" json:"sourceNamespace"`
+	TargetNamespace string   `thrift:"targetNamespace,2,required" db:"targetNamespace" json:"targetNamespace"`
+	RangeStart      int64    `thrift:"rangeStart,3,required" db:"rangeStart" json:"rangeStart"`
+	RangeEnd        int64    `thrift:"rangeEnd,4,required" db:"rangeEnd" json:"rangeEnd"`
+	Step            string   `thrift:"step,5,required" db:"step" json:"step"`
+	RangeType       TimeType `thrift:"rangeType,6" db:"rangeType" json:"rangeType,omitempty"`
+}
+
+func NewAggregateTilesRequest() *AggregateTilesRequest {
+	return &AggregateTilesRequest{
+		RangeType: 0,
+	}
+}
+
+func (p *AggregateTilesRequest) GetSourceNamespace() string {
+	return p.SourceNamespace
+}
+
+func (p *AggregateTilesRequest) GetTargetNamespace() string {
+	return p.TargetNamespace
+}
+
+func (p *AggregateTilesRequest) GetRangeStart() int64 {
+	return p.RangeStart
+}
+
+func (p *AggregateTilesRequest) GetRangeEnd() int64 {
+	return p.RangeEnd
+}
+
+func (p *AggregateTilesRequest) GetStep() string {
+	return p.Step
+}
+
+var AggregateTilesRequest_RangeType_DEFAULT TimeType = 0
+
+func (p *AggregateTilesRequest) GetRangeType() TimeType {
+	return p.RangeType
+}
+func (p *AggregateTilesRequest) IsSetRangeType() bool {
+	return p.RangeType != AggregateTilesRequest_RangeType_DEFAULT
+}
+
+func (p *AggregateTilesRequest) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	var issetDirectory bool = false
+	var issetSourceNamespace bool = false
+	var issetTargetNamespace bool = false
+	var issetRangeStart bool = false
+	var issetRangeEnd bool = false
+	var issetStep bool = false
 
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
@@ -15214,7 +15366,31 @@ func (p *DebugIndexMemorySegmentsRequest) Read(iprot thrift.TProtocol) error {
 			if err := p.ReadField1(iprot); err != nil {
 				return err
 			}
-			issetDirectory = true
+			issetSourceNamespace = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetTargetNamespace = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+			issetRangeStart = true
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+			issetRangeEnd = true
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+			issetStep = true
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
 		default:
 			if err := iprot.Skip(fieldTypeId); err != nil {
 				return err
@@ -15227,29 +15403,102 @@ func (p *DebugIndexMemorySegmentsRequest) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	if !issetDirectory {
-		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Directory is not set"))
+	if !issetSourceNamespace {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field SourceNamespace is not set"))
+	}
+	if !issetTargetNamespace {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field TargetNamespace is not set"))
+	}
+	if !issetRangeStart {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeStart is not set"))
+	}
+	if !issetRangeEnd {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field RangeEnd is not set"))
+	}
+	if !issetStep {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Step is not set"))
 	}
 	return nil
 }
 
-func (p *DebugIndexMemorySegmentsRequest) ReadField1(iprot thrift.TProtocol) error {
+func (p *AggregateTilesRequest) ReadField1(iprot thrift.TProtocol) error {
 	if v, err := iprot.ReadString(); err != nil {
 		return thrift.PrependError("error reading field 1: ", err)
 	} else {
-		p.Directory = v
+		p.SourceNamespace = v
 	}
 	return nil
 }
 
-func (p *DebugIndexMemorySegmentsRequest) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("DebugIndexMemorySegmentsRequest"); err != nil {
+func (p *AggregateTilesRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.TargetNamespace = v
+	}
+	return nil
+}
+
+func (p *AggregateTilesRequest) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.RangeStart = v
+	}
+	return nil
+}
+
+func (p *AggregateTilesRequest) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.RangeEnd = v
+	}
+	return nil
+}
+
+func (p *AggregateTilesRequest) ReadField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Step = v
+	}
+	return nil
+}
+
+func (p *AggregateTilesRequest) ReadField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI32(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		temp := TimeType(v)
+		p.RangeType = temp
+	}
+	return nil
+}
+
+func (p *AggregateTilesRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateTilesRequest"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField6(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -15260,38 +15509,113 @@ func (p *DebugIndexMemorySegmentsRequest) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *DebugIndexMemorySegmentsRequest) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("directory", thrift.STRING, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:directory: ", p), err)
+func (p *AggregateTilesRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("sourceNamespace", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:sourceNamespace: ", p), err)
 	}
-	if err := oprot.WriteString(string(p.Directory)); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T.directory (1) field write error: ", p), err)
+	if err := oprot.WriteString(string(p.SourceNamespace)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.sourceNamespace (1) field write error: ", p), err)
 	}
 	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:directory: ", p), err)
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:sourceNamespace: ", p), err)
 	}
 	return err
 }
 
-func (p *DebugIndexMemorySegmentsRequest) String() string {
-	if p == nil {
-		return "<nil>"
+func (p *AggregateTilesRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("targetNamespace", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:targetNamespace: ", p), err)
 	}
-	return fmt.Sprintf("DebugIndexMemorySegmentsRequest(%+v)", *p)
-}
+	if err := oprot.WriteString(string(p.TargetNamespace)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.targetNamespace (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:targetNamespace: ", p), err)
+	}
+	return err
+}
 
-type DebugIndexMemorySegmentsResult_ struct {
+func (p *AggregateTilesRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeStart", thrift.I64, 3); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:rangeStart: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.RangeStart)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeStart (3) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 3:rangeStart: ", p), err)
+	}
+	return err
 }
 
-func NewDebugIndexMemorySegmentsResult_() *DebugIndexMemorySegmentsResult_ {
-	return &DebugIndexMemorySegmentsResult_{}
+func (p *AggregateTilesRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("rangeEnd", thrift.I64, 4); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:rangeEnd: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.RangeEnd)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.rangeEnd (4) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 4:rangeEnd: ", p), err)
+	}
+	return err
 }
 
-func (p *DebugIndexMemorySegmentsResult_) Read(iprot thrift.TProtocol) error {
+func (p *AggregateTilesRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("step", thrift.STRING, 5); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:step: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Step)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.step (5) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 5:step: ", p), err)
+	}
+	return err
+}
+
+func (p *AggregateTilesRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetRangeType() {
+		if err := oprot.WriteFieldBegin("rangeType", thrift.I32, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:rangeType: ", p), err)
+		}
+		if err := oprot.WriteI32(int32(p.RangeType)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.rangeType (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:rangeType: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *AggregateTilesRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("AggregateTilesRequest(%+v)", *p)
+}
+
+// Attributes:
+//  - ProcessedTileCount
+type AggregateTilesResult_ struct {
+	ProcessedTileCount int64 `thrift:"processedTileCount,1,required" db:"processedTileCount" json:"processedTileCount"`
+}
+
+func NewAggregateTilesResult_() *AggregateTilesResult_ {
+	return &AggregateTilesResult_{}
+}
+
+func (p *AggregateTilesResult_) GetProcessedTileCount() int64 {
+	return p.ProcessedTileCount
+}
+func (p *AggregateTilesResult_) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
+	var issetProcessedTileCount bool = false
+
 	for {
 		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
 		if err != nil {
@@ -15300,8 +15624,16 @@ func (p *DebugIndexMemorySegmentsResult_) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetProcessedTileCount = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -15310,14 +15642,29 @@ func (p *DebugIndexMemorySegmentsResult_) Read(iprot thrift.TProtocol) error {
 	if err := iprot.ReadStructEnd(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
+	if !issetProcessedTileCount {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field ProcessedTileCount is not set"))
+	}
 	return nil
 }
 
-func (p *DebugIndexMemorySegmentsResult_) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("DebugIndexMemorySegmentsResult"); err != nil {
+func (p *AggregateTilesResult_) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.ProcessedTileCount = v
+	}
+	return nil
+}
+
+func (p *AggregateTilesResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("AggregateTilesResult"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -15328,142 +15675,1588 @@ func (p *DebugIndexMemorySegmentsResult_) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *DebugIndexMemorySegmentsResult_) String() string {
+func (p *AggregateTilesResult_) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("processedTileCount", thrift.I64, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:processedTileCount: ", p), err)
+	}
+	if err := oprot.WriteI64(int64(p.ProcessedTileCount)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.processedTileCount (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:processedTileCount: ", p), err)
+	}
+	return err
+}
+
+func (p *AggregateTilesResult_) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("DebugIndexMemorySegmentsResult_(%+v)", *p)
+	return fmt.Sprintf("AggregateTilesResult_(%+v)", *p)
 }
 
-type Node interface {
-	// Parameters:
-	//  - Req
-	Query(req *QueryRequest) (r *QueryResult_, err error)
-	// Parameters:
-	//  - Req
-	Aggregate(req *AggregateQueryRequest) (r *AggregateQueryResult_, err error)
-	// Parameters:
-	//  - Req
-	Fetch(req *FetchRequest) (r *FetchResult_, err error)
-	// Parameters:
-	//  - Req
-	Write(req *WriteRequest) (err error)
-	// Parameters:
-	//  - Req
-	WriteTagged(req *WriteTaggedRequest) (err error)
-	// Parameters:
-	//  - Req
-	AggregateRaw(req *AggregateQueryRawRequest) (r *AggregateQueryRawResult_, err error)
-	// Parameters:
-	//  - Req
-	FetchBatchRaw(req *FetchBatchRawRequest) (r *FetchBatchRawResult_, err error)
-	// Parameters:
-	//  - Req
-	FetchBatchRawV2(req *FetchBatchRawV2Request) (r *FetchBatchRawResult_, err error)
-	// Parameters:
-	//  - Req
-	FetchBlocksRaw(req *FetchBlocksRawRequest) (r *FetchBlocksRawResult_, err error)
-	// Parameters:
-	//  - Req
-	FetchTagged(req *FetchTaggedRequest) (r *FetchTaggedResult_, err error)
-	// Parameters:
-	//  - Req
-	FetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (r *FetchBlocksMetadataRawV2Result_, err error)
-	// Parameters:
-	//  - Req
-	WriteBatchRaw(req *WriteBatchRawRequest) (err error)
-	// Parameters:
-	//  - Req
-	WriteBatchRawV2(req *WriteBatchRawV2Request) (err error)
-	// Parameters:
-	//  - Req
-	WriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error)
-	// Parameters:
-	//  - Req
-	WriteTaggedBatchRawV2(req *WriteTaggedBatchRawV2Request) (err error)
-	Repair() (err error)
-	// Parameters:
-	//  - Req
-	Truncate(req *TruncateRequest) (r *TruncateResult_, err error)
-	// Parameters:
-	//  - Req
-	AggregateTiles(req *AggregateTilesRequest) (r *AggregateTilesResult_, err error)
-	Health() (r *NodeHealthResult_, err error)
-	Bootstrapped() (r *NodeBootstrappedResult_, err error)
-	BootstrappedInPlacementOrNoPlacement() (r *NodeBootstrappedInPlacementOrNoPlacementResult_, err error)
-	GetPersistRateLimit() (r *NodePersistRateLimitResult_, err error)
-	// Parameters:
-	//  - Req
-	SetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (r *NodePersistRateLimitResult_, err error)
-	GetWriteNewSeriesAsync() (r *NodeWriteNewSeriesAsyncResult_, err error)
-	// Parameters:
-	//  - Req
-	SetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (r *NodeWriteNewSeriesAsyncResult_, err error)
-	GetWriteNewSeriesBackoffDuration() (r *NodeWriteNewSeriesBackoffDurationResult_, err error)
-	// Parameters:
-	//  - Req
-	SetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (r *NodeWriteNewSeriesBackoffDurationResult_, err error)
-	GetWriteNewSeriesLimitPerShardPerSecond() (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error)
-	// Parameters:
-	//  - Req
-	SetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error)
-	// Parameters:
-	//  - Req
-	DebugProfileStart(req *DebugProfileStartRequest) (r *DebugProfileStartResult_, err error)
-	// Parameters:
-	//  - Req
-	DebugProfileStop(req *DebugProfileStopRequest) (r *DebugProfileStopResult_, err error)
-	// Parameters:
-	//  - Req
-	DebugIndexMemorySegments(req *DebugIndexMemorySegmentsRequest) (r *DebugIndexMemorySegmentsResult_, err error)
+// Attributes:
+//  - Name
+//  - FilePathTemplate
+//  - Interval
+//  - Duration
+//  - Debug
+//  - ConditionalNumGoroutinesGreaterThan
+//  - ConditionalNumGoroutinesLessThan
+//  - ConditionalIsOverloaded
+type DebugProfileStartRequest struct {
+	Name                                string  `thrift:"name,1,required" db:"name" json:"name"`
+	FilePathTemplate                    string  `thrift:"filePathTemplate,2,required" db:"filePathTemplate" json:"filePathTemplate"`
+	Interval                            *string `thrift:"interval,3" db:"interval" json:"interval,omitempty"`
+	Duration                            *string `thrift:"duration,4" db:"duration" json:"duration,omitempty"`
+	Debug                               *int64  `thrift:"debug,5" db:"debug" json:"debug,omitempty"`
+	ConditionalNumGoroutinesGreaterThan *int64  `thrift:"conditionalNumGoroutinesGreaterThan,6" db:"conditionalNumGoroutinesGreaterThan" json:"conditionalNumGoroutinesGreaterThan,omitempty"`
+	ConditionalNumGoroutinesLessThan    *int64  `thrift:"conditionalNumGoroutinesLessThan,7" db:"conditionalNumGoroutinesLessThan" json:"conditionalNumGoroutinesLessThan,omitempty"`
+	ConditionalIsOverloaded             *bool   `thrift:"conditionalIsOverloaded,8" db:"conditionalIsOverloaded" json:"conditionalIsOverloaded,omitempty"`
 }
 
-type NodeClient struct {
-	Transport       thrift.TTransport
-	ProtocolFactory thrift.TProtocolFactory
-	InputProtocol   thrift.TProtocol
-	OutputProtocol  thrift.TProtocol
-	SeqId           int32
+func NewDebugProfileStartRequest() *DebugProfileStartRequest {
+	return &DebugProfileStartRequest{}
 }
 
-func NewNodeClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *NodeClient {
-	return &NodeClient{Transport: t,
-		ProtocolFactory: f,
-		InputProtocol:   f.GetProtocol(t),
-		OutputProtocol:  f.GetProtocol(t),
-		SeqId:           0,
-	}
+func (p *DebugProfileStartRequest) GetName() string {
+	return p.Name
 }
 
-func NewNodeClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *NodeClient {
-	return &NodeClient{Transport: t,
-		ProtocolFactory: nil,
-		InputProtocol:   iprot,
-		OutputProtocol:  oprot,
-		SeqId:           0,
-	}
+func (p *DebugProfileStartRequest) GetFilePathTemplate() string {
+	return p.FilePathTemplate
+}
+
+var DebugProfileStartRequest_Interval_DEFAULT string
+
+func (p *DebugProfileStartRequest) GetInterval() string {
+	if !p.IsSetInterval() {
+		return DebugProfileStartRequest_Interval_DEFAULT
+	}
+	return *p.Interval
+}
+
+var DebugProfileStartRequest_Duration_DEFAULT string
+
+func (p *DebugProfileStartRequest) GetDuration() string {
+	if !p.IsSetDuration() {
+		return DebugProfileStartRequest_Duration_DEFAULT
+	}
+	return *p.Duration
+}
+
+var DebugProfileStartRequest_Debug_DEFAULT int64
+
+func (p *DebugProfileStartRequest) GetDebug() int64 {
+	if !p.IsSetDebug() {
+		return DebugProfileStartRequest_Debug_DEFAULT
+	}
+	return *p.Debug
+}
+
+var DebugProfileStartRequest_ConditionalNumGoroutinesGreaterThan_DEFAULT int64
+
+func (p *DebugProfileStartRequest) GetConditionalNumGoroutinesGreaterThan() int64 {
+	if !p.IsSetConditionalNumGoroutinesGreaterThan() {
+		return DebugProfileStartRequest_ConditionalNumGoroutinesGreaterThan_DEFAULT
+	}
+	return *p.ConditionalNumGoroutinesGreaterThan
+}
+
+var DebugProfileStartRequest_ConditionalNumGoroutinesLessThan_DEFAULT int64
+
+func (p *DebugProfileStartRequest) GetConditionalNumGoroutinesLessThan() int64 {
+	if !p.IsSetConditionalNumGoroutinesLessThan() {
+		return DebugProfileStartRequest_ConditionalNumGoroutinesLessThan_DEFAULT
+	}
+	return *p.ConditionalNumGoroutinesLessThan
+}
+
+var DebugProfileStartRequest_ConditionalIsOverloaded_DEFAULT bool
+
+func (p *DebugProfileStartRequest) GetConditionalIsOverloaded() bool {
+	if !p.IsSetConditionalIsOverloaded() {
+		return DebugProfileStartRequest_ConditionalIsOverloaded_DEFAULT
+	}
+	return *p.ConditionalIsOverloaded
+}
+func (p *DebugProfileStartRequest) IsSetInterval() bool {
+	return p.Interval != nil
+}
+
+func (p *DebugProfileStartRequest) IsSetDuration() bool {
+	return p.Duration != nil
+}
+
+func (p *DebugProfileStartRequest) IsSetDebug() bool {
+	return p.Debug != nil
+}
+
+func (p *DebugProfileStartRequest) IsSetConditionalNumGoroutinesGreaterThan() bool {
+	return p.ConditionalNumGoroutinesGreaterThan != nil
+}
+
+func (p *DebugProfileStartRequest) IsSetConditionalNumGoroutinesLessThan() bool {
+	return p.ConditionalNumGoroutinesLessThan != nil
+}
+
+func (p *DebugProfileStartRequest) IsSetConditionalIsOverloaded() bool {
+	return p.ConditionalIsOverloaded != nil
+}
+
+func (p *DebugProfileStartRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetName bool = false
+	var issetFilePathTemplate bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetName = true
+		case 2:
+			if err := p.ReadField2(iprot); err != nil {
+				return err
+			}
+			issetFilePathTemplate = true
+		case 3:
+			if err := p.ReadField3(iprot); err != nil {
+				return err
+			}
+		case 4:
+			if err := p.ReadField4(iprot); err != nil {
+				return err
+			}
+		case 5:
+			if err := p.ReadField5(iprot); err != nil {
+				return err
+			}
+		case 6:
+			if err := p.ReadField6(iprot); err != nil {
+				return err
+			}
+		case 7:
+			if err := p.ReadField7(iprot); err != nil {
+				return err
+			}
+		case 8:
+			if err := p.ReadField8(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetName {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Name is not set"))
+	}
+	if !issetFilePathTemplate {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field FilePathTemplate is not set"))
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Name = v
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) ReadField2(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 2: ", err)
+	} else {
+		p.FilePathTemplate = v
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) ReadField3(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 3: ", err)
+	} else {
+		p.Interval = &v
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) ReadField4(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 4: ", err)
+	} else {
+		p.Duration = &v
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) ReadField5(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 5: ", err)
+	} else {
+		p.Debug = &v
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) ReadField6(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 6: ", err)
+	} else {
+		p.ConditionalNumGoroutinesGreaterThan = &v
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) ReadField7(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadI64(); err != nil {
+		return thrift.PrependError("error reading field 7: ", err)
+	} else {
+		p.ConditionalNumGoroutinesLessThan = &v
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) ReadField8(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadBool(); err != nil {
+		return thrift.PrependError("error reading field 8: ", err)
+	} else {
+		p.ConditionalIsOverloaded = &v
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DebugProfileStartRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField2(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField3(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField4(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField5(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField6(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField7(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField8(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DebugProfileStartRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Name)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err)
+	}
+	return err
+}
+
+func (p *DebugProfileStartRequest) writeField2(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("filePathTemplate", thrift.STRING, 2); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 2:filePathTemplate: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.FilePathTemplate)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.filePathTemplate (2) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 2:filePathTemplate: ", p), err)
+	}
+	return err
+}
+
+func (p *DebugProfileStartRequest) writeField3(oprot thrift.TProtocol) (err error) {
+	if p.IsSetInterval() {
+		if err := oprot.WriteFieldBegin("interval", thrift.STRING, 3); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 3:interval: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Interval)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.interval (3) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 3:interval: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugProfileStartRequest) writeField4(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDuration() {
+		if err := oprot.WriteFieldBegin("duration", thrift.STRING, 4); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 4:duration: ", p), err)
+		}
+		if err := oprot.WriteString(string(*p.Duration)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.duration (4) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 4:duration: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugProfileStartRequest) writeField5(oprot thrift.TProtocol) (err error) {
+	if p.IsSetDebug() {
+		if err := oprot.WriteFieldBegin("debug", thrift.I64, 5); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 5:debug: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.Debug)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.debug (5) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 5:debug: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugProfileStartRequest) writeField6(oprot thrift.TProtocol) (err error) {
+	if p.IsSetConditionalNumGoroutinesGreaterThan() {
+		if err := oprot.WriteFieldBegin("conditionalNumGoroutinesGreaterThan", thrift.I64, 6); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 6:conditionalNumGoroutinesGreaterThan: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.ConditionalNumGoroutinesGreaterThan)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.conditionalNumGoroutinesGreaterThan (6) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 6:conditionalNumGoroutinesGreaterThan: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugProfileStartRequest) writeField7(oprot thrift.TProtocol) (err error) {
+	if p.IsSetConditionalNumGoroutinesLessThan() {
+		if err := oprot.WriteFieldBegin("conditionalNumGoroutinesLessThan", thrift.I64, 7); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 7:conditionalNumGoroutinesLessThan: ", p), err)
+		}
+		if err := oprot.WriteI64(int64(*p.ConditionalNumGoroutinesLessThan)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.conditionalNumGoroutinesLessThan (7) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 7:conditionalNumGoroutinesLessThan: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugProfileStartRequest) writeField8(oprot thrift.TProtocol) (err error) {
+	if p.IsSetConditionalIsOverloaded() {
+		if err := oprot.WriteFieldBegin("conditionalIsOverloaded", thrift.BOOL, 8); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 8:conditionalIsOverloaded: ", p), err)
+		}
+		if err := oprot.WriteBool(bool(*p.ConditionalIsOverloaded)); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T.conditionalIsOverloaded (8) field write error: ", p), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 8:conditionalIsOverloaded: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *DebugProfileStartRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DebugProfileStartRequest(%+v)", *p)
+}
+
+type DebugProfileStartResult_ struct {
+}
+
+func NewDebugProfileStartResult_() *DebugProfileStartResult_ {
+	return &DebugProfileStartResult_{}
+}
+
+func (p *DebugProfileStartResult_) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DebugProfileStartResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DebugProfileStartResult"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DebugProfileStartResult_) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DebugProfileStartResult_(%+v)", *p)
+}
+
+// Attributes:
+//  - Name
+type DebugProfileStopRequest struct {
+	Name string `thrift:"name,1,required" db:"name" json:"name"`
+}
+
+func NewDebugProfileStopRequest() *DebugProfileStopRequest {
+	return &DebugProfileStopRequest{}
+}
+
+func (p *DebugProfileStopRequest) GetName() string {
+	return p.Name
+}
+func (p *DebugProfileStopRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetName bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetName = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetName {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Name is not set"))
+	}
+	return nil
+}
+
+func (p *DebugProfileStopRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Name = v
+	}
+	return nil
+}
+
+func (p *DebugProfileStopRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DebugProfileStopRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DebugProfileStopRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("name", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:name: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Name)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.name (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:name: ", p), err)
+	}
+	return err
+}
+
+func (p *DebugProfileStopRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DebugProfileStopRequest(%+v)", *p)
+}
+
+type DebugProfileStopResult_ struct {
+}
+
+func NewDebugProfileStopResult_() *DebugProfileStopResult_ {
+	return &DebugProfileStopResult_{}
+}
+
+func (p *DebugProfileStopResult_) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DebugProfileStopResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DebugProfileStopResult"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DebugProfileStopResult_) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DebugProfileStopResult_(%+v)", *p)
+}
+
+// Attributes:
+//  - Directory
+type DebugIndexMemorySegmentsRequest struct {
+	Directory string `thrift:"directory,1,required" db:"directory" json:"directory"`
+}
+
+func NewDebugIndexMemorySegmentsRequest() *DebugIndexMemorySegmentsRequest {
+	return &DebugIndexMemorySegmentsRequest{}
+}
+
+func (p *DebugIndexMemorySegmentsRequest) GetDirectory() string {
+	return p.Directory
+}
+func (p *DebugIndexMemorySegmentsRequest) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	var issetDirectory bool = false
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+			issetDirectory = true
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	if !issetDirectory {
+		return thrift.NewTProtocolExceptionWithType(thrift.INVALID_DATA, fmt.Errorf("Required field Directory is not set"))
+	}
+	return nil
+}
+
+func (p *DebugIndexMemorySegmentsRequest) ReadField1(iprot thrift.TProtocol) error {
+	if v, err := iprot.ReadString(); err != nil {
+		return thrift.PrependError("error reading field 1: ", err)
+	} else {
+		p.Directory = v
+	}
+	return nil
+}
+
+func (p *DebugIndexMemorySegmentsRequest) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DebugIndexMemorySegmentsRequest"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DebugIndexMemorySegmentsRequest) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("directory", thrift.STRING, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:directory: ", p), err)
+	}
+	if err := oprot.WriteString(string(p.Directory)); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T.directory (1) field write error: ", p), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:directory: ", p), err)
+	}
+	return err
+}
+
+func (p *DebugIndexMemorySegmentsRequest) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DebugIndexMemorySegmentsRequest(%+v)", *p)
+}
+
+type DebugIndexMemorySegmentsResult_ struct {
+}
+
+func NewDebugIndexMemorySegmentsResult_() *DebugIndexMemorySegmentsResult_ {
+	return &DebugIndexMemorySegmentsResult_{}
+}
+
+func (p *DebugIndexMemorySegmentsResult_) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *DebugIndexMemorySegmentsResult_) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("DebugIndexMemorySegmentsResult"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *DebugIndexMemorySegmentsResult_) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("DebugIndexMemorySegmentsResult_(%+v)", *p)
+}
+
+type Node interface {
+	// Parameters:
+	//  - Req
+	Query(req *QueryRequest) (r *QueryResult_, err error)
+	// Parameters:
+	//  - Req
+	Aggregate(req *AggregateQueryRequest) (r *AggregateQueryResult_, err error)
+	// Parameters:
+	//  - Req
+	Fetch(req *FetchRequest) (r *FetchResult_, err error)
+	// Parameters:
+	//  - Req
+	Write(req *WriteRequest) (err error)
+	// Parameters:
+	//  - Req
+	WriteTagged(req *WriteTaggedRequest) (err error)
+	// Parameters:
+	//  - Req
+	AggregateRaw(req *AggregateQueryRawRequest) (r *AggregateQueryRawResult_, err error)
+	// Parameters:
+	//  - Req
+	FetchBatchRaw(req *FetchBatchRawRequest) (r *FetchBatchRawResult_, err error)
+	// Parameters:
+	//  - Req
+	FetchBatchRawV2(req *FetchBatchRawV2Request) (r *FetchBatchRawResult_, err error)
+	// Parameters:
+	//  - Req
+	FetchBlocksRaw(req *FetchBlocksRawRequest) (r *FetchBlocksRawResult_, err error)
+	// Parameters:
+	//  - Req
+	FetchTagged(req *FetchTaggedRequest) (r *FetchTaggedResult_, err error)
+	// Parameters:
+	//  - Req
+	FetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (r *FetchBlocksMetadataRawV2Result_, err error)
+	// Parameters:
+	//  - Req
+	WriteBatchRaw(req *WriteBatchRawRequest) (err error)
+	// Parameters:
+	//  - Req
+	WriteBatchRawV2(req *WriteBatchRawV2Request) (err error)
+	// Parameters:
+	//  - Req
+	WriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error)
+	// Parameters:
+	//  - Req
+	WriteTaggedBatchRawV2(req *WriteTaggedBatchRawV2Request) (err error)
+	Repair() (err error)
+	// Parameters:
+	//  - Req
+	Truncate(req *TruncateRequest) (r *TruncateResult_, err error)
+	// Parameters:
+	//  - Req
+	DeleteSeries(req *DeleteSeriesRequest) (r *DeleteSeriesResult_, err error)
+	// Parameters:
+	//  - Req
+	Backup(req *BackupRequest) (r *BackupResult_, err error)
+	// Parameters:
+	//  - Req
+	Restore(req *RestoreRequest) (r *RestoreResult_, err error)
+	// Parameters:
+	//  - Req
+	AggregateTiles(req *AggregateTilesRequest) (r *AggregateTilesResult_, err error)
+	Health() (r *NodeHealthResult_, err error)
+	Bootstrapped() (r *NodeBootstrappedResult_, err error)
+	BootstrappedInPlacementOrNoPlacement() (r *NodeBootstrappedInPlacementOrNoPlacementResult_, err error)
+	GetPersistRateLimit() (r *NodePersistRateLimitResult_, err error)
+	// Parameters:
+	//  - Req
+	SetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (r *NodePersistRateLimitResult_, err error)
+	GetWriteNewSeriesAsync() (r *NodeWriteNewSeriesAsyncResult_, err error)
+	// Parameters:
+	//  - Req
+	SetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (r *NodeWriteNewSeriesAsyncResult_, err error)
+	GetWriteNewSeriesBackoffDuration() (r *NodeWriteNewSeriesBackoffDurationResult_, err error)
+	// Parameters:
+	//  - Req
+	SetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (r *NodeWriteNewSeriesBackoffDurationResult_, err error)
+	GetWriteNewSeriesLimitPerShardPerSecond() (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error)
+	// Parameters:
+	//  - Req
+	SetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error)
+	// Parameters:
+	//  - Req
+	DebugProfileStart(req *DebugProfileStartRequest) (r *DebugProfileStartResult_, err error)
+	// Parameters:
+	//  - Req
+	DebugProfileStop(req *DebugProfileStopRequest) (r *DebugProfileStopResult_, err error)
+	// Parameters:
+	//  - Req
+	DebugIndexMemorySegments(req *DebugIndexMemorySegmentsRequest) (r *DebugIndexMemorySegmentsResult_, err error)
+}
+
+type NodeClient struct {
+	Transport       thrift.TTransport
+	ProtocolFactory thrift.TProtocolFactory
+	InputProtocol   thrift.TProtocol
+	OutputProtocol  thrift.TProtocol
+	SeqId           int32
+}
+
+func NewNodeClientFactory(t thrift.TTransport, f thrift.TProtocolFactory) *NodeClient {
+	return &NodeClient{Transport: t,
+		ProtocolFactory: f,
+		InputProtocol:   f.GetProtocol(t),
+		OutputProtocol:  f.GetProtocol(t),
+		SeqId:           0,
+	}
+}
+
+func NewNodeClientProtocol(t thrift.TTransport, iprot thrift.TProtocol, oprot thrift.TProtocol) *NodeClient {
+	return &NodeClient{Transport: t,
+		ProtocolFactory: nil,
+		InputProtocol:   iprot,
+		OutputProtocol:  oprot,
+		SeqId:           0,
+	}
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) Query(req *QueryRequest) (r *QueryResult_, err error) {
+	if err = p.sendQuery(req); err != nil {
+		return
+	}
+	return p.recvQuery()
+}
+
+func (p *NodeClient) sendQuery(req *QueryRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("query", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeQueryArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvQuery() (value *QueryResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "query" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "query failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "query failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error35 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error36 error
+		error36, err = error35.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error36
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "query failed: invalid message type")
+		return
+	}
+	result := NodeQueryResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) Aggregate(req *AggregateQueryRequest) (r *AggregateQueryResult_, err error) {
+	if err = p.sendAggregate(req); err != nil {
+		return
+	}
+	return p.recvAggregate()
+}
+
+func (p *NodeClient) sendAggregate(req *AggregateQueryRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("aggregate", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeAggregateArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvAggregate() (value *AggregateQueryResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "aggregate" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "aggregate failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "aggregate failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error37 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error38 error
+		error38, err = error37.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error38
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "aggregate failed: invalid message type")
+		return
+	}
+	result := NodeAggregateResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) Fetch(req *FetchRequest) (r *FetchResult_, err error) {
+	if err = p.sendFetch(req); err != nil {
+		return
+	}
+	return p.recvFetch()
+}
+
+func (p *NodeClient) sendFetch(req *FetchRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetch", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetch() (value *FetchResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetch" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetch failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetch failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error39 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error40 error
+		error40, err = error39.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error40
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetch failed: invalid message type")
+		return
+	}
+	result := NodeFetchResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) Write(req *WriteRequest) (err error) {
+	if err = p.sendWrite(req); err != nil {
+		return
+	}
+	return p.recvWrite()
+}
+
+func (p *NodeClient) sendWrite(req *WriteRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("write", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeWriteArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvWrite() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "write" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "write failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "write failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error41 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error42 error
+		error42, err = error41.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error42
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "write failed: invalid message type")
+		return
+	}
+	result := NodeWriteResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) WriteTagged(req *WriteTaggedRequest) (err error) {
+	if err = p.sendWriteTagged(req); err != nil {
+		return
+	}
+	return p.recvWriteTagged()
+}
+
+func (p *NodeClient) sendWriteTagged(req *WriteTaggedRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("writeTagged", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeWriteTaggedArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvWriteTagged() (err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "writeTagged" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeTagged failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTagged failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error43 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error44 error
+		error44, err = error43.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error44
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTagged failed: invalid message type")
+		return
+	}
+	result := NodeWriteTaggedResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) AggregateRaw(req *AggregateQueryRawRequest) (r *AggregateQueryRawResult_, err error) {
+	if err = p.sendAggregateRaw(req); err != nil {
+		return
+	}
+	return p.recvAggregateRaw()
+}
+
+func (p *NodeClient) sendAggregateRaw(req *AggregateQueryRawRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("aggregateRaw", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeAggregateRawArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvAggregateRaw() (value *AggregateQueryRawResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "aggregateRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "aggregateRaw failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "aggregateRaw failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error45 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error46 error
+		error46, err = error45.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error46
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "aggregateRaw failed: invalid message type")
+		return
+	}
+	result := NodeAggregateRawResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) FetchBatchRaw(req *FetchBatchRawRequest) (r *FetchBatchRawResult_, err error) {
+	if err = p.sendFetchBatchRaw(req); err != nil {
+		return
+	}
+	return p.recvFetchBatchRaw()
+}
+
+func (p *NodeClient) sendFetchBatchRaw(req *FetchBatchRawRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetchBatchRaw", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchBatchRawArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetchBatchRaw() (value *FetchBatchRawResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetchBatchRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBatchRaw failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBatchRaw failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error47 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error48 error
+		error48, err = error47.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error48
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBatchRaw failed: invalid message type")
+		return
+	}
+	result := NodeFetchBatchRawResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) FetchBatchRawV2(req *FetchBatchRawV2Request) (r *FetchBatchRawResult_, err error) {
+	if err = p.sendFetchBatchRawV2(req); err != nil {
+		return
+	}
+	return p.recvFetchBatchRawV2()
+}
+
+func (p *NodeClient) sendFetchBatchRawV2(req *FetchBatchRawV2Request) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetchBatchRawV2", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchBatchRawV2Args{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetchBatchRawV2() (value *FetchBatchRawResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetchBatchRawV2" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBatchRawV2 failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBatchRawV2 failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error49 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error50 error
+		error50, err = error49.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error50
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBatchRawV2 failed: invalid message type")
+		return
+	}
+	result := NodeFetchBatchRawV2Result{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
 }
 
 // Parameters:
 //  - Req
-func (p *NodeClient) Query(req *QueryRequest) (r *QueryResult_, err error) {
-	if err = p.sendQuery(req); err != nil {
+func (p *NodeClient) FetchBlocksRaw(req *FetchBlocksRawRequest) (r *FetchBlocksRawResult_, err error) {
+	if err = p.sendFetchBlocksRaw(req); err != nil {
 		return
 	}
-	return p.recvQuery()
+	return p.recvFetchBlocksRaw()
 }
 
-func (p *NodeClient) sendQuery(req *QueryRequest) (err error) {
+func (p *NodeClient) sendFetchBlocksRaw(req *FetchBlocksRawRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("query", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("fetchBlocksRaw", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeQueryArgs{
+	args := NodeFetchBlocksRawArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -15475,7 +17268,7 @@ func (p *NodeClient) sendQuery(req *QueryRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvQuery() (value *QueryResult_, err error) {
+func (p *NodeClient) recvFetchBlocksRaw() (value *FetchBlocksRawResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -15485,32 +17278,194 @@ func (p *NodeClient) recvQuery() (value *QueryResult_, err error) {
 	if err != nil {
 		return
 	}
-	if method != "query" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "query failed: wrong method name")
+	if method != "fetchBlocksRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksRaw failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksRaw failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error51 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error52 error
+		error52, err = error51.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error52
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksRaw failed: invalid message type")
+		return
+	}
+	result := NodeFetchBlocksRawResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) FetchTagged(req *FetchTaggedRequest) (r *FetchTaggedResult_, err error) {
+	if err = p.sendFetchTagged(req); err != nil {
+		return
+	}
+	return p.recvFetchTagged()
+}
+
+func (p *NodeClient) sendFetchTagged(req *FetchTaggedRequest) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetchTagged", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchTaggedArgs{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetchTagged() (value *FetchTaggedResult_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetchTagged" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchTagged failed: wrong method name")
+		return
+	}
+	if p.SeqId != seqId {
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchTagged failed: out of sequence response")
+		return
+	}
+	if mTypeId == thrift.EXCEPTION {
+		error53 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error54 error
+		error54, err = error53.Read(iprot)
+		if err != nil {
+			return
+		}
+		if err = iprot.ReadMessageEnd(); err != nil {
+			return
+		}
+		err = error54
+		return
+	}
+	if mTypeId != thrift.REPLY {
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchTagged failed: invalid message type")
+		return
+	}
+	result := NodeFetchTaggedResult{}
+	if err = result.Read(iprot); err != nil {
+		return
+	}
+	if err = iprot.ReadMessageEnd(); err != nil {
+		return
+	}
+	if result.Err != nil {
+		err = result.Err
+		return
+	}
+	value = result.GetSuccess()
+	return
+}
+
+// Parameters:
+//  - Req
+func (p *NodeClient) FetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (r *FetchBlocksMetadataRawV2Result_, err error) {
+	if err = p.sendFetchBlocksMetadataRawV2(req); err != nil {
+		return
+	}
+	return p.recvFetchBlocksMetadataRawV2()
+}
+
+func (p *NodeClient) sendFetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (err error) {
+	oprot := p.OutputProtocol
+	if oprot == nil {
+		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.OutputProtocol = oprot
+	}
+	p.SeqId++
+	if err = oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.CALL, p.SeqId); err != nil {
+		return
+	}
+	args := NodeFetchBlocksMetadataRawV2Args{
+		Req: req,
+	}
+	if err = args.Write(oprot); err != nil {
+		return
+	}
+	if err = oprot.WriteMessageEnd(); err != nil {
+		return
+	}
+	return oprot.Flush()
+}
+
+func (p *NodeClient) recvFetchBlocksMetadataRawV2() (value *FetchBlocksMetadataRawV2Result_, err error) {
+	iprot := p.InputProtocol
+	if iprot == nil {
+		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
+		p.InputProtocol = iprot
+	}
+	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return
+	}
+	if method != "fetchBlocksMetadataRawV2" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksMetadataRawV2 failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "query failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksMetadataRawV2 failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error35 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error36 error
-		error36, err = error35.Read(iprot)
+		error55 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error56 error
+		error56, err = error55.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error36
+		err = error56
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "query failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksMetadataRawV2 failed: invalid message type")
 		return
 	}
-	result := NodeQueryResult{}
+	result := NodeFetchBlocksMetadataRawV2Result{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -15527,24 +17482,24 @@ func (p *NodeClient) recvQuery() (value *QueryResult_, err error) {
 
 // Parameters:
 //  - Req
-func (p *NodeClient) Aggregate(req *AggregateQueryRequest) (r *AggregateQueryResult_, err error) {
-	if err = p.sendAggregate(req); err != nil {
+func (p *NodeClient) WriteBatchRaw(req *WriteBatchRawRequest) (err error) {
+	if err = p.sendWriteBatchRaw(req); err != nil {
 		return
 	}
-	return p.recvAggregate()
+	return p.recvWriteBatchRaw()
 }
 
-func (p *NodeClient) sendAggregate(req *AggregateQueryRequest) (err error) {
+func (p *NodeClient) sendWriteBatchRaw(req *WriteBatchRawRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("aggregate", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("writeBatchRaw", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeAggregateArgs{
+	args := NodeWriteBatchRawArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -15556,7 +17511,7 @@ func (p *NodeClient) sendAggregate(req *AggregateQueryRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvAggregate() (value *AggregateQueryResult_, err error) {
+func (p *NodeClient) recvWriteBatchRaw() (err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -15566,32 +17521,32 @@ func (p *NodeClient) recvAggregate() (value *AggregateQueryResult_, err error) {
 	if err != nil {
 		return
 	}
-	if method != "aggregate" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "aggregate failed: wrong method name")
+	if method != "writeBatchRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeBatchRaw failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "aggregate failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeBatchRaw failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error37 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error38 error
-		error38, err = error37.Read(iprot)
+		error57 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error58 error
+		error58, err = error57.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error38
+		err = error58
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "aggregate failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeBatchRaw failed: invalid message type")
 		return
 	}
-	result := NodeAggregateResult{}
+	result := NodeWriteBatchRawResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -15602,30 +17557,29 @@ func (p *NodeClient) recvAggregate() (value *AggregateQueryResult_, err error) {
 		err = result.Err
 		return
 	}
-	value = result.GetSuccess()
 	return
 }
 
 // Parameters:
 //  - Req
-func (p *NodeClient) Fetch(req *FetchRequest) (r *FetchResult_, err error) {
-	if err = p.sendFetch(req); err != nil {
+func (p *NodeClient) WriteBatchRawV2(req *WriteBatchRawV2Request) (err error) {
+	if err = p.sendWriteBatchRawV2(req); err != nil {
 		return
 	}
-	return p.recvFetch()
+	return p.recvWriteBatchRawV2()
 }
 
-func (p *NodeClient) sendFetch(req *FetchRequest) (err error) {
+func (p *NodeClient) sendWriteBatchRawV2(req *WriteBatchRawV2Request) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetch", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("writeBatchRawV2", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchArgs{
+	args := NodeWriteBatchRawV2Args{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -15637,7 +17591,7 @@ func (p *NodeClient) sendFetch(req *FetchRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetch() (value *FetchResult_, err error) {
+func (p *NodeClient) recvWriteBatchRawV2() (err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -15647,32 +17601,32 @@ func (p *NodeClient) recvFetch() (value *FetchResult_, err error) {
 	if err != nil {
 		return
 	}
-	if method != "fetch" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetch failed: wrong method name")
+	if method != "writeBatchRawV2" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeBatchRawV2 failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetch failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeBatchRawV2 failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error39 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error40 error
-		error40, err = error39.Read(iprot)
+		error59 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error60 error
+		error60, err = error59.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error40
+		err = error60
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetch failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeBatchRawV2 failed: invalid message type")
 		return
 	}
-	result := NodeFetchResult{}
+	result := NodeWriteBatchRawV2Result{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -15683,30 +17637,29 @@ func (p *NodeClient) recvFetch() (value *FetchResult_, err error) {
 		err = result.Err
 		return
 	}
-	value = result.GetSuccess()
 	return
 }
 
 // Parameters:
 //  - Req
-func (p *NodeClient) Write(req *WriteRequest) (err error) {
-	if err = p.sendWrite(req); err != nil {
+func (p *NodeClient) WriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error) {
+	if err = p.sendWriteTaggedBatchRaw(req); err != nil {
 		return
 	}
-	return p.recvWrite()
+	return p.recvWriteTaggedBatchRaw()
 }
 
-func (p *NodeClient) sendWrite(req *WriteRequest) (err error) {
+func (p *NodeClient) sendWriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("write", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeWriteArgs{
+	args := NodeWriteTaggedBatchRawArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -15718,7 +17671,7 @@ func (p *NodeClient) sendWrite(req *WriteRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvWrite() (err error) {
+func (p *NodeClient) recvWriteTaggedBatchRaw() (err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -15728,32 +17681,32 @@ func (p *NodeClient) recvWrite() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "write" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "write failed: wrong method name")
+	if method != "writeTaggedBatchRaw" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeTaggedBatchRaw failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "write failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTaggedBatchRaw failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error41 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error42 error
-		error42, err = error41.Read(iprot)
+		error61 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error62 error
+		error62, err = error61.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error42
+		err = error62
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "write failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTaggedBatchRaw failed: invalid message type")
 		return
 	}
-	result := NodeWriteResult{}
+	result := NodeWriteTaggedBatchRawResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -15769,24 +17722,24 @@ func (p *NodeClient) recvWrite() (err error) {
 
 // Parameters:
 //  - Req
-func (p *NodeClient) WriteTagged(req *WriteTaggedRequest) (err error) {
-	if err = p.sendWriteTagged(req); err != nil {
+func (p *NodeClient) WriteTaggedBatchRawV2(req *WriteTaggedBatchRawV2Request) (err error) {
+	if err = p.sendWriteTaggedBatchRawV2(req); err != nil {
 		return
 	}
-	return p.recvWriteTagged()
+	return p.recvWriteTaggedBatchRawV2()
 }
 
-func (p *NodeClient) sendWriteTagged(req *WriteTaggedRequest) (err error) {
+func (p *NodeClient) sendWriteTaggedBatchRawV2(req *WriteTaggedBatchRawV2Request) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("writeTagged", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("writeTaggedBatchRawV2", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeWriteTaggedArgs{
+	args := NodeWriteTaggedBatchRawV2Args{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -15798,7 +17751,7 @@ func (p *NodeClient) sendWriteTagged(req *WriteTaggedRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvWriteTagged() (err error) {
+func (p *NodeClient) recvWriteTaggedBatchRawV2() (err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -15808,32 +17761,32 @@ func (p *NodeClient) recvWriteTagged() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "writeTagged" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeTagged failed: wrong method name")
+	if method != "writeTaggedBatchRawV2" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeTaggedBatchRawV2 failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTagged failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTaggedBatchRawV2 failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error43 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error44 error
-		error44, err = error43.Read(iprot)
+		error63 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error64 error
+		error64, err = error63.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error44
+		err = error64
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTagged failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTaggedBatchRawV2 failed: invalid message type")
 		return
 	}
-	result := NodeWriteTaggedResult{}
+	result := NodeWriteTaggedBatchRawV2Result{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -15847,28 +17800,24 @@ func (p *NodeClient) recvWriteTagged() (err error) {
 	return
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) AggregateRaw(req *AggregateQueryRawRequest) (r *AggregateQueryRawResult_, err error) {
-	if err = p.sendAggregateRaw(req); err != nil {
+func (p *NodeClient) Repair() (err error) {
+	if err = p.sendRepair(); err != nil {
 		return
 	}
-	return p.recvAggregateRaw()
+	return p.recvRepair()
 }
 
-func (p *NodeClient) sendAggregateRaw(req *AggregateQueryRawRequest) (err error) {
+func (p *NodeClient) sendRepair() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("aggregateRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("repair", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeAggregateRawArgs{
-		Req: req,
-	}
+	args := NodeRepairArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -15878,7 +17827,7 @@ func (p *NodeClient) sendAggregateRaw(req *AggregateQueryRawRequest) (err error)
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvAggregateRaw() (value *AggregateQueryRawResult_, err error) {
+func (p *NodeClient) recvRepair() (err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -15888,32 +17837,32 @@ func (p *NodeClient) recvAggregateRaw() (value *AggregateQueryRawResult_, err er
 	if err != nil {
 		return
 	}
-	if method != "aggregateRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "aggregateRaw failed: wrong method name")
+	if method != "repair" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "repair failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "aggregateRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "repair failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error45 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error46 error
-		error46, err = error45.Read(iprot)
+		error65 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error66 error
+		error66, err = error65.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error46
+		err = error66
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "aggregateRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "repair failed: invalid message type")
 		return
 	}
-	result := NodeAggregateRawResult{}
+	result := NodeRepairResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -15924,30 +17873,29 @@ func (p *NodeClient) recvAggregateRaw() (value *AggregateQueryRawResult_, err er
 		err = result.Err
 		return
 	}
-	value = result.GetSuccess()
 	return
 }
 
 // Parameters:
 //  - Req
-func (p *NodeClient) FetchBatchRaw(req *FetchBatchRawRequest) (r *FetchBatchRawResult_, err error) {
-	if err = p.sendFetchBatchRaw(req); err != nil {
+func (p *NodeClient) Truncate(req *TruncateRequest) (r *TruncateResult_, err error) {
+	if err = p.sendTruncate(req); err != nil {
 		return
 	}
-	return p.recvFetchBatchRaw()
+	return p.recvTruncate()
 }
 
-func (p *NodeClient) sendFetchBatchRaw(req *FetchBatchRawRequest) (err error) {
+func (p *NodeClient) sendTruncate(req *TruncateRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchBatchRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("truncate", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchBatchRawArgs{
+	args := NodeTruncateArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -15959,7 +17907,7 @@ func (p *NodeClient) sendFetchBatchRaw(req *FetchBatchRawRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetchBatchRaw() (value *FetchBatchRawResult_, err error) {
+func (p *NodeClient) recvTruncate() (value *TruncateResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -15969,32 +17917,32 @@ func (p *NodeClient) recvFetchBatchRaw() (value *FetchBatchRawResult_, err error
 	if err != nil {
 		return
 	}
-	if method != "fetchBatchRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBatchRaw failed: wrong method name")
+	if method != "truncate" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "truncate failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBatchRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "truncate failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error47 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error48 error
-		error48, err = error47.Read(iprot)
+		error67 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error68 error
+		error68, err = error67.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error48
+		err = error68
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBatchRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "truncate failed: invalid message type")
 		return
 	}
-	result := NodeFetchBatchRawResult{}
+	result := NodeTruncateResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16011,24 +17959,24 @@ func (p *NodeClient) recvFetchBatchRaw() (value *FetchBatchRawResult_, err error
 
 // Parameters:
 //  - Req
-func (p *NodeClient) FetchBatchRawV2(req *FetchBatchRawV2Request) (r *FetchBatchRawResult_, err error) {
-	if err = p.sendFetchBatchRawV2(req); err != nil {
+func (p *NodeClient) DeleteSeries(req *DeleteSeriesRequest) (r *DeleteSeriesResult_, err error) {
+	if err = p.sendDeleteSeries(req); err != nil {
 		return
 	}
-	return p.recvFetchBatchRawV2()
+	return p.recvDeleteSeries()
 }
 
-func (p *NodeClient) sendFetchBatchRawV2(req *FetchBatchRawV2Request) (err error) {
+func (p *NodeClient) sendDeleteSeries(req *DeleteSeriesRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchBatchRawV2", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("deleteSeries", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchBatchRawV2Args{
+	args := NodeDeleteSeriesArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -16040,7 +17988,7 @@ func (p *NodeClient) sendFetchBatchRawV2(req *FetchBatchRawV2Request) (err error
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetchBatchRawV2() (value *FetchBatchRawResult_, err error) {
+func (p *NodeClient) recvDeleteSeries() (value *DeleteSeriesResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16050,32 +17998,32 @@ func (p *NodeClient) recvFetchBatchRawV2() (value *FetchBatchRawResult_, err err
 	if err != nil {
 		return
 	}
-	if method != "fetchBatchRawV2" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBatchRawV2 failed: wrong method name")
+	if method != "deleteSeries" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "deleteSeries failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBatchRawV2 failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "deleteSeries failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error49 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error50 error
-		error50, err = error49.Read(iprot)
+		error67 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error68 error
+		error68, err = error67.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error50
+		err = error68
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBatchRawV2 failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "deleteSeries failed: invalid message type")
 		return
 	}
-	result := NodeFetchBatchRawV2Result{}
+	result := NodeDeleteSeriesResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16092,24 +18040,24 @@ func (p *NodeClient) recvFetchBatchRawV2() (value *FetchBatchRawResult_, err err
 
 // Parameters:
 //  - Req
-func (p *NodeClient) FetchBlocksRaw(req *FetchBlocksRawRequest) (r *FetchBlocksRawResult_, err error) {
-	if err = p.sendFetchBlocksRaw(req); err != nil {
+func (p *NodeClient) Backup(req *BackupRequest) (r *BackupResult_, err error) {
+	if err = p.sendBackup(req); err != nil {
 		return
 	}
-	return p.recvFetchBlocksRaw()
+	return p.recvBackup()
 }
 
-func (p *NodeClient) sendFetchBlocksRaw(req *FetchBlocksRawRequest) (err error) {
+func (p *NodeClient) sendBackup(req *BackupRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchBlocksRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("backup", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchBlocksRawArgs{
+	args := NodeBackupArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -16121,7 +18069,7 @@ func (p *NodeClient) sendFetchBlocksRaw(req *FetchBlocksRawRequest) (err error)
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetchBlocksRaw() (value *FetchBlocksRawResult_, err error) {
+func (p *NodeClient) recvBackup() (value *BackupResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16131,32 +18079,32 @@ func (p *NodeClient) recvFetchBlocksRaw() (value *FetchBlocksRawResult_, err err
 	if err != nil {
 		return
 	}
-	if method != "fetchBlocksRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksRaw failed: wrong method name")
+	if method != "backup" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "backup failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "backup failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error51 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error52 error
-		error52, err = error51.Read(iprot)
+		error67 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error68 error
+		error68, err = error67.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error52
+		err = error68
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "backup failed: invalid message type")
 		return
 	}
-	result := NodeFetchBlocksRawResult{}
+	result := NodeBackupResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16173,24 +18121,24 @@ func (p *NodeClient) recvFetchBlocksRaw() (value *FetchBlocksRawResult_, err err
 
 // Parameters:
 //  - Req
-func (p *NodeClient) FetchTagged(req *FetchTaggedRequest) (r *FetchTaggedResult_, err error) {
-	if err = p.sendFetchTagged(req); err != nil {
+func (p *NodeClient) Restore(req *RestoreRequest) (r *RestoreResult_, err error) {
+	if err = p.sendRestore(req); err != nil {
 		return
 	}
-	return p.recvFetchTagged()
+	return p.recvRestore()
 }
 
-func (p *NodeClient) sendFetchTagged(req *FetchTaggedRequest) (err error) {
+func (p *NodeClient) sendRestore(req *RestoreRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchTagged", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("restore", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchTaggedArgs{
+	args := NodeRestoreArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -16202,7 +18150,7 @@ func (p *NodeClient) sendFetchTagged(req *FetchTaggedRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetchTagged() (value *FetchTaggedResult_, err error) {
+func (p *NodeClient) recvRestore() (value *RestoreResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16212,32 +18160,32 @@ func (p *NodeClient) recvFetchTagged() (value *FetchTaggedResult_, err error) {
 	if err != nil {
 		return
 	}
-	if method != "fetchTagged" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchTagged failed: wrong method name")
+	if method != "restore" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "restore failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchTagged failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "restore failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error53 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error54 error
-		error54, err = error53.Read(iprot)
+		error67 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error68 error
+		error68, err = error67.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error54
+		err = error68
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchTagged failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "restore failed: invalid message type")
 		return
 	}
-	result := NodeFetchTaggedResult{}
+	result := NodeRestoreResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16254,24 +18202,24 @@ func (p *NodeClient) recvFetchTagged() (value *FetchTaggedResult_, err error) {
 
 // Parameters:
 //  - Req
-func (p *NodeClient) FetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (r *FetchBlocksMetadataRawV2Result_, err error) {
-	if err = p.sendFetchBlocksMetadataRawV2(req); err != nil {
+func (p *NodeClient) AggregateTiles(req *AggregateTilesRequest) (r *AggregateTilesResult_, err error) {
+	if err = p.sendAggregateTiles(req); err != nil {
 		return
 	}
-	return p.recvFetchBlocksMetadataRawV2()
+	return p.recvAggregateTiles()
 }
 
-func (p *NodeClient) sendFetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2Request) (err error) {
+func (p *NodeClient) sendAggregateTiles(req *AggregateTilesRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("aggregateTiles", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeFetchBlocksMetadataRawV2Args{
+	args := NodeAggregateTilesArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -16283,7 +18231,7 @@ func (p *NodeClient) sendFetchBlocksMetadataRawV2(req *FetchBlocksMetadataRawV2R
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvFetchBlocksMetadataRawV2() (value *FetchBlocksMetadataRawV2Result_, err error) {
+func (p *NodeClient) recvAggregateTiles() (value *AggregateTilesResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16293,32 +18241,32 @@ func (p *NodeClient) recvFetchBlocksMetadataRawV2() (value *FetchBlocksMetadataR
 	if err != nil {
 		return
 	}
-	if method != "fetchBlocksMetadataRawV2" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "fetchBlocksMetadataRawV2 failed: wrong method name")
+	if method != "aggregateTiles" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "aggregateTiles failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "fetchBlocksMetadataRawV2 failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "aggregateTiles failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error55 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error56 error
-		error56, err = error55.Read(iprot)
+		error69 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error70 error
+		error70, err = error69.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error56
+		err = error70
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "fetchBlocksMetadataRawV2 failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "aggregateTiles failed: invalid message type")
 		return
 	}
-	result := NodeFetchBlocksMetadataRawV2Result{}
+	result := NodeAggregateTilesResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16333,28 +18281,24 @@ func (p *NodeClient) recvFetchBlocksMetadataRawV2() (value *FetchBlocksMetadataR
 	return
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) WriteBatchRaw(req *WriteBatchRawRequest) (err error) {
-	if err = p.sendWriteBatchRaw(req); err != nil {
+func (p *NodeClient) Health() (r *NodeHealthResult_, err error) {
+	if err = p.sendHealth(); err != nil {
 		return
 	}
-	return p.recvWriteBatchRaw()
+	return p.recvHealth()
 }
 
-func (p *NodeClient) sendWriteBatchRaw(req *WriteBatchRawRequest) (err error) {
+func (p *NodeClient) sendHealth() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("writeBatchRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("health", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeWriteBatchRawArgs{
-		Req: req,
-	}
+	args := NodeHealthArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -16364,7 +18308,7 @@ func (p *NodeClient) sendWriteBatchRaw(req *WriteBatchRawRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvWriteBatchRaw() (err error) {
+func (p *NodeClient) recvHealth() (value *NodeHealthResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16374,32 +18318,32 @@ func (p *NodeClient) recvWriteBatchRaw() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "writeBatchRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeBatchRaw failed: wrong method name")
+	if method != "health" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "health failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeBatchRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "health failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error57 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error58 error
-		error58, err = error57.Read(iprot)
+		error71 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error72 error
+		error72, err = error71.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error58
+		err = error72
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeBatchRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "health failed: invalid message type")
 		return
 	}
-	result := NodeWriteBatchRawResult{}
+	result := NodeHealthResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16410,31 +18354,28 @@ func (p *NodeClient) recvWriteBatchRaw() (err error) {
 		err = result.Err
 		return
 	}
+	value = result.GetSuccess()
 	return
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) WriteBatchRawV2(req *WriteBatchRawV2Request) (err error) {
-	if err = p.sendWriteBatchRawV2(req); err != nil {
+func (p *NodeClient) Bootstrapped() (r *NodeBootstrappedResult_, err error) {
+	if err = p.sendBootstrapped(); err != nil {
 		return
 	}
-	return p.recvWriteBatchRawV2()
+	return p.recvBootstrapped()
 }
 
-func (p *NodeClient) sendWriteBatchRawV2(req *WriteBatchRawV2Request) (err error) {
+func (p *NodeClient) sendBootstrapped() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("writeBatchRawV2", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("bootstrapped", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeWriteBatchRawV2Args{
-		Req: req,
-	}
+	args := NodeBootstrappedArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -16444,7 +18385,7 @@ func (p *NodeClient) sendWriteBatchRawV2(req *WriteBatchRawV2Request) (err error
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvWriteBatchRawV2() (err error) {
+func (p *NodeClient) recvBootstrapped() (value *NodeBootstrappedResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16454,32 +18395,32 @@ func (p *NodeClient) recvWriteBatchRawV2() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "writeBatchRawV2" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeBatchRawV2 failed: wrong method name")
+	if method != "bootstrapped" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "bootstrapped failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeBatchRawV2 failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "bootstrapped failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error59 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error60 error
-		error60, err = error59.Read(iprot)
+		error73 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error74 error
+		error74, err = error73.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error60
+		err = error74
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeBatchRawV2 failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "bootstrapped failed: invalid message type")
 		return
 	}
-	result := NodeWriteBatchRawV2Result{}
+	result := NodeBootstrappedResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16490,31 +18431,28 @@ func (p *NodeClient) recvWriteBatchRawV2() (err error) {
 		err = result.Err
 		return
 	}
+	value = result.GetSuccess()
 	return
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) WriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error) {
-	if err = p.sendWriteTaggedBatchRaw(req); err != nil {
+func (p *NodeClient) BootstrappedInPlacementOrNoPlacement() (r *NodeBootstrappedInPlacementOrNoPlacementResult_, err error) {
+	if err = p.sendBootstrappedInPlacementOrNoPlacement(); err != nil {
 		return
 	}
-	return p.recvWriteTaggedBatchRaw()
+	return p.recvBootstrappedInPlacementOrNoPlacement()
 }
 
-func (p *NodeClient) sendWriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (err error) {
+func (p *NodeClient) sendBootstrappedInPlacementOrNoPlacement() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("bootstrappedInPlacementOrNoPlacement", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeWriteTaggedBatchRawArgs{
-		Req: req,
-	}
+	args := NodeBootstrappedInPlacementOrNoPlacementArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -16524,7 +18462,7 @@ func (p *NodeClient) sendWriteTaggedBatchRaw(req *WriteTaggedBatchRawRequest) (e
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvWriteTaggedBatchRaw() (err error) {
+func (p *NodeClient) recvBootstrappedInPlacementOrNoPlacement() (value *NodeBootstrappedInPlacementOrNoPlacementResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16534,32 +18472,32 @@ func (p *NodeClient) recvWriteTaggedBatchRaw() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "writeTaggedBatchRaw" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeTaggedBatchRaw failed: wrong method name")
+	if method != "bootstrappedInPlacementOrNoPlacement" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "bootstrappedInPlacementOrNoPlacement failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTaggedBatchRaw failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "bootstrappedInPlacementOrNoPlacement failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error61 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error62 error
-		error62, err = error61.Read(iprot)
+		error75 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error76 error
+		error76, err = error75.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error62
+		err = error76
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTaggedBatchRaw failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "bootstrappedInPlacementOrNoPlacement failed: invalid message type")
 		return
 	}
-	result := NodeWriteTaggedBatchRawResult{}
+	result := NodeBootstrappedInPlacementOrNoPlacementResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16570,31 +18508,28 @@ func (p *NodeClient) recvWriteTaggedBatchRaw() (err error) {
 		err = result.Err
 		return
 	}
+	value = result.GetSuccess()
 	return
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) WriteTaggedBatchRawV2(req *WriteTaggedBatchRawV2Request) (err error) {
-	if err = p.sendWriteTaggedBatchRawV2(req); err != nil {
+func (p *NodeClient) GetPersistRateLimit() (r *NodePersistRateLimitResult_, err error) {
+	if err = p.sendGetPersistRateLimit(); err != nil {
 		return
 	}
-	return p.recvWriteTaggedBatchRawV2()
+	return p.recvGetPersistRateLimit()
 }
 
-func (p *NodeClient) sendWriteTaggedBatchRawV2(req *WriteTaggedBatchRawV2Request) (err error) {
+func (p *NodeClient) sendGetPersistRateLimit() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("writeTaggedBatchRawV2", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("getPersistRateLimit", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeWriteTaggedBatchRawV2Args{
-		Req: req,
-	}
+	args := NodeGetPersistRateLimitArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -16604,7 +18539,7 @@ func (p *NodeClient) sendWriteTaggedBatchRawV2(req *WriteTaggedBatchRawV2Request
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvWriteTaggedBatchRawV2() (err error) {
+func (p *NodeClient) recvGetPersistRateLimit() (value *NodePersistRateLimitResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16614,32 +18549,32 @@ func (p *NodeClient) recvWriteTaggedBatchRawV2() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "writeTaggedBatchRawV2" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "writeTaggedBatchRawV2 failed: wrong method name")
+	if method != "getPersistRateLimit" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getPersistRateLimit failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "writeTaggedBatchRawV2 failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getPersistRateLimit failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error63 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error64 error
-		error64, err = error63.Read(iprot)
+		error77 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error78 error
+		error78, err = error77.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error64
+		err = error78
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "writeTaggedBatchRawV2 failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getPersistRateLimit failed: invalid message type")
 		return
 	}
-	result := NodeWriteTaggedBatchRawV2Result{}
+	result := NodeGetPersistRateLimitResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16650,27 +18585,32 @@ func (p *NodeClient) recvWriteTaggedBatchRawV2() (err error) {
 		err = result.Err
 		return
 	}
+	value = result.GetSuccess()
 	return
 }
 
-func (p *NodeClient) Repair() (err error) {
-	if err = p.sendRepair(); err != nil {
+// Parameters:
+//  - Req
+func (p *NodeClient) SetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (r *NodePersistRateLimitResult_, err error) {
+	if err = p.sendSetPersistRateLimit(req); err != nil {
 		return
 	}
-	return p.recvRepair()
+	return p.recvSetPersistRateLimit()
 }
 
-func (p *NodeClient) sendRepair() (err error) {
+func (p *NodeClient) sendSetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("repair", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("setPersistRateLimit", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeRepairArgs{}
+	args := NodeSetPersistRateLimitArgs{
+		Req: req,
+	}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -16680,7 +18620,7 @@ func (p *NodeClient) sendRepair() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvRepair() (err error) {
+func (p *NodeClient) recvSetPersistRateLimit() (value *NodePersistRateLimitResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16690,32 +18630,32 @@ func (p *NodeClient) recvRepair() (err error) {
 	if err != nil {
 		return
 	}
-	if method != "repair" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "repair failed: wrong method name")
+	if method != "setPersistRateLimit" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setPersistRateLimit failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "repair failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setPersistRateLimit failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error65 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error66 error
-		error66, err = error65.Read(iprot)
+		error79 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error80 error
+		error80, err = error79.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error66
+		err = error80
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "repair failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setPersistRateLimit failed: invalid message type")
 		return
 	}
-	result := NodeRepairResult{}
+	result := NodeSetPersistRateLimitResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16726,31 +18666,28 @@ func (p *NodeClient) recvRepair() (err error) {
 		err = result.Err
 		return
 	}
+	value = result.GetSuccess()
 	return
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) Truncate(req *TruncateRequest) (r *TruncateResult_, err error) {
-	if err = p.sendTruncate(req); err != nil {
+func (p *NodeClient) GetWriteNewSeriesAsync() (r *NodeWriteNewSeriesAsyncResult_, err error) {
+	if err = p.sendGetWriteNewSeriesAsync(); err != nil {
 		return
 	}
-	return p.recvTruncate()
+	return p.recvGetWriteNewSeriesAsync()
 }
 
-func (p *NodeClient) sendTruncate(req *TruncateRequest) (err error) {
+func (p *NodeClient) sendGetWriteNewSeriesAsync() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("truncate", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeTruncateArgs{
-		Req: req,
-	}
+	args := NodeGetWriteNewSeriesAsyncArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -16760,7 +18697,7 @@ func (p *NodeClient) sendTruncate(req *TruncateRequest) (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvTruncate() (value *TruncateResult_, err error) {
+func (p *NodeClient) recvGetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyncResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16770,32 +18707,32 @@ func (p *NodeClient) recvTruncate() (value *TruncateResult_, err error) {
 	if err != nil {
 		return
 	}
-	if method != "truncate" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "truncate failed: wrong method name")
+	if method != "getWriteNewSeriesAsync" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesAsync failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "truncate failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesAsync failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error67 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error68 error
-		error68, err = error67.Read(iprot)
+		error81 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error82 error
+		error82, err = error81.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error68
+		err = error82
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "truncate failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesAsync failed: invalid message type")
 		return
 	}
-	result := NodeTruncateResult{}
+	result := NodeGetWriteNewSeriesAsyncResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16812,24 +18749,24 @@ func (p *NodeClient) recvTruncate() (value *TruncateResult_, err error) {
 
 // Parameters:
 //  - Req
-func (p *NodeClient) AggregateTiles(req *AggregateTilesRequest) (r *AggregateTilesResult_, err error) {
-	if err = p.sendAggregateTiles(req); err != nil {
+func (p *NodeClient) SetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (r *NodeWriteNewSeriesAsyncResult_, err error) {
+	if err = p.sendSetWriteNewSeriesAsync(req); err != nil {
 		return
 	}
-	return p.recvAggregateTiles()
+	return p.recvSetWriteNewSeriesAsync()
 }
 
-func (p *NodeClient) sendAggregateTiles(req *AggregateTilesRequest) (err error) {
+func (p *NodeClient) sendSetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("aggregateTiles", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeAggregateTilesArgs{
+	args := NodeSetWriteNewSeriesAsyncArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -16841,7 +18778,7 @@ func (p *NodeClient) sendAggregateTiles(req *AggregateTilesRequest) (err error)
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvAggregateTiles() (value *AggregateTilesResult_, err error) {
+func (p *NodeClient) recvSetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyncResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16851,32 +18788,32 @@ func (p *NodeClient) recvAggregateTiles() (value *AggregateTilesResult_, err err
 	if err != nil {
 		return
 	}
-	if method != "aggregateTiles" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "aggregateTiles failed: wrong method name")
+	if method != "setWriteNewSeriesAsync" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesAsync failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "aggregateTiles failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesAsync failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error69 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error70 error
-		error70, err = error69.Read(iprot)
+		error83 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error84 error
+		error84, err = error83.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error70
+		err = error84
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "aggregateTiles failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesAsync failed: invalid message type")
 		return
 	}
-	result := NodeAggregateTilesResult{}
+	result := NodeSetWriteNewSeriesAsyncResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16891,24 +18828,24 @@ func (p *NodeClient) recvAggregateTiles() (value *AggregateTilesResult_, err err
 	return
 }
 
-func (p *NodeClient) Health() (r *NodeHealthResult_, err error) {
-	if err = p.sendHealth(); err != nil {
+func (p *NodeClient) GetWriteNewSeriesBackoffDuration() (r *NodeWriteNewSeriesBackoffDurationResult_, err error) {
+	if err = p.sendGetWriteNewSeriesBackoffDuration(); err != nil {
 		return
 	}
-	return p.recvHealth()
+	return p.recvGetWriteNewSeriesBackoffDuration()
 }
 
-func (p *NodeClient) sendHealth() (err error) {
+func (p *NodeClient) sendGetWriteNewSeriesBackoffDuration() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("health", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeHealthArgs{}
+	args := NodeGetWriteNewSeriesBackoffDurationArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -16918,7 +18855,7 @@ func (p *NodeClient) sendHealth() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvHealth() (value *NodeHealthResult_, err error) {
+func (p *NodeClient) recvGetWriteNewSeriesBackoffDuration() (value *NodeWriteNewSeriesBackoffDurationResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -16928,32 +18865,32 @@ func (p *NodeClient) recvHealth() (value *NodeHealthResult_, err error) {
 	if err != nil {
 		return
 	}
-	if method != "health" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "health failed: wrong method name")
+	if method != "getWriteNewSeriesBackoffDuration" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesBackoffDuration failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "health failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesBackoffDuration failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error71 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error72 error
-		error72, err = error71.Read(iprot)
+		error85 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error86 error
+		error86, err = error85.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error72
+		err = error86
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "health failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesBackoffDuration failed: invalid message type")
 		return
 	}
-	result := NodeHealthResult{}
+	result := NodeGetWriteNewSeriesBackoffDurationResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -16968,24 +18905,28 @@ func (p *NodeClient) recvHealth() (value *NodeHealthResult_, err error) {
 	return
 }
 
-func (p *NodeClient) Bootstrapped() (r *NodeBootstrappedResult_, err error) {
-	if err = p.sendBootstrapped(); err != nil {
+// Parameters:
+//  - Req
+func (p *NodeClient) SetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (r *NodeWriteNewSeriesBackoffDurationResult_, err error) {
+	if err = p.sendSetWriteNewSeriesBackoffDuration(req); err != nil {
 		return
 	}
-	return p.recvBootstrapped()
+	return p.recvSetWriteNewSeriesBackoffDuration()
 }
 
-func (p *NodeClient) sendBootstrapped() (err error) {
+func (p *NodeClient) sendSetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("bootstrapped", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeBootstrappedArgs{}
+	args := NodeSetWriteNewSeriesBackoffDurationArgs{
+		Req: req,
+	}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -16995,7 +18936,7 @@ func (p *NodeClient) sendBootstrapped() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvBootstrapped() (value *NodeBootstrappedResult_, err error) {
+func (p *NodeClient) recvSetWriteNewSeriesBackoffDuration() (value *NodeWriteNewSeriesBackoffDurationResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -17005,32 +18946,32 @@ func (p *NodeClient) recvBootstrapped() (value *NodeBootstrappedResult_, err err
 	if err != nil {
 		return
 	}
-	if method != "bootstrapped" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "bootstrapped failed: wrong method name")
+	if method != "setWriteNewSeriesBackoffDuration" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesBackoffDuration failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "bootstrapped failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesBackoffDuration failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error73 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error74 error
-		error74, err = error73.Read(iprot)
+		error87 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error88 error
+		error88, err = error87.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error74
+		err = error88
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "bootstrapped failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesBackoffDuration failed: invalid message type")
 		return
 	}
-	result := NodeBootstrappedResult{}
+	result := NodeSetWriteNewSeriesBackoffDurationResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -17045,24 +18986,24 @@ func (p *NodeClient) recvBootstrapped() (value *NodeBootstrappedResult_, err err
 	return
 }
 
-func (p *NodeClient) BootstrappedInPlacementOrNoPlacement() (r *NodeBootstrappedInPlacementOrNoPlacementResult_, err error) {
-	if err = p.sendBootstrappedInPlacementOrNoPlacement(); err != nil {
+func (p *NodeClient) GetWriteNewSeriesLimitPerShardPerSecond() (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
+	if err = p.sendGetWriteNewSeriesLimitPerShardPerSecond(); err != nil {
 		return
 	}
-	return p.recvBootstrappedInPlacementOrNoPlacement()
+	return p.recvGetWriteNewSeriesLimitPerShardPerSecond()
 }
 
-func (p *NodeClient) sendBootstrappedInPlacementOrNoPlacement() (err error) {
+func (p *NodeClient) sendGetWriteNewSeriesLimitPerShardPerSecond() (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("bootstrappedInPlacementOrNoPlacement", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeBootstrappedInPlacementOrNoPlacementArgs{}
+	args := NodeGetWriteNewSeriesLimitPerShardPerSecondArgs{}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -17072,7 +19013,7 @@ func (p *NodeClient) sendBootstrappedInPlacementOrNoPlacement() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvBootstrappedInPlacementOrNoPlacement() (value *NodeBootstrappedInPlacementOrNoPlacementResult_, err error) {
+func (p *NodeClient) recvGetWriteNewSeriesLimitPerShardPerSecond() (value *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -17082,32 +19023,32 @@ func (p *NodeClient) recvBootstrappedInPlacementOrNoPlacement() (value *NodeBoot
 	if err != nil {
 		return
 	}
-	if method != "bootstrappedInPlacementOrNoPlacement" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "bootstrappedInPlacementOrNoPlacement failed: wrong method name")
+	if method != "getWriteNewSeriesLimitPerShardPerSecond" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesLimitPerShardPerSecond failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "bootstrappedInPlacementOrNoPlacement failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesLimitPerShardPerSecond failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error75 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error76 error
-		error76, err = error75.Read(iprot)
+		error89 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error90 error
+		error90, err = error89.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error76
+		err = error90
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "bootstrappedInPlacementOrNoPlacement failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesLimitPerShardPerSecond failed: invalid message type")
 		return
 	}
-	result := NodeBootstrappedInPlacementOrNoPlacementResult{}
+	result := NodeGetWriteNewSeriesLimitPerShardPerSecondResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -17122,24 +19063,28 @@ func (p *NodeClient) recvBootstrappedInPlacementOrNoPlacement() (value *NodeBoot
 	return
 }
 
-func (p *NodeClient) GetPersistRateLimit() (r *NodePersistRateLimitResult_, err error) {
-	if err = p.sendGetPersistRateLimit(); err != nil {
+// Parameters:
+//  - Req
+func (p *NodeClient) SetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
+	if err = p.sendSetWriteNewSeriesLimitPerShardPerSecond(req); err != nil {
 		return
 	}
-	return p.recvGetPersistRateLimit()
+	return p.recvSetWriteNewSeriesLimitPerShardPerSecond()
 }
 
-func (p *NodeClient) sendGetPersistRateLimit() (err error) {
+func (p *NodeClient) sendSetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("getPersistRateLimit", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeGetPersistRateLimitArgs{}
+	args := NodeSetWriteNewSeriesLimitPerShardPerSecondArgs{
+		Req: req,
+	}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -17149,7 +19094,7 @@ func (p *NodeClient) sendGetPersistRateLimit() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvGetPersistRateLimit() (value *NodePersistRateLimitResult_, err error) {
+func (p *NodeClient) recvSetWriteNewSeriesLimitPerShardPerSecond() (value *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -17159,32 +19104,32 @@ func (p *NodeClient) recvGetPersistRateLimit() (value *NodePersistRateLimitResul
 	if err != nil {
 		return
 	}
-	if method != "getPersistRateLimit" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getPersistRateLimit failed: wrong method name")
+	if method != "setWriteNewSeriesLimitPerShardPerSecond" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesLimitPerShardPerSecond failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getPersistRateLimit failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesLimitPerShardPerSecond failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error77 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error78 error
-		error78, err = error77.Read(iprot)
+		error91 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error92 error
+		error92, err = error91.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error78
+		err = error92
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getPersistRateLimit failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesLimitPerShardPerSecond failed: invalid message type")
 		return
 	}
-	result := NodeGetPersistRateLimitResult{}
+	result := NodeSetWriteNewSeriesLimitPerShardPerSecondResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -17201,24 +19146,24 @@ func (p *NodeClient) recvGetPersistRateLimit() (value *NodePersistRateLimitResul
 
 // Parameters:
 //  - Req
-func (p *NodeClient) SetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (r *NodePersistRateLimitResult_, err error) {
-	if err = p.sendSetPersistRateLimit(req); err != nil {
+func (p *NodeClient) DebugProfileStart(req *DebugProfileStartRequest) (r *DebugProfileStartResult_, err error) {
+	if err = p.sendDebugProfileStart(req); err != nil {
 		return
 	}
-	return p.recvSetPersistRateLimit()
+	return p.recvDebugProfileStart()
 }
 
-func (p *NodeClient) sendSetPersistRateLimit(req *NodeSetPersistRateLimitRequest) (err error) {
+func (p *NodeClient) sendDebugProfileStart(req *DebugProfileStartRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("setPersistRateLimit", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("debugProfileStart", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeSetPersistRateLimitArgs{
+	args := NodeDebugProfileStartArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -17230,7 +19175,7 @@ func (p *NodeClient) sendSetPersistRateLimit(req *NodeSetPersistRateLimitRequest
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvSetPersistRateLimit() (value *NodePersistRateLimitResult_, err error) {
+func (p *NodeClient) recvDebugProfileStart() (value *DebugProfileStartResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -17240,32 +19185,32 @@ func (p *NodeClient) recvSetPersistRateLimit() (value *NodePersistRateLimitResul
 	if err != nil {
 		return
 	}
-	if method != "setPersistRateLimit" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setPersistRateLimit failed: wrong method name")
+	if method != "debugProfileStart" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "debugProfileStart failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setPersistRateLimit failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "debugProfileStart failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error79 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error80 error
-		error80, err = error79.Read(iprot)
+		error93 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error94 error
+		error94, err = error93.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error80
+		err = error94
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setPersistRateLimit failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "debugProfileStart failed: invalid message type")
 		return
 	}
-	result := NodeSetPersistRateLimitResult{}
+	result := NodeDebugProfileStartResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -17280,24 +19225,28 @@ func (p *NodeClient) recvSetPersistRateLimit() (value *NodePersistRateLimitResul
 	return
 }
 
-func (p *NodeClient) GetWriteNewSeriesAsync() (r *NodeWriteNewSeriesAsyncResult_, err error) {
-	if err = p.sendGetWriteNewSeriesAsync(); err != nil {
+// Parameters:
+//  - Req
+func (p *NodeClient) DebugProfileStop(req *DebugProfileStopRequest) (r *DebugProfileStopResult_, err error) {
+	if err = p.sendDebugProfileStop(req); err != nil {
 		return
 	}
-	return p.recvGetWriteNewSeriesAsync()
+	return p.recvDebugProfileStop()
 }
 
-func (p *NodeClient) sendGetWriteNewSeriesAsync() (err error) {
+func (p *NodeClient) sendDebugProfileStop(req *DebugProfileStopRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("debugProfileStop", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeGetWriteNewSeriesAsyncArgs{}
+	args := NodeDebugProfileStopArgs{
+		Req: req,
+	}
 	if err = args.Write(oprot); err != nil {
 		return
 	}
@@ -17307,7 +19256,7 @@ func (p *NodeClient) sendGetWriteNewSeriesAsync() (err error) {
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvGetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyncResult_, err error) {
+func (p *NodeClient) recvDebugProfileStop() (value *DebugProfileStopResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -17317,32 +19266,32 @@ func (p *NodeClient) recvGetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyn
 	if err != nil {
 		return
 	}
-	if method != "getWriteNewSeriesAsync" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesAsync failed: wrong method name")
+	if method != "debugProfileStop" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "debugProfileStop failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesAsync failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "debugProfileStop failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error81 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error82 error
-		error82, err = error81.Read(iprot)
+		error95 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error96 error
+		error96, err = error95.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error82
+		err = error96
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesAsync failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "debugProfileStop failed: invalid message type")
 		return
 	}
-	result := NodeGetWriteNewSeriesAsyncResult{}
+	result := NodeDebugProfileStopResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -17359,24 +19308,24 @@ func (p *NodeClient) recvGetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyn
 
 // Parameters:
 //  - Req
-func (p *NodeClient) SetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (r *NodeWriteNewSeriesAsyncResult_, err error) {
-	if err = p.sendSetWriteNewSeriesAsync(req); err != nil {
+func (p *NodeClient) DebugIndexMemorySegments(req *DebugIndexMemorySegmentsRequest) (r *DebugIndexMemorySegmentsResult_, err error) {
+	if err = p.sendDebugIndexMemorySegments(req); err != nil {
 		return
 	}
-	return p.recvSetWriteNewSeriesAsync()
+	return p.recvDebugIndexMemorySegments()
 }
 
-func (p *NodeClient) sendSetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncRequest) (err error) {
+func (p *NodeClient) sendDebugIndexMemorySegments(req *DebugIndexMemorySegmentsRequest) (err error) {
 	oprot := p.OutputProtocol
 	if oprot == nil {
 		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
 		p.OutputProtocol = oprot
 	}
 	p.SeqId++
-	if err = oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.CALL, p.SeqId); err != nil {
+	if err = oprot.WriteMessageBegin("debugIndexMemorySegments", thrift.CALL, p.SeqId); err != nil {
 		return
 	}
-	args := NodeSetWriteNewSeriesAsyncArgs{
+	args := NodeDebugIndexMemorySegmentsArgs{
 		Req: req,
 	}
 	if err = args.Write(oprot); err != nil {
@@ -17388,7 +19337,7 @@ func (p *NodeClient) sendSetWriteNewSeriesAsync(req *NodeSetWriteNewSeriesAsyncR
 	return oprot.Flush()
 }
 
-func (p *NodeClient) recvSetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyncResult_, err error) {
+func (p *NodeClient) recvDebugIndexMemorySegments() (value *DebugIndexMemorySegmentsResult_, err error) {
 	iprot := p.InputProtocol
 	if iprot == nil {
 		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
@@ -17398,32 +19347,32 @@ func (p *NodeClient) recvSetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyn
 	if err != nil {
 		return
 	}
-	if method != "setWriteNewSeriesAsync" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesAsync failed: wrong method name")
+	if method != "debugIndexMemorySegments" {
+		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "debugIndexMemorySegments failed: wrong method name")
 		return
 	}
 	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesAsync failed: out of sequence response")
+		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "debugIndexMemorySegments failed: out of sequence response")
 		return
 	}
 	if mTypeId == thrift.EXCEPTION {
-		error83 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error84 error
-		error84, err = error83.Read(iprot)
+		error97 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
+		var error98 error
+		error98, err = error97.Read(iprot)
 		if err != nil {
 			return
 		}
 		if err = iprot.ReadMessageEnd(); err != nil {
 			return
 		}
-		err = error84
+		err = error98
 		return
 	}
 	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesAsync failed: invalid message type")
+		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "debugIndexMemorySegments failed: invalid message type")
 		return
 	}
-	result := NodeSetWriteNewSeriesAsyncResult{}
+	result := NodeDebugIndexMemorySegmentsResult{}
 	if err = result.Read(iprot); err != nil {
 		return
 	}
@@ -17438,650 +19387,871 @@ func (p *NodeClient) recvSetWriteNewSeriesAsync() (value *NodeWriteNewSeriesAsyn
 	return
 }
 
-func (p *NodeClient) GetWriteNewSeriesBackoffDuration() (r *NodeWriteNewSeriesBackoffDurationResult_, err error) {
-	if err = p.sendGetWriteNewSeriesBackoffDuration(); err != nil {
-		return
+type NodeProcessor struct {
+	processorMap map[string]thrift.TProcessorFunction
+	handler      Node
+}
+
+func (p *NodeProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
+	p.processorMap[key] = processor
+}
+
+func (p *NodeProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
+	processor, ok = p.processorMap[key]
+	return processor, ok
+}
+
+func (p *NodeProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
+	return p.processorMap
+}
+
+func NewNodeProcessor(handler Node) *NodeProcessor {
+
+	self99 := &NodeProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
+	self99.processorMap["query"] = &nodeProcessorQuery{handler: handler}
+	self99.processorMap["aggregate"] = &nodeProcessorAggregate{handler: handler}
+	self99.processorMap["fetch"] = &nodeProcessorFetch{handler: handler}
+	self99.processorMap["write"] = &nodeProcessorWrite{handler: handler}
+	self99.processorMap["writeTagged"] = &nodeProcessorWriteTagged{handler: handler}
+	self99.processorMap["aggregateRaw"] = &nodeProcessorAggregateRaw{handler: handler}
+	self99.processorMap["fetchBatchRaw"] = &nodeProcessorFetchBatchRaw{handler: handler}
+	self99.processorMap["fetchBatchRawV2"] = &nodeProcessorFetchBatchRawV2{handler: handler}
+	self99.processorMap["fetchBlocksRaw"] = &nodeProcessorFetchBlocksRaw{handler: handler}
+	self99.processorMap["fetchTagged"] = &nodeProcessorFetchTagged{handler: handler}
+	self99.processorMap["fetchBlocksMetadataRawV2"] = &nodeProcessorFetchBlocksMetadataRawV2{handler: handler}
+	self99.processorMap["writeBatchRaw"] = &nodeProcessorWriteBatchRaw{handler: handler}
+	self99.processorMap["writeBatchRawV2"] = &nodeProcessorWriteBatchRawV2{handler: handler}
+	self99.processorMap["writeTaggedBatchRaw"] = &nodeProcessorWriteTaggedBatchRaw{handler: handler}
+	self99.processorMap["writeTaggedBatchRawV2"] = &nodeProcessorWriteTaggedBatchRawV2{handler: handler}
+	self99.processorMap["repair"] = &nodeProcessorRepair{handler: handler}
+	self99.processorMap["truncate"] = &nodeProcessorTruncate{handler: handler}
+	self99.processorMap["deleteSeries"] = &nodeProcessorDeleteSeries{handler: handler}
+	self99.processorMap["backup"] = &nodeProcessorBackup{handler: handler}
+	self99.processorMap["restore"] = &nodeProcessorRestore{handler: handler}
+	self99.processorMap["aggregateTiles"] = &nodeProcessorAggregateTiles{handler: handler}
+	self99.processorMap["health"] = &nodeProcessorHealth{handler: handler}
+	self99.processorMap["bootstrapped"] = &nodeProcessorBootstrapped{handler: handler}
+	self99.processorMap["bootstrappedInPlacementOrNoPlacement"] = &nodeProcessorBootstrappedInPlacementOrNoPlacement{handler: handler}
+	self99.processorMap["getPersistRateLimit"] = &nodeProcessorGetPersistRateLimit{handler: handler}
+	self99.processorMap["setPersistRateLimit"] = &nodeProcessorSetPersistRateLimit{handler: handler}
+	self99.processorMap["getWriteNewSeriesAsync"] = &nodeProcessorGetWriteNewSeriesAsync{handler: handler}
+	self99.processorMap["setWriteNewSeriesAsync"] = &nodeProcessorSetWriteNewSeriesAsync{handler: handler}
+	self99.processorMap["getWriteNewSeriesBackoffDuration"] = &nodeProcessorGetWriteNewSeriesBackoffDuration{handler: handler}
+	self99.processorMap["setWriteNewSeriesBackoffDuration"] = &nodeProcessorSetWriteNewSeriesBackoffDuration{handler: handler}
+	self99.processorMap["getWriteNewSeriesLimitPerShardPerSecond"] = &nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond{handler: handler}
+	self99.processorMap["setWriteNewSeriesLimitPerShardPerSecond"] = &nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond{handler: handler}
+	self99.processorMap["debugProfileStart"] = &nodeProcessorDebugProfileStart{handler: handler}
+	self99.processorMap["debugProfileStop"] = &nodeProcessorDebugProfileStop{handler: handler}
+	self99.processorMap["debugIndexMemorySegments"] = &nodeProcessorDebugIndexMemorySegments{handler: handler}
+	return self99
+}
+
+func (p *NodeProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	name, _, seqId, err := iprot.ReadMessageBegin()
+	if err != nil {
+		return false, err
 	}
-	return p.recvGetWriteNewSeriesBackoffDuration()
+	if processor, ok := p.GetProcessorFunction(name); ok {
+		return processor.Process(seqId, iprot, oprot)
+	}
+	iprot.Skip(thrift.STRUCT)
+	iprot.ReadMessageEnd()
+	x100 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
+	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
+	x100.Write(oprot)
+	oprot.WriteMessageEnd()
+	oprot.Flush()
+	return false, x100
+
 }
 
-func (p *NodeClient) sendGetWriteNewSeriesBackoffDuration() (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+type nodeProcessorQuery struct {
+	handler Node
+}
+
+func (p *nodeProcessorQuery) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeQueryArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("query", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.CALL, p.SeqId); err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeQueryResult{}
+	var retval *QueryResult_
+	var err2 error
+	if retval, err2 = p.handler.Query(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing query: "+err2.Error())
+			oprot.WriteMessageBegin("query", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
 	}
-	args := NodeGetWriteNewSeriesBackoffDurationArgs{}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err2 = oprot.WriteMessageBegin("query", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	return oprot.Flush()
+	return true, err
 }
 
-func (p *NodeClient) recvGetWriteNewSeriesBackoffDuration() (value *NodeWriteNewSeriesBackoffDurationResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+type nodeProcessorAggregate struct {
+	handler Node
+}
+
+func (p *nodeProcessorAggregate) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeAggregateArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("aggregate", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeAggregateResult{}
+	var retval *AggregateQueryResult_
+	var err2 error
+	if retval, err2 = p.handler.Aggregate(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing aggregate: "+err2.Error())
+			oprot.WriteMessageBegin("aggregate", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
 	}
-	if method != "getWriteNewSeriesBackoffDuration" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesBackoffDuration failed: wrong method name")
-		return
+	if err2 = oprot.WriteMessageBegin("aggregate", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesBackoffDuration failed: out of sequence response")
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error85 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error86 error
-		error86, err = error85.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+	return true, err
+}
+
+type nodeProcessorFetch struct {
+	handler Node
+}
+
+func (p *nodeProcessorFetch) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetch", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := NodeFetchResult{}
+	var retval *FetchResult_
+	var err2 error
+	if retval, err2 = p.handler.Fetch(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetch: "+err2.Error())
+			oprot.WriteMessageBegin("fetch", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error86
-		return
+	} else {
+		result.Success = retval
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesBackoffDuration failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("fetch", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeGetWriteNewSeriesBackoffDurationResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	value = result.GetSuccess()
-	return
+	return true, err
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) SetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (r *NodeWriteNewSeriesBackoffDurationResult_, err error) {
-	if err = p.sendSetWriteNewSeriesBackoffDuration(req); err != nil {
-		return
-	}
-	return p.recvSetWriteNewSeriesBackoffDuration()
+type nodeProcessorWrite struct {
+	handler Node
 }
 
-func (p *NodeClient) sendSetWriteNewSeriesBackoffDuration(req *NodeSetWriteNewSeriesBackoffDurationRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+func (p *nodeProcessorWrite) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("write", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.CALL, p.SeqId); err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeWriteResult{}
+	var err2 error
+	if err2 = p.handler.Write(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing write: "+err2.Error())
+			oprot.WriteMessageBegin("write", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
 	}
-	args := NodeSetWriteNewSeriesBackoffDurationArgs{
-		Req: req,
+	if err2 = oprot.WriteMessageBegin("write", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	return oprot.Flush()
-}
-
-func (p *NodeClient) recvSetWriteNewSeriesBackoffDuration() (value *NodeWriteNewSeriesBackoffDurationResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
 		return
 	}
-	if method != "setWriteNewSeriesBackoffDuration" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesBackoffDuration failed: wrong method name")
-		return
-	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesBackoffDuration failed: out of sequence response")
-		return
+	return true, err
+}
+
+type nodeProcessorWriteTagged struct {
+	handler Node
+}
+
+func (p *nodeProcessorWriteTagged) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteTaggedArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("writeTagged", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error87 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error88 error
-		error88, err = error87.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+
+	iprot.ReadMessageEnd()
+	result := NodeWriteTaggedResult{}
+	var err2 error
+	if err2 = p.handler.WriteTagged(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTagged: "+err2.Error())
+			oprot.WriteMessageBegin("writeTagged", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error88
-		return
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesBackoffDuration failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("writeTagged", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeSetWriteNewSeriesBackoffDurationResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
-		return
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	value = result.GetSuccess()
-	return
-}
-
-func (p *NodeClient) GetWriteNewSeriesLimitPerShardPerSecond() (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
-	if err = p.sendGetWriteNewSeriesLimitPerShardPerSecond(); err != nil {
+	if err != nil {
 		return
 	}
-	return p.recvGetWriteNewSeriesLimitPerShardPerSecond()
+	return true, err
 }
 
-func (p *NodeClient) sendGetWriteNewSeriesLimitPerShardPerSecond() (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
-	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.CALL, p.SeqId); err != nil {
-		return
-	}
-	args := NodeGetWriteNewSeriesLimitPerShardPerSecondArgs{}
-	if err = args.Write(oprot); err != nil {
-		return
-	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
-	}
-	return oprot.Flush()
+type nodeProcessorAggregateRaw struct {
+	handler Node
 }
 
-func (p *NodeClient) recvGetWriteNewSeriesLimitPerShardPerSecond() (value *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
-	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
-	}
-	if method != "getWriteNewSeriesLimitPerShardPerSecond" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "getWriteNewSeriesLimitPerShardPerSecond failed: wrong method name")
-		return
-	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "getWriteNewSeriesLimitPerShardPerSecond failed: out of sequence response")
-		return
+func (p *nodeProcessorAggregateRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeAggregateRawArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("aggregateRaw", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error89 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error90 error
-		error90, err = error89.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+
+	iprot.ReadMessageEnd()
+	result := NodeAggregateRawResult{}
+	var retval *AggregateQueryRawResult_
+	var err2 error
+	if retval, err2 = p.handler.AggregateRaw(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing aggregateRaw: "+err2.Error())
+			oprot.WriteMessageBegin("aggregateRaw", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error90
-		return
+	} else {
+		result.Success = retval
+	}
+	if err2 = oprot.WriteMessageBegin("aggregateRaw", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "getWriteNewSeriesLimitPerShardPerSecond failed: invalid message type")
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	result := NodeGetWriteNewSeriesLimitPerShardPerSecondResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
+	if err != nil {
 		return
 	}
-	value = result.GetSuccess()
-	return
+	return true, err
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) SetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (r *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
-	if err = p.sendSetWriteNewSeriesLimitPerShardPerSecond(req); err != nil {
-		return
-	}
-	return p.recvSetWriteNewSeriesLimitPerShardPerSecond()
+type nodeProcessorFetchBatchRaw struct {
+	handler Node
 }
 
-func (p *NodeClient) sendSetWriteNewSeriesLimitPerShardPerSecond(req *NodeSetWriteNewSeriesLimitPerShardPerSecondRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
-	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.CALL, p.SeqId); err != nil {
-		return
-	}
-	args := NodeSetWriteNewSeriesLimitPerShardPerSecondArgs{
-		Req: req,
-	}
-	if err = args.Write(oprot); err != nil {
-		return
-	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchBatchRawArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetchBatchRaw", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	return oprot.Flush()
-}
 
-func (p *NodeClient) recvSetWriteNewSeriesLimitPerShardPerSecond() (value *NodeWriteNewSeriesLimitPerShardPerSecondResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
-	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
-	}
-	if method != "setWriteNewSeriesLimitPerShardPerSecond" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "setWriteNewSeriesLimitPerShardPerSecond failed: wrong method name")
-		return
-	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "setWriteNewSeriesLimitPerShardPerSecond failed: out of sequence response")
-		return
-	}
-	if mTypeId == thrift.EXCEPTION {
-		error91 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error92 error
-		error92, err = error91.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+	iprot.ReadMessageEnd()
+	result := NodeFetchBatchRawResult{}
+	var retval *FetchBatchRawResult_
+	var err2 error
+	if retval, err2 = p.handler.FetchBatchRaw(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBatchRaw: "+err2.Error())
+			oprot.WriteMessageBegin("fetchBatchRaw", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error92
-		return
+	} else {
+		result.Success = retval
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "setWriteNewSeriesLimitPerShardPerSecond failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("fetchBatchRaw", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeSetWriteNewSeriesLimitPerShardPerSecondResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	value = result.GetSuccess()
-	return
+	return true, err
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) DebugProfileStart(req *DebugProfileStartRequest) (r *DebugProfileStartResult_, err error) {
-	if err = p.sendDebugProfileStart(req); err != nil {
-		return
-	}
-	return p.recvDebugProfileStart()
+type nodeProcessorFetchBatchRawV2 struct {
+	handler Node
 }
 
-func (p *NodeClient) sendDebugProfileStart(req *DebugProfileStartRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+func (p *nodeProcessorFetchBatchRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchBatchRawV2Args{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetchBatchRawV2", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("debugProfileStart", thrift.CALL, p.SeqId); err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeFetchBatchRawV2Result{}
+	var retval *FetchBatchRawResult_
+	var err2 error
+	if retval, err2 = p.handler.FetchBatchRawV2(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBatchRawV2: "+err2.Error())
+			oprot.WriteMessageBegin("fetchBatchRawV2", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
 	}
-	args := NodeDebugProfileStartArgs{
-		Req: req,
+	if err2 = oprot.WriteMessageBegin("fetchBatchRawV2", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	return oprot.Flush()
-}
-
-func (p *NodeClient) recvDebugProfileStart() (value *DebugProfileStartResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
 		return
 	}
-	if method != "debugProfileStart" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "debugProfileStart failed: wrong method name")
-		return
-	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "debugProfileStart failed: out of sequence response")
-		return
+	return true, err
+}
+
+type nodeProcessorFetchBlocksRaw struct {
+	handler Node
+}
+
+func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchBlocksRawArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetchBlocksRaw", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error93 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error94 error
-		error94, err = error93.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+
+	iprot.ReadMessageEnd()
+	result := NodeFetchBlocksRawResult{}
+	var retval *FetchBlocksRawResult_
+	var err2 error
+	if retval, err2 = p.handler.FetchBlocksRaw(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksRaw: "+err2.Error())
+			oprot.WriteMessageBegin("fetchBlocksRaw", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error94
-		return
+	} else {
+		result.Success = retval
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "debugProfileStart failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("fetchBlocksRaw", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeDebugProfileStartResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	value = result.GetSuccess()
-	return
+	return true, err
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) DebugProfileStop(req *DebugProfileStopRequest) (r *DebugProfileStopResult_, err error) {
-	if err = p.sendDebugProfileStop(req); err != nil {
-		return
-	}
-	return p.recvDebugProfileStop()
+type nodeProcessorFetchTagged struct {
+	handler Node
 }
 
-func (p *NodeClient) sendDebugProfileStop(req *DebugProfileStopRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+func (p *nodeProcessorFetchTagged) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchTaggedArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetchTagged", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("debugProfileStop", thrift.CALL, p.SeqId); err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeFetchTaggedResult{}
+	var retval *FetchTaggedResult_
+	var err2 error
+	if retval, err2 = p.handler.FetchTagged(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchTagged: "+err2.Error())
+			oprot.WriteMessageBegin("fetchTagged", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
 	}
-	args := NodeDebugProfileStopArgs{
-		Req: req,
+	if err2 = oprot.WriteMessageBegin("fetchTagged", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	return oprot.Flush()
+	return true, err
 }
 
-func (p *NodeClient) recvDebugProfileStop() (value *DebugProfileStopResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+type nodeProcessorFetchBlocksMetadataRawV2 struct {
+	handler Node
+}
+
+func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeFetchBlocksMetadataRawV2Args{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeFetchBlocksMetadataRawV2Result{}
+	var retval *FetchBlocksMetadataRawV2Result_
+	var err2 error
+	if retval, err2 = p.handler.FetchBlocksMetadataRawV2(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *Error:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksMetadataRawV2: "+err2.Error())
+			oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	} else {
+		result.Success = retval
 	}
-	if method != "debugProfileStop" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "debugProfileStop failed: wrong method name")
-		return
+	if err2 = oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "debugProfileStop failed: out of sequence response")
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error95 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error96 error
-		error96, err = error95.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+	return true, err
+}
+
+type nodeProcessorWriteBatchRaw struct {
+	handler Node
+}
+
+func (p *nodeProcessorWriteBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteBatchRawArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("writeBatchRaw", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
+	}
+
+	iprot.ReadMessageEnd()
+	result := NodeWriteBatchRawResult{}
+	var err2 error
+	if err2 = p.handler.WriteBatchRaw(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *WriteBatchRawErrors:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeBatchRaw: "+err2.Error())
+			oprot.WriteMessageBegin("writeBatchRaw", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error96
-		return
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "debugProfileStop failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("writeBatchRaw", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeDebugProfileStopResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	value = result.GetSuccess()
-	return
+	return true, err
 }
 
-// Parameters:
-//  - Req
-func (p *NodeClient) DebugIndexMemorySegments(req *DebugIndexMemorySegmentsRequest) (r *DebugIndexMemorySegmentsResult_, err error) {
-	if err = p.sendDebugIndexMemorySegments(req); err != nil {
-		return
-	}
-	return p.recvDebugIndexMemorySegments()
+type nodeProcessorWriteBatchRawV2 struct {
+	handler Node
 }
 
-func (p *NodeClient) sendDebugIndexMemorySegments(req *DebugIndexMemorySegmentsRequest) (err error) {
-	oprot := p.OutputProtocol
-	if oprot == nil {
-		oprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.OutputProtocol = oprot
+func (p *nodeProcessorWriteBatchRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteBatchRawV2Args{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("writeBatchRawV2", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	p.SeqId++
-	if err = oprot.WriteMessageBegin("debugIndexMemorySegments", thrift.CALL, p.SeqId); err != nil {
-		return
+
+	iprot.ReadMessageEnd()
+	result := NodeWriteBatchRawV2Result{}
+	var err2 error
+	if err2 = p.handler.WriteBatchRawV2(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *WriteBatchRawErrors:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeBatchRawV2: "+err2.Error())
+			oprot.WriteMessageBegin("writeBatchRawV2", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
 	}
-	args := NodeDebugIndexMemorySegmentsArgs{
-		Req: req,
+	if err2 = oprot.WriteMessageBegin("writeBatchRawV2", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	if err = args.Write(oprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = oprot.WriteMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	return oprot.Flush()
-}
-
-func (p *NodeClient) recvDebugIndexMemorySegments() (value *DebugIndexMemorySegmentsResult_, err error) {
-	iprot := p.InputProtocol
-	if iprot == nil {
-		iprot = p.ProtocolFactory.GetProtocol(p.Transport)
-		p.InputProtocol = iprot
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
 	}
-	method, mTypeId, seqId, err := iprot.ReadMessageBegin()
 	if err != nil {
 		return
 	}
-	if method != "debugIndexMemorySegments" {
-		err = thrift.NewTApplicationException(thrift.WRONG_METHOD_NAME, "debugIndexMemorySegments failed: wrong method name")
-		return
-	}
-	if p.SeqId != seqId {
-		err = thrift.NewTApplicationException(thrift.BAD_SEQUENCE_ID, "debugIndexMemorySegments failed: out of sequence response")
-		return
+	return true, err
+}
+
+type nodeProcessorWriteTaggedBatchRaw struct {
+	handler Node
+}
+
+func (p *nodeProcessorWriteTaggedBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteTaggedBatchRawArgs{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
+		return false, err
 	}
-	if mTypeId == thrift.EXCEPTION {
-		error97 := thrift.NewTApplicationException(thrift.UNKNOWN_APPLICATION_EXCEPTION, "Unknown Exception")
-		var error98 error
-		error98, err = error97.Read(iprot)
-		if err != nil {
-			return
-		}
-		if err = iprot.ReadMessageEnd(); err != nil {
-			return
+
+	iprot.ReadMessageEnd()
+	result := NodeWriteTaggedBatchRawResult{}
+	var err2 error
+	if err2 = p.handler.WriteTaggedBatchRaw(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *WriteBatchRawErrors:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTaggedBatchRaw: "+err2.Error())
+			oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
 		}
-		err = error98
-		return
 	}
-	if mTypeId != thrift.REPLY {
-		err = thrift.NewTApplicationException(thrift.INVALID_MESSAGE_TYPE_EXCEPTION, "debugIndexMemorySegments failed: invalid message type")
-		return
+	if err2 = oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.REPLY, seqId); err2 != nil {
+		err = err2
 	}
-	result := NodeDebugIndexMemorySegmentsResult{}
-	if err = result.Read(iprot); err != nil {
-		return
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
 	}
-	if err = iprot.ReadMessageEnd(); err != nil {
-		return
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
 	}
-	if result.Err != nil {
-		err = result.Err
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
 		return
 	}
-	value = result.GetSuccess()
-	return
-}
-
-type NodeProcessor struct {
-	processorMap map[string]thrift.TProcessorFunction
-	handler      Node
-}
-
-func (p *NodeProcessor) AddToProcessorMap(key string, processor thrift.TProcessorFunction) {
-	p.processorMap[key] = processor
-}
-
-func (p *NodeProcessor) GetProcessorFunction(key string) (processor thrift.TProcessorFunction, ok bool) {
-	processor, ok = p.processorMap[key]
-	return processor, ok
-}
-
-func (p *NodeProcessor) ProcessorMap() map[string]thrift.TProcessorFunction {
-	return p.processorMap
+	return true, err
 }
 
-func NewNodeProcessor(handler Node) *NodeProcessor {
-
-	self99 := &NodeProcessor{handler: handler, processorMap: make(map[string]thrift.TProcessorFunction)}
-	self99.processorMap["query"] = &nodeProcessorQuery{handler: handler}
-	self99.processorMap["aggregate"] = &nodeProcessorAggregate{handler: handler}
-	self99.processorMap["fetch"] = &nodeProcessorFetch{handler: handler}
-	self99.processorMap["write"] = &nodeProcessorWrite{handler: handler}
-	self99.processorMap["writeTagged"] = &nodeProcessorWriteTagged{handler: handler}
-	self99.processorMap["aggregateRaw"] = &nodeProcessorAggregateRaw{handler: handler}
-	self99.processorMap["fetchBatchRaw"] = &nodeProcessorFetchBatchRaw{handler: handler}
-	self99.processorMap["fetchBatchRawV2"] = &nodeProcessorFetchBatchRawV2{handler: handler}
-	self99.processorMap["fetchBlocksRaw"] = &nodeProcessorFetchBlocksRaw{handler: handler}
-	self99.processorMap["fetchTagged"] = &nodeProcessorFetchTagged{handler: handler}
-	self99.processorMap["fetchBlocksMetadataRawV2"] = &nodeProcessorFetchBlocksMetadataRawV2{handler: handler}
-	self99.processorMap["writeBatchRaw"] = &nodeProcessorWriteBatchRaw{handler: handler}
-	self99.processorMap["writeBatchRawV2"] = &nodeProcessorWriteBatchRawV2{handler: handler}
-	self99.processorMap["writeTaggedBatchRaw"] = &nodeProcessorWriteTaggedBatchRaw{handler: handler}
-	self99.processorMap["writeTaggedBatchRawV2"] = &nodeProcessorWriteTaggedBatchRawV2{handler: handler}
-	self99.processorMap["repair"] = &nodeProcessorRepair{handler: handler}
-	self99.processorMap["truncate"] = &nodeProcessorTruncate{handler: handler}
-	self99.processorMap["aggregateTiles"] = &nodeProcessorAggregateTiles{handler: handler}
-	self99.processorMap["health"] = &nodeProcessorHealth{handler: handler}
-	self99.processorMap["bootstrapped"] = &nodeProcessorBootstrapped{handler: handler}
-	self99.processorMap["bootstrappedInPlacementOrNoPlacement"] = &nodeProcessorBootstrappedInPlacementOrNoPlacement{handler: handler}
-	self99.processorMap["getPersistRateLimit"] = &nodeProcessorGetPersistRateLimit{handler: handler}
-	self99.processorMap["setPersistRateLimit"] = &nodeProcessorSetPersistRateLimit{handler: handler}
-	self99.processorMap["getWriteNewSeriesAsync"] = &nodeProcessorGetWriteNewSeriesAsync{handler: handler}
-	self99.processorMap["setWriteNewSeriesAsync"] = &nodeProcessorSetWriteNewSeriesAsync{handler: handler}
-	self99.processorMap["getWriteNewSeriesBackoffDuration"] = &nodeProcessorGetWriteNewSeriesBackoffDuration{handler: handler}
-	self99.processorMap["setWriteNewSeriesBackoffDuration"] = &nodeProcessorSetWriteNewSeriesBackoffDuration{handler: handler}
-	self99.processorMap["getWriteNewSeriesLimitPerShardPerSecond"] = &nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond{handler: handler}
-	self99.processorMap["setWriteNewSeriesLimitPerShardPerSecond"] = &nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond{handler: handler}
-	self99.processorMap["debugProfileStart"] = &nodeProcessorDebugProfileStart{handler: handler}
-	self99.processorMap["debugProfileStop"] = &nodeProcessorDebugProfileStop{handler: handler}
-	self99.processorMap["debugIndexMemorySegments"] = &nodeProcessorDebugIndexMemorySegments{handler: handler}
-	return self99
+type nodeProcessorWriteTaggedBatchRawV2 struct {
+	handler Node
 }
 
-func (p *NodeProcessor) Process(iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	name, _, seqId, err := iprot.ReadMessageBegin()
-	if err != nil {
+func (p *nodeProcessorWriteTaggedBatchRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeWriteTaggedBatchRawV2Args{}
+	if err = args.Read(iprot); err != nil {
+		iprot.ReadMessageEnd()
+		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
+		oprot.WriteMessageBegin("writeTaggedBatchRawV2", thrift.EXCEPTION, seqId)
+		x.Write(oprot)
+		oprot.WriteMessageEnd()
+		oprot.Flush()
 		return false, err
 	}
-	if processor, ok := p.GetProcessorFunction(name); ok {
-		return processor.Process(seqId, iprot, oprot)
-	}
-	iprot.Skip(thrift.STRUCT)
-	iprot.ReadMessageEnd()
-	x100 := thrift.NewTApplicationException(thrift.UNKNOWN_METHOD, "Unknown function "+name)
-	oprot.WriteMessageBegin(name, thrift.EXCEPTION, seqId)
-	x100.Write(oprot)
-	oprot.WriteMessageEnd()
-	oprot.Flush()
-	return false, x100
 
+	iprot.ReadMessageEnd()
+	result := NodeWriteTaggedBatchRawV2Result{}
+	var err2 error
+	if err2 = p.handler.WriteTaggedBatchRawV2(args.Req); err2 != nil {
+		switch v := err2.(type) {
+		case *WriteBatchRawErrors:
+			result.Err = v
+		default:
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTaggedBatchRawV2: "+err2.Error())
+			oprot.WriteMessageBegin("writeTaggedBatchRawV2", thrift.EXCEPTION, seqId)
+			x.Write(oprot)
+			oprot.WriteMessageEnd()
+			oprot.Flush()
+			return true, err2
+		}
+	}
+	if err2 = oprot.WriteMessageBegin("writeTaggedBatchRawV2", thrift.REPLY, seqId); err2 != nil {
+		err = err2
+	}
+	if err2 = result.Write(oprot); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err2 = oprot.Flush(); err == nil && err2 != nil {
+		err = err2
+	}
+	if err != nil {
+		return
+	}
+	return true, err
 }
 
-type nodeProcessorQuery struct {
+type nodeProcessorRepair struct {
 	handler Node
 }
 
-func (p *nodeProcessorQuery) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeQueryArgs{}
+func (p *nodeProcessorRepair) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeRepairArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("query", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("repair", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18089,25 +20259,22 @@ func (p *nodeProcessorQuery) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeQueryResult{}
-	var retval *QueryResult_
+	result := NodeRepairResult{}
 	var err2 error
-	if retval, err2 = p.handler.Query(args.Req); err2 != nil {
+	if err2 = p.handler.Repair(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing query: "+err2.Error())
-			oprot.WriteMessageBegin("query", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing repair: "+err2.Error())
+			oprot.WriteMessageBegin("repair", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
-	} else {
-		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("query", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("repair", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18125,16 +20292,16 @@ func (p *nodeProcessorQuery) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	return true, err
 }
 
-type nodeProcessorAggregate struct {
+type nodeProcessorTruncate struct {
 	handler Node
 }
 
-func (p *nodeProcessorAggregate) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeAggregateArgs{}
+func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeTruncateArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("aggregate", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("truncate", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18142,16 +20309,16 @@ func (p *nodeProcessorAggregate) Process(seqId int32, iprot, oprot thrift.TProto
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeAggregateResult{}
-	var retval *AggregateQueryResult_
+	result := NodeTruncateResult{}
+	var retval *TruncateResult_
 	var err2 error
-	if retval, err2 = p.handler.Aggregate(args.Req); err2 != nil {
+	if retval, err2 = p.handler.Truncate(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing aggregate: "+err2.Error())
-			oprot.WriteMessageBegin("aggregate", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing truncate: "+err2.Error())
+			oprot.WriteMessageBegin("truncate", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18160,7 +20327,7 @@ func (p *nodeProcessorAggregate) Process(seqId int32, iprot, oprot thrift.TProto
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("aggregate", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("truncate", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18178,16 +20345,16 @@ func (p *nodeProcessorAggregate) Process(seqId int32, iprot, oprot thrift.TProto
 	return true, err
 }
 
-type nodeProcessorFetch struct {
+type nodeProcessorDeleteSeries struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetch) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchArgs{}
+func (p *nodeProcessorDeleteSeries) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeDeleteSeriesArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetch", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("deleteSeries", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18195,16 +20362,16 @@ func (p *nodeProcessorFetch) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchResult{}
-	var retval *FetchResult_
+	result := NodeDeleteSeriesResult{}
+	var retval *DeleteSeriesResult_
 	var err2 error
-	if retval, err2 = p.handler.Fetch(args.Req); err2 != nil {
+	if retval, err2 = p.handler.DeleteSeries(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetch: "+err2.Error())
-			oprot.WriteMessageBegin("fetch", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing deleteSeries: "+err2.Error())
+			oprot.WriteMessageBegin("deleteSeries", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18213,7 +20380,7 @@ func (p *nodeProcessorFetch) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetch", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("deleteSeries", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18231,16 +20398,16 @@ func (p *nodeProcessorFetch) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	return true, err
 }
 
-type nodeProcessorWrite struct {
+type nodeProcessorBackup struct {
 	handler Node
 }
 
-func (p *nodeProcessorWrite) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteArgs{}
+func (p *nodeProcessorBackup) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeBackupArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("write", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("backup", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18248,22 +20415,25 @@ func (p *nodeProcessorWrite) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteResult{}
+	result := NodeBackupResult{}
+	var retval *BackupResult_
 	var err2 error
-	if err2 = p.handler.Write(args.Req); err2 != nil {
+	if retval, err2 = p.handler.Backup(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing write: "+err2.Error())
-			oprot.WriteMessageBegin("write", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing backup: "+err2.Error())
+			oprot.WriteMessageBegin("backup", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("write", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("backup", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18281,16 +20451,16 @@ func (p *nodeProcessorWrite) Process(seqId int32, iprot, oprot thrift.TProtocol)
 	return true, err
 }
 
-type nodeProcessorWriteTagged struct {
+type nodeProcessorRestore struct {
 	handler Node
 }
 
-func (p *nodeProcessorWriteTagged) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteTaggedArgs{}
+func (p *nodeProcessorRestore) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeRestoreArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("writeTagged", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("restore", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18298,22 +20468,25 @@ func (p *nodeProcessorWriteTagged) Process(seqId int32, iprot, oprot thrift.TPro
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteTaggedResult{}
+	result := NodeRestoreResult{}
+	var retval *RestoreResult_
 	var err2 error
-	if err2 = p.handler.WriteTagged(args.Req); err2 != nil {
+	if retval, err2 = p.handler.Restore(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTagged: "+err2.Error())
-			oprot.WriteMessageBegin("writeTagged", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing restore: "+err2.Error())
+			oprot.WriteMessageBegin("restore", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("writeTagged", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("restore", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18331,16 +20504,16 @@ func (p *nodeProcessorWriteTagged) Process(seqId int32, iprot, oprot thrift.TPro
 	return true, err
 }
 
-type nodeProcessorAggregateRaw struct {
+type nodeProcessorAggregateTiles struct {
 	handler Node
 }
 
-func (p *nodeProcessorAggregateRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeAggregateRawArgs{}
+func (p *nodeProcessorAggregateTiles) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeAggregateTilesArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("aggregateRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("aggregateTiles", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18348,16 +20521,16 @@ func (p *nodeProcessorAggregateRaw) Process(seqId int32, iprot, oprot thrift.TPr
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeAggregateRawResult{}
-	var retval *AggregateQueryRawResult_
+	result := NodeAggregateTilesResult{}
+	var retval *AggregateTilesResult_
 	var err2 error
-	if retval, err2 = p.handler.AggregateRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.AggregateTiles(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing aggregateRaw: "+err2.Error())
-			oprot.WriteMessageBegin("aggregateRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing aggregateTiles: "+err2.Error())
+			oprot.WriteMessageBegin("aggregateTiles", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18366,7 +20539,7 @@ func (p *nodeProcessorAggregateRaw) Process(seqId int32, iprot, oprot thrift.TPr
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("aggregateRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("aggregateTiles", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18384,16 +20557,16 @@ func (p *nodeProcessorAggregateRaw) Process(seqId int32, iprot, oprot thrift.TPr
 	return true, err
 }
 
-type nodeProcessorFetchBatchRaw struct {
+type nodeProcessorHealth struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchBatchRawArgs{}
+func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeHealthArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchBatchRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("health", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18401,16 +20574,16 @@ func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchBatchRawResult{}
-	var retval *FetchBatchRawResult_
+	result := NodeHealthResult{}
+	var retval *NodeHealthResult_
 	var err2 error
-	if retval, err2 = p.handler.FetchBatchRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.Health(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBatchRaw: "+err2.Error())
-			oprot.WriteMessageBegin("fetchBatchRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing health: "+err2.Error())
+			oprot.WriteMessageBegin("health", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18419,7 +20592,7 @@ func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchBatchRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("health", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18437,16 +20610,16 @@ func (p *nodeProcessorFetchBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	return true, err
 }
 
-type nodeProcessorFetchBatchRawV2 struct {
+type nodeProcessorBootstrapped struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchBatchRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchBatchRawV2Args{}
+func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeBootstrappedArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchBatchRawV2", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("bootstrapped", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18454,16 +20627,16 @@ func (p *nodeProcessorFetchBatchRawV2) Process(seqId int32, iprot, oprot thrift.
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchBatchRawV2Result{}
-	var retval *FetchBatchRawResult_
+	result := NodeBootstrappedResult{}
+	var retval *NodeBootstrappedResult_
 	var err2 error
-	if retval, err2 = p.handler.FetchBatchRawV2(args.Req); err2 != nil {
+	if retval, err2 = p.handler.Bootstrapped(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBatchRawV2: "+err2.Error())
-			oprot.WriteMessageBegin("fetchBatchRawV2", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing bootstrapped: "+err2.Error())
+			oprot.WriteMessageBegin("bootstrapped", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18472,7 +20645,7 @@ func (p *nodeProcessorFetchBatchRawV2) Process(seqId int32, iprot, oprot thrift.
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchBatchRawV2", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("bootstrapped", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18490,16 +20663,16 @@ func (p *nodeProcessorFetchBatchRawV2) Process(seqId int32, iprot, oprot thrift.
 	return true, err
 }
 
-type nodeProcessorFetchBlocksRaw struct {
+type nodeProcessorBootstrappedInPlacementOrNoPlacement struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchBlocksRawArgs{}
+func (p *nodeProcessorBootstrappedInPlacementOrNoPlacement) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeBootstrappedInPlacementOrNoPlacementArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchBlocksRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("bootstrappedInPlacementOrNoPlacement", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18507,16 +20680,16 @@ func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.T
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchBlocksRawResult{}
-	var retval *FetchBlocksRawResult_
+	result := NodeBootstrappedInPlacementOrNoPlacementResult{}
+	var retval *NodeBootstrappedInPlacementOrNoPlacementResult_
 	var err2 error
-	if retval, err2 = p.handler.FetchBlocksRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.BootstrappedInPlacementOrNoPlacement(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksRaw: "+err2.Error())
-			oprot.WriteMessageBegin("fetchBlocksRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing bootstrappedInPlacementOrNoPlacement: "+err2.Error())
+			oprot.WriteMessageBegin("bootstrappedInPlacementOrNoPlacement", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18525,7 +20698,7 @@ func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.T
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchBlocksRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("bootstrappedInPlacementOrNoPlacement", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18543,16 +20716,16 @@ func (p *nodeProcessorFetchBlocksRaw) Process(seqId int32, iprot, oprot thrift.T
 	return true, err
 }
 
-type nodeProcessorFetchTagged struct {
+type nodeProcessorGetPersistRateLimit struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchTagged) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchTaggedArgs{}
+func (p *nodeProcessorGetPersistRateLimit) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeGetPersistRateLimitArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchTagged", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("getPersistRateLimit", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18560,16 +20733,16 @@ func (p *nodeProcessorFetchTagged) Process(seqId int32, iprot, oprot thrift.TPro
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchTaggedResult{}
-	var retval *FetchTaggedResult_
+	result := NodeGetPersistRateLimitResult{}
+	var retval *NodePersistRateLimitResult_
 	var err2 error
-	if retval, err2 = p.handler.FetchTagged(args.Req); err2 != nil {
+	if retval, err2 = p.handler.GetPersistRateLimit(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchTagged: "+err2.Error())
-			oprot.WriteMessageBegin("fetchTagged", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getPersistRateLimit: "+err2.Error())
+			oprot.WriteMessageBegin("getPersistRateLimit", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18578,7 +20751,7 @@ func (p *nodeProcessorFetchTagged) Process(seqId int32, iprot, oprot thrift.TPro
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchTagged", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("getPersistRateLimit", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18596,16 +20769,16 @@ func (p *nodeProcessorFetchTagged) Process(seqId int32, iprot, oprot thrift.TPro
 	return true, err
 }
 
-type nodeProcessorFetchBlocksMetadataRawV2 struct {
+type nodeProcessorSetPersistRateLimit struct {
 	handler Node
 }
 
-func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeFetchBlocksMetadataRawV2Args{}
+func (p *nodeProcessorSetPersistRateLimit) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeSetPersistRateLimitArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("setPersistRateLimit", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18613,16 +20786,16 @@ func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, opro
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeFetchBlocksMetadataRawV2Result{}
-	var retval *FetchBlocksMetadataRawV2Result_
+	result := NodeSetPersistRateLimitResult{}
+	var retval *NodePersistRateLimitResult_
 	var err2 error
-	if retval, err2 = p.handler.FetchBlocksMetadataRawV2(args.Req); err2 != nil {
+	if retval, err2 = p.handler.SetPersistRateLimit(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing fetchBlocksMetadataRawV2: "+err2.Error())
-			oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setPersistRateLimit: "+err2.Error())
+			oprot.WriteMessageBegin("setPersistRateLimit", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18631,7 +20804,7 @@ func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, opro
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("fetchBlocksMetadataRawV2", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("setPersistRateLimit", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18649,16 +20822,16 @@ func (p *nodeProcessorFetchBlocksMetadataRawV2) Process(seqId int32, iprot, opro
 	return true, err
 }
 
-type nodeProcessorWriteBatchRaw struct {
+type nodeProcessorGetWriteNewSeriesAsync struct {
 	handler Node
 }
 
-func (p *nodeProcessorWriteBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteBatchRawArgs{}
+func (p *nodeProcessorGetWriteNewSeriesAsync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeGetWriteNewSeriesAsyncArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("writeBatchRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18666,22 +20839,25 @@ func (p *nodeProcessorWriteBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteBatchRawResult{}
+	result := NodeGetWriteNewSeriesAsyncResult{}
+	var retval *NodeWriteNewSeriesAsyncResult_
 	var err2 error
-	if err2 = p.handler.WriteBatchRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.GetWriteNewSeriesAsync(); err2 != nil {
 		switch v := err2.(type) {
-		case *WriteBatchRawErrors:
+		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeBatchRaw: "+err2.Error())
-			oprot.WriteMessageBegin("writeBatchRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesAsync: "+err2.Error())
+			oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("writeBatchRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18699,16 +20875,16 @@ func (p *nodeProcessorWriteBatchRaw) Process(seqId int32, iprot, oprot thrift.TP
 	return true, err
 }
 
-type nodeProcessorWriteBatchRawV2 struct {
+type nodeProcessorSetWriteNewSeriesAsync struct {
 	handler Node
 }
 
-func (p *nodeProcessorWriteBatchRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteBatchRawV2Args{}
+func (p *nodeProcessorSetWriteNewSeriesAsync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeSetWriteNewSeriesAsyncArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("writeBatchRawV2", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18716,22 +20892,25 @@ func (p *nodeProcessorWriteBatchRawV2) Process(seqId int32, iprot, oprot thrift.
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteBatchRawV2Result{}
+	result := NodeSetWriteNewSeriesAsyncResult{}
+	var retval *NodeWriteNewSeriesAsyncResult_
 	var err2 error
-	if err2 = p.handler.WriteBatchRawV2(args.Req); err2 != nil {
+	if retval, err2 = p.handler.SetWriteNewSeriesAsync(args.Req); err2 != nil {
 		switch v := err2.(type) {
-		case *WriteBatchRawErrors:
+		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeBatchRawV2: "+err2.Error())
-			oprot.WriteMessageBegin("writeBatchRawV2", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesAsync: "+err2.Error())
+			oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("writeBatchRawV2", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18749,16 +20928,16 @@ func (p *nodeProcessorWriteBatchRawV2) Process(seqId int32, iprot, oprot thrift.
 	return true, err
 }
 
-type nodeProcessorWriteTaggedBatchRaw struct {
+type nodeProcessorGetWriteNewSeriesBackoffDuration struct {
 	handler Node
 }
 
-func (p *nodeProcessorWriteTaggedBatchRaw) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteTaggedBatchRawArgs{}
+func (p *nodeProcessorGetWriteNewSeriesBackoffDuration) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeGetWriteNewSeriesBackoffDurationArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18766,22 +20945,25 @@ func (p *nodeProcessorWriteTaggedBatchRaw) Process(seqId int32, iprot, oprot thr
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteTaggedBatchRawResult{}
+	result := NodeGetWriteNewSeriesBackoffDurationResult{}
+	var retval *NodeWriteNewSeriesBackoffDurationResult_
 	var err2 error
-	if err2 = p.handler.WriteTaggedBatchRaw(args.Req); err2 != nil {
+	if retval, err2 = p.handler.GetWriteNewSeriesBackoffDuration(); err2 != nil {
 		switch v := err2.(type) {
-		case *WriteBatchRawErrors:
+		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTaggedBatchRaw: "+err2.Error())
-			oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesBackoffDuration: "+err2.Error())
+			oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("writeTaggedBatchRaw", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18799,16 +20981,16 @@ func (p *nodeProcessorWriteTaggedBatchRaw) Process(seqId int32, iprot, oprot thr
 	return true, err
 }
 
-type nodeProcessorWriteTaggedBatchRawV2 struct {
+type nodeProcessorSetWriteNewSeriesBackoffDuration struct {
 	handler Node
 }
 
-func (p *nodeProcessorWriteTaggedBatchRawV2) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeWriteTaggedBatchRawV2Args{}
+func (p *nodeProcessorSetWriteNewSeriesBackoffDuration) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeSetWriteNewSeriesBackoffDurationArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("writeTaggedBatchRawV2", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18816,22 +20998,25 @@ func (p *nodeProcessorWriteTaggedBatchRawV2) Process(seqId int32, iprot, oprot t
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeWriteTaggedBatchRawV2Result{}
+	result := NodeSetWriteNewSeriesBackoffDurationResult{}
+	var retval *NodeWriteNewSeriesBackoffDurationResult_
 	var err2 error
-	if err2 = p.handler.WriteTaggedBatchRawV2(args.Req); err2 != nil {
+	if retval, err2 = p.handler.SetWriteNewSeriesBackoffDuration(args.Req); err2 != nil {
 		switch v := err2.(type) {
-		case *WriteBatchRawErrors:
+		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing writeTaggedBatchRawV2: "+err2.Error())
-			oprot.WriteMessageBegin("writeTaggedBatchRawV2", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesBackoffDuration: "+err2.Error())
+			oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("writeTaggedBatchRawV2", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18849,16 +21034,16 @@ func (p *nodeProcessorWriteTaggedBatchRawV2) Process(seqId int32, iprot, oprot t
 	return true, err
 }
 
-type nodeProcessorRepair struct {
+type nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond struct {
 	handler Node
 }
 
-func (p *nodeProcessorRepair) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeRepairArgs{}
+func (p *nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeGetWriteNewSeriesLimitPerShardPerSecondArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("repair", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18866,22 +21051,25 @@ func (p *nodeProcessorRepair) Process(seqId int32, iprot, oprot thrift.TProtocol
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeRepairResult{}
+	result := NodeGetWriteNewSeriesLimitPerShardPerSecondResult{}
+	var retval *NodeWriteNewSeriesLimitPerShardPerSecondResult_
 	var err2 error
-	if err2 = p.handler.Repair(); err2 != nil {
+	if retval, err2 = p.handler.GetWriteNewSeriesLimitPerShardPerSecond(); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing repair: "+err2.Error())
-			oprot.WriteMessageBegin("repair", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesLimitPerShardPerSecond: "+err2.Error())
+			oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
 			return true, err2
 		}
+	} else {
+		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("repair", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18899,16 +21087,16 @@ func (p *nodeProcessorRepair) Process(seqId int32, iprot, oprot thrift.TProtocol
 	return true, err
 }
 
-type nodeProcessorTruncate struct {
+type nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond struct {
 	handler Node
 }
 
-func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeTruncateArgs{}
+func (p *nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeSetWriteNewSeriesLimitPerShardPerSecondArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("truncate", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18916,16 +21104,16 @@ func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtoc
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeTruncateResult{}
-	var retval *TruncateResult_
+	result := NodeSetWriteNewSeriesLimitPerShardPerSecondResult{}
+	var retval *NodeWriteNewSeriesLimitPerShardPerSecondResult_
 	var err2 error
-	if retval, err2 = p.handler.Truncate(args.Req); err2 != nil {
+	if retval, err2 = p.handler.SetWriteNewSeriesLimitPerShardPerSecond(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing truncate: "+err2.Error())
-			oprot.WriteMessageBegin("truncate", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesLimitPerShardPerSecond: "+err2.Error())
+			oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18934,7 +21122,7 @@ func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtoc
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("truncate", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -18952,16 +21140,16 @@ func (p *nodeProcessorTruncate) Process(seqId int32, iprot, oprot thrift.TProtoc
 	return true, err
 }
 
-type nodeProcessorAggregateTiles struct {
+type nodeProcessorDebugProfileStart struct {
 	handler Node
 }
 
-func (p *nodeProcessorAggregateTiles) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeAggregateTilesArgs{}
+func (p *nodeProcessorDebugProfileStart) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeDebugProfileStartArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("aggregateTiles", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("debugProfileStart", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -18969,16 +21157,16 @@ func (p *nodeProcessorAggregateTiles) Process(seqId int32, iprot, oprot thrift.T
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeAggregateTilesResult{}
-	var retval *AggregateTilesResult_
+	result := NodeDebugProfileStartResult{}
+	var retval *DebugProfileStartResult_
 	var err2 error
-	if retval, err2 = p.handler.AggregateTiles(args.Req); err2 != nil {
+	if retval, err2 = p.handler.DebugProfileStart(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing aggregateTiles: "+err2.Error())
-			oprot.WriteMessageBegin("aggregateTiles", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing debugProfileStart: "+err2.Error())
+			oprot.WriteMessageBegin("debugProfileStart", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -18987,7 +21175,7 @@ func (p *nodeProcessorAggregateTiles) Process(seqId int32, iprot, oprot thrift.T
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("aggregateTiles", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("debugProfileStart", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -19005,16 +21193,16 @@ func (p *nodeProcessorAggregateTiles) Process(seqId int32, iprot, oprot thrift.T
 	return true, err
 }
 
-type nodeProcessorHealth struct {
+type nodeProcessorDebugProfileStop struct {
 	handler Node
 }
 
-func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeHealthArgs{}
+func (p *nodeProcessorDebugProfileStop) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeDebugProfileStopArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("health", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("debugProfileStop", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -19022,16 +21210,16 @@ func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeHealthResult{}
-	var retval *NodeHealthResult_
+	result := NodeDebugProfileStopResult{}
+	var retval *DebugProfileStopResult_
 	var err2 error
-	if retval, err2 = p.handler.Health(); err2 != nil {
+	if retval, err2 = p.handler.DebugProfileStop(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing health: "+err2.Error())
-			oprot.WriteMessageBegin("health", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing debugProfileStop: "+err2.Error())
+			oprot.WriteMessageBegin("debugProfileStop", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -19040,7 +21228,7 @@ func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("health", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("debugProfileStop", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -19058,16 +21246,16 @@ func (p *nodeProcessorHealth) Process(seqId int32, iprot, oprot thrift.TProtocol
 	return true, err
 }
 
-type nodeProcessorBootstrapped struct {
+type nodeProcessorDebugIndexMemorySegments struct {
 	handler Node
 }
 
-func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeBootstrappedArgs{}
+func (p *nodeProcessorDebugIndexMemorySegments) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
+	args := NodeDebugIndexMemorySegmentsArgs{}
 	if err = args.Read(iprot); err != nil {
 		iprot.ReadMessageEnd()
 		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("bootstrapped", thrift.EXCEPTION, seqId)
+		oprot.WriteMessageBegin("debugIndexMemorySegments", thrift.EXCEPTION, seqId)
 		x.Write(oprot)
 		oprot.WriteMessageEnd()
 		oprot.Flush()
@@ -19075,16 +21263,16 @@ func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TPr
 	}
 
 	iprot.ReadMessageEnd()
-	result := NodeBootstrappedResult{}
-	var retval *NodeBootstrappedResult_
+	result := NodeDebugIndexMemorySegmentsResult{}
+	var retval *DebugIndexMemorySegmentsResult_
 	var err2 error
-	if retval, err2 = p.handler.Bootstrapped(); err2 != nil {
+	if retval, err2 = p.handler.DebugIndexMemorySegments(args.Req); err2 != nil {
 		switch v := err2.(type) {
 		case *Error:
 			result.Err = v
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing bootstrapped: "+err2.Error())
-			oprot.WriteMessageBegin("bootstrapped", thrift.EXCEPTION, seqId)
+			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing debugIndexMemorySegments: "+err2.Error())
+			oprot.WriteMessageBegin("debugIndexMemorySegments", thrift.EXCEPTION, seqId)
 			x.Write(oprot)
 			oprot.WriteMessageEnd()
 			oprot.Flush()
@@ -19093,7 +21281,7 @@ func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TPr
 	} else {
 		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("bootstrapped", thrift.REPLY, seqId); err2 != nil {
+	if err2 = oprot.WriteMessageBegin("debugIndexMemorySegments", thrift.REPLY, seqId); err2 != nil {
 		err = err2
 	}
 	if err2 = result.Write(oprot); err == nil && err2 != nil {
@@ -19111,667 +21299,796 @@ func (p *nodeProcessorBootstrapped) Process(seqId int32, iprot, oprot thrift.TPr
 	return true, err
 }
 
-type nodeProcessorBootstrappedInPlacementOrNoPlacement struct {
-	handler Node
+// HELPER FUNCTIONS AND STRUCTURES
+
+// Attributes:
+//  - Req
+type NodeQueryArgs struct {
+	Req *QueryRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func (p *nodeProcessorBootstrappedInPlacementOrNoPlacement) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeBootstrappedInPlacementOrNoPlacementArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("bootstrappedInPlacementOrNoPlacement", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func NewNodeQueryArgs() *NodeQueryArgs {
+	return &NodeQueryArgs{}
+}
+
+var NodeQueryArgs_Req_DEFAULT *QueryRequest
+
+func (p *NodeQueryArgs) GetReq() *QueryRequest {
+	if !p.IsSetReq() {
+		return NodeQueryArgs_Req_DEFAULT
 	}
+	return p.Req
+}
+func (p *NodeQueryArgs) IsSetReq() bool {
+	return p.Req != nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeBootstrappedInPlacementOrNoPlacementResult{}
-	var retval *NodeBootstrappedInPlacementOrNoPlacementResult_
-	var err2 error
-	if retval, err2 = p.handler.BootstrappedInPlacementOrNoPlacement(); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
+func (p *NodeQueryArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
+		}
+	}
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
+	}
+	return nil
+}
+
+func (p *NodeQueryArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &QueryRequest{
+		RangeType: 0,
+
+		ResultTimeType: 0,
+	}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *NodeQueryArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("query_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
+	}
+	return nil
+}
+
+func (p *NodeQueryArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *NodeQueryArgs) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeQueryArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Err
+type NodeQueryResult struct {
+	Success *QueryResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error        `thrift:"err,1" db:"err" json:"err,omitempty"`
+}
+
+func NewNodeQueryResult() *NodeQueryResult {
+	return &NodeQueryResult{}
+}
+
+var NodeQueryResult_Success_DEFAULT *QueryResult_
+
+func (p *NodeQueryResult) GetSuccess() *QueryResult_ {
+	if !p.IsSetSuccess() {
+		return NodeQueryResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeQueryResult_Err_DEFAULT *Error
+
+func (p *NodeQueryResult) GetErr() *Error {
+	if !p.IsSetErr() {
+		return NodeQueryResult_Err_DEFAULT
+	}
+	return p.Err
+}
+func (p *NodeQueryResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeQueryResult) IsSetErr() bool {
+	return p.Err != nil
+}
+
+func (p *NodeQueryResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing bootstrappedInPlacementOrNoPlacement: "+err2.Error())
-			oprot.WriteMessageBegin("bootstrappedInPlacementOrNoPlacement", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("bootstrappedInPlacementOrNoPlacement", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
 	}
-	if err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return true, err
+	return nil
 }
 
-type nodeProcessorGetPersistRateLimit struct {
-	handler Node
+func (p *NodeQueryResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &QueryResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
 }
 
-func (p *nodeProcessorGetPersistRateLimit) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeGetPersistRateLimitArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("getPersistRateLimit", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeQueryResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
+	}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeGetPersistRateLimitResult{}
-	var retval *NodePersistRateLimitResult_
-	var err2 error
-	if retval, err2 = p.handler.GetPersistRateLimit(); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getPersistRateLimit: "+err2.Error())
-			oprot.WriteMessageBegin("getPersistRateLimit", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+func (p *NodeQueryResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("query_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("getPersistRateLimit", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *NodeQueryResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return err
+}
+
+func (p *NodeQueryResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:err: ", p), err)
+		}
 	}
-	if err != nil {
-		return
+	return err
+}
+
+func (p *NodeQueryResult) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return true, err
+	return fmt.Sprintf("NodeQueryResult(%+v)", *p)
 }
 
-type nodeProcessorSetPersistRateLimit struct {
-	handler Node
+// Attributes:
+//  - Req
+type NodeAggregateArgs struct {
+	Req *AggregateQueryRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func (p *nodeProcessorSetPersistRateLimit) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeSetPersistRateLimitArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("setPersistRateLimit", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func NewNodeAggregateArgs() *NodeAggregateArgs {
+	return &NodeAggregateArgs{}
+}
+
+var NodeAggregateArgs_Req_DEFAULT *AggregateQueryRequest
+
+func (p *NodeAggregateArgs) GetReq() *AggregateQueryRequest {
+	if !p.IsSetReq() {
+		return NodeAggregateArgs_Req_DEFAULT
 	}
+	return p.Req
+}
+func (p *NodeAggregateArgs) IsSetReq() bool {
+	return p.Req != nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeSetPersistRateLimitResult{}
-	var retval *NodePersistRateLimitResult_
-	var err2 error
-	if retval, err2 = p.handler.SetPersistRateLimit(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
+func (p *NodeAggregateArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
+	}
+
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setPersistRateLimit: "+err2.Error())
-			oprot.WriteMessageBegin("setPersistRateLimit", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("setPersistRateLimit", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
 	}
-	if err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return true, err
+	return nil
 }
 
-type nodeProcessorGetWriteNewSeriesAsync struct {
-	handler Node
+func (p *NodeAggregateArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &AggregateQueryRequest{
+		AggregateQueryType: 1,
+
+		RangeType: 0,
+	}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
 }
 
-func (p *nodeProcessorGetWriteNewSeriesAsync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeGetWriteNewSeriesAsyncArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeAggregateArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("aggregate_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
+	}
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
+	}
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeGetWriteNewSeriesAsyncResult{}
-	var retval *NodeWriteNewSeriesAsyncResult_
-	var err2 error
-	if retval, err2 = p.handler.GetWriteNewSeriesAsync(); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesAsync: "+err2.Error())
-			oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
+func (p *NodeAggregateArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
-	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesAsync", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	return err
+}
+
+func (p *NodeAggregateArgs) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return fmt.Sprintf("NodeAggregateArgs(%+v)", *p)
+}
+
+// Attributes:
+//  - Success
+//  - Err
+type NodeAggregateResult struct {
+	Success *AggregateQueryResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                 `thrift:"err,1" db:"err" json:"err,omitempty"`
+}
+
+func NewNodeAggregateResult() *NodeAggregateResult {
+	return &NodeAggregateResult{}
+}
+
+var NodeAggregateResult_Success_DEFAULT *AggregateQueryResult_
+
+func (p *NodeAggregateResult) GetSuccess() *AggregateQueryResult_ {
+	if !p.IsSetSuccess() {
+		return NodeAggregateResult_Success_DEFAULT
 	}
-	if err != nil {
-		return
+	return p.Success
+}
+
+var NodeAggregateResult_Err_DEFAULT *Error
+
+func (p *NodeAggregateResult) GetErr() *Error {
+	if !p.IsSetErr() {
+		return NodeAggregateResult_Err_DEFAULT
 	}
-	return true, err
+	return p.Err
+}
+func (p *NodeAggregateResult) IsSetSuccess() bool {
+	return p.Success != nil
 }
 
-type nodeProcessorSetWriteNewSeriesAsync struct {
-	handler Node
+func (p *NodeAggregateResult) IsSetErr() bool {
+	return p.Err != nil
 }
 
-func (p *nodeProcessorSetWriteNewSeriesAsync) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeSetWriteNewSeriesAsyncArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeAggregateResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	iprot.ReadMessageEnd()
-	result := NodeSetWriteNewSeriesAsyncResult{}
-	var retval *NodeWriteNewSeriesAsyncResult_
-	var err2 error
-	if retval, err2 = p.handler.SetWriteNewSeriesAsync(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesAsync: "+err2.Error())
-			oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesAsync", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
 	}
-	if err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return true, err
+	return nil
 }
 
-type nodeProcessorGetWriteNewSeriesBackoffDuration struct {
-	handler Node
+func (p *NodeAggregateResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &AggregateQueryResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
 }
 
-func (p *nodeProcessorGetWriteNewSeriesBackoffDuration) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeGetWriteNewSeriesBackoffDurationArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeAggregateResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
+	}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeGetWriteNewSeriesBackoffDurationResult{}
-	var retval *NodeWriteNewSeriesBackoffDurationResult_
-	var err2 error
-	if retval, err2 = p.handler.GetWriteNewSeriesBackoffDuration(); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesBackoffDuration: "+err2.Error())
-			oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+func (p *NodeAggregateResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("aggregate_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesBackoffDuration", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *NodeAggregateResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return err
+}
+
+func (p *NodeAggregateResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:err: ", p), err)
+		}
 	}
-	if err != nil {
-		return
+	return err
+}
+
+func (p *NodeAggregateResult) String() string {
+	if p == nil {
+		return "<nil>"
 	}
-	return true, err
+	return fmt.Sprintf("NodeAggregateResult(%+v)", *p)
 }
 
-type nodeProcessorSetWriteNewSeriesBackoffDuration struct {
-	handler Node
+// Attributes:
+//  - Req
+type NodeFetchArgs struct {
+	Req *FetchRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func (p *nodeProcessorSetWriteNewSeriesBackoffDuration) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeSetWriteNewSeriesBackoffDurationArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
-	}
+func NewNodeFetchArgs() *NodeFetchArgs {
+	return &NodeFetchArgs{}
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeSetWriteNewSeriesBackoffDurationResult{}
-	var retval *NodeWriteNewSeriesBackoffDurationResult_
-	var err2 error
-	if retval, err2 = p.handler.SetWriteNewSeriesBackoffDuration(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesBackoffDuration: "+err2.Error())
-			oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesBackoffDuration", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err != nil {
-		return
+var NodeFetchArgs_Req_DEFAULT *FetchRequest
+
+func (p *NodeFetchArgs) GetReq() *FetchRequest {
+	if !p.IsSetReq() {
+		return NodeFetchArgs_Req_DEFAULT
 	}
-	return true, err
+	return p.Req
 }
-
-type nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond struct {
-	handler Node
+func (p *NodeFetchArgs) IsSetReq() bool {
+	return p.Req != nil
 }
 
-func (p *nodeProcessorGetWriteNewSeriesLimitPerShardPerSecond) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeGetWriteNewSeriesLimitPerShardPerSecondArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeFetchArgs) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	iprot.ReadMessageEnd()
-	result := NodeGetWriteNewSeriesLimitPerShardPerSecondResult{}
-	var retval *NodeWriteNewSeriesLimitPerShardPerSecondResult_
-	var err2 error
-	if retval, err2 = p.handler.GetWriteNewSeriesLimitPerShardPerSecond(); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing getWriteNewSeriesLimitPerShardPerSecond: "+err2.Error())
-			oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("getWriteNewSeriesLimitPerShardPerSecond", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
 	}
-	if err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return true, err
+	return nil
 }
 
-type nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond struct {
-	handler Node
-}
+func (p *NodeFetchArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchRequest{
+		RangeType: 0,
 
-func (p *nodeProcessorSetWriteNewSeriesLimitPerShardPerSecond) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeSetWriteNewSeriesLimitPerShardPerSecondArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+		ResultTimeType: 0,
+	}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeSetWriteNewSeriesLimitPerShardPerSecondResult{}
-	var retval *NodeWriteNewSeriesLimitPerShardPerSecondResult_
-	var err2 error
-	if retval, err2 = p.handler.SetWriteNewSeriesLimitPerShardPerSecond(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing setWriteNewSeriesLimitPerShardPerSecond: "+err2.Error())
-			oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+func (p *NodeFetchArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetch_args"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
+	}
+	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
 	}
-	if err2 = oprot.WriteMessageBegin("setWriteNewSeriesLimitPerShardPerSecond", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *NodeFetchArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
 	}
-	if err != nil {
-		return
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
 	}
-	return true, err
-}
-
-type nodeProcessorDebugProfileStart struct {
-	handler Node
+	return err
 }
 
-func (p *nodeProcessorDebugProfileStart) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeDebugProfileStartArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("debugProfileStart", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeFetchArgs) String() string {
+	if p == nil {
+		return "<nil>"
 	}
+	return fmt.Sprintf("NodeFetchArgs(%+v)", *p)
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeDebugProfileStartResult{}
-	var retval *DebugProfileStartResult_
-	var err2 error
-	if retval, err2 = p.handler.DebugProfileStart(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing debugProfileStart: "+err2.Error())
-			oprot.WriteMessageBegin("debugProfileStart", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("debugProfileStart", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+// Attributes:
+//  - Success
+//  - Err
+type NodeFetchResult struct {
+	Success *FetchResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error        `thrift:"err,1" db:"err" json:"err,omitempty"`
+}
+
+func NewNodeFetchResult() *NodeFetchResult {
+	return &NodeFetchResult{}
+}
+
+var NodeFetchResult_Success_DEFAULT *FetchResult_
+
+func (p *NodeFetchResult) GetSuccess() *FetchResult_ {
+	if !p.IsSetSuccess() {
+		return NodeFetchResult_Success_DEFAULT
 	}
-	if err != nil {
-		return
+	return p.Success
+}
+
+var NodeFetchResult_Err_DEFAULT *Error
+
+func (p *NodeFetchResult) GetErr() *Error {
+	if !p.IsSetErr() {
+		return NodeFetchResult_Err_DEFAULT
 	}
-	return true, err
+	return p.Err
+}
+func (p *NodeFetchResult) IsSetSuccess() bool {
+	return p.Success != nil
 }
 
-type nodeProcessorDebugProfileStop struct {
-	handler Node
+func (p *NodeFetchResult) IsSetErr() bool {
+	return p.Err != nil
 }
 
-func (p *nodeProcessorDebugProfileStop) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeDebugProfileStopArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("debugProfileStop", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeFetchResult) Read(iprot thrift.TProtocol) error {
+	if _, err := iprot.ReadStructBegin(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
 
-	iprot.ReadMessageEnd()
-	result := NodeDebugProfileStopResult{}
-	var retval *DebugProfileStopResult_
-	var err2 error
-	if retval, err2 = p.handler.DebugProfileStop(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
+	for {
+		_, fieldTypeId, fieldId, err := iprot.ReadFieldBegin()
+		if err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T field %d read error: ", p, fieldId), err)
+		}
+		if fieldTypeId == thrift.STOP {
+			break
+		}
+		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
 		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing debugProfileStop: "+err2.Error())
-			oprot.WriteMessageBegin("debugProfileStop", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
+		}
+		if err := iprot.ReadFieldEnd(); err != nil {
+			return err
 		}
-	} else {
-		result.Success = retval
-	}
-	if err2 = oprot.WriteMessageBegin("debugProfileStop", thrift.REPLY, seqId); err2 != nil {
-		err = err2
-	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
-	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
 	}
-	if err != nil {
-		return
+	if err := iprot.ReadStructEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T read struct end error: ", p), err)
 	}
-	return true, err
+	return nil
 }
 
-type nodeProcessorDebugIndexMemorySegments struct {
-	handler Node
+func (p *NodeFetchResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
 }
 
-func (p *nodeProcessorDebugIndexMemorySegments) Process(seqId int32, iprot, oprot thrift.TProtocol) (success bool, err thrift.TException) {
-	args := NodeDebugIndexMemorySegmentsArgs{}
-	if err = args.Read(iprot); err != nil {
-		iprot.ReadMessageEnd()
-		x := thrift.NewTApplicationException(thrift.PROTOCOL_ERROR, err.Error())
-		oprot.WriteMessageBegin("debugIndexMemorySegments", thrift.EXCEPTION, seqId)
-		x.Write(oprot)
-		oprot.WriteMessageEnd()
-		oprot.Flush()
-		return false, err
+func (p *NodeFetchResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
 	}
+	if err := p.Err.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
+	}
+	return nil
+}
 
-	iprot.ReadMessageEnd()
-	result := NodeDebugIndexMemorySegmentsResult{}
-	var retval *DebugIndexMemorySegmentsResult_
-	var err2 error
-	if retval, err2 = p.handler.DebugIndexMemorySegments(args.Req); err2 != nil {
-		switch v := err2.(type) {
-		case *Error:
-			result.Err = v
-		default:
-			x := thrift.NewTApplicationException(thrift.INTERNAL_ERROR, "Internal error processing debugIndexMemorySegments: "+err2.Error())
-			oprot.WriteMessageBegin("debugIndexMemorySegments", thrift.EXCEPTION, seqId)
-			x.Write(oprot)
-			oprot.WriteMessageEnd()
-			oprot.Flush()
-			return true, err2
-		}
-	} else {
-		result.Success = retval
+func (p *NodeFetchResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetch_result"); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
-	if err2 = oprot.WriteMessageBegin("debugIndexMemorySegments", thrift.REPLY, seqId); err2 != nil {
-		err = err2
+	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
 	}
-	if err2 = result.Write(oprot); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteFieldStop(); err != nil {
+		return thrift.PrependError("write field stop error: ", err)
 	}
-	if err2 = oprot.WriteMessageEnd(); err == nil && err2 != nil {
-		err = err2
+	if err := oprot.WriteStructEnd(); err != nil {
+		return thrift.PrependError("write struct stop error: ", err)
 	}
-	if err2 = oprot.Flush(); err == nil && err2 != nil {
-		err = err2
+	return nil
+}
+
+func (p *NodeFetchResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
 	}
-	if err != nil {
-		return
+	return err
+}
+
+func (p *NodeFetchResult) writeField1(oprot thrift.TProtocol) (err error) {
+	if p.IsSetErr() {
+		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
+		}
+		if err := p.Err.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Err), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 1:err: ", p), err)
+		}
 	}
-	return true, err
+	return err
 }
 
-// HELPER FUNCTIONS AND STRUCTURES
+func (p *NodeFetchResult) String() string {
+	if p == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("NodeFetchResult(%+v)", *p)
+}
 
 // Attributes:
 //  - Req
-type NodeQueryArgs struct {
-	Req *QueryRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteArgs struct {
+	Req *WriteRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeQueryArgs() *NodeQueryArgs {
-	return &NodeQueryArgs{}
+func NewNodeWriteArgs() *NodeWriteArgs {
+	return &NodeWriteArgs{}
 }
 
-var NodeQueryArgs_Req_DEFAULT *QueryRequest
+var NodeWriteArgs_Req_DEFAULT *WriteRequest
 
-func (p *NodeQueryArgs) GetReq() *QueryRequest {
+func (p *NodeWriteArgs) GetReq() *WriteRequest {
 	if !p.IsSetReq() {
-		return NodeQueryArgs_Req_DEFAULT
+		return NodeWriteArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeQueryArgs) IsSetReq() bool {
+func (p *NodeWriteArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeQueryArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -19804,20 +22121,16 @@ func (p *NodeQueryArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &QueryRequest{
-		RangeType: 0,
-
-		ResultTimeType: 0,
-	}
+func (p *NodeWriteArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeQueryArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("query_args"); err != nil {
+func (p *NodeWriteArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("write_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -19834,7 +22147,7 @@ func (p *NodeQueryArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -19847,51 +22160,36 @@ func (p *NodeQueryArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeQueryArgs) String() string {
+func (p *NodeWriteArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeQueryArgs(%+v)", *p)
+	return fmt.Sprintf("NodeWriteArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - Err
-type NodeQueryResult struct {
-	Success *QueryResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error        `thrift:"err,1" db:"err" json:"err,omitempty"`
-}
-
-func NewNodeQueryResult() *NodeQueryResult {
-	return &NodeQueryResult{}
+type NodeWriteResult struct {
+	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-var NodeQueryResult_Success_DEFAULT *QueryResult_
-
-func (p *NodeQueryResult) GetSuccess() *QueryResult_ {
-	if !p.IsSetSuccess() {
-		return NodeQueryResult_Success_DEFAULT
-	}
-	return p.Success
+func NewNodeWriteResult() *NodeWriteResult {
+	return &NodeWriteResult{}
 }
 
-var NodeQueryResult_Err_DEFAULT *Error
+var NodeWriteResult_Err_DEFAULT *Error
 
-func (p *NodeQueryResult) GetErr() *Error {
+func (p *NodeWriteResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeQueryResult_Err_DEFAULT
+		return NodeWriteResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeQueryResult) IsSetSuccess() bool {
-	return p.Success != nil
-}
-
-func (p *NodeQueryResult) IsSetErr() bool {
+func (p *NodeWriteResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeQueryResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -19905,10 +22203,6 @@ func (p *NodeQueryResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
-		case 0:
-			if err := p.ReadField0(iprot); err != nil {
-				return err
-			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -19928,15 +22222,7 @@ func (p *NodeQueryResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &QueryResult_{}
-	if err := p.Success.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-	}
-	return nil
-}
-
-func (p *NodeQueryResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeWriteResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -19946,14 +22232,11 @@ func (p *NodeQueryResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("query_result"); err != nil {
+func (p *NodeWriteResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("write_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField0(oprot); err != nil {
-			return err
-		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -19967,22 +22250,7 @@ func (p *NodeQueryResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeQueryResult) writeField0(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
-		}
-		if err := p.Success.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeQueryResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -19997,36 +22265,36 @@ func (p *NodeQueryResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeQueryResult) String() string {
+func (p *NodeWriteResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeQueryResult(%+v)", *p)
+	return fmt.Sprintf("NodeWriteResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeAggregateArgs struct {
-	Req *AggregateQueryRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteTaggedArgs struct {
+	Req *WriteTaggedRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeAggregateArgs() *NodeAggregateArgs {
-	return &NodeAggregateArgs{}
+func NewNodeWriteTaggedArgs() *NodeWriteTaggedArgs {
+	return &NodeWriteTaggedArgs{}
 }
 
-var NodeAggregateArgs_Req_DEFAULT *AggregateQueryRequest
+var NodeWriteTaggedArgs_Req_DEFAULT *WriteTaggedRequest
 
-func (p *NodeAggregateArgs) GetReq() *AggregateQueryRequest {
+func (p *NodeWriteTaggedArgs) GetReq() *WriteTaggedRequest {
 	if !p.IsSetReq() {
-		return NodeAggregateArgs_Req_DEFAULT
+		return NodeWriteTaggedArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeAggregateArgs) IsSetReq() bool {
+func (p *NodeWriteTaggedArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeAggregateArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -20059,20 +22327,16 @@ func (p *NodeAggregateArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &AggregateQueryRequest{
-		AggregateQueryType: 1,
-
-		RangeType: 0,
-	}
+func (p *NodeWriteTaggedArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteTaggedRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeAggregateArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("aggregate_args"); err != nil {
+func (p *NodeWriteTaggedArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTagged_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -20089,7 +22353,7 @@ func (p *NodeAggregateArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -20102,51 +22366,36 @@ func (p *NodeAggregateArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeAggregateArgs) String() string {
+func (p *NodeWriteTaggedArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeAggregateArgs(%+v)", *p)
+	return fmt.Sprintf("NodeWriteTaggedArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - Err
-type NodeAggregateResult struct {
-	Success *AggregateQueryResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                 `thrift:"err,1" db:"err" json:"err,omitempty"`
-}
-
-func NewNodeAggregateResult() *NodeAggregateResult {
-	return &NodeAggregateResult{}
+type NodeWriteTaggedResult struct {
+	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-var NodeAggregateResult_Success_DEFAULT *AggregateQueryResult_
-
-func (p *NodeAggregateResult) GetSuccess() *AggregateQueryResult_ {
-	if !p.IsSetSuccess() {
-		return NodeAggregateResult_Success_DEFAULT
-	}
-	return p.Success
+func NewNodeWriteTaggedResult() *NodeWriteTaggedResult {
+	return &NodeWriteTaggedResult{}
 }
 
-var NodeAggregateResult_Err_DEFAULT *Error
+var NodeWriteTaggedResult_Err_DEFAULT *Error
 
-func (p *NodeAggregateResult) GetErr() *Error {
+func (p *NodeWriteTaggedResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeAggregateResult_Err_DEFAULT
+		return NodeWriteTaggedResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeAggregateResult) IsSetSuccess() bool {
-	return p.Success != nil
-}
-
-func (p *NodeAggregateResult) IsSetErr() bool {
+func (p *NodeWriteTaggedResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeAggregateResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -20160,10 +22409,6 @@ func (p *NodeAggregateResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
-		case 0:
-			if err := p.ReadField0(iprot); err != nil {
-				return err
-			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -20183,15 +22428,7 @@ func (p *NodeAggregateResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &AggregateQueryResult_{}
-	if err := p.Success.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-	}
-	return nil
-}
-
-func (p *NodeAggregateResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -20201,14 +22438,11 @@ func (p *NodeAggregateResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("aggregate_result"); err != nil {
+func (p *NodeWriteTaggedResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTagged_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField0(oprot); err != nil {
-			return err
-		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -20222,22 +22456,7 @@ func (p *NodeAggregateResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateResult) writeField0(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
-		}
-		if err := p.Success.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeAggregateResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -20252,36 +22471,36 @@ func (p *NodeAggregateResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeAggregateResult) String() string {
+func (p *NodeWriteTaggedResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeAggregateResult(%+v)", *p)
+	return fmt.Sprintf("NodeWriteTaggedResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchArgs struct {
-	Req *FetchRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeAggregateRawArgs struct {
+	Req *AggregateQueryRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchArgs() *NodeFetchArgs {
-	return &NodeFetchArgs{}
+func NewNodeAggregateRawArgs() *NodeAggregateRawArgs {
+	return &NodeAggregateRawArgs{}
 }
 
-var NodeFetchArgs_Req_DEFAULT *FetchRequest
+var NodeAggregateRawArgs_Req_DEFAULT *AggregateQueryRawRequest
 
-func (p *NodeFetchArgs) GetReq() *FetchRequest {
+func (p *NodeAggregateRawArgs) GetReq() *AggregateQueryRawRequest {
 	if !p.IsSetReq() {
-		return NodeFetchArgs_Req_DEFAULT
+		return NodeAggregateRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchArgs) IsSetReq() bool {
+func (p *NodeAggregateRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeAggregateRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -20314,11 +22533,11 @@ func (p *NodeFetchArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchRequest{
-		RangeType: 0,
+func (p *NodeAggregateRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &AggregateQueryRawRequest{
+		AggregateQueryType: 1,
 
-		ResultTimeType: 0,
+		RangeType: 0,
 	}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
@@ -20326,8 +22545,8 @@ func (p *NodeFetchArgs) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetch_args"); err != nil {
+func (p *NodeAggregateRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("aggregateRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -20344,7 +22563,7 @@ func (p *NodeFetchArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeAggregateRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -20357,51 +22576,51 @@ func (p *NodeFetchArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeFetchArgs) String() string {
+func (p *NodeAggregateRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchArgs(%+v)", *p)
+	return fmt.Sprintf("NodeAggregateRawArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Err
-type NodeFetchResult struct {
-	Success *FetchResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error        `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeAggregateRawResult struct {
+	Success *AggregateQueryRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                    `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeFetchResult() *NodeFetchResult {
-	return &NodeFetchResult{}
+func NewNodeAggregateRawResult() *NodeAggregateRawResult {
+	return &NodeAggregateRawResult{}
 }
 
-var NodeFetchResult_Success_DEFAULT *FetchResult_
+var NodeAggregateRawResult_Success_DEFAULT *AggregateQueryRawResult_
 
-func (p *NodeFetchResult) GetSuccess() *FetchResult_ {
+func (p *NodeAggregateRawResult) GetSuccess() *AggregateQueryRawResult_ {
 	if !p.IsSetSuccess() {
-		return NodeFetchResult_Success_DEFAULT
+		return NodeAggregateRawResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var NodeFetchResult_Err_DEFAULT *Error
+var NodeAggregateRawResult_Err_DEFAULT *Error
 
-func (p *NodeFetchResult) GetErr() *Error {
+func (p *NodeAggregateRawResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeFetchResult_Err_DEFAULT
+		return NodeAggregateRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchResult) IsSetSuccess() bool {
+func (p *NodeAggregateRawResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *NodeFetchResult) IsSetErr() bool {
+func (p *NodeAggregateRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeAggregateRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -20438,15 +22657,15 @@ func (p *NodeFetchResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchResult_{}
+func (p *NodeAggregateRawResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &AggregateQueryRawResult_{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeAggregateRawResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -20456,8 +22675,8 @@ func (p *NodeFetchResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetch_result"); err != nil {
+func (p *NodeAggregateRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("aggregateRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -20477,7 +22696,7 @@ func (p *NodeFetchResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *NodeAggregateRawResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -20492,7 +22711,7 @@ func (p *NodeFetchResult) writeField0(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeFetchResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeAggregateRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -20507,36 +22726,36 @@ func (p *NodeFetchResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeFetchResult) String() string {
+func (p *NodeAggregateRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchResult(%+v)", *p)
+	return fmt.Sprintf("NodeAggregateRawResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeWriteArgs struct {
-	Req *WriteRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchBatchRawArgs struct {
+	Req *FetchBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeWriteArgs() *NodeWriteArgs {
-	return &NodeWriteArgs{}
+func NewNodeFetchBatchRawArgs() *NodeFetchBatchRawArgs {
+	return &NodeFetchBatchRawArgs{}
 }
 
-var NodeWriteArgs_Req_DEFAULT *WriteRequest
+var NodeFetchBatchRawArgs_Req_DEFAULT *FetchBatchRawRequest
 
-func (p *NodeWriteArgs) GetReq() *WriteRequest {
+func (p *NodeFetchBatchRawArgs) GetReq() *FetchBatchRawRequest {
 	if !p.IsSetReq() {
-		return NodeWriteArgs_Req_DEFAULT
+		return NodeFetchBatchRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeWriteArgs) IsSetReq() bool {
+func (p *NodeFetchBatchRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeWriteArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -20569,16 +22788,18 @@ func (p *NodeWriteArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteRequest{}
+func (p *NodeFetchBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchBatchRawRequest{
+		RangeTimeType: 0,
+	}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("write_args"); err != nil {
+func (p *NodeFetchBatchRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBatchRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -20595,7 +22816,7 @@ func (p *NodeWriteArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -20608,36 +22829,51 @@ func (p *NodeWriteArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeWriteArgs) String() string {
+func (p *NodeFetchBatchRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteArgs(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBatchRawArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Err
-type NodeWriteResult struct {
-	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchBatchRawResult struct {
+	Success *FetchBatchRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteResult() *NodeWriteResult {
-	return &NodeWriteResult{}
+func NewNodeFetchBatchRawResult() *NodeFetchBatchRawResult {
+	return &NodeFetchBatchRawResult{}
 }
 
-var NodeWriteResult_Err_DEFAULT *Error
+var NodeFetchBatchRawResult_Success_DEFAULT *FetchBatchRawResult_
 
-func (p *NodeWriteResult) GetErr() *Error {
+func (p *NodeFetchBatchRawResult) GetSuccess() *FetchBatchRawResult_ {
+	if !p.IsSetSuccess() {
+		return NodeFetchBatchRawResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeFetchBatchRawResult_Err_DEFAULT *Error
+
+func (p *NodeFetchBatchRawResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeWriteResult_Err_DEFAULT
+		return NodeFetchBatchRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteResult) IsSetErr() bool {
+func (p *NodeFetchBatchRawResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeFetchBatchRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBatchRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -20651,6 +22887,10 @@ func (p *NodeWriteResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -20670,7 +22910,15 @@ func (p *NodeWriteResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchBatchRawResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchBatchRawResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *NodeFetchBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -20680,11 +22928,14 @@ func (p *NodeWriteResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("write_result"); err != nil {
+func (p *NodeFetchBatchRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBatchRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -20698,7 +22949,22 @@ func (p *NodeWriteResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBatchRawResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *NodeFetchBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -20713,36 +22979,36 @@ func (p *NodeWriteResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeWriteResult) String() string {
+func (p *NodeFetchBatchRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteResult(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBatchRawResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeWriteTaggedArgs struct {
-	Req *WriteTaggedRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchBatchRawV2Args struct {
+	Req *FetchBatchRawV2Request `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeWriteTaggedArgs() *NodeWriteTaggedArgs {
-	return &NodeWriteTaggedArgs{}
+func NewNodeFetchBatchRawV2Args() *NodeFetchBatchRawV2Args {
+	return &NodeFetchBatchRawV2Args{}
 }
 
-var NodeWriteTaggedArgs_Req_DEFAULT *WriteTaggedRequest
+var NodeFetchBatchRawV2Args_Req_DEFAULT *FetchBatchRawV2Request
 
-func (p *NodeWriteTaggedArgs) GetReq() *WriteTaggedRequest {
+func (p *NodeFetchBatchRawV2Args) GetReq() *FetchBatchRawV2Request {
 	if !p.IsSetReq() {
-		return NodeWriteTaggedArgs_Req_DEFAULT
+		return NodeFetchBatchRawV2Args_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeWriteTaggedArgs) IsSetReq() bool {
+func (p *NodeFetchBatchRawV2Args) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeWriteTaggedArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBatchRawV2Args) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -20775,16 +23041,16 @@ func (p *NodeWriteTaggedArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteTaggedRequest{}
+func (p *NodeFetchBatchRawV2Args) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchBatchRawV2Request{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteTaggedArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTagged_args"); err != nil {
+func (p *NodeFetchBatchRawV2Args) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBatchRawV2_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -20801,7 +23067,7 @@ func (p *NodeWriteTaggedArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBatchRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -20814,36 +23080,51 @@ func (p *NodeWriteTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeWriteTaggedArgs) String() string {
+func (p *NodeFetchBatchRawV2Args) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedArgs(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBatchRawV2Args(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Err
-type NodeWriteTaggedResult struct {
-	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchBatchRawV2Result struct {
+	Success *FetchBatchRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteTaggedResult() *NodeWriteTaggedResult {
-	return &NodeWriteTaggedResult{}
+func NewNodeFetchBatchRawV2Result() *NodeFetchBatchRawV2Result {
+	return &NodeFetchBatchRawV2Result{}
 }
 
-var NodeWriteTaggedResult_Err_DEFAULT *Error
+var NodeFetchBatchRawV2Result_Success_DEFAULT *FetchBatchRawResult_
 
-func (p *NodeWriteTaggedResult) GetErr() *Error {
+func (p *NodeFetchBatchRawV2Result) GetSuccess() *FetchBatchRawResult_ {
+	if !p.IsSetSuccess() {
+		return NodeFetchBatchRawV2Result_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeFetchBatchRawV2Result_Err_DEFAULT *Error
+
+func (p *NodeFetchBatchRawV2Result) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeWriteTaggedResult_Err_DEFAULT
+		return NodeFetchBatchRawV2Result_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteTaggedResult) IsSetErr() bool {
+func (p *NodeFetchBatchRawV2Result) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeFetchBatchRawV2Result) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteTaggedResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBatchRawV2Result) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -20857,6 +23138,10 @@ func (p *NodeWriteTaggedResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -20876,7 +23161,15 @@ func (p *NodeWriteTaggedResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchBatchRawV2Result) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchBatchRawResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *NodeFetchBatchRawV2Result) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -20886,11 +23179,14 @@ func (p *NodeWriteTaggedResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTagged_result"); err != nil {
+func (p *NodeFetchBatchRawV2Result) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBatchRawV2_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -20904,7 +23200,22 @@ func (p *NodeWriteTaggedResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBatchRawV2Result) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *NodeFetchBatchRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -20919,36 +23230,36 @@ func (p *NodeWriteTaggedResult) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeWriteTaggedResult) String() string {
+func (p *NodeFetchBatchRawV2Result) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedResult(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBatchRawV2Result(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeAggregateRawArgs struct {
-	Req *AggregateQueryRawRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchBlocksRawArgs struct {
+	Req *FetchBlocksRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeAggregateRawArgs() *NodeAggregateRawArgs {
-	return &NodeAggregateRawArgs{}
+func NewNodeFetchBlocksRawArgs() *NodeFetchBlocksRawArgs {
+	return &NodeFetchBlocksRawArgs{}
 }
 
-var NodeAggregateRawArgs_Req_DEFAULT *AggregateQueryRawRequest
+var NodeFetchBlocksRawArgs_Req_DEFAULT *FetchBlocksRawRequest
 
-func (p *NodeAggregateRawArgs) GetReq() *AggregateQueryRawRequest {
+func (p *NodeFetchBlocksRawArgs) GetReq() *FetchBlocksRawRequest {
 	if !p.IsSetReq() {
-		return NodeAggregateRawArgs_Req_DEFAULT
+		return NodeFetchBlocksRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeAggregateRawArgs) IsSetReq() bool {
+func (p *NodeFetchBlocksRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeAggregateRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -20981,20 +23292,16 @@ func (p *NodeAggregateRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &AggregateQueryRawRequest{
-		AggregateQueryType: 1,
-
-		RangeType: 0,
-	}
+func (p *NodeFetchBlocksRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchBlocksRawRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeAggregateRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("aggregateRaw_args"); err != nil {
+func (p *NodeFetchBlocksRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -21011,7 +23318,7 @@ func (p *NodeAggregateRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -21024,51 +23331,51 @@ func (p *NodeAggregateRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeAggregateRawArgs) String() string {
+func (p *NodeFetchBlocksRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeAggregateRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksRawArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Err
-type NodeAggregateRawResult struct {
-	Success *AggregateQueryRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                    `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchBlocksRawResult struct {
+	Success *FetchBlocksRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                 `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeAggregateRawResult() *NodeAggregateRawResult {
-	return &NodeAggregateRawResult{}
+func NewNodeFetchBlocksRawResult() *NodeFetchBlocksRawResult {
+	return &NodeFetchBlocksRawResult{}
 }
 
-var NodeAggregateRawResult_Success_DEFAULT *AggregateQueryRawResult_
+var NodeFetchBlocksRawResult_Success_DEFAULT *FetchBlocksRawResult_
 
-func (p *NodeAggregateRawResult) GetSuccess() *AggregateQueryRawResult_ {
+func (p *NodeFetchBlocksRawResult) GetSuccess() *FetchBlocksRawResult_ {
 	if !p.IsSetSuccess() {
-		return NodeAggregateRawResult_Success_DEFAULT
+		return NodeFetchBlocksRawResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var NodeAggregateRawResult_Err_DEFAULT *Error
+var NodeFetchBlocksRawResult_Err_DEFAULT *Error
 
-func (p *NodeAggregateRawResult) GetErr() *Error {
+func (p *NodeFetchBlocksRawResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeAggregateRawResult_Err_DEFAULT
+		return NodeFetchBlocksRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeAggregateRawResult) IsSetSuccess() bool {
+func (p *NodeFetchBlocksRawResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *NodeAggregateRawResult) IsSetErr() bool {
+func (p *NodeFetchBlocksRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeAggregateRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -21105,15 +23412,15 @@ func (p *NodeAggregateRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateRawResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &AggregateQueryRawResult_{}
+func (p *NodeFetchBlocksRawResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchBlocksRawResult_{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *NodeAggregateRawResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksRawResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -21123,8 +23430,8 @@ func (p *NodeAggregateRawResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("aggregateRaw_result"); err != nil {
+func (p *NodeFetchBlocksRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -21144,7 +23451,7 @@ func (p *NodeAggregateRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeAggregateRawResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksRawResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -21159,7 +23466,7 @@ func (p *NodeAggregateRawResult) writeField0(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeAggregateRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -21174,36 +23481,36 @@ func (p *NodeAggregateRawResult) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeAggregateRawResult) String() string {
+func (p *NodeFetchBlocksRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeAggregateRawResult(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksRawResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchBatchRawArgs struct {
-	Req *FetchBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchTaggedArgs struct {
+	Req *FetchTaggedRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchBatchRawArgs() *NodeFetchBatchRawArgs {
-	return &NodeFetchBatchRawArgs{}
+func NewNodeFetchTaggedArgs() *NodeFetchTaggedArgs {
+	return &NodeFetchTaggedArgs{}
 }
 
-var NodeFetchBatchRawArgs_Req_DEFAULT *FetchBatchRawRequest
+var NodeFetchTaggedArgs_Req_DEFAULT *FetchTaggedRequest
 
-func (p *NodeFetchBatchRawArgs) GetReq() *FetchBatchRawRequest {
+func (p *NodeFetchTaggedArgs) GetReq() *FetchTaggedRequest {
 	if !p.IsSetReq() {
-		return NodeFetchBatchRawArgs_Req_DEFAULT
+		return NodeFetchTaggedArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchBatchRawArgs) IsSetReq() bool {
+func (p *NodeFetchTaggedArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchBatchRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchTaggedArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -21236,9 +23543,11 @@ func (p *NodeFetchBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchBatchRawRequest{
+func (p *NodeFetchTaggedArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchTaggedRequest{
 		RangeTimeType: 0,
+
+		RequireExhaustive: true,
 	}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
@@ -21246,8 +23555,8 @@ func (p *NodeFetchBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBatchRaw_args"); err != nil {
+func (p *NodeFetchTaggedArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchTagged_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -21264,7 +23573,7 @@ func (p *NodeFetchBatchRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -21277,51 +23586,51 @@ func (p *NodeFetchBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeFetchBatchRawArgs) String() string {
+func (p *NodeFetchTaggedArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBatchRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeFetchTaggedArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Err
-type NodeFetchBatchRawResult struct {
-	Success *FetchBatchRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchTaggedResult struct {
+	Success *FetchTaggedResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error              `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeFetchBatchRawResult() *NodeFetchBatchRawResult {
-	return &NodeFetchBatchRawResult{}
+func NewNodeFetchTaggedResult() *NodeFetchTaggedResult {
+	return &NodeFetchTaggedResult{}
 }
 
-var NodeFetchBatchRawResult_Success_DEFAULT *FetchBatchRawResult_
+var NodeFetchTaggedResult_Success_DEFAULT *FetchTaggedResult_
 
-func (p *NodeFetchBatchRawResult) GetSuccess() *FetchBatchRawResult_ {
+func (p *NodeFetchTaggedResult) GetSuccess() *FetchTaggedResult_ {
 	if !p.IsSetSuccess() {
-		return NodeFetchBatchRawResult_Success_DEFAULT
+		return NodeFetchTaggedResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var NodeFetchBatchRawResult_Err_DEFAULT *Error
+var NodeFetchTaggedResult_Err_DEFAULT *Error
 
-func (p *NodeFetchBatchRawResult) GetErr() *Error {
+func (p *NodeFetchTaggedResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeFetchBatchRawResult_Err_DEFAULT
+		return NodeFetchTaggedResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchBatchRawResult) IsSetSuccess() bool {
+func (p *NodeFetchTaggedResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *NodeFetchBatchRawResult) IsSetErr() bool {
+func (p *NodeFetchTaggedResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchBatchRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchTaggedResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -21358,15 +23667,15 @@ func (p *NodeFetchBatchRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchBatchRawResult_{}
+func (p *NodeFetchTaggedResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchTaggedResult_{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchTaggedResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -21376,8 +23685,8 @@ func (p *NodeFetchBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBatchRaw_result"); err != nil {
+func (p *NodeFetchTaggedResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchTagged_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -21397,7 +23706,7 @@ func (p *NodeFetchBatchRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchTaggedResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -21412,7 +23721,7 @@ func (p *NodeFetchBatchRawResult) writeField0(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *NodeFetchBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchTaggedResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -21427,36 +23736,36 @@ func (p *NodeFetchBatchRawResult) writeField1(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *NodeFetchBatchRawResult) String() string {
+func (p *NodeFetchTaggedResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBatchRawResult(%+v)", *p)
+	return fmt.Sprintf("NodeFetchTaggedResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchBatchRawV2Args struct {
-	Req *FetchBatchRawV2Request `thrift:"req,1" db:"req" json:"req"`
+type NodeFetchBlocksMetadataRawV2Args struct {
+	Req *FetchBlocksMetadataRawV2Request `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchBatchRawV2Args() *NodeFetchBatchRawV2Args {
-	return &NodeFetchBatchRawV2Args{}
+func NewNodeFetchBlocksMetadataRawV2Args() *NodeFetchBlocksMetadataRawV2Args {
+	return &NodeFetchBlocksMetadataRawV2Args{}
 }
 
-var NodeFetchBatchRawV2Args_Req_DEFAULT *FetchBatchRawV2Request
+var NodeFetchBlocksMetadataRawV2Args_Req_DEFAULT *FetchBlocksMetadataRawV2Request
 
-func (p *NodeFetchBatchRawV2Args) GetReq() *FetchBatchRawV2Request {
+func (p *NodeFetchBlocksMetadataRawV2Args) GetReq() *FetchBlocksMetadataRawV2Request {
 	if !p.IsSetReq() {
-		return NodeFetchBatchRawV2Args_Req_DEFAULT
+		return NodeFetchBlocksMetadataRawV2Args_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchBatchRawV2Args) IsSetReq() bool {
+func (p *NodeFetchBlocksMetadataRawV2Args) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchBatchRawV2Args) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksMetadataRawV2Args) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -21489,16 +23798,16 @@ func (p *NodeFetchBatchRawV2Args) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawV2Args) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchBatchRawV2Request{}
+func (p *NodeFetchBlocksMetadataRawV2Args) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &FetchBlocksMetadataRawV2Request{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBatchRawV2Args) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBatchRawV2_args"); err != nil {
+func (p *NodeFetchBlocksMetadataRawV2Args) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksMetadataRawV2_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -21515,7 +23824,7 @@ func (p *NodeFetchBatchRawV2Args) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksMetadataRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -21528,51 +23837,51 @@ func (p *NodeFetchBatchRawV2Args) writeField1(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *NodeFetchBatchRawV2Args) String() string {
+func (p *NodeFetchBlocksMetadataRawV2Args) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBatchRawV2Args(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksMetadataRawV2Args(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Err
-type NodeFetchBatchRawV2Result struct {
-	Success *FetchBatchRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeFetchBlocksMetadataRawV2Result struct {
+	Success *FetchBlocksMetadataRawV2Result_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error                           `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeFetchBatchRawV2Result() *NodeFetchBatchRawV2Result {
-	return &NodeFetchBatchRawV2Result{}
+func NewNodeFetchBlocksMetadataRawV2Result() *NodeFetchBlocksMetadataRawV2Result {
+	return &NodeFetchBlocksMetadataRawV2Result{}
 }
 
-var NodeFetchBatchRawV2Result_Success_DEFAULT *FetchBatchRawResult_
+var NodeFetchBlocksMetadataRawV2Result_Success_DEFAULT *FetchBlocksMetadataRawV2Result_
 
-func (p *NodeFetchBatchRawV2Result) GetSuccess() *FetchBatchRawResult_ {
+func (p *NodeFetchBlocksMetadataRawV2Result) GetSuccess() *FetchBlocksMetadataRawV2Result_ {
 	if !p.IsSetSuccess() {
-		return NodeFetchBatchRawV2Result_Success_DEFAULT
+		return NodeFetchBlocksMetadataRawV2Result_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var NodeFetchBatchRawV2Result_Err_DEFAULT *Error
+var NodeFetchBlocksMetadataRawV2Result_Err_DEFAULT *Error
 
-func (p *NodeFetchBatchRawV2Result) GetErr() *Error {
+func (p *NodeFetchBlocksMetadataRawV2Result) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeFetchBatchRawV2Result_Err_DEFAULT
+		return NodeFetchBlocksMetadataRawV2Result_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchBatchRawV2Result) IsSetSuccess() bool {
+func (p *NodeFetchBlocksMetadataRawV2Result) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *NodeFetchBatchRawV2Result) IsSetErr() bool {
+func (p *NodeFetchBlocksMetadataRawV2Result) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchBatchRawV2Result) Read(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksMetadataRawV2Result) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -21609,15 +23918,15 @@ func (p *NodeFetchBatchRawV2Result) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawV2Result) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchBatchRawResult_{}
+func (p *NodeFetchBlocksMetadataRawV2Result) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &FetchBlocksMetadataRawV2Result_{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBatchRawV2Result) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeFetchBlocksMetadataRawV2Result) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -21627,8 +23936,8 @@ func (p *NodeFetchBatchRawV2Result) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawV2Result) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBatchRawV2_result"); err != nil {
+func (p *NodeFetchBlocksMetadataRawV2Result) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("fetchBlocksMetadataRawV2_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -21648,7 +23957,7 @@ func (p *NodeFetchBatchRawV2Result) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBatchRawV2Result) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksMetadataRawV2Result) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -21663,7 +23972,7 @@ func (p *NodeFetchBatchRawV2Result) writeField0(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *NodeFetchBatchRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeFetchBlocksMetadataRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -21678,36 +23987,36 @@ func (p *NodeFetchBatchRawV2Result) writeField1(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *NodeFetchBatchRawV2Result) String() string {
+func (p *NodeFetchBlocksMetadataRawV2Result) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBatchRawV2Result(%+v)", *p)
+	return fmt.Sprintf("NodeFetchBlocksMetadataRawV2Result(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchBlocksRawArgs struct {
-	Req *FetchBlocksRawRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteBatchRawArgs struct {
+	Req *WriteBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchBlocksRawArgs() *NodeFetchBlocksRawArgs {
-	return &NodeFetchBlocksRawArgs{}
+func NewNodeWriteBatchRawArgs() *NodeWriteBatchRawArgs {
+	return &NodeWriteBatchRawArgs{}
 }
 
-var NodeFetchBlocksRawArgs_Req_DEFAULT *FetchBlocksRawRequest
+var NodeWriteBatchRawArgs_Req_DEFAULT *WriteBatchRawRequest
 
-func (p *NodeFetchBlocksRawArgs) GetReq() *FetchBlocksRawRequest {
+func (p *NodeWriteBatchRawArgs) GetReq() *WriteBatchRawRequest {
 	if !p.IsSetReq() {
-		return NodeFetchBlocksRawArgs_Req_DEFAULT
+		return NodeWriteBatchRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchBlocksRawArgs) IsSetReq() bool {
+func (p *NodeWriteBatchRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchBlocksRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -21740,16 +24049,16 @@ func (p *NodeFetchBlocksRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchBlocksRawRequest{}
+func (p *NodeWriteBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteBatchRawRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBlocksRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksRaw_args"); err != nil {
+func (p *NodeWriteBatchRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeBatchRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -21766,7 +24075,7 @@ func (p *NodeFetchBlocksRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -21779,51 +24088,36 @@ func (p *NodeFetchBlocksRawArgs) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeFetchBlocksRawArgs) String() string {
+func (p *NodeWriteBatchRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeWriteBatchRawArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - Err
-type NodeFetchBlocksRawResult struct {
-	Success *FetchBlocksRawResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                 `thrift:"err,1" db:"err" json:"err,omitempty"`
-}
-
-func NewNodeFetchBlocksRawResult() *NodeFetchBlocksRawResult {
-	return &NodeFetchBlocksRawResult{}
+type NodeWriteBatchRawResult struct {
+	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-var NodeFetchBlocksRawResult_Success_DEFAULT *FetchBlocksRawResult_
-
-func (p *NodeFetchBlocksRawResult) GetSuccess() *FetchBlocksRawResult_ {
-	if !p.IsSetSuccess() {
-		return NodeFetchBlocksRawResult_Success_DEFAULT
-	}
-	return p.Success
+func NewNodeWriteBatchRawResult() *NodeWriteBatchRawResult {
+	return &NodeWriteBatchRawResult{}
 }
 
-var NodeFetchBlocksRawResult_Err_DEFAULT *Error
+var NodeWriteBatchRawResult_Err_DEFAULT *WriteBatchRawErrors
 
-func (p *NodeFetchBlocksRawResult) GetErr() *Error {
+func (p *NodeWriteBatchRawResult) GetErr() *WriteBatchRawErrors {
 	if !p.IsSetErr() {
-		return NodeFetchBlocksRawResult_Err_DEFAULT
+		return NodeWriteBatchRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchBlocksRawResult) IsSetSuccess() bool {
-	return p.Success != nil
-}
-
-func (p *NodeFetchBlocksRawResult) IsSetErr() bool {
+func (p *NodeWriteBatchRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchBlocksRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteBatchRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -21837,10 +24131,6 @@ func (p *NodeFetchBlocksRawResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
-		case 0:
-			if err := p.ReadField0(iprot); err != nil {
-				return err
-			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -21860,32 +24150,19 @@ func (p *NodeFetchBlocksRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksRawResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchBlocksRawResult_{}
-	if err := p.Success.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-	}
-	return nil
-}
-
-func (p *NodeFetchBlocksRawResult) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &Error{
-		Type: 0,
-	}
+func (p *NodeWriteBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &WriteBatchRawErrors{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBlocksRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksRaw_result"); err != nil {
+func (p *NodeWriteBatchRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeBatchRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField0(oprot); err != nil {
-			return err
-		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -21899,22 +24176,7 @@ func (p *NodeFetchBlocksRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksRawResult) writeField0(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
-		}
-		if err := p.Success.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeFetchBlocksRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -21929,36 +24191,36 @@ func (p *NodeFetchBlocksRawResult) writeField1(oprot thrift.TProtocol) (err erro
 	return err
 }
 
-func (p *NodeFetchBlocksRawResult) String() string {
+func (p *NodeWriteBatchRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksRawResult(%+v)", *p)
+	return fmt.Sprintf("NodeWriteBatchRawResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchTaggedArgs struct {
-	Req *FetchTaggedRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteBatchRawV2Args struct {
+	Req *WriteBatchRawV2Request `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchTaggedArgs() *NodeFetchTaggedArgs {
-	return &NodeFetchTaggedArgs{}
+func NewNodeWriteBatchRawV2Args() *NodeWriteBatchRawV2Args {
+	return &NodeWriteBatchRawV2Args{}
 }
 
-var NodeFetchTaggedArgs_Req_DEFAULT *FetchTaggedRequest
+var NodeWriteBatchRawV2Args_Req_DEFAULT *WriteBatchRawV2Request
 
-func (p *NodeFetchTaggedArgs) GetReq() *FetchTaggedRequest {
+func (p *NodeWriteBatchRawV2Args) GetReq() *WriteBatchRawV2Request {
 	if !p.IsSetReq() {
-		return NodeFetchTaggedArgs_Req_DEFAULT
+		return NodeWriteBatchRawV2Args_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchTaggedArgs) IsSetReq() bool {
+func (p *NodeWriteBatchRawV2Args) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchTaggedArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteBatchRawV2Args) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -21991,20 +24253,16 @@ func (p *NodeFetchTaggedArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchTaggedArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchTaggedRequest{
-		RangeTimeType: 0,
-
-		RequireExhaustive: true,
-	}
+func (p *NodeWriteBatchRawV2Args) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteBatchRawV2Request{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchTaggedArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchTagged_args"); err != nil {
+func (p *NodeWriteBatchRawV2Args) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeBatchRawV2_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -22021,7 +24279,7 @@ func (p *NodeFetchTaggedArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteBatchRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -22034,51 +24292,36 @@ func (p *NodeFetchTaggedArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeFetchTaggedArgs) String() string {
+func (p *NodeWriteBatchRawV2Args) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchTaggedArgs(%+v)", *p)
+	return fmt.Sprintf("NodeWriteBatchRawV2Args(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - Err
-type NodeFetchTaggedResult struct {
-	Success *FetchTaggedResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error              `thrift:"err,1" db:"err" json:"err,omitempty"`
-}
-
-func NewNodeFetchTaggedResult() *NodeFetchTaggedResult {
-	return &NodeFetchTaggedResult{}
+type NodeWriteBatchRawV2Result struct {
+	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-var NodeFetchTaggedResult_Success_DEFAULT *FetchTaggedResult_
-
-func (p *NodeFetchTaggedResult) GetSuccess() *FetchTaggedResult_ {
-	if !p.IsSetSuccess() {
-		return NodeFetchTaggedResult_Success_DEFAULT
-	}
-	return p.Success
+func NewNodeWriteBatchRawV2Result() *NodeWriteBatchRawV2Result {
+	return &NodeWriteBatchRawV2Result{}
 }
 
-var NodeFetchTaggedResult_Err_DEFAULT *Error
+var NodeWriteBatchRawV2Result_Err_DEFAULT *WriteBatchRawErrors
 
-func (p *NodeFetchTaggedResult) GetErr() *Error {
+func (p *NodeWriteBatchRawV2Result) GetErr() *WriteBatchRawErrors {
 	if !p.IsSetErr() {
-		return NodeFetchTaggedResult_Err_DEFAULT
+		return NodeWriteBatchRawV2Result_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchTaggedResult) IsSetSuccess() bool {
-	return p.Success != nil
-}
-
-func (p *NodeFetchTaggedResult) IsSetErr() bool {
+func (p *NodeWriteBatchRawV2Result) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchTaggedResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteBatchRawV2Result) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -22092,10 +24335,6 @@ func (p *NodeFetchTaggedResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
-		case 0:
-			if err := p.ReadField0(iprot); err != nil {
-				return err
-			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -22115,32 +24354,19 @@ func (p *NodeFetchTaggedResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchTaggedResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchTaggedResult_{}
-	if err := p.Success.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-	}
-	return nil
-}
-
-func (p *NodeFetchTaggedResult) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &Error{
-		Type: 0,
-	}
+func (p *NodeWriteBatchRawV2Result) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &WriteBatchRawErrors{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchTaggedResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchTagged_result"); err != nil {
+func (p *NodeWriteBatchRawV2Result) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeBatchRawV2_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField0(oprot); err != nil {
-			return err
-		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -22154,22 +24380,7 @@ func (p *NodeFetchTaggedResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchTaggedResult) writeField0(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
-		}
-		if err := p.Success.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeFetchTaggedResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteBatchRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -22184,36 +24395,36 @@ func (p *NodeFetchTaggedResult) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeFetchTaggedResult) String() string {
+func (p *NodeWriteBatchRawV2Result) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchTaggedResult(%+v)", *p)
+	return fmt.Sprintf("NodeWriteBatchRawV2Result(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeFetchBlocksMetadataRawV2Args struct {
-	Req *FetchBlocksMetadataRawV2Request `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteTaggedBatchRawArgs struct {
+	Req *WriteTaggedBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeFetchBlocksMetadataRawV2Args() *NodeFetchBlocksMetadataRawV2Args {
-	return &NodeFetchBlocksMetadataRawV2Args{}
+func NewNodeWriteTaggedBatchRawArgs() *NodeWriteTaggedBatchRawArgs {
+	return &NodeWriteTaggedBatchRawArgs{}
 }
 
-var NodeFetchBlocksMetadataRawV2Args_Req_DEFAULT *FetchBlocksMetadataRawV2Request
+var NodeWriteTaggedBatchRawArgs_Req_DEFAULT *WriteTaggedBatchRawRequest
 
-func (p *NodeFetchBlocksMetadataRawV2Args) GetReq() *FetchBlocksMetadataRawV2Request {
+func (p *NodeWriteTaggedBatchRawArgs) GetReq() *WriteTaggedBatchRawRequest {
 	if !p.IsSetReq() {
-		return NodeFetchBlocksMetadataRawV2Args_Req_DEFAULT
+		return NodeWriteTaggedBatchRawArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeFetchBlocksMetadataRawV2Args) IsSetReq() bool {
+func (p *NodeWriteTaggedBatchRawArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -22246,16 +24457,16 @@ func (p *NodeFetchBlocksMetadataRawV2Args) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &FetchBlocksMetadataRawV2Request{}
+func (p *NodeWriteTaggedBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteTaggedBatchRawRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksMetadataRawV2_args"); err != nil {
+func (p *NodeWriteTaggedBatchRawArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTaggedBatchRaw_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -22272,7 +24483,7 @@ func (p *NodeFetchBlocksMetadataRawV2Args) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -22285,51 +24496,36 @@ func (p *NodeFetchBlocksMetadataRawV2Args) writeField1(oprot thrift.TProtocol) (
 	return err
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Args) String() string {
+func (p *NodeWriteTaggedBatchRawArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksMetadataRawV2Args(%+v)", *p)
+	return fmt.Sprintf("NodeWriteTaggedBatchRawArgs(%+v)", *p)
 }
 
 // Attributes:
-//  - Success
 //  - Err
-type NodeFetchBlocksMetadataRawV2Result struct {
-	Success *FetchBlocksMetadataRawV2Result_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error                           `thrift:"err,1" db:"err" json:"err,omitempty"`
-}
-
-func NewNodeFetchBlocksMetadataRawV2Result() *NodeFetchBlocksMetadataRawV2Result {
-	return &NodeFetchBlocksMetadataRawV2Result{}
+type NodeWriteTaggedBatchRawResult struct {
+	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-var NodeFetchBlocksMetadataRawV2Result_Success_DEFAULT *FetchBlocksMetadataRawV2Result_
-
-func (p *NodeFetchBlocksMetadataRawV2Result) GetSuccess() *FetchBlocksMetadataRawV2Result_ {
-	if !p.IsSetSuccess() {
-		return NodeFetchBlocksMetadataRawV2Result_Success_DEFAULT
-	}
-	return p.Success
+func NewNodeWriteTaggedBatchRawResult() *NodeWriteTaggedBatchRawResult {
+	return &NodeWriteTaggedBatchRawResult{}
 }
 
-var NodeFetchBlocksMetadataRawV2Result_Err_DEFAULT *Error
+var NodeWriteTaggedBatchRawResult_Err_DEFAULT *WriteBatchRawErrors
 
-func (p *NodeFetchBlocksMetadataRawV2Result) GetErr() *Error {
+func (p *NodeWriteTaggedBatchRawResult) GetErr() *WriteBatchRawErrors {
 	if !p.IsSetErr() {
-		return NodeFetchBlocksMetadataRawV2Result_Err_DEFAULT
+		return NodeWriteTaggedBatchRawResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeFetchBlocksMetadataRawV2Result) IsSetSuccess() bool {
-	return p.Success != nil
-}
-
-func (p *NodeFetchBlocksMetadataRawV2Result) IsSetErr() bool {
+func (p *NodeWriteTaggedBatchRawResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Result) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedBatchRawResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -22343,10 +24539,6 @@ func (p *NodeFetchBlocksMetadataRawV2Result) Read(iprot thrift.TProtocol) error
 			break
 		}
 		switch fieldId {
-		case 0:
-			if err := p.ReadField0(iprot); err != nil {
-				return err
-			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -22366,32 +24558,19 @@ func (p *NodeFetchBlocksMetadataRawV2Result) Read(iprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Result) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &FetchBlocksMetadataRawV2Result_{}
-	if err := p.Success.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
-	}
-	return nil
-}
-
-func (p *NodeFetchBlocksMetadataRawV2Result) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &Error{
-		Type: 0,
-	}
+func (p *NodeWriteTaggedBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &WriteBatchRawErrors{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
-
-func (p *NodeFetchBlocksMetadataRawV2Result) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("fetchBlocksMetadataRawV2_result"); err != nil {
+
+func (p *NodeWriteTaggedBatchRawResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTaggedBatchRaw_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField0(oprot); err != nil {
-			return err
-		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -22405,22 +24584,7 @@ func (p *NodeFetchBlocksMetadataRawV2Result) Write(oprot thrift.TProtocol) error
 	return nil
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Result) writeField0(oprot thrift.TProtocol) (err error) {
-	if p.IsSetSuccess() {
-		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
-		}
-		if err := p.Success.Write(oprot); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
-		}
-		if err := oprot.WriteFieldEnd(); err != nil {
-			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
-		}
-	}
-	return err
-}
-
-func (p *NodeFetchBlocksMetadataRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -22435,36 +24599,36 @@ func (p *NodeFetchBlocksMetadataRawV2Result) writeField1(oprot thrift.TProtocol)
 	return err
 }
 
-func (p *NodeFetchBlocksMetadataRawV2Result) String() string {
+func (p *NodeWriteTaggedBatchRawResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeFetchBlocksMetadataRawV2Result(%+v)", *p)
+	return fmt.Sprintf("NodeWriteTaggedBatchRawResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeWriteBatchRawArgs struct {
-	Req *WriteBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeWriteTaggedBatchRawV2Args struct {
+	Req *WriteTaggedBatchRawV2Request `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeWriteBatchRawArgs() *NodeWriteBatchRawArgs {
-	return &NodeWriteBatchRawArgs{}
+func NewNodeWriteTaggedBatchRawV2Args() *NodeWriteTaggedBatchRawV2Args {
+	return &NodeWriteTaggedBatchRawV2Args{}
 }
 
-var NodeWriteBatchRawArgs_Req_DEFAULT *WriteBatchRawRequest
+var NodeWriteTaggedBatchRawV2Args_Req_DEFAULT *WriteTaggedBatchRawV2Request
 
-func (p *NodeWriteBatchRawArgs) GetReq() *WriteBatchRawRequest {
+func (p *NodeWriteTaggedBatchRawV2Args) GetReq() *WriteTaggedBatchRawV2Request {
 	if !p.IsSetReq() {
-		return NodeWriteBatchRawArgs_Req_DEFAULT
+		return NodeWriteTaggedBatchRawV2Args_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeWriteBatchRawArgs) IsSetReq() bool {
+func (p *NodeWriteTaggedBatchRawV2Args) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeWriteBatchRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedBatchRawV2Args) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -22497,16 +24661,16 @@ func (p *NodeWriteBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteBatchRawRequest{}
+func (p *NodeWriteTaggedBatchRawV2Args) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &WriteTaggedBatchRawV2Request{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteBatchRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeBatchRaw_args"); err != nil {
+func (p *NodeWriteTaggedBatchRawV2Args) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTaggedBatchRawV2_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -22523,7 +24687,7 @@ func (p *NodeWriteBatchRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedBatchRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -22536,36 +24700,36 @@ func (p *NodeWriteBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error)
 	return err
 }
 
-func (p *NodeWriteBatchRawArgs) String() string {
+func (p *NodeWriteTaggedBatchRawV2Args) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteBatchRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeWriteTaggedBatchRawV2Args(%+v)", *p)
 }
 
 // Attributes:
 //  - Err
-type NodeWriteBatchRawResult struct {
+type NodeWriteTaggedBatchRawV2Result struct {
 	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteBatchRawResult() *NodeWriteBatchRawResult {
-	return &NodeWriteBatchRawResult{}
+func NewNodeWriteTaggedBatchRawV2Result() *NodeWriteTaggedBatchRawV2Result {
+	return &NodeWriteTaggedBatchRawV2Result{}
 }
 
-var NodeWriteBatchRawResult_Err_DEFAULT *WriteBatchRawErrors
+var NodeWriteTaggedBatchRawV2Result_Err_DEFAULT *WriteBatchRawErrors
 
-func (p *NodeWriteBatchRawResult) GetErr() *WriteBatchRawErrors {
+func (p *NodeWriteTaggedBatchRawV2Result) GetErr() *WriteBatchRawErrors {
 	if !p.IsSetErr() {
-		return NodeWriteBatchRawResult_Err_DEFAULT
+		return NodeWriteTaggedBatchRawV2Result_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteBatchRawResult) IsSetErr() bool {
+func (p *NodeWriteTaggedBatchRawV2Result) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteBatchRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedBatchRawV2Result) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -22598,7 +24762,7 @@ func (p *NodeWriteBatchRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeWriteTaggedBatchRawV2Result) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &WriteBatchRawErrors{}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
@@ -22606,8 +24770,8 @@ func (p *NodeWriteBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeBatchRaw_result"); err != nil {
+func (p *NodeWriteTaggedBatchRawV2Result) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("writeTaggedBatchRawV2_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -22624,7 +24788,7 @@ func (p *NodeWriteBatchRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeWriteTaggedBatchRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -22639,36 +24803,21 @@ func (p *NodeWriteBatchRawResult) writeField1(oprot thrift.TProtocol) (err error
 	return err
 }
 
-func (p *NodeWriteBatchRawResult) String() string {
+func (p *NodeWriteTaggedBatchRawV2Result) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteBatchRawResult(%+v)", *p)
-}
-
-// Attributes:
-//  - Req
-type NodeWriteBatchRawV2Args struct {
-	Req *WriteBatchRawV2Request `thrift:"req,1" db:"req" json:"req"`
+	return fmt.Sprintf("NodeWriteTaggedBatchRawV2Result(%+v)", *p)
 }
 
-func NewNodeWriteBatchRawV2Args() *NodeWriteBatchRawV2Args {
-	return &NodeWriteBatchRawV2Args{}
+type NodeRepairArgs struct {
 }
 
-var NodeWriteBatchRawV2Args_Req_DEFAULT *WriteBatchRawV2Request
-
-func (p *NodeWriteBatchRawV2Args) GetReq() *WriteBatchRawV2Request {
-	if !p.IsSetReq() {
-		return NodeWriteBatchRawV2Args_Req_DEFAULT
-	}
-	return p.Req
-}
-func (p *NodeWriteBatchRawV2Args) IsSetReq() bool {
-	return p.Req != nil
+func NewNodeRepairArgs() *NodeRepairArgs {
+	return &NodeRepairArgs{}
 }
 
-func (p *NodeWriteBatchRawV2Args) Read(iprot thrift.TProtocol) error {
+func (p *NodeRepairArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -22681,15 +24830,8 @@ func (p *NodeWriteBatchRawV2Args) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		switch fieldId {
-		case 1:
-			if err := p.ReadField1(iprot); err != nil {
-				return err
-			}
-		default:
-			if err := iprot.Skip(fieldTypeId); err != nil {
-				return err
-			}
+		if err := iprot.Skip(fieldTypeId); err != nil {
+			return err
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -22701,22 +24843,11 @@ func (p *NodeWriteBatchRawV2Args) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawV2Args) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteBatchRawV2Request{}
-	if err := p.Req.Read(iprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
-	}
-	return nil
-}
-
-func (p *NodeWriteBatchRawV2Args) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeBatchRawV2_args"); err != nil {
+func (p *NodeRepairArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("repair_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
-		if err := p.writeField1(oprot); err != nil {
-			return err
-		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -22727,49 +24858,36 @@ func (p *NodeWriteBatchRawV2Args) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
-	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
-	}
-	if err := p.Req.Write(oprot); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
-	}
-	if err := oprot.WriteFieldEnd(); err != nil {
-		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
-	}
-	return err
-}
-
-func (p *NodeWriteBatchRawV2Args) String() string {
+func (p *NodeRepairArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteBatchRawV2Args(%+v)", *p)
+	return fmt.Sprintf("NodeRepairArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Err
-type NodeWriteBatchRawV2Result struct {
-	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeRepairResult struct {
+	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteBatchRawV2Result() *NodeWriteBatchRawV2Result {
-	return &NodeWriteBatchRawV2Result{}
+func NewNodeRepairResult() *NodeRepairResult {
+	return &NodeRepairResult{}
 }
 
-var NodeWriteBatchRawV2Result_Err_DEFAULT *WriteBatchRawErrors
+var NodeRepairResult_Err_DEFAULT *Error
 
-func (p *NodeWriteBatchRawV2Result) GetErr() *WriteBatchRawErrors {
+func (p *NodeRepairResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeWriteBatchRawV2Result_Err_DEFAULT
+		return NodeRepairResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteBatchRawV2Result) IsSetErr() bool {
+func (p *NodeRepairResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteBatchRawV2Result) Read(iprot thrift.TProtocol) error {
+func (p *NodeRepairResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -22802,16 +24920,18 @@ func (p *NodeWriteBatchRawV2Result) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawV2Result) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &WriteBatchRawErrors{}
+func (p *NodeRepairResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
+	}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteBatchRawV2Result) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeBatchRawV2_result"); err != nil {
+func (p *NodeRepairResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("repair_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -22828,7 +24948,7 @@ func (p *NodeWriteBatchRawV2Result) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteBatchRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeRepairResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -22843,36 +24963,36 @@ func (p *NodeWriteBatchRawV2Result) writeField1(oprot thrift.TProtocol) (err err
 	return err
 }
 
-func (p *NodeWriteBatchRawV2Result) String() string {
+func (p *NodeRepairResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteBatchRawV2Result(%+v)", *p)
+	return fmt.Sprintf("NodeRepairResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeWriteTaggedBatchRawArgs struct {
-	Req *WriteTaggedBatchRawRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeTruncateArgs struct {
+	Req *TruncateRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeWriteTaggedBatchRawArgs() *NodeWriteTaggedBatchRawArgs {
-	return &NodeWriteTaggedBatchRawArgs{}
+func NewNodeTruncateArgs() *NodeTruncateArgs {
+	return &NodeTruncateArgs{}
 }
 
-var NodeWriteTaggedBatchRawArgs_Req_DEFAULT *WriteTaggedBatchRawRequest
+var NodeTruncateArgs_Req_DEFAULT *TruncateRequest
 
-func (p *NodeWriteTaggedBatchRawArgs) GetReq() *WriteTaggedBatchRawRequest {
+func (p *NodeTruncateArgs) GetReq() *TruncateRequest {
 	if !p.IsSetReq() {
-		return NodeWriteTaggedBatchRawArgs_Req_DEFAULT
+		return NodeTruncateArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeWriteTaggedBatchRawArgs) IsSetReq() bool {
+func (p *NodeTruncateArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeTruncateArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -22905,16 +25025,16 @@ func (p *NodeWriteTaggedBatchRawArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteTaggedBatchRawRequest{}
+func (p *NodeTruncateArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &TruncateRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTaggedBatchRaw_args"); err != nil {
+func (p *NodeTruncateArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("truncate_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -22931,7 +25051,7 @@ func (p *NodeWriteTaggedBatchRawArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeTruncateArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -22944,36 +25064,51 @@ func (p *NodeWriteTaggedBatchRawArgs) writeField1(oprot thrift.TProtocol) (err e
 	return err
 }
 
-func (p *NodeWriteTaggedBatchRawArgs) String() string {
+func (p *NodeTruncateArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedBatchRawArgs(%+v)", *p)
+	return fmt.Sprintf("NodeTruncateArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Err
-type NodeWriteTaggedBatchRawResult struct {
-	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeTruncateResult struct {
+	Success *TruncateResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error           `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteTaggedBatchRawResult() *NodeWriteTaggedBatchRawResult {
-	return &NodeWriteTaggedBatchRawResult{}
+func NewNodeTruncateResult() *NodeTruncateResult {
+	return &NodeTruncateResult{}
 }
 
-var NodeWriteTaggedBatchRawResult_Err_DEFAULT *WriteBatchRawErrors
+var NodeTruncateResult_Success_DEFAULT *TruncateResult_
 
-func (p *NodeWriteTaggedBatchRawResult) GetErr() *WriteBatchRawErrors {
+func (p *NodeTruncateResult) GetSuccess() *TruncateResult_ {
+	if !p.IsSetSuccess() {
+		return NodeTruncateResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeTruncateResult_Err_DEFAULT *Error
+
+func (p *NodeTruncateResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeWriteTaggedBatchRawResult_Err_DEFAULT
+		return NodeTruncateResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteTaggedBatchRawResult) IsSetErr() bool {
+func (p *NodeTruncateResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeTruncateResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteTaggedBatchRawResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeTruncateResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -22987,6 +25122,10 @@ func (p *NodeWriteTaggedBatchRawResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -23006,19 +25145,32 @@ func (p *NodeWriteTaggedBatchRawResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawResult) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &WriteBatchRawErrors{}
+func (p *NodeTruncateResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &TruncateResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *NodeTruncateResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
+	}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTaggedBatchRaw_result"); err != nil {
+func (p *NodeTruncateResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("truncate_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -23032,7 +25184,22 @@ func (p *NodeWriteTaggedBatchRawResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeTruncateResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *NodeTruncateResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -23047,36 +25214,36 @@ func (p *NodeWriteTaggedBatchRawResult) writeField1(oprot thrift.TProtocol) (err
 	return err
 }
 
-func (p *NodeWriteTaggedBatchRawResult) String() string {
+func (p *NodeTruncateResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedBatchRawResult(%+v)", *p)
+	return fmt.Sprintf("NodeTruncateResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeWriteTaggedBatchRawV2Args struct {
-	Req *WriteTaggedBatchRawV2Request `thrift:"req,1" db:"req" json:"req"`
+type NodeDeleteSeriesArgs struct {
+	Req *DeleteSeriesRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeWriteTaggedBatchRawV2Args() *NodeWriteTaggedBatchRawV2Args {
-	return &NodeWriteTaggedBatchRawV2Args{}
+func NewNodeDeleteSeriesArgs() *NodeDeleteSeriesArgs {
+	return &NodeDeleteSeriesArgs{}
 }
 
-var NodeWriteTaggedBatchRawV2Args_Req_DEFAULT *WriteTaggedBatchRawV2Request
+var NodeDeleteSeriesArgs_Req_DEFAULT *DeleteSeriesRequest
 
-func (p *NodeWriteTaggedBatchRawV2Args) GetReq() *WriteTaggedBatchRawV2Request {
+func (p *NodeDeleteSeriesArgs) GetReq() *DeleteSeriesRequest {
 	if !p.IsSetReq() {
-		return NodeWriteTaggedBatchRawV2Args_Req_DEFAULT
+		return NodeDeleteSeriesArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeWriteTaggedBatchRawV2Args) IsSetReq() bool {
+func (p *NodeDeleteSeriesArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeWriteTaggedBatchRawV2Args) Read(iprot thrift.TProtocol) error {
+func (p *NodeDeleteSeriesArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -23109,16 +25276,16 @@ func (p *NodeWriteTaggedBatchRawV2Args) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawV2Args) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &WriteTaggedBatchRawV2Request{}
+func (p *NodeDeleteSeriesArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &DeleteSeriesRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawV2Args) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTaggedBatchRawV2_args"); err != nil {
+func (p *NodeDeleteSeriesArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("deleteSeries_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -23135,7 +25302,7 @@ func (p *NodeWriteTaggedBatchRawV2Args) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawV2Args) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeDeleteSeriesArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -23148,36 +25315,51 @@ func (p *NodeWriteTaggedBatchRawV2Args) writeField1(oprot thrift.TProtocol) (err
 	return err
 }
 
-func (p *NodeWriteTaggedBatchRawV2Args) String() string {
+func (p *NodeDeleteSeriesArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedBatchRawV2Args(%+v)", *p)
+	return fmt.Sprintf("NodeDeleteSeriesArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Err
-type NodeWriteTaggedBatchRawV2Result struct {
-	Err *WriteBatchRawErrors `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeDeleteSeriesResult struct {
+	Success *DeleteSeriesResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error               `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeWriteTaggedBatchRawV2Result() *NodeWriteTaggedBatchRawV2Result {
-	return &NodeWriteTaggedBatchRawV2Result{}
+func NewNodeDeleteSeriesResult() *NodeDeleteSeriesResult {
+	return &NodeDeleteSeriesResult{}
 }
 
-var NodeWriteTaggedBatchRawV2Result_Err_DEFAULT *WriteBatchRawErrors
+var NodeDeleteSeriesResult_Success_DEFAULT *DeleteSeriesResult_
 
-func (p *NodeWriteTaggedBatchRawV2Result) GetErr() *WriteBatchRawErrors {
+func (p *NodeDeleteSeriesResult) GetSuccess() *DeleteSeriesResult_ {
+	if !p.IsSetSuccess() {
+		return NodeDeleteSeriesResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeDeleteSeriesResult_Err_DEFAULT *Error
+
+func (p *NodeDeleteSeriesResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeWriteTaggedBatchRawV2Result_Err_DEFAULT
+		return NodeDeleteSeriesResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeWriteTaggedBatchRawV2Result) IsSetErr() bool {
+func (p *NodeDeleteSeriesResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeDeleteSeriesResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeWriteTaggedBatchRawV2Result) Read(iprot thrift.TProtocol) error {
+func (p *NodeDeleteSeriesResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -23191,6 +25373,10 @@ func (p *NodeWriteTaggedBatchRawV2Result) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -23210,19 +25396,32 @@ func (p *NodeWriteTaggedBatchRawV2Result) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawV2Result) ReadField1(iprot thrift.TProtocol) error {
-	p.Err = &WriteBatchRawErrors{}
+func (p *NodeDeleteSeriesResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &DeleteSeriesResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *NodeDeleteSeriesResult) ReadField1(iprot thrift.TProtocol) error {
+	p.Err = &Error{
+		Type: 0,
+	}
 	if err := p.Err.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Err), err)
 	}
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawV2Result) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("writeTaggedBatchRawV2_result"); err != nil {
+func (p *NodeDeleteSeriesResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("deleteSeries_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -23236,7 +25435,22 @@ func (p *NodeWriteTaggedBatchRawV2Result) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeWriteTaggedBatchRawV2Result) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeDeleteSeriesResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *NodeDeleteSeriesResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -23251,21 +25465,36 @@ func (p *NodeWriteTaggedBatchRawV2Result) writeField1(oprot thrift.TProtocol) (e
 	return err
 }
 
-func (p *NodeWriteTaggedBatchRawV2Result) String() string {
+func (p *NodeDeleteSeriesResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeWriteTaggedBatchRawV2Result(%+v)", *p)
+	return fmt.Sprintf("NodeDeleteSeriesResult(%+v)", *p)
 }
 
-type NodeRepairArgs struct {
+// Attributes:
+//  - Req
+type NodeBackupArgs struct {
+	Req *BackupRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeRepairArgs() *NodeRepairArgs {
-	return &NodeRepairArgs{}
+func NewNodeBackupArgs() *NodeBackupArgs {
+	return &NodeBackupArgs{}
 }
 
-func (p *NodeRepairArgs) Read(iprot thrift.TProtocol) error {
+var NodeBackupArgs_Req_DEFAULT *BackupRequest
+
+func (p *NodeBackupArgs) GetReq() *BackupRequest {
+	if !p.IsSetReq() {
+		return NodeBackupArgs_Req_DEFAULT
+	}
+	return p.Req
+}
+func (p *NodeBackupArgs) IsSetReq() bool {
+	return p.Req != nil
+}
+
+func (p *NodeBackupArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -23278,8 +25507,15 @@ func (p *NodeRepairArgs) Read(iprot thrift.TProtocol) error {
 		if fieldTypeId == thrift.STOP {
 			break
 		}
-		if err := iprot.Skip(fieldTypeId); err != nil {
-			return err
+		switch fieldId {
+		case 1:
+			if err := p.ReadField1(iprot); err != nil {
+				return err
+			}
+		default:
+			if err := iprot.Skip(fieldTypeId); err != nil {
+				return err
+			}
 		}
 		if err := iprot.ReadFieldEnd(); err != nil {
 			return err
@@ -23291,11 +25527,22 @@ func (p *NodeRepairArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("repair_args"); err != nil {
+func (p *NodeBackupArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &BackupRequest{}
+	if err := p.Req.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
+	}
+	return nil
+}
+
+func (p *NodeBackupArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("backup_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField1(oprot); err != nil {
+			return err
+		}
 	}
 	if err := oprot.WriteFieldStop(); err != nil {
 		return thrift.PrependError("write field stop error: ", err)
@@ -23306,36 +25553,64 @@ func (p *NodeRepairArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairArgs) String() string {
+func (p *NodeBackupArgs) writeField1(oprot thrift.TProtocol) (err error) {
+	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
+	}
+	if err := p.Req.Write(oprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Req), err)
+	}
+	if err := oprot.WriteFieldEnd(); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T write field end error 1:req: ", p), err)
+	}
+	return err
+}
+
+func (p *NodeBackupArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeRepairArgs(%+v)", *p)
+	return fmt.Sprintf("NodeBackupArgs(%+v)", *p)
 }
 
 // Attributes:
+//  - Success
 //  - Err
-type NodeRepairResult struct {
-	Err *Error `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeBackupResult struct {
+	Success *BackupResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error         `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeRepairResult() *NodeRepairResult {
-	return &NodeRepairResult{}
+func NewNodeBackupResult() *NodeBackupResult {
+	return &NodeBackupResult{}
 }
 
-var NodeRepairResult_Err_DEFAULT *Error
+var NodeBackupResult_Success_DEFAULT *BackupResult_
 
-func (p *NodeRepairResult) GetErr() *Error {
+func (p *NodeBackupResult) GetSuccess() *BackupResult_ {
+	if !p.IsSetSuccess() {
+		return NodeBackupResult_Success_DEFAULT
+	}
+	return p.Success
+}
+
+var NodeBackupResult_Err_DEFAULT *Error
+
+func (p *NodeBackupResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeRepairResult_Err_DEFAULT
+		return NodeBackupResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeRepairResult) IsSetErr() bool {
+func (p *NodeBackupResult) IsSetSuccess() bool {
+	return p.Success != nil
+}
+
+func (p *NodeBackupResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeRepairResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeBackupResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -23349,6 +25624,10 @@ func (p *NodeRepairResult) Read(iprot thrift.TProtocol) error {
 			break
 		}
 		switch fieldId {
+		case 0:
+			if err := p.ReadField0(iprot); err != nil {
+				return err
+			}
 		case 1:
 			if err := p.ReadField1(iprot); err != nil {
 				return err
@@ -23368,7 +25647,15 @@ func (p *NodeRepairResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeBackupResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &BackupResult_{}
+	if err := p.Success.Read(iprot); err != nil {
+		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
+	}
+	return nil
+}
+
+func (p *NodeBackupResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -23378,11 +25665,14 @@ func (p *NodeRepairResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("repair_result"); err != nil {
+func (p *NodeBackupResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("backup_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
+		if err := p.writeField0(oprot); err != nil {
+			return err
+		}
 		if err := p.writeField1(oprot); err != nil {
 			return err
 		}
@@ -23396,7 +25686,22 @@ func (p *NodeRepairResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeRepairResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeBackupResult) writeField0(oprot thrift.TProtocol) (err error) {
+	if p.IsSetSuccess() {
+		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
+		}
+		if err := p.Success.Write(oprot); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T error writing struct: ", p.Success), err)
+		}
+		if err := oprot.WriteFieldEnd(); err != nil {
+			return thrift.PrependError(fmt.Sprintf("%T write field end error 0:success: ", p), err)
+		}
+	}
+	return err
+}
+
+func (p *NodeBackupResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -23411,36 +25716,36 @@ func (p *NodeRepairResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeRepairResult) String() string {
+func (p *NodeBackupResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeRepairResult(%+v)", *p)
+	return fmt.Sprintf("NodeBackupResult(%+v)", *p)
 }
 
 // Attributes:
 //  - Req
-type NodeTruncateArgs struct {
-	Req *TruncateRequest `thrift:"req,1" db:"req" json:"req"`
+type NodeRestoreArgs struct {
+	Req *RestoreRequest `thrift:"req,1" db:"req" json:"req"`
 }
 
-func NewNodeTruncateArgs() *NodeTruncateArgs {
-	return &NodeTruncateArgs{}
+func NewNodeRestoreArgs() *NodeRestoreArgs {
+	return &NodeRestoreArgs{}
 }
 
-var NodeTruncateArgs_Req_DEFAULT *TruncateRequest
+var NodeRestoreArgs_Req_DEFAULT *RestoreRequest
 
-func (p *NodeTruncateArgs) GetReq() *TruncateRequest {
+func (p *NodeRestoreArgs) GetReq() *RestoreRequest {
 	if !p.IsSetReq() {
-		return NodeTruncateArgs_Req_DEFAULT
+		return NodeRestoreArgs_Req_DEFAULT
 	}
 	return p.Req
 }
-func (p *NodeTruncateArgs) IsSetReq() bool {
+func (p *NodeRestoreArgs) IsSetReq() bool {
 	return p.Req != nil
 }
 
-func (p *NodeTruncateArgs) Read(iprot thrift.TProtocol) error {
+func (p *NodeRestoreArgs) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -23473,16 +25778,16 @@ func (p *NodeTruncateArgs) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateArgs) ReadField1(iprot thrift.TProtocol) error {
-	p.Req = &TruncateRequest{}
+func (p *NodeRestoreArgs) ReadField1(iprot thrift.TProtocol) error {
+	p.Req = &RestoreRequest{}
 	if err := p.Req.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Req), err)
 	}
 	return nil
 }
 
-func (p *NodeTruncateArgs) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("truncate_args"); err != nil {
+func (p *NodeRestoreArgs) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("restore_args"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -23499,7 +25804,7 @@ func (p *NodeTruncateArgs) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateArgs) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeRestoreArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	if err := oprot.WriteFieldBegin("req", thrift.STRUCT, 1); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:req: ", p), err)
 	}
@@ -23512,51 +25817,51 @@ func (p *NodeTruncateArgs) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeTruncateArgs) String() string {
+func (p *NodeRestoreArgs) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeTruncateArgs(%+v)", *p)
+	return fmt.Sprintf("NodeRestoreArgs(%+v)", *p)
 }
 
 // Attributes:
 //  - Success
 //  - Err
-type NodeTruncateResult struct {
-	Success *TruncateResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
-	Err     *Error           `thrift:"err,1" db:"err" json:"err,omitempty"`
+type NodeRestoreResult struct {
+	Success *RestoreResult_ `thrift:"success,0" db:"success" json:"success,omitempty"`
+	Err     *Error          `thrift:"err,1" db:"err" json:"err,omitempty"`
 }
 
-func NewNodeTruncateResult() *NodeTruncateResult {
-	return &NodeTruncateResult{}
+func NewNodeRestoreResult() *NodeRestoreResult {
+	return &NodeRestoreResult{}
 }
 
-var NodeTruncateResult_Success_DEFAULT *TruncateResult_
+var NodeRestoreResult_Success_DEFAULT *RestoreResult_
 
-func (p *NodeTruncateResult) GetSuccess() *TruncateResult_ {
+func (p *NodeRestoreResult) GetSuccess() *RestoreResult_ {
 	if !p.IsSetSuccess() {
-		return NodeTruncateResult_Success_DEFAULT
+		return NodeRestoreResult_Success_DEFAULT
 	}
 	return p.Success
 }
 
-var NodeTruncateResult_Err_DEFAULT *Error
+var NodeRestoreResult_Err_DEFAULT *Error
 
-func (p *NodeTruncateResult) GetErr() *Error {
+func (p *NodeRestoreResult) GetErr() *Error {
 	if !p.IsSetErr() {
-		return NodeTruncateResult_Err_DEFAULT
+		return NodeRestoreResult_Err_DEFAULT
 	}
 	return p.Err
 }
-func (p *NodeTruncateResult) IsSetSuccess() bool {
+func (p *NodeRestoreResult) IsSetSuccess() bool {
 	return p.Success != nil
 }
 
-func (p *NodeTruncateResult) IsSetErr() bool {
+func (p *NodeRestoreResult) IsSetErr() bool {
 	return p.Err != nil
 }
 
-func (p *NodeTruncateResult) Read(iprot thrift.TProtocol) error {
+func (p *NodeRestoreResult) Read(iprot thrift.TProtocol) error {
 	if _, err := iprot.ReadStructBegin(); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T read error: ", p), err)
 	}
@@ -23593,15 +25898,15 @@ func (p *NodeTruncateResult) Read(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateResult) ReadField0(iprot thrift.TProtocol) error {
-	p.Success = &TruncateResult_{}
+func (p *NodeRestoreResult) ReadField0(iprot thrift.TProtocol) error {
+	p.Success = &RestoreResult_{}
 	if err := p.Success.Read(iprot); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T error reading struct: ", p.Success), err)
 	}
 	return nil
 }
 
-func (p *NodeTruncateResult) ReadField1(iprot thrift.TProtocol) error {
+func (p *NodeRestoreResult) ReadField1(iprot thrift.TProtocol) error {
 	p.Err = &Error{
 		Type: 0,
 	}
@@ -23611,8 +25916,8 @@ func (p *NodeTruncateResult) ReadField1(iprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateResult) Write(oprot thrift.TProtocol) error {
-	if err := oprot.WriteStructBegin("truncate_result"); err != nil {
+func (p *NodeRestoreResult) Write(oprot thrift.TProtocol) error {
+	if err := oprot.WriteStructBegin("restore_result"); err != nil {
 		return thrift.PrependError(fmt.Sprintf("%T write struct begin error: ", p), err)
 	}
 	if p != nil {
@@ -23632,7 +25937,7 @@ func (p *NodeTruncateResult) Write(oprot thrift.TProtocol) error {
 	return nil
 }
 
-func (p *NodeTruncateResult) writeField0(oprot thrift.TProtocol) (err error) {
+func (p *NodeRestoreResult) writeField0(oprot thrift.TProtocol) (err error) {
 	if p.IsSetSuccess() {
 		if err := oprot.WriteFieldBegin("success", thrift.STRUCT, 0); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 0:success: ", p), err)
@@ -23647,7 +25952,7 @@ func (p *NodeTruncateResult) writeField0(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeTruncateResult) writeField1(oprot thrift.TProtocol) (err error) {
+func (p *NodeRestoreResult) writeField1(oprot thrift.TProtocol) (err error) {
 	if p.IsSetErr() {
 		if err := oprot.WriteFieldBegin("err", thrift.STRUCT, 1); err != nil {
 			return thrift.PrependError(fmt.Sprintf("%T write field begin error 1:err: ", p), err)
@@ -23662,11 +25967,11 @@ func (p *NodeTruncateResult) writeField1(oprot thrift.TProtocol) (err error) {
 	return err
 }
 
-func (p *NodeTruncateResult) String() string {
+func (p *NodeRestoreResult) String() string {
 	if p == nil {
 		return "<nil>"
 	}
-	return fmt.Sprintf("NodeTruncateResult(%+v)", *p)
+	return fmt.Sprintf("NodeRestoreResult(%+v)", *p)
 }
 
 // Attributes:
@@ -225,6 +225,21 @@ func (mr *MockTChanNodeMockRecorder) AggregateTiles(ctx, req interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AggregateTiles", reflect.TypeOf((*MockTChanNode)(nil).AggregateTiles), ctx, req)
 }
 
+// Backup mocks base method.
+func (m *MockTChanNode) Backup(ctx thrift.Context, req *BackupRequest) (*BackupResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", ctx, req)
+	ret0, _ := ret[0].(*BackupResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockTChanNodeMockRecorder) Backup(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockTChanNode)(nil).Backup), ctx, req)
+}
+
 // Bootstrapped mocks base method.
 func (m *MockTChanNode) Bootstrapped(ctx thrift.Context) (*NodeBootstrappedResult_, error) {
 	m.ctrl.T.Helper()
@@ -300,6 +315,21 @@ func (mr *MockTChanNodeMockRecorder) DebugProfileStop(ctx, req interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DebugProfileStop", reflect.TypeOf((*MockTChanNode)(nil).DebugProfileStop), ctx, req)
 }
 
+// DeleteSeries mocks base method.
+func (m *MockTChanNode) DeleteSeries(ctx thrift.Context, req *DeleteSeriesRequest) (*DeleteSeriesResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, req)
+	ret0, _ := ret[0].(*DeleteSeriesResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockTChanNodeMockRecorder) DeleteSeries(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockTChanNode)(nil).DeleteSeries), ctx, req)
+}
+
 // Fetch mocks base method.
 func (m *MockTChanNode) Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error) {
 	m.ctrl.T.Helper()
@@ -494,6 +524,21 @@ func (mr *MockTChanNodeMockRecorder) Repair(ctx interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*MockTChanNode)(nil).Repair), ctx)
 }
 
+// Restore mocks base method.
+func (m *MockTChanNode) Restore(ctx thrift.Context, req *RestoreRequest) (*RestoreResult_, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, req)
+	ret0, _ := ret[0].(*RestoreResult_)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockTChanNodeMockRecorder) Restore(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockTChanNode)(nil).Restore), ctx, req)
+}
+
 // SetPersistRateLimit mocks base method.
 func (m *MockTChanNode) SetPersistRateLimit(ctx thrift.Context, req *NodeSetPersistRateLimitRequest) (*NodePersistRateLimitResult_, error) {
 	m.ctrl.T.Helper()
@@ -48,11 +48,13 @@ type TChanNode interface {
 	Aggregate(ctx thrift.Context, req *AggregateQueryRequest) (*AggregateQueryResult_, error)
 	AggregateRaw(ctx thrift.Context, req *AggregateQueryRawRequest) (*AggregateQueryRawResult_, error)
 	AggregateTiles(ctx thrift.Context, req *AggregateTilesRequest) (*AggregateTilesResult_, error)
+	Backup(ctx thrift.Context, req *BackupRequest) (*BackupResult_, error)
 	Bootstrapped(ctx thrift.Context) (*NodeBootstrappedResult_, error)
 	BootstrappedInPlacementOrNoPlacement(ctx thrift.Context) (*NodeBootstrappedInPlacementOrNoPlacementResult_, error)
 	DebugIndexMemorySegments(ctx thrift.Context, req *DebugIndexMemorySegmentsRequest) (*DebugIndexMemorySegmentsResult_, error)
 	DebugProfileStart(ctx thrift.Context, req *DebugProfileStartRequest) (*DebugProfileStartResult_, error)
 	DebugProfileStop(ctx thrift.Context, req *DebugProfileStopRequest) (*DebugProfileStopResult_, error)
+	DeleteSeries(ctx thrift.Context, req *DeleteSeriesRequest) (*DeleteSeriesResult_, error)
 	Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error)
 	FetchBatchRaw(ctx thrift.Context, req *FetchBatchRawRequest) (*FetchBatchRawResult_, error)
 	FetchBatchRawV2(ctx thrift.Context, req *FetchBatchRawV2Request) (*FetchBatchRawResult_, error)
@@ -66,6 +68,7 @@ type TChanNode interface {
 	Health(ctx thrift.Context) (*NodeHealthResult_, error)
 	Query(ctx thrift.Context, req *QueryRequest) (*QueryResult_, error)
 	Repair(ctx thrift.Context) error
+	Restore(ctx thrift.Context, req *RestoreRequest) (*RestoreResult_, error)
 	SetPersistRateLimit(ctx thrift.Context, req *NodeSetPersistRateLimitRequest) (*NodePersistRateLimitResult_, error)
 	SetWriteNewSeriesAsync(ctx thrift.Context, req *NodeSetWriteNewSeriesAsyncRequest) (*NodeWriteNewSeriesAsyncResult_, error)
 	SetWriteNewSeriesBackoffDuration(ctx thrift.Context, req *NodeSetWriteNewSeriesBackoffDurationRequest) (*NodeWriteNewSeriesBackoffDurationResult_, error)
@@ -537,6 +540,24 @@ func (c *tchanNodeClient) AggregateTiles(ctx thrift.Context, req *AggregateTiles
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanNodeClient) Backup(ctx thrift.Context, req *BackupRequest) (*BackupResult_, error) {
+	var resp NodeBackupResult
+	args := NodeBackupArgs{
+		Req: req,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "backup", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			err = fmt.Errorf("received no result or unknown exception for backup")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanNodeClient) Bootstrapped(ctx thrift.Context) (*NodeBootstrappedResult_, error) {
 	var resp NodeBootstrappedResult
 	args := NodeBootstrappedArgs{}
@@ -623,6 +644,24 @@ func (c *tchanNodeClient) DebugProfileStop(ctx thrift.Context, req *DebugProfile
 	return resp.GetSuccess(), err
 }
 
+func (c *tchanNodeClient) DeleteSeries(ctx thrift.Context, req *DeleteSeriesRequest) (*DeleteSeriesResult_, error) {
+	var resp NodeDeleteSeriesResult
+	args := NodeDeleteSeriesArgs{
+		Req: req,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "deleteSeries", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			err = fmt.Errorf("received no result or unknown exception for deleteSeries")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanNodeClient) Fetch(ctx thrift.Context, req *FetchRequest) (*FetchResult_, error) {
 	var resp NodeFetchResult
 	args := NodeFetchArgs{
@@ -845,6 +884,24 @@ func (c *tchanNodeClient) Repair(ctx thrift.Context) error {
 	return err
 }
 
+func (c *tchanNodeClient) Restore(ctx thrift.Context, req *RestoreRequest) (*RestoreResult_, error) {
+	var resp NodeRestoreResult
+	args := NodeRestoreArgs{
+		Req: req,
+	}
+	success, err := c.client.Call(ctx, c.thriftService, "restore", &args, &resp)
+	if err == nil && !success {
+		switch {
+		case resp.Err != nil:
+			err = resp.Err
+		default:
+			err = fmt.Errorf("received no result or unknown exception for restore")
+		}
+	}
+
+	return resp.GetSuccess(), err
+}
+
 func (c *tchanNodeClient) SetPersistRateLimit(ctx thrift.Context, req *NodeSetPersistRateLimitRequest) (*NodePersistRateLimitResult_, error) {
 	var resp NodeSetPersistRateLimitResult
 	args := NodeSetPersistRateLimitArgs{
@@ -1064,11 +1121,13 @@ func (s *tchanNodeServer) Methods() []string {
 		"aggregate",
 		"aggregateRaw",
 		"aggregateTiles",
+		"backup",
 		"bootstrapped",
 		"bootstrappedInPlacementOrNoPlacement",
 		"debugIndexMemorySegments",
 		"debugProfileStart",
 		"debugProfileStop",
+		"deleteSeries",
 		"fetch",
 		"fetchBatchRaw",
 		"fetchBatchRawV2",
@@ -1082,6 +1141,7 @@ func (s *tchanNodeServer) Methods() []string {
 		"health",
 		"query",
 		"repair",
+		"restore",
 		"setPersistRateLimit",
 		"setWriteNewSeriesAsync",
 		"setWriteNewSeriesBackoffDuration",
@@ -1104,6 +1164,8 @@ func (s *tchanNodeServer) Handle(ctx thrift.Context, methodName string, protocol
 		return s.handleAggregateRaw(ctx, protocol)
 	case "aggregateTiles":
 		return s.handleAggregateTiles(ctx, protocol)
+	case "backup":
+		return s.handleBackup(ctx, protocol)
 	case "bootstrapped":
 		return s.handleBootstrapped(ctx, protocol)
 	case "bootstrappedInPlacementOrNoPlacement":
@@ -1114,6 +1176,8 @@ func (s *tchanNodeServer) Handle(ctx thrift.Context, methodName string, protocol
 		return s.handleDebugProfileStart(ctx, protocol)
 	case "debugProfileStop":
 		return s.handleDebugProfileStop(ctx, protocol)
+	case "deleteSeries":
+		return s.handleDeleteSeries(ctx, protocol)
 	case "fetch":
 		return s.handleFetch(ctx, protocol)
 	case "fetchBatchRaw":
@@ -1140,6 +1204,8 @@ func (s *tchanNodeServer) Handle(ctx thrift.Context, methodName string, protocol
 		return s.handleQuery(ctx, protocol)
 	case "repair":
 		return s.handleRepair(ctx, protocol)
+	case "restore":
+		return s.handleRestore(ctx, protocol)
 	case "setPersistRateLimit":
 		return s.handleSetPersistRateLimit(ctx, protocol)
 	case "setWriteNewSeriesAsync":
@@ -1252,6 +1318,34 @@ func (s *tchanNodeServer) handleAggregateTiles(ctx thrift.Context, protocol athr
 	return err == nil, &res, nil
 }
 
+func (s *tchanNodeServer) handleBackup(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req NodeBackupArgs
+	var res NodeBackupResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.Backup(ctx, req.Req)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *Error:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for err returned non-nil error type *Error but nil value")
+			}
+			res.Err = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanNodeServer) handleBootstrapped(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req NodeBootstrappedArgs
 	var res NodeBootstrappedResult
@@ -1392,6 +1486,34 @@ func (s *tchanNodeServer) handleDebugProfileStop(ctx thrift.Context, protocol at
 	return err == nil, &res, nil
 }
 
+func (s *tchanNodeServer) handleDeleteSeries(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req NodeDeleteSeriesArgs
+	var res NodeDeleteSeriesResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.DeleteSeries(ctx, req.Req)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *Error:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for err returned non-nil error type *Error but nil value")
+			}
+			res.Err = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanNodeServer) handleFetch(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req NodeFetchArgs
 	var res NodeFetchResult
@@ -1755,6 +1877,34 @@ func (s *tchanNodeServer) handleRepair(ctx thrift.Context, protocol athrift.TPro
 	return err == nil, &res, nil
 }
 
+func (s *tchanNodeServer) handleRestore(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
+	var req NodeRestoreArgs
+	var res NodeRestoreResult
+
+	if err := req.Read(protocol); err != nil {
+		return false, nil, err
+	}
+
+	r, err :=
+		s.handler.Restore(ctx, req.Req)
+
+	if err != nil {
+		switch v := err.(type) {
+		case *Error:
+			if v == nil {
+				return false, nil, fmt.Errorf("Handler for err returned non-nil error type *Error but nil value")
+			}
+			res.Err = v
+		default:
+			return false, nil, err
+		}
+	} else {
+		res.Success = r
+	}
+
+	return err == nil, &res, nil
+}
+
 func (s *tchanNodeServer) handleSetPersistRateLimit(ctx thrift.Context, protocol athrift.TProtocol) (bool, athrift.TStruct, error) {
 	var req NodeSetPersistRateLimitArgs
 	var res NodeSetPersistRateLimitResult
@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/ident"
 )
 
@@ -56,6 +57,7 @@ type MetadataConfiguration struct {
 	RepairEnabled         *bool                   `yaml:"repairEnabled"`
 	ColdWritesEnabled     *bool                   `yaml:"coldWritesEnabled"`
 	CacheBlocksOnRetrieve *bool                   `yaml:"cacheBlocksOnRetrieve"`
+	Compression           *compress.Type          `yaml:"compression"`
 	Retention             retention.Configuration `yaml:"retention" validate:"nonzero"`
 	Index                 IndexConfiguration      `yaml:"index"`
 }
@@ -88,6 +90,9 @@ func (mc *MetadataConfiguration) Metadata() (Metadata, error) {
 	if v := mc.CacheBlocksOnRetrieve; v != nil {
 		opts = opts.SetCacheBlocksOnRetrieve(*v)
 	}
+	if v := mc.Compression; v != nil {
+		opts = opts.SetCompression(*v)
+	}
 	return NewMetadata(ident.StringID(mc.ID), opts)
 }
 
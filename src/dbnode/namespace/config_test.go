@@ -28,6 +28,7 @@ import (
 	yaml "gopkg.in/yaml.v2"
 
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/ident"
 )
 
@@ -158,6 +159,7 @@ metadatas:
     writesToCommitLog: true
     cleanupEnabled: true
     repairEnabled: true
+    compression: zstd
     retention:
       retentionPeriod: 960h
       blockSize: 12h
@@ -187,6 +189,7 @@ metadatas:
 	require.Equal(t, false, opts.CleanupEnabled())
 	require.Equal(t, false, opts.RepairEnabled())
 	require.Equal(t, false, opts.IndexOptions().Enabled())
+	require.Equal(t, compress.NoneType, opts.Compression())
 	testRetentionOpts := retention.NewOptions().
 		SetRetentionPeriod(8 * time.Hour).
 		SetBlockSize(2 * time.Hour).
@@ -224,6 +227,7 @@ metadatas:
 	require.Equal(t, true, opts.RepairEnabled())
 	require.Equal(t, true, opts.IndexOptions().Enabled())
 	require.Equal(t, 24*time.Hour, opts.IndexOptions().BlockSize())
+	require.Equal(t, compress.ZstdType, opts.Compression())
 	testRetentionOpts = retention.NewOptions().
 		SetRetentionPeriod(960 * time.Hour).
 		SetBlockSize(12 * time.Hour).
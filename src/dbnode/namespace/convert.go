@@ -32,6 +32,7 @@ import (
 
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/ident"
 	xtime "github.com/m3db/m3/src/x/time"
 )
@@ -196,6 +197,11 @@ func ToMetadata(
 		return nil, err
 	}
 
+	compression, err := ToCompression(opts.Compression)
+	if err != nil {
+		return nil, err
+	}
+
 	mOpts := NewOptions().
 		SetBootstrapEnabled(opts.BootstrapEnabled).
 		SetFlushEnabled(opts.FlushEnabled).
@@ -210,7 +216,8 @@ func ToMetadata(
 		SetRuntimeOptions(runtimeOpts).
 		SetExtendedOptions(extendedOpts).
 		SetAggregationOptions(aggOpts).
-		SetStagingState(stagingState)
+		SetStagingState(stagingState).
+		SetCompression(compression)
 
 	if opts.CacheBlocksOnRetrieve != nil {
 		mOpts = mOpts.SetCacheBlocksOnRetrieve(opts.CacheBlocksOnRetrieve.Value)
@@ -232,6 +239,19 @@ func ToStagingState(state *nsproto.StagingState) (StagingState, error) {
 	return NewStagingState(state.Status)
 }
 
+// ToCompression converts nsproto.CompressionType to a compression type.
+func ToCompression(compression nsproto.CompressionType) (compress.Type, error) {
+	switch compression {
+	case nsproto.CompressionType_NO_COMPRESSION:
+		return compress.NoneType, nil
+	case nsproto.CompressionType_ZSTD_COMPRESSION:
+		return compress.ZstdType, nil
+	case nsproto.CompressionType_SNAPPY_COMPRESSION:
+		return compress.SnappyType, nil
+	}
+	return 0, fmt.Errorf("invalid namespace compression: %v", compression)
+}
+
 // ToAggregationOptions converts nsproto.AggregationOptions to AggregationOptions.
 func ToAggregationOptions(opts *nsproto.AggregationOptions) (AggregationOptions, error) {
 	aggOpts := NewAggregationOptions()
@@ -313,6 +333,11 @@ func OptionsToProto(opts Options) (*nsproto.NamespaceOptions, error) {
 		return nil, err
 	}
 
+	compression, err := toProtoCompression(opts.Compression())
+	if err != nil {
+		return nil, err
+	}
+
 	nsOpts := &nsproto.NamespaceOptions{
 		BootstrapEnabled:  opts.BootstrapEnabled(),
 		FlushEnabled:      opts.FlushEnabled(),
@@ -340,6 +365,7 @@ func OptionsToProto(opts Options) (*nsproto.NamespaceOptions, error) {
 		ExtendedOptions:       extendedOpts,
 		AggregationOptions:    toProtoAggregationOptions(opts.AggregationOptions()),
 		StagingState:          stagingState,
+		Compression:           compression,
 	}
 
 	return nsOpts, nil
@@ -361,6 +387,18 @@ func toProtoStagingState(state StagingState) (*nsproto.StagingState, error) {
 	return &nsproto.StagingState{Status: protoStatus}, nil
 }
 
+func toProtoCompression(compression compress.Type) (nsproto.CompressionType, error) {
+	switch compression {
+	case compress.NoneType:
+		return nsproto.CompressionType_NO_COMPRESSION, nil
+	case compress.ZstdType:
+		return nsproto.CompressionType_ZSTD_COMPRESSION, nil
+	case compress.SnappyType:
+		return nsproto.CompressionType_SNAPPY_COMPRESSION, nil
+	}
+	return 0, fmt.Errorf("invalid compression: %v", compression)
+}
+
 func toProtoAggregationOptions(aggOpts AggregationOptions) *nsproto.AggregationOptions {
 	if aggOpts == nil || len(aggOpts.Aggregations()) == 0 {
 		return nil
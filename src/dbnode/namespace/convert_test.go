@@ -31,6 +31,7 @@ import (
 	nsproto "github.com/m3db/m3/src/dbnode/generated/proto/namespace"
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/ident"
 	xtest "github.com/m3db/m3/src/x/test"
 )
@@ -76,6 +77,7 @@ var (
 			SchemaOptions:         testSchemaOptions,
 			ExtendedOptions:       validExtendedOpts,
 			StagingState:          &nsproto.StagingState{Status: nsproto.StagingStatus_INITIALIZING},
+			Compression:           nsproto.CompressionType_ZSTD_COMPRESSION,
 		},
 		{
 			BootstrapEnabled:  true,
@@ -220,7 +222,8 @@ func TestToProto(t *testing.T) {
 	md1, err := namespace.NewMetadata(ident.StringID("ns1"),
 		namespace.NewOptions().
 			SetBootstrapEnabled(true).
-			SetStagingState(state))
+			SetStagingState(state).
+			SetCompression(compress.SnappyType))
 	require.NoError(t, err)
 	md2, err := namespace.NewMetadata(ident.StringID("ns2"),
 		namespace.NewOptions().SetBootstrapEnabled(false))
@@ -422,6 +425,9 @@ func assertEqualMetadata(t *testing.T, name string, expected nsproto.NamespaceOp
 
 	assertEqualRetentions(t, *expected.RetentionOptions, opts.RetentionOptions())
 	assertEqualStagingState(t, expected.StagingState, opts.StagingState())
+	expectedCompression, err := namespace.ToCompression(expected.Compression)
+	require.NoError(t, err)
+	require.Equal(t, expectedCompression, opts.Compression())
 	assertEqualExtendedOpts(t, expected.ExtendedOptions, opts.ExtendedOptions())
 }
 
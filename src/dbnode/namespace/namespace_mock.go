@@ -30,6 +30,7 @@ import (
 
 	"github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/resource"
@@ -131,6 +132,20 @@ func (mr *MockOptionsMockRecorder) ColdWritesEnabled() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdWritesEnabled", reflect.TypeOf((*MockOptions)(nil).ColdWritesEnabled))
 }
 
+// Compression mocks base method.
+func (m *MockOptions) Compression() compress.Type {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compression")
+	ret0, _ := ret[0].(compress.Type)
+	return ret0
+}
+
+// Compression indicates an expected call of Compression.
+func (mr *MockOptionsMockRecorder) Compression() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compression", reflect.TypeOf((*MockOptions)(nil).Compression))
+}
+
 // Equal mocks base method.
 func (m *MockOptions) Equal(value Options) bool {
 	m.ctrl.T.Helper()
@@ -313,6 +328,20 @@ func (mr *MockOptionsMockRecorder) SetColdWritesEnabled(value interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetColdWritesEnabled", reflect.TypeOf((*MockOptions)(nil).SetColdWritesEnabled), value)
 }
 
+// SetCompression mocks base method.
+func (m *MockOptions) SetCompression(value compress.Type) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCompression", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetCompression indicates an expected call of SetCompression.
+func (mr *MockOptionsMockRecorder) SetCompression(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCompression", reflect.TypeOf((*MockOptions)(nil).SetCompression), value)
+}
+
 // SetExtendedOptions mocks base method.
 func (m *MockOptions) SetExtendedOptions(value ExtendedOptions) Options {
 	m.ctrl.T.Helper()
@@ -24,6 +24,7 @@ import (
 	"errors"
 
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/compress"
 )
 
 const (
@@ -77,6 +78,7 @@ type options struct {
 	extendedOpts          ExtendedOptions
 	aggregationOpts       AggregationOptions
 	stagingState          StagingState
+	compression           compress.Type
 }
 
 // NewSchemaHistory returns an empty schema history.
@@ -118,6 +120,10 @@ func (o *options) Validate() error {
 		return err
 	}
 
+	if err := o.compression.Validate(); err != nil {
+		return err
+	}
+
 	if !o.indexOpts.Enabled() {
 		return nil
 	}
@@ -160,7 +166,8 @@ func (o *options) Equal(value Options) bool {
 		o.schemaHis.Equal(value.SchemaHistory()) &&
 		o.runtimeOpts.Equal(value.RuntimeOptions()) &&
 		o.aggregationOpts.Equal(value.AggregationOptions()) &&
-		o.stagingState == value.StagingState()
+		o.stagingState == value.StagingState() &&
+		o.compression == value.Compression()
 }
 
 func (o *options) SetBootstrapEnabled(value bool) Options {
@@ -312,3 +319,13 @@ func (o *options) SetStagingState(value StagingState) Options {
 func (o *options) StagingState() StagingState {
 	return o.stagingState
 }
+
+func (o *options) SetCompression(value compress.Type) Options {
+	opts := *o
+	opts.compression = value
+	return &opts
+}
+
+func (o *options) Compression() compress.Type {
+	return o.compression
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/compress"
 )
 
 func TestOptionsEquals(t *testing.T) {
@@ -217,3 +218,13 @@ func TestOptionsValidateStagingStatus(t *testing.T) {
 	o1 = o1.SetStagingState(StagingState{status: StagingStatus(12)})
 	require.Error(t, o1.Validate())
 }
+
+func TestOptionsValidateCompression(t *testing.T) {
+	o1 := NewOptions().SetCompression(compress.ZstdType)
+	require.NoError(t, o1.Validate())
+	require.Equal(t, compress.ZstdType, o1.Compression())
+	require.False(t, o1.Equal(NewOptions()))
+
+	o1 = o1.SetCompression(compress.Type(12))
+	require.Error(t, o1.Validate())
+}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/m3db/m3/src/cluster/client"
 	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 	xresource "github.com/m3db/m3/src/x/resource"
@@ -131,6 +132,12 @@ type Options interface {
 
 	// StagingState returns the state related to a namespace's availability for use.
 	StagingState() StagingState
+
+	// SetCompression sets the compression applied to the filesets of this namespace.
+	SetCompression(value compress.Type) Options
+
+	// Compression returns the compression applied to the filesets of this namespace.
+	Compression() compress.Type
 }
 
 // IndexOptions controls the indexing options for a namespace.
@@ -263,6 +263,12 @@ func FromRPCFetchTaggedRequest(
 	if len(req.Source) > 0 {
 		opts.Source = req.Source
 	}
+	if req.GetSeriesShardCount() > 1 {
+		opts.SeriesShard = &index.SeriesShard{
+			Index: int(req.GetSeriesShardIndex()),
+			Count: int(req.GetSeriesShardCount()),
+		}
+	}
 
 	q, err := idx.Unmarshal(req.Query)
 	if err != nil {
@@ -326,9 +332,59 @@ func ToRPCFetchTaggedRequest(
 		request.Source = opts.Source
 	}
 
+	if shard := opts.SeriesShard; shard != nil && shard.Count > 1 {
+		shardIndex, shardCount := int32(shard.Index), int32(shard.Count)
+		request.SeriesShardIndex = &shardIndex
+		request.SeriesShardCount = &shardCount
+	}
+
 	return request, nil
 }
 
+// FromRPCDeleteSeriesRequest converts the rpc request type for
+// DeleteSeriesRequest into the query and time range of the series to delete.
+func FromRPCDeleteSeriesRequest(
+	req *rpc.DeleteSeriesRequest,
+) (index.Query, xtime.Range, error) {
+	start, err := ToTime(req.RangeStart, req.RangeTimeType)
+	if err != nil {
+		return index.Query{}, xtime.Range{}, err
+	}
+
+	end, err := ToTime(req.RangeEnd, req.RangeTimeType)
+	if err != nil {
+		return index.Query{}, xtime.Range{}, err
+	}
+
+	q, err := idx.Unmarshal(req.Query)
+	if err != nil {
+		return index.Query{}, xtime.Range{}, err
+	}
+
+	return index.Query{Query: q}, xtime.Range{Start: start, End: end}, nil
+}
+
+// ToRPCDeleteSeriesRequest converts the Go `client/` types into rpc request
+// type for DeleteSeriesRequest.
+func ToRPCDeleteSeriesRequest(
+	ns ident.ID,
+	q index.Query,
+	start, end xtime.UnixNano,
+) (rpc.DeleteSeriesRequest, error) {
+	query, err := idx.Marshal(q.Query)
+	if err != nil {
+		return rpc.DeleteSeriesRequest{}, err
+	}
+
+	return rpc.DeleteSeriesRequest{
+		NameSpace:     ns.Bytes(),
+		Query:         query,
+		RangeStart:    int64(start),
+		RangeEnd:      int64(end),
+		RangeTimeType: rpc.TimeType_UNIX_NANOSECONDS,
+	}, nil
+}
+
 // FromRPCAggregateQueryRequest converts the rpc request type for AggregateRawQueryRequest into corresponding Go API types.
 func FromRPCAggregateQueryRequest(
 	req *rpc.AggregateQueryRequest,
@@ -30,6 +30,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/uber/tchannel-go/thirdparty/github.com/apache/thrift/lib/go/thrift"
 
 	"github.com/m3db/m3/src/dbnode/generated/thrift/rpc"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
@@ -108,8 +109,10 @@ func conjunctionQueryATestCase(t *testing.T) (idx.Query, []byte) {
 
 func TestConvertFetchTaggedRequest(t *testing.T) {
 	var (
-		seriesLimit int64 = 10
-		docsLimit   int64 = 10
+		seriesLimit      int64 = 10
+		docsLimit        int64 = 10
+		seriesShardIndex int32 = 1
+		seriesShardCount int32 = 4
 	)
 	ns := ident.StringID("abc")
 	opts := index.QueryOptions{
@@ -119,6 +122,7 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 		DocsLimit:         int(docsLimit),
 		RequireExhaustive: true,
 		RequireNoWait:     true,
+		SeriesShard:       &index.SeriesShard{Index: 1, Count: 4},
 	}
 	fetchData := true
 	requestSkeleton := &rpc.FetchTaggedRequest{
@@ -130,6 +134,8 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 		DocsLimit:         &docsLimit,
 		RequireExhaustive: true,
 		RequireNoWait:     true,
+		SeriesShardIndex:  &seriesShardIndex,
+		SeriesShardCount:  &seriesShardCount,
 	}
 	requireEqual := func(a, b interface{}) {
 		d := cmp.Diff(a, b)
@@ -180,6 +186,64 @@ func TestConvertFetchTaggedRequest(t *testing.T) {
 	}
 }
 
+func TestFetchTaggedRequestSeriesShardSerialization(t *testing.T) {
+	var (
+		shardIndex int32 = 2
+		shardCount int32 = 3
+		expected         = rpc.NewFetchTaggedRequest()
+	)
+	expected.NameSpace = []byte("abc")
+	expected.Query = []byte("query")
+	expected.SeriesShardIndex = &shardIndex
+	expected.SeriesShardCount = &shardCount
+
+	buf := thrift.NewTMemoryBuffer()
+	require.NoError(t, expected.Write(thrift.NewTBinaryProtocolTransport(buf)))
+
+	actual := rpc.NewFetchTaggedRequest()
+	require.NoError(t, actual.Read(thrift.NewTBinaryProtocolTransport(buf)))
+	require.Equal(t, expected, actual)
+
+	// Requests without a series shard match every series.
+	_, rpcQuery := allQueryTestCase(t)
+	_, _, opts, _, err := convert.FromRPCFetchTaggedRequest(&rpc.FetchTaggedRequest{
+		Query: rpcQuery,
+	}, nil)
+	require.NoError(t, err)
+	require.Nil(t, opts.SeriesShard)
+}
+
+func TestConvertDeleteSeriesRequest(t *testing.T) {
+	var (
+		ns       = ident.StringID("abc")
+		q, data  = conjunctionQueryATestCase(t)
+		end      = xtime.Now().Truncate(time.Second)
+		start    = end.Add(-time.Hour)
+		expected = xtime.Range{Start: start, End: end}
+	)
+
+	req, err := convert.ToRPCDeleteSeriesRequest(ns, index.Query{Query: q}, start, end)
+	require.NoError(t, err)
+	require.Equal(t, ns.Bytes(), req.NameSpace)
+	require.Equal(t, data, req.Query)
+
+	actualQuery, actualRange, err := convert.FromRPCDeleteSeriesRequest(&req)
+	require.NoError(t, err)
+	require.Equal(t, q.String(), actualQuery.String())
+	require.Equal(t, expected, actualRange)
+
+	// Ranges in seconds, the default time type, are also supported.
+	req = rpc.DeleteSeriesRequest{
+		NameSpace:  ns.Bytes(),
+		Query:      data,
+		RangeStart: start.Seconds(),
+		RangeEnd:   end.Seconds(),
+	}
+	_, actualRange, err = convert.FromRPCDeleteSeriesRequest(&req)
+	require.NoError(t, err)
+	require.Equal(t, expected, actualRange)
+}
+
 func TestConvertAggregateRawQueryRequest(t *testing.T) {
 	var (
 		seriesLimit       int64 = 10
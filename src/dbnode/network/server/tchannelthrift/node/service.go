@@ -114,6 +114,9 @@ type serviceMetrics struct {
 	fetchBlocksMetadata     instrument.MethodMetrics
 	repair                  instrument.MethodMetrics
 	truncate                instrument.MethodMetrics
+	deleteSeries            instrument.MethodMetrics
+	backup                  instrument.MethodMetrics
+	restore                 instrument.MethodMetrics
 	fetchBatchRawRPCS       tally.Counter
 	fetchBatchRaw           instrument.BatchMethodMetrics
 	writeBatchRawRPCs       tally.Counter
@@ -138,6 +141,9 @@ func newServiceMetrics(scope tally.Scope, opts instrument.TimerOptions) serviceM
 		fetchBlocksMetadata:     instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", opts),
 		repair:                  instrument.NewMethodMetrics(scope, "repair", opts),
 		truncate:                instrument.NewMethodMetrics(scope, "truncate", opts),
+		deleteSeries:            instrument.NewMethodMetrics(scope, "deleteSeries", opts),
+		backup:                  instrument.NewMethodMetrics(scope, "backup", opts),
+		restore:                 instrument.NewMethodMetrics(scope, "restore", opts),
 		fetchBatchRawRPCS:       scope.Counter("fetchBatchRaw-rpcs"),
 		fetchBatchRaw:           instrument.NewBatchMethodMetrics(scope, "fetchBatchRaw", opts),
 		writeBatchRawRPCs:       scope.Counter("writeBatchRaw-rpcs"),
@@ -2211,6 +2217,103 @@ func (s *service) Truncate(tctx thrift.Context, req *rpc.TruncateRequest) (r *rp
 	return res, nil
 }
 
+func (s *service) DeleteSeries(
+	tctx thrift.Context,
+	req *rpc.DeleteSeriesRequest,
+) (*rpc.DeleteSeriesResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+
+	query, deleted, err := convert.FromRPCDeleteSeriesRequest(req)
+	if err != nil {
+		s.metrics.deleteSeries.ReportError(s.nowFn().Sub(callStart))
+		return nil, tterrors.NewBadRequestError(err)
+	}
+
+	numSeries, err := db.DeleteSeries(ctx, s.newID(ctx, req.NameSpace), query,
+		deleted.Start, deleted.End)
+	if err != nil {
+		s.metrics.deleteSeries.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewDeleteSeriesResult_()
+	res.NumSeries = numSeries
+
+	s.metrics.deleteSeries.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) Backup(
+	tctx thrift.Context,
+	req *rpc.BackupRequest,
+) (*rpc.BackupResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+
+	manifest, err := db.Backup(s.newID(ctx, req.NameSpace), req.BackupID)
+	if err != nil {
+		s.metrics.backup.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewBackupResult_()
+	res.NumFiles = int64(len(manifest.Files))
+	res.NumBytes = manifest.NumBytes()
+
+	s.metrics.backup.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
+func (s *service) Restore(
+	tctx thrift.Context,
+	req *rpc.RestoreRequest,
+) (*rpc.RestoreResult_, error) {
+	db, err := s.startRPCWithDB()
+	if err != nil {
+		return nil, err
+	}
+
+	callStart := s.nowFn()
+	ctx := tchannelthrift.Context(tctx)
+
+	var pointInTime xtime.UnixNano
+	if req.PointInTime != 0 {
+		pointInTime, err = convert.ToTime(req.PointInTime, req.PointInTimeType)
+		if err != nil {
+			s.metrics.restore.ReportError(s.nowFn().Sub(callStart))
+			return nil, tterrors.NewBadRequestError(err)
+		}
+	}
+
+	manifest, err := db.Restore(s.newID(ctx, req.NameSpace), req.BackupID, pointInTime)
+	if err != nil {
+		s.metrics.restore.ReportError(s.nowFn().Sub(callStart))
+		return nil, convert.ToRPCError(err)
+	}
+
+	res := rpc.NewRestoreResult_()
+	res.BackupID = manifest.ID
+	res.NumFiles = int64(len(manifest.Files))
+	res.NumBytes = manifest.NumBytes()
+
+	s.metrics.restore.ReportSuccess(s.nowFn().Sub(callStart))
+
+	return res, nil
+}
+
 func (s *service) GetPersistRateLimit(
 	ctx thrift.Context,
 ) (*rpc.NodePersistRateLimitResult_, error) {
@@ -42,6 +42,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift"
 	"github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/convert"
 	tterrors "github.com/m3db/m3/src/dbnode/network/server/tchannelthrift/errors"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/block"
@@ -3170,6 +3171,100 @@ func TestServiceTruncate(t *testing.T) {
 	assert.Equal(t, truncated, r.NumSeries)
 }
 
+func TestServiceDeleteSeries(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false)
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID    = "metrics"
+		start   = xtime.Now().Truncate(time.Second).Add(-time.Hour)
+		end     = start.Add(time.Hour)
+		deleted = int64(12)
+	)
+	q := index.Query{Query: idx.NewTermQuery([]byte("foo"), []byte("bar"))}
+	req, err := convert.ToRPCDeleteSeriesRequest(ident.StringID(nsID), q, start, end)
+	require.NoError(t, err)
+
+	mockDB.EXPECT().
+		DeleteSeries(gomock.Any(), ident.NewIDMatcher(nsID), gomock.Any(), start, end).
+		DoAndReturn(func(
+			_ context.Context,
+			_ ident.ID,
+			query index.Query,
+			_, _ xtime.UnixNano,
+		) (int64, error) {
+			assert.Equal(t, q.String(), query.String())
+			return deleted, nil
+		})
+
+	r, err := service.DeleteSeries(tctx, &req)
+	require.NoError(t, err)
+	assert.Equal(t, deleted, r.NumSeries)
+}
+
+func TestServiceBackupAndRestore(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDB := storage.NewMockDatabase(ctrl)
+	mockDB.EXPECT().Options().Return(testStorageOpts).AnyTimes()
+	mockDB.EXPECT().IsOverloaded().Return(false).Times(2)
+
+	service := NewService(mockDB, testTChannelThriftOptions).(*service)
+
+	tctx, _ := tchannelthrift.NewContext(time.Minute)
+	ctx := tchannelthrift.Context(tctx)
+	defer ctx.Close()
+
+	var (
+		nsID        = "metrics"
+		pointInTime = xtime.Now().Truncate(time.Second)
+		manifest    = fs.BackupManifest{
+			ID: "backup0",
+			Files: []fs.BackupFile{
+				{Path: "a", Size: 3},
+				{Path: "b", Size: 4},
+			},
+		}
+	)
+
+	mockDB.EXPECT().
+		Backup(ident.NewIDMatcher(nsID), "backup0").
+		Return(manifest, nil)
+
+	backup, err := service.Backup(tctx, &rpc.BackupRequest{
+		NameSpace: []byte(nsID),
+		BackupID:  "backup0",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), backup.NumFiles)
+	assert.Equal(t, int64(7), backup.NumBytes)
+
+	mockDB.EXPECT().
+		Restore(ident.NewIDMatcher(nsID), "", pointInTime).
+		Return(manifest, nil)
+
+	restore, err := service.Restore(tctx, &rpc.RestoreRequest{
+		NameSpace:       []byte(nsID),
+		PointInTime:     pointInTime.Seconds(),
+		PointInTimeType: rpc.TimeType_UNIX_SECONDS,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "backup0", restore.BackupID)
+	assert.Equal(t, int64(2), restore.NumFiles)
+	assert.Equal(t, int64(7), restore.NumBytes)
+}
+
 func TestServiceSetPersistRateLimit(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
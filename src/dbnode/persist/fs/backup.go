@@ -0,0 +1,674 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/objstore"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// backupManifestsPrefix is the prefix of the keys of backup manifests,
+	// which are kept apart from the files of the backups so that finding a
+	// backup only lists manifests.
+	backupManifestsPrefix = "manifests"
+	backupManifestSuffix  = ".json"
+
+	// backupMaxAttempts is how many times the filesets of a shard are listed
+	// and copied before giving up when flushes and cleanup remove files
+	// while they are being copied.
+	backupMaxAttempts = 3
+
+	defaultBackupSnapshotTimeout = 10 * time.Minute
+)
+
+var (
+	errBackupNoStore   = errors.New("backups require an object store")
+	errBackupNoHostID  = errors.New("backups require a host ID")
+	errInvalidBackupID = fmt.Errorf("backup ID must only contain letters, digits, "+
+		"'.', '_' and '-' and must not be '.', '..' or %q", backupManifestsPrefix)
+
+	// ErrBackupNotFound is returned when restoring a backup that does not
+	// exist or is incomplete.
+	ErrBackupNotFound = errors.New("backup not found")
+
+	backupIDPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+)
+
+// BackupManagerOptions are the options for the backup manager.
+type BackupManagerOptions struct {
+	// Store is the object store backups are written to.
+	Store objstore.Store
+	// FilesystemOptions are the options for the local filesets.
+	FilesystemOptions Options
+	// HostID is the ID of the host, backups of each host are kept apart.
+	HostID string
+	// SnapshotTimeout is how long a backup waits for a snapshot taken after
+	// its point in time to complete.
+	SnapshotTimeout time.Duration
+}
+
+// BackupManifest describes a backup of a namespace taken by a host. It is
+// written once all of the files of the backup have been copied, a backup
+// without a manifest is incomplete and can't be restored.
+type BackupManifest struct {
+	ID          string       `json:"id"`
+	Namespace   string       `json:"namespace"`
+	HostID      string       `json:"hostID"`
+	PointInTime time.Time    `json:"pointInTime"`
+	Shards      []uint32     `json:"shards"`
+	Files       []BackupFile `json:"files"`
+}
+
+// NumBytes returns the size of the files of the backup.
+func (m BackupManifest) NumBytes() int64 {
+	var n int64
+	for _, f := range m.Files {
+		n += f.Size
+	}
+	return n
+}
+
+// BackupFile is a file of a backup.
+type BackupFile struct {
+	// Path is the path of the file relative to the file path prefix.
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+type backupMetrics struct {
+	backups       tally.Counter
+	backupErrors  tally.Counter
+	backupBytes   tally.Counter
+	restores      tally.Counter
+	restoreErrors tally.Counter
+	restoreBytes  tally.Counter
+}
+
+func newBackupMetrics(scope tally.Scope) backupMetrics {
+	return backupMetrics{
+		backups:       scope.Counter("backups"),
+		backupErrors:  scope.Counter("backup-errors"),
+		backupBytes:   scope.Counter("backup-bytes"),
+		restores:      scope.Counter("restores"),
+		restoreErrors: scope.Counter("restore-errors"),
+		restoreBytes:  scope.Counter("restore-bytes"),
+	}
+}
+
+type backupManager struct {
+	store            objstore.Store
+	fsOpts           Options
+	filePathPrefix   string
+	hostID           string
+	snapshotTimeout  time.Duration
+	newFileMode      os.FileMode
+	newDirectoryMode os.FileMode
+
+	metrics backupMetrics
+	logger  *zap.Logger
+}
+
+// NewBackupManager returns a new backup manager.
+func NewBackupManager(opts BackupManagerOptions) (BackupManager, error) {
+	if opts.Store == nil {
+		return nil, errBackupNoStore
+	}
+	if opts.HostID == "" {
+		return nil, errBackupNoHostID
+	}
+	fsOpts := opts.FilesystemOptions
+	if fsOpts == nil {
+		fsOpts = NewOptions()
+	}
+	snapshotTimeout := opts.SnapshotTimeout
+	if snapshotTimeout <= 0 {
+		snapshotTimeout = defaultBackupSnapshotTimeout
+	}
+
+	iOpts := fsOpts.InstrumentOptions()
+	return &backupManager{
+		store:            opts.Store,
+		fsOpts:           fsOpts,
+		filePathPrefix:   fsOpts.FilePathPrefix(),
+		hostID:           opts.HostID,
+		snapshotTimeout:  snapshotTimeout,
+		newFileMode:      fsOpts.NewFileMode(),
+		newDirectoryMode: fsOpts.NewDirectoryMode(),
+		metrics:          newBackupMetrics(iOpts.MetricsScope().SubScope("backup")),
+		logger:           iOpts.Logger(),
+	}, nil
+}
+
+func (m *backupManager) SnapshotTimeout() time.Duration {
+	return m.snapshotTimeout
+}
+
+func (m *backupManager) Backup(spec BackupSpec) (BackupManifest, error) {
+	manifest, err := m.backup(spec)
+	if err != nil {
+		m.metrics.backupErrors.Inc(1)
+		return BackupManifest{}, fmt.Errorf("failed to back up namespace %s: %w",
+			spec.Namespace.String(), err)
+	}
+	m.metrics.backups.Inc(1)
+	m.metrics.backupBytes.Inc(manifest.NumBytes())
+	return manifest, nil
+}
+
+func (m *backupManager) backup(spec BackupSpec) (BackupManifest, error) {
+	if !validBackupID(spec.ID) {
+		return BackupManifest{}, errInvalidBackupID
+	}
+
+	snapshotIDs, err := m.snapshotIDsSince(spec.SnapshotIndex)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+
+	var (
+		ctx      = context.Background()
+		manifest = BackupManifest{
+			ID:          spec.ID,
+			Namespace:   spec.Namespace.String(),
+			HostID:      m.hostID,
+			PointInTime: spec.PointInTime.ToTime().UTC(),
+			Shards:      spec.Shards,
+		}
+	)
+	for _, shard := range spec.Shards {
+		shard := shard
+		files, err := m.withRetries(func() ([]BackupFile, error) {
+			return m.backupShard(ctx, spec, shard, snapshotIDs)
+		})
+		if err != nil {
+			return BackupManifest{}, fmt.Errorf("shard %d: %w", shard, err)
+		}
+		manifest.Files = append(manifest.Files, files...)
+	}
+
+	if spec.IndexEnabled {
+		files, err := m.withRetries(func() ([]BackupFile, error) {
+			return m.backupIndex(ctx, spec)
+		})
+		if err != nil {
+			return BackupManifest{}, fmt.Errorf("index: %w", err)
+		}
+		manifest.Files = append(manifest.Files, files...)
+	}
+
+	tombstonesFilePath := NamespaceTombstonesFilePath(m.filePathPrefix, spec.Namespace)
+	exists, err := FileExists(tombstonesFilePath)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	if exists {
+		files, err := m.backupFiles(ctx, spec.ID, m.filePathPrefix,
+			[]string{tombstonesFilePath})
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		manifest.Files = append(manifest.Files, files...)
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	key := backupManifestKey(spec.Namespace.String(), m.hostID, spec.ID)
+	if err := m.store.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		return BackupManifest{}, err
+	}
+
+	m.logger.Info("backed up namespace",
+		zap.String("namespace", manifest.Namespace),
+		zap.String("backupID", manifest.ID),
+		zap.Time("pointInTime", manifest.PointInTime),
+		zap.Int("numFiles", len(manifest.Files)),
+		zap.Int64("numBytes", manifest.NumBytes()))
+	return manifest, nil
+}
+
+// withRetries runs a copy of filesets again when any of their files were
+// removed before they could be copied, which happens when filesets are
+// superseded by flushes, snapshots or compaction during the backup.
+func (m *backupManager) withRetries(
+	fn func() ([]BackupFile, error),
+) ([]BackupFile, error) {
+	var err error
+	for attempt := 0; attempt < backupMaxAttempts; attempt++ {
+		var files []BackupFile
+		files, err = fn()
+		if err == nil || !errors.Is(err, os.ErrNotExist) {
+			return files, err
+		}
+		m.logger.Warn("filesets changed during backup, retrying", zap.Error(err))
+	}
+	return nil, err
+}
+
+func (m *backupManager) backupShard(
+	ctx context.Context,
+	spec BackupSpec,
+	shard uint32,
+	snapshotIDs map[string]struct{},
+) ([]BackupFile, error) {
+	dataFiles, err := DataFiles(m.filePathPrefix, spec.Namespace, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		files []BackupFile
+		tier  = m.fsOpts.TieredStorage()
+	)
+	for _, fileSet := range latestCompleteVolumes(dataFiles) {
+		var (
+			prefix  = m.filePathPrefix
+			release = func() {}
+		)
+		if tier != nil && tier.Enabled(spec.Namespace) {
+			// Offloaded filesets are copied from the tiered storage cache.
+			prefix, release, err = tier.Fetch(fileSet.ID)
+			if err != nil {
+				return nil, err
+			}
+			if prefix != m.filePathPrefix {
+				fileSet, err = m.fileSetAt(prefix, fileSet.ID)
+				if err != nil {
+					release()
+					return nil, err
+				}
+			}
+		}
+		fileSetFiles, err := m.backupFiles(ctx, spec.ID, prefix,
+			fileSet.AbsoluteFilePaths)
+		release()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fileSetFiles...)
+	}
+
+	if spec.SnapshotIndex < 0 {
+		return files, nil
+	}
+
+	snapshotFiles, err := SnapshotFiles(m.filePathPrefix, spec.Namespace, shard)
+	if err != nil {
+		return nil, err
+	}
+	for _, fileSet := range latestCompleteVolumes(snapshotFiles) {
+		_, snapshotID, err := fileSet.SnapshotTimeAndID()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := snapshotIDs[snapshotID.String()]; !ok {
+			// The block was flushed since it was last snapshotted.
+			continue
+		}
+		fileSetFiles, err := m.backupFiles(ctx, spec.ID, m.filePathPrefix,
+			fileSet.AbsoluteFilePaths)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fileSetFiles...)
+	}
+
+	return files, nil
+}
+
+func (m *backupManager) backupIndex(
+	ctx context.Context,
+	spec BackupSpec,
+) ([]BackupFile, error) {
+	indexFiles, err := filesetFiles(filesetFilesSelector{
+		fileSetType:    persist.FileSetFlushType,
+		contentType:    persist.FileSetIndexContentType,
+		filePathPrefix: m.filePathPrefix,
+		namespace:      spec.Namespace,
+		pattern:        filesetFilePattern,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var files []BackupFile
+	for _, fileSet := range indexFiles {
+		// Every complete volume of an index block is used, not only the
+		// latest one.
+		if !fileSet.HasCompleteCheckpointFile() {
+			continue
+		}
+		fileSetFiles, err := m.backupFiles(ctx, spec.ID, m.filePathPrefix,
+			fileSet.AbsoluteFilePaths)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fileSetFiles...)
+	}
+	return files, nil
+}
+
+func (m *backupManager) fileSetAt(
+	prefix string,
+	id FileSetFileIdentifier,
+) (FileSetFile, error) {
+	fileSet, ok, err := FileSetAt(prefix, id.Namespace, id.Shard,
+		id.BlockStart, id.VolumeIndex)
+	if err != nil {
+		return FileSetFile{}, err
+	}
+	if !ok {
+		return FileSetFile{}, fmt.Errorf("fileset %s: %w",
+			tieredFileSetKey(id), os.ErrNotExist)
+	}
+	return fileSet, nil
+}
+
+// backupFiles copies the files under the prefix to the backup, copying any
+// checkpoint file last so that files are restored in an order that only
+// ever makes a fileset complete once all of its files are in place.
+func (m *backupManager) backupFiles(
+	ctx context.Context,
+	backupID string,
+	prefix string,
+	filePaths []string,
+) ([]BackupFile, error) {
+	files := make([]BackupFile, 0, len(filePaths))
+	for _, filePath := range checkpointLast(filePaths) {
+		rel, err := filepath.Rel(prefix, filePath)
+		if err != nil {
+			return nil, err
+		}
+		rel = filepath.ToSlash(rel)
+
+		size, err := m.backupFile(ctx, backupObjectKey(backupID, m.hostID, rel), filePath)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, BackupFile{Path: rel, Size: size})
+	}
+	return files, nil
+}
+
+func (m *backupManager) backupFile(
+	ctx context.Context,
+	key string,
+	filePath string,
+) (int64, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	if err := m.store.Put(ctx, key, f); err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// snapshotIDsSince returns the IDs of the complete snapshots with an index
+// of at least the given index.
+func (m *backupManager) snapshotIDsSince(index int64) (map[string]struct{}, error) {
+	snapshotIDs := make(map[string]struct{})
+	if index < 0 {
+		return snapshotIDs, nil
+	}
+
+	metadatas, _, err := SortedSnapshotMetadataFiles(m.fsOpts)
+	if err != nil {
+		return nil, err
+	}
+	for _, metadata := range metadatas {
+		if metadata.ID.Index >= index {
+			snapshotIDs[metadata.ID.UUID.String()] = struct{}{}
+		}
+	}
+	return snapshotIDs, nil
+}
+
+func (m *backupManager) Restore(
+	namespace ident.ID,
+	backupID string,
+	pointInTime xtime.UnixNano,
+) (BackupManifest, error) {
+	manifest, err := m.restore(namespace, backupID, pointInTime)
+	if err != nil {
+		m.metrics.restoreErrors.Inc(1)
+		return BackupManifest{}, fmt.Errorf("failed to restore namespace %s: %w",
+			namespace.String(), err)
+	}
+	m.metrics.restores.Inc(1)
+	m.metrics.restoreBytes.Inc(manifest.NumBytes())
+	return manifest, nil
+}
+
+func (m *backupManager) restore(
+	namespace ident.ID,
+	backupID string,
+	pointInTime xtime.UnixNano,
+) (BackupManifest, error) {
+	ctx := context.Background()
+	manifest, err := m.findManifest(ctx, namespace.String(), backupID, pointInTime)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+
+	// Validate every path before touching the local files.
+	var (
+		filePaths = make([]string, 0, len(manifest.Files))
+		root      = filepath.Clean(m.filePathPrefix) + string(filepath.Separator)
+	)
+	for _, f := range manifest.Files {
+		filePath := filepath.Join(m.filePathPrefix, filepath.FromSlash(f.Path))
+		if !strings.HasPrefix(filePath, root) {
+			return BackupManifest{}, fmt.Errorf("backup file %s is outside of the file path prefix", f.Path)
+		}
+		filePaths = append(filePaths, filePath)
+	}
+
+	// Remove all of the existing files of the namespace so that none of
+	// them shadow the restored filesets.
+	for _, dirPath := range []string{
+		NamespaceDataDirPath(m.filePathPrefix, namespace),
+		NamespaceSnapshotsDirPath(m.filePathPrefix, namespace),
+		NamespaceIndexDataDirPath(m.filePathPrefix, namespace),
+		NamespaceIndexSnapshotDirPath(m.filePathPrefix, namespace),
+	} {
+		if err := os.RemoveAll(dirPath); err != nil {
+			return BackupManifest{}, err
+		}
+	}
+	if err := removeFiles([]string{
+		NamespaceTombstonesFilePath(m.filePathPrefix, namespace),
+	}); err != nil {
+		return BackupManifest{}, err
+	}
+
+	// Files are restored in the order they were backed up, which restores
+	// the checkpoint file of each fileset last.
+	for i, f := range manifest.Files {
+		if err := os.MkdirAll(filepath.Dir(filePaths[i]), m.newDirectoryMode); err != nil {
+			return BackupManifest{}, err
+		}
+		key := backupObjectKey(manifest.ID, manifest.HostID, f.Path)
+		if _, err := downloadObject(ctx, m.store, key, filePaths[i], m.newFileMode); err != nil {
+			return BackupManifest{}, fmt.Errorf("backup file %s: %w", f.Path, err)
+		}
+	}
+
+	m.logger.Info("restored namespace",
+		zap.String("namespace", manifest.Namespace),
+		zap.String("backupID", manifest.ID),
+		zap.Time("pointInTime", manifest.PointInTime),
+		zap.Int("numFiles", len(manifest.Files)),
+		zap.Int64("numBytes", manifest.NumBytes()))
+	return manifest, nil
+}
+
+// findManifest returns the manifest of the backup with the given ID, or of
+// the latest backup at or before the point in time if no ID is given.
+func (m *backupManager) findManifest(
+	ctx context.Context,
+	namespace string,
+	backupID string,
+	pointInTime xtime.UnixNano,
+) (BackupManifest, error) {
+	if backupID != "" {
+		if !validBackupID(backupID) {
+			return BackupManifest{}, errInvalidBackupID
+		}
+		return m.readManifest(ctx, backupManifestKey(namespace, m.hostID, backupID))
+	}
+
+	prefix := path.Join(backupManifestsPrefix, namespace, m.hostID) + "/"
+	keys, err := m.store.List(ctx, prefix)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+
+	var (
+		latest BackupManifest
+		found  bool
+	)
+	for _, key := range keys {
+		if !strings.HasSuffix(key, backupManifestSuffix) {
+			continue
+		}
+		manifest, err := m.readManifest(ctx, key)
+		if err != nil {
+			return BackupManifest{}, err
+		}
+		if pointInTime != 0 && manifest.PointInTime.After(pointInTime.ToTime()) {
+			continue
+		}
+		if !found || manifest.PointInTime.After(latest.PointInTime) {
+			latest = manifest
+			found = true
+		}
+	}
+	if !found {
+		return BackupManifest{}, ErrBackupNotFound
+	}
+	return latest, nil
+}
+
+func (m *backupManager) readManifest(
+	ctx context.Context,
+	key string,
+) (BackupManifest, error) {
+	r, err := m.store.Get(ctx, key)
+	if errors.Is(err, objstore.ErrNotFound) {
+		return BackupManifest{}, ErrBackupNotFound
+	}
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return BackupManifest{}, err
+	}
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return BackupManifest{}, fmt.Errorf("unable to decode backup manifest %s: %w", key, err)
+	}
+	return manifest, nil
+}
+
+// latestCompleteVolumes returns the latest volume with a complete checkpoint
+// file of every block, ordered by block start.
+func latestCompleteVolumes(fileSets FileSetFilesSlice) []FileSetFile {
+	latest := make(map[xtime.UnixNano]FileSetFile)
+	for _, fileSet := range fileSets {
+		if !fileSet.HasCompleteCheckpointFile() {
+			continue
+		}
+		existing, ok := latest[fileSet.ID.BlockStart]
+		if !ok || fileSet.ID.VolumeIndex > existing.ID.VolumeIndex {
+			latest[fileSet.ID.BlockStart] = fileSet
+		}
+	}
+
+	result := make([]FileSetFile, 0, len(latest))
+	for _, fileSet := range latest {
+		result = append(result, fileSet)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID.BlockStart.Before(result[j].ID.BlockStart)
+	})
+	return result
+}
+
+func checkpointLast(filePaths []string) []string {
+	sorted := make([]string, 0, len(filePaths))
+	var checkpoints []string
+	for _, filePath := range filePaths {
+		if strings.Contains(path.Base(filePath), CheckpointFileSuffix) {
+			checkpoints = append(checkpoints, filePath)
+			continue
+		}
+		sorted = append(sorted, filePath)
+	}
+	return append(sorted, checkpoints...)
+}
+
+func validBackupID(backupID string) bool {
+	switch backupID {
+	case ".", "..", backupManifestsPrefix:
+		return false
+	}
+	return backupIDPattern.MatchString(backupID)
+}
+
+func backupObjectKey(backupID, hostID, rel string) string {
+	return path.Join(backupID, hostID, rel)
+}
+
+func backupManifestKey(namespace, hostID, backupID string) string {
+	return path.Join(backupManifestsPrefix, namespace, hostID, backupID+backupManifestSuffix)
+}
@@ -0,0 +1,239 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/x/objstore"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestBackupManager(
+	t *testing.T,
+	filePathPrefix string,
+	store objstore.Store,
+) BackupManager {
+	mgr, err := NewBackupManager(BackupManagerOptions{
+		Store:             store,
+		FilesystemOptions: testDefaultOpts.SetFilePathPrefix(filePathPrefix),
+		HostID:            "host0",
+	})
+	require.NoError(t, err)
+	return mgr
+}
+
+func backupFilePaths(manifest BackupManifest) []string {
+	paths := make([]string, 0, len(manifest.Files))
+	for _, f := range manifest.Files {
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+func TestBackupAndRestore(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		srcPrefix      = filepath.Join(dir, "src")
+		dstPrefix      = filepath.Join(dir, "dst")
+		entries        = newTestTieredEntries()
+		nextBlockStart = testWriterStart.Add(testBlockSize)
+		pointInTime    = nextBlockStart.Add(time.Minute)
+		store, err     = objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+		srcMgr         = newTestBackupManager(t, srcPrefix, store)
+		dstMgr         = newTestBackupManager(t, dstPrefix, store)
+		fsOpts         = testDefaultOpts.SetFilePathPrefix(srcPrefix)
+		snapshotWriter = NewSnapshotMetadataWriter(fsOpts)
+	)
+	require.NoError(t, err)
+
+	w := newTestWriter(t, srcPrefix)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+	writeTestDataWithVolume(t, w, 0, nextBlockStart, 0, entries[:1], persist.FileSetFlushType)
+	writeTestDataWithVolume(t, w, 0, nextBlockStart, 1, entries, persist.FileSetFlushType)
+	writeTestData(t, w, 1, nextBlockStart, entries, persist.FileSetSnapshotType)
+	require.NoError(t, snapshotWriter.Write(SnapshotMetadataWriteArgs{
+		ID: SnapshotMetadataIdentifier{Index: 0, UUID: testSnapshotID},
+		CommitlogIdentifier: persist.CommitLogFile{
+			FilePath: CommitLogFilePath(srcPrefix, 0),
+			Index:    0,
+		},
+	}))
+
+	manifest, err := srcMgr.Backup(BackupSpec{
+		ID:            "backup0",
+		Namespace:     testNs1ID,
+		Shards:        []uint32{0, 1},
+		PointInTime:   pointInTime,
+		SnapshotIndex: 0,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "backup0", manifest.ID)
+	require.Equal(t, testNs1ID.String(), manifest.Namespace)
+	require.Equal(t, "host0", manifest.HostID)
+	require.True(t, pointInTime.ToTime().Equal(manifest.PointInTime))
+	require.True(t, manifest.NumBytes() > 0)
+
+	// Only the latest volume of a block is backed up, with the checkpoint
+	// file of each fileset last.
+	paths := backupFilePaths(manifest)
+	require.Equal(t, len(tieredFileSetSuffixes)*3, len(paths))
+	for i := len(tieredFileSetSuffixes) - 1; i < len(paths); i += len(tieredFileSetSuffixes) {
+		assert.Contains(t, paths[i], CheckpointFileSuffix)
+	}
+
+	// Stale local files of the namespace are replaced by the restore.
+	stale := newTestWriter(t, dstPrefix)
+	writeTestData(t, stale, 0, testWriterStart.Add(-testBlockSize), entries,
+		persist.FileSetFlushType)
+
+	restored, err := dstMgr.Restore(testNs1ID, "backup0", 0)
+	require.NoError(t, err)
+	require.Equal(t, manifest, restored)
+
+	dataFiles, err := DataFiles(dstPrefix, testNs1ID, 0)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dataFiles))
+	require.Equal(t, testWriterStart, dataFiles[0].ID.BlockStart)
+	require.Equal(t, nextBlockStart, dataFiles[1].ID.BlockStart)
+	require.Equal(t, 1, dataFiles[1].ID.VolumeIndex)
+	require.True(t, dataFiles[1].HasCompleteCheckpointFile())
+
+	snapshotFiles, err := SnapshotFiles(dstPrefix, testNs1ID, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(snapshotFiles))
+	_, snapshotID, err := snapshotFiles[0].SnapshotTimeAndID()
+	require.NoError(t, err)
+	require.Equal(t, testSnapshotID.String(), snapshotID.String())
+
+	r := newTestReader(t, dstPrefix)
+	readTestData(t, r, 0, testWriterStart, entries)
+}
+
+func TestBackupSkipsSnapshotsBeforeSnapshotIndex(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		filePathPrefix = filepath.Join(dir, "data")
+		store, err     = objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+		mgr            = newTestBackupManager(t, filePathPrefix, store)
+		snapshotWriter = NewSnapshotMetadataWriter(
+			testDefaultOpts.SetFilePathPrefix(filePathPrefix))
+	)
+	require.NoError(t, err)
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, newTestTieredEntries(),
+		persist.FileSetSnapshotType)
+	require.NoError(t, snapshotWriter.Write(SnapshotMetadataWriteArgs{
+		ID: SnapshotMetadataIdentifier{Index: 0, UUID: testSnapshotID},
+		CommitlogIdentifier: persist.CommitLogFile{
+			FilePath: CommitLogFilePath(filePathPrefix, 0),
+			Index:    0,
+		},
+	}))
+
+	for _, snapshotIndex := range []int64{-1, 1} {
+		manifest, err := mgr.Backup(BackupSpec{
+			ID:            "backup0",
+			Namespace:     testNs1ID,
+			Shards:        []uint32{0},
+			PointInTime:   testWriterStart,
+			SnapshotIndex: snapshotIndex,
+		})
+		require.NoError(t, err)
+		require.Empty(t, manifest.Files)
+	}
+}
+
+func TestBackupRestoreLatestAtPointInTime(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		filePathPrefix = filepath.Join(dir, "data")
+		store, err     = objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+		mgr            = newTestBackupManager(t, filePathPrefix, store)
+		first          = testWriterStart
+		second         = testWriterStart.Add(time.Hour)
+	)
+	require.NoError(t, err)
+
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, newTestTieredEntries(),
+		persist.FileSetFlushType)
+	for id, pointInTime := range map[string]xtime.UnixNano{
+		"first":  first,
+		"second": second,
+	} {
+		_, err := mgr.Backup(BackupSpec{
+			ID:            id,
+			Namespace:     testNs1ID,
+			Shards:        []uint32{0},
+			PointInTime:   pointInTime,
+			SnapshotIndex: -1,
+		})
+		require.NoError(t, err)
+	}
+
+	manifest, err := mgr.Restore(testNs1ID, "", 0)
+	require.NoError(t, err)
+	require.Equal(t, "second", manifest.ID)
+
+	manifest, err = mgr.Restore(testNs1ID, "", second.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, "first", manifest.ID)
+
+	_, err = mgr.Restore(testNs1ID, "", first.Add(-time.Minute))
+	require.True(t, errors.Is(err, ErrBackupNotFound))
+
+	_, err = mgr.Restore(testNs1ID, "unknown", 0)
+	require.True(t, errors.Is(err, ErrBackupNotFound))
+}
+
+func TestBackupInvalidID(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	store, err := objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+	mgr := newTestBackupManager(t, filepath.Join(dir, "data"), store)
+
+	for _, id := range []string{"", "a/b", "..", backupManifestsPrefix} {
+		_, err := mgr.Backup(BackupSpec{
+			ID:            id,
+			Namespace:     testNs1ID,
+			SnapshotIndex: -1,
+		})
+		require.Error(t, err, id)
+	}
+}
@@ -22,6 +22,7 @@ package commitlog
 
 import (
 	"bufio"
+	"crypto/cipher"
 	"io"
 	"os"
 
@@ -43,6 +44,9 @@ type chunkReader struct {
 	chunkData          []byte
 	chunkDataRemaining int
 	charBuff           []byte
+	// stream decrypts the data read when the commit log is encrypted,
+	// checksums are computed on the encrypted chunk data.
+	stream cipher.Stream
 }
 
 func newChunkReader(bufferLen int) *chunkReader {
@@ -57,6 +61,12 @@ func (r *chunkReader) reset(fd *os.File) {
 	r.fd = fd
 	r.buffer.Reset(fd)
 	r.chunkDataRemaining = 0
+	r.stream = nil
+}
+
+// setStream sets the stream that decrypts all data read from now on.
+func (r *chunkReader) setStream(stream cipher.Stream) {
+	r.stream = stream
 }
 
 func (r *chunkReader) readHeader() error {
@@ -118,6 +128,14 @@ func (r *chunkReader) readHeader() error {
 }
 
 func (r *chunkReader) Read(p []byte) (int, error) {
+	n, err := r.read(p)
+	if r.stream != nil {
+		r.stream.XORKeyStream(p[:n], p[:n])
+	}
+	return n, err
+}
+
+func (r *chunkReader) read(p []byte) (int, error) {
 	size := len(p)
 	read := 0
 	// Check if requesting for size larger than this chunk
@@ -139,7 +157,7 @@ func (r *chunkReader) Read(p []byte) (int, error) {
 		p = p[read:]
 
 		// Perform consecutive read(s)
-		n, err := r.Read(p)
+		n, err := r.read(p)
 		read += n
 		return read, err
 	}
@@ -45,6 +45,7 @@ import (
 	"github.com/m3db/m3/src/x/checked"
 	"github.com/m3db/m3/src/x/clock"
 	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/encryption"
 	"github.com/m3db/m3/src/x/ident"
 	xtime "github.com/m3db/m3/src/x/time"
 )
@@ -495,6 +496,47 @@ func TestCommitLogWrite(t *testing.T) {
 	}
 }
 
+func TestCommitLogWriteEncrypted(t *testing.T) {
+	opts, scope := newTestOptions(t, overrides{
+		strategy: StrategyWriteWait,
+	})
+	keyProvider, err := encryption.NewStaticKeyProvider("key-1", map[string][]byte{
+		"key-1": bytes.Repeat([]byte{1}, encryption.MasterKeyLen),
+	})
+	require.NoError(t, err)
+	opts = opts.SetFilesystemOptions(opts.FilesystemOptions().
+		SetEncryptionKeyProvider(keyProvider))
+	defer cleanup(t, opts)
+
+	writes := []testWrite{
+		{
+			testSeries(t, opts, 0, "encrypted.foo", ident.NewTags(ident.StringTag("name1", "val1")), 127),
+			xtime.Now(), 123.456, xtime.Second, randomByteSlice(opts.FlushSize() - 200), nil,
+		},
+		{
+			testSeries(t, opts, 1, "encrypted.bar", ident.NewTags(ident.StringTag("name2", "val2")), 150),
+			xtime.Now(), 456.789, xtime.Second, randomByteSlice(40 + 2*opts.FlushSize()), nil,
+		},
+	}
+
+	commitLog := newTestCommitLog(t, opts)
+	writeCommitLogs(t, scope, commitLog, writes).Wait()
+	require.NoError(t, commitLog.Close())
+
+	// Series IDs are written in plaintext unless encrypted.
+	files, err := fs.SortedCommitLogFiles(
+		fs.CommitLogsDirPath(opts.FilesystemOptions().FilePathPrefix()))
+	require.NoError(t, err)
+	require.True(t, len(files) > 0)
+	for _, file := range files {
+		contents, err := ioutil.ReadFile(file)
+		require.NoError(t, err)
+		require.False(t, bytes.Contains(contents, []byte("encrypted.")))
+	}
+
+	assertCommitLogWritesByIterating(t, commitLog, writes)
+}
+
 func TestReadCommitLogMissingMetadata(t *testing.T) {
 	readConc := 4
 	// Make sure we're not leaking goroutines
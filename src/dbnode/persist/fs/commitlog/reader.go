@@ -29,6 +29,7 @@ import (
 
 	"go.uber.org/atomic"
 
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/ts"
@@ -141,6 +142,10 @@ func (r *reader) Open(filePath string) (int64, error) {
 		r.Close()
 		return 0, err
 	}
+	if err := r.resetEncryption(info.Encryption); err != nil {
+		r.Close()
+		return 0, err
+	}
 
 	r.fileReadID = commitLogFileReadCounter.Inc()
 
@@ -158,6 +163,20 @@ func (r *reader) readInfo() (schema.LogInfo, error) {
 	return r.infoDecoder.DecodeLogInfo()
 }
 
+func (r *reader) resetEncryption(info schema.EncryptionInfo) error {
+	fsOpts := r.opts.commitLogOptions.FilesystemOptions()
+	dataKey, err := fs.DecryptDataKey(fsOpts, info)
+	if err != nil {
+		return err
+	}
+	cipher, err := fs.NewFileCipher(dataKey, commitLogCipherName)
+	if err != nil || cipher == nil {
+		return err
+	}
+	r.chunkReader.setStream(cipher.Stream())
+	return nil
+}
+
 // Read reads the next log entry in order.
 func (r *reader) Read() (LogEntry, error) {
 	err := r.readLogEntry()
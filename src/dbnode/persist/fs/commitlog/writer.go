@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/encryption"
 	xos "github.com/m3db/m3/src/x/os"
 	xtime "github.com/m3db/m3/src/x/time"
 )
@@ -55,6 +56,10 @@ const (
 	defaultBitSetLength = 65536
 
 	defaultEncoderBuffSize = 16384
+
+	// commitLogCipherName is the name the cipher of commit log files is
+	// derived from, each file is encrypted with its own data key.
+	commitLogCipherName = "commitlog"
 )
 
 var (
@@ -109,6 +114,7 @@ type writer struct {
 	chunkWriter         chunkWriter
 	chunkReserveHeader  []byte
 	buffer              *bufio.Writer
+	encrypter           *encryption.Writer
 	sizeBuffer          []byte
 	seen                *bitset.BitSet
 	logEncoder          *msgpack.Encoder
@@ -131,6 +137,7 @@ func newCommitLogWriter(
 		chunkWriter:         newChunkWriter(flushFn, shouldFsync),
 		chunkReserveHeader:  make([]byte, chunkHeaderLen),
 		buffer:              bufio.NewWriterSize(nil, opts.FlushSize()),
+		encrypter:           encryption.NewWriter(opts.FlushSize()),
 		sizeBuffer:          make([]byte, binary.MaxVarintLen64),
 		seen:                bitset.NewBitSet(defaultBitSetLength),
 		logEncoder:          msgpack.NewEncoder(),
@@ -163,8 +170,17 @@ func (w *writer) Open() (persist.CommitLogFile, error) {
 	if err != nil {
 		return persist.CommitLogFile{}, err
 	}
+	encryptionInfo, dataKey, err := fs.GenerateDataKey(w.opts.FilesystemOptions())
+	if err != nil {
+		return persist.CommitLogFile{}, err
+	}
+	cipher, err := fs.NewFileCipher(dataKey, commitLogCipherName)
+	if err != nil {
+		return persist.CommitLogFile{}, err
+	}
 	logInfo := schema.LogInfo{
-		Index: int64(index),
+		Index:      int64(index),
+		Encryption: encryptionInfo,
 	}
 	w.logEncoder.Reset()
 	if err := w.logEncoder.EncodeLogInfo(logInfo); err != nil {
@@ -177,10 +193,14 @@ func (w *writer) Open() (persist.CommitLogFile, error) {
 
 	w.chunkWriter.reset(fd)
 	w.buffer.Reset(w.chunkWriter)
+	// The log info is written in plaintext since it holds the data key,
+	// everything written after it is encrypted.
+	w.encrypter.Reset(w.buffer, nil)
 	if err := w.write(w.logEncoder.Bytes()); err != nil {
 		w.Close()
 		return persist.CommitLogFile{}, err
 	}
+	w.encrypter.Reset(w.buffer, cipher)
 
 	return persist.CommitLogFile{
 		FilePath: filePath,
@@ -293,10 +313,10 @@ func (w *writer) write(data []byte) error {
 	}
 
 	// Write size and then data
-	if _, err := w.buffer.Write(w.sizeBuffer[:sizeLen]); err != nil {
+	if _, err := w.encrypter.Write(w.sizeBuffer[:sizeLen]); err != nil {
 		return err
 	}
-	_, err := w.buffer.Write(data)
+	_, err := w.encrypter.Write(data)
 	return err
 }
 
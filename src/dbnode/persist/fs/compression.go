@@ -0,0 +1,232 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/m3db/m3/src/x/compress"
+)
+
+var (
+	errCompressedIndexBlockTooLarge = errors.New("compressed index block length is too large")
+	errUnexpectedDecompressedSize   = errors.New("decompressed data does not match expected size")
+)
+
+// maxCompressedIndexBlockSize bounds the length read from a compressed index
+// block header so a corrupt file can't trigger a huge allocation.
+const maxCompressedIndexBlockSize = 1 << 30
+
+// indexBlockWriter writes the index file, when compressed the index entries
+// are buffered into blocks that start at each summary so that the seeker can
+// begin decompressing at any summary offset. Each block is written as its
+// compressed length as a uvarint followed by the compressed bytes.
+type indexBlockWriter struct {
+	writer     io.Writer
+	compressor compress.Compressor
+	offset     int64
+	block      []byte
+	compressed []byte
+	lenBuf     [binary.MaxVarintLen64]byte
+}
+
+func (w *indexBlockWriter) reset(writer io.Writer, compressor compress.Compressor) {
+	w.writer = writer
+	w.compressor = compressor
+	w.offset = 0
+	w.block = w.block[:0]
+}
+
+// startBlock flushes any pending block and returns the offset in the file
+// the next written index entry can be read from.
+func (w *indexBlockWriter) startBlock() (int64, error) {
+	if err := w.flush(); err != nil {
+		return 0, err
+	}
+	return w.offset, nil
+}
+
+func (w *indexBlockWriter) Write(p []byte) (int, error) {
+	if w.compressor == nil {
+		n, err := w.writer.Write(p)
+		w.offset += int64(n)
+		return n, err
+	}
+	w.block = append(w.block, p...)
+	return len(p), nil
+}
+
+func (w *indexBlockWriter) flush() error {
+	if w.compressor == nil || len(w.block) == 0 {
+		return nil
+	}
+	w.compressed = w.compressor.Compress(w.compressed[:0], w.block)
+	w.block = w.block[:0]
+
+	n := binary.PutUvarint(w.lenBuf[:], uint64(len(w.compressed)))
+	if _, err := w.writer.Write(w.lenBuf[:n]); err != nil {
+		return err
+	}
+	if _, err := w.writer.Write(w.compressed); err != nil {
+		return err
+	}
+	w.offset += int64(n + len(w.compressed))
+	return nil
+}
+
+type blockReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// indexBlockReader reads the index entries of a compressed index file from
+// the start of a block onwards, decompressing each block as it is reached.
+type indexBlockReader struct {
+	reader     blockReader
+	compressor compress.Compressor
+	compressed []byte
+	block      []byte
+	pos        int
+}
+
+func newIndexBlockReader() *indexBlockReader {
+	return &indexBlockReader{}
+}
+
+func (r *indexBlockReader) reset(reader blockReader, compressor compress.Compressor) {
+	r.reader = reader
+	r.compressor = compressor
+	r.block = r.block[:0]
+	r.pos = 0
+}
+
+func (r *indexBlockReader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	for r.pos >= len(r.block) {
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.block[r.pos:])
+	r.pos += n
+	return n, nil
+}
+
+func (r *indexBlockReader) ReadByte() (byte, error) {
+	for r.pos >= len(r.block) {
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+	b := r.block[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *indexBlockReader) UnreadByte() error {
+	if r.pos == 0 {
+		return io.ErrNoProgress
+	}
+	r.pos--
+	return nil
+}
+
+func (r *indexBlockReader) next() error {
+	size, err := binary.ReadUvarint(r.reader)
+	if err != nil {
+		return err
+	}
+	if size > maxCompressedIndexBlockSize {
+		return errCompressedIndexBlockTooLarge
+	}
+
+	if uint64(cap(r.compressed)) < size {
+		r.compressed = make([]byte, size)
+	}
+	r.compressed = r.compressed[:size]
+	if _, err := io.ReadFull(r.reader, r.compressed); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	r.block, err = r.compressor.Decompress(r.block[:0], r.compressed)
+	if err != nil {
+		return fmt.Errorf("could not decompress index block: %w", err)
+	}
+	r.pos = 0
+	return nil
+}
+
+// decompressIndex decompresses all the blocks of a compressed index file.
+func decompressIndex(compressor compress.Compressor, dst, src []byte) ([]byte, error) {
+	for len(src) > 0 {
+		size, n := binary.Uvarint(src)
+		if n <= 0 || size > uint64(len(src)-n) {
+			return nil, fmt.Errorf("invalid compressed index block at remaining length %d", len(src))
+		}
+		src = src[n:]
+
+		var err error
+		dst, err = compressor.Decompress(dst, src[:size])
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress index block: %w", err)
+		}
+		src = src[size:]
+	}
+	return dst, nil
+}
+
+// decompressData decompresses the data of an index entry into dst which
+// must be exactly the uncompressed size of the data.
+func decompressData(compressor compress.Compressor, dst, src []byte) error {
+	result, err := compressor.Decompress(dst[:0], src)
+	if err != nil {
+		return fmt.Errorf("could not decompress data: %w", err)
+	}
+	if len(result) != len(dst) {
+		return errUnexpectedDecompressedSize
+	}
+	if len(dst) > 0 && &result[0] != &dst[0] {
+		// The compressor had to allocate a new slice.
+		copy(dst, result)
+	}
+	return nil
+}
+
+// reusableBuffer is a buffer that is grown as required and reused.
+type reusableBuffer struct {
+	buf []byte
+}
+
+func (b *reusableBuffer) bytes(size int) []byte {
+	if cap(b.buf) < size {
+		b.buf = make([]byte, size)
+	}
+	b.buf = b.buf[:size]
+	return b.buf
+}
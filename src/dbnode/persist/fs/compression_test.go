@@ -0,0 +1,246 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3/src/x/compress"
+	"github.com/m3db/m3/src/x/ident"
+)
+
+func newTestCompressedEntries(n int) []testEntry {
+	entries := make([]testEntry, 0, n)
+	for i := 0; i < n; i++ {
+		entries = append(entries, testEntry{
+			id: fmt.Sprintf("compressed.series.%04d", i),
+			tags: map[string]string{
+				"city":    "new_york",
+				"service": fmt.Sprintf("service-%d", i%10),
+			},
+			data: bytes.Repeat([]byte{byte(i), 1, 2, 3}, 16+i%32),
+		})
+	}
+	return entries
+}
+
+func writeTestCompressedData(
+	t *testing.T,
+	w DataFileSetWriter,
+	shard uint32,
+	entries []testEntry,
+	compression compress.Type,
+) {
+	err := w.Open(DataWriterOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      shard,
+			BlockStart: testWriterStart,
+		},
+		BlockSize:   testBlockSize,
+		FileSetType: persist.FileSetFlushType,
+		Compression: compression,
+	})
+	require.NoError(t, err)
+
+	for i := range entries {
+		metadata := persist.NewMetadataFromIDAndTags(entries[i].ID(),
+			entries[i].Tags(), persist.MetadataOptions{})
+		require.NoError(t, w.Write(metadata,
+			bytesRefd(entries[i].data),
+			digest.Checksum(entries[i].data)))
+	}
+	require.NoError(t, w.Close())
+}
+
+func requireSeekTestData(
+	t *testing.T,
+	filePathPrefix string,
+	opts Options,
+	shard uint32,
+	entries []testEntry,
+) {
+	resources := newTestReusableSeekerResources()
+	s := NewSeeker(filePathPrefix, testReaderBufferSize, testReaderBufferSize,
+		testBytesPool, false, opts)
+	require.NoError(t, s.Open(testNs1ID, shard, testWriterStart, 0, resources))
+	defer func() {
+		require.NoError(t, s.Close())
+	}()
+
+	for _, entry := range entries {
+		data, err := s.SeekByID(ident.StringID(entry.id), resources)
+		require.NoError(t, err)
+		data.IncRef()
+		assert.Equal(t, entry.data, data.Bytes())
+		data.DecRef()
+		data.Finalize()
+	}
+
+	_, err := s.SeekByID(ident.StringID("not-exists"), resources)
+	require.Equal(t, errSeekIDNotFound, err)
+}
+
+func TestCompressedReadWrite(t *testing.T) {
+	for _, compression := range []compress.Type{compress.ZstdType, compress.SnappyType} {
+		t.Run(compression.String(), func(t *testing.T) {
+			dir := createTempDir(t)
+			filePathPrefix := filepath.Join(dir, "")
+			defer os.RemoveAll(dir)
+
+			entries := newTestCompressedEntries(200)
+			w := newTestWriter(t, filePathPrefix)
+			writeTestCompressedData(t, w, 0, entries, compression)
+			writeTestData(t, w, 1, testWriterStart, entries, persist.FileSetFlushType)
+
+			r := newTestReader(t, filePathPrefix)
+			readTestData(t, r, 0, testWriterStart, entries)
+
+			require.NoError(t, r.Open(DataReaderOpenOptions{
+				Identifier: FileSetFileIdentifier{
+					Namespace:  testNs1ID,
+					Shard:      0,
+					BlockStart: testWriterStart,
+				},
+			}))
+			for range entries {
+				_, _, _, _, err := readData(t, r)
+				require.NoError(t, err)
+			}
+			require.NoError(t, r.Validate())
+			require.NoError(t, r.Close())
+
+			requireSeekTestData(t, filePathPrefix, testDefaultOpts, 0, entries)
+
+			// The compressed files are recorded in the info file and are
+			// smaller than the uncompressed ones.
+			info := readTestInfoFile(t, filePathPrefix, 0)
+			require.Equal(t, compression, info.Compression)
+			require.Equal(t, int64(schema.CompressedMinorVersion), info.MinorVersion)
+			info = readTestInfoFile(t, filePathPrefix, 1)
+			require.Equal(t, compress.NoneType, info.Compression)
+			require.Equal(t, int64(schema.MinorVersion), info.MinorVersion)
+
+			for _, suffix := range []string{dataFileSuffix, indexFileSuffix} {
+				compressed := testFileSetFileSize(t, filePathPrefix, 0, suffix)
+				uncompressed := testFileSetFileSize(t, filePathPrefix, 1, suffix)
+				require.True(t, compressed < uncompressed,
+					"%s file is not smaller: %d >= %d", suffix, compressed, uncompressed)
+			}
+		})
+	}
+}
+
+func TestCompressedEncryptedReadWrite(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	entries := newTestCompressedEntries(100)
+	provider := newTestKeyProvider(t, "key-1", "key-1")
+	w := newTestEncryptedWriter(t, filePathPrefix, provider)
+	writeTestCompressedData(t, w, 0, entries, compress.ZstdType)
+
+	requireNoFileContains(t, dir, []byte("compressed.series"))
+
+	info := readTestInfoFile(t, filePathPrefix, 0)
+	require.Equal(t, int64(schema.EncryptedMinorVersion), info.MinorVersion)
+
+	r := newTestEncryptedReader(t, filePathPrefix, provider)
+	readTestData(t, r, 0, testWriterStart, entries)
+
+	requireSeekTestData(t, filePathPrefix,
+		testDefaultOpts.SetEncryptionKeyProvider(provider), 0, entries)
+}
+
+func TestIndexBlockWriterReader(t *testing.T) {
+	compressor, err := compress.NewCompressor(compress.ZstdType)
+	require.NoError(t, err)
+
+	var (
+		buf     bytes.Buffer
+		w       indexBlockWriter
+		offsets []int64
+		blocks  = [][]byte{
+			[]byte("first block"),
+			bytes.Repeat([]byte("second block"), 100),
+			[]byte("third block"),
+		}
+	)
+	w.reset(&buf, compressor)
+	for _, block := range blocks {
+		offset, err := w.startBlock()
+		require.NoError(t, err)
+		offsets = append(offsets, offset)
+
+		// Write the block in two parts, they are still compressed together.
+		_, err = w.Write(block[:5])
+		require.NoError(t, err)
+		_, err = w.Write(block[5:])
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.flush())
+	require.Equal(t, int64(buf.Len()), w.offset)
+
+	// Reading from any block start reads through to the end of the file.
+	for i, offset := range offsets {
+		r := newIndexBlockReader()
+		r.reset(bufio.NewReader(bytes.NewReader(buf.Bytes()[offset:])), compressor)
+		result, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, bytes.Join(blocks[i:], nil), result)
+	}
+
+	result, err := decompressIndex(compressor, nil, buf.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, bytes.Join(blocks, nil), result)
+
+	_, err = decompressIndex(compressor, nil, buf.Bytes()[:buf.Len()-1])
+	require.Error(t, err)
+}
+
+func readTestInfoFile(t *testing.T, filePathPrefix string, shard uint32) schema.IndexInfo {
+	results := ReadInfoFiles(filePathPrefix, testNs1ID, shard,
+		testReaderBufferSize, nil, persist.FileSetFlushType)
+	require.Equal(t, 1, len(results))
+	require.NoError(t, results[0].Err.Error())
+	return results[0].Info
+}
+
+func testFileSetFileSize(t *testing.T, filePathPrefix string, shard uint32, suffix string) int64 {
+	shardDir := ShardDataDirPath(filePathPrefix, testNs1ID, shard)
+	path := dataFilesetPathFromTimeAndIndex(shardDir, testWriterStart, 0, suffix, false)
+	stat, err := os.Stat(path)
+	require.NoError(t, err)
+	return stat.Size()
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	"github.com/m3db/m3/src/x/encryption"
+)
+
+var errEncryptedWithoutKeyProvider = errors.New(
+	"files are encrypted but no encryption key provider is set")
+
+// GenerateDataKey generates a new data key to encrypt a set of files with,
+// returning the encryption info to persist alongside the files and the
+// plaintext data key. Both are empty when encryption is disabled.
+func GenerateDataKey(opts Options) (schema.EncryptionInfo, []byte, error) {
+	provider := opts.EncryptionKeyProvider()
+	if provider == nil {
+		return schema.EncryptionInfo{}, nil, nil
+	}
+	dataKey, err := provider.GenerateDataKey()
+	if err != nil {
+		return schema.EncryptionInfo{}, nil, err
+	}
+	info := schema.EncryptionInfo{
+		KeyID:            dataKey.KeyID,
+		EncryptedDataKey: dataKey.Encrypted,
+	}
+	return info, dataKey.Plaintext, nil
+}
+
+// DecryptDataKey returns the plaintext data key of a set of files encrypted
+// as described by the encryption info, or nil if they are not encrypted.
+func DecryptDataKey(opts Options, info schema.EncryptionInfo) ([]byte, error) {
+	if !info.Enabled() {
+		return nil, nil
+	}
+	provider := opts.EncryptionKeyProvider()
+	if provider == nil {
+		return nil, errEncryptedWithoutKeyProvider
+	}
+	return provider.DecryptDataKey(info.KeyID, info.EncryptedDataKey)
+}
+
+// NewFileCipher returns the cipher for the file with the given name, or nil
+// if the data key is nil since the file is then not encrypted.
+func NewFileCipher(dataKey []byte, name string) (*encryption.Cipher, error) {
+	if dataKey == nil {
+		return nil, nil
+	}
+	return encryption.NewCipher(dataKey, name)
+}
+
+// dataFileSetCiphers are the ciphers of the encrypted files of a data
+// fileset volume, the info, digest and checkpoint files are never encrypted
+// as they hold the metadata required to decrypt and validate the others.
+type dataFileSetCiphers struct {
+	index       *encryption.Cipher
+	summaries   *encryption.Cipher
+	bloomFilter *encryption.Cipher
+	data        *encryption.Cipher
+}
+
+func newDataFileSetCiphers(dataKey []byte) (dataFileSetCiphers, error) {
+	var (
+		ciphers dataFileSetCiphers
+		err     error
+	)
+	for _, c := range []struct {
+		cipher **encryption.Cipher
+		suffix string
+	}{
+		{cipher: &ciphers.index, suffix: indexFileSuffix},
+		{cipher: &ciphers.summaries, suffix: summariesFileSuffix},
+		{cipher: &ciphers.bloomFilter, suffix: bloomFilterFileSuffix},
+		{cipher: &ciphers.data, suffix: dataFileSuffix},
+	} {
+		*c.cipher, err = NewFileCipher(dataKey, c.suffix)
+		if err != nil {
+			return dataFileSetCiphers{}, err
+		}
+	}
+	return ciphers, nil
+}
+
+// indexSegmentFileCipherName returns the name the cipher of an index segment
+// file is derived from, all the files of an index volume share a data key.
+func indexSegmentFileCipherName(
+	segmentIdx int,
+	segmentFileType idxpersist.IndexSegmentFileType,
+) string {
+	return fmt.Sprintf("segment-%d-%s", segmentIdx, segmentFileType)
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	"github.com/m3db/m3/src/x/encryption"
+	"github.com/m3db/m3/src/x/ident"
+)
+
+func newTestKeyProvider(t *testing.T, currentKeyID string, keyIDs ...string) encryption.KeyProvider {
+	keys := make(map[string][]byte, len(keyIDs))
+	for i, keyID := range keyIDs {
+		keys[keyID] = bytes.Repeat([]byte{byte(i + 1)}, encryption.MasterKeyLen)
+	}
+	provider, err := encryption.NewStaticKeyProvider(currentKeyID, keys)
+	require.NoError(t, err)
+	return provider
+}
+
+func newTestEncryptedWriter(
+	t *testing.T,
+	filePathPrefix string,
+	provider encryption.KeyProvider,
+) DataFileSetWriter {
+	writer, err := NewWriter(testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetWriterBufferSize(testWriterBufferSize).
+		SetEncryptionKeyProvider(provider))
+	require.NoError(t, err)
+	return writer
+}
+
+func newTestEncryptedReader(
+	t *testing.T,
+	filePathPrefix string,
+	provider encryption.KeyProvider,
+) DataFileSetReader {
+	reader, err := NewReader(testBytesPool, testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetInfoReaderBufferSize(testReaderBufferSize).
+		SetDataReaderBufferSize(testReaderBufferSize).
+		SetEncryptionKeyProvider(provider))
+	require.NoError(t, err)
+	return reader
+}
+
+// requireNoFileContains asserts that none of the files written contain the
+// plaintext value.
+func requireNoFileContains(t *testing.T, dir string, value []byte) {
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		contents, err := ioutil.ReadFile(path)
+		require.NoError(t, err)
+		require.False(t, bytes.Contains(contents, value),
+			"file %s contains plaintext", path)
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestEncryptedReadWrite(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	entries := []testEntry{
+		{"encrypted-foo", nil, []byte("plaintext-datapoints")},
+		{"encrypted-bar", nil, make([]byte, 65536)},
+		{"encrypted-baz", map[string]string{
+			"encrypted-tag": "encrypted-value",
+		}, []byte{7, 8, 9}},
+	}
+
+	provider := newTestKeyProvider(t, "key-1", "key-1")
+	w := newTestEncryptedWriter(t, filePathPrefix, provider)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	requireNoFileContains(t, dir, []byte("encrypted-"))
+	requireNoFileContains(t, dir, []byte("plaintext-datapoints"))
+
+	// Encrypted files can't be read by older versions.
+	info := readTestInfoFile(t, filePathPrefix, 0)
+	require.True(t, info.Encryption.Enabled())
+	require.Equal(t, int64(schema.EncryptedMinorVersion), info.MinorVersion)
+
+	r := newTestEncryptedReader(t, filePathPrefix, provider)
+	readTestData(t, r, 0, testWriterStart, entries)
+
+	require.NoError(t, r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	}))
+	for range entries {
+		_, _, _, _, err := readData(t, r)
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.Validate())
+	require.NoError(t, r.Close())
+
+	resources := newTestReusableSeekerResources()
+	s := NewSeeker(filePathPrefix, testReaderBufferSize, testReaderBufferSize,
+		testBytesPool, false, testDefaultOpts.SetEncryptionKeyProvider(provider))
+	require.NoError(t, s.Open(testNs1ID, 0, testWriterStart, 0, resources))
+	for _, entry := range entries {
+		data, err := s.SeekByID(ident.StringID(entry.id), resources)
+		require.NoError(t, err)
+		data.IncRef()
+		assert.Equal(t, entry.data, data.Bytes())
+		data.DecRef()
+		data.Finalize()
+	}
+	require.NoError(t, s.Close())
+}
+
+func TestEncryptedReadWriteKeyRotation(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+	}
+
+	w := newTestEncryptedWriter(t, filePathPrefix,
+		newTestKeyProvider(t, "key-1", "key-1"))
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	// Filesets written with the previous key remain readable as long as the
+	// previous key is still provided.
+	rotated := newTestKeyProvider(t, "key-2", "key-1", "key-2")
+	r := newTestEncryptedReader(t, filePathPrefix, rotated)
+	readTestData(t, r, 0, testWriterStart, entries)
+
+	w = newTestEncryptedWriter(t, filePathPrefix, rotated)
+	writeTestData(t, w, 1, testWriterStart, entries, persist.FileSetFlushType)
+	require.NoError(t, r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      1,
+			BlockStart: testWriterStart,
+		},
+	}))
+	require.Equal(t, "key-2", r.(*reader).encryption.KeyID)
+	require.NoError(t, r.Close())
+
+	// Once the previous key is removed the filesets it encrypted can no
+	// longer be read.
+	r = newTestEncryptedReader(t, filePathPrefix,
+		newTestKeyProvider(t, "key-2", "key-2"))
+	err := r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	})
+	require.True(t, errors.Is(err, encryption.ErrKeyNotFound))
+}
+
+func TestEncryptedReadWithoutKeyProvider(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	entries := []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+	}
+
+	w := newTestEncryptedWriter(t, filePathPrefix,
+		newTestKeyProvider(t, "key-1", "key-1"))
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	r := newTestReader(t, filePathPrefix)
+	err := r.Open(DataReaderOpenOptions{
+		Identifier: FileSetFileIdentifier{
+			Namespace:  testNs1ID,
+			Shard:      0,
+			BlockStart: testWriterStart,
+		},
+	})
+	require.Equal(t, errEncryptedWithoutKeyProvider, err)
+}
+
+func TestEncryptedIndexReadWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	test := newIndexWriteTestSetup(t)
+	defer test.cleanup()
+
+	provider := newTestKeyProvider(t, "key-1", "key-1")
+	writer, err := NewIndexWriter(testDefaultOpts.
+		SetFilePathPrefix(test.filePathPrefix).
+		SetWriterBufferSize(testWriterBufferSize).
+		SetEncryptionKeyProvider(provider))
+	require.NoError(t, err)
+	require.NoError(t, writer.Open(IndexWriterOpenOptions{
+		Identifier:  test.fileSetID,
+		BlockSize:   test.blockSize,
+		FileSetType: persist.FileSetFlushType,
+		Shards:      shardsSet(1, 3, 5),
+	}))
+
+	plaintext := append([]byte("plaintext-segment"), randDataFactorOfBuffSize(t, 1.5)...)
+	testSegments := []testIndexSegment{
+		{
+			segmentType:  idxpersist.IndexSegmentType("fst"),
+			majorVersion: 1,
+			minorVersion: 2,
+			files: []testIndexSegmentFile{
+				{idxpersist.IndexSegmentFileType("first"), plaintext},
+				{idxpersist.IndexSegmentFileType("second"), randDataFactorOfBuffSize(t, 2.5)},
+			},
+		},
+	}
+	writeTestIndexSegments(t, ctrl, writer, testSegments)
+	require.NoError(t, writer.Close())
+
+	requireNoFileContains(t, test.rootDir, []byte("plaintext-segment"))
+
+	reader, err := NewIndexReader(testDefaultOpts.
+		SetFilePathPrefix(test.filePathPrefix).
+		SetIndexReaderAutovalidateIndexSegments(true).
+		SetEncryptionKeyProvider(provider))
+	require.NoError(t, err)
+	result, err := reader.Open(IndexReaderOpenOptions{
+		Identifier:  test.fileSetID,
+		FileSetType: persist.FileSetFlushType,
+	})
+	require.NoError(t, err)
+	require.Equal(t, shardsSet(1, 3, 5), result.Shards)
+
+	readTestIndexSegments(t, ctrl, reader, testSegments)
+	require.NoError(t, reader.Validate())
+	require.NoError(t, reader.Close())
+}
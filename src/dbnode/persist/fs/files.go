@@ -58,6 +58,7 @@ const (
 	indexDirName      = "index"
 	snapshotDirName   = "snapshots"
 	commitLogsDirName = "commitlogs"
+	tombstonesDirName = "tombstones"
 
 	// The maximum number of delimeters ('-' or '.') that is expected in a
 	// (base) filename.
@@ -1516,6 +1517,17 @@ func CommitLogsDirPath(prefix string) string {
 	return path.Join(prefix, commitLogsDirName)
 }
 
+// TombstonesDirPath returns the path to the series tombstones directory.
+func TombstonesDirPath(prefix string) string {
+	return path.Join(prefix, tombstonesDirName)
+}
+
+// NamespaceTombstonesFilePath returns the path to the series tombstones file
+// for a given namespace.
+func NamespaceTombstonesFilePath(prefix string, namespace ident.ID) string {
+	return path.Join(TombstonesDirPath(prefix), namespace.String()+".json")
+}
+
 // DataFileSetExists determines whether data fileset files exist for the given
 // namespace, shard, block start, and volume.
 func DataFileSetExists(
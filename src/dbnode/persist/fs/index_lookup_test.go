@@ -82,6 +82,7 @@ func TestNewNearestIndexOffsetDetectsUnsortedFiles(t *testing.T) {
 		msgpack.NewByteDecoderStream(nil),
 		len(outOfOrderSummaries),
 		false,
+		nil,
 		mmap.ReporterOptions{},
 	)
 	expectedErr := fmt.Errorf("summaries file is not sorted: %s", file.Name())
@@ -226,6 +227,7 @@ func newIndexLookupWithSummaries(
 		msgpack.NewByteDecoderStream(nil),
 		len(indexSummaries),
 		forceMmapMemory,
+		nil,
 		mmap.ReporterOptions{},
 	)
 	require.NoError(t, err)
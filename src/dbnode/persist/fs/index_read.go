@@ -32,7 +32,9 @@ import (
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/generated/proto/index"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
 	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	"github.com/m3db/m3/src/x/encryption"
 	"github.com/m3db/m3/src/x/mmap"
 	xtime "github.com/m3db/m3/src/x/time"
 )
@@ -57,6 +59,7 @@ type indexReader struct {
 	expectedDigest         index.IndexDigests
 	expectedDigestOfDigest uint32
 	readDigests            indexReaderReadDigests
+	dataKey                []byte
 }
 
 type indexReaderReadDigests struct {
@@ -135,6 +138,14 @@ func (r *indexReader) Open(
 	if err := r.readInfoFile(infoFilepath); err != nil {
 		return result, err
 	}
+	dataKey, err := DecryptDataKey(r.opts, schema.EncryptionInfo{
+		KeyID:            r.info.EncryptionKeyID,
+		EncryptedDataKey: r.info.EncryptedDataKey,
+	})
+	if err != nil {
+		return result, err
+	}
+	r.dataKey = dataKey
 	result.Shards = make(map[uint32]struct{}, len(r.info.Shards))
 	for _, shard := range r.info.Shards {
 		result.Shards[shard] = struct{}{}
@@ -257,9 +268,6 @@ func (r *indexReader) ReadSegmentFileSet() (
 			r.logger.Warn("warning while mmapping files in reader", zap.Error(warning))
 		}
 
-		file := newReadableIndexSegmentFileMmap(segFileType, fd, desc)
-		result.files = append(result.files, file)
-
 		if r.opts.IndexReaderAutovalidateIndexSegments() {
 			// Only checksum the file if we are autovalidating the index
 			// segments on open.
@@ -269,11 +277,32 @@ func (r *indexReader) ReadSegmentFileSet() (
 			})
 		}
 
-		// NB(bodu): Free mmaped bytes after we take the checksum so we don't
-		// get memory spikes at bootstrap time.
-		if err := mmap.MadviseDontNeed(desc); err != nil {
+		cipher, err := NewFileCipher(r.dataKey, indexSegmentFileCipherName(r.currIdx, segFileType))
+		if err != nil {
+			mmap.Munmap(desc)
+			fd.Close()
+			return nil, err
+		}
+		if cipher != nil {
+			// Encrypted files are decrypted into an anonymous region which
+			// replaces the file backed one.
+			decrypted, err := r.decryptSegmentFile(desc, cipher)
+			mmap.Munmap(desc)
+			if err != nil {
+				fd.Close()
+				return nil, err
+			}
+			desc = decrypted
+		} else if err := mmap.MadviseDontNeed(desc); err != nil {
+			// NB(bodu): Free mmaped bytes after we take the checksum so we don't
+			// get memory spikes at bootstrap time.
+			mmap.Munmap(desc)
+			fd.Close()
 			return nil, err
 		}
+
+		file := newReadableIndexSegmentFileMmap(segFileType, fd, desc)
+		result.files = append(result.files, file)
 	}
 
 	r.currIdx++
@@ -282,6 +311,28 @@ func (r *indexReader) ReadSegmentFileSet() (
 	return result, nil
 }
 
+func (r *indexReader) decryptSegmentFile(
+	encrypted mmap.Descriptor,
+	cipher *encryption.Cipher,
+) (mmap.Descriptor, error) {
+	decrypted, err := mmap.Bytes(int64(len(encrypted.Bytes)), mmap.Options{
+		Read:    true,
+		Write:   true,
+		HugeTLB: r.hugePagesOpts,
+		ReporterOptions: mmap.ReporterOptions{
+			Context: mmap.Context{
+				Name: mmapPersistFsIndexName,
+			},
+			Reporter: r.opts.MmapReporter(),
+		},
+	})
+	if err != nil {
+		return mmap.Descriptor{}, err
+	}
+	cipher.XORKeyStreamAt(decrypted.Bytes, encrypted.Bytes, 0)
+	return decrypted, nil
+}
+
 func (r *indexReader) Validate() error {
 	if err := r.validateDigestsFileDigest(); err != nil {
 		return err
@@ -34,7 +34,9 @@ import (
 	"github.com/m3db/m3/src/dbnode/digest"
 	"github.com/m3db/m3/src/dbnode/generated/proto/index"
 	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
 	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
+	"github.com/m3db/m3/src/x/encryption"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	xos "github.com/m3db/m3/src/x/os"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -62,6 +64,7 @@ type indexWriter struct {
 	newFileMode      os.FileMode
 	newDirectoryMode os.FileMode
 	fdWithDigest     digest.FdWithDigestWriter
+	encrypter        *encryption.Writer
 
 	err             error
 	blockSize       time.Duration
@@ -72,6 +75,8 @@ type indexWriter struct {
 	indexVolumeType idxpersist.IndexVolumeType
 	shards          map[uint32]struct{}
 	segments        []writtenIndexSegment
+	encryption      schema.EncryptionInfo
+	dataKey         []byte
 
 	namespaceDir       string
 	checkpointFilePath string
@@ -103,6 +108,7 @@ func NewIndexWriter(opts Options) (IndexFileSetWriter, error) {
 		newFileMode:      opts.NewFileMode(),
 		newDirectoryMode: opts.NewDirectoryMode(),
 		fdWithDigest:     digest.NewFdWithDigestWriter(indexWriteBufferSize),
+		encrypter:        encryption.NewWriter(indexWriteBufferSize),
 	}, nil
 }
 
@@ -153,6 +159,12 @@ func (w *indexWriter) Open(opts IndexWriterOpenOptions) error {
 			w.checkpointFilePath)
 	}
 
+	// Each volume is encrypted with its own data key.
+	w.encryption, w.dataKey, err = GenerateDataKey(w.opts)
+	if err != nil {
+		return err
+	}
+
 	// NB: Write out an incomplete index info file when we start writing a volume,
 	// this is later used in the cleanup of corrupted/incomplete index filesets.
 	infoFileData, err := w.infoFileData()
@@ -207,16 +219,23 @@ func (w *indexWriter) WriteSegmentFileSet(
 			return w.markSegmentWriteError(segType, segFileType, err)
 		}
 
+		cipher, err := NewFileCipher(w.dataKey, indexSegmentFileCipherName(idx, segFileType))
+		if err != nil {
+			return w.markSegmentWriteError(segType, segFileType, err)
+		}
+
 		fd, err := OpenWritable(filePath, w.newFileMode)
 		if err != nil {
 			return w.markSegmentWriteError(segType, segFileType, err)
 		}
 
 		// Use buffered IO writer to write the file in case the reader
-		// returns small chunks of data
+		// returns small chunks of data, the digest is computed on the
+		// encrypted bytes when encryption is enabled.
 		w.fdWithDigest.Reset(fd)
+		w.encrypter.Reset(w.fdWithDigest, cipher)
 		digest := w.fdWithDigest.Digest()
-		writer := bufio.NewWriter(w.fdWithDigest)
+		writer := bufio.NewWriter(w.encrypter)
 		writeErr := segmentFileSet.WriteFile(segFileType, writer)
 		err = xerrors.FirstError(writeErr, writer.Flush(), w.fdWithDigest.Close())
 		if err != nil {
@@ -258,6 +277,8 @@ func (w *indexWriter) infoFileData() ([]byte, error) {
 		IndexVolumeType: &protobuftypes.StringValue{
 			Value: string(w.indexVolumeType),
 		},
+		EncryptionKeyID:  w.encryption.KeyID,
+		EncryptedDataKey: w.encryption.EncryptedDataKey,
 	}
 	for _, segment := range w.segments {
 		segmentInfo := &index.SegmentInfo{
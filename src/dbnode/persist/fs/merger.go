@@ -55,7 +55,8 @@ type merger struct {
 // NewMerger returns a new Merger. This implementation is in charge of merging
 // the data from an existing fileset with a merge target. If data for a series
 // at a timestamp exists both on disk and the merge target, data from the merge
-// target will be used. This merged data is then persisted.
+// target will be used. Datapoints covered by tombstones of the merge target
+// are dropped. This merged data is then persisted.
 //
 // Note that the merger does not know how or where this merged data is
 // persisted since it just uses the flushPreparer that is passed in. Further,
@@ -206,10 +207,13 @@ func (m *merger) Merge(
 				FinalizeTagIterator: true,
 			})
 
+		// Drop any datapoints of the series that have been deleted.
+		deleted := tombstonesForBlock(mergeWith.Tombstones(id), blockStart, blockSize)
+
 		// In the special (but common) case that we're just copying the series data from the old file
 		// into the new one without merging or adding any additional data we can avoid recalculating
 		// the checksum.
-		if len(segmentReaders) == 1 && hasInMemoryData == false {
+		if len(segmentReaders) == 1 && hasInMemoryData == false && len(deleted) == 0 {
 			segment, err := segmentReaders[0].Segment()
 			if err != nil {
 				return closer, err
@@ -219,7 +223,7 @@ func (m *merger) Merge(
 				return closer, err
 			}
 		} else {
-			if err := persistSegmentReaders(metadata, segmentReaders, iterResources, prepared.Persist); err != nil {
+			if err := persistSegmentReaders(metadata, segmentReaders, deleted, iterResources, prepared.Persist); err != nil {
 				return closer, err
 			}
 		}
@@ -239,7 +243,9 @@ func (m *merger) Merge(
 			segmentReaders = appendBlockReadersToSegmentReaders(segmentReaders, mergeWithData.Blocks)
 
 			metadata := persist.NewMetadata(seriesMetadata)
-			err := persistSegmentReaders(metadata, segmentReaders, iterResources, prepared.Persist)
+			deleted := tombstonesForBlock(mergeWith.Tombstones(ident.BytesID(seriesMetadata.ID)),
+				blockStart, blockSize)
+			err := persistSegmentReaders(metadata, segmentReaders, deleted, iterResources, prepared.Persist)
 
 			if err == nil {
 				err = onFlush.OnFlushNewSeries(persist.OnFlushNewSeriesEvent{
@@ -313,9 +319,28 @@ func segmentReaderFromData(
 	return segReader
 }
 
+// tombstonesForBlock returns the deleted time ranges that overlap a block.
+func tombstonesForBlock(
+	ranges []xtime.Range,
+	blockStart xtime.UnixNano,
+	blockSize time.Duration,
+) []xtime.Range {
+	var (
+		block  = xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}
+		result []xtime.Range
+	)
+	for _, r := range ranges {
+		if r.Overlaps(block) {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
 func persistSegmentReaders(
 	metadata persist.Metadata,
 	segReaders []xio.SegmentReader,
+	deleted []xtime.Range,
 	ir iterResources,
 	persistFn persist.DataFn,
 ) error {
@@ -323,16 +348,17 @@ func persistSegmentReaders(
 		return nil
 	}
 
-	if len(segReaders) == 1 {
+	if len(segReaders) == 1 && len(deleted) == 0 {
 		return persistSegmentReader(metadata, segReaders[0], persistFn)
 	}
 
-	return persistIter(metadata, segReaders, ir, persistFn)
+	return persistIter(metadata, segReaders, deleted, ir, persistFn)
 }
 
 func persistIter(
 	metadata persist.Metadata,
 	segReaders []xio.SegmentReader,
+	deleted []xtime.Range,
 	ir iterResources,
 	persistFn persist.DataFn,
 ) error {
@@ -341,7 +367,11 @@ func persistIter(
 	encoder := ir.encoderPool.Get()
 	encoder.Reset(ir.blockStart, ir.blockAllocSize, ir.schema)
 	for it.Next() {
-		if err := encoder.Encode(it.Current()); err != nil {
+		dp, unit, annotation := it.Current()
+		if IsTombstoned(deleted, dp.TimestampNanos) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
 			encoder.Close()
 			return err
 		}
@@ -351,6 +381,14 @@ func persistIter(
 		return err
 	}
 
+	if encoder.NumEncoded() == 0 {
+		// All datapoints of the series in this block have been deleted, so
+		// the series is not persisted at all.
+		encoder.Close()
+		metadata.Finalize()
+		return nil
+	}
+
 	segment := encoder.Discard()
 	return persistSegment(metadata, segment, persistFn)
 }
@@ -434,6 +434,68 @@ func TestMergeWithNoData(t *testing.T) {
 	testMergeWith(t, diskData, mergeTargetData, expected)
 }
 
+func TestMergeWithTombstones(t *testing.T) {
+	diskData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	diskData.Set(id0, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(0 * time.Second), Value: 0},
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 1},
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 2},
+		{TimestampNanos: startTime.Add(3 * time.Second), Value: 3},
+	}))
+	diskData.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 4},
+		{TimestampNanos: startTime.Add(4 * time.Second), Value: 5},
+	}))
+	diskData.Set(id2, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 6},
+	}))
+
+	mergeTargetData := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	mergeTargetData.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(3 * time.Second), Value: 7},
+		{TimestampNanos: startTime.Add(5 * time.Second), Value: 8},
+	}))
+	mergeTargetData.Set(id3, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(1 * time.Second), Value: 9},
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 10},
+	}))
+
+	tombstones := map[string][]xtime.Range{
+		id0.String(): {
+			{Start: startTime.Add(1 * time.Second), End: startTime.Add(3 * time.Second)},
+		},
+		id1.String(): {
+			{Start: startTime.Add(4 * time.Second), End: startTime.Add(time.Hour)},
+		},
+		// Deletes the entire series from the block.
+		id2.String(): {
+			{Start: startTime.Add(-time.Hour), End: startTime.Add(time.Hour)},
+		},
+		id3.String(): {
+			{Start: startTime, End: startTime.Add(2 * time.Second)},
+		},
+		// Tombstones outside of the block are ignored.
+		id4.String(): {
+			{Start: startTime.Add(-time.Hour), End: startTime},
+		},
+	}
+
+	expected := newCheckedBytesByIDMap(newCheckedBytesByIDMapOptions{})
+	expected.Set(id0, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(0 * time.Second), Value: 0},
+		{TimestampNanos: startTime.Add(3 * time.Second), Value: 3},
+	}))
+	expected.Set(id1, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 4},
+		{TimestampNanos: startTime.Add(3 * time.Second), Value: 7},
+	}))
+	expected.Set(id3, datapointsToCheckedBytes(t, []ts.Datapoint{
+		{TimestampNanos: startTime.Add(2 * time.Second), Value: 10},
+	}))
+
+	testMergeWithTombstones(t, diskData, mergeTargetData, tombstones, expected)
+}
+
 func TestCleanup(t *testing.T) {
 	dir := createTempDir(t)
 	filePathPrefix := filepath.Join(dir, "")
@@ -532,6 +594,16 @@ func testMergeWith(
 	diskData *checkedBytesMap,
 	mergeTargetData *checkedBytesMap,
 	expectedData *checkedBytesMap,
+) {
+	testMergeWithTombstones(t, diskData, mergeTargetData, nil, expectedData)
+}
+
+func testMergeWithTombstones(
+	t *testing.T,
+	diskData *checkedBytesMap,
+	mergeTargetData *checkedBytesMap,
+	tombstones map[string][]xtime.Range,
+	expectedData *checkedBytesMap,
 ) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -570,7 +642,7 @@ func testMergeWith(
 		Shard:      uint32(8),
 		BlockStart: startTime,
 	}
-	mergeWith := mockMergeWithFromData(t, ctrl, diskData, mergeTargetData)
+	mergeWith := mockMergeWithFromData(t, ctrl, diskData, mergeTargetData, tombstones)
 	close, err := merger.Merge(fsID, mergeWith, 1, preparer, nsCtx, &persist.NoOpColdFlushNamespace{})
 	require.NoError(t, err)
 	require.False(t, deferClosed)
@@ -655,8 +727,14 @@ func mockMergeWithFromData(
 	ctrl *gomock.Controller,
 	diskData *checkedBytesMap,
 	mergeTargetData *checkedBytesMap,
+	tombstones map[string][]xtime.Range,
 ) *MockMergeWith {
 	mergeWith := NewMockMergeWith(ctrl)
+	mergeWith.EXPECT().Tombstones(gomock.Any()).
+		DoAndReturn(func(id ident.ID) []xtime.Range {
+			return tombstones[id.String()]
+		}).
+		AnyTimes()
 
 	// Get the series IDs in the merge target that does not exist in disk data.
 	// This logic is not tested here because it should be part of tests of the
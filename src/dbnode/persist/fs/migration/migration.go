@@ -24,6 +24,8 @@ import (
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3/src/x/compress"
 	xtime "github.com/m3db/m3/src/x/time"
 )
 
@@ -44,11 +46,30 @@ type toVersion1_1Task struct {
 	opts TaskOptions
 }
 
-// MigrationTask returns true or false if a fileset should be migrated. If true, also returns
-// a function that can be used to create a new migration task.
-func MigrationTask(info fs.ReadInfoFileResult) (NewTaskFn, bool) {
-	if info.Info.MajorVersion == 1 && info.Info.MinorVersion == 0 {
-		return NewToVersion1_1Task, true
+// toVersion1_2Task is an object responsible for migrating a fileset to version 1.2.
+type toVersion1_2Task struct {
+	opts TaskOptions
+}
+
+// MigrationTask returns true or false if a fileset should be migrated to the target
+// version. If true, also returns a function that can be used to create a new migration task.
+func MigrationTask(
+	info fs.ReadInfoFileResult,
+	md namespace.Metadata,
+	target MigrationVersion,
+) (NewTaskFn, bool) {
+	if info.Info.MajorVersion != 1 {
+		return nil, false
+	}
+	switch target {
+	case MigrationVersion_1_1:
+		if info.Info.MinorVersion == 0 {
+			return NewToVersion1_1Task, true
+		}
+	case MigrationVersion_1_2:
+		if info.Info.MinorVersion == 0 || info.Info.Compression != md.Options().Compression() {
+			return NewToVersion1_2Task, true
+		}
 	}
 	return nil, false
 }
@@ -65,14 +86,39 @@ func NewToVersion1_1Task(opts TaskOptions) (Task, error) {
 
 // Run executes the steps to bring a fileset to Version 1.1.
 func (v *toVersion1_1Task) Run() (fs.ReadInfoFileResult, error) {
+	// Simply rewrite the same files with the current encoder which will generate
+	// index files with the entry level checksums.
+	return rewriteFileSet(v.opts)
+}
+
+// NewToVersion1_2Task creates a task for migrating a fileset to version 1.2.
+func NewToVersion1_2Task(opts TaskOptions) (Task, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return &toVersion1_2Task{
+		opts: opts,
+	}, nil
+}
+
+// Run executes the steps to bring a fileset to Version 1.2.
+func (v *toVersion1_2Task) Run() (fs.ReadInfoFileResult, error) {
+	// Rewriting the files compresses them with the compression currently
+	// configured for the namespace, or decompresses them if it was disabled.
+	return rewriteFileSet(v.opts)
+}
+
+// rewriteFileSet rewrites a fileset into a new volume with the current encoder
+// and the current namespace options.
+func rewriteFileSet(opts TaskOptions) (fs.ReadInfoFileResult, error) {
 	var (
-		sOpts          = v.opts.StorageOptions()
-		fsOpts         = v.opts.FilesystemOptions()
-		newMergerFn    = v.opts.NewMergerFn()
-		nsMd           = v.opts.NamespaceMetadata()
-		infoFileResult = v.opts.InfoFileResult()
-		shard          = v.opts.Shard()
-		persistManager = v.opts.PersistManager()
+		sOpts          = opts.StorageOptions()
+		fsOpts         = opts.FilesystemOptions()
+		newMergerFn    = opts.NewMergerFn()
+		nsMd           = opts.NamespaceMetadata()
+		infoFileResult = opts.InfoFileResult()
+		shard          = opts.Shard()
+		persistManager = opts.PersistManager()
 	)
 	reader, err := fs.NewReader(sOpts.BytesPool(), fsOpts)
 	if err != nil {
@@ -99,8 +145,7 @@ func (v *toVersion1_1Task) Run() (fs.ReadInfoFileResult, error) {
 		return infoFileResult, err
 	}
 
-	// Intentionally use a noop merger here as we simply want to rewrite the same files with the current encoder which
-	// will generate index files with the entry level checksums.
+	// Intentionally use a noop merger here as we simply want to rewrite the same files.
 	newIndex := volIndex + 1
 	if err = merger.MergeAndCleanup(fsID, fs.NewNoopMergeWith(), newIndex, flushPersist, nsCtx,
 		&persist.NoOpColdFlushNamespace{}, false); err != nil {
@@ -111,8 +156,13 @@ func (v *toVersion1_1Task) Run() (fs.ReadInfoFileResult, error) {
 		return infoFileResult, err
 	}
 
+	compression := nsMd.Options().Compression()
 	infoFileResult.Info.VolumeIndex = newIndex
-	infoFileResult.Info.MinorVersion = 1
+	infoFileResult.Info.MinorVersion = schema.MinorVersion
+	if compression != compress.NoneType {
+		infoFileResult.Info.MinorVersion = schema.CompressedMinorVersion
+	}
+	infoFileResult.Info.Compression = compression
 
 	return infoFileResult, nil
 }
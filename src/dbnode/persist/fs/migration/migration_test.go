@@ -36,10 +36,12 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
+	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/storage"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -131,6 +133,138 @@ func TestToVersion1_1Run(t *testing.T) {
 	require.Contains(t, err.Error(), "checksum mismatch")
 }
 
+func TestToVersion1_2Run(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	var shard uint32 = 1
+	nsID := ident.StringID("foo")
+
+	// Write uncompressed fileset to disk
+	fsOpts := writeUnmigratedData(t, filePathPrefix, nsID, shard).
+		SetEncodingOptions(msgpack.DefaultLegacyEncodingOptions)
+
+	results := fs.ReadInfoFiles(filePathPrefix, nsID, shard,
+		fsOpts.InfoReaderBufferSize(), fsOpts.DecodingOptions(), persist.FileSetFlushType)
+	require.Equal(t, 1, len(results))
+	infoFileResult := results[0]
+	require.Equal(t, compress.NoneType, infoFileResult.Info.Compression)
+
+	md, err := namespace.NewMetadata(nsID, namespace.NewOptions().
+		SetCompression(compress.ZstdType))
+	require.NoError(t, err)
+
+	newTaskFn, ok := MigrationTask(infoFileResult, md, MigrationVersion_1_2)
+	require.True(t, ok)
+
+	pm, err := fs.NewPersistManager(fsOpts)
+	require.NoError(t, err)
+	fs.ResetIndexClaimsManagersUnsafe()
+	icm, err := fs.NewIndexClaimsManager(fsOpts)
+	require.NoError(t, err)
+
+	plCache, err := index.NewPostingsListCache(1, index.PostingsListCacheOptions{
+		InstrumentOptions: instrument.NewOptions(),
+	})
+	require.NoError(t, err)
+	defer plCache.Start()()
+
+	task, err := newTaskFn(NewTaskOptions().
+		SetNewMergerFn(fs.NewMerger).
+		SetPersistManager(pm).
+		SetNamespaceMetadata(md).
+		SetStorageOptions(storage.DefaultTestOptions().
+			SetPersistManager(pm).
+			SetIndexClaimsManager(icm).
+			SetNamespaceInitializer(namespace.NewStaticInitializer([]namespace.Metadata{md})).
+			SetRepairEnabled(false).
+			SetIndexOptions(index.NewOptions().
+				SetPostingsListCache(plCache)).
+			SetBlockLeaseManager(block.NewLeaseManager(nil))).
+		SetShard(shard).
+		SetInfoFileResult(infoFileResult).
+		SetFilesystemOptions(fsOpts))
+	require.NoError(t, err)
+
+	updatedInfoFile, err := task.Run()
+	require.NoError(t, err)
+	require.Equal(t, compress.ZstdType, updatedInfoFile.Info.Compression)
+	require.Equal(t, int64(schema.CompressedMinorVersion), updatedInfoFile.Info.MinorVersion)
+
+	// Read new info file and make sure it matches results returned by task
+	newInfoBytes, err := ioutil.ReadAll(openFile(t, fsOpts, nsID, shard, updatedInfoFile, "info"))
+	require.NoError(t, err)
+	decoder := msgpack.NewDecoder(nil)
+	decoder.Reset(msgpack.NewByteDecoderStream(newInfoBytes))
+	info, err := decoder.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, updatedInfoFile.Info, info)
+
+	// The migrated fileset no longer needs to be migrated and can be read back.
+	_, ok = MigrationTask(updatedInfoFile, md, MigrationVersion_1_2)
+	require.False(t, ok)
+
+	reader, err := fs.NewReader(nil, fsOpts)
+	require.NoError(t, err)
+	require.NoError(t, reader.Open(fs.DataReaderOpenOptions{
+		Identifier: fs.FileSetFileIdentifier{
+			Namespace:   nsID,
+			Shard:       shard,
+			BlockStart:  xtime.UnixNano(info.BlockStart),
+			VolumeIndex: info.VolumeIndex,
+		},
+	}))
+	id, _, data, _, err := reader.Read()
+	require.NoError(t, err)
+	require.Equal(t, "foo", id.String())
+	data.IncRef()
+	require.Equal(t, []byte{1, 2, 3}, data.Bytes())
+	data.DecRef()
+	require.NoError(t, reader.Validate())
+	require.NoError(t, reader.Close())
+}
+
+func TestMigrationTask(t *testing.T) {
+	uncompressedMd, err := namespace.NewMetadata(ident.StringID("foo"), namespace.NewOptions())
+	require.NoError(t, err)
+	compressedMd, err := namespace.NewMetadata(ident.StringID("foo"), namespace.NewOptions().
+		SetCompression(compress.SnappyType))
+	require.NoError(t, err)
+
+	newInfo := func(minorVersion int64, compression compress.Type) fs.ReadInfoFileResult {
+		return fs.ReadInfoFileResult{Info: schema.IndexInfo{
+			MajorVersion: 1,
+			MinorVersion: minorVersion,
+			Compression:  compression,
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		info    fs.ReadInfoFileResult
+		md      namespace.Metadata
+		target  MigrationVersion
+		migrate bool
+	}{
+		{"none", newInfo(0, compress.NoneType), uncompressedMd, MigrationVersionNone, false},
+		{"1.1 from 1.0", newInfo(0, compress.NoneType), uncompressedMd, MigrationVersion_1_1, true},
+		{"1.1 from 1.1", newInfo(1, compress.NoneType), compressedMd, MigrationVersion_1_1, false},
+		{"1.2 from 1.0", newInfo(0, compress.NoneType), uncompressedMd, MigrationVersion_1_2, true},
+		{"1.2 same compression", newInfo(1, compress.NoneType), uncompressedMd, MigrationVersion_1_2, false},
+		{"1.2 compress", newInfo(1, compress.NoneType), compressedMd, MigrationVersion_1_2, true},
+		{"1.2 decompress", newInfo(2, compress.SnappyType), uncompressedMd, MigrationVersion_1_2, true},
+		{"1.2 recompress", newInfo(2, compress.ZstdType), compressedMd, MigrationVersion_1_2, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newTaskFn, migrate := MigrationTask(test.info, test.md, test.target)
+			require.Equal(t, test.migrate, migrate)
+			require.Equal(t, test.migrate, newTaskFn != nil)
+		})
+	}
+}
+
 func openFile(
 	t *testing.T,
 	fsOpts fs.Options,
@@ -27,12 +27,16 @@ const (
 	MigrationVersionNone MigrationVersion = iota
 	// MigrationVersion_1_1 indicates node should attempt to migrate data files up to version 1.1.
 	MigrationVersion_1_1
+	// MigrationVersion_1_2 indicates node should attempt to migrate data files up to version 1.2,
+	// rewriting data files whose compression differs from the one configured for their namespace.
+	MigrationVersion_1_2
 )
 
 var (
 	validMigrationVersions = []MigrationVersion{
 		MigrationVersionNone,
 		MigrationVersion_1_1,
+		MigrationVersion_1_2,
 	}
 )
 
@@ -42,6 +46,8 @@ func (m *MigrationVersion) String() string {
 		return "none"
 	case MigrationVersion_1_1:
 		return "1.1"
+	case MigrationVersion_1_2:
+		return "1.2"
 	default:
 		return "unknown"
 	}
@@ -36,13 +36,20 @@ func TestParseMigrateVersion(t *testing.T) {
 	v, err = ParseMigrationVersion("1.1")
 	require.NoError(t, err)
 	require.Equal(t, MigrationVersion_1_1, v)
+
+	v, err = ParseMigrationVersion("1.2")
+	require.NoError(t, err)
+	require.Equal(t, MigrationVersion_1_2, v)
 }
 
 func TestValidateMigrateVersion(t *testing.T) {
 	err := ValidateMigrationVersion(MigrationVersion_1_1)
 	require.NoError(t, err)
 
-	err = ValidateMigrationVersion(2)
+	err = ValidateMigrationVersion(MigrationVersion_1_2)
+	require.NoError(t, err)
+
+	err = ValidateMigrationVersion(3)
 	require.Error(t, err)
 }
 
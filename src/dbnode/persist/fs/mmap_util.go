@@ -24,6 +24,7 @@ import (
 	"fmt"
 
 	"github.com/m3db/m3/src/dbnode/digest"
+	"github.com/m3db/m3/src/x/encryption"
 	"github.com/m3db/m3/src/x/mmap"
 )
 
@@ -31,8 +32,20 @@ func validateAndMmap(
 	fdWithDigest digest.FdWithDigestReader,
 	expectedDigest uint32,
 	forceMmapMemory bool,
+	cipher *encryption.Cipher,
 	reporterOptions mmap.ReporterOptions,
 ) (mmap.Descriptor, error) {
+	if cipher != nil {
+		// Encrypted files are always decrypted into an anonymous region
+		// after validating the digest of the encrypted bytes.
+		mmapDescriptor, err := validateAndMmapMemory(fdWithDigest, expectedDigest, reporterOptions)
+		if err != nil {
+			return mmap.Descriptor{}, err
+		}
+		cipher.XORKeyStreamAt(mmapDescriptor.Bytes, mmapDescriptor.Bytes, 0)
+		return mmapDescriptor, nil
+	}
+
 	if forceMmapMemory {
 		return validateAndMmapMemory(fdWithDigest, expectedDigest, reporterOptions)
 	}
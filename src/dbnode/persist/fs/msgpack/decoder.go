@@ -29,6 +29,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/pool"
 )
 
@@ -109,7 +110,7 @@ func (dec *Decoder) DecodeIndexInfo() (schema.IndexInfo, error) {
 		return emptyIndexInfo, dec.err
 	}
 
-	_, numFieldsToSkip := dec.decodeRootObject(indexInfoVersion, indexInfoType)
+	_, numFieldsToSkip := dec.decodeRootObject(encryptedIndexInfoVersion, indexInfoType)
 	indexInfo := dec.decodeIndexInfo()
 	dec.skip(numFieldsToSkip)
 	if dec.err != nil {
@@ -154,7 +155,7 @@ func (dec *Decoder) DecodeLogInfo() (schema.LogInfo, error) {
 	if dec.err != nil {
 		return emptyLogInfo, dec.err
 	}
-	_, numFieldsToSkip := dec.decodeRootObject(logInfoVersion, logInfoType)
+	_, numFieldsToSkip := dec.decodeRootObject(encryptedLogInfoVersion, logInfoType)
 	logInfo := dec.decodeLogInfo()
 	dec.skip(numFieldsToSkip)
 	if dec.err != nil {
@@ -273,6 +274,16 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 		opts.override = true
 		opts.numExpectedMinFields = 6
 		opts.numExpectedCurrFields = 10
+	case LegacyEncodingIndexVersionV5:
+		// V5 had 11 fields.
+		opts.override = true
+		opts.numExpectedMinFields = 6
+		opts.numExpectedCurrFields = 11
+	case LegacyEncodingIndexVersionV6:
+		// V6 had 13 fields.
+		opts.override = true
+		opts.numExpectedMinFields = 6
+		opts.numExpectedCurrFields = 13
 	}
 
 	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(indexInfoType, opts)
@@ -325,10 +336,40 @@ func (dec *Decoder) decodeIndexInfo() schema.IndexInfo {
 	// Decode fields added in V5.
 	indexInfo.MinorVersion = dec.decodeVarint()
 
+	// At this point if its a V5 file we've decoded all the available fields.
+	if dec.legacy.DecodeLegacyIndexInfoVersion == LegacyEncodingIndexVersionV5 || actual < 13 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	// Decode fields added in V6.
+	indexInfo.Encryption = dec.decodeEncryptionInfo()
+
+	// At this point if its a V6 file we've decoded all the available fields.
+	if dec.legacy.DecodeLegacyIndexInfoVersion == LegacyEncodingIndexVersionV6 || actual < 14 {
+		dec.skip(numFieldsToSkip)
+		return indexInfo
+	}
+
+	// Decode fields added in V7.
+	indexInfo.Compression = compress.Type(dec.decodeVarint())
+
 	dec.skip(numFieldsToSkip)
 	return indexInfo
 }
 
+func (dec *Decoder) decodeEncryptionInfo() schema.EncryptionInfo {
+	var info schema.EncryptionInfo
+	keyID, _, _ := dec.decodeBytes()
+	info.KeyID = string(keyID)
+	encryptedDataKey, _, _ := dec.decodeBytes()
+	if len(encryptedDataKey) > 0 {
+		// Copy since the bytes may reference the underlying stream.
+		info.EncryptedDataKey = append([]byte(nil), encryptedDataKey...)
+	}
+	return info
+}
+
 func (dec *Decoder) decodeIndexSummariesInfo() schema.IndexSummariesInfo {
 	numFieldsToSkip, _, ok := dec.checkNumFieldsFor(indexSummariesInfoType, checkNumFieldsOptions{})
 	if !ok {
@@ -371,8 +412,13 @@ func (dec *Decoder) checkNumIndexEntryFields() (int, int, bool) {
 		opts.override = true
 		opts.numExpectedMinFields = 5
 		opts.numExpectedCurrFields = 6
+	case LegacyEncodingIndexEntryVersionV3:
+		// V3 had 7 fields.
+		opts.override = true
+		opts.numExpectedMinFields = 5
+		opts.numExpectedCurrFields = 7
 	case LegacyEncodingIndexEntryVersionCurrent:
-		// V3 is current version, no overrides needed
+		// V4 is current version, no overrides needed
 		break
 	default:
 		dec.err = fmt.Errorf("invalid legacyEncodingIndexEntryVersion provided: %v",
@@ -423,6 +469,12 @@ func (dec *Decoder) decodeIndexEntry(bytesPool pool.BytesPool) schema.IndexEntry
 
 	// NB(nate): Any new fields should be parsed here.
 
+	// Decode fields added in V4, the checksum is still the final field so
+	// only decode them if there are more than the V3 fields.
+	if dec.legacy.DecodeLegacyIndexEntryVersion != LegacyEncodingIndexEntryVersionV3 && actual >= 8 {
+		indexEntry.CompressedSize = dec.decodeVarint()
+	}
+
 	// Intentionally skip any extra fields here as we've stipulated that from V3 onward, IndexEntryChecksum will be the
 	// final field on index entries
 	dec.skip(numFieldsToSkip)
@@ -474,7 +526,7 @@ func (dec *Decoder) decodeIndexSummary() (schema.IndexSummary, IndexSummaryToken
 }
 
 func (dec *Decoder) decodeLogInfo() schema.LogInfo {
-	numFieldsToSkip, _, ok := dec.checkNumFieldsFor(logInfoType, checkNumFieldsOptions{})
+	numFieldsToSkip, actual, ok := dec.checkNumFieldsFor(logInfoType, checkNumFieldsOptions{})
 	if !ok {
 		return emptyLogInfo
 	}
@@ -485,6 +537,12 @@ func (dec *Decoder) decodeLogInfo() schema.LogInfo {
 	logInfo.DeprecatedDoNotUseDuration = dec.decodeVarint()
 
 	logInfo.Index = dec.decodeVarint()
+
+	// Encryption info was added after the initial fields.
+	if actual >= 5 {
+		logInfo.Encryption = dec.decodeEncryptionInfo()
+	}
+
 	dec.skip(numFieldsToSkip)
 	if dec.err != nil {
 		return emptyLogInfo
@@ -58,22 +58,25 @@ type Encoder struct {
 type LegacyEncodingIndexInfoVersion int
 
 const (
-	LegacyEncodingIndexVersionCurrent                                = LegacyEncodingIndexVersionV5
+	LegacyEncodingIndexVersionCurrent                                = LegacyEncodingIndexVersionV7
 	LegacyEncodingIndexVersionV1      LegacyEncodingIndexInfoVersion = iota
 	LegacyEncodingIndexVersionV2
 	LegacyEncodingIndexVersionV3
 	LegacyEncodingIndexVersionV4
 	LegacyEncodingIndexVersionV5
+	LegacyEncodingIndexVersionV6
+	LegacyEncodingIndexVersionV7
 )
 
 // LegacyEncodingIndexEntryVersion is the encoding/decoding version to use when processing index entries
 type LegacyEncodingIndexEntryVersion int
 
 const (
-	LegacyEncodingIndexEntryVersionCurrent                                 = LegacyEncodingIndexEntryVersionV3
+	LegacyEncodingIndexEntryVersionCurrent                                 = LegacyEncodingIndexEntryVersionV4
 	LegacyEncodingIndexEntryVersionV1      LegacyEncodingIndexEntryVersion = iota
 	LegacyEncodingIndexEntryVersionV2
 	LegacyEncodingIndexEntryVersionV3
+	LegacyEncodingIndexEntryVersionV4
 )
 
 // LegacyEncodingOptions allows you to specify the version to use when encoding/decoding
@@ -141,7 +144,11 @@ func (enc *Encoder) EncodeIndexInfo(info schema.IndexInfo) error {
 	if enc.err != nil {
 		return enc.err
 	}
-	enc.encodeRootObject(indexInfoVersion, indexInfoType)
+	version := indexInfoVersion
+	if info.Encryption.Enabled() {
+		version = encryptedIndexInfoVersion
+	}
+	enc.encodeRootObject(version, indexInfoType)
 	switch enc.legacy.EncodeLegacyIndexInfoVersion {
 	case LegacyEncodingIndexVersionV1:
 		enc.encodeIndexInfoV1(info)
@@ -151,8 +158,12 @@ func (enc *Encoder) EncodeIndexInfo(info schema.IndexInfo) error {
 		enc.encodeIndexInfoV3(info)
 	case LegacyEncodingIndexVersionV4:
 		enc.encodeIndexInfoV4(info)
-	default:
+	case LegacyEncodingIndexVersionV5:
 		enc.encodeIndexInfoV5(info)
+	case LegacyEncodingIndexVersionV6:
+		enc.encodeIndexInfoV6(info)
+	default:
+		enc.encodeIndexInfoV7(info)
 	}
 	return enc.err
 }
@@ -173,8 +184,10 @@ func (enc *Encoder) EncodeIndexEntry(entry schema.IndexEntry) error {
 		enc.encodeIndexEntryV1(entry)
 	case LegacyEncodingIndexEntryVersionV2:
 		enc.encodeIndexEntryV2(entry)
-	default:
+	case LegacyEncodingIndexEntryVersionV3:
 		enc.encodeIndexEntryV3(entry, checksumStart)
+	default:
+		enc.encodeIndexEntryV4(entry, checksumStart)
 	}
 	return enc.err
 }
@@ -194,7 +207,11 @@ func (enc *Encoder) EncodeLogInfo(info schema.LogInfo) error {
 	if enc.err != nil {
 		return enc.err
 	}
-	enc.encodeRootObject(logInfoVersion, logInfoType)
+	version := logInfoVersion
+	if info.Encryption.Enabled() {
+		version = encryptedLogInfoVersion
+	}
+	enc.encodeRootObject(version, logInfoType)
 	enc.encodeLogInfo(info)
 	return enc.err
 }
@@ -278,6 +295,38 @@ func (enc *Encoder) encodeIndexInfoV4(info schema.IndexInfo) {
 }
 
 func (enc *Encoder) encodeIndexInfoV5(info schema.IndexInfo) {
+	enc.encodeArrayLenFn(11) // V5 had 11 fields.
+	enc.encodeVarintFn(info.BlockStart)
+	enc.encodeVarintFn(info.BlockSize)
+	enc.encodeVarintFn(info.Entries)
+	enc.encodeVarintFn(info.MajorVersion)
+	enc.encodeIndexSummariesInfo(info.Summaries)
+	enc.encodeIndexBloomFilterInfo(info.BloomFilter)
+	enc.encodeVarintFn(info.SnapshotTime)
+	enc.encodeVarintFn(int64(info.FileType))
+	enc.encodeBytesFn(info.SnapshotID)
+	enc.encodeVarintFn(int64(info.VolumeIndex))
+	enc.encodeVarintFn(info.MinorVersion)
+}
+
+func (enc *Encoder) encodeIndexInfoV6(info schema.IndexInfo) {
+	enc.encodeArrayLenFn(13) // V6 had 13 fields.
+	enc.encodeVarintFn(info.BlockStart)
+	enc.encodeVarintFn(info.BlockSize)
+	enc.encodeVarintFn(info.Entries)
+	enc.encodeVarintFn(info.MajorVersion)
+	enc.encodeIndexSummariesInfo(info.Summaries)
+	enc.encodeIndexBloomFilterInfo(info.BloomFilter)
+	enc.encodeVarintFn(info.SnapshotTime)
+	enc.encodeVarintFn(int64(info.FileType))
+	enc.encodeBytesFn(info.SnapshotID)
+	enc.encodeVarintFn(int64(info.VolumeIndex))
+	enc.encodeVarintFn(info.MinorVersion)
+	enc.encodeBytesFn([]byte(info.Encryption.KeyID))
+	enc.encodeBytesFn(info.Encryption.EncryptedDataKey)
+}
+
+func (enc *Encoder) encodeIndexInfoV7(info schema.IndexInfo) {
 	enc.encodeNumObjectFieldsForFn(indexInfoType)
 	enc.encodeVarintFn(info.BlockStart)
 	enc.encodeVarintFn(info.BlockSize)
@@ -290,6 +339,9 @@ func (enc *Encoder) encodeIndexInfoV5(info schema.IndexInfo) {
 	enc.encodeBytesFn(info.SnapshotID)
 	enc.encodeVarintFn(int64(info.VolumeIndex))
 	enc.encodeVarintFn(info.MinorVersion)
+	enc.encodeBytesFn([]byte(info.Encryption.KeyID))
+	enc.encodeBytesFn(info.Encryption.EncryptedDataKey)
+	enc.encodeVarintFn(int64(info.Compression))
 }
 
 func (enc *Encoder) encodeIndexSummariesInfo(info schema.IndexSummariesInfo) {
@@ -326,6 +378,19 @@ func (enc *Encoder) encodeIndexEntryV2(entry schema.IndexEntry) {
 }
 
 func (enc *Encoder) encodeIndexEntryV3(entry schema.IndexEntry, checksumStart int) {
+	enc.encodeArrayLenFn(7) // V3 had 7 fields.
+	enc.encodeVarintFn(entry.Index)
+	enc.encodeBytesFn(entry.ID)
+	enc.encodeVarintFn(entry.Size)
+	enc.encodeVarintFn(entry.Offset)
+	enc.encodeVarintFn(entry.DataChecksum)
+	enc.encodeBytesFn(entry.EncodedTags)
+
+	checksum := digest.Checksum(enc.Bytes()[checksumStart:])
+	enc.encodeVarintFn(int64(checksum))
+}
+
+func (enc *Encoder) encodeIndexEntryV4(entry schema.IndexEntry, checksumStart int) {
 	enc.encodeNumObjectFieldsForFn(indexEntryType)
 	enc.encodeVarintFn(entry.Index)
 	enc.encodeBytesFn(entry.ID)
@@ -333,7 +398,9 @@ func (enc *Encoder) encodeIndexEntryV3(entry schema.IndexEntry, checksumStart in
 	enc.encodeVarintFn(entry.Offset)
 	enc.encodeVarintFn(entry.DataChecksum)
 	enc.encodeBytesFn(entry.EncodedTags)
+	enc.encodeVarintFn(entry.CompressedSize)
 
+	// The checksum is always the final field from V3 onward.
 	checksum := digest.Checksum(enc.Bytes()[checksumStart:])
 	enc.encodeVarintFn(int64(checksum))
 }
@@ -354,6 +421,8 @@ func (enc *Encoder) encodeLogInfo(info schema.LogInfo) {
 	enc.encodeVarintFn(info.DeprecatedDoNotUseDuration)
 
 	enc.encodeVarintFn(info.Index)
+	enc.encodeBytesFn([]byte(info.Encryption.KeyID))
+	enc.encodeBytesFn(info.Encryption.EncryptedDataKey)
 }
 
 func (enc *Encoder) encodeLogEntry(entry schema.LogEntry) {
@@ -78,8 +78,12 @@ func testExpectedResultForIndexInfo(t *testing.T, indexInfo schema.IndexInfo) []
 	_, currIndexInfo := numFieldsForType(indexInfoType)
 	_, currSummariesInfo := numFieldsForType(indexSummariesInfoType)
 	_, currIndexBloomFilterInfo := numFieldsForType(indexBloomFilterInfoType)
+	version := indexInfoVersion
+	if indexInfo.Encryption.Enabled() {
+		version = encryptedIndexInfoVersion
+	}
 	return []interface{}{
-		int64(indexInfoVersion),
+		int64(version),
 		currRoot,
 		int64(indexInfoType),
 		currIndexInfo,
@@ -97,6 +101,9 @@ func testExpectedResultForIndexInfo(t *testing.T, indexInfo schema.IndexInfo) []
 		indexInfo.SnapshotID,
 		int64(indexInfo.VolumeIndex),
 		indexInfo.MinorVersion,
+		[]byte(indexInfo.Encryption.KeyID),
+		indexInfo.Encryption.EncryptedDataKey,
+		int64(indexInfo.Compression),
 	}
 }
 
@@ -114,6 +121,7 @@ func testExpectedResultForIndexEntry(t *testing.T, indexEntry schema.IndexEntry)
 		indexEntry.Offset,
 		indexEntry.DataChecksum,
 		indexEntry.EncodedTags,
+		indexEntry.CompressedSize,
 		int64(testIndexEntryChecksum), // Checksum auto-added to the end of the index entry
 	}
 }
@@ -121,14 +129,20 @@ func testExpectedResultForIndexEntry(t *testing.T, indexEntry schema.IndexEntry)
 func testExpectedResultForLogInfo(t *testing.T, logInfo schema.LogInfo) []interface{} {
 	_, currRoot := numFieldsForType(rootObjectType)
 	_, currLogInfo := numFieldsForType(logInfoType)
+	version := logInfoVersion
+	if logInfo.Encryption.Enabled() {
+		version = encryptedLogInfoVersion
+	}
 	return []interface{}{
-		int64(logInfoVersion),
+		int64(version),
 		currRoot,
 		int64(logInfoType),
 		currLogInfo,
 		logInfo.DeprecatedDoNotUseStart,
 		logInfo.DeprecatedDoNotUseDuration,
 		logInfo.Index,
+		[]byte(logInfo.Encryption.KeyID),
+		logInfo.Encryption.EncryptedDataKey,
 	}
 }
 
@@ -172,6 +186,17 @@ func TestEncodeIndexInfo(t *testing.T) {
 	require.Equal(t, expected, *actual)
 }
 
+func TestEncodeIndexInfoPlaintext(t *testing.T) {
+	info := testIndexInfo
+	info.Encryption = schema.EncryptionInfo{}
+
+	enc, actual := testCapturingEncoder(t)
+	require.NoError(t, enc.EncodeIndexInfo(info))
+	expected := testExpectedResultForIndexInfo(t, info)
+	require.Equal(t, expected, *actual)
+	require.Equal(t, int64(indexInfoVersion), (*actual)[0])
+}
+
 func TestEncodeIndexEntry(t *testing.T) {
 	enc, actual := testCapturingEncoder(t)
 	require.NoError(t, enc.EncodeIndexEntry(testIndexEntry))
@@ -186,6 +211,32 @@ func TestEncodeLogInfo(t *testing.T) {
 	require.Equal(t, expected, *actual)
 }
 
+func TestEncodeLogInfoPlaintext(t *testing.T) {
+	info := testLogInfo
+	info.Encryption = schema.EncryptionInfo{}
+
+	enc, actual := testCapturingEncoder(t)
+	require.NoError(t, enc.EncodeLogInfo(info))
+	expected := testExpectedResultForLogInfo(t, info)
+	require.Equal(t, expected, *actual)
+	require.Equal(t, int64(logInfoVersion), (*actual)[0])
+}
+
+func TestEncodeEncryptedInfoNotDecodedByOldVersions(t *testing.T) {
+	enc := NewEncoder()
+	require.NoError(t, enc.EncodeIndexInfo(testIndexInfo))
+	dec := NewDecoder(nil)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	dec.decodeRootObject(indexInfoVersion, indexInfoType)
+	require.Error(t, dec.err)
+
+	enc.Reset()
+	require.NoError(t, enc.EncodeLogInfo(testLogInfo))
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	dec.decodeRootObject(logInfoVersion, logInfoType)
+	require.Error(t, dec.err)
+}
+
 func TestEncodeLogEntry(t *testing.T) {
 	enc, actual := testCapturingEncoder(t)
 	require.NoError(t, enc.EncodeLogEntry(testLogEntry))
@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
+	"github.com/m3db/m3/src/x/compress"
 	xtest "github.com/m3db/m3/src/x/test"
 	xhash "github.com/m3db/m3/src/x/test/hash"
 )
@@ -50,17 +51,23 @@ var (
 		SnapshotID:   []byte("some_bytes"),
 		VolumeIndex:  1,
 		MinorVersion: schema.MinorVersion,
+		Encryption: schema.EncryptionInfo{
+			KeyID:            "key-1",
+			EncryptedDataKey: []byte("encrypted_data_key"),
+		},
+		Compression: compress.ZstdType,
 	}
 
-	testIndexEntryChecksum = int64(2611877657)
+	testIndexEntryChecksum = int64(3822262297)
 	testIndexEntry         = schema.IndexEntry{
-		Index:         234,
-		ID:            []byte("testIndexEntry"),
-		Size:          5456,
-		Offset:        2390423,
-		DataChecksum:  134245634534,
-		IndexChecksum: testIndexEntryChecksum,
-		EncodedTags:   []byte("testEncodedTags"),
+		Index:          234,
+		ID:             []byte("testIndexEntry"),
+		Size:           5456,
+		Offset:         2390423,
+		DataChecksum:   134245634534,
+		IndexChecksum:  testIndexEntryChecksum,
+		EncodedTags:    []byte("testEncodedTags"),
+		CompressedSize: 2345,
 	}
 
 	testIndexSummary = schema.IndexSummary{
@@ -71,6 +78,10 @@ var (
 
 	testLogInfo = schema.LogInfo{
 		Index: 234,
+		Encryption: schema.EncryptionInfo{
+			KeyID:            "key-1",
+			EncryptedDataKey: []byte("encrypted_data_key"),
+		},
 	}
 
 	testLogEntry = schema.LogEntry{
@@ -122,18 +133,24 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV1(t *testing.T) {
 		currSnapshotID   = testIndexInfo.SnapshotID
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currEncryption   = testIndexInfo.Encryption
+		currCompression  = testIndexInfo.Compression
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -160,6 +177,8 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 		currSnapshotID   = testIndexInfo.SnapshotID
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currEncryption   = testIndexInfo.Encryption
+		currCompression  = testIndexInfo.Compression
 	)
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -171,12 +190,16 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -185,7 +208,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV1(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V2 file format.
+// Make sure the V6 decoding code can handle the V2 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV2}
@@ -203,18 +226,24 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV2(t *testing.T) {
 		currSnapshotID   = testIndexInfo.SnapshotID
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currEncryption   = testIndexInfo.Encryption
+		currCompression  = testIndexInfo.Compression
 	)
 	testIndexInfo.SnapshotTime = 0
 	testIndexInfo.FileType = 0
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
 	defer func() {
 		testIndexInfo.SnapshotTime = currSnapshotTime
 		testIndexInfo.FileType = currFileType
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -238,6 +267,8 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	currSnapshotID := testIndexInfo.SnapshotID
 	currVolumeIndex := testIndexInfo.VolumeIndex
 	currMinorVersion := testIndexInfo.MinorVersion
+	currEncryption := testIndexInfo.Encryption
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
@@ -246,10 +277,14 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	testIndexInfo.SnapshotID = nil
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
 	defer func() {
 		testIndexInfo.SnapshotID = currSnapshotID
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -258,7 +293,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV2(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V3 file format.
+// Make sure the V6 decoding code can handle the V3 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV3}
@@ -273,12 +308,18 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV3(t *testing.T) {
 	var (
 		currVolumeIndex  = testIndexInfo.VolumeIndex
 		currMinorVersion = testIndexInfo.MinorVersion
+		currEncryption   = testIndexInfo.Encryption
+		currCompression  = testIndexInfo.Compression
 	)
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
 	defer func() {
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -301,6 +342,8 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	// because the old decoder won't read the new fields.
 	currVolumeIndex := testIndexInfo.VolumeIndex
 	currMinorVersion := testIndexInfo.MinorVersion
+	currEncryption := testIndexInfo.Encryption
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
@@ -308,9 +351,13 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	// encoded the data.
 	testIndexInfo.VolumeIndex = 0
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
 	defer func() {
 		testIndexInfo.VolumeIndex = currVolumeIndex
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -319,7 +366,7 @@ func TestIndexInfoRoundTripForwardsCompatibilityV3(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V5 decoding code can handle the V4 file format.
+// Make sure the V6 decoding code can handle the V4 file format.
 func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV4}
@@ -332,10 +379,16 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
 	// because the new decoder won't try and read the new fields from
 	// the old file format.
 	currMinorVersion := testIndexInfo.MinorVersion
+	currEncryption := testIndexInfo.Encryption
+	currCompression := testIndexInfo.Compression
 
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
 	defer func() {
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
 	}()
 
 	enc.EncodeIndexInfo(testIndexInfo)
@@ -345,7 +398,7 @@ func TestIndexInfoRoundTripBackwardsCompatibilityV4(t *testing.T) {
 	require.Equal(t, testIndexInfo, res)
 }
 
-// Make sure the V4 decoder code can handle the V5 file format.
+// Make sure the V4 decoder code can handle the V6 file format.
 func TestIndexInfoRoundTripForwardsCompatibilityV4(t *testing.T) {
 	var (
 		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV4}
@@ -357,14 +410,134 @@ func TestIndexInfoRoundTripForwardsCompatibilityV4(t *testing.T) {
 	// and then restore them at the end of the test - This is required
 	// because the old decoder won't read the new fields.
 	currMinorVersion := testIndexInfo.MinorVersion
+	currEncryption := testIndexInfo.Encryption
+	currCompression := testIndexInfo.Compression
 
 	enc.EncodeIndexInfo(testIndexInfo)
 
 	// Make sure to zero them before we compare, but after we have
 	// encoded the data.
 	testIndexInfo.MinorVersion = 0
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
 	defer func() {
 		testIndexInfo.MinorVersion = currMinorVersion
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
+	}()
+
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V6 decoding code can handle the V5 file format.
+func TestIndexInfoRoundTripBackwardsCompatibilityV5(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV5}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V5,
+	// and then restore them at the end of the test - This is required
+	// because the new decoder won't try and read the new fields from
+	// the old file format.
+	currEncryption := testIndexInfo.Encryption
+	currCompression := testIndexInfo.Compression
+
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
+	defer func() {
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
+	}()
+
+	enc.EncodeIndexInfo(testIndexInfo)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V5 decoder code can handle the V6 file format.
+func TestIndexInfoRoundTripForwardsCompatibilityV5(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV5}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V5
+	// and then restore them at the end of the test - This is required
+	// because the old decoder won't read the new fields.
+	currEncryption := testIndexInfo.Encryption
+	currCompression := testIndexInfo.Compression
+
+	enc.EncodeIndexInfo(testIndexInfo)
+
+	// Make sure to zero them before we compare, but after we have
+	// encoded the data.
+	testIndexInfo.Encryption = schema.EncryptionInfo{}
+	testIndexInfo.Compression = compress.NoneType
+	defer func() {
+		testIndexInfo.Encryption = currEncryption
+		testIndexInfo.Compression = currCompression
+	}()
+
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V7 decoding code can handle the V6 file format.
+func TestIndexInfoRoundTripBackwardsCompatibilityV6(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{EncodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV6}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V6,
+	// and then restore them at the end of the test - This is required
+	// because the new decoder won't try and read the new fields from
+	// the old file format.
+	currCompression := testIndexInfo.Compression
+
+	testIndexInfo.Compression = compress.NoneType
+	defer func() {
+		testIndexInfo.Compression = currCompression
+	}()
+
+	enc.EncodeIndexInfo(testIndexInfo)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexInfo()
+	require.NoError(t, err)
+	require.Equal(t, testIndexInfo, res)
+}
+
+// Make sure the V6 decoder code can handle the V7 file format.
+func TestIndexInfoRoundTripForwardsCompatibilityV6(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{DecodeLegacyIndexInfoVersion: LegacyEncodingIndexVersionV6}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, nil)
+	)
+
+	// Set the default values on the fields that did not exist in V6
+	// and then restore them at the end of the test - This is required
+	// because the old decoder won't read the new fields.
+	currCompression := testIndexInfo.Compression
+
+	enc.EncodeIndexInfo(testIndexInfo)
+
+	// Make sure to zero them before we compare, but after we have
+	// encoded the data.
+	testIndexInfo.Compression = compress.NoneType
+	defer func() {
+		testIndexInfo.Compression = currCompression
 	}()
 
 	dec.Reset(NewByteDecoderStream(enc.Bytes()))
@@ -419,6 +592,7 @@ func TestIndexEntryRoundTripBackwardsCompatibilityV1(t *testing.T) {
 	require.NoError(t, err)
 	expected := testIndexEntry
 	expected.IndexChecksum = 0
+	expected.CompressedSize = 0
 	require.Equal(t, expected, res)
 }
 
@@ -455,6 +629,7 @@ func TestIndexEntryRoundTripForwardsCompatibilityV1(t *testing.T) {
 	require.NoError(t, err)
 
 	expected.IndexChecksum = 0
+	expected.CompressedSize = 0
 	require.Equal(t, expected, res)
 }
 
@@ -478,6 +653,7 @@ func TestIndexEntryRoundTripBackwardsCompatibilityV2(t *testing.T) {
 	require.NoError(t, err)
 	expected := testIndexEntry
 	expected.IndexChecksum = 0
+	expected.CompressedSize = 0
 	require.Equal(t, expected, res)
 }
 
@@ -500,6 +676,49 @@ func TestIndexEntryRoundTripForwardsCompatibilityV2(t *testing.T) {
 	require.NoError(t, err)
 	expected := testIndexEntry
 	expected.IndexChecksum = 0
+	expected.CompressedSize = 0
+	require.Equal(t, expected, res)
+}
+
+// Make sure the V4 decoding code can handle the V3 file format.
+func TestIndexEntryRoundTripBackwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{EncodeLegacyIndexEntryVersion: LegacyEncodingIndexEntryVersionV3,
+			DecodeLegacyIndexEntryVersion: LegacyEncodingIndexEntryVersionCurrent}
+		enc = newEncoder(opts)
+		dec = newDecoder(opts, NewDecodingOptions().SetIndexEntryHasher(xhash.NewParsedIndexHasher(t)))
+	)
+
+	// The checksum is computed over the encoded fields so it differs from
+	// the V4 one, only compare the fields that exist in V3.
+	err := enc.EncodeIndexEntry(testIndexEntry)
+	require.NoError(t, err)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry(nil)
+	require.NoError(t, err)
+	expected := testIndexEntry
+	expected.IndexChecksum = res.IndexChecksum
+	expected.CompressedSize = 0
+	require.Equal(t, expected, res)
+}
+
+// Make sure the V3 decoder code can handle the V4 file format.
+func TestIndexEntryRoundTripForwardsCompatibilityV3(t *testing.T) {
+	var (
+		opts = LegacyEncodingOptions{DecodeLegacyIndexEntryVersion: LegacyEncodingIndexEntryVersionV3}
+		enc  = newEncoder(opts)
+		dec  = newDecoder(opts, NewDecodingOptions().SetIndexEntryHasher(xhash.NewParsedIndexHasher(t)))
+	)
+
+	// The V3 decoder skips the compressed size but still validates the
+	// checksum since it is the final field.
+	err := enc.EncodeIndexEntry(testIndexEntry)
+	require.NoError(t, err)
+	dec.Reset(NewByteDecoderStream(enc.Bytes()))
+	res, err := dec.DecodeIndexEntry(nil)
+	require.NoError(t, err)
+	expected := testIndexEntry
+	expected.CompressedSize = 0
 	require.Equal(t, expected, res)
 }
 
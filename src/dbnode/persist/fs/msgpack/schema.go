@@ -50,6 +50,13 @@ const (
 	logInfoVersion      = 1
 	logEntryVersion     = 1
 	logMetadataVersion  = 1
+
+	// The info of encrypted filesets and commit logs is written with a newer
+	// version than the info of plaintext ones, so that old binaries fail to
+	// decode it rather than reading the encrypted files that follow it. The
+	// versions of plaintext ones are not incremented to keep them readable.
+	encryptedIndexInfoVersion = 2
+	encryptedLogInfoVersion   = 2
 )
 
 type objectType int
@@ -99,12 +106,12 @@ const (
 	// correct number of fields is encoded into the files. These values need
 	// to be incremented whenever we add new fields to an object.
 	currNumRootObjectFields           = 2
-	currNumIndexInfoFields            = 11
+	currNumIndexInfoFields            = 14
 	currNumIndexSummariesInfoFields   = 1
 	currNumIndexBloomFilterInfoFields = 2
-	currNumIndexEntryFields           = 7
+	currNumIndexEntryFields           = 8
 	currNumIndexSummaryFields         = 3
-	currNumLogInfoFields              = 3
+	currNumLogInfoFields              = 5
 	currNumLogEntryFields             = 7
 	currNumLogMetadataFields          = 3
 )
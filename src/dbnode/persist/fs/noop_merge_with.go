@@ -53,3 +53,7 @@ func (m *noopMergeWith) ForEachRemaining(
 ) error {
 	return nil
 }
+
+func (m *noopMergeWith) Tombstones(_ ident.ID) []xtime.Range {
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/runtime"
 	"github.com/m3db/m3/src/m3ninx/index/segment/fst"
 	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/encryption"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/mmap"
 	"github.com/m3db/m3/src/x/pool"
@@ -109,6 +110,8 @@ type options struct {
 	mmapReporter                         mmap.Reporter
 	indexReaderAutovalidateIndexSegments bool
 	encodingOptions                      msgpack.LegacyEncodingOptions
+	encryptionKeyProvider                encryption.KeyProvider
+	tieredStorage                        TieredStorage
 }
 
 type optionsInput struct {
@@ -469,3 +472,23 @@ func (o *options) SetEncodingOptions(value msgpack.LegacyEncodingOptions) Option
 func (o *options) EncodingOptions() msgpack.LegacyEncodingOptions {
 	return o.encodingOptions
 }
+
+func (o *options) SetEncryptionKeyProvider(value encryption.KeyProvider) Options {
+	opts := *o
+	opts.encryptionKeyProvider = value
+	return &opts
+}
+
+func (o *options) EncryptionKeyProvider() encryption.KeyProvider {
+	return o.encryptionKeyProvider
+}
+
+func (o *options) SetTieredStorage(value TieredStorage) Options {
+	opts := *o
+	opts.tieredStorage = value
+	return &opts
+}
+
+func (o *options) TieredStorage() TieredStorage {
+	return o.tieredStorage
+}
@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/compress"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/mmap"
@@ -85,6 +86,19 @@ type reader struct {
 	dataMmap   mmap.Descriptor
 	dataReader digest.ReaderWithDigest
 
+	// When encrypted the index is decrypted up front, the data is decrypted
+	// as it is read. The raw index digest is the digest of the index file
+	// bytes on disk, computed before the index is decrypted or decompressed.
+	ciphers        dataFileSetCiphers
+	encryption     schema.EncryptionInfo
+	rawIndexDigest uint32
+
+	// When compressed the index is decompressed up front as well, the data
+	// is decompressed as it is read.
+	compression    compress.Type
+	compressor     compress.Compressor
+	compressedData []byte
+
 	bloomFilterFd *os.File
 
 	entries         int
@@ -173,7 +187,18 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 		indexFilepath = FilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, indexFileSuffix)
 		dataFilepath = FilesetPathFromTimeAndIndex(shardDir, blockStart, volumeIndex, dataFileSuffix)
 	case persist.FileSetFlushType:
-		shardDir = ShardDataDirPath(r.filePathPrefix, namespace, shard)
+		filePathPrefix := r.filePathPrefix
+		if tier := r.opts.TieredStorage(); tier != nil {
+			// Offloaded filesets are read from the tiered storage cache.
+			var release func()
+			filePathPrefix, release, err = tier.Fetch(opts.Identifier)
+			if err != nil {
+				return err
+			}
+			// The files are all opened before Open returns.
+			defer release()
+		}
+		shardDir = ShardDataDirPath(filePathPrefix, namespace, shard)
 
 		isLegacy := false
 		if volumeIndex == 0 {
@@ -282,6 +307,14 @@ func (r *reader) Open(opts DataReaderOpenOptions) error {
 		r.Close()
 		return err
 	}
+	if err := r.decryptIndex(); err != nil {
+		r.Close()
+		return err
+	}
+	if err := r.decompressIndex(); err != nil {
+		r.Close()
+		return err
+	}
 	if opts.StreamingEnabled {
 		r.decoder.Reset(r.indexDecoderStream)
 	} else if err := r.readIndexAndSortByOffsetAsc(); err != nil {
@@ -346,6 +379,49 @@ func (r *reader) readInfo(size int) error {
 	r.entriesRead = 0
 	r.metadataRead = 0
 	r.bloomFilterInfo = info.BloomFilter
+	r.encryption = info.Encryption
+	r.compression = info.Compression
+	return nil
+}
+
+func (r *reader) decryptIndex() error {
+	dataKey, err := DecryptDataKey(r.opts, r.encryption)
+	if err != nil {
+		return err
+	}
+	r.ciphers, err = newDataFileSetCiphers(dataKey)
+	if err != nil || r.ciphers.index == nil {
+		return err
+	}
+
+	// NB: Digests are computed on the encrypted bytes, so compute it before
+	// decrypting the index for ValidateMetadata.
+	encrypted := r.indexMmap.Bytes
+	r.rawIndexDigest = digest.Checksum(encrypted)
+	decrypted := make([]byte, len(encrypted))
+	r.ciphers.index.XORKeyStreamAt(decrypted, encrypted, 0)
+	r.indexDecoderStream.Reset(decrypted)
+	return nil
+}
+
+func (r *reader) decompressIndex() error {
+	var err error
+	r.compressor, err = compress.NewCompressor(r.compression)
+	if err != nil || r.compressor == nil {
+		return err
+	}
+
+	// NB: Digests are computed on the bytes on disk, so compute it before
+	// decompressing the index for ValidateMetadata if not done already.
+	compressed := r.indexDecoderStream.Bytes()
+	if r.ciphers.index == nil {
+		r.rawIndexDigest = digest.Checksum(compressed)
+	}
+	decompressed, err := decompressIndex(r.compressor, nil, compressed)
+	if err != nil {
+		return err
+	}
+	r.indexDecoderStream.Reset(decompressed)
 	return nil
 }
 
@@ -383,20 +459,38 @@ func (r *reader) StreamingRead() (StreamedDataEntry, error) {
 		return StreamedDataEntry{}, err
 	}
 
-	if entry.Offset+entry.Size > int64(len(r.dataMmap.Bytes)) {
+	size := entry.Size
+	if r.compressor != nil {
+		size = entry.CompressedSize
+	}
+	if entry.Offset+size > int64(len(r.dataMmap.Bytes)) {
 		return StreamedDataEntry{}, fmt.Errorf(
 			"attempt to read beyond data file size (offset=%d, size=%d, file size=%d)",
-			entry.Offset, entry.Size, len(r.dataMmap.Bytes))
+			entry.Offset, size, len(r.dataMmap.Bytes))
+	}
+	data := r.dataMmap.Bytes[entry.Offset : entry.Offset+size]
+	if r.compressor != nil {
+		r.compressedData = append(r.compressedData[:0], data...)
+		if r.ciphers.data != nil {
+			r.ciphers.data.XORKeyStreamAt(r.compressedData, r.compressedData, entry.Offset)
+		}
+		r.streamingData, err = r.compressor.Decompress(r.streamingData[:0], r.compressedData)
+		if err != nil {
+			return StreamedDataEntry{}, err
+		}
+	} else {
+		r.streamingData = append(r.streamingData[:0], data...)
+		if r.ciphers.data != nil {
+			r.ciphers.data.XORKeyStreamAt(r.streamingData, r.streamingData, entry.Offset)
+		}
 	}
-	data := r.dataMmap.Bytes[entry.Offset : entry.Offset+entry.Size]
 
 	// NB(r): _must_ check the checksum against known checksum as the data
 	// file might not have been verified if we haven't read through the file yet.
-	if entry.DataChecksum != int64(digest.Checksum(data)) {
+	if entry.DataChecksum != int64(digest.Checksum(r.streamingData)) {
 		return StreamedDataEntry{}, errSeekChecksumMismatch
 	}
 
-	r.streamingData = append(r.streamingData[:0], data...)
 	r.streamingID = append(r.streamingID[:0], entry.ID...)
 	r.streamingTags = append(r.streamingTags[:0], entry.EncodedTags...)
 
@@ -441,13 +535,9 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 		defer data.DecRef()
 	}
 
-	n, err := r.dataReader.Read(data.Bytes())
-	if err != nil {
+	if err := r.readData(entry, data.Bytes()); err != nil {
 		return nil, nil, nil, 0, err
 	}
-	if n != int(entry.Size) {
-		return nil, nil, nil, 0, errReadNotExpectedSize
-	}
 
 	id := r.entryClonedID(entry.ID)
 	tags := r.entryClonedEncodedTagsIter(entry.EncodedTags)
@@ -456,6 +546,38 @@ func (r *reader) Read() (ident.ID, ident.TagIterator, checked.Bytes, uint32, err
 	return id, tags, data, uint32(entry.DataChecksum), nil
 }
 
+func (r *reader) readData(entry schema.IndexEntry, data []byte) error {
+	if r.compressor == nil {
+		n, err := r.dataReader.Read(data)
+		if err != nil {
+			return err
+		}
+		if n != int(entry.Size) {
+			return errReadNotExpectedSize
+		}
+		if r.ciphers.data != nil {
+			r.ciphers.data.XORKeyStreamAt(data, data, entry.Offset)
+		}
+		return nil
+	}
+
+	if int64(cap(r.compressedData)) < entry.CompressedSize {
+		r.compressedData = make([]byte, entry.CompressedSize)
+	}
+	r.compressedData = r.compressedData[:entry.CompressedSize]
+	n, err := r.dataReader.Read(r.compressedData)
+	if err != nil {
+		return err
+	}
+	if n != int(entry.CompressedSize) {
+		return errReadNotExpectedSize
+	}
+	if r.ciphers.data != nil {
+		r.ciphers.data.XORKeyStreamAt(r.compressedData, r.compressedData, entry.Offset)
+	}
+	return decompressData(r.compressor, data, r.compressedData)
+}
+
 func (r *reader) StreamingReadMetadata() (StreamedMetadataEntry, error) {
 	if !r.streamingEnabled {
 		return StreamedMetadataEntry{}, errStreamingRequired
@@ -510,6 +632,7 @@ func (r *reader) ReadBloomFilter() (*ManagedConcurrentBloomFilter, error) {
 		uint(r.bloomFilterInfo.NumElementsM),
 		uint(r.bloomFilterInfo.NumHashesK),
 		r.opts.ForceBloomFilterMmapMemory(),
+		r.ciphers.bloomFilter,
 		mmap.ReporterOptions{
 			Reporter: r.opts.MmapReporter(),
 		},
@@ -555,6 +678,13 @@ func (r *reader) Validate() error {
 // NB(r): ValidateMetadata can be called immediately after Open(...) since
 // the metadata is read upfront.
 func (r *reader) ValidateMetadata() error {
+	if r.ciphers.index != nil || r.compressor != nil {
+		if r.rawIndexDigest != r.expectedIndexDigest {
+			return fmt.Errorf("could not validate index file: expected digest %d, actual %d",
+				r.expectedIndexDigest, r.rawIndexDigest)
+		}
+		return nil
+	}
 	err := r.indexDecoderStream.reader().Validate(r.expectedIndexDigest)
 	if err != nil {
 		return fmt.Errorf("could not validate index file: %v", err)
@@ -35,6 +35,8 @@ import (
 	xmsgpack "github.com/m3db/m3/src/dbnode/persist/fs/msgpack"
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/compress"
+	"github.com/m3db/m3/src/x/encryption"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
@@ -76,6 +78,11 @@ type seeker struct {
 	indexFd       *os.File
 	indexFileSize int64
 
+	// Ciphers of the files when the fileset is encrypted.
+	ciphers dataFileSetCiphers
+	// Compressor of the files when the fileset is compressed.
+	compressor compress.Compressor
+
 	unreadBuf []byte
 
 	// Bloom filter associated with the shard / block the seeker is responsible
@@ -89,10 +96,11 @@ type seeker struct {
 // IndexEntry is an entry from the index file which can be passed to
 // SeekUsingIndexEntry to seek to the data for that entry.
 type IndexEntry struct {
-	Size         uint32
-	DataChecksum uint32
-	Offset       int64
-	EncodedTags  checked.Bytes
+	Size           uint32
+	CompressedSize uint32
+	DataChecksum   uint32
+	Offset         int64
+	EncodedTags    checked.Bytes
 }
 
 // NewSeeker returns a new seeker.
@@ -230,6 +238,22 @@ func (s *seeker) Open(
 	s.blockSize = time.Duration(info.BlockSize)
 	s.versionChecker = schema.NewVersionChecker(int(info.MajorVersion), int(info.MinorVersion))
 
+	dataKey, err := DecryptDataKey(s.opts.opts, info.Encryption)
+	if err != nil {
+		s.Close()
+		return err
+	}
+	s.ciphers, err = newDataFileSetCiphers(dataKey)
+	if err != nil {
+		s.Close()
+		return err
+	}
+	s.compressor, err = compress.NewCompressor(info.Compression)
+	if err != nil {
+		s.Close()
+		return err
+	}
+
 	err = s.validateIndexFileDigest(
 		indexFdWithDigest, expectedDigests.indexDigest)
 	if err != nil {
@@ -254,6 +278,7 @@ func (s *seeker) Open(
 		uint(info.BloomFilter.NumElementsM),
 		uint(info.BloomFilter.NumHashesK),
 		s.opts.opts.ForceBloomFilterMmapMemory(),
+		s.ciphers.bloomFilter,
 		mmap.ReporterOptions{
 			Reporter: s.opts.opts.MmapReporter(),
 		},
@@ -271,6 +296,7 @@ func (s *seeker) Open(
 		resources.byteDecoderStream,
 		int(info.Summaries.Summaries),
 		s.opts.opts.ForceIndexSummariesMmapMemory(),
+		s.ciphers.summaries,
 		mmap.ReporterOptions{
 			Reporter: s.opts.opts.MmapReporter(),
 		},
@@ -338,7 +364,7 @@ func (s *seeker) SeekByIndexEntry(
 	entry IndexEntry,
 	resources ReusableSeekerResources,
 ) (checked.Bytes, error) {
-	resources.offsetFileReader.reset(s.dataFd, entry.Offset)
+	resources.offsetFileReader.reset(s.dataFd, entry.Offset, s.ciphers.data)
 
 	// Obtain an appropriately sized buffer.
 	var buffer checked.Bytes
@@ -355,15 +381,26 @@ func (s *seeker) SeekByIndexEntry(
 
 	// Copy the actual data into the underlying buffer.
 	underlyingBuf := buffer.Bytes()
-	n, err := io.ReadFull(resources.offsetFileReader, underlyingBuf)
-	if err != nil {
-		return nil, err
-	}
-	if n != int(entry.Size) {
-		// This check is redundant because io.ReadFull will return an error if
-		// its not able to read the specified number of bytes, but we keep it
-		// in for posterity.
-		return nil, fmt.Errorf("tried to read: %d bytes but read: %d", entry.Size, n)
+	if s.compressor != nil {
+		// Read the compressed data and decompress it into the buffer.
+		compressed := resources.compressedBuffer.bytes(int(entry.CompressedSize))
+		if _, err := io.ReadFull(resources.offsetFileReader, compressed); err != nil {
+			return nil, err
+		}
+		if err := decompressData(s.compressor, underlyingBuf, compressed); err != nil {
+			return nil, err
+		}
+	} else {
+		n, err := io.ReadFull(resources.offsetFileReader, underlyingBuf)
+		if err != nil {
+			return nil, err
+		}
+		if n != int(entry.Size) {
+			// This check is redundant because io.ReadFull will return an error if
+			// its not able to read the specified number of bytes, but we keep it
+			// in for posterity.
+			return nil, fmt.Errorf("tried to read: %d bytes but read: %d", entry.Size, n)
+		}
 	}
 
 	// NB(r): _must_ check the checksum against known checksum as the data
@@ -381,7 +418,8 @@ func (s *seeker) SeekByIndexEntry(
 //     point for scanning the index file.
 //  2. Reset an offsetFileReader with the index fd and an offset (so that calls to Read() will
 //     begin at the offset provided by the offset lookup).
-//  3. Reset a decoder with fileDecoderStream (offsetFileReader wrapped in a bufio.Reader).
+//  3. Reset a decoder with fileDecoderStream (offsetFileReader wrapped in a bufio.Reader),
+//     wrapped in an indexBlockReader when the index is compressed.
 //  4. Call DecodeIndexEntry in a tight loop (which will advance our position in the
 //     offsetFileReader internally) until we've either found the entry we're looking for or gone so
 //     far we know it does not exist.
@@ -396,9 +434,15 @@ func (s *seeker) SeekIndexEntry(
 		return IndexEntry{}, err
 	}
 
-	resources.offsetFileReader.reset(s.indexFd, offset)
+	resources.offsetFileReader.reset(s.indexFd, offset, s.ciphers.index)
 	resources.fileDecoderStream.Reset(resources.offsetFileReader)
-	resources.xmsgpackDecoder.Reset(resources.fileDecoderStream)
+	if s.compressor != nil {
+		// Summaries of compressed files point at the start of a block.
+		resources.indexBlockReader.reset(resources.fileDecoderStream, s.compressor)
+		resources.xmsgpackDecoder.Reset(resources.indexBlockReader)
+	} else {
+		resources.xmsgpackDecoder.Reset(resources.fileDecoderStream)
+	}
 
 	idBytes := id.Bytes()
 	for {
@@ -438,10 +482,11 @@ func (s *seeker) SeekIndexEntry(
 			}
 
 			indexEntry := IndexEntry{
-				Size:         uint32(entry.Size),
-				DataChecksum: uint32(entry.DataChecksum),
-				Offset:       entry.Offset,
-				EncodedTags:  checkedEncodedTags,
+				Size:           uint32(entry.Size),
+				CompressedSize: uint32(entry.CompressedSize),
+				DataChecksum:   uint32(entry.DataChecksum),
+				Offset:         entry.Offset,
+				EncodedTags:    checkedEncodedTags,
 			}
 
 			// Safe to return resources to the pool because ID will not be
@@ -514,6 +559,10 @@ func (s *seeker) ConcurrentClone() (ConcurrentDataFileSetSeeker, error) {
 		// they are concurrency safe and can be shared among clones.
 		indexFd: s.indexFd,
 		dataFd:  s.dataFd,
+		ciphers: s.ciphers,
+
+		// Compressors are concurrency safe.
+		compressor: s.compressor,
 
 		versionChecker: s.versionChecker,
 	}
@@ -555,6 +604,8 @@ type ReusableSeekerResources struct {
 	fileDecoderStream *bufio.Reader
 	byteDecoderStream xmsgpack.ByteDecoderStream
 	offsetFileReader  *offsetFileReader
+	indexBlockReader  *indexBlockReader
+	compressedBuffer  *reusableBuffer
 	// This pool should only be used for calling DecodeIndexEntry. We use a
 	// special pool here to avoid the overhead of channel synchronization, as
 	// well as ref counting that comes with the checked bytes pool. In addition,
@@ -593,6 +644,8 @@ func NewReusableSeekerResources(opts Options) ReusableSeekerResources {
 		fileDecoderStream:         bufio.NewReaderSize(nil, seekReaderSize),
 		byteDecoderStream:         xmsgpack.NewByteDecoderStream(nil),
 		offsetFileReader:          newOffsetFileReader(),
+		indexBlockReader:          newIndexBlockReader(),
+		compressedBuffer:          &reusableBuffer{},
 		decodeIndexEntryBytesPool: newSimpleBytesPool(),
 		seekerOpenResources:       newReusableSeekerOpenResources(opts),
 	}
@@ -654,10 +707,12 @@ var _ io.Reader = &offsetFileReader{}
 // to issue reads to specific portions of the index and data files without having
 // to first call Seek(). This reduces the number of syscalls that need to be made
 // and also allows the fds to be shared among concurrent goroutines since the
-// internal F.D offset managed by the kernel is not being used.
+// internal F.D offset managed by the kernel is not being used. Reads are
+// decrypted when the file is encrypted.
 type offsetFileReader struct {
 	fd     *os.File
 	offset int64
+	cipher *encryption.Cipher
 }
 
 func newOffsetFileReader() *offsetFileReader {
@@ -666,11 +721,15 @@ func newOffsetFileReader() *offsetFileReader {
 
 func (p *offsetFileReader) Read(b []byte) (n int, err error) {
 	n, err = p.fd.ReadAt(b, p.offset)
+	if p.cipher != nil {
+		p.cipher.XORKeyStreamAt(b[:n], b[:n], p.offset)
+	}
 	p.offset += int64(n)
 	return n, err
 }
 
-func (p *offsetFileReader) reset(fd *os.File, offset int64) {
+func (p *offsetFileReader) reset(fd *os.File, offset int64, cipher *encryption.Cipher) {
 	p.fd = fd
 	p.offset = offset
+	p.cipher = cipher
 }
@@ -115,6 +115,7 @@ type seekerManager struct {
 
 	bytesPool      pool.CheckedBytesPool
 	filePathPrefix string
+	tieredStorage  TieredStorage
 
 	status                     seekerManagerStatus
 	shardSet                   sharding.ShardSet
@@ -150,6 +151,9 @@ type seekersAndBloom struct {
 	seekers     []borrowableSeeker
 	bloomFilter *ManagedConcurrentBloomFilter
 	volume      int
+	// fetched is set when the seekers read a fileset fetched into the tiered
+	// storage cache, they are closed once it is evicted from the cache.
+	fetched bool
 }
 
 func (s seekersAndBloom) closeWithLock() error {
@@ -223,6 +227,7 @@ func NewSeekerManager(
 	m := &seekerManager{
 		bytesPool:                   bytesPool,
 		filePathPrefix:              opts.FilePathPrefix(),
+		tieredStorage:               opts.TieredStorage(),
 		opts:                        opts,
 		blockRetrieverOpts:          blockRetrieverOpts,
 		fetchConcurrency:            blockRetrieverOpts.FetchConcurrency(),
@@ -768,6 +773,10 @@ func (m *seekerManager) newSeekersAndBloom(
 	if err != nil {
 		return seekersAndBloom{}, err
 	}
+	if m.tieredStorage != nil {
+		newSeekersAndBloom.fetched = m.tieredStorage.Cached(
+			m.fileSetFileIdentifier(shard, blockStart, volume))
+	}
 
 	return newSeekersAndBloom, nil
 }
@@ -805,6 +814,16 @@ func (m *seekerManager) openAnyUnopenSeekers(byTime *seekersByTime) error {
 	blockSize := m.namespaceMetadata.Options().RetentionOptions().BlockSize()
 	multiErr := xerrors.NewMultiError()
 
+	// Blocks old enough to have been offloaded are only opened on demand
+	// since opening them may require fetching them from the object store.
+	if m.tieredStorage != nil && m.tieredStorage.Enabled(m.namespace) {
+		now := xtime.ToUnixNano(m.opts.ClockOptions().NowFn()())
+		latestOffloaded := now.Add(-blockSize - m.tieredStorage.OffloadAfter()).Truncate(blockSize)
+		if !latestOffloaded.Before(start) {
+			start = latestOffloaded.Add(blockSize)
+		}
+	}
+
 	for t := start; !t.After(end); t = t.Add(blockSize) {
 		byTime.Lock()
 		_, err := m.getOrOpenSeekersWithLock(t, byTime)
@@ -831,6 +850,19 @@ func (m *seekerManager) newOpenSeeker(
 		return nil, errSeekerManagerFileSetNotFound
 	}
 
+	filePathPrefix := m.filePathPrefix
+	if m.tieredStorage != nil {
+		// Offloaded filesets are read from the tiered storage cache.
+		var release func()
+		filePathPrefix, release, err = m.tieredStorage.Fetch(
+			m.fileSetFileIdentifier(shard, blockStart, volume))
+		if err != nil {
+			return nil, err
+		}
+		// The seeker opens all of the files it reads before returning.
+		defer release()
+	}
+
 	// NB(r): Use a lock on the unread buffer to avoid multiple
 	// goroutines reusing the unread buffer that we share between the seekers
 	// when we open each seeker.
@@ -838,7 +870,7 @@ func (m *seekerManager) newOpenSeeker(
 	defer m.unreadBuf.Unlock()
 
 	seekerIface := NewSeeker(
-		m.filePathPrefix,
+		filePathPrefix,
 		m.opts.DataReaderBufferSize(),
 		m.opts.InfoReaderBufferSize(),
 		m.bytesPool,
@@ -870,6 +902,19 @@ func (m *seekerManager) newOpenSeeker(
 	return seeker, nil
 }
 
+func (m *seekerManager) fileSetFileIdentifier(
+	shard uint32,
+	blockStart xtime.UnixNano,
+	volume int,
+) FileSetFileIdentifier {
+	return FileSetFileIdentifier{
+		Namespace:   m.namespace,
+		Shard:       shard,
+		BlockStart:  blockStart,
+		VolumeIndex: volume,
+	}
+}
+
 func (m *seekerManager) seekersByTime(shard uint32) (*seekersByTime, bool) {
 	m.RLock()
 	if !m.shardExistsWithLock(shard) {
@@ -1022,11 +1067,14 @@ func (m *seekerManager) openCloseLoop() {
 		m.RLock()
 		for shard, byTime := range m.seekersByShardIdx {
 			byTime.RLock()
-			for blockStart := range byTime.seekers {
+			for blockStart, seekers := range byTime.seekers {
 				if blockStart.Before(earliestSeekableBlockStart) ||
 					// Close seekers for shards that are no longer available. This
 					// ensure that seekers are eventually consistent w/ shard state.
-					!m.shardExistsWithLock(uint32(shard)) {
+					!m.shardExistsWithLock(uint32(shard)) ||
+					// Close seekers for offloaded filesets evicted from the tiered
+					// storage cache so that the disk space of the files is released.
+					m.evictedFromTieredStorageWithLock(uint32(shard), blockStart, seekers.active) {
 					shouldClose = append(shouldClose, seekerManagerPendingClose{
 						shard:      uint32(shard),
 						blockStart: blockStart,
@@ -1102,6 +1150,17 @@ func (m *seekerManager) openCloseLoop() {
 	m.openCloseLoopDoneCh <- struct{}{}
 }
 
+func (m *seekerManager) evictedFromTieredStorageWithLock(
+	shard uint32,
+	blockStart xtime.UnixNano,
+	seekers seekersAndBloom,
+) bool {
+	if !seekers.fetched || seekers.wg != nil {
+		return false
+	}
+	return !m.tieredStorage.Cached(m.fileSetFileIdentifier(shard, blockStart, seekers.volume))
+}
+
 func (m *seekerManager) getSeekerResources() ReusableSeekerResources {
 	return m.reusableSeekerResourcesPool.Get().(ReusableSeekerResources)
 }
@@ -21,6 +21,8 @@
 package fs
 
 import (
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -34,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/objstore"
 	xtest "github.com/m3db/m3/src/x/test"
 	xtime "github.com/m3db/m3/src/x/time"
 )
@@ -733,3 +736,77 @@ func TestSeekerManagerDoNotOpenSeekersForOutOfRetentionBlocks(t *testing.T) {
 	require.NotContains(t, openSeekers, earliestBlockStart.Add(-blockSize))
 	require.NotContains(t, openSeekers, earliestBlockStart.Add(-2*blockSize))
 }
+
+func TestSeekerManagerDoNotOpenSeekersForOffloadedBlocks(t *testing.T) {
+	defer leaktest.CheckTimeout(t, 1*time.Minute)()
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		ctrl        = xtest.NewController(t)
+		shards      = []uint32{0}
+		metadata    = testNs1Metadata(t)
+		rOpts       = metadata.Options().RetentionOptions()
+		blockSize   = rOpts.BlockSize()
+		signal      = make(chan struct{})
+		openSeekers = make(map[xtime.UnixNano]struct{})
+		now         = time.Now()
+		opts        = NewOptions().SetFilePathPrefix(dir)
+	)
+	store, err := objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+	tier, err := NewTieredStorage(TieredStorageOptions{
+		Store:             store,
+		FilesystemOptions: opts,
+		CacheDirectory:    filepath.Join(dir, "cache"),
+		OffloadAfter:      2 * blockSize,
+	})
+	require.NoError(t, err)
+
+	shardSet, err := sharding.NewShardSet(
+		sharding.NewShards(shards, shard.Available),
+		sharding.DefaultHashFn(1),
+	)
+	require.NoError(t, err)
+	opts = opts.
+		SetTieredStorage(tier).
+		SetClockOptions(opts.ClockOptions().SetNowFn(func() time.Time {
+			return now
+		}))
+	m := NewSeekerManager(nil, opts, defaultTestBlockRetrieverOptions).(*seekerManager)
+	m.sleepFn = func(_ time.Duration) {
+		signal <- struct{}{} // signal once to indicate that openCloseLoop completed.
+		m.sleepFn = time.Sleep
+	}
+	require.NoError(t, m.Open(metadata, shardSet))
+	defer func() {
+		require.NoError(t, m.Close())
+	}()
+
+	m.newOpenSeekerFn = func(shard uint32, blockStart xtime.UnixNano, volume int) (DataFileSetSeeker, error) {
+		openSeekers[blockStart] = struct{}{}
+		mockSeeker := NewMockDataFileSetSeeker(ctrl)
+		mockConcurrentDataFileSetSeeker := NewMockConcurrentDataFileSetSeeker(ctrl)
+		mockConcurrentDataFileSetSeeker.EXPECT().Close().Return(nil)
+		mockSeeker.EXPECT().ConcurrentClone().Return(mockConcurrentDataFileSetSeeker, nil)
+		mockSeeker.EXPECT().ConcurrentIDBloomFilter().Return(nil)
+		mockSeeker.EXPECT().Close().Return(nil)
+		return mockSeeker, nil
+	}
+
+	// Blocks that ended at least the offload age ago are left to be opened
+	// on demand.
+	var (
+		latestBlockStart    = xtime.ToUnixNano(now).Truncate(blockSize)
+		latestOffloadable   = xtime.ToUnixNano(now).Add(-3 * blockSize).Truncate(blockSize)
+		earliestBlockStart  = retention.FlushTimeStart(rOpts, xtime.ToUnixNano(now))
+		firstPreOpenedBlock = latestOffloadable.Add(blockSize)
+	)
+	require.NoError(t, m.CacheShardIndices(shards))
+
+	<-signal
+	require.Contains(t, openSeekers, latestBlockStart)
+	require.Contains(t, openSeekers, firstPreOpenedBlock)
+	require.NotContains(t, openSeekers, latestOffloadable)
+	require.NotContains(t, openSeekers, earliestBlockStart)
+}
@@ -31,6 +31,7 @@ import (
 
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/ident"
 	xtime "github.com/m3db/m3/src/x/time"
 )
@@ -58,6 +59,7 @@ type StreamingWriterOpenOptions struct {
 	BlockStart  xtime.UnixNano
 	BlockSize   time.Duration
 	VolumeIndex int
+	Compression compress.Type
 
 	// PlannedRecordsCount is an estimate of the number of series to be written.
 	// Must be greater than 0.
@@ -71,7 +73,6 @@ type streamingWriter struct {
 	prevIDBytes  []byte
 	summaryEvery int64
 	bloomFilter  *bloom.BloomFilter
-	indexOffset  int64
 	summaries    int
 }
 
@@ -93,7 +94,8 @@ func (w *streamingWriter) Open(opts StreamingWriterOpenOptions) error {
 	}
 
 	writerOpts := DataWriterOpenOptions{
-		BlockSize: opts.BlockSize,
+		BlockSize:   opts.BlockSize,
+		Compression: opts.Compression,
 		Identifier: FileSetFileIdentifier{
 			Namespace:   opts.NamespaceID,
 			Shard:       opts.ShardID,
@@ -125,7 +127,6 @@ func (w *streamingWriter) Open(opts StreamingWriterOpenOptions) error {
 	}
 
 	w.currIdx = 0
-	w.indexOffset = 0
 	w.summaries = 0
 	w.prevIDBytes = nil
 
@@ -174,10 +175,22 @@ func (w *streamingWriter) writeData(
 		size:           uint32(size),
 		dataChecksum:   dataChecksum,
 	}
-	for _, d := range data {
-		if err := w.writer.writeData(d); err != nil {
+	if w.writer.compressor != nil {
+		w.writer.dataBuf = w.writer.dataBuf[:0]
+		for _, d := range data {
+			w.writer.dataBuf = append(w.writer.dataBuf, d...)
+		}
+		compressedSize, err := w.writer.writeCompressedData()
+		if err != nil {
 			return indexEntry{}, false, err
 		}
+		entry.compressedSize = compressedSize
+	} else {
+		for _, d := range data {
+			if err := w.writer.writeData(d); err != nil {
+				return indexEntry{}, false, err
+			}
+		}
 	}
 
 	w.currIdx++
@@ -199,18 +212,19 @@ func (w *streamingWriter) writeIndexRelated(
 	if writeSummary {
 		// Capture the offset for when we write this summary back, only capture
 		// for every summary we'll actually write to avoid a few memcopies
-		entry.indexFileOffset = w.indexOffset
+		offset, err := w.writer.indexWriter.startBlock()
+		if err != nil {
+			return err
+		}
+		entry.indexFileOffset = offset
 	}
 
-	length, err := w.writer.writeIndexWithEncodedTags(id, encodedTags, entry)
-	if err != nil {
+	if err := w.writer.writeIndexWithEncodedTags(id, encodedTags, entry); err != nil {
 		return err
 	}
-	w.indexOffset += length
 
 	if writeSummary {
-		err = w.writer.writeSummariesEntry(id, entry)
-		if err != nil {
+		if err := w.writer.writeSummariesEntry(id, entry); err != nil {
 			return err
 		}
 		w.summaries++
@@ -220,6 +234,11 @@ func (w *streamingWriter) writeIndexRelated(
 }
 
 func (w *streamingWriter) Close() error {
+	// Write out the last block of the index
+	if err := w.writer.indexWriter.flush(); err != nil {
+		return err
+	}
+
 	// Write the bloom filter bitset out
 	if err := w.writer.writeBloomFilterFileContents(w.bloomFilter); err != nil {
 		return err
@@ -0,0 +1,657 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/objstore"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var (
+	errTieredStorageNoStore          = errors.New("tiered storage requires an object store")
+	errTieredStorageNoCacheDirectory = errors.New("tiered storage requires a cache directory")
+	errTieredStorageNoOffloadAfter   = errors.New("tiered storage requires a positive offload age")
+)
+
+// fileSetDirNames are the directories under the file path prefix that hold
+// the files of the node, the cache directory must not be within any of them.
+var fileSetDirNames = []string{
+	dataDirName,
+	indexDirName,
+	snapshotDirName,
+	commitLogsDirName,
+	tombstonesDirName,
+}
+
+const defaultTieredStorageOffloadConcurrency = 4
+
+// tieredFileSetSuffixes are the files of a data fileset that are offloaded,
+// the checkpoint file is always transferred last so that a fileset is only
+// ever considered complete once all of its other files are in place.
+var tieredFileSetSuffixes = []string{
+	InfoFileSuffix,
+	summariesFileSuffix,
+	bloomFilterFileSuffix,
+	indexFileSuffix,
+	dataFileSuffix,
+	DigestFileSuffix,
+	CheckpointFileSuffix,
+}
+
+// evictedFileSetSuffixes are the files of a data fileset that are removed
+// from local disk once it has been offloaded, the remaining files are small
+// and keep the fileset visible to bootstrapping, flushing and cleanup.
+var evictedFileSetSuffixes = []string{
+	indexFileSuffix,
+	dataFileSuffix,
+}
+
+// TieredStorageOptions are the options for tiered storage.
+type TieredStorageOptions struct {
+	// Store is the object store filesets are offloaded to.
+	Store objstore.Store
+	// FilesystemOptions are the options for the local filesets.
+	FilesystemOptions Options
+	// CacheDirectory is the directory offloaded filesets are fetched into,
+	// the filesets in it are cleared when tiered storage is created.
+	CacheDirectory string
+	// CacheMaxBytes is the size the cache is kept under by evicting the
+	// least recently used filesets, the cache is unbounded when zero.
+	CacheMaxBytes int64
+	// OffloadAfter is how long after the end of a block its filesets are
+	// offloaded.
+	OffloadAfter time.Duration
+	// Namespaces restricts offloading to the given namespaces, filesets of
+	// all namespaces are offloaded when empty.
+	Namespaces []string
+	// OffloadConcurrency is how many filesets are offloaded at once,
+	// defaults to 4 when zero.
+	OffloadConcurrency int
+}
+
+type tieredStorageMetrics struct {
+	offloaded      tally.Counter
+	offloadErrors  tally.Counter
+	purged         tally.Counter
+	fetched        tally.Counter
+	fetchErrors    tally.Counter
+	cacheHits      tally.Counter
+	cacheEvictions tally.Counter
+	cacheBytes     tally.Gauge
+}
+
+func newTieredStorageMetrics(scope tally.Scope) tieredStorageMetrics {
+	return tieredStorageMetrics{
+		offloaded:      scope.Counter("offloaded"),
+		offloadErrors:  scope.Counter("offload-errors"),
+		purged:         scope.Counter("purged"),
+		fetched:        scope.Counter("fetched"),
+		fetchErrors:    scope.Counter("fetch-errors"),
+		cacheHits:      scope.Counter("cache-hits"),
+		cacheEvictions: scope.Counter("cache-evictions"),
+		cacheBytes:     scope.Gauge("cache-bytes"),
+	}
+}
+
+type tieredCacheEntry struct {
+	key   string
+	paths []string
+	size  int64
+	// pins is the number of callers that are yet to open the fileset, a
+	// pinned fileset is never removed from the cache.
+	pins int
+	// purged is set when the fileset was purged while pinned, it is removed
+	// from the cache once it is released.
+	purged bool
+}
+
+type tieredFetch struct {
+	done chan struct{}
+	err  error
+}
+
+type tieredStorage struct {
+	sync.Mutex
+
+	store            objstore.Store
+	filePathPrefix   string
+	cacheDir         string
+	cacheMaxBytes    int64
+	offloadAfter     time.Duration
+	offloadConc      int
+	namespaces       map[string]struct{}
+	newFileMode      os.FileMode
+	newDirectoryMode os.FileMode
+
+	// cache holds the fetched filesets, most recently used first.
+	cache      *list.List
+	cached     map[string]*list.Element
+	cacheBytes int64
+	fetches    map[string]*tieredFetch
+
+	metrics tieredStorageMetrics
+	logger  *zap.Logger
+}
+
+// NewTieredStorage returns a new tiered storage.
+func NewTieredStorage(opts TieredStorageOptions) (TieredStorage, error) {
+	if opts.Store == nil {
+		return nil, errTieredStorageNoStore
+	}
+	if opts.CacheDirectory == "" {
+		return nil, errTieredStorageNoCacheDirectory
+	}
+	if opts.OffloadAfter <= 0 {
+		return nil, errTieredStorageNoOffloadAfter
+	}
+	fsOpts := opts.FilesystemOptions
+	if fsOpts == nil {
+		fsOpts = NewOptions()
+	}
+	err := ValidateTieredStorageCacheDirectory(fsOpts.FilePathPrefix(), opts.CacheDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing tracks the filesets that were fetched before a restart, so
+	// start with an empty cache. NB: only the directory the filesets are
+	// fetched into is removed, never the cache directory itself.
+	if err := os.RemoveAll(path.Join(opts.CacheDirectory, dataDirName)); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(opts.CacheDirectory, fsOpts.NewDirectoryMode()); err != nil {
+		return nil, err
+	}
+
+	offloadConc := opts.OffloadConcurrency
+	if offloadConc <= 0 {
+		offloadConc = defaultTieredStorageOffloadConcurrency
+	}
+
+	namespaces := make(map[string]struct{}, len(opts.Namespaces))
+	for _, ns := range opts.Namespaces {
+		namespaces[ns] = struct{}{}
+	}
+
+	iOpts := fsOpts.InstrumentOptions()
+	return &tieredStorage{
+		store:            opts.Store,
+		filePathPrefix:   fsOpts.FilePathPrefix(),
+		cacheDir:         opts.CacheDirectory,
+		cacheMaxBytes:    opts.CacheMaxBytes,
+		offloadAfter:     opts.OffloadAfter,
+		offloadConc:      offloadConc,
+		namespaces:       namespaces,
+		newFileMode:      fsOpts.NewFileMode(),
+		newDirectoryMode: fsOpts.NewDirectoryMode(),
+		cache:            list.New(),
+		cached:           make(map[string]*list.Element),
+		fetches:          make(map[string]*tieredFetch),
+		metrics:          newTieredStorageMetrics(iOpts.MetricsScope().SubScope("tiered-storage")),
+		logger:           iOpts.Logger(),
+	}, nil
+}
+
+// ValidateTieredStorageCacheDirectory returns an error if the cache directory
+// overlaps with the files of the node under the file path prefix, since the
+// filesets in the cache directory are removed when tiered storage is created.
+func ValidateTieredStorageCacheDirectory(filePathPrefix, cacheDir string) error {
+	prefix, err := filepath.Abs(filePathPrefix)
+	if err != nil {
+		return err
+	}
+	cache, err := filepath.Abs(cacheDir)
+	if err != nil {
+		return err
+	}
+
+	if within(prefix, cache) {
+		return fmt.Errorf(
+			"tiered storage cache directory %s must not contain the file path prefix %s",
+			cacheDir, filePathPrefix)
+	}
+
+	for _, name := range fileSetDirNames {
+		if within(cache, filepath.Join(prefix, name)) {
+			return fmt.Errorf(
+				"tiered storage cache directory %s must not be within the %s directory of %s",
+				cacheDir, name, filePathPrefix)
+		}
+	}
+
+	return nil
+}
+
+// within returns true if the path is the directory or is within it.
+func within(p, dir string) bool {
+	rel, err := filepath.Rel(dir, p)
+	if err != nil {
+		return false
+	}
+
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
+
+func (t *tieredStorage) Enabled(namespace ident.ID) bool {
+	if len(t.namespaces) == 0 {
+		return true
+	}
+	_, ok := t.namespaces[namespace.String()]
+	return ok
+}
+
+func (t *tieredStorage) OffloadAfter() time.Duration {
+	return t.offloadAfter
+}
+
+func (t *tieredStorage) OffloadConcurrency() int {
+	return t.offloadConc
+}
+
+func (t *tieredStorage) Offloaded(id FileSetFileIdentifier) (bool, error) {
+	isLegacy, err := t.isLegacy(id)
+	if err == ErrCheckpointFileNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	shardDir := ShardDataDirPath(t.filePathPrefix, id.Namespace, id.Shard)
+	exists, err := FileExists(dataFilesetPathFromTimeAndIndex(
+		shardDir, id.BlockStart, id.VolumeIndex, dataFileSuffix, isLegacy))
+	if err != nil || exists {
+		return false, err
+	}
+
+	return CompleteCheckpointFileExists(dataFilesetPathFromTimeAndIndex(
+		shardDir, id.BlockStart, id.VolumeIndex, CheckpointFileSuffix, isLegacy))
+}
+
+func (t *tieredStorage) Offload(id FileSetFileIdentifier) error {
+	if err := t.offload(id); err != nil {
+		t.metrics.offloadErrors.Inc(1)
+		return fmt.Errorf("failed to offload fileset %s: %w", tieredFileSetKey(id), err)
+	}
+	t.metrics.offloaded.Inc(1)
+	return nil
+}
+
+func (t *tieredStorage) offload(id FileSetFileIdentifier) error {
+	offloaded, err := t.Offloaded(id)
+	if err != nil || offloaded {
+		return err
+	}
+
+	isLegacy, err := t.isLegacy(id)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	shardDir := ShardDataDirPath(t.filePathPrefix, id.Namespace, id.Shard)
+	for _, suffix := range tieredFileSetSuffixes {
+		filePath := dataFilesetPathFromTimeAndIndex(
+			shardDir, id.BlockStart, id.VolumeIndex, suffix, isLegacy)
+		if err := t.upload(ctx, filePath); err != nil {
+			return err
+		}
+	}
+
+	evict := make([]string, 0, len(evictedFileSetSuffixes))
+	for _, suffix := range evictedFileSetSuffixes {
+		evict = append(evict, dataFilesetPathFromTimeAndIndex(
+			shardDir, id.BlockStart, id.VolumeIndex, suffix, isLegacy))
+	}
+	return DeleteFiles(evict)
+}
+
+func (t *tieredStorage) upload(ctx context.Context, filePath string) error {
+	key, err := t.objectKey(filePath)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return t.store.Put(ctx, key, f)
+}
+
+func (t *tieredStorage) Fetch(id FileSetFileIdentifier) (string, func(), error) {
+	offloaded, err := t.Offloaded(id)
+	if err != nil {
+		return "", nil, err
+	}
+	if !offloaded {
+		return t.filePathPrefix, noopTieredRelease, nil
+	}
+
+	key := tieredFileSetKey(id)
+	for {
+		t.Lock()
+		if elem, ok := t.cached[key]; ok {
+			t.cache.MoveToFront(elem)
+			release := t.pinWithLock(elem)
+			t.Unlock()
+			t.metrics.cacheHits.Inc(1)
+			return t.cacheDir, release, nil
+		}
+		fetch, ok := t.fetches[key]
+		if !ok {
+			break
+		}
+		// Another caller is already fetching the fileset, wait on it rather
+		// than fetching it twice and then pin it from the cache.
+		t.Unlock()
+		<-fetch.done
+		if fetch.err != nil {
+			return "", nil, fetch.err
+		}
+	}
+	fetch := &tieredFetch{done: make(chan struct{})}
+	t.fetches[key] = fetch
+	t.Unlock()
+
+	entry, err := t.download(id)
+
+	var release func()
+	t.Lock()
+	delete(t.fetches, key)
+	if err == nil {
+		elem := t.cache.PushFront(entry)
+		t.cached[key] = elem
+		t.cacheBytes += entry.size
+		release = t.pinWithLock(elem)
+		t.evictWithLock()
+	}
+	t.Unlock()
+
+	if err != nil {
+		t.metrics.fetchErrors.Inc(1)
+		err = fmt.Errorf("failed to fetch fileset %s: %w", key, err)
+	} else {
+		t.metrics.fetched.Inc(1)
+	}
+	fetch.err = err
+	close(fetch.done)
+	if err != nil {
+		return "", nil, err
+	}
+	return t.cacheDir, release, nil
+}
+
+func noopTieredRelease() {}
+
+// pinWithLock pins a cached fileset so that it is not evicted before the
+// caller has opened its files, the returned func releases the pin.
+func (t *tieredStorage) pinWithLock(elem *list.Element) func() {
+	entry := elem.Value.(*tieredCacheEntry)
+	entry.pins++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			t.Lock()
+			defer t.Unlock()
+			entry.pins--
+			if entry.pins == 0 && entry.purged {
+				t.removeWithLock(elem)
+			}
+			t.evictWithLock()
+		})
+	}
+}
+
+func (t *tieredStorage) download(id FileSetFileIdentifier) (*tieredCacheEntry, error) {
+	isLegacy, err := t.isLegacy(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		ctx         = context.Background()
+		shardDir    = ShardDataDirPath(t.filePathPrefix, id.Namespace, id.Shard)
+		cacheDir    = ShardDataDirPath(t.cacheDir, id.Namespace, id.Shard)
+		entry       = &tieredCacheEntry{key: tieredFileSetKey(id)}
+		downloadErr error
+	)
+	if err := os.MkdirAll(cacheDir, t.newDirectoryMode); err != nil {
+		return nil, err
+	}
+	for _, suffix := range tieredFileSetSuffixes {
+		key, err := t.objectKey(dataFilesetPathFromTimeAndIndex(
+			shardDir, id.BlockStart, id.VolumeIndex, suffix, isLegacy))
+		if err != nil {
+			downloadErr = err
+			break
+		}
+		filePath := dataFilesetPathFromTimeAndIndex(
+			cacheDir, id.BlockStart, id.VolumeIndex, suffix, isLegacy)
+		entry.paths = append(entry.paths, filePath)
+		size, err := downloadObject(ctx, t.store, key, filePath, t.newFileMode)
+		if err != nil {
+			downloadErr = err
+			break
+		}
+		entry.size += size
+	}
+	if downloadErr != nil {
+		// Don't leave a partially fetched fileset behind.
+		if err := removeFiles(entry.paths); err != nil {
+			t.logger.Error("could not remove partially fetched fileset",
+				zap.String("fileset", entry.key), zap.Error(err))
+		}
+		return nil, downloadErr
+	}
+
+	return entry, nil
+}
+
+// downloadObject writes the object with the given key to the file path,
+// returning the size of the object.
+func downloadObject(
+	ctx context.Context,
+	store objstore.Store,
+	key string,
+	filePath string,
+	fileMode os.FileMode,
+) (int64, error) {
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+
+	f, err := OpenWritable(filePath, fileMode)
+	if err != nil {
+		return 0, err
+	}
+	size, err := io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	return size, f.Close()
+}
+
+// evictWithLock removes the least recently used filesets from the cache
+// until it is within its size, always keeping the most recently used one and
+// those that are pinned. Seekers that still hold evicted files open keep
+// reading them until they are closed.
+func (t *tieredStorage) evictWithLock() {
+	if t.cacheMaxBytes > 0 {
+		elem := t.cache.Back()
+		for elem != nil && elem != t.cache.Front() && t.cacheBytes > t.cacheMaxBytes {
+			prev := elem.Prev()
+			if elem.Value.(*tieredCacheEntry).pins == 0 {
+				t.removeWithLock(elem)
+				t.metrics.cacheEvictions.Inc(1)
+			}
+			elem = prev
+		}
+	}
+	t.metrics.cacheBytes.Update(float64(t.cacheBytes))
+}
+
+func (t *tieredStorage) removeWithLock(elem *list.Element) {
+	entry := t.cache.Remove(elem).(*tieredCacheEntry)
+	delete(t.cached, entry.key)
+	t.cacheBytes -= entry.size
+	if err := removeFiles(entry.paths); err != nil {
+		t.logger.Error("could not remove fileset from tiered storage cache",
+			zap.String("fileset", entry.key), zap.Error(err))
+	}
+}
+
+func (t *tieredStorage) Cached(id FileSetFileIdentifier) bool {
+	t.Lock()
+	_, ok := t.cached[tieredFileSetKey(id)]
+	t.Unlock()
+	return ok
+}
+
+func (t *tieredStorage) PurgeDeleted(namespace ident.ID, shard uint32) error {
+	prefix, err := t.objectKey(ShardDataDirPath(t.filePathPrefix, namespace, shard))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	keys, err := t.store.List(ctx, prefix+"/")
+	if err != nil {
+		return err
+	}
+
+	// Group the objects by fileset, the checkpoint file of each fileset is
+	// deleted first so that a partially deleted fileset is never fetched.
+	type fileSetVolume struct {
+		blockStart xtime.UnixNano
+		volume     int
+	}
+	var (
+		volumes          []fileSetVolume
+		keysByFileSetVol = make(map[fileSetVolume][]string)
+	)
+	for _, key := range keys {
+		blockStart, volume, err := TimeAndVolumeIndexFromDataFileSetFilename(key)
+		if err != nil {
+			continue
+		}
+		v := fileSetVolume{blockStart: blockStart, volume: volume}
+		if _, ok := keysByFileSetVol[v]; !ok {
+			volumes = append(volumes, v)
+		}
+		if strings.Contains(path.Base(key), CheckpointFileSuffix) {
+			keysByFileSetVol[v] = append([]string{key}, keysByFileSetVol[v]...)
+		} else {
+			keysByFileSetVol[v] = append(keysByFileSetVol[v], key)
+		}
+	}
+
+	for _, v := range volumes {
+		exists, err := DataFileSetExists(t.filePathPrefix, namespace, shard,
+			v.blockStart, v.volume)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+
+		for _, key := range keysByFileSetVol[v] {
+			if err := t.store.Delete(ctx, key); err != nil {
+				return err
+			}
+		}
+
+		key := tieredFileSetKey(FileSetFileIdentifier{
+			Namespace:   namespace,
+			Shard:       shard,
+			BlockStart:  v.blockStart,
+			VolumeIndex: v.volume,
+		})
+		t.Lock()
+		if elem, ok := t.cached[key]; ok {
+			if entry := elem.Value.(*tieredCacheEntry); entry.pins > 0 {
+				// Leave the files in place until they have been opened.
+				entry.purged = true
+			} else {
+				t.removeWithLock(elem)
+				t.metrics.cacheBytes.Update(float64(t.cacheBytes))
+			}
+		}
+		t.Unlock()
+		t.metrics.purged.Inc(1)
+	}
+
+	return nil
+}
+
+func (t *tieredStorage) isLegacy(id FileSetFileIdentifier) (bool, error) {
+	if id.VolumeIndex != 0 {
+		return false, nil
+	}
+	return isFirstVolumeLegacy(
+		ShardDataDirPath(t.filePathPrefix, id.Namespace, id.Shard),
+		id.BlockStart, CheckpointFileSuffix)
+}
+
+// objectKey returns the key of the object for a file under the file path
+// prefix, the key mirrors the layout of the file path prefix.
+func (t *tieredStorage) objectKey(filePath string) (string, error) {
+	rel, err := filepath.Rel(t.filePathPrefix, filePath)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func tieredFileSetKey(id FileSetFileIdentifier) string {
+	return fmt.Sprintf("%s/%d/%d/%d", id.Namespace.String(), id.Shard,
+		int64(id.BlockStart), id.VolumeIndex)
+}
+
+func removeFiles(filePaths []string) error {
+	for _, filePath := range filePaths {
+		if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
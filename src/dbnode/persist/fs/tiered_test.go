@@ -0,0 +1,405 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/objstore"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestTieredStorage(
+	t *testing.T,
+	dir string,
+	cacheMaxBytes int64,
+) (TieredStorage, objstore.Store) {
+	store, err := objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+
+	tier, err := NewTieredStorage(TieredStorageOptions{
+		Store:             store,
+		FilesystemOptions: testDefaultOpts.SetFilePathPrefix(dir),
+		CacheDirectory:    filepath.Join(dir, "cache"),
+		CacheMaxBytes:     cacheMaxBytes,
+		OffloadAfter:      time.Hour,
+	})
+	require.NoError(t, err)
+	return tier, store
+}
+
+func newTestTieredEntries() []testEntry {
+	return []testEntry{
+		{"foo", nil, []byte{1, 2, 3}},
+		{"bar", nil, []byte{4, 5, 6}},
+		{"baz", map[string]string{"qux": "qaz"}, make([]byte, 4096)},
+	}
+}
+
+func testFileSetID(shard uint32, blockStart xtime.UnixNano) FileSetFileIdentifier {
+	return FileSetFileIdentifier{
+		Namespace:  testNs1ID,
+		Shard:      shard,
+		BlockStart: blockStart,
+	}
+}
+
+func testFileSetFileExists(
+	t *testing.T,
+	filePathPrefix string,
+	id FileSetFileIdentifier,
+	suffix string,
+) bool {
+	shardDir := ShardDataDirPath(filePathPrefix, id.Namespace, id.Shard)
+	_, err := os.Stat(FilesetPathFromTimeAndIndex(
+		shardDir, id.BlockStart, id.VolumeIndex, suffix))
+	if os.IsNotExist(err) {
+		return false
+	}
+	require.NoError(t, err)
+	return true
+}
+
+func TestTieredStorageOffloadAndFetch(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	var (
+		entries   = newTestTieredEntries()
+		id        = testFileSetID(0, testWriterStart)
+		tier, _   = newTestTieredStorage(t, filePathPrefix, 0)
+		cachePath = filepath.Join(filePathPrefix, "cache")
+	)
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+
+	offloaded, err := tier.Offloaded(id)
+	require.NoError(t, err)
+	require.False(t, offloaded)
+
+	// Filesets that have not been offloaded are read from local disk.
+	prefix, release, err := tier.Fetch(id)
+	require.NoError(t, err)
+	require.Equal(t, filePathPrefix, prefix)
+	release()
+
+	require.NoError(t, tier.Offload(id))
+	offloaded, err = tier.Offloaded(id)
+	require.NoError(t, err)
+	require.True(t, offloaded)
+
+	// Only the index and data files are removed from local disk.
+	for _, suffix := range tieredFileSetSuffixes {
+		expected := suffix != indexFileSuffix && suffix != dataFileSuffix
+		assert.Equal(t, expected, testFileSetFileExists(t, filePathPrefix, id, suffix), suffix)
+	}
+	exists, err := DataFileSetExists(filePathPrefix, testNs1ID, 0, testWriterStart, 0)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	// Offloading again is a no-op.
+	require.NoError(t, tier.Offload(id))
+
+	// Reads are served from the cache once fetched.
+	require.False(t, tier.Cached(id))
+	r, err := NewReader(testBytesPool, testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetInfoReaderBufferSize(testReaderBufferSize).
+		SetDataReaderBufferSize(testReaderBufferSize).
+		SetTieredStorage(tier))
+	require.NoError(t, err)
+	readTestData(t, r, 0, testWriterStart, entries)
+	require.True(t, tier.Cached(id))
+	for _, suffix := range tieredFileSetSuffixes {
+		assert.True(t, testFileSetFileExists(t, cachePath, id, suffix), suffix)
+	}
+
+	prefix, release, err = tier.Fetch(id)
+	require.NoError(t, err)
+	require.Equal(t, cachePath, prefix)
+	requireSeekTestData(t, prefix, testDefaultOpts, 0, entries)
+	release()
+}
+
+func TestTieredStorageSeekOffloadedFileSet(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	var (
+		entries = newTestTieredEntries()
+		id      = testFileSetID(0, testWriterStart)
+		tier, _ = newTestTieredStorage(t, filePathPrefix, 0)
+	)
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, testWriterStart, entries, persist.FileSetFlushType)
+	require.NoError(t, tier.Offload(id))
+
+	opts := testDefaultOpts.
+		SetFilePathPrefix(filePathPrefix).
+		SetTieredStorage(tier)
+	m := NewSeekerManager(testBytesPool, opts, defaultTestBlockRetrieverOptions).(*seekerManager)
+	m.namespace = testNs1ID
+
+	seeker, err := m.newOpenSeeker(0, testWriterStart, 0)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, seeker.Close())
+	}()
+	require.True(t, tier.Cached(id))
+
+	resources := newTestReusableSeekerResources()
+	for _, entry := range entries {
+		data, err := seeker.SeekByID(ident.StringID(entry.id), resources)
+		require.NoError(t, err)
+		data.IncRef()
+		assert.Equal(t, entry.data, data.Bytes())
+		data.DecRef()
+		data.Finalize()
+	}
+}
+
+func TestTieredStorageCacheEviction(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	var (
+		entries = newTestTieredEntries()
+		first   = testFileSetID(0, testWriterStart)
+		second  = testFileSetID(1, testWriterStart)
+		// A single byte cache only ever holds the most recently fetched fileset.
+		tier, _ = newTestTieredStorage(t, filePathPrefix, 1)
+	)
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, first.Shard, testWriterStart, entries, persist.FileSetFlushType)
+	writeTestData(t, w, second.Shard, testWriterStart, entries, persist.FileSetFlushType)
+	require.NoError(t, tier.Offload(first))
+	require.NoError(t, tier.Offload(second))
+
+	cachePath, release, err := tier.Fetch(first)
+	require.NoError(t, err)
+	require.True(t, tier.Cached(first))
+	release()
+
+	_, release, err = tier.Fetch(second)
+	require.NoError(t, err)
+	require.True(t, tier.Cached(second))
+	require.False(t, tier.Cached(first))
+	require.False(t, testFileSetFileExists(t, cachePath, first, dataFileSuffix))
+	release()
+
+	// Evicted filesets are fetched again.
+	_, release, err = tier.Fetch(first)
+	require.NoError(t, err)
+	require.True(t, tier.Cached(first))
+	require.False(t, tier.Cached(second))
+	requireSeekTestData(t, cachePath, testDefaultOpts, first.Shard, entries)
+	release()
+}
+
+func TestTieredStorageCachePinning(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	var (
+		entries = newTestTieredEntries()
+		first   = testFileSetID(0, testWriterStart)
+		second  = testFileSetID(1, testWriterStart)
+		tier, _ = newTestTieredStorage(t, filePathPrefix, 1)
+	)
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, first.Shard, testWriterStart, entries, persist.FileSetFlushType)
+	writeTestData(t, w, second.Shard, testWriterStart, entries, persist.FileSetFlushType)
+	require.NoError(t, tier.Offload(first))
+	require.NoError(t, tier.Offload(second))
+
+	cachePath, releaseFirst, err := tier.Fetch(first)
+	require.NoError(t, err)
+	_, releaseSecond, err := tier.Fetch(second)
+	require.NoError(t, err)
+
+	// The first fileset is not evicted while it has not been opened.
+	require.True(t, tier.Cached(first))
+	require.True(t, tier.Cached(second))
+	requireSeekTestData(t, cachePath, testDefaultOpts, first.Shard, entries)
+
+	releaseFirst()
+	require.False(t, tier.Cached(first))
+	require.True(t, tier.Cached(second))
+
+	// Releasing more than once has no effect.
+	releaseFirst()
+	releaseSecond()
+	require.True(t, tier.Cached(second))
+}
+
+func TestTieredStoragePurgeDeleted(t *testing.T) {
+	dir := createTempDir(t)
+	filePathPrefix := filepath.Join(dir, "")
+	defer os.RemoveAll(dir)
+
+	var (
+		entries     = newTestTieredEntries()
+		retained    = testFileSetID(0, testWriterStart)
+		deleted     = testFileSetID(0, testWriterStart.Add(-testBlockSize))
+		tier, store = newTestTieredStorage(t, filePathPrefix, 0)
+	)
+	w := newTestWriter(t, filePathPrefix)
+	writeTestData(t, w, 0, retained.BlockStart, entries, persist.FileSetFlushType)
+	writeTestData(t, w, 0, deleted.BlockStart, entries, persist.FileSetFlushType)
+	require.NoError(t, tier.Offload(retained))
+	require.NoError(t, tier.Offload(deleted))
+	_, release, err := tier.Fetch(deleted)
+	require.NoError(t, err)
+	release()
+
+	keys, err := store.List(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, keys, 2*len(tieredFileSetSuffixes))
+
+	// Nothing is purged while the filesets exist locally.
+	require.NoError(t, tier.PurgeDeleted(testNs1ID, 0))
+	keys, err = store.List(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, keys, 2*len(tieredFileSetSuffixes))
+
+	fileset, ok, err := FileSetAt(filePathPrefix, testNs1ID, 0, deleted.BlockStart, 0)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, DeleteFiles(fileset.AbsoluteFilePaths))
+
+	require.NoError(t, tier.PurgeDeleted(testNs1ID, 0))
+	keys, err = store.List(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, keys, len(tieredFileSetSuffixes))
+	for _, key := range keys {
+		blockStart, _, err := TimeAndVolumeIndexFromDataFileSetFilename(key)
+		require.NoError(t, err)
+		require.Equal(t, retained.BlockStart, blockStart)
+	}
+	require.False(t, tier.Cached(deleted))
+}
+
+func TestTieredStorageEnabled(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	store, err := objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+
+	opts := TieredStorageOptions{
+		Store:             store,
+		FilesystemOptions: testDefaultOpts.SetFilePathPrefix(dir),
+		CacheDirectory:    filepath.Join(dir, "cache"),
+		OffloadAfter:      time.Hour,
+	}
+	tier, err := NewTieredStorage(opts)
+	require.NoError(t, err)
+	require.True(t, tier.Enabled(testNs1ID))
+	require.True(t, tier.Enabled(testNs2ID))
+
+	opts.Namespaces = []string{testNs1ID.String()}
+	tier, err = NewTieredStorage(opts)
+	require.NoError(t, err)
+	require.True(t, tier.Enabled(testNs1ID))
+	require.False(t, tier.Enabled(testNs2ID))
+
+	opts.OffloadAfter = 0
+	_, err = NewTieredStorage(opts)
+	require.Error(t, err)
+}
+
+func TestTieredStorageClearsOnlyFetchedFileSets(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	store, err := objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+
+	var (
+		cacheDir = filepath.Join(dir, "cache")
+		fetched  = filepath.Join(ShardDataDirPath(cacheDir, testNs1ID, 0), "fileset")
+		other    = filepath.Join(cacheDir, "other")
+	)
+	require.NoError(t, os.MkdirAll(filepath.Dir(fetched), 0755))
+	require.NoError(t, ioutil.WriteFile(fetched, []byte{1}, 0644))
+	require.NoError(t, ioutil.WriteFile(other, []byte{1}, 0644))
+
+	_, err = NewTieredStorage(TieredStorageOptions{
+		Store:             store,
+		FilesystemOptions: testDefaultOpts.SetFilePathPrefix(dir),
+		CacheDirectory:    cacheDir,
+		OffloadAfter:      time.Hour,
+	})
+	require.NoError(t, err)
+
+	_, err = os.Stat(fetched)
+	require.True(t, os.IsNotExist(err))
+	_, err = os.Stat(other)
+	require.NoError(t, err)
+}
+
+func TestValidateTieredStorageCacheDirectory(t *testing.T) {
+	tests := []struct {
+		cacheDir string
+		valid    bool
+	}{
+		{cacheDir: "/var/lib/m3db/cache", valid: true},
+		{cacheDir: "/var/lib/m3db-cache", valid: true},
+		{cacheDir: "/mnt/cache", valid: true},
+		{cacheDir: "/var/lib/m3db/datacache", valid: true},
+		{cacheDir: "/var/lib/m3db"},
+		{cacheDir: "/var/lib/m3db/"},
+		{cacheDir: "/var/lib"},
+		{cacheDir: "/"},
+		{cacheDir: "/var/lib/m3db/data"},
+		{cacheDir: "/var/lib/m3db/data/ns"},
+		{cacheDir: "/var/lib/m3db/index"},
+		{cacheDir: "/var/lib/m3db/snapshots"},
+		{cacheDir: "/var/lib/m3db/commitlogs"},
+		{cacheDir: "/var/lib/m3db/cache/../commitlogs/cache"},
+		{cacheDir: "/var/lib/m3db/tombstones"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.cacheDir, func(t *testing.T) {
+			err := ValidateTieredStorageCacheDirectory("/var/lib/m3db", tt.cacheDir)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"sync"
+
+	"go.uber.org/atomic"
+
+	"github.com/m3db/m3/src/x/ident"
+	xos "github.com/m3db/m3/src/x/os"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// Tombstone marks the datapoints of a series within a time range as deleted.
+type Tombstone struct {
+	ID    ident.ID
+	Range xtime.Range
+	// Open marks a deletion up to the time it was made, which hides the
+	// series from index queries past the deleted range until it is written
+	// again.
+	Open bool
+}
+
+type tombstonesFile struct {
+	Tombstones []tombstonesFileEntry `json:"tombstones"`
+}
+
+type tombstonesFileEntry struct {
+	ID    []byte         `json:"id"`
+	Start xtime.UnixNano `json:"start"`
+	End   xtime.UnixNano `json:"end"`
+	Open  bool           `json:"open,omitempty"`
+}
+
+// Tombstones is the set of series deletions of a namespace. The deleted time
+// ranges are persisted to a per namespace file so that deleted data remains
+// hidden across restarts until it has been physically removed by a merge of
+// the filesets it belongs to. A nil Tombstones holds no tombstones.
+type Tombstones struct {
+	sync.RWMutex
+
+	filePath string
+	dirPath  string
+	fileMode os.FileMode
+	dirMode  os.FileMode
+	ranges   map[string][]xtime.Range
+	open     map[string]struct{}
+	// numOpen is the number of open series, read without the lock on every
+	// write.
+	numOpen atomic.Int64
+}
+
+// NewTombstones returns the tombstones of a namespace, loading any
+// previously persisted tombstones from disk.
+func NewTombstones(opts Options, namespace ident.ID) (*Tombstones, error) {
+	prefix := opts.FilePathPrefix()
+	t := &Tombstones{
+		filePath: NamespaceTombstonesFilePath(prefix, namespace),
+		dirPath:  TombstonesDirPath(prefix),
+		fileMode: opts.NewFileMode(),
+		dirMode:  opts.NewDirectoryMode(),
+		ranges:   make(map[string][]xtime.Range),
+		open:     make(map[string]struct{}),
+	}
+
+	data, err := ioutil.ReadFile(t.filePath) // nolint: gosec
+	if os.IsNotExist(err) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file tombstonesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("unable to decode tombstones file %s: %w", t.filePath, err)
+	}
+	for _, entry := range file.Tombstones {
+		t.addWithLock(string(entry.ID), xtime.Range{Start: entry.Start, End: entry.End}, entry.Open)
+	}
+	return t, nil
+}
+
+// Add records the given tombstones and persists all tombstones to disk.
+func (t *Tombstones) Add(tombstones []Tombstone) error {
+	if len(tombstones) == 0 {
+		return nil
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	for _, tombstone := range tombstones {
+		t.addWithLock(tombstone.ID.String(), tombstone.Range, tombstone.Open)
+	}
+	return t.persistWithLock()
+}
+
+func (t *Tombstones) addWithLock(id string, r xtime.Range, open bool) {
+	if r.IsEmpty() {
+		return
+	}
+	if _, ok := t.open[id]; open && !ok {
+		t.open[id] = struct{}{}
+		t.numOpen.Inc()
+	}
+
+	merged := xtime.NewRanges(t.ranges[id]...)
+	merged.AddRange(r)
+	// NB: Never mutate the existing slice in place since it may have been
+	// returned to a caller of Ranges.
+	result := make([]xtime.Range, 0, merged.Len())
+	for it := merged.Iter(); it.Next(); {
+		result = append(result, it.Value())
+	}
+	t.ranges[id] = result
+}
+
+// Ranges returns the deleted time ranges of a series sorted by start time,
+// or nil if the series has no tombstones. The result must not be modified.
+func (t *Tombstones) Ranges(id ident.ID) []xtime.Range {
+	if t == nil {
+		return nil
+	}
+	t.RLock()
+	defer t.RUnlock()
+	if len(t.ranges) == 0 {
+		// Avoid converting the ID to a string in the common case.
+		return nil
+	}
+	return t.ranges[id.String()]
+}
+
+// Written records a write of a series at the given time, which makes an open
+// series visible to index queries past its deleted ranges again unless the
+// written datapoint is itself deleted.
+func (t *Tombstones) Written(id ident.ID, timestamp xtime.UnixNano) {
+	if t == nil || t.numOpen.Load() == 0 {
+		return
+	}
+
+	t.RLock()
+	_, open := t.open[string(id.Bytes())]
+	deleted := IsTombstoned(t.ranges[string(id.Bytes())], timestamp)
+	t.RUnlock()
+	if !open || deleted {
+		return
+	}
+
+	t.Lock()
+	if _, ok := t.open[string(id.Bytes())]; ok {
+		delete(t.open, string(id.Bytes()))
+		t.numOpen.Dec()
+	}
+	t.Unlock()
+}
+
+// Hides returns whether a series is hidden from index queries of the given
+// time range, which is the case when its deleted ranges cover the time range.
+// The time range of an open series is capped at the end of its last deleted
+// range since it has not been written since.
+func (t *Tombstones) Hides(id ident.ID, r xtime.Range) bool {
+	if t == nil || t.Len() == 0 {
+		return false
+	}
+	t.RLock()
+	defer t.RUnlock()
+	return t.hidesWithLock(string(id.Bytes()), r)
+}
+
+// HiddenIDs returns the IDs of the series hidden from index queries of the
+// given time range.
+func (t *Tombstones) HiddenIDs(r xtime.Range) []ident.ID {
+	if t == nil || t.Len() == 0 {
+		return nil
+	}
+	t.RLock()
+	defer t.RUnlock()
+	var ids []ident.ID
+	for id := range t.ranges {
+		if t.hidesWithLock(id, r) {
+			ids = append(ids, ident.StringID(id))
+		}
+	}
+	return ids
+}
+
+func (t *Tombstones) hidesWithLock(id string, r xtime.Range) bool {
+	ranges, ok := t.ranges[id]
+	if !ok {
+		return false
+	}
+	if _, open := t.open[id]; open {
+		if last := ranges[len(ranges)-1].End; r.End.After(last) {
+			r.End = last
+		}
+	}
+	return IsCovered(ranges, r)
+}
+
+// Len returns the number of series with tombstones.
+func (t *Tombstones) Len() int {
+	if t == nil {
+		return 0
+	}
+	t.RLock()
+	n := len(t.ranges)
+	t.RUnlock()
+	return n
+}
+
+// ForEach calls the given function with the deleted time ranges of each
+// series that has tombstones.
+func (t *Tombstones) ForEach(fn func(id ident.ID, ranges []xtime.Range)) {
+	if t == nil {
+		return
+	}
+	t.RLock()
+	defer t.RUnlock()
+	for id, ranges := range t.ranges {
+		fn(ident.StringID(id), ranges)
+	}
+}
+
+// Expire removes the tombstones that end before the given time, since the
+// data they cover has been expired by retention, and persists the remaining
+// tombstones to disk if any were removed.
+func (t *Tombstones) Expire(earliestToRetain xtime.UnixNano) error {
+	if t == nil {
+		return nil
+	}
+	t.Lock()
+	defer t.Unlock()
+
+	expired := false
+	for id, ranges := range t.ranges {
+		retained := make([]xtime.Range, 0, len(ranges))
+		for _, r := range ranges {
+			if r.End > earliestToRetain {
+				retained = append(retained, r)
+			}
+		}
+		if len(retained) == len(ranges) {
+			continue
+		}
+
+		expired = true
+		if len(retained) == 0 {
+			delete(t.ranges, id)
+			if _, ok := t.open[id]; ok {
+				delete(t.open, id)
+				t.numOpen.Dec()
+			}
+			continue
+		}
+		t.ranges[id] = retained
+	}
+
+	if !expired {
+		return nil
+	}
+	return t.persistWithLock()
+}
+
+func (t *Tombstones) persistWithLock() error {
+	ids := make([]string, 0, len(t.ranges))
+	for id := range t.ranges {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var file tombstonesFile
+	for _, id := range ids {
+		_, open := t.open[id]
+		for _, r := range t.ranges[id] {
+			file.Tombstones = append(file.Tombstones, tombstonesFileEntry{
+				ID:    []byte(id),
+				Start: r.Start,
+				End:   r.End,
+				Open:  open,
+			})
+		}
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.dirPath, t.dirMode); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first and rename it so that a crash while
+	// writing never leaves a partially written tombstones file behind.
+	tmpFilePath := t.filePath + ".tmp"
+	if err := xos.WriteFileSync(tmpFilePath, data, t.fileMode); err != nil {
+		return err
+	}
+	return os.Rename(tmpFilePath, t.filePath)
+}
+
+// IsTombstoned returns whether a timestamp falls within any of the given
+// deleted time ranges.
+func IsTombstoned(ranges []xtime.Range, t xtime.UnixNano) bool {
+	for _, r := range ranges {
+		if !t.Before(r.Start) && t.Before(r.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCovered returns whether the given sorted and non overlapping deleted time
+// ranges cover the whole time range.
+func IsCovered(ranges []xtime.Range, r xtime.Range) bool {
+	covered := r.Start
+	for _, deleted := range ranges {
+		if !covered.Before(r.End) {
+			break
+		}
+		if deleted.Start.After(covered) {
+			return false
+		}
+		if deleted.End.After(covered) {
+			covered = deleted.End
+		}
+	}
+	return !covered.Before(r.End)
+}
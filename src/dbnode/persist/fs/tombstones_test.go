@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fs
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestTombstonesAddAndReload(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts  = NewOptions().SetFilePathPrefix(dir)
+		nsID  = ident.StringID("testns")
+		start = xtime.Now().Truncate(time.Hour)
+	)
+	tombstones, err := NewTombstones(opts, nsID)
+	require.NoError(t, err)
+	require.Equal(t, 0, tombstones.Len())
+	require.Nil(t, tombstones.Ranges(ident.StringID("foo")))
+
+	require.NoError(t, tombstones.Add([]Tombstone{
+		{ID: ident.StringID("foo"), Range: xtime.Range{Start: start, End: start.Add(time.Minute)}},
+		{ID: ident.StringID("foo"), Range: xtime.Range{Start: start.Add(30 * time.Second), End: start.Add(2 * time.Minute)}},
+		{ID: ident.StringID("foo"), Range: xtime.Range{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)}},
+		{ID: ident.StringID("bar"), Range: xtime.Range{Start: start, End: start.Add(time.Hour)}},
+	}))
+
+	expected := []xtime.Range{
+		{Start: start, End: start.Add(2 * time.Minute)},
+		{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)},
+	}
+	require.Equal(t, 2, tombstones.Len())
+	require.Equal(t, expected, tombstones.Ranges(ident.StringID("foo")))
+
+	// Tombstones are persisted and loaded again on startup.
+	reloaded, err := NewTombstones(opts, nsID)
+	require.NoError(t, err)
+	require.Equal(t, 2, reloaded.Len())
+	require.Equal(t, expected, reloaded.Ranges(ident.StringID("foo")))
+
+	// Other namespaces do not share tombstones.
+	other, err := NewTombstones(opts, ident.StringID("otherns"))
+	require.NoError(t, err)
+	require.Equal(t, 0, other.Len())
+}
+
+func TestTombstonesExpire(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts  = NewOptions().SetFilePathPrefix(dir)
+		nsID  = ident.StringID("testns")
+		start = xtime.Now().Truncate(time.Hour)
+	)
+	tombstones, err := NewTombstones(opts, nsID)
+	require.NoError(t, err)
+	require.NoError(t, tombstones.Add([]Tombstone{
+		{ID: ident.StringID("foo"), Range: xtime.Range{Start: start, End: start.Add(time.Hour)}},
+		{ID: ident.StringID("foo"), Range: xtime.Range{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)}},
+		{ID: ident.StringID("bar"), Range: xtime.Range{Start: start, End: start.Add(time.Hour)}},
+	}))
+
+	require.NoError(t, tombstones.Expire(start.Add(time.Hour)))
+	require.Equal(t, 1, tombstones.Len())
+	require.Equal(t, []xtime.Range{
+		{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+	}, tombstones.Ranges(ident.StringID("foo")))
+
+	reloaded, err := NewTombstones(opts, nsID)
+	require.NoError(t, err)
+	require.Equal(t, 1, reloaded.Len())
+}
+
+func TestIsTombstoned(t *testing.T) {
+	start := xtime.Now().Truncate(time.Hour)
+	ranges := []xtime.Range{
+		{Start: start, End: start.Add(time.Minute)},
+		{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)},
+	}
+
+	require.False(t, IsTombstoned(nil, start))
+	require.True(t, IsTombstoned(ranges, start))
+	require.True(t, IsTombstoned(ranges, start.Add(30*time.Second)))
+	require.False(t, IsTombstoned(ranges, start.Add(time.Minute)))
+	require.True(t, IsTombstoned(ranges, start.Add(90*time.Minute)))
+	require.False(t, IsTombstoned(ranges, start.Add(2*time.Hour)))
+}
+
+func TestTombstonesHides(t *testing.T) {
+	dir := createTempDir(t)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts    = NewOptions().SetFilePathPrefix(dir)
+		nsID    = ident.StringID("testns")
+		start   = xtime.Now().Truncate(time.Hour)
+		deleted = xtime.Range{Start: start, End: start.Add(time.Hour)}
+		after   = xtime.Range{Start: start.Add(30 * time.Minute), End: start.Add(2 * time.Hour)}
+	)
+	tombstones, err := NewTombstones(opts, nsID)
+	require.NoError(t, err)
+	require.False(t, tombstones.Hides(ident.StringID("foo"), deleted))
+	require.NoError(t, tombstones.Add([]Tombstone{
+		{ID: ident.StringID("foo"), Range: deleted},
+		{ID: ident.StringID("bar"), Range: deleted, Open: true},
+	}))
+
+	// Series are hidden from the queries of time ranges within the deleted
+	// ranges, and open series from the queries past them too.
+	for _, id := range []string{"foo", "bar"} {
+		require.True(t, tombstones.Hides(ident.StringID(id), deleted))
+		require.False(t, tombstones.Hides(ident.StringID(id),
+			xtime.Range{Start: start.Add(-time.Minute), End: start.Add(time.Minute)}))
+	}
+	require.False(t, tombstones.Hides(ident.StringID("foo"), after))
+	require.True(t, tombstones.Hides(ident.StringID("bar"), after))
+	require.Equal(t, []ident.ID{ident.StringID("bar")}, tombstones.HiddenIDs(after))
+
+	// The open state is persisted.
+	reloaded, err := NewTombstones(opts, nsID)
+	require.NoError(t, err)
+	require.True(t, reloaded.Hides(ident.StringID("bar"), after))
+
+	// Writing deleted datapoints does not make an open series visible again,
+	// writing other datapoints does.
+	tombstones.Written(ident.StringID("bar"), start.Add(time.Minute))
+	require.True(t, tombstones.Hides(ident.StringID("bar"), after))
+	tombstones.Written(ident.StringID("bar"), start.Add(time.Hour))
+	require.False(t, tombstones.Hides(ident.StringID("bar"), after))
+	require.True(t, tombstones.Hides(ident.StringID("bar"), deleted))
+	require.Empty(t, tombstones.HiddenIDs(after))
+}
+
+func TestIsCovered(t *testing.T) {
+	start := xtime.Now().Truncate(time.Hour)
+	ranges := []xtime.Range{
+		{Start: start, End: start.Add(time.Minute)},
+		{Start: start.Add(time.Minute), End: start.Add(time.Hour)},
+		{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)},
+	}
+
+	require.False(t, IsCovered(nil, xtime.Range{Start: start, End: start.Add(time.Minute)}))
+	require.True(t, IsCovered(nil, xtime.Range{Start: start, End: start}))
+	require.True(t, IsCovered(ranges, xtime.Range{Start: start, End: start.Add(time.Hour)}))
+	require.True(t, IsCovered(ranges, xtime.Range{Start: start.Add(2 * time.Hour), End: start.Add(3 * time.Hour)}))
+	require.False(t, IsCovered(ranges, xtime.Range{Start: start, End: start.Add(2 * time.Hour)}))
+	require.False(t, IsCovered(ranges, xtime.Range{Start: start.Add(-time.Minute), End: start.Add(time.Minute)}))
+	require.False(t, IsCovered(ranges, xtime.Range{Start: start.Add(2 * time.Hour), End: start.Add(4 * time.Hour)}))
+}
@@ -42,7 +42,9 @@ import (
 	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
 	"github.com/m3db/m3/src/x/checked"
 	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/context"
+	"github.com/m3db/m3/src/x/encryption"
 	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/mmap"
@@ -71,6 +73,8 @@ type DataWriterOpenOptions struct {
 	FileSetContentType persist.FileSetContentType
 	Identifier         FileSetFileIdentifier
 	BlockSize          time.Duration
+	// Compression is the compression of the data and index files.
+	Compression compress.Type
 	// Only used when writing snapshot files
 	Snapshot DataWriterSnapshotOptions
 }
@@ -268,6 +272,87 @@ type ConcurrentDataFileSetSeeker interface {
 	ConcurrentIDBloomFilter() *ManagedConcurrentBloomFilter
 }
 
+// TieredStorage offloads the data filesets of sealed blocks to an object
+// store and fetches them back on demand. Offloaded filesets keep their small
+// metadata files on local disk so that they remain visible to bootstrapping,
+// flushing and cleanup, while their index and data files are removed.
+type TieredStorage interface {
+	// Enabled returns whether filesets of the namespace are offloaded.
+	Enabled(namespace ident.ID) bool
+
+	// OffloadAfter returns how long after the end of a block its filesets
+	// are offloaded.
+	OffloadAfter() time.Duration
+
+	// OffloadConcurrency returns how many filesets are offloaded at once.
+	OffloadConcurrency() int
+
+	// Offloaded returns whether the fileset has been offloaded and its index
+	// and data files removed from local disk.
+	Offloaded(id FileSetFileIdentifier) (bool, error)
+
+	// Offload uploads the fileset to the object store and removes its index
+	// and data files from local disk.
+	Offload(id FileSetFileIdentifier) error
+
+	// Fetch returns the file path prefix the complete fileset can be read
+	// from, which is the local file path prefix unless the fileset has been
+	// offloaded, in which case it is fetched into the cache. The fileset is
+	// kept in the cache until the returned func is called, which callers must
+	// do once they have opened its files.
+	Fetch(id FileSetFileIdentifier) (string, func(), error)
+
+	// Cached returns whether an offloaded fileset is held in the cache.
+	Cached(id FileSetFileIdentifier) bool
+
+	// PurgeDeleted removes the offloaded copies of the filesets of a shard
+	// that no longer exist locally, such as those deleted by cleanup after
+	// falling out of retention.
+	PurgeDeleted(namespace ident.ID, shard uint32) error
+}
+
+// BackupManager copies the filesets of a namespace to a backup store and
+// restores them from it. A backup holds the latest complete data fileset
+// volume of every block, the complete index filesets and the snapshot
+// filesets of a snapshot taken after the point in time of the backup, which
+// together are everything the bootstrappers need to rehydrate the namespace.
+type BackupManager interface {
+	// SnapshotTimeout returns how long a backup waits for a snapshot taken
+	// after its point in time to complete.
+	SnapshotTimeout() time.Duration
+
+	// Backup copies the filesets of a namespace to the backup store.
+	Backup(spec BackupSpec) (BackupManifest, error)
+
+	// Restore replaces the filesets of a namespace with those of a backup.
+	// The latest backup taken at or before the point in time is restored if
+	// no backup ID is given, or the latest backup if neither is given.
+	Restore(
+		namespace ident.ID,
+		backupID string,
+		pointInTime xtime.UnixNano,
+	) (BackupManifest, error)
+}
+
+// BackupSpec describes a backup of a namespace.
+type BackupSpec struct {
+	// ID is the ID of the backup, it is shared by the hosts that take part
+	// in the backup.
+	ID string
+	// Namespace is the namespace that is backed up.
+	Namespace ident.ID
+	// Shards are the shards of the namespace that are backed up.
+	Shards []uint32
+	// PointInTime is the time at which the backup was taken.
+	PointInTime xtime.UnixNano
+	// SnapshotIndex is the index of the first snapshot that was taken after
+	// the point in time, snapshot filesets of older snapshots are not backed
+	// up. No snapshot filesets are backed up if it is negative.
+	SnapshotIndex int64
+	// IndexEnabled is whether the index filesets are backed up.
+	IndexEnabled bool
+}
+
 // DataFileSetSeekerManager provides management of seekers for a TSDB namespace.
 type DataFileSetSeekerManager interface {
 	io.Closer
@@ -540,6 +625,22 @@ type Options interface {
 
 	// EncodingOptions returns the encoder options used by the encoder.
 	EncodingOptions() msgpack.LegacyEncodingOptions
+
+	// SetEncryptionKeyProvider sets the key provider used to encrypt files
+	// at rest, files are written in plaintext when it is nil.
+	SetEncryptionKeyProvider(value encryption.KeyProvider) Options
+
+	// EncryptionKeyProvider returns the key provider used to encrypt files
+	// at rest, files are written in plaintext when it is nil.
+	EncryptionKeyProvider() encryption.KeyProvider
+
+	// SetTieredStorage sets the tiered storage filesets are offloaded to and
+	// fetched from, filesets are kept on local disk when it is nil.
+	SetTieredStorage(value TieredStorage) Options
+
+	// TieredStorage returns the tiered storage filesets are offloaded to and
+	// fetched from, filesets are kept on local disk when it is nil.
+	TieredStorage() TieredStorage
 }
 
 // BlockRetrieverOptions represents the options for block retrieval.
@@ -613,6 +714,10 @@ type MergeWith interface {
 		fn ForEachRemainingFn,
 		nsCtx namespace.Context,
 	) error
+
+	// Tombstones returns the deleted time ranges of the given series, the
+	// datapoints within these ranges are dropped from the merged data.
+	Tombstones(seriesID ident.ID) []xtime.Range
 }
 
 // Merger is in charge of merging filesets with some target MergeWith interface.
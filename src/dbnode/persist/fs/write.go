@@ -38,6 +38,8 @@ import (
 	"github.com/m3db/m3/src/dbnode/persist/schema"
 	"github.com/m3db/m3/src/dbnode/ts"
 	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/compress"
+	"github.com/m3db/m3/src/x/encryption"
 	"github.com/m3db/m3/src/x/ident"
 	xresource "github.com/m3db/m3/src/x/resource"
 	"github.com/m3db/m3/src/x/serialize"
@@ -71,6 +73,24 @@ type writer struct {
 	checkpointFilePath         string
 	indexEntries               indexEntries
 
+	// The encrypters write through to the files with digest as is when
+	// encryption is disabled, digests are computed on the bytes on disk.
+	opts                 Options
+	encryption           schema.EncryptionInfo
+	indexEncrypter       *encryption.Writer
+	summariesEncrypter   *encryption.Writer
+	bloomFilterEncrypter *encryption.Writer
+	dataEncrypter        *encryption.Writer
+
+	// The index is written through the index writer which compresses it in
+	// blocks when compression is enabled, compression happens before
+	// encryption.
+	compression       compress.Type
+	compressor        compress.Compressor
+	indexWriter       indexBlockWriter
+	dataBuf           []byte
+	compressedDataBuf []byte
+
 	start        xtime.UnixNano
 	volumeIndex  int
 	snapshotTime xtime.UnixNano
@@ -91,6 +111,7 @@ type indexEntry struct {
 	dataFileOffset  int64
 	indexFileOffset int64
 	size            uint32
+	compressedSize  uint32
 	dataChecksum    uint32
 }
 
@@ -144,6 +165,11 @@ func NewWriter(opts Options) (DataFileSetWriter, error) {
 		bloomFilterFdWithDigest:         digest.NewFdWithDigestWriter(bufferSize),
 		dataFdWithDigest:                digest.NewFdWithDigestWriter(bufferSize),
 		digestFdWithDigestContents:      digest.NewFdWithDigestContentsWriter(bufferSize),
+		opts:                            opts,
+		indexEncrypter:                  encryption.NewWriter(bufferSize),
+		summariesEncrypter:              encryption.NewWriter(bufferSize),
+		bloomFilterEncrypter:            encryption.NewWriter(bufferSize),
+		dataEncrypter:                   encryption.NewWriter(bufferSize),
 		encoder:                         msgpack.NewEncoderWithOptions(opts.EncodingOptions()),
 		digestBuf:                       digest.NewBuffer(),
 		singleCheckedBytes:              make([]checked.Bytes, 1),
@@ -165,6 +191,11 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 	)
 	w.reset(opts)
 
+	w.compressor, err = compress.NewCompressor(opts.Compression)
+	if err != nil {
+		return err
+	}
+
 	var (
 		shardDir            string
 		infoFilepath        string
@@ -207,6 +238,17 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 		return fmt.Errorf("unable to open reader with fileset type: %s", opts.FileSetType)
 	}
 
+	// Each volume is encrypted with its own data key.
+	encryptionInfo, dataKey, err := GenerateDataKey(w.opts)
+	if err != nil {
+		return err
+	}
+	ciphers, err := newDataFileSetCiphers(dataKey)
+	if err != nil {
+		return err
+	}
+	w.encryption = encryptionInfo
+
 	var infoFd, indexFd, summariesFd, bloomFilterFd, dataFd, digestFd *os.File
 	err = openFiles(w.openWritable,
 		map[string]**os.File{
@@ -228,6 +270,11 @@ func (w *writer) Open(opts DataWriterOpenOptions) error {
 	w.bloomFilterFdWithDigest.Reset(bloomFilterFd)
 	w.dataFdWithDigest.Reset(dataFd)
 	w.digestFdWithDigestContents.Reset(digestFd)
+	w.indexEncrypter.Reset(w.indexFdWithDigest, ciphers.index)
+	w.summariesEncrypter.Reset(w.summariesFdWithDigest, ciphers.summaries)
+	w.bloomFilterEncrypter.Reset(w.bloomFilterFdWithDigest, ciphers.bloomFilter)
+	w.dataEncrypter.Reset(w.dataFdWithDigest, ciphers.data)
+	w.indexWriter.reset(w.indexEncrypter, w.compressor)
 
 	return nil
 }
@@ -238,6 +285,7 @@ func (w *writer) reset(opts DataWriterOpenOptions) {
 	w.volumeIndex = opts.Identifier.VolumeIndex
 	w.snapshotTime = opts.Snapshot.SnapshotTime
 	w.snapshotID = opts.Snapshot.SnapshotID
+	w.compression = opts.Compression
 	w.currIdx = 0
 	w.currOffset = 0
 	w.err = nil
@@ -252,7 +300,7 @@ func (w *writer) writeData(data []byte) error {
 	if len(data) == 0 {
 		return nil
 	}
-	written, err := w.dataFdWithDigest.Write(data)
+	written, err := w.dataEncrypter.Write(data)
 	if err != nil {
 		return err
 	}
@@ -260,6 +308,16 @@ func (w *writer) writeData(data []byte) error {
 	return nil
 }
 
+// writeCompressedData compresses the data buffered in dataBuf and writes it
+// out, returning the size of the compressed data.
+func (w *writer) writeCompressedData() (uint32, error) {
+	w.compressedDataBuf = w.compressor.Compress(w.compressedDataBuf[:0], w.dataBuf)
+	if err := w.writeData(w.compressedDataBuf); err != nil {
+		return 0, err
+	}
+	return uint32(len(w.compressedDataBuf)), nil
+}
+
 func (w *writer) Write(
 	metadata persist.Metadata,
 	data checked.Bytes,
@@ -310,13 +368,29 @@ func (w *writer) writeAll(
 		},
 		metadata: metadata,
 	}
-	for _, d := range data {
-		if d == nil {
-			continue
+	if w.compressor != nil {
+		// Each entry is compressed on its own so it can be read by itself.
+		w.dataBuf = w.dataBuf[:0]
+		for _, d := range data {
+			if d == nil {
+				continue
+			}
+			w.dataBuf = append(w.dataBuf, d.Bytes()...)
 		}
-		if err := w.writeData(d.Bytes()); err != nil {
+		compressedSize, err := w.writeCompressedData()
+		if err != nil {
 			return err
 		}
+		entry.entry.compressedSize = compressedSize
+	} else {
+		for _, d := range data {
+			if d == nil {
+				continue
+			}
+			if err := w.writeData(d.Bytes()); err != nil {
+				return err
+			}
+		}
 	}
 
 	w.indexEntries = append(w.indexEntries, entry)
@@ -457,7 +531,6 @@ func (w *writer) writeIndexFileContents(
 	sort.Sort(w.indexEntries)
 
 	var (
-		offset       int64
 		prevID       []byte
 		tagsReusable = w.tagsIterator
 		tagsEncoder  = w.tagEncoderPool.Get()
@@ -485,19 +558,22 @@ func (w *writer) writeIndexFileContents(
 		if i%summaryEvery == 0 {
 			// Capture the offset for when we write this summary back, only capture
 			// for every summary we'll actually write to avoid a few memcopies
+			offset, err := w.indexWriter.startBlock()
+			if err != nil {
+				return err
+			}
 			w.indexEntries[i].entry.indexFileOffset = offset
 		}
 
-		length, err := w.writeIndex(id, tagsIter, tagsEncoder, entry.entry)
-		if err != nil {
+		if err := w.writeIndex(id, tagsIter, tagsEncoder, entry.entry); err != nil {
 			return err
 		}
-		offset += length
 
 		prevID = id
 	}
 
-	return nil
+	// Write out the last block of the index.
+	return w.indexWriter.flush()
 }
 
 func (w *writer) writeIndex(
@@ -505,17 +581,17 @@ func (w *writer) writeIndex(
 	tagsIter ident.TagIterator,
 	tagsEncoder serialize.TagEncoder,
 	entry indexEntry,
-) (int64, error) {
+) error {
 	var encodedTags []byte
 	if numTags := tagsIter.Remaining(); numTags > 0 {
 		tagsEncoder.Reset()
 		if err := tagsEncoder.Encode(tagsIter); err != nil {
-			return 0, err
+			return err
 		}
 
 		encodedTagsData, ok := tagsEncoder.Data()
 		if !ok {
-			return 0, errWriterEncodeTagsDataNotAccessible
+			return errWriterEncodeTagsDataNotAccessible
 		}
 
 		encodedTags = encodedTagsData.Bytes()
@@ -528,27 +604,24 @@ func (w *writer) writeIndexWithEncodedTags(
 	id []byte,
 	encodedTags ts.EncodedTags,
 	entry indexEntry,
-) (int64, error) {
+) error {
 	e := schema.IndexEntry{
-		Index:        entry.index,
-		ID:           id,
-		Size:         int64(entry.size),
-		Offset:       entry.dataFileOffset,
-		DataChecksum: int64(entry.dataChecksum),
-		EncodedTags:  encodedTags,
+		Index:          entry.index,
+		ID:             id,
+		Size:           int64(entry.size),
+		Offset:         entry.dataFileOffset,
+		DataChecksum:   int64(entry.dataChecksum),
+		EncodedTags:    encodedTags,
+		CompressedSize: int64(entry.compressedSize),
 	}
 
 	w.encoder.Reset()
 	if err := w.encoder.EncodeIndexEntry(e); err != nil {
-		return 0, err
-	}
-
-	data := w.encoder.Bytes()
-	if _, err := w.indexFdWithDigest.Write(data); err != nil {
-		return 0, err
+		return err
 	}
 
-	return int64(len(data)), nil
+	_, err := w.indexWriter.Write(w.encoder.Bytes())
+	return err
 }
 
 func (w *writer) writeSummariesFileContents(
@@ -585,7 +658,7 @@ func (w *writer) writeSummariesEntry(
 	}
 
 	data := w.encoder.Bytes()
-	if _, err := w.summariesFdWithDigest.Write(data); err != nil {
+	if _, err := w.summariesEncrypter.Write(data); err != nil {
 		return err
 	}
 
@@ -595,7 +668,7 @@ func (w *writer) writeSummariesEntry(
 func (w *writer) writeBloomFilterFileContents(
 	bloomFilter *bloom.BloomFilter,
 ) error {
-	return bloomFilter.BitSet().Write(w.bloomFilterFdWithDigest)
+	return bloomFilter.BitSet().Write(w.bloomFilterEncrypter)
 }
 
 func (w *writer) writeInfoFileContents(
@@ -608,6 +681,17 @@ func (w *writer) writeInfoFileContents(
 		return fmt.Errorf("error marshaling snapshot ID into bytes: %v", err)
 	}
 
+	// Compressed and encrypted files can't be read by older versions, only
+	// bump the minor version when compressing or encrypting to keep the
+	// files readable otherwise.
+	minorVersion := int64(schema.MinorVersion)
+	if w.compressor != nil {
+		minorVersion = schema.CompressedMinorVersion
+	}
+	if w.encryption.Enabled() {
+		minorVersion = schema.EncryptedMinorVersion
+	}
+
 	info := schema.IndexInfo{
 		BlockStart:   int64(w.start),
 		VolumeIndex:  w.volumeIndex,
@@ -616,7 +700,7 @@ func (w *writer) writeInfoFileContents(
 		BlockSize:    int64(w.blockSize),
 		Entries:      entriesCount,
 		MajorVersion: schema.MajorVersion,
-		MinorVersion: schema.MinorVersion,
+		MinorVersion: minorVersion,
 		Summaries: schema.IndexSummariesInfo{
 			Summaries: int64(summaries),
 		},
@@ -624,6 +708,8 @@ func (w *writer) writeInfoFileContents(
 			NumElementsM: int64(bloomFilter.M()),
 			NumHashesK:   int64(bloomFilter.K()),
 		},
+		Encryption:  w.encryption,
+		Compression: w.compression,
 	}
 
 	w.encoder.Reset()
@@ -23,6 +23,7 @@ package schema
 import (
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/x/compress"
 	"github.com/m3db/m3/src/x/ident"
 )
 
@@ -36,6 +37,15 @@ const MajorVersion = 1
 // we want to have some level of control around how they're rolled out.
 const MinorVersion = 1
 
+// CompressedMinorVersion is the minor schema version written for a set of
+// compressed fileset files, older readers are not able to read them.
+const CompressedMinorVersion = 2
+
+// EncryptedMinorVersion is the minor schema version written for a set of
+// encrypted fileset files, which may also be compressed, older readers are
+// not able to read them.
+const EncryptedMinorVersion = 3
+
 // IndexInfo stores metadata information about block filesets.
 type IndexInfo struct {
 	MajorVersion int64
@@ -49,6 +59,20 @@ type IndexInfo struct {
 	SnapshotID   []byte
 	VolumeIndex  int
 	MinorVersion int64
+	Encryption   EncryptionInfo
+	Compression  compress.Type
+}
+
+// EncryptionInfo stores the data key a set of files is encrypted with,
+// files are not encrypted when the key ID is empty.
+type EncryptionInfo struct {
+	KeyID            string
+	EncryptedDataKey []byte
+}
+
+// Enabled returns whether the files are encrypted.
+func (e EncryptionInfo) Enabled() bool {
+	return e.KeyID != ""
 }
 
 // IndexSummariesInfo stores metadata about the summaries.
@@ -67,14 +91,18 @@ type IndexBloomFilterInfo struct {
 // When serialized to disk, the encoder will automatically add the IndexEntryChecksum, a checksum to validate
 // the index entry itself, to the end of the entry. That field is not exposed on this struct as this is handled
 // transparently by the encoder and decoder. Appending of checksum starts in V3.
+//
+// Size is always the uncompressed size of the data, CompressedSize is the
+// size of the data on disk when the files are compressed and zero otherwise.
 type IndexEntry struct {
-	Index         int64
-	ID            []byte
-	Size          int64
-	Offset        int64
-	DataChecksum  int64
-	EncodedTags   []byte
-	IndexChecksum int64
+	Index          int64
+	ID             []byte
+	Size           int64
+	Offset         int64
+	DataChecksum   int64
+	EncodedTags    []byte
+	IndexChecksum  int64
+	CompressedSize int64
 }
 
 // IndexEntryHasher hashes an index entry.
@@ -104,7 +132,8 @@ type LogInfo struct {
 	DeprecatedDoNotUseStart    int64
 	DeprecatedDoNotUseDuration int64
 
-	Index int64
+	Index      int64
+	Encryption EncryptionInfo
 }
 
 // LogEntry stores per-entry data in a commit log
@@ -41,3 +41,15 @@ func NewVersionChecker(majorVersion int, minorVersion int) VersionChecker {
 func (v *VersionChecker) IndexEntryValidationEnabled() bool {
 	return v.majorVersion >= 2 || v.majorVersion == 1 && v.minorVersion >= 1
 }
+
+// CompressionSupported checks the version to determine if fileset files
+// of the specified version may be compressed.
+func (v *VersionChecker) CompressionSupported() bool {
+	return v.majorVersion >= 2 || v.majorVersion == 1 && v.minorVersion >= 2
+}
+
+// EncryptionSupported checks the version to determine if fileset files
+// of the specified version may be encrypted.
+func (v *VersionChecker) EncryptionSupported() bool {
+	return v.majorVersion >= 2 || v.majorVersion == 1 && v.minorVersion >= 3
+}
@@ -44,3 +44,29 @@ func TestIndexEntryValidationDisabled(t *testing.T) {
 	checker := NewVersionChecker(1, 0)
 	require.False(t, checker.IndexEntryValidationEnabled())
 }
+
+func TestCompressionSupported(t *testing.T) {
+	checker := NewVersionChecker(1, 2)
+	require.True(t, checker.CompressionSupported())
+
+	checker = NewVersionChecker(2, 0)
+	require.True(t, checker.CompressionSupported())
+
+	checker = NewVersionChecker(1, 1)
+	require.False(t, checker.CompressionSupported())
+
+	checker = NewVersionChecker(1, 0)
+	require.False(t, checker.CompressionSupported())
+}
+
+func TestEncryptionSupported(t *testing.T) {
+	checker := NewVersionChecker(1, 3)
+	require.True(t, checker.EncryptionSupported())
+	require.True(t, checker.CompressionSupported())
+
+	checker = NewVersionChecker(2, 0)
+	require.True(t, checker.EncryptionSupported())
+
+	checker = NewVersionChecker(1, 2)
+	require.False(t, checker.EncryptionSupported())
+}
@@ -639,6 +639,32 @@ func Run(runOpts RunOptions) {
 		SetForceBloomFilterMmapMemory(cfg.Filesystem.ForceBloomFilterMmapMemoryOrDefault()).
 		SetIndexBloomFilterFalsePositivePercent(cfg.Filesystem.BloomFilterFalsePositivePercentOrDefault()).
 		SetMmapReporter(mmapReporter)
+	if encryptionCfg := cfg.Filesystem.Encryption; encryptionCfg != nil {
+		keyProvider, err := encryptionCfg.NewKeyProvider()
+		if err != nil {
+			logger.Fatal("could not create encryption key provider", zap.Error(err))
+		}
+		fsopts = fsopts.SetEncryptionKeyProvider(keyProvider)
+	}
+	if tierCfg := cfg.Filesystem.TieredStorage; tierCfg != nil {
+		store, err := tierCfg.Store.NewStore()
+		if err != nil {
+			logger.Fatal("could not create tiered storage object store", zap.Error(err))
+		}
+		tier, err := fs.NewTieredStorage(fs.TieredStorageOptions{
+			Store:              store,
+			FilesystemOptions:  fsopts,
+			CacheDirectory:     tierCfg.CacheDirectoryOrDefault(fsopts.FilePathPrefix()),
+			CacheMaxBytes:      tierCfg.CacheMaxBytesOrDefault(),
+			OffloadAfter:       tierCfg.OffloadAfter,
+			Namespaces:         tierCfg.Namespaces,
+			OffloadConcurrency: tierCfg.OffloadConcurrency,
+		})
+		if err != nil {
+			logger.Fatal("could not create tiered storage", zap.Error(err))
+		}
+		fsopts = fsopts.SetTieredStorage(tier)
+	}
 
 	var commitLogQueueSize int
 	cfgCommitLog := cfg.CommitLogOrDefault()
@@ -731,6 +757,23 @@ func Run(runOpts RunOptions) {
 	}
 	opts = opts.SetPersistManager(pm)
 
+	if backupCfg := cfg.Filesystem.Backup; backupCfg != nil {
+		store, err := backupCfg.Store.NewStore()
+		if err != nil {
+			logger.Fatal("could not create backup object store", zap.Error(err))
+		}
+		backupMgr, err := fs.NewBackupManager(fs.BackupManagerOptions{
+			Store:             store,
+			FilesystemOptions: fsopts,
+			HostID:            hostID,
+			SnapshotTimeout:   backupCfg.SnapshotTimeout,
+		})
+		if err != nil {
+			logger.Fatal("could not create backup manager", zap.Error(err))
+		}
+		opts = opts.SetBackupManager(backupMgr)
+	}
+
 	// Set the index claims manager
 	icm, err := fs.NewIndexClaimsManager(fsopts)
 	if err != nil {
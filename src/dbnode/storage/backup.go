@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// backupSnapshotPollInterval is how often a backup checks whether the
+// snapshot covering its point in time has completed.
+const backupSnapshotPollInterval = time.Second
+
+var (
+	// errBackupNotConfigured raised when a backup or restore is requested
+	// without a backup manager.
+	errBackupNotConfigured = errors.New("backup is not configured")
+
+	// errRestoreNamespaceLoaded raised when restoring a namespace that is
+	// still loaded by the database.
+	errRestoreNamespaceLoaded = errors.New(
+		"namespace must be removed and the node restarted before it can be restored")
+)
+
+func (d *db) Backup(namespace ident.ID, backupID string) (fs.BackupManifest, error) {
+	mgr := d.opts.BackupManager()
+	if mgr == nil {
+		return fs.BackupManifest{}, errBackupNotConfigured
+	}
+
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return fs.BackupManifest{}, err
+	}
+
+	// Writes acknowledged before the point in time are either in the flushed
+	// filesets or in the commit log. Rather than copying the commit log, it
+	// is rotated and the backup waits for the next snapshot which then
+	// contains all the writes of the rotated commit log.
+	var (
+		pointInTime   = xtime.ToUnixNano(d.nowFn())
+		snapshotIndex = int64(-1)
+	)
+	if n.Options().SnapshotEnabled() {
+		snapshotIndex, err = d.awaitBackupSnapshot(mgr.SnapshotTimeout())
+		if err != nil {
+			return fs.BackupManifest{}, err
+		}
+	}
+
+	var shards []uint32
+	for _, shard := range n.OwnedShards() {
+		if shard.IsBootstrapped() {
+			shards = append(shards, shard.ID())
+		}
+	}
+
+	return mgr.Backup(fs.BackupSpec{
+		ID:            backupID,
+		Namespace:     n.ID(),
+		Shards:        shards,
+		PointInTime:   pointInTime,
+		SnapshotIndex: snapshotIndex,
+		IndexEnabled:  n.Options().IndexOptions().Enabled(),
+	})
+}
+
+// awaitBackupSnapshot rotates the commit log and waits for a snapshot that
+// started after the rotation, returning the index of that snapshot.
+func (d *db) awaitBackupSnapshot(timeout time.Duration) (int64, error) {
+	rotated, err := d.commitLog.RotateLogs()
+	if err != nil {
+		return 0, fmt.Errorf("could not rotate commit log: %w", err)
+	}
+
+	fsOpts := d.opts.CommitLogOptions().FilesystemOptions()
+	deadline := d.nowFn().Add(timeout)
+	for {
+		metadatas, _, err := fs.SortedSnapshotMetadataFiles(fsOpts)
+		if err != nil {
+			return 0, err
+		}
+		for _, metadata := range metadatas {
+			if metadata.CommitlogIdentifier.Index > rotated.Index {
+				return metadata.ID.Index, nil
+			}
+		}
+
+		if !d.nowFn().Before(deadline) {
+			return 0, fmt.Errorf("timed out after %v waiting for snapshot of commit log %d",
+				timeout, rotated.Index)
+		}
+		time.Sleep(backupSnapshotPollInterval)
+	}
+}
+
+func (d *db) Restore(
+	namespace ident.ID,
+	backupID string,
+	pointInTime xtime.UnixNano,
+) (fs.BackupManifest, error) {
+	mgr := d.opts.BackupManager()
+	if mgr == nil {
+		return fs.BackupManifest{}, errBackupNotConfigured
+	}
+
+	// NB: Hold the bootstrap mutex so that the namespace cannot be added
+	// and bootstrapped from partially restored files, and disable file ops
+	// so that cleanup does not race with the restore.
+	d.bootstrapMutex.Lock()
+	defer d.bootstrapMutex.Unlock()
+
+	if _, err := d.namespaceFor(namespace); err == nil {
+		return fs.BackupManifest{}, errRestoreNamespaceLoaded
+	}
+
+	d.disableFileOpsAndWait()
+	defer d.enableFileOps()
+
+	d.Lock()
+	d.restoredNamespaces[namespace.String()] = struct{}{}
+	d.Unlock()
+
+	manifest, err := mgr.Restore(namespace, backupID, pointInTime)
+	if err != nil {
+		// Let cleanup remove any partially restored files.
+		d.Lock()
+		delete(d.restoredNamespaces, namespace.String())
+		d.Unlock()
+		return fs.BackupManifest{}, err
+	}
+
+	d.log.Info("restored namespace from backup, add the namespace to bootstrap it",
+		zap.String("namespace", namespace.String()),
+		zap.String("backupID", manifest.ID),
+		zap.Time("pointInTime", manifest.PointInTime),
+		zap.Int("numFiles", len(manifest.Files)))
+	return manifest, nil
+}
+
+func (d *db) RestoredNamespaces() []ident.ID {
+	d.RLock()
+	defer d.RUnlock()
+
+	namespaces := make([]ident.ID, 0, len(d.restoredNamespaces))
+	for id := range d.restoredNamespaces {
+		namespaces = append(namespaces, ident.StringID(id))
+	}
+	return namespaces
+}
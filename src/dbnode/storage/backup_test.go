@@ -0,0 +1,102 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/objstore"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestDatabaseBackupNotConfigured(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	d, mapCh, _ := defaultTestDatabase(t, ctrl, Bootstrapped)
+	defer close(mapCh)
+
+	_, err := d.Backup(ident.StringID("testns1"), "backup0")
+	require.Equal(t, errBackupNotConfigured, err)
+
+	_, err = d.Restore(ident.StringID("testns1"), "backup0", 0)
+	require.Equal(t, errBackupNotConfigured, err)
+}
+
+func TestDatabaseRestore(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	store, err := objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+	mgr, err := fs.NewBackupManager(fs.BackupManagerOptions{
+		Store: store,
+		FilesystemOptions: fs.NewOptions().
+			SetFilePathPrefix(filepath.Join(dir, "data")),
+		HostID: "host0",
+	})
+	require.NoError(t, err)
+
+	d, mapCh, _ := newTestDatabase(t, ctrl, newTestDatabaseOpt{
+		bs:    Bootstrapped,
+		nsMap: testNamespaceMap(t),
+		dbOpt: DefaultTestOptions().SetBackupManager(mgr),
+	})
+	defer close(mapCh)
+
+	dbAddNewMockNamespace(ctrl, d, "testns1")
+
+	// Loaded namespaces cannot be restored.
+	_, err = d.Restore(ident.StringID("testns1"), "backup0", 0)
+	require.Equal(t, errRestoreNamespaceLoaded, err)
+
+	manifest, err := mgr.Backup(fs.BackupSpec{
+		ID:            "backup0",
+		Namespace:     ident.StringID("restored"),
+		PointInTime:   xtime.Now(),
+		SnapshotIndex: -1,
+	})
+	require.NoError(t, err)
+
+	restored, err := d.Restore(ident.StringID("restored"), "", 0)
+	require.NoError(t, err)
+	require.Equal(t, manifest.ID, restored.ID)
+
+	_, err = d.Restore(ident.StringID("missing"), "backup0", 0)
+	require.Error(t, err)
+
+	// Only the restored namespace is kept from cleanup until added back.
+	namespaces := d.RestoredNamespaces()
+	require.Equal(t, 1, len(namespaces))
+	require.Equal(t, "restored", namespaces[0].String())
+}
@@ -190,7 +190,8 @@ func (m *Migrator) findMigrationCandidates() []migrationCandidate {
 	for md, resultsByShard := range m.infoFilesByNamespace {
 		for shard, results := range resultsByShard {
 			for _, info := range results {
-				newTaskFn, shouldMigrate := m.migrationTaskFn(info)
+				newTaskFn, shouldMigrate := m.migrationTaskFn(info, md,
+					m.migrationOpts.TargetMigrationVersion())
 				if shouldMigrate {
 					candidates = append(candidates, migrationCandidate{
 						newTaskFn:      newTaskFn,
@@ -63,7 +63,11 @@ func TestMigratorRun(t *testing.T) {
 	}
 
 	opts = opts.
-		SetMigrationTaskFn(func(result fs.ReadInfoFileResult) (migration.NewTaskFn, bool) {
+		SetMigrationTaskFn(func(
+			result fs.ReadInfoFileResult,
+			_ namespace.Metadata,
+			_ migration.MigrationVersion,
+		) (migration.NewTaskFn, bool) {
 			return newTestTask, result.Info.VolumeIndex == 0
 		}).
 		SetInfoFilesByNamespace(infoFilesByNamespace).
@@ -21,6 +21,7 @@
 package migrator
 
 import (
+	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/persist/fs/migration"
 	"github.com/m3db/m3/src/dbnode/storage"
@@ -28,8 +29,13 @@ import (
 	"github.com/m3db/m3/src/x/instrument"
 )
 
-// MigrationTaskFn returns a fileset migration function and a boolean indicating if migration is necessary.
-type MigrationTaskFn func(result fs.ReadInfoFileResult) (migration.NewTaskFn, bool)
+// MigrationTaskFn returns a fileset migration function and a boolean indicating if migration to the
+// target version is necessary.
+type MigrationTaskFn func(
+	result fs.ReadInfoFileResult,
+	md namespace.Metadata,
+	target migration.MigrationVersion,
+) (migration.NewTaskFn, bool)
 
 // Options represents the options for the migrator.
 type Options interface {
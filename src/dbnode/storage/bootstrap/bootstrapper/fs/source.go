@@ -210,8 +210,8 @@ func (s *fileSystemSource) Read(
 }
 
 func (s *fileSystemSource) runMigrations(ctx context.Context, infoFilesByNamespace bootstrap.InfoFilesByNamespace) {
-	// Only one migration for now, so just short circuit entirely if not enabled
-	if s.opts.MigrationOptions().TargetMigrationVersion() != migration.MigrationVersion_1_1 {
+	// Short circuit entirely if not enabled
+	if s.opts.MigrationOptions().TargetMigrationVersion() == migration.MigrationVersionNone {
 		return
 	}
 
@@ -242,6 +242,9 @@ func (m *cleanupManager) deleteInactiveNamespaceFiles(namespaces []databaseNames
 	for _, n := range namespaces {
 		namespaceDirNames = append(namespaceDirNames, n.ID().String())
 	}
+	for _, id := range m.database.RestoredNamespaces() {
+		namespaceDirNames = append(namespaceDirNames, id.String())
+	}
 
 	return m.deleteInactiveDirectoriesFn(dataDirPath, namespaceDirNames)
 }
@@ -537,6 +537,28 @@ func TestDeleteInactiveDataAndSnapshotFileSetFiles(t *testing.T) {
 	}
 }
 
+func TestCleanupManagerKeepsRestoredNamespaces(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().ID().Return(ident.StringID("nsID")).AnyTimes()
+
+	db := NewMockdatabase(ctrl)
+	db.EXPECT().Options().Return(DefaultTestOptions()).AnyTimes()
+	db.EXPECT().RestoredNamespaces().Return([]ident.ID{ident.StringID("restored")})
+	mgr := newCleanupManager(db, newNoopFakeActiveLogs(), tally.NoopScope).(*cleanupManager)
+
+	var activeDirNames []string
+	mgr.deleteInactiveDirectoriesFn = func(_ string, names []string) error {
+		activeDirNames = names
+		return nil
+	}
+
+	require.NoError(t, mgr.deleteInactiveNamespaceFiles([]databaseNamespace{ns}))
+	require.Equal(t, []string{"nsID", "restored"}, activeDirNames)
+}
+
 func TestCleanupManagerPropagatesOwnedNamespacesError(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
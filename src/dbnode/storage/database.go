@@ -106,6 +106,10 @@ type db struct {
 	namespaces             *databaseNamespacesMap
 	runtimeOptionsRegistry namespace.RuntimeOptionsManagerRegistry
 
+	// restoredNamespaces are the namespaces restored from a backup that
+	// have not yet been added back to the database.
+	restoredNamespaces map[string]struct{}
+
 	commitLog commitlog.CommitLog
 
 	state    databaseState
@@ -192,6 +196,7 @@ func NewDatabase(
 		lastReceivedNewShards:  nowFn(),
 		namespaces:             newDatabaseNamespacesMap(databaseNamespacesMapOptions{}),
 		runtimeOptionsRegistry: opts.NamespaceRuntimeOptionsManagerRegistry(),
+		restoredNamespaces:     make(map[string]struct{}),
 		commitLog:              commitLog,
 		scope:                  scope,
 		metrics:                newDatabaseMetrics(scope),
@@ -255,6 +260,13 @@ func NewDatabase(
 		}
 	}
 
+	if opts.CommitLogOptions().FilesystemOptions().TieredStorage() != nil {
+		err = d.mediator.RegisterBackgroundProcess(newDatabaseOffloader(d, opts))
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	for _, fn := range opts.BackgroundProcessFns() {
 		process, err := fn(d, opts)
 		if err != nil {
@@ -475,6 +487,7 @@ func (d *db) addNamespacesWithLock(namespaces []namespace.Metadata) error {
 			return err
 		}
 		d.namespaces.Set(n.ID(), newNs)
+		delete(d.restoredNamespaces, n.ID().String())
 		createdNamespaces = append(createdNamespaces, newNs)
 	}
 
@@ -1220,6 +1233,19 @@ func (d *db) Truncate(namespace ident.ID) (int64, error) {
 	return n.Truncate()
 }
 
+func (d *db) DeleteSeries(
+	ctx context.Context,
+	namespace ident.ID,
+	query index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	n, err := d.namespaceFor(namespace)
+	if err != nil {
+		return 0, err
+	}
+	return n.DeleteSeries(ctx, query, start, end)
+}
+
 func (d *db) IsOverloaded() bool {
 	queueSize := float64(d.commitLog.QueueLength())
 	queueCapacity := float64(d.opts.CommitLogOptions().BacklogQueueSize())
@@ -149,6 +149,7 @@ func testRepairOptions(ctrl *gomock.Controller) repair.Options {
 func newMockdatabase(ctrl *gomock.Controller, ns ...databaseNamespace) *Mockdatabase {
 	db := NewMockdatabase(ctrl)
 	db.EXPECT().Options().Return(DefaultTestOptions()).AnyTimes()
+	db.EXPECT().RestoredNamespaces().Return(nil).AnyTimes()
 	if len(ns) != 0 {
 		db.EXPECT().OwnedNamespaces().Return(ns, nil).AnyTimes()
 	}
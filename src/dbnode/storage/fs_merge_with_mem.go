@@ -42,6 +42,7 @@ type fsMergeWithMem struct {
 	retriever          series.QueryableBlockRetriever
 	dirtySeries        *dirtySeriesMap
 	dirtySeriesToWrite map[xtime.UnixNano]*idList
+	tombstones         *fs.Tombstones
 	reusableID         *ident.ReusableBytesID
 }
 
@@ -50,12 +51,14 @@ func newFSMergeWithMem(
 	retriever series.QueryableBlockRetriever,
 	dirtySeries *dirtySeriesMap,
 	dirtySeriesToWrite map[xtime.UnixNano]*idList,
+	tombstones *fs.Tombstones,
 ) fs.MergeWith {
 	return &fsMergeWithMem{
 		shard:              shard,
 		retriever:          retriever,
 		dirtySeries:        dirtySeries,
 		dirtySeriesToWrite: dirtySeriesToWrite,
+		tombstones:         tombstones,
 		reusableID:         ident.NewReusableBytesID(),
 	}
 }
@@ -143,3 +146,7 @@ func (m *fsMergeWithMem) ForEachRemaining(
 
 	return nil
 }
+
+func (m *fsMergeWithMem) Tombstones(seriesID ident.ID) []xtime.Range {
+	return m.tombstones.Ranges(seriesID)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/x/xio"
@@ -81,7 +82,7 @@ func TestRead(t *testing.T) {
 			Return(result, nil)
 	}
 
-	mergeWith := newFSMergeWithMem(shard, retriever, dirtySeries, dirtySeriesToWrite)
+	mergeWith := newFSMergeWithMem(shard, retriever, dirtySeries, dirtySeriesToWrite, nil)
 
 	for _, d := range data {
 		require.True(t, dirtySeries.Contains(idAndBlockStart{
@@ -170,7 +171,7 @@ func TestForEachRemaining(t *testing.T) {
 		addDirtySeries(dirtySeries, dirtySeriesToWrite, d.id, d.start)
 	}
 
-	mergeWith := newFSMergeWithMem(shard, retriever, dirtySeries, dirtySeriesToWrite)
+	mergeWith := newFSMergeWithMem(shard, retriever, dirtySeries, dirtySeriesToWrite, nil)
 
 	var forEachCalls []doc.Metadata
 	shard.EXPECT().
@@ -232,6 +233,27 @@ func TestForEachRemaining(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestTombstones(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(t.TempDir())
+	tombstones, err := fs.NewTombstones(fsOpts, ident.StringID("ns"))
+	require.NoError(t, err)
+
+	deleted := xtime.Range{Start: 10, End: 20}
+	require.NoError(t, tombstones.Add([]fs.Tombstone{
+		{ID: ident.StringID("deleted"), Range: deleted},
+	}))
+
+	mergeWith := newFSMergeWithMem(NewMockdatabaseShard(ctrl),
+		series.NewMockQueryableBlockRetriever(ctrl), newDirtySeriesMap(),
+		make(map[xtime.UnixNano]*idList), tombstones)
+
+	assert.Equal(t, []xtime.Range{deleted}, mergeWith.Tombstones(ident.StringID("deleted")))
+	assert.Empty(t, mergeWith.Tombstones(ident.StringID("other")))
+}
+
 func addDirtySeries(
 	dirtySeries *dirtySeriesMap,
 	dirtySeriesToWrite map[xtime.UnixNano]*idList,
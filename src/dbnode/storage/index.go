@@ -137,6 +137,10 @@ type nsIndex struct {
 
 	doNotIndexWithFields []doc.Field
 
+	// tombstones hide deleted series from queries, since their documents
+	// remain in the index until they expire.
+	tombstones *fs.Tombstones
+
 	activeBlock index.Block
 }
 
@@ -207,6 +211,7 @@ type newNamespaceIndexOpts struct {
 	opts                    Options
 	newIndexQueueFn         newNamespaceIndexInsertQueueFn
 	newBlockFn              index.NewBlockFn
+	tombstones              *fs.Tombstones
 }
 
 // execBlockQueryFn executes a query against the given block whilst tracking state.
@@ -273,6 +278,26 @@ func newNamespaceIndex(
 	})
 }
 
+// newNamespaceIndexWithTombstones returns a new namespaceIndex hiding the
+// series deleted by the given tombstones from queries.
+func newNamespaceIndexWithTombstones(
+	nsMD namespace.Metadata,
+	namespaceRuntimeOptsMgr namespace.RuntimeOptionsManager,
+	shardSet sharding.ShardSet,
+	tombstones *fs.Tombstones,
+	opts Options,
+) (NamespaceIndex, error) {
+	return newNamespaceIndexWithOptions(newNamespaceIndexOpts{
+		md:                      nsMD,
+		namespaceRuntimeOptsMgr: namespaceRuntimeOptsMgr,
+		shardSet:                shardSet,
+		opts:                    opts,
+		newIndexQueueFn:         newNamespaceIndexInsertQueue,
+		newBlockFn:              index.NewBlock,
+		tombstones:              tombstones,
+	})
+}
+
 // newNamespaceIndexWithInsertQueueFn is a ctor used in tests to override the insert queue.
 func newNamespaceIndexWithInsertQueueFn(
 	nsMD namespace.Metadata,
@@ -383,6 +408,7 @@ func newNamespaceIndexWithOptions(
 		metrics:        newNamespaceIndexMetrics(indexOpts, instrumentOpts),
 
 		doNotIndexWithFields: doNotIndexWithFields,
+		tombstones:           newIndexOpts.tombstones,
 	}
 
 	activeBlock, err := idx.newBlockFn(xtime.UnixNano(0), idx.nsMetadata,
@@ -1504,7 +1530,10 @@ func (i *nsIndex) Query(
 	results := i.resultsPool.Get()
 	results.Reset(i.nsMetadata.ID(), index.QueryResultsOptions{
 		SizeLimit: opts.SeriesLimit,
-		FilterID:  i.shardsFilterID(),
+		// NB: drop series outside of the requested series shard before they
+		// count towards the limits or their data is read.
+		FilterID: i.filterDeletedID(opts,
+			opts.SeriesShard.FilterID(i.shardsFilterID())),
 	})
 	ctx.RegisterFinalizer(results)
 	queryRes, err := i.query(ctx, query, results, opts, i.execBlockQueryFn,
@@ -1566,6 +1595,10 @@ func (i *nsIndex) AggregateQuery(
 			aopts.RestrictByQuery = &query
 		}
 	}
+	if restrict, ok := i.restrictDeleted(query, opts.QueryOptions); ok {
+		// NB: only return the terms of series that have not been deleted.
+		aopts.RestrictByQuery = &restrict
+	}
 	aopts.FieldFilter = aopts.FieldFilter.SortAndDedupe()
 	results.Reset(id, aopts)
 	queryRes, err := i.query(ctx, query, results, opts.QueryOptions, fn,
@@ -1580,6 +1613,48 @@ func (i *nsIndex) AggregateQuery(
 	}, nil
 }
 
+// filterDeletedID returns a filter of the IDs that match the given filter, if
+// any, and have not been deleted within the time range of the query.
+func (i *nsIndex) filterDeletedID(
+	opts index.QueryOptions,
+	filter func(ident.ID) bool,
+) func(ident.ID) bool {
+	if i.tombstones.Len() == 0 {
+		return filter
+	}
+	queryRange := xtime.Range{Start: opts.StartInclusive, End: opts.EndExclusive}
+	return func(id ident.ID) bool {
+		if filter != nil && !filter(id) {
+			return false
+		}
+		return !i.tombstones.Hides(id, queryRange)
+	}
+}
+
+// restrictDeleted returns the query restricted to the series that have not
+// been deleted within the time range of the query, if any have been.
+func (i *nsIndex) restrictDeleted(
+	query index.Query,
+	opts index.QueryOptions,
+) (index.Query, bool) {
+	deleted := i.tombstones.HiddenIDs(xtime.Range{
+		Start: opts.StartInclusive,
+		End:   opts.EndExclusive,
+	})
+	if len(deleted) == 0 {
+		return index.Query{}, false
+	}
+
+	terms := make([]idx.Query, 0, len(deleted))
+	for _, id := range deleted {
+		terms = append(terms, idx.NewTermQuery(doc.IDReservedFieldName, id.Bytes()))
+	}
+	return index.Query{
+		Query: idx.NewConjunctionQuery(query.Query,
+			idx.NewNegationQuery(idx.NewDisjunctionQuery(terms...))),
+	}, true
+}
+
 type queryResult struct {
 	exhaustive bool
 	waited     int
@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"github.com/cespare/xxhash/v2"
+
+	"github.com/m3db/m3/src/x/ident"
+)
+
+// SeriesShard selects a subset of series by hashing their IDs, a query split
+// into every shard of a count matches each series exactly once.
+type SeriesShard struct {
+	// Index is the selected shard, in the range [0, Count).
+	Index int
+	// Count is the total number of shards.
+	Count int
+}
+
+// Contains returns true if the series with the given ID belongs to the shard.
+func (s *SeriesShard) Contains(id []byte) bool {
+	if s == nil || s.Count <= 1 {
+		return true
+	}
+	return int(xxhash.Sum64(id)%uint64(s.Count)) == s.Index
+}
+
+// FilterID returns a filter of the IDs in the shard that also match the
+// given filter, if any.
+func (s *SeriesShard) FilterID(filter func(ident.ID) bool) func(ident.ID) bool {
+	if s == nil || s.Count <= 1 {
+		return filter
+	}
+	return func(id ident.ID) bool {
+		if filter != nil && !filter(id) {
+			return false
+		}
+		return s.Contains(id.Bytes())
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package index
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/x/ident"
+)
+
+func TestSeriesShardContains(t *testing.T) {
+	var nilShard *SeriesShard
+	assert.True(t, nilShard.Contains([]byte("foo")))
+	assert.True(t, (&SeriesShard{Index: 0, Count: 1}).Contains([]byte("foo")))
+
+	// Every series belongs to exactly one shard.
+	shards := make([]SeriesShard, 4)
+	for i := range shards {
+		shards[i] = SeriesShard{Index: i, Count: len(shards)}
+	}
+	counts := make([]int, len(shards))
+	for i := 0; i < 1000; i++ {
+		id := []byte(fmt.Sprintf("series_%d", i))
+		matched := 0
+		for j := range shards {
+			if shards[j].Contains(id) {
+				matched++
+				counts[j]++
+			}
+		}
+		require.Equal(t, 1, matched)
+	}
+	for _, count := range counts {
+		assert.InDelta(t, 250, count, 75)
+	}
+}
+
+func TestSeriesShardFilterID(t *testing.T) {
+	var (
+		nilShard *SeriesShard
+		shard    = &SeriesShard{Index: 1, Count: 2}
+		owned    = func(id ident.ID) bool { return id.String() != "unowned" }
+	)
+	assert.Nil(t, nilShard.FilterID(nil))
+
+	filter := shard.FilterID(owned)
+	for i := 0; i < 100; i++ {
+		id := ident.StringID(fmt.Sprintf("series_%d", i))
+		assert.Equal(t, shard.Contains(id.Bytes()), filter(id))
+	}
+	assert.False(t, filter(ident.StringID("unowned")))
+	assert.True(t, shard.FilterID(nil)(ident.StringID("series_1")) ==
+		shard.Contains([]byte("series_1")))
+}
@@ -94,6 +94,9 @@ type QueryOptions struct {
 	IterateEqualTimestampStrategy *encoding.IterateEqualTimestampStrategy
 	// Source is an optional query source.
 	Source []byte
+	// SeriesShard optionally restricts the results to the series whose ID
+	// hashes to the shard, so that a query can be split across series.
+	SeriesShard *SeriesShard
 }
 
 // IterationOptions enables users to specify iteration preferences.
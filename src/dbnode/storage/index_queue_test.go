@@ -33,6 +33,7 @@ import (
 	"github.com/uber-go/tally"
 
 	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
 	m3dberrors "github.com/m3db/m3/src/dbnode/storage/errors"
 	"github.com/m3db/m3/src/dbnode/storage/index"
 	idxconvert "github.com/m3db/m3/src/dbnode/storage/index/convert"
@@ -403,6 +404,74 @@ func TestNamespaceIndexInsertQuery(t *testing.T) {
 		tags))
 }
 
+func TestNamespaceIndexInsertQuerySeriesShard(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	now := xtime.Now()
+	idx := setupIndex(t, ctrl, now, false)
+	defer idx.Close()
+
+	// Series outside of the requested series shard are not returned.
+	shard := &index.SeriesShard{Index: 0, Count: 2}
+	if shard.Contains([]byte("foo")) {
+		shard.Index = 1
+	}
+	reQuery, err := m3ninxidx.NewRegexpQuery([]byte("name"), []byte("val.*"))
+	require.NoError(t, err)
+	res, err := idx.Query(ctx, index.Query{Query: reQuery}, index.QueryOptions{
+		StartInclusive: now.Add(-1 * time.Minute),
+		EndExclusive:   now.Add(1 * time.Minute),
+		SeriesShard:    shard,
+	})
+	require.NoError(t, err)
+	assert.True(t, res.Exhaustive)
+	assert.Equal(t, 0, res.Results.Size())
+}
+
+func TestNamespaceIndexInsertQueryDeletedSeries(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	now := xtime.Now()
+	idx := setupIndex(t, ctrl, now, false)
+	defer idx.Close()
+
+	tombstones, err := fs.NewTombstones(fs.NewOptions().SetFilePathPrefix(t.TempDir()),
+		defaultTestNs1ID)
+	require.NoError(t, err)
+	require.NoError(t, tombstones.Add([]fs.Tombstone{{
+		ID:    ident.StringID("foo"),
+		Range: xtime.Range{Start: now.Add(-time.Hour), End: now.Add(time.Hour)},
+	}}))
+	idx.(*nsIndex).tombstones = tombstones
+
+	reQuery, err := m3ninxidx.NewRegexpQuery([]byte("name"), []byte("val.*"))
+	require.NoError(t, err)
+	opts := index.QueryOptions{
+		StartInclusive: now.Add(-1 * time.Minute),
+		EndExclusive:   now.Add(1 * time.Minute),
+	}
+
+	// Deleted series are neither returned by queries nor by aggregations.
+	res, err := idx.Query(ctx, index.Query{Query: reQuery}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Results.Size())
+
+	for _, query := range []m3ninxidx.Query{reQuery, m3ninxidx.NewAllQuery()} {
+		aggRes, err := idx.AggregateQuery(ctx, index.Query{Query: query},
+			index.AggregationOptions{QueryOptions: opts})
+		require.NoError(t, err)
+		assert.Equal(t, 0, aggRes.Results.Map().Len())
+	}
+}
+
 func TestNamespaceIndexInsertAggregateQuery(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
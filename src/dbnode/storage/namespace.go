@@ -36,6 +36,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/dbnode/persist"
 	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
 	"github.com/m3db/m3/src/dbnode/sharding"
 	"github.com/m3db/m3/src/dbnode/storage/block"
 	"github.com/m3db/m3/src/dbnode/storage/bootstrap"
@@ -138,6 +139,7 @@ type dbNamespace struct {
 	increasingIndex increasingIndex
 	commitLogWriter commitLogWriter
 	reverseIndex    NamespaceIndex
+	tombstones      *fs.Tombstones
 
 	createEmptyWarmIndexIfNotExistsFn createEmptyWarmIndexIfNotExistsFn
 
@@ -174,6 +176,7 @@ type databaseNamespaceMetrics struct {
 	fetchBlocksMetadata instrument.MethodMetrics
 	queryIDs            instrument.MethodMetrics
 	aggregateQuery      instrument.MethodMetrics
+	deleteSeries        instrument.MethodMetrics
 
 	unfulfilled             tally.Counter
 	bootstrapStart          tally.Counter
@@ -263,6 +266,7 @@ func newDatabaseNamespaceMetrics(
 		fetchBlocksMetadata: instrument.NewMethodMetrics(scope, "fetchBlocksMetadata", opts),
 		queryIDs:            instrument.NewMethodMetrics(scope, "queryIDs", opts),
 		aggregateQuery:      instrument.NewMethodMetrics(scope, "aggregateQuery", opts),
+		deleteSeries:        instrument.NewMethodMetrics(scope, "deleteSeries", opts),
 
 		unfulfilled:             bootstrapScope.Counter("unfulfilled"),
 		bootstrapStart:          bootstrapScope.Counter("start"),
@@ -354,13 +358,17 @@ func newDatabaseNamespace(
 			metadata.ID().String(), err)
 	}
 
-	var (
-		index NamespaceIndex
-		err   error
-	)
+	tombstones, err := fs.NewTombstones(opts.CommitLogOptions().FilesystemOptions(), id)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"unable to create namespace %v, could not load tombstones: %w",
+			metadata.ID().String(), err)
+	}
+
+	var index NamespaceIndex
 	if metadata.Options().IndexOptions().Enabled() {
-		index, err = newNamespaceIndex(metadata, namespaceRuntimeOptsMgr,
-			shardSet, opts)
+		index, err = newNamespaceIndexWithTombstones(metadata,
+			namespaceRuntimeOptsMgr, shardSet, tombstones, opts)
 		if err != nil {
 			return nil, err
 		}
@@ -382,6 +390,7 @@ func newDatabaseNamespace(
 		increasingIndex:        increasingIndex,
 		commitLogWriter:        commitLogWriter,
 		reverseIndex:           index,
+		tombstones:             tombstones,
 		tickWorkers:            tickWorkers,
 		tickWorkersConcurrency: tickWorkersConcurrency,
 		metrics:                newDatabaseNamespaceMetrics(scope, iops.TimerOptions()),
@@ -543,8 +552,11 @@ func (n *dbNamespace) assignShardSet(
 		// Otherwise it's the initial assignment or there isn't an existing
 		// shard created for this shard ID.
 		n.shards[shard] = newDatabaseShard(metadata, shard, n.blockRetriever,
-			n.namespaceReaderMgr, n.increasingIndex, n.reverseIndex,
+			n.namespaceReaderMgr, n.increasingIndex, n.reverseIndex, n.tombstones,
 			opts.needsBootstrap, n.opts, n.seriesOpts)
+		// Make sure data deleted before a restart is dropped from disk by the
+		// next cold flush since tombstoned blocks are only tracked in memory.
+		n.shards[shard].MarkTombstonedBlocks(n.tombstonedBlockStartsWithLock(shard))
 		createdShardIds = append(createdShardIds, shard)
 		// NB(bodu): We only record shard add metrics for shards created in non
 		// initial assignments.
@@ -651,6 +663,12 @@ func (n *dbNamespace) Tick(c context.Cancellable, startTime xtime.UnixNano) erro
 
 	wg.Wait()
 
+	// Drop tombstones of data that has been expired by retention.
+	earliestToRetain := retention.FlushTimeStart(n.nopts.RetentionOptions(), startTime)
+	if err := n.tombstones.Expire(earliestToRetain); err != nil {
+		multiErr = multiErr.Add(err)
+	}
+
 	// Tick namespaceIndex if it exists.
 	var (
 		indexTickResults namespaceIndexTickResult
@@ -1243,9 +1261,10 @@ func (n *dbNamespace) ColdFlush(flushPersist persist.FlushPreparer) error {
 	repairsAny := n.repairsAny
 	n.RUnlock()
 
-	// If repair has run we still need cold flush regardless of whether cold writes is
-	// enabled since repairs are dependent on the cold flushing logic.
-	enabled := n.nopts.ColdWritesEnabled() || repairsAny
+	// If repair has run or series have been deleted we still need cold flush regardless
+	// of whether cold writes is enabled since repairs and physically dropping deleted
+	// data are dependent on the cold flushing logic.
+	enabled := n.nopts.ColdWritesEnabled() || repairsAny || n.tombstones.Len() > 0
 	if n.ReadOnly() || !enabled {
 		n.metrics.flushColdData.ReportSuccess(n.nowFn().Sub(callStart))
 		return nil
@@ -1471,6 +1490,117 @@ func (n *dbNamespace) Truncate() (int64, error) {
 	return totalNumSeries, nil
 }
 
+func (n *dbNamespace) DeleteSeries(
+	ctx context.Context,
+	query index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	callStart := n.nowFn()
+	if n.ReadOnly() {
+		n.metrics.deleteSeries.ReportError(n.nowFn().Sub(callStart))
+		return 0, errNamespaceReadOnly
+	}
+
+	result, err := n.QueryIDs(ctx, query, index.QueryOptions{
+		StartInclusive:    start,
+		EndExclusive:      end,
+		RequireExhaustive: true,
+	})
+	if err != nil {
+		n.metrics.deleteSeries.ReportError(n.nowFn().Sub(callStart))
+		return 0, err
+	}
+
+	n.RLock()
+	shardSet := n.shardSet
+	n.RUnlock()
+
+	var (
+		deleted = xtime.Range{Start: start, End: end}
+		// NB: a deletion reaching the times still written to also hides the
+		// series from the index queries after it until it is written again.
+		bufferPast = n.nopts.RetentionOptions().BufferPast()
+		open       = end.After(xtime.ToUnixNano(callStart).Add(-bufferPast))
+		tombstones = make([]fs.Tombstone, 0, result.Results.Map().Len())
+		byShard    = make(map[uint32]struct{})
+	)
+	for _, entry := range result.Results.Map().Iter() {
+		id := ident.BytesID(append([]byte(nil), entry.Key()...))
+		tombstones = append(tombstones, fs.Tombstone{ID: id, Range: deleted, Open: open})
+		byShard[shardSet.Lookup(id)] = struct{}{}
+	}
+
+	// Persist the tombstones before acknowledging the delete so that the
+	// deleted data stays hidden even if the node restarts.
+	if err := n.tombstones.Add(tombstones); err != nil {
+		n.metrics.deleteSeries.ReportError(n.nowFn().Sub(callStart))
+		return 0, err
+	}
+
+	blockStarts := n.blockStartsInRange(deleted)
+	n.RLock()
+	for shardID := range byShard {
+		shard, _, err := n.shardAtWithRLock(shardID)
+		if err != nil {
+			// The shard is no longer owned by this node, the data will be
+			// dropped when the shard is cleaned up.
+			continue
+		}
+		shard.MarkTombstonedBlocks(blockStarts)
+	}
+	n.RUnlock()
+
+	n.metrics.deleteSeries.ReportSuccess(n.nowFn().Sub(callStart))
+	return int64(len(tombstones)), nil
+}
+
+// tombstonedBlockStartsWithLock returns the block starts within retention
+// that contain deleted data of series that belong to the given shard.
+func (n *dbNamespace) tombstonedBlockStartsWithLock(shard uint32) []xtime.UnixNano {
+	if n.tombstones.Len() == 0 {
+		return nil
+	}
+
+	var (
+		deleted     = xtime.NewRanges()
+		blockStarts []xtime.UnixNano
+	)
+	n.tombstones.ForEach(func(id ident.ID, ranges []xtime.Range) {
+		if n.shardSet.Lookup(id) != shard {
+			return
+		}
+		for _, r := range ranges {
+			deleted.AddRange(r)
+		}
+	})
+	for it := deleted.Iter(); it.Next(); {
+		blockStarts = append(blockStarts, n.blockStartsInRange(it.Value())...)
+	}
+	return blockStarts
+}
+
+// blockStartsInRange returns the block starts within retention that
+// overlap the given time range, including blocks that have not been warm
+// flushed yet since warm flushes persist deleted data as is.
+func (n *dbNamespace) blockStartsInRange(r xtime.Range) []xtime.UnixNano {
+	var (
+		ropts       = n.nopts.RetentionOptions()
+		blockSize   = ropts.BlockSize()
+		now         = xtime.ToUnixNano(n.nowFn())
+		earliest    = retention.FlushTimeStart(ropts, now)
+		latest      = now.Add(ropts.BufferFuture()).Truncate(blockSize)
+		blockStarts []xtime.UnixNano
+	)
+	start := r.Start.Truncate(blockSize)
+	if start.Before(earliest) {
+		start = earliest
+	}
+	for t := start; t.Before(r.End) && !t.After(latest); t = t.Add(blockSize) {
+		blockStarts = append(blockStarts, t)
+	}
+	return blockStarts
+}
+
 func (n *dbNamespace) Repair(
 	repairer databaseShardRepairer,
 	tr xtime.Range,
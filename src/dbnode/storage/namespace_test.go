@@ -51,6 +51,7 @@ import (
 	"github.com/m3db/m3/src/dbnode/storage/series"
 	"github.com/m3db/m3/src/dbnode/tracepoint"
 	xmetrics "github.com/m3db/m3/src/dbnode/x/metrics"
+	"github.com/m3db/m3/src/m3ninx/doc"
 	xidx "github.com/m3db/m3/src/m3ninx/idx"
 	idxpersist "github.com/m3db/m3/src/m3ninx/persist"
 	"github.com/m3db/m3/src/x/context"
@@ -1471,6 +1472,70 @@ func TestNamespaceIndexQuery(t *testing.T) {
 	assert.Equal(t, "root", spans[1].OperationName)
 }
 
+func TestNamespaceDeleteSeries(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	idx := NewMockNamespaceIndex(ctrl)
+	idx.EXPECT().Bootstrapped().Return(true)
+
+	ns, closer := newTestNamespaceWithIndex(t, idx)
+	defer closer()
+
+	fsOpts := fs.NewOptions().SetFilePathPrefix(t.TempDir())
+	tombstones, err := fs.NewTombstones(fsOpts, ns.ID())
+	require.NoError(t, err)
+	ns.tombstones = tombstones
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	var (
+		now   = xtime.ToUnixNano(ns.nowFn())
+		start = now.Add(-time.Hour)
+		end   = now
+		query = index.Query{
+			Query: xidx.NewTermQuery([]byte("foo"), []byte("bar")),
+		}
+		opts = index.QueryOptions{
+			StartInclusive:    start,
+			EndExclusive:      end,
+			RequireExhaustive: true,
+		}
+	)
+
+	results := index.NewQueryResults(ns.ID(), index.QueryResultsOptions{},
+		DefaultTestOptions().IndexOptions())
+	_, _, err = results.AddDocuments([]doc.Document{
+		doc.NewDocumentFromMetadata(doc.Metadata{ID: []byte("a")}),
+		doc.NewDocumentFromMetadata(doc.Metadata{ID: []byte("b")}),
+	})
+	require.NoError(t, err)
+	idx.EXPECT().Query(gomock.Any(), query, opts).
+		Return(index.QueryResult{Results: results, Exhaustive: true}, nil)
+
+	numSeries, err := ns.DeleteSeries(ctx, query, start, end)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), numSeries)
+
+	deleted := []xtime.Range{{Start: start, End: end}}
+	assert.Equal(t, deleted, tombstones.Ranges(ident.StringID("a")))
+	assert.Equal(t, deleted, tombstones.Ranges(ident.StringID("b")))
+
+	// Deleting up to now also hides the series from later index queries.
+	assert.True(t, tombstones.Hides(ident.StringID("a"),
+		xtime.Range{Start: start, End: end.Add(time.Hour)}))
+
+	// Deleted data must be dropped from disk by a cold flush.
+	shard := ns.shards[testShardIDs[0].ID()].(*dbShard)
+	shard.RLock()
+	assert.NotEmpty(t, shard.tombstonedBlocks)
+	shard.RUnlock()
+
+	idx.EXPECT().Close().Return(nil)
+	require.NoError(t, ns.Close())
+}
+
 func TestNamespaceAggregateQuery(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
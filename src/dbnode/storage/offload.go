@@ -0,0 +1,212 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xsync "github.com/m3db/m3/src/x/sync"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const offloadCheckInterval = time.Minute
+
+var errOffloadInProgress = errors.New("offload already in progress")
+
+type offloaderMetrics struct {
+	status   tally.Gauge
+	duration tally.Timer
+}
+
+func newOffloaderMetrics(scope tally.Scope) offloaderMetrics {
+	return offloaderMetrics{
+		status:   scope.Gauge("offload"),
+		duration: scope.Timer("data-offload-duration"),
+	}
+}
+
+// dbOffloader offloads the data filesets of sealed blocks to tiered storage
+// in the background, so that uploads never hold up flushes.
+type dbOffloader struct {
+	database       database
+	tier           fs.TieredStorage
+	filePathPrefix string
+
+	sleepFn  sleepFn
+	nowFn    clock.NowFn
+	logger   *zap.Logger
+	interval time.Duration
+	metrics  offloaderMetrics
+
+	closedLock sync.Mutex
+	running    int32
+	closed     bool
+}
+
+func newDatabaseOffloader(database database, opts Options) *dbOffloader {
+	fsOpts := opts.CommitLogOptions().FilesystemOptions()
+	return &dbOffloader{
+		database:       database,
+		tier:           fsOpts.TieredStorage(),
+		filePathPrefix: fsOpts.FilePathPrefix(),
+		sleepFn:        time.Sleep,
+		nowFn:          opts.ClockOptions().NowFn(),
+		logger:         opts.InstrumentOptions().Logger(),
+		interval:       offloadCheckInterval,
+		metrics: newOffloaderMetrics(
+			opts.InstrumentOptions().MetricsScope().SubScope("offload")),
+	}
+}
+
+func (o *dbOffloader) run() {
+	for {
+		o.closedLock.Lock()
+		closed := o.closed
+		o.closedLock.Unlock()
+
+		if closed {
+			break
+		}
+
+		o.sleepFn(o.interval)
+
+		if err := o.Offload(); err != nil {
+			o.logger.Error("error offloading filesets", zap.Error(err))
+		}
+	}
+}
+
+func (o *dbOffloader) Start() {
+	go o.run()
+}
+
+func (o *dbOffloader) Stop() {
+	o.closedLock.Lock()
+	o.closed = true
+	o.closedLock.Unlock()
+}
+
+// Offload offloads the data filesets of blocks that ended more than the
+// offload age ago to tiered storage, and purges the offloaded copies of
+// filesets that have since been deleted locally.
+func (o *dbOffloader) Offload() error {
+	// Don't offload before bootstrapping, filesets may still be flushed
+	// for blocks that are past the offload age.
+	if !o.database.IsBootstrapped() {
+		return nil
+	}
+
+	if !atomic.CompareAndSwapInt32(&o.running, 0, 1) {
+		return errOffloadInProgress
+	}
+
+	defer func() {
+		atomic.StoreInt32(&o.running, 0)
+	}()
+
+	namespaces, err := o.database.OwnedNamespaces()
+	if err != nil {
+		return err
+	}
+
+	var (
+		start    = o.nowFn()
+		now      = xtime.ToUnixNano(start)
+		workers  = xsync.NewWorkerPool(o.tier.OffloadConcurrency())
+		wg       sync.WaitGroup
+		mutex    sync.Mutex
+		multiErr = xerrors.NewMultiError()
+	)
+	workers.Init()
+	addErr := func(err error) {
+		mutex.Lock()
+		multiErr = multiErr.Add(err)
+		mutex.Unlock()
+	}
+	for _, ns := range namespaces {
+		if !o.tier.Enabled(ns.ID()) {
+			continue
+		}
+
+		var (
+			blockSize = ns.Options().RetentionOptions().BlockSize()
+			// Only blocks that ended at least the offload age ago are offloaded.
+			cutoff = now.Add(-o.tier.OffloadAfter())
+		)
+		for _, shard := range ns.OwnedShards() {
+			filesets, err := fs.DataFiles(o.filePathPrefix, ns.ID(), shard.ID())
+			if err != nil {
+				addErr(err)
+				continue
+			}
+
+			for i, fileset := range filesets {
+				if fileset.ID.BlockStart.Add(blockSize).After(cutoff) ||
+					!fileset.HasCompleteCheckpointFile() {
+					continue
+				}
+				// Earlier volumes of a block are superseded by the latest volume
+				// and are deleted by cleanup, so only offload the latest one.
+				if next := i + 1; next < len(filesets) &&
+					filesets[next].ID.BlockStart.Equal(fileset.ID.BlockStart) {
+					continue
+				}
+
+				id := fileset.ID
+				wg.Add(1)
+				workers.Go(func() {
+					defer wg.Done()
+					if err := o.tier.Offload(id); err != nil {
+						addErr(err)
+					}
+				})
+			}
+
+			if err := o.tier.PurgeDeleted(ns.ID(), shard.ID()); err != nil {
+				addErr(fmt.Errorf(
+					"namespace %s failed to purge deleted filesets for shard %d: %w",
+					ns.ID().String(), shard.ID(), err))
+			}
+		}
+	}
+	wg.Wait()
+
+	o.metrics.duration.Record(o.nowFn().Sub(start))
+	return multiErr.FinalError()
+}
+
+func (o *dbOffloader) Report() {
+	if atomic.LoadInt32(&o.running) == 1 {
+		o.metrics.status.Update(1)
+	} else {
+		o.metrics.status.Update(0)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/retention"
+	"github.com/m3db/m3/src/x/objstore"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestDatabaseOffloaderOffload(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	dir, err := ioutil.TempDir("", "testdir")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var (
+		opts      = DefaultTestOptions()
+		fsOpts    = opts.CommitLogOptions().FilesystemOptions().SetFilePathPrefix(dir)
+		blockSize = 2 * time.Hour
+		now       = xtime.Now().Truncate(blockSize)
+		recent    = now.Add(-blockSize)
+		old       = now.Add(-3 * blockSize)
+		older     = now.Add(-4 * blockSize)
+		shardID   = uint32(0)
+	)
+	store, err := objstore.NewFilesystemStore(filepath.Join(dir, "store"))
+	require.NoError(t, err)
+	tier, err := fs.NewTieredStorage(fs.TieredStorageOptions{
+		Store:              store,
+		FilesystemOptions:  fsOpts,
+		CacheDirectory:     filepath.Join(dir, "cache"),
+		OffloadAfter:       blockSize,
+		OffloadConcurrency: 2,
+	})
+	require.NoError(t, err)
+	fsOpts = fsOpts.SetTieredStorage(tier)
+	opts = opts.
+		SetCommitLogOptions(opts.CommitLogOptions().SetFilesystemOptions(fsOpts)).
+		SetClockOptions(opts.ClockOptions().SetNowFn(now.ToTime))
+
+	writer, err := fs.NewWriter(fsOpts)
+	require.NoError(t, err)
+	ids := []fs.FileSetFileIdentifier{
+		{Namespace: defaultTestNs1ID, Shard: shardID, BlockStart: older, VolumeIndex: 0},
+		{Namespace: defaultTestNs1ID, Shard: shardID, BlockStart: old, VolumeIndex: 0},
+		{Namespace: defaultTestNs1ID, Shard: shardID, BlockStart: old, VolumeIndex: 1},
+		{Namespace: defaultTestNs1ID, Shard: shardID, BlockStart: recent, VolumeIndex: 0},
+	}
+	for _, id := range ids {
+		require.NoError(t, writer.Open(fs.DataWriterOpenOptions{
+			FileSetType: persist.FileSetFlushType,
+			Identifier:  id,
+			BlockSize:   blockSize,
+		}))
+		require.NoError(t, writer.Close())
+	}
+
+	shard := NewMockdatabaseShard(ctrl)
+	shard.EXPECT().ID().Return(shardID).AnyTimes()
+	ns := NewMockdatabaseNamespace(ctrl)
+	ns.EXPECT().ID().Return(defaultTestNs1ID).AnyTimes()
+	ns.EXPECT().Options().Return(namespace.NewOptions().
+		SetRetentionOptions(retention.NewOptions().SetBlockSize(blockSize))).AnyTimes()
+	ns.EXPECT().OwnedShards().Return([]databaseShard{shard})
+
+	db := NewMockdatabase(ctrl)
+	db.EXPECT().IsBootstrapped().Return(true)
+	db.EXPECT().OwnedNamespaces().Return([]databaseNamespace{ns}, nil)
+	offloader := newDatabaseOffloader(db, opts)
+	require.NoError(t, offloader.Offload())
+
+	// Only the latest volume of the blocks that ended at least the offload
+	// age ago are offloaded.
+	for i, expected := range []bool{true, false, true, false} {
+		offloaded, err := tier.Offloaded(ids[i])
+		require.NoError(t, err)
+		require.Equal(t, expected, offloaded, "fileset %d", i)
+	}
+}
+
+func TestDatabaseOffloaderOffloadNotBootstrapped(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockdatabase(ctrl)
+	db.EXPECT().IsBootstrapped().Return(false)
+	offloader := newDatabaseOffloader(db, DefaultTestOptions())
+	require.NoError(t, offloader.Offload())
+}
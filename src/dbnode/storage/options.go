@@ -173,6 +173,7 @@ type options struct {
 	newBackgroundProcessFns         []NewBackgroundProcessFn
 	namespaceHooks                  NamespaceHooks
 	tileAggregator                  TileAggregator
+	backupManager                   fs.BackupManager
 	permitsOptions                  permits.Options
 	limitsOptions                   limits.Options
 	coreFn                          xsync.CoreFn
@@ -924,6 +925,16 @@ func (o *options) TileAggregator() TileAggregator {
 	return o.tileAggregator
 }
 
+func (o *options) SetBackupManager(value fs.BackupManager) Options {
+	opts := *o
+	opts.backupManager = value
+	return &opts
+}
+
+func (o *options) BackupManager() fs.BackupManager {
+	return o.backupManager
+}
+
 func (o *options) CoreFn() xsync.CoreFn {
 	return o.coreFn
 }
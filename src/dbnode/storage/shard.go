@@ -125,14 +125,16 @@ type dbShard struct {
 	increasingIndex       increasingIndex
 	seriesPool            series.DatabaseSeriesPool
 	reverseIndex          NamespaceIndex
+	tombstones            *fs.Tombstones
 	insertQueue           *dbShardInsertQueue
 
 	// protected by dbShard lock.
-	lastEntryIndex uint64
-	lookup         *shardMap
-	list           *list.List
-	state          dbShardState
-	bootstrapState BootstrapState
+	lastEntryIndex   uint64
+	lookup           *shardMap
+	list             *list.List
+	state            dbShardState
+	bootstrapState   BootstrapState
+	tombstonedBlocks map[xtime.UnixNano]struct{}
 
 	newMergerFn              fs.NewMergerFn
 	newFSMergeWithMemFn      newFSMergeWithMemFn
@@ -254,6 +256,7 @@ func newDatabaseShard(
 	namespaceReaderMgr databaseNamespaceReaderManager,
 	increasingIndex increasingIndex,
 	reverseIndex NamespaceIndex,
+	tombstones *fs.Tombstones,
 	needsBootstrap bool,
 	opts Options,
 	seriesOpts series.Options,
@@ -272,6 +275,8 @@ func newDatabaseShard(
 		increasingIndex:      increasingIndex,
 		seriesPool:           opts.DatabaseSeriesPool(),
 		reverseIndex:         reverseIndex,
+		tombstones:           tombstones,
+		tombstonedBlocks:     make(map[xtime.UnixNano]struct{}),
 		lookup:               newShardMap(shardMapOptions{}),
 		list:                 list.New(),
 		newMergerFn:          fs.NewMerger,
@@ -1020,6 +1025,10 @@ func (s *dbShard) writeAndIndex(
 		commitLogSeriesUniqueIndex = result.entry.Index
 	}
 
+	// Make a series deleted up to the time of its deletion visible to index
+	// queries after it again.
+	s.tombstones.Written(id, timestamp)
+
 	// Return metadata useful for writing to commit log and indexing.
 	return SeriesWrite{
 		Series: ts.Series{
@@ -1108,15 +1117,22 @@ func (s *dbShard) ReadEncoded(
 		return nil, err
 	}
 
+	var iter series.BlockReaderIter
 	if entry != nil {
-		return entry.Series.ReadEncoded(ctx, start, end, nsCtx)
+		iter, err = entry.Series.ReadEncoded(ctx, start, end, nsCtx)
+	} else {
+		retriever := s.seriesBlockRetriever
+		onRetrieve := s.seriesOnRetrieveBlock
+		opts := s.seriesOpts
+		reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
+		iter, err = reader.ReadEncoded(ctx, start, end, nsCtx)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	retriever := s.seriesBlockRetriever
-	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
-	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, nil, opts)
-	return reader.ReadEncoded(ctx, start, end, nsCtx)
+	// Hide deleted data that has not yet been dropped by a cold flush.
+	return newTombstonedBlockReaderIter(iter, s.tombstones.Ranges(id), s.opts, nsCtx), nil
 }
 
 // lookupEntryWithLock returns the entry for a given id while holding a read lock or a write lock.
@@ -1613,18 +1629,30 @@ func (s *dbShard) FetchBlocks(
 		return nil, err
 	}
 
+	var results []block.FetchBlockResult
 	if entry != nil {
-		return entry.Series.FetchBlocks(ctx, starts, nsCtx)
+		results, err = entry.Series.FetchBlocks(ctx, starts, nsCtx)
+	} else {
+		retriever := s.seriesBlockRetriever
+		onRetrieve := s.seriesOnRetrieveBlock
+		opts := s.seriesOpts
+		// Nil for onRead callback because we don't want peer bootstrapping to impact
+		// the behavior of the LRU
+		var onReadCb block.OnReadBlock
+		reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, onReadCb, opts)
+		results, err = reader.FetchBlocks(ctx, starts, nsCtx)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	retriever := s.seriesBlockRetriever
-	onRetrieve := s.seriesOnRetrieveBlock
-	opts := s.seriesOpts
-	// Nil for onRead callback because we don't want peer bootstrapping to impact
-	// the behavior of the LRU
-	var onReadCb block.OnReadBlock
-	reader := series.NewReaderUsingRetriever(id, retriever, onRetrieve, onReadCb, opts)
-	return reader.FetchBlocks(ctx, starts, nsCtx)
+	// Do not stream deleted data that has not yet been dropped by a cold
+	// flush to the peers bootstrapping or repairing from this node.
+	deleted := s.tombstones.Ranges(id)
+	if len(deleted) == 0 {
+		return results, nil
+	}
+	return newTombstoneFilter(deleted, s.opts, nsCtx).filterFetchBlocks(ctx, results), nil
 }
 
 func (s *dbShard) FetchBlocksForColdFlush(
@@ -1684,6 +1712,8 @@ func (s *dbShard) fetchActiveBlocksMetadata(
 			return false
 		}
 
+		s.removeDeletedBlocksMetadata(metadata.ID, metadata.Blocks)
+
 		// If the blocksMetadata is empty, the series have no data within the specified
 		// time range so we don't return it to the client
 		if len(metadata.Blocks.Results()) == 0 {
@@ -1700,6 +1730,45 @@ func (s *dbShard) fetchActiveBlocksMetadata(
 	return res, nextIndexCursor, loopErr
 }
 
+// isBlockDeleted returns whether all the datapoints of a series in the block
+// starting at the given time have been deleted.
+func (s *dbShard) isBlockDeleted(id ident.ID, blockStart xtime.UnixNano) bool {
+	deleted := s.tombstones.Ranges(id)
+	if len(deleted) == 0 {
+		return false
+	}
+	blockSize := s.namespace.Options().RetentionOptions().BlockSize()
+	return fs.IsCovered(deleted, xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)})
+}
+
+// removeDeletedBlocksMetadata removes the metadata of the blocks of a series
+// all the datapoints of which have been deleted. The metadata of the blocks
+// with some deleted datapoints is kept, the data fetched for them is filtered.
+func (s *dbShard) removeDeletedBlocksMetadata(
+	id ident.ID,
+	blocks block.FetchBlockMetadataResults,
+) {
+	if len(s.tombstones.Ranges(id)) == 0 {
+		return
+	}
+
+	results := blocks.Results()
+	retained := make([]block.FetchBlockMetadataResult, 0, len(results))
+	for _, result := range results {
+		if !s.isBlockDeleted(id, result.Start) {
+			retained = append(retained, result)
+		}
+	}
+	if len(retained) == len(results) {
+		return
+	}
+
+	blocks.Reset()
+	for _, result := range retained {
+		blocks.Add(result)
+	}
+}
+
 func (s *dbShard) FetchBlocksMetadataV2(
 	ctx context.Context,
 	start, end xtime.UnixNano,
@@ -1857,6 +1926,14 @@ func (s *dbShard) FetchBlocksMetadataV2(
 					blockStart, err)
 			}
 
+			if s.isBlockDeleted(id, blockStart) {
+				// NB: the peers would only fetch the block to find that all
+				// its datapoints have been deleted.
+				id.Finalize()
+				tags.Close()
+				continue
+			}
+
 			blockResult := s.opts.FetchBlockMetadataResultsPool().Get()
 			value := block.FetchBlockMetadataResult{
 				Start: blockStart,
@@ -2347,7 +2424,19 @@ func (s *dbShard) ColdFlush(
 		return shardColdFlush{}, loopErr
 	}
 
-	if dirtySeries.Len() == 0 {
+	// Blocks with deleted data need to be rewritten even if no series have
+	// cold writes so that the deleted data is dropped from disk.
+	tombstoned, err := s.takeTombstonedBlocks()
+	if err != nil {
+		return shardColdFlush{}, err
+	}
+	for t := range tombstoned {
+		if dirtySeriesToWrite[t] == nil {
+			dirtySeriesToWrite[t] = newIDList(idElementPool)
+		}
+	}
+
+	if dirtySeries.Len() == 0 && len(tombstoned) == 0 {
 		// Early exit if there is nothing dirty to merge. dirtySeriesToWrite
 		// may be non-empty when dirtySeries is empty because we purposely
 		// leave empty seriesLists in the dirtySeriesToWrite map to avoid having
@@ -2363,14 +2452,16 @@ func (s *dbShard) ColdFlush(
 		s.opts.SegmentReaderPool(), s.opts.MultiReaderIteratorPool(),
 		s.opts.IdentifierPool(), s.opts.EncoderPool(), s.opts.ContextPool(),
 		s.opts.CommitLogOptions().FilesystemOptions().FilePathPrefix(), s.namespace.Options())
-	mergeWithMem := s.newFSMergeWithMemFn(s, s, dirtySeries, dirtySeriesToWrite)
+	mergeWithMem := s.newFSMergeWithMemFn(s, s, dirtySeries, dirtySeriesToWrite, s.tombstones)
 	// Loop through each block that we know has ColdWrites. Since each block
 	// has its own fileset, if we encounter an error while trying to persist
 	// a block, we continue to try persisting other blocks.
 	for startTime := range dirtySeriesToWrite {
+		_, isTombstoned := tombstoned[startTime]
 		coldVersion, err := s.RetrievableBlockColdVersion(startTime)
 		if err != nil {
 			multiErr = multiErr.Add(err)
+			s.MarkTombstonedBlocks(tombstonedBlockStarts(startTime, isTombstoned))
 			continue
 		}
 
@@ -2386,17 +2477,63 @@ func (s *dbShard) ColdFlush(
 			onFlushSeries)
 		if err != nil {
 			multiErr = multiErr.Add(err)
+			s.MarkTombstonedBlocks(tombstonedBlockStarts(startTime, isTombstoned))
 			continue
 		}
 		flush.doneFns = append(flush.doneFns, shardColdFlushDone{
 			startTime:   startTime,
 			nextVersion: nextVersion,
 			close:       close,
+			tombstoned:  isTombstoned,
 		})
 	}
 	return flush, multiErr.FinalError()
 }
 
+func (s *dbShard) MarkTombstonedBlocks(blockStarts []xtime.UnixNano) {
+	if len(blockStarts) == 0 {
+		return
+	}
+	s.Lock()
+	for _, t := range blockStarts {
+		s.tombstonedBlocks[t] = struct{}{}
+	}
+	s.Unlock()
+}
+
+// takeTombstonedBlocks removes and returns the tombstoned blocks that can be
+// rewritten by a cold flush, blocks that have not been warm flushed yet are
+// kept until they are.
+func (s *dbShard) takeTombstonedBlocks() (map[xtime.UnixNano]struct{}, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if len(s.tombstonedBlocks) == 0 {
+		return nil, nil
+	}
+
+	taken := make(map[xtime.UnixNano]struct{}, len(s.tombstonedBlocks))
+	for t := range s.tombstonedBlocks {
+		hasWarmFlushed, err := s.hasWarmFlushed(t)
+		if err != nil {
+			return nil, err
+		}
+		if !hasWarmFlushed {
+			continue
+		}
+		taken[t] = struct{}{}
+		delete(s.tombstonedBlocks, t)
+	}
+	return taken, nil
+}
+
+func tombstonedBlockStarts(blockStart xtime.UnixNano, tombstoned bool) []xtime.UnixNano {
+	if !tombstoned {
+		return nil
+	}
+	return []xtime.UnixNano{blockStart}
+}
+
 func (s *dbShard) FilterBlocksNeedSnapshot(blockStarts []xtime.UnixNano) []xtime.UnixNano {
 	if !s.IsBootstrapped() {
 		return nil
@@ -2627,6 +2764,14 @@ func (s *dbShard) removeAnyFlushStatesTooEarly(startTime xtime.UnixNano) {
 		}
 	}
 	s.flushState.Unlock()
+
+	s.Lock()
+	for t := range s.tombstonedBlocks {
+		if t.Before(earliestFlush) {
+			delete(s.tombstonedBlocks, t)
+		}
+	}
+	s.Unlock()
 }
 
 func (s *dbShard) CleanupExpiredFileSets(earliestToRetain xtime.UnixNano) error {
@@ -2833,6 +2978,7 @@ type shardColdFlushDone struct {
 	startTime   xtime.UnixNano
 	nextVersion int
 	close       persist.DataCloser
+	tombstoned  bool
 }
 
 type shardColdFlush struct {
@@ -2848,12 +2994,15 @@ func (s shardColdFlush) Done() error {
 
 		if err := done.close(); err != nil {
 			multiErr = multiErr.Add(err)
+			// Retry dropping the deleted data on the next cold flush.
+			s.shard.MarkTombstonedBlocks(tombstonedBlockStarts(startTime, done.tombstoned))
 			continue
 		}
 
 		err := s.shard.finishWriting(startTime, nextVersion, false)
 		if err != nil {
 			multiErr = multiErr.Add(err)
+			s.shard.MarkTombstonedBlocks(tombstonedBlockStarts(startTime, done.tombstoned))
 		}
 	}
 	return multiErr.FinalError()
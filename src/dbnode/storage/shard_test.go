@@ -90,7 +90,7 @@ func testDatabaseShardWithIndexFn(
 		SetColdWritesEnabled(coldWritesEnabled)
 
 	return newDatabaseShard(metadata, 0, nil, nsReaderMgr,
-		&testIncreasingIndex{}, idx, true, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, idx, nil, true, opts, seriesOpts).(*dbShard)
 }
 
 func addMockSeries(ctrl *gomock.Controller, shard *dbShard, id ident.ID, tags ident.Tags, index uint64) *series.MockDatabaseSeries {
@@ -112,7 +112,7 @@ func TestShardDontNeedBootstrap(t *testing.T) {
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
 	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
-		&testIncreasingIndex{}, nil, false, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, nil, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
 	require.Equal(t, Bootstrapped, shard.bootstrapState)
@@ -125,7 +125,7 @@ func TestShardErrorIfDoubleBootstrap(t *testing.T) {
 	defer closer()
 	seriesOpts := NewSeriesOptionsFromOptions(opts, testNs.Options().RetentionOptions())
 	shard := newDatabaseShard(testNs.metadata, 0, nil, nil,
-		&testIncreasingIndex{}, nil, false, opts, seriesOpts).(*dbShard)
+		&testIncreasingIndex{}, nil, nil, false, opts, seriesOpts).(*dbShard)
 	defer shard.Close()
 
 	require.Equal(t, Bootstrapped, shard.bootstrapState)
@@ -749,6 +749,72 @@ func TestShardColdFlushNoMergeIfNothingDirty(t *testing.T) {
 	}
 }
 
+func TestShardColdFlushTombstonedBlocks(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+	now := xtime.Now()
+	nowFn := func() time.Time {
+		return now.ToTime()
+	}
+	opts := DefaultTestOptions()
+	fsOpts := opts.CommitLogOptions().FilesystemOptions().
+		SetFilePathPrefix(t.TempDir())
+	opts = opts.
+		SetClockOptions(opts.ClockOptions().SetNowFn(nowFn)).
+		SetCommitLogOptions(opts.CommitLogOptions().
+			SetFilesystemOptions(fsOpts))
+	blockSize := opts.SeriesOptions().RetentionOptions().BlockSize()
+	shard := testDatabaseShard(t, opts)
+
+	ctx := context.NewBackground()
+	defer ctx.Close()
+
+	nsCtx := namespace.Context{ID: ident.StringID("foo")}
+	require.NoError(t, shard.Bootstrap(ctx, nsCtx))
+
+	shard.newMergerFn = newMergerTestFn
+	shard.newFSMergeWithMemFn = newFSMergeWithMemTestFn
+
+	t0 := now.Truncate(blockSize).Add(-10 * blockSize)
+	t1 := t0.Add(1 * blockSize)
+	t2 := t0.Add(2 * blockSize)
+	shard.markWarmDataFlushStateSuccess(t0)
+	shard.markWarmDataFlushStateSuccess(t1)
+
+	// Deleted data in t1 and t2 but only t1 has been warm flushed, so t2
+	// must be kept until it has been.
+	shard.MarkTombstonedBlocks([]xtime.UnixNano{t1, t2})
+
+	resources := coldFlushReusableResources{
+		dirtySeries:        newDirtySeriesMap(),
+		dirtySeriesToWrite: make(map[xtime.UnixNano]*idList),
+		idElementPool:      newIDElementPool(nil),
+		fsReader:           fs.NewMockDataFileSetReader(ctrl),
+	}
+
+	preparer := persist.NewMockFlushPreparer(ctrl)
+	shardColdFlush, err := shard.ColdFlush(preparer, resources, nsCtx, &persist.NoOpColdFlushNamespace{})
+	require.NoError(t, err)
+	require.NoError(t, shardColdFlush.Done())
+
+	for _, tc := range []struct {
+		blockStart  xtime.UnixNano
+		coldVersion int
+	}{
+		{blockStart: t0, coldVersion: 0},
+		{blockStart: t1, coldVersion: 1},
+		{blockStart: t2, coldVersion: 0},
+	} {
+		coldVersion, err := shard.RetrievableBlockColdVersion(tc.blockStart)
+		require.NoError(t, err)
+		assert.Equal(t, tc.coldVersion, coldVersion)
+	}
+
+	shard.RLock()
+	assert.Equal(t, map[xtime.UnixNano]struct{}{t2: {}}, shard.tombstonedBlocks)
+	shard.RUnlock()
+}
+
 func newMergerTestFn(
 	_ fs.DataFileSetReader,
 	_ int,
@@ -794,6 +860,7 @@ func newFSMergeWithMemTestFn(
 	_ series.QueryableBlockRetriever,
 	_ *dirtySeriesMap,
 	_ map[xtime.UnixNano]*idList,
+	_ *fs.Tombstones,
 ) fs.MergeWith {
 	return fs.NewNoopMergeWith()
 }
@@ -1570,6 +1637,83 @@ func TestShardFetchBlocksIDExists(t *testing.T) {
 	require.Equal(t, expected, res)
 }
 
+func TestShardFetchBlocksTombstoned(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	opts := DefaultTestOptions()
+	ctx := opts.ContextPool().Get()
+	defer ctx.Close()
+
+	shard := testDatabaseShard(t, opts)
+	defer shard.Close()
+
+	var (
+		id        = ident.StringID("foo")
+		series    = addMockSeries(ctrl, shard, id, ident.Tags{}, 0)
+		blockSize = shard.seriesOpts.RetentionOptions().BlockSize()
+		t0        = xtime.Now().Truncate(blockSize).Add(-2 * blockSize)
+		t1        = t0.Add(blockSize)
+	)
+	tombstones, err := fs.NewTombstones(fs.NewOptions().SetFilePathPrefix(t.TempDir()),
+		defaultTestNs1ID)
+	require.NoError(t, err)
+	require.NoError(t, tombstones.Add([]fs.Tombstone{{
+		ID:    id,
+		Range: xtime.Range{Start: t0.Add(2 * time.Minute), End: t1.Add(blockSize)},
+	}}))
+	shard.tombstones = tombstones
+
+	newBlock := func(start xtime.UnixNano, offsets ...time.Duration) []xio.BlockReader {
+		encoder := opts.EncoderPool().Get()
+		encoder.Reset(start, 0, nil)
+		for _, offset := range offsets {
+			dp := ts.Datapoint{TimestampNanos: start.Add(offset), Value: 1}
+			require.NoError(t, encoder.Encode(dp, xtime.Second, nil))
+		}
+		return []xio.BlockReader{{
+			SegmentReader: xio.NewSegmentReader(encoder.Discard()),
+			Start:         start,
+			BlockSize:     blockSize,
+		}}
+	}
+	starts := []xtime.UnixNano{t0, t1}
+	series.EXPECT().FetchBlocks(ctx, starts, gomock.Any()).Return([]block.FetchBlockResult{
+		block.NewFetchBlockResult(t0, newBlock(t0, time.Minute, 2*time.Minute), nil),
+		block.NewFetchBlockResult(t1, newBlock(t1, time.Minute), nil),
+	}, nil)
+
+	// The deleted datapoints are not streamed to peers and neither are the
+	// blocks all the datapoints of which were deleted.
+	res, err := shard.FetchBlocks(ctx, id, starts, namespace.Context{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(res))
+	require.Equal(t, t0, res[0].Start)
+
+	iter := opts.MultiReaderIteratorPool().Get()
+	defer iter.Close()
+	segReaders := make([]xio.SegmentReader, 0, len(res[0].Blocks))
+	for _, r := range res[0].Blocks {
+		segReaders = append(segReaders, r.SegmentReader)
+	}
+	iter.Reset(segReaders, t0, blockSize, nil)
+	var timestamps []xtime.UnixNano
+	for iter.Next() {
+		dp, _, _ := iter.Current()
+		timestamps = append(timestamps, dp.TimestampNanos)
+	}
+	require.NoError(t, iter.Err())
+	require.Equal(t, []xtime.UnixNano{t0.Add(time.Minute)}, timestamps)
+
+	// Only the metadata of the blocks with data left is returned.
+	blocks := block.NewFetchBlockMetadataResults()
+	blocks.Add(block.NewFetchBlockMetadataResult(t0, 1, nil, 0, nil))
+	blocks.Add(block.NewFetchBlockMetadataResult(t1, 1, nil, 0, nil))
+	shard.removeDeletedBlocksMetadata(id, blocks)
+	require.Equal(t, 1, len(blocks.Results()))
+	require.Equal(t, t0, blocks.Results()[0].Start)
+}
+
 func TestShardCleanupExpiredFileSets(t *testing.T) {
 	opts := DefaultTestOptions()
 	shard := testDatabaseShard(t, opts)
@@ -162,6 +162,21 @@ func (mr *MockDatabaseMockRecorder) AssignShardSet(shardSet interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignShardSet", reflect.TypeOf((*MockDatabase)(nil).AssignShardSet), shardSet)
 }
 
+// Backup mocks base method.
+func (m *MockDatabase) Backup(namespace ident.ID, backupID string) (fs.BackupManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", namespace, backupID)
+	ret0, _ := ret[0].(fs.BackupManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockDatabaseMockRecorder) Backup(namespace, backupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*MockDatabase)(nil).Backup), namespace, backupID)
+}
+
 // BatchWriter mocks base method.
 func (m *MockDatabase) BatchWriter(namespace ident.ID, batchSize int) (writes.BatchWriter, error) {
 	m.ctrl.T.Helper()
@@ -219,6 +234,21 @@ func (mr *MockDatabaseMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockDatabase)(nil).Close))
 }
 
+// DeleteSeries mocks base method.
+func (m *MockDatabase) DeleteSeries(ctx context.Context, namespace ident.ID, query index.Query, start, end time0.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, namespace, query, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockDatabaseMockRecorder) DeleteSeries(ctx, namespace, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockDatabase)(nil).DeleteSeries), ctx, namespace, query, start, end)
+}
+
 // FetchBlocks mocks base method.
 func (m *MockDatabase) FetchBlocks(ctx context.Context, namespace ident.ID, shard uint32, id ident.ID, starts []time0.UnixNano) ([]block.FetchBlockResult, error) {
 	m.ctrl.T.Helper()
@@ -408,6 +438,21 @@ func (mr *MockDatabaseMockRecorder) Repair() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*MockDatabase)(nil).Repair))
 }
 
+// Restore mocks base method.
+func (m *MockDatabase) Restore(namespace ident.ID, backupID string, pointInTime time0.UnixNano) (fs.BackupManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", namespace, backupID, pointInTime)
+	ret0, _ := ret[0].(fs.BackupManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockDatabaseMockRecorder) Restore(namespace, backupID, pointInTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockDatabase)(nil).Restore), namespace, backupID, pointInTime)
+}
+
 // ShardSet mocks base method.
 func (m *MockDatabase) ShardSet() sharding.ShardSet {
 	m.ctrl.T.Helper()
@@ -572,6 +617,21 @@ func (mr *MockdatabaseMockRecorder) AssignShardSet(shardSet interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignShardSet", reflect.TypeOf((*Mockdatabase)(nil).AssignShardSet), shardSet)
 }
 
+// Backup mocks base method.
+func (m *Mockdatabase) Backup(namespace ident.ID, backupID string) (fs.BackupManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backup", namespace, backupID)
+	ret0, _ := ret[0].(fs.BackupManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backup indicates an expected call of Backup.
+func (mr *MockdatabaseMockRecorder) Backup(namespace, backupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backup", reflect.TypeOf((*Mockdatabase)(nil).Backup), namespace, backupID)
+}
+
 // BatchWriter mocks base method.
 func (m *Mockdatabase) BatchWriter(namespace ident.ID, batchSize int) (writes.BatchWriter, error) {
 	m.ctrl.T.Helper()
@@ -629,6 +689,21 @@ func (mr *MockdatabaseMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*Mockdatabase)(nil).Close))
 }
 
+// DeleteSeries mocks base method.
+func (m *Mockdatabase) DeleteSeries(ctx context.Context, namespace ident.ID, query index.Query, start, end time0.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, namespace, query, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockdatabaseMockRecorder) DeleteSeries(ctx, namespace, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*Mockdatabase)(nil).DeleteSeries), ctx, namespace, query, start, end)
+}
+
 // FetchBlocks mocks base method.
 func (m *Mockdatabase) FetchBlocks(ctx context.Context, namespace ident.ID, shard uint32, id ident.ID, starts []time0.UnixNano) ([]block.FetchBlockResult, error) {
 	m.ctrl.T.Helper()
@@ -833,6 +908,35 @@ func (mr *MockdatabaseMockRecorder) Repair() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Repair", reflect.TypeOf((*Mockdatabase)(nil).Repair))
 }
 
+// Restore mocks base method.
+func (m *Mockdatabase) Restore(namespace ident.ID, backupID string, pointInTime time0.UnixNano) (fs.BackupManifest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", namespace, backupID, pointInTime)
+	ret0, _ := ret[0].(fs.BackupManifest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockdatabaseMockRecorder) Restore(namespace, backupID, pointInTime interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*Mockdatabase)(nil).Restore), namespace, backupID, pointInTime)
+}
+
+// RestoredNamespaces mocks base method.
+func (m *Mockdatabase) RestoredNamespaces() []ident.ID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoredNamespaces")
+	ret0, _ := ret[0].([]ident.ID)
+	return ret0
+}
+
+// RestoredNamespaces indicates an expected call of RestoredNamespaces.
+func (mr *MockdatabaseMockRecorder) RestoredNamespaces() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoredNamespaces", reflect.TypeOf((*Mockdatabase)(nil).RestoredNamespaces))
+}
+
 // ShardSet mocks base method.
 func (m *Mockdatabase) ShardSet() sharding.ShardSet {
 	m.ctrl.T.Helper()
@@ -1275,6 +1379,21 @@ func (mr *MockdatabaseNamespaceMockRecorder) ColdFlush(flush interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ColdFlush", reflect.TypeOf((*MockdatabaseNamespace)(nil).ColdFlush), flush)
 }
 
+// DeleteSeries mocks base method.
+func (m *MockdatabaseNamespace) DeleteSeries(ctx context.Context, query index.Query, start, end time0.UnixNano) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSeries", ctx, query, start, end)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSeries indicates an expected call of DeleteSeries.
+func (mr *MockdatabaseNamespaceMockRecorder) DeleteSeries(ctx, query, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSeries", reflect.TypeOf((*MockdatabaseNamespace)(nil).DeleteSeries), ctx, query, start, end)
+}
+
 // DocRef mocks base method.
 func (m *MockdatabaseNamespace) DocRef(id ident.ID) (doc.Metadata, bool, error) {
 	m.ctrl.T.Helper()
@@ -2162,6 +2281,18 @@ func (mr *MockdatabaseShardMockRecorder) LoadBlocks(series interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBlocks", reflect.TypeOf((*MockdatabaseShard)(nil).LoadBlocks), series)
 }
 
+// MarkTombstonedBlocks mocks base method.
+func (m *MockdatabaseShard) MarkTombstonedBlocks(blockStarts []time0.UnixNano) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "MarkTombstonedBlocks", blockStarts)
+}
+
+// MarkTombstonedBlocks indicates an expected call of MarkTombstonedBlocks.
+func (mr *MockdatabaseShardMockRecorder) MarkTombstonedBlocks(blockStarts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTombstonedBlocks", reflect.TypeOf((*MockdatabaseShard)(nil).MarkTombstonedBlocks), blockStarts)
+}
+
 // MarkWarmIndexFlushStateSuccessOrError mocks base method.
 func (m *MockdatabaseShard) MarkWarmIndexFlushStateSuccessOrError(blockStart time0.UnixNano, err error) {
 	m.ctrl.T.Helper()
@@ -3902,6 +4033,20 @@ func (mr *MockOptionsMockRecorder) BackgroundProcessFns() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackgroundProcessFns", reflect.TypeOf((*MockOptions)(nil).BackgroundProcessFns))
 }
 
+// BackupManager mocks base method.
+func (m *MockOptions) BackupManager() fs.BackupManager {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackupManager")
+	ret0, _ := ret[0].(fs.BackupManager)
+	return ret0
+}
+
+// BackupManager indicates an expected call of BackupManager.
+func (mr *MockOptionsMockRecorder) BackupManager() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupManager", reflect.TypeOf((*MockOptions)(nil).BackupManager))
+}
+
 // BlockLeaseManager mocks base method.
 func (m *MockOptions) BlockLeaseManager() block.LeaseManager {
 	m.ctrl.T.Helper()
@@ -4560,6 +4705,20 @@ func (mr *MockOptionsMockRecorder) SetBackgroundProcessFns(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBackgroundProcessFns", reflect.TypeOf((*MockOptions)(nil).SetBackgroundProcessFns), arg0)
 }
 
+// SetBackupManager mocks base method.
+func (m *MockOptions) SetBackupManager(value fs.BackupManager) Options {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBackupManager", value)
+	ret0, _ := ret[0].(Options)
+	return ret0
+}
+
+// SetBackupManager indicates an expected call of SetBackupManager.
+func (mr *MockOptionsMockRecorder) SetBackupManager(value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBackupManager", reflect.TypeOf((*MockOptions)(nil).SetBackupManager), value)
+}
+
 // SetBlockLeaseManager mocks base method.
 func (m *MockOptions) SetBlockLeaseManager(leaseMgr block.LeaseManager) Options {
 	m.ctrl.T.Helper()
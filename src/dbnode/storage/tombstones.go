@@ -0,0 +1,208 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/namespace"
+	"github.com/m3db/m3/src/dbnode/persist/fs"
+	"github.com/m3db/m3/src/dbnode/storage/block"
+	"github.com/m3db/m3/src/dbnode/storage/series"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/x/context"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// tombstoneFilter drops the datapoints of a series that have been deleted
+// but not yet removed from disk from the blocks read for the series.
+type tombstoneFilter struct {
+	deleted             []xtime.Range
+	encoderPool         encoding.EncoderPool
+	multiReaderIterPool encoding.MultiReaderIteratorPool
+	blockAllocSize      int
+	nsCtx               namespace.Context
+}
+
+func newTombstoneFilter(
+	deleted []xtime.Range,
+	opts Options,
+	nsCtx namespace.Context,
+) tombstoneFilter {
+	return tombstoneFilter{
+		deleted:             deleted,
+		encoderPool:         opts.EncoderPool(),
+		multiReaderIterPool: opts.MultiReaderIteratorPool(),
+		blockAllocSize:      opts.DatabaseBlockOptions().DatabaseBlockAllocSize(),
+		nsCtx:               nsCtx,
+	}
+}
+
+// tombstonedBlockReaderIter wraps a BlockReaderIter and drops the datapoints
+// of a series that have been deleted but not yet removed from disk.
+type tombstonedBlockReaderIter struct {
+	series.BlockReaderIter
+	tombstoneFilter
+
+	curr []xio.BlockReader
+	err  error
+}
+
+func newTombstonedBlockReaderIter(
+	iter series.BlockReaderIter,
+	deleted []xtime.Range,
+	opts Options,
+	nsCtx namespace.Context,
+) series.BlockReaderIter {
+	if iter == nil || len(deleted) == 0 {
+		return iter
+	}
+	return &tombstonedBlockReaderIter{
+		BlockReaderIter: iter,
+		tombstoneFilter: newTombstoneFilter(deleted, opts, nsCtx),
+	}
+}
+
+func (i *tombstonedBlockReaderIter) Next(ctx context.Context) bool {
+	for i.BlockReaderIter.Next(ctx) {
+		curr, err := i.filter(ctx, i.BlockReaderIter.Current())
+		if err != nil {
+			i.err = err
+			return false
+		}
+		if len(curr) == 0 {
+			// Every datapoint in the block has been deleted.
+			continue
+		}
+		i.curr = curr
+		return true
+	}
+	i.curr = nil
+	return false
+}
+
+func (i *tombstonedBlockReaderIter) Current() []xio.BlockReader {
+	return i.curr
+}
+
+func (i *tombstonedBlockReaderIter) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	return i.BlockReaderIter.Err()
+}
+
+func (i *tombstonedBlockReaderIter) ToSlices(ctx context.Context) ([][]xio.BlockReader, error) {
+	var results [][]xio.BlockReader
+	for i.Next(ctx) {
+		results = append(results, i.Current())
+	}
+	if err := i.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// filterFetchBlocks drops the deleted datapoints from the fetched blocks of a
+// series, which are streamed to peers, leaving out the blocks all the
+// datapoints of which have been deleted.
+func (f tombstoneFilter) filterFetchBlocks(
+	ctx context.Context,
+	results []block.FetchBlockResult,
+) []block.FetchBlockResult {
+	filtered := results[:0]
+	for _, result := range results {
+		if result.Err == nil {
+			blocks, err := f.filter(ctx, result.Blocks)
+			if err != nil {
+				result.Err = err
+			} else if len(blocks) == 0 {
+				continue
+			}
+			result.Blocks = blocks
+		}
+		filtered = append(filtered, result)
+	}
+	return filtered
+}
+
+func (f tombstoneFilter) filter(
+	ctx context.Context,
+	readers []xio.BlockReader,
+) ([]xio.BlockReader, error) {
+	if len(readers) == 0 {
+		return readers, nil
+	}
+
+	var (
+		blockStart = readers[0].Start
+		blockSize  = readers[0].BlockSize
+		blockRange = xtime.Range{Start: blockStart, End: blockStart.Add(blockSize)}
+		overlaps   bool
+	)
+	for _, r := range f.deleted {
+		if r.Overlaps(blockRange) {
+			overlaps = true
+			break
+		}
+	}
+	if !overlaps {
+		return readers, nil
+	}
+
+	segReaders := make([]xio.SegmentReader, 0, len(readers))
+	for _, r := range readers {
+		segReaders = append(segReaders, r.SegmentReader)
+	}
+
+	iter := f.multiReaderIterPool.Get()
+	defer iter.Close()
+	iter.Reset(segReaders, blockStart, blockSize, f.nsCtx.Schema)
+
+	encoder := f.encoderPool.Get()
+	encoder.Reset(blockStart, f.blockAllocSize, f.nsCtx.Schema)
+	for iter.Next() {
+		dp, unit, annotation := iter.Current()
+		if fs.IsTombstoned(f.deleted, dp.TimestampNanos) {
+			continue
+		}
+		if err := encoder.Encode(dp, unit, annotation); err != nil {
+			encoder.Close()
+			return nil, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		encoder.Close()
+		return nil, err
+	}
+
+	if encoder.NumEncoded() == 0 {
+		encoder.Close()
+		return nil, nil
+	}
+
+	segReader := xio.NewSegmentReader(encoder.Discard())
+	ctx.RegisterFinalizer(segReader)
+	return []xio.BlockReader{{
+		SegmentReader: segReader,
+		Start:         blockStart,
+		BlockSize:     blockSize,
+	}}, nil
+}
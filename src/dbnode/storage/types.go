@@ -218,6 +218,29 @@ type Database interface {
 	// Truncate truncates data for the given namespace.
 	Truncate(namespace ident.ID) (int64, error)
 
+	// DeleteSeries deletes the datapoints within the given time range of
+	// the series matching the query, returning the number of series deleted.
+	DeleteSeries(
+		ctx context.Context,
+		namespace ident.ID,
+		query index.Query,
+		start, end xtime.UnixNano,
+	) (int64, error)
+
+	// Backup takes a consistent backup of the shards of the namespace owned
+	// by this node and returns the manifest of the backup.
+	Backup(namespace ident.ID, backupID string) (fs.BackupManifest, error)
+
+	// Restore restores the backup of the namespace with the given ID or, if
+	// empty, the latest backup taken at or before the point in time. The
+	// namespace must not be loaded and is bootstrapped from the restored
+	// files once it is added back.
+	Restore(
+		namespace ident.ID,
+		backupID string,
+		pointInTime xtime.UnixNano,
+	) (fs.BackupManifest, error)
+
 	// BootstrapState captures and returns a snapshot of the databases'
 	// bootstrap state.
 	BootstrapState() DatabaseBootstrapState
@@ -238,6 +261,10 @@ type database interface {
 
 	// UpdateOwnedNamespaces updates the namespaces this database owns.
 	UpdateOwnedNamespaces(namespaces namespace.Map) error
+
+	// RestoredNamespaces returns the namespaces restored from a backup that
+	// have not yet been added back, whose files must not be cleaned up.
+	RestoredNamespaces() []ident.ID
 }
 
 // Namespace is a time series database namespace.
@@ -403,6 +430,15 @@ type databaseNamespace interface {
 	// Truncate truncates the in-memory data for this namespace.
 	Truncate() (int64, error)
 
+	// DeleteSeries records tombstones for the datapoints within the given
+	// time range of the series matching the query, returning the number of
+	// series deleted.
+	DeleteSeries(
+		ctx context.Context,
+		query index.Query,
+		start, end xtime.UnixNano,
+	) (int64, error)
+
 	// Repair repairs the namespace data for a given time range.
 	Repair(repairer databaseShardRepairer, tr xtime.Range, opts NamespaceRepairOptions) error
 
@@ -576,6 +612,11 @@ type databaseShard interface {
 		onFlush persist.OnFlushSeries,
 	) (ShardColdFlush, error)
 
+	// MarkTombstonedBlocks marks blocks that contain deleted datapoints so
+	// that the next cold flush merges them, which physically drops the
+	// deleted datapoints from disk.
+	MarkTombstonedBlocks(blockStarts []xtime.UnixNano)
+
 	// FilterBlocksNeedSnapshot computes which blocks require snapshots.
 	FilterBlocksNeedSnapshot(blockStarts []xtime.UnixNano) []xtime.UnixNano
 
@@ -1327,6 +1368,12 @@ type Options interface {
 	// TileAggregator returns the TileAggregator.
 	TileAggregator() TileAggregator
 
+	// SetBackupManager sets the backup manager.
+	SetBackupManager(value fs.BackupManager) Options
+
+	// BackupManager returns the backup manager.
+	BackupManager() fs.BackupManager
+
 	// PermitsOptions returns the permits options.
 	PermitsOptions() permits.Options
 
@@ -1401,6 +1448,7 @@ type newFSMergeWithMemFn func(
 	retriever series.QueryableBlockRetriever,
 	dirtySeries *dirtySeriesMap,
 	dirtySeriesToWrite map[xtime.UnixNano]*idList,
+	tombstones *fs.Tombstones,
 ) fs.MergeWith
 
 // NewBackgroundProcessFn is a function that creates and returns a new BackgroundProcess.
@@ -0,0 +1,686 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/x/instrument"
+	"github.com/m3db/m3/src/x/unsafe"
+)
+
+const (
+	// The maximum pickle message size accepted, this matches the maximum
+	// message size accepted by the carbon daemons.
+	maxPickleMessageSize = 1 << 20
+
+	pickleHeaderSize = 4
+)
+
+// Subset of the pickle opcodes that can be emitted when pickling a list of
+// (path, (timestamp, value)) tuples with the protocols 0 to 4.
+const (
+	pickleOpMark            = '('
+	pickleOpStop            = '.'
+	pickleOpPop             = '0'
+	pickleOpPopMark         = '1'
+	pickleOpDup             = '2'
+	pickleOpFloat           = 'F'
+	pickleOpInt             = 'I'
+	pickleOpBinInt          = 'J'
+	pickleOpBinInt1         = 'K'
+	pickleOpLong            = 'L'
+	pickleOpBinInt2         = 'M'
+	pickleOpNone            = 'N'
+	pickleOpString          = 'S'
+	pickleOpBinString       = 'T'
+	pickleOpShortBinString  = 'U'
+	pickleOpUnicode         = 'V'
+	pickleOpBinUnicode      = 'X'
+	pickleOpAppend          = 'a'
+	pickleOpAppends         = 'e'
+	pickleOpGet             = 'g'
+	pickleOpBinGet          = 'h'
+	pickleOpLongBinGet      = 'j'
+	pickleOpList            = 'l'
+	pickleOpEmptyList       = ']'
+	pickleOpPut             = 'p'
+	pickleOpBinPut          = 'q'
+	pickleOpLongBinPut      = 'r'
+	pickleOpTuple           = 't'
+	pickleOpEmptyTuple      = ')'
+	pickleOpBinFloat        = 'G'
+	pickleOpBinBytes        = 'B'
+	pickleOpShortBinBytes   = 'C'
+	pickleOpProto           = 0x80
+	pickleOpTuple1          = 0x85
+	pickleOpTuple2          = 0x86
+	pickleOpTuple3          = 0x87
+	pickleOpNewTrue         = 0x88
+	pickleOpNewFalse        = 0x89
+	pickleOpLong1           = 0x8a
+	pickleOpShortBinUnicode = 0x8c
+	pickleOpMemoize         = 0x94
+	pickleOpFrame           = 0x95
+)
+
+var (
+	errPickleTruncated      = errors.New("truncated pickle message")
+	errPickleStackUnderflow = errors.New("pickle stack underflow")
+	errPickleMarkNotFound   = errors.New("pickle mark not found")
+	errPickleNotList        = errors.New("pickle message is not a list")
+	errPickleInvalidMetric  = errors.New("pickled metric is not a (path, (timestamp, value)) tuple")
+)
+
+// PickleScanner is used to scan carbon metrics sent with the pickle protocol
+// from an underlying io.Reader. Each pickle message is prefixed by its length
+// as a 4 byte big endian integer and contains a list of
+// (path, (timestamp, value)) tuples.
+type PickleScanner struct {
+	r         *bufio.Reader
+	header    [pickleHeaderSize]byte
+	message   []byte
+	decoder   pickleDecoder
+	metrics   []Metric
+	metricIdx int
+	err       error
+
+	// The number of malformed metrics encountered.
+	MalformedCount int
+
+	iOpts instrument.Options
+}
+
+// NewPickleScanner creates a new carbon pickle scanner.
+func NewPickleScanner(r io.Reader, iOpts instrument.Options) *PickleScanner {
+	return &PickleScanner{
+		r:     bufio.NewReaderSize(r, initScannerBufferSize),
+		iOpts: iOpts,
+	}
+}
+
+// Scan scans for the next carbon metric. Malformed metrics are skipped but
+// counted, as are the metrics of pickle messages that cannot be decoded.
+func (s *PickleScanner) Scan() bool {
+	for {
+		if s.metricIdx < len(s.metrics) {
+			s.metricIdx++
+			return true
+		}
+
+		if s.err != nil {
+			return false
+		}
+
+		if _, err := io.ReadFull(s.r, s.header[:]); err != nil {
+			if err != io.EOF {
+				s.err = err
+			}
+			return false
+		}
+
+		size := binary.BigEndian.Uint32(s.header[:])
+		if size > maxPickleMessageSize {
+			// Messages cannot be skipped safely once the stream is out of sync.
+			s.err = fmt.Errorf("pickle message size %d exceeds max size %d",
+				size, maxPickleMessageSize)
+			return false
+		}
+
+		if cap(s.message) < int(size) {
+			s.message = make([]byte, size)
+		}
+		s.message = s.message[:size]
+		if _, err := io.ReadFull(s.r, s.message); err != nil {
+			s.err = err
+			return false
+		}
+
+		var malformed int
+		s.metrics, malformed, s.err = s.decoder.decodeMetrics(s.metrics[:0], s.message)
+		s.metricIdx = 0
+		if s.err != nil {
+			s.iOpts.Logger().Error("error trying to decode malformed carbon pickle message",
+				zap.Int("size", int(size)), zap.Error(s.err))
+			s.MalformedCount++
+			s.err = nil
+			s.metrics = s.metrics[:0]
+			continue
+		}
+		s.MalformedCount += malformed
+	}
+}
+
+// Metric returns the path, timestamp, and value of the last scanned metric.
+func (s *PickleScanner) Metric() ([]byte, time.Time, float64) {
+	m := s.metrics[s.metricIdx-1]
+	return m.Name, m.Time, m.Val
+}
+
+// Err returns any errors in the scan.
+func (s *PickleScanner) Err() error { return s.err }
+
+// ParsePickleMessage parses a pickle message, without its length prefix, and
+// returns the metrics and number of malformed metrics.
+func ParsePickleMessage(message []byte) ([]Metric, int, error) {
+	var d pickleDecoder
+	return d.decodeMetrics(nil, message)
+}
+
+type pickleList struct {
+	items []interface{}
+}
+
+// pickleDecoder is a very limited pickle virtual machine that only supports
+// the opcodes required to unpickle lists and tuples of strings and numbers.
+// Decoded strings reference the message bytes whenever possible.
+type pickleDecoder struct {
+	data  []byte
+	pos   int
+	stack []interface{}
+	marks []int
+	memo  map[int]interface{}
+}
+
+func (d *pickleDecoder) decodeMetrics(
+	metrics []Metric,
+	message []byte,
+) ([]Metric, int, error) {
+	value, err := d.decode(message)
+	if err != nil {
+		return metrics, 0, err
+	}
+
+	var items []interface{}
+	switch v := value.(type) {
+	case *pickleList:
+		items = v.items
+	case []interface{}:
+		items = v
+	default:
+		return metrics, 0, errPickleNotList
+	}
+
+	malformed := 0
+	for _, item := range items {
+		metric, err := pickleMetric(item)
+		if err != nil {
+			malformed++
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+
+	return metrics, malformed, nil
+}
+
+func pickleMetric(item interface{}) (Metric, error) {
+	tuple, ok := pickleSequence(item)
+	if !ok || len(tuple) != 2 {
+		return Metric{}, errPickleInvalidMetric
+	}
+
+	name, ok := tuple[0].([]byte)
+	if !ok || len(name) == 0 {
+		return Metric{}, errPickleInvalidMetric
+	}
+	if !utf8.Valid(name) {
+		return Metric{}, errNotUTF8
+	}
+
+	datapoint, ok := pickleSequence(tuple[1])
+	if !ok || len(datapoint) != 2 {
+		return Metric{}, errPickleInvalidMetric
+	}
+
+	timestamp, err := pickleFloat(datapoint[0])
+	if err != nil {
+		return Metric{}, err
+	}
+	value, err := pickleFloat(datapoint[1])
+	if err != nil {
+		return Metric{}, err
+	}
+
+	return Metric{
+		Name: name,
+		Time: time.Unix(int64(timestamp), 0),
+		Val:  value,
+	}, nil
+}
+
+func pickleSequence(v interface{}) ([]interface{}, bool) {
+	switch v := v.(type) {
+	case []interface{}:
+		return v, true
+	case *pickleList:
+		return v.items, true
+	default:
+		return nil, false
+	}
+}
+
+func pickleFloat(v interface{}) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case int64:
+		return float64(v), nil
+	case []byte:
+		var (
+			value float64
+			err   error
+		)
+		unsafe.WithString(v, func(s string) {
+			if val := strings.ToLower(s); val == negativeNanStr || val == nanStr {
+				value = mathNan
+			} else {
+				value, err = strconv.ParseFloat(s, floatBitSize)
+			}
+		})
+		return value, err
+	default:
+		return 0, errPickleInvalidMetric
+	}
+}
+
+func (d *pickleDecoder) reset(data []byte) {
+	for i := range d.stack {
+		// Free pointers.
+		d.stack[i] = nil
+	}
+	d.data = data
+	d.pos = 0
+	d.stack = d.stack[:0]
+	d.marks = d.marks[:0]
+	for k := range d.memo {
+		delete(d.memo, k)
+	}
+}
+
+func (d *pickleDecoder) decode(data []byte) (interface{}, error) {
+	d.reset(data)
+	for {
+		op, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case pickleOpStop:
+			return d.pop()
+		case pickleOpProto:
+			_, err = d.read(1)
+		case pickleOpFrame:
+			_, err = d.read(8)
+		case pickleOpMark:
+			d.marks = append(d.marks, len(d.stack))
+		case pickleOpPop:
+			_, err = d.pop()
+		case pickleOpPopMark:
+			_, err = d.popMark()
+		case pickleOpDup:
+			var v interface{}
+			if v, err = d.top(); err == nil {
+				d.push(v)
+			}
+		case pickleOpNone:
+			d.push(nil)
+		case pickleOpNewTrue:
+			d.push(true)
+		case pickleOpNewFalse:
+			d.push(false)
+		case pickleOpInt:
+			err = d.loadInt()
+		case pickleOpLong:
+			err = d.loadLong()
+		case pickleOpBinInt:
+			var b []byte
+			if b, err = d.read(4); err == nil {
+				d.push(int64(int32(binary.LittleEndian.Uint32(b))))
+			}
+		case pickleOpBinInt1:
+			var b []byte
+			if b, err = d.read(1); err == nil {
+				d.push(int64(b[0]))
+			}
+		case pickleOpBinInt2:
+			var b []byte
+			if b, err = d.read(2); err == nil {
+				d.push(int64(binary.LittleEndian.Uint16(b)))
+			}
+		case pickleOpLong1:
+			err = d.loadLong1()
+		case pickleOpFloat:
+			var line []byte
+			if line, err = d.readLine(); err == nil {
+				var v float64
+				if v, err = pickleFloat(line); err == nil {
+					d.push(v)
+				}
+			}
+		case pickleOpBinFloat:
+			var b []byte
+			if b, err = d.read(8); err == nil {
+				d.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+			}
+		case pickleOpString:
+			err = d.loadString()
+		case pickleOpUnicode:
+			var line []byte
+			if line, err = d.readLine(); err == nil {
+				if bytes.IndexByte(line, '\\') >= 0 {
+					err = fmt.Errorf("unsupported escaped pickle unicode string: %s", line)
+				} else {
+					d.push(line)
+				}
+			}
+		case pickleOpShortBinString, pickleOpShortBinBytes, pickleOpShortBinUnicode:
+			var b []byte
+			if b, err = d.read(1); err == nil {
+				err = d.loadBytes(int(b[0]))
+			}
+		case pickleOpBinString, pickleOpBinBytes, pickleOpBinUnicode:
+			var b []byte
+			if b, err = d.read(4); err == nil {
+				err = d.loadBytes(int(binary.LittleEndian.Uint32(b)))
+			}
+		case pickleOpEmptyList:
+			d.push(&pickleList{})
+		case pickleOpList:
+			var items []interface{}
+			if items, err = d.popMark(); err == nil {
+				d.push(&pickleList{items: items})
+			}
+		case pickleOpAppend:
+			var v interface{}
+			if v, err = d.pop(); err == nil {
+				err = d.appendToList(v)
+			}
+		case pickleOpAppends:
+			var items []interface{}
+			if items, err = d.popMark(); err == nil {
+				err = d.appendToList(items...)
+			}
+		case pickleOpEmptyTuple:
+			d.push([]interface{}{})
+		case pickleOpTuple:
+			var items []interface{}
+			if items, err = d.popMark(); err == nil {
+				d.push(items)
+			}
+		case pickleOpTuple1, pickleOpTuple2, pickleOpTuple3:
+			err = d.loadTuple(int(op-pickleOpTuple1) + 1)
+		case pickleOpPut:
+			var line []byte
+			if line, err = d.readLine(); err == nil {
+				var idx int64
+				if idx, err = strconv.ParseInt(string(line), 10, 32); err == nil {
+					err = d.put(int(idx))
+				}
+			}
+		case pickleOpBinPut:
+			var b []byte
+			if b, err = d.read(1); err == nil {
+				err = d.put(int(b[0]))
+			}
+		case pickleOpLongBinPut:
+			var b []byte
+			if b, err = d.read(4); err == nil {
+				err = d.put(int(binary.LittleEndian.Uint32(b)))
+			}
+		case pickleOpMemoize:
+			err = d.put(len(d.memo))
+		case pickleOpGet:
+			var line []byte
+			if line, err = d.readLine(); err == nil {
+				var idx int64
+				if idx, err = strconv.ParseInt(string(line), 10, 32); err == nil {
+					err = d.get(int(idx))
+				}
+			}
+		case pickleOpBinGet:
+			var b []byte
+			if b, err = d.read(1); err == nil {
+				err = d.get(int(b[0]))
+			}
+		case pickleOpLongBinGet:
+			var b []byte
+			if b, err = d.read(4); err == nil {
+				err = d.get(int(binary.LittleEndian.Uint32(b)))
+			}
+		default:
+			err = fmt.Errorf("unsupported pickle opcode: 0x%x", op)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (d *pickleDecoder) readByte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, errPickleTruncated
+	}
+	b := d.data[d.pos]
+	d.pos++
+	return b, nil
+}
+
+func (d *pickleDecoder) read(n int) ([]byte, error) {
+	if n < 0 || len(d.data)-d.pos < n {
+		return nil, errPickleTruncated
+	}
+	b := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return b, nil
+}
+
+func (d *pickleDecoder) readLine() ([]byte, error) {
+	idx := bytes.IndexByte(d.data[d.pos:], '\n')
+	if idx < 0 {
+		return nil, errPickleTruncated
+	}
+	line := d.data[d.pos : d.pos+idx]
+	d.pos += idx + 1
+	return line, nil
+}
+
+func (d *pickleDecoder) push(v interface{}) {
+	d.stack = append(d.stack, v)
+}
+
+func (d *pickleDecoder) top() (interface{}, error) {
+	if len(d.stack) == 0 {
+		return nil, errPickleStackUnderflow
+	}
+	return d.stack[len(d.stack)-1], nil
+}
+
+func (d *pickleDecoder) pop() (interface{}, error) {
+	v, err := d.top()
+	if err != nil {
+		return nil, err
+	}
+	d.stack = d.stack[:len(d.stack)-1]
+	return v, nil
+}
+
+func (d *pickleDecoder) popMark() ([]interface{}, error) {
+	if len(d.marks) == 0 {
+		return nil, errPickleMarkNotFound
+	}
+	mark := d.marks[len(d.marks)-1]
+	d.marks = d.marks[:len(d.marks)-1]
+
+	// Copy the items since the stack is reused.
+	items := append([]interface{}(nil), d.stack[mark:]...)
+	d.stack = d.stack[:mark]
+	return items, nil
+}
+
+func (d *pickleDecoder) appendToList(items ...interface{}) error {
+	v, err := d.top()
+	if err != nil {
+		return err
+	}
+	list, ok := v.(*pickleList)
+	if !ok {
+		return errPickleNotList
+	}
+	list.items = append(list.items, items...)
+	return nil
+}
+
+func (d *pickleDecoder) loadTuple(n int) error {
+	if len(d.stack) < n {
+		return errPickleStackUnderflow
+	}
+	items := append([]interface{}(nil), d.stack[len(d.stack)-n:]...)
+	d.stack = d.stack[:len(d.stack)-n]
+	d.push(items)
+	return nil
+}
+
+func (d *pickleDecoder) loadInt() error {
+	line, err := d.readLine()
+	if err != nil {
+		return err
+	}
+
+	// Protocol 0 encodes booleans as the "I01" and "I00" integers.
+	switch string(line) {
+	case "01":
+		d.push(true)
+		return nil
+	case "00":
+		d.push(false)
+		return nil
+	}
+
+	v, err := strconv.ParseInt(string(line), 10, 64)
+	if err != nil {
+		return err
+	}
+	d.push(v)
+	return nil
+}
+
+func (d *pickleDecoder) loadLong() error {
+	line, err := d.readLine()
+	if err != nil {
+		return err
+	}
+
+	v, err := strconv.ParseInt(string(bytes.TrimSuffix(line, []byte("L"))), 10, 64)
+	if err != nil {
+		return err
+	}
+	d.push(v)
+	return nil
+}
+
+func (d *pickleDecoder) loadLong1() error {
+	b, err := d.read(1)
+	if err != nil {
+		return err
+	}
+	n := int(b[0])
+	if n > 8 {
+		return fmt.Errorf("unsupported pickle long size: %d", n)
+	}
+	if b, err = d.read(n); err != nil {
+		return err
+	}
+
+	// Longs are encoded as little endian two's complement integers.
+	var v uint64
+	for i := n - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	if n > 0 && n < 8 && b[n-1]&0x80 != 0 {
+		v |= math.MaxUint64 << (8 * uint(n))
+	}
+	d.push(int64(v))
+	return nil
+}
+
+func (d *pickleDecoder) loadString() error {
+	line, err := d.readLine()
+	if err != nil {
+		return err
+	}
+
+	if len(line) < 2 || line[0] != line[len(line)-1] ||
+		(line[0] != '\'' && line[0] != '"') {
+		return fmt.Errorf("invalid pickle string: %s", line)
+	}
+	line = line[1 : len(line)-1]
+	if bytes.IndexByte(line, '\\') >= 0 {
+		return fmt.Errorf("unsupported escaped pickle string: %s", line)
+	}
+
+	d.push(line)
+	return nil
+}
+
+func (d *pickleDecoder) loadBytes(n int) error {
+	b, err := d.read(n)
+	if err != nil {
+		return err
+	}
+	d.push(b)
+	return nil
+}
+
+func (d *pickleDecoder) put(idx int) error {
+	v, err := d.top()
+	if err != nil {
+		return err
+	}
+	if d.memo == nil {
+		d.memo = make(map[int]interface{})
+	}
+	d.memo[idx] = v
+	return nil
+}
+
+func (d *pickleDecoder) get(idx int) error {
+	v, ok := d.memo[idx]
+	if !ok {
+		return fmt.Errorf("pickle memo key not found: %d", idx)
+	}
+	d.push(v)
+	return nil
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package carbon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Pickled with python pickle.dumps([("foo.bar", (1428951394, 1.5)),
+// ("foo.baz;dc=east", (1428951394.0, -2)), ("foo.qux", (1428951394, "3"))]).
+var testPickleMessages = map[string]string{
+	"protocol 0": "(lp0\n(Vfoo.bar\np1\n(I1428951394\nF1.5\ntp2\ntp3\na" +
+		"(Vfoo.baz;dc=east\np4\n(F1428951394.0\nI-2\ntp5\ntp6\na" +
+		"(Vfoo.qux\np7\n(I1428951394\nV3\np8\ntp9\ntp10\na.",
+	"protocol 2": "\x80\x02\x5d\x71\x00\x28\x58\x07\x00\x00\x00\x66\x6f\x6f\x2e\x62" +
+		"\x61\x72\x71\x01\x4a\x62\x11\x2c\x55\x47\x3f\xf8\x00\x00\x00\x00\x00\x00" +
+		"\x86\x71\x02\x86\x71\x03\x58\x0f\x00\x00\x00\x66\x6f\x6f\x2e\x62\x61\x7a" +
+		"\x3b\x64\x63\x3d\x65\x61\x73\x74\x71\x04\x47\x41\xd5\x4b\x04\x58\x80\x00" +
+		"\x00\x4a\xfe\xff\xff\xff\x86\x71\x05\x86\x71\x06\x58\x07\x00\x00\x00\x66" +
+		"\x6f\x6f\x2e\x71\x75\x78\x71\x07\x4a\x62\x11\x2c\x55\x58\x01\x00\x00\x00" +
+		"\x33\x71\x08\x86\x71\x09\x86\x71\x0a\x65\x2e",
+	"protocol 4": "\x80\x04\x95\x5c\x00\x00\x00\x00\x00\x00\x00\x5d\x94\x28\x8c\x07" +
+		"\x66\x6f\x6f\x2e\x62\x61\x72\x94\x4a\x62\x11\x2c\x55\x47\x3f\xf8\x00\x00" +
+		"\x00\x00\x00\x00\x86\x94\x86\x94\x8c\x0f\x66\x6f\x6f\x2e\x62\x61\x7a\x3b" +
+		"\x64\x63\x3d\x65\x61\x73\x74\x94\x47\x41\xd5\x4b\x04\x58\x80\x00\x00\x4a" +
+		"\xfe\xff\xff\xff\x86\x94\x86\x94\x8c\x07\x66\x6f\x6f\x2e\x71\x75\x78\x94" +
+		"\x4a\x62\x11\x2c\x55\x8c\x01\x33\x94\x86\x94\x86\x94\x65\x2e",
+}
+
+var testPickleMetrics = []Metric{
+	{Name: []byte("foo.bar"), Time: time.Unix(1428951394, 0), Val: 1.5},
+	{Name: []byte("foo.baz;dc=east"), Time: time.Unix(1428951394, 0), Val: -2},
+	{Name: []byte("foo.qux"), Time: time.Unix(1428951394, 0), Val: 3},
+}
+
+func TestParsePickleMessage(t *testing.T) {
+	for name, message := range testPickleMessages {
+		t.Run(name, func(t *testing.T) {
+			metrics, malformed, err := ParsePickleMessage([]byte(message))
+			require.NoError(t, err)
+			require.Equal(t, 0, malformed)
+			require.Equal(t, testPickleMetrics, metrics)
+		})
+	}
+}
+
+func TestParsePickleMessageMalformedMetrics(t *testing.T) {
+	// Pickled with python pickle.dumps([("a", (1, -2**40)), ("bad",),
+	// ("b", (1, 2))], protocol=2).
+	message := "\x80\x02\x5d\x71\x00\x28\x58\x01\x00\x00\x00\x61\x71\x01\x4b\x01" +
+		"\x8a\x06\x00\x00\x00\x00\x00\xff\x86\x71\x02\x86\x71\x03\x58\x03\x00\x00" +
+		"\x00\x62\x61\x64\x71\x04\x85\x71\x05\x58\x01\x00\x00\x00\x62\x71\x06\x4b" +
+		"\x01\x4b\x02\x86\x71\x07\x86\x71\x08\x65\x2e"
+
+	metrics, malformed, err := ParsePickleMessage([]byte(message))
+	require.NoError(t, err)
+	require.Equal(t, 1, malformed)
+	require.Equal(t, []Metric{
+		{Name: []byte("a"), Time: time.Unix(1, 0), Val: -(1 << 40)},
+		{Name: []byte("b"), Time: time.Unix(1, 0), Val: 2},
+	}, metrics)
+}
+
+func TestParsePickleMessageErrors(t *testing.T) {
+	for _, message := range []string{
+		"",
+		"(lp0\n(Vfoo.bar\n",
+		"\x80\x02K\x01.",
+		"\x80\x02cos\nsystem\n.",
+		"a.",
+	} {
+		_, _, err := ParsePickleMessage([]byte(message))
+		require.Error(t, err, message)
+	}
+}
+
+func TestPickleScanner(t *testing.T) {
+	var buf bytes.Buffer
+	writeMessage := func(message string) {
+		var header [pickleHeaderSize]byte
+		binary.BigEndian.PutUint32(header[:], uint32(len(message)))
+		buf.Write(header[:])
+		buf.WriteString(message)
+	}
+	writeMessage(testPickleMessages["protocol 2"])
+	writeMessage("\x80\x02K\x01.")
+	writeMessage(testPickleMessages["protocol 0"])
+
+	var (
+		s       = NewPickleScanner(&buf, testIOpts)
+		metrics []Metric
+	)
+	for s.Scan() {
+		name, timestamp, value := s.Metric()
+		metrics = append(metrics, Metric{
+			Name: append([]byte(nil), name...),
+			Time: timestamp,
+			Val:  value,
+		})
+	}
+	require.NoError(t, s.Err())
+	require.Equal(t, 1, s.MalformedCount)
+	require.Equal(t, append(testPickleMetrics, testPickleMetrics...), metrics)
+}
+
+func TestPickleScannerMessageTooLarge(t *testing.T) {
+	var header [pickleHeaderSize]byte
+	binary.BigEndian.PutUint32(header[:], maxPickleMessageSize+1)
+
+	s := NewPickleScanner(bytes.NewReader(header[:]), testIOpts)
+	require.False(t, s.Scan())
+	require.Error(t, s.Err())
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// BackupURL is the url for the namespace backup endpoint.
+	BackupURL = route.Prefix + "/database/backup"
+	// BackupHTTPMethod is the HTTP method used with the backup endpoint.
+	BackupHTTPMethod = http.MethodPost
+
+	// RestoreURL is the url for the namespace restore endpoint.
+	RestoreURL = route.Prefix + "/database/restore"
+	// RestoreHTTPMethod is the HTTP method used with the restore endpoint.
+	RestoreHTTPMethod = http.MethodPost
+
+	// backupIDFormat is the time format of the default backup ID.
+	backupIDFormat = "20060102T150405Z"
+)
+
+var (
+	errBackupNoClusters     = errors.New("no M3DB clusters configured")
+	errBackupNoNamespace    = errors.New("namespace is required")
+	errBackupNoAdminSession = errors.New("cluster session does not support backups")
+)
+
+// BackupRequest is a request to back up a namespace.
+type BackupRequest struct {
+	// Namespace to back up.
+	Namespace string `json:"namespace"`
+	// BackupID is the ID of the backup, defaults to the current UTC time.
+	BackupID string `json:"backupID"`
+	// Hosts to back up, defaults to all hosts.
+	Hosts []string `json:"hosts"`
+}
+
+// RestoreRequest is a request to restore a namespace.
+type RestoreRequest struct {
+	// Namespace to restore.
+	Namespace string `json:"namespace"`
+	// BackupID is the ID of the backup to restore. If empty the latest
+	// backup taken at or before the point in time is restored.
+	BackupID string `json:"backupID"`
+	// PointInTime is an RFC3339 time used to select the backup to restore
+	// when no backup ID is set, defaults to the latest backup.
+	PointInTime string `json:"pointInTime"`
+	// Hosts to restore, defaults to all hosts.
+	Hosts []string `json:"hosts"`
+}
+
+// BackupResponse is the response of a backup or restore.
+type BackupResponse struct {
+	// BackupID is the ID of the backup requested.
+	BackupID string `json:"backupID"`
+	// Hosts are the results of each host.
+	Hosts []BackupHostResponse `json:"hosts"`
+}
+
+// BackupHostResponse is the result of a backup or restore on a host.
+type BackupHostResponse struct {
+	HostID   string `json:"hostID"`
+	BackupID string `json:"backupID"`
+	NumFiles int64  `json:"numFiles"`
+	NumBytes int64  `json:"numBytes"`
+}
+
+// BackupHandler is the handler for namespace backups.
+type BackupHandler struct {
+	clusters       m3.Clusters
+	nowFn          clock.NowFn
+	instrumentOpts instrument.Options
+}
+
+// NewBackupHandler returns a new instance of the backup handler.
+func NewBackupHandler(opts options.HandlerOptions) http.Handler {
+	return &BackupHandler{
+		clusters:       opts.Clusters(),
+		nowFn:          opts.NowFn(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *BackupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context(), h.instrumentOpts)
+
+	var req BackupRequest
+	if err := parseBackupRequest(r, &req); err != nil {
+		logger.Error("unable to parse request", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+	if req.Namespace == "" {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(errBackupNoNamespace))
+		return
+	}
+	if req.BackupID == "" {
+		req.BackupID = h.nowFn().UTC().Format(backupIDFormat)
+	}
+
+	session, err := backupSession(h.clusters, req.Namespace)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	results, err := session.Backup(ident.StringID(req.Namespace), req.BackupID, req.Hosts)
+	if err != nil {
+		logger.Error("unable to back up namespace",
+			zap.String("namespace", req.Namespace),
+			zap.String("backupID", req.BackupID),
+			zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	logger.Info("backed up namespace",
+		zap.String("namespace", req.Namespace),
+		zap.String("backupID", req.BackupID),
+		zap.Int("numHosts", len(results)))
+	xhttp.WriteJSONResponse(w, newBackupResponse(req.BackupID, results), logger)
+}
+
+// RestoreHandler is the handler for namespace restores.
+type RestoreHandler struct {
+	clusters       m3.Clusters
+	instrumentOpts instrument.Options
+}
+
+// NewRestoreHandler returns a new instance of the restore handler.
+func NewRestoreHandler(opts options.HandlerOptions) http.Handler {
+	return &RestoreHandler{
+		clusters:       opts.Clusters(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *RestoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context(), h.instrumentOpts)
+
+	var req RestoreRequest
+	if err := parseBackupRequest(r, &req); err != nil {
+		logger.Error("unable to parse request", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+	if req.Namespace == "" {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(errBackupNoNamespace))
+		return
+	}
+
+	var pointInTime xtime.UnixNano
+	if req.PointInTime != "" {
+		t, err := time.Parse(time.RFC3339, req.PointInTime)
+		if err != nil {
+			xhttp.WriteError(w, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid pointInTime: %w", err)))
+			return
+		}
+		pointInTime = xtime.ToUnixNano(t)
+	}
+
+	session, err := backupSession(h.clusters, req.Namespace)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	results, err := session.Restore(ident.StringID(req.Namespace), req.BackupID,
+		pointInTime, req.Hosts)
+	if err != nil {
+		logger.Error("unable to restore namespace",
+			zap.String("namespace", req.Namespace),
+			zap.String("backupID", req.BackupID),
+			zap.String("pointInTime", req.PointInTime),
+			zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	logger.Info("restored namespace",
+		zap.String("namespace", req.Namespace),
+		zap.String("backupID", req.BackupID),
+		zap.Int("numHosts", len(results)))
+	xhttp.WriteJSONResponse(w, newBackupResponse(req.BackupID, results), logger)
+}
+
+func parseBackupRequest(r *http.Request, req interface{}) error {
+	defer r.Body.Close()
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		return xerrors.NewInvalidParamsError(err)
+	}
+	return nil
+}
+
+// backupSession returns the admin session of the cluster of the namespace.
+// Since a namespace being restored may no longer be known to the
+// coordinator, the session of the unaggregated namespace is used for
+// unknown namespaces, all namespaces sharing the same cluster.
+func backupSession(clusters m3.Clusters, namespace string) (client.AdminSession, error) {
+	if clusters == nil {
+		return nil, errBackupNoClusters
+	}
+
+	var session client.Session
+	for _, namespaces := range []m3.ClusterNamespaces{
+		clusters.ClusterNamespaces(),
+		clusters.NonReadyClusterNamespaces(),
+	} {
+		for _, ns := range namespaces {
+			if ns.NamespaceID().String() == namespace {
+				session = ns.Session()
+				break
+			}
+		}
+		if session != nil {
+			break
+		}
+	}
+	if session == nil {
+		ns, ok := clusters.UnaggregatedClusterNamespace()
+		if !ok {
+			return nil, errBackupNoClusters
+		}
+		session = ns.Session()
+	}
+
+	adminSession, ok := session.(client.AdminSession)
+	if !ok {
+		return nil, errBackupNoAdminSession
+	}
+	return adminSession, nil
+}
+
+func newBackupResponse(backupID string, results []client.BackupHostResult) BackupResponse {
+	resp := BackupResponse{
+		BackupID: backupID,
+		Hosts:    make([]BackupHostResponse, 0, len(results)),
+	}
+	for _, result := range results {
+		resp.Hosts = append(resp.Hosts, BackupHostResponse{
+			HostID:   result.HostID,
+			BackupID: result.BackupID,
+			NumFiles: result.NumFiles,
+			NumBytes: result.NumBytes,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package database
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestBackupClusters(ctrl *gomock.Controller, session client.AdminSession) m3.Clusters {
+	ns := m3.NewMockClusterNamespace(ctrl)
+	ns.EXPECT().NamespaceID().Return(ident.StringID("default")).AnyTimes()
+	ns.EXPECT().Session().Return(session).AnyTimes()
+	clusters := m3.NewMockClusters(ctrl)
+	clusters.EXPECT().ClusterNamespaces().Return(m3.ClusterNamespaces{ns}).AnyTimes()
+	clusters.EXPECT().NonReadyClusterNamespaces().Return(nil).AnyTimes()
+	clusters.EXPECT().UnaggregatedClusterNamespace().Return(ns, true).AnyTimes()
+	return clusters
+}
+
+func TestBackupHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockAdminSession(ctrl)
+	now := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	h := NewBackupHandler(options.EmptyHandlerOptions().
+		SetClusters(newTestBackupClusters(ctrl, session)).
+		SetNowFn(func() time.Time { return now }))
+
+	session.EXPECT().
+		Backup(ident.NewIDMatcher("metrics"), "20210304T050607Z", []string{"host0"}).
+		Return([]client.BackupHostResult{
+			{HostID: "host0", BackupID: "20210304T050607Z", NumFiles: 3, NumBytes: 42},
+		}, nil)
+
+	req := httptest.NewRequest(BackupHTTPMethod, BackupURL,
+		strings.NewReader(`{"namespace":"metrics","hosts":["host0"]}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp BackupResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, BackupResponse{
+		BackupID: "20210304T050607Z",
+		Hosts: []BackupHostResponse{
+			{HostID: "host0", BackupID: "20210304T050607Z", NumFiles: 3, NumBytes: 42},
+		},
+	}, resp)
+
+	// A namespace is required.
+	req = httptest.NewRequest(BackupHTTPMethod, BackupURL, strings.NewReader(`{}`))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRestoreHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockAdminSession(ctrl)
+	h := NewRestoreHandler(options.EmptyHandlerOptions().
+		SetClusters(newTestBackupClusters(ctrl, session)))
+
+	pointInTime := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	session.EXPECT().
+		Restore(ident.NewIDMatcher("metrics"), "", xtime.ToUnixNano(pointInTime), nil).
+		Return([]client.BackupHostResult{
+			{HostID: "host0", BackupID: "backup0", NumFiles: 3, NumBytes: 42},
+		}, nil)
+
+	req := httptest.NewRequest(RestoreHTTPMethod, RestoreURL,
+		strings.NewReader(`{"namespace":"metrics","pointInTime":"2021-03-04T05:06:07Z"}`))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	var resp BackupResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 1, len(resp.Hosts))
+	assert.Equal(t, "backup0", resp.Hosts[0].BackupID)
+
+	// The point in time must be an RFC3339 time.
+	req = httptest.NewRequest(RestoreHTTPMethod, RestoreURL,
+		strings.NewReader(`{"namespace":"metrics","pointInTime":"yesterday"}`))
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
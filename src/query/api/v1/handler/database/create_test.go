@@ -192,6 +192,7 @@ func testLocalType(t *testing.T, providedType string, placementExists bool) {
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
+						"compression": "NO_COMPRESSION",
 						"extendedOptions": null,
 						"stagingState": {
 							"status": "UNKNOWN"
@@ -371,6 +372,7 @@ func TestLocalTypeWithNumShards(t *testing.T) {
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
+						"compression": "NO_COMPRESSION",
 						"extendedOptions": null,
 						"stagingState": {
 							"status": "UNKNOWN"
@@ -503,6 +505,7 @@ func TestLocalWithBlockSizeNanos(t *testing.T) {
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
+						"compression": "NO_COMPRESSION",
 						"extendedOptions": null,
 						"stagingState": {
 							"status": "UNKNOWN"
@@ -640,6 +643,7 @@ func TestLocalWithBlockSizeExpectedSeriesDatapointsPerHour(t *testing.T) {
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
+						"compression": "NO_COMPRESSION",
 						"extendedOptions": null,
 						"stagingState": {
 							"status": "UNKNOWN"
@@ -907,6 +911,7 @@ func testClusterTypeHosts(t *testing.T, placementExists bool) {
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
+						"compression": "NO_COMPRESSION",
 						"extendedOptions": null,
 						"stagingState": {
 							"status": "UNKNOWN"
@@ -1068,6 +1073,7 @@ func TestClusterTypeHostsWithIsolationGroup(t *testing.T) {
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
+						"compression": "NO_COMPRESSION",
 						"extendedOptions": null,
 						"stagingState": {
 							"status": "UNKNOWN"
@@ -1292,6 +1298,7 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
+						"compression": "NO_COMPRESSION",
 						"extendedOptions": null,
 						"stagingState": {
 							"status": "UNKNOWN"
@@ -1334,6 +1341,7 @@ func TestLocalTypeWithAggregatedNamespace(t *testing.T) {
 						"runtimeOptions": null,
 						"schemaOptions": null,
 						"coldWritesEnabled": false,
+						"compression": "NO_COMPRESSION",
 						"extendedOptions": null,
 						"stagingState": {
 							"status": "UNKNOWN"
@@ -0,0 +1,273 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/graphite/events"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	"github.com/m3db/m3/src/query/util/logging"
+	"github.com/m3db/m3/src/x/clock"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// EventsURL is the url for adding graphite events.
+	EventsURL = route.Prefix + "/graphite/events/"
+
+	// EventsGetDataURL is the url for querying graphite events.
+	EventsGetDataURL = EventsURL + "get_data"
+)
+
+var (
+	// EventsHTTPMethods are the HTTP methods for adding graphite events.
+	EventsHTTPMethods = []string{http.MethodPost}
+
+	// EventsGetDataHTTPMethods are the HTTP methods for querying graphite
+	// events.
+	EventsGetDataHTTPMethods = []string{http.MethodGet}
+
+	errEventsNotEnabled = errors.New("graphite events are not enabled")
+	errEventTags        = errors.New("event tags must be a list or a space separated string")
+)
+
+type eventsHandler struct {
+	store          events.Store
+	nowFn          clock.NowFn
+	instrumentOpts instrument.Options
+}
+
+// NewEventsHandler returns a new handler adding graphite events.
+func NewEventsHandler(opts options.HandlerOptions) http.Handler {
+	return newEventsHandler(opts)
+}
+
+func newEventsHandler(opts options.HandlerOptions) *eventsHandler {
+	return &eventsHandler{
+		store:          opts.GraphiteEventsStore(),
+		nowFn:          opts.NowFn(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+// eventTags are the tags of an added event which graphite accepts either
+// as a list or as a space separated string.
+type eventTags []string
+
+func (t *eventTags) UnmarshalJSON(data []byte) error {
+	var tags []string
+	if err := json.Unmarshal(data, &tags); err == nil {
+		*t = tags
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return errEventTags
+	}
+	*t = strings.Fields(str)
+	return nil
+}
+
+type addEventRequest struct {
+	What string    `json:"what"`
+	Data string    `json:"data"`
+	Tags eventTags `json:"tags"`
+	When *float64  `json:"when"`
+}
+
+type eventJSON struct {
+	ID   string   `json:"id"`
+	When int64    `json:"when"`
+	What string   `json:"what"`
+	Data string   `json:"data"`
+	Tags []string `json:"tags"`
+}
+
+func newEventJSON(event events.Event) eventJSON {
+	tags := event.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	return eventJSON{
+		ID:   event.ID,
+		When: event.When.Unix(),
+		What: event.What,
+		Data: event.Data,
+		Tags: tags,
+	}
+}
+
+func parseAddEventRequest(r *http.Request, now time.Time) (events.Event, error) {
+	var req addEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return events.Event{}, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid event: %w", err))
+	}
+
+	if req.What == "" {
+		return events.Event{}, xerrors.NewInvalidParamsError(
+			errors.New("missing 'what' field"))
+	}
+
+	when := now
+	if req.When != nil {
+		if math.IsNaN(*req.When) || math.IsInf(*req.When, 0) {
+			return events.Event{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid 'when': %f", *req.When))
+		}
+		sec, frac := math.Modf(*req.When)
+		when = time.Unix(int64(sec), int64(frac*float64(time.Second)))
+	}
+
+	return events.Event{
+		When: when,
+		What: req.What,
+		Data: req.Data,
+		Tags: req.Tags,
+	}, nil
+}
+
+func (h *eventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context(), h.instrumentOpts)
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	if h.store == nil {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(errEventsNotEnabled))
+		return
+	}
+
+	event, err := parseAddEventRequest(r, h.nowFn())
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	event, err = h.store.Add(r.Context(), event)
+	if err != nil {
+		logger.Error("unable to add graphite event", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(newEventJSON(event)); err != nil {
+		logger.Error("unable to render graphite event", zap.Error(err))
+	}
+}
+
+type eventsGetDataHandler struct {
+	eventsHandler
+}
+
+// NewEventsGetDataHandler returns a new handler querying graphite events.
+func NewEventsGetDataHandler(opts options.HandlerOptions) http.Handler {
+	return &eventsGetDataHandler{
+		eventsHandler: *newEventsHandler(opts),
+	}
+}
+
+func parseEventsQuery(r *http.Request, now time.Time) (events.Query, error) {
+	if err := r.ParseForm(); err != nil {
+		return events.Query{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	fromString, untilString := r.FormValue("from"), r.FormValue("until")
+	if len(fromString) == 0 {
+		fromString = "0"
+	}
+	if len(untilString) == 0 {
+		untilString = "now"
+	}
+
+	var (
+		query events.Query
+		err   error
+	)
+	query.Start, err = graphite.ParseTime(fromString, now, tzOffsetForAbsoluteTime)
+	if err != nil {
+		return events.Query{}, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid 'from': %s", fromString))
+	}
+	query.End, err = graphite.ParseTime(untilString, now, tzOffsetForAbsoluteTime)
+	if err != nil {
+		return events.Query{}, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid 'until': %s", untilString))
+	}
+	// NB: events at the until time are included like graphite does.
+	query.End = query.End.Add(time.Second)
+
+	query.Tags = events.NormalizeTags(r.Form["tags"])
+	switch set := r.FormValue("set"); set {
+	case "", "intersection":
+		query.Set = events.IntersectionSetOperation
+	case "union":
+		query.Set = events.UnionSetOperation
+	default:
+		return events.Query{}, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid 'set': %s", set))
+	}
+
+	return query, nil
+}
+
+func (h *eventsGetDataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := logging.WithContext(r.Context(), h.instrumentOpts)
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	if h.store == nil {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(errEventsNotEnabled))
+		return
+	}
+
+	query, err := parseEventsQuery(r, h.nowFn())
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	found, err := h.store.Find(r.Context(), query)
+	if err != nil {
+		logger.Error("unable to find graphite events", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	results := make([]eventJSON, 0, len(found))
+	for _, event := range found {
+		results = append(results, newEventJSON(event))
+	}
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		logger.Error("unable to render graphite events", zap.Error(err))
+	}
+}
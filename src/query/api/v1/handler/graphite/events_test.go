@@ -0,0 +1,168 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/graphite/events"
+)
+
+type testEventsStore struct {
+	added []events.Event
+	found []events.Event
+	query events.Query
+}
+
+func (s *testEventsStore) Add(_ context.Context, event events.Event) (events.Event, error) {
+	event.ID = "id"
+	s.added = append(s.added, event)
+	return event, nil
+}
+
+func (s *testEventsStore) Find(_ context.Context, query events.Query) ([]events.Event, error) {
+	s.query = query
+	return s.found, nil
+}
+
+func newTestEventsHandlerOptions(store events.Store) options.HandlerOptions {
+	now := time.Unix(1600000000, 0)
+	return options.EmptyHandlerOptions().
+		SetGraphiteEventsStore(store).
+		SetNowFn(func() time.Time { return now })
+}
+
+func TestEventsHandler(t *testing.T) {
+	store := &testEventsStore{}
+	h := NewEventsHandler(newTestEventsHandlerOptions(store))
+
+	tests := []struct {
+		body     string
+		expected events.Event
+	}{
+		{
+			body: `{"what":"deploy","tags":"deploy master","data":"v1","when":1500000000}`,
+			expected: events.Event{
+				ID:   "id",
+				When: time.Unix(1500000000, 0),
+				What: "deploy",
+				Data: "v1",
+				Tags: []string{"deploy", "master"},
+			},
+		},
+		{
+			body: `{"what":"restart","tags":["db"]}`,
+			expected: events.Event{
+				ID:   "id",
+				When: time.Unix(1600000000, 0),
+				What: "restart",
+				Tags: []string{"db"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, EventsURL, strings.NewReader(tt.body))
+		h.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		added := store.added[len(store.added)-1]
+		assert.True(t, tt.expected.When.Equal(added.When))
+		added.When = tt.expected.When
+		assert.Equal(t, tt.expected, added)
+
+		var actual eventJSON
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &actual))
+		assert.Equal(t, newEventJSON(tt.expected), actual)
+	}
+}
+
+func TestEventsHandlerErrors(t *testing.T) {
+	for _, body := range []string{
+		`{"tags":"deploy"}`,
+		`{"what":"deploy","tags":1}`,
+		`not json`,
+	} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, EventsURL, strings.NewReader(body))
+		NewEventsHandler(newTestEventsHandlerOptions(&testEventsStore{})).ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, EventsURL, strings.NewReader(`{"what":"deploy"}`))
+	NewEventsHandler(options.EmptyHandlerOptions()).ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestEventsGetDataHandler(t *testing.T) {
+	store := &testEventsStore{
+		found: []events.Event{
+			{
+				ID:   "a",
+				When: time.Unix(1500000000, 0),
+				What: "deploy",
+				Data: "v1",
+				Tags: []string{"deploy"},
+			},
+			{
+				ID:   "b",
+				When: time.Unix(1500000060, 0),
+				What: "restart",
+			},
+		},
+	}
+	h := NewEventsGetDataHandler(newTestEventsHandlerOptions(store))
+
+	params := url.Values{
+		"from":  []string{"1499990000"},
+		"until": []string{"1500010000"},
+		"tags":  []string{"deploy restart"},
+		"set":   []string{"union"},
+	}
+	w := serveTagsRequest(h, EventsGetDataURL, params)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	assert.True(t, time.Unix(1499990000, 0).Equal(store.query.Start))
+	assert.True(t, time.Unix(1500010001, 0).Equal(store.query.End))
+	assert.Equal(t, []string{"deploy", "restart"}, store.query.Tags)
+	assert.Equal(t, events.UnionSetOperation, store.query.Set)
+
+	expected := `[{"id":"a","when":1500000000,"what":"deploy","data":"v1","tags":["deploy"]},` +
+		`{"id":"b","when":1500000060,"what":"restart","data":"","tags":[]}]`
+	assert.JSONEq(t, expected, w.Body.String())
+
+	params.Set("set", "invalid")
+	w = serveTagsRequest(h, EventsGetDataURL, params)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
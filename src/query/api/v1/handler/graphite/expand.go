@@ -0,0 +1,565 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/errors"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xsync "github.com/m3db/m3/src/x/sync"
+)
+
+const (
+	// ExpandURL is the url for expanding graphite metric paths.
+	ExpandURL = route.Prefix + "/graphite/metrics/expand"
+
+	// IndexJSONURL is the url for listing every graphite metric path.
+	IndexJSONURL = route.Prefix + "/graphite/metrics/index.json"
+
+	// streamFlushPaths is the number of paths written to a streamed
+	// response between each flush to the client.
+	streamFlushPaths = 1000
+
+	// indexWalkConcurrency is the maximum number of finds in flight while
+	// walking the metric tree for a single index request.
+	indexWalkConcurrency = 8
+)
+
+var (
+	// ExpandHTTPMethods are the HTTP methods for the expand handler.
+	ExpandHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	// IndexJSONHTTPMethods are the HTTP methods for the index handler.
+	IndexJSONHTTPMethods = []string{http.MethodGet, http.MethodPost}
+)
+
+type metricsParams struct {
+	queries     []string
+	from        time.Time
+	until       time.Time
+	leavesOnly  bool
+	groupByExpr bool
+	stream      bool
+}
+
+func parseMetricsParams(r *http.Request, requireQuery bool) (metricsParams, error) {
+	if err := r.ParseForm(); err != nil {
+		return metricsParams{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	var (
+		params metricsParams
+		seen   = make(map[string]struct{}, len(r.Form["query"]))
+		err    error
+	)
+	for _, query := range r.Form["query"] {
+		if _, ok := seen[query]; ok || query == "" {
+			continue
+		}
+		seen[query] = struct{}{}
+		params.queries = append(params.queries, query)
+	}
+	if requireQuery && len(params.queries) == 0 {
+		return metricsParams{}, xerrors.NewInvalidParamsError(errors.ErrNoQueryFound)
+	}
+
+	params.from, params.until, err = parseFindTimeRange(r)
+	if err != nil {
+		return metricsParams{}, err
+	}
+
+	for _, p := range []struct {
+		name  string
+		value *bool
+	}{
+		{name: "leavesOnly", value: &params.leavesOnly},
+		{name: "groupByExpr", value: &params.groupByExpr},
+		{name: "stream", value: &params.stream},
+	} {
+		str := r.Form.Get(p.name)
+		if str == "" {
+			continue
+		}
+		*p.value, err = strconv.ParseBool(str)
+		if err != nil {
+			return metricsParams{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid '%s': %s", p.name, str))
+		}
+	}
+
+	return params, nil
+}
+
+// pathStreamWriter writes paths to a chunked response, flushing them to the
+// client periodically so that the full result set is never buffered.
+type pathStreamWriter struct {
+	jw      json.Writer
+	flusher http.Flusher
+	pending int
+	flushed bool
+}
+
+func newPathStreamWriter(w http.ResponseWriter) *pathStreamWriter {
+	flusher, _ := w.(http.Flusher)
+	return &pathStreamWriter{
+		jw:      json.NewWriter(w),
+		flusher: flusher,
+	}
+}
+
+func (s *pathStreamWriter) writePath(path string) error {
+	s.jw.WriteString(path)
+	s.pending++
+	if s.pending < streamFlushPaths {
+		return nil
+	}
+	return s.flush()
+}
+
+// writeErrorField writes the error as the error field of the object being
+// written, so that clients can tell a failed streamed response, the status of
+// which was already sent, from a complete one.
+func (s *pathStreamWriter) writeErrorField(err error) {
+	s.jw.BeginObjectField("error")
+	s.jw.WriteString(err.Error())
+}
+
+func (s *pathStreamWriter) flush() error {
+	s.pending = 0
+	s.flushed = true
+	if err := s.jw.Flush(); err != nil {
+		return err
+	}
+	if s.flusher != nil {
+		s.flusher.Flush()
+	}
+	return nil
+}
+
+type expandHandler struct {
+	finder *grahiteFindHandler
+}
+
+// NewExpandHandler returns a new instance of the graphite expand handler,
+// which returns the paths matching each of the given queries.
+func NewExpandHandler(opts options.HandlerOptions) http.Handler {
+	return &expandHandler{finder: newFindHandler(opts)}
+}
+
+func (h *expandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, opts, err := h.finder.fetchOptionsBuilder.NewFetchOptions(r.Context(), r)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	logger := logging.WithContext(ctx, h.finder.instrumentOpts)
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	params, err := parseMetricsParams(r, true)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if params.stream {
+		// NB: result metadata headers cannot be set once streaming has
+		// started, so streamed responses never carry them.
+		sw := newPathStreamWriter(w)
+		if err := h.stream(ctx, sw, params, opts); err != nil {
+			logger.Error("unable to stream expand results", zap.Error(err))
+			if !sw.flushed {
+				xhttp.WriteError(w, err)
+			}
+		}
+		return
+	}
+
+	var (
+		results = make(map[string][]string, len(params.queries))
+		meta    = block.NewResultMetadata()
+	)
+	for _, query := range params.queries {
+		paths, queryMeta, err := h.expand(ctx, query, params, opts)
+		if err != nil {
+			logger.Error("unable to expand search", zap.Error(err))
+			xhttp.WriteError(w, err)
+			return
+		}
+		results[query] = paths
+		meta = meta.CombineMetadata(queryMeta)
+	}
+
+	if err := handleroptions.AddDBResultResponseHeaders(w, meta, opts); err != nil {
+		logger.Error("unable to render expand header", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if err := expandResultsJSON(w, params, results); err != nil {
+		logger.Error("unable to render expand results", zap.Error(err))
+	}
+}
+
+// expand returns the sorted paths matching the query, excluding branches if
+// only leaves were requested.
+func (h *expandHandler) expand(
+	ctx context.Context,
+	query string,
+	params metricsParams,
+	opts *storage.FetchOptions,
+) ([]string, block.ResultMetadata, error) {
+	terminatedQuery, childQuery, err := findQueriesForGlob(query, params.from, params.until)
+	if err != nil {
+		return nil, block.ResultMetadata{}, err
+	}
+
+	results, meta, err := h.finder.find(ctx, terminatedQuery, childQuery, query, opts)
+	if err != nil {
+		return nil, block.ResultMetadata{}, err
+	}
+
+	paths := make([]string, 0, len(results))
+	for _, result := range results {
+		if result.node.isLeaf || (result.node.hasChildren && !params.leavesOnly) {
+			paths = append(paths, result.id)
+		}
+	}
+
+	return paths, meta, nil
+}
+
+// stream writes the paths of each query as soon as they are found, the
+// paths of each query are sorted but are not deduplicated across queries.
+// An expand failing once paths were flushed ends the results and is written
+// as the error field of the response.
+func (h *expandHandler) stream(
+	ctx context.Context,
+	sw *pathStreamWriter,
+	params metricsParams,
+	opts *storage.FetchOptions,
+) error {
+	sw.jw.BeginObject()
+	sw.jw.BeginObjectField("results")
+	if params.groupByExpr {
+		sw.jw.BeginObject()
+	} else {
+		sw.jw.BeginArray()
+	}
+
+	for _, query := range params.queries {
+		paths, _, err := h.expand(ctx, query, params, opts)
+		if err != nil {
+			if sw.flushed {
+				endStreamResults(sw, params)
+				sw.writeErrorField(err)
+				sw.jw.EndObject()
+				_ = sw.jw.Close()
+			}
+			return err
+		}
+
+		if params.groupByExpr {
+			sw.jw.BeginObjectField(query)
+			sw.jw.BeginArray()
+		}
+		for _, path := range paths {
+			if err := sw.writePath(path); err != nil {
+				return err
+			}
+		}
+		if params.groupByExpr {
+			sw.jw.EndArray()
+		}
+
+		if err := sw.flush(); err != nil {
+			return err
+		}
+	}
+
+	endStreamResults(sw, params)
+	sw.jw.EndObject()
+	return sw.jw.Close()
+}
+
+func endStreamResults(sw *pathStreamWriter, params metricsParams) {
+	if params.groupByExpr {
+		sw.jw.EndObject()
+	} else {
+		sw.jw.EndArray()
+	}
+}
+
+func expandResultsJSON(
+	w http.ResponseWriter,
+	params metricsParams,
+	results map[string][]string,
+) error {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+	jw.BeginObjectField("results")
+
+	if params.groupByExpr {
+		jw.BeginObject()
+		for _, query := range params.queries {
+			jw.BeginObjectField(query)
+			writePathsJSON(jw, results[query])
+		}
+		jw.EndObject()
+	} else {
+		var all []string
+		for _, paths := range results {
+			all = append(all, paths...)
+		}
+		writePathsJSON(jw, sortedUniquePaths(all))
+	}
+
+	jw.EndObject()
+	return jw.Close()
+}
+
+func writePathsJSON(jw json.Writer, paths []string) {
+	jw.BeginArray()
+	for _, path := range paths {
+		jw.WriteString(path)
+	}
+	jw.EndArray()
+}
+
+func sortedUniquePaths(paths []string) []string {
+	sort.Strings(paths)
+	unique := paths[:0]
+	for i, path := range paths {
+		if i > 0 && path == paths[i-1] {
+			continue
+		}
+		unique = append(unique, path)
+	}
+	return unique
+}
+
+type indexHandler struct {
+	finder *grahiteFindHandler
+}
+
+// NewIndexJSONHandler returns a new instance of the graphite index handler,
+// which walks the whole metric tree and returns the path of every leaf.
+func NewIndexJSONHandler(opts options.HandlerOptions) http.Handler {
+	return &indexHandler{finder: newFindHandler(opts)}
+}
+
+func (h *indexHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, opts, err := h.finder.fetchOptionsBuilder.NewFetchOptions(r.Context(), r)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	logger := logging.WithContext(ctx, h.finder.instrumentOpts)
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	params, err := parseMetricsParams(r, false)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if params.stream {
+		// NB: paths are streamed in tree walk order, with the children of
+		// each node following it, rather than fully sorted.
+		sw := newPathStreamWriter(w)
+		sw.jw.BeginArray()
+		if _, err := h.walk(ctx, params, opts, sw.writePath); err != nil {
+			logger.Error("unable to stream index results", zap.Error(err))
+			if !sw.flushed {
+				xhttp.WriteError(w, err)
+				return
+			}
+
+			// NB: the paths already flushed are followed by an object with
+			// the error so that the response is not mistaken for the index.
+			sw.jw.BeginObject()
+			sw.writeErrorField(err)
+			sw.jw.EndObject()
+			sw.jw.EndArray()
+			_ = sw.jw.Close()
+			return
+		}
+		sw.jw.EndArray()
+		if err := sw.jw.Close(); err != nil {
+			logger.Error("unable to stream index results", zap.Error(err))
+		}
+		return
+	}
+
+	var paths []string
+	meta, err := h.walk(ctx, params, opts, func(path string) error {
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		logger.Error("unable to walk index", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if err := handleroptions.AddDBResultResponseHeaders(w, meta, opts); err != nil {
+		logger.Error("unable to render index header", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	sort.Strings(paths)
+	jw := json.NewWriter(w)
+	writePathsJSON(jw, paths)
+	if err := jw.Close(); err != nil {
+		logger.Error("unable to render index results", zap.Error(err))
+	}
+}
+
+// walk visits the metric tree depth first, calling fn with the path of every
+// leaf. The children of the next branches of each node are found ahead of
+// their visit, up to indexWalkConcurrency finds at a time, so that only the
+// children of the nodes on the current branch and of the next few branches
+// are held in memory at any time.
+func (h *indexHandler) walk(
+	ctx context.Context,
+	params metricsParams,
+	opts *storage.FetchOptions,
+	fn func(path string) error,
+) (block.ResultMetadata, error) {
+	// NB: cancel the finds still in flight if the walk fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := xsync.NewWorkerPool(indexWalkConcurrency)
+	workers.Init()
+	w := &indexWalker{
+		finder:  h.finder,
+		workers: workers,
+		params:  params,
+		opts:    opts,
+		fn:      fn,
+	}
+	return w.walk(ctx, w.find(ctx, ""))
+}
+
+type indexWalker struct {
+	finder  *grahiteFindHandler
+	workers xsync.WorkerPool
+	params  metricsParams
+	opts    *storage.FetchOptions
+	fn      func(path string) error
+}
+
+// indexFind is a find of the children of a node of the metric tree, the
+// results of which are set once done is closed.
+type indexFind struct {
+	done    chan struct{}
+	results []findResult
+	meta    block.ResultMetadata
+	err     error
+}
+
+// find finds the children of the node with the given path in the background,
+// blocking while indexWalkConcurrency finds are in flight.
+func (w *indexWalker) find(ctx context.Context, prefix string) *indexFind {
+	f := &indexFind{done: make(chan struct{})}
+	w.workers.Go(func() {
+		defer close(f.done)
+
+		query := "*"
+		if prefix != "" {
+			query = prefix + ".*"
+		}
+
+		terminatedQuery, childQuery, err := findQueriesForGlob(query,
+			w.params.from, w.params.until)
+		if err != nil {
+			f.err = err
+			return
+		}
+
+		f.results, f.meta, f.err = w.finder.find(ctx, terminatedQuery,
+			childQuery, query, w.opts)
+	})
+	return f
+}
+
+func (w *indexWalker) walk(ctx context.Context, f *indexFind) (block.ResultMetadata, error) {
+	<-f.done
+	if f.err != nil {
+		return block.ResultMetadata{}, f.err
+	}
+
+	var (
+		meta    = f.meta
+		results = f.results
+		// NB: pending are the finds of the branches in results[i:next].
+		pending []*indexFind
+		next    int
+	)
+	for i, result := range results {
+		if result.node.isLeaf {
+			if err := w.fn(result.id); err != nil {
+				return block.ResultMetadata{}, err
+			}
+		}
+		if !result.node.hasChildren {
+			continue
+		}
+
+		if next <= i {
+			next = i
+		}
+		for ; next < len(results) && len(pending) < indexWalkConcurrency; next++ {
+			if results[next].node.hasChildren {
+				pending = append(pending, w.find(ctx, results[next].id))
+			}
+		}
+
+		child := pending[0]
+		pending = pending[1:]
+		childMeta, err := w.walk(ctx, child)
+		if err != nil {
+			return block.ResultMetadata{}, err
+		}
+		meta = meta.CombineMetadata(childMeta)
+	}
+
+	return meta, nil
+}
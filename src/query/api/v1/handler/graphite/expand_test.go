@@ -0,0 +1,294 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+var testTreePaths = []string{
+	"a.b.c",
+	"a.b.d",
+	"a.e",
+	"a.e.f",
+	"g",
+}
+
+// newTestTreeStorage returns a storage completing graphite path tags from the
+// test tree paths, as the index would for the matchers of a find query.
+func newTestTreeStorage(t *testing.T, ctrl *gomock.Controller) storage.Storage {
+	return newTestTreeStorageWithPaths(t, ctrl, testTreePaths)
+}
+
+// newTestTreeStorageWithPaths returns a storage completing graphite path tags
+// from the given paths, failing the queries of the children of fail.
+func newTestTreeStorageWithPaths(
+	t *testing.T,
+	ctrl *gomock.Controller,
+	paths []string,
+) storage.Storage {
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().
+		CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			query *storage.CompleteTagsQuery,
+			_ *storage.FetchOptions,
+		) (*consolidators.CompleteTagsResult, error) {
+			require.Len(t, query.FilterNameTags, 1)
+			filter := testTreeTagIndex(t, query.FilterNameTags[0])
+			for _, m := range query.TagMatchers {
+				if m.Type == models.MatchEqual && string(m.Value) == "fail" {
+					return nil, errors.New("find failed")
+				}
+			}
+
+			var (
+				values [][]byte
+				seen   = make(map[string]struct{})
+			)
+			for _, path := range paths {
+				parts := strings.Split(path, ".")
+				if !testTreeMatches(t, query.TagMatchers, parts) {
+					continue
+				}
+				if _, ok := seen[parts[filter]]; ok {
+					continue
+				}
+				seen[parts[filter]] = struct{}{}
+				values = append(values, b(parts[filter]))
+			}
+
+			return &consolidators.CompleteTagsResult{
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: query.FilterNameTags[0], Values: values},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		}).
+		AnyTimes()
+	return store
+}
+
+func testTreeTagIndex(t *testing.T, name []byte) int {
+	var idx int
+	_, err := fmt.Sscanf(string(name), "__g%d__", &idx)
+	require.NoError(t, err)
+	return idx
+}
+
+func testTreeMatches(t *testing.T, matchers models.Matchers, parts []string) bool {
+	for _, m := range matchers {
+		var (
+			idx   = testTreeTagIndex(t, m.Name)
+			has   = idx < len(parts)
+			value string
+		)
+		if has {
+			value = parts[idx]
+		}
+
+		switch m.Type {
+		case models.MatchField:
+			if !has {
+				return false
+			}
+		case models.MatchNotField:
+			if has {
+				return false
+			}
+		case models.MatchEqual:
+			if !has || value != string(m.Value) {
+				return false
+			}
+		case models.MatchRegexp:
+			re := regexp.MustCompile("^(?:" + string(m.Value) + ")$")
+			if !has || !re.MatchString(value) {
+				return false
+			}
+		default:
+			require.FailNow(t, "unexpected matcher type", m.Type.String())
+		}
+	}
+	return true
+}
+
+func TestExpandHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewExpandHandler(newTestTagsHandlerOptions(t, newTestTreeStorage(t, ctrl)))
+	for _, test := range []struct {
+		name     string
+		params   url.Values
+		expected string
+	}{
+		{
+			name:     "branches and leaves",
+			params:   url.Values{"query": []string{"a.*"}},
+			expected: `{"results":["a.b","a.e"]}`,
+		},
+		{
+			name: "leaves only",
+			params: url.Values{
+				"query":      []string{"a.*"},
+				"leavesOnly": []string{"1"},
+			},
+			expected: `{"results":["a.e"]}`,
+		},
+		{
+			name:     "multiple queries",
+			params:   url.Values{"query": []string{"a.*", "*", "a.{b,e}"}},
+			expected: `{"results":["a","a.b","a.e","g"]}`,
+		},
+		{
+			name: "group by expression",
+			params: url.Values{
+				"query":       []string{"a.*", "*"},
+				"groupByExpr": []string{"1"},
+			},
+			expected: `{"results":{"a.*":["a.b","a.e"],"*":["a","g"]}}`,
+		},
+		{
+			name: "stream",
+			params: url.Values{
+				"query":  []string{"a.*", "*"},
+				"stream": []string{"true"},
+			},
+			expected: `{"results":["a.b","a.e","a","g"]}`,
+		},
+		{
+			name: "stream group by expression",
+			params: url.Values{
+				"query":       []string{"a.*", "*"},
+				"groupByExpr": []string{"1"},
+				"stream":      []string{"true"},
+			},
+			expected: `{"results":{"a.*":["a.b","a.e"],"*":["a","g"]}}`,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			w := serveTagsRequest(h, ExpandURL, test.params)
+			require.Equal(t, http.StatusOK, w.Code)
+			require.JSONEq(t, test.expected, w.Body.String())
+		})
+	}
+
+	w := serveTagsRequest(h, ExpandURL, url.Values{})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = serveTagsRequest(h, ExpandURL, url.Values{
+		"query":      []string{"a.*"},
+		"leavesOnly": []string{"maybe"},
+	})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestIndexJSONHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	h := NewIndexJSONHandler(newTestTagsHandlerOptions(t, newTestTreeStorage(t, ctrl)))
+	expected := `["a.b.c","a.b.d","a.e","a.e.f","g"]`
+
+	w := serveTagsRequest(h, IndexJSONURL, url.Values{})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, expected, w.Body.String())
+
+	w = serveTagsRequest(h, IndexJSONURL, url.Values{"stream": []string{"1"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, expected, w.Body.String())
+}
+
+// testLargeTreePaths returns more leaves than are flushed at once followed
+// by a branch the children of which cannot be found.
+func testLargeTreePaths() []string {
+	paths := make([]string, 0, streamFlushPaths+2)
+	for i := 0; i <= streamFlushPaths; i++ {
+		paths = append(paths, fmt.Sprintf("a.%04d", i))
+	}
+	return append(paths, "fail.b")
+}
+
+func TestExpandHandlerStreamError(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store := newTestTreeStorageWithPaths(t, ctrl, testLargeTreePaths())
+	h := NewExpandHandler(newTestTagsHandlerOptions(t, store))
+
+	// NB: the error of a query before any path was flushed is the response.
+	w := serveTagsRequest(h, ExpandURL, url.Values{
+		"query":  []string{"fail.*"},
+		"stream": []string{"1"},
+	})
+	require.NotEqual(t, http.StatusOK, w.Code)
+
+	w = serveTagsRequest(h, ExpandURL, url.Values{
+		"query":  []string{"a.*", "fail.*"},
+		"stream": []string{"1"},
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Results []string `json:"results"`
+		Error   string   `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Results, streamFlushPaths+1)
+	require.Equal(t, "find failed", resp.Error)
+}
+
+func TestIndexJSONHandlerStreamError(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store := newTestTreeStorageWithPaths(t, ctrl, testLargeTreePaths())
+	h := NewIndexJSONHandler(newTestTagsHandlerOptions(t, store))
+
+	w := serveTagsRequest(h, IndexJSONURL, url.Values{})
+	require.NotEqual(t, http.StatusOK, w.Code)
+
+	w = serveTagsRequest(h, IndexJSONURL, url.Values{"stream": []string{"1"}})
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp []interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp, streamFlushPaths+2)
+	require.Equal(t, "a.0000", resp[0])
+	require.Equal(t, map[string]interface{}{"error": "find failed"}, resp[len(resp)-1])
+}
@@ -21,6 +21,7 @@
 package graphite
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"sort"
@@ -31,8 +32,10 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/graphite/graphite"
 	graphitestorage "github.com/m3db/m3/src/query/graphite/storage"
+	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
 	"github.com/m3db/m3/src/query/util/logging"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -57,6 +60,10 @@ type grahiteFindHandler struct {
 
 // NewFindHandler returns a new instance of handler.
 func NewFindHandler(opts options.HandlerOptions) http.Handler {
+	return newFindHandler(opts)
+}
+
+func newFindHandler(opts options.HandlerOptions) *grahiteFindHandler {
 	wrappedStore := graphitestorage.NewM3WrappedStorage(opts.Storage(),
 		opts.M3DBOptions(), opts.InstrumentOpts(), opts.GraphiteStorageOptions())
 	return &grahiteFindHandler{
@@ -163,6 +170,38 @@ func (h *grahiteFindHandler) ServeHTTP(
 		return
 	}
 
+	results, meta, err := h.find(ctx, terminatedQuery, childQuery, raw, opts)
+	if err != nil {
+		logger.Error("unable to find search", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	err = handleroptions.AddDBResultResponseHeaders(w, meta, opts)
+	if err != nil {
+		logger.Error("unable to render find header", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	// TODO: Support multiple result types
+	resultOpts := findResultsOptions{
+		includeBothExpandableAndLeaf: h.graphiteStorageOpts.FindResultsIncludeBothExpandableAndLeaf,
+	}
+	if err := findResultsJSON(w, results, resultOpts); err != nil {
+		logger.Error("unable to render find results", zap.Error(err))
+	}
+}
+
+// find runs the terminated and child queries for the raw query and returns
+// the sorted nodes found, along with the combined result metadata.
+func (h *grahiteFindHandler) find(
+	ctx context.Context,
+	terminatedQuery *storage.CompleteTagsQuery,
+	childQuery *storage.CompleteTagsQuery,
+	raw string,
+	opts *storage.FetchOptions,
+) ([]findResult, block.ResultMetadata, error) {
 	var (
 		terminatedResult *consolidators.CompleteTagsResult
 		tErr             error
@@ -189,9 +228,7 @@ func (h *grahiteFindHandler) ServeHTTP(
 	wg.Wait()
 
 	if err := xerrors.FirstError(tErr, cErr); err != nil {
-		logger.Error("unable to find search", zap.Error(err))
-		xhttp.WriteError(w, err)
-		return
+		return nil, block.ResultMetadata{}, err
 	}
 
 	meta := childResult.Metadata
@@ -202,9 +239,7 @@ func (h *grahiteFindHandler) ServeHTTP(
 	// NB: merge results from both queries to specify which series have children
 	seenMap, err := mergeTags(terminatedResult, childResult)
 	if err != nil {
-		logger.Error("unable to find merge", zap.Error(err))
-		xhttp.WriteError(w, err)
-		return
+		return nil, block.ResultMetadata{}, err
 	}
 
 	prefix := graphite.DropLastMetricPart(raw)
@@ -212,20 +247,5 @@ func (h *grahiteFindHandler) ServeHTTP(
 		prefix += "."
 	}
 
-	results := findResultsSorted(prefix, seenMap)
-
-	err = handleroptions.AddDBResultResponseHeaders(w, meta, opts)
-	if err != nil {
-		logger.Error("unable to render find header", zap.Error(err))
-		xhttp.WriteError(w, err)
-		return
-	}
-
-	// TODO: Support multiple result types
-	resultOpts := findResultsOptions{
-		includeBothExpandableAndLeaf: h.graphiteStorageOpts.FindResultsIncludeBothExpandableAndLeaf,
-	}
-	if err := findResultsJSON(w, results, resultOpts); err != nil {
-		logger.Error("unable to render find results", zap.Error(err))
-	}
+	return findResultsSorted(prefix, seenMap), meta, nil
 }
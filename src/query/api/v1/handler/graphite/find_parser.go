@@ -69,6 +69,22 @@ func parseFindParamsToQueries(r *http.Request) (
 			xerrors.NewInvalidParamsError(errors.ErrNoQueryFound)
 	}
 
+	from, until, err := parseFindTimeRange(r)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	terminatedQuery, childQuery, err := findQueriesForGlob(query, from, until)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	return terminatedQuery, childQuery, query, nil
+}
+
+// parseFindTimeRange parses the from and until parameters shared by the find,
+// expand and index handlers, defaulting to all time.
+func parseFindTimeRange(r *http.Request) (time.Time, time.Time, error) {
 	now := time.Now()
 	fromString, untilString := r.FormValue("from"), r.FormValue("until")
 	if len(fromString) == 0 {
@@ -85,7 +101,7 @@ func parseFindParamsToQueries(r *http.Request) (
 		tzOffsetForAbsoluteTime,
 	)
 	if err != nil {
-		return nil, nil, "",
+		return time.Time{}, time.Time{},
 			xerrors.NewInvalidParamsError(fmt.Errorf("invalid 'from': %s", fromString))
 	}
 
@@ -95,13 +111,27 @@ func parseFindParamsToQueries(r *http.Request) (
 		tzOffsetForAbsoluteTime,
 	)
 	if err != nil {
-		return nil, nil, "",
+		return time.Time{}, time.Time{},
 			xerrors.NewInvalidParamsError(fmt.Errorf("invalid 'until': %s", untilString))
 	}
 
+	return from, until, nil
+}
+
+// findQueriesForGlob translates a graphite glob to the terminated and child
+// complete tags queries described in parseFindParamsToQueries.
+func findQueriesForGlob(
+	query string,
+	from time.Time,
+	until time.Time,
+) (
+	_terminatedQuery *storage.CompleteTagsQuery,
+	_childQuery *storage.CompleteTagsQuery,
+	_err error,
+) {
 	matchers, queryType, err := graphitestorage.TranslateQueryToMatchersWithTerminator(query)
 	if err != nil {
-		return nil, nil, "",
+		return nil, nil,
 			xerrors.NewInvalidParamsError(fmt.Errorf("invalid 'query': %s", query))
 	}
 
@@ -130,20 +160,20 @@ func parseFindParamsToQueries(r *http.Request) (
 			Start:            xtime.ToUnixNano(from),
 			End:              xtime.ToUnixNano(until),
 		}
-		return nil, childQuery, query, nil
+		return nil, childQuery, nil
 	case graphitestorage.TerminatedTranslatedQuery:
 		// Default type of translated query, explicitly craft queries for
 		// a terminated part of the query and a child part of the query.
 		break
 	default:
-		return nil, nil, "", fmt.Errorf("unknown query type: %v", queryType)
+		return nil, nil, fmt.Errorf("unknown query type: %v", queryType)
 	}
 
 	// NB: Filter will always be the second last term in the matchers, and the
 	// matchers should always have a length of at least 2 (term + terminator)
 	// so this is a sanity check and unexpected in actual execution.
 	if len(matchers) < 2 {
-		return nil, nil, "",
+		return nil, nil,
 			xerrors.NewInvalidParamsError(fmt.Errorf("unable to parse 'query': %s", query))
 	}
 
@@ -169,7 +199,7 @@ func parseFindParamsToQueries(r *http.Request) (
 		End:              xtime.ToUnixNano(until),
 	}
 
-	return terminatedQuery, childQuery, query, nil
+	return terminatedQuery, childQuery, nil
 }
 
 type findResultsOptions struct {
@@ -124,6 +124,7 @@ func (h *renderHandler) serveHTTP(
 		Timeout:       p.Timeout,
 		MaxDataPoints: p.MaxDataPoints,
 		FetchOpts:     fetchOpts,
+		Events:        h.opts.GraphiteEventsStore(),
 	})
 
 	// Set the request context.
@@ -21,7 +21,9 @@
 package graphite
 
 import (
+	"bufio"
 	"context"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
@@ -30,6 +32,8 @@ import (
 	"strconv"
 	"time"
 
+	"gopkg.in/vmihailenco/msgpack.v2"
+
 	"github.com/m3db/m3/src/query/api/v1/handler/graphite/pickle"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/graphite/graphite"
@@ -44,7 +48,18 @@ const (
 	realTimeQueryThreshold   = time.Minute
 	queryRangeShiftThreshold = 55 * time.Minute
 	queryRangeShift          = 15 * time.Second
-	pickleFormat             = "pickle"
+
+	jsonFormat    = "json"
+	pickleFormat  = "pickle"
+	csvFormat     = "csv"
+	rawFormat     = "raw"
+	msgpackFormat = "msgpack"
+
+	contentTypeCSV     = "text/csv"
+	contentTypeRaw     = "text/plain"
+	contentTypeMsgpack = "application/x-msgpack"
+
+	csvTimeFormat = "2006-01-02 15:04:05"
 )
 
 var (
@@ -59,12 +74,23 @@ func WriteRenderResponse(
 	format string,
 	opts renderResultsJSONOptions,
 ) error {
-	if format == pickleFormat {
+	switch format {
+	case pickleFormat:
 		w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeOctetStream)
 		return renderResultsPickle(w, series.Values)
+	case csvFormat:
+		w.Header().Set(xhttp.HeaderContentType, contentTypeCSV)
+		return renderResultsCSV(w, series.Values)
+	case rawFormat:
+		w.Header().Set(xhttp.HeaderContentType, contentTypeRaw)
+		return renderResultsRaw(w, series.Values)
+	case msgpackFormat:
+		w.Header().Set(xhttp.HeaderContentType, contentTypeMsgpack)
+		return renderResultsMsgpack(w, series.Values)
 	}
 
-	// NB: return json unless requesting specifically `pickleFormat`
+	// NB: formats are validated when parsing the request, so anything else
+	// is rendered as json.
 	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
 	return renderResultsJSON(w, series.Values, opts)
 }
@@ -111,6 +137,17 @@ func ParseRenderRequest(
 		return nil, p, nil, errNoTarget
 	}
 
+	p.Format = r.FormValue("format")
+	switch p.Format {
+	case "":
+		p.Format = jsonFormat
+	case jsonFormat, pickleFormat, csvFormat, rawFormat, msgpackFormat:
+	default:
+		return nil, p, nil, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid 'format': %s, supported formats are %s, %s, %s, %s and %s",
+				p.Format, jsonFormat, pickleFormat, csvFormat, rawFormat, msgpackFormat))
+	}
+
 	fromString, untilString := r.FormValue("from"), r.FormValue("until")
 	if len(fromString) == 0 {
 		fromString = "-30min"
@@ -263,3 +300,128 @@ func renderResultsPickle(w io.Writer, series []*ts.Series) error {
 
 	return pw.Close()
 }
+
+// renderResultsCSV renders one "name,time,value" line per datapoint, times
+// are rendered in UTC and NaNs are rendered as empty values.
+func renderResultsCSV(w io.Writer, series []*ts.Series) error {
+	cw := csv.NewWriter(w)
+	record := make([]string, 3)
+	for _, s := range series {
+		record[0] = s.Name()
+		for i := 0; i < s.Len(); i++ {
+			record[1] = s.StartTimeForStep(i).UTC().Format(csvTimeFormat)
+			record[2] = ""
+			if v := s.ValueAt(i); !math.IsNaN(v) {
+				record[2] = strconv.FormatFloat(v, 'f', -1, 64)
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// renderResultsRaw renders one "name,start,end,step|value,..." line per series,
+// NaNs are rendered as "None".
+func renderResultsRaw(w io.Writer, series []*ts.Series) error {
+	bw := bufio.NewWriter(w)
+	buf := make([]byte, 0, 64)
+	for _, s := range series {
+		bw.WriteString(s.Name()) // nolint: errcheck
+		buf = append(buf[:0], ',')
+		buf = strconv.AppendInt(buf, s.StartTime().Unix(), 10)
+		buf = append(buf, ',')
+		buf = strconv.AppendInt(buf, s.EndTime().Unix(), 10)
+		buf = append(buf, ',')
+		buf = strconv.AppendInt(buf, int64(s.MillisPerStep()/1000), 10)
+		buf = append(buf, '|')
+		bw.Write(buf) // nolint: errcheck
+
+		for i := 0; i < s.Len(); i++ {
+			buf = buf[:0]
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			if v := s.ValueAt(i); math.IsNaN(v) {
+				buf = append(buf, "None"...)
+			} else {
+				buf = strconv.AppendFloat(buf, v, 'f', -1, 64)
+			}
+			bw.Write(buf) // nolint: errcheck
+		}
+		bw.WriteByte('\n') // nolint: errcheck
+	}
+
+	// Write errors are sticky and returned by flush.
+	return bw.Flush()
+}
+
+// renderResultsMsgpack renders the same structure as the pickle format, a list
+// of maps with the name, start, end, step and values of each series.
+func renderResultsMsgpack(w io.Writer, series []*ts.Series) error {
+	bw := bufio.NewWriter(w)
+	enc := msgpack.NewEncoder(bw)
+	if err := enc.EncodeArrayLen(len(series)); err != nil {
+		return err
+	}
+
+	for _, s := range series {
+		if err := encodeMsgpackSeries(enc, s); err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+func encodeMsgpackSeries(enc *msgpack.Encoder, s *ts.Series) error {
+	if err := enc.EncodeMapLen(5); err != nil {
+		return err
+	}
+
+	for _, field := range []struct {
+		key   string
+		value int64
+	}{
+		{key: "start", value: s.StartTime().Unix()},
+		{key: "end", value: s.EndTime().Unix()},
+		{key: "step", value: int64(s.MillisPerStep() / 1000)},
+	} {
+		if err := enc.EncodeString(field.key); err != nil {
+			return err
+		}
+		if err := enc.EncodeInt64(field.value); err != nil {
+			return err
+		}
+	}
+
+	if err := enc.EncodeString("name"); err != nil {
+		return err
+	}
+	if err := enc.EncodeString(s.Name()); err != nil {
+		return err
+	}
+
+	if err := enc.EncodeString("values"); err != nil {
+		return err
+	}
+	if err := enc.EncodeArrayLen(s.Len()); err != nil {
+		return err
+	}
+	for i := 0; i < s.Len(); i++ {
+		var err error
+		if v := s.ValueAt(i); math.IsNaN(v) {
+			err = enc.EncodeNil()
+		} else {
+			err = enc.EncodeFloat64(v)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -32,12 +32,15 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/vmihailenco/msgpack.v2"
 
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/block"
+	graphitectx "github.com/m3db/m3/src/query/graphite/context"
 	"github.com/m3db/m3/src/query/graphite/graphite"
 	graphiteStorage "github.com/m3db/m3/src/query/graphite/storage"
+	graphitets "github.com/m3db/m3/src/query/graphite/ts"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/mock"
@@ -392,6 +395,89 @@ func TestParseQueryResultsAllNaN(t *testing.T) {
 	require.Equal(t, expected, string(buf))
 }
 
+func TestParseQueryInvalidFormat(t *testing.T) {
+	mockStorage := mock.NewMockStorage()
+
+	opts := testHandlerOptions(t).SetStorage(mockStorage)
+	handler := NewRenderHandler(opts)
+
+	req := newGraphiteReadHTTPRequest(t)
+	req.URL.RawQuery = "target=foo.bar&from=-2h&until=now&format=png"
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, req)
+
+	res := recorder.Result()
+	require.Equal(t, 400, res.StatusCode)
+}
+
+func TestWriteRenderResponseFormats(t *testing.T) {
+	ctx := graphitectx.New()
+	defer func() { _ = ctx.Close() }()
+
+	start := time.Unix(1600000000, 0)
+	vals := graphitets.NewValues(ctx, 10000, 3)
+	vals.SetValueAt(0, 1.5)
+	vals.SetValueAt(2, 3)
+	series := graphitets.NewSeriesListWithSeries(
+		graphitets.NewSeries(ctx, "foo.bar", start, vals))
+
+	tests := []struct {
+		format      string
+		contentType string
+		expected    string
+	}{
+		{
+			format:      "csv",
+			contentType: "text/csv",
+			expected: "foo.bar,2020-09-13 12:26:40,1.5\n" +
+				"foo.bar,2020-09-13 12:26:50,\n" +
+				"foo.bar,2020-09-13 12:27:00,3\n",
+		},
+		{
+			format:      "raw",
+			contentType: "text/plain",
+			expected:    "foo.bar,1600000000,1600000030,10|1.5,None,3\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			err := WriteRenderResponse(recorder, series, test.format,
+				renderResultsJSONOptions{})
+			require.NoError(t, err)
+			require.Equal(t, test.contentType, recorder.Header().Get("Content-Type"))
+			require.Equal(t, test.expected, recorder.Body.String())
+		})
+	}
+
+	t.Run("msgpack", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		err := WriteRenderResponse(recorder, series, "msgpack",
+			renderResultsJSONOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "application/x-msgpack", recorder.Header().Get("Content-Type"))
+
+		var decoded []struct {
+			Name   string     `msgpack:"name"`
+			Start  int64      `msgpack:"start"`
+			End    int64      `msgpack:"end"`
+			Step   int64      `msgpack:"step"`
+			Values []*float64 `msgpack:"values"`
+		}
+		require.NoError(t, msgpack.Unmarshal(recorder.Body.Bytes(), &decoded))
+		require.Equal(t, 1, len(decoded))
+		require.Equal(t, "foo.bar", decoded[0].Name)
+		require.Equal(t, int64(1600000000), decoded[0].Start)
+		require.Equal(t, int64(1600000030), decoded[0].End)
+		require.Equal(t, int64(10), decoded[0].Step)
+		require.Equal(t, 3, len(decoded[0].Values))
+		require.Equal(t, 1.5, *decoded[0].Values[0])
+		require.Nil(t, decoded[0].Values[1])
+		require.Equal(t, 3.0, *decoded[0].Values[2])
+	})
+}
+
 func newGraphiteReadHTTPRequest(t *testing.T) *http.Request {
 	req, err := http.NewRequest(ReadHTTPMethods[0], ReadURL, nil)
 	require.NoError(t, err)
@@ -0,0 +1,414 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/graphite/graphite"
+	graphitestorage "github.com/m3db/m3/src/query/graphite/storage"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// TagsURL is the url for listing graphite tags.
+	TagsURL = route.Prefix + "/graphite/tags"
+
+	// TagsAutoCompleteTagsURL is the url for autocompleting graphite tag names.
+	TagsAutoCompleteTagsURL = TagsURL + "/autoComplete/tags"
+
+	// TagsAutoCompleteValuesURL is the url for autocompleting graphite tag values.
+	TagsAutoCompleteValuesURL = TagsURL + "/autoComplete/values"
+
+	// TagsFindSeriesURL is the url for finding graphite tagged series.
+	TagsFindSeriesURL = TagsURL + "/findSeries"
+
+	// defaultTagsLimit matches the graphite TagDB autocomplete limit default.
+	defaultTagsLimit = 100
+)
+
+// TagsHTTPMethods are the HTTP methods for the graphite TagDB handlers.
+var TagsHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+type tagsHandlerType uint
+
+const (
+	listTagsHandlerType tagsHandlerType = iota
+	autoCompleteTagsHandlerType
+	autoCompleteValuesHandlerType
+	findSeriesHandlerType
+)
+
+// tagsHandler implements the graphite TagDB endpoints used by tag aware
+// clients such as the grafana graphite datasource.
+type tagsHandler struct {
+	handlerType         tagsHandlerType
+	storage             graphitestorage.Storage
+	searchStorage       storage.Storage
+	fetchOptionsBuilder handleroptions.FetchOptionsBuilder
+	instrumentOpts      instrument.Options
+}
+
+// NewTagsHandler returns a new handler listing graphite tags.
+func NewTagsHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, listTagsHandlerType)
+}
+
+// NewAutoCompleteTagsHandler returns a new handler autocompleting graphite
+// tag names.
+func NewAutoCompleteTagsHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, autoCompleteTagsHandlerType)
+}
+
+// NewAutoCompleteValuesHandler returns a new handler autocompleting graphite
+// tag values.
+func NewAutoCompleteValuesHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, autoCompleteValuesHandlerType)
+}
+
+// NewFindSeriesHandler returns a new handler finding graphite tagged series.
+func NewFindSeriesHandler(opts options.HandlerOptions) http.Handler {
+	return newTagsHandler(opts, findSeriesHandlerType)
+}
+
+func newTagsHandler(
+	opts options.HandlerOptions,
+	handlerType tagsHandlerType,
+) http.Handler {
+	wrappedStore := graphitestorage.NewM3WrappedStorage(opts.Storage(),
+		opts.M3DBOptions(), opts.InstrumentOpts(), opts.GraphiteStorageOptions())
+	return &tagsHandler{
+		handlerType:         handlerType,
+		storage:             wrappedStore,
+		searchStorage:       opts.Storage(),
+		fetchOptionsBuilder: opts.GraphiteFindFetchOptionsBuilder(),
+		instrumentOpts:      opts.InstrumentOpts(),
+	}
+}
+
+type tagsParams struct {
+	exprs       []string
+	matchers    models.Matchers
+	start       time.Time
+	end         time.Time
+	limit       int
+	tag         string
+	tagPrefix   string
+	valuePrefix string
+	filter      *regexp.Regexp
+}
+
+func parseTagsParams(r *http.Request, handlerType tagsHandlerType) (tagsParams, error) {
+	if err := r.ParseForm(); err != nil {
+		return tagsParams{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	params := tagsParams{
+		exprs:       r.Form["expr"],
+		limit:       defaultTagsLimit,
+		tag:         r.FormValue("tag"),
+		tagPrefix:   r.FormValue("tagPrefix"),
+		valuePrefix: r.FormValue("valuePrefix"),
+	}
+
+	now := time.Now()
+	fromString, untilString := r.FormValue("from"), r.FormValue("until")
+	if len(fromString) == 0 {
+		fromString = "0"
+	}
+	if len(untilString) == 0 {
+		untilString = "now"
+	}
+
+	var err error
+	params.start, err = graphite.ParseTime(fromString, now, tzOffsetForAbsoluteTime)
+	if err != nil {
+		return tagsParams{}, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid 'from': %s", fromString))
+	}
+	params.end, err = graphite.ParseTime(untilString, now, tzOffsetForAbsoluteTime)
+	if err != nil {
+		return tagsParams{}, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid 'until': %s", untilString))
+	}
+
+	if str := r.FormValue("limit"); str != "" {
+		params.limit, err = strconv.Atoi(str)
+		if err != nil || params.limit < 0 {
+			return tagsParams{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid 'limit': %s", str))
+		}
+	}
+
+	if str := r.FormValue("filter"); str != "" {
+		params.filter, err = regexp.Compile(str)
+		if err != nil {
+			return tagsParams{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid 'filter': %s", str))
+		}
+	}
+
+	switch handlerType {
+	case autoCompleteValuesHandlerType:
+		if params.tag == "" {
+			return tagsParams{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("missing 'tag' parameter"))
+		}
+	case findSeriesHandlerType:
+		if len(params.exprs) == 0 {
+			return tagsParams{}, xerrors.NewInvalidParamsError(
+				fmt.Errorf("missing 'expr' parameter"))
+		}
+	}
+
+	if len(params.exprs) == 0 {
+		// NB: match all tagged series, which all have the name tag.
+		params.matchers = models.Matchers{
+			{
+				Type:  models.MatchRegexp,
+				Name:  []byte(graphite.TaggedNameTag),
+				Value: []byte(graphite.MatchAllPattern),
+			},
+		}
+		return params, nil
+	}
+
+	query := graphite.SeriesByTagQuery(params.exprs)
+	params.matchers, _, err = graphitestorage.TranslateSeriesByTagQueryToMatchers(query)
+	if err != nil {
+		return tagsParams{}, xerrors.NewInvalidParamsError(err)
+	}
+
+	return params, nil
+}
+
+func (h *tagsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, opts, err := h.fetchOptionsBuilder.NewFetchOptions(r.Context(), r)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	logger := logging.WithContext(ctx, h.instrumentOpts)
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	params, err := parseTagsParams(r, h.handlerType)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	var (
+		results []string
+		meta    block.ResultMetadata
+	)
+	switch h.handlerType {
+	case findSeriesHandlerType:
+		results, meta, err = h.findSeries(ctx, params, opts)
+	case autoCompleteValuesHandlerType:
+		results, meta, err = h.completeTagValues(ctx, params, opts)
+	default:
+		results, meta, err = h.completeTagNames(ctx, params, opts)
+	}
+	if err != nil {
+		logger.Error("unable to complete graphite tags", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if err := handleroptions.AddDBResultResponseHeaders(w, meta, opts); err != nil {
+		logger.Error("unable to render graphite tags header", zap.Error(err))
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	if err := tagsResultsJSON(w, results, h.handlerType); err != nil {
+		logger.Error("unable to render graphite tags results", zap.Error(err))
+	}
+}
+
+func (h *tagsHandler) completeTagNames(
+	ctx context.Context,
+	params tagsParams,
+	opts *storage.FetchOptions,
+) ([]string, block.ResultMetadata, error) {
+	result, err := h.storage.CompleteTags(ctx, &storage.CompleteTagsQuery{
+		CompleteNameOnly: true,
+		TagMatchers:      params.matchers,
+		Start:            xtime.ToUnixNano(params.start),
+		End:              xtime.ToUnixNano(params.end),
+	}, opts)
+	if err != nil {
+		return nil, block.ResultMetadata{}, err
+	}
+
+	// NB: like graphite, do not autocomplete tags already in the expressions.
+	exclude := make(map[string]struct{}, len(params.exprs))
+	if h.handlerType == autoCompleteTagsHandlerType {
+		for _, expr := range params.exprs {
+			if parsed, err := graphite.ParseTagExpression(expr); err == nil {
+				exclude[parsed.Name] = struct{}{}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(result.CompletedTags))
+	for _, tag := range result.CompletedTags {
+		name := string(tag.Name)
+		if _, isPath := graphite.TagIndex(tag.Name); isPath {
+			// Graphite path tags are not exposed as tags.
+			continue
+		}
+		if _, ok := exclude[name]; ok {
+			continue
+		}
+		if !strings.HasPrefix(name, params.tagPrefix) {
+			continue
+		}
+		if params.filter != nil && !params.filter.MatchString(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	return sortAndLimit(names, params.limit), result.Metadata, nil
+}
+
+func (h *tagsHandler) completeTagValues(
+	ctx context.Context,
+	params tagsParams,
+	opts *storage.FetchOptions,
+) ([]string, block.ResultMetadata, error) {
+	result, err := h.storage.CompleteTags(ctx, &storage.CompleteTagsQuery{
+		CompleteNameOnly: false,
+		FilterNameTags:   [][]byte{[]byte(params.tag)},
+		TagMatchers:      params.matchers,
+		Start:            xtime.ToUnixNano(params.start),
+		End:              xtime.ToUnixNano(params.end),
+	}, opts)
+	if err != nil {
+		return nil, block.ResultMetadata{}, err
+	}
+
+	var values []string
+	for _, tag := range result.CompletedTags {
+		if string(tag.Name) != params.tag {
+			continue
+		}
+		for _, value := range tag.Values {
+			if strings.HasPrefix(string(value), params.valuePrefix) {
+				values = append(values, string(value))
+			}
+		}
+	}
+
+	return sortAndLimit(values, params.limit), result.Metadata, nil
+}
+
+func (h *tagsHandler) findSeries(
+	ctx context.Context,
+	params tagsParams,
+	opts *storage.FetchOptions,
+) ([]string, block.ResultMetadata, error) {
+	result, err := h.searchStorage.SearchSeries(ctx, &storage.FetchQuery{
+		Raw:         graphite.SeriesByTagQuery(params.exprs),
+		TagMatchers: params.matchers,
+		Start:       params.start,
+		End:         params.end,
+	}, opts)
+	if err != nil {
+		return nil, block.ResultMetadata{}, err
+	}
+
+	names := make([]string, 0, len(result.Metrics))
+	for _, metric := range result.Metrics {
+		tags := make(map[string]string, metric.Tags.Len())
+		for _, tag := range metric.Tags.Tags {
+			tags[string(tag.Name)] = string(tag.Value)
+		}
+		if _, ok := tags[graphite.TaggedNameTag]; !ok {
+			continue
+		}
+		names = append(names, graphite.TaggedName(tags))
+	}
+
+	// NB: graphite does not limit find series results.
+	return sortAndLimit(names, 0), result.Metadata, nil
+}
+
+// sortAndLimit sorts and dedupes the results, returning at most limit results
+// unless limit is zero.
+func sortAndLimit(results []string, limit int) []string {
+	sort.Strings(results)
+	deduped := results[:0]
+	for _, result := range results {
+		if n := len(deduped); n > 0 && deduped[n-1] == result {
+			continue
+		}
+		deduped = append(deduped, result)
+	}
+	if limit > 0 && len(deduped) > limit {
+		deduped = deduped[:limit]
+	}
+	return deduped
+}
+
+func tagsResultsJSON(
+	w http.ResponseWriter,
+	results []string,
+	handlerType tagsHandlerType,
+) error {
+	jw := json.NewWriter(w)
+	jw.BeginArray()
+	for _, result := range results {
+		if handlerType != listTagsHandlerType {
+			jw.WriteString(result)
+			continue
+		}
+
+		jw.BeginObject()
+		jw.BeginObjectField("tag")
+		jw.WriteString(result)
+		jw.EndObject()
+	}
+	jw.EndArray()
+	return jw.Close()
+}
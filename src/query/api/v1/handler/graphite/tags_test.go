@@ -0,0 +1,210 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+func newTestTagsHandlerOptions(t *testing.T, store storage.Storage) options.HandlerOptions {
+	builder, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{
+			Timeout: 15 * time.Second,
+		})
+	require.NoError(t, err)
+
+	return options.EmptyHandlerOptions().
+		SetGraphiteFindFetchOptionsBuilder(builder).
+		SetStorage(store)
+}
+
+func serveTagsRequest(h http.Handler, path string, params url.Values) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path+"?"+params.Encode(), nil)
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestTagsHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().
+		CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			query *storage.CompleteTagsQuery,
+			_ *storage.FetchOptions,
+		) (*consolidators.CompleteTagsResult, error) {
+			require.True(t, query.CompleteNameOnly)
+			require.Equal(t, models.Matchers{
+				{Type: models.MatchRegexp, Name: b("name"), Value: b(".*")},
+			}, query.TagMatchers)
+			return &consolidators.CompleteTagsResult{
+				CompleteNameOnly: true,
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("__g0__")},
+					{Name: b("name")},
+					{Name: b("host")},
+					{Name: b("dc")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewTagsHandler(newTestTagsHandlerOptions(t, store))
+	w := serveTagsRequest(h, TagsURL, url.Values{"filter": []string{"^(dc|host)$"}})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `[{"tag":"dc"},{"tag":"host"}]`, w.Body.String())
+}
+
+func TestAutoCompleteTagsHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().
+		CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			query *storage.CompleteTagsQuery,
+			_ *storage.FetchOptions,
+		) (*consolidators.CompleteTagsResult, error) {
+			require.Equal(t, models.Matchers{
+				{Type: models.MatchEqual, Name: b("name"), Value: b("disk.used")},
+			}, query.TagMatchers)
+			return &consolidators.CompleteTagsResult{
+				CompleteNameOnly: true,
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("name")},
+					{Name: b("host")},
+					{Name: b("dc")},
+					{Name: b("datacenter")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewAutoCompleteTagsHandler(newTestTagsHandlerOptions(t, store))
+	w := serveTagsRequest(h, TagsAutoCompleteTagsURL, url.Values{
+		"expr":      []string{"name=disk.used"},
+		"tagPrefix": []string{"d"},
+		"limit":     []string{"1"},
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `["datacenter"]`, w.Body.String())
+}
+
+func TestAutoCompleteValuesHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().
+		CompleteTags(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			query *storage.CompleteTagsQuery,
+			_ *storage.FetchOptions,
+		) (*consolidators.CompleteTagsResult, error) {
+			require.False(t, query.CompleteNameOnly)
+			require.Equal(t, bs("dc"), query.FilterNameTags)
+			return &consolidators.CompleteTagsResult{
+				CompletedTags: []consolidators.CompletedTag{
+					{Name: b("dc"), Values: bs("west", "east", "central")},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewAutoCompleteValuesHandler(newTestTagsHandlerOptions(t, store))
+	w := serveTagsRequest(h, TagsAutoCompleteValuesURL, url.Values{
+		"tag":         []string{"dc"},
+		"valuePrefix": []string{"e"},
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `["east"]`, w.Body.String())
+
+	w = serveTagsRequest(h, TagsAutoCompleteValuesURL, url.Values{})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFindSeriesHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	tagOpts := models.NewTagOptions().SetIDSchemeType(models.TypeGraphite)
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().
+		SearchSeries(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ interface{},
+			query *storage.FetchQuery,
+			_ *storage.FetchOptions,
+		) (*storage.SearchResults, error) {
+			require.Equal(t, models.Matchers{
+				{Type: models.MatchEqual, Name: b("name"), Value: b("disk.used")},
+				{Type: models.MatchRegexp, Name: b("dc"), Value: b("(?:e).*")},
+			}, query.TagMatchers)
+			return &storage.SearchResults{
+				Metrics: models.Metrics{
+					{Tags: models.NewTags(2, tagOpts).AddTags([]models.Tag{
+						{Name: b("name"), Value: b("disk.used")},
+						{Name: b("host"), Value: b("b")},
+						{Name: b("dc"), Value: b("east")},
+					})},
+					{Tags: models.NewTags(2, tagOpts).AddTags([]models.Tag{
+						{Name: b("name"), Value: b("disk.used")},
+						{Name: b("host"), Value: b("a")},
+						{Name: b("dc"), Value: b("east")},
+					})},
+				},
+				Metadata: block.NewResultMetadata(),
+			}, nil
+		})
+
+	h := NewFindSeriesHandler(newTestTagsHandlerOptions(t, store))
+	w := serveTagsRequest(h, TagsFindSeriesURL, url.Values{
+		"expr": []string{"name=disk.used", "dc=~e"},
+	})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `["disk.used;dc=east;host=a","disk.used;dc=east;host=b"]`,
+		w.Body.String())
+
+	w = serveTagsRequest(h, TagsFindSeriesURL, url.Values{})
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
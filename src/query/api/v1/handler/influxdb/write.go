@@ -29,6 +29,7 @@ import (
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"time"
 
 	imodels "github.com/influxdata/influxdb/models"
@@ -36,10 +37,12 @@ import (
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
 	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/api/v1/route"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util/logging"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -54,6 +57,21 @@ const (
 
 	// InfluxWriteHTTPMethod is the HTTP method used with this resource
 	InfluxWriteHTTPMethod = http.MethodPost
+
+	// InfluxV2WriteURL is the Influx DB 2.x write handler URL, which is not
+	// prefixed so that InfluxDB 2.x clients can write to it unchanged.
+	InfluxV2WriteURL = "/api/v2/write"
+
+	// InfluxV2WriteHTTPMethod is the HTTP method used with this resource
+	InfluxV2WriteHTTPMethod = http.MethodPost
+)
+
+// apiVersion is the version of the InfluxDB write API served by a handler.
+type apiVersion int
+
+const (
+	apiV1 apiVersion = iota
+	apiV2
 )
 
 var defaultValue = ingest.IterValue{
@@ -66,6 +84,7 @@ type ingestWriteHandler struct {
 	handlerOpts  options.HandlerOptions
 	tagOpts      models.TagOptions
 	promRewriter *promRewriter
+	version      apiVersion
 }
 
 type ingestField struct {
@@ -286,27 +305,50 @@ func (ii *ingestIterator) CurrentMetadata() ts.Metadata {
 
 // NewInfluxWriterHandler returns a new influx write handler.
 func NewInfluxWriterHandler(options options.HandlerOptions) http.Handler {
+	return newIngestWriteHandler(options, apiV1)
+}
+
+// NewInfluxV2WriterHandler returns a new influx write handler serving the
+// InfluxDB 2.x write API, where the bucket written to is the name of the
+// namespace the points are stored in.
+func NewInfluxV2WriterHandler(options options.HandlerOptions) http.Handler {
+	return newIngestWriteHandler(options, apiV2)
+}
+
+func newIngestWriteHandler(options options.HandlerOptions, version apiVersion) http.Handler {
 	return &ingestWriteHandler{
 		handlerOpts:  options,
 		tagOpts:      options.TagOptions(),
 		promRewriter: newPromRewriter(),
+		version:      version,
 	}
 }
 
 func (iwh *ingestWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if r.Body == http.NoBody {
-		xhttp.WriteError(w, xhttp.NewError(errors.New("empty request body"), http.StatusBadRequest))
+		iwh.writeError(w, errors.New("empty request body"), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := iwh.writeOptions(r)
+	if err != nil {
+		iwh.writeError(w, err, statusCode(err))
+		return
+	}
+
+	precision, err := parsePrecision(r.URL.Query().Get("precision"), iwh.version)
+	if err != nil {
+		iwh.writeError(w, err, http.StatusBadRequest)
 		return
 	}
 
 	var bytes []byte
-	var err error
 	var reader io.ReadCloser
 
 	if r.Header.Get("Content-Encoding") == "gzip" {
 		reader, err = gzip.NewReader(r.Body)
 		if err != nil {
-			xhttp.WriteError(w, xhttp.NewError(err, http.StatusBadRequest))
+			iwh.writeError(w, err, http.StatusBadRequest)
 			return
 		}
 	} else {
@@ -315,22 +357,21 @@ func (iwh *ingestWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 
 	bytes, err = ioutil.ReadAll(reader)
 	if err != nil {
-		xhttp.WriteError(w, err)
+		iwh.writeError(w, err, http.StatusInternalServerError)
 		return
 	}
 
 	err = reader.Close()
 	if err != nil {
-		xhttp.WriteError(w, err)
+		iwh.writeError(w, err, http.StatusInternalServerError)
 		return
 	}
 
-	// InfluxDB line protocol v1.8 supports following precision values ns, u, ms, s, m and h
-	// If precision is not given, nanosecond precision is assumed
-	precision := r.URL.Query().Get("precision")
-	points, err := imodels.ParsePointsWithPrecision(bytes, time.Now().UTC(), precision)
-	if err != nil {
-		xhttp.WriteError(w, err)
+	// NB: like InfluxDB, the lines which parse are written even if
+	// others fail to, reporting the failures as a partial write.
+	points, parseErr := imodels.ParsePointsWithPrecision(bytes, time.Now().UTC(), precision)
+	if parseErr != nil && len(points) == 0 {
+		iwh.writeError(w, parseErr, http.StatusBadRequest)
 		return
 	}
 
@@ -339,12 +380,12 @@ func (iwh *ingestWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 	var mapTagsOpts handleroptions.MapTagsOptions
 	if mapStr := r.Header.Get(headers.MapTagsByJSONHeader); mapStr != "" {
 		if err := json.Unmarshal([]byte(mapStr), &mapTagsOpts); err != nil {
-			xhttp.WriteError(w, xhttp.NewError(err, http.StatusBadRequest))
+			iwh.writeError(w, err, http.StatusBadRequest)
 			return
 		}
 		for _, mapper := range mapTagsOpts.TagMappers {
 			if err := mapper.Validate(); err != nil {
-				xhttp.WriteError(w, xhttp.NewError(err, http.StatusBadRequest))
+				iwh.writeError(w, err, http.StatusBadRequest)
 				return
 			}
 
@@ -356,81 +397,211 @@ func (iwh *ingestWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 
 			if op := mapper.Drop; !op.IsEmpty() {
 				err := errors.New("'drop' operation is not yet supported")
-				xhttp.WriteError(w, xhttp.NewError(err, http.StatusBadRequest))
+				iwh.writeError(w, err, http.StatusBadRequest)
 				return
 			}
 
 			if op := mapper.DropWithValue; !op.IsEmpty() {
 				err := errors.New("'dropWithValue' operation is not yet supported")
-				xhttp.WriteError(w, xhttp.NewError(err, http.StatusBadRequest))
+				iwh.writeError(w, err, http.StatusBadRequest)
 				return
 			}
 
 			if op := mapper.Replace; !op.IsEmpty() {
 				err := errors.New("'replace' operation is not yet supported")
-				xhttp.WriteError(w, xhttp.NewError(err, http.StatusBadRequest))
+				iwh.writeError(w, err, http.StatusBadRequest)
 				return
 			}
 		}
 	}
 
-	opts := ingest.WriteOptions{}
 	iter := &ingestIterator{points: points, tagOpts: iwh.tagOpts, promRewriter: iwh.promRewriter, writeTags: writeTags}
 	batchErr := iwh.handlerOpts.DownsamplerAndWriter().WriteBatch(r.Context(), iter, opts)
-	if batchErr == nil {
+	if batchErr == nil && parseErr == nil {
 		w.WriteHeader(http.StatusNoContent)
 		return
 	}
+
 	var (
-		errs              = batchErr.Errors()
 		lastRegularErr    string
 		lastBadRequestErr string
 		numRegular        int
 		numBadRequest     int
 	)
-	for _, err := range errs {
-		switch {
-		case client.IsBadRequestError(err):
-			numBadRequest++
-			lastBadRequestErr = err.Error()
-		case xerrors.IsInvalidParams(err):
-			numBadRequest++
-			lastBadRequestErr = err.Error()
-		default:
-			numRegular++
-			lastRegularErr = err.Error()
+	if batchErr != nil {
+		errs := batchErr.Errors()
+		for _, err := range errs {
+			switch {
+			case client.IsBadRequestError(err):
+				numBadRequest++
+				lastBadRequestErr = err.Error()
+			case xerrors.IsInvalidParams(err):
+				numBadRequest++
+				lastBadRequestErr = err.Error()
+			default:
+				numRegular++
+				lastRegularErr = err.Error()
+			}
 		}
 	}
 
-	var status int
-	switch {
-	case numBadRequest == len(errs):
-		status = http.StatusBadRequest
-	default:
+	// NB: a failed write of any point is retryable, points which were
+	// written are simply overwritten when the batch is retried.
+	status := http.StatusBadRequest
+	if numRegular > 0 {
 		status = http.StatusInternalServerError
 	}
 
-	logger := logging.WithContext(r.Context(), iwh.handlerOpts.InstrumentOpts())
-	logger.Error("write error",
-		zap.String("remoteAddr", r.RemoteAddr),
-		zap.Int("httpResponseStatusCode", status),
-		zap.Int("numRegularErrors", numRegular),
-		zap.Int("numBadRequestErrors", numBadRequest),
-		zap.String("lastRegularError", lastRegularErr),
-		zap.String("lastBadRequestErr", lastBadRequestErr))
+	if batchErr != nil {
+		logger := logging.WithContext(r.Context(), iwh.handlerOpts.InstrumentOpts())
+		logger.Error("write error",
+			zap.String("remoteAddr", r.RemoteAddr),
+			zap.Int("httpResponseStatusCode", status),
+			zap.Int("numRegularErrors", numRegular),
+			zap.Int("numBadRequestErrors", numBadRequest),
+			zap.String("lastRegularError", lastRegularErr),
+			zap.String("lastBadRequestErr", lastBadRequestErr))
+	}
 
-	var resultErr string
+	var resultErrs []string
+	if parseErr != nil {
+		resultErrs = append(resultErrs, fmt.Sprintf("partial write: %s", parseErr.Error()))
+	}
 	if lastRegularErr != "" {
-		resultErr = fmt.Sprintf("retryable_errors: count=%d, last=%s",
-			numRegular, lastRegularErr)
+		resultErrs = append(resultErrs, fmt.Sprintf("retryable_errors: count=%d, last=%s",
+			numRegular, lastRegularErr))
 	}
 	if lastBadRequestErr != "" {
-		var sep string
-		if lastRegularErr != "" {
-			sep = ", "
+		resultErrs = append(resultErrs, fmt.Sprintf("bad_request_errors: count=%d, last=%s",
+			numBadRequest, lastBadRequestErr))
+	}
+	iwh.writeError(w, errors.New(strings.Join(resultErrs, ", ")), status)
+}
+
+// writeOptions returns the options of writes to the bucket of a v2 write
+// request, v1 writes use the default downsampling rules and storage policies.
+func (iwh *ingestWriteHandler) writeOptions(r *http.Request) (ingest.WriteOptions, error) {
+	if iwh.version != apiV2 {
+		return ingest.WriteOptions{}, nil
+	}
+
+	// NB: the organization is accepted for compatibility with InfluxDB
+	// clients but otherwise ignored since M3 has no notion of it.
+	bucket := r.URL.Query().Get("bucket")
+	if bucket == "" {
+		return ingest.WriteOptions{}, xerrors.NewInvalidParamsError(errors.New("bucket is required"))
+	}
+
+	attrs, err := iwh.bucketAttributes(bucket)
+	if err != nil {
+		return ingest.WriteOptions{}, err
+	}
+
+	// Override the downsampling rules with zero rules so that only
+	// direct writes to the namespace of the bucket are made.
+	opts := ingest.WriteOptions{DownsampleOverride: true}
+	if attrs.MetricsType == storagemetadata.AggregatedMetricsType {
+		opts.WriteOverride = true
+		opts.WriteStoragePolicies = policy.StoragePolicies{
+			policy.NewStoragePolicy(attrs.Resolution, xtime.Second, attrs.Retention),
+		}
+	}
+	return opts, nil
+}
+
+// bucketAttributes returns the attributes of the namespace with the name of
+// the bucket.
+func (iwh *ingestWriteHandler) bucketAttributes(bucket string) (storagemetadata.Attributes, error) {
+	clusters := iwh.handlerOpts.Clusters()
+	if clusters != nil {
+		for _, ns := range clusters.ClusterNamespaces() {
+			if ns.NamespaceID().String() != bucket {
+				continue
+			}
+			if ns.Options().ReadOnly() {
+				err := fmt.Errorf("bucket %q is read only", bucket)
+				return storagemetadata.Attributes{}, xerrors.NewInvalidParamsError(err)
+			}
+			return ns.Options().Attributes(), nil
+		}
+	}
+
+	err := fmt.Errorf("bucket %q not found", bucket)
+	return storagemetadata.Attributes{}, xhttp.NewError(err, http.StatusNotFound)
+}
+
+// writeError writes an error with the response body expected by clients of
+// the API version of the handler.
+func (iwh *ingestWriteHandler) writeError(w http.ResponseWriter, err error, status int) {
+	err = xhttp.NewError(err, status)
+	if iwh.version != apiV2 {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	body, jsonErr := json.Marshal(influxV2Error{
+		Code:    influxV2ErrorCode(status),
+		Message: err.Error(),
+	})
+	if jsonErr != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+	xhttp.WriteError(w, err, xhttp.WithErrorResponse(body))
+}
+
+func statusCode(err error) int {
+	var httpErr xhttp.Error
+	switch {
+	case errors.As(err, &httpErr):
+		return httpErr.Code()
+	case xerrors.IsInvalidParams(err):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// influxV2Error is the body of the error responses of the InfluxDB 2.x API.
+type influxV2Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func influxV2ErrorCode(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return "invalid"
+	case http.StatusNotFound:
+		return "not found"
+	case http.StatusRequestEntityTooLarge:
+		return "request too large"
+	default:
+		return "internal error"
+	}
+}
+
+// parsePrecision returns the precision of the InfluxDB models package
+// matching the precision of a write request, which defaults to nanoseconds.
+func parsePrecision(precision string, version apiVersion) (string, error) {
+	switch precision {
+	case "", "ns", "n":
+		return "n", nil
+	case "us", "u":
+		return "u", nil
+	case "ms":
+		return "ms", nil
+	case "s":
+		return "s", nil
+	}
+	if version == apiV1 {
+		// InfluxDB line protocol v1.8 also supports minutes and hours.
+		switch precision {
+		case "m", "h":
+			return precision, nil
 		}
-		resultErr = fmt.Sprintf("%s%sbad_request_errors: count=%d, last=%s",
-			resultErr, sep, numBadRequest, lastBadRequestErr)
 	}
-	xhttp.WriteError(w, xhttp.NewError(errors.New(resultErr), status))
+	err := fmt.Errorf("invalid precision %q", precision)
+	return "", xerrors.NewInvalidParamsError(err)
 }
@@ -24,10 +24,14 @@ import (
 	"bytes"
 	"compress/gzip"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -37,8 +41,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/metrics/policy"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/m3"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/ident"
 	xtest "github.com/m3db/m3/src/x/test"
 	xtime "github.com/m3db/m3/src/x/time"
 )
@@ -288,6 +297,16 @@ func TestInfluxDBWritePrecision(t *testing.T) {
 			expectedStatus: http.StatusNoContent,
 			precision:      "",
 		},
+		{
+			name:           "Nanosecond precision",
+			expectedStatus: http.StatusNoContent,
+			precision:      "ns",
+		},
+		{
+			name:           "Microsecond precision",
+			expectedStatus: http.StatusNoContent,
+			precision:      "us",
+		},
 		{
 			name:           "Millisecond precision",
 			expectedStatus: http.StatusNoContent,
@@ -308,8 +327,10 @@ func TestInfluxDBWritePrecision(t *testing.T) {
 		t.Run(testCase.name, func(tt *testing.T) {
 			var precision time.Duration
 			switch testCase.precision {
-			case "":
+			case "", "ns":
 				precision = time.Nanosecond
+			case "us":
+				precision = time.Microsecond
 			case "ms":
 				precision = time.Millisecond
 			case "s":
@@ -349,3 +370,163 @@ func TestInfluxDBWritePrecision(t *testing.T) {
 		})
 	}
 }
+
+func TestInfluxDBWriteInvalidPrecision(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	handler := NewInfluxWriterHandler(makeOptions(mockDownsamplerAndWriter))
+
+	msg := makeInfluxDBLineProtocolMessage(t, false, time.Now(), time.Nanosecond)
+	req := httptest.NewRequest(InfluxWriteHTTPMethod, InfluxWriteURL+"?precision=d", msg)
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	resp := writer.Result()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	resp.Body.Close()
+}
+
+func TestInfluxDBWritePartial(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(
+		_ context.Context,
+		iter *ingestIterator,
+		opts ingest.WriteOptions,
+	) interface{} {
+		require.Equal(t, 1, len(iter.points))
+		return nil
+	}).Times(1)
+
+	handler := NewInfluxWriterHandler(makeOptions(mockDownsamplerAndWriter))
+
+	body := "weather,location=us-midwest temperature=82 1574838670386469800\nweather temperature\n"
+	req := httptest.NewRequest(InfluxWriteHTTPMethod, InfluxWriteURL, strings.NewReader(body))
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	resp := writer.Result()
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(respBody), "partial write: unable to parse 'weather temperature'")
+}
+
+func TestInfluxDBV2Write(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	clusters, err := m3.NewClusters(m3.UnaggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("default"),
+		Session:     client.NewMockSession(ctrl),
+		Retention:   48 * time.Hour,
+	}, m3.AggregatedClusterNamespaceDefinition{
+		NamespaceID: ident.StringID("metrics_1m_40d"),
+		Session:     client.NewMockSession(ctrl),
+		Retention:   40 * 24 * time.Hour,
+		Resolution:  time.Minute,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name            string
+		query           string
+		writeErr        error
+		expectedOpts    *ingest.WriteOptions
+		expectedStatus  int
+		expectedErrCode string
+	}{
+		{
+			name:           "Unaggregated bucket",
+			query:          "?org=m3&bucket=default&precision=s",
+			expectedOpts:   &ingest.WriteOptions{DownsampleOverride: true},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:  "Aggregated bucket",
+			query: "?org=m3&bucket=metrics_1m_40d&precision=s",
+			expectedOpts: &ingest.WriteOptions{
+				DownsampleOverride: true,
+				WriteOverride:      true,
+				WriteStoragePolicies: policy.StoragePolicies{
+					policy.MustParseStoragePolicy("1m@1s:40d"),
+				},
+			},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:            "Missing bucket",
+			query:           "?org=m3&precision=s",
+			expectedStatus:  http.StatusBadRequest,
+			expectedErrCode: "invalid",
+		},
+		{
+			name:            "Unknown bucket",
+			query:           "?org=m3&bucket=unknown&precision=s",
+			expectedStatus:  http.StatusNotFound,
+			expectedErrCode: "not found",
+		},
+		{
+			name:            "Invalid precision",
+			query:           "?org=m3&bucket=default&precision=m",
+			expectedStatus:  http.StatusBadRequest,
+			expectedErrCode: "invalid",
+		},
+		{
+			name:            "Write error",
+			query:           "?org=m3&bucket=default&precision=s",
+			writeErr:        errors.New("write failed"),
+			expectedOpts:    &ingest.WriteOptions{DownsampleOverride: true},
+			expectedStatus:  http.StatusInternalServerError,
+			expectedErrCode: "internal error",
+		},
+	}
+
+	for _, testCase := range tests {
+		testCase := testCase
+		t.Run(testCase.name, func(t *testing.T) {
+			mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+			if testCase.expectedOpts != nil {
+				mockDownsamplerAndWriter.
+					EXPECT().
+					WriteBatch(gomock.Any(), gomock.Any(), *testCase.expectedOpts).
+					DoAndReturn(func(
+						_ context.Context,
+						_ *ingestIterator,
+						_ ingest.WriteOptions,
+					) ingest.BatchError {
+						if testCase.writeErr == nil {
+							return nil
+						}
+						return xerrors.NewMultiError().Add(testCase.writeErr)
+					}).
+					Times(1)
+			}
+
+			opts := makeOptions(mockDownsamplerAndWriter).SetClusters(clusters)
+			handler := NewInfluxV2WriterHandler(opts)
+
+			msg := makeInfluxDBLineProtocolMessage(t, false, time.Now(), time.Second)
+			req := httptest.NewRequest(InfluxV2WriteHTTPMethod, InfluxV2WriteURL+testCase.query, msg)
+			writer := httptest.NewRecorder()
+			handler.ServeHTTP(writer, req)
+			resp := writer.Result()
+			defer resp.Body.Close()
+			require.Equal(t, testCase.expectedStatus, resp.StatusCode)
+			if testCase.expectedErrCode == "" {
+				return
+			}
+
+			var respErr influxV2Error
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&respErr))
+			assert.Equal(t, testCase.expectedErrCode, respErr.Code)
+			assert.NotEmpty(t, respErr.Message)
+		})
+	}
+}
@@ -151,6 +151,7 @@ func TestNamespaceAddHandler(t *testing.T) {
 						"runtimeOptions":    nil,
 						"schemaOptions":     nil,
 						"coldWritesEnabled": false,
+						"compression":       "NO_COMPRESSION",
 						"extendedOptions":   xtest.NewTestExtendedOptionsJSON("foo"),
 					},
 				},
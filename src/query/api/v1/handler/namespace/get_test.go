@@ -127,6 +127,7 @@ func TestNamespaceGetHandler(t *testing.T) {
 						"cacheBlocksOnRetrieve": nil,
 						"cleanupEnabled":        false,
 						"coldWritesEnabled":     false,
+						"compression":           "NO_COMPRESSION",
 						"flushEnabled":          true,
 						"indexOptions":          nil,
 						"repairEnabled":         false,
@@ -212,6 +213,7 @@ func TestNamespaceGetHandlerWithDebug(t *testing.T) {
 						"cacheBlocksOnRetrieve": nil,
 						"cleanupEnabled":        false,
 						"coldWritesEnabled":     false,
+						"compression":           "NO_COMPRESSION",
 						"flushEnabled":          true,
 						"indexOptions":          nil,
 						"repairEnabled":         false,
@@ -202,6 +202,7 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 						"schemaOptions":     nil,
 						"stagingState":      xjson.Map{"status": "UNKNOWN"},
 						"coldWritesEnabled": false,
+						"compression":       "NO_COMPRESSION",
 						"extendedOptions":   xtest.NewTestExtendedOptionsJSON("bar"),
 					},
 				},
@@ -260,6 +261,7 @@ func TestNamespaceUpdateHandler(t *testing.T) {
 						"schemaOptions":     nil,
 						"stagingState":      xjson.Map{"status": "UNKNOWN"},
 						"coldWritesEnabled": false,
+						"compression":       "NO_COMPRESSION",
 						"extendedOptions":   xtest.NewTestExtendedOptionsJSON("foo"),
 					},
 				},
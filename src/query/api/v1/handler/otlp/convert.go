@@ -0,0 +1,368 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	metricNameLabel = "__name__"
+	bucketLabel     = "le"
+	jobLabel        = "job"
+	instanceLabel   = "instance"
+
+	serviceNameAttribute       = "service.name"
+	serviceNamespaceAttribute  = "service.namespace"
+	serviceInstanceIDAttribute = "service.instance.id"
+
+	counterSuffix = "_total"
+	bucketSuffix  = "_bucket"
+	sumSuffix     = "_sum"
+	countSuffix   = "_count"
+)
+
+// series is a single converted series along with the Prometheus series
+// attributes it was derived from, since write queries do not carry them.
+type series struct {
+	query      *storage.WriteQuery
+	attributes ts.SeriesAttributes
+}
+
+// conversionResult is the result of converting an OTLP export request.
+type conversionResult struct {
+	series []series
+	// dropped is the number of datapoints that could not be represented
+	// as Prometheus style series, e.g. delta sums or summaries.
+	dropped int
+}
+
+// convertRequest converts an OTLP metrics export request into write queries,
+// following the same naming conventions as the Prometheus OTLP translation:
+// monotonic cumulative sums become counters suffixed with "_total",
+// non-monotonic sums and gauges become gauges and explicit bucket histograms
+// are expanded into "_bucket", "_sum" and "_count" series.
+func convertRequest(
+	req *colmetricspb.ExportMetricsServiceRequest,
+	tagOpts models.TagOptions,
+) (conversionResult, error) {
+	var result conversionResult
+	for _, rm := range req.GetResourceMetrics() {
+		resourceLabels := resourceToLabels(rm.GetResource().GetAttributes())
+		for _, ilm := range rm.GetInstrumentationLibraryMetrics() {
+			for _, metric := range ilm.GetMetrics() {
+				if err := result.addMetric(metric, resourceLabels, tagOpts); err != nil {
+					return conversionResult{}, err
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *conversionResult) addMetric(
+	metric *metricspb.Metric,
+	resourceLabels []prompb.Label,
+	tagOpts models.TagOptions,
+) error {
+	name := sanitizeMetricName(metric.GetName())
+	switch {
+	case metric.GetGauge() != nil:
+		attrs := ts.SeriesAttributes{PromType: ts.PromMetricTypeGauge}
+		for _, dp := range metric.GetGauge().GetDataPoints() {
+			if err := r.addNumberDataPoint(name, dp, attrs, resourceLabels, tagOpts); err != nil {
+				return err
+			}
+		}
+	case metric.GetSum() != nil:
+		sum := metric.GetSum()
+		if sum.GetAggregationTemporality() != metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE {
+			r.dropped += len(sum.GetDataPoints())
+			return nil
+		}
+
+		attrs := ts.SeriesAttributes{PromType: ts.PromMetricTypeGauge}
+		if sum.GetIsMonotonic() {
+			attrs = ts.SeriesAttributes{
+				PromType:          ts.PromMetricTypeCounter,
+				HandleValueResets: true,
+			}
+			if !strings.HasSuffix(name, counterSuffix) {
+				name += counterSuffix
+			}
+		}
+		for _, dp := range sum.GetDataPoints() {
+			if err := r.addNumberDataPoint(name, dp, attrs, resourceLabels, tagOpts); err != nil {
+				return err
+			}
+		}
+	case metric.GetHistogram() != nil:
+		histogram := metric.GetHistogram()
+		if histogram.GetAggregationTemporality() != metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE {
+			r.dropped += len(histogram.GetDataPoints())
+			return nil
+		}
+
+		for _, dp := range histogram.GetDataPoints() {
+			if err := r.addHistogramDataPoint(name, dp, resourceLabels, tagOpts); err != nil {
+				return err
+			}
+		}
+	case metric.GetSummary() != nil:
+		r.dropped += len(metric.GetSummary().GetDataPoints())
+	}
+	return nil
+}
+
+func (r *conversionResult) addNumberDataPoint(
+	name string,
+	dp *metricspb.NumberDataPoint,
+	attrs ts.SeriesAttributes,
+	resourceLabels []prompb.Label,
+	tagOpts models.TagOptions,
+) error {
+	var value float64
+	switch v := dp.GetValue().(type) {
+	case *metricspb.NumberDataPoint_AsDouble:
+		value = v.AsDouble
+	case *metricspb.NumberDataPoint_AsInt:
+		value = float64(v.AsInt)
+	default:
+		r.dropped++
+		return nil
+	}
+
+	labels := dataPointLabels(name, dp.GetAttributes(), resourceLabels)
+	return r.add(labels, dp.GetTimeUnixNano(), value, attrs, tagOpts)
+}
+
+func (r *conversionResult) addHistogramDataPoint(
+	name string,
+	dp *metricspb.HistogramDataPoint,
+	resourceLabels []prompb.Label,
+	tagOpts models.TagOptions,
+) error {
+	var (
+		timestamp = dp.GetTimeUnixNano()
+		bounds    = dp.GetExplicitBounds()
+		counts    = dp.GetBucketCounts()
+		attrs     = ts.SeriesAttributes{
+			PromType:          ts.PromMetricTypeHistogram,
+			HandleValueResets: true,
+		}
+	)
+
+	sumLabels := dataPointLabels(name+sumSuffix, dp.GetAttributes(), resourceLabels)
+	if err := r.add(sumLabels, timestamp, dp.GetSum(), attrs, tagOpts); err != nil {
+		return err
+	}
+
+	countLabels := dataPointLabels(name+countSuffix, dp.GetAttributes(), resourceLabels)
+	if err := r.add(countLabels, timestamp, float64(dp.GetCount()), attrs, tagOpts); err != nil {
+		return err
+	}
+
+	// NB: OTLP bucket counts are per bucket whereas Prometheus buckets are
+	// cumulative, the final bucket count (if present) is the +Inf bucket.
+	var cumulative uint64
+	for i, bound := range bounds {
+		if i < len(counts) {
+			cumulative += counts[i]
+		}
+		le := strconv.FormatFloat(bound, 'f', -1, 64)
+		labels := dataPointLabels(name+bucketSuffix, dp.GetAttributes(), resourceLabels)
+		labels = append(labels, prompb.Label{
+			Name:  []byte(bucketLabel),
+			Value: []byte(le),
+		})
+		if err := r.add(labels, timestamp, float64(cumulative), attrs, tagOpts); err != nil {
+			return err
+		}
+	}
+
+	infLabels := dataPointLabels(name+bucketSuffix, dp.GetAttributes(), resourceLabels)
+	infLabels = append(infLabels, prompb.Label{
+		Name:  []byte(bucketLabel),
+		Value: []byte(strconv.FormatFloat(math.Inf(1), 'f', -1, 64)),
+	})
+	return r.add(infLabels, timestamp, float64(dp.GetCount()), attrs, tagOpts)
+}
+
+func (r *conversionResult) add(
+	labels []prompb.Label,
+	timestampNanos uint64,
+	value float64,
+	attrs ts.SeriesAttributes,
+	tagOpts models.TagOptions,
+) error {
+	// NB: truncate to millisecond precision to match the resolution
+	// of series written via Prometheus remote write.
+	timestamp := xtime.UnixNano(timestampNanos).Truncate(time.Millisecond)
+	query, err := storage.NewWriteQuery(storage.WriteQueryOptions{
+		Tags: storage.PromLabelsToM3Tags(labels, tagOpts),
+		Datapoints: ts.Datapoints{
+			{Timestamp: timestamp, Value: value},
+		},
+		Unit: xtime.Millisecond,
+		Attributes: storagemetadata.Attributes{
+			MetricsType: storagemetadata.UnaggregatedMetricsType,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	r.series = append(r.series, series{query: query, attributes: attrs})
+	return nil
+}
+
+// resourceToLabels converts the well known service resource attributes into
+// the job and instance labels, matching Prometheus target labels.
+func resourceToLabels(attrs []*commonpb.KeyValue) []prompb.Label {
+	var serviceName, serviceNamespace, serviceInstanceID string
+	for _, kv := range attrs {
+		switch kv.GetKey() {
+		case serviceNameAttribute:
+			serviceName = attributeValue(kv.GetValue())
+		case serviceNamespaceAttribute:
+			serviceNamespace = attributeValue(kv.GetValue())
+		case serviceInstanceIDAttribute:
+			serviceInstanceID = attributeValue(kv.GetValue())
+		}
+	}
+
+	var labels []prompb.Label
+	if serviceName != "" {
+		job := serviceName
+		if serviceNamespace != "" {
+			job = serviceNamespace + "/" + serviceName
+		}
+		labels = append(labels, prompb.Label{
+			Name:  []byte(jobLabel),
+			Value: []byte(job),
+		})
+	}
+	if serviceInstanceID != "" {
+		labels = append(labels, prompb.Label{
+			Name:  []byte(instanceLabel),
+			Value: []byte(serviceInstanceID),
+		})
+	}
+	return labels
+}
+
+func dataPointLabels(
+	name string,
+	attrs []*commonpb.KeyValue,
+	resourceLabels []prompb.Label,
+) []prompb.Label {
+	labels := make([]prompb.Label, 0, 2+len(attrs)+len(resourceLabels))
+	labels = append(labels, prompb.Label{
+		Name:  []byte(metricNameLabel),
+		Value: []byte(name),
+	})
+
+	seen := make(map[string]struct{}, 1+len(attrs))
+	seen[metricNameLabel] = struct{}{}
+	for _, kv := range attrs {
+		key := sanitizeLabelName(kv.GetKey())
+		value := attributeValue(kv.GetValue())
+		if key == "" || value == "" {
+			continue
+		}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		labels = append(labels, prompb.Label{
+			Name:  []byte(key),
+			Value: []byte(value),
+		})
+	}
+
+	// Data point attributes take precedence over resource labels.
+	for _, l := range resourceLabels {
+		if _, ok := seen[string(l.Name)]; ok {
+			continue
+		}
+		labels = append(labels, l)
+	}
+	return labels
+}
+
+func attributeValue(v *commonpb.AnyValue) string {
+	switch value := v.GetValue().(type) {
+	case *commonpb.AnyValue_StringValue:
+		return value.StringValue
+	case *commonpb.AnyValue_BoolValue:
+		return strconv.FormatBool(value.BoolValue)
+	case *commonpb.AnyValue_IntValue:
+		return strconv.FormatInt(value.IntValue, 10)
+	case *commonpb.AnyValue_DoubleValue:
+		return strconv.FormatFloat(value.DoubleValue, 'f', -1, 64)
+	default:
+		return ""
+	}
+}
+
+// sanitizeMetricName replaces any characters not allowed in a Prometheus
+// metric name with underscores.
+func sanitizeMetricName(name string) string {
+	return sanitize(name, true)
+}
+
+// sanitizeLabelName replaces any characters not allowed in a Prometheus
+// label name with underscores.
+func sanitizeLabelName(name string) string {
+	return sanitize(name, false)
+}
+
+func sanitize(name string, allowColon bool) string {
+	if name == "" {
+		return name
+	}
+
+	b := []byte(name)
+	for i, c := range b {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_':
+		case c == ':' && allowColon:
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,387 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package otlp provides an OpenTelemetry protocol metrics ingestion endpoint.
+package otlp
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/uber-go/tally"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// WriteURL is the url for the OTLP metrics write handler.
+	WriteURL = route.Prefix + "/otlp/v1/metrics"
+
+	// WriteHTTPMethod is the HTTP method used with this resource.
+	WriteHTTPMethod = http.MethodPost
+
+	protobufContentType = "application/x-protobuf"
+	jsonContentType     = "application/json"
+)
+
+var (
+	errNoDownsamplerAndWriter = errors.New("no downsampler and writer set")
+	errNoTagOptions           = errors.New("no tag options set")
+	errEmptyBody              = errors.New("empty request body")
+
+	defaultValue = ingest.IterValue{
+		Tags:       models.EmptyTags(),
+		Attributes: ts.DefaultSeriesAttributes(),
+		Metadata:   ts.Metadata{},
+	}
+)
+
+type writeHandler struct {
+	downsamplerAndWriter ingest.DownsamplerAndWriter
+	tagOptions           models.TagOptions
+	storeMetricsType     bool
+	instrumentOpts       instrument.Options
+	metrics              writeMetrics
+}
+
+// NewWriteHandler returns a new OTLP metrics write handler, which accepts
+// OTLP/HTTP export requests encoded as either protobuf or JSON.
+func NewWriteHandler(options options.HandlerOptions) (http.Handler, error) {
+	var (
+		downsamplerAndWriter = options.DownsamplerAndWriter()
+		tagOptions           = options.TagOptions()
+	)
+
+	if downsamplerAndWriter == nil {
+		return nil, errNoDownsamplerAndWriter
+	}
+
+	if tagOptions == nil {
+		return nil, errNoTagOptions
+	}
+
+	scope := options.InstrumentOpts().
+		MetricsScope().
+		Tagged(map[string]string{"handler": "otlp-write"})
+
+	return &writeHandler{
+		downsamplerAndWriter: downsamplerAndWriter,
+		tagOptions:           tagOptions,
+		storeMetricsType:     options.StoreMetricsType(),
+		instrumentOpts:       options.InstrumentOpts(),
+		metrics:              newWriteMetrics(scope),
+	}, nil
+}
+
+type writeMetrics struct {
+	writeSuccess      tally.Counter
+	writeErrorsServer tally.Counter
+	writeErrorsClient tally.Counter
+	droppedPoints     tally.Counter
+}
+
+func (m *writeMetrics) incError(err error) {
+	if xhttp.IsClientError(err) {
+		m.writeErrorsClient.Inc(1)
+	} else {
+		m.writeErrorsServer.Inc(1)
+	}
+}
+
+func newWriteMetrics(scope tally.Scope) writeMetrics {
+	return writeMetrics{
+		writeSuccess:      scope.SubScope("write").Counter("success"),
+		writeErrorsServer: scope.SubScope("write").Tagged(map[string]string{"code": "5XX"}).Counter("errors"),
+		writeErrorsClient: scope.SubScope("write").Tagged(map[string]string{"code": "4XX"}).Counter("errors"),
+		droppedPoints:     scope.SubScope("write").Counter("dropped-datapoints"),
+	}
+}
+
+func (h *writeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, contentType, err := h.parseRequest(r)
+	if err != nil {
+		err = xhttp.NewError(err, http.StatusBadRequest)
+		h.metrics.incError(err)
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	result, err := convertRequest(req, h.tagOptions)
+	if err != nil {
+		// Conversion only fails on invalid series, which is a client error.
+		err = xhttp.NewError(err, http.StatusBadRequest)
+		h.metrics.incError(err)
+		xhttp.WriteError(w, err)
+		return
+	}
+	h.metrics.droppedPoints.Inc(int64(result.dropped))
+
+	iter := newSeriesIter(result.series, h.storeMetricsType)
+	batchErr := h.downsamplerAndWriter.WriteBatch(r.Context(), iter, ingest.WriteOptions{})
+	if batchErr != nil {
+		err := h.batchError(r, batchErr)
+		h.metrics.incError(err)
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	body, err := marshalResponse(&colmetricspb.ExportMetricsServiceResponse{}, contentType)
+	if err != nil {
+		h.metrics.incError(err)
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set(xhttp.HeaderContentType, contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+	h.metrics.writeSuccess.Inc(1)
+}
+
+func (h *writeHandler) parseRequest(
+	r *http.Request,
+) (*colmetricspb.ExportMetricsServiceRequest, string, error) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, "", errEmptyBody
+	}
+
+	contentType := protobufContentType
+	if v := r.Header.Get(xhttp.HeaderContentType); v != "" {
+		mediaType, _, err := mime.ParseMediaType(v)
+		if err != nil {
+			return nil, "", err
+		}
+		contentType = mediaType
+	}
+
+	var reader io.ReadCloser = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			return nil, "", err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var req colmetricspb.ExportMetricsServiceRequest
+	switch contentType {
+	case protobufContentType:
+		err = proto.Unmarshal(body, &req)
+	case jsonContentType:
+		err = protojson.Unmarshal(body, &req)
+	default:
+		err = fmt.Errorf("unsupported content type: %s", contentType)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &req, contentType, nil
+}
+
+func marshalResponse(
+	resp *colmetricspb.ExportMetricsServiceResponse,
+	contentType string,
+) ([]byte, error) {
+	if contentType == jsonContentType {
+		return protojson.Marshal(resp)
+	}
+	return proto.Marshal(resp)
+}
+
+func (h *writeHandler) batchError(r *http.Request, batchErr ingest.BatchError) error {
+	var (
+		errs                 = batchErr.Errors()
+		lastRegularErr       string
+		lastBadRequestErr    string
+		numRegular           int
+		numBadRequest        int
+		numResourceExhausted int
+	)
+	for _, err := range errs {
+		switch {
+		case client.IsResourceExhaustedError(err):
+			numResourceExhausted++
+			lastBadRequestErr = err.Error()
+		case client.IsBadRequestError(err):
+			numBadRequest++
+			lastBadRequestErr = err.Error()
+		case xerrors.IsInvalidParams(err):
+			numBadRequest++
+			lastBadRequestErr = err.Error()
+		default:
+			numRegular++
+			lastRegularErr = err.Error()
+		}
+	}
+
+	var status int
+	switch {
+	case numBadRequest == len(errs):
+		status = http.StatusBadRequest
+	case numResourceExhausted > 0:
+		status = http.StatusTooManyRequests
+	default:
+		status = http.StatusInternalServerError
+	}
+
+	logger := logging.WithContext(r.Context(), h.instrumentOpts)
+	logger.Error("write error",
+		zap.String("remoteAddr", r.RemoteAddr),
+		zap.Int("httpResponseStatusCode", status),
+		zap.Int("numResourceExhaustedErrors", numResourceExhausted),
+		zap.Int("numRegularErrors", numRegular),
+		zap.Int("numBadRequestErrors", numBadRequest),
+		zap.String("lastRegularError", lastRegularErr),
+		zap.String("lastBadRequestErr", lastBadRequestErr))
+
+	var resultErrMessage string
+	if lastRegularErr != "" {
+		resultErrMessage = fmt.Sprintf("retryable_errors: count=%d, last=%s",
+			numRegular, lastRegularErr)
+	}
+	if lastBadRequestErr != "" {
+		var sep string
+		if lastRegularErr != "" {
+			sep = ", "
+		}
+		resultErrMessage = fmt.Sprintf("%s%sbad_request_errors: count=%d, last=%s",
+			resultErrMessage, sep, numBadRequest, lastBadRequestErr)
+	}
+
+	return xhttp.NewError(errors.New(resultErrMessage), status)
+}
+
+func newSeriesIter(series []series, storeMetricsType bool) *seriesIter {
+	return &seriesIter{
+		idx:              -1,
+		series:           series,
+		storeMetricsType: storeMetricsType,
+	}
+}
+
+// seriesIter iterates over converted series for the downsampler and writer.
+type seriesIter struct {
+	idx        int
+	err        error
+	series     []series
+	metadatas  []ts.Metadata
+	annotation []byte
+
+	storeMetricsType bool
+}
+
+func (i *seriesIter) Next() bool {
+	if i.err != nil {
+		return false
+	}
+
+	i.idx++
+	if i.idx >= len(i.series) {
+		return false
+	}
+
+	if !i.storeMetricsType {
+		return true
+	}
+
+	annotationPayload, err := storage.SeriesAttributesToAnnotationPayload(i.series[i.idx].attributes)
+	if err != nil {
+		i.err = err
+		return false
+	}
+
+	i.annotation, err = annotationPayload.Marshal()
+	if err != nil {
+		i.err = err
+		return false
+	}
+
+	if len(i.annotation) == 0 {
+		i.annotation = nil
+	}
+
+	return true
+}
+
+func (i *seriesIter) Current() ingest.IterValue {
+	if len(i.series) == 0 || i.idx < 0 || i.idx >= len(i.series) {
+		return defaultValue
+	}
+
+	curr := i.series[i.idx]
+	value := ingest.IterValue{
+		Tags:       curr.query.Tags(),
+		Datapoints: curr.query.Datapoints(),
+		Attributes: curr.attributes,
+		Unit:       curr.query.Unit(),
+		Annotation: i.annotation,
+	}
+	if i.idx < len(i.metadatas) {
+		value.Metadata = i.metadatas[i.idx]
+	}
+	return value
+}
+
+func (i *seriesIter) Reset() error {
+	i.idx = -1
+	i.err = nil
+	i.annotation = nil
+	return nil
+}
+
+func (i *seriesIter) Error() error {
+	return i.err
+}
+
+func (i *seriesIter) SetCurrentMetadata(metadata ts.Metadata) {
+	if len(i.metadatas) == 0 {
+		i.metadatas = make([]ts.Metadata, len(i.series))
+	}
+	if i.idx < 0 || i.idx >= len(i.metadatas) {
+		return
+	}
+	i.metadatas[i.idx] = metadata
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	colmetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var testTime = time.Unix(1600000000, 123456789)
+
+func stringAttr(key, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key: key,
+		Value: &commonpb.AnyValue{
+			Value: &commonpb.AnyValue_StringValue{StringValue: value},
+		},
+	}
+}
+
+func newTestRequest(metrics ...*metricspb.Metric) *colmetricspb.ExportMetricsServiceRequest {
+	return &colmetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: []*metricspb.ResourceMetrics{
+			{
+				Resource: &resourcepb.Resource{
+					Attributes: []*commonpb.KeyValue{
+						stringAttr("service.name", "api"),
+						stringAttr("service.namespace", "shop"),
+						stringAttr("service.instance.id", "host-1"),
+					},
+				},
+				InstrumentationLibraryMetrics: []*metricspb.InstrumentationLibraryMetrics{
+					{Metrics: metrics},
+				},
+			},
+		},
+	}
+}
+
+func gaugeMetric(name string, value float64) *metricspb.Metric {
+	return &metricspb.Metric{
+		Name: name,
+		Data: &metricspb.Metric_Gauge{
+			Gauge: &metricspb.Gauge{
+				DataPoints: []*metricspb.NumberDataPoint{
+					{
+						Attributes:   []*commonpb.KeyValue{stringAttr("http.method", "GET")},
+						TimeUnixNano: uint64(testTime.UnixNano()),
+						Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: value},
+					},
+				},
+			},
+		},
+	}
+}
+
+func sumMetric(
+	name string,
+	value int64,
+	monotonic bool,
+	temporality metricspb.AggregationTemporality,
+) *metricspb.Metric {
+	return &metricspb.Metric{
+		Name: name,
+		Data: &metricspb.Metric_Sum{
+			Sum: &metricspb.Sum{
+				AggregationTemporality: temporality,
+				IsMonotonic:            monotonic,
+				DataPoints: []*metricspb.NumberDataPoint{
+					{
+						TimeUnixNano: uint64(testTime.UnixNano()),
+						Value:        &metricspb.NumberDataPoint_AsInt{AsInt: value},
+					},
+				},
+			},
+		},
+	}
+}
+
+func histogramMetric(name string) *metricspb.Metric {
+	return &metricspb.Metric{
+		Name: name,
+		Data: &metricspb.Metric_Histogram{
+			Histogram: &metricspb.Histogram{
+				AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+				DataPoints: []*metricspb.HistogramDataPoint{
+					{
+						TimeUnixNano:   uint64(testTime.UnixNano()),
+						Count:          10,
+						Sum:            42,
+						BucketCounts:   []uint64{2, 3, 5},
+						ExplicitBounds: []float64{0.1, 1},
+					},
+				},
+			},
+		},
+	}
+}
+
+// seriesString returns a human readable representation of a converted series.
+func seriesString(s series) string {
+	dps := s.query.Datapoints()
+	return fmt.Sprintf("%s %v", s.query.Tags().String(), dps[0].Value)
+}
+
+func TestConvertRequest(t *testing.T) {
+	req := newTestRequest(
+		gaugeMetric("process.memory.usage", 1.5),
+		sumMetric("http.requests", 7, true,
+			metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE),
+		sumMetric("queue.size", 3, false,
+			metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE),
+		sumMetric("delta.requests", 1, true,
+			metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_DELTA),
+		histogramMetric("latency"),
+	)
+
+	result, err := convertRequest(req, models.NewTagOptions())
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.dropped)
+
+	actual := make([]string, 0, len(result.series))
+	for _, s := range result.series {
+		actual = append(actual, seriesString(s))
+	}
+
+	expected := []string{
+		"__name__: process_memory_usage, http_method: GET, instance: host-1, job: shop/api 1.5",
+		"__name__: http_requests_total, instance: host-1, job: shop/api 7",
+		"__name__: queue_size, instance: host-1, job: shop/api 3",
+		"__name__: latency_sum, instance: host-1, job: shop/api 42",
+		"__name__: latency_count, instance: host-1, job: shop/api 10",
+		"__name__: latency_bucket, instance: host-1, job: shop/api, le: 0.1 2",
+		"__name__: latency_bucket, instance: host-1, job: shop/api, le: 1 5",
+		"__name__: latency_bucket, instance: host-1, job: shop/api, le: +Inf 10",
+	}
+	assert.Equal(t, expected, actual)
+
+	assert.Equal(t, ts.PromMetricTypeGauge, result.series[0].attributes.PromType)
+	assert.Equal(t, ts.PromMetricTypeCounter, result.series[1].attributes.PromType)
+	assert.Equal(t, ts.PromMetricTypeGauge, result.series[2].attributes.PromType)
+	assert.Equal(t, ts.PromMetricTypeHistogram, result.series[3].attributes.PromType)
+
+	// Timestamps are truncated to millisecond precision.
+	expectedTime := xtime.ToUnixNano(testTime.Truncate(time.Millisecond))
+	for _, s := range result.series {
+		assert.Equal(t, expectedTime, s.query.Datapoints()[0].Timestamp)
+		assert.Equal(t, xtime.Millisecond, s.query.Unit())
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "http_server_duration", sanitizeMetricName("http.server.duration"))
+	assert.Equal(t, "ns:metric", sanitizeMetricName("ns:metric"))
+	assert.Equal(t, "_xx", sanitizeMetricName("9xx"))
+	assert.Equal(t, "ns_label", sanitizeLabelName("ns:label"))
+	assert.Equal(t, "a1_b", sanitizeLabelName("a1-b"))
+}
+
+func makeOptions(ds ingest.DownsamplerAndWriter) options.HandlerOptions {
+	return options.EmptyHandlerOptions().
+		SetDownsamplerAndWriter(ds).
+		SetTagOptions(models.NewTagOptions())
+}
+
+func newRequestBody(t *testing.T, req *colmetricspb.ExportMetricsServiceRequest) *bytes.Reader {
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return bytes.NewReader(data)
+}
+
+func TestWrite(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), ingest.WriteOptions{}).
+		DoAndReturn(func(
+			_ context.Context,
+			iter ingest.DownsampleAndWriteIter,
+			_ ingest.WriteOptions,
+		) ingest.BatchError {
+			var n int
+			for iter.Next() {
+				n++
+			}
+			assert.Equal(t, 2, n)
+			return nil
+		})
+
+	handler, err := NewWriteHandler(makeOptions(mockDownsamplerAndWriter))
+	require.NoError(t, err)
+
+	body := newRequestBody(t, newTestRequest(
+		gaugeMetric("up", 1),
+		sumMetric("requests", 1, true,
+			metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE),
+	))
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL, body)
+	req.Header.Set("Content-Type", protobufContentType)
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	resp := writer.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, protobufContentType, resp.Header.Get("Content-Type"))
+}
+
+func TestWriteInvalidBody(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	handler, err := NewWriteHandler(makeOptions(mockDownsamplerAndWriter))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL,
+		bytes.NewReader([]byte("not a protobuf")))
+	req.Header.Set("Content-Type", "text/plain")
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	require.Equal(t, http.StatusBadRequest, writer.Result().StatusCode)
+}
+
+func TestWriteError(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	multiErr := xerrors.NewMultiError().Add(errors.New("an error"))
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(ingest.BatchError(multiErr))
+
+	handler, err := NewWriteHandler(makeOptions(mockDownsamplerAndWriter))
+	require.NoError(t, err)
+
+	body := newRequestBody(t, newTestRequest(gaugeMetric("up", 1)))
+	req := httptest.NewRequest(WriteHTTPMethod, WriteURL, body)
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, req)
+	require.Equal(t, http.StatusInternalServerError, writer.Result().StatusCode)
+}
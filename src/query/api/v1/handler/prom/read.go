@@ -121,59 +121,21 @@ func (h *readHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	params := request.Params
 	fetchOptions := request.FetchOpts
 
-	// NB (@shreyas): We put the FetchOptions in context so it can be
-	// retrieved in the queryable object as there is no other way to pass
-	// that through.
-	//
-	// We also put a function into the context that allows callers to safely
-	// pass back result metadata concurrently so that they can be combined
-	// for later reporting.
-	var resultMetadataMutex sync.Mutex
-	resultMetadata := block.NewResultMetadata()
-	resultMetadataReceiveFn := func(m block.ResultMetadata) {
-		resultMetadataMutex.Lock()
-		defer resultMetadataMutex.Unlock()
-		resultMetadata = resultMetadata.CombineMetadata(m)
+	var (
+		res            *promql.Result
+		resultMetadata block.ResultMetadata
+	)
+	if cache, req, ok := h.resultsCacheRequest(request); ok {
+		res, resultMetadata, err = h.execCached(ctx, cache, req, params, fetchOptions)
+	} else {
+		var qry promql.Query
+		qry, res, resultMetadata, err = h.exec(ctx, params, fetchOptions)
+		if qry != nil {
+			defer qry.Close()
+		}
 	}
-	ctx = context.WithValue(ctx, prometheus.FetchOptionsContextKey, fetchOptions)
-	ctx = context.WithValue(ctx, prometheus.BlockResultMetadataFnKey, resultMetadataReceiveFn)
-
-	qry, err := h.opts.newQueryFn(params)
 	if err != nil {
-		h.logger.Error("error creating query",
-			zap.Error(err), zap.String("query", params.Query),
-			zap.Bool("instant", h.opts.instant))
-		xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
-		return
-	}
-	defer qry.Close()
-
-	res := qry.Exec(ctx)
-	if res.Err != nil {
-		h.logger.Error("error executing query",
-			zap.Error(res.Err), zap.String("query", params.Query),
-			zap.Bool("instant", h.opts.instant))
-		var sErr *prometheus.StorageErr
-		if errors.As(res.Err, &sErr) {
-			// If the error happened in the m3 storage layer, propagate the causing error as is.
-			err := sErr.Unwrap()
-			if queryerrors.IsTimeout(err) {
-				xhttp.WriteError(w, queryerrors.NewErrQueryTimeout(err))
-			} else {
-				xhttp.WriteError(w, err)
-			}
-		} else {
-			promErr := errs.Cause(res.Err)
-			switch promErr.(type) { //nolint:errorlint
-			case promql.ErrQueryTimeout:
-				promErr = queryerrors.NewErrQueryTimeout(promErr)
-			case promql.ErrQueryCanceled:
-			default:
-				// Assume any prometheus library error is a 4xx, since there are no remote calls.
-				promErr = xerrors.NewInvalidParamsError(res.Err)
-			}
-			xhttp.WriteError(w, promErr)
-		}
+		xhttp.WriteError(w, err)
 		return
 	}
 
@@ -226,6 +188,69 @@ func (h *readHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// exec executes the query, returning its result along with the metadata of
+// the fetches made. The query must be closed once the result is consumed.
+func (h *readHandler) exec(
+	ctx context.Context,
+	params models.RequestParams,
+	fetchOptions *storage.FetchOptions,
+) (promql.Query, *promql.Result, block.ResultMetadata, error) {
+	// NB (@shreyas): We put the FetchOptions in context so it can be
+	// retrieved in the queryable object as there is no other way to pass
+	// that through.
+	//
+	// We also put a function into the context that allows callers to safely
+	// pass back result metadata concurrently so that they can be combined
+	// for later reporting.
+	var resultMetadataMutex sync.Mutex
+	resultMetadata := block.NewResultMetadata()
+	resultMetadataReceiveFn := func(m block.ResultMetadata) {
+		resultMetadataMutex.Lock()
+		defer resultMetadataMutex.Unlock()
+		resultMetadata = resultMetadata.CombineMetadata(m)
+	}
+	ctx = context.WithValue(ctx, prometheus.FetchOptionsContextKey, fetchOptions)
+	ctx = context.WithValue(ctx, prometheus.BlockResultMetadataFnKey, resultMetadataReceiveFn)
+
+	qry, err := h.opts.newQueryFn(params)
+	if err != nil {
+		h.logger.Error("error creating query",
+			zap.Error(err), zap.String("query", params.Query),
+			zap.Bool("instant", h.opts.instant))
+		return nil, nil, resultMetadata, xerrors.NewInvalidParamsError(err)
+	}
+
+	res := qry.Exec(ctx)
+	if res.Err != nil {
+		qry.Close()
+		h.logger.Error("error executing query",
+			zap.Error(res.Err), zap.String("query", params.Query),
+			zap.Bool("instant", h.opts.instant))
+		var sErr *prometheus.StorageErr
+		if errors.As(res.Err, &sErr) {
+			// If the error happened in the m3 storage layer, propagate the causing error as is.
+			err := sErr.Unwrap()
+			if queryerrors.IsTimeout(err) {
+				return nil, nil, resultMetadata, queryerrors.NewErrQueryTimeout(err)
+			}
+			return nil, nil, resultMetadata, err
+		}
+
+		promErr := errs.Cause(res.Err)
+		switch promErr.(type) { //nolint:errorlint
+		case promql.ErrQueryTimeout:
+			promErr = queryerrors.NewErrQueryTimeout(promErr)
+		case promql.ErrQueryCanceled:
+		default:
+			// Assume any prometheus library error is a 4xx, since there are no remote calls.
+			promErr = xerrors.NewInvalidParamsError(res.Err)
+		}
+		return nil, nil, resultMetadata, promErr
+	}
+
+	return qry, res, resultMetadata, nil
+}
+
 func (h *readHandler) limitReturnedData(query string,
 	res *promql.Result,
 	fetchOpts *storage.FetchOptions,
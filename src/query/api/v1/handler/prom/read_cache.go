@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package prom
+
+import (
+	"context"
+	"sort"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	resultsCacheEngine = "prometheus"
+
+	// resultsCacheWarningName is the name of warnings raised by the
+	// Prometheus engine when executing the extents of cached queries.
+	resultsCacheWarningName = "prometheus"
+)
+
+func (h *readHandler) resultsCacheRequest(
+	parsed native.ParsedOptions,
+) (resultscache.Cache, resultscache.Request, bool) {
+	cache := h.hOpts.ResultsCache()
+	if cache == nil || h.opts.instant {
+		return nil, resultscache.Request{}, false
+	}
+
+	// NB: the Prometheus engine always includes the end of range queries.
+	parsed.Params.IncludeEnd = true
+	req, ok := native.ResultsCacheRequest(resultsCacheEngine, parsed)
+	return cache, req, ok
+}
+
+// execCached executes a range query through the results cache.
+func (h *readHandler) execCached(
+	ctx context.Context,
+	cache resultscache.Cache,
+	req resultscache.Request,
+	params models.RequestParams,
+	fetchOptions *storage.FetchOptions,
+) (*promql.Result, block.ResultMetadata, error) {
+	res, err := cache.Query(ctx, req, func(
+		ctx context.Context,
+		start, end xtime.UnixNano,
+	) (resultscache.Result, error) {
+		extent := params
+		extent.Start = start
+		extent.End = end
+
+		qry, res, meta, err := h.exec(ctx, extent, fetchOptions)
+		if err != nil {
+			return resultscache.Result{}, err
+		}
+		defer qry.Close()
+
+		matrix, err := res.Matrix()
+		if err != nil {
+			return resultscache.Result{}, err
+		}
+
+		// Keep the engine warnings as part of the metadata so that extents
+		// with warnings are not cached.
+		for _, warn := range res.Warnings {
+			meta.AddWarning(resultsCacheWarningName, warn.Error())
+		}
+
+		return resultscache.Result{
+			Series: matrixToPromTimeSeries(matrix),
+			Meta:   meta,
+		}, nil
+	})
+	if err != nil {
+		return nil, block.ResultMetadata{}, err
+	}
+
+	return &promql.Result{Value: promTimeSeriesToMatrix(res.Series)}, res.Meta, nil
+}
+
+func matrixToPromTimeSeries(matrix promql.Matrix) []prompb.TimeSeries {
+	result := make([]prompb.TimeSeries, 0, len(matrix))
+	for _, s := range matrix {
+		promLabels := make([]prompb.Label, 0, len(s.Metric))
+		for _, l := range s.Metric {
+			promLabels = append(promLabels, prompb.Label{
+				Name:  []byte(l.Name),
+				Value: []byte(l.Value),
+			})
+		}
+
+		samples := make([]prompb.Sample, 0, len(s.Points))
+		for _, p := range s.Points {
+			samples = append(samples, prompb.Sample{Timestamp: p.T, Value: p.V})
+		}
+
+		result = append(result, prompb.TimeSeries{Labels: promLabels, Samples: samples})
+	}
+	return result
+}
+
+func promTimeSeriesToMatrix(series []prompb.TimeSeries) promql.Matrix {
+	matrix := make(promql.Matrix, 0, len(series))
+	for _, s := range series {
+		// Range queries never return series without any points.
+		if len(s.Samples) == 0 {
+			continue
+		}
+
+		metric := make(labels.Labels, 0, len(s.Labels))
+		for _, l := range s.Labels {
+			metric = append(metric, labels.Label{
+				Name:  string(l.Name),
+				Value: string(l.Value),
+			})
+		}
+
+		points := make([]promql.Point, 0, len(s.Samples))
+		for _, sample := range s.Samples {
+			points = append(points, promql.Point{T: sample.Timestamp, V: sample.Value})
+		}
+
+		matrix = append(matrix, promql.Series{Metric: metric, Points: points})
+	}
+	sort.Sort(matrix)
+	return matrix
+}
@@ -33,13 +33,16 @@ import (
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql"
 	promstorage "github.com/prometheus/prometheus/storage"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
 	"github.com/m3db/m3/src/query/storage/prometheus"
 	xerrors "github.com/m3db/m3/src/x/errors"
@@ -132,6 +135,70 @@ func TestPromReadHandler(t *testing.T) {
 	require.Equal(t, statusSuccess, resp.Status)
 }
 
+func TestPromReadHandlerResultsCache(t *testing.T) {
+	cache, err := resultscache.NewCache(
+		resultscache.NewMemoryBackend(resultscache.MemoryBackendOptions{}),
+		resultscache.Options{})
+	require.NoError(t, err)
+
+	fetchOptsBuilder, err := handleroptions.NewFetchOptionsBuilder(
+		handleroptions.FetchOptionsBuilderOptions{Timeout: 15 * time.Second})
+	require.NoError(t, err)
+	engine := executor.NewEngine(executor.NewEngineOptions().
+		SetLookbackDuration(time.Minute).
+		SetInstrumentOptions(instrument.NewOptions()))
+	hOpts := options.EmptyHandlerOptions().
+		SetFetchOptionsBuilder(fetchOptsBuilder).
+		SetEngine(engine).
+		SetResultsCache(cache)
+
+	var (
+		queryable = &mockQueryable{}
+		rangeFn   = newRangeQueryFn(testPromQLEngineFn, queryable)
+		queried   []models.RequestParams
+	)
+	handler, err := newReadHandler(hOpts, opts{
+		queryable: queryable,
+		newQueryFn: func(params models.RequestParams) (promql.Query, error) {
+			queried = append(queried, params)
+			return rangeFn(params)
+		},
+	})
+	require.NoError(t, err)
+
+	// Query two whole days in the past so that both can be cached.
+	end := time.Now().Truncate(24 * time.Hour).Add(-time.Hour)
+	vals := url.Values{}
+	vals.Add(queryParam, "vector(1)")
+	vals.Add(startParam, fmt.Sprint(end.Add(-47*time.Hour).Unix()))
+	vals.Add(endParam, fmt.Sprint(end.Unix()))
+	vals.Add(handleroptions.StepParam, time.Hour.String())
+
+	var bodies []string
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, native.PromReadURL+"?"+vals.Encode(), nil)
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, req)
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+		bodies = append(bodies, recorder.Body.String())
+	}
+
+	assert.Equal(t, bodies[0], bodies[1])
+	// The second request is served entirely from the cache.
+	require.Len(t, queried, 1)
+
+	var resp struct {
+		Data struct {
+			Result []struct {
+				Values [][]interface{} `json:"values"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(bodies[1]), &resp))
+	require.Len(t, resp.Data.Result, 1)
+	assert.Len(t, resp.Data.Result[0].Values, 48)
+}
+
 func TestPromReadHandlerInvalidQuery(t *testing.T) {
 	setup := setupTest(t)
 
@@ -0,0 +1,157 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// DeleteSeriesURL is the url for the series deletion endpoint.
+	DeleteSeriesURL = route.DeleteSeriesURL
+)
+
+var (
+	// DeleteSeriesHTTPMethods are the HTTP methods for this handler.
+	DeleteSeriesHTTPMethods = []string{http.MethodPost, http.MethodPut}
+
+	errNoClusters     = errors.New("no M3DB clusters configured")
+	errNoMatchersSet  = errors.New("no match[] parameter provided")
+	errNoAdminSession = errors.New("cluster session does not support series deletion")
+)
+
+// DeleteSeriesHandler is a handler for the Prometheus compatible series
+// deletion endpoint, deleting the data of the series matching any of the
+// match[] selectors within the requested time range from every namespace.
+type DeleteSeriesHandler struct {
+	clusters       m3.Clusters
+	parseOpts      promql.ParseOptions
+	tagOpts        models.TagOptions
+	instrumentOpts instrument.Options
+}
+
+// NewDeleteSeriesHandler returns a new series deletion handler.
+func NewDeleteSeriesHandler(opts options.HandlerOptions) http.Handler {
+	return &DeleteSeriesHandler{
+		clusters:       opts.Clusters(),
+		parseOpts:      promql.NewParseOptions().SetNowFn(opts.NowFn()),
+		tagOpts:        opts.TagOptions(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *DeleteSeriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.clusters == nil {
+		xhttp.WriteError(w, errNoClusters)
+		return
+	}
+
+	start, end, err := prometheus.ParseStartAndEnd(r, h.parseOpts)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	matches, ok, err := prometheus.ParseMatch(r, h.parseOpts, h.tagOpts)
+	if err != nil {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+		return
+	}
+	if !ok {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(errNoMatchersSet))
+		return
+	}
+
+	var (
+		ctx    = r.Context()
+		logger = logging.WithContext(ctx, h.instrumentOpts)
+		// NB: The Prometheus end time is inclusive while the deleted
+		// ranges of the database exclude their end.
+		rangeStart = xtime.ToUnixNano(start)
+		rangeEnd   = xtime.ToUnixNano(end).Add(time.Nanosecond)
+	)
+	for _, match := range matches {
+		query, err := storage.FetchQueryToM3Query(&storage.FetchQuery{
+			TagMatchers: match.Matchers,
+			Start:       start,
+			End:         end,
+		}, nil)
+		if err != nil {
+			xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+			return
+		}
+
+		for _, ns := range h.clusters.ClusterNamespaces() {
+			numSeries, err := deleteSeries(ns, query, rangeStart, rangeEnd)
+			if err != nil {
+				logger.Error("unable to delete series",
+					zap.String("match", match.Match),
+					zap.Stringer("namespace", ns.NamespaceID()),
+					zap.Error(err))
+				xhttp.WriteError(w, err)
+				return
+			}
+
+			logger.Info("deleted series",
+				zap.String("match", match.Match),
+				zap.Stringer("namespace", ns.NamespaceID()),
+				zap.Int64("numSeries", numSeries))
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func deleteSeries(
+	ns m3.ClusterNamespace,
+	query index.Query,
+	start, end xtime.UnixNano,
+) (int64, error) {
+	if ns.Options().ReadOnly() {
+		return 0, nil
+	}
+
+	session, ok := ns.Session().(client.AdminSession)
+	if !ok {
+		return 0, fmt.Errorf("namespace %s: %w", ns.NamespaceID(), errNoAdminSession)
+	}
+	return session.DeleteSeries(ns.NamespaceID(), query, start, end)
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestDeleteSeriesHandler(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	session := client.NewMockAdminSession(ctrl)
+	ns := m3.NewMockClusterNamespace(ctrl)
+	ns.EXPECT().NamespaceID().Return(ident.StringID("metrics")).AnyTimes()
+	ns.EXPECT().Options().Return(m3.ClusterNamespaceOptions{}).AnyTimes()
+	ns.EXPECT().Session().Return(session).AnyTimes()
+	clusters := m3.NewMockClusters(ctrl)
+	clusters.EXPECT().ClusterNamespaces().Return(m3.ClusterNamespaces{ns}).AnyTimes()
+
+	h := NewDeleteSeriesHandler(options.EmptyHandlerOptions().
+		SetClusters(clusters).
+		SetTagOptions(models.NewTagOptions()))
+
+	var (
+		start = time.Unix(1600000000, 0)
+		end   = start.Add(time.Hour)
+	)
+	session.EXPECT().
+		DeleteSeries(ident.NewIDMatcher("metrics"), gomock.Any(),
+			xtime.ToUnixNano(start), xtime.ToUnixNano(end).Add(time.Nanosecond)).
+		DoAndReturn(func(
+			_ ident.ID,
+			query index.Query,
+			_, _ xtime.UnixNano,
+		) (int64, error) {
+			assert.Equal(t, `conjunction(term(__name__,up), term(job,node))`, query.String())
+			return 3, nil
+		})
+
+	form := url.Values{
+		"match[]": []string{`up{job="node"}`},
+		"start":   []string{"1600000000"},
+		"end":     []string{"1600003600"},
+	}
+	req := httptest.NewRequest(http.MethodPost, DeleteSeriesURL,
+		strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code, w.Body.String())
+
+	// A matcher is required to not delete every series by accident.
+	req = httptest.NewRequest(http.MethodPost, DeleteSeriesURL, nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/storage/metricmetadata"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// MetricMetadataURL is the url for the metric metadata endpoint.
+	MetricMetadataURL = route.MetadataURL
+
+	metricParam = "metric"
+	limitParam  = "limit"
+)
+
+var (
+	// MetricMetadataHTTPMethods are the HTTP methods for this handler.
+	MetricMetadataHTTPMethods = []string{http.MethodGet}
+
+	errInvalidMetadataLimit = errors.New("limit must be a number")
+
+	// NB: these match the metric type names Prometheus responds with.
+	metricTypeNames = map[prompb.MetricType]string{
+		prompb.MetricType_UNKNOWN:         "unknown",
+		prompb.MetricType_COUNTER:         "counter",
+		prompb.MetricType_GAUGE:           "gauge",
+		prompb.MetricType_HISTOGRAM:       "histogram",
+		prompb.MetricType_GAUGE_HISTOGRAM: "gaugehistogram",
+		prompb.MetricType_SUMMARY:         "summary",
+		prompb.MetricType_INFO:            "info",
+		prompb.MetricType_STATESET:        "stateset",
+	}
+)
+
+// MetricMetadataHandler is a handler for the Prometheus compatible metric
+// metadata endpoint, serving the metadata received with remote writes.
+type MetricMetadataHandler struct {
+	store          metricmetadata.Store
+	instrumentOpts instrument.Options
+}
+
+// NewMetricMetadataHandler returns a new metric metadata handler.
+func NewMetricMetadataHandler(opts options.HandlerOptions) http.Handler {
+	return &MetricMetadataHandler{
+		store:          opts.MetricMetadataStore(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *MetricMetadataHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	limit := -1
+	if s := r.FormValue(limitParam); s != "" {
+		var err error
+		if limit, err = strconv.Atoi(s); err != nil {
+			xhttp.WriteError(w, xerrors.NewInvalidParamsError(errInvalidMetadataLimit))
+			return
+		}
+	}
+
+	results := map[string][]prompb.MetricMetadata{}
+	if h.store != nil {
+		var err error
+		results, err = h.store.Query(r.FormValue(metricParam), limit)
+		if err != nil {
+			xhttp.WriteError(w, err)
+			return
+		}
+	}
+
+	if err := renderMetricMetadataResultsJSON(w, results); err != nil {
+		logger := logging.WithContext(r.Context(), h.instrumentOpts)
+		logger.Error("unable to render metric metadata", zap.Error(err))
+	}
+}
+
+func renderMetricMetadataResultsJSON(
+	w io.Writer,
+	results map[string][]prompb.MetricMetadata,
+) error {
+	names := make([]string, 0, len(results))
+	for name := range results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+	for _, name := range names {
+		jw.BeginObjectField(name)
+		jw.BeginArray()
+		for _, m := range results[name] {
+			jw.BeginObject()
+			jw.BeginObjectField("type")
+			typ, ok := metricTypeNames[m.Type]
+			if !ok {
+				typ = metricTypeNames[prompb.MetricType_UNKNOWN]
+			}
+			jw.WriteString(typ)
+			jw.BeginObjectField("help")
+			jw.WriteString(m.Help)
+			jw.BeginObjectField("unit")
+			jw.WriteString(m.Unit)
+			jw.EndObject()
+		}
+		jw.EndArray()
+	}
+	jw.EndObject()
+
+	jw.EndObject()
+	return jw.Close()
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/storage/metricmetadata"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+func queryMetricMetadata(t *testing.T, handler http.Handler, params url.Values) (int, string) {
+	req := httptest.NewRequest(http.MethodGet,
+		MetricMetadataURL+"?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	body, err := ioutil.ReadAll(w.Result().Body)
+	require.NoError(t, err)
+	return w.Result().StatusCode, string(body)
+}
+
+func TestMetricMetadata(t *testing.T) {
+	store, err := metricmetadata.NewStore(mem.NewStore(), metricmetadata.Options{})
+	require.NoError(t, err)
+	require.NoError(t, store.Update([]prompb.MetricMetadata{
+		{
+			Type:             prompb.MetricType_COUNTER,
+			MetricFamilyName: "http_requests_total",
+			Help:             "Total HTTP requests.",
+		},
+		{
+			Type:             prompb.MetricType_GAUGE_HISTOGRAM,
+			MetricFamilyName: "queue_size",
+			Help:             "Queue size.",
+			Unit:             "items",
+		},
+	}))
+
+	handler := NewMetricMetadataHandler(
+		options.EmptyHandlerOptions().SetMetricMetadataStore(store))
+
+	tests := []struct {
+		name     string
+		params   url.Values
+		expected string
+	}{
+		{
+			name: "all",
+			expected: `{"status":"success","data":{` +
+				`"http_requests_total":[{"type":"counter","help":"Total HTTP requests.","unit":""}],` +
+				`"queue_size":[{"type":"gaugehistogram","help":"Queue size.","unit":"items"}]` +
+				`}}`,
+		},
+		{
+			name:   "metric",
+			params: url.Values{"metric": []string{"queue_size"}},
+			expected: `{"status":"success","data":{` +
+				`"queue_size":[{"type":"gaugehistogram","help":"Queue size.","unit":"items"}]` +
+				`}}`,
+		},
+		{
+			name:   "limit",
+			params: url.Values{"limit": []string{"1"}},
+			expected: `{"status":"success","data":{` +
+				`"http_requests_total":[{"type":"counter","help":"Total HTTP requests.","unit":""}]` +
+				`}}`,
+		},
+		{
+			name:     "missing metric",
+			params:   url.Values{"metric": []string{"missing"}},
+			expected: `{"status":"success","data":{}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := queryMetricMetadata(t, handler, tt.params)
+			require.Equal(t, http.StatusOK, code)
+			assert.Equal(t, xtest.MustPrettyJSONString(t, tt.expected),
+				xtest.MustPrettyJSONString(t, body))
+		})
+	}
+}
+
+func TestMetricMetadataInvalidLimit(t *testing.T) {
+	handler := NewMetricMetadataHandler(options.EmptyHandlerOptions())
+
+	code, _ := queryMetricMetadata(t, handler, url.Values{"limit": []string{"abc"}})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
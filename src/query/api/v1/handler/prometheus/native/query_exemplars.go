@@ -0,0 +1,162 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	promexemplar "github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	pql "github.com/prometheus/prometheus/promql/parser"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage/exemplar"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// QueryExemplarsURL is the url for the query exemplars endpoint.
+	QueryExemplarsURL = route.QueryExemplarsURL
+
+	queryParam = "query"
+)
+
+var (
+	// QueryExemplarsHTTPMethods are the HTTP methods for this handler.
+	QueryExemplarsHTTPMethods = []string{http.MethodGet, http.MethodPost}
+
+	errMissingQuery = errors.New("query param is required")
+)
+
+// QueryExemplarsHandler is a handler for the Prometheus compatible query
+// exemplars endpoint, serving the exemplars received with remote writes.
+type QueryExemplarsHandler struct {
+	store          exemplar.Store
+	parseOpts      promql.ParseOptions
+	instrumentOpts instrument.Options
+}
+
+// NewQueryExemplarsHandler returns a new query exemplars handler.
+func NewQueryExemplarsHandler(opts options.HandlerOptions) http.Handler {
+	return &QueryExemplarsHandler{
+		store:          opts.ExemplarStore(),
+		parseOpts:      promql.NewParseOptions().SetNowFn(opts.NowFn()),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *QueryExemplarsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	start, end, err := prometheus.ParseStartAndEnd(r, h.parseOpts)
+	if err != nil {
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	query := r.FormValue(queryParam)
+	if query == "" {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(errMissingQuery))
+		return
+	}
+
+	expr, err := h.parseOpts.ParseFn()(query)
+	if err != nil {
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(err))
+		return
+	}
+
+	// NB: exemplars are optional, when they are disabled respond with no
+	// results rather than an error so that dashboards requesting them
+	// alongside queries keep working.
+	results := []promexemplar.QueryResult{}
+	if h.store != nil {
+		results, err = h.store.Select(r.Context(), start, end,
+			pql.ExtractSelectors(expr)...)
+		if err != nil {
+			xhttp.WriteError(w, err)
+			return
+		}
+	}
+
+	if err := renderQueryExemplarsResultsJSON(w, results); err != nil {
+		logger := logging.WithContext(r.Context(), h.instrumentOpts)
+		logger.Error("unable to render exemplars", zap.Error(err))
+	}
+}
+
+func renderQueryExemplarsResultsJSON(
+	w io.Writer,
+	results []promexemplar.QueryResult,
+) error {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginArray()
+	for _, result := range results {
+		jw.BeginObject()
+		jw.BeginObjectField("seriesLabels")
+		writeLabels(jw, result.SeriesLabels)
+
+		jw.BeginObjectField("exemplars")
+		jw.BeginArray()
+		for _, e := range result.Exemplars {
+			jw.BeginObject()
+			jw.BeginObjectField("labels")
+			writeLabels(jw, e.Labels)
+			jw.BeginObjectField("value")
+			jw.WriteString(utils.FormatFloat(e.Value))
+			jw.BeginObjectField("timestamp")
+			jw.WriteFloat64(float64(e.Ts) / 1000)
+			jw.EndObject()
+		}
+		jw.EndArray()
+		jw.EndObject()
+	}
+	jw.EndArray()
+
+	jw.EndObject()
+	return jw.Close()
+}
+
+func writeLabels(jw json.Writer, lbls labels.Labels) {
+	jw.BeginObject()
+	for _, l := range lbls {
+		jw.BeginObjectField(l.Name)
+		jw.WriteString(l.Value)
+	}
+	jw.EndObject()
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	promexemplar "github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/storage/exemplar"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+func queryExemplars(t *testing.T, handler http.Handler, params url.Values) (int, string) {
+	req := httptest.NewRequest(http.MethodGet,
+		QueryExemplarsURL+"?"+params.Encode(), nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	body, err := ioutil.ReadAll(w.Result().Body)
+	require.NoError(t, err)
+	return w.Result().StatusCode, string(body)
+}
+
+func TestQueryExemplars(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	series := labels.FromStrings("__name__", "latency_bucket", "le", "0.5")
+	store := exemplar.NewMockStore(ctrl)
+	store.EXPECT().
+		Select(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			start, end time.Time,
+			matchers ...[]*labels.Matcher,
+		) ([]promexemplar.QueryResult, error) {
+			assert.True(t, start.Equal(time.Unix(1, 0)))
+			assert.True(t, end.Equal(time.Unix(2, 0)))
+			assert.Equal(t, [][]*labels.Matcher{{
+				labels.MustNewMatcher(labels.MatchEqual, "__name__", "latency_bucket"),
+			}}, matchers)
+			return []promexemplar.QueryResult{{
+				SeriesLabels: series,
+				Exemplars: []promexemplar.Exemplar{{
+					Labels: labels.FromStrings("trace_id", "abc"),
+					Value:  0.25,
+					Ts:     1500,
+					HasTs:  true,
+				}},
+			}}, nil
+		})
+
+	opts := options.EmptyHandlerOptions().
+		SetNowFn(func() time.Time { return time.Unix(10, 0) }).
+		SetExemplarStore(store)
+	handler := NewQueryExemplarsHandler(opts)
+
+	code, body := queryExemplars(t, handler, url.Values{
+		"query": []string{`histogram_quantile(0.9, sum(rate(latency_bucket[5m])) by (le))`},
+		"start": []string{"1"},
+		"end":   []string{"2"},
+	})
+	require.Equal(t, http.StatusOK, code)
+	expected := `{"status":"success","data":[{` +
+		`"seriesLabels":{"__name__":"latency_bucket","le":"0.5"},` +
+		`"exemplars":[{"labels":{"trace_id":"abc"},"value":"0.25","timestamp":1.500000}]` +
+		`}]}`
+	assert.Equal(t, xtest.MustPrettyJSONString(t, expected),
+		xtest.MustPrettyJSONString(t, body))
+}
+
+func TestQueryExemplarsDisabled(t *testing.T) {
+	handler := NewQueryExemplarsHandler(options.EmptyHandlerOptions())
+
+	code, body := queryExemplars(t, handler, url.Values{"query": []string{"up"}})
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `{"status":"success","data":[]}`, body)
+}
+
+func TestQueryExemplarsInvalidQuery(t *testing.T) {
+	handler := NewQueryExemplarsHandler(options.EmptyHandlerOptions())
+
+	code, _ := queryExemplars(t, handler, url.Values{})
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = queryExemplars(t, handler, url.Values{"query": []string{"sum("}})
+	assert.Equal(t, http.StatusBadRequest, code)
+}
@@ -21,6 +21,7 @@
 package native
 
 import (
+	"context"
 	"net/http"
 
 	opentracingext "github.com/opentracing/opentracing-go/ext"
@@ -58,6 +59,8 @@ const (
 
 	// M3QueryReadInstantURL is the URL for native instantaneous m3 query read handler.
 	M3QueryReadInstantURL = "/m3query" + PromReadInstantURL
+
+	resultsCacheEngine = "native"
 )
 
 var (
@@ -132,7 +135,7 @@ func (h *promReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		zap.Duration("fetchTimeout", parsedOptions.FetchOpts.Timeout),
 	)
 
-	result, err := read(ctx, parsedOptions, h.opts)
+	result, err := h.read(ctx, parsedOptions)
 	if err != nil {
 		sp := xopentracing.SpanFromContextOrNoop(ctx)
 		sp.LogFields(opentracinglog.Error(err))
@@ -217,3 +220,15 @@ func (h *promReadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}
 }
+
+func (h *promReadHandler) read(
+	ctx context.Context,
+	parsedOptions ParsedOptions,
+) (ReadResult, error) {
+	if cache := h.opts.ResultsCache(); cache != nil && !h.instant {
+		if req, ok := ResultsCacheRequest(resultsCacheEngine, parsedOptions); ok {
+			return readCached(ctx, cache, req, parsedOptions, h.opts)
+		}
+	}
+	return read(ctx, parsedOptions, h.opts)
+}
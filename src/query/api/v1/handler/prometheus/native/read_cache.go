@@ -0,0 +1,179 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// ResultsCacheRequest returns the results cache request for the parsed range
+// query, identified by the engine executing it, and false if the results of
+// the query cannot be cached.
+func ResultsCacheRequest(engine string, parsed ParsedOptions) (resultscache.Request, bool) {
+	params := parsed.Params
+	if params.Step <= 0 || !resultscache.Cacheable(params.Query) {
+		return resultscache.Request{}, false
+	}
+
+	end := params.ExclusiveEnd().Add(-1)
+	if end < params.Start {
+		return resultscache.Request{}, false
+	}
+
+	var restrict string
+	if parsed.FetchOpts != nil {
+		restrict = restrictQueryOptionsKey(parsed.FetchOpts.RestrictQueryOptions)
+	}
+
+	return resultscache.Request{
+		Key: fmt.Sprintf("%s:%q:%d:%d:%s", engine, params.Query,
+			params.LookbackDuration, params.BlockType, restrict),
+		Start: params.Start,
+		End:   end,
+		Step:  params.Step,
+	}, true
+}
+
+func restrictQueryOptionsKey(opts *storage.RestrictQueryOptions) string {
+	if opts == nil {
+		return ""
+	}
+
+	var b strings.Builder
+	restrictByTypes := opts.RestrictByTypes
+	if opts.RestrictByType != nil {
+		restrictByTypes = append([]*storage.RestrictByType{opts.RestrictByType},
+			restrictByTypes...)
+	}
+	for _, r := range restrictByTypes {
+		fmt.Fprintf(&b, "type=%s/%s;", r.MetricsType, r.StoragePolicy)
+	}
+	if r := opts.RestrictByTag; r != nil {
+		fmt.Fprintf(&b, "tag=%s;", r.Restrict)
+		for _, strip := range r.Strip {
+			fmt.Fprintf(&b, "strip=%s;", strip)
+		}
+	}
+	return b.String()
+}
+
+// readCached executes a range query through the results cache.
+func readCached(
+	ctx context.Context,
+	cache resultscache.Cache,
+	req resultscache.Request,
+	parsed ParsedOptions,
+	handlerOpts options.HandlerOptions,
+) (ReadResult, error) {
+	var blockType block.BlockType
+	res, err := cache.Query(ctx, req, func(
+		ctx context.Context,
+		start, end xtime.UnixNano,
+	) (resultscache.Result, error) {
+		extent := parsed
+		extent.Params.Start = start
+		extent.Params.End = end
+		extent.Params.IncludeEnd = true
+
+		result, err := read(ctx, extent, handlerOpts)
+		if err != nil {
+			return resultscache.Result{}, err
+		}
+
+		blockType = result.BlockType
+		return resultscache.Result{
+			Series: seriesToPromTimeSeries(result.Series),
+			Meta:   result.Meta,
+		}, nil
+	})
+	if err != nil {
+		return ReadResult{}, err
+	}
+
+	var (
+		tagOpts  = handlerOpts.TagOptions()
+		step     = req.Step
+		numSteps = int(req.End.Sub(req.Start)/step) + 1
+		series   = make([]*ts.Series, 0, len(res.Series))
+	)
+	for _, s := range res.Series {
+		values := ts.NewFixedStepValues(step, numSteps, math.NaN(), req.Start)
+		for _, sample := range s.Samples {
+			t := storage.PromTimestampToTime(sample.Timestamp)
+			values.SetValueAt(int(xtime.ToUnixNano(t).Sub(req.Start)/step), sample.Value)
+		}
+
+		tagList := make([]models.Tag, 0, len(s.Labels))
+		for _, l := range s.Labels {
+			tagList = append(tagList, models.Tag{Name: l.Name, Value: l.Value})
+		}
+		tags := models.NewTags(len(tagList), tagOpts).AddTags(tagList)
+		series = append(series, ts.NewSeries(tags.ID(), values, tags))
+	}
+
+	return ReadResult{
+		Series:    series,
+		Meta:      res.Meta,
+		BlockType: blockType,
+	}, nil
+}
+
+// seriesToPromTimeSeries converts the series to Prometheus time series
+// without their NaN datapoints, keeping the tag names as is.
+func seriesToPromTimeSeries(series []*ts.Series) []prompb.TimeSeries {
+	result := make([]prompb.TimeSeries, 0, len(series))
+	for _, s := range series {
+		labels := make([]prompb.Label, 0, s.Tags.Len())
+		for _, t := range s.Tags.Tags {
+			labels = append(labels, prompb.Label{Name: t.Name, Value: t.Value})
+		}
+
+		var (
+			values  = s.Values()
+			samples = make([]prompb.Sample, 0, values.Len())
+		)
+		for i := 0; i < values.Len(); i++ {
+			dp := values.DatapointAt(i)
+			if math.IsNaN(dp.Value) {
+				continue
+			}
+			samples = append(samples, prompb.Sample{
+				Timestamp: storage.TimeToPromTimestamp(dp.Timestamp),
+				Value:     dp.Value,
+			})
+		}
+
+		result = append(result, prompb.TimeSeries{Labels: labels, Samples: samples})
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestResultsCacheRequest(t *testing.T) {
+	parsed := ParsedOptions{
+		FetchOpts: storage.NewFetchOptions(),
+		Params: models.RequestParams{
+			Query:            "up",
+			Start:            xtime.UnixNano(time.Hour),
+			End:              xtime.UnixNano(2 * time.Hour),
+			Step:             time.Minute,
+			IncludeEnd:       true,
+			LookbackDuration: 5 * time.Minute,
+		},
+	}
+
+	req, ok := ResultsCacheRequest("native", parsed)
+	require.True(t, ok)
+	assert.Equal(t, parsed.Params.Start, req.Start)
+	// The end is included by extending the range up to the next step.
+	assert.Equal(t, parsed.Params.End.Add(time.Minute-1), req.End)
+	assert.Equal(t, time.Minute, req.Step)
+
+	// Options affecting results are part of the key.
+	lookback := parsed
+	lookback.Params.LookbackDuration = time.Minute
+	lookbackReq, ok := ResultsCacheRequest("native", lookback)
+	require.True(t, ok)
+	assert.NotEqual(t, req.Key, lookbackReq.Key)
+
+	restricted := parsed
+	restricted.FetchOpts = storage.NewFetchOptions()
+	restricted.FetchOpts.RestrictQueryOptions = &storage.RestrictQueryOptions{
+		RestrictByType: &storage.RestrictByType{
+			MetricsType: storagemetadata.UnaggregatedMetricsType,
+		},
+	}
+	restrictedReq, ok := ResultsCacheRequest("native", restricted)
+	require.True(t, ok)
+	assert.NotEqual(t, req.Key, restrictedReq.Key)
+
+	otherEngineReq, ok := ResultsCacheRequest("prometheus", parsed)
+	require.True(t, ok)
+	assert.NotEqual(t, req.Key, otherEngineReq.Key)
+
+	// The end is excluded when requested.
+	exclusive := parsed
+	exclusive.Params.IncludeEnd = false
+	exclusiveReq, ok := ResultsCacheRequest("native", exclusive)
+	require.True(t, ok)
+	assert.Equal(t, parsed.Params.End.Add(-1), exclusiveReq.End)
+
+	atEnd := parsed
+	atEnd.Params.Query = "up @ end()"
+	_, ok = ResultsCacheRequest("native", atEnd)
+	assert.False(t, ok)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/scrape"
+	"github.com/m3db/m3/src/query/api/v1/options"
+	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/util/json"
+	"github.com/m3db/m3/src/query/util/logging"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+const (
+	// TargetsURL is the url for the scrape targets endpoint.
+	TargetsURL = route.TargetsURL
+
+	stateParam      = "state"
+	scrapePoolParam = "scrapePool"
+
+	activeTargetsState  = "active"
+	droppedTargetsState = "dropped"
+	anyTargetsState     = "any"
+)
+
+// TargetsHTTPMethods are the HTTP methods for this handler.
+var TargetsHTTPMethods = []string{http.MethodGet}
+
+// TargetsHandler is a handler for the Prometheus compatible targets
+// endpoint, reporting the health of the targets of the scrape manager.
+type TargetsHandler struct {
+	manager        scrape.Manager
+	instrumentOpts instrument.Options
+}
+
+// NewTargetsHandler returns a new targets handler.
+func NewTargetsHandler(opts options.HandlerOptions) http.Handler {
+	return &TargetsHandler{
+		manager:        opts.ScrapeManager(),
+		instrumentOpts: opts.InstrumentOpts(),
+	}
+}
+
+func (h *TargetsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(xhttp.HeaderContentType, xhttp.ContentTypeJSON)
+
+	state := r.FormValue(stateParam)
+	switch state {
+	case "":
+		state = anyTargetsState
+	case activeTargetsState, droppedTargetsState, anyTargetsState:
+	default:
+		xhttp.WriteError(w, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid state: %s", state)))
+		return
+	}
+
+	var targets []scrape.TargetStatus
+	if h.manager != nil && state != droppedTargetsState {
+		scrapePool := r.FormValue(scrapePoolParam)
+		for _, target := range h.manager.Targets() {
+			if scrapePool != "" && target.ScrapePool != scrapePool {
+				continue
+			}
+			targets = append(targets, target)
+		}
+	}
+
+	if err := renderTargetsResultsJSON(w, targets, state); err != nil {
+		logger := logging.WithContext(r.Context(), h.instrumentOpts)
+		logger.Error("unable to render targets", zap.Error(err))
+	}
+}
+
+func renderTargetsResultsJSON(
+	w io.Writer,
+	targets []scrape.TargetStatus,
+	state string,
+) error {
+	jw := json.NewWriter(w)
+	jw.BeginObject()
+
+	jw.BeginObjectField("status")
+	jw.WriteString("success")
+
+	jw.BeginObjectField("data")
+	jw.BeginObject()
+
+	if state != droppedTargetsState {
+		jw.BeginObjectField("activeTargets")
+		jw.BeginArray()
+		for _, target := range targets {
+			renderTargetJSON(jw, target)
+		}
+		jw.EndArray()
+	}
+
+	if state != activeTargetsState {
+		// NB: targets are not relabeled so none are ever dropped.
+		jw.BeginObjectField("droppedTargets")
+		jw.BeginArray()
+		jw.EndArray()
+	}
+
+	jw.EndObject()
+
+	jw.EndObject()
+	return jw.Close()
+}
+
+func renderTargetJSON(jw json.Writer, target scrape.TargetStatus) {
+	jw.BeginObject()
+
+	jw.BeginObjectField("discoveredLabels")
+	renderLabelsJSON(jw, target.DiscoveredLabels)
+	jw.BeginObjectField("labels")
+	renderLabelsJSON(jw, target.Labels)
+
+	jw.BeginObjectField("scrapePool")
+	jw.WriteString(target.ScrapePool)
+	jw.BeginObjectField("scrapeUrl")
+	jw.WriteString(target.ScrapeURL)
+	jw.BeginObjectField("globalUrl")
+	jw.WriteString(target.ScrapeURL)
+	jw.BeginObjectField("lastError")
+	jw.WriteString(target.LastError)
+	jw.BeginObjectField("lastScrape")
+	jw.WriteString(target.LastScrape.Format(time.RFC3339Nano))
+	jw.BeginObjectField("lastScrapeDuration")
+	jw.WriteFloat64(target.LastScrapeDuration.Seconds())
+	jw.BeginObjectField("health")
+	jw.WriteString(string(target.Health))
+	jw.BeginObjectField("scrapeInterval")
+	jw.WriteString(model.Duration(target.ScrapeInterval).String())
+	jw.BeginObjectField("scrapeTimeout")
+	jw.WriteString(model.Duration(target.ScrapeTimeout).String())
+
+	jw.EndObject()
+}
+
+func renderLabelsJSON(jw json.Writer, lset labels.Labels) {
+	jw.BeginObject()
+	for _, l := range lset {
+		jw.BeginObjectField(l.Name)
+		jw.WriteString(l.Value)
+	}
+	jw.EndObject()
+}
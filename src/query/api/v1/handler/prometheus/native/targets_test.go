@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package native
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/scrape"
+	"github.com/m3db/m3/src/query/api/v1/options"
+)
+
+type testScrapeManager struct {
+	targets []scrape.TargetStatus
+}
+
+func (m *testScrapeManager) Start() error                   { return nil }
+func (m *testScrapeManager) Targets() []scrape.TargetStatus { return m.targets }
+func (m *testScrapeManager) Close() error                   { return nil }
+
+func TestTargetsHandler(t *testing.T) {
+	manager := &testScrapeManager{
+		targets: []scrape.TargetStatus{
+			{
+				DiscoveredLabels: labels.FromStrings(
+					"__address__", "host:9100", "job", "node"),
+				Labels:             labels.FromStrings("instance", "host:9100", "job", "node"),
+				ScrapePool:         "node",
+				ScrapeURL:          "http://host:9100/metrics",
+				LastError:          "server returned HTTP status 500",
+				LastScrape:         time.Unix(1600000000, 0).UTC(),
+				LastScrapeDuration: 500 * time.Millisecond,
+				Health:             scrape.HealthBad,
+				ScrapeInterval:     time.Minute,
+				ScrapeTimeout:      10 * time.Second,
+			},
+			{
+				Labels:         labels.FromStrings("instance", "other:9090", "job", "other"),
+				ScrapePool:     "other",
+				ScrapeURL:      "http://other:9090/metrics",
+				Health:         scrape.HealthUnknown,
+				ScrapeInterval: 15 * time.Second,
+				ScrapeTimeout:  15 * time.Second,
+			},
+		},
+	}
+	h := NewTargetsHandler(options.EmptyHandlerOptions().SetScrapeManager(manager))
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, TargetsURL+query, nil))
+		return w
+	}
+
+	w := serve("?scrapePool=node")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{
+		"status": "success",
+		"data": {
+			"activeTargets": [
+				{
+					"discoveredLabels": {"__address__": "host:9100", "job": "node"},
+					"labels": {"instance": "host:9100", "job": "node"},
+					"scrapePool": "node",
+					"scrapeUrl": "http://host:9100/metrics",
+					"globalUrl": "http://host:9100/metrics",
+					"lastError": "server returned HTTP status 500",
+					"lastScrape": "2020-09-13T12:26:40Z",
+					"lastScrapeDuration": 0.5,
+					"health": "down",
+					"scrapeInterval": "1m",
+					"scrapeTimeout": "10s"
+				}
+			],
+			"droppedTargets": []
+		}
+	}`, w.Body.String())
+
+	w = serve("?state=active")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"scrapePool":"other"`)
+	assert.NotContains(t, w.Body.String(), "droppedTargets")
+
+	w = serve("?state=dropped")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"success","data":{"droppedTargets":[]}}`, w.Body.String())
+
+	w = serve("?state=invalid")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// No targets are reported when scraping is not enabled.
+	w = httptest.NewRecorder()
+	NewTargetsHandler(options.EmptyHandlerOptions()).ServeHTTP(w,
+		httptest.NewRequest(http.MethodGet, TargetsURL, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"success","data":{"activeTargets":[],"droppedTargets":[]}}`,
+		w.Body.String())
+}
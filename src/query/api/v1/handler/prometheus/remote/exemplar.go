@@ -0,0 +1,81 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"context"
+	"sort"
+
+	promexemplar "github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/util/logging"
+)
+
+// appendExemplars queues the exemplars of the written series to be written
+// to the exemplar store. Exemplars are best effort, failing to store them
+// does not fail the write.
+func (h *PromWriteHandler) appendExemplars(
+	ctx context.Context,
+	timeseries []prompb.TimeSeries,
+) {
+	if h.exemplarStore == nil {
+		return
+	}
+
+	for _, promTS := range timeseries {
+		if len(promTS.Exemplars) == 0 {
+			continue
+		}
+
+		exemplars := make([]promexemplar.Exemplar, 0, len(promTS.Exemplars))
+		for _, e := range promTS.Exemplars {
+			exemplars = append(exemplars, promexemplar.Exemplar{
+				Labels: promLabelsToLabels(e.Labels),
+				Value:  e.Value,
+				Ts:     e.Timestamp,
+				HasTs:  true,
+			})
+		}
+
+		series := promLabelsToLabels(promTS.Labels)
+		if err := h.exemplarStore.Append(series, exemplars); err != nil {
+			h.metrics.exemplarErrors.Inc(1)
+			logging.WithContext(ctx, h.instrumentOpts).
+				Debug("could not append exemplars",
+					zap.Stringer("series", series), zap.Error(err))
+		}
+	}
+}
+
+func promLabelsToLabels(promLabels []prompb.Label) labels.Labels {
+	result := make(labels.Labels, 0, len(promLabels))
+	for _, l := range promLabels {
+		result = append(result, labels.Label{
+			Name:  string(l.Name),
+			Value: string(l.Value),
+		})
+	}
+	sort.Sort(result)
+	return result
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/util/logging"
+)
+
+// updateMetricMetadata persists the metric metadata sent with the write.
+// Like exemplars, metadata is best effort and failing to persist it does not
+// fail the write.
+func (h *PromWriteHandler) updateMetricMetadata(
+	ctx context.Context,
+	metadata []prompb.MetricMetadata,
+) {
+	if h.metricMetadataStore == nil || len(metadata) == 0 {
+		return
+	}
+
+	if err := h.metricMetadataStore.Update(metadata); err != nil {
+		h.metrics.metadataErrors.Inc(1)
+		logging.WithContext(ctx, h.instrumentOpts).
+			Debug("could not update metric metadata", zap.Error(err))
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// nativeHistogramValue is a single native histogram sample, stored as a
+// datapoint holding the observation count with the encoded histogram
+// carried in its annotation.
+type nativeHistogramValue struct {
+	tags       models.Tags
+	datapoint  ts.Datapoint
+	attributes ts.SeriesAttributes
+	annotation []byte
+}
+
+func newNativeHistogramIter(
+	timeseries []prompb.TimeSeries,
+	tagOpts models.TagOptions,
+) (*nativeHistogramIter, error) {
+	var values []nativeHistogramValue
+	for _, promTS := range timeseries {
+		if len(promTS.Histograms) == 0 {
+			continue
+		}
+
+		tags := storage.PromLabelsToM3Tags(promTS.Labels, tagOpts)
+		for _, promHistogram := range promTS.Histograms {
+			var (
+				gauge        = promHistogram.ResetHint == prompb.Histogram_GAUGE
+				h            = storage.PromHistogramToNative(promHistogram)
+				payload, err = storage.NativeHistogramToAnnotationPayload(h, gauge)
+			)
+			if err != nil {
+				return nil, xerrors.NewInvalidParamsError(err)
+			}
+
+			annotation, err := payload.Marshal()
+			if err != nil {
+				return nil, err
+			}
+
+			attributes := ts.SeriesAttributes{PromType: ts.PromMetricTypeHistogram}
+			if gauge {
+				attributes.PromType = ts.PromMetricTypeGaugeHistogram
+			}
+
+			values = append(values, nativeHistogramValue{
+				tags: tags,
+				datapoint: ts.Datapoint{
+					Timestamp: xtime.ToUnixNano(storage.PromTimestampToTime(promHistogram.Timestamp)),
+					Value:     h.Count,
+				},
+				attributes: attributes,
+				annotation: annotation,
+			})
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	return &nativeHistogramIter{
+		idx:    -1,
+		values: values,
+	}, nil
+}
+
+// nativeHistogramIter iterates over native histogram samples, returning each
+// sample as its own value since every sample has a distinct annotation.
+type nativeHistogramIter struct {
+	idx       int
+	values    []nativeHistogramValue
+	metadatas []ts.Metadata
+}
+
+func (i *nativeHistogramIter) Next() bool {
+	i.idx++
+	return i.idx < len(i.values)
+}
+
+func (i *nativeHistogramIter) Current() ingest.IterValue {
+	if i.idx < 0 || i.idx >= len(i.values) {
+		return defaultValue
+	}
+
+	curr := i.values[i.idx]
+	value := ingest.IterValue{
+		Tags:       curr.tags,
+		Datapoints: ts.Datapoints{curr.datapoint},
+		Attributes: curr.attributes,
+		Unit:       xtime.Millisecond,
+		Annotation: curr.annotation,
+	}
+	if i.idx < len(i.metadatas) {
+		value.Metadata = i.metadatas[i.idx]
+	}
+	return value
+}
+
+func (i *nativeHistogramIter) Reset() error {
+	i.idx = -1
+	return nil
+}
+
+func (i *nativeHistogramIter) Error() error {
+	return nil
+}
+
+func (i *nativeHistogramIter) SetCurrentMetadata(metadata ts.Metadata) {
+	if len(i.metadatas) == 0 {
+		i.metadatas = make([]ts.Metadata, len(i.values))
+	}
+	if i.idx < 0 || i.idx >= len(i.metadatas) {
+		return
+	}
+	i.metadatas[i.idx] = metadata
+}
+
+// nativeHistogramWriteOptions returns the write options for native histogram
+// samples. Downsampling is disabled since aggregating the datapoints would
+// only retain the observation count and lose the buckets.
+func nativeHistogramWriteOptions(opts ingest.WriteOptions) ingest.WriteOptions {
+	opts.DownsampleOverride = true
+	opts.DownsampleMappingRules = nil
+	return opts
+}
@@ -50,7 +50,9 @@ import (
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/exemplar"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/storage/metricmetadata"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/clock"
@@ -119,18 +121,21 @@ var (
 
 // PromWriteHandler represents a handler for prometheus write endpoint.
 type PromWriteHandler struct {
-	downsamplerAndWriter   ingest.DownsamplerAndWriter
-	tagOptions             models.TagOptions
-	storeMetricsType       bool
-	forwarding             handleroptions.PromWriteHandlerForwardingOptions
-	forwardTimeout         time.Duration
-	forwardHTTPClient      *http.Client
-	forwardingBoundWorkers xsync.WorkerPool
-	forwardContext         context.Context
-	forwardRetrier         retry.Retrier
-	nowFn                  clock.NowFn
-	instrumentOpts         instrument.Options
-	metrics                promWriteMetrics
+	downsamplerAndWriter    ingest.DownsamplerAndWriter
+	tagOptions              models.TagOptions
+	storeMetricsType        bool
+	ingestCreatedTimestamps bool
+	exemplarStore           exemplar.Store
+	metricMetadataStore     metricmetadata.Store
+	forwarding              handleroptions.PromWriteHandlerForwardingOptions
+	forwardTimeout          time.Duration
+	forwardHTTPClient       *http.Client
+	forwardingBoundWorkers  xsync.WorkerPool
+	forwardContext          context.Context
+	forwardRetrier          retry.Retrier
+	nowFn                   clock.NowFn
+	instrumentOpts          instrument.Options
+	metrics                 promWriteMetrics
 
 	// Counting the number of times of "literal is too long" error for log sampling purposes.
 	numLiteralIsTooLong uint32
@@ -192,18 +197,21 @@ func NewPromWriteHandler(options options.HandlerOptions) (http.Handler, error) {
 	)
 
 	return &PromWriteHandler{
-		downsamplerAndWriter:   downsamplerAndWriter,
-		tagOptions:             tagOptions,
-		storeMetricsType:       options.StoreMetricsType(),
-		forwarding:             forwarding,
-		forwardTimeout:         forwardTimeout,
-		forwardHTTPClient:      xhttp.NewHTTPClient(forwardHTTPOpts),
-		forwardingBoundWorkers: forwardingBoundWorkers,
-		forwardContext:         context.Background(),
-		forwardRetrier:         retry.NewRetrier(forwardRetryOpts),
-		nowFn:                  nowFn,
-		metrics:                metrics,
-		instrumentOpts:         instrumentOpts,
+		downsamplerAndWriter:    downsamplerAndWriter,
+		tagOptions:              tagOptions,
+		storeMetricsType:        options.StoreMetricsType(),
+		ingestCreatedTimestamps: options.IngestCreatedTimestamps(),
+		exemplarStore:           options.ExemplarStore(),
+		metricMetadataStore:     options.MetricMetadataStore(),
+		forwarding:              forwarding,
+		forwardTimeout:          forwardTimeout,
+		forwardHTTPClient:       xhttp.NewHTTPClient(forwardHTTPOpts),
+		forwardingBoundWorkers:  forwardingBoundWorkers,
+		forwardContext:          context.Background(),
+		forwardRetrier:          retry.NewRetrier(forwardRetryOpts),
+		nowFn:                   nowFn,
+		metrics:                 metrics,
+		instrumentOpts:          instrumentOpts,
 	}, nil
 }
 
@@ -221,6 +229,9 @@ type promWriteMetrics struct {
 	forwardLatency           tally.Histogram
 	forwardShadowKeep        tally.Counter
 	forwardShadowDrop        tally.Counter
+	exemplarErrors           tally.Counter
+	metadataErrors           tally.Counter
+	createdTimestampsDropped tally.Counter
 }
 
 func (m *promWriteMetrics) incError(err error) {
@@ -250,6 +261,9 @@ func newPromWriteMetrics(scope tally.Scope) (promWriteMetrics, error) {
 		forwardLatency:           scope.SubScope("forward").Histogram("latency", buckets.WriteLatencyBuckets),
 		forwardShadowKeep:        scope.SubScope("forward").SubScope("shadow").Counter("keep"),
 		forwardShadowDrop:        scope.SubScope("forward").SubScope("shadow").Counter("drop"),
+		exemplarErrors:           scope.SubScope("exemplars").Counter("errors"),
+		metadataErrors:           scope.SubScope("metadata").Counter("errors"),
+		createdTimestampsDropped: scope.SubScope("created-timestamps").Counter("dropped"),
 	}, nil
 }
 
@@ -257,7 +271,14 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	batchRequestStopwatch := h.metrics.writeBatchLatency.Start()
 	defer batchRequestStopwatch.Stop()
 
-	checkedReq, err := h.checkedParseRequest(r)
+	protoMsg, err := parseRemoteWriteProtoMsg(r.Header.Get(xhttp.HeaderContentType))
+	if err != nil {
+		h.metrics.incError(err)
+		xhttp.WriteError(w, err)
+		return
+	}
+
+	checkedReq, err := h.checkedParseRequest(r, protoMsg)
 	if err != nil {
 		h.metrics.incError(err)
 		xhttp.WriteError(w, err)
@@ -326,7 +347,13 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	h.appendExemplars(r.Context(), req.Timeseries)
+	h.updateMetricMetadata(r.Context(), req.Metadata)
 	batchErr := h.write(r.Context(), req, opts)
+	if batchErr == nil {
+		batchErr = h.writeCreatedTimestamps(r.Context(),
+			checkedReq.CreatedTimestamps, opts)
+	}
 
 	// Record ingestion delay latency
 	now := h.nowFn()
@@ -397,6 +424,12 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				resultErrMessage, sep, numBadRequest, lastBadRequestErr)
 		}
 
+		if protoMsg == remoteWriteV2ProtoMsg {
+			// Nothing is reported as written when any write fails since the
+			// sender cannot tell which of the samples were written.
+			setWrittenHeaders(w, writtenStats{})
+		}
+
 		resultError := xhttp.NewError(errors.New(resultErrMessage), status)
 		h.metrics.incError(resultError)
 		xhttp.WriteError(w, resultError)
@@ -406,6 +439,9 @@ func (h *PromWriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// NB(schallert): this is frustrating but if we don't explicitly write an HTTP
 	// status code (or via Write()), OpenTracing middleware reports code=0 and
 	// shows up as error.
+	if protoMsg == remoteWriteV2ProtoMsg {
+		setWrittenHeaders(w, checkedReq.Written)
+	}
 	w.WriteHeader(200)
 	h.metrics.writeSuccess.Inc(1)
 }
@@ -414,12 +450,18 @@ type parseRequestResult struct {
 	Request        *prompb.WriteRequest
 	Options        ingest.WriteOptions
 	CompressResult prometheus.ParsePromCompressedRequestResult
+	ProtoMsg       remoteWriteProtoMsg
+	Written        writtenStats
+	// CreatedTimestamps are the zero samples of created timestamps received
+	// with remote write 2.0 requests, they are neither forwarded nor mapped.
+	CreatedTimestamps []prompb.TimeSeries
 }
 
 func (h *PromWriteHandler) checkedParseRequest(
 	r *http.Request,
+	protoMsg remoteWriteProtoMsg,
 ) (parseRequestResult, error) {
-	result, err := h.parseRequest(r)
+	result, err := h.parseRequest(r, protoMsg)
 	if err != nil {
 		// Always invalid request if parsing fails params.
 		return parseRequestResult{}, xerrors.NewInvalidParamsError(err)
@@ -436,6 +478,7 @@ func (h *PromWriteHandler) checkedParseRequest(
 // uphold the same guarantees.
 func (h *PromWriteHandler) parseRequest(
 	r *http.Request,
+	protoMsg remoteWriteProtoMsg,
 ) (parseRequestResult, error) {
 	var opts ingest.WriteOptions
 	if v := strings.TrimSpace(r.Header.Get(headers.MetricsTypeHeader)); v != "" {
@@ -489,9 +532,29 @@ func (h *PromWriteHandler) parseRequest(
 		return parseRequestResult{}, err
 	}
 
-	var req prompb.WriteRequest
-	if err := proto.Unmarshal(result.UncompressedBody, &req); err != nil {
-		return parseRequestResult{}, err
+	var (
+		req               prompb.WriteRequest
+		written           writtenStats
+		createdTimestamps []prompb.TimeSeries
+	)
+	switch protoMsg {
+	case remoteWriteV2ProtoMsg:
+		decoded, err := decodeWriteV2Request(result.UncompressedBody,
+			h.ingestCreatedTimestamps, h.nowFn())
+		if err != nil {
+			return parseRequestResult{}, err
+		}
+		req = *decoded.request
+		written = decoded.written
+		createdTimestamps = decoded.createdTimestamps
+		if h.exemplarStore == nil {
+			// Exemplars are dropped if there is nowhere to store them.
+			written.exemplars = 0
+		}
+	default:
+		if err := proto.Unmarshal(result.UncompressedBody, &req); err != nil {
+			return parseRequestResult{}, err
+		}
 	}
 
 	if mapStr := r.Header.Get(headers.MapTagsByJSONHeader); mapStr != "" {
@@ -530,9 +593,12 @@ func (h *PromWriteHandler) parseRequest(
 	}
 
 	return parseRequestResult{
-		Request:        &req,
-		Options:        opts,
-		CompressResult: result,
+		Request:           &req,
+		Options:           opts,
+		CompressResult:    result,
+		ProtoMsg:          protoMsg,
+		Written:           written,
+		CreatedTimestamps: createdTimestamps,
 	}, nil
 }
 
@@ -546,7 +612,64 @@ func (h *PromWriteHandler) write(
 		var errs xerrors.MultiError
 		return errs.Add(err)
 	}
-	return h.downsamplerAndWriter.WriteBatch(ctx, iter, opts)
+
+	histogramIter, err := newNativeHistogramIter(r.Timeseries, h.tagOptions)
+	if err != nil {
+		var errs xerrors.MultiError
+		return errs.Add(err)
+	}
+
+	batchErr := h.downsamplerAndWriter.WriteBatch(ctx, iter, opts)
+	if histogramIter == nil {
+		return batchErr
+	}
+
+	histogramErr := h.downsamplerAndWriter.WriteBatch(ctx, histogramIter,
+		nativeHistogramWriteOptions(opts))
+	if histogramErr == nil {
+		return batchErr
+	}
+	if batchErr == nil {
+		return histogramErr
+	}
+
+	var errs xerrors.MultiError
+	for _, err := range append(batchErr.Errors(), histogramErr.Errors()...) {
+		errs = errs.Add(err)
+	}
+	return errs
+}
+
+// writeCreatedTimestamps writes the zero samples of created timestamps. Zero
+// samples rejected by the database, such as those older than the buffer past
+// of a namespace, are dropped without failing the write since they only mark
+// the reset of series whose samples have been written.
+func (h *PromWriteHandler) writeCreatedTimestamps(
+	ctx context.Context,
+	series []prompb.TimeSeries,
+	opts ingest.WriteOptions,
+) ingest.BatchError {
+	if len(series) == 0 {
+		return nil
+	}
+
+	batchErr := h.write(ctx, &prompb.WriteRequest{Timeseries: series}, opts)
+	if batchErr == nil {
+		return nil
+	}
+
+	var errs xerrors.MultiError
+	for _, err := range batchErr.Errors() {
+		if client.IsBadRequestError(err) || xerrors.IsInvalidParams(err) {
+			h.metrics.createdTimestampsDropped.Inc(1)
+			continue
+		}
+		errs = errs.Add(err)
+	}
+	if errs.Empty() {
+		return nil
+	}
+	return errs
 }
 
 func (h *PromWriteHandler) forward(
@@ -556,6 +679,15 @@ func (h *PromWriteHandler) forward(
 	target handleroptions.PromWriteHandlerForwardTargetOptions,
 ) error {
 	body := bytes.NewReader(res.CompressResult.CompressedBody)
+	if res.ProtoMsg == remoteWriteV2ProtoMsg && target.Shadow == nil {
+		// Targets are sent remote write 1.0 requests since the forwarded
+		// request carries no content type to negotiate the proto message.
+		buffer, err := encodeWriteV1Request(res.Request)
+		if err != nil {
+			return fmt.Errorf("failed to marshal forwarding request: %w", err)
+		}
+		body.Reset(buffer)
+	}
 	if shadowOpts := target.Shadow; shadowOpts != nil {
 		// Need to send a subset of the original series to the shadow target.
 		buffer, err := h.buildForwardShadowRequestBody(res, shadowOpts)
@@ -734,6 +866,11 @@ func newPromTSIter(
 
 	graphiteTagOpts := tagOpts.SetIDSchemeType(models.TypeGraphite)
 	for _, promTS := range timeseries {
+		if len(promTS.Samples) == 0 && len(promTS.Histograms) > 0 {
+			// Native histograms are written separately.
+			continue
+		}
+
 		attributes, err := storage.PromTimeSeriesToSeriesAttributes(promTS)
 		if err != nil {
 			return nil, err
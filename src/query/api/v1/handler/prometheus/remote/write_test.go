@@ -35,10 +35,13 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	promexemplar "github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/uber-go/tally"
 
+	"github.com/m3db/m3/src/cluster/kv/mem"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
@@ -48,7 +51,11 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/options"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/exemplar"
+	"github.com/m3db/m3/src/query/storage/histogram"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
+	"github.com/m3db/m3/src/query/storage/metricmetadata"
 	xclock "github.com/m3db/m3/src/x/clock"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	"github.com/m3db/m3/src/x/headers"
@@ -82,7 +89,7 @@ func TestPromWriteParsing(t *testing.T) {
 	promReqBody := test.GeneratePromWriteRequestBody(t, promReq)
 	req := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, promReqBody)
 
-	r, err := handler.(*PromWriteHandler).parseRequest(req)
+	r, err := handler.(*PromWriteHandler).parseRequest(req, remoteWriteV1ProtoMsg)
 	require.Nil(t, err, "unable to parse request")
 	require.Equal(t, len(r.Request.Timeseries), 2)
 	require.Equal(t, ingest.WriteOptions{}, r.Options)
@@ -348,6 +355,157 @@ func TestPromWriteOpenMetricsTypes(t *testing.T) {
 	}, secondAnnotationPayload, "second annotation invalidated")
 }
 
+func TestPromWriteNativeHistograms(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		capturedIters []ingest.DownsampleAndWriteIter
+		capturedOpts  []ingest.WriteOptions
+	)
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(_ context.Context, iter ingest.DownsampleAndWriteIter, opts ingest.WriteOptions) ingest.BatchError {
+			capturedIters = append(capturedIters, iter)
+			capturedOpts = append(capturedOpts, opts)
+			return nil
+		}).
+		Times(2)
+
+	opts := makeOptions(mockDownsamplerAndWriter)
+
+	promHistogram := prompb.Histogram{
+		CountInt:       3,
+		Sum:            4.5,
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 2}},
+		PositiveDeltas: []int64{1, 1},
+		Timestamp:      1000,
+	}
+	promReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("gauge")}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+			{
+				Labels:     []prompb.Label{{Name: []byte("__name__"), Value: []byte("latency")}},
+				Histograms: []prompb.Histogram{promHistogram},
+			},
+		},
+	}
+
+	executeWriteRequest(t, opts, promReq)
+	require.Len(t, capturedIters, 2)
+
+	// Regular samples are written as before, without the histogram series.
+	require.True(t, capturedIters[0].Next())
+	assert.Equal(t, 1.0, capturedIters[0].Current().Datapoints[0].Value)
+	require.False(t, capturedIters[0].Next())
+
+	// Native histograms are written without downsampling.
+	assert.True(t, capturedOpts[1].DownsampleOverride)
+	iter := capturedIters[1]
+	require.True(t, iter.Next())
+	value := iter.Current()
+	require.Len(t, value.Datapoints, 1)
+	assert.Equal(t, 3.0, value.Datapoints[0].Value)
+	assert.Equal(t, int64(1000), storage.TimeToPromTimestamp(value.Datapoints[0].Timestamp))
+
+	payload := unmarshalAnnotation(t, value.Annotation)
+	assert.Equal(t, annotation.OpenMetricsFamilyType_HISTOGRAM, payload.OpenMetricsFamilyType)
+	h, err := histogram.Decode(payload.NativeHistogram)
+	require.NoError(t, err)
+	assert.Equal(t, storage.PromHistogramToNative(promHistogram), h)
+
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Error())
+}
+
+func TestPromWriteExemplars(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	store := exemplar.NewMockStore(ctrl)
+	store.EXPECT().
+		Append(labels.FromStrings("__name__", "latency"), []promexemplar.Exemplar{
+			{
+				Labels: labels.FromStrings("trace_id", "abc"),
+				Value:  0.5,
+				Ts:     900,
+				HasTs:  true,
+			},
+			{Labels: labels.Labels{}, Value: 0.2, Ts: 800, HasTs: true},
+		}).
+		// Failing to store exemplars does not fail the write.
+		Return(errors.New("exemplar error"))
+	opts := makeOptions(mockDownsamplerAndWriter).SetExemplarStore(store)
+
+	promReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("latency")}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+				Exemplars: []prompb.Exemplar{
+					{
+						Labels:    []prompb.Label{{Name: []byte("trace_id"), Value: []byte("abc")}},
+						Value:     0.5,
+						Timestamp: 900,
+					},
+					{Value: 0.2, Timestamp: 800},
+				},
+			},
+		},
+	}
+
+	executeWriteRequest(t, opts, promReq)
+}
+
+func TestPromWriteMetricMetadata(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	store, err := metricmetadata.NewStore(mem.NewStore(), metricmetadata.Options{})
+	require.NoError(t, err)
+	opts := makeOptions(mockDownsamplerAndWriter).SetMetricMetadataStore(store)
+
+	metadata := prompb.MetricMetadata{
+		Type:             prompb.MetricType_COUNTER,
+		MetricFamilyName: "http_requests_total",
+		Help:             "Total HTTP requests.",
+	}
+	promReq := &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: []byte("__name__"), Value: []byte("http_requests_total")}},
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+		Metadata: []prompb.MetricMetadata{metadata},
+	}
+
+	executeWriteRequest(t, opts, promReq)
+
+	results, err := store.Query("", -1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]prompb.MetricMetadata{
+		"http_requests_total": {metadata},
+	}, results)
+}
+
 func TestPromWriteGraphiteMetricsTypes(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
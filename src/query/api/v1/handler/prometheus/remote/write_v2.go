@@ -0,0 +1,383 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/generated/proto/writev2pb"
+	"github.com/m3db/m3/src/x/headers"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+)
+
+// remoteWriteProtoMsg is the proto message a remote write request body is
+// encoded with, negotiated with the proto parameter of its content type.
+type remoteWriteProtoMsg string
+
+const (
+	// remoteWriteV1ProtoMsg is the proto message of remote write 1.0 requests.
+	remoteWriteV1ProtoMsg remoteWriteProtoMsg = "prometheus.WriteRequest"
+	// remoteWriteV2ProtoMsg is the proto message of remote write 2.0 requests.
+	remoteWriteV2ProtoMsg remoteWriteProtoMsg = "io.prometheus.write.v2.Request"
+
+	// createdTimestampWindow bounds how old the created timestamps ingested
+	// as zero samples can be. It matches the default buffer past of
+	// namespaces, older datapoints being rejected by the database.
+	createdTimestampWindow = 10 * time.Minute
+)
+
+var (
+	promMetricNameLabel = []byte("__name__")
+
+	errWriteV2FirstSymbolNotEmpty = errors.New("first symbol of remote write 2.0 request must be empty")
+	errWriteV2OddLabelsRefs       = errors.New("remote write 2.0 labels refs must be name and value pairs")
+
+	writeV2MetricTypes = map[writev2pb.Metadata_MetricType]prompb.MetricType{
+		writev2pb.Metadata_METRIC_TYPE_UNSPECIFIED:    prompb.MetricType_UNKNOWN,
+		writev2pb.Metadata_METRIC_TYPE_COUNTER:        prompb.MetricType_COUNTER,
+		writev2pb.Metadata_METRIC_TYPE_GAUGE:          prompb.MetricType_GAUGE,
+		writev2pb.Metadata_METRIC_TYPE_HISTOGRAM:      prompb.MetricType_HISTOGRAM,
+		writev2pb.Metadata_METRIC_TYPE_GAUGEHISTOGRAM: prompb.MetricType_GAUGE_HISTOGRAM,
+		writev2pb.Metadata_METRIC_TYPE_SUMMARY:        prompb.MetricType_SUMMARY,
+		writev2pb.Metadata_METRIC_TYPE_INFO:           prompb.MetricType_INFO,
+		writev2pb.Metadata_METRIC_TYPE_STATESET:       prompb.MetricType_STATESET,
+	}
+
+	// metricFamilySuffixes are the suffixes of the series of a metric family
+	// that are not part of the metric family name, by metric type.
+	metricFamilySuffixes = map[prompb.MetricType][]string{
+		prompb.MetricType_HISTOGRAM:       {"_bucket", "_count", "_sum"},
+		prompb.MetricType_GAUGE_HISTOGRAM: {"_bucket", "_gcount", "_gsum"},
+		prompb.MetricType_SUMMARY:         {"_count", "_sum"},
+	}
+)
+
+// decodedWriteV2Request is a remote write 2.0 request converted to a remote
+// write 1.0 request.
+type decodedWriteV2Request struct {
+	request *prompb.WriteRequest
+	// createdTimestamps are the series holding the zero samples of created
+	// timestamps, written separately so that they can be rejected without
+	// failing the request.
+	createdTimestamps []prompb.TimeSeries
+	written           writtenStats
+}
+
+// writtenStats are the number of samples, histograms and exemplars received
+// with a remote write 2.0 request, returned in the response headers.
+type writtenStats struct {
+	samples    int
+	histograms int
+	exemplars  int
+}
+
+// parseRemoteWriteProtoMsg negotiates the proto message of a remote write
+// request from its content type. Requests without a protobuf content type are
+// treated as remote write 1.0 requests, as those were always accepted.
+func parseRemoteWriteProtoMsg(contentType string) (remoteWriteProtoMsg, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != xhttp.ContentTypeProtobuf {
+		return remoteWriteV1ProtoMsg, nil
+	}
+
+	switch msg := remoteWriteProtoMsg(params["proto"]); msg {
+	case "", remoteWriteV1ProtoMsg:
+		return remoteWriteV1ProtoMsg, nil
+	case remoteWriteV2ProtoMsg:
+		return remoteWriteV2ProtoMsg, nil
+	default:
+		err := fmt.Errorf("unsupported remote write proto message: %s", msg)
+		return "", xhttp.NewError(err, http.StatusUnsupportedMediaType)
+	}
+}
+
+// decodeWriteV2Request decodes a remote write 2.0 request, converting it to
+// a remote write 1.0 request so that it can be written, forwarded and mapped
+// the same way. Label names and values are resolved once per symbol so that
+// series sharing labels also share the underlying bytes. Created timestamps
+// are ingested as zero samples if ingestCreatedTimestamps is set, provided
+// they are within the created timestamp window of now.
+func decodeWriteV2Request(
+	body []byte,
+	ingestCreatedTimestamps bool,
+	now time.Time,
+) (decodedWriteV2Request, error) {
+	var req writev2pb.Request
+	if err := proto.Unmarshal(body, &req); err != nil {
+		return decodedWriteV2Request{}, err
+	}
+
+	symbols := newWriteV2Symbols(req.Symbols)
+	if len(req.Symbols) > 0 && req.Symbols[0] != "" {
+		return decodedWriteV2Request{}, errWriteV2FirstSymbolNotEmpty
+	}
+
+	var (
+		result = decodedWriteV2Request{
+			request: &prompb.WriteRequest{
+				Timeseries: make([]prompb.TimeSeries, 0, len(req.Timeseries)),
+			},
+		}
+		minCreatedTimestamp = now.Add(-createdTimestampWindow).UnixNano() /
+			int64(time.Millisecond)
+		seenMetadata = make(map[string]struct{})
+	)
+	for i := range req.Timeseries {
+		series := &req.Timeseries[i]
+		labels, err := symbols.labels(series.LabelsRefs)
+		if err != nil {
+			return decodedWriteV2Request{}, err
+		}
+
+		metricType, ok := writeV2MetricTypes[series.Metadata.Type]
+		if !ok {
+			return decodedWriteV2Request{}, fmt.Errorf(
+				"unknown remote write 2.0 metric type: %d", series.Metadata.Type)
+		}
+
+		ts := prompb.TimeSeries{
+			Labels:     labels,
+			Samples:    series.Samples,
+			Histograms: series.Histograms,
+			Type:       metricType,
+		}
+		for _, e := range series.Exemplars {
+			exemplarLabels, err := symbols.labels(e.LabelsRefs)
+			if err != nil {
+				return decodedWriteV2Request{}, err
+			}
+
+			ts.Exemplars = append(ts.Exemplars, prompb.Exemplar{
+				Labels:    exemplarLabels,
+				Value:     e.Value,
+				Timestamp: e.Timestamp,
+			})
+		}
+
+		result.written.samples += len(ts.Samples)
+		result.written.histograms += len(ts.Histograms)
+		result.written.exemplars += len(ts.Exemplars)
+
+		if ingestCreatedTimestamps {
+			created, ok := createdTimestampSeries(ts, series.CreatedTimestamp,
+				minCreatedTimestamp)
+			if ok {
+				result.createdTimestamps = append(result.createdTimestamps, created)
+			}
+		}
+
+		metadata, ok, err := symbols.metadata(labels, metricType, series.Metadata)
+		if err != nil {
+			return decodedWriteV2Request{}, err
+		}
+		if _, seen := seenMetadata[metadata.MetricFamilyName]; ok && !seen {
+			seenMetadata[metadata.MetricFamilyName] = struct{}{}
+			result.request.Metadata = append(result.request.Metadata, metadata)
+		}
+
+		result.request.Timeseries = append(result.request.Timeseries, ts)
+	}
+
+	return result, nil
+}
+
+// createdTimestampSeries returns a series holding a zero sample at the created
+// timestamp of counters, histograms and summaries, so that resets are visible
+// to functions such as rate and increase. Created timestamps are sent with
+// every request, so a zero sample is only returned when the created timestamp
+// precedes the first sample of the series and is not older than minTime.
+// Older created timestamps have either been ingested by a previous request or
+// can no longer be written.
+func createdTimestampSeries(
+	ts prompb.TimeSeries,
+	createdTimestamp int64,
+	minTime int64,
+) (prompb.TimeSeries, bool) {
+	if createdTimestamp == 0 || createdTimestamp < minTime {
+		return prompb.TimeSeries{}, false
+	}
+
+	switch ts.Type {
+	case prompb.MetricType_COUNTER, prompb.MetricType_HISTOGRAM,
+		prompb.MetricType_SUMMARY:
+	default:
+		return prompb.TimeSeries{}, false
+	}
+
+	created := prompb.TimeSeries{Labels: ts.Labels, Type: ts.Type}
+	if len(ts.Samples) > 0 && createdTimestamp < ts.Samples[0].Timestamp {
+		created.Samples = []prompb.Sample{{Timestamp: createdTimestamp}}
+	}
+	if len(ts.Histograms) > 0 && createdTimestamp < ts.Histograms[0].Timestamp {
+		created.Histograms = []prompb.Histogram{{
+			Schema:    ts.Histograms[0].Schema,
+			ResetHint: prompb.Histogram_YES,
+			Timestamp: createdTimestamp,
+		}}
+	}
+
+	return created, len(created.Samples) > 0 || len(created.Histograms) > 0
+}
+
+type writeV2Symbols struct {
+	symbols []string
+	bytes   [][]byte
+}
+
+func newWriteV2Symbols(symbols []string) *writeV2Symbols {
+	return &writeV2Symbols{
+		symbols: symbols,
+		bytes:   make([][]byte, len(symbols)),
+	}
+}
+
+func (s *writeV2Symbols) symbol(ref uint32) (string, error) {
+	if int(ref) >= len(s.symbols) {
+		return "", fmt.Errorf("remote write 2.0 symbol ref out of range: ref=%d, symbols=%d",
+			ref, len(s.symbols))
+	}
+	return s.symbols[ref], nil
+}
+
+func (s *writeV2Symbols) symbolBytes(ref uint32) ([]byte, error) {
+	symbol, err := s.symbol(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if b := s.bytes[ref]; b != nil {
+		return b, nil
+	}
+
+	b := []byte(symbol)
+	s.bytes[ref] = b
+	return b, nil
+}
+
+func (s *writeV2Symbols) labels(refs []uint32) ([]prompb.Label, error) {
+	if len(refs)%2 != 0 {
+		return nil, errWriteV2OddLabelsRefs
+	}
+
+	labels := make([]prompb.Label, 0, len(refs)/2)
+	for i := 0; i < len(refs); i += 2 {
+		name, err := s.symbolBytes(refs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := s.symbolBytes(refs[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, prompb.Label{Name: name, Value: value})
+	}
+
+	return labels, nil
+}
+
+// metadata returns the metric metadata of a series, the metric family name
+// being derived from the metric name of the series and its type. Returns
+// false if the series has no metadata.
+func (s *writeV2Symbols) metadata(
+	labels []prompb.Label,
+	metricType prompb.MetricType,
+	metadata writev2pb.Metadata,
+) (prompb.MetricMetadata, bool, error) {
+	if metricType == prompb.MetricType_UNKNOWN &&
+		metadata.HelpRef == 0 && metadata.UnitRef == 0 {
+		return prompb.MetricMetadata{}, false, nil
+	}
+
+	var name []byte
+	for _, l := range labels {
+		if bytes.Equal(l.Name, promMetricNameLabel) {
+			name = l.Value
+			break
+		}
+	}
+	if len(name) == 0 {
+		return prompb.MetricMetadata{}, false, nil
+	}
+
+	var help, unit string
+	if metadata.HelpRef != 0 {
+		v, err := s.symbol(metadata.HelpRef)
+		if err != nil {
+			return prompb.MetricMetadata{}, false, err
+		}
+		help = v
+	}
+	if metadata.UnitRef != 0 {
+		v, err := s.symbol(metadata.UnitRef)
+		if err != nil {
+			return prompb.MetricMetadata{}, false, err
+		}
+		unit = v
+	}
+
+	family := string(name)
+	for _, suffix := range metricFamilySuffixes[metricType] {
+		if trimmed := bytes.TrimSuffix(name, []byte(suffix)); len(trimmed) != len(name) {
+			family = string(trimmed)
+			break
+		}
+	}
+
+	return prompb.MetricMetadata{
+		Type:             metricType,
+		MetricFamilyName: family,
+		Help:             help,
+		Unit:             unit,
+	}, true, nil
+}
+
+// encodeWriteV1Request encodes a request as a snappy compressed remote
+// write 1.0 request body.
+func encodeWriteV1Request(req *prompb.WriteRequest) ([]byte, error) {
+	encoded, err := proto.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, encoded), nil
+}
+
+// setWrittenHeaders sets the remote write 2.0 response headers with the
+// number of samples, histograms and exemplars written.
+func setWrittenHeaders(w http.ResponseWriter, stats writtenStats) {
+	w.Header().Set(headers.PromRemoteWriteSamplesWrittenHeader,
+		strconv.Itoa(stats.samples))
+	w.Header().Set(headers.PromRemoteWriteHistogramsWrittenHeader,
+		strconv.Itoa(stats.histograms))
+	w.Header().Set(headers.PromRemoteWriteExemplarsWrittenHeader,
+		strconv.Itoa(stats.exemplars))
+}
@@ -0,0 +1,432 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package remote
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/generated/proto/writev2pb"
+	"github.com/m3db/m3/src/query/storage/metricmetadata"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/headers"
+	xhttp "github.com/m3db/m3/src/x/net/http"
+	xtest "github.com/m3db/m3/src/x/test"
+)
+
+const testWriteV2ContentType = "application/x-protobuf;proto=io.prometheus.write.v2.Request"
+
+func TestParseRemoteWriteProtoMsg(t *testing.T) {
+	tests := []struct {
+		contentType string
+		expected    remoteWriteProtoMsg
+		expectedErr bool
+	}{
+		{contentType: "", expected: remoteWriteV1ProtoMsg},
+		{contentType: "application/octet-stream", expected: remoteWriteV1ProtoMsg},
+		{contentType: "application/x-protobuf", expected: remoteWriteV1ProtoMsg},
+		{
+			contentType: "application/x-protobuf;proto=prometheus.WriteRequest",
+			expected:    remoteWriteV1ProtoMsg,
+		},
+		{contentType: testWriteV2ContentType, expected: remoteWriteV2ProtoMsg},
+		{
+			contentType: "application/x-protobuf;proto=io.prometheus.write.v3.Request",
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.contentType, func(t *testing.T) {
+			msg, err := parseRemoteWriteProtoMsg(tt.contentType)
+			if tt.expectedErr {
+				require.Error(t, err)
+				httpErr, ok := err.(xhttp.Error)
+				require.True(t, ok)
+				assert.Equal(t, http.StatusUnsupportedMediaType, httpErr.Code())
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, msg)
+		})
+	}
+}
+
+func TestDecodeWriteV2Request(t *testing.T) {
+	req := &writev2pb.Request{
+		Symbols: []string{
+			"", "__name__", "http_request_duration_seconds_bucket", "le", "0.5",
+			"http_request_duration_seconds_count", "Request latency.", "seconds",
+			"trace_id", "abc",
+		},
+		Timeseries: []writev2pb.TimeSeries{
+			{
+				LabelsRefs: []uint32{1, 2, 3, 4},
+				Samples:    []prompb.Sample{{Value: 2, Timestamp: 2000}},
+				Exemplars: []writev2pb.Exemplar{
+					{LabelsRefs: []uint32{8, 9}, Value: 0.3, Timestamp: 1900},
+				},
+				Metadata: writev2pb.Metadata{
+					Type:    writev2pb.Metadata_METRIC_TYPE_HISTOGRAM,
+					HelpRef: 6,
+					UnitRef: 7,
+				},
+				CreatedTimestamp: 1000,
+			},
+			{
+				LabelsRefs: []uint32{1, 5},
+				Samples:    []prompb.Sample{{Value: 2, Timestamp: 2000}},
+				Metadata: writev2pb.Metadata{
+					Type:    writev2pb.Metadata_METRIC_TYPE_HISTOGRAM,
+					HelpRef: 6,
+					UnitRef: 7,
+				},
+			},
+		},
+	}
+	body, err := proto.Marshal(req)
+	require.NoError(t, err)
+
+	decoded, err := decodeWriteV2Request(body, true, time.Unix(60, 0))
+	require.NoError(t, err)
+	assert.Equal(t, writtenStats{samples: 2, exemplars: 1}, decoded.written)
+
+	b := func(v string) []byte { return []byte(v) }
+	// Created timestamp is ingested as a zero sample.
+	assert.Equal(t, []prompb.TimeSeries{
+		{
+			Labels: []prompb.Label{
+				{Name: b("__name__"), Value: b("http_request_duration_seconds_bucket")},
+				{Name: b("le"), Value: b("0.5")},
+			},
+			Samples: []prompb.Sample{{Value: 0, Timestamp: 1000}},
+			Type:    prompb.MetricType_HISTOGRAM,
+		},
+	}, decoded.createdTimestamps)
+
+	result := decoded.request
+	assert.Equal(t, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels: []prompb.Label{
+					{Name: b("__name__"), Value: b("http_request_duration_seconds_bucket")},
+					{Name: b("le"), Value: b("0.5")},
+				},
+				Samples: []prompb.Sample{{Value: 2, Timestamp: 2000}},
+				Exemplars: []prompb.Exemplar{
+					{
+						Labels:    []prompb.Label{{Name: b("trace_id"), Value: b("abc")}},
+						Value:     0.3,
+						Timestamp: 1900,
+					},
+				},
+				Type: prompb.MetricType_HISTOGRAM,
+			},
+			{
+				Labels: []prompb.Label{
+					{Name: b("__name__"), Value: b("http_request_duration_seconds_count")},
+				},
+				Samples: []prompb.Sample{{Value: 2, Timestamp: 2000}},
+				Type:    prompb.MetricType_HISTOGRAM,
+			},
+		},
+		// Metadata is deduplicated by metric family.
+		Metadata: []prompb.MetricMetadata{
+			{
+				Type:             prompb.MetricType_HISTOGRAM,
+				MetricFamilyName: "http_request_duration_seconds",
+				Help:             "Request latency.",
+				Unit:             "seconds",
+			},
+		},
+	}, result)
+
+	// Label names and values referencing the same symbol share bytes.
+	assert.Equal(t, &result.Timeseries[0].Labels[0].Name[0],
+		&result.Timeseries[1].Labels[0].Name[0])
+}
+
+func TestDecodeWriteV2RequestInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *writev2pb.Request
+	}{
+		{
+			name: "first symbol not empty",
+			req:  &writev2pb.Request{Symbols: []string{"__name__"}},
+		},
+		{
+			name: "odd labels refs",
+			req: &writev2pb.Request{
+				Symbols:    []string{"", "__name__"},
+				Timeseries: []writev2pb.TimeSeries{{LabelsRefs: []uint32{1}}},
+			},
+		},
+		{
+			name: "labels ref out of range",
+			req: &writev2pb.Request{
+				Symbols:    []string{"", "__name__"},
+				Timeseries: []writev2pb.TimeSeries{{LabelsRefs: []uint32{1, 2}}},
+			},
+		},
+		{
+			name: "help ref out of range",
+			req: &writev2pb.Request{
+				Symbols: []string{"", "__name__", "up"},
+				Timeseries: []writev2pb.TimeSeries{
+					{
+						LabelsRefs: []uint32{1, 2},
+						Metadata:   writev2pb.Metadata{HelpRef: 3},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := proto.Marshal(tt.req)
+			require.NoError(t, err)
+
+			_, err = decodeWriteV2Request(body, false, time.Now())
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPromWriteV2(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	mockDownsamplerAndWriter.
+		EXPECT().
+		WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	store, err := metricmetadata.NewStore(mem.NewStore(), metricmetadata.Options{})
+	require.NoError(t, err)
+	opts := makeOptions(mockDownsamplerAndWriter).SetMetricMetadataStore(store)
+
+	handler, err := NewPromWriteHandler(opts)
+	require.NoError(t, err)
+
+	req := &writev2pb.Request{
+		Symbols: []string{"", "__name__", "http_requests_total", "Total HTTP requests."},
+		Timeseries: []writev2pb.TimeSeries{
+			{
+				LabelsRefs: []uint32{1, 2},
+				Samples: []prompb.Sample{
+					{Value: 1, Timestamp: 1000},
+					{Value: 2, Timestamp: 2000},
+				},
+				// Exemplars are not written without an exemplar store.
+				Exemplars: []writev2pb.Exemplar{{Value: 1, Timestamp: 1000}},
+				Metadata: writev2pb.Metadata{
+					Type:    writev2pb.Metadata_METRIC_TYPE_COUNTER,
+					HelpRef: 3,
+				},
+			},
+		},
+	}
+	body := generateWriteV2RequestBody(t, req)
+	httpReq := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, body)
+	httpReq.Header.Set(xhttp.HeaderContentType, testWriteV2ContentType)
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, httpReq)
+	resp := writer.Result()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get(headers.PromRemoteWriteSamplesWrittenHeader))
+	assert.Equal(t, "0", resp.Header.Get(headers.PromRemoteWriteHistogramsWrittenHeader))
+	assert.Equal(t, "0", resp.Header.Get(headers.PromRemoteWriteExemplarsWrittenHeader))
+
+	results, err := store.Query("", -1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]prompb.MetricMetadata{
+		"http_requests_total": {
+			{
+				Type:             prompb.MetricType_COUNTER,
+				MetricFamilyName: "http_requests_total",
+				Help:             "Total HTTP requests.",
+			},
+		},
+	}, results)
+}
+
+func TestCreatedTimestampSeries(t *testing.T) {
+	labels := []prompb.Label{{Name: []byte("__name__"), Value: []byte("requests_total")}}
+	tests := []struct {
+		name             string
+		metricType       prompb.MetricType
+		createdTimestamp int64
+		expected         bool
+	}{
+		{name: "precedes first sample", metricType: prompb.MetricType_COUNTER,
+			createdTimestamp: 1500, expected: true},
+		{name: "not set", metricType: prompb.MetricType_COUNTER},
+		{name: "older than window", metricType: prompb.MetricType_COUNTER,
+			createdTimestamp: 500},
+		{name: "after first sample", metricType: prompb.MetricType_COUNTER,
+			createdTimestamp: 2500},
+		{name: "gauge", metricType: prompb.MetricType_GAUGE,
+			createdTimestamp: 1500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created, ok := createdTimestampSeries(prompb.TimeSeries{
+				Labels:  labels,
+				Samples: []prompb.Sample{{Value: 1, Timestamp: 2000}},
+				Type:    tt.metricType,
+			}, tt.createdTimestamp, 1000)
+			require.Equal(t, tt.expected, ok)
+			if ok {
+				assert.Equal(t, prompb.TimeSeries{
+					Labels:  labels,
+					Samples: []prompb.Sample{{Timestamp: tt.createdTimestamp}},
+					Type:    tt.metricType,
+				}, created)
+			}
+		})
+	}
+}
+
+func TestPromWriteV2CreatedTimestamps(t *testing.T) {
+	tests := []struct {
+		name           string
+		createdErr     error
+		expectedStatus int
+	}{
+		{
+			name:           "too old created timestamps are dropped",
+			createdErr:     xerrors.NewInvalidParamsError(errors.New("datapoint too far in past")),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "other errors fail the write",
+			createdErr:     errors.New("an error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := xtest.NewController(t)
+			defer ctrl.Finish()
+
+			now := time.Unix(3600, 0)
+			mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+			gomock.InOrder(
+				mockDownsamplerAndWriter.
+					EXPECT().
+					WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil),
+				mockDownsamplerAndWriter.
+					EXPECT().
+					WriteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(
+						_ interface{},
+						iter ingest.DownsampleAndWriteIter,
+						_ ingest.WriteOptions,
+					) ingest.BatchError {
+						require.True(t, iter.Next())
+						value := iter.Current()
+						assert.Equal(t, 1, len(value.Datapoints))
+						assert.Equal(t, float64(0), value.Datapoints[0].Value)
+						assert.False(t, iter.Next())
+						return xerrors.NewMultiError().Add(tt.createdErr)
+					}),
+			)
+
+			opts := makeOptions(mockDownsamplerAndWriter).
+				SetNowFn(func() time.Time { return now }).
+				SetIngestCreatedTimestamps(true)
+			handler, err := NewPromWriteHandler(opts)
+			require.NoError(t, err)
+
+			nowMillis := now.UnixNano() / int64(time.Millisecond)
+			req := &writev2pb.Request{
+				Symbols: []string{"", "__name__", "requests_total", "errors_total"},
+				Timeseries: []writev2pb.TimeSeries{
+					{
+						LabelsRefs:       []uint32{1, 2},
+						Samples:          []prompb.Sample{{Value: 1, Timestamp: nowMillis}},
+						Metadata:         writev2pb.Metadata{Type: writev2pb.Metadata_METRIC_TYPE_COUNTER},
+						CreatedTimestamp: nowMillis - 1000,
+					},
+					{
+						// Created timestamps outside of the window are not ingested.
+						LabelsRefs:       []uint32{1, 3},
+						Samples:          []prompb.Sample{{Value: 1, Timestamp: nowMillis}},
+						Metadata:         writev2pb.Metadata{Type: writev2pb.Metadata_METRIC_TYPE_COUNTER},
+						CreatedTimestamp: nowMillis - int64(time.Hour/time.Millisecond),
+					},
+				},
+			}
+			body := generateWriteV2RequestBody(t, req)
+			httpReq := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, body)
+			httpReq.Header.Set(xhttp.HeaderContentType, testWriteV2ContentType)
+
+			writer := httptest.NewRecorder()
+			handler.ServeHTTP(writer, httpReq)
+			assert.Equal(t, tt.expectedStatus, writer.Result().StatusCode)
+		})
+	}
+}
+
+func TestPromWriteUnsupportedProtoMsg(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	mockDownsamplerAndWriter := ingest.NewMockDownsamplerAndWriter(ctrl)
+	handler, err := NewPromWriteHandler(makeOptions(mockDownsamplerAndWriter))
+	require.NoError(t, err)
+
+	body := generateWriteV2RequestBody(t, &writev2pb.Request{})
+	httpReq := httptest.NewRequest(PromWriteHTTPMethod, PromWriteURL, body)
+	httpReq.Header.Set(xhttp.HeaderContentType,
+		"application/x-protobuf;proto=io.prometheus.write.v3.Request")
+
+	writer := httptest.NewRecorder()
+	handler.ServeHTTP(writer, httpReq)
+	resp := writer.Result()
+	require.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+}
+
+func generateWriteV2RequestBody(t *testing.T, req *writev2pb.Request) *bytes.Reader {
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+	return bytes.NewReader(snappy.Encode(nil, data))
+}
@@ -0,0 +1,323 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	xcache "github.com/m3db/m3/src/x/cache"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	// DefaultTTL is the default duration cached extents are kept for.
+	DefaultTTL = 7 * 24 * time.Hour
+
+	diskFileSuffix     = ".pb"
+	diskTempFilePrefix = "tmp-"
+)
+
+var (
+	errNoDiskPath          = errors.New("no results cache path")
+	errInvalidDiskMaxBytes = errors.New("results cache max bytes must be positive")
+)
+
+// Backend stores the series of cached extents.
+type Backend interface {
+	// Get returns the series of the extent, and false if it is not cached.
+	Get(key string) ([]prompb.TimeSeries, bool, error)
+
+	// Set stores the series of the extent.
+	Set(key string, series []prompb.TimeSeries) error
+}
+
+// MemoryBackendOptions are the options for the in-memory backend.
+type MemoryBackendOptions struct {
+	// MaxEntries is the maximum number of extents cached.
+	MaxEntries int
+	// TTL is the duration extents are cached for.
+	TTL time.Duration
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+type memoryBackend struct {
+	lru *xcache.LRU
+}
+
+// NewMemoryBackend returns a backend keeping the least recently used
+// extents in memory.
+func NewMemoryBackend(opts MemoryBackendOptions) Backend {
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+
+	return &memoryBackend{
+		lru: xcache.NewLRU(&xcache.LRUOptions{
+			TTL:        ttl,
+			MaxEntries: opts.MaxEntries,
+			Metrics:    iOpts.MetricsScope().SubScope("results-cache"),
+		}),
+	}
+}
+
+func (b *memoryBackend) Get(key string) ([]prompb.TimeSeries, bool, error) {
+	value, ok := b.lru.TryGet(key)
+	if !ok {
+		return nil, false, nil
+	}
+	return value.([]prompb.TimeSeries), true, nil
+}
+
+func (b *memoryBackend) Set(key string, series []prompb.TimeSeries) error {
+	b.lru.Put(key, series)
+	return nil
+}
+
+// DiskBackendOptions are the options for the local disk backend.
+type DiskBackendOptions struct {
+	// Path is the directory extents are stored in.
+	Path string
+	// MaxBytes is the maximum size of the extents stored, the oldest extents
+	// are removed first.
+	MaxBytes int64
+	// TTL is the duration extents are cached for.
+	TTL time.Duration
+	// NowFn is the function returning the current time.
+	NowFn clock.NowFn
+}
+
+type diskEntry struct {
+	name    string
+	size    int64
+	created time.Time
+}
+
+type diskBackend struct {
+	sync.Mutex
+
+	path     string
+	maxBytes int64
+	ttl      time.Duration
+	nowFn    clock.NowFn
+
+	// entries are ordered from oldest to newest.
+	entries *list.List
+	byName  map[string]*list.Element
+	size    int64
+}
+
+// NewDiskBackend returns a backend storing extents as files on local disk,
+// extents already stored in the directory are reused.
+func NewDiskBackend(opts DiskBackendOptions) (Backend, error) {
+	if opts.Path == "" {
+		return nil, errNoDiskPath
+	}
+	if opts.MaxBytes <= 0 {
+		return nil, errInvalidDiskMaxBytes
+	}
+
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = DefaultTTL
+	}
+	nowFn := opts.NowFn
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+
+	if err := os.MkdirAll(opts.Path, 0755); err != nil {
+		return nil, err
+	}
+
+	b := &diskBackend{
+		path:     opts.Path,
+		maxBytes: opts.MaxBytes,
+		ttl:      ttl,
+		nowFn:    nowFn,
+		entries:  list.New(),
+		byName:   make(map[string]*list.Element),
+	}
+	if err := b.load(); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (b *diskBackend) load() error {
+	files, err := ioutil.ReadDir(b.path)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		if strings.HasPrefix(f.Name(), diskTempFilePrefix) {
+			// Left behind by an interrupted write.
+			_ = os.Remove(filepath.Join(b.path, f.Name()))
+			continue
+		}
+		if !strings.HasSuffix(f.Name(), diskFileSuffix) {
+			continue
+		}
+		b.add(diskEntry{name: f.Name(), size: f.Size(), created: f.ModTime()})
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	return b.evictWithLock()
+}
+
+func diskFileName(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:]) + diskFileSuffix
+}
+
+func (b *diskBackend) Get(key string) ([]prompb.TimeSeries, bool, error) {
+	name := diskFileName(key)
+
+	b.Lock()
+	elem, ok := b.byName[name]
+	if ok && b.nowFn().Sub(elem.Value.(diskEntry).created) > b.ttl {
+		err := b.removeWithLock(elem)
+		b.Unlock()
+		return nil, false, err
+	}
+	b.Unlock()
+	if !ok {
+		return nil, false, nil
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(b.path, name))
+	if os.IsNotExist(err) {
+		// Removed concurrently.
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	var result prompb.QueryResult
+	if err := result.Unmarshal(data); err != nil {
+		return nil, false, fmt.Errorf("could not decode cached results %s: %w", name, err)
+	}
+	series := make([]prompb.TimeSeries, 0, len(result.Timeseries))
+	for _, s := range result.Timeseries {
+		series = append(series, *s)
+	}
+	return series, true, nil
+}
+
+func (b *diskBackend) Set(key string, series []prompb.TimeSeries) error {
+	result := prompb.QueryResult{
+		Timeseries: make([]*prompb.TimeSeries, 0, len(series)),
+	}
+	for i := range series {
+		result.Timeseries = append(result.Timeseries, &series[i])
+	}
+	data, err := result.Marshal()
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so that readers never observe a
+	// partially written extent.
+	f, err := ioutil.TempFile(b.path, diskTempFilePrefix)
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	name := diskFileName(key)
+	if err := os.Rename(tmp, filepath.Join(b.path, name)); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	if elem, ok := b.byName[name]; ok {
+		b.size -= elem.Value.(diskEntry).size
+		b.entries.Remove(elem)
+		delete(b.byName, name)
+	}
+	b.add(diskEntry{name: name, size: int64(len(data)), created: b.nowFn()})
+	return b.evictWithLock()
+}
+
+func (b *diskBackend) add(entry diskEntry) {
+	b.byName[entry.name] = b.entries.PushBack(entry)
+	b.size += entry.size
+}
+
+func (b *diskBackend) evictWithLock() error {
+	for b.size > b.maxBytes && b.entries.Len() > 0 {
+		if err := b.removeWithLock(b.entries.Front()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *diskBackend) removeWithLock(elem *list.Element) error {
+	entry := elem.Value.(diskEntry)
+	b.entries.Remove(elem)
+	delete(b.byName, entry.name)
+	b.size -= entry.size
+
+	err := os.Remove(filepath.Join(b.path, entry.name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+)
+
+func testSeries(value float64) []prompb.TimeSeries {
+	return []prompb.TimeSeries{
+		{
+			Labels:  testLabels,
+			Samples: []prompb.Sample{{Timestamp: 1000, Value: value}},
+		},
+	}
+}
+
+func TestMemoryBackend(t *testing.T) {
+	b := NewMemoryBackend(MemoryBackendOptions{})
+
+	_, ok, err := b.Get("a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, b.Set("a", testSeries(1)))
+	series, ok, err := b.Get("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, testSeries(1), series)
+}
+
+func TestDiskBackend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resultscache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	now := time.Now()
+	opts := DiskBackendOptions{
+		Path:     dir,
+		MaxBytes: 1 << 20,
+		TTL:      time.Hour,
+		NowFn:    func() time.Time { return now },
+	}
+	b, err := NewDiskBackend(opts)
+	require.NoError(t, err)
+
+	_, ok, err := b.Get("a")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, b.Set("a", testSeries(1)))
+	require.NoError(t, b.Set("b", testSeries(2)))
+	series, ok, err := b.Get("a")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, testSeries(1), series)
+
+	// Extents are reused when reopening the directory.
+	b, err = NewDiskBackend(opts)
+	require.NoError(t, err)
+	series, ok, err = b.Get("b")
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, testSeries(2), series)
+
+	// Expired extents are removed.
+	now = now.Add(2 * time.Hour)
+	_, ok, err = b.Get("b")
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestDiskBackendEviction(t *testing.T) {
+	dir, err := ioutil.TempDir("", "resultscache")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	data, err := (&prompb.QueryResult{
+		Timeseries: []*prompb.TimeSeries{&testSeries(1)[0]},
+	}).Marshal()
+	require.NoError(t, err)
+
+	// Only room for two extents.
+	b, err := NewDiskBackend(DiskBackendOptions{
+		Path:     dir,
+		MaxBytes: int64(2 * len(data)),
+	})
+	require.NoError(t, err)
+
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, b.Set(key, testSeries(1)))
+	}
+
+	_, ok, err := b.Get("a")
+	require.NoError(t, err)
+	assert.False(t, ok, "oldest extent should be evicted")
+	for _, key := range []string{"b", "c"} {
+		_, ok, err := b.Get(key)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
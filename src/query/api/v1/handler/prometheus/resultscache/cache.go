@@ -0,0 +1,398 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package resultscache provides a step aligned cache of range query results.
+//
+// Range queries are split into extents by a fixed interval, a day by default,
+// and the extents which are old enough to no longer change are cached so that
+// repeated queries, such as dashboard refreshes, only execute the newest
+// extents.
+package resultscache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	pql "github.com/prometheus/prometheus/promql/parser"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/parser/promql/promparser"
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// DefaultSplitInterval is the default interval range queries are split by.
+	DefaultSplitInterval = 24 * time.Hour
+	// DefaultMaxFreshness is the default age below which results are not
+	// cached since they may still change.
+	DefaultMaxFreshness = 10 * time.Minute
+)
+
+var (
+	errInvalidSplitInterval = errors.New("split interval must be positive")
+	errInvalidMaxFreshness  = errors.New("max freshness must not be negative")
+	errNoBackend            = errors.New("no results cache backend")
+)
+
+// Cache caches the results of range queries.
+type Cache interface {
+	// Query returns the results of the range query, executing the query only
+	// for the extents of the range which are not cached.
+	Query(ctx context.Context, req Request, fn ExtentFn) (Result, error)
+}
+
+// Request is a range query served through the cache.
+type Request struct {
+	// Key identifies the query and any options other than the time range
+	// affecting its results.
+	Key string
+	// Start is the first step of the query.
+	Start xtime.UnixNano
+	// End is the last step of the query, inclusive.
+	End xtime.UnixNano
+	// Step is the query resolution.
+	Step time.Duration
+}
+
+// Result is the result of a range query.
+type Result struct {
+	// Series are the resulting series.
+	Series []prompb.TimeSeries
+	// Meta is the metadata of the executed queries, the results of a query
+	// are only cached if it is exhaustive and without warnings.
+	Meta block.ResultMetadata
+}
+
+// ExtentFn executes the query for the steps between start and end inclusive.
+type ExtentFn func(ctx context.Context, start, end xtime.UnixNano) (Result, error)
+
+// Options are the options for the results cache.
+type Options struct {
+	// SplitInterval is the interval range queries are split by.
+	SplitInterval time.Duration
+	// MaxFreshness is how recent the end of an extent must be for it to not
+	// be cached.
+	MaxFreshness time.Duration
+	// NowFn is the function returning the current time.
+	NowFn clock.NowFn
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+type cacheMetrics struct {
+	hits          tally.Counter
+	misses        tally.Counter
+	backendErrors tally.Counter
+}
+
+func newCacheMetrics(scope tally.Scope) cacheMetrics {
+	return cacheMetrics{
+		hits:          scope.Counter("hits"),
+		misses:        scope.Counter("misses"),
+		backendErrors: scope.Counter("backend-errors"),
+	}
+}
+
+type cache struct {
+	backend       Backend
+	splitInterval time.Duration
+	maxFreshness  time.Duration
+	nowFn         clock.NowFn
+	logger        *zap.Logger
+	metrics       cacheMetrics
+}
+
+// NewCache returns a new results cache storing extents in the backend.
+func NewCache(backend Backend, opts Options) (Cache, error) {
+	if backend == nil {
+		return nil, errNoBackend
+	}
+
+	splitInterval := opts.SplitInterval
+	if splitInterval == 0 {
+		splitInterval = DefaultSplitInterval
+	}
+	if splitInterval < 0 {
+		return nil, errInvalidSplitInterval
+	}
+	if opts.MaxFreshness < 0 {
+		return nil, errInvalidMaxFreshness
+	}
+
+	nowFn := opts.NowFn
+	if nowFn == nil {
+		nowFn = time.Now
+	}
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+
+	return &cache{
+		backend:       backend,
+		splitInterval: splitInterval,
+		maxFreshness:  opts.MaxFreshness,
+		nowFn:         nowFn,
+		logger:        iOpts.Logger(),
+		metrics:       newCacheMetrics(iOpts.MetricsScope().SubScope("results-cache")),
+	}, nil
+}
+
+// Cacheable returns whether the results of a query can be cached per extent,
+// which is not the case for queries using the start() or end() @ modifiers
+// since their results depend on the range queried.
+func Cacheable(query string) bool {
+	expr, err := promparser.ParseExpr(query)
+	if err != nil {
+		return false
+	}
+
+	cacheable := true
+	pql.Inspect(expr, func(node pql.Node, _ []pql.Node) error {
+		switch n := node.(type) {
+		case *pql.VectorSelector:
+			if n.StartOrEnd != 0 {
+				cacheable = false
+			}
+		case *pql.SubqueryExpr:
+			if n.StartOrEnd != 0 {
+				cacheable = false
+			}
+		}
+		return nil
+	})
+	return cacheable
+}
+
+type extent struct {
+	start     xtime.UnixNano
+	end       xtime.UnixNano
+	key       string
+	cacheable bool
+}
+
+// extents splits the request into extents by the split interval. Extents
+// which are old enough to be cached cover their whole interval, irrespective
+// of the requested range, so that they can be reused by later queries.
+func (c *cache) extents(req Request) []extent {
+	var (
+		step     = xtime.UnixNano(req.Step)
+		offset   = req.Start % step
+		interval = c.splitInterval
+		cutoff   = xtime.ToUnixNano(c.nowFn()).Add(-c.maxFreshness)
+		extents  []extent
+	)
+	for from := req.Start.Truncate(interval); from <= req.End; from = from.Add(interval) {
+		to := from.Add(interval)
+		e := extent{
+			start: alignUp(from, offset, step),
+			end:   alignUp(to, offset, step) - step,
+		}
+		if e.start > e.end {
+			// No steps within this interval.
+			continue
+		}
+
+		if !to.After(cutoff) {
+			e.cacheable = true
+			e.key = fmt.Sprintf("%s:%d:%d:%d", req.Key, req.Step, e.start, e.end)
+		} else {
+			if e.start < req.Start {
+				e.start = req.Start
+			}
+			if e.end > req.End {
+				e.end = req.End
+			}
+		}
+
+		extents = append(extents, e)
+	}
+
+	return extents
+}
+
+// alignUp returns the first time at or after t which is offset from a
+// multiple of step.
+func alignUp(t, offset, step xtime.UnixNano) xtime.UnixNano {
+	rem := (t - offset) % step
+	if rem < 0 {
+		rem += step
+	}
+	if rem == 0 {
+		return t
+	}
+	return t + step - rem
+}
+
+func (c *cache) Query(ctx context.Context, req Request, fn ExtentFn) (Result, error) {
+	if req.Step <= 0 || req.Step >= c.splitInterval || req.End < req.Start {
+		// Nothing to gain from splitting the query.
+		return fn(ctx, req.Start, req.End)
+	}
+
+	// Align the end to the last step of the query.
+	step := xtime.UnixNano(req.Step)
+	req.End = req.Start + (req.End-req.Start)/step*step
+
+	var (
+		extents = c.extents(req)
+		results = make([][]prompb.TimeSeries, len(extents))
+		found   = make([]bool, len(extents))
+		meta    = block.NewResultMetadata()
+	)
+	for i, e := range extents {
+		if !e.cacheable {
+			continue
+		}
+
+		series, ok, err := c.backend.Get(e.key)
+		if err != nil {
+			c.metrics.backendErrors.Inc(1)
+			c.logger.Warn("could not get cached results", zap.Error(err))
+		}
+		if !ok {
+			c.metrics.misses.Inc(1)
+			continue
+		}
+
+		c.metrics.hits.Inc(1)
+		results[i] = series
+		found[i] = true
+	}
+
+	// Execute the query once for every run of consecutive extents which are
+	// not cached.
+	for i := 0; i < len(extents); i++ {
+		if found[i] {
+			continue
+		}
+
+		j := i
+		for j+1 < len(extents) && !found[j+1] {
+			j++
+		}
+
+		res, err := fn(ctx, extents[i].start, extents[j].end)
+		if err != nil {
+			return Result{}, err
+		}
+		meta = meta.CombineMetadata(res.Meta)
+
+		cacheable := res.Meta.Exhaustive && len(res.Meta.Warnings) == 0
+		for k, series := range splitSeries(res.Series, extents[i:j+1]) {
+			e := extents[i+k]
+			results[i+k] = series
+			if !cacheable || !e.cacheable {
+				continue
+			}
+
+			if err := c.backend.Set(e.key, series); err != nil {
+				c.metrics.backendErrors.Inc(1)
+				c.logger.Warn("could not cache results", zap.Error(err))
+			}
+		}
+
+		i = j
+	}
+
+	return Result{
+		Series: mergeSeries(results, req.Start, req.End),
+		Meta:   meta,
+	}, nil
+}
+
+// splitSeries splits the series by the extents their samples fall within.
+func splitSeries(series []prompb.TimeSeries, extents []extent) [][]prompb.TimeSeries {
+	results := make([][]prompb.TimeSeries, len(extents))
+	for _, s := range series {
+		var (
+			samples = s.Samples
+			idx     int
+		)
+		for i, e := range extents {
+			start := idx
+			for idx < len(samples) && sampleTime(samples[idx]) <= e.end {
+				idx++
+			}
+			// NB: series are kept in every extent, even without samples, so
+			// that series without any datapoints are still returned.
+			results[i] = append(results[i], prompb.TimeSeries{
+				Labels:  s.Labels,
+				Samples: samples[start:idx:idx],
+			})
+		}
+	}
+	return results
+}
+
+// mergeSeries merges the series of consecutive extents, keeping only the
+// samples between start and end.
+func mergeSeries(
+	results [][]prompb.TimeSeries,
+	start, end xtime.UnixNano,
+) []prompb.TimeSeries {
+	var (
+		merged  []prompb.TimeSeries
+		indexes = make(map[string]int)
+	)
+	for _, series := range results {
+		for _, s := range series {
+			id := seriesID(s.Labels)
+			idx, ok := indexes[id]
+			if !ok {
+				idx = len(merged)
+				indexes[id] = idx
+				merged = append(merged, prompb.TimeSeries{Labels: s.Labels})
+			}
+
+			for _, sample := range s.Samples {
+				t := sampleTime(sample)
+				if t < start || t > end {
+					continue
+				}
+				merged[idx].Samples = append(merged[idx].Samples, sample)
+			}
+		}
+	}
+	return merged
+}
+
+func seriesID(labels []prompb.Label) string {
+	var b strings.Builder
+	for _, l := range labels {
+		b.Write(l.Name)
+		b.WriteByte(0xff)
+		b.Write(l.Value)
+		b.WriteByte(0xff)
+	}
+	return b.String()
+}
+
+func sampleTime(s prompb.Sample) xtime.UnixNano {
+	return xtime.UnixNano(s.Timestamp * int64(time.Millisecond))
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var testLabels = []prompb.Label{{Name: []byte("__name__"), Value: []byte("up")}}
+
+type extentCall struct {
+	start xtime.UnixNano
+	end   xtime.UnixNano
+}
+
+type testQuerier struct {
+	step  time.Duration
+	meta  block.ResultMetadata
+	calls []extentCall
+}
+
+func newTestQuerier(step time.Duration) *testQuerier {
+	return &testQuerier{step: step, meta: block.NewResultMetadata()}
+}
+
+// query returns a series with a sample at every step, valued by the
+// timestamp in seconds.
+func (q *testQuerier) query(
+	_ context.Context,
+	start, end xtime.UnixNano,
+) (Result, error) {
+	q.calls = append(q.calls, extentCall{start: start, end: end})
+	return Result{
+		Series: []prompb.TimeSeries{{Labels: testLabels, Samples: samples(start, end, q.step)}},
+		Meta:   q.meta,
+	}, nil
+}
+
+func samples(start, end xtime.UnixNano, step time.Duration) []prompb.Sample {
+	var result []prompb.Sample
+	for t := start; t <= end; t = t.Add(step) {
+		result = append(result, prompb.Sample{
+			Timestamp: int64(t) / int64(time.Millisecond),
+			Value:     float64(t.Seconds()),
+		})
+	}
+	return result
+}
+
+func newTestCache(t *testing.T, now xtime.UnixNano) Cache {
+	c, err := NewCache(NewMemoryBackend(MemoryBackendOptions{}), Options{
+		MaxFreshness: 10 * time.Minute,
+		NowFn:        now.ToTime,
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func TestCacheQuery(t *testing.T) {
+	var (
+		day   = xtime.UnixNano(24 * time.Hour)
+		now   = 3*day + xtime.UnixNano(12*time.Hour)
+		step  = time.Hour
+		cache = newTestCache(t, now)
+		q     = newTestQuerier(step)
+		req   = Request{
+			Key:   "up",
+			Start: day.Add(6 * time.Hour),
+			End:   3*day + xtime.UnixNano(11*time.Hour),
+			Step:  step,
+		}
+		expected = []prompb.TimeSeries{
+			{Labels: testLabels, Samples: samples(req.Start, req.End, step)},
+		}
+	)
+
+	res, err := cache.Query(context.Background(), req, q.query)
+	require.NoError(t, err)
+	assert.Equal(t, expected, res.Series)
+	// Extents which can be cached are queried in full.
+	assert.Equal(t, []extentCall{{start: day, end: req.End}}, q.calls)
+
+	q.calls = nil
+	res, err = cache.Query(context.Background(), req, q.query)
+	require.NoError(t, err)
+	assert.Equal(t, expected, res.Series)
+	// Only the newest extent is queried once the others are cached.
+	assert.Equal(t, []extentCall{{start: 3 * day, end: req.End}}, q.calls)
+
+	// Queries over a subset of the cached range are served from the cache.
+	q.calls = nil
+	req.Start = 2*day + xtime.UnixNano(time.Hour)
+	req.End = 2*day + xtime.UnixNano(3*time.Hour)
+	res, err = cache.Query(context.Background(), req, q.query)
+	require.NoError(t, err)
+	assert.Equal(t, []prompb.TimeSeries{
+		{Labels: testLabels, Samples: samples(req.Start, req.End, step)},
+	}, res.Series)
+	assert.Empty(t, q.calls)
+}
+
+func TestCacheQueryUnalignedStep(t *testing.T) {
+	var (
+		day   = xtime.UnixNano(24 * time.Hour)
+		now   = 2*day + xtime.UnixNano(time.Hour)
+		step  = 7 * time.Minute
+		cache = newTestCache(t, now)
+		q     = newTestQuerier(step)
+		req   = Request{
+			Key:   "up",
+			Start: xtime.UnixNano(3 * time.Minute),
+			End:   2*day + xtime.UnixNano(30*time.Minute),
+			Step:  step,
+		}
+	)
+
+	for i := 0; i < 2; i++ {
+		res, err := cache.Query(context.Background(), req, q.query)
+		require.NoError(t, err)
+		require.Len(t, res.Series, 1)
+
+		// Every step of the query is returned exactly once.
+		actual := res.Series[0].Samples
+		expected := samples(req.Start, req.End, step)
+		require.Equal(t, len(expected), len(actual))
+		assert.Equal(t, expected, actual)
+	}
+}
+
+func TestCacheQueryNotExhaustive(t *testing.T) {
+	var (
+		day   = xtime.UnixNano(24 * time.Hour)
+		cache = newTestCache(t, 3*day)
+		q     = newTestQuerier(time.Hour)
+		req   = Request{Key: "up", Start: 0, End: 2 * day, Step: time.Hour}
+	)
+	q.meta.Exhaustive = false
+
+	for i := 0; i < 2; i++ {
+		_, err := cache.Query(context.Background(), req, q.query)
+		require.NoError(t, err)
+	}
+
+	// Results which are not exhaustive are never cached.
+	assert.Equal(t, []extentCall{
+		{start: 0, end: 2 * day},
+		{start: 0, end: 2 * day},
+	}, q.calls)
+}
+
+func TestCacheable(t *testing.T) {
+	tests := []struct {
+		query     string
+		cacheable bool
+	}{
+		{query: "up", cacheable: true},
+		{query: "rate(up[5m] @ 100)", cacheable: true},
+		{query: "up @ end()", cacheable: false},
+		{query: "rate(up[5m] @ start())", cacheable: false},
+		{query: "max_over_time(up[1h:5m] @ end())", cacheable: false},
+		{query: "sum(", cacheable: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			assert.Equal(t, tt.cacheable, Cacheable(tt.query))
+		})
+	}
+}
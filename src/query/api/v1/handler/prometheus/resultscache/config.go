@@ -0,0 +1,110 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package resultscache
+
+import (
+	"time"
+
+	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+// Configuration is the configuration for the range query results cache.
+type Configuration struct {
+	// Enabled enables caching of range query results.
+	Enabled bool `yaml:"enabled"`
+
+	// SplitInterval is the interval range queries are split by, only extents
+	// covering a whole interval are cached.
+	SplitInterval time.Duration `yaml:"splitInterval"`
+
+	// MaxFreshness is how recent the end of an extent must be for it to not
+	// be cached, it should be at least as long as writes can be delayed by.
+	MaxFreshness *time.Duration `yaml:"maxFreshness"`
+
+	// TTL is the duration extents are cached for.
+	TTL time.Duration `yaml:"ttl"`
+
+	// Memory configures the in-memory backend, used unless Disk is set.
+	Memory MemoryConfiguration `yaml:"memory"`
+
+	// Disk configures the local disk backend.
+	Disk *DiskConfiguration `yaml:"disk"`
+}
+
+// MemoryConfiguration is the configuration for the in-memory backend.
+type MemoryConfiguration struct {
+	// MaxEntries is the maximum number of extents cached.
+	MaxEntries int `yaml:"maxEntries"`
+}
+
+// DiskConfiguration is the configuration for the local disk backend.
+type DiskConfiguration struct {
+	// Path is the directory extents are stored in.
+	Path string `yaml:"path" validate:"nonzero"`
+
+	// MaxBytes is the maximum size of the extents stored.
+	MaxBytes int64 `yaml:"maxBytes" validate:"min=1"`
+}
+
+// NewCache returns the configured results cache, or nil if it is disabled.
+func (c Configuration) NewCache(
+	nowFn clock.NowFn,
+	iOpts instrument.Options,
+) (Cache, error) {
+	if !c.Enabled {
+		return nil, nil
+	}
+
+	var (
+		backend Backend
+		err     error
+	)
+	if c.Disk != nil {
+		backend, err = NewDiskBackend(DiskBackendOptions{
+			Path:     c.Disk.Path,
+			MaxBytes: c.Disk.MaxBytes,
+			TTL:      c.TTL,
+			NowFn:    nowFn,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		backend = NewMemoryBackend(MemoryBackendOptions{
+			MaxEntries:        c.Memory.MaxEntries,
+			TTL:               c.TTL,
+			InstrumentOptions: iOpts,
+		})
+	}
+
+	maxFreshness := DefaultMaxFreshness
+	if c.MaxFreshness != nil {
+		maxFreshness = *c.MaxFreshness
+	}
+
+	return NewCache(backend, Options{
+		SplitInterval:     c.SplitInterval,
+		MaxFreshness:      maxFreshness,
+		NowFn:             nowFn,
+		InstrumentOptions: iOpts,
+	})
+}
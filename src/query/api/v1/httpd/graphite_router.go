@@ -23,6 +23,7 @@ package httpd
 import (
 	"net/http"
 
+	"github.com/m3db/m3/src/query/api/v1/handler/graphite"
 	"github.com/m3db/m3/src/query/api/v1/options"
 )
 
@@ -59,3 +60,35 @@ func (r *findRouter) Setup(opts options.GraphiteFindRouterOptions) {
 func (r *findRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	r.findHandler(w, req)
 }
+
+type tagsRouter struct {
+	tagsHandler               func(http.ResponseWriter, *http.Request)
+	autoCompleteTagsHandler   func(http.ResponseWriter, *http.Request)
+	autoCompleteValuesHandler func(http.ResponseWriter, *http.Request)
+	findSeriesHandler         func(http.ResponseWriter, *http.Request)
+}
+
+// NewGraphiteTagsRouter returns a new graphite TagDB router.
+func NewGraphiteTagsRouter() options.GraphiteTagsRouter {
+	return &tagsRouter{}
+}
+
+func (r *tagsRouter) Setup(opts options.GraphiteTagsRouterOptions) {
+	r.tagsHandler = opts.TagsHandler
+	r.autoCompleteTagsHandler = opts.AutoCompleteTagsHandler
+	r.autoCompleteValuesHandler = opts.AutoCompleteValuesHandler
+	r.findSeriesHandler = opts.FindSeriesHandler
+}
+
+func (r *tagsRouter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	switch req.URL.Path {
+	case graphite.TagsAutoCompleteTagsURL:
+		r.autoCompleteTagsHandler(w, req)
+	case graphite.TagsAutoCompleteValuesURL:
+		r.autoCompleteValuesHandler(w, req)
+	case graphite.TagsFindSeriesURL:
+		r.findSeriesHandler(w, req)
+	default:
+		r.tagsHandler(w, req)
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/query/api/v1/handler/graphite"
 	"github.com/m3db/m3/src/query/api/v1/options"
 )
 
@@ -67,3 +68,39 @@ func TestGraphiteFindHandler(t *testing.T) {
 	router.ServeHTTP(rr, req)
 	assert.Equal(t, 1, called)
 }
+
+func TestGraphiteTagsHandler(t *testing.T) {
+	called := make(map[string]int)
+	handler := func(name string) func(http.ResponseWriter, *http.Request) {
+		return func(w http.ResponseWriter, req *http.Request) {
+			called[name]++
+		}
+	}
+
+	router := NewGraphiteTagsRouter()
+	router.Setup(options.GraphiteTagsRouterOptions{
+		TagsHandler:               handler("tags"),
+		AutoCompleteTagsHandler:   handler("autoCompleteTags"),
+		AutoCompleteValuesHandler: handler("autoCompleteValues"),
+		FindSeriesHandler:         handler("findSeries"),
+	})
+
+	for _, url := range []string{
+		graphite.TagsURL,
+		graphite.TagsAutoCompleteTagsURL + "?tagPrefix=d",
+		graphite.TagsAutoCompleteValuesURL + "?tag=dc",
+		graphite.TagsFindSeriesURL + "?expr=name=foo",
+	} {
+		rr := httptest.NewRecorder()
+		req, err := http.NewRequestWithContext(context.Background(), "GET", url, nil)
+		require.NoError(t, err)
+		router.ServeHTTP(rr, req)
+	}
+
+	assert.Equal(t, map[string]int{
+		"tags":               1,
+		"autoCompleteTags":   1,
+		"autoCompleteValues": 1,
+		"findSeries":         1,
+	}, called)
+}
@@ -42,6 +42,7 @@ import (
 	m3json "github.com/m3db/m3/src/query/api/v1/handler/json"
 	"github.com/m3db/m3/src/query/api/v1/handler/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/openapi"
+	"github.com/m3db/m3/src/query/api/v1/handler/otlp"
 	"github.com/m3db/m3/src/query/api/v1/handler/prom"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/native"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/remote"
@@ -138,6 +139,10 @@ func (h *Handler) RegisterRoutes() error {
 	if err != nil {
 		return err
 	}
+	otlpWriteHandler, err := otlp.NewWriteHandler(h.options)
+	if err != nil {
+		return err
+	}
 
 	nativeSourceOpts := h.options.SetInstrumentOpts(instrumentOpts.
 		SetMetricsScope(instrumentOpts.MetricsScope().
@@ -253,6 +258,28 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// InfluxDB 2.x write endpoint.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    influxdb.InfluxV2WriteURL,
+		Handler: influxdb.NewInfluxV2WriterHandler(h.options),
+		Methods: methods(influxdb.InfluxV2WriteHTTPMethod),
+		// Register with no response logging for write calls since so frequent.
+		MiddlewareOverride: middleware.WithNoResponseLogging,
+	}); err != nil {
+		return err
+	}
+
+	// OpenTelemetry metrics write endpoint.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    otlp.WriteURL,
+		Handler: otlpWriteHandler,
+		Methods: methods(otlp.WriteHTTPMethod),
+		// Register with no response logging for write calls since so frequent.
+		MiddlewareOverride: middleware.WithNoResponseLogging,
+	}); err != nil {
+		return err
+	}
+
 	// Native M3 search and write endpoints.
 	if err := h.registry.Register(queryhttp.RegisterOptions{
 		Path:    handler.SearchURL,
@@ -306,6 +333,60 @@ func (h *Handler) RegisterRoutes() error {
 		return err
 	}
 
+	// Metric metadata endpoints.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:               native.MetricMetadataURL,
+		Handler:            native.NewMetricMetadataHandler(h.options),
+		Methods:            native.MetricMetadataHTTPMethods,
+		MiddlewareOverride: native.WithQueryParams,
+	}); err != nil {
+		return err
+	}
+
+	// Scrape targets endpoints.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.TargetsURL,
+		Handler: native.NewTargetsHandler(h.options),
+		Methods: native.TargetsHTTPMethods,
+	}); err != nil {
+		return err
+	}
+
+	// Series deletion endpoints.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    native.DeleteSeriesURL,
+		Handler: native.NewDeleteSeriesHandler(h.options),
+		Methods: native.DeleteSeriesHTTPMethods,
+	}); err != nil {
+		return err
+	}
+
+	// Namespace backup and restore endpoints.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    database.BackupURL,
+		Handler: database.NewBackupHandler(h.options),
+		Methods: methods(database.BackupHTTPMethod),
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    database.RestoreURL,
+		Handler: database.NewRestoreHandler(h.options),
+		Methods: methods(database.RestoreHTTPMethod),
+	}); err != nil {
+		return err
+	}
+
+	// Exemplar endpoints.
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:               native.QueryExemplarsURL,
+		Handler:            native.NewQueryExemplarsHandler(h.options),
+		Methods:            native.QueryExemplarsHTTPMethods,
+		MiddlewareOverride: native.WithQueryParams,
+	}); err != nil {
+		return err
+	}
+
 	// Query parse endpoints.
 	if err := h.registry.Register(queryhttp.RegisterOptions{
 		Path:    native.PromParseURL,
@@ -353,6 +434,64 @@ func (h *Handler) RegisterRoutes() error {
 	}); err != nil {
 		return err
 	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    graphite.ExpandURL,
+		Handler: graphite.NewExpandHandler(h.options),
+		Methods: graphite.ExpandHTTPMethods,
+	}); err != nil {
+		return err
+	}
+	if err := h.registry.Register(queryhttp.RegisterOptions{
+		Path:    graphite.IndexJSONURL,
+		Handler: graphite.NewIndexJSONHandler(h.options),
+		Methods: graphite.IndexJSONHTTPMethods,
+	}); err != nil {
+		return err
+	}
+
+	// Graphite TagDB endpoints.
+	graphiteTagsRouter := h.options.GraphiteTagsRouter()
+	if graphiteTagsRouter == nil {
+		graphiteTagsRouter = NewGraphiteTagsRouter()
+	}
+	graphiteTagsRouter.Setup(options.GraphiteTagsRouterOptions{
+		TagsHandler:               graphite.NewTagsHandler(h.options).ServeHTTP,
+		AutoCompleteTagsHandler:   graphite.NewAutoCompleteTagsHandler(h.options).ServeHTTP,
+		AutoCompleteValuesHandler: graphite.NewAutoCompleteValuesHandler(h.options).ServeHTTP,
+		FindSeriesHandler:         graphite.NewFindSeriesHandler(h.options).ServeHTTP,
+	})
+	for _, path := range []string{
+		graphite.TagsURL,
+		graphite.TagsAutoCompleteTagsURL,
+		graphite.TagsAutoCompleteValuesURL,
+		graphite.TagsFindSeriesURL,
+	} {
+		if err := h.registry.Register(queryhttp.RegisterOptions{
+			Path:    path,
+			Handler: graphiteTagsRouter,
+			Methods: graphite.TagsHTTPMethods,
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Graphite events endpoints.
+	if h.options.GraphiteEventsStore() != nil {
+		if err := h.registry.Register(queryhttp.RegisterOptions{
+			Path:    graphite.EventsURL,
+			Handler: graphite.NewEventsHandler(h.options),
+			Methods: graphite.EventsHTTPMethods,
+		}); err != nil {
+			return err
+		}
+		if err := h.registry.Register(queryhttp.RegisterOptions{
+			Path:    graphite.EventsGetDataURL,
+			Handler: graphite.NewEventsGetDataHandler(h.options),
+			Methods: graphite.EventsGetDataHTTPMethods,
+		}); err != nil {
+			return err
+		}
+	}
 
 	placementOpts, err := h.placementOpts()
 	if err != nil {
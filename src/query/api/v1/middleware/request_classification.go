@@ -33,6 +33,7 @@ import (
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/api/v1/route"
+	"github.com/m3db/m3/src/query/parser/promql/promparser"
 	"github.com/m3db/m3/src/x/headers"
 )
 
@@ -207,7 +208,7 @@ func classifyForQueryEndpoints(
 
 	durationBuckets := cfg.DurationBuckets
 	if len(durationBuckets) > 0 {
-		expr, err := parser.ParseExpr(params.Query)
+		expr, err := promparser.ParseExpr(params.Query)
 		if err != nil {
 			return newClassificationTags(), err
 		}
@@ -37,6 +37,7 @@ import (
 
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/parser/promql/promparser"
 	"github.com/m3db/m3/src/query/storage"
 	xhttp "github.com/m3db/m3/src/x/net/http"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -159,7 +160,7 @@ func RewriteRangeDuration(
 	}
 
 	// parse the query so that we can manipulate it
-	expr, err := parser.ParseExpr(params.query)
+	expr, err := promparser.ParseExpr(params.query)
 	if err != nil {
 		return err
 	}
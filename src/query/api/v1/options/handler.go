@@ -22,6 +22,7 @@
 package options
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -31,22 +32,30 @@ import (
 	"google.golang.org/protobuf/runtime/protoiface"
 
 	clusterclient "github.com/m3db/m3/src/cluster/client"
+	"github.com/m3db/m3/src/cluster/kv/mem"
 	placementhandleroptions "github.com/m3db/m3/src/cluster/placementhandler/handleroptions"
 	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest"
+	"github.com/m3db/m3/src/cmd/services/m3coordinator/ingest/scrape"
 	dbconfig "github.com/m3db/m3/src/cmd/services/m3dbnode/config"
 	"github.com/m3db/m3/src/cmd/services/m3query/config"
+	"github.com/m3db/m3/src/dbnode/client"
 	"github.com/m3db/m3/src/dbnode/encoding"
 	dbnamespace "github.com/m3db/m3/src/dbnode/namespace"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/handleroptions"
+	"github.com/m3db/m3/src/query/api/v1/handler/prometheus/resultscache"
 	"github.com/m3db/m3/src/query/api/v1/middleware"
 	"github.com/m3db/m3/src/query/api/v1/validators"
 	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/graphite/events"
 	graphite "github.com/m3db/m3/src/query/graphite/storage"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/exemplar"
 	"github.com/m3db/m3/src/query/storage/m3"
+	"github.com/m3db/m3/src/query/storage/metricmetadata"
 	"github.com/m3db/m3/src/query/ts"
 	"github.com/m3db/m3/src/x/clock"
+	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 )
 
@@ -60,6 +69,11 @@ const (
 	M3QueryEngine QueryEngine = "m3query"
 )
 
+var (
+	errNoClusters                    = errors.New("no clusters to store exemplars in")
+	errUnaggregatedNamespaceNotReady = errors.New("unaggregated namespace is not yet initialized")
+)
+
 // PromQLEngineFn constructs promql.Engine with the given lookbackDuration. promql.Engine uses
 // a fixed lookback, so we have to create multiple engines for different lookback values.
 //
@@ -128,6 +142,20 @@ type GraphiteFindRouterOptions struct {
 	FindHandler func(http.ResponseWriter, *http.Request)
 }
 
+// GraphiteTagsRouter is responsible for routing graphite TagDB queries.
+type GraphiteTagsRouter interface {
+	Setup(opts GraphiteTagsRouterOptions)
+	ServeHTTP(w http.ResponseWriter, req *http.Request)
+}
+
+// GraphiteTagsRouterOptions defines options for the graphite TagDB router.
+type GraphiteTagsRouterOptions struct {
+	TagsHandler               func(http.ResponseWriter, *http.Request)
+	AutoCompleteTagsHandler   func(http.ResponseWriter, *http.Request)
+	AutoCompleteValuesHandler func(http.ResponseWriter, *http.Request)
+	FindSeriesHandler         func(http.ResponseWriter, *http.Request)
+}
+
 // RemoteReadRenderer renders remote read output.
 type RemoteReadRenderer func(io.Writer, []*ts.Series,
 	models.RequestParams, bool)
@@ -254,6 +282,23 @@ type HandlerOptions interface {
 	// SetGraphiteFindRouter sets the graphite find router.
 	SetGraphiteFindRouter(value GraphiteFindRouter) HandlerOptions
 
+	// GraphiteTagsRouter is a reference to the router for graphite TagDB queries.
+	GraphiteTagsRouter() GraphiteTagsRouter
+	// SetGraphiteTagsRouter sets the graphite TagDB router.
+	SetGraphiteTagsRouter(value GraphiteTagsRouter) HandlerOptions
+
+	// GraphiteEventsStore returns the graphite events store, nil if
+	// graphite events are not enabled.
+	GraphiteEventsStore() events.Store
+	// SetGraphiteEventsStore sets the graphite events store.
+	SetGraphiteEventsStore(value events.Store) HandlerOptions
+
+	// ScrapeManager returns the scrape manager, nil if scraping is not
+	// enabled.
+	ScrapeManager() scrape.Manager
+	// SetScrapeManager sets the scrape manager.
+	SetScrapeManager(value scrape.Manager) HandlerOptions
+
 	// SetM3DBOptions sets the M3DB options.
 	SetM3DBOptions(value m3.Options) HandlerOptions
 	// M3DBOptions returns the M3DB options.
@@ -264,6 +309,28 @@ type HandlerOptions interface {
 	// StoreMetricsType returns true if storing of metrics type is enabled.
 	StoreMetricsType() bool
 
+	// SetIngestCreatedTimestamps enables/disables ingesting created timestamps
+	// received with remote writes as zero samples.
+	SetIngestCreatedTimestamps(value bool) HandlerOptions
+	// IngestCreatedTimestamps returns true if ingesting created timestamps
+	// received with remote writes as zero samples is enabled.
+	IngestCreatedTimestamps() bool
+
+	// SetExemplarStore sets the exemplar store.
+	SetExemplarStore(value exemplar.Store) HandlerOptions
+	// ExemplarStore returns the exemplar store, nil if exemplars are disabled.
+	ExemplarStore() exemplar.Store
+
+	// SetMetricMetadataStore sets the metric metadata store.
+	SetMetricMetadataStore(value metricmetadata.Store) HandlerOptions
+	// MetricMetadataStore returns the metric metadata store.
+	MetricMetadataStore() metricmetadata.Store
+
+	// SetResultsCache sets the range query results cache.
+	SetResultsCache(value resultscache.Cache) HandlerOptions
+	// ResultsCache returns the range query results cache, nil if disabled.
+	ResultsCache() resultscache.Cache
+
 	// SetNamespaceValidator sets the NamespaceValidator.
 	SetNamespaceValidator(NamespaceValidator) HandlerOptions
 	// NamespaceValidator returns the NamespaceValidator.
@@ -312,10 +379,17 @@ type handlerOptions struct {
 	m3dbOpts                          m3.Options
 	namespaceValidator                NamespaceValidator
 	storeMetricsType                  bool
+	ingestCreatedTimestamps           bool
+	exemplarStore                     exemplar.Store
+	metricMetadataStore               metricmetadata.Store
+	resultsCache                      resultscache.Cache
 	kvStoreProtoParser                KVStoreProtoParser
 	registerMiddleware                middleware.Register
 	graphiteRenderRouter              GraphiteRenderRouter
 	graphiteFindRouter                GraphiteFindRouter
+	graphiteTagsRouter                GraphiteTagsRouter
+	graphiteEventsStore               events.Store
+	scrapeManager                     scrape.Manager
 	defaultLookback                   time.Duration
 }
 
@@ -357,6 +431,16 @@ func NewHandlerOptions(
 	if cfg.StoreMetricsType != nil {
 		storeMetricsType = *cfg.StoreMetricsType
 	}
+	exemplarStore := cfg.Exemplars.NewStore(
+		newExemplarNamespaceFn(cfg.Exemplars, m3dbClusters), instrumentOpts)
+	metricMetadataStore, err := newMetricMetadataStore(clusterClient, instrumentOpts)
+	if err != nil {
+		return nil, err
+	}
+	resultsCache, err := cfg.ResultsCache.NewCache(time.Now, instrumentOpts)
+	if err != nil {
+		return nil, err
+	}
 	return &handlerOptions{
 		storage:                           downsamplerAndWriter.Storage(),
 		downsamplerAndWriter:              downsamplerAndWriter,
@@ -382,6 +466,10 @@ func NewHandlerOptions(
 		graphiteStorageOpts:               graphiteStorageOpts,
 		m3dbOpts:                          m3dbOpts,
 		storeMetricsType:                  storeMetricsType,
+		ingestCreatedTimestamps:           cfg.IngestCreatedTimestamps,
+		exemplarStore:                     exemplarStore,
+		metricMetadataStore:               metricMetadataStore,
+		resultsCache:                      resultsCache,
 		namespaceValidator:                validators.NamespaceValidator,
 		registerMiddleware:                middleware.Default,
 		graphiteRenderRouter:              graphiteRenderRouter,
@@ -390,6 +478,43 @@ func NewHandlerOptions(
 	}, nil
 }
 
+// newExemplarNamespaceFn returns the namespace of the unaggregated cluster to
+// store exemplars in, resolved lazily since dynamic clusters may not have
+// initialized yet.
+func newExemplarNamespaceFn(
+	cfg exemplar.Configuration,
+	clusters m3.Clusters,
+) exemplar.NamespaceFn {
+	return func() (client.Session, ident.ID, error) {
+		if clusters == nil {
+			return nil, nil, errNoClusters
+		}
+		ns, ok := clusters.UnaggregatedClusterNamespace()
+		if !ok {
+			return nil, nil, errUnaggregatedNamespaceNotReady
+		}
+		return ns.Session(), ident.StringID(cfg.Namespace), nil
+	}
+}
+
+// newMetricMetadataStore persists metric metadata in the cluster KV store so
+// that it is shared between coordinators, falling back to keeping it in memory
+// when there is no cluster client. The KV store is resolved on first use since
+// the cluster client may not have initialized yet.
+func newMetricMetadataStore(
+	clusterClient clusterclient.Client,
+	instrumentOpts instrument.Options,
+) (metricmetadata.Store, error) {
+	opts := metricmetadata.Options{
+		InstrumentOptions: instrumentOpts,
+	}
+	if clusterClient == nil {
+		return metricmetadata.NewStore(mem.NewStore(), opts)
+	}
+
+	return metricmetadata.NewLazyStore(clusterClient.KV, opts)
+}
+
 func (o *handlerOptions) CreatedAt() time.Time {
 	return o.createdAt
 }
@@ -648,6 +773,36 @@ func (o *handlerOptions) SetGraphiteFindRouter(value GraphiteFindRouter) Handler
 	return &opts
 }
 
+func (o *handlerOptions) GraphiteTagsRouter() GraphiteTagsRouter {
+	return o.graphiteTagsRouter
+}
+
+func (o *handlerOptions) SetGraphiteTagsRouter(value GraphiteTagsRouter) HandlerOptions {
+	opts := *o
+	opts.graphiteTagsRouter = value
+	return &opts
+}
+
+func (o *handlerOptions) GraphiteEventsStore() events.Store {
+	return o.graphiteEventsStore
+}
+
+func (o *handlerOptions) SetGraphiteEventsStore(value events.Store) HandlerOptions {
+	opts := *o
+	opts.graphiteEventsStore = value
+	return &opts
+}
+
+func (o *handlerOptions) ScrapeManager() scrape.Manager {
+	return o.scrapeManager
+}
+
+func (o *handlerOptions) SetScrapeManager(value scrape.Manager) HandlerOptions {
+	opts := *o
+	opts.scrapeManager = value
+	return &opts
+}
+
 func (o *handlerOptions) SetM3DBOptions(value m3.Options) HandlerOptions {
 	opts := *o
 	opts.m3dbOpts = value
@@ -668,6 +823,46 @@ func (o *handlerOptions) StoreMetricsType() bool {
 	return o.storeMetricsType
 }
 
+func (o *handlerOptions) SetIngestCreatedTimestamps(value bool) HandlerOptions {
+	opts := *o
+	opts.ingestCreatedTimestamps = value
+	return &opts
+}
+
+func (o *handlerOptions) IngestCreatedTimestamps() bool {
+	return o.ingestCreatedTimestamps
+}
+
+func (o *handlerOptions) SetExemplarStore(value exemplar.Store) HandlerOptions {
+	opts := *o
+	opts.exemplarStore = value
+	return &opts
+}
+
+func (o *handlerOptions) ExemplarStore() exemplar.Store {
+	return o.exemplarStore
+}
+
+func (o *handlerOptions) SetMetricMetadataStore(value metricmetadata.Store) HandlerOptions {
+	opts := *o
+	opts.metricMetadataStore = value
+	return &opts
+}
+
+func (o *handlerOptions) MetricMetadataStore() metricmetadata.Store {
+	return o.metricMetadataStore
+}
+
+func (o *handlerOptions) SetResultsCache(value resultscache.Cache) HandlerOptions {
+	opts := *o
+	opts.resultsCache = value
+	return &opts
+}
+
+func (o *handlerOptions) ResultsCache() resultscache.Cache {
+	return o.resultsCache
+}
+
 func (o *handlerOptions) SetNamespaceValidator(value NamespaceValidator) HandlerOptions {
 	opts := *o
 	opts.namespaceValidator = value
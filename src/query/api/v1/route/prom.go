@@ -38,4 +38,16 @@ const (
 
 	// SeriesMatchURL is the url for remote prom series matcher handler.
 	SeriesMatchURL = Prefix + "/series"
+
+	// QueryExemplarsURL is the url for the query exemplars endpoint.
+	QueryExemplarsURL = Prefix + "/query_exemplars"
+
+	// MetadataURL is the url for the metric metadata endpoint.
+	MetadataURL = Prefix + "/metadata"
+
+	// TargetsURL is the url for the scrape targets endpoint.
+	TargetsURL = Prefix + "/targets"
+
+	// DeleteSeriesURL is the url for the series deletion endpoint.
+	DeleteSeriesURL = Prefix + "/admin/tsdb/delete_series"
 )
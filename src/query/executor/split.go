@@ -0,0 +1,494 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"context"
+	"math"
+	"time"
+
+	pql "github.com/prometheus/prometheus/promql/parser"
+	"github.com/uber-go/tally"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/storage"
+)
+
+const (
+	// DefaultSplitInterval is the default interval range queries are split by.
+	DefaultSplitInterval = 24 * time.Hour
+	// DefaultSplitMaxConcurrency is the default maximum number of sub-queries
+	// executed concurrently for a single query.
+	DefaultSplitMaxConcurrency = 8
+)
+
+// SplitOptions are options for splitting queries into sub-queries.
+type SplitOptions struct {
+	// Interval is the interval range queries are split by, sub-ranges are
+	// aligned to multiples of the interval.
+	Interval time.Duration
+	// SeriesShards is the number of series shards that associative
+	// aggregations are split into, values below two disable sharding.
+	SeriesShards int
+	// MaxConcurrency is the maximum number of sub-queries executed
+	// concurrently for a single query.
+	MaxConcurrency int
+}
+
+type splitEngine struct {
+	Engine
+
+	opts    SplitOptions
+	metrics splitMetrics
+}
+
+type splitMetrics struct {
+	split      tally.Counter
+	subqueries tally.Counter
+}
+
+// NewSplitEngine returns an engine that splits range queries into time
+// aligned sub-ranges, and associative aggregations such as sum, count, min
+// and max into series shards, executes the pieces concurrently against the
+// given engine and merges the results.
+func NewSplitEngine(engine Engine, opts SplitOptions) Engine {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultSplitInterval
+	}
+	if opts.MaxConcurrency <= 0 {
+		opts.MaxConcurrency = DefaultSplitMaxConcurrency
+	}
+
+	scope := engine.Options().InstrumentOptions().MetricsScope().SubScope("split")
+	return &splitEngine{
+		Engine: engine,
+		opts:   opts,
+		metrics: splitMetrics{
+			split:      scope.Counter("queries"),
+			subqueries: scope.Counter("subqueries"),
+		},
+	}
+}
+
+type splitPlan struct {
+	bounds  models.Bounds
+	ranges  []models.RequestParams
+	offsets []int
+	shards  int
+	combine func(a, b float64) float64
+}
+
+type splitTask struct {
+	rangeIdx  int
+	params    models.RequestParams
+	fetchOpts *storage.FetchOptions
+}
+
+type splitSeries struct {
+	meta   block.SeriesMeta
+	values []float64
+}
+
+type splitResult struct {
+	series  []splitSeries
+	tagOpts models.TagOptions
+	meta    block.ResultMetadata
+}
+
+func (e *splitEngine) ExecuteExpr(
+	ctx context.Context,
+	parser parser.Parser,
+	opts *QueryOptions,
+	fetchOpts *storage.FetchOptions,
+	params models.RequestParams,
+) (block.Block, error) {
+	plan, ok := e.plan(opts, params)
+	if !ok {
+		return e.Engine.ExecuteExpr(ctx, parser, opts, fetchOpts, params)
+	}
+
+	tasks := make([]splitTask, 0, len(plan.ranges)*plan.shards)
+	for i, subParams := range plan.ranges {
+		for shard := 0; shard < plan.shards; shard++ {
+			subFetchOpts := fetchOpts.Clone()
+			if plan.shards > 1 {
+				subFetchOpts.SeriesShard = &storage.SeriesShard{
+					Index: shard,
+					Count: plan.shards,
+				}
+			}
+
+			tasks = append(tasks, splitTask{
+				rangeIdx:  i,
+				params:    subParams,
+				fetchOpts: subFetchOpts,
+			})
+		}
+	}
+
+	e.metrics.split.Inc(1)
+	e.metrics.subqueries.Inc(int64(len(tasks)))
+
+	var (
+		results = make([]splitResult, len(tasks))
+		sem     = make(chan struct{}, e.opts.MaxConcurrency)
+	)
+	g, gCtx := errgroup.WithContext(ctx)
+	for i, task := range tasks {
+		i, task := i, task
+		g.Go(func() error {
+			select {
+			case sem <- struct{}{}:
+			case <-gCtx.Done():
+				return gCtx.Err()
+			}
+			defer func() { <-sem }()
+
+			result, err := e.execute(gCtx, parser, opts, task)
+			results[i] = result
+			return err
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	return mergeSplitResults(ctx, opts, plan, tasks, results)
+}
+
+func (e *splitEngine) execute(
+	ctx context.Context,
+	parser parser.Parser,
+	opts *QueryOptions,
+	task splitTask,
+) (splitResult, error) {
+	bl, err := e.Engine.ExecuteExpr(ctx, parser, opts, task.fetchOpts, task.params)
+	if err != nil {
+		return splitResult{}, err
+	}
+
+	result, err := readSplitBlock(bl)
+	if closeErr := bl.Close(); err == nil {
+		err = closeErr
+	}
+
+	return result, err
+}
+
+// plan determines how the query is split, returning false if the query
+// should be executed as is.
+func (e *splitEngine) plan(
+	opts *QueryOptions,
+	params models.RequestParams,
+) (splitPlan, bool) {
+	if opts != nil && opts.QueryContextOptions.Instantaneous {
+		return splitPlan{}, false
+	}
+
+	bounds := models.Bounds{
+		Start:    params.Start,
+		Duration: params.ExclusiveEnd().Sub(params.Start),
+		StepSize: params.Step,
+	}
+	if bounds.Steps() < 1 {
+		return splitPlan{}, false
+	}
+
+	expr, err := e.Options().ParseOptions().ParseFn()(params.Query)
+	if err != nil || !splittableExpr(expr) {
+		// NB: let the engine surface any parse error.
+		return splitPlan{}, false
+	}
+
+	plan := splitPlan{bounds: bounds, shards: 1}
+	plan.ranges, plan.offsets = splitRanges(params, bounds, e.opts.Interval)
+	if e.opts.SeriesShards > 1 {
+		if combine, ok := shardedAggregation(expr); ok {
+			plan.shards = e.opts.SeriesShards
+			plan.combine = combine
+		}
+	}
+
+	if len(plan.ranges) < 2 && plan.shards < 2 {
+		return splitPlan{}, false
+	}
+
+	return plan, true
+}
+
+// splitRanges splits the request into sub-ranges aligned to the interval,
+// each starting on a step of the original request so that the steps of the
+// sub-ranges line up with the steps of the original request.
+func splitRanges(
+	params models.RequestParams,
+	bounds models.Bounds,
+	interval time.Duration,
+) ([]models.RequestParams, []int) {
+	var (
+		end     = bounds.Start.Add(bounds.StepSize * time.Duration(bounds.Steps()))
+		ranges  []models.RequestParams
+		offsets []int
+	)
+	for start := bounds.Start; start < end; {
+		next := start.Truncate(interval).Add(interval)
+		if rem := next.Sub(start) % bounds.StepSize; rem != 0 {
+			next = next.Add(bounds.StepSize - rem)
+		}
+		if next > end {
+			next = end
+		}
+
+		subParams := params
+		subParams.Start = start
+		subParams.End = next
+		subParams.IncludeEnd = false
+
+		ranges = append(ranges, subParams)
+		offsets = append(offsets, int(start.Sub(bounds.Start)/bounds.StepSize))
+		start = next
+	}
+
+	return ranges, offsets
+}
+
+// splittableExpr returns true if the expression can be evaluated over
+// sub-ranges of the query independently, which is not the case when it
+// refers to the start or end of the query.
+func splittableExpr(expr pql.Expr) bool {
+	splittable := true
+	pql.Inspect(expr, func(node pql.Node, _ []pql.Node) error {
+		switch n := node.(type) {
+		case *pql.VectorSelector:
+			if n.StartOrEnd != 0 {
+				splittable = false
+			}
+		case *pql.SubqueryExpr:
+			if n.StartOrEnd != 0 {
+				splittable = false
+			}
+		}
+		return nil
+	})
+	return splittable
+}
+
+// unshardableFunctions are functions whose result for a series depends on
+// other series, and as such can not be evaluated over a shard of the series.
+var unshardableFunctions = map[string]struct{}{
+	"absent":             {},
+	"absent_over_time":   {},
+	"histogram_quantile": {},
+	"scalar":             {},
+	"vector":             {},
+}
+
+// shardedAggregation returns the function used to combine partial results
+// of the aggregation at the root of the expression, if the aggregation can
+// be evaluated over disjoint shards of the series independently.
+func shardedAggregation(expr pql.Expr) (func(a, b float64) float64, bool) {
+	for {
+		paren, ok := expr.(*pql.ParenExpr)
+		if !ok {
+			break
+		}
+		expr = paren.Expr
+	}
+
+	agg, ok := expr.(*pql.AggregateExpr)
+	if !ok {
+		return nil, false
+	}
+
+	var combine func(a, b float64) float64
+	switch agg.Op {
+	case pql.SUM, pql.COUNT:
+		combine = func(a, b float64) float64 { return a + b }
+	case pql.MIN:
+		combine = math.Min
+	case pql.MAX, pql.GROUP:
+		combine = math.Max
+	default:
+		return nil, false
+	}
+
+	var (
+		selectors int
+		shardable = true
+	)
+	pql.Inspect(agg.Expr, func(node pql.Node, _ []pql.Node) error {
+		switch n := node.(type) {
+		case *pql.VectorSelector:
+			selectors++
+		case *pql.AggregateExpr:
+			shardable = false
+		case *pql.BinaryExpr:
+			// NB: vector matching pairs up series that may live in
+			// different shards.
+			if n.LHS.Type() == pql.ValueTypeVector &&
+				n.RHS.Type() == pql.ValueTypeVector {
+				shardable = false
+			}
+		case *pql.Call:
+			if _, ok := unshardableFunctions[n.Func.Name]; ok {
+				shardable = false
+			}
+		}
+		return nil
+	})
+
+	// NB: expressions without selectors evaluate to the same result in every
+	// shard, so combining them would be incorrect.
+	if !shardable || selectors == 0 {
+		return nil, false
+	}
+
+	return combine, true
+}
+
+func readSplitBlock(bl block.Block) (splitResult, error) {
+	it, err := bl.StepIter()
+	if err != nil {
+		return splitResult{}, err
+	}
+	defer it.Close()
+
+	var (
+		meta       = bl.Meta()
+		seriesMeta = it.SeriesMeta()
+		series     = make([]splitSeries, 0, len(seriesMeta))
+	)
+	for _, m := range seriesMeta {
+		series = append(series, splitSeries{
+			meta: block.SeriesMeta{
+				Name: m.Name,
+				Tags: m.Tags.AddTags(meta.Tags.Tags),
+			},
+			values: make([]float64, 0, it.StepCount()),
+		})
+	}
+
+	for it.Next() {
+		for i, v := range it.Current().Values() {
+			series[i].values = append(series[i].values, v)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return splitResult{}, err
+	}
+
+	return splitResult{
+		series:  series,
+		tagOpts: meta.Tags.Opts,
+		meta:    meta.ResultMetadata,
+	}, nil
+}
+
+// mergeSplitResults merges the results of the sub-queries into a single
+// block, concatenating the sub-ranges of each series and combining the
+// values of series shards with the combine function of the plan.
+func mergeSplitResults(
+	ctx context.Context,
+	opts *QueryOptions,
+	plan splitPlan,
+	tasks []splitTask,
+	results []splitResult,
+) (block.Block, error) {
+	var (
+		steps      = plan.bounds.Steps()
+		resultMeta = block.NewResultMetadata()
+		tagOpts    models.TagOptions
+		index      = make(map[string]int)
+		merged     []splitSeries
+	)
+	for i, result := range results {
+		resultMeta = resultMeta.CombineMetadata(result.meta)
+		if tagOpts == nil {
+			tagOpts = result.tagOpts
+		}
+
+		offset := plan.offsets[tasks[i].rangeIdx]
+		for _, s := range result.series {
+			id := string(s.meta.Tags.ID())
+			idx, ok := index[id]
+			if !ok {
+				values := make([]float64, steps)
+				for j := range values {
+					values[j] = math.NaN()
+				}
+
+				idx = len(merged)
+				index[id] = idx
+				merged = append(merged, splitSeries{meta: s.meta, values: values})
+			}
+
+			values := merged[idx].values
+			for j, v := range s.values {
+				k := offset + j
+				if k >= steps || math.IsNaN(v) {
+					continue
+				}
+
+				if math.IsNaN(values[k]) {
+					values[k] = v
+				} else if plan.combine != nil {
+					values[k] = plan.combine(values[k], v)
+				}
+			}
+		}
+	}
+
+	seriesMeta := make([]block.SeriesMeta, 0, len(merged))
+	for _, s := range merged {
+		seriesMeta = append(seriesMeta, s.meta)
+	}
+
+	var queryCtxOpts models.QueryContextOptions
+	if opts != nil {
+		queryCtxOpts = opts.QueryContextOptions
+	}
+
+	queryCtx := models.NewQueryContext(ctx, tally.NoopScope, queryCtxOpts)
+	builder := block.NewColumnBlockBuilder(queryCtx, block.Metadata{
+		Bounds:         plan.bounds,
+		Tags:           models.NewTags(0, tagOpts),
+		ResultMetadata: resultMeta,
+	}, seriesMeta)
+	if err := builder.AddCols(steps); err != nil {
+		return nil, err
+	}
+
+	column := make([]float64, len(merged))
+	for k := 0; k < steps; k++ {
+		for i, s := range merged {
+			column[i] = s.values[k]
+		}
+		if err := builder.AppendValues(k, column); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Build(), nil
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// fakeSplitEngine evaluates sum(foo) by (group) over ten series foo_<i>
+// with value i plus the step time in minutes.
+type fakeSplitEngine struct {
+	Engine
+
+	sync.Mutex
+	calls  []models.RequestParams
+	shards []*storage.SeriesShard
+}
+
+func newFakeSplitEngine() *fakeSplitEngine {
+	return &fakeSplitEngine{Engine: NewEngine(NewEngineOptions().
+		SetInstrumentOptions(instrument.NewOptions()))}
+}
+
+func (e *fakeSplitEngine) ExecuteExpr(
+	ctx context.Context,
+	_ parser.Parser,
+	_ *QueryOptions,
+	fetchOpts *storage.FetchOptions,
+	params models.RequestParams,
+) (block.Block, error) {
+	e.Lock()
+	e.calls = append(e.calls, params)
+	e.shards = append(e.shards, fetchOpts.SeriesShard)
+	e.Unlock()
+
+	bounds := models.Bounds{
+		Start:    params.Start,
+		Duration: params.ExclusiveEnd().Sub(params.Start),
+		StepSize: params.Step,
+	}
+
+	var (
+		index      = make(map[string]int)
+		seriesMeta []block.SeriesMeta
+		values     [][]float64
+	)
+	for i := 0; i < 10; i++ {
+		if !fetchOpts.SeriesShard.Contains([]byte(fmt.Sprintf("foo_%d", i))) {
+			continue
+		}
+
+		group := fmt.Sprintf("%d", i%2)
+		idx, ok := index[group]
+		if !ok {
+			idx = len(seriesMeta)
+			index[group] = idx
+			seriesMeta = append(seriesMeta, block.SeriesMeta{
+				Tags: models.EmptyTags().AddTag(models.Tag{
+					Name:  []byte("group"),
+					Value: []byte(group),
+				}),
+			})
+			values = append(values, make([]float64, bounds.Steps()))
+		}
+
+		for j := range values[idx] {
+			t := bounds.Start.Add(time.Duration(j) * bounds.StepSize)
+			values[idx][j] += float64(i) + float64(t)/float64(time.Minute)
+		}
+	}
+
+	builder := block.NewColumnBlockBuilder(models.NoopQueryContext(),
+		block.Metadata{Bounds: bounds, Tags: models.EmptyTags()}, seriesMeta)
+	if err := builder.AddCols(bounds.Steps()); err != nil {
+		return nil, err
+	}
+	for j := 0; j < bounds.Steps(); j++ {
+		for _, v := range values {
+			if err := builder.AppendValue(j, v[j]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return builder.Build(), nil
+}
+
+func expectedFakeSplitValues(group int, bounds models.Bounds) []float64 {
+	values := make([]float64, bounds.Steps())
+	for j := range values {
+		t := bounds.Start.Add(time.Duration(j) * bounds.StepSize)
+		for i := group; i < 10; i += 2 {
+			values[j] += float64(i) + float64(t)/float64(time.Minute)
+		}
+	}
+	return values
+}
+
+func readSplitTestBlock(t *testing.T, bl block.Block) map[string][]float64 {
+	result, err := readSplitBlock(bl)
+	require.NoError(t, err)
+	require.NoError(t, bl.Close())
+
+	values := make(map[string][]float64, len(result.series))
+	for _, s := range result.series {
+		group, ok := s.meta.Tags.Get([]byte("group"))
+		require.True(t, ok)
+		values[string(group)] = s.values
+	}
+	return values
+}
+
+func TestSplitEngineSplitsByInterval(t *testing.T) {
+	var (
+		fake   = newFakeSplitEngine()
+		engine = NewSplitEngine(fake, SplitOptions{Interval: time.Hour})
+		start  = xtime.UnixNano(0).Add(10*24*time.Hour + 7*time.Minute)
+		params = models.RequestParams{
+			Start:      start,
+			End:        start.Add(3*time.Hour + 30*time.Minute),
+			Step:       7 * time.Minute,
+			Query:      "sum(foo) by (group)",
+			IncludeEnd: true,
+		}
+		bounds = models.Bounds{
+			Start:    params.Start,
+			Duration: params.ExclusiveEnd().Sub(params.Start),
+			StepSize: params.Step,
+		}
+	)
+
+	bl, err := engine.ExecuteExpr(context.Background(), nil,
+		&QueryOptions{}, storage.NewFetchOptions(), params)
+	require.NoError(t, err)
+	assert.True(t, bounds.Equals(bl.Meta().Bounds))
+
+	assert.Equal(t, map[string][]float64{
+		"0": expectedFakeSplitValues(0, bounds),
+		"1": expectedFakeSplitValues(1, bounds),
+	}, readSplitTestBlock(t, bl))
+
+	// The intervals are executed concurrently so calls are recorded in any
+	// order.
+	require.Equal(t, 4, len(fake.calls))
+	sort.Slice(fake.calls, func(i, j int) bool {
+		return fake.calls[i].Start < fake.calls[j].Start
+	})
+	next := params.Start
+	for _, call := range fake.calls {
+		assert.Equal(t, next, call.Start)
+		assert.False(t, call.IncludeEnd)
+		assert.Equal(t, time.Duration(0), call.Start.Sub(params.Start)%params.Step)
+		next = call.End
+	}
+	assert.Equal(t, params.ExclusiveEnd(), next)
+}
+
+func TestSplitEngineShardsAggregations(t *testing.T) {
+	var (
+		fake   = newFakeSplitEngine()
+		engine = NewSplitEngine(fake, SplitOptions{SeriesShards: 4})
+		start  = xtime.Now().Truncate(24 * time.Hour)
+		params = models.RequestParams{
+			Start: start,
+			End:   start.Add(time.Hour),
+			Step:  time.Minute,
+			Query: "sum(rate(foo[5m]) * 2) by (group)",
+		}
+		bounds = models.Bounds{
+			Start:    params.Start,
+			Duration: time.Hour,
+			StepSize: params.Step,
+		}
+	)
+
+	bl, err := engine.ExecuteExpr(context.Background(), nil,
+		&QueryOptions{}, storage.NewFetchOptions(), params)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]float64{
+		"0": expectedFakeSplitValues(0, bounds),
+		"1": expectedFakeSplitValues(1, bounds),
+	}, readSplitTestBlock(t, bl))
+
+	require.Equal(t, 4, len(fake.calls))
+	indexes := make(map[int]struct{})
+	for i, shard := range fake.shards {
+		require.NotNil(t, shard)
+		assert.Equal(t, 4, shard.Count)
+		assert.Equal(t, params, fake.calls[i])
+		indexes[shard.Index] = struct{}{}
+	}
+	assert.Equal(t, 4, len(indexes))
+}
+
+func TestSplitEngineExecutesUnsplittableQueriesAsIs(t *testing.T) {
+	start := xtime.Now().Truncate(24 * time.Hour)
+	tests := []struct {
+		name          string
+		query         string
+		duration      time.Duration
+		instantaneous bool
+	}{
+		{name: "instant", query: "foo", duration: 48 * time.Hour, instantaneous: true},
+		{name: "at modifier", query: "foo @ end()", duration: 48 * time.Hour},
+		{name: "short range", query: "foo", duration: time.Hour},
+		{name: "non associative aggregation", query: "avg(foo)", duration: time.Hour},
+		{name: "invalid query", query: "sum(", duration: 48 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				fake   = newFakeSplitEngine()
+				engine = NewSplitEngine(fake, SplitOptions{SeriesShards: 4})
+				params = models.RequestParams{
+					Start: start,
+					End:   start.Add(tt.duration),
+					Step:  time.Minute,
+					Query: tt.query,
+				}
+				opts = &QueryOptions{
+					QueryContextOptions: models.QueryContextOptions{
+						Instantaneous: tt.instantaneous,
+					},
+				}
+			)
+
+			bl, err := engine.ExecuteExpr(context.Background(), nil,
+				opts, storage.NewFetchOptions(), params)
+			require.NoError(t, err)
+			require.NoError(t, bl.Close())
+
+			assert.Equal(t, []models.RequestParams{params}, fake.calls)
+			assert.Equal(t, []*storage.SeriesShard{nil}, fake.shards)
+		})
+	}
+}
+
+func TestShardedAggregation(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected bool
+		a, b     float64
+		combined float64
+	}{
+		{query: "sum(foo)", expected: true, a: 1, b: 2, combined: 3},
+		{query: "(count(rate(foo[1m])) by (bar))", expected: true, a: 1, b: 2, combined: 3},
+		{query: "min(foo * 2)", expected: true, a: 1, b: 2, combined: 1},
+		{query: "max without (bar) (label_replace(foo, \"a\", \"b\", \"c\", \"d\"))", expected: true, a: 1, b: 2, combined: 2},
+		{query: "avg(foo)"},
+		{query: "topk(2, foo)"},
+		{query: "sum(foo / bar)"},
+		{query: "sum(sum(foo) by (bar))"},
+		{query: "sum(histogram_quantile(0.9, foo))"},
+		{query: "sum(absent(foo))"},
+		{query: "sum(vector(1))"},
+		{query: "foo"},
+	}
+
+	parseFn := promql.NewParseOptions().ParseFn()
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			expr, err := parseFn(tt.query)
+			require.NoError(t, err)
+
+			combine, ok := shardedAggregation(expr)
+			require.Equal(t, tt.expected, ok)
+			if ok {
+				assert.Equal(t, tt.combined, combine(tt.a, tt.b))
+			}
+		})
+	}
+}
+
+func TestSplitEngineKeepsMissingValues(t *testing.T) {
+	plan := splitPlan{
+		bounds: models.Bounds{
+			Start:    xtime.Now().Truncate(time.Hour),
+			Duration: 4 * time.Minute,
+			StepSize: time.Minute,
+		},
+		offsets: []int{0, 2},
+		combine: func(a, b float64) float64 { return a + b },
+	}
+	tags := models.EmptyTags().AddTag(models.Tag{
+		Name:  []byte("group"),
+		Value: []byte("0"),
+	})
+
+	bl, err := mergeSplitResults(context.Background(), nil, plan,
+		[]splitTask{{rangeIdx: 0}, {rangeIdx: 1}, {rangeIdx: 1}},
+		[]splitResult{
+			{series: []splitSeries{{meta: block.SeriesMeta{Tags: tags}, values: []float64{1, math.NaN()}}}},
+			{series: []splitSeries{{meta: block.SeriesMeta{Tags: tags}, values: []float64{math.NaN(), 2}}}},
+			{series: []splitSeries{{meta: block.SeriesMeta{Tags: tags}, values: []float64{math.NaN(), 3}}}},
+		})
+	require.NoError(t, err)
+
+	values := readSplitTestBlock(t, bl)["0"]
+	require.Equal(t, 4, len(values))
+	assert.Equal(t, float64(1), values[0])
+	assert.True(t, math.IsNaN(values[1]))
+	assert.True(t, math.IsNaN(values[2]))
+	assert.Equal(t, float64(5), values[3])
+}
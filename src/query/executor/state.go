@@ -161,6 +161,13 @@ func (s *ExecutionState) createNode(
 
 	transformNode, controller := CreateTransform(step.ID(),
 		transformParams, options)
+	parentOptions := options
+	if timeSpecOp, ok := transformParams.(transform.TimeSpecOp); ok {
+		timeSpec := timeSpecOp.ParentTimeSpec(options.TimeSpec(),
+			s.plan.LookbackDuration)
+		parentOptions = options.SetTimeSpec(timeSpec)
+	}
+
 	for _, parentID := range step.Parents {
 		parentStep, ok := s.plan.Step(parentID)
 		if !ok {
@@ -168,7 +175,7 @@ func (s *ExecutionState) createNode(
 				"%s, node: %s", parentID, step.ID())
 		}
 
-		parentController, err := s.createNode(parentStep, options)
+		parentController, err := s.createNode(parentStep, parentOptions)
 		if err != nil {
 			return nil, err
 		}
@@ -82,6 +82,13 @@ func (o Options) TimeSpec() TimeSpec {
 	return o.timeSpec
 }
 
+// SetTimeSpec returns a copy of the options with the TimeSpec option set.
+func (o Options) SetTimeSpec(timeSpec TimeSpec) Options {
+	opts := o
+	opts.timeSpec = timeSpec
+	return opts
+}
+
 // Debug returns the Debug option.
 func (o Options) Debug() bool {
 	return o.debug
@@ -150,6 +157,14 @@ type BoundOp interface {
 	Bounds() BoundSpec
 }
 
+// TimeSpecOp is an operation that evaluates its parents with a different
+// TimeSpec to its own, e.g. a subquery with its own range and resolution.
+type TimeSpecOp interface {
+	// ParentTimeSpec returns the TimeSpec to evaluate parents of this operation
+	// with, given the TimeSpec of the operation itself and the lookback duration.
+	ParentTimeSpec(timeSpec TimeSpec, lookback time.Duration) TimeSpec
+}
+
 // BoundSpec is the boundary specification for an operation.
 type BoundSpec struct {
 	// Range is the time range for the operation.
@@ -157,3 +172,54 @@ type BoundSpec struct {
 	// Offset is the offset for the operation.
 	Offset time.Duration
 }
+
+// AtModifierOp is an operation which may be pinned to a fixed evaluation time
+// with the @ modifier.
+type AtModifierOp interface {
+	// ResolveAtModifier returns the operation with any @ start() or @ end()
+	// modifier resolved against the given query bounds.
+	ResolveAtModifier(start, end xtime.UnixNano) parser.Params
+}
+
+// AtModifier pins the evaluation of an operation to a fixed time.
+type AtModifier struct {
+	// Timestamp is the time evaluation is pinned to.
+	Timestamp xtime.UnixNano
+	// Start is true if evaluation is pinned to the start of the query.
+	Start bool
+	// End is true if evaluation is pinned to the end of the query.
+	End bool
+}
+
+// Resolve resolves a modifier pinned to the start or end of a query against
+// the given query bounds.
+func (m AtModifier) Resolve(start, end xtime.UnixNano) AtModifier {
+	switch {
+	case m.Start:
+		return AtModifier{Timestamp: start}
+	case m.End:
+		return AtModifier{Timestamp: end}
+	default:
+		return m
+	}
+}
+
+// Offset returns the offset which shifts the last step of the given TimeSpec
+// onto the pinned time.
+func (m AtModifier) Offset(timeSpec TimeSpec) time.Duration {
+	bounds := timeSpec.Bounds()
+	last := bounds.Start.Add(time.Duration(bounds.Steps()-1) * bounds.StepSize)
+	return last.Sub(m.Timestamp)
+}
+
+// String is the string representation for the modifier.
+func (m AtModifier) String() string {
+	switch {
+	case m.Start:
+		return "start()"
+	case m.End:
+		return "end()"
+	default:
+		return m.Timestamp.String()
+	}
+}
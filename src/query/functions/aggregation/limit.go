@@ -0,0 +1,228 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/prometheus/prometheus/model/labels"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions/utils"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+const (
+	// LimitKType gathers the first k non nan elements in a list of series.
+	LimitKType = "limitk"
+	// LimitRatioType gathers a deterministic sample of approximately the given
+	// ratio of elements in a list of series, a negative ratio gathers the
+	// complement of the sample of the same positive ratio.
+	LimitRatioType = "limit_ratio"
+)
+
+// NewLimitOp creates a new limit operation.
+func NewLimitOp(
+	opType string,
+	params NodeParams,
+) (parser.Params, error) {
+	switch opType {
+	case LimitKType:
+	case LimitRatioType:
+		if r := params.Parameter; r < -1 || r > 1 {
+			return nil, fmt.Errorf("%s ratio must be between -1 and 1, got %v",
+				opType, r)
+		}
+	default:
+		return nil, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	return limitOp{
+		params: params,
+		opType: opType,
+	}, nil
+}
+
+// limitOp stores required properties for limit ops.
+type limitOp struct {
+	params NodeParams
+	opType string
+}
+
+// OpType for the operator.
+func (o limitOp) OpType() string {
+	return o.opType
+}
+
+// String representation.
+func (o limitOp) String() string {
+	return fmt.Sprintf("type: %s", o.OpType())
+}
+
+// Node creates an execution node.
+func (o limitOp) Node(
+	controller *transform.Controller,
+	_ transform.Options,
+) transform.OpNode {
+	return &limitNode{
+		op:         o,
+		controller: controller,
+	}
+}
+
+// limitNode is different from base node as it only uses grouping to determine
+// groups from which to keep series, and keeps the values of kept series as is
+// instead of compressing the series set.
+type limitNode struct {
+	op         limitOp
+	controller *transform.Controller
+}
+
+func (n *limitNode) Params() parser.Params {
+	return n.op
+}
+
+// Process the block.
+func (n *limitNode) Process(queryCtx *models.QueryContext, ID parser.NodeID, b block.Block) error {
+	return transform.ProcessSimpleBlock(n, n.controller, queryCtx, ID, b)
+}
+
+func (n *limitNode) ProcessBlock(
+	queryCtx *models.QueryContext,
+	ID parser.NodeID,
+	b block.Block,
+) (block.Block, error) {
+	stepIter, err := b.StepIter()
+	if err != nil {
+		return nil, err
+	}
+
+	params := n.op.params
+	meta := b.Meta()
+	seriesMetas := utils.FlattenMetadata(meta, stepIter.SeriesMeta())
+	buckets, _ := utils.GroupSeries(
+		params.MatchingTags,
+		params.Without,
+		[]byte(n.op.opType),
+		seriesMetas,
+	)
+
+	var limitFn func(values []float64)
+	switch n.op.opType {
+	case LimitKType:
+		k := int(params.Parameter)
+		limitFn = func(values []float64) {
+			limitKFn(k, values, buckets)
+		}
+	default:
+		sampled := limitRatioSample(params.Parameter, seriesMetas)
+		limitFn = func(values []float64) {
+			for i, keep := range sampled {
+				if !keep {
+					values[i] = math.NaN()
+				}
+			}
+		}
+	}
+
+	builder, err := n.controller.BlockBuilder(queryCtx, meta, seriesMetas)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = builder.AddCols(stepIter.StepCount()); err != nil {
+		return nil, err
+	}
+
+	for index := 0; stepIter.Next(); index++ {
+		values := stepIter.Current().Values()
+		limitFn(values)
+		if err := builder.AppendValues(index, values); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = stepIter.Err(); err != nil {
+		return nil, err
+	}
+
+	return builder.Build(), nil
+}
+
+// limitKFn keeps the first k non nan values of each bucket, clearing the
+// remaining values.
+func limitKFn(k int, values []float64, buckets [][]int) {
+	for _, bucket := range buckets {
+		kept := 0
+		for _, idx := range bucket {
+			if math.IsNaN(values[idx]) {
+				continue
+			}
+
+			if kept < k {
+				kept++
+				continue
+			}
+
+			values[idx] = math.NaN()
+		}
+	}
+}
+
+// limitRatioSample returns which series are part of the sample for the given
+// ratio, based on the hash of the series labels so that the same series are
+// sampled at every step, for every query, and as by Prometheus.
+func limitRatioSample(ratio float64, seriesMetas []block.SeriesMeta) []bool {
+	sampled := make([]bool, len(seriesMetas))
+	for i, meta := range seriesMetas {
+		offset := float64(labelsHash(meta.Tags)) / float64(math.MaxUint64)
+		sampled[i] = (ratio >= 0 && offset < ratio) ||
+			(ratio < 0 && offset >= 1+ratio)
+	}
+
+	return sampled
+}
+
+// labelsHash returns the Prometheus hash of the labels of the given tags.
+func labelsHash(tags models.Tags) uint64 {
+	var metricName []byte
+	if tags.Opts != nil {
+		metricName = tags.Opts.MetricName()
+	}
+
+	lbls := make(labels.Labels, 0, tags.Len())
+	for _, tag := range tags.Tags {
+		name := string(tag.Name)
+		if metricName != nil && bytes.Equal(tag.Name, metricName) {
+			name = labels.MetricName
+		}
+
+		lbls = append(lbls, labels.Label{Name: name, Value: string(tag.Value)})
+	}
+
+	sort.Sort(lbls)
+	return lbls.Hash()
+}
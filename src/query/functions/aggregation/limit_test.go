@@ -0,0 +1,180 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package aggregation
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/compare"
+	"github.com/m3db/m3/src/query/test/executor"
+)
+
+func processLimitOp(t *testing.T, op parser.Params) *executor.SinkNode {
+	bl := test.NewBlockFromValuesWithSeriesMeta(bounds, seriesMetas, v)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(rune(1)))
+	node := op.(limitOp).Node(c, transform.Options{})
+	err := node.Process(models.NoopQueryContext(), parser.NodeID(rune(0)), bl)
+	require.NoError(t, err)
+	return sink
+}
+
+func TestLimitKFunctionFilteringWithoutA(t *testing.T) {
+	op, err := NewLimitOp(LimitKType, NodeParams{
+		MatchingTags: [][]byte{[]byte("a")}, Without: true, Parameter: 1,
+	})
+	require.NoError(t, err)
+	sink := processLimitOp(t, op)
+	expected := [][]float64{
+		// Taking the first value of the first two series at each step.
+		{0, math.NaN(), 2, 3, 4},
+		{math.NaN(), 6, math.NaN(), math.NaN(), math.NaN()},
+		// Taking the first value of third, fourth, and fifth series.
+		{10, 20, 30, 40, 50},
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()},
+		// Taking the first value of last series, keeping it.
+		{600, 700, 800, 900, 1000},
+	}
+
+	// Should have the same metas as when started.
+	assert.Equal(t, seriesMetas, sink.Metas)
+	compare.EqualsWithNansWithDelta(t, expected, sink.Values, math.Pow10(-5))
+	assert.Equal(t, bounds, sink.Meta.Bounds)
+}
+
+func TestLimitKZero(t *testing.T) {
+	op, err := NewLimitOp(LimitKType, NodeParams{Parameter: 0})
+	require.NoError(t, err)
+	sink := processLimitOp(t, op)
+	for _, values := range sink.Values {
+		for _, value := range values {
+			assert.True(t, math.IsNaN(value))
+		}
+	}
+}
+
+func TestLimitRatio(t *testing.T) {
+	for _, ratio := range []float64{-1, 1} {
+		op, err := NewLimitOp(LimitRatioType, NodeParams{Parameter: ratio})
+		require.NoError(t, err)
+		sink := processLimitOp(t, op)
+		compare.EqualsWithNansWithDelta(t, v, sink.Values, math.Pow10(-5))
+	}
+
+	op, err := NewLimitOp(LimitRatioType, NodeParams{Parameter: 0})
+	require.NoError(t, err)
+	sink := processLimitOp(t, op)
+	for _, values := range sink.Values {
+		for _, value := range values {
+			assert.True(t, math.IsNaN(value))
+		}
+	}
+}
+
+func TestLimitRatioSampleComplement(t *testing.T) {
+	sampled := limitRatioSample(0.5, seriesMetas)
+	complement := limitRatioSample(-0.5, seriesMetas)
+	for i := range seriesMetas {
+		// Every series is part of exactly one of the samples.
+		assert.NotEqual(t, sampled[i], complement[i])
+	}
+
+	// Samples are deterministic.
+	assert.Equal(t, sampled, limitRatioSample(0.5, seriesMetas))
+}
+
+func TestLimitRatioFractional(t *testing.T) {
+	for _, ratio := range []float64{0.25, 0.5, 0.75, -0.25, -0.5} {
+		op, err := NewLimitOp(LimitRatioType, NodeParams{Parameter: ratio})
+		require.NoError(t, err)
+		sink := processLimitOp(t, op)
+		for i, meta := range seriesMetas {
+			lbls := make([]labels.Label, 0, meta.Tags.Len())
+			for _, tag := range meta.Tags.Tags {
+				lbls = append(lbls, labels.Label{
+					Name:  string(tag.Name),
+					Value: string(tag.Value),
+				})
+			}
+
+			offset := float64(labels.New(lbls...).Hash()) / float64(math.MaxUint64)
+			keep := (ratio >= 0 && offset < ratio) || (ratio < 0 && offset >= 1+ratio)
+			for j, value := range sink.Values[i] {
+				if keep {
+					compare.EqualsWithNans(t, v[i][j], value)
+				} else {
+					assert.True(t, math.IsNaN(value))
+				}
+			}
+		}
+	}
+}
+
+func TestLimitRatioSampleDistribution(t *testing.T) {
+	metas := make([]block.SeriesMeta, 1000)
+	for i := range metas {
+		metas[i] = block.SeriesMeta{Tags: test.StringTagsToTags(test.StringTags{
+			{N: "__name__", V: "up"}, {N: "instance", V: strconv.Itoa(i)},
+		})}
+	}
+
+	sampled := limitRatioSample(0.3, metas)
+	complement := limitRatioSample(-0.7, metas)
+	kept := 0
+	for i := range metas {
+		// A ratio and the negative of its complement split the series.
+		assert.NotEqual(t, sampled[i], complement[i])
+		if sampled[i] {
+			kept++
+		}
+	}
+
+	assert.InDelta(t, 300, kept, 50)
+}
+
+func TestLabelsHashMetricName(t *testing.T) {
+	opts := models.NewTagOptions().SetMetricName([]byte("name"))
+	tags := models.NewTags(2, opts).
+		AddTag(models.Tag{Name: []byte("name"), Value: []byte("up")}).
+		AddTag(models.Tag{Name: []byte("job"), Value: []byte("api")})
+
+	expected := labels.FromStrings("__name__", "up", "job", "api").Hash()
+	assert.Equal(t, expected, labelsHash(tags))
+}
+
+func TestLimitOpInvalid(t *testing.T) {
+	_, err := NewLimitOp(LimitRatioType, NodeParams{Parameter: 1.5})
+	require.Error(t, err)
+
+	_, err = NewLimitOp(TopKType, NodeParams{Parameter: 1})
+	require.Error(t, err)
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/m3db/m3/src/query/util/logging"
 	"github.com/m3db/m3/src/x/instrument"
 	"github.com/m3db/m3/src/x/opentracing"
+	xtime "github.com/m3db/m3/src/x/time"
 )
 
 // FetchType gets the series from storage
@@ -46,6 +47,11 @@ type FetchOp struct {
 	Range    time.Duration
 	Offset   time.Duration
 	Matchers models.Matchers
+	// At pins the fetch to a fixed evaluation time if set.
+	At *transform.AtModifier
+	// ExpandNativeHistograms expands native histograms into classic
+	// histogram bucket series, set when the fetch feeds histogram_quantile.
+	ExpandNativeHistograms bool
 }
 
 // FetchNode is a fetch execution node.
@@ -74,8 +80,25 @@ func (o FetchOp) Bounds() transform.BoundSpec {
 	}
 }
 
+// ResolveAtModifier resolves the @ modifier for this operation against the
+// given query bounds.
+func (o FetchOp) ResolveAtModifier(start, end xtime.UnixNano) parser.Params {
+	if o.At != nil {
+		at := o.At.Resolve(start, end)
+		o.At = &at
+	}
+
+	return o
+}
+
 // String is the string representation for this operation.
 func (o FetchOp) String() string {
+	if o.At != nil {
+		return fmt.Sprintf("type: %s. name: %s, range: %v, offset: %v, "+
+			"at: %v, matchers: %v", o.OpType(), o.Name, o.Range, o.Offset,
+			o.At, o.Matchers)
+	}
+
 	return fmt.Sprintf("type: %s. name: %s, range: %v, offset: %v, matchers: %v",
 		o.OpType(), o.Name, o.Range, o.Offset, o.Matchers)
 }
@@ -113,8 +136,9 @@ func (n *FetchNode) fetch(queryCtx *models.QueryContext) (block.Result, error) {
 	if err != nil {
 		return block.Result{}, err
 	}
+	opts.ExpandNativeHistograms = n.op.ExpandNativeHistograms
 
-	offset := n.op.Offset
+	offset := n.op.Offset + n.atOffset()
 	return n.storage.FetchBlocks(ctx, &storage.FetchQuery{
 		Start:       startTime.Add(-1 * offset).ToTime(),
 		End:         endTime.Add(-1 * offset).ToTime(),
@@ -123,6 +147,39 @@ func (n *FetchNode) fetch(queryCtx *models.QueryContext) (block.Result, error) {
 	}, opts)
 }
 
+// atOffset returns the additional offset required to evaluate the fetch at the
+// time given by the @ modifier, if set.
+func (n *FetchNode) atOffset() time.Duration {
+	if n.op.At == nil {
+		return 0
+	}
+
+	return n.op.At.Offset(n.timespec)
+}
+
+// pinBlock shifts a block fetched for a selector with an @ modifier back onto
+// the bounds of the query, such that the last step holds the pinned values.
+func (n *FetchNode) pinBlock(b block.Block) block.Block {
+	offset := n.atOffset()
+	if offset == 0 {
+		return b
+	}
+
+	var (
+		tt = func(t xtime.UnixNano) xtime.UnixNano { return t.Add(offset) }
+		mt = func(meta block.Metadata) block.Metadata {
+			meta.Bounds.Start = meta.Bounds.Start.Add(offset)
+			return meta
+		}
+	)
+
+	lazyOpts := block.NewLazyOptions().
+		SetTimeTransform(tt).
+		SetMetaTransform(mt)
+
+	return block.NewLazyBlock(b, lazyOpts)
+}
+
 // Execute runs the fetch node operation
 func (n *FetchNode) Execute(queryCtx *models.QueryContext) error {
 	ctx := queryCtx.Ctx
@@ -132,6 +189,7 @@ func (n *FetchNode) Execute(queryCtx *models.QueryContext) error {
 	}
 
 	for _, block := range blockResult.Blocks {
+		block = n.pinBlock(block)
 		if n.debug {
 			// Ignore any errors
 			iter, _ := block.StepIter()
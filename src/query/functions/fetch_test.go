@@ -123,6 +123,63 @@ func TestOffsetFetch(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestAtModifierFetch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		end   = start.Add(5 * time.Minute)
+		at    = start.Add(-1 * time.Hour)
+		// NB: the last step of the query is shifted onto the pinned time.
+		offset = time.Hour + 4*time.Minute
+	)
+
+	op := FetchOp{At: &transform.AtModifier{Start: true}}
+	op = op.ResolveAtModifier(at, end).(FetchOp)
+	require.Equal(t, &transform.AtModifier{Timestamp: at}, op.At)
+
+	opts := transformtest.Options(t, transform.OptionsParams{
+		TimeSpec: transform.TimeSpec{
+			Start: start,
+			End:   end,
+			Step:  time.Minute,
+		},
+	})
+
+	qMatcher := &predicateMatcher{
+		name: "query",
+		fn: func(i interface{}) bool {
+			q, ok := i.(*storage.FetchQuery)
+			if !ok {
+				return false
+			}
+
+			return q.Start.Equal(start.Add(-1*offset).ToTime()) &&
+				q.End.Equal(end.Add(-1*offset).ToTime())
+		},
+	}
+
+	values, bounds := test.GenerateValuesAndBounds(nil, &models.Bounds{
+		Start:    start.Add(-1 * offset),
+		Duration: 5 * time.Minute,
+		StepSize: time.Minute,
+	})
+
+	b := test.NewBlockFromValues(bounds, values)
+	store := storage.NewMockStorage(ctrl)
+	store.EXPECT().FetchBlocks(gomock.Any(), qMatcher, gomock.Any()).
+		Return(block.Result{Blocks: []block.Block{b}}, nil)
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID(rune(1)))
+	node := op.Node(c, store, opts)
+
+	err := node.Execute(models.NoopQueryContext())
+	require.NoError(t, err)
+	assert.Equal(t, values, sink.Values)
+	assert.Equal(t, start, sink.Meta.Bounds.Start)
+}
+
 func TestFetchWithRestrictFetch(t *testing.T) {
 	values, bounds := test.GenerateValuesAndBounds(nil, nil)
 	b := test.NewBlockFromValues(bounds, values)
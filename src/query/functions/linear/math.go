@@ -60,6 +60,10 @@ const (
 
 	// Log10Type calculates the decimal logarithm for values.
 	Log10Type = "log10"
+
+	// SgnType returns the sign of all values, 1 for positive values, -1 for
+	// negative values and 0 for zero values.
+	SgnType = "sgn"
 )
 
 var (
@@ -72,6 +76,7 @@ var (
 		LnType:    math.Log,
 		Log2Type:  math.Log2,
 		Log10Type: math.Log10,
+		SgnType:   sgn,
 	}
 )
 
@@ -84,3 +89,15 @@ func NewMathOp(opType string) (parser.Params, error) {
 
 	return nil, fmt.Errorf("unknown math type: %s", opType)
 }
+
+func sgn(v float64) float64 {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	default:
+		// NB: returns the value itself for zero and NaN values.
+		return v
+	}
+}
@@ -247,6 +247,32 @@ func TestSqrtWithSomeValues(t *testing.T) {
 	compare.EqualsWithNans(t, expected, sink.Values)
 }
 
+func TestSgnWithSomeValues(t *testing.T) {
+	v := [][]float64{
+		{0, math.NaN(), -2, 3, math.Inf(-1)},
+		{math.NaN(), 6, -0.5, math.Inf(1), 9},
+	}
+
+	values, bounds := test.GenerateValuesAndBounds(v, nil)
+	block := test.NewBlockFromValues(bounds, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(rune(1)))
+	mathOp, err := NewMathOp(SgnType)
+	require.NoError(t, err)
+
+	op, ok := mathOp.(transform.Params)
+	require.True(t, ok)
+
+	node := op.Node(c, transform.Options{})
+	err = node.Process(models.NoopQueryContext(), parser.NodeID(rune(0)), block)
+	require.NoError(t, err)
+	expected := [][]float64{
+		{0, math.NaN(), -1, 1, -1},
+		{math.NaN(), 1, -1, 1, 1},
+	}
+	assert.Len(t, sink.Values, 2)
+	compare.EqualsWithNans(t, expected, sink.Values)
+}
+
 func TestNonExistentFunc(t *testing.T) {
 	_, err := NewMathOp("nonexistent_func")
 	require.Error(t, err)
@@ -21,6 +21,7 @@
 package linear
 
 import (
+	"bytes"
 	"fmt"
 	"sort"
 
@@ -37,6 +38,14 @@ const (
 
 	// SortDescType is the same as sort, but sorts in descending order.
 	SortDescType = "sort_desc"
+
+	// SortByLabelType returns timeseries elements sorted by the values of the
+	// given labels, in ascending order.
+	SortByLabelType = "sort_by_label"
+
+	// SortByLabelDescType is the same as sort_by_label, but sorts in
+	// descending order.
+	SortByLabelDescType = "sort_by_label_desc"
 )
 
 type sortOp struct {
@@ -64,7 +73,30 @@ type valueAndMeta struct {
 	seriesMeta block.SeriesMeta
 }
 
-type lessFn func(i, j float64) bool
+type lessFn func(i, j valueAndMeta) bool
+
+func valueLessFn(fn func(i, j float64) bool) lessFn {
+	return func(i, j valueAndMeta) bool {
+		return fn(i.val, j.val)
+	}
+}
+
+func labelLessFn(labels [][]byte, descending bool) lessFn {
+	return func(i, j valueAndMeta) bool {
+		for _, label := range labels {
+			iValue, _ := i.seriesMeta.Tags.Get(label)
+			jValue, _ := j.seriesMeta.Tags.Get(label)
+			if c := bytes.Compare(iValue, jValue); c != 0 {
+				return (c < 0) != descending
+			}
+		}
+
+		// NB: series with equal values for all given labels are ordered by
+		// their full label set for a consistent ordering.
+		c := bytes.Compare(i.seriesMeta.Tags.ID(), j.seriesMeta.Tags.ID())
+		return (c < 0) != descending
+	}
+}
 
 // Node creates an execution node
 func (o sortOp) Node(
@@ -117,7 +149,7 @@ func (n *sortNode) processInstantBlock(queryCtx *models.QueryContext, stepIter b
 	}
 
 	sort.Slice(valuesToSort, func(i, j int) bool {
-		return n.op.lessFn(valuesToSort[i].val, valuesToSort[j].val)
+		return n.op.lessFn(valuesToSort[i], valuesToSort[j])
 	})
 
 	for i, sorted := range valuesToSort {
@@ -152,6 +184,7 @@ func (n *sortNode) processInstantBlock(queryCtx *models.QueryContext, stepIter b
 	return blockBuilder.Build(), nil
 }
 
+// NewSortOp creates a new sort op based on the type.
 func NewSortOp(opType string) (parser.Params, error) {
 	ascending := opType == SortType
 	if !ascending && opType != SortDescType {
@@ -163,5 +196,20 @@ func NewSortOp(opType string) (parser.Params, error) {
 		lessFn = utils.LesserWithNaNs
 	}
 
-	return sortOp{opType, lessFn}, nil
+	return sortOp{opType, valueLessFn(lessFn)}, nil
+}
+
+// NewSortByLabelOp creates a new sort op which sorts by the given labels.
+func NewSortByLabelOp(opType string, labels []string) (parser.Params, error) {
+	ascending := opType == SortByLabelType
+	if !ascending && opType != SortByLabelDescType {
+		return nil, fmt.Errorf("operator not supported: %s", opType)
+	}
+
+	byteLabels := make([][]byte, 0, len(labels))
+	for _, label := range labels {
+		byteLabels = append(byteLabels, []byte(label))
+	}
+
+	return sortOp{opType, labelLessFn(byteLabels, !ascending)}, nil
 }
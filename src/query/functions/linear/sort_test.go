@@ -99,6 +99,59 @@ func TestSortNop(t *testing.T) {
 	compare.EqualsWithNansWithDelta(t, expected, sink.Values, math.Pow10(-5))
 }
 
+func TestSortByLabelInstant(t *testing.T) {
+	op, err := NewSortByLabelOp(SortByLabelType, []string{"group", "instance"})
+	require.NoError(t, err)
+
+	sink := processSortOpWithSeries(t, op, sortByLabelSeriesMetas(), sortByLabelValues)
+
+	assert.Equal(t, []block.SeriesMeta{
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "api-server"}, {N: "instance", V: "0"}, {N: "group", V: "canary"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "app-server"}, {N: "instance", V: "1"}, {N: "group", V: "canary"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "app-server"}, {N: "instance", V: "0"}, {N: "group", V: "production"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "api-server"}, {N: "instance", V: "1"}, {N: "group", V: "production"}})},
+	}, sink.Metas)
+	compare.EqualsWithNansWithDelta(t, [][]float64{{400}, {100}, {200}, {300}},
+		sink.Values, math.Pow10(-5))
+}
+
+func TestSortByLabelDescInstant(t *testing.T) {
+	op, err := NewSortByLabelOp(SortByLabelDescType, []string{"group", "instance"})
+	require.NoError(t, err)
+
+	sink := processSortOpWithSeries(t, op, sortByLabelSeriesMetas(), sortByLabelValues)
+
+	assert.Equal(t, []block.SeriesMeta{
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "api-server"}, {N: "instance", V: "1"}, {N: "group", V: "production"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "app-server"}, {N: "instance", V: "0"}, {N: "group", V: "production"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "app-server"}, {N: "instance", V: "1"}, {N: "group", V: "canary"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "api-server"}, {N: "instance", V: "0"}, {N: "group", V: "canary"}})},
+	}, sink.Metas)
+	compare.EqualsWithNansWithDelta(t, [][]float64{{300}, {200}, {100}, {400}},
+		sink.Values, math.Pow10(-5))
+}
+
+func TestSortByLabelInvalidOpType(t *testing.T) {
+	_, err := NewSortByLabelOp(SortType, []string{"instance"})
+	require.Error(t, err)
+}
+
+var sortByLabelValues = [][]float64{
+	{10, 20, 30, 40, 100},
+	{50, 60, 70, 80, 200},
+	{60, 70, 80, 90, 300},
+	{70, 80, 90, 100, 400},
+}
+
+func sortByLabelSeriesMetas() []block.SeriesMeta {
+	return []block.SeriesMeta{
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "app-server"}, {N: "instance", V: "1"}, {N: "group", V: "canary"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "app-server"}, {N: "instance", V: "0"}, {N: "group", V: "production"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "api-server"}, {N: "instance", V: "1"}, {N: "group", V: "production"}})},
+		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "api-server"}, {N: "instance", V: "0"}, {N: "group", V: "canary"}})},
+	}
+}
+
 var (
 	seriesMetas = []block.SeriesMeta{
 		{Tags: test.StringTagsToTags(test.StringTags{{N: "job", V: "api-server"}, {N: "instance", V: "0"}, {N: "group", V: "production"}})},
@@ -141,3 +194,19 @@ func processSortOp(t *testing.T, op parser.Params, instant bool) *executor.SinkN
 	require.NoError(t, err)
 	return sink
 }
+
+func processSortOpWithSeries(
+	t *testing.T,
+	op parser.Params,
+	metas []block.SeriesMeta,
+	values [][]float64,
+) *executor.SinkNode {
+	bl := test.NewBlockFromValuesWithSeriesMeta(bounds, metas, values)
+	c, sink := executor.NewControllerWithSink(parser.NodeID(rune(1)))
+	node := op.(sortOp).Node(c, transform.Options{})
+	queryContext := models.NoopQueryContext()
+	queryContext.Options.Instantaneous = true
+	err := node.Process(queryContext, parser.NodeID(rune(0)), bl)
+	require.NoError(t, err)
+	return sink
+}
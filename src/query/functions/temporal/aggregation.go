@@ -57,20 +57,32 @@ const (
 
 	// QuantileType calculates the φ-quantile (0 ≤ φ ≤ 1) of the values in the specified interval.
 	QuantileType = "quantile_over_time"
+
+	// MadType calculates the median absolute deviation of all values in the specified interval.
+	MadType = "mad_over_time"
+
+	// PresentType returns 1 for any series with values in the specified interval.
+	PresentType = "present_over_time"
+
+	// AbsentType returns 1 if no series have values in the specified interval,
+	// it is evaluated as the absent aggregation of present_over_time.
+	AbsentType = "absent_over_time"
 )
 
 type aggFunc func([]float64) float64
 
 var (
 	aggFuncs = map[string]aggFunc{
-		AvgType:    avgOverTime,
-		CountType:  countOverTime,
-		MinType:    minOverTime,
-		MaxType:    maxOverTime,
-		SumType:    sumOverTime,
-		StdDevType: stddevOverTime,
-		StdVarType: stdvarOverTime,
-		LastType:   lastOverTime,
+		AvgType:     avgOverTime,
+		CountType:   countOverTime,
+		MinType:     minOverTime,
+		MaxType:     maxOverTime,
+		SumType:     sumOverTime,
+		StdDevType:  stddevOverTime,
+		StdVarType:  stdvarOverTime,
+		LastType:    lastOverTime,
+		MadType:     madOverTime,
+		PresentType: presentOverTime,
 	}
 )
 
@@ -233,6 +245,26 @@ func lastOverTime(values []float64) float64 {
 	return values[length-1]
 }
 
+func madOverTime(values []float64) float64 {
+	values = removeNaNs(values)
+	median := quantile(0.5, values)
+	for i, v := range values {
+		values[i] = math.Abs(v - median)
+	}
+
+	return quantile(0.5, values)
+}
+
+func presentOverTime(values []float64) float64 {
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			return 1
+		}
+	}
+
+	return math.NaN()
+}
+
 func sumAndCount(values []float64) (float64, float64) {
 	sum := 0.0
 	count := 0.0
@@ -273,6 +273,54 @@ var aggregationTestCases = []testCase{
 			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
 		},
 	},
+	{
+		name:   "mad_over_time",
+		opType: MadType,
+		vals: [][]float64{
+			{nan, 1, 2, 3, 4, 0, 1, 2, 3, 4},
+			{5, 6, 7, 8, 9, 5, 6, 7, 8, 9},
+		},
+		expected: [][]float64{
+			{nan, 0, 0.5, 1, 1, 1, 1, 1, 1, 1},
+			{0, 0.5, 1, 1, 1, 1, 1, 1, 1, 1},
+		},
+	},
+	{
+		name:   "mad_over_time all NaNs",
+		opType: MadType,
+		vals: [][]float64{
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+		},
+		expected: [][]float64{
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+		},
+	},
+	{
+		name:   "present_over_time",
+		opType: PresentType,
+		vals: [][]float64{
+			{nan, 1, nan, nan, nan, nan, nan, nan, nan, nan},
+			{5, 6, 7, 8, 9, 5, 6, 7, 8, 9},
+		},
+		expected: [][]float64{
+			{nan, 1, 1, 1, 1, 1, nan, nan, nan, nan},
+			{1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		},
+	},
+	{
+		name:   "present_over_time all NaNs",
+		opType: PresentType,
+		vals: [][]float64{
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+		},
+		expected: [][]float64{
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+			{nan, nan, nan, nan, nan, nan, nan, nan, nan, nan},
+		},
+	},
 }
 
 func TestAggregation(t *testing.T) {
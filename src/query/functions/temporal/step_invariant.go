@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package temporal
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+)
+
+// StepInvariantType evaluates an inner expression at a single step and
+// broadcasts the result across every step of the query. It is used for
+// expressions pinned to a fixed time by the @ modifier.
+const StepInvariantType = "step_invariant"
+
+// StepInvariantOp stores required properties for a step invariant operation.
+type StepInvariantOp struct {
+	// InnerRange is the largest range selected by the inner expression.
+	InnerRange time.Duration
+}
+
+// NewStepInvariantOp creates a new step invariant operation.
+func NewStepInvariantOp(innerRange time.Duration) StepInvariantOp {
+	return StepInvariantOp{InnerRange: innerRange}
+}
+
+// OpType for the operator.
+func (o StepInvariantOp) OpType() string {
+	return StepInvariantType
+}
+
+// String is the string representation for this operation.
+func (o StepInvariantOp) String() string {
+	return fmt.Sprintf("type: %s, inner range: %v", o.OpType(), o.InnerRange)
+}
+
+// ParentTimeSpec returns a TimeSpec which evaluates the inner expression at
+// a single step, at the start of the given TimeSpec.
+func (o StepInvariantOp) ParentTimeSpec(
+	timeSpec transform.TimeSpec,
+	lookback time.Duration,
+) transform.TimeSpec {
+	shift := o.InnerRange
+	if shift == 0 {
+		shift = lookback
+	}
+
+	if remainder := shift % timeSpec.Step; remainder != 0 {
+		shift += timeSpec.Step - remainder
+	}
+
+	return transform.TimeSpec{
+		Start: timeSpec.Start.Add(-1 * shift),
+		End:   timeSpec.Start.Add(timeSpec.Step),
+		Now:   timeSpec.Now,
+		Step:  timeSpec.Step,
+	}
+}
+
+// Node creates an execution node.
+func (o StepInvariantOp) Node(
+	controller *transform.Controller,
+	opts transform.Options,
+) transform.OpNode {
+	return &stepInvariantNode{
+		op:         o,
+		controller: controller,
+		timeSpec:   opts.TimeSpec(),
+	}
+}
+
+type stepInvariantNode struct {
+	op         StepInvariantOp
+	controller *transform.Controller
+	timeSpec   transform.TimeSpec
+}
+
+// Process broadcasts the values at the last step of the incoming block across
+// every step of the query.
+func (n *stepInvariantNode) Process(
+	queryCtx *models.QueryContext,
+	_ parser.NodeID,
+	b block.Block,
+) error {
+	iter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	var (
+		seriesMetas = iter.SeriesMeta()
+		values      = make([]float64, len(seriesMetas))
+	)
+
+	for i := range values {
+		values[i] = math.NaN()
+	}
+
+	for iter.Next() {
+		copy(values, iter.Current().Values())
+	}
+
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	meta := b.Meta()
+	meta.Bounds = n.timeSpec.Bounds()
+	if err := b.Close(); err != nil {
+		return err
+	}
+
+	builder, err := n.controller.BlockBuilder(queryCtx, meta, seriesMetas)
+	if err != nil {
+		return err
+	}
+
+	steps := meta.Bounds.Steps()
+	if err := builder.AddCols(steps); err != nil {
+		return err
+	}
+
+	for i := 0; i < steps; i++ {
+		if err := builder.AppendValues(i, values); err != nil {
+			return err
+		}
+	}
+
+	bl := builder.Build()
+	defer bl.Close()
+	return n.controller.Process(queryCtx, bl)
+}
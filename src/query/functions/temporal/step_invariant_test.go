@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package temporal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/compare"
+	"github.com/m3db/m3/src/query/test/executor"
+	"github.com/m3db/m3/src/query/test/transformtest"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestStepInvariantParentTimeSpec(t *testing.T) {
+	start := xtime.UnixNano(0).Add(time.Hour)
+	timeSpec := transform.TimeSpec{
+		Start: start,
+		End:   start.Add(time.Hour),
+		Step:  time.Minute,
+	}
+
+	// NB: the shift is aligned to the step so the query start is a step.
+	inner := NewStepInvariantOp(90*time.Second).ParentTimeSpec(timeSpec, 0)
+	assert.Equal(t, transform.TimeSpec{
+		Start: start.Add(-2 * time.Minute),
+		End:   start.Add(time.Minute),
+		Step:  time.Minute,
+	}, inner)
+
+	inner = NewStepInvariantOp(0).ParentTimeSpec(timeSpec, 5*time.Minute)
+	assert.Equal(t, transform.TimeSpec{
+		Start: start.Add(-5 * time.Minute),
+		End:   start.Add(time.Minute),
+		Step:  time.Minute,
+	}, inner)
+
+	at := transform.AtModifier{Timestamp: start.Add(-time.Hour)}
+	assert.Equal(t, time.Hour, at.Offset(inner))
+}
+
+func TestStepInvariantBroadcastsLastStep(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		inner = models.Bounds{
+			Start:    start.Add(-2 * time.Minute),
+			Duration: 3 * time.Minute,
+			StepSize: time.Minute,
+		}
+
+		values = [][]float64{
+			{1, 2, 3},
+			{4, 5, nan},
+		}
+	)
+
+	bl := test.NewBlockFromValuesWithMetaAndSeriesMeta(
+		block.Metadata{
+			Bounds:         inner,
+			Tags:           models.NewTags(0, models.NewTagOptions()),
+			ResultMetadata: block.NewResultMetadata(),
+		},
+		test.NewSeriesMeta("dummy", len(values)),
+		values,
+	)
+
+	opts := transformtest.Options(t, transform.OptionsParams{
+		TimeSpec: transform.TimeSpec{
+			Start: start,
+			End:   start.Add(4 * time.Minute),
+			Step:  time.Minute,
+		},
+	})
+
+	c, sink := executor.NewControllerWithSink(parser.NodeID("1"))
+	node := NewStepInvariantOp(0).Node(c, opts)
+	err := node.Process(models.NoopQueryContext(), parser.NodeID("0"), bl)
+	require.NoError(t, err)
+
+	expected := [][]float64{
+		{3, 3, 3, 3},
+		{nan, nan, nan, nan},
+	}
+
+	compare.EqualsWithNansWithDelta(t, expected, sink.Values, 0.0001)
+	assert.Equal(t, opts.TimeSpec().Bounds(), sink.Meta.Bounds)
+	assert.Equal(t, test.NewSeriesMeta("dummy", len(values)), sink.Metas)
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package temporal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/ts"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// SubqueryType evaluates an inner expression at its own resolution over a
+// range, presenting the results as raw datapoints for temporal functions.
+const SubqueryType = "subquery"
+
+// SubqueryOp stores required properties for a subquery.
+type SubqueryOp struct {
+	// Range is the range of the subquery.
+	Range time.Duration
+	// Step is the resolution the inner expression is evaluated at.
+	Step time.Duration
+	// Offset is the offset of the subquery.
+	Offset time.Duration
+	// InnerRange is the largest range selected by the inner expression, used
+	// to make sure that the first inner steps have enough data to be valid.
+	InnerRange time.Duration
+	// At pins the subquery to a fixed evaluation time if set.
+	At *transform.AtModifier
+}
+
+// NewSubqueryOp creates a new subquery operation.
+func NewSubqueryOp(
+	rangeDuration time.Duration,
+	step time.Duration,
+	offset time.Duration,
+	innerRange time.Duration,
+	at *transform.AtModifier,
+) (SubqueryOp, error) {
+	if rangeDuration <= 0 {
+		return SubqueryOp{}, fmt.Errorf("subquery range must be positive, "+
+			"received: %v", rangeDuration)
+	}
+
+	if step <= 0 {
+		return SubqueryOp{}, fmt.Errorf("subquery step must be positive, "+
+			"received: %v", step)
+	}
+
+	if offset < 0 {
+		return SubqueryOp{}, fmt.Errorf("subquery offset must be positive, "+
+			"received: %v", offset)
+	}
+
+	return SubqueryOp{
+		Range:      rangeDuration,
+		Step:       step,
+		Offset:     offset,
+		InnerRange: innerRange,
+		At:         at,
+	}, nil
+}
+
+// OpType for the operator.
+func (o SubqueryOp) OpType() string {
+	return SubqueryType
+}
+
+// String is the string representation for this operation.
+func (o SubqueryOp) String() string {
+	return fmt.Sprintf("type: %s, range: %v, step: %v, offset: %v",
+		o.OpType(), o.Range, o.Step, o.Offset)
+}
+
+// ResolveAtModifier resolves the @ modifier for this operation against the
+// given query bounds.
+func (o SubqueryOp) ResolveAtModifier(start, end xtime.UnixNano) parser.Params {
+	if o.At != nil {
+		at := o.At.Resolve(start, end)
+		o.At = &at
+	}
+
+	return o
+}
+
+// offset returns the total offset of the subquery for the given TimeSpec,
+// including any offset required to evaluate it at the time given by the @
+// modifier.
+func (o SubqueryOp) offset(timeSpec transform.TimeSpec) time.Duration {
+	if o.At == nil {
+		return o.Offset
+	}
+
+	return o.Offset + o.At.Offset(timeSpec)
+}
+
+// Bounds returns the bounds for this operation.
+func (o SubqueryOp) Bounds() transform.BoundSpec {
+	return transform.BoundSpec{
+		Range:  o.Range,
+		Offset: o.Offset,
+	}
+}
+
+// ParentTimeSpec returns the TimeSpec to evaluate the inner expression with.
+func (o SubqueryOp) ParentTimeSpec(
+	timeSpec transform.TimeSpec,
+	lookback time.Duration,
+) transform.TimeSpec {
+	shift := o.InnerRange
+	if shift == 0 {
+		shift = lookback
+	}
+
+	// NB: inner steps are aligned to multiples of the subquery step since
+	// the Unix epoch, as in Prometheus, so that results do not depend on the
+	// query start time. Truncate aligns to the zero time instead, which
+	// differs for steps that do not divide a day evenly.
+	offset := o.offset(timeSpec)
+	start := timeSpec.Start.Add(-1 * (o.Range + offset + shift))
+	start -= xtime.UnixNano(int64(start) % int64(o.Step))
+	return transform.TimeSpec{
+		Start: start,
+		End:   timeSpec.End.Add(-1 * offset),
+		Now:   timeSpec.Now,
+		Step:  o.Step,
+	}
+}
+
+// Node creates an execution node.
+func (o SubqueryOp) Node(
+	controller *transform.Controller,
+	opts transform.Options,
+) transform.OpNode {
+	return &subqueryNode{
+		op:         o,
+		controller: controller,
+		timeSpec:   opts.TimeSpec(),
+		offset:     o.offset(opts.TimeSpec()),
+	}
+}
+
+type subqueryNode struct {
+	op         SubqueryOp
+	controller *transform.Controller
+	timeSpec   transform.TimeSpec
+	offset     time.Duration
+}
+
+// Process converts the consolidated results of the inner expression into raw
+// datapoints bounded by the outer query.
+func (n *subqueryNode) Process(
+	queryCtx *models.QueryContext,
+	_ parser.NodeID,
+	b block.Block,
+) error {
+	iter, err := b.StepIter()
+	if err != nil {
+		return err
+	}
+
+	var (
+		seriesMetas = iter.SeriesMeta()
+		datapoints  = make([]ts.Datapoints, len(seriesMetas))
+	)
+
+	for iter.Next() {
+		step := iter.Current()
+		t := step.Time().Add(n.offset)
+		for i, v := range step.Values() {
+			if math.IsNaN(v) {
+				continue
+			}
+
+			datapoints[i] = append(datapoints[i], ts.Datapoint{
+				Timestamp: t,
+				Value:     v,
+			})
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	series := make([]block.UnconsolidatedSeries, 0, len(seriesMetas))
+	for i, meta := range seriesMetas {
+		series = append(series, block.NewUnconsolidatedSeries(datapoints[i],
+			meta, block.UnconsolidatedSeriesStats{}))
+	}
+
+	meta := b.Meta()
+	meta.Bounds = n.timeSpec.Bounds()
+	if err := b.Close(); err != nil {
+		return err
+	}
+
+	bl := &subqueryBlock{
+		meta:   meta,
+		series: series,
+	}
+
+	return n.controller.Process(queryCtx, bl)
+}
+
+var errSubqueryStepIter = errors.New("step iterator undefined for a " +
+	"subquery block, subqueries must be the argument to a range function")
+
+// subqueryBlock is a block holding the raw datapoints produced by a subquery.
+type subqueryBlock struct {
+	meta   block.Metadata
+	series []block.UnconsolidatedSeries
+}
+
+func (b *subqueryBlock) Meta() block.Metadata {
+	return b.meta
+}
+
+func (b *subqueryBlock) StepIter() (block.StepIter, error) {
+	return nil, errSubqueryStepIter
+}
+
+func (b *subqueryBlock) SeriesIter() (block.SeriesIter, error) {
+	return block.NewUnconsolidatedSeriesIter(b.series), nil
+}
+
+func (b *subqueryBlock) MultiSeriesIter(
+	concurrency int,
+) ([]block.SeriesIterBatch, error) {
+	if concurrency < 1 {
+		return nil, fmt.Errorf("batch size %d must be greater than 0",
+			concurrency)
+	}
+
+	var (
+		numSeries = len(b.series)
+		batchSize = numSeries / concurrency
+		batches   = make([]block.SeriesIterBatch, 0, concurrency)
+	)
+
+	if numSeries%concurrency != 0 {
+		batchSize++
+	}
+
+	for start := 0; start < numSeries; start += batchSize {
+		end := start + batchSize
+		if end > numSeries {
+			end = numSeries
+		}
+
+		batches = append(batches, block.SeriesIterBatch{
+			Iter: block.NewUnconsolidatedSeriesIter(b.series[start:end]),
+			Size: end - start,
+		})
+	}
+
+	return batches, nil
+}
+
+func (b *subqueryBlock) Info() block.BlockInfo {
+	return block.NewBlockInfo(block.BlockDecompressed)
+}
+
+func (b *subqueryBlock) Close() error {
+	return nil
+}
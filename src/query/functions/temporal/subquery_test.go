@@ -0,0 +1,173 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package temporal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/test"
+	"github.com/m3db/m3/src/query/test/compare"
+	"github.com/m3db/m3/src/query/test/executor"
+	"github.com/m3db/m3/src/query/test/transformtest"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestNewSubqueryOpValidation(t *testing.T) {
+	_, err := NewSubqueryOp(0, time.Minute, 0, 0, nil)
+	require.Error(t, err)
+
+	_, err = NewSubqueryOp(time.Hour, 0, 0, 0, nil)
+	require.Error(t, err)
+
+	_, err = NewSubqueryOp(time.Hour, time.Minute, -time.Minute, 0, nil)
+	require.Error(t, err)
+
+	op, err := NewSubqueryOp(time.Hour, time.Minute, time.Minute, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, SubqueryType, op.OpType())
+	assert.Equal(t, transform.BoundSpec{
+		Range:  time.Hour,
+		Offset: time.Minute,
+	}, op.Bounds())
+}
+
+func TestSubqueryParentTimeSpec(t *testing.T) {
+	start := xtime.UnixNano(0).Add(time.Hour + 30*time.Second)
+	timeSpec := transform.TimeSpec{
+		Start: start,
+		End:   start.Add(time.Hour),
+		Step:  15 * time.Second,
+	}
+
+	op, err := NewSubqueryOp(10*time.Minute, time.Minute, 0, 5*time.Minute, nil)
+	require.NoError(t, err)
+
+	// NB: shifted by the range and inner range, then aligned to the step.
+	inner := op.ParentTimeSpec(timeSpec, time.Minute)
+	assert.Equal(t, transform.TimeSpec{
+		Start: xtime.UnixNano(0).Add(45 * time.Minute),
+		End:   timeSpec.End,
+		Step:  time.Minute,
+	}, inner)
+
+	// NB: without an inner range, the lookback duration is used instead.
+	op, err = NewSubqueryOp(10*time.Minute, time.Minute, 2*time.Minute, 0, nil)
+	require.NoError(t, err)
+
+	inner = op.ParentTimeSpec(timeSpec, 5*time.Minute)
+	assert.Equal(t, transform.TimeSpec{
+		Start: xtime.UnixNano(0).Add(43 * time.Minute),
+		End:   timeSpec.End.Add(-2 * time.Minute),
+		Step:  time.Minute,
+	}, inner)
+}
+
+func TestSubqueryParentTimeSpecAlignsToEpoch(t *testing.T) {
+	// NB: 7m does not divide a day evenly, so aligning to the zero time
+	// rather than the Unix epoch would give a different start.
+	start := xtime.UnixNano(0).Add(24*time.Hour + 30*time.Second)
+	timeSpec := transform.TimeSpec{
+		Start: start,
+		End:   start.Add(time.Hour),
+		Step:  15 * time.Second,
+	}
+
+	op, err := NewSubqueryOp(10*time.Minute, 7*time.Minute, 0, 5*time.Minute, nil)
+	require.NoError(t, err)
+
+	inner := op.ParentTimeSpec(timeSpec, time.Minute)
+	assert.Equal(t, xtime.UnixNano(0).Add(203*7*time.Minute), inner.Start)
+	assert.Equal(t, 7*time.Minute, inner.Step)
+	assert.Equal(t, int64(0), int64(inner.Start)%int64(7*time.Minute))
+}
+
+func TestSubqueryWithTemporalFunction(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		inner = models.Bounds{
+			Start:    start,
+			Duration: 10 * time.Minute,
+			StepSize: time.Minute,
+		}
+
+		values = [][]float64{
+			{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			{nan, nan, nan, nan, nan, nan, 10, nan, nan, nan},
+		}
+	)
+
+	bl := test.NewBlockFromValuesWithMetaAndSeriesMeta(
+		block.Metadata{
+			Bounds:         inner,
+			Tags:           models.NewTags(0, models.NewTagOptions()),
+			ResultMetadata: block.NewResultMetadata(),
+		},
+		test.NewSeriesMeta("dummy", len(values)),
+		values,
+	)
+
+	opts := transformtest.Options(t, transform.OptionsParams{
+		TimeSpec: transform.TimeSpec{
+			Start: start.Add(5 * time.Minute),
+			End:   start.Add(10 * time.Minute),
+			Step:  time.Minute,
+		},
+	})
+
+	aggOp, err := NewAggOp([]interface{}{3 * time.Minute}, SumType)
+	require.NoError(t, err)
+
+	sinkController, sink := executor.NewControllerWithSink(parser.NodeID("2"))
+	aggController := &transform.Controller{ID: parser.NodeID("1")}
+	aggController.AddTransform(aggOp.Node(sinkController, opts))
+
+	subqueryOp, err := NewSubqueryOp(3*time.Minute, time.Minute, 0, 0, nil)
+	require.NoError(t, err)
+
+	node := subqueryOp.Node(aggController, opts)
+	err = node.Process(models.NoopQueryContext(), parser.NodeID("0"), bl)
+	require.NoError(t, err)
+
+	expected := [][]float64{
+		{14, 18, 22, 26, 30},
+		{nan, 10, 10, 10, 10},
+	}
+
+	compare.EqualsWithNansWithDelta(t, expected, sink.Values, 0.0001)
+	assert.Equal(t, opts.TimeSpec().Bounds(), sink.Meta.Bounds)
+}
+
+func TestSubqueryBlockStepIterErrors(t *testing.T) {
+	bl := &subqueryBlock{}
+	_, err := bl.StepIter()
+	require.Error(t, err)
+
+	batches, err := bl.MultiSeriesIter(4)
+	require.NoError(t, err)
+	assert.Len(t, batches, 0)
+}
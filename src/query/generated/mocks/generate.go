@@ -28,6 +28,7 @@
 //go:generate sh -c "mockgen -package=transform -destination=../../executor/transform/types_mock.go $PACKAGE/src/query/executor/transform OpNode"
 //go:generate sh -c "mockgen -package=executor -destination=../../executor/types_mock.go $PACKAGE/src/query/executor Engine"
 //go:generate sh -c "mockgen -package=storage -destination=../../graphite/storage/storage_mock.go $PACKAGE/src/query/graphite/storage Storage"
+//go:generate sh -c "mockgen -package=exemplar -destination=../../storage/exemplar/exemplar_mock.go $PACKAGE/src/query/storage/exemplar Store"
 
 // mockgen rules for generating mocks for unexported interfaces (file mode).
 //go:generate sh -c "mockgen -package=m3ql -destination=../../parser/m3ql/types_mock.go -source=../../parser/m3ql/types.go"
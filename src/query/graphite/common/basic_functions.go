@@ -277,7 +277,7 @@ func Changed(ctx *Context, seriesList ts.SeriesList, renamer SeriesRenamer) (ts.
 				vals.SetValueAt(i, 0)
 			}
 		}
-		newSeries := ts.NewSeries(ctx, renamer(series), series.StartTime(), vals)
+		newSeries := ts.NewSeriesWithTags(ctx, renamer(series), series.StartTime(), vals, series.Tags())
 		results = append(results, newSeries)
 	}
 	seriesList.Values = results
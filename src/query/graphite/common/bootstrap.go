@@ -36,7 +36,7 @@ func bootstrapWithIDs(ctx *Context, seriesList ts.SeriesList, startTime, endTime
 	for i, series := range seriesList.Values {
 		numSteps := dur / (series.MillisPerStep() * 1000 * 1000) // convert to ns for step calculation
 		vals := ts.NewValues(ctx, series.MillisPerStep(), numSteps)
-		bootstrapList[i] = ts.NewSeries(ctx, series.Name(), startTime, vals)
+		bootstrapList[i] = ts.NewSeriesWithTags(ctx, series.Name(), startTime, vals, series.Tags())
 	}
 
 	seriesList.Values = bootstrapList
@@ -75,7 +75,7 @@ func FetchWithBootstrap(ctx *Context, seriesList ts.SeriesList, duration time.Du
 		for j := numBootstrapValues; j < numCombinedValues; j++ {
 			values.SetValueAt(j, original.ValueAt(j-numBootstrapValues))
 		}
-		newSeries := ts.NewSeries(ctx, original.Name(), startTime, values)
+		newSeries := ts.NewSeriesWithTags(ctx, original.Name(), startTime, values, original.Tags())
 		newSeries.Specification = original.Specification
 		newSeriesList[i] = newSeries
 	}
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/graphite/context"
+	"github.com/m3db/m3/src/query/graphite/events"
 	"github.com/m3db/m3/src/query/storage"
 )
 
@@ -64,6 +65,9 @@ type contextBase struct {
 	// FetchOpts are the fetch options to use for the query.
 	FetchOpts *storage.FetchOptions
 
+	// Events is the graphite events store, nil if events are not enabled.
+	Events events.Store
+
 	parent         *Context
 	reqCtx         ctx.Context
 	storageContext context.Context
@@ -83,6 +87,7 @@ type ContextOptions struct {
 	Timeout       time.Duration
 	MaxDataPoints int64
 	FetchOpts     *storage.FetchOptions
+	Events        events.Store
 }
 
 // TimeRangeAdjustment is an applied time range adjustment.
@@ -106,6 +111,7 @@ func NewContext(options ContextOptions) *Context {
 			Timeout:        options.Timeout,
 			MaxDataPoints:  options.MaxDataPoints,
 			FetchOpts:      options.FetchOpts,
+			Events:         options.Events,
 		},
 	}
 }
@@ -144,7 +144,7 @@ func RemoveByPercentile(
 			}
 		}
 		name := pn(series.Name(), percentile)
-		newSeries := ts.NewSeries(ctx, name, series.StartTime(), vals)
+		newSeries := ts.NewSeriesWithTags(ctx, name, series.StartTime(), vals, series.Tags())
 		results = append(results, newSeries)
 	}
 	in.Values = results
@@ -198,7 +198,7 @@ func Transform(ctx *Context, in ts.SeriesList, t Transformer, renamer SeriesRena
 			values.SetValueAt(step, t.Apply(value))
 		}
 
-		results[i] = ts.NewSeries(ctx, renamer(series), series.StartTime(), values)
+		results[i] = ts.NewSeriesWithTags(ctx, renamer(series), series.StartTime(), values, series.Tags())
 	}
 
 	in.Values = results
@@ -303,7 +303,7 @@ func PerSecond(ctx *Context, in ts.SeriesList, renamer SeriesRenamer) (ts.Series
 			secsSinceLastVal = secsPerStep
 		}
 
-		s := ts.NewSeries(ctx, renamer(series), series.StartTime(), vals)
+		s := ts.NewSeriesWithTags(ctx, renamer(series), series.StartTime(), vals, series.Tags())
 		results = append(results, s)
 	}
 
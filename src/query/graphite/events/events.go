@@ -0,0 +1,96 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package events provides storage of graphite events, the annotations
+// dashboards draw as markers such as deploys on top of graphs.
+package events
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Event is a graphite event.
+type Event struct {
+	// ID uniquely identifies the event, it is set by the store.
+	ID string
+	// When is the time the event happened at.
+	When time.Time
+	// What is a short description of the event.
+	What string
+	// Data holds the event details.
+	Data string
+	// Tags are the tags the event can be searched by.
+	Tags []string
+}
+
+// SetOperation is the operation used to match the tags of a query.
+type SetOperation uint
+
+const (
+	// IntersectionSetOperation matches events having all of the query tags.
+	IntersectionSetOperation SetOperation = iota
+	// UnionSetOperation matches events having any of the query tags.
+	UnionSetOperation
+)
+
+// Query is a query for events.
+type Query struct {
+	// Start is the inclusive start of the queried time range.
+	Start time.Time
+	// End is the exclusive end of the queried time range.
+	End time.Time
+	// Tags restricts the matched events by tags, all events in the time
+	// range are matched when empty.
+	Tags []string
+	// Set is the operation used to match the tags.
+	Set SetOperation
+}
+
+// Store stores graphite events.
+type Store interface {
+	// Add stores the event, returning it with its ID set.
+	Add(ctx context.Context, event Event) (Event, error)
+
+	// Find returns the events matching the query ordered by time.
+	Find(ctx context.Context, query Query) ([]Event, error)
+}
+
+// NormalizeTags returns the sorted unique tags, splitting any tag containing
+// whitespace into several tags as graphite does.
+func NormalizeTags(tags []string) []string {
+	var (
+		result = make([]string, 0, len(tags))
+		seen   = make(map[string]struct{}, len(tags))
+	)
+	for _, tag := range tags {
+		for _, field := range strings.Fields(tag) {
+			if _, ok := seen[field]; ok {
+				continue
+			}
+			seen[field] = struct{}{}
+			result = append(result, field)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,249 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package events
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/generated/proto/eventspb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+const (
+	// IDTagName is the name of the tag holding the ID of an event series.
+	IDTagName = "event_id"
+	// TagsTagName is the name of the tag holding the space separated tags
+	// of an event series.
+	TagsTagName = "tags"
+
+	seriesIDPrefix = "graphite_event_"
+	tagsSeparator  = " "
+)
+
+var errNoNamespace = errors.New("no namespace to store graphite events in")
+
+// NamespaceFn returns the session and the namespace to store events in.
+type NamespaceFn func() (client.Session, ident.ID, error)
+
+type m3Store struct {
+	namespaceFn NamespaceFn
+	limit       int
+}
+
+// NewM3Store returns a store writing each event as a single datapoint
+// series of a dbnode namespace, the event itself is stored as the protobuf
+// encoded annotation of the datapoint so the namespace can use the proto
+// encoding with the eventspb.Event schema. Limit bounds the number of
+// events returned by a query, no limit is applied if zero.
+func NewM3Store(namespaceFn NamespaceFn, limit int) Store {
+	return &m3Store{
+		namespaceFn: namespaceFn,
+		limit:       limit,
+	}
+}
+
+func (s *m3Store) Add(_ context.Context, event Event) (Event, error) {
+	session, namespace, err := s.namespace()
+	if err != nil {
+		return Event{}, err
+	}
+
+	event.When = event.When.Truncate(time.Second)
+	event.Tags = NormalizeTags(event.Tags)
+	pb := eventspb.Event{
+		What: event.What,
+		Data: event.Data,
+		Tags: event.Tags,
+	}
+	annotation, err := pb.Marshal()
+	if err != nil {
+		return Event{}, err
+	}
+
+	event.ID = eventID(event.When, annotation)
+	tags := ident.NewTags(ident.StringTag(IDTagName, event.ID))
+	if len(event.Tags) > 0 {
+		tags.Append(ident.StringTag(TagsTagName,
+			strings.Join(event.Tags, tagsSeparator)))
+	}
+
+	err = session.WriteTagged(namespace, ident.StringID(seriesIDPrefix+event.ID),
+		ident.NewTagsIterator(tags), xtime.ToUnixNano(event.When), 1,
+		xtime.Second, annotation)
+	if err != nil {
+		return Event{}, err
+	}
+
+	return event, nil
+}
+
+func (s *m3Store) Find(ctx context.Context, query Query) ([]Event, error) {
+	session, namespace, err := s.namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	matchers, err := tagMatchers(query)
+	if err != nil {
+		return nil, err
+	}
+
+	fetchQuery := &storage.FetchQuery{
+		TagMatchers: matchers,
+		Start:       query.Start,
+		End:         query.End,
+	}
+	m3Query, err := storage.FetchQueryToM3Query(fetchQuery, storage.NewFetchOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	iters, _, err := session.FetchTagged(ctx, namespace, m3Query, index.QueryOptions{
+		StartInclusive: xtime.ToUnixNano(query.Start),
+		EndExclusive:   xtime.ToUnixNano(query.End),
+		SeriesLimit:    s.limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer iters.Close()
+
+	events := make([]Event, 0, iters.Len())
+	for _, iter := range iters.Iters() {
+		id := strings.TrimPrefix(iter.ID().String(), seriesIDPrefix)
+		for iter.Next() {
+			dp, _, annotation := iter.Current()
+			var pb eventspb.Event
+			if err := pb.Unmarshal(annotation); err != nil {
+				return nil, fmt.Errorf("could not decode event %s: %w", id, err)
+			}
+
+			events = append(events, Event{
+				ID:   id,
+				When: dp.TimestampNanos.ToTime(),
+				What: pb.What,
+				Data: pb.Data,
+				Tags: pb.Tags,
+			})
+		}
+
+		if err := iter.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if !events[i].When.Equal(events[j].When) {
+			return events[i].When.Before(events[j].When)
+		}
+		return events[i].ID < events[j].ID
+	})
+
+	return events, nil
+}
+
+func (s *m3Store) namespace() (client.Session, ident.ID, error) {
+	session, namespace, err := s.namespaceFn()
+	if err != nil {
+		return nil, nil, err
+	}
+	if session == nil || namespace == nil {
+		return nil, nil, errNoNamespace
+	}
+	return session, namespace, nil
+}
+
+// eventID returns an ID derived from the time and content of the event so
+// that adding the same event twice does not duplicate it.
+func eventID(when time.Time, annotation []byte) string {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(when.UnixNano()))
+
+	h := fnv.New64a()
+	_, _ = h.Write(buf[:])
+	_, _ = h.Write(annotation)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// tagMatchers returns the matchers of the event series matching the tags of
+// the query, tags being stored space separated in a single series tag. Event
+// series are always matched by their ID tag so that queries without tags do
+// not match every series of the namespace.
+func tagMatchers(query Query) (models.Matchers, error) {
+	idMatcher, err := models.NewMatcher(models.MatchRegexp,
+		[]byte(IDTagName), []byte(".+"))
+	if err != nil {
+		return nil, err
+	}
+
+	tags := NormalizeTags(query.Tags)
+	if len(tags) == 0 {
+		return models.Matchers{idMatcher}, nil
+	}
+
+	quoted := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		quoted = append(quoted, regexp.QuoteMeta(tag))
+	}
+
+	var patterns []string
+	switch query.Set {
+	case UnionSetOperation:
+		patterns = []string{tagPattern("(" + strings.Join(quoted, "|") + ")")}
+	case IntersectionSetOperation:
+		for _, q := range quoted {
+			patterns = append(patterns, tagPattern(q))
+		}
+	default:
+		return nil, fmt.Errorf("unknown set operation: %d", query.Set)
+	}
+
+	matchers := make(models.Matchers, 0, 1+len(patterns))
+	matchers = append(matchers, idMatcher)
+	for _, pattern := range patterns {
+		matcher, err := models.NewMatcher(models.MatchRegexp,
+			[]byte(TagsTagName), []byte(pattern))
+		if err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+
+	return matchers, nil
+}
+
+func tagPattern(tag string) string {
+	return "(.*" + tagsSeparator + ")?" + tag + "(" + tagsSeparator + ".*)?"
+}
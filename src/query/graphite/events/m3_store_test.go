@@ -0,0 +1,235 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package events
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/generated/proto/eventspb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestM3Store(session client.Session) Store {
+	return NewM3Store(func() (client.Session, ident.ID, error) {
+		return session, ident.StringID("events"), nil
+	}, 100)
+}
+
+func TestM3StoreAdd(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		session = client.NewMockSession(ctrl)
+		store   = newTestM3Store(session)
+		when    = time.Unix(1600000000, 500)
+	)
+
+	session.EXPECT().
+		WriteTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			float64(1), xtime.Second, gomock.Any()).
+		DoAndReturn(func(
+			namespace, id ident.ID,
+			tags ident.TagIterator,
+			timestamp xtime.UnixNano,
+			_ float64,
+			_ xtime.Unit,
+			annotation []byte,
+		) error {
+			assert.Equal(t, "events", namespace.String())
+			assert.Equal(t, xtime.ToUnixNano(time.Unix(1600000000, 0)), timestamp)
+
+			actual := make(map[string]string)
+			for tags.Next() {
+				tag := tags.Current()
+				actual[tag.Name.String()] = tag.Value.String()
+			}
+			require.NoError(t, tags.Err())
+			assert.Equal(t, seriesIDPrefix+actual[IDTagName], id.String())
+			assert.Equal(t, "deploy master", actual[TagsTagName])
+
+			var pb eventspb.Event
+			require.NoError(t, pb.Unmarshal(annotation))
+			assert.Equal(t, eventspb.Event{
+				What: "deploy",
+				Data: "v1.2.3",
+				Tags: []string{"deploy", "master"},
+			}, pb)
+			return nil
+		}).
+		Times(2)
+
+	event := Event{
+		When: when,
+		What: "deploy",
+		Data: "v1.2.3",
+		Tags: []string{"master deploy", "deploy"},
+	}
+	added, err := store.Add(context.Background(), event)
+	require.NoError(t, err)
+	assert.NotEmpty(t, added.ID)
+	assert.Equal(t, []string{"deploy", "master"}, added.Tags)
+
+	again, err := store.Add(context.Background(), event)
+	require.NoError(t, err)
+	assert.Equal(t, added.ID, again.ID)
+}
+
+func newTestEventIterator(
+	ctrl *gomock.Controller,
+	id string,
+	when time.Time,
+	event eventspb.Event,
+) encoding.SeriesIterator {
+	annotation, err := event.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	iter := encoding.NewMockSeriesIterator(ctrl)
+	iter.EXPECT().ID().Return(ident.StringID(seriesIDPrefix + id)).AnyTimes()
+	gomock.InOrder(
+		iter.EXPECT().Next().Return(true),
+		iter.EXPECT().Next().Return(false),
+	)
+	iter.EXPECT().Current().Return(ts.Datapoint{
+		TimestampNanos: xtime.ToUnixNano(when),
+		Value:          1,
+	}, xtime.Second, ts.Annotation(annotation))
+	iter.EXPECT().Err().Return(nil)
+	iter.EXPECT().Close()
+	return iter
+}
+
+func TestM3StoreFind(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		session = client.NewMockSession(ctrl)
+		store   = newTestM3Store(session)
+		start   = time.Unix(1600000000, 0)
+		end     = start.Add(time.Hour)
+	)
+
+	iters := encoding.NewSeriesIterators([]encoding.SeriesIterator{
+		newTestEventIterator(ctrl, "b", start.Add(time.Minute),
+			eventspb.Event{What: "second", Tags: []string{"deploy"}}),
+		newTestEventIterator(ctrl, "a", start,
+			eventspb.Event{What: "first", Data: "data", Tags: []string{"deploy"}}),
+	})
+	session.EXPECT().
+		FetchTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			namespace ident.ID,
+			_ index.Query,
+			opts index.QueryOptions,
+		) (encoding.SeriesIterators, client.FetchResponseMetadata, error) {
+			assert.Equal(t, "events", namespace.String())
+			assert.Equal(t, xtime.ToUnixNano(start), opts.StartInclusive)
+			assert.Equal(t, xtime.ToUnixNano(end), opts.EndExclusive)
+			assert.Equal(t, 100, opts.SeriesLimit)
+			return iters, client.FetchResponseMetadata{Exhaustive: true}, nil
+		})
+
+	found, err := store.Find(context.Background(), Query{
+		Start: start,
+		End:   end,
+		Tags:  []string{"deploy"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(found))
+
+	assert.Equal(t, "a", found[0].ID)
+	assert.True(t, start.Equal(found[0].When))
+	assert.Equal(t, "first", found[0].What)
+	assert.Equal(t, "data", found[0].Data)
+	assert.Equal(t, []string{"deploy"}, found[0].Tags)
+
+	assert.Equal(t, "b", found[1].ID)
+	assert.True(t, start.Add(time.Minute).Equal(found[1].When))
+	assert.Equal(t, "second", found[1].What)
+}
+
+func TestTagMatchers(t *testing.T) {
+	idMatcher := models.Matcher{
+		Type:  models.MatchRegexp,
+		Name:  []byte(IDTagName),
+		Value: []byte(".+"),
+	}
+
+	matchers, err := tagMatchers(Query{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(matchers))
+	assert.Equal(t, idMatcher.String(), matchers[0].String())
+
+	tests := []struct {
+		set      SetOperation
+		tags     string
+		expected bool
+	}{
+		{set: IntersectionSetOperation, tags: "a b.c", expected: true},
+		{set: IntersectionSetOperation, tags: "a bxc", expected: false},
+		{set: IntersectionSetOperation, tags: "b.c", expected: false},
+		{set: UnionSetOperation, tags: "b.c", expected: true},
+		{set: UnionSetOperation, tags: "x a y", expected: true},
+		{set: UnionSetOperation, tags: "ab", expected: false},
+	}
+
+	for _, tt := range tests {
+		matchers, err := tagMatchers(Query{
+			Tags: []string{"a", "b.c"},
+			Set:  tt.set,
+		})
+		require.NoError(t, err)
+		require.True(t, len(matchers) > 1)
+		assert.Equal(t, idMatcher.String(), matchers[0].String())
+
+		matched := true
+		for _, matcher := range matchers[1:] {
+			assert.Equal(t, TagsTagName, string(matcher.Name))
+			re := regexp.MustCompile("^(?:" + string(matcher.Value) + ")$")
+			matched = matched && re.MatchString(tt.tags)
+		}
+		assert.Equal(t, tt.expected, matched, "set=%d tags=%s", tt.set, tt.tags)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"a", "b", "c"},
+		NormalizeTags([]string{"c a", "", "b", "a"}))
+	assert.Equal(t, []string{}, NormalizeTags(nil))
+}
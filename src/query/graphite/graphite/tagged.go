@@ -0,0 +1,207 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// TaggedNameTag is the tag holding the metric path of a graphite tagged
+	// series, i.e. "disk.used" for the series "disk.used;dc=east".
+	TaggedNameTag = "name"
+
+	// TaggedSeparator separates the metric path and the tags of a graphite
+	// tagged series name.
+	TaggedSeparator = ';'
+
+	seriesByTagFunction = "seriesByTag"
+)
+
+var (
+	errNoTagExpressions        = errors.New("seriesByTag requires at least one tag expression")
+	errNoMatchingTagExpression = errors.New("seriesByTag requires at least one " +
+		"tag expression matching a non-empty value")
+)
+
+// TagExpressionOp is the operator of a seriesByTag tag expression.
+type TagExpressionOp uint
+
+const (
+	// TagExpressionEqual matches tags with the value, "a=b".
+	TagExpressionEqual TagExpressionOp = iota
+	// TagExpressionNotEqual matches tags without the value, "a!=b".
+	TagExpressionNotEqual
+	// TagExpressionRegexp matches tags with a value matching the regexp, "a=~b".
+	TagExpressionRegexp
+	// TagExpressionNotRegexp matches tags with a value not matching the
+	// regexp, "a!=~b".
+	TagExpressionNotRegexp
+)
+
+// TagExpression is a single seriesByTag tag expression.
+type TagExpression struct {
+	Name  string
+	Op    TagExpressionOp
+	Value string
+}
+
+// ParseTagExpression parses a seriesByTag tag expression such as "dc=east"
+// or "host!=~web.*".
+func ParseTagExpression(expr string) (TagExpression, error) {
+	idx := strings.IndexByte(expr, '=')
+	if idx < 0 {
+		return TagExpression{}, fmt.Errorf("invalid tag expression: %s", expr)
+	}
+
+	var (
+		name  = expr[:idx]
+		value = expr[idx+1:]
+		op    = TagExpressionEqual
+	)
+	if strings.HasSuffix(name, "!") {
+		name = name[:len(name)-1]
+		op = TagExpressionNotEqual
+	}
+	if strings.HasPrefix(value, "~") {
+		value = value[1:]
+		if op == TagExpressionEqual {
+			op = TagExpressionRegexp
+		} else {
+			op = TagExpressionNotRegexp
+		}
+	}
+	if len(name) == 0 {
+		return TagExpression{}, fmt.Errorf("invalid tag expression, no tag name: %s", expr)
+	}
+
+	return TagExpression{Name: name, Op: op, Value: value}, nil
+}
+
+func (e TagExpression) matchesValue() bool {
+	switch e.Op {
+	case TagExpressionEqual:
+		return len(e.Value) > 0
+	case TagExpressionRegexp:
+		return true
+	}
+	return false
+}
+
+// SeriesByTagQuery returns the seriesByTag call matching the given tag
+// expressions, this is used as the fetch query of tagged series.
+func SeriesByTagQuery(exprs []string) string {
+	var b strings.Builder
+	b.WriteString(seriesByTagFunction)
+	b.WriteByte('(')
+	for i, expr := range exprs {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('\'')
+		b.WriteString(expr)
+		b.WriteByte('\'')
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// ParseSeriesByTagQuery returns the tag expressions of a seriesByTag fetch
+// query and true, or false if the query is not a seriesByTag call.
+func ParseSeriesByTagQuery(query string) ([]TagExpression, bool, error) {
+	if !strings.HasPrefix(query, seriesByTagFunction+"(") ||
+		!strings.HasSuffix(query, ")") {
+		return nil, false, nil
+	}
+
+	var (
+		args  = query[len(seriesByTagFunction)+1 : len(query)-1]
+		exprs []TagExpression
+	)
+	for len(args) > 0 {
+		quote := args[0]
+		if quote != '\'' && quote != '"' {
+			return nil, true, fmt.Errorf("invalid seriesByTag query, "+
+				"expected quoted tag expression: %s", query)
+		}
+
+		end := strings.IndexByte(args[1:], quote)
+		if end < 0 {
+			return nil, true, fmt.Errorf("invalid seriesByTag query, "+
+				"unterminated tag expression: %s", query)
+		}
+
+		expr, err := ParseTagExpression(args[1 : end+1])
+		if err != nil {
+			return nil, true, err
+		}
+		exprs = append(exprs, expr)
+
+		args = strings.TrimLeft(args[end+2:], " ")
+		if len(args) > 0 {
+			if args[0] != ',' {
+				return nil, true, fmt.Errorf("invalid seriesByTag query, "+
+					"expected ',' between tag expressions: %s", query)
+			}
+			args = strings.TrimLeft(args[1:], " ")
+		}
+	}
+
+	if len(exprs) == 0 {
+		return nil, true, errNoTagExpressions
+	}
+
+	// NB: like graphite, do not allow queries only made of negative matches
+	// since they would select the entire index.
+	for _, expr := range exprs {
+		if expr.matchesValue() {
+			return exprs, true, nil
+		}
+	}
+
+	return nil, true, errNoMatchingTagExpression
+}
+
+// TaggedName formats tags as a graphite tagged series name, the metric path
+// held by the "name" tag followed by the other tags sorted by name, so that
+// {name: disk.used, dc: east} returns "disk.used;dc=east".
+func TaggedName(tags map[string]string) string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		if name != TaggedNameTag {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(tags[TaggedNameTag])
+	for _, name := range names {
+		b.WriteByte(TaggedSeparator)
+		b.WriteString(name)
+		b.WriteByte('=')
+		b.WriteString(tags[name])
+	}
+	return b.String()
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package graphite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTagExpression(t *testing.T) {
+	for _, test := range []struct {
+		expr     string
+		expected TagExpression
+	}{
+		{
+			expr:     "dc=east",
+			expected: TagExpression{Name: "dc", Op: TagExpressionEqual, Value: "east"},
+		},
+		{
+			expr:     "dc!=east",
+			expected: TagExpression{Name: "dc", Op: TagExpressionNotEqual, Value: "east"},
+		},
+		{
+			expr:     "name=~disk.*",
+			expected: TagExpression{Name: "name", Op: TagExpressionRegexp, Value: "disk.*"},
+		},
+		{
+			expr:     "host!=~web[0-9]+",
+			expected: TagExpression{Name: "host", Op: TagExpressionNotRegexp, Value: "web[0-9]+"},
+		},
+		{
+			expr:     "dc=",
+			expected: TagExpression{Name: "dc", Op: TagExpressionEqual},
+		},
+	} {
+		t.Run(test.expr, func(t *testing.T) {
+			actual, err := ParseTagExpression(test.expr)
+			require.NoError(t, err)
+			require.Equal(t, test.expected, actual)
+		})
+	}
+
+	for _, expr := range []string{"dc", "=east", "!=east", ""} {
+		_, err := ParseTagExpression(expr)
+		require.Error(t, err, expr)
+	}
+}
+
+func TestSeriesByTagQuery(t *testing.T) {
+	query := SeriesByTagQuery([]string{"name=disk.used", "dc=~east|west"})
+	require.Equal(t, "seriesByTag('name=disk.used','dc=~east|west')", query)
+
+	exprs, ok, err := ParseSeriesByTagQuery(query)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []TagExpression{
+		{Name: "name", Op: TagExpressionEqual, Value: "disk.used"},
+		{Name: "dc", Op: TagExpressionRegexp, Value: "east|west"},
+	}, exprs)
+
+	exprs, ok, err = ParseSeriesByTagQuery(`seriesByTag("a=~x{1,2}", 'b!=c')`)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []TagExpression{
+		{Name: "a", Op: TagExpressionRegexp, Value: "x{1,2}"},
+		{Name: "b", Op: TagExpressionNotEqual, Value: "c"},
+	}, exprs)
+
+	_, ok, err = ParseSeriesByTagQuery("foo.bar.*")
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	for _, query := range []string{
+		"seriesByTag()",
+		"seriesByTag(a=b)",
+		"seriesByTag('a=b)",
+		"seriesByTag('a=b' 'c=d')",
+		"seriesByTag('a')",
+		"seriesByTag('a!=b','c=')",
+	} {
+		_, ok, err := ParseSeriesByTagQuery(query)
+		require.True(t, ok, query)
+		require.Error(t, err, query)
+	}
+}
+
+func TestTaggedName(t *testing.T) {
+	require.Equal(t, "disk.used;dc=east;host=a", TaggedName(map[string]string{
+		"name": "disk.used",
+		"host": "a",
+		"dc":   "east",
+	}))
+	require.Equal(t, "foo.bar.baz", TaggedName(map[string]string{"name": "foo.bar.baz"}))
+}
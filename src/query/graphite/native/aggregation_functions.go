@@ -21,6 +21,7 @@
 package native
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"runtime"
@@ -30,6 +31,7 @@ import (
 
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/graphite/common"
+	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/graphite/ts"
 	xerrors "github.com/m3db/m3/src/x/errors"
 )
@@ -328,6 +330,64 @@ func aggregate(ctx *common.Context, series singlePathSpec, fname string) (ts.Ser
 	}
 }
 
+// aggregateSeriesLists aggregates the series of two lists pairwise, in order,
+// using the specified function. Both lists must have the same length.
+func aggregateSeriesLists(
+	ctx *common.Context,
+	seriesListFirstPos singlePathSpec,
+	seriesListSecondPos singlePathSpec,
+	fname string,
+) (ts.SeriesList, error) {
+	if len(seriesListFirstPos.Values) != len(seriesListSecondPos.Values) {
+		err := xerrors.NewInvalidParamsError(fmt.Errorf(
+			"aggregateSeriesLists both SeriesLists must have exactly the same length"))
+		return ts.NewSeriesList(), err
+	}
+
+	// If either list is not sorted yet then apply a default sort for deterministic results.
+	if !seriesListFirstPos.SortApplied {
+		// Use sort.Stable for deterministic output.
+		sort.Stable(ts.SeriesByName(seriesListFirstPos.Values))
+		seriesListFirstPos.SortApplied = true
+	}
+	if !seriesListSecondPos.SortApplied {
+		// Use sort.Stable for deterministic output.
+		sort.Stable(ts.SeriesByName(seriesListSecondPos.Values))
+		seriesListSecondPos.SortApplied = true
+	}
+
+	results := make([]*ts.Series, 0, len(seriesListFirstPos.Values))
+	for idx, first := range seriesListFirstPos.Values {
+		second := seriesListSecondPos.Values[idx]
+		pair := singlePathSpec{
+			Values:   []*ts.Series{first, second},
+			Metadata: seriesListFirstPos.Metadata.CombineMetadata(seriesListSecondPos.Metadata),
+		}
+		aggregated, err := aggregate(ctx, pair, fname)
+		if err != nil {
+			return ts.NewSeriesList(), err
+		}
+		if aggregated.Len() == 0 {
+			continue
+		}
+
+		// Rename to "<fn>Series(first,second)" as graphite-web does.
+		result := aggregated.Values[0]
+		prefix := result.Name()
+		if idx := strings.Index(prefix, "Series("); idx >= 0 {
+			prefix = prefix[:idx]
+		}
+		name := fmt.Sprintf("%sSeries(%s,%s)", prefix, first.Name(), second.Name())
+		results = append(results, result.RenamedTo(name))
+	}
+
+	r := ts.SeriesList(seriesListFirstPos)
+	// Set sorted as we sorted any input that wasn't already sorted.
+	r.SortApplied = true
+	r.Values = results
+	return r, nil
+}
+
 // averageSeriesWithWildcards splits the given set of series into sub-groupings
 // based on wildcard matches in the hierarchy, then averages the values in each
 // grouping
@@ -702,6 +762,41 @@ func groupByNodes(ctx *common.Context, seriesList singlePathSpec, fname string,
 	return applyFnToMetaSeries(ctx, seriesList, metaSeries, fname)
 }
 
+// groupByTags takes a serieslist and maps a callback to subgroups within as
+// defined by a common set of tag values
+//
+//	&target=groupByTags(seriesByTag("name=cpu","dc=dc1"),"sum","dc")
+//
+// Would return multiple series which are each the result of applying the "sum"
+// aggregation to groups joined on the "dc" tag, the series are named after the
+// tags of the group and the aggregation, or the "name" tag when grouped by it:
+//
+//	sum;dc=dc1
+func groupByTags(ctx *common.Context, seriesList singlePathSpec, fname string, tags ...string) (ts.SeriesList, error) {
+	if len(tags) == 0 {
+		err := xerrors.NewInvalidParamsError(errors.New("groupByTags requires at least one tag"))
+		return ts.NewSeriesList(), err
+	}
+
+	metaSeries := make(map[string][]*ts.Series)
+	for _, s := range seriesList.Values {
+		seriesTags, err := getSeriesTags(s)
+		if err != nil {
+			return ts.NewSeriesList(), err
+		}
+
+		keyTags := map[string]string{graphite.TaggedNameTag: fname}
+		for _, tag := range tags {
+			keyTags[tag] = seriesTags[tag]
+		}
+
+		key := graphite.TaggedName(keyTags)
+		metaSeries[key] = append(metaSeries[key], s)
+	}
+
+	return applyFnToMetaSeries(ctx, seriesList, metaSeries, fname)
+}
+
 func applyFnToMetaSeries(ctx *common.Context, series singlePathSpec, metaSeries map[string][]*ts.Series, fname string) (ts.SeriesList, error) {
 	newSeries := make([]*ts.Series, 0, len(metaSeries))
 	for key, metaSeries := range metaSeries {
@@ -906,6 +906,75 @@ func TestGroupByNodes(t *testing.T) {
 	}
 }
 
+func TestGroupByTags(t *testing.T) {
+	var (
+		start, _ = time.Parse(time.RFC1123, "Mon, 27 Jul 2015 19:41:19 GMT")
+		end, _   = time.Parse(time.RFC1123, "Mon, 27 Jul 2015 19:43:19 GMT")
+		ctx      = common.NewContext(common.ContextOptions{Start: start, End: end})
+		inputs   = []*ts.Series{
+			ts.NewSeriesWithTags(ctx, "cpu.load;dc=east;host=a", start,
+				ts.NewConstantValues(ctx, 2, 12, 10000),
+				map[string]string{"name": "cpu.load", "dc": "east", "host": "a"}),
+			ts.NewSeriesWithTags(ctx, "cpu.load;dc=east;host=b", start,
+				ts.NewConstantValues(ctx, 4, 12, 10000),
+				map[string]string{"name": "cpu.load", "dc": "east", "host": "b"}),
+			ts.NewSeriesWithTags(ctx, "cpu.load;dc=west;host=c", start,
+				ts.NewConstantValues(ctx, 6, 12, 10000),
+				map[string]string{"name": "cpu.load", "dc": "west", "host": "c"}),
+			ts.NewSeriesWithTags(ctx, "cpu.idle;dc=west;host=c", start,
+				ts.NewConstantValues(ctx, 8, 12, 10000),
+				map[string]string{"name": "cpu.idle", "dc": "west", "host": "c"}),
+		}
+	)
+
+	defer ctx.Close()
+
+	type result struct {
+		name      string
+		sumOfVals float64
+	}
+
+	tests := []struct {
+		fname           string
+		tags            []string
+		expectedResults []result
+	}{
+		{"sum", []string{"dc"}, []result{
+			{"sum;dc=east", (2 + 4) * 12},
+			{"sum;dc=west", (6 + 8) * 12},
+		}},
+		{"max", []string{"name", "dc"}, []result{
+			{"cpu.idle;dc=west", 8 * 12},
+			{"cpu.load;dc=east", 4 * 12},
+			{"cpu.load;dc=west", 6 * 12},
+		}},
+		{"sum", []string{"role"}, []result{ // test missing tag
+			{"sum;role=", (2 + 4 + 6 + 8) * 12},
+		}},
+	}
+
+	for _, test := range tests {
+		outSeries, err := groupByTags(ctx, singlePathSpec{
+			Values: inputs,
+		}, test.fname, test.tags...)
+		require.NoError(t, err)
+		require.Equal(t, len(test.expectedResults), len(outSeries.Values))
+
+		outSeries, _ = sortByName(ctx, singlePathSpec(outSeries), false, false)
+
+		for i, expected := range test.expectedResults {
+			series := outSeries.Values[i]
+			assert.Equal(t, expected.name, series.Name(),
+				"wrong name for %v %s (%d)", test.tags, test.fname, i)
+			assert.Equal(t, expected.sumOfVals, series.SafeSum(),
+				"wrong result for %v %s (%d)", test.tags, test.fname, i)
+		}
+	}
+
+	_, err := groupByTags(ctx, singlePathSpec{Values: inputs}, "sum")
+	require.Error(t, err)
+}
+
 func TestWeightedAverage(t *testing.T) {
 	ctx, _ := newConsolidationTestSeries()
 	defer ctx.Close()
@@ -964,3 +1033,64 @@ func TestCountSeries(t *testing.T) {
 	common.CompareOutputsAndExpected(t, input[1].MillisPerStep(), input[1].StartTime(),
 		[]common.TestSeries{expected}, results.Values)
 }
+
+func TestAggregateSeriesLists(t *testing.T) {
+	start := time.Now().Truncate(time.Minute).Add(-10 * time.Minute)
+	end := start.Add(3 * time.Minute)
+	ctx := common.NewContext(common.ContextOptions{Start: start, End: end})
+	defer func() { _ = ctx.Close() }()
+
+	nan := math.NaN()
+	first := []*ts.Series{
+		ts.NewSeries(ctx, "b", start,
+			common.NewTestSeriesValues(ctx, 60000, []float64{1, 2, 3})),
+		ts.NewSeries(ctx, "a", start,
+			common.NewTestSeriesValues(ctx, 60000, []float64{4, nan, 6})),
+	}
+	second := []*ts.Series{
+		ts.NewSeries(ctx, "c", start,
+			common.NewTestSeriesValues(ctx, 60000, []float64{10, 20, 30})),
+		ts.NewSeries(ctx, "d", start,
+			common.NewTestSeriesValues(ctx, 60000, []float64{40, 50, nan})),
+	}
+
+	res, err := aggregateSeriesLists(ctx, singlePathSpec{
+		Values: first,
+	}, singlePathSpec{
+		Values: second,
+	}, "sum")
+	require.NoError(t, err)
+	expected := []common.TestSeries{
+		{Name: "sumSeries(a,c)", Data: []float64{14, 20, 36}},
+		{Name: "sumSeries(b,d)", Data: []float64{41, 52, 3}},
+	}
+	common.CompareOutputsAndExpected(t, 60000, start, expected, res.Values)
+
+	res, err = aggregateSeriesLists(ctx, singlePathSpec{
+		Values: first,
+	}, singlePathSpec{
+		Values: second,
+	}, "max")
+	require.NoError(t, err)
+	expected = []common.TestSeries{
+		{Name: "maxSeries(a,c)", Data: []float64{10, 20, 30}},
+		{Name: "maxSeries(b,d)", Data: []float64{40, 50, 3}},
+	}
+	common.CompareOutputsAndExpected(t, 60000, start, expected, res.Values)
+
+	// error - lists of different lengths
+	_, err = aggregateSeriesLists(ctx, singlePathSpec{
+		Values: first,
+	}, singlePathSpec{
+		Values: second[:1],
+	}, "sum")
+	require.Error(t, err)
+
+	// error - unknown aggregation function
+	_, err = aggregateSeriesLists(ctx, singlePathSpec{
+		Values: first,
+	}, singlePathSpec{
+		Values: second,
+	}, "unknown")
+	require.Error(t, err)
+}
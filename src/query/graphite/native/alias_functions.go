@@ -21,10 +21,13 @@
 package native
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/m3db/m3/src/query/graphite/common"
+	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/graphite/ts"
+	xerrors "github.com/m3db/m3/src/x/errors"
 )
 
 // alias takes one metric or a wildcard seriesList and a string in quotes.
@@ -69,6 +72,60 @@ func aliasByNode(ctx *common.Context, seriesList singlePathSpec, nodes ...int) (
 	return ts.SeriesList(seriesList), nil
 }
 
+// aliasByTags renames a time series result according to a subset of its tags,
+// given either by tag name or by node index into the "name" tag.
+func aliasByTags(ctx *common.Context, seriesList singlePathSpec, tags ...genericInterface) (ts.SeriesList, error) {
+	renamed := make([]*ts.Series, 0, ts.SeriesList(seriesList).Len())
+	for _, series := range seriesList.Values {
+		seriesTags, err := getSeriesTags(series)
+		if err != nil {
+			return ts.SeriesList{}, err
+		}
+
+		nameParts := strings.Split(seriesTags[graphite.TaggedNameTag], ".")
+		newNameParts := make([]string, 0, len(tags))
+		for _, tag := range tags {
+			switch v := tag.(type) {
+			case string:
+				newNameParts = append(newNameParts, seriesTags[v])
+			case float64:
+				node := int(v)
+				if node < 0 {
+					node += len(nameParts)
+				}
+				if node < 0 || node >= len(nameParts) {
+					continue
+				}
+				newNameParts = append(newNameParts, nameParts[node])
+			default:
+				err := xerrors.NewInvalidParamsError(fmt.Errorf(
+					"aliasByTags expects tag names or node indexes, received %v", tag))
+				return ts.SeriesList{}, err
+			}
+		}
+		newName := strings.Join(newNameParts, ".")
+		newSeries := series.RenamedTo(newName)
+		renamed = append(renamed, newSeries)
+	}
+	seriesList.Values = renamed
+	return ts.SeriesList(seriesList), nil
+}
+
+// getSeriesTags returns the tags of a series, series without tags only have
+// the "name" tag holding their first path expression.
+func getSeriesTags(series *ts.Series) (map[string]string, error) {
+	if tags := series.Tags(); tags != nil {
+		return tags, nil
+	}
+
+	path, err := getFirstPathExpression(series.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{graphite.TaggedNameTag: path}, nil
+}
+
 // aliasSub runs series names through a regex search/replace.
 func aliasSub(ctx *common.Context, input singlePathSpec, search, replace string) (ts.SeriesList, error) {
 	return common.AliasSub(ctx, ts.SeriesList(input), search, replace)
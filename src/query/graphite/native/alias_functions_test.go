@@ -29,6 +29,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/m3db/m3/src/query/graphite/common"
+	"github.com/m3db/m3/src/query/graphite/context"
+	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/graphite/storage"
 	"github.com/m3db/m3/src/query/graphite/ts"
 	xgomock "github.com/m3db/m3/src/x/test"
@@ -377,3 +379,80 @@ func TestGroupByNodeAndAliasSubAndScopeMetric(t *testing.T) {
 	require.Equal(t, seriesList.Values[0].Name(), "a")
 	require.Equal(t, seriesList.Values[1].Name(), "b")
 }
+
+func TestAliasByTags(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer ctx.Close()
+
+	now := time.Now()
+	values := ts.NewConstantValues(ctx, 10.0, 1000, 10)
+	scaled, err := scale(ctx, singlePathSpec{Values: []*ts.Series{
+		ts.NewSeriesWithTags(ctx, "disk.used;dc=west;host=b", now, values,
+			map[string]string{"name": "disk.used", "dc": "west", "host": "b"}),
+	}}, 2)
+	require.NoError(t, err)
+
+	series := []*ts.Series{
+		ts.NewSeriesWithTags(ctx, "disk.used;dc=east;host=a", now, values,
+			map[string]string{"name": "disk.used", "dc": "east", "host": "a"}),
+		// Tags are carried by series wrapped by functions.
+		scaled.Values[0],
+		ts.NewSeries(ctx, "servers.c.disk.used", now, values),
+	}
+
+	results, err := aliasByTags(ctx, singlePathSpec{
+		Values: series,
+	}, "host", 1.0, "dc")
+	require.NoError(t, err)
+	require.Equal(t, len(series), results.Len())
+	assert.Equal(t, "a.used.east", results.Values[0].Name())
+	assert.Equal(t, "b.used.west", results.Values[1].Name())
+	assert.Equal(t, ".c.", results.Values[2].Name())
+
+	_, err = aliasByTags(ctx, singlePathSpec{
+		Values: series,
+	}, true)
+	require.Error(t, err)
+}
+
+func TestSeriesByTagAndAliasByTags(t *testing.T) {
+	ctrl := xgomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := storage.NewMockStorage(ctrl)
+
+	engine := NewEngine(store, CompileOptions{})
+
+	ctx := common.NewContext(common.ContextOptions{Start: time.Now().Add(-1 * time.Hour), End: time.Now(), Engine: engine})
+
+	stepSize := int((10 * time.Minute) / time.Millisecond)
+	store.EXPECT().FetchByQuery(gomock.Any(),
+		"seriesByTag('name=disk.used','dc=~east|west')", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, opts storage.FetchOptions) (*storage.FetchResult, error) {
+			series := make([]*ts.Series, 0, 2)
+			for _, tags := range []map[string]string{
+				{"name": "disk.used", "dc": "east", "host": "a"},
+				{"name": "disk.used", "dc": "west", "host": "b"},
+			} {
+				name := graphite.TaggedName(tags)
+				series = append(series, testTaggedSeries(name, tags, stepSize, 0, opts))
+			}
+
+			return &storage.FetchResult{SeriesList: series}, nil
+		})
+
+	expr, err := engine.Compile("aliasByTags(seriesByTag('name=disk.used','dc=~east|west'), 'dc', -1)")
+	require.NoError(t, err)
+
+	seriesList, err := expr.Execute(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, seriesList.Len())
+	require.Equal(t, "east.used", seriesList.Values[0].Name())
+	require.Equal(t, "west.used", seriesList.Values[1].Name())
+
+	expr, err = engine.Compile("seriesByTag('dc!=east')")
+	require.NoError(t, err)
+	_, err = expr.Execute(ctx)
+	require.Error(t, err)
+}
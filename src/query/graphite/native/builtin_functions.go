@@ -34,6 +34,7 @@ import (
 	"time"
 
 	"github.com/m3db/m3/src/query/graphite/common"
+	"github.com/m3db/m3/src/query/graphite/events"
 	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/graphite/ts"
 	"github.com/m3db/m3/src/query/util"
@@ -395,6 +396,185 @@ func timeShift(
 	}, nil
 }
 
+// timeStack draws the selected metrics shifted back in time by each multiple
+// of timeShiftUnit from timeShiftStart to timeShiftEnd, exclusive, stacked on
+// top of the current time range. If no sign is given, a minus sign ( - ) is
+// implied which will shift the metrics back in time.
+func timeStack(
+	ctx *common.Context,
+	_ singlePathSpec,
+	timeShiftUnit string,
+	timeShiftStart int,
+	timeShiftEnd int,
+) (*unaryContextShifter, error) {
+	if !(strings.HasPrefix(timeShiftUnit, "+") || strings.HasPrefix(timeShiftUnit, "-")) {
+		timeShiftUnit = "-" + timeShiftUnit
+	}
+
+	unit, err := common.ParseInterval(timeShiftUnit)
+	if err != nil {
+		return nil, xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid timeStack parameter %s: %w", timeShiftUnit, err))
+	}
+
+	// Fetch the union of all the shifted time ranges once and then slice
+	// each shifted time range out of it.
+	var minShift, maxShift time.Duration
+	if timeShiftEnd > timeShiftStart {
+		minShift = time.Duration(timeShiftStart) * unit
+		maxShift = time.Duration(timeShiftEnd-1) * unit
+		if minShift > maxShift {
+			minShift, maxShift = maxShift, minShift
+		}
+	}
+
+	rangeDuration := ctx.EndTime.Sub(ctx.StartTime)
+	contextShiftingFn := func(c *common.Context) *common.Context {
+		opts := common.NewChildContextOptions()
+		opts.AdjustTimeRange(minShift, maxShift, 0, 0)
+		return c.NewChildContext(opts)
+	}
+
+	transformerFn := func(input ts.SeriesList) (ts.SeriesList, error) {
+		var output []*ts.Series
+		for shift := timeShiftStart; shift < timeShiftEnd; shift++ {
+			for _, in := range input.Values {
+				var (
+					step   = time.Duration(in.MillisPerStep()) * time.Millisecond
+					offset = int((time.Duration(shift)*unit - minShift) / step)
+					n      = int((rangeDuration + step - 1) / step)
+					vals   = ts.NewValues(ctx, in.MillisPerStep(), n)
+				)
+				for i := 0; i < n && offset+i < in.Len(); i++ {
+					vals.SetValueAt(i, in.ValueAt(offset+i))
+				}
+
+				name := fmt.Sprintf("timeShift(%s, %s, %d)", in.Name(), timeShiftUnit, shift)
+				output = append(output, ts.NewSeriesWithTags(ctx, name,
+					in.StartTime().Add(-minShift), vals, in.Tags()))
+			}
+		}
+		input.Values = output
+		return input, nil
+	}
+
+	return &unaryContextShifter{
+		ContextShiftFunc: contextShiftingFn,
+		UnaryTransformer: transformerFn,
+	}, nil
+}
+
+// linearRegression draws the linear regression of each series, computed from
+// the values of the series between startSourceAt and endSourceAt, which
+// default to the query time range.
+func linearRegression(
+	ctx *common.Context,
+	seriesList singlePathSpec,
+	startSourceAt string,
+	endSourceAt string,
+) (*unaryContextShifter, error) {
+	var (
+		now         = time.Now()
+		sourceStart = ctx.StartTime
+		sourceEnd   = ctx.EndTime
+		err         error
+	)
+	if startSourceAt != "" {
+		sourceStart, err = graphite.ParseTime(startSourceAt, now, 0)
+		if err != nil {
+			return nil, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid linearRegression startSourceAt %s: %w", startSourceAt, err))
+		}
+	}
+	if endSourceAt != "" {
+		sourceEnd, err = graphite.ParseTime(endSourceAt, now, 0)
+		if err != nil {
+			return nil, xerrors.NewInvalidParamsError(
+				fmt.Errorf("invalid linearRegression endSourceAt %s: %w", endSourceAt, err))
+		}
+	}
+	if !sourceStart.Before(sourceEnd) {
+		return nil, xerrors.NewInvalidParamsError(
+			fmt.Errorf("linearRegression source start %v must be before end %v", sourceStart, sourceEnd))
+	}
+
+	contextShiftingFn := func(c *common.Context) *common.Context {
+		opts := common.NewChildContextOptions()
+		opts.AdjustTimeRange(sourceStart.Sub(c.StartTime), sourceEnd.Sub(c.EndTime), 0, 0)
+		return c.NewChildContext(opts)
+	}
+
+	transformerFn := func(sourceList ts.SeriesList) (ts.SeriesList, error) {
+		sources := make(map[string]*ts.Series, sourceList.Len())
+		for _, source := range sourceList.Values {
+			sources[source.Name()] = source
+		}
+
+		output := make([]*ts.Series, 0, len(seriesList.Values))
+		for _, series := range seriesList.Values {
+			source, ok := sources[series.Name()]
+			if !ok {
+				continue
+			}
+
+			factor, offset, ok := linearRegressionAnalysis(source)
+			if !ok {
+				continue
+			}
+
+			vals := ts.NewValues(ctx, series.MillisPerStep(), series.Len())
+			for i := 0; i < series.Len(); i++ {
+				t := float64(series.StartTimeForStep(i).Unix())
+				vals.SetValueAt(i, offset+t*factor)
+			}
+
+			name := fmt.Sprintf("linearRegression(%s, %d, %d)",
+				series.Name(), sourceStart.Unix(), sourceEnd.Unix())
+			output = append(output, ts.NewSeriesWithTags(ctx, name, series.StartTime(), vals, series.Tags()))
+		}
+
+		r := ts.SeriesList(seriesList)
+		r.Values = output
+		return r, nil
+	}
+
+	return &unaryContextShifter{
+		ContextShiftFunc: contextShiftingFn,
+		UnaryTransformer: transformerFn,
+	}, nil
+}
+
+// linearRegressionAnalysis returns the factor and offset of the least squares
+// line fitting the non NaN values of the series, with time in seconds.
+func linearRegressionAnalysis(series *ts.Series) (float64, float64, bool) {
+	var n, sumI, sumV, sumII, sumIV float64
+	for i := 0; i < series.Len(); i++ {
+		v := series.ValueAt(i)
+		if math.IsNaN(v) {
+			continue
+		}
+		fi := float64(i)
+		n++
+		sumI += fi
+		sumV += v
+		sumII += fi * fi
+		sumIV += fi * v
+	}
+
+	denominator := n*sumII - sumI*sumI
+	if denominator == 0 {
+		return 0, 0, false
+	}
+
+	var (
+		step   = float64(series.MillisPerStep()) / millisPerSecond
+		start  = float64(series.StartTime().Unix())
+		factor = (n*sumIV - sumI*sumV) / denominator / step
+		offset = (sumII*sumV-sumIV*sumI)/denominator - factor*start
+	)
+	return factor, offset, true
+}
+
 // delay shifts all samples later by an integer number of steps. This can be used
 // for custom derivative calculations, among other things. Note: this will pad
 // the early end of the data with NaN for every step shifted. delay complements
@@ -410,7 +590,7 @@ func delay(
 
 	for _, series := range input.Values {
 		delayedVals := delayValuesHelper(ctx, series, steps)
-		delayedSeries := ts.NewSeries(ctx, series.Name(), series.StartTime(), delayedVals)
+		delayedSeries := ts.NewSeriesWithTags(ctx, series.Name(), series.StartTime(), delayedVals, series.Tags())
 		renamedSeries := delayedSeries.RenamedTo(fmt.Sprintf("delay(%s,%d)", delayedSeries.Name(), steps))
 		output = append(output, renamedSeries)
 	}
@@ -462,7 +642,7 @@ func timeSlice(ctx *common.Context, inputPath singlePathSpec, start string, end
 			currentTime = currentTime.Add(stepDuration)
 		}
 
-		slicedSeries := ts.NewSeries(ctx, series.Name(), series.StartTime(), truncatedValues)
+		slicedSeries := ts.NewSeriesWithTags(ctx, series.Name(), series.StartTime(), truncatedValues, series.Tags())
 		renamedSlicedSeries := slicedSeries.RenamedTo(fmt.Sprintf("timeSlice(%s, %q, %q)", slicedSeries.Name(), start, end))
 		output = append(output, renamedSlicedSeries)
 	}
@@ -507,7 +687,7 @@ func scaleToSeconds(
 			value := series.ValueAt(step)
 			outvals.SetValueAt(step, value*factor)
 		}
-		output[i] = ts.NewSeries(ctx, name, series.StartTime(), outvals)
+		output[i] = ts.NewSeriesWithTags(ctx, name, series.StartTime(), outvals, series.Tags())
 	}
 
 	r := ts.SeriesList(seriesList)
@@ -605,7 +785,7 @@ func keepLastValue(ctx *common.Context, input singlePathSpec, limit int) (ts.Ser
 			}
 		}
 		name := fmt.Sprintf("keepLastValue(%s)", series.Name())
-		newSeries := ts.NewSeries(ctx, name, series.StartTime(), vals)
+		newSeries := ts.NewSeriesWithTags(ctx, name, series.StartTime(), vals, series.Tags())
 		output = append(output, newSeries)
 	}
 
@@ -632,7 +812,7 @@ func roundFunction(ctx *common.Context, input singlePathSpec, precision int) (ts
 		} else {
 			name = fmt.Sprintf("roundFunction(%s,%d)", series.Name(), precision)
 		}
-		newSeries := ts.NewSeries(ctx, name, series.StartTime(), vals)
+		newSeries := ts.NewSeriesWithTags(ctx, name, series.StartTime(), vals, series.Tags())
 		output = append(output, newSeries)
 	}
 
@@ -752,7 +932,7 @@ func sustainedCompare(ctx *common.Context, input singlePathSpec, threshold float
 
 		name := fmt.Sprintf("%s(%s, %f, '%s')",
 			funcName, series.Name(), threshold, intervalString)
-		newSeries := ts.NewSeries(ctx, name, series.StartTime(), vals)
+		newSeries := ts.NewSeriesWithTags(ctx, name, series.StartTime(), vals, series.Tags())
 		output = append(output, newSeries)
 	}
 
@@ -1328,7 +1508,7 @@ func powHelper(ctx *common.Context, input singlePathSpec, factor float64, isInve
 		if isInvert {
 			newName = fmt.Sprintf("%s(%s)", renamePrefix, series.Name())
 		}
-		results = append(results, ts.NewSeries(ctx, newName, series.StartTime(), vals))
+		results = append(results, ts.NewSeriesWithTags(ctx, newName, series.StartTime(), vals, series.Tags()))
 	}
 
 	r := ts.SeriesList(input)
@@ -1349,7 +1529,7 @@ func logarithm(ctx *common.Context, input singlePathSpec, base float64) (ts.Seri
 		vals := ts.NewValues(ctx, series.MillisPerStep(), series.Len())
 		newName := fmt.Sprintf("log(%s, %f)", series.Name(), base)
 		if series.AllNaN() {
-			results = append(results, ts.NewSeries(ctx, newName, series.StartTime(), vals))
+			results = append(results, ts.NewSeriesWithTags(ctx, newName, series.StartTime(), vals, series.Tags()))
 			continue
 		}
 
@@ -1360,7 +1540,7 @@ func logarithm(ctx *common.Context, input singlePathSpec, base float64) (ts.Seri
 			}
 		}
 
-		results = append(results, ts.NewSeries(ctx, newName, series.StartTime(), vals))
+		results = append(results, ts.NewSeriesWithTags(ctx, newName, series.StartTime(), vals, series.Tags()))
 	}
 
 	r := ts.SeriesList(input)
@@ -1418,7 +1598,7 @@ func interpolate(ctx *common.Context, input singlePathSpec, limit int) (ts.Serie
 		}
 
 		name := fmt.Sprintf("interpolate(%s)", series.Name())
-		newSeries := ts.NewSeries(ctx, name, series.StartTime(), vals)
+		newSeries := ts.NewSeriesWithTags(ctx, name, series.StartTime(), vals, series.Tags())
 		output = append(output, newSeries)
 	}
 	r := ts.SeriesList(input)
@@ -1451,7 +1631,7 @@ func derivativeTemplate(ctx *common.Context, input singlePathSpec, nameTemplate
 		}
 
 		name := fmt.Sprintf("%s(%s)", nameTemplate, in.Name())
-		output[i] = ts.NewSeries(ctx, name, in.StartTime(), derivativeValues)
+		output[i] = ts.NewSeriesWithTags(ctx, name, in.StartTime(), derivativeValues, in.Tags())
 	}
 
 	r := ts.SeriesList(input)
@@ -1481,7 +1661,7 @@ func integral(ctx *common.Context, input singlePathSpec) (ts.SeriesList, error)
 		}
 
 		newName := fmt.Sprintf("integral(%s)", series.Name())
-		results = append(results, ts.NewSeries(ctx, newName, series.StartTime(), outvals))
+		results = append(results, ts.NewSeriesWithTags(ctx, newName, series.StartTime(), outvals, series.Tags()))
 	}
 
 	r := ts.SeriesList(input)
@@ -1521,7 +1701,7 @@ func integralByInterval(ctx *common.Context, input singlePathSpec, intervalStrin
 		}
 
 		newName := fmt.Sprintf("integralByInterval(%s, %s)", series.Name(), intervalString)
-		results = append(results, ts.NewSeries(ctx, newName, series.StartTime(), outVals))
+		results = append(results, ts.NewSeriesWithTags(ctx, newName, series.StartTime(), outVals, series.Tags()))
 	}
 
 	r := ts.SeriesList(input)
@@ -1774,7 +1954,7 @@ func hitCountImpl(
 			}
 		}
 		newName := fmt.Sprintf("hitcount(%s, %q)", series.Name(), intervalString)
-		newSeries := ts.NewSeries(ctx, newName, newStart, buckets)
+		newSeries := ts.NewSeriesWithTags(ctx, newName, newStart, buckets, series.Tags())
 		resultSeries = append(resultSeries, newSeries)
 	}
 
@@ -1858,7 +2038,7 @@ func combineBootstrapWithOriginal(
 		if !found {
 			numSteps := ts.NumSteps(bootstrapStartTime, bootstrapEndTime, series.MillisPerStep())
 			vals := ts.NewValues(ctx, series.MillisPerStep(), numSteps)
-			bs = ts.NewSeries(ctx, series.Name(), bootstrapStartTime, vals)
+			bs = ts.NewSeriesWithTags(ctx, series.Name(), bootstrapStartTime, vals, series.Tags())
 		} else {
 			// Delete from the lookup so we can fill in
 			// the bootstrapped time series with NaNs if
@@ -1898,7 +2078,7 @@ func combineBootstrapWithOriginal(
 		for j := numBootstrapValues; j < numCombinedValues; j++ {
 			values.SetValueAt(j, original.ValueAt(j-numBootstrapValues))
 		}
-		newSeries := ts.NewSeries(ctx, original.Name(), bootstrapStartTime, values)
+		newSeries := ts.NewSeriesWithTags(ctx, original.Name(), bootstrapStartTime, values, original.Tags())
 		newSeries.Specification = original.Specification
 		newSeriesList = append(newSeriesList, newSeries)
 	}
@@ -2068,7 +2248,7 @@ func holtWintersAberrationInternal(
 			aberration.SetValueAt(i, newValue)
 		}
 		newName := fmt.Sprintf("holtWintersAberration(%s)", series.Name())
-		newSeries := ts.NewSeries(ctx, newName, series.StartTime(), aberration)
+		newSeries := ts.NewSeriesWithTags(ctx, newName, series.StartTime(), aberration, series.Tags())
 		results[index] = newSeries
 	}
 
@@ -2199,6 +2379,58 @@ func squareRoot(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList,
 	)
 }
 
+// exp raises e to the power of each datapoint.
+func exp(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	return transform(
+		ctx,
+		seriesList,
+		func(fname string) string { return fmt.Sprintf(wrappingFmt, "exp", fname) },
+		math.Exp,
+	)
+}
+
+// sigmoid applies the sigmoid function 1 / (1 + exp(-x)) to each datapoint.
+func sigmoid(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	return transform(
+		ctx,
+		seriesList,
+		func(fname string) string { return fmt.Sprintf(wrappingFmt, "sigmoid", fname) },
+		func(v float64) float64 { return 1 / (1 + math.Exp(-v)) },
+	)
+}
+
+// minMax applies min-max normalization to each series, scaling its values
+// to the [0, 1] range. Series with a single distinct value are scaled to 0.
+func minMax(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList, error) {
+	results := make([]*ts.Series, 0, len(seriesList.Values))
+	for _, series := range seriesList.Values {
+		var (
+			numSteps = series.Len()
+			minValue = series.SafeMin()
+			maxValue = series.SafeMax()
+			vals     = ts.NewValues(ctx, series.MillisPerStep(), numSteps)
+		)
+		for i := 0; i < numSteps; i++ {
+			v := series.ValueAt(i)
+			if math.IsNaN(v) {
+				continue
+			}
+			if maxValue == minValue {
+				vals.SetValueAt(i, 0)
+				continue
+			}
+			vals.SetValueAt(i, (v-minValue)/(maxValue-minValue))
+		}
+
+		name := fmt.Sprintf(wrappingFmt, "minMax", series.Name())
+		results = append(results, ts.NewSeriesWithTags(ctx, name, series.StartTime(), vals, series.Tags()))
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = results
+	return r, nil
+}
+
 // stdev takes one metric or a wildcard seriesList followed by an integer N. Draw the standard deviation
 // of all metrics passed for the past N datapoints. If the ratio of null points in the window is greater than
 // windowTolerance, skip the calculation.
@@ -2248,6 +2480,82 @@ func removeBelowPercentile(ctx *common.Context, seriesList singlePathSpec, perce
 		common.LessThan)
 }
 
+// removeBetweenPercentile removes the series that have all their values
+// strictly between the nth and (100-n)th percentiles of the values of all the
+// series at each step.
+func removeBetweenPercentile(
+	_ *common.Context,
+	seriesList singlePathSpec,
+	percentile float64,
+) (ts.SeriesList, error) {
+	if percentile < 0 || percentile > 100 {
+		return ts.NewSeriesList(), common.ErrInvalidPercentile(percentile)
+	}
+	if percentile < 50 {
+		percentile = 100 - percentile
+	}
+
+	numSteps := 0
+	for _, series := range seriesList.Values {
+		if series.Len() > numSteps {
+			numSteps = series.Len()
+		}
+	}
+
+	var (
+		lowPercentiles  = make([]float64, numSteps)
+		highPercentiles = make([]float64, numSteps)
+		values          = make([]float64, 0, len(seriesList.Values))
+	)
+	for i := 0; i < numSteps; i++ {
+		values = values[:0]
+		for _, series := range seriesList.Values {
+			if i < series.Len() {
+				values = append(values, series.ValueAt(i))
+			}
+		}
+		lowPercentiles[i] = common.GetPercentile(values, 100-percentile, false)
+		highPercentiles[i] = common.GetPercentile(values, percentile, false)
+	}
+
+	results := make([]*ts.Series, 0, len(seriesList.Values))
+	for _, series := range seriesList.Values {
+		for i := 0; i < series.Len(); i++ {
+			// NB: NaNs are never between the percentiles so series with
+			// missing values are always kept, as in graphite-web.
+			v := series.ValueAt(i)
+			if !(lowPercentiles[i] < v && v < highPercentiles[i]) {
+				results = append(results, series)
+				break
+			}
+		}
+	}
+
+	r := ts.SeriesList(seriesList)
+	r.Values = results
+	return r, nil
+}
+
+// unique removes the series with duplicate names from the series lists,
+// keeping the first series of each name.
+func unique(_ *common.Context, seriesLists multiplePathSpecs) (ts.SeriesList, error) {
+	var (
+		seen    = make(map[string]struct{}, len(seriesLists.Values))
+		results = make([]*ts.Series, 0, len(seriesLists.Values))
+	)
+	for _, series := range seriesLists.Values {
+		if _, ok := seen[series.Name()]; ok {
+			continue
+		}
+		seen[series.Name()] = struct{}{}
+		results = append(results, series)
+	}
+
+	r := ts.SeriesList(seriesLists)
+	r.Values = results
+	return r, nil
+}
+
 // randomWalkFunction returns a random walk starting at 0.
 // Note: step has a unit of seconds.
 func randomWalkFunction(ctx *common.Context, name string, step int) (ts.SeriesList, error) {
@@ -2536,7 +2844,7 @@ func newMovingBinaryTransform(
 
 				name := fmt.Sprintf("%s(%s,%s)", movingFunctionName, series.Name(), windowSize.stringValue)
 
-				newSeries := ts.NewSeries(ctx, name, newStartTime, vals)
+				newSeries := ts.NewSeriesWithTags(ctx, name, newStartTime, vals, series.Tags())
 				results = append(results, newSeries)
 			}
 
@@ -2768,7 +3076,7 @@ func offsetToZero(ctx *common.Context, seriesList singlePathSpec) (ts.SeriesList
 			}
 		}
 		name := fmt.Sprintf("offsetToZero(%s)", series.Name())
-		series := ts.NewSeries(ctx, name, series.StartTime(), vals)
+		series := ts.NewSeriesWithTags(ctx, name, series.StartTime(), vals, series.Tags())
 		results[idx] = series
 	}
 
@@ -2798,6 +3106,53 @@ func timeFunction(ctx *common.Context, name string, step int) (ts.SeriesList, er
 	return ts.NewSeriesListWithSeries(series), nil
 }
 
+// eventsFunction returns the number of graphite events at each second of
+// the time range having all of the given tags, or of all events if the only
+// tag is "*".
+func eventsFunction(ctx *common.Context, tags ...string) (ts.SeriesList, error) {
+	if ctx.Events == nil {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			errors.New("graphite events are not enabled"))
+	}
+
+	query := events.Query{
+		Start: ctx.StartTime,
+		End:   ctx.EndTime,
+		Tags:  tags,
+		Set:   events.IntersectionSetOperation,
+	}
+	if len(tags) == 1 && tags[0] == "*" {
+		query.Tags = nil
+	}
+
+	found, err := ctx.Events.Find(ctx.RequestContext(), query)
+	if err != nil {
+		return ts.NewSeriesList(), err
+	}
+
+	var (
+		start    = ctx.StartTime.Truncate(time.Second)
+		numSteps = ts.NumSteps(start, ctx.EndTime, millisPerSecond)
+		vals     = ts.NewValues(ctx, millisPerSecond, numSteps)
+	)
+	for _, event := range found {
+		idx := int(event.When.Sub(start) / time.Second)
+		if idx < 0 || idx >= numSteps {
+			continue
+		}
+
+		count := vals.ValueAt(idx)
+		if math.IsNaN(count) {
+			count = 0
+		}
+		vals.SetValueAt(idx, count+1)
+	}
+
+	name := fmt.Sprintf("events(\"%s\")", strings.Join(tags, "\", \""))
+	series := ts.NewSeries(ctx, name, start, vals)
+	return ts.NewSeriesListWithSeries(series), nil
+}
+
 // dashed draws the selected metrics with a dotted line with segments of length f.
 func dashed(_ *common.Context, seriesList singlePathSpec, dashLength float64) (ts.SeriesList, error) {
 	if dashLength <= 0 {
@@ -2817,6 +3172,17 @@ func dashed(_ *common.Context, seriesList singlePathSpec, dashLength float64) (t
 	return r, nil
 }
 
+// seriesByTag returns the tagged series matching all of the given tag
+// expressions, i.e. seriesByTag('name=disk.used', 'dc=~east|west').
+func seriesByTag(ctx *common.Context, tagExpressions ...string) (ts.SeriesList, error) {
+	query := graphite.SeriesByTagQuery(tagExpressions)
+	if _, _, err := graphite.ParseSeriesByTagQuery(query); err != nil {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(err)
+	}
+
+	return newFetchExpression(query).Execute(ctx)
+}
+
 // threshold draws a horizontal line at value f across the graph.
 func threshold(ctx *common.Context, value float64, label string, color string) (ts.SeriesList, error) {
 	seriesList, err := constantLine(ctx, value)
@@ -2834,6 +3200,36 @@ func threshold(ctx *common.Context, value float64, label string, color string) (
 	return ts.NewSeriesListWithSeries(series), nil
 }
 
+// verticalLine draws a vertical line at the designated timestamp, which must
+// be within the query time range. The line is named after the label, or after
+// the timestamp if no label is given.
+func verticalLine(ctx *common.Context, timestamp string, label string, _ string) (ts.SeriesList, error) {
+	t, err := graphite.ParseTime(timestamp, time.Now(), 0)
+	if err != nil {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(
+			fmt.Errorf("invalid verticalLine timestamp %s: %w", timestamp, err))
+	}
+
+	t = t.Truncate(time.Second)
+	if t.Before(ctx.StartTime.Truncate(time.Second)) {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(fmt.Errorf(
+			"verticalLine timestamp %s exists before start of range", timestamp))
+	}
+	if t.After(ctx.EndTime) {
+		return ts.NewSeriesList(), xerrors.NewInvalidParamsError(fmt.Errorf(
+			"verticalLine timestamp %s exists after end of range", timestamp))
+	}
+
+	if label == "" {
+		label = timestamp
+	}
+
+	// NB: graphite-web draws the line as infinite, the closest we can get
+	// is a one second series.
+	vals := ts.NewConstantValues(ctx, 1, 1, millisPerSecond)
+	return ts.NewSeriesListWithSeries(ts.NewSeries(ctx, label, t, vals)), nil
+}
+
 func init() {
 	// functions - in alpha ordering
 	MustRegisterFunction(absolute)
@@ -2841,12 +3237,14 @@ func init() {
 	MustRegisterFunction(aggregateLine).WithDefaultParams(map[uint8]interface{}{
 		2: "avg", // f
 	})
+	MustRegisterFunction(aggregateSeriesLists)
 	MustRegisterFunction(aggregateWithWildcards).WithDefaultParams(map[uint8]interface{}{
 		3: -1, // positions
 	})
 	MustRegisterFunction(alias)
 	MustRegisterFunction(aliasByMetric)
 	MustRegisterFunction(aliasByNode)
+	MustRegisterFunction(aliasByTags)
 	MustRegisterFunction(aliasSub)
 	MustRegisterFunction(applyByNode).WithDefaultParams(map[uint8]interface{}{
 		4: "", // newName
@@ -2876,7 +3274,9 @@ func init() {
 	MustRegisterFunction(diffSeries)
 	MustRegisterFunction(divideSeries)
 	MustRegisterFunction(divideSeriesLists)
+	MustRegisterFunction(eventsFunction)
 	MustRegisterFunction(exclude)
+	MustRegisterFunction(exp)
 	MustRegisterFunction(exponentialMovingAverage).
 		WithoutUnaryContextShifterSkipFetchOptimization()
 	MustRegisterFunction(fallbackSeries)
@@ -2887,6 +3287,7 @@ func init() {
 		3: "average", // fname
 	})
 	MustRegisterFunction(groupByNodes)
+	MustRegisterFunction(groupByTags)
 	MustRegisterFunction(highest).WithDefaultParams(map[uint8]interface{}{
 		2: 1,         // n,
 		3: "average", // f
@@ -2913,6 +3314,12 @@ func init() {
 	})
 	MustRegisterFunction(legendValue)
 	MustRegisterFunction(limit)
+	MustRegisterFunction(linearRegression).
+		WithDefaultParams(map[uint8]interface{}{
+			2: "", // startSourceAt
+			3: "", // endSourceAt
+		}).
+		WithoutUnaryContextShifterSkipFetchOptimization()
 	MustRegisterFunction(logarithm).WithDefaultParams(map[uint8]interface{}{
 		2: 10.0, // base
 	})
@@ -2924,6 +3331,7 @@ func init() {
 	MustRegisterFunction(lowestCurrent)
 	MustRegisterFunction(maxSeries)
 	MustRegisterFunction(maximumAbove)
+	MustRegisterFunction(minMax)
 	MustRegisterFunction(minSeries)
 	MustRegisterFunction(minimumAbove)
 	MustRegisterFunction(mostDeviant)
@@ -2983,6 +3391,7 @@ func init() {
 	MustRegisterFunction(removeAbovePercentile)
 	MustRegisterFunction(removeAboveValue)
 	MustRegisterFunction(removeBelowPercentile)
+	MustRegisterFunction(removeBetweenPercentile)
 	MustRegisterFunction(removeBelowValue)
 	MustRegisterFunction(removeEmptySeries).WithDefaultParams(map[uint8]interface{}{
 		2: 0.0, // xFilesFactor
@@ -2992,6 +3401,8 @@ func init() {
 	})
 	MustRegisterFunction(scale)
 	MustRegisterFunction(scaleToSeconds)
+	MustRegisterFunction(seriesByTag)
+	MustRegisterFunction(sigmoid)
 	MustRegisterFunction(sortBy).WithDefaultParams(map[uint8]interface{}{
 		2: "average", // fn
 		3: false,     // reverse
@@ -3039,19 +3450,30 @@ func init() {
 	MustRegisterFunction(timeSlice).WithDefaultParams(map[uint8]interface{}{
 		3: "now", // endTime
 	})
+	MustRegisterFunction(timeStack).WithDefaultParams(map[uint8]interface{}{
+		2: "1d", // timeShiftUnit
+		3: 0,    // timeShiftStart
+		4: 7,    // timeShiftEnd
+	})
 	MustRegisterFunction(transformNull).WithDefaultParams(map[uint8]interface{}{
 		2: 0.0, // defaultValue
 	})
+	MustRegisterFunction(unique)
 	MustRegisterFunction(useSeriesAbove)
+	MustRegisterFunction(verticalLine).WithDefaultParams(map[uint8]interface{}{
+		2: "", // label
+		3: "", // color
+	})
 	MustRegisterFunction(weightedAverage)
 
 	// alias functions - in alpha ordering
 	MustRegisterAliasedFunction("abs", absolute)
-	MustRegisterAliasedFunction("aliasByTags", aliasByNode)
 	MustRegisterAliasedFunction("avg", averageSeries)
+	MustRegisterAliasedFunction("events", eventsFunction)
 	MustRegisterAliasedFunction("log", logarithm)
 	MustRegisterAliasedFunction("max", maxSeries)
 	MustRegisterAliasedFunction("min", minSeries)
+	MustRegisterAliasedFunction("pct", asPercent)
 	MustRegisterAliasedFunction("randomWalk", randomWalkFunction)
 	MustRegisterAliasedFunction("round", roundFunction)
 	MustRegisterAliasedFunction("sum", sumSeries)
@@ -35,6 +35,7 @@ import (
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/graphite/common"
 	xctx "github.com/m3db/m3/src/query/graphite/context"
+	"github.com/m3db/m3/src/query/graphite/events"
 	"github.com/m3db/m3/src/query/graphite/storage"
 	xtest "github.com/m3db/m3/src/query/graphite/testing"
 	"github.com/m3db/m3/src/query/graphite/ts"
@@ -4417,6 +4418,58 @@ func TestTimeFunction(t *testing.T) {
 		[]common.TestSeries{expected}, results.Values)
 }
 
+type testEventsStore struct {
+	events []events.Event
+	query  events.Query
+}
+
+func (s *testEventsStore) Add(_ context.Context, event events.Event) (events.Event, error) {
+	s.events = append(s.events, event)
+	return event, nil
+}
+
+func (s *testEventsStore) Find(_ context.Context, query events.Query) ([]events.Event, error) {
+	s.query = query
+	return s.events, nil
+}
+
+func TestEventsFunction(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	store := &testEventsStore{
+		events: []events.Event{
+			{When: start, What: "a"},
+			{When: start.Add(2 * time.Second), What: "b"},
+			{When: start.Add(2*time.Second + time.Millisecond), What: "c"},
+			{When: start.Add(time.Hour), What: "out of range"},
+		},
+	}
+	ctx := common.NewContext(common.ContextOptions{
+		Start:  start,
+		End:    start.Add(4 * time.Second),
+		Events: store,
+	})
+	defer func() { _ = ctx.Close() }()
+
+	results, err := eventsFunction(ctx, "deploy", "master")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"deploy", "master"}, store.query.Tags)
+	assert.Equal(t, events.IntersectionSetOperation, store.query.Set)
+	expected := common.TestSeries{
+		Name: `events("deploy", "master")`,
+		Data: []float64{1, math.NaN(), 2, math.NaN()},
+	}
+	common.CompareOutputsAndExpected(t, 1000, start,
+		[]common.TestSeries{expected}, results.Values)
+
+	_, err = eventsFunction(ctx, "*")
+	require.NoError(t, err)
+	assert.Empty(t, store.query.Tags)
+
+	ctx.Events = nil
+	_, err = eventsFunction(ctx, "*")
+	require.Error(t, err)
+}
+
 func TestTimeShift(t *testing.T) {
 	ctrl := xgomock.NewController(t)
 	defer ctrl.Finish()
@@ -4579,12 +4632,243 @@ func TestThreshold(t *testing.T) {
 	require.Equal(t, "1.000", results[0].Name())
 }
 
+func TestExpAndSigmoid(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	nan := math.NaN()
+	series := ts.NewSeries(ctx, "foo", ctx.StartTime,
+		common.NewTestSeriesValues(ctx, 10000, []float64{0, 1, -1, nan}))
+
+	res, err := exp(ctx, singlePathSpec{Values: []*ts.Series{series}})
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, 10000, ctx.StartTime,
+		[]common.TestSeries{{
+			Name: "exp(foo)",
+			Data: []float64{1, math.E, 1 / math.E, nan},
+		}}, res.Values)
+
+	res, err = sigmoid(ctx, singlePathSpec{Values: []*ts.Series{series}})
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, 10000, ctx.StartTime,
+		[]common.TestSeries{{
+			Name: "sigmoid(foo)",
+			Data: []float64{0.5, 1 / (1 + 1/math.E), 1 / (1 + math.E), nan},
+		}}, res.Values)
+}
+
+func TestMinMax(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	nan := math.NaN()
+	inputs := []struct {
+		name     string
+		values   []float64
+		expected []float64
+	}{
+		{
+			"foo",
+			[]float64{1, 2, nan, 5},
+			[]float64{0, 0.25, nan, 1},
+		},
+		{
+			"bar",
+			[]float64{3, 3, 3},
+			[]float64{0, 0, 0},
+		},
+		{
+			"baz",
+			[]float64{nan, nan},
+			[]float64{nan, nan},
+		},
+	}
+
+	for _, input := range inputs {
+		series := ts.NewSeries(ctx, input.name, ctx.StartTime,
+			common.NewTestSeriesValues(ctx, 10000, input.values))
+		res, err := minMax(ctx, singlePathSpec{Values: []*ts.Series{series}})
+		require.NoError(t, err)
+		common.CompareOutputsAndExpected(t, 10000, ctx.StartTime,
+			[]common.TestSeries{{
+				Name: fmt.Sprintf("minMax(%s)", input.name),
+				Data: input.expected,
+			}}, res.Values)
+	}
+}
+
+func TestRemoveBetweenPercentile(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	var (
+		nan    = math.NaN()
+		inputs = []struct {
+			name   string
+			values []float64
+		}{
+			{"a", []float64{1, 1}},
+			{"b", []float64{2, 2}},
+			{"c", []float64{3, 3}},
+			{"d", []float64{4, nan}},
+			{"e", []float64{5, 5}},
+		}
+		series = make([]*ts.Series, 0, len(inputs))
+	)
+	for _, input := range inputs {
+		series = append(series, ts.NewSeries(ctx, input.name, ctx.StartTime,
+			common.NewTestSeriesValues(ctx, 10000, input.values)))
+	}
+
+	res, err := removeBetweenPercentile(ctx, singlePathSpec{Values: series}, 30)
+	require.NoError(t, err)
+	names := make([]string, 0, res.Len())
+	for _, s := range res.Values {
+		names = append(names, s.Name())
+	}
+	// The 30th and 70th percentiles are 2 and 5 for both steps, d is kept
+	// since its missing value is not between them.
+	assert.Equal(t, []string{"a", "b", "d", "e"}, names)
+
+	_, err = removeBetweenPercentile(ctx, singlePathSpec{Values: series}, 101)
+	require.Error(t, err)
+}
+
+func TestUnique(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	newSeries := func(name string, value float64) *ts.Series {
+		return ts.NewSeries(ctx, name, ctx.StartTime,
+			ts.NewConstantValues(ctx, value, 3, 10000))
+	}
+
+	res, err := unique(ctx, multiplePathSpecs{
+		Values: []*ts.Series{
+			newSeries("foo", 1),
+			newSeries("bar", 2),
+			newSeries("foo", 3),
+			newSeries("baz", 4),
+			newSeries("bar", 5),
+		},
+	})
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, 10000, ctx.StartTime,
+		[]common.TestSeries{
+			{Name: "foo", Data: []float64{1, 1, 1}},
+			{Name: "bar", Data: []float64{2, 2, 2}},
+			{Name: "baz", Data: []float64{4, 4, 4}},
+		}, res.Values)
+}
+
+func TestVerticalLine(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	at := ctx.StartTime.Add(10 * time.Minute)
+	timestamp := fmt.Sprintf("%d", at.Unix())
+
+	res, err := verticalLine(ctx, timestamp, "deploy", "")
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Len())
+	common.CompareOutputsAndExpected(t, 1000, at,
+		[]common.TestSeries{{Name: "deploy", Data: []float64{1}}}, res.Values)
+
+	res, err = verticalLine(ctx, timestamp, "", "")
+	require.NoError(t, err)
+	require.Equal(t, 1, res.Len())
+	assert.Equal(t, timestamp, res.Values[0].Name())
+
+	// error - timestamp before the start of the query
+	_, err = verticalLine(ctx,
+		fmt.Sprintf("%d", ctx.StartTime.Add(-time.Hour).Unix()), "", "")
+	require.Error(t, err)
+
+	// error - timestamp after the end of the query
+	_, err = verticalLine(ctx,
+		fmt.Sprintf("%d", ctx.EndTime.Add(time.Hour).Unix()), "", "")
+	require.Error(t, err)
+}
+
+func TestTimeStack(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	shifter, err := timeStack(ctx, singlePathSpec{}, "10min", 0, 3)
+	require.NoError(t, err)
+
+	childCtx := shifter.ContextShiftFunc(ctx)
+	require.True(t, ctx.StartTime.Add(-20*time.Minute).Equal(childCtx.StartTime))
+	require.True(t, ctx.EndTime.Equal(childCtx.EndTime))
+
+	// The bootstrapped series covers the query range plus the 20 minutes
+	// before it, with one value per 10 minutes.
+	stepSize := int((10 * time.Minute) / time.Millisecond)
+	bootstrapped := ts.NewSeries(ctx, "foo", childCtx.StartTime,
+		common.NewTestSeriesValues(ctx, stepSize, []float64{1, 2, 3, 4, 5, 6, 7, 8}))
+
+	res, err := shifter.UnaryTransformer(ts.NewSeriesListWithSeries(bootstrapped))
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime,
+		[]common.TestSeries{
+			{Name: "timeShift(foo, -10min, 0)", Data: []float64{3, 4, 5, 6, 7, 8}},
+			{Name: "timeShift(foo, -10min, 1)", Data: []float64{2, 3, 4, 5, 6, 7}},
+			{Name: "timeShift(foo, -10min, 2)", Data: []float64{1, 2, 3, 4, 5, 6}},
+		}, res.Values)
+}
+
+func TestLinearRegression(t *testing.T) {
+	ctx := common.NewTestContext()
+	defer func() { _ = ctx.Close() }()
+
+	nan := math.NaN()
+	stepSize := 60000
+	input := []*ts.Series{
+		ts.NewSeries(ctx, "foo", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{nan, nan, nan, nan})),
+		ts.NewSeries(ctx, "bar", ctx.StartTime,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{nan, nan, nan, nan})),
+	}
+
+	sourceStart := ctx.StartTime.Add(-4 * time.Minute)
+	sourceEnd := ctx.StartTime
+	shifter, err := linearRegression(ctx, singlePathSpec{Values: input},
+		fmt.Sprintf("%d", sourceStart.Unix()), fmt.Sprintf("%d", sourceEnd.Unix()))
+	require.NoError(t, err)
+
+	childCtx := shifter.ContextShiftFunc(ctx)
+	require.True(t, sourceStart.Equal(childCtx.StartTime))
+	require.True(t, sourceEnd.Equal(childCtx.EndTime))
+
+	// The source of foo increases by one every step, bar has no slope.
+	sources := ts.NewSeriesListWithSeries(
+		ts.NewSeries(ctx, "foo", sourceStart,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{1, nan, 3, 4})),
+		ts.NewSeries(ctx, "bar", sourceStart,
+			common.NewTestSeriesValues(ctx, stepSize, []float64{nan, nan, 2, nan})),
+	)
+	res, err := shifter.UnaryTransformer(sources)
+	require.NoError(t, err)
+	common.CompareOutputsAndExpected(t, stepSize, ctx.StartTime,
+		[]common.TestSeries{{
+			Name: fmt.Sprintf("linearRegression(foo, %d, %d)",
+				sourceStart.Unix(), sourceEnd.Unix()),
+			Data: []float64{5, 6, 7, 8},
+		}}, res.Values)
+
+	// error - source start after source end
+	_, err = linearRegression(ctx, singlePathSpec{Values: input},
+		fmt.Sprintf("%d", sourceEnd.Unix()), fmt.Sprintf("%d", sourceStart.Unix()))
+	require.Error(t, err)
+}
+
 func TestFunctionsRegistered(t *testing.T) {
 	fnames := []string{
 		"abs",
 		"absolute",
 		"aggregate",
 		"aggregateLine",
+		"aggregateSeriesLists",
 		"alias",
 		"aliasByMetric",
 		"aliasByNode",
@@ -4609,13 +4893,16 @@ func TestFunctionsRegistered(t *testing.T) {
 		"diffSeries",
 		"divideSeries",
 		"divideSeriesLists",
+		"events",
 		"exclude",
+		"exp",
 		"exponentialMovingAverage",
 		"fallbackSeries",
 		"grep",
 		"group",
 		"groupByNode",
 		"groupByNodes",
+		"groupByTags",
 		"highest",
 		"highestAverage",
 		"highestCurrent",
@@ -4632,6 +4919,7 @@ func TestFunctionsRegistered(t *testing.T) {
 		"keepLastValue",
 		"legendValue",
 		"limit",
+		"linearRegression",
 		"log",
 		"logarithm",
 		"lowest",
@@ -4643,6 +4931,7 @@ func TestFunctionsRegistered(t *testing.T) {
 		"min",
 		"minSeries",
 		"minimumAbove",
+		"minMax",
 		"mostDeviant",
 		"movingAverage",
 		"movingMedian",
@@ -4654,6 +4943,7 @@ func TestFunctionsRegistered(t *testing.T) {
 		"nPercentile",
 		"offset",
 		"offsetToZero",
+		"pct",
 		"perSecond",
 		"pow",
 		"powSeries",
@@ -4662,11 +4952,14 @@ func TestFunctionsRegistered(t *testing.T) {
 		"rangeOfSeries",
 		"removeAbovePercentile",
 		"removeAboveValue",
+		"removeBetweenPercentile",
 		"removeBelowPercentile",
 		"removeBelowValue",
 		"removeEmptySeries",
 		"scale",
 		"scaleToSeconds",
+		"seriesByTag",
+		"sigmoid",
 		"smartSummarize",
 		"sortByMaxima",
 		"sortByMinima",
@@ -4684,8 +4977,11 @@ func TestFunctionsRegistered(t *testing.T) {
 		"timeFunction",
 		"timeShift",
 		"timeSlice",
+		"timeStack",
 		"transformNull",
+		"unique",
 		"useSeriesAbove",
+		"verticalLine",
 		"weightedAverage",
 	}
 
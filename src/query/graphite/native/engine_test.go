@@ -57,11 +57,21 @@ func snapStartToStepSize(t time.Time, stepSize int) time.Time {
 }
 
 func testSeries(name string, stepSize int, val float64, opts storage.FetchOptions) *ts.Series {
+	return testTaggedSeries(name, nil, stepSize, val, opts)
+}
+
+func testTaggedSeries(
+	name string,
+	tags map[string]string,
+	stepSize int,
+	val float64,
+	opts storage.FetchOptions,
+) *ts.Series {
 	ctx := context.New()
 	numSteps := int(opts.EndTime.Sub(opts.StartTime)/time.Millisecond) / stepSize
 	vals := ts.NewConstantValues(ctx, val, numSteps, stepSize)
 	firstPoint := snapStartToStepSize(opts.StartTime, stepSize)
-	return ts.NewSeries(ctx, name, firstPoint, vals)
+	return ts.NewSeriesWithTags(ctx, name, firstPoint, vals, tags)
 }
 
 func buildTestSeriesFn(
@@ -141,7 +141,7 @@ func summarizeTimeSeries(
 			}
 		}
 	}
-	return ts.NewSeries(ctx, newName, newStart, newValues)
+	return ts.NewSeriesWithTags(ctx, newName, newStart, newValues, series.Tags())
 }
 
 // smartSummarize is an alias of summarize with alignToFrom set to true
@@ -21,6 +21,8 @@
 package storage
 
 import (
+	"fmt"
+
 	"github.com/m3db/m3/src/query/graphite/graphite"
 	"github.com/m3db/m3/src/query/models"
 )
@@ -78,3 +80,47 @@ func matcherTerminator(count int) models.Matcher {
 		Name: graphite.TagName(count),
 	}
 }
+
+// convertTagExpressionToMatcher converts a seriesByTag tag expression to a
+// matcher, graphite regexps are only anchored at the start of the value
+// while matcher regexps are fully anchored.
+func convertTagExpressionToMatcher(
+	expr graphite.TagExpression,
+) (models.Matcher, error) {
+	name := []byte(expr.Name)
+	switch expr.Op {
+	case graphite.TagExpressionEqual:
+		if expr.Value == "" {
+			// An empty value matches series without the tag.
+			return models.Matcher{Type: models.MatchNotField, Name: name}, nil
+		}
+		return models.Matcher{
+			Type:  models.MatchEqual,
+			Name:  name,
+			Value: []byte(expr.Value),
+		}, nil
+	case graphite.TagExpressionNotEqual:
+		if expr.Value == "" {
+			return models.Matcher{Type: models.MatchField, Name: name}, nil
+		}
+		return models.Matcher{
+			Type:  models.MatchNotEqual,
+			Name:  name,
+			Value: []byte(expr.Value),
+		}, nil
+	case graphite.TagExpressionRegexp:
+		return models.Matcher{
+			Type:  models.MatchRegexp,
+			Name:  name,
+			Value: []byte("(?:" + expr.Value + ").*"),
+		}, nil
+	case graphite.TagExpressionNotRegexp:
+		return models.Matcher{
+			Type:  models.MatchNotRegexp,
+			Name:  name,
+			Value: []byte("(?:" + expr.Value + ").*"),
+		}, nil
+	}
+
+	return models.Matcher{}, fmt.Errorf("unknown tag expression op: %d", expr.Op)
+}
@@ -90,3 +90,44 @@ func TestMatcherTerminator(t *testing.T) {
 		assert.Equal(t, expected, actual)
 	}
 }
+
+func TestConvertTagExpressionToMatcher(t *testing.T) {
+	for _, test := range []struct {
+		expr     string
+		expected models.Matcher
+	}{
+		{
+			expr:     "dc=east",
+			expected: models.Matcher{Type: models.MatchEqual, Name: []byte("dc"), Value: []byte("east")},
+		},
+		{
+			expr:     "dc=",
+			expected: models.Matcher{Type: models.MatchNotField, Name: []byte("dc")},
+		},
+		{
+			expr:     "dc!=east",
+			expected: models.Matcher{Type: models.MatchNotEqual, Name: []byte("dc"), Value: []byte("east")},
+		},
+		{
+			expr:     "dc!=",
+			expected: models.Matcher{Type: models.MatchField, Name: []byte("dc")},
+		},
+		{
+			expr:     "dc=~ea",
+			expected: models.Matcher{Type: models.MatchRegexp, Name: []byte("dc"), Value: []byte("(?:ea).*")},
+		},
+		{
+			expr:     "dc!=~ea|we",
+			expected: models.Matcher{Type: models.MatchNotRegexp, Name: []byte("dc"), Value: []byte("(?:ea|we).*")},
+		},
+	} {
+		t.Run(test.expr, func(t *testing.T) {
+			expr, err := graphite.ParseTagExpression(test.expr)
+			require.NoError(t, err)
+
+			actual, err := convertTagExpressionToMatcher(expr)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
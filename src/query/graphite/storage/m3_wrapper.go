@@ -46,7 +46,11 @@ import (
 	xtime "github.com/m3db/m3/src/x/time"
 )
 
-var errSeriesNoResolution = errors.New("series has no resolution set")
+var (
+	errSeriesNoResolution = errors.New("series has no resolution set")
+
+	taggedNameTag = []byte(graphite.TaggedNameTag)
+)
 
 type m3WrappedStore struct {
 	m3             storage.Storage
@@ -168,15 +172,43 @@ func GetQueryTerminatorTagName(query string) []byte {
 	return graphite.TagName(metricLength)
 }
 
+// TranslateSeriesByTagQueryToMatchers converts a seriesByTag query to tag
+// matchers, returning false if the query is not a seriesByTag query.
+func TranslateSeriesByTagQueryToMatchers(
+	query string,
+) (models.Matchers, bool, error) {
+	exprs, ok, err := graphite.ParseSeriesByTagQuery(query)
+	if !ok || err != nil {
+		return nil, ok, err
+	}
+
+	matchers := make(models.Matchers, 0, len(exprs))
+	for _, expr := range exprs {
+		m, err := convertTagExpressionToMatcher(expr)
+		if err != nil {
+			return nil, true, err
+		}
+		matchers = append(matchers, m)
+	}
+
+	return matchers, true, nil
+}
+
 func translateQuery(
 	query string,
 	fetchOpts FetchOptions,
 	opts M3WrappedStorageOptions,
 ) (*storage.FetchQuery, error) {
-	matchers, _, err := TranslateQueryToMatchersWithTerminator(query)
+	matchers, ok, err := TranslateSeriesByTagQueryToMatchers(query)
 	if err != nil {
 		return nil, err
 	}
+	if !ok {
+		matchers, _, err = TranslateQueryToMatchersWithTerminator(query)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Apply any shifts.
 	fetchOpts.StartTime = fetchOpts.StartTime.Add(opts.ShiftTimeStart)
@@ -405,7 +437,8 @@ func translateTimeseriesFromIter(
 		}
 
 		name := string(seriesMetas[idx].Name)
-		series = append(series, ts.NewSeries(ctx, name, start.ToTime(), values))
+		tags := taggedSeriesTags(seriesMetas[idx].Tags)
+		series = append(series, ts.NewSeriesWithTags(ctx, name, start.ToTime(), values, tags))
 	}
 
 	if err := iter.Err(); err != nil {
@@ -415,6 +448,21 @@ func translateTimeseriesFromIter(
 	return series, nil
 }
 
+// taggedSeriesTags returns the graphite tags of a tagged series, which carry
+// their metric path in the "name" tag, and nil for other series.
+func taggedSeriesTags(tags models.Tags) map[string]string {
+	if _, ok := tags.Get(taggedNameTag); !ok {
+		return nil
+	}
+
+	result := make(map[string]string, tags.Len())
+	for _, tag := range tags.Tags {
+		result[string(tag.Name)] = string(tag.Value)
+	}
+
+	return result
+}
+
 func (s *m3WrappedStore) fanoutOptions() *storage.FanoutOptions {
 	fanoutOpts := &storage.FanoutOptions{
 		FanoutUnaggregated:        storage.FanoutForceDisable,
@@ -84,6 +84,34 @@ func TestTranslateQuery(t *testing.T) {
 	assert.Equal(t, expected, matchers)
 }
 
+func TestTranslateQuerySeriesByTag(t *testing.T) {
+	query := `seriesByTag('name=disk.used','dc=~east|west','host!=a','role=')`
+	end := time.Now()
+	start := end.Add(time.Hour * -2)
+	opts := FetchOptions{
+		StartTime: start,
+		EndTime:   end,
+		DataOptions: DataOptions{
+			Timeout: time.Minute,
+		},
+	}
+
+	translated, err := translateQuery(query, opts, M3WrappedStorageOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, query, translated.Raw)
+	expected := models.Matchers{
+		{Type: models.MatchEqual, Name: []byte("name"), Value: []byte("disk.used")},
+		{Type: models.MatchRegexp, Name: []byte("dc"), Value: []byte("(?:east|west).*")},
+		{Type: models.MatchNotEqual, Name: []byte("host"), Value: []byte("a")},
+		{Type: models.MatchNotField, Name: []byte("role")},
+	}
+
+	assert.Equal(t, expected, translated.TagMatchers)
+
+	_, err = translateQuery(`seriesByTag('host!=a')`, opts, M3WrappedStorageOptions{})
+	assert.Error(t, err)
+}
+
 func TestTranslateQueryStarStar(t *testing.T) {
 	query := `foo**bar`
 	end := time.Now()
@@ -205,6 +233,23 @@ func TestTranslateTimeseries(t *testing.T) {
 	}
 }
 
+func TestTaggedSeriesTags(t *testing.T) {
+	tags := models.NewTags(3, nil).
+		AddTag(models.Tag{Name: []byte("name"), Value: []byte("disk.used")}).
+		AddTag(models.Tag{Name: []byte("dc"), Value: []byte("east")}).
+		AddTag(models.Tag{Name: []byte("host"), Value: []byte("a")})
+	assert.Equal(t, map[string]string{
+		"name": "disk.used",
+		"dc":   "east",
+		"host": "a",
+	}, taggedSeriesTags(tags))
+
+	untagged := models.NewTags(2, nil).
+		AddTag(models.Tag{Name: graphite.TagName(0), Value: []byte("disk")}).
+		AddTag(models.Tag{Name: graphite.TagName(1), Value: []byte("used")})
+	assert.Nil(t, taggedSeriesTags(untagged))
+}
+
 func TestFetchByQuery(t *testing.T) {
 	ctrl := xtest.NewController(t)
 	defer ctrl.Finish()
@@ -63,6 +63,9 @@ type Series struct {
 	// specific results.
 	Specification string
 
+	// tags are the graphite tags of the series, nil for series without tags.
+	tags map[string]string
+
 	// consolidationFunc specifies how the series will be consolidated when the
 	// number of data points in the series is more than the maximum number allowed.
 	consolidationFunc ConsolidationFunc
@@ -136,9 +139,23 @@ func NewSeries(ctx context.Context, name string, startTime time.Time, vals Value
 	}
 }
 
+// NewSeriesWithTags creates a new Series at a given start time, backed by the
+// provided values and carrying the given graphite tags.
+func NewSeriesWithTags(
+	ctx context.Context,
+	name string,
+	startTime time.Time,
+	vals Values,
+	tags map[string]string,
+) *Series {
+	series := NewSeries(ctx, name, startTime, vals)
+	series.tags = tags
+	return series
+}
+
 // DerivedSeries returns a series derived from the current series with different datapoints
 func (b *Series) DerivedSeries(startTime time.Time, vals Values) *Series {
-	series := NewSeries(b.ctx, b.name, startTime, vals)
+	series := NewSeriesWithTags(b.ctx, b.name, startTime, vals, b.tags)
 	series.Specification = b.Specification
 	series.consolidationFunc = b.consolidationFunc
 	return series
@@ -147,6 +164,9 @@ func (b *Series) DerivedSeries(startTime time.Time, vals Values) *Series {
 // Name returns the name of the timeseries block
 func (b *Series) Name() string { return b.name }
 
+// Tags returns the graphite tags of the series, nil for series without tags.
+func (b *Series) Tags() map[string]string { return b.tags }
+
 // RenamedTo returns a new timeseries with the same values but a different name
 func (b *Series) RenamedTo(name string) *Series {
 	return &Series{
@@ -155,6 +175,7 @@ func (b *Series) RenamedTo(name string) *Series {
 		vals:              b.vals,
 		ctx:               b.ctx,
 		Specification:     b.Specification,
+		tags:              b.tags,
 		consolidationFunc: b.consolidationFunc,
 	}
 }
@@ -167,6 +188,7 @@ func (b *Series) Shift(shift time.Duration) *Series {
 		vals:              b.vals,
 		ctx:               b.ctx,
 		Specification:     b.Specification,
+		tags:              b.tags,
 		consolidationFunc: b.consolidationFunc,
 	}
 }
@@ -216,7 +238,8 @@ func (b *Series) Slice(begin, end int) (*Series, error) {
 		return nil, ErrRangeIsInvalid
 	}
 
-	result := NewSeries(b.ctx, b.name, b.StartTimeForStep(begin), b.vals.Slice(begin, end))
+	result := NewSeriesWithTags(b.ctx, b.name, b.StartTimeForStep(begin),
+		b.vals.Slice(begin, end), b.tags)
 	result.consolidationFunc = b.consolidationFunc
 
 	return result, nil
@@ -383,11 +406,11 @@ func (b *Series) IntersectAndResize(
 ) (*Series, error) {
 	intersects, start, end := b.intersection(start, end)
 	if !intersects {
-		ts := NewSeries(b.ctx, b.name, start, &float64Values{
+		ts := NewSeriesWithTags(b.ctx, b.name, start, &float64Values{
 			millisPerStep: millisPerStep,
 			values:        []float64{},
 			numSteps:      0,
-		})
+		}, b.tags)
 		ts.Specification = b.Specification
 		return ts, nil
 	}
@@ -405,11 +428,11 @@ func (b *Series) resized(
 	// TODO: This append based model completely screws pooling; need to rewrite to allow for pooling.
 	v := &resized{}
 	b.resizeStep(start, end, millisPerStep, stepAggregator, v.appender)
-	ts := NewSeries(b.ctx, b.name, start, &float64Values{
+	ts := NewSeriesWithTags(b.ctx, b.name, start, &float64Values{
 		millisPerStep: millisPerStep,
 		values:        v.values,
 		numSteps:      len(v.values),
-	})
+	}, b.tags)
 	ts.Specification = b.Specification
 	return ts
 }
@@ -306,6 +306,30 @@ func TestSlicing(t *testing.T) {
 	}
 }
 
+func TestSeriesTags(t *testing.T) {
+	ctx := context.New()
+	defer ctx.Close()
+
+	tags := map[string]string{"name": "disk.used", "dc": "east"}
+	series := NewSeriesWithTags(ctx, "disk.used;dc=east", time.Now(),
+		NewConstantValues(ctx, 42.0, 10, 100), tags)
+	assert.Equal(t, tags, series.Tags())
+
+	sliced, err := series.Slice(1, 5)
+	require.NoError(t, err)
+
+	for _, derived := range []*Series{
+		series.RenamedTo("scale(disk.used;dc=east,2)"),
+		series.Shift(time.Minute),
+		series.DerivedSeries(series.StartTime(), NewConstantValues(ctx, 1.0, 10, 100)),
+		sliced,
+	} {
+		assert.Equal(t, tags, derived.Tags())
+	}
+
+	assert.Nil(t, NewSeries(ctx, "disk.used", time.Now(), series.vals).Tags())
+}
+
 func TestAddSeries(t *testing.T) {
 	ctx := context.New()
 	defer ctx.Close()
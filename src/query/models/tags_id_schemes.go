@@ -21,6 +21,8 @@
 package models
 
 import (
+	"bytes"
+
 	"github.com/m3db/m3/src/query/models/strconv"
 	"github.com/m3db/m3/src/query/util/writer"
 )
@@ -218,6 +220,12 @@ func idLenGraphite(t Tags) int {
 }
 
 func graphiteID(t Tags) []byte {
+	for i, tag := range t.Tags {
+		if bytes.Equal(tag.Name, graphiteTaggedNameTag) {
+			return graphiteTaggedID(t, i)
+		}
+	}
+
 	// TODO: pool these bytes.
 	id := make([]byte, idLenGraphite(t))
 	idx := 0
@@ -231,3 +239,47 @@ func graphiteID(t Tags) []byte {
 	copy(id[idx:], t.Tags[lastIndex].Value)
 	return id
 }
+
+// graphiteTaggedID returns the graphite 1.1 canonical name of a tagged series,
+// the metric path followed by the remaining tags sorted lexically:
+// {name:disk.used},{host:a},{dc:east} -> disk.used;dc=east;host=a
+func graphiteTaggedID(t Tags, nameIdx int) []byte {
+	idLen := len(t.Tags[nameIdx].Value)
+	for i, tag := range t.Tags {
+		if i != nameIdx {
+			idLen += len(tag.Name) + len(tag.Value) + 2 // account for separators
+		}
+	}
+
+	// NB: the ID outlives the tags it is built from so it is not pooled, it is
+	// the only allocation since the remaining tags are written in lexical
+	// order by selecting the next one in place, tagged series having few tags.
+	id := make([]byte, idLen)
+	idx := copy(id, t.Tags[nameIdx].Value)
+	var last []byte
+	for {
+		next := -1
+		for i, tag := range t.Tags {
+			if i == nameIdx || (last != nil && bytes.Compare(tag.Name, last) <= 0) {
+				continue
+			}
+
+			if next < 0 || bytes.Compare(tag.Name, t.Tags[next].Name) < 0 {
+				next = i
+			}
+		}
+
+		if next < 0 {
+			return id[:idx]
+		}
+
+		tag := t.Tags[next]
+		id[idx] = graphiteTaggedSep
+		idx++
+		idx += copy(id[idx:], tag.Name)
+		id[idx] = eq
+		idx++
+		idx += copy(id[idx:], tag.Value)
+		last = tag.Name
+	}
+}
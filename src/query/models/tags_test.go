@@ -76,6 +76,24 @@ func TestLongTagNewIDOutOfOrderGraphite(t *testing.T) {
 	assert.Equal(t, []byte("v0.v1.v2.v3.v4.v5.v6.v7.v8.v9.v10.v11.v12"), actual)
 }
 
+func TestTaggedNewIDGraphite(t *testing.T) {
+	opts := NewTagOptions().SetIDSchemeType(TypeGraphite)
+	tags := NewTags(3, opts).AddTags([]Tag{
+		{Name: []byte("name"), Value: []byte("disk.used")},
+		{Name: []byte("host"), Value: []byte("a")},
+		{Name: []byte("dc"), Value: []byte("east")},
+	})
+
+	require.NoError(t, tags.Validate())
+	assert.Equal(t, []byte("disk.used;dc=east;host=a"), tags.ID())
+
+	// Graphite tags are sorted numerically, the ID sorts them lexically.
+	tags = tags.AddTag(Tag{Name: []byte("zone"), Value: []byte("z")}).
+		AddTag(Tag{Name: []byte("abcde"), Value: []byte("b")})
+	assert.Equal(t, []byte("disk.used;abcde=b;dc=east;host=a;zone=z"), tags.ID())
+	assert.Equal(t, 1.0, testing.AllocsPerRun(10, func() { tags.ID() }))
+}
+
 func TestLongTagNewIDOutOfOrderQuotedWithEscape(t *testing.T) {
 	tags := testLongTagIDOutOfOrder(t, TypeQuoted)
 	tags = tags.AddTag(Tag{Name: []byte(`t5""`), Value: []byte(`v"5`)})
@@ -27,14 +27,19 @@ import (
 
 // Separators for tags.
 const (
-	graphiteSep  = byte('.')
-	sep          = byte(',')
-	finish       = byte('!')
-	eq           = byte('=')
-	leftBracket  = byte('{')
-	rightBracket = byte('}')
+	graphiteSep       = byte('.')
+	graphiteTaggedSep = byte(';')
+	sep               = byte(',')
+	finish            = byte('!')
+	eq                = byte('=')
+	leftBracket       = byte('{')
+	rightBracket      = byte('}')
 )
 
+// graphiteTaggedNameTag is the tag holding the metric path of a graphite
+// tagged series.
+var graphiteTaggedNameTag = []byte("name")
+
 // IDSchemeType determines the scheme for generating
 // series IDs based on their tags.
 type IDSchemeType uint16
@@ -63,6 +68,10 @@ const (
 	// used on non-graphite data.
 	// {__g0__:v1},{__g1__:v2} -> v1.v2
 	//
+	// Graphite tagged series, which carry their metric path in a "name" tag,
+	// are instead given their graphite canonical name with sorted tags.
+	// {name:v1.v2},{dc:east} -> v1.v2;dc=east
+	//
 	// NB: when TypeGraphite is specified, tags are ordered numerically rather
 	// than lexically.
 	//
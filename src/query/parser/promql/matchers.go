@@ -39,6 +39,7 @@ import (
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	"github.com/m3db/m3/src/query/parser/common"
+	"github.com/m3db/m3/src/query/parser/promql/promparser"
 )
 
 // NewSelectorFromVector creates a new fetchop.
@@ -55,6 +56,7 @@ func NewSelectorFromVector(
 		Name:     n.Name,
 		Offset:   n.Offset,
 		Matchers: matchers,
+		At:       newAtModifier(n.Timestamp, n.StartOrEnd),
 	}, nil
 }
 
@@ -74,6 +76,7 @@ func NewSelectorFromMatrix(
 		Offset:   vectorSelector.Offset,
 		Matchers: matchers,
 		Range:    n.Range,
+		At:       newAtModifier(vectorSelector.Timestamp, vectorSelector.StartOrEnd),
 	}, nil
 }
 
@@ -104,6 +107,15 @@ func NewAggregationOperator(expr *promql.AggregateExpr) (parser.Params, error) {
 		nodeInformation.Parameter = val
 		return aggregation.NewTakeOp(op, nodeInformation)
 
+	case aggregation.LimitKType, aggregation.LimitRatioType:
+		val, err := resolveScalarArgument(expr.Param)
+		if err != nil {
+			return nil, err
+		}
+
+		nodeInformation.Parameter = val
+		return aggregation.NewLimitOp(op, nodeInformation)
+
 	case aggregation.CountValuesType:
 		paren := unwrapParenExpr(expr.Param)
 		val, err := resolveStringArgument(paren)
@@ -172,6 +184,11 @@ func getAggOpType(opType promql.ItemType) string {
 	case promql.COUNT_VALUES:
 		return aggregation.CountValuesType
 
+	case promparser.LIMITK:
+		return aggregation.LimitKType
+	case promparser.LIMIT_RATIO:
+		return aggregation.LimitRatioType
+
 	default:
 		return common.UnknownOpType
 	}
@@ -235,7 +252,7 @@ func NewFunctionExpr(
 	switch name {
 	case linear.AbsType, linear.CeilType, linear.ExpType,
 		linear.FloorType, linear.LnType, linear.Log10Type,
-		linear.Log2Type, linear.SqrtType:
+		linear.Log2Type, linear.SqrtType, linear.SgnType:
 		p, err = linear.NewMathOp(name)
 		return p, true, err
 
@@ -261,7 +278,8 @@ func NewFunctionExpr(
 
 	case temporal.AvgType, temporal.CountType, temporal.MinType,
 		temporal.MaxType, temporal.SumType, temporal.StdDevType,
-		temporal.StdVarType, temporal.LastType:
+		temporal.StdVarType, temporal.LastType, temporal.MadType,
+		temporal.PresentType:
 		p, err = temporal.NewAggOp(argValues, name)
 		return p, true, err
 
@@ -299,6 +317,10 @@ func NewFunctionExpr(
 		p, err = linear.NewSortOp(name)
 		return p, true, err
 
+	case linear.SortByLabelType, linear.SortByLabelDescType:
+		p, err = linear.NewSortByLabelOp(name, stringValues)
+		return p, true, err
+
 	// NB: no-ops.
 	case scalar.ScalarType:
 		return nil, false, err
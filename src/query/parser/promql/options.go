@@ -28,6 +28,7 @@ import (
 
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
+	"github.com/m3db/m3/src/query/parser/promql/promparser"
 	xclock "github.com/m3db/m3/src/x/clock"
 )
 
@@ -47,7 +48,7 @@ type ParseFunctionExpr func(
 type ParseFn func(query string) (pql.Expr, error)
 
 func defaultParseFn(query string) (pql.Expr, error) {
-	return pql.ParseExpr(query)
+	return promparser.ParseExpr(query)
 }
 
 // MetricSelectorFn is a function that parses a query to Prometheus selectors.
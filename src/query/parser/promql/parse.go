@@ -27,9 +27,14 @@ import (
 	pql "github.com/prometheus/prometheus/promql/parser"
 
 	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/executor/transform"
+	"github.com/m3db/m3/src/query/functions"
+	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
 	"github.com/m3db/m3/src/query/functions/lazy"
+	"github.com/m3db/m3/src/query/functions/linear"
 	"github.com/m3db/m3/src/query/functions/scalar"
+	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
 	xtime "github.com/m3db/m3/src/x/time"
@@ -56,7 +61,7 @@ func Parse(
 	}
 
 	return &promParser{
-		expr:              expr,
+		expr:              wrapStepInvariantExpr(expr),
 		stepSize:          stepSize,
 		tagOpts:           tagOpts,
 		parseFunctionExpr: parseOptions.FunctionParseExpr(),
@@ -161,6 +166,84 @@ func (p *parseState) addLazyOffsetTransform(offset time.Duration) error {
 	return nil
 }
 
+func (p *parseState) addSubqueryTransform(
+	n *pql.SubqueryExpr,
+	inner parser.Nodes,
+) error {
+	// NB: if no step is given, the subquery defaults to the query step size.
+	step := n.Step
+	if step == 0 {
+		step = p.stepSize
+	}
+
+	op, err := temporal.NewSubqueryOp(n.Range, step, n.OriginalOffset,
+		maxRange(inner), newAtModifier(n.Timestamp, n.StartOrEnd))
+	if err != nil {
+		return err
+	}
+
+	opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+	p.edges = append(p.edges, parser.Edge{
+		ParentID: p.lastTransformID(),
+		ChildID:  opTransform.ID,
+	})
+	p.transforms = append(p.transforms, opTransform)
+
+	return nil
+}
+
+func (p *parseState) addAbsentTransform() error {
+	op := aggregation.NewAbsentOp()
+	opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+	p.edges = append(p.edges, parser.Edge{
+		ParentID: p.lastTransformID(),
+		ChildID:  opTransform.ID,
+	})
+	p.transforms = append(p.transforms, opTransform)
+
+	return nil
+}
+
+func (p *parseState) addStepInvariantTransform(inner parser.Nodes) error {
+	op := temporal.NewStepInvariantOp(maxRange(inner))
+	opTransform := parser.NewTransformFromOperation(op, p.transformLen())
+	p.edges = append(p.edges, parser.Edge{
+		ParentID: p.lastTransformID(),
+		ChildID:  opTransform.ID,
+	})
+	p.transforms = append(p.transforms, opTransform)
+
+	return nil
+}
+
+// expandNativeHistograms marks the fetches of the given nodes to expand
+// native histograms into classic histogram bucket series, which allows
+// histogram_quantile to evaluate them.
+func expandNativeHistograms(nodes parser.Nodes) {
+	for i, node := range nodes {
+		if fetchOp, ok := node.Op.(functions.FetchOp); ok {
+			fetchOp.ExpandNativeHistograms = true
+			nodes[i].Op = fetchOp
+		}
+	}
+}
+
+// maxRange returns the largest range selected by the given nodes. Nodes which
+// evaluate inner expressions at a different time need enough data before
+// their own start to evaluate these range selectors.
+func maxRange(nodes parser.Nodes) time.Duration {
+	var result time.Duration
+	for _, node := range nodes {
+		if boundOp, ok := node.Op.(transform.BoundOp); ok {
+			if r := boundOp.Bounds().Range; r > result {
+				result = r
+			}
+		}
+	}
+
+	return result
+}
+
 func adjustOffset(offset time.Duration, step time.Duration) time.Duration {
 	// handles case where offset is 0 too.
 	align := offset % step
@@ -257,6 +340,8 @@ func (p *parseState) walk(node pql.Node) error {
 			n.Args[i] = unwrapParenExpr(expr)
 		}
 
+		innerIdx := p.transformLen()
+
 		var (
 			// argTypes describes Prom's expected argument types for this call.
 			argTypes = n.Func.ArgTypes
@@ -301,8 +386,15 @@ func (p *parseState) walk(node pql.Node) error {
 			} else if argType == pql.ValueTypeString {
 				stringValues = append(stringValues, expr.(*pql.StringLiteral).Val)
 			} else {
-				if e, ok := expr.(*pql.MatrixSelector); ok {
+				switch e := expr.(type) {
+				case *pql.MatrixSelector:
+					argValues = append(argValues, e.Range)
+				case *pql.SubqueryExpr:
 					argValues = append(argValues, e.Range)
+				case *pql.StepInvariantExpr:
+					if argType == pql.ValueTypeMatrix {
+						return errPinnedRangeArgument(n.Func.Name)
+					}
 				}
 
 				if err := p.walk(expr); err != nil {
@@ -328,7 +420,18 @@ func (p *parseState) walk(node pql.Node) error {
 			}
 		}
 
-		op, ok, err := p.parseFunctionExpr(n.Func.Name, argValues,
+		if n.Func.Name == linear.HistogramQuantileType {
+			expandNativeHistograms(p.transforms[innerIdx:])
+		}
+
+		name := n.Func.Name
+		if name == temporal.AbsentType {
+			// NB: absent_over_time is evaluated as the absent aggregation of
+			// present_over_time.
+			name = temporal.PresentType
+		}
+
+		op, ok, err := p.parseFunctionExpr(name, argValues,
 			stringValues, hasValue, n.Args.String(), p.tagOpts)
 		if err != nil {
 			return err
@@ -347,6 +450,10 @@ func (p *parseState) walk(node pql.Node) error {
 		}
 
 		p.transforms = append(p.transforms, opTransform)
+		if n.Func.Name == temporal.AbsentType {
+			return p.addAbsentTransform()
+		}
+
 		return nil
 
 	case *pql.BinaryExpr:
@@ -379,6 +486,22 @@ func (p *parseState) walk(node pql.Node) error {
 		p.transforms = append(p.transforms, opTransform)
 		return nil
 
+	case *pql.SubqueryExpr:
+		innerIdx := p.transformLen()
+		if err := p.walk(n.Expr); err != nil {
+			return err
+		}
+
+		return p.addSubqueryTransform(n, p.transforms[innerIdx:])
+
+	case *pql.StepInvariantExpr:
+		innerIdx := p.transformLen()
+		if err := p.walk(n.Expr); err != nil {
+			return err
+		}
+
+		return p.addStepInvariantTransform(p.transforms[innerIdx:])
+
 	case *pql.NumberLiteral:
 		op, err := newScalarOperator(n, p.tagOpts)
 		if err != nil {
@@ -29,6 +29,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/functions"
 	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/functions/binary"
@@ -39,6 +40,7 @@ import (
 	"github.com/m3db/m3/src/query/functions/temporal"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
+	xtime "github.com/m3db/m3/src/x/time"
 )
 
 func TestDAGWithCountOp(t *testing.T) {
@@ -156,6 +158,13 @@ var aggregateParseTests = []struct {
 	{"count_values(\"some_name\", up)", aggregation.CountValuesType},
 
 	{"absent(up)", aggregation.AbsentType},
+
+	{"limitk(3, up)", aggregation.LimitKType},
+	{"limitk(3, up) by (job)", aggregation.LimitKType},
+	{"limitk without (job) (3, up)", aggregation.LimitKType},
+	{"limit_ratio(0.5, up)", aggregation.LimitRatioType},
+	{"limit_ratio by (job) (-0.25, up)", aggregation.LimitRatioType},
+	{"limit_ratio  # ratio\n  (0.5, up)", aggregation.LimitRatioType},
 }
 
 func TestAggregateParses(t *testing.T) {
@@ -221,6 +230,7 @@ var linearParseTests = []struct {
 	{"log2(up)", linear.Log2Type},
 	{"log10(up)", linear.Log10Type},
 	{"sqrt(up)", linear.SqrtType},
+	{"sgn(up)", linear.SgnType},
 	{"round(up)", linear.RoundType},
 	{"round(up, 10)", linear.RoundType},
 
@@ -293,6 +303,9 @@ var sortTests = []struct {
 }{
 	{"sort(up)", linear.SortType},
 	{"sort_desc(up)", linear.SortDescType},
+	{`sort_by_label(up, "instance")`, linear.SortByLabelType},
+	{`sort_by_label_desc(up, "job", "instance")`, linear.SortByLabelDescType},
+	{"sort_by_label (\n  up, # by job\n  \"job\")", linear.SortByLabelType},
 }
 
 func TestSort(t *testing.T) {
@@ -481,6 +494,8 @@ var temporalParseTests = []struct {
 	{"holt_winters(up[5m], 0.2, 0.3)", temporal.HoltWintersType},
 	{"predict_linear(up[5m], 100)", temporal.PredictLinearType},
 	{"deriv(up[5m])", temporal.DerivType},
+	{"mad_over_time(up[5m])", temporal.MadType},
+	{"present_over_time(up[5m])", temporal.PresentType},
 }
 
 func TestTemporalParses(t *testing.T) {
@@ -503,6 +518,23 @@ func TestTemporalParses(t *testing.T) {
 	}
 }
 
+func TestAbsentOverTimeParses(t *testing.T) {
+	p, err := Parse("absent_over_time(up[5m])", time.Second,
+		models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 3)
+	assert.Equal(t, functions.FetchType, transforms[0].Op.OpType())
+	assert.Equal(t, temporal.PresentType, transforms[1].Op.OpType())
+	assert.Equal(t, aggregation.AbsentType, transforms[2].Op.OpType())
+	require.Len(t, edges, 2)
+	assert.Equal(t, parser.NodeID("0"), edges[0].ParentID)
+	assert.Equal(t, parser.NodeID("1"), edges[0].ChildID)
+	assert.Equal(t, parser.NodeID("1"), edges[1].ParentID)
+	assert.Equal(t, parser.NodeID("2"), edges[1].ChildID)
+}
+
 var tagParseTests = []struct {
 	q            string
 	expectedType string
@@ -531,6 +563,131 @@ func TestTagParses(t *testing.T) {
 	}
 }
 
+func TestSubqueryParses(t *testing.T) {
+	q := "max_over_time(rate(http_requests_total[5m])[1h:1m] offset 2m)"
+	p, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, edges, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 4)
+	assert.Equal(t, transforms[0].Op.OpType(), functions.FetchType)
+	assert.Equal(t, transforms[1].Op.OpType(), temporal.RateType)
+	assert.Equal(t, transforms[2].Op.OpType(), temporal.SubqueryType)
+	assert.Equal(t, transforms[3].Op.OpType(), temporal.MaxType)
+
+	subquery, ok := transforms[2].Op.(temporal.SubqueryOp)
+	require.True(t, ok)
+	assert.Equal(t, temporal.SubqueryOp{
+		Range:      time.Hour,
+		Step:       time.Minute,
+		Offset:     2 * time.Minute,
+		InnerRange: 5 * time.Minute,
+	}, subquery)
+
+	require.Len(t, edges, 3)
+	for i, edge := range edges {
+		assert.Equal(t, parser.NodeID(fmt.Sprint(i)), edge.ParentID)
+		assert.Equal(t, parser.NodeID(fmt.Sprint(i+1)), edge.ChildID)
+	}
+}
+
+func TestSubqueryDefaultsToQueryStep(t *testing.T) {
+	q := "sum_over_time(up[10m:])"
+	p, err := Parse(q, 15*time.Second, models.NewTagOptions(), NewParseOptions())
+	require.NoError(t, err)
+	transforms, _, err := p.DAG()
+	require.NoError(t, err)
+	require.Len(t, transforms, 3)
+
+	subquery, ok := transforms[1].Op.(temporal.SubqueryOp)
+	require.True(t, ok)
+	assert.Equal(t, 10*time.Minute, subquery.Range)
+	assert.Equal(t, 15*time.Second, subquery.Step)
+	assert.Equal(t, time.Duration(0), subquery.InnerRange)
+}
+
+func TestHistogramQuantileExpandsNativeHistograms(t *testing.T) {
+	tests := []struct {
+		q        string
+		expected []bool
+	}{
+		{q: "histogram_quantile(0.9, rate(foo[1m]))", expected: []bool{true}},
+		{q: "histogram_quantile(0.9, sum by (le) (foo)) + bar", expected: []bool{true, false}},
+		{q: "rate(foo[1m])", expected: []bool{false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			p, err := Parse(tt.q, time.Second, models.NewTagOptions(), NewParseOptions())
+			require.NoError(t, err)
+			transforms, _, err := p.DAG()
+			require.NoError(t, err)
+
+			var expand []bool
+			for _, tr := range transforms {
+				if fetch, ok := tr.Op.(functions.FetchOp); ok {
+					expand = append(expand, fetch.ExpandNativeHistograms)
+				}
+			}
+			assert.Equal(t, tt.expected, expand)
+		})
+	}
+}
+
+func TestAtModifierParses(t *testing.T) {
+	at := &transform.AtModifier{Timestamp: xtime.UnixNano(100 * time.Second)}
+	tests := []struct {
+		q        string
+		expected []string
+		at       []*transform.AtModifier
+	}{
+		{
+			q:        "up @ 100",
+			expected: []string{functions.FetchType, temporal.StepInvariantType},
+			at:       []*transform.AtModifier{at},
+		},
+		{
+			q: "rate(up[5m] @ end())",
+			expected: []string{functions.FetchType, temporal.RateType,
+				temporal.StepInvariantType},
+			at: []*transform.AtModifier{{End: true}},
+		},
+		{
+			q: "sum(up @ start()) + 1",
+			expected: []string{functions.FetchType, aggregation.SumType,
+				scalar.ScalarType, binary.PlusType, temporal.StepInvariantType},
+			at: []*transform.AtModifier{{Start: true}},
+		},
+		{
+			q: "up - up @ 100",
+			expected: []string{functions.FetchType, functions.FetchType,
+				temporal.StepInvariantType, binary.MinusType},
+			at: []*transform.AtModifier{nil, at},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			p, err := Parse(tt.q, time.Second, models.NewTagOptions(),
+				NewParseOptions())
+			require.NoError(t, err)
+			transforms, _, err := p.DAG()
+			require.NoError(t, err)
+			require.Len(t, transforms, len(tt.expected))
+
+			var fetchAt []*transform.AtModifier
+			for i, node := range transforms {
+				assert.Equal(t, tt.expected[i], node.Op.OpType())
+				if fetch, ok := node.Op.(functions.FetchOp); ok {
+					fetchAt = append(fetchAt, fetch.At)
+				}
+			}
+
+			assert.Equal(t, tt.at, fetchAt)
+		})
+	}
+}
+
 func TestFailedTemporalParse(t *testing.T) {
 	q := "unknown_over_time(http_requests_total[5m])"
 	_, err := Parse(q, time.Second, models.NewTagOptions(), NewParseOptions())
@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package promparser
+
+import (
+	"strings"
+
+	pql "github.com/prometheus/prometheus/promql/parser"
+)
+
+// Aggregation operators supported by the native engine that are not known
+// to the Prometheus parser.
+//
+// NB: they take a parameter like topk, and are lexed as its token so that
+// they are parsed by the same grammar. They are told apart from it by their
+// value when the aggregation is built. The Prometheus expression printer
+// does not know them either, so they are printed by their number.
+const (
+	nativeAggregatorsStart ItemType = startSymbolsEnd + iota
+	// LIMITK is the limitk aggregation operator.
+	LIMITK
+	// LIMIT_RATIO is the limit_ratio aggregation operator.
+	LIMIT_RATIO
+	nativeAggregatorsEnd
+)
+
+var nativeAggregators = map[string]ItemType{
+	"limitk":      LIMITK,
+	"limit_ratio": LIMIT_RATIO,
+}
+
+// IsNativeAggregator returns true if the item type is an aggregation
+// operator that is not known to the Prometheus parser.
+func IsNativeAggregator(i ItemType) bool {
+	return i > nativeAggregatorsStart && i < nativeAggregatorsEnd
+}
+
+// aggregateOp returns the aggregation operator of the item.
+func aggregateOp(op Item) ItemType {
+	if native, ok := nativeAggregators[strings.ToLower(op.Val)]; ok {
+		return native
+	}
+
+	return op.Typ
+}
+
+func isAggregatorWithParam(i ItemType) bool {
+	return i.IsAggregatorWithParam() || IsNativeAggregator(i)
+}
+
+// nativeFunctions are the functions supported by the native engine that are
+// not known to the Prometheus parser.
+var nativeFunctions = map[string]*Function{
+	"mad_over_time": {
+		Name:       "mad_over_time",
+		ArgTypes:   []ValueType{ValueTypeMatrix},
+		ReturnType: ValueTypeVector,
+	},
+	"sort_by_label": {
+		Name:       "sort_by_label",
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
+		Variadic:   -1,
+		ReturnType: ValueTypeVector,
+	},
+	"sort_by_label_desc": {
+		Name:       "sort_by_label_desc",
+		ArgTypes:   []ValueType{ValueTypeVector, ValueTypeString},
+		Variadic:   -1,
+		ReturnType: ValueTypeVector,
+	},
+}
+
+func getFunction(name string) (*Function, bool) {
+	if fn, ok := nativeFunctions[name]; ok {
+		return fn, true
+	}
+
+	fn, ok := pql.Functions[name]
+	return fn, ok
+}
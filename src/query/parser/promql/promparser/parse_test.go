@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package promparser
+
+import (
+	"testing"
+
+	pql "github.com/prometheus/prometheus/promql/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExprNativeAggregations(t *testing.T) {
+	tests := []struct {
+		q        string
+		op       ItemType
+		grouping []string
+		without  bool
+	}{
+		{q: "limitk(2, up)", op: LIMITK},
+		{q: "limitk(2, up) by (job)", op: LIMITK, grouping: []string{"job"}},
+		{q: "LIMITK by (job) (2, up)", op: LIMITK, grouping: []string{"job"}},
+		{q: "limit_ratio without (job) (0.5, up)", op: LIMIT_RATIO,
+			grouping: []string{"job"}, without: true},
+		{q: "limit_ratio\n\t# ratio of the series\n\t(0.5, up)", op: LIMIT_RATIO},
+		{q: "topk(2, up)", op: TOPK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			expr, err := ParseExpr(tt.q)
+			require.NoError(t, err)
+
+			agg, ok := expr.(*AggregateExpr)
+			require.True(t, ok)
+			assert.Equal(t, tt.op, agg.Op)
+			assert.Equal(t, tt.grouping, agg.Grouping)
+			assert.Equal(t, tt.without, agg.Without)
+			assert.Equal(t, Pos(0), agg.PosRange.Start)
+			assert.Equal(t, Pos(len(tt.q)), agg.PosRange.End)
+			require.NotNil(t, agg.Param)
+			assert.Equal(t, "up", agg.Expr.String())
+		})
+	}
+}
+
+func TestParseExprNativeFunctions(t *testing.T) {
+	q := `sort_by_label_desc(
+		sort_by_label(mad_over_time(up[5m]), "job", "instance"), # by job
+		"env"
+	)`
+	expr, err := ParseExpr(q)
+	require.NoError(t, err)
+
+	call, ok := expr.(*Call)
+	require.True(t, ok)
+	assert.Equal(t, nativeFunctions["sort_by_label_desc"], call.Func)
+	require.Len(t, call.Args, 2)
+
+	inner, ok := call.Args[0].(*Call)
+	require.True(t, ok)
+	assert.Equal(t, nativeFunctions["sort_by_label"], inner.Func)
+	require.Len(t, inner.Args, 3)
+
+	mad, ok := inner.Args[0].(*Call)
+	require.True(t, ok)
+	assert.Equal(t, nativeFunctions["mad_over_time"], mad.Func)
+
+	assert.Equal(t, `sort_by_label_desc(sort_by_label(mad_over_time(up[5m]), `+
+		`"job", "instance"), "env")`, expr.String())
+}
+
+func TestParseExprNativeNamesAsLabels(t *testing.T) {
+	expr, err := ParseExpr(`sum by (limitk) (limitk{limit_ratio="limitk("})`)
+	require.NoError(t, err)
+
+	agg, ok := expr.(*AggregateExpr)
+	require.True(t, ok)
+	assert.Equal(t, ItemType(SUM), agg.Op)
+	assert.Equal(t, []string{"limitk"}, agg.Grouping)
+	assert.Equal(t, "limitk", agg.Expr.(*VectorSelector).Name)
+}
+
+func TestParseExprNativeErrors(t *testing.T) {
+	tests := []struct {
+		q   string
+		err string
+	}{
+		{
+			q:   `limitk("a", up)`,
+			err: `1:8: parse error: expected type scalar in aggregation parameter, got string`,
+		},
+		{
+			q:   `limitk(up)`,
+			err: `1:1: parse error: wrong number of arguments for aggregate expression provided, expected 2, got 1`,
+		},
+		{
+			q:   `sort_by_label(up, 1)`,
+			err: `1:19: parse error: expected type string in call to function "sort_by_label", got scalar`,
+		},
+		{
+			q:   "mad_over_time(\n  up)",
+			err: `2:3: parse error: expected type range vector in call to function "mad_over_time", got instant vector`,
+		},
+		{
+			q:   `mad_over_time by (job) (up[5m])`,
+			err: `1:15: parse error: unexpected <by>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.q, func(t *testing.T) {
+			_, err := ParseExpr(tt.q)
+			require.Error(t, err)
+			assert.Equal(t, tt.err, err.Error())
+		})
+	}
+}
+
+func TestParseExprDoesNotRegisterNativeFunctions(t *testing.T) {
+	_, err := ParseExpr("limitk(1, up) + mad_over_time(up[1m])")
+	require.NoError(t, err)
+
+	for name := range nativeFunctions {
+		_, ok := pql.Functions[name]
+		assert.False(t, ok, name)
+	}
+
+	_, err = pql.ParseExpr("mad_over_time(up[1m])")
+	require.Error(t, err)
+
+	_, err = pql.ParseExpr("limitk(1, up)")
+	require.Error(t, err)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package promparser is a fork of the Prometheus expression parser that
+// supports the functions and aggregations of the native engine which the
+// Prometheus parser does not know.
+//
+// NB: the functions of the Prometheus parser are global and shared with the
+// Prometheus engine, so they are not registered with it. The grammar and the
+// generated parser are the ones of the vendored Prometheus version.
+package promparser
+
+import (
+	"fmt"
+
+	pql "github.com/prometheus/prometheus/promql/parser"
+)
+
+// NB: the parser builds the expression types of the Prometheus parser, so
+// that parsed expressions can be used wherever Prometheus expressions are.
+type (
+	// Node is a node of a parsed expression.
+	Node = pql.Node
+	// Expr is a parsed expression.
+	Expr = pql.Expr
+	// Expressions is a list of parsed expressions.
+	Expressions = pql.Expressions
+
+	// AggregateExpr is an aggregation.
+	AggregateExpr = pql.AggregateExpr
+	// BinaryExpr is a binary expression.
+	BinaryExpr = pql.BinaryExpr
+	// Call is a function call.
+	Call = pql.Call
+	// MatrixSelector is a range vector selector.
+	MatrixSelector = pql.MatrixSelector
+	// SubqueryExpr is a subquery.
+	SubqueryExpr = pql.SubqueryExpr
+	// NumberLiteral is a number.
+	NumberLiteral = pql.NumberLiteral
+	// ParenExpr is a parenthesized expression.
+	ParenExpr = pql.ParenExpr
+	// StringLiteral is a string.
+	StringLiteral = pql.StringLiteral
+	// UnaryExpr is a unary expression.
+	UnaryExpr = pql.UnaryExpr
+	// VectorSelector is an instant vector selector.
+	VectorSelector = pql.VectorSelector
+	// EvalStmt is an evaluation statement.
+	EvalStmt = pql.EvalStmt
+
+	// VectorMatching describes the matching of a binary expression.
+	VectorMatching = pql.VectorMatching
+	// Function is a function of the expression language.
+	Function = pql.Function
+	// ValueType is the type of an expression.
+	ValueType = pql.ValueType
+
+	// Item is a token returned by the lexer.
+	Item = pql.Item
+	// ItemType is the type of a token.
+	ItemType = pql.ItemType
+	// Pos is a position in the query.
+	Pos = pql.Pos
+	// PositionRange is a range of positions in the query.
+	PositionRange = pql.PositionRange
+
+	// ParseErr is a parse error.
+	ParseErr = pql.ParseErr
+	// ParseErrors are the parse errors of a query.
+	ParseErrors = pql.ParseErrors
+)
+
+const (
+	// CardOneToOne is one-to-one vector matching.
+	CardOneToOne = pql.CardOneToOne
+	// CardManyToOne is many-to-one vector matching.
+	CardManyToOne = pql.CardManyToOne
+	// CardOneToMany is one-to-many vector matching.
+	CardOneToMany = pql.CardOneToMany
+	// CardManyToMany is many-to-many vector matching.
+	CardManyToMany = pql.CardManyToMany
+
+	// ValueTypeNone is the type of expressions without a value.
+	ValueTypeNone = pql.ValueTypeNone
+	// ValueTypeVector is the type of instant vectors.
+	ValueTypeVector = pql.ValueTypeVector
+	// ValueTypeScalar is the type of scalars.
+	ValueTypeScalar = pql.ValueTypeScalar
+	// ValueTypeMatrix is the type of range vectors.
+	ValueTypeMatrix = pql.ValueTypeMatrix
+	// ValueTypeString is the type of strings.
+	ValueTypeString = pql.ValueTypeString
+)
+
+func mergeRanges(first, last Node) PositionRange {
+	return PositionRange{
+		Start: first.PositionRange().Start,
+		End:   last.PositionRange().End,
+	}
+}
+
+// itemDesc describes the item in errors.
+func itemDesc(i Item) string {
+	if _, ok := pql.ItemTypeStr[i.Typ]; ok {
+		return i.String()
+	}
+	if i.Typ == EOF {
+		return itemTypeDesc(i.Typ)
+	}
+	return fmt.Sprintf("%s %s", itemTypeDesc(i.Typ), i)
+}
+
+func itemTypeDesc(i ItemType) string {
+	switch i {
+	case ERROR:
+		return "error"
+	case EOF:
+		return "end of input"
+	case COMMENT:
+		return "comment"
+	case IDENTIFIER:
+		return "identifier"
+	case METRIC_IDENTIFIER:
+		return "metric identifier"
+	case STRING:
+		return "string"
+	case NUMBER:
+		return "number"
+	case DURATION:
+		return "duration"
+	}
+	return fmt.Sprintf("%q", i)
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package promql
+
+import (
+	"fmt"
+	"time"
+
+	prom "github.com/prometheus/prometheus/promql"
+	pql "github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/m3db/m3/src/query/executor/transform"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// wrapStepInvariantExpr wraps every sub-expression that is pinned to a fixed
+// time by the @ modifier, and so evaluates to the same value at every step,
+// in a StepInvariantExpr. This mirrors the preprocessing done by the
+// Prometheus engine, except that expressions without any @ modifier, such as
+// number literals, are left as is.
+func wrapStepInvariantExpr(expr pql.Expr) pql.Expr {
+	if invariant, pinned := stepInvariant(expr); invariant && pinned {
+		return newStepInvariantExpr(expr)
+	}
+
+	return expr
+}
+
+// stepInvariant wraps step invariant children of the given expression, and
+// returns whether the expression itself is step invariant, and whether it is
+// pinned by an @ modifier.
+func stepInvariant(expr pql.Expr) (bool, bool) {
+	switch n := expr.(type) {
+	case *pql.VectorSelector:
+		pinned := isPinned(n.Timestamp, n.StartOrEnd)
+		return pinned, pinned
+
+	case *pql.MatrixSelector:
+		return stepInvariant(n.VectorSelector)
+
+	case *pql.AggregateExpr:
+		return stepInvariant(n.Expr)
+
+	case *pql.BinaryExpr:
+		lhsInvariant, lhsPinned := stepInvariant(n.LHS)
+		rhsInvariant, rhsPinned := stepInvariant(n.RHS)
+		if lhsInvariant && rhsInvariant {
+			return true, lhsPinned || rhsPinned
+		}
+
+		if lhsInvariant && lhsPinned {
+			n.LHS = newStepInvariantExpr(n.LHS)
+		}
+
+		if rhsInvariant && rhsPinned {
+			n.RHS = newStepInvariantExpr(n.RHS)
+		}
+
+		return false, false
+
+	case *pql.Call:
+		_, unsafe := prom.AtModifierUnsafeFunctions[n.Func.Name]
+		var (
+			invariant  = !unsafe
+			pinned     = false
+			argsPinned = make([]bool, len(n.Args))
+		)
+
+		for i, arg := range n.Args {
+			argInvariant, argPinned := stepInvariant(arg)
+			invariant = invariant && argInvariant
+			pinned = pinned || argPinned
+			argsPinned[i] = argInvariant && argPinned
+		}
+
+		if invariant {
+			return true, pinned
+		}
+
+		for i, argPinned := range argsPinned {
+			if argPinned {
+				n.Args[i] = newStepInvariantExpr(n.Args[i])
+			}
+		}
+
+		return false, false
+
+	case *pql.SubqueryExpr:
+		if invariant, pinned := stepInvariant(n.Expr); invariant && pinned {
+			n.Expr = newStepInvariantExpr(n.Expr)
+		}
+
+		pinned := isPinned(n.Timestamp, n.StartOrEnd)
+		return pinned, pinned
+
+	case *pql.ParenExpr:
+		return stepInvariant(n.Expr)
+
+	case *pql.UnaryExpr:
+		return stepInvariant(n.Expr)
+
+	case *pql.StringLiteral, *pql.NumberLiteral:
+		return true, false
+	}
+
+	return false, false
+}
+
+func newStepInvariantExpr(expr pql.Expr) pql.Expr {
+	return &pql.StepInvariantExpr{Expr: unwrapParenExpr(expr)}
+}
+
+func isPinned(timestamp *int64, startOrEnd pql.ItemType) bool {
+	return timestamp != nil || startOrEnd == pql.START || startOrEnd == pql.END
+}
+
+// newAtModifier converts the @ modifier of an expression, if any.
+func newAtModifier(
+	timestamp *int64,
+	startOrEnd pql.ItemType,
+) *transform.AtModifier {
+	switch {
+	case startOrEnd == pql.START:
+		return &transform.AtModifier{Start: true}
+	case startOrEnd == pql.END:
+		return &transform.AtModifier{End: true}
+	case timestamp != nil:
+		return &transform.AtModifier{
+			Timestamp: xtime.UnixNano(*timestamp * int64(time.Millisecond)),
+		}
+	default:
+		return nil
+	}
+}
+
+// errPinnedRangeArgument is returned when a range argument to a function is
+// pinned by an @ modifier but the function as a whole can not be.
+func errPinnedRangeArgument(name string) error {
+	return fmt.Errorf("@ modifier on range argument to %q requires all "+
+		"other arguments to be step invariant", name)
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
+	xtime "github.com/m3db/m3/src/x/time"
 )
 
 // PhysicalPlan represents the physical plan.
@@ -75,6 +76,7 @@ func NewPhysicalPlan(
 		LookbackDuration: params.LookbackDuration,
 	}
 
+	p.resolveAtModifiers(params.Start, params.End)
 	pl, err := p.createResultNode()
 	if err != nil {
 		return PhysicalPlan{}, err
@@ -85,6 +87,20 @@ func NewPhysicalPlan(
 	return pl, nil
 }
 
+// resolveAtModifiers resolves any @ start() or @ end() modifiers against the
+// bounds of the query.
+func (p PhysicalPlan) resolveAtModifiers(start, end xtime.UnixNano) {
+	for id, step := range p.steps {
+		atOp, ok := step.Transform.Op.(transform.AtModifierOp)
+		if !ok {
+			continue
+		}
+
+		step.Transform.Op = atOp.ResolveAtModifier(start, end)
+		p.steps[id] = step
+	}
+}
+
 func (p PhysicalPlan) shiftTime() PhysicalPlan {
 	var maxRange time.Duration
 
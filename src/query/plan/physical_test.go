@@ -27,10 +27,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/m3db/m3/src/query/executor/transform"
 	"github.com/m3db/m3/src/query/functions"
 	"github.com/m3db/m3/src/query/functions/aggregation"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser"
+	xtime "github.com/m3db/m3/src/x/time"
 )
 
 func testRequestParams() models.RequestParams {
@@ -139,3 +141,28 @@ func TestShiftTime(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveAtModifiers(t *testing.T) {
+	fetchTransform := parser.NewTransformFromOperation(functions.FetchOp{
+		At: &transform.AtModifier{End: true},
+	}, 1)
+
+	lp, err := NewLogicalPlan(parser.Nodes{fetchTransform}, parser.Edges{})
+	require.NoError(t, err)
+
+	params := testRequestParams()
+	params.Start = xtime.Now().Truncate(time.Hour)
+	params.End = params.Start.Add(time.Hour)
+	p, err := NewPhysicalPlan(lp, params)
+	require.NoError(t, err)
+
+	step, ok := p.Step(fetchTransform.ID)
+	require.True(t, ok)
+	fetchOp, ok := step.Transform.Op.(functions.FetchOp)
+	require.True(t, ok)
+	assert.Equal(t, &transform.AtModifier{Timestamp: params.End}, fetchOp.At)
+
+	// NB: the logical plan is left unchanged.
+	assert.Equal(t, &transform.AtModifier{End: true},
+		lp.Steps[fetchTransform.ID].Transform.Op.(functions.FetchOp).At)
+}
@@ -62,6 +62,7 @@ import (
 	"github.com/m3db/m3/src/query/api/v1/options"
 	m3dbcluster "github.com/m3db/m3/src/query/cluster/m3db"
 	"github.com/m3db/m3/src/query/executor"
+	"github.com/m3db/m3/src/query/graphite/events"
 	graphite "github.com/m3db/m3/src/query/graphite/storage"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/parser/promql"
@@ -78,6 +79,7 @@ import (
 	"github.com/m3db/m3/src/query/stores/m3db"
 	"github.com/m3db/m3/src/x/clock"
 	xconfig "github.com/m3db/m3/src/x/config"
+	"github.com/m3db/m3/src/x/ident"
 	"github.com/m3db/m3/src/x/instrument"
 	xio "github.com/m3db/m3/src/x/io"
 	xnet "github.com/m3db/m3/src/x/net"
@@ -563,6 +565,9 @@ func Run(runOpts RunOptions) RunResult {
 	}
 
 	engine := executor.NewEngine(engineOpts)
+	if splitOpts, ok := cfg.Query.Split.SplitOptions(); ok {
+		engine = executor.NewSplitEngine(engine, splitOpts)
+	}
 	downsamplerAndWriter, err := newDownsamplerAndWriter(
 		backendStorage,
 		downsampler,
@@ -679,6 +684,34 @@ func Run(runOpts RunOptions) RunResult {
 	if err != nil {
 		logger.Fatal("unable to set up handler options", zap.Error(err))
 	}
+	handlerOptions = handlerOptions.SetGraphiteTagsRouter(httpd.NewGraphiteTagsRouter())
+	if cfg.Carbon != nil && cfg.Carbon.Events != nil {
+		if m3dbClusters == nil {
+			logger.Fatal("graphite events are only supported when connecting to M3DB clusters directly")
+		}
+		eventsStore, err := newGraphiteEventsStore(*cfg.Carbon.Events, m3dbClusters)
+		if err != nil {
+			logger.Fatal("unable to create graphite events store", zap.Error(err))
+		}
+		handlerOptions = handlerOptions.SetGraphiteEventsStore(eventsStore)
+	}
+
+	if cfg.Scrape != nil {
+		scrapeManager, err := cfg.Scrape.NewManager(downsamplerAndWriter,
+			tagOptions, handlerOptions.StoreMetricsType(), instrumentOptions)
+		if err != nil {
+			logger.Fatal("unable to create scrape manager", zap.Error(err))
+		}
+		if err := scrapeManager.Start(); err != nil {
+			logger.Fatal("unable to start scrape manager", zap.Error(err))
+		}
+		defer func() {
+			if err := scrapeManager.Close(); err != nil {
+				logger.Warn("unable to close scrape manager", zap.Error(err))
+			}
+		}()
+		handlerOptions = handlerOptions.SetScrapeManager(scrapeManager)
+	}
 
 	var customHandlerOpts options.CustomHandlerOptions
 	if runOpts.CustomHandlerOptions != nil {
@@ -765,10 +798,10 @@ func Run(runOpts RunOptions) RunResult {
 	}
 
 	if cfg.Carbon != nil && cfg.Carbon.Ingester != nil {
-		server := startCarbonIngestion(*cfg.Carbon.Ingester, listenerOpts,
+		closeCarbonServers := startCarbonIngestion(*cfg.Carbon.Ingester, listenerOpts,
 			instrumentOptions, logger, m3dbClusters, clusterNamespacesWatcher,
 			downsamplerAndWriter)
-		defer server.Close()
+		defer closeCarbonServers()
 	}
 
 	// Stop our async watch and now block waiting for the interrupt.
@@ -1269,6 +1302,27 @@ func startGRPCServer(
 	return server, nil
 }
 
+// newGraphiteEventsStore returns a graphite events store writing to the
+// dedicated events namespace of the unaggregated cluster, resolved lazily
+// since dynamic clusters may not have initialized yet.
+func newGraphiteEventsStore(
+	cfg config.CarbonEventsConfiguration,
+	clusters m3.Clusters,
+) (events.Store, error) {
+	if cfg.Namespace == "" {
+		return nil, errors.New("graphite events require a dedicated namespace")
+	}
+
+	namespace := ident.StringID(cfg.Namespace)
+	return events.NewM3Store(func() (client.Session, ident.ID, error) {
+		ns, ok := clusters.UnaggregatedClusterNamespace()
+		if !ok {
+			return nil, nil, errors.New("unaggregated namespace is not yet initialized")
+		}
+		return ns.Session(), namespace, nil
+	}, cfg.LimitOrDefault()), nil
+}
+
 func startCarbonIngestion(
 	ingesterCfg config.CarbonIngesterConfiguration,
 	listenerOpts xnet.ListenerOptions,
@@ -1277,7 +1331,7 @@ func startCarbonIngestion(
 	m3dbClusters m3.Clusters,
 	clusterNamespacesWatcher m3.ClusterNamespacesWatcher,
 	downsamplerAndWriter ingest.DownsamplerAndWriter,
-) xserver.Server {
+) func() {
 	logger.Info("carbon ingestion enabled, configuring ingester")
 
 	// Setup worker pool.
@@ -1341,7 +1395,41 @@ func startCarbonIngestion(
 
 	logger.Info("started carbon ingestion server", zap.String("listenAddress", carbonListenAddress))
 
-	return carbonServer
+	var (
+		pickleServer xserver.Server
+		udpServer    xserver.PacketServer
+	)
+	if pickleListenAddress := ingesterCfg.PickleListenAddress; pickleListenAddress != "" {
+		pickleServer = xserver.NewServer(pickleListenAddress, ingester.PickleHandler(), serverOpts)
+
+		logger.Info("starting carbon pickle ingestion server", zap.String("listenAddress", pickleListenAddress))
+		if err := pickleServer.ListenAndServe(); err != nil {
+			logger.Fatal("unable to start carbon pickle ingestion server at listen address",
+				zap.String("listenAddress", pickleListenAddress), zap.Error(err))
+		}
+		logger.Info("started carbon pickle ingestion server", zap.String("listenAddress", pickleListenAddress))
+	}
+
+	if udpListenAddress := ingesterCfg.UDPListenAddress; udpListenAddress != "" {
+		udpServer = xserver.NewPacketServer(udpListenAddress, ingester, serverOpts)
+
+		logger.Info("starting carbon UDP ingestion server", zap.String("listenAddress", udpListenAddress))
+		if err := udpServer.ListenAndServe(); err != nil {
+			logger.Fatal("unable to start carbon UDP ingestion server at listen address",
+				zap.String("listenAddress", udpListenAddress), zap.Error(err))
+		}
+		logger.Info("started carbon UDP ingestion server", zap.String("listenAddress", udpListenAddress))
+	}
+
+	return func() {
+		carbonServer.Close()
+		if pickleServer != nil {
+			pickleServer.Close()
+		}
+		if udpServer != nil {
+			udpServer.Close()
+		}
+	}
 }
 
 func newDownsamplerAndWriter(
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exemplar
+
+import "github.com/m3db/m3/src/x/instrument"
+
+const defaultLimit = 10000
+
+// Configuration is the configuration for storing exemplars.
+type Configuration struct {
+	// Namespace is the dbnode namespace of the unaggregated cluster that
+	// exemplars are stored in, exemplars are dropped if not set. The namespace
+	// must be dedicated to exemplars since their series have the same IDs and
+	// tags as the series they belong to.
+	Namespace string `yaml:"namespace"`
+	// Limit is the max number of series returned for each selector of an
+	// exemplars query, it defaults to 10,000.
+	Limit int `yaml:"limit" validate:"min=0"`
+	// QueueSize is the max number of series whose exemplars are waiting to
+	// be written, it defaults to 4,096. Exemplars are dropped once it is
+	// reached.
+	QueueSize int `yaml:"queueSize" validate:"min=0"`
+	// WriteBatchSize is the max number of series whose exemplars are written
+	// at once, it defaults to 128.
+	WriteBatchSize int `yaml:"writeBatchSize" validate:"min=0"`
+}
+
+// NewStore returns the configured exemplar store, or nil if it is disabled.
+func (c Configuration) NewStore(
+	namespaceFn NamespaceFn,
+	instrumentOpts instrument.Options,
+) Store {
+	if c.Namespace == "" {
+		return nil
+	}
+
+	limit := c.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	return NewM3Store(Options{
+		NamespaceFn:       namespaceFn,
+		Limit:             limit,
+		QueueSize:         c.QueueSize,
+		WriteBatchSize:    c.WriteBatchSize,
+		InstrumentOptions: instrumentOpts,
+	})
+}
@@ -0,0 +1,445 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package exemplar stores the exemplars received alongside Prometheus remote
+// write requests in a dbnode namespace.
+package exemplar
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/parser/promql"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/x/ident"
+	"github.com/m3db/m3/src/x/instrument"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var (
+	// ErrExemplarLabelLength is returned when the labels of an exemplar are
+	// longer than allowed by the OpenMetrics specification.
+	ErrExemplarLabelLength = fmt.Errorf("label length for exemplar exceeds "+
+		"maximum of %d UTF-8 characters", exemplar.ExemplarMaxLabelSetLength)
+
+	errNoNamespace = errors.New("no namespace to store exemplars in")
+	errQueueFull   = errors.New("exemplar write queue is full")
+)
+
+// Store is a store of exemplars.
+type Store interface {
+	// Append queues exemplars for the series with the given labels to be
+	// written. Invalid exemplars are skipped and the last error encountered
+	// is returned, exemplars are dropped if the queue is full.
+	Append(series labels.Labels, exemplars []exemplar.Exemplar) error
+
+	// Select returns the exemplars within the start and end times (inclusive)
+	// for series that match any of the matcher sets.
+	Select(
+		ctx context.Context,
+		start, end time.Time,
+		matchers ...[]*labels.Matcher,
+	) ([]exemplar.QueryResult, error)
+}
+
+// NamespaceFn returns the session and the namespace to store exemplars in.
+type NamespaceFn func() (client.Session, ident.ID, error)
+
+const (
+	defaultQueueSize      = 4096
+	defaultWriteBatchSize = 128
+)
+
+// Options are the options for an exemplar store.
+type Options struct {
+	// NamespaceFn returns the session and namespace to store exemplars in.
+	NamespaceFn NamespaceFn
+	// Limit bounds the number of series returned for each matcher set of a
+	// query.
+	Limit int
+	// QueueSize is the max number of series whose exemplars are waiting to
+	// be written, exemplars are dropped once it is reached.
+	QueueSize int
+	// WriteBatchSize is the max number of series whose exemplars are written
+	// at once.
+	WriteBatchSize int
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+type storeMetrics struct {
+	dropped     tally.Counter
+	written     tally.Counter
+	writeErrors tally.Counter
+}
+
+func newStoreMetrics(scope tally.Scope) storeMetrics {
+	return storeMetrics{
+		dropped:     scope.Counter("dropped"),
+		written:     scope.Counter("written"),
+		writeErrors: scope.Counter("write-errors"),
+	}
+}
+
+// queuedWrite holds the encoded exemplars of a series waiting to be written.
+type queuedWrite struct {
+	id          ident.ID
+	tags        ident.Tags
+	timestamps  []xtime.UnixNano
+	values      []float64
+	annotations [][]byte
+}
+
+type m3Store struct {
+	namespaceFn    NamespaceFn
+	limit          int
+	writeBatchSize int
+	queue          chan queuedWrite
+	tagOptions     models.TagOptions
+	metrics        storeMetrics
+	logger         *zap.Logger
+}
+
+// NewM3Store returns a store writing exemplars to a dbnode namespace. Each
+// exemplar is written as a datapoint of a series with the labels of the
+// series it belongs to, holding the value of the exemplar, while the labels
+// of the exemplar are stored as the protobuf encoded prompb.Exemplar
+// annotation of the datapoint. Exemplars are written in the background so
+// that they do not add to the latency of the writes they arrive with.
+func NewM3Store(opts Options) Store {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = defaultQueueSize
+	}
+	if opts.WriteBatchSize <= 0 {
+		opts.WriteBatchSize = defaultWriteBatchSize
+	}
+	if opts.InstrumentOptions == nil {
+		opts.InstrumentOptions = instrument.NewOptions()
+	}
+
+	s := &m3Store{
+		namespaceFn:    opts.NamespaceFn,
+		limit:          opts.Limit,
+		writeBatchSize: opts.WriteBatchSize,
+		queue:          make(chan queuedWrite, opts.QueueSize),
+		tagOptions:     models.NewTagOptions(),
+		metrics: newStoreMetrics(opts.InstrumentOptions.MetricsScope().
+			SubScope("exemplar-store")),
+		logger: opts.InstrumentOptions.Logger(),
+	}
+	go s.writeLoop()
+	return s
+}
+
+func (s *m3Store) Append(series labels.Labels, exemplars []exemplar.Exemplar) error {
+	if len(exemplars) == 0 {
+		return nil
+	}
+
+	// NB: keep appending past invalid exemplars so that a single bad
+	// exemplar does not drop the rest of the series.
+	var (
+		write = queuedWrite{
+			timestamps:  make([]xtime.UnixNano, 0, len(exemplars)),
+			values:      make([]float64, 0, len(exemplars)),
+			annotations: make([][]byte, 0, len(exemplars)),
+		}
+		lastErr error
+	)
+	for _, e := range exemplars {
+		annotation, err := encodeAnnotation(e)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		write.timestamps = append(write.timestamps,
+			xtime.UnixNano(e.Ts*int64(time.Millisecond)))
+		write.values = append(write.values, e.Value)
+		write.annotations = append(write.annotations, annotation)
+	}
+	if len(write.annotations) == 0 {
+		return lastErr
+	}
+
+	tags := make([]ident.Tag, 0, len(series))
+	for _, l := range series {
+		tags = append(tags, ident.StringTag(l.Name, l.Value))
+	}
+	write.id = ident.StringID(series.String())
+	write.tags = ident.NewTags(tags...)
+
+	select {
+	case s.queue <- write:
+	default:
+		s.metrics.dropped.Inc(int64(len(write.annotations)))
+		return errQueueFull
+	}
+
+	return lastErr
+}
+
+// writeLoop writes the queued exemplars in batches, the writes of a batch are
+// issued concurrently so that the session batches them into as few requests
+// as possible.
+func (s *m3Store) writeLoop() {
+	batch := make([]queuedWrite, 0, s.writeBatchSize)
+	for write := range s.queue {
+		batch = append(batch[:0], write)
+	drain:
+		for len(batch) < s.writeBatchSize {
+			select {
+			case write := <-s.queue:
+				batch = append(batch, write)
+			default:
+				break drain
+			}
+		}
+
+		s.writeBatch(batch)
+	}
+}
+
+func (s *m3Store) writeBatch(batch []queuedWrite) {
+	session, namespace, err := s.namespace()
+	if err != nil {
+		for _, write := range batch {
+			s.metrics.writeErrors.Inc(int64(len(write.annotations)))
+		}
+		s.logger.Debug("could not write exemplars", zap.Error(err))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, write := range batch {
+		write := write
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// NB: the exemplars of a series are written in order, since only
+			// annotations that differ from the previous one are stored.
+			for i := range write.annotations {
+				err := session.WriteTagged(namespace, write.id,
+					ident.NewTagsIterator(write.tags), write.timestamps[i],
+					write.values[i], xtime.Millisecond, write.annotations[i])
+				if err != nil {
+					s.metrics.writeErrors.Inc(1)
+					s.logger.Debug("could not write exemplar",
+						zap.Stringer("series", write.id), zap.Error(err))
+					continue
+				}
+				s.metrics.written.Inc(1)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func (s *m3Store) Select(
+	ctx context.Context,
+	start, end time.Time,
+	matchers ...[]*labels.Matcher,
+) ([]exemplar.QueryResult, error) {
+	session, namespace, err := s.namespace()
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		results = make([]exemplar.QueryResult, 0)
+		seen    = make(map[string]struct{})
+	)
+	for _, set := range matchers {
+		tagMatchers, err := promql.LabelMatchersToModelMatcher(set, s.tagOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		fetchQuery := &storage.FetchQuery{
+			TagMatchers: tagMatchers,
+			Start:       start,
+			End:         end,
+		}
+		m3Query, err := storage.FetchQueryToM3Query(fetchQuery, storage.NewFetchOptions())
+		if err != nil {
+			return nil, err
+		}
+
+		iters, _, err := session.FetchTagged(ctx, namespace, m3Query, index.QueryOptions{
+			StartInclusive: xtime.ToUnixNano(start),
+			EndExclusive:   xtime.ToUnixNano(end).Add(time.Millisecond),
+			SeriesLimit:    s.limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		results, err = appendResults(results, seen, iters.Iters())
+		iters.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return labels.Compare(results[i].SeriesLabels, results[j].SeriesLabels) < 0
+	})
+	return results, nil
+}
+
+func (s *m3Store) namespace() (client.Session, ident.ID, error) {
+	session, namespace, err := s.namespaceFn()
+	if err != nil {
+		return nil, nil, err
+	}
+	if session == nil || namespace == nil {
+		return nil, nil, errNoNamespace
+	}
+	return session, namespace, nil
+}
+
+// appendResults appends the exemplars of the series not seen yet, series
+// matching several matcher sets are only returned once.
+func appendResults(
+	results []exemplar.QueryResult,
+	seen map[string]struct{},
+	iters []encoding.SeriesIterator,
+) ([]exemplar.QueryResult, error) {
+	for _, iter := range iters {
+		id := iter.ID().String()
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+
+		series, err := iteratorLabels(iter)
+		if err != nil {
+			return nil, err
+		}
+
+		var (
+			result         = exemplar.QueryResult{SeriesLabels: series}
+			exemplarLabels labels.Labels
+		)
+		for iter.Next() {
+			dp, _, annotation := iter.Current()
+			if len(annotation) > 0 {
+				// NB: annotations are only returned when they change, otherwise
+				// the labels of the previous exemplar still apply.
+				decoded, err := decodeAnnotation(annotation)
+				if err != nil {
+					return nil, fmt.Errorf("could not decode exemplar of %s: %w", id, err)
+				}
+				exemplarLabels = decoded.Labels
+			}
+
+			result.Exemplars = append(result.Exemplars, exemplar.Exemplar{
+				Labels: exemplarLabels,
+				Value:  dp.Value,
+				Ts:     storage.TimeToPromTimestamp(dp.TimestampNanos),
+				HasTs:  true,
+			})
+		}
+		if err := iter.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(result.Exemplars) > 0 {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func iteratorLabels(iter encoding.SeriesIterator) (labels.Labels, error) {
+	tags := iter.Tags()
+	series := make(labels.Labels, 0, tags.Remaining())
+	for tags.Next() {
+		tag := tags.Current()
+		series = append(series, labels.Label{
+			Name:  tag.Name.String(),
+			Value: tag.Value.String(),
+		})
+	}
+	if err := tags.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Sort(series)
+	return series, nil
+}
+
+func encodeAnnotation(e exemplar.Exemplar) ([]byte, error) {
+	labelSetLen := 0
+	pb := prompb.Exemplar{Labels: make([]prompb.Label, 0, len(e.Labels))}
+	if len(e.Labels) == 0 {
+		// NB: an empty annotation is never written and would be read back
+		// with the labels of the previous exemplar, so set the timestamp to
+		// keep the annotation of an exemplar without labels non-empty.
+		pb.Timestamp = e.Ts
+	}
+	for _, l := range e.Labels {
+		labelSetLen += utf8.RuneCountInString(l.Name)
+		labelSetLen += utf8.RuneCountInString(l.Value)
+		if labelSetLen > exemplar.ExemplarMaxLabelSetLength {
+			return nil, ErrExemplarLabelLength
+		}
+
+		pb.Labels = append(pb.Labels, prompb.Label{
+			Name:  []byte(l.Name),
+			Value: []byte(l.Value),
+		})
+	}
+
+	return pb.Marshal()
+}
+
+func decodeAnnotation(annotation []byte) (exemplar.Exemplar, error) {
+	var pb prompb.Exemplar
+	if err := pb.Unmarshal(annotation); err != nil {
+		return exemplar.Exemplar{}, err
+	}
+
+	e := exemplar.Exemplar{Labels: make(labels.Labels, 0, len(pb.Labels))}
+	for _, l := range pb.Labels {
+		e.Labels = append(e.Labels, labels.Label{
+			Name:  string(l.Name),
+			Value: string(l.Value),
+		})
+	}
+	sort.Sort(e.Labels)
+	return e, nil
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package exemplar
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/client"
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/storage/index"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func newTestM3Store(session client.Session) Store {
+	return NewM3Store(Options{
+		NamespaceFn: func() (client.Session, ident.ID, error) {
+			return session, ident.StringID("exemplars"), nil
+		},
+		Limit: 100,
+	})
+}
+
+func newTestExemplar(traceID string, value float64, ts int64) exemplar.Exemplar {
+	return exemplar.Exemplar{
+		Labels: labels.FromStrings("trace_id", traceID),
+		Value:  value,
+		Ts:     ts,
+		HasTs:  true,
+	}
+}
+
+func TestM3StoreAppend(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		session = client.NewMockSession(ctrl)
+		store   = newTestM3Store(session)
+		series  = labels.FromStrings("__name__", "latency", "service", "foo")
+		written []exemplar.Exemplar
+		wg      sync.WaitGroup
+	)
+	wg.Add(2)
+	session.EXPECT().
+		WriteTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), xtime.Millisecond, gomock.Any()).
+		DoAndReturn(func(
+			namespace, id ident.ID,
+			tags ident.TagIterator,
+			timestamp xtime.UnixNano,
+			value float64,
+			_ xtime.Unit,
+			annotation []byte,
+		) error {
+			assert.Equal(t, "exemplars", namespace.String())
+			assert.Equal(t, series.String(), id.String())
+
+			var actual labels.Labels
+			for tags.Next() {
+				tag := tags.Current()
+				actual = append(actual, labels.Label{
+					Name:  tag.Name.String(),
+					Value: tag.Value.String(),
+				})
+			}
+			require.NoError(t, tags.Err())
+			assert.Equal(t, series, actual)
+
+			var pb prompb.Exemplar
+			require.NoError(t, pb.Unmarshal(annotation))
+			require.Equal(t, 1, len(pb.Labels))
+			written = append(written, newTestExemplar(string(pb.Labels[0].Value),
+				value, int64(timestamp)/int64(time.Millisecond)))
+			wg.Done()
+			return nil
+		}).
+		Times(2)
+
+	// Exemplars with labels that are too long are skipped.
+	long := newTestExemplar(strings.Repeat("a", exemplar.ExemplarMaxLabelSetLength), 9, 1500)
+	err := store.Append(series, []exemplar.Exemplar{
+		newTestExemplar("a", 1, 1000),
+		long,
+		newTestExemplar("b", 2, 2000),
+	})
+	assert.Equal(t, ErrExemplarLabelLength, err)
+
+	// Exemplars are written in the background, in order.
+	wg.Wait()
+	assert.Equal(t, []exemplar.Exemplar{
+		newTestExemplar("a", 1, 1000),
+		newTestExemplar("b", 2, 2000),
+	}, written)
+}
+
+func TestM3StoreAppendQueueFull(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		session = client.NewMockSession(ctrl)
+		store   = NewM3Store(Options{
+			NamespaceFn: func() (client.Session, ident.ID, error) {
+				return session, ident.StringID("exemplars"), nil
+			},
+			QueueSize: 1,
+		})
+		series  = labels.FromStrings("__name__", "latency")
+		writing = make(chan struct{})
+		unblock = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	wg.Add(2)
+	session.EXPECT().
+		WriteTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(),
+			gomock.Any(), xtime.Millisecond, gomock.Any()).
+		DoAndReturn(func(
+			_, _ ident.ID,
+			_ ident.TagIterator,
+			_ xtime.UnixNano,
+			_ float64,
+			_ xtime.Unit,
+			_ []byte,
+		) error {
+			select {
+			case writing <- struct{}{}:
+			default:
+			}
+			<-unblock
+			wg.Done()
+			return nil
+		}).
+		Times(2)
+
+	require.NoError(t, store.Append(series, []exemplar.Exemplar{newTestExemplar("a", 1, 1000)}))
+	<-writing
+
+	// Appending does not wait on writes, and exemplars are dropped once the
+	// queue is full.
+	require.NoError(t, store.Append(series, []exemplar.Exemplar{newTestExemplar("b", 2, 2000)}))
+	require.Equal(t, errQueueFull,
+		store.Append(series, []exemplar.Exemplar{newTestExemplar("c", 3, 3000)}))
+
+	close(unblock)
+	wg.Wait()
+}
+
+func newTestExemplarIterator(
+	ctrl *gomock.Controller,
+	series labels.Labels,
+	exemplars ...exemplar.Exemplar,
+) encoding.SeriesIterator {
+	tags := make([]ident.Tag, 0, len(series))
+	for _, l := range series {
+		tags = append(tags, ident.StringTag(l.Name, l.Value))
+	}
+
+	iter := encoding.NewMockSeriesIterator(ctrl)
+	iter.EXPECT().ID().Return(ident.StringID(series.String())).AnyTimes()
+	iter.EXPECT().Tags().Return(ident.NewTagsIterator(ident.NewTags(tags...))).AnyTimes()
+	var (
+		calls = make([]*gomock.Call, 0, 2*len(exemplars)+1)
+		prev  []byte
+	)
+	for _, e := range exemplars {
+		annotation, err := encodeAnnotation(e)
+		if err != nil {
+			panic(err)
+		}
+		// NB: as with m3tsz, annotations are only returned when they change.
+		if bytes.Equal(annotation, prev) {
+			annotation = nil
+		} else {
+			prev = annotation
+		}
+		calls = append(calls,
+			iter.EXPECT().Next().Return(true),
+			iter.EXPECT().Current().Return(ts.Datapoint{
+				TimestampNanos: xtime.UnixNano(e.Ts * int64(time.Millisecond)),
+				Value:          e.Value,
+			}, xtime.Millisecond, ts.Annotation(annotation)))
+	}
+	calls = append(calls, iter.EXPECT().Next().Return(false))
+	gomock.InOrder(calls...)
+	iter.EXPECT().Err().Return(nil)
+	iter.EXPECT().Close()
+	return iter
+}
+
+func TestM3StoreSelect(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	var (
+		session = client.NewMockSession(ctrl)
+		store   = newTestM3Store(session)
+		start   = time.Unix(1, 0)
+		end     = time.Unix(3, 0)
+		foo     = labels.FromStrings("__name__", "latency", "service", "foo")
+		bar     = labels.FromStrings("__name__", "latency", "service", "bar")
+	)
+
+	// The same series matched by a second matcher set is only returned once.
+	duplicate := encoding.NewMockSeriesIterator(ctrl)
+	duplicate.EXPECT().ID().Return(ident.StringID(foo.String()))
+	duplicate.EXPECT().Close()
+
+	fetched := []encoding.SeriesIterators{
+		encoding.NewSeriesIterators([]encoding.SeriesIterator{
+			newTestExemplarIterator(ctrl, foo,
+				newTestExemplar("a", 1, 1000), newTestExemplar("b", 2, 2000),
+				newTestExemplar("b", 4, 2500)),
+			newTestExemplarIterator(ctrl, bar, newTestExemplar("c", 3, 1500)),
+		}),
+		encoding.NewSeriesIterators([]encoding.SeriesIterator{duplicate}),
+	}
+	session.EXPECT().
+		FetchTagged(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(
+			_ context.Context,
+			namespace ident.ID,
+			_ index.Query,
+			opts index.QueryOptions,
+		) (encoding.SeriesIterators, client.FetchResponseMetadata, error) {
+			assert.Equal(t, "exemplars", namespace.String())
+			assert.Equal(t, xtime.ToUnixNano(start), opts.StartInclusive)
+			assert.Equal(t, xtime.ToUnixNano(end).Add(time.Millisecond), opts.EndExclusive)
+			assert.Equal(t, 100, opts.SeriesLimit)
+
+			iters := fetched[0]
+			fetched = fetched[1:]
+			return iters, client.FetchResponseMetadata{Exhaustive: true}, nil
+		}).
+		Times(2)
+
+	results, err := store.Select(context.Background(), start, end,
+		[]*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "__name__", "latency")},
+		[]*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "service", "foo")})
+	require.NoError(t, err)
+	assert.Equal(t, []exemplar.QueryResult{
+		{SeriesLabels: bar, Exemplars: []exemplar.Exemplar{newTestExemplar("c", 3, 1500)}},
+		{SeriesLabels: foo, Exemplars: []exemplar.Exemplar{
+			newTestExemplar("a", 1, 1000),
+			newTestExemplar("b", 2, 2000),
+			// Identical consecutive exemplars keep their labels.
+			newTestExemplar("b", 4, 2500),
+		}},
+	}, results)
+}
+
+func TestAnnotationWithoutLabels(t *testing.T) {
+	e := exemplar.Exemplar{Value: 1, Ts: 1000, HasTs: true}
+	annotation, err := encodeAnnotation(e)
+	require.NoError(t, err)
+	// Exemplars without labels must not look unchanged from the previous one.
+	require.NotEmpty(t, annotation)
+
+	decoded, err := decodeAnnotation(annotation)
+	require.NoError(t, err)
+	assert.Empty(t, decoded.Labels)
+}
+
+func TestConfigurationDisabled(t *testing.T) {
+	assert.Nil(t, Configuration{}.NewStore(nil, nil))
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/uber-go/tally"
 
+	"github.com/m3db/m3/src/dbnode/storage/index"
 	"github.com/m3db/m3/src/query/models"
 	"github.com/m3db/m3/src/query/storage/m3/storagemetadata"
 )
@@ -101,3 +102,7 @@ func (o *FetchOptions) Clone() *FetchOptions {
 	result := *o
 	return &result
 }
+
+// SeriesShard selects a subset of series by hashing their IDs, it is pushed
+// down to the index query of the dbnodes.
+type SeriesShard = index.SeriesShard
@@ -0,0 +1,274 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	encodingVersion = 1
+
+	// flagIntegerCounts is set when all counts are integers, in which case
+	// counts are varint encoded and bucket counts are delta encoded.
+	flagIntegerCounts = 1 << 0
+
+	// maxIntegerCount is the largest count that is exactly representable
+	// as both a float64 and an int64.
+	maxIntegerCount = 1 << 53
+)
+
+var (
+	errTruncated = errors.New("histogram encoding truncated")
+)
+
+// Encode returns the binary encoding of the histogram.
+//
+// The encoding starts with a version byte and a flags byte, followed by the
+// schema, zero threshold, sum, count and zero count, then the negative and
+// positive spans each followed by their bucket counts. Integer counts are
+// written as varints with bucket counts encoded as deltas between adjacent
+// buckets, which keeps typical histograms to a few bytes per bucket.
+func Encode(h Histogram) ([]byte, error) {
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+
+	var flags byte
+	if h.IsInteger() {
+		flags |= flagIntegerCounts
+	}
+
+	e := encoder{
+		buf: make([]byte, 0, 32+
+			8*(len(h.PositiveBuckets)+len(h.NegativeBuckets))),
+		integers: flags&flagIntegerCounts != 0,
+	}
+	e.buf = append(e.buf, encodingVersion, flags)
+	e.writeVarint(int64(h.Schema))
+	e.writeFloat(h.ZeroThreshold)
+	e.writeFloat(h.Sum)
+	e.writeCount(h.Count)
+	e.writeCount(h.ZeroCount)
+	e.writeBuckets(h.NegativeSpans, h.NegativeBuckets)
+	e.writeBuckets(h.PositiveSpans, h.PositiveBuckets)
+	return e.buf, nil
+}
+
+// Decode decodes a histogram previously encoded with Encode.
+func Decode(b []byte) (Histogram, error) {
+	if len(b) < 2 {
+		return Histogram{}, errTruncated
+	}
+	if b[0] != encodingVersion {
+		return Histogram{}, fmt.Errorf(
+			"unsupported histogram encoding version: %d", b[0])
+	}
+
+	var (
+		h Histogram
+		d = decoder{
+			buf:      b[2:],
+			integers: b[1]&flagIntegerCounts != 0,
+		}
+	)
+	h.Schema = int32(d.readVarint())
+	h.ZeroThreshold = d.readFloat()
+	h.Sum = d.readFloat()
+	h.Count = d.readCount()
+	h.ZeroCount = d.readCount()
+	h.NegativeSpans, h.NegativeBuckets = d.readBuckets()
+	h.PositiveSpans, h.PositiveBuckets = d.readBuckets()
+	if d.err != nil {
+		return Histogram{}, d.err
+	}
+	if err := h.Validate(); err != nil {
+		return Histogram{}, err
+	}
+	return h, nil
+}
+
+// IsInteger returns true if all counts of the histogram are integers that
+// can be represented exactly.
+func (h Histogram) IsInteger() bool {
+	if !isIntegerCount(h.Count) || !isIntegerCount(h.ZeroCount) {
+		return false
+	}
+	for _, v := range h.PositiveBuckets {
+		if !isIntegerCount(v) {
+			return false
+		}
+	}
+	for _, v := range h.NegativeBuckets {
+		if !isIntegerCount(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func isIntegerCount(v float64) bool {
+	return v >= 0 && v <= maxIntegerCount && v == math.Trunc(v)
+}
+
+type encoder struct {
+	buf      []byte
+	scratch  [binary.MaxVarintLen64]byte
+	integers bool
+}
+
+func (e *encoder) writeVarint(v int64) {
+	n := binary.PutVarint(e.scratch[:], v)
+	e.buf = append(e.buf, e.scratch[:n]...)
+}
+
+func (e *encoder) writeUvarint(v uint64) {
+	n := binary.PutUvarint(e.scratch[:], v)
+	e.buf = append(e.buf, e.scratch[:n]...)
+}
+
+func (e *encoder) writeFloat(v float64) {
+	binary.LittleEndian.PutUint64(e.scratch[:8], math.Float64bits(v))
+	e.buf = append(e.buf, e.scratch[:8]...)
+}
+
+func (e *encoder) writeCount(v float64) {
+	if e.integers {
+		e.writeUvarint(uint64(v))
+		return
+	}
+	e.writeFloat(v)
+}
+
+func (e *encoder) writeBuckets(spans []Span, buckets []float64) {
+	e.writeUvarint(uint64(len(spans)))
+	for _, span := range spans {
+		e.writeVarint(int64(span.Offset))
+		e.writeUvarint(uint64(span.Length))
+	}
+
+	var prev int64
+	for _, v := range buckets {
+		if !e.integers {
+			e.writeFloat(v)
+			continue
+		}
+		curr := int64(v)
+		e.writeVarint(curr - prev)
+		prev = curr
+	}
+}
+
+type decoder struct {
+	buf      []byte
+	integers bool
+	err      error
+}
+
+func (d *decoder) readVarint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.buf)
+	if n <= 0 {
+		d.err = errTruncated
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) readUvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errTruncated
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *decoder) readFloat() float64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.buf) < 8 {
+		d.err = errTruncated
+		return 0
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(d.buf[:8]))
+	d.buf = d.buf[8:]
+	return v
+}
+
+func (d *decoder) readCount() float64 {
+	if d.integers {
+		return float64(d.readUvarint())
+	}
+	return d.readFloat()
+}
+
+func (d *decoder) readBuckets() ([]Span, []float64) {
+	numSpans := d.readUvarint()
+	if d.err != nil || numSpans == 0 {
+		return nil, nil
+	}
+	// Each span takes at least two bytes.
+	if numSpans > uint64(len(d.buf)/2) {
+		d.err = errTruncated
+		return nil, nil
+	}
+
+	spans := make([]Span, 0, numSpans)
+	for i := uint64(0); i < numSpans; i++ {
+		spans = append(spans, Span{
+			Offset: int32(d.readVarint()),
+			Length: uint32(d.readUvarint()),
+		})
+	}
+
+	n := numBuckets(spans)
+	// Each bucket takes at least one byte.
+	if d.err != nil || n > len(d.buf) {
+		d.err = errTruncated
+		return nil, nil
+	}
+
+	var (
+		buckets = make([]float64, 0, n)
+		prev    int64
+	)
+	for i := 0; i < n; i++ {
+		if !d.integers {
+			buckets = append(buckets, d.readFloat())
+			continue
+		}
+		prev += d.readVarint()
+		buckets = append(buckets, float64(prev))
+	}
+	return spans, buckets
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package histogram implements the annotation payload for Prometheus native
+// (sparse) histograms, which use exponentially sized buckets instead of a
+// fixed set of bucket boundaries.
+//
+// This is not an M3DB encoding scheme. M3DB stores a native histogram sample
+// as a regular m3tsz datapoint holding the observation count, and the full
+// histogram encoded with this package is carried in the annotation of that
+// datapoint. Each sample is encoded on its own, there is no delta or XOR
+// compression across samples.
+package histogram
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// MinSchema is the lowest supported schema, giving buckets that grow by
+	// a factor of 65536.
+	MinSchema = -4
+	// MaxSchema is the highest supported schema, giving buckets that grow by
+	// a factor of 2^(2^-8).
+	MaxSchema = 8
+)
+
+var (
+	errSpansBucketsMismatch = errors.New("histogram spans do not match number of buckets")
+)
+
+// Span describes a run of consecutive populated buckets. The offset of the
+// first span is the absolute index of its first bucket, subsequent offsets
+// are the gap to the end of the previous span.
+type Span struct {
+	Offset int32
+	Length uint32
+}
+
+// Histogram is a native histogram with absolute (non delta encoded) bucket
+// counts.
+type Histogram struct {
+	// Schema defines the bucket boundaries, bucket i ends at 2^(i*2^-Schema).
+	Schema int32
+	// ZeroThreshold is the width of the zero bucket in each direction.
+	ZeroThreshold float64
+	// ZeroCount is the number of observations in the zero bucket.
+	ZeroCount float64
+	// Count is the total number of observations.
+	Count float64
+	// Sum is the sum of all observations.
+	Sum float64
+
+	PositiveSpans   []Span
+	PositiveBuckets []float64
+	NegativeSpans   []Span
+	NegativeBuckets []float64
+}
+
+// Validate returns an error if the histogram is not well formed.
+func (h Histogram) Validate() error {
+	if h.Schema < MinSchema || h.Schema > MaxSchema {
+		return fmt.Errorf("histogram schema %d out of range [%d, %d]",
+			h.Schema, MinSchema, MaxSchema)
+	}
+	if numBuckets(h.PositiveSpans) != len(h.PositiveBuckets) {
+		return errSpansBucketsMismatch
+	}
+	if numBuckets(h.NegativeSpans) != len(h.NegativeBuckets) {
+		return errSpansBucketsMismatch
+	}
+	return nil
+}
+
+// Bucket is a single histogram bucket with its boundaries.
+type Bucket struct {
+	Lower float64
+	Upper float64
+	Count float64
+}
+
+// Buckets returns all populated buckets of the histogram in ascending order
+// of their boundaries: negative buckets, the zero bucket then positive
+// buckets.
+func (h Histogram) Buckets() []Bucket {
+	buckets := make([]Bucket, 0,
+		len(h.NegativeBuckets)+len(h.PositiveBuckets)+1)
+
+	negative := spanBuckets(h.Schema, h.NegativeSpans, h.NegativeBuckets)
+	for i := len(negative) - 1; i >= 0; i-- {
+		b := negative[i]
+		buckets = append(buckets, Bucket{
+			Lower: -b.Upper,
+			Upper: -b.Lower,
+			Count: b.Count,
+		})
+	}
+
+	if h.ZeroCount > 0 {
+		buckets = append(buckets, Bucket{
+			Lower: -h.ZeroThreshold,
+			Upper: h.ZeroThreshold,
+			Count: h.ZeroCount,
+		})
+	}
+
+	return append(buckets,
+		spanBuckets(h.Schema, h.PositiveSpans, h.PositiveBuckets)...)
+}
+
+// Quantile returns the estimated q quantile of the histogram, using the same
+// linear interpolation within a bucket as Prometheus.
+func Quantile(q float64, h Histogram) float64 {
+	if q < 0 {
+		return math.Inf(-1)
+	}
+	if q > 1 {
+		return math.Inf(1)
+	}
+	if h.Count == 0 || math.IsNaN(q) {
+		return math.NaN()
+	}
+
+	var (
+		buckets = h.Buckets()
+		rank    = q * h.Count
+		count   float64
+		bucket  Bucket
+	)
+	for _, b := range buckets {
+		bucket = b
+		count += b.Count
+		if count >= rank {
+			break
+		}
+	}
+
+	if bucket.Lower < 0 && bucket.Upper > 0 {
+		// The zero bucket only extends to the side that has observations.
+		if len(h.NegativeBuckets) == 0 && len(h.PositiveBuckets) > 0 {
+			bucket.Lower = 0
+		} else if len(h.PositiveBuckets) == 0 && len(h.NegativeBuckets) > 0 {
+			bucket.Upper = 0
+		}
+	}
+
+	// Due to rounding the accumulated count may exceed the total count.
+	if count > h.Count {
+		count = h.Count
+	}
+
+	// The rank may not be reached if the histogram contains NaN
+	// observations, in which case use the highest bucket.
+	if count < rank || bucket.Count == 0 {
+		return bucket.Upper
+	}
+
+	rank -= count - bucket.Count
+	return bucket.Lower + (bucket.Upper-bucket.Lower)*(rank/bucket.Count)
+}
+
+// CumulativeCounts returns the number of observations less than or equal to
+// each of the given ascending upper bounds, counting only whole buckets.
+func CumulativeCounts(h Histogram, bounds []float64) []float64 {
+	var (
+		buckets = h.Buckets()
+		counts  = make([]float64, 0, len(bounds))
+		count   float64
+		idx     int
+	)
+	for _, upper := range bounds {
+		if math.IsInf(upper, 1) {
+			counts = append(counts, h.Count)
+			continue
+		}
+		for ; idx < len(buckets) && buckets[idx].Upper <= upper; idx++ {
+			count += buckets[idx].Count
+		}
+		counts = append(counts, count)
+	}
+	return counts
+}
+
+// UpperBounds returns the upper bounds of all populated buckets.
+func UpperBounds(h Histogram) []float64 {
+	buckets := h.Buckets()
+	bounds := make([]float64, 0, len(buckets))
+	for _, b := range buckets {
+		bounds = append(bounds, b.Upper)
+	}
+	return bounds
+}
+
+func spanBuckets(schema int32, spans []Span, counts []float64) []Bucket {
+	var (
+		buckets = make([]Bucket, 0, len(counts))
+		idx     int32
+		i       int
+	)
+	for spanIdx, span := range spans {
+		if spanIdx == 0 {
+			idx = span.Offset
+		} else {
+			idx += span.Offset
+		}
+		for j := uint32(0); j < span.Length && i < len(counts); j++ {
+			if counts[i] != 0 {
+				buckets = append(buckets, Bucket{
+					Lower: bound(idx-1, schema),
+					Upper: bound(idx, schema),
+					Count: counts[i],
+				})
+			}
+			idx++
+			i++
+		}
+	}
+	return buckets
+}
+
+// bound returns the upper boundary of the bucket with the given index.
+func bound(idx, schema int32) float64 {
+	if schema < 0 {
+		return math.Ldexp(1, int(idx)<<uint(-schema))
+	}
+	return math.Exp2(float64(idx) / float64(int64(1)<<uint(schema)))
+}
+
+func numBuckets(spans []Span) int {
+	var n int
+	for _, span := range spans {
+		n += int(span.Length)
+	}
+	return n
+}
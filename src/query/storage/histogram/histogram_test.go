@@ -0,0 +1,169 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package histogram
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHistogram has schema 0, so bucket i covers (2^(i-1), 2^i].
+func testHistogram() Histogram {
+	return Histogram{
+		Schema:        0,
+		ZeroThreshold: 0.001,
+		ZeroCount:     2,
+		Count:         14,
+		Sum:           31.5,
+		PositiveSpans: []Span{
+			{Offset: 0, Length: 2},
+			{Offset: 1, Length: 2},
+		},
+		// Buckets (0.5, 1], (1, 2], (4, 8], (8, 16].
+		PositiveBuckets: []float64{1, 3, 6, 0},
+		NegativeSpans:   []Span{{Offset: 1, Length: 1}},
+		// Bucket [-2, -1).
+		NegativeBuckets: []float64{2},
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	integer := testHistogram()
+
+	float := testHistogram()
+	float.Count = 14.5
+	float.PositiveBuckets = []float64{1, 3.5, 6, 0}
+
+	empty := Histogram{Schema: -2}
+
+	for _, h := range []Histogram{integer, float, empty} {
+		encoded, err := Encode(h)
+		require.NoError(t, err)
+
+		decoded, err := Decode(encoded)
+		require.NoError(t, err)
+		assert.Equal(t, h, decoded)
+	}
+}
+
+func TestEncodeIntegerIsCompact(t *testing.T) {
+	h := testHistogram()
+	integer, err := Encode(h)
+	require.NoError(t, err)
+
+	h.Count += 0.5
+	float, err := Encode(h)
+	require.NoError(t, err)
+
+	assert.True(t, len(integer) < len(float))
+}
+
+func TestEncodeInvalid(t *testing.T) {
+	h := testHistogram()
+	h.PositiveBuckets = h.PositiveBuckets[1:]
+	_, err := Encode(h)
+	require.Error(t, err)
+
+	h = testHistogram()
+	h.Schema = MaxSchema + 1
+	_, err = Encode(h)
+	require.Error(t, err)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	encoded, err := Encode(testHistogram())
+	require.NoError(t, err)
+
+	for i := 0; i < len(encoded); i++ {
+		_, err := Decode(encoded[:i])
+		assert.Error(t, err, "prefix of length %d", i)
+	}
+
+	_, err = Decode([]byte{encodingVersion + 1, 0})
+	require.Error(t, err)
+}
+
+func TestBuckets(t *testing.T) {
+	expected := []Bucket{
+		{Lower: -2, Upper: -1, Count: 2},
+		{Lower: -0.001, Upper: 0.001, Count: 2},
+		{Lower: 0.5, Upper: 1, Count: 1},
+		{Lower: 1, Upper: 2, Count: 3},
+		{Lower: 4, Upper: 8, Count: 6},
+	}
+	assert.Equal(t, expected, testHistogram().Buckets())
+}
+
+func TestBound(t *testing.T) {
+	assert.Equal(t, 1.0, bound(0, 3))
+	assert.InDelta(t, math.Sqrt(2), bound(1, 1), 1e-12)
+	assert.Equal(t, 4.0, bound(1, -1))
+	assert.Equal(t, 0.25, bound(-1, -1))
+	assert.Equal(t, 65536.0, bound(1, -4))
+}
+
+func TestQuantile(t *testing.T) {
+	h := testHistogram()
+
+	tests := []struct {
+		q        float64
+		expected float64
+	}{
+		{q: -1, expected: math.Inf(-1)},
+		{q: 2, expected: math.Inf(1)},
+		{q: 0, expected: -2},
+		// Rank 7 falls two thirds of the way through the (1, 2] bucket.
+		{q: 0.5, expected: 1 + 2.0/3},
+		// Rank 11 falls halfway through the (4, 8] bucket.
+		{q: 11.0 / 14, expected: 6},
+		{q: 1, expected: 8},
+	}
+
+	for _, tt := range tests {
+		assert.InDelta(t, tt.expected, Quantile(tt.q, h), 1e-9, "q=%v", tt.q)
+	}
+
+	assert.True(t, math.IsNaN(Quantile(0.5, Histogram{})))
+	assert.True(t, math.IsNaN(Quantile(math.NaN(), h)))
+}
+
+func TestQuantileZeroBucket(t *testing.T) {
+	h := Histogram{
+		ZeroThreshold:   1,
+		ZeroCount:       4,
+		Count:           4,
+		PositiveSpans:   []Span{{Offset: 2, Length: 1}},
+		PositiveBuckets: []float64{0},
+	}
+
+	// With no negative buckets the zero bucket is treated as [0, 1].
+	assert.InDelta(t, 0.5, Quantile(0.5, h), 1e-9)
+}
+
+func TestCumulativeCounts(t *testing.T) {
+	h := testHistogram()
+	bounds := []float64{-1, 0.001, 2, 4, 8, math.Inf(1)}
+	assert.Equal(t, []float64{2, 4, 8, 8, 14, 14}, CumulativeCounts(h, bounds))
+	assert.Equal(t, []float64{-1, 0.001, 1, 2, 8}, UpperBounds(h))
+}
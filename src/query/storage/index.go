@@ -91,6 +91,7 @@ func FetchOptionsToM3Options(
 		ReadConsistencyLevel:          fetchOptions.ReadConsistencyLevel,
 		IterateEqualTimestampStrategy: fetchOptions.IterateEqualTimestampStrategy,
 		Source:                        fetchOptions.Source,
+		SeriesShard:                   fetchOptions.SeriesShard,
 		StartInclusive:                xtime.ToUnixNano(start),
 		EndExclusive:                  xtime.ToUnixNano(end),
 	}, nil
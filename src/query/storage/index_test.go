@@ -430,3 +430,16 @@ func TestFetchOptionsToAggregateOptions(t *testing.T) {
 func timePtr(t time.Time) *time.Time {
 	return &t
 }
+
+func TestFetchOptionsToM3OptionsSeriesShard(t *testing.T) {
+	now := time.Now()
+	fetchOptions := NewFetchOptions()
+	fetchOptions.SeriesShard = &SeriesShard{Index: 1, Count: 3}
+
+	opts, err := FetchOptionsToM3Options(fetchOptions, &FetchQuery{
+		Start: now.Add(-time.Hour),
+		End:   now,
+	})
+	require.NoError(t, err)
+	require.Equal(t, &index.SeriesShard{Index: 1, Count: 3}, opts.SeriesShard)
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/dbnode/x/xio"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/histogram"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+var (
+	histogramEncodingOpts = encoding.NewOptions()
+	histogramIterAlloc    = m3tsz.DefaultReaderIteratorAllocFn(histogramEncodingOpts)
+)
+
+type nativeHistogramPoint struct {
+	timestamp xtime.UnixNano
+	histogram histogram.Histogram
+}
+
+// expandNativeHistograms replaces every series holding native histograms
+// with one series per cumulative bucket, tagged with the bucket upper bound,
+// so that functions operating on classic histograms such as
+// histogram_quantile can be evaluated over them. Other series are kept as is.
+func expandNativeHistograms(
+	result consolidators.SeriesFetchResult,
+	tagOpts models.TagOptions,
+) (consolidators.SeriesFetchResult, error) {
+	var (
+		count    = result.Count()
+		iters    = make([]encoding.SeriesIterator, 0, count)
+		tags     = make([]*models.Tags, 0, count)
+		expanded bool
+	)
+	for i := 0; i < count; i++ {
+		iter, seriesTags, err := result.IterTagsAtIndex(i, tagOpts)
+		if err != nil {
+			return result, err
+		}
+
+		if !isNativeHistogramSeries(iter) {
+			iters = append(iters, iter)
+			tags = append(tags, &seriesTags)
+			continue
+		}
+
+		points, err := readNativeHistograms(iter)
+		if err != nil {
+			return result, err
+		}
+
+		expanded = true
+		if len(points) == 0 {
+			iter.Close()
+			continue
+		}
+
+		bucketIters, bucketTags, err := nativeHistogramBucketSeries(iter, seriesTags, points)
+		iter.Close()
+		if err != nil {
+			return result, err
+		}
+
+		iters = append(iters, bucketIters...)
+		tags = append(tags, bucketTags...)
+	}
+
+	if !expanded {
+		return result, nil
+	}
+
+	return consolidators.NewSeriesFetchResult(
+		encoding.NewSeriesIterators(iters), tags, result.Metadata)
+}
+
+func isNativeHistogramSeries(iter encoding.SeriesIterator) bool {
+	ant := iter.FirstAnnotation()
+	if len(ant) == 0 {
+		return false
+	}
+
+	_, _, ok, err := storage.AnnotationToNativeHistogram(ant)
+	return err == nil && ok
+}
+
+func readNativeHistograms(iter encoding.SeriesIterator) ([]nativeHistogramPoint, error) {
+	var (
+		points []nativeHistogramPoint
+		curr   histogram.Histogram
+	)
+	for iter.Next() {
+		dp, _, ant := iter.Current()
+		if len(ant) > 0 {
+			// NB: annotations are only returned when they change, otherwise
+			// the previous histogram still applies.
+			h, _, ok, err := storage.AnnotationToNativeHistogram(ant)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				curr = h
+			}
+		}
+
+		points = append(points, nativeHistogramPoint{
+			timestamp: dp.TimestampNanos,
+			histogram: curr,
+		})
+	}
+
+	return points, iter.Err()
+}
+
+func nativeHistogramBucketSeries(
+	iter encoding.SeriesIterator,
+	seriesTags models.Tags,
+	points []nativeHistogramPoint,
+) ([]encoding.SeriesIterator, []*models.Tags, error) {
+	// Use the union of the bucket bounds over time, histograms which do not
+	// have a bucket populated still give the correct cumulative count for it.
+	seen := make(map[float64]struct{})
+	for _, p := range points {
+		for _, b := range histogram.UpperBounds(p.histogram) {
+			seen[b] = struct{}{}
+		}
+	}
+
+	bounds := make([]float64, 0, len(seen)+1)
+	for b := range seen {
+		if !math.IsInf(b, 1) {
+			bounds = append(bounds, b)
+		}
+	}
+	sort.Float64s(bounds)
+	bounds = append(bounds, math.Inf(1))
+
+	encoders := make([]encoding.Encoder, 0, len(bounds))
+	for range bounds {
+		encoders = append(encoders, m3tsz.NewEncoder(iter.Start(),
+			checked.NewBytes(nil, nil), m3tsz.DefaultIntOptimizationEnabled,
+			histogramEncodingOpts))
+	}
+
+	for _, p := range points {
+		counts := histogram.CumulativeCounts(p.histogram, bounds)
+		for i, enc := range encoders {
+			dp := ts.Datapoint{TimestampNanos: p.timestamp, Value: counts[i]}
+			if err := enc.Encode(dp, xtime.Millisecond, nil); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+
+	var (
+		iters = make([]encoding.SeriesIterator, 0, len(bounds))
+		tags  = make([]*models.Tags, 0, len(bounds))
+		ns    ident.ID
+	)
+	if iterNs := iter.Namespace(); iterNs != nil {
+		ns = ident.StringID(iterNs.String())
+	}
+	for i, enc := range encoders {
+		bucketTags := seriesTags.Clone().SetBucket(formatBound(bounds[i]))
+		tags = append(tags, &bucketTags)
+		iters = append(iters, newEncodedSeriesIterator(ident.BytesID(bucketTags.ID()),
+			ns, iter.Start(), iter.End(), enc))
+	}
+
+	return iters, tags, nil
+}
+
+func newEncodedSeriesIterator(
+	id ident.ID,
+	namespace ident.ID,
+	start xtime.UnixNano,
+	end xtime.UnixNano,
+	enc encoding.Encoder,
+) encoding.SeriesIterator {
+	var (
+		reader = xio.BlockReader{
+			SegmentReader: xio.NewSegmentReader(enc.Discard()),
+			Start:         start,
+			BlockSize:     end.Sub(start),
+		}
+		replica = encoding.NewMultiReaderIterator(histogramIterAlloc, nil)
+	)
+	replica.ResetSliceOfSlices(xio.NewReaderSliceOfSlicesFromBlockReadersIterator(
+		[][]xio.BlockReader{{reader}}), nil)
+
+	return encoding.NewSeriesIterator(encoding.SeriesIteratorOptions{
+		ID:             id,
+		Namespace:      namespace,
+		StartInclusive: start,
+		EndExclusive:   end,
+		Replicas:       []encoding.MultiReaderIterator{replica},
+	}, nil)
+}
+
+func formatBound(b float64) []byte {
+	if math.IsInf(b, 1) {
+		return []byte("+Inf")
+	}
+	return []byte(strconv.FormatFloat(b, 'f', -1, 64))
+}
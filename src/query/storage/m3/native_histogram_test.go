@@ -0,0 +1,127 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/histogram"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func nativeHistogramAnnotation(t *testing.T, h histogram.Histogram) ts.Annotation {
+	payload, err := storage.NativeHistogramToAnnotationPayload(h, false)
+	require.NoError(t, err)
+	ant, err := payload.Marshal()
+	require.NoError(t, err)
+	return ant
+}
+
+func TestExpandNativeHistograms(t *testing.T) {
+	var (
+		start = xtime.Now().Truncate(time.Hour)
+		end   = start.Add(time.Hour)
+		first = histogram.Histogram{
+			Count:           3,
+			PositiveSpans:   []histogram.Span{{Offset: 0, Length: 2}},
+			PositiveBuckets: []float64{1, 2},
+		}
+		second = histogram.Histogram{
+			Count:           6,
+			PositiveSpans:   []histogram.Span{{Offset: 0, Length: 2}, {Offset: 0, Length: 1}},
+			PositiveBuckets: []float64{2, 3, 1},
+		}
+	)
+
+	enc := m3tsz.NewEncoder(start, checked.NewBytes(nil, nil),
+		m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+	for i, h := range []histogram.Histogram{first, first, second} {
+		dp := ts.Datapoint{
+			TimestampNanos: start.Add(time.Duration(i+1) * time.Minute),
+			Value:          h.Count,
+		}
+		require.NoError(t, enc.Encode(dp, xtime.Millisecond, nativeHistogramAnnotation(t, h)))
+	}
+
+	tagOpts := models.NewTagOptions()
+	seriesTags := models.NewTags(1, tagOpts).SetName([]byte("latency"))
+	iter := newEncodedSeriesIterator(ident.StringID("latency"),
+		ident.StringID("ns"), start, end, enc)
+
+	gaugeEnc := m3tsz.NewEncoder(start, checked.NewBytes(nil, nil),
+		m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+	require.NoError(t, gaugeEnc.Encode(ts.Datapoint{
+		TimestampNanos: start.Add(time.Minute),
+		Value:          42,
+	}, xtime.Millisecond, nil))
+	gaugeTags := models.NewTags(1, tagOpts).SetName([]byte("gauge"))
+	gaugeIter := newEncodedSeriesIterator(ident.StringID("gauge"),
+		ident.StringID("ns"), start, end, gaugeEnc)
+
+	result, err := consolidators.NewSeriesFetchResult(
+		encoding.NewSeriesIterators([]encoding.SeriesIterator{iter, gaugeIter}),
+		[]*models.Tags{&seriesTags, &gaugeTags},
+		block.NewResultMetadata())
+	require.NoError(t, err)
+
+	expanded, err := expandNativeHistograms(result, tagOpts)
+	require.NoError(t, err)
+
+	actual := make(map[string][]float64, expanded.Count())
+	for i := 0; i < expanded.Count(); i++ {
+		it, tags, err := expanded.IterTagsAtIndex(i, tagOpts)
+		require.NoError(t, err)
+
+		key := "none"
+		if bucket, ok := tags.Bucket(); ok {
+			key = string(bucket)
+		}
+
+		var values []float64
+		for it.Next() {
+			dp, _, _ := it.Current()
+			values = append(values, dp.Value)
+		}
+		require.NoError(t, it.Err())
+		actual[key] = values
+	}
+
+	assert.Equal(t, map[string][]float64{
+		"1":    {1, 1, 2},
+		"2":    {3, 3, 5},
+		"4":    {3, 3, 6},
+		"+Inf": {3, 3, 6},
+		"none": {42},
+	}, actual)
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3
+
+import (
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+)
+
+// filterSeriesShard drops every series that does not belong to the given
+// series shard, closing the iterators of the dropped series. The shard is
+// already applied by the index query of the dbnodes, this only guards against
+// dbnodes that predate series shards and return every matching series.
+func filterSeriesShard(
+	result consolidators.SeriesFetchResult,
+	shard *storage.SeriesShard,
+	tagOpts models.TagOptions,
+) (consolidators.SeriesFetchResult, error) {
+	var (
+		count = result.Count()
+		iters = make([]encoding.SeriesIterator, 0, count)
+		tags  = make([]*models.Tags, 0, count)
+	)
+	for i := 0; i < count; i++ {
+		iter, seriesTags, err := result.IterTagsAtIndex(i, tagOpts)
+		if err != nil {
+			return result, err
+		}
+
+		if !shard.Contains(iter.ID().Bytes()) {
+			iter.Close()
+			continue
+		}
+
+		iters = append(iters, iter)
+		tags = append(tags, &seriesTags)
+	}
+
+	if len(iters) == count {
+		return result, nil
+	}
+
+	return consolidators.NewSeriesFetchResult(
+		encoding.NewSeriesIterators(iters), tags, result.Metadata)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package m3
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/encoding/m3tsz"
+	"github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/x/checked"
+	"github.com/m3db/m3/src/x/ident"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func TestFilterSeriesShard(t *testing.T) {
+	var (
+		start     = xtime.Now().Truncate(time.Hour)
+		end       = start.Add(time.Hour)
+		tagOpts   = models.NewTagOptions()
+		numSeries = 20
+		numShards = 3
+	)
+
+	newResult := func() consolidators.SeriesFetchResult {
+		iters := make([]encoding.SeriesIterator, 0, numSeries)
+		tags := make([]*models.Tags, 0, numSeries)
+		for i := 0; i < numSeries; i++ {
+			name := fmt.Sprintf("series_%d", i)
+			enc := m3tsz.NewEncoder(start, checked.NewBytes(nil, nil),
+				m3tsz.DefaultIntOptimizationEnabled, encoding.NewOptions())
+			require.NoError(t, enc.Encode(ts.Datapoint{
+				TimestampNanos: start.Add(time.Minute),
+				Value:          float64(i),
+			}, xtime.Millisecond, nil))
+
+			seriesTags := models.NewTags(1, tagOpts).SetName([]byte(name))
+			iters = append(iters, newEncodedSeriesIterator(ident.StringID(name),
+				ident.StringID("ns"), start, end, enc))
+			tags = append(tags, &seriesTags)
+		}
+
+		result, err := consolidators.NewSeriesFetchResult(
+			encoding.NewSeriesIterators(iters), tags, block.NewResultMetadata())
+		require.NoError(t, err)
+		return result
+	}
+
+	seen := make(map[string]int, numSeries)
+	for i := 0; i < numShards; i++ {
+		shard := &storage.SeriesShard{Index: i, Count: numShards}
+		filtered, err := filterSeriesShard(newResult(), shard, tagOpts)
+		require.NoError(t, err)
+
+		for j := 0; j < filtered.Count(); j++ {
+			iter, tags, err := filtered.IterTagsAtIndex(j, tagOpts)
+			require.NoError(t, err)
+
+			id := iter.ID().String()
+			assert.True(t, shard.Contains([]byte(id)))
+			name, ok := tags.Name()
+			require.True(t, ok)
+			assert.Equal(t, id, string(name))
+			seen[id]++
+		}
+	}
+
+	require.Equal(t, numSeries, len(seen))
+	for id, count := range seen {
+		assert.Equal(t, 1, count, id)
+	}
+}
@@ -247,6 +247,26 @@ func FetchResultToBlockResult(
 		StepSize: query.Interval,
 	}
 
+	if options.SeriesShard != nil {
+		filtered, err := filterSeriesShard(result, options.SeriesShard, opts.TagOptions())
+		if err != nil {
+			return block.Result{
+				Metadata: block.NewResultMetadata(),
+			}, err
+		}
+		result = filtered
+	}
+
+	if options.ExpandNativeHistograms {
+		expanded, err := expandNativeHistograms(result, opts.TagOptions())
+		if err != nil {
+			return block.Result{
+				Metadata: block.NewResultMetadata(),
+			}, err
+		}
+		result = expanded
+	}
+
 	blocks, err := ConvertM3DBSeriesIterators(
 		result,
 		bounds,
@@ -0,0 +1,399 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package metricmetadata persists the metric metadata, such as the type,
+// help and unit of metric families, received with Prometheus remote writes.
+package metricmetadata
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cespare/xxhash/v2"
+	"github.com/uber-go/tally"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	xerrors "github.com/m3db/m3/src/x/errors"
+	"github.com/m3db/m3/src/x/instrument"
+)
+
+const (
+	// DefaultNumShards is the default number of keys the metadata is
+	// spread across in the KV store.
+	DefaultNumShards = 32
+	// DefaultMaxShardBytes is the default max size of the metadata stored
+	// in a single key, well below the default etcd request size limit.
+	DefaultMaxShardBytes = 512 * 1024
+	// DefaultMaxCachedMetrics is the default max number of metric families
+	// whose latest metadata is cached to skip unchanged updates.
+	DefaultMaxCachedMetrics = 100000
+
+	keyPrefix         = "_metric_metadata"
+	maxUpdateAttempts = 5
+)
+
+var (
+	errInvalidNumShards        = errors.New("number of shards must be positive")
+	errInvalidMaxShardBytes    = errors.New("max shard bytes must be positive")
+	errInvalidMaxCachedMetrics = errors.New("max cached metrics must be positive")
+)
+
+// KVStoreFn returns the KV store to persist metadata in.
+type KVStoreFn func() (kv.Store, error)
+
+// Store persists metric metadata, keeping the latest metadata received
+// for each metric family.
+type Store interface {
+	// Update persists the metadata of any metric family for which it is
+	// new or has changed.
+	Update(metadata []prompb.MetricMetadata) error
+
+	// Query returns the metadata by metric family name, for a single metric
+	// family if metric is set. At most limit metric families are returned
+	// unless limit is negative.
+	Query(metric string, limit int) (map[string][]prompb.MetricMetadata, error)
+}
+
+// Options are the options for a metric metadata store.
+type Options struct {
+	// NumShards is the number of keys the metadata is spread across.
+	NumShards int
+	// MaxShardBytes is the max size of the metadata stored in a single key,
+	// metadata of new metric families is dropped once it is reached.
+	MaxShardBytes int
+	// MaxCachedMetrics is the max number of metric families whose latest
+	// metadata is cached.
+	MaxCachedMetrics int
+	// InstrumentOptions are the instrument options.
+	InstrumentOptions instrument.Options
+}
+
+type storeMetrics struct {
+	updates         tally.Counter
+	updateConflicts tally.Counter
+	updateErrors    tally.Counter
+	dropped         tally.Counter
+}
+
+func newStoreMetrics(scope tally.Scope) storeMetrics {
+	return storeMetrics{
+		updates:         scope.Counter("updates"),
+		updateConflicts: scope.Counter("update-conflicts"),
+		updateErrors:    scope.Counter("update-errors"),
+		dropped:         scope.Counter("dropped"),
+	}
+}
+
+type store struct {
+	sync.RWMutex
+
+	kvLock           sync.Mutex
+	kvStore          kv.Store
+	kvStoreFn        KVStoreFn
+	numShards        int
+	maxShardBytes    int
+	maxCachedMetrics int
+	seen             map[string]prompb.MetricMetadata
+	metrics          storeMetrics
+}
+
+// NewStore returns a metric metadata store backed by the given KV store.
+func NewStore(kvStore kv.Store, opts Options) (Store, error) {
+	return NewLazyStore(func() (kv.Store, error) {
+		return kvStore, nil
+	}, opts)
+}
+
+// NewLazyStore returns a metric metadata store backed by the KV store
+// returned by kvStoreFn, which is only called on first use and until it
+// succeeds so that the KV store does not need to be available on startup.
+func NewLazyStore(kvStoreFn KVStoreFn, opts Options) (Store, error) {
+	numShards := opts.NumShards
+	if numShards == 0 {
+		numShards = DefaultNumShards
+	}
+	if numShards < 0 {
+		return nil, errInvalidNumShards
+	}
+
+	maxShardBytes := opts.MaxShardBytes
+	if maxShardBytes == 0 {
+		maxShardBytes = DefaultMaxShardBytes
+	}
+	if maxShardBytes < 0 {
+		return nil, errInvalidMaxShardBytes
+	}
+
+	maxCachedMetrics := opts.MaxCachedMetrics
+	if maxCachedMetrics == 0 {
+		maxCachedMetrics = DefaultMaxCachedMetrics
+	}
+	if maxCachedMetrics < 0 {
+		return nil, errInvalidMaxCachedMetrics
+	}
+
+	iOpts := opts.InstrumentOptions
+	if iOpts == nil {
+		iOpts = instrument.NewOptions()
+	}
+
+	return &store{
+		kvStoreFn:        kvStoreFn,
+		numShards:        numShards,
+		maxShardBytes:    maxShardBytes,
+		maxCachedMetrics: maxCachedMetrics,
+		seen:             make(map[string]prompb.MetricMetadata),
+		metrics:          newStoreMetrics(iOpts.MetricsScope().SubScope("metric-metadata")),
+	}, nil
+}
+
+func (s *store) kv() (kv.Store, error) {
+	s.kvLock.Lock()
+	defer s.kvLock.Unlock()
+
+	if s.kvStore != nil {
+		return s.kvStore, nil
+	}
+
+	kvStore, err := s.kvStoreFn()
+	if err != nil {
+		return nil, err
+	}
+	s.kvStore = kvStore
+	return kvStore, nil
+}
+
+func (s *store) shard(metric string) int {
+	return int(xxhash.Sum64String(metric) % uint64(s.numShards))
+}
+
+func shardKey(shard int) string {
+	return fmt.Sprintf("%s/%d", keyPrefix, shard)
+}
+
+func (s *store) Update(metadata []prompb.MetricMetadata) error {
+	changed := make(map[int][]prompb.MetricMetadata)
+	s.RLock()
+	for _, m := range metadata {
+		if m.MetricFamilyName == "" {
+			continue
+		}
+		if existing, ok := s.seen[m.MetricFamilyName]; ok && existing == m {
+			continue
+		}
+		shard := s.shard(m.MetricFamilyName)
+		changed[shard] = append(changed[shard], m)
+	}
+	s.RUnlock()
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	kvStore, err := s.kv()
+	if err != nil {
+		s.metrics.updateErrors.Inc(1)
+		return err
+	}
+
+	var multiErr xerrors.MultiError
+	for shard, updates := range changed {
+		dropped, err := s.updateShard(kvStore, shard, updates)
+		if err != nil {
+			s.metrics.updateErrors.Inc(1)
+			multiErr = multiErr.Add(err)
+			continue
+		}
+		s.metrics.dropped.Inc(int64(dropped))
+
+		// NB: dropped metadata is cached too so that it is not retried on
+		// every write while its shard is full.
+		s.Lock()
+		for _, m := range updates {
+			s.cacheWithLock(m)
+		}
+		s.Unlock()
+	}
+
+	return multiErr.FinalError()
+}
+
+// cacheWithLock caches the metadata, evicting an arbitrary metric family
+// once the cache is full.
+func (s *store) cacheWithLock(m prompb.MetricMetadata) {
+	if _, ok := s.seen[m.MetricFamilyName]; !ok && len(s.seen) >= s.maxCachedMetrics {
+		for name := range s.seen {
+			delete(s.seen, name)
+			break
+		}
+	}
+	s.seen[m.MetricFamilyName] = m
+}
+
+// updateShard merges the updates into the metadata of the shard and returns
+// the number of updates dropped since they do not fit in the shard.
+func (s *store) updateShard(
+	kvStore kv.Store,
+	shard int,
+	updates []prompb.MetricMetadata,
+) (int, error) {
+	key := shardKey(shard)
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		list, version, err := get(kvStore, key)
+		if err != nil {
+			return 0, err
+		}
+
+		changed, dropped := mergeMetadata(&list, updates, s.maxShardBytes)
+		if !changed {
+			// Already up to date, possibly written by another coordinator.
+			return dropped, nil
+		}
+
+		if version == 0 {
+			_, err = kvStore.SetIfNotExists(key, &list)
+		} else {
+			_, err = kvStore.CheckAndSet(key, version, &list)
+		}
+		switch err {
+		case nil:
+			s.metrics.updates.Inc(1)
+			return dropped, nil
+		case kv.ErrAlreadyExists, kv.ErrVersionMismatch:
+			s.metrics.updateConflicts.Inc(1)
+			continue
+		default:
+			return 0, err
+		}
+	}
+
+	return 0, fmt.Errorf("could not update metric metadata key %s after %d attempts",
+		key, maxUpdateAttempts)
+}
+
+func get(kvStore kv.Store, key string) (prompb.MetricMetadataList, int, error) {
+	var list prompb.MetricMetadataList
+	value, err := kvStore.Get(key)
+	if err == kv.ErrNotFound {
+		return list, 0, nil
+	}
+	if err != nil {
+		return list, 0, err
+	}
+
+	if err := value.Unmarshal(&list); err != nil {
+		return list, 0, err
+	}
+	return list, value.Version(), nil
+}
+
+// mergeMetadata merges the updates into the list, keeping it sorted by
+// metric family name and its encoded size within maxBytes. It returns
+// whether the list changed and the number of updates that did not fit.
+func mergeMetadata(
+	list *prompb.MetricMetadataList,
+	updates []prompb.MetricMetadata,
+	maxBytes int,
+) (bool, int) {
+	var (
+		changed bool
+		dropped int
+	)
+	for _, update := range updates {
+		idx := sort.Search(len(list.Metadata), func(i int) bool {
+			return list.Metadata[i].MetricFamilyName >= update.MetricFamilyName
+		})
+		if idx < len(list.Metadata) &&
+			list.Metadata[idx].MetricFamilyName == update.MetricFamilyName {
+			prev := list.Metadata[idx]
+			if prev == update {
+				continue
+			}
+			list.Metadata[idx] = update
+			if list.Size() > maxBytes {
+				list.Metadata[idx] = prev
+				dropped++
+				continue
+			}
+			changed = true
+			continue
+		}
+
+		list.Metadata = append(list.Metadata, prompb.MetricMetadata{})
+		copy(list.Metadata[idx+1:], list.Metadata[idx:])
+		list.Metadata[idx] = update
+		if list.Size() > maxBytes {
+			list.Metadata = append(list.Metadata[:idx], list.Metadata[idx+1:]...)
+			dropped++
+			continue
+		}
+		changed = true
+	}
+	return changed, dropped
+}
+
+func (s *store) Query(
+	metric string,
+	limit int,
+) (map[string][]prompb.MetricMetadata, error) {
+	shards := make([]int, 0, s.numShards)
+	if metric != "" {
+		shards = append(shards, s.shard(metric))
+	} else {
+		for shard := 0; shard < s.numShards; shard++ {
+			shards = append(shards, shard)
+		}
+	}
+
+	kvStore, err := s.kv()
+	if err != nil {
+		return nil, err
+	}
+
+	var all []prompb.MetricMetadata
+	for _, shard := range shards {
+		list, _, err := get(kvStore, shardKey(shard))
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range list.Metadata {
+			if metric == "" || m.MetricFamilyName == metric {
+				all = append(all, m)
+			}
+		}
+	}
+
+	// Sort so that limited results are deterministic.
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].MetricFamilyName < all[j].MetricFamilyName
+	})
+
+	results := make(map[string][]prompb.MetricMetadata, len(all))
+	for _, m := range all {
+		if limit >= 0 && len(results) >= limit {
+			break
+		}
+		results[m.MetricFamilyName] = []prompb.MetricMetadata{m}
+	}
+	return results, nil
+}
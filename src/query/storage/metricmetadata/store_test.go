@@ -0,0 +1,190 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package metricmetadata
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/cluster/kv"
+	"github.com/m3db/m3/src/cluster/kv/mem"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+)
+
+func TestStoreUpdateAndQuery(t *testing.T) {
+	kvStore := mem.NewStore()
+	s, err := NewStore(kvStore, Options{NumShards: 4})
+	require.NoError(t, err)
+
+	var (
+		requests = prompb.MetricMetadata{
+			Type:             prompb.MetricType_COUNTER,
+			MetricFamilyName: "http_requests_total",
+			Help:             "Total HTTP requests.",
+		}
+		latency = prompb.MetricMetadata{
+			Type:             prompb.MetricType_HISTOGRAM,
+			MetricFamilyName: "http_request_duration_seconds",
+			Help:             "HTTP request latency.",
+			Unit:             "seconds",
+		}
+		unnamed = prompb.MetricMetadata{Type: prompb.MetricType_GAUGE}
+	)
+	require.NoError(t, s.Update([]prompb.MetricMetadata{requests, latency, unnamed}))
+
+	res, err := s.Query("", -1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]prompb.MetricMetadata{
+		"http_requests_total":           {requests},
+		"http_request_duration_seconds": {latency},
+	}, res)
+
+	res, err = s.Query("http_requests_total", -1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]prompb.MetricMetadata{
+		"http_requests_total": {requests},
+	}, res)
+
+	res, err = s.Query("missing", -1)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	// Limited results are ordered by metric family name.
+	res, err = s.Query("", 1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]prompb.MetricMetadata{
+		"http_request_duration_seconds": {latency},
+	}, res)
+
+	res, err = s.Query("", 0)
+	require.NoError(t, err)
+	assert.Empty(t, res)
+
+	// Updates replace the previous metadata and are visible to other stores
+	// sharing the same KV store.
+	requests.Help = "Total number of HTTP requests."
+	require.NoError(t, s.Update([]prompb.MetricMetadata{requests}))
+
+	other, err := NewStore(kvStore, Options{NumShards: 4})
+	require.NoError(t, err)
+	res, err = other.Query("http_requests_total", -1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]prompb.MetricMetadata{
+		"http_requests_total": {requests},
+	}, res)
+}
+
+func TestStoreConcurrentWriters(t *testing.T) {
+	kvStore := mem.NewStore()
+	first, err := NewStore(kvStore, Options{NumShards: 1})
+	require.NoError(t, err)
+	second, err := NewStore(kvStore, Options{NumShards: 1})
+	require.NoError(t, err)
+
+	a := prompb.MetricMetadata{Type: prompb.MetricType_GAUGE, MetricFamilyName: "a"}
+	b := prompb.MetricMetadata{Type: prompb.MetricType_GAUGE, MetricFamilyName: "b"}
+	require.NoError(t, first.Update([]prompb.MetricMetadata{a}))
+	require.NoError(t, second.Update([]prompb.MetricMetadata{b}))
+
+	res, err := first.Query("", -1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]prompb.MetricMetadata{
+		"a": {a},
+		"b": {b},
+	}, res)
+}
+
+func TestNewStoreInvalidOptions(t *testing.T) {
+	_, err := NewStore(mem.NewStore(), Options{NumShards: -1})
+	require.Error(t, err)
+	_, err = NewStore(mem.NewStore(), Options{MaxShardBytes: -1})
+	require.Error(t, err)
+	_, err = NewStore(mem.NewStore(), Options{MaxCachedMetrics: -1})
+	require.Error(t, err)
+}
+
+func TestLazyStoreResolvesKVStoreOnFirstUse(t *testing.T) {
+	var (
+		kvStore  = mem.NewStore()
+		kvErr    = errors.New("not yet initialized")
+		resolved int
+	)
+	s, err := NewLazyStore(func() (kv.Store, error) {
+		resolved++
+		if resolved == 1 {
+			return nil, kvErr
+		}
+		return kvStore, nil
+	}, Options{NumShards: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 0, resolved)
+
+	a := prompb.MetricMetadata{Type: prompb.MetricType_GAUGE, MetricFamilyName: "a"}
+	assert.Equal(t, kvErr, s.Update([]prompb.MetricMetadata{a}))
+	require.NoError(t, s.Update([]prompb.MetricMetadata{a}))
+
+	res, err := s.Query("", -1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]prompb.MetricMetadata{"a": {a}}, res)
+	assert.Equal(t, 2, resolved)
+}
+
+func TestStoreDropsMetadataOverMaxShardBytes(t *testing.T) {
+	a := prompb.MetricMetadata{Type: prompb.MetricType_GAUGE, MetricFamilyName: "a"}
+	list := prompb.MetricMetadataList{Metadata: []prompb.MetricMetadata{a}}
+
+	s, err := NewStore(mem.NewStore(), Options{
+		NumShards:     1,
+		MaxShardBytes: list.Size(),
+	})
+	require.NoError(t, err)
+
+	b := prompb.MetricMetadata{Type: prompb.MetricType_GAUGE, MetricFamilyName: "b"}
+	require.NoError(t, s.Update([]prompb.MetricMetadata{a, b}))
+
+	// Changes to existing metric families that no longer fit are dropped too.
+	updated := a
+	updated.Help = "Help that does not fit."
+	require.NoError(t, s.Update([]prompb.MetricMetadata{updated}))
+
+	res, err := s.Query("", -1)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]prompb.MetricMetadata{"a": {a}}, res)
+}
+
+func TestStoreBoundsCachedMetrics(t *testing.T) {
+	s, err := NewStore(mem.NewStore(), Options{MaxCachedMetrics: 2})
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c", "b"} {
+		require.NoError(t, s.Update([]prompb.MetricMetadata{
+			{Type: prompb.MetricType_GAUGE, MetricFamilyName: name},
+		}))
+		assert.True(t, len(s.(*store).seen) <= 2)
+	}
+
+	res, err := s.Query("", -1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(res))
+}
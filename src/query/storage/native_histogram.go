@@ -0,0 +1,202 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/storage/histogram"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+// PromHistogramToNative converts a Prometheus native histogram, which may
+// use either delta encoded integer counts or absolute float counts, into a
+// histogram with absolute counts.
+func PromHistogramToNative(h prompb.Histogram) histogram.Histogram {
+	result := histogram.Histogram{
+		Schema:        h.Schema,
+		ZeroThreshold: h.ZeroThreshold,
+		Sum:           h.Sum,
+		PositiveSpans: promSpansToNative(h.PositiveSpans),
+		NegativeSpans: promSpansToNative(h.NegativeSpans),
+	}
+
+	if isPromFloatHistogram(h) {
+		result.Count = h.CountFloat
+		result.ZeroCount = h.ZeroCountFloat
+		result.PositiveBuckets = copyCounts(h.PositiveCounts)
+		result.NegativeBuckets = copyCounts(h.NegativeCounts)
+		return result
+	}
+
+	result.Count = float64(h.CountInt)
+	result.ZeroCount = float64(h.ZeroCountInt)
+	result.PositiveBuckets = deltasToCounts(h.PositiveDeltas)
+	result.NegativeBuckets = deltasToCounts(h.NegativeDeltas)
+	return result
+}
+
+// NativeHistogramToProm converts a native histogram into a Prometheus native
+// histogram at the given timestamp, using integer counts when possible.
+func NativeHistogramToProm(
+	h histogram.Histogram,
+	timestamp xtime.UnixNano,
+	gauge bool,
+) prompb.Histogram {
+	result := prompb.Histogram{
+		Schema:        h.Schema,
+		ZeroThreshold: h.ZeroThreshold,
+		Sum:           h.Sum,
+		PositiveSpans: nativeSpansToProm(h.PositiveSpans),
+		NegativeSpans: nativeSpansToProm(h.NegativeSpans),
+		Timestamp:     TimeToPromTimestamp(timestamp),
+	}
+	if gauge {
+		result.ResetHint = prompb.Histogram_GAUGE
+	}
+
+	if !h.IsInteger() {
+		result.CountFloat = h.Count
+		result.ZeroCountFloat = h.ZeroCount
+		result.PositiveCounts = copyCounts(h.PositiveBuckets)
+		result.NegativeCounts = copyCounts(h.NegativeBuckets)
+		return result
+	}
+
+	result.CountInt = uint64(h.Count)
+	result.ZeroCountInt = uint64(h.ZeroCount)
+	result.PositiveDeltas = countsToDeltas(h.PositiveBuckets)
+	result.NegativeDeltas = countsToDeltas(h.NegativeBuckets)
+	return result
+}
+
+// NativeHistogramToAnnotationPayload returns the annotation payload used to
+// store a native histogram datapoint.
+func NativeHistogramToAnnotationPayload(
+	h histogram.Histogram,
+	gauge bool,
+) (annotation.Payload, error) {
+	encoded, err := histogram.Encode(h)
+	if err != nil {
+		return annotation.Payload{}, err
+	}
+
+	metricType := annotation.OpenMetricsFamilyType_HISTOGRAM
+	if gauge {
+		metricType = annotation.OpenMetricsFamilyType_GAUGE_HISTOGRAM
+	}
+
+	return annotation.Payload{
+		SourceFormat:          annotation.SourceFormat_OPEN_METRICS,
+		OpenMetricsFamilyType: metricType,
+		NativeHistogram:       encoded,
+	}, nil
+}
+
+// AnnotationToNativeHistogram decodes the native histogram stored in a
+// datapoint annotation, returning false if the annotation does not hold a
+// native histogram.
+func AnnotationToNativeHistogram(
+	ant []byte,
+) (histogram.Histogram, annotation.Payload, bool, error) {
+	var payload annotation.Payload
+	if err := payload.Unmarshal(ant); err != nil {
+		return histogram.Histogram{}, payload, false, err
+	}
+
+	if len(payload.NativeHistogram) == 0 {
+		return histogram.Histogram{}, payload, false, nil
+	}
+
+	h, err := histogram.Decode(payload.NativeHistogram)
+	if err != nil {
+		return histogram.Histogram{}, payload, false, err
+	}
+
+	return h, payload, true, nil
+}
+
+// isPromFloatHistogram returns true if the histogram uses float counts rather
+// than delta encoded integer counts.
+func isPromFloatHistogram(h prompb.Histogram) bool {
+	return h.CountFloat > 0 || h.ZeroCountFloat > 0 || len(h.PositiveCounts) > 0 ||
+		len(h.NegativeCounts) > 0
+}
+
+func promSpansToNative(spans []prompb.BucketSpan) []histogram.Span {
+	if len(spans) == 0 {
+		return nil
+	}
+	result := make([]histogram.Span, 0, len(spans))
+	for _, s := range spans {
+		result = append(result, histogram.Span{Offset: s.Offset, Length: s.Length})
+	}
+	return result
+}
+
+func nativeSpansToProm(spans []histogram.Span) []prompb.BucketSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+	result := make([]prompb.BucketSpan, 0, len(spans))
+	for _, s := range spans {
+		result = append(result, prompb.BucketSpan{Offset: s.Offset, Length: s.Length})
+	}
+	return result
+}
+
+func deltasToCounts(deltas []int64) []float64 {
+	if len(deltas) == 0 {
+		return nil
+	}
+	var (
+		result = make([]float64, 0, len(deltas))
+		curr   int64
+	)
+	for _, d := range deltas {
+		curr += d
+		result = append(result, float64(curr))
+	}
+	return result
+}
+
+func countsToDeltas(counts []float64) []int64 {
+	if len(counts) == 0 {
+		return nil
+	}
+	var (
+		result = make([]int64, 0, len(counts))
+		prev   int64
+	)
+	for _, c := range counts {
+		curr := int64(c)
+		result = append(result, curr-prev)
+		prev = curr
+	}
+	return result
+}
+
+func copyCounts(counts []float64) []float64 {
+	if len(counts) == 0 {
+		return nil
+	}
+	return append(make([]float64, 0, len(counts)), counts...)
+}
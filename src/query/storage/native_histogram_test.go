@@ -0,0 +1,175 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/m3db/m3/src/dbnode/encoding"
+	"github.com/m3db/m3/src/dbnode/generated/proto/annotation"
+	dts "github.com/m3db/m3/src/dbnode/ts"
+	"github.com/m3db/m3/src/query/block"
+	"github.com/m3db/m3/src/query/generated/proto/prompb"
+	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/histogram"
+	"github.com/m3db/m3/src/query/storage/m3/consolidators"
+	"github.com/m3db/m3/src/x/ident"
+	xtest "github.com/m3db/m3/src/x/test"
+	xtime "github.com/m3db/m3/src/x/time"
+)
+
+func testPromHistogram() prompb.Histogram {
+	return prompb.Histogram{
+		CountInt:       9,
+		Sum:            12.5,
+		Schema:         1,
+		ZeroThreshold:  0.001,
+		ZeroCountInt:   1,
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 0, Length: 2}, {Offset: 2, Length: 1}},
+		PositiveDeltas: []int64{2, 1, -2},
+		NegativeSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}},
+		NegativeDeltas: []int64{2},
+		Timestamp:      1000,
+	}
+}
+
+func TestPromHistogramToNative(t *testing.T) {
+	h := PromHistogramToNative(testPromHistogram())
+	assert.Equal(t, histogram.Histogram{
+		Schema:          1,
+		ZeroThreshold:   0.001,
+		ZeroCount:       1,
+		Count:           9,
+		Sum:             12.5,
+		PositiveSpans:   []histogram.Span{{Offset: 0, Length: 2}, {Offset: 2, Length: 1}},
+		PositiveBuckets: []float64{2, 3, 1},
+		NegativeSpans:   []histogram.Span{{Offset: 1, Length: 1}},
+		NegativeBuckets: []float64{2},
+	}, h)
+
+	// Integer histograms round trip with delta encoded counts.
+	assert.Equal(t, testPromHistogram(),
+		NativeHistogramToProm(h, xtime.UnixNano(time.Second), false))
+
+	floatHistogram := prompb.Histogram{
+		CountFloat:     2.5,
+		ZeroCountFloat: 0.5,
+		PositiveSpans:  []prompb.BucketSpan{{Offset: 1, Length: 1}},
+		PositiveCounts: []float64{2},
+		ResetHint:      prompb.Histogram_GAUGE,
+		Timestamp:      2000,
+	}
+	h = PromHistogramToNative(floatHistogram)
+	assert.Equal(t, []float64{2}, h.PositiveBuckets)
+	assert.Equal(t, floatHistogram,
+		NativeHistogramToProm(h, xtime.UnixNano(2*time.Second), true))
+}
+
+func TestNativeHistogramAnnotationRoundTrip(t *testing.T) {
+	h := PromHistogramToNative(testPromHistogram())
+	payload, err := NativeHistogramToAnnotationPayload(h, true)
+	require.NoError(t, err)
+	assert.Equal(t, annotation.OpenMetricsFamilyType_GAUGE_HISTOGRAM,
+		payload.OpenMetricsFamilyType)
+
+	decoded, decodedPayload, ok, err := AnnotationToNativeHistogram(annotationBytes(t, &payload))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, h, decoded)
+	assert.Equal(t, payload.OpenMetricsFamilyType, decodedPayload.OpenMetricsFamilyType)
+
+	gaugePayload := &annotation.Payload{
+		OpenMetricsFamilyType: annotation.OpenMetricsFamilyType_GAUGE,
+	}
+	_, _, ok, err = AnnotationToNativeHistogram(annotationBytes(t, gaugePayload))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestSeriesIteratorsToPromResultNativeHistograms(t *testing.T) {
+	ctrl := xtest.NewController(t)
+	defer ctrl.Finish()
+
+	first := PromHistogramToNative(testPromHistogram())
+	second := first
+	second.Count = 10
+	second.ZeroCount = 2
+
+	firstPayload, err := NativeHistogramToAnnotationPayload(first, false)
+	require.NoError(t, err)
+	secondPayload, err := NativeHistogramToAnnotationPayload(second, false)
+	require.NoError(t, err)
+
+	var (
+		start = xtime.UnixNano(time.Second)
+		given = []struct {
+			dp  dts.Datapoint
+			ant dts.Annotation
+		}{
+			{
+				dp:  dts.Datapoint{TimestampNanos: start, Value: 9},
+				ant: annotationBytes(t, &firstPayload),
+			},
+			// A nil annotation means the histogram is unchanged.
+			{dp: dts.Datapoint{TimestampNanos: start.Add(time.Second), Value: 9}},
+			{
+				dp:  dts.Datapoint{TimestampNanos: start.Add(2 * time.Second), Value: 10},
+				ant: annotationBytes(t, &secondPayload),
+			},
+		}
+	)
+
+	iter := encoding.NewMockSeriesIterator(ctrl)
+	iter.EXPECT().FirstAnnotation().Return(given[0].ant).AnyTimes()
+	for _, g := range given {
+		iter.EXPECT().Next().Return(true)
+		iter.EXPECT().Current().Return(g.dp, xtime.Millisecond, g.ant)
+	}
+	iter.EXPECT().Next().Return(false)
+	iter.EXPECT().Err().Return(nil)
+	iter.EXPECT().Tags().Return(ident.EmptyTagIterator)
+
+	iters := encoding.NewMockSeriesIterators(ctrl)
+	iters.EXPECT().Iters().Return([]encoding.SeriesIterator{iter}).AnyTimes()
+	iters.EXPECT().Len().Return(1).AnyTimes()
+
+	fetchResult, err := consolidators.NewSeriesFetchResult(iters, nil,
+		block.NewResultMetadata())
+	require.NoError(t, err)
+
+	res, err := SeriesIteratorsToPromResult(context.Background(), fetchResult,
+		nil, models.NewTagOptions(), NewPromConvertOptions(), buildFetchOpts())
+	require.NoError(t, err)
+
+	series := res.PromResult.GetTimeseries()
+	require.Len(t, series, 1)
+	assert.Empty(t, series[0].Samples)
+	assert.Equal(t, []prompb.Histogram{
+		NativeHistogramToProm(first, given[0].dp.TimestampNanos, false),
+		NativeHistogramToProm(first, given[1].dp.TimestampNanos, false),
+		NativeHistogramToProm(second, given[2].dp.TimestampNanos, false),
+	}, series[0].Histograms)
+}
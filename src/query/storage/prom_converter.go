@@ -31,6 +31,7 @@ import (
 	"github.com/m3db/m3/src/query/block"
 	"github.com/m3db/m3/src/query/generated/proto/prompb"
 	"github.com/m3db/m3/src/query/models"
+	"github.com/m3db/m3/src/query/storage/histogram"
 	"github.com/m3db/m3/src/query/storage/m3/consolidators"
 	xerrors "github.com/m3db/m3/src/x/errors"
 	xsync "github.com/m3db/m3/src/x/sync"
@@ -59,11 +60,38 @@ func iteratorToPromResult(
 		cumulativeSum float64
 		prevDP        ts.Datapoint
 
-		samples = make([]prompb.Sample, 0, initRawFetchAllocSize)
+		// Native histograms carry the full histogram in the annotation, which
+		// is only returned for a datapoint when it differs from the previous.
+		isNativeHistogram bool
+		isGaugeHistogram  bool
+		currHistogram     histogram.Histogram
+
+		samples    = make([]prompb.Sample, 0, initRawFetchAllocSize)
+		histograms []prompb.Histogram
 	)
 
 	for iter.Next() {
-		dp, _, _ := iter.Current()
+		dp, _, dpAnnotation := iter.Current()
+
+		if len(dpAnnotation) > 0 && (firstDP || isNativeHistogram) {
+			h, payload, ok, err := AnnotationToNativeHistogram(dpAnnotation)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				isNativeHistogram = true
+				isGaugeHistogram = payload.OpenMetricsFamilyType ==
+					annotation.OpenMetricsFamilyType_GAUGE_HISTOGRAM
+				currHistogram = h
+			}
+		}
+
+		if isNativeHistogram {
+			histograms = append(histograms,
+				NativeHistogramToProm(currHistogram, dp.TimestampNanos, isGaugeHistogram))
+			firstDP = false
+			continue
+		}
 
 		if valueDecreaseTolerance > 0 && dp.TimestampNanos.Before(valueDecreaseToleranceUntil) {
 			if !firstDP && dp.Value < prevDP.Value && dp.Value > prevDP.Value*(1-valueDecreaseTolerance) {
@@ -117,12 +145,23 @@ func iteratorToPromResult(
 		})
 	}
 
+	if isNativeHistogram {
+		return &prompb.TimeSeries{
+			Labels:     TagsToPromLabels(tags),
+			Histograms: histograms,
+		}, nil
+	}
+
 	return &prompb.TimeSeries{
 		Labels:  TagsToPromLabels(tags),
 		Samples: samples,
 	}, nil
 }
 
+func hasPromDatapoints(series *prompb.TimeSeries) bool {
+	return len(series.GetSamples()) > 0 || len(series.GetHistograms()) > 0
+}
+
 // Fall back to sequential decompression if unable to decompress concurrently.
 func toPromSequentially(
 	fetchResult consolidators.SeriesFetchResult,
@@ -145,13 +184,13 @@ func toPromSequentially(
 			return PromResult{}, err
 		}
 
-		if len(series.GetSamples()) > 0 {
+		if hasPromDatapoints(series) {
 			seriesList = append(seriesList, series)
 		}
 
 		if fetchOptions != nil && fetchOptions.MaxMetricMetadataStats > 0 {
 			name, _ := tags.Get(promDefaultName)
-			if len(series.GetSamples()) > 0 {
+			if hasPromDatapoints(series) {
 				meta.ByName(name).WithSamples++
 			} else {
 				meta.ByName(name).NoSamples++
@@ -227,13 +266,13 @@ func toPromConcurrently(
 	meta := block.NewResultMetadata()
 	filteredList := seriesList[:0]
 	for _, series := range seriesList {
-		if len(series.GetSamples()) > 0 {
+		if hasPromDatapoints(series) {
 			filteredList = append(filteredList, series)
 		}
 
 		if fetchOptions != nil && fetchOptions.MaxMetricMetadataStats > 0 {
 			name := metricNameFromLabels(series.Labels)
-			if len(series.GetSamples()) > 0 {
+			if hasPromDatapoints(series) {
 				meta.ByName(name).WithSamples++
 			} else {
 				meta.ByName(name).NoSamples++
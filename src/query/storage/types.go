@@ -151,6 +151,12 @@ type FetchOptions struct {
 	IterateEqualTimestampStrategy *encoding.IterateEqualTimestampStrategy
 	// Source is the source for the query.
 	Source []byte
+	// ExpandNativeHistograms expands series holding native histograms into
+	// one series per cumulative bucket, as used by classic histograms.
+	ExpandNativeHistograms bool
+	// SeriesShard restricts fetched series to a single shard of the series
+	// ID hash space, used when splitting aggregations across series.
+	SeriesShard *SeriesShard
 
 	RelatedQueryOptions *RelatedQueryOptions
 }
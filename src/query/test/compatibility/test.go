@@ -56,6 +56,7 @@ import (
 
 	cparser "github.com/m3db/m3/src/cmd/services/m3comparator/main/parser"
 	"github.com/m3db/m3/src/query/api/v1/handler/prometheus"
+	"github.com/m3db/m3/src/query/parser/promql/promparser"
 )
 
 var (
@@ -155,7 +156,7 @@ func (t *Test) parseEval(lines []string, i int) (int, *evalCmd, error) {
 		at   = parts[2]
 		expr = parts[3]
 	)
-	_, err := parser.ParseExpr(expr)
+	_, err := promparser.ParseExpr(expr)
 	if err != nil {
 		if perr, ok := err.(*parser.ParseErr); ok {
 			perr.LineOffset = i
@@ -508,7 +509,7 @@ func (t *Test) exec(tc testCommand) error {
 		return cmd.append()
 
 	case *evalCmd:
-		expr, err := parser.ParseExpr(cmd.expr)
+		expr, err := promparser.ParseExpr(cmd.expr)
 		if err != nil {
 			return err
 		}
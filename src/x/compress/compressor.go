@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compress
+
+import (
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+var (
+	zstdOnce       sync.Once
+	zstdEncoder    *zstd.Encoder
+	zstdDecoder    *zstd.Decoder
+	zstdInitErr    error
+	snappyInstance = snappyCompressor{}
+)
+
+// NewCompressor returns the compressor for the compression type, it returns
+// nil for NoneType.
+func NewCompressor(t Type) (Compressor, error) {
+	switch t {
+	case NoneType:
+		return nil, nil
+	case ZstdType:
+		// The zstd encoder and decoder hold large buffers and are safe for
+		// concurrent use of EncodeAll and DecodeAll so they are shared.
+		zstdOnce.Do(func() {
+			zstdEncoder, zstdInitErr = zstd.NewWriter(nil,
+				zstd.WithEncoderLevel(zstd.SpeedDefault))
+			if zstdInitErr != nil {
+				return
+			}
+			zstdDecoder, zstdInitErr = zstd.NewReader(nil)
+		})
+		if zstdInitErr != nil {
+			return nil, zstdInitErr
+		}
+		return zstdCompressor{}, nil
+	case SnappyType:
+		return snappyInstance, nil
+	default:
+		return nil, t.Validate()
+	}
+}
+
+type zstdCompressor struct{}
+
+func (zstdCompressor) Type() Type {
+	return ZstdType
+}
+
+func (zstdCompressor) Compress(dst, src []byte) []byte {
+	return zstdEncoder.EncodeAll(src, dst)
+}
+
+func (zstdCompressor) Decompress(dst, src []byte) ([]byte, error) {
+	return zstdDecoder.DecodeAll(src, dst)
+}
+
+type snappyCompressor struct{}
+
+func (snappyCompressor) Type() Type {
+	return SnappyType
+}
+
+func (snappyCompressor) Compress(dst, src []byte) []byte {
+	start := len(dst)
+	dst = grow(dst, snappy.MaxEncodedLen(len(src)))
+	encoded := snappy.Encode(dst[start:cap(dst)], src)
+	return dst[:start+len(encoded)]
+}
+
+func (snappyCompressor) Decompress(dst, src []byte) ([]byte, error) {
+	n, err := snappy.DecodedLen(src)
+	if err != nil {
+		return nil, err
+	}
+	start := len(dst)
+	dst = grow(dst, n)
+	decoded, err := snappy.Decode(dst[start:start+n], src)
+	if err != nil {
+		return nil, err
+	}
+	return dst[:start+len(decoded)], nil
+}
+
+// grow returns dst with capacity for at least n more bytes.
+func grow(dst []byte, n int) []byte {
+	if cap(dst)-len(dst) >= n {
+		return dst
+	}
+	grown := make([]byte, len(dst), len(dst)+n)
+	copy(grown, dst)
+	return grown
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package compress
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestCompressorRoundTrip(t *testing.T) {
+	var (
+		block  = bytes.Repeat([]byte("foo.bar.baz{host=a,region=b}"), 100)
+		prefix = []byte("prefix")
+	)
+	for _, typ := range []Type{ZstdType, SnappyType} {
+		t.Run(typ.String(), func(t *testing.T) {
+			c, err := NewCompressor(typ)
+			require.NoError(t, err)
+			require.Equal(t, typ, c.Type())
+
+			compressed := c.Compress(append([]byte(nil), prefix...), block)
+			require.Equal(t, prefix, compressed[:len(prefix)])
+			require.True(t, len(compressed) < len(block))
+
+			decompressed, err := c.Decompress(append([]byte(nil), prefix...),
+				compressed[len(prefix):])
+			require.NoError(t, err)
+			require.Equal(t, append(append([]byte(nil), prefix...), block...), decompressed)
+
+			_, err = c.Decompress(nil, []byte("not compressed"))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewCompressorNone(t *testing.T) {
+	c, err := NewCompressor(NoneType)
+	require.NoError(t, err)
+	require.Nil(t, c)
+
+	_, err = NewCompressor(Type(10))
+	require.Error(t, err)
+}
+
+func TestTypeYAML(t *testing.T) {
+	var cfg struct {
+		Compression Type `yaml:"compression"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte("compression: zstd"), &cfg))
+	require.Equal(t, ZstdType, cfg.Compression)
+
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	require.Equal(t, "compression: zstd\n", string(out))
+
+	require.Error(t, yaml.Unmarshal([]byte("compression: lz4"), &cfg))
+}
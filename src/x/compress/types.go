@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package compress provides the block compression algorithms that can be
+// applied to files persisted to disk.
+package compress
+
+import "fmt"
+
+// Type is a compression algorithm.
+type Type int
+
+const (
+	// NoneType leaves blocks uncompressed.
+	NoneType Type = iota
+	// ZstdType compresses blocks using Zstandard.
+	ZstdType
+	// SnappyType compresses blocks using Snappy.
+	SnappyType
+)
+
+var (
+	validTypes = []Type{
+		NoneType,
+		ZstdType,
+		SnappyType,
+	}
+)
+
+func (t Type) String() string {
+	switch t {
+	case NoneType:
+		return "none"
+	case ZstdType:
+		return "zstd"
+	case SnappyType:
+		return "snappy"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseType parses a string for a compression type.
+func ParseType(str string) (Type, error) {
+	for _, valid := range validTypes {
+		if str == valid.String() {
+			return valid, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unrecognized compression type: %v", str)
+}
+
+// Validate validates the compression type.
+func (t Type) Validate() error {
+	for _, valid := range validTypes {
+		if valid == t {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid compression type '%v': should be one of %v",
+		t, validTypes)
+}
+
+// MarshalYAML marshals a compression type.
+func (t Type) MarshalYAML() (interface{}, error) {
+	return t.String(), nil
+}
+
+// UnmarshalYAML unmarshals a compression type.
+func (t *Type) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+
+	value, err := ParseType(str)
+	if err != nil {
+		return fmt.Errorf("invalid compression type '%s' valid types are: %v",
+			str, validTypes)
+	}
+	*t = value
+	return nil
+}
+
+// Compressor compresses and decompresses independent blocks, it is safe for
+// concurrent use.
+type Compressor interface {
+	// Type returns the compression algorithm of the compressor.
+	Type() Type
+
+	// Compress appends the compressed block to dst and returns the result.
+	Compress(dst, src []byte) []byte
+
+	// Decompress appends the decompressed block to dst and returns the result.
+	Decompress(dst, src []byte) ([]byte, error)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	// DataKeyLen is the length of data keys, data is encrypted with AES-256.
+	DataKeyLen = 32
+)
+
+// Cipher encrypts and decrypts the contents of a single file with AES in
+// counter mode, which allows any range of the file to be decrypted on its
+// own. A Cipher is safe for concurrent use.
+type Cipher struct {
+	block cipher.Block
+	iv    [aes.BlockSize]byte
+}
+
+// NewCipher returns a cipher for the file with the given name using the
+// data key. Files encrypted with the same data key must use distinct names.
+func NewCipher(dataKey []byte, name string) (*Cipher, error) {
+	if len(dataKey) != DataKeyLen {
+		return nil, fmt.Errorf("invalid data key length: expected=%d, actual=%d",
+			DataKeyLen, len(dataKey))
+	}
+	block, err := aes.NewCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cipher{block: block}
+	sum := sha256.Sum256([]byte(name))
+	copy(c.iv[:], sum[:])
+	return c, nil
+}
+
+// Stream returns a stream that encrypts or decrypts the file from its start.
+func (c *Cipher) Stream() cipher.Stream {
+	return cipher.NewCTR(c.block, c.iv[:])
+}
+
+// XORKeyStreamAt encrypts or decrypts src into dst as if src was located at
+// the given offset of the file. Dst and src may overlap entirely.
+func (c *Cipher) XORKeyStreamAt(dst, src []byte, offset int64) {
+	var (
+		counter [aes.BlockSize]byte
+		skip    [aes.BlockSize]byte
+		blocks  = uint64(offset / aes.BlockSize)
+	)
+	// Add the number of blocks to skip to the IV, as a 128 bit big endian
+	// counter, carrying into the high half on overflow.
+	lo := binary.BigEndian.Uint64(c.iv[8:])
+	hi := binary.BigEndian.Uint64(c.iv[:8])
+	if lo+blocks < lo {
+		hi++
+	}
+	binary.BigEndian.PutUint64(counter[:8], hi)
+	binary.BigEndian.PutUint64(counter[8:], lo+blocks)
+
+	stream := cipher.NewCTR(c.block, counter[:])
+	if rem := offset % aes.BlockSize; rem > 0 {
+		stream.XORKeyStream(skip[:rem], skip[:rem])
+	}
+	stream.XORKeyStream(dst, src)
+}
+
+// Writer encrypts everything written to it before writing it to an
+// underlying writer. A Writer reset with a nil cipher writes through as is.
+type Writer struct {
+	dst    io.Writer
+	stream cipher.Stream
+	buf    []byte
+}
+
+// NewWriter returns a new writer that encrypts using a buffer of the
+// given size.
+func NewWriter(bufferSize int) *Writer {
+	return &Writer{buf: make([]byte, bufferSize)}
+}
+
+// Reset resets the writer to encrypt to the given writer from the start of
+// the file, a nil cipher disables encryption.
+func (w *Writer) Reset(dst io.Writer, c *Cipher) {
+	w.dst = dst
+	w.stream = nil
+	if c != nil {
+		w.stream = c.Stream()
+	}
+}
+
+// Write encrypts and writes p, p itself is left untouched.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.stream == nil {
+		return w.dst.Write(p)
+	}
+	var written int
+	for len(p) > 0 {
+		chunk := w.buf
+		if len(p) < len(chunk) {
+			chunk = chunk[:len(p)]
+		}
+		w.stream.XORKeyStream(chunk, p[:len(chunk)])
+		n, err := w.dst.Write(chunk)
+		written += n
+		if err != nil {
+			return written, err
+		}
+		p = p[len(chunk):]
+	}
+	return written, nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCipher(t *testing.T, name string) *Cipher {
+	key := make([]byte, DataKeyLen)
+	_, err := io.ReadFull(rand.Reader, key)
+	require.NoError(t, err)
+	c, err := NewCipher(key, name)
+	require.NoError(t, err)
+	return c
+}
+
+func TestNewCipherInvalidKeyLength(t *testing.T) {
+	_, err := NewCipher(make([]byte, 16), "data")
+	require.Error(t, err)
+}
+
+func TestCipherXORKeyStreamAt(t *testing.T) {
+	var (
+		c         = newTestCipher(t, "data")
+		plaintext = make([]byte, 1000)
+	)
+	_, err := io.ReadFull(rand.Reader, plaintext)
+	require.NoError(t, err)
+
+	ciphertext := make([]byte, len(plaintext))
+	c.Stream().XORKeyStream(ciphertext, plaintext)
+	require.NotEqual(t, plaintext, ciphertext)
+
+	for _, r := range []struct{ start, end int }{
+		{0, 1000},
+		{0, 1},
+		{15, 17},
+		{16, 32},
+		{123, 456},
+		{999, 1000},
+	} {
+		decrypted := make([]byte, r.end-r.start)
+		c.XORKeyStreamAt(decrypted, ciphertext[r.start:r.end], int64(r.start))
+		require.Equal(t, plaintext[r.start:r.end], decrypted)
+	}
+}
+
+func TestCipherNamesUseDistinctStreams(t *testing.T) {
+	key := make([]byte, DataKeyLen)
+	data, err := NewCipher(key, "data")
+	require.NoError(t, err)
+	index, err := NewCipher(key, "index")
+	require.NoError(t, err)
+
+	plaintext := make([]byte, 64)
+	a, b := make([]byte, 64), make([]byte, 64)
+	data.XORKeyStreamAt(a, plaintext, 0)
+	index.XORKeyStreamAt(b, plaintext, 0)
+	require.NotEqual(t, a, b)
+}
+
+func TestWriter(t *testing.T) {
+	var (
+		c         = newTestCipher(t, "data")
+		plaintext = make([]byte, 100)
+		buf       bytes.Buffer
+		w         = NewWriter(7)
+	)
+	_, err := io.ReadFull(rand.Reader, plaintext)
+	require.NoError(t, err)
+	original := append([]byte(nil), plaintext...)
+
+	w.Reset(&buf, c)
+	for _, chunk := range [][]byte{plaintext[:3], plaintext[3:50], plaintext[50:]} {
+		n, err := w.Write(chunk)
+		require.NoError(t, err)
+		require.Equal(t, len(chunk), n)
+	}
+	require.Equal(t, original, plaintext)
+	require.NotEqual(t, plaintext, buf.Bytes())
+
+	decrypted := make([]byte, buf.Len())
+	c.XORKeyStreamAt(decrypted, buf.Bytes(), 0)
+	require.Equal(t, plaintext, decrypted)
+
+	buf.Reset()
+	w.Reset(&buf, nil)
+	_, err = w.Write(plaintext)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, buf.Bytes())
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// MasterKeyLen is the length of master keys, data keys are encrypted
+	// with AES-256-GCM.
+	MasterKeyLen = 32
+)
+
+var errNoCurrentKeyID = errors.New("no current encryption key ID specified")
+
+type staticKeyProvider struct {
+	currentKeyID string
+	keys         map[string]cipher.AEAD
+}
+
+// NewStaticKeyProvider returns a key provider that holds the given master
+// keys by ID, data keys are generated with the current key. Keys that are no
+// longer current should be kept for as long as files encrypted with them
+// exist.
+func NewStaticKeyProvider(
+	currentKeyID string,
+	keys map[string][]byte,
+) (KeyProvider, error) {
+	if currentKeyID == "" {
+		return nil, errNoCurrentKeyID
+	}
+	p := &staticKeyProvider{
+		currentKeyID: currentKeyID,
+		keys:         make(map[string]cipher.AEAD, len(keys)),
+	}
+	for id, key := range keys {
+		if len(key) != MasterKeyLen {
+			return nil, fmt.Errorf("invalid length for encryption key %s: expected=%d, actual=%d",
+				id, MasterKeyLen, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+		p.keys[id] = aead
+	}
+	if _, ok := p.keys[currentKeyID]; !ok {
+		return nil, fmt.Errorf("current encryption key %s: %w", currentKeyID, ErrKeyNotFound)
+	}
+	return p, nil
+}
+
+func (p *staticKeyProvider) GenerateDataKey() (DataKey, error) {
+	aead := p.keys[p.currentKeyID]
+	plaintext := make([]byte, DataKeyLen)
+	if _, err := io.ReadFull(rand.Reader, plaintext); err != nil {
+		return DataKey{}, err
+	}
+	// The nonce is stored in front of the encrypted key.
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+DataKeyLen+aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return DataKey{}, err
+	}
+	return DataKey{
+		KeyID:     p.currentKeyID,
+		Plaintext: plaintext,
+		Encrypted: aead.Seal(nonce, nonce, plaintext, []byte(p.currentKeyID)),
+	}, nil
+}
+
+func (p *staticKeyProvider) DecryptDataKey(keyID string, encrypted []byte) ([]byte, error) {
+	aead, ok := p.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("encryption key %s: %w", keyID, ErrKeyNotFound)
+	}
+	if len(encrypted) < aead.NonceSize() {
+		return nil, fmt.Errorf("encrypted data key too short: %d", len(encrypted))
+	}
+	nonce, ciphertext := encrypted[:aead.NonceSize()], encrypted[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt data key with encryption key %s: %v", keyID, err)
+	}
+	return plaintext, nil
+}
+
+// keyFile is the format of the key file read by NewFileKeyProvider.
+type keyFile struct {
+	// CurrentKeyID is the ID of the key used to encrypt new data keys.
+	CurrentKeyID string `yaml:"currentKeyID"`
+	// Keys are the base64 encoded master keys by ID.
+	Keys map[string]string `yaml:"keys"`
+}
+
+// NewFileKeyProvider returns a key provider holding the master keys read from
+// a local YAML key file, for instance:
+//
+//	currentKeyID: key-2
+//	keys:
+//	  key-1: <base64 encoded 32 byte key>
+//	  key-2: <base64 encoded 32 byte key>
+//
+// Keys are rotated by adding a new key to the file and making it current.
+func NewFileKeyProvider(path string) (KeyProvider, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var f keyFile
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("could not parse key file %s: %v", path, err)
+	}
+	keys := make(map[string][]byte, len(f.Keys))
+	for id, encoded := range f.Keys {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode encryption key %s: %v", id, err)
+		}
+		keys[id] = key
+	}
+	return NewStaticKeyProvider(f.CurrentKeyID, keys)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticKeyProvider(t *testing.T) {
+	p, err := NewStaticKeyProvider("key-1", map[string][]byte{
+		"key-1": bytes.Repeat([]byte{1}, MasterKeyLen),
+	})
+	require.NoError(t, err)
+
+	dataKey, err := p.GenerateDataKey()
+	require.NoError(t, err)
+	require.Equal(t, "key-1", dataKey.KeyID)
+	require.Len(t, dataKey.Plaintext, DataKeyLen)
+	require.NotContains(t, string(dataKey.Encrypted), string(dataKey.Plaintext))
+
+	decrypted, err := p.DecryptDataKey(dataKey.KeyID, dataKey.Encrypted)
+	require.NoError(t, err)
+	require.Equal(t, dataKey.Plaintext, decrypted)
+
+	_, err = p.DecryptDataKey("key-2", dataKey.Encrypted)
+	require.True(t, errors.Is(err, ErrKeyNotFound))
+
+	tampered := append([]byte(nil), dataKey.Encrypted...)
+	tampered[len(tampered)-1] ^= 1
+	_, err = p.DecryptDataKey(dataKey.KeyID, tampered)
+	require.Error(t, err)
+}
+
+func TestNewStaticKeyProviderErrors(t *testing.T) {
+	_, err := NewStaticKeyProvider("", nil)
+	require.Error(t, err)
+
+	_, err = NewStaticKeyProvider("key-1", map[string][]byte{
+		"key-1": make([]byte, 16),
+	})
+	require.Error(t, err)
+
+	_, err = NewStaticKeyProvider("key-2", map[string][]byte{
+		"key-1": make([]byte, MasterKeyLen),
+	})
+	require.True(t, errors.Is(err, ErrKeyNotFound))
+}
+
+func TestFileKeyProviderRotation(t *testing.T) {
+	var (
+		path = filepath.Join(t.TempDir(), "keys.yaml")
+		key1 = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, MasterKeyLen))
+		key2 = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, MasterKeyLen))
+	)
+	writeKeyFile := func(contents string) KeyProvider {
+		require.NoError(t, ioutil.WriteFile(path, []byte(contents), 0o600))
+		p, err := Configuration{KeyFile: path}.NewKeyProvider()
+		require.NoError(t, err)
+		return p
+	}
+
+	p := writeKeyFile("currentKeyID: key-1\nkeys:\n  key-1: " + key1 + "\n")
+	oldDataKey, err := p.GenerateDataKey()
+	require.NoError(t, err)
+
+	// Rotate to a new key while keeping the old one to decrypt older files.
+	p = writeKeyFile("currentKeyID: key-2\nkeys:\n  key-1: " + key1 + "\n  key-2: " + key2 + "\n")
+	newDataKey, err := p.GenerateDataKey()
+	require.NoError(t, err)
+	require.Equal(t, "key-2", newDataKey.KeyID)
+
+	decrypted, err := p.DecryptDataKey(oldDataKey.KeyID, oldDataKey.Encrypted)
+	require.NoError(t, err)
+	require.Equal(t, oldDataKey.Plaintext, decrypted)
+
+	_, err = NewFileKeyProvider(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
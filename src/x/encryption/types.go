@@ -0,0 +1,58 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package encryption provides envelope encryption for files persisted to disk.
+//
+// Each file, or set of files, is encrypted with its own randomly generated
+// data key. The data key is stored alongside the file encrypted by a master
+// key held by a KeyProvider, together with the ID of that master key, so that
+// master keys can be rotated without rewriting existing files.
+package encryption
+
+import "errors"
+
+var (
+	// ErrKeyNotFound is returned when a key provider does not hold the
+	// master key a data key was encrypted with.
+	ErrKeyNotFound = errors.New("encryption key not found")
+)
+
+// DataKey is a key used to encrypt the contents of files.
+type DataKey struct {
+	// KeyID is the ID of the master key the data key is encrypted with.
+	KeyID string
+	// Plaintext is the data key, it must never be persisted.
+	Plaintext []byte
+	// Encrypted is the data key encrypted with the master key, it is
+	// persisted alongside the files it encrypts.
+	Encrypted []byte
+}
+
+// KeyProvider generates and decrypts data keys, in the style of a key
+// management service.
+type KeyProvider interface {
+	// GenerateDataKey returns a new data key encrypted with the current
+	// master key.
+	GenerateDataKey() (DataKey, error)
+
+	// DecryptDataKey decrypts a data key that was encrypted with the master
+	// key with the given ID.
+	DecryptDataKey(keyID string, encrypted []byte) ([]byte, error)
+}
@@ -52,6 +52,18 @@ const (
 	// field `headerToMetricType`)
 	PromTypeHeader = "Prometheus-Metric-Type"
 
+	// PromRemoteWriteSamplesWrittenHeader is the Prometheus remote write 2.0
+	// response header with the number of samples written.
+	PromRemoteWriteSamplesWrittenHeader = "X-Prometheus-Remote-Write-Samples-Written"
+
+	// PromRemoteWriteHistogramsWrittenHeader is the Prometheus remote write
+	// 2.0 response header with the number of histograms written.
+	PromRemoteWriteHistogramsWrittenHeader = "X-Prometheus-Remote-Write-Histograms-Written"
+
+	// PromRemoteWriteExemplarsWrittenHeader is the Prometheus remote write 2.0
+	// response header with the number of exemplars written.
+	PromRemoteWriteExemplarsWrittenHeader = "X-Prometheus-Remote-Write-Exemplars-Written"
+
 	// WriteTypeHeader is a header that controls if default
 	// writes should be written to both unaggregated and aggregated
 	// namespaces, or if unaggregated values are skipped and
@@ -0,0 +1,73 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package objstore
+
+import "errors"
+
+var errNoStoreConfigured = errors.New(
+	"objstore: exactly one of filesystem or s3 must be configured")
+
+// Configuration is the configuration for an object store, exactly one of
+// the stores must be set.
+type Configuration struct {
+	// Filesystem keeps objects as files under a local directory.
+	Filesystem *FilesystemConfiguration `yaml:"filesystem"`
+
+	// S3 keeps objects in an S3 compatible object store.
+	S3 *S3Configuration `yaml:"s3"`
+}
+
+// FilesystemConfiguration is the configuration for a filesystem store.
+type FilesystemConfiguration struct {
+	// Directory is the directory objects are kept under.
+	Directory string `yaml:"directory" validate:"nonzero"`
+}
+
+// S3Configuration is the configuration for an S3 compatible store.
+type S3Configuration struct {
+	Bucket          string `yaml:"bucket" validate:"nonzero"`
+	Prefix          string `yaml:"prefix"`
+	Region          string `yaml:"region"`
+	Endpoint        string `yaml:"endpoint"`
+	ForcePathStyle  bool   `yaml:"forcePathStyle"`
+	AccessKeyID     string `yaml:"accessKeyID"`
+	SecretAccessKey string `yaml:"secretAccessKey"`
+}
+
+// NewStore returns the store for the configuration.
+func (c Configuration) NewStore() (Store, error) {
+	switch {
+	case c.Filesystem != nil && c.S3 == nil:
+		return NewFilesystemStore(c.Filesystem.Directory)
+	case c.S3 != nil && c.Filesystem == nil:
+		return NewS3Store(S3Options{
+			Bucket:          c.S3.Bucket,
+			Prefix:          c.S3.Prefix,
+			Region:          c.S3.Region,
+			Endpoint:        c.S3.Endpoint,
+			ForcePathStyle:  c.S3.ForcePathStyle,
+			AccessKeyID:     c.S3.AccessKeyID,
+			SecretAccessKey: c.S3.SecretAccessKey,
+		})
+	default:
+		return nil, errNoStoreConfigured
+	}
+}
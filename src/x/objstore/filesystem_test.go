@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package objstore
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilesystemStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	s, err := NewFilesystemStore(dir)
+	require.NoError(t, err)
+
+	_, err = s.Get(ctx, "a/b/c")
+	require.Equal(t, ErrNotFound, err)
+
+	require.NoError(t, s.Put(ctx, "a/b/c", bytes.NewReader([]byte("foo"))))
+	require.NoError(t, s.Put(ctx, "a/b/d", bytes.NewReader([]byte("bar"))))
+	require.NoError(t, s.Put(ctx, "a/bc", bytes.NewReader([]byte("baz"))))
+	require.NoError(t, s.Put(ctx, "e", bytes.NewReader([]byte("qux"))))
+
+	// Overwrites replace the object.
+	require.NoError(t, s.Put(ctx, "a/b/c", bytes.NewReader([]byte("foo2"))))
+	r, err := s.Get(ctx, "a/b/c")
+	require.NoError(t, err)
+	contents, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "foo2", string(contents))
+
+	keys, err := s.List(ctx, "a/b")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a/b/c", "a/b/d", "a/bc"}, keys)
+
+	keys, err = s.List(ctx, "a/b/")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a/b/c", "a/b/d"}, keys)
+
+	keys, err = s.List(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"a/b/c", "a/b/d", "a/bc", "e"}, keys)
+
+	keys, err = s.List(ctx, "missing/")
+	require.NoError(t, err)
+	require.Empty(t, keys)
+
+	require.NoError(t, s.Delete(ctx, "a/b/c"))
+	require.NoError(t, s.Delete(ctx, "a/b/c"))
+	_, err = s.Get(ctx, "a/b/c")
+	require.Equal(t, ErrNotFound, err)
+}
+
+func TestFilesystemStoreInvalidKeys(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := NewFilesystemStore(dir)
+	require.NoError(t, err)
+
+	for _, key := range []string{"", "/a", "../a", "a/../../b", "a//b", "a/"} {
+		require.Error(t, s.Put(context.Background(), key, bytes.NewReader(nil)), key)
+	}
+}
+
+func TestConfigurationNewStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "objstore")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = Configuration{}.NewStore()
+	require.Error(t, err)
+
+	_, err = Configuration{
+		Filesystem: &FilesystemConfiguration{Directory: dir},
+		S3:         &S3Configuration{Bucket: "bucket"},
+	}.NewStore()
+	require.Error(t, err)
+
+	s, err := Configuration{
+		Filesystem: &FilesystemConfiguration{Directory: dir},
+	}.NewStore()
+	require.NoError(t, err)
+	require.NotNil(t, s)
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package objstore provides a minimal interface over object stores such as
+// S3, and implementations of it, for persisting immutable files away from
+// the local disk.
+package objstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+var (
+	// ErrNotFound is returned when an object does not exist.
+	ErrNotFound = errors.New("object not found")
+)
+
+// Store is a flat namespace of objects addressed by keys, keys use forward
+// slashes as separators so that objects can be listed by prefix.
+type Store interface {
+	// Put writes the object with the given key, replacing any existing
+	// object with the same key.
+	Put(ctx context.Context, key string, r io.Reader) error
+
+	// Get returns the contents of the object with the given key, ErrNotFound
+	// is returned if the object does not exist.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object with the given key, it is not an error if the
+	// object does not exist.
+	Delete(ctx context.Context, key string) error
+
+	// List returns the keys of all objects with the given prefix in
+	// lexicographical order.
+	List(ctx context.Context, prefix string) ([]string, error)
+}
@@ -0,0 +1,152 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package server
+
+import (
+	"errors"
+	"net"
+	"sync"
+
+	"github.com/uber-go/tally"
+	"go.uber.org/zap"
+)
+
+// maxPacketSize is the maximum size of a UDP datagram.
+const maxPacketSize = 65535
+
+var errPacketServerClosed = errors.New("packet server is closed")
+
+// PacketServer is a server capable of listening to incoming packets, such
+// as UDP datagrams, and closing itself when it's shut down.
+type PacketServer interface {
+	// ListenAndServe forever listens to new incoming packets and
+	// handles them.
+	ListenAndServe() error
+
+	// Serve reads and handles incoming packets on the connection conn forever.
+	Serve(conn net.PacketConn) error
+
+	// Close closes the server.
+	Close()
+}
+
+// PacketHandler can handle the packets received by a packet server.
+type PacketHandler interface {
+	// HandlePacket handles a packet, the packet buffer is reused once the
+	// function returns. Packets are handled one at a time.
+	HandlePacket(packet []byte)
+}
+
+type packetServerMetrics struct {
+	packets    tally.Counter
+	readErrors tally.Counter
+}
+
+func newPacketServerMetrics(scope tally.Scope) packetServerMetrics {
+	return packetServerMetrics{
+		packets:    scope.Counter("packets"),
+		readErrors: scope.Counter("read-errors"),
+	}
+}
+
+type packetServer struct {
+	sync.Mutex
+
+	address string
+	log     *zap.Logger
+	handler PacketHandler
+	metrics packetServerMetrics
+
+	conn   net.PacketConn
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewPacketServer creates a new UDP packet server.
+func NewPacketServer(address string, handler PacketHandler, opts Options) PacketServer {
+	instrumentOpts := opts.InstrumentOptions()
+	return &packetServer{
+		address: address,
+		log:     instrumentOpts.Logger(),
+		handler: handler,
+		metrics: newPacketServerMetrics(instrumentOpts.MetricsScope()),
+	}
+}
+
+func (s *packetServer) ListenAndServe() error {
+	conn, err := net.ListenPacket("udp", s.address)
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(conn)
+}
+
+func (s *packetServer) Serve(conn net.PacketConn) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.closed {
+		return errPacketServerClosed
+	}
+
+	s.address = conn.LocalAddr().String()
+	s.conn = conn
+	s.wg.Add(1)
+	go s.serve(conn)
+	return nil
+}
+
+func (s *packetServer) serve(conn net.PacketConn) {
+	defer s.wg.Done()
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			s.metrics.readErrors.Inc(1)
+			s.log.Error("unable to read packet", zap.Error(err))
+			continue
+		}
+
+		s.metrics.packets.Inc(1)
+		s.handler.HandlePacket(buf[:n])
+	}
+}
+
+func (s *packetServer) Close() {
+	s.Lock()
+	if s.closed {
+		s.Unlock()
+		return
+	}
+	s.closed = true
+	conn := s.conn
+	s.Unlock()
+
+	if conn != nil {
+		conn.Close() // nolint: errcheck
+	}
+	s.wg.Wait()
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2026 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package server
+
+import (
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockPacketHandler struct {
+	sync.Mutex
+	packets []string
+}
+
+func (h *mockPacketHandler) HandlePacket(packet []byte) {
+	h.Lock()
+	h.packets = append(h.packets, string(packet))
+	h.Unlock()
+}
+
+func (h *mockPacketHandler) Packets() []string {
+	h.Lock()
+	defer h.Unlock()
+	return append([]string(nil), h.packets...)
+}
+
+func TestPacketServerServeAndClose(t *testing.T) {
+	conn, err := net.ListenPacket("udp", testListenAddress)
+	require.NoError(t, err)
+
+	handler := &mockPacketHandler{}
+	s := NewPacketServer(testListenAddress, handler, NewOptions())
+	require.NoError(t, s.Serve(conn))
+
+	client, err := net.Dial("udp", conn.LocalAddr().String())
+	require.NoError(t, err)
+	defer client.Close() // nolint: errcheck
+
+	_, err = client.Write([]byte("foo 1 1"))
+	require.NoError(t, err)
+	_, err = client.Write([]byte("bar 2 2"))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(handler.Packets()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"foo 1 1", "bar 2 2"}, handler.Packets())
+
+	s.Close()
+	s.Close()
+
+	conn, err = net.ListenPacket("udp", testListenAddress)
+	require.NoError(t, err)
+	defer conn.Close() // nolint: errcheck
+	require.Error(t, s.Serve(conn))
+}